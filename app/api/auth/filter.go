@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/pkg/errors"
+)
+
+// FilterSpaces returns the spaces for which the permission is granted for the current auth session.
+// The permissions of all spaces are checked at once, public spaces are kept without a check if orPublic is set.
+func FilterSpaces(ctx context.Context, authorizer authz.Authorizer, session *auth.Session,
+	spaces []*types.Space, permission enum.Permission, orPublic bool,
+) ([]*types.Space, error) {
+	return filter(ctx, authorizer, session, spaces, func(space *types.Space) (bool, types.PermissionCheck, error) {
+		if orPublic && space.IsPublic {
+			return true, types.PermissionCheck{}, nil
+		}
+
+		parentSpace, name, err := paths.DisectLeaf(space.Path)
+		if err != nil {
+			return false, types.PermissionCheck{}, errors.Wrapf(err, "Failed to disect path '%s'", space.Path)
+		}
+
+		return false, types.PermissionCheck{
+			Scope:      types.Scope{SpacePath: parentSpace},
+			Resource:   types.Resource{Type: enum.ResourceTypeSpace, Name: name},
+			Permission: permission,
+		}, nil
+	})
+}
+
+// FilterRepos returns the repos for which the permission is granted for the current auth session.
+// The permissions of all repos are checked at once, public repos are kept without a check if orPublic is set.
+func FilterRepos(ctx context.Context, authorizer authz.Authorizer, session *auth.Session,
+	repos []*types.Repository, permission enum.Permission, orPublic bool,
+) ([]*types.Repository, error) {
+	return filter(ctx, authorizer, session, repos, func(repo *types.Repository) (bool, types.PermissionCheck, error) {
+		if orPublic && repo.IsPublic {
+			return true, types.PermissionCheck{}, nil
+		}
+
+		parentSpace, name, err := paths.DisectLeaf(repo.Path)
+		if err != nil {
+			return false, types.PermissionCheck{}, errors.Wrapf(err, "Failed to disect path '%s'", repo.Path)
+		}
+
+		return false, types.PermissionCheck{
+			Scope:      types.Scope{SpacePath: parentSpace},
+			Resource:   types.Resource{Type: enum.ResourceTypeRepo, Name: name},
+			Permission: permission,
+		}, nil
+	})
+}
+
+// filter returns the items that are either kept right away or pass their permission check.
+func filter[T any](ctx context.Context, authorizer authz.Authorizer, session *auth.Session,
+	items []T, toCheck func(T) (bool, types.PermissionCheck, error),
+) ([]T, error) {
+	keep := make([]bool, len(items))
+	checks := make([]types.PermissionCheck, 0, len(items))
+	checked := make([]int, 0, len(items))
+	for i, item := range items {
+		ok, check, err := toCheck(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			keep[i] = true
+			continue
+		}
+
+		checks = append(checks, check)
+		checked = append(checked, i)
+	}
+
+	if len(checks) > 0 && session != nil {
+		results, err := authorizer.CheckMany(ctx, session, checks...)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, allowed := range results {
+			keep[checked[j]] = allowed
+		}
+	}
+
+	res := make([]T, 0, len(items))
+	for i, item := range items {
+		if keep[i] {
+			res = append(res, item)
+		}
+	}
+
+	return res, nil
+}
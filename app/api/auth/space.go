@@ -50,3 +50,23 @@ func CheckSpace(ctx context.Context, authorizer authz.Authorizer, session *auth.
 
 	return Check(ctx, authorizer, session, scope, resource, permission)
 }
+
+// CheckSpaceOrPublic checks if a space specific permission is granted for the current auth session
+// in the scope of its parent, or if the space is public.
+// Returns whether the access is granted only because the space is public,
+// in which case the content of the space has to be filtered by the caller.
+// Returns an error if neither is the case.
+func CheckSpaceOrPublic(ctx context.Context, authorizer authz.Authorizer, session *auth.Session,
+	space *types.Space, permission enum.Permission,
+) (bool, error) {
+	err := CheckSpace(ctx, authorizer, session, space, permission, false)
+	if err == nil {
+		return false, nil
+	}
+
+	if space.IsPublic && (errors.Is(err, ErrNotAuthorized) || errors.Is(err, ErrNotAuthenticated)) {
+		return true, nil
+	}
+
+	return false, err
+}
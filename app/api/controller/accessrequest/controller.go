@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	accessRequestStore store.AccessRequestStore
+	membershipStore    store.MembershipStore
+	repoStore          store.RepoStore
+	spaceStore         store.SpaceStore
+	principalInfoCache store.PrincipalInfoCache
+	spaceReporter      *spaceevents.Reporter
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	accessRequestStore store.AccessRequestStore,
+	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	principalInfoCache store.PrincipalInfoCache,
+	spaceReporter *spaceevents.Reporter,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		accessRequestStore: accessRequestStore,
+		membershipStore:    membershipStore,
+		repoStore:          repoStore,
+		spaceStore:         spaceStore,
+		principalInfoCache: principalInfoCache,
+		spaceReporter:      spaceReporter,
+	}
+}
+
+// getParent resolves the repo or space the access is requested for. Repositories don't have
+// memberships of their own, hence for them the access is requested for the parent space.
+// Resources the principal can't view are reported as not found, same as resources that don't exist,
+// to not disclose the existence of private repos and spaces.
+func (c *Controller) getParent(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+) (*types.Space, *types.Repository, error) {
+	switch parentType {
+	case enum.AccessRequestParentRepo:
+		if parentRef == "" {
+			return nil, nil, usererror.BadRequest("A valid repository reference must be provided.")
+		}
+
+		repo, err := c.repoStore.FindByRef(ctx, parentRef)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, nil, usererror.ErrNotFound
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true)
+		if err = notFoundIfNotAuthorized(err); err != nil {
+			return nil, nil, err
+		}
+
+		space, err := c.spaceStore.Find(ctx, repo.ParentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find parent space of repo: %w", err)
+		}
+
+		return space, repo, nil
+
+	case enum.AccessRequestParentSpace:
+		if parentRef == "" {
+			return nil, nil, usererror.BadRequest("A valid space reference must be provided.")
+		}
+
+		space, err := c.spaceStore.FindByRef(ctx, parentRef)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, nil, usererror.ErrNotFound
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true)
+		if err = notFoundIfNotAuthorized(err); err != nil {
+			return nil, nil, err
+		}
+
+		return space, nil, nil
+
+	default:
+		return nil, nil, fmt.Errorf("access request parent type '%s' is not supported", parentType)
+	}
+}
+
+// notFoundIfNotAuthorized replaces authorization errors with the not found error.
+func notFoundIfNotAuthorized(err error) error {
+	if errors.Is(err, apiauth.ErrNotAuthorized) || errors.Is(err, apiauth.ErrNotAuthenticated) {
+		return usererror.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return nil
+}
+
+// getParentCheckAccess resolves the repo or space of the access requests and verifies
+// that the principal is allowed to manage the memberships of the space.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+) (*types.Space, *types.Repository, error) {
+	space, repo, err := c.getParent(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return space, repo, nil
+}
+
+// getRequestVerifyOwnership returns the access request and ensures it belongs to the parent.
+func (c *Controller) getRequestVerifyOwnership(
+	ctx context.Context,
+	space *types.Space,
+	repo *types.Repository,
+	accessRequestID int64,
+) (*types.AccessRequest, error) {
+	if accessRequestID <= 0 {
+		return nil, usererror.BadRequest("A valid access request ID must be provided.")
+	}
+
+	req, err := c.accessRequestStore.Find(ctx, accessRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find access request with id %d: %w", accessRequestID, err)
+	}
+
+	// ensure the request actually belongs to the parent
+	if req.SpaceID != space.ID || (repo != nil && (req.RepoID == nil || *req.RepoID != repo.ID)) {
+		return nil, fmt.Errorf("access request doesn't belong to requested parent. Returning error %w",
+			usererror.ErrNotFound)
+	}
+
+	return req, nil
+}
+
+// backfillPrincipals sets the requester and decider principal infos of the provided access requests.
+func (c *Controller) backfillPrincipals(ctx context.Context, reqs ...*types.AccessRequest) error {
+	ids := make([]int64, 0, 2*len(reqs))
+	for _, req := range reqs {
+		ids = append(ids, req.PrincipalID)
+		if req.DecidedBy != nil {
+			ids = append(ids, *req.DecidedBy)
+		}
+	}
+
+	infos, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load access request principals: %w", err)
+	}
+
+	for _, req := range reqs {
+		req.Requester = infos[req.PrincipalID]
+		if req.DecidedBy != nil {
+			req.Decider = infos[*req.DecidedBy]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testAdminID     = 1
+	testRequesterID = 2
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// fakeAuthorizer grants space edit to the admin and view access on public resources
+// or resources named in viewable.
+type fakeAuthorizer struct {
+	authz.Authorizer
+	viewable map[string]bool
+}
+
+func (a *fakeAuthorizer) Check(_ context.Context, session *auth.Session, _ *types.Scope, resource *types.Resource,
+	permission enum.Permission) (bool, error) {
+	if session.Principal.ID == testAdminID {
+		return true, nil
+	}
+
+	return permission == enum.PermissionSpaceView && a.viewable[resource.Name], nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s *fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeSpaceStore) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s *fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.Path == repoRef {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeMembershipStore struct {
+	store.MembershipStore
+	memberships map[types.MembershipKey]*types.Membership
+}
+
+func (s *fakeMembershipStore) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	membership, ok := s.memberships[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	clone := *membership
+	return &clone, nil
+}
+
+func (s *fakeMembershipStore) Create(_ context.Context, membership *types.Membership) error {
+	if _, ok := s.memberships[membership.MembershipKey]; ok {
+		return gitness_store.ErrDuplicate
+	}
+	s.memberships[membership.MembershipKey] = membership
+	return nil
+}
+
+func (s *fakeMembershipStore) DeleteExpired(_ context.Context, key types.MembershipKey, now int64) (bool, error) {
+	membership, ok := s.memberships[key]
+	if !ok || membership.Expires == nil || *membership.Expires > now {
+		return false, nil
+	}
+	delete(s.memberships, key)
+	return true, nil
+}
+
+type fakeAccessRequestStore struct {
+	store.AccessRequestStore
+	requests []*types.AccessRequest
+}
+
+func (s *fakeAccessRequestStore) Find(_ context.Context, id int64) (*types.AccessRequest, error) {
+	for _, req := range s.requests {
+		if req.ID == id {
+			clone := *req
+			return &clone, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeAccessRequestStore) FindPending(
+	_ context.Context,
+	spaceID int64,
+	principalID int64,
+) (*types.AccessRequest, error) {
+	for _, req := range s.requests {
+		if req.SpaceID == spaceID && req.PrincipalID == principalID && req.State == enum.AccessRequestStatePending {
+			return req, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeAccessRequestStore) Create(_ context.Context, req *types.AccessRequest) error {
+	req.ID = int64(len(s.requests) + 1)
+	clone := *req
+	s.requests = append(s.requests, &clone)
+	return nil
+}
+
+func (s *fakeAccessRequestStore) UpdateDecision(_ context.Context, req *types.AccessRequest) error {
+	for i, existing := range s.requests {
+		if existing.ID != req.ID {
+			continue
+		}
+		if existing.State != enum.AccessRequestStatePending {
+			return gitness_store.ErrVersionConflict
+		}
+		clone := *req
+		s.requests[i] = &clone
+		return nil
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	infos := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		infos[id] = &types.PrincipalInfo{ID: id}
+	}
+	return infos, nil
+}
+
+type fakeStreamProducer struct {
+	streams []string
+}
+
+func (p *fakeStreamProducer) Send(_ context.Context, streamID string, _ map[string]interface{}) (string, error) {
+	p.streams = append(p.streams, streamID)
+	return "1-0", nil
+}
+
+type testSetup struct {
+	ctrl            *Controller
+	requestStore    *fakeAccessRequestStore
+	membershipStore *fakeMembershipStore
+	producer        *fakeStreamProducer
+}
+
+// setup returns a controller with the public space "public" containing the private repo "public/private"
+// and the private space "private".
+func setup(t *testing.T) *testSetup {
+	t.Helper()
+
+	producer := &fakeStreamProducer{}
+	eventsSystem, err := events.NewSystem(func(string, string) (events.StreamConsumer, error) {
+		return nil, nil
+	}, producer)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	spaceReporter, err := spaceevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create space reporter: %v", err)
+	}
+
+	requestStore := &fakeAccessRequestStore{}
+	membershipStore := &fakeMembershipStore{memberships: map[types.MembershipKey]*types.Membership{}}
+
+	return &testSetup{
+		ctrl: &Controller{
+			tx:                 fakeTransactor{},
+			authorizer:         &fakeAuthorizer{viewable: map[string]bool{"public": true}},
+			accessRequestStore: requestStore,
+			membershipStore:    membershipStore,
+			repoStore: &fakeRepoStore{repos: []*types.Repository{
+				{ID: 1, ParentID: 1, Path: "public/private"},
+			}},
+			spaceStore: &fakeSpaceStore{spaces: []*types.Space{
+				{ID: 1, Path: "public", IsPublic: true},
+				{ID: 2, Path: "private"},
+			}},
+			principalInfoCache: fakePrincipalInfoCache{},
+			spaceReporter:      spaceReporter,
+		},
+		requestStore:    requestStore,
+		membershipStore: membershipStore,
+		producer:        producer,
+	}
+}
+
+func requesterSession() *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: testRequesterID, Type: enum.PrincipalTypeUser}}
+}
+
+func adminSession() *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: testAdminID, Type: enum.PrincipalTypeUser}}
+}
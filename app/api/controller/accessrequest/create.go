@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxAccessRequestMessageLength = 1024
+
+type CreateInput struct {
+	// Role is the requested membership role, it defaults to reader.
+	Role    enum.MembershipRole `json:"role"`
+	Message string              `json:"message"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if in.Role == "" {
+		in.Role = enum.MembershipRoleReader
+	}
+
+	role, ok := in.Role.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Provided role '%s' is not supported. Valid values are: %v",
+			in.Role, enum.MembershipRoles)
+	}
+
+	in.Role = role
+	in.Message = strings.TrimSpace(in.Message)
+
+	if len(in.Message) > maxAccessRequestMessageLength {
+		return usererror.BadRequestf("Message can be at most %d characters long.", maxAccessRequestMessageLength)
+	}
+
+	return nil
+}
+
+// Create requests the membership of the current user in a space, or in the parent space of a repo.
+// Only view access to the space or repo is required (e.g. because it's public), the space admins
+// get notified about the request.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	in *CreateInput,
+) (*types.AccessRequest, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if session.Principal.Type != enum.PrincipalTypeUser {
+		return nil, usererror.BadRequest("Only users can request access.")
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, repo, err := c.getParent(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership, err := c.membershipStore.Find(ctx, types.MembershipKey{
+		SpaceID:     space.ID,
+		PrincipalID: session.Principal.ID,
+	})
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find membership: %w", err)
+	}
+	if err == nil && (membership.Expires == nil || *membership.Expires > now) {
+		return nil, usererror.ConflictWithPayload("You are already a member of the space.")
+	}
+
+	_, err = c.accessRequestStore.FindPending(ctx, space.ID, session.Principal.ID)
+	if err == nil {
+		return nil, usererror.ConflictWithPayload("You already have a pending access request for the space.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find pending access request: %w", err)
+	}
+
+	req := &types.AccessRequest{
+		SpaceID:     space.ID,
+		PrincipalID: session.Principal.ID,
+		Role:        in.Role,
+		Message:     in.Message,
+		State:       enum.AccessRequestStatePending,
+		Created:     now,
+		Updated:     now,
+	}
+	if repo != nil {
+		req.RepoID = &repo.ID
+	}
+
+	err = c.accessRequestStore.Create(ctx, req)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("You already have a pending access request for the space.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access request: %w", err)
+	}
+
+	c.spaceReporter.AccessRequestCreated(ctx, &spaceevents.AccessRequestCreatedPayload{
+		AccessRequestID: req.ID,
+		SpaceID:         space.ID,
+		PrincipalID:     session.Principal.ID,
+	})
+
+	if err = c.backfillPrincipals(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
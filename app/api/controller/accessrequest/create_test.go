@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCreate(t *testing.T) {
+	s := setup(t)
+	ctx := context.Background()
+
+	_, err := s.ctrl.Create(ctx, requesterSession(), enum.AccessRequestParentRepo, "public",
+		&CreateInput{Message: " please "})
+	if !errors.Is(err, usererror.ErrNotFound) {
+		t.Fatalf("expected not found for a space reference used as repo, got %v", err)
+	}
+
+	req, err := s.ctrl.Create(ctx, requesterSession(), enum.AccessRequestParentSpace, "public",
+		&CreateInput{Message: " please "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.State != enum.AccessRequestStatePending {
+		t.Errorf("expected state %q, got %q", enum.AccessRequestStatePending, req.State)
+	}
+	if req.Role != enum.MembershipRoleReader {
+		t.Errorf("expected default role %q, got %q", enum.MembershipRoleReader, req.Role)
+	}
+	if req.Message != "please" {
+		t.Errorf("expected trimmed message, got %q", req.Message)
+	}
+	if req.Requester == nil || req.Requester.ID != testRequesterID {
+		t.Errorf("expected requester to be backfilled, got %+v", req.Requester)
+	}
+	if len(s.producer.streams) != 1 {
+		t.Errorf("expected one access request created event, got %d", len(s.producer.streams))
+	}
+
+	_, err = s.ctrl.Create(ctx, requesterSession(), enum.AccessRequestParentSpace, "public", &CreateInput{})
+	if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusConflict {
+		t.Errorf("expected conflict for a second pending request, got %v", err)
+	}
+}
+
+func TestCreateAlreadyMember(t *testing.T) {
+	s := setup(t)
+
+	key := types.MembershipKey{SpaceID: 1, PrincipalID: testRequesterID}
+	s.membershipStore.memberships[key] = &types.Membership{MembershipKey: key, Role: enum.MembershipRoleReader}
+
+	_, err := s.ctrl.Create(context.Background(), requesterSession(), enum.AccessRequestParentSpace, "public",
+		&CreateInput{Role: enum.MembershipRoleContributor})
+	if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusConflict {
+		t.Errorf("expected conflict for an existing member, got %v", err)
+	}
+}
+
+// TestCreateHidesInvisibleParents verifies that resources the user can't view can't be distinguished
+// from resources that don't exist.
+func TestCreateHidesInvisibleParents(t *testing.T) {
+	tests := []struct {
+		name       string
+		parentType enum.AccessRequestParent
+		parentRef  string
+	}{
+		{name: "private-space", parentType: enum.AccessRequestParentSpace, parentRef: "private"},
+		{name: "missing-space", parentType: enum.AccessRequestParentSpace, parentRef: "missing"},
+		{name: "private-repo", parentType: enum.AccessRequestParentRepo, parentRef: "public/private"},
+		{name: "missing-repo", parentType: enum.AccessRequestParentRepo, parentRef: "public/missing"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := setup(t)
+
+			_, err := s.ctrl.Create(context.Background(), requesterSession(), test.parentType, test.parentRef,
+				&CreateInput{})
+			if err != usererror.ErrNotFound { //nolint:errorlint // the exact same error is expected.
+				t.Errorf("expected not found error, got %v", err)
+			}
+			if len(s.requestStore.requests) != 0 {
+				t.Errorf("expected no access request to be created")
+			}
+		})
+	}
+}
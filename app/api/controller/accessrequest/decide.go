@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxAccessRequestReasonLength = 1024
+
+type ApproveInput struct {
+	// Role overrides the requested membership role, if provided.
+	Role   enum.MembershipRole `json:"role"`
+	Reason string              `json:"reason"`
+}
+
+func (in *ApproveInput) sanitize() error {
+	if in.Role != "" {
+		role, ok := in.Role.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Provided role '%s' is not supported. Valid values are: %v",
+				in.Role, enum.MembershipRoles)
+		}
+
+		in.Role = role
+	}
+
+	return sanitizeReason(&in.Reason)
+}
+
+type DenyInput struct {
+	Reason string `json:"reason"`
+}
+
+func (in *DenyInput) sanitize() error {
+	return sanitizeReason(&in.Reason)
+}
+
+func sanitizeReason(reason *string) error {
+	*reason = strings.TrimSpace(*reason)
+
+	if len(*reason) > maxAccessRequestReasonLength {
+		return usererror.BadRequestf("Reason can be at most %d characters long.", maxAccessRequestReasonLength)
+	}
+
+	return nil
+}
+
+// Approve approves a pending access request and adds the requester as member of the space.
+// An existing membership of the requester gets the approved role and its expiry is removed.
+func (c *Controller) Approve(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	accessRequestID int64,
+	in *ApproveInput,
+) (*types.AccessRequest, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.decide(ctx, session, parentType, parentRef, accessRequestID,
+		func(ctx context.Context, req *types.AccessRequest) error {
+			if in.Role != "" {
+				req.Role = in.Role
+			}
+
+			req.State = enum.AccessRequestStateApproved
+			req.DecisionReason = in.Reason
+
+			return c.grantMembership(ctx, session, req)
+		})
+}
+
+// Deny denies a pending access request.
+func (c *Controller) Deny(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	accessRequestID int64,
+	in *DenyInput,
+) (*types.AccessRequest, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return c.decide(ctx, session, parentType, parentRef, accessRequestID,
+		func(_ context.Context, req *types.AccessRequest) error {
+			req.State = enum.AccessRequestStateDenied
+			req.DecisionReason = in.Reason
+
+			return nil
+		})
+}
+
+// decide stores the decision about a pending access request made by the decideFn.
+// The decided request remains as the audit record of the decision.
+func (c *Controller) decide(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	accessRequestID int64,
+	decideFn func(ctx context.Context, req *types.AccessRequest) error,
+) (*types.AccessRequest, error) {
+	space, repo, err := c.getParentCheckAccess(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.getRequestVerifyOwnership(ctx, space, repo, accessRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.State != enum.AccessRequestStatePending {
+		return nil, usererror.BadRequestf("The access request has already been %s.", req.State)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		req.Updated = now
+		req.Decided = &now
+		req.DecidedBy = &session.Principal.ID
+
+		if err := decideFn(ctx, req); err != nil {
+			return err
+		}
+
+		err := c.accessRequestStore.UpdateDecision(ctx, req)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			return usererror.ConflictWithPayload("The access request has already been decided.")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update access request: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("access_request_id", req.ID).
+		Int64("space_id", req.SpaceID).
+		Int64("principal_id", req.PrincipalID).
+		Int64("decided_by", session.Principal.ID).
+		Str("state", string(req.State)).
+		Str("role", string(req.Role)).
+		Msg("access request decided")
+
+	c.spaceReporter.AccessRequestDecided(ctx, &spaceevents.AccessRequestDecidedPayload{
+		AccessRequestID: req.ID,
+		SpaceID:         req.SpaceID,
+		PrincipalID:     session.Principal.ID,
+		State:           req.State,
+	})
+
+	if err = c.backfillPrincipals(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// grantMembership adds the requester as member of the space with the approved role.
+// Existing memberships are never changed, instead the approval is rejected.
+func (c *Controller) grantMembership(ctx context.Context, session *auth.Session, req *types.AccessRequest) error {
+	key := types.MembershipKey{
+		SpaceID:     req.SpaceID,
+		PrincipalID: req.PrincipalID,
+	}
+
+	membership, err := c.membershipStore.Find(ctx, key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	if err == nil {
+		// never change the role or the expiry of an existing membership through an access request.
+		if membership.Expires == nil || *membership.Expires > *req.Decided {
+			return usererror.ConflictWithPayload(
+				"The user is already a member of the space, update the membership instead.")
+		}
+
+		// the membership expired but wasn't revoked yet.
+		if _, err = c.membershipStore.DeleteExpired(ctx, key, *req.Decided); err != nil {
+			return fmt.Errorf("failed to delete expired membership: %w", err)
+		}
+	}
+
+	err = c.membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: key,
+		CreatedBy:     session.Principal.ID,
+		Created:       *req.Decided,
+		Updated:       *req.Decided,
+		Role:          req.Role,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create membership: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// createRequest creates a pending access request of the requester for the public space.
+func createRequest(t *testing.T, s *testSetup, role enum.MembershipRole) *types.AccessRequest {
+	t.Helper()
+
+	req, err := s.ctrl.Create(context.Background(), requesterSession(), enum.AccessRequestParentSpace, "public",
+		&CreateInput{Role: role})
+	if err != nil {
+		t.Fatalf("failed to create access request: %v", err)
+	}
+
+	return req
+}
+
+func TestApprove(t *testing.T) {
+	s := setup(t)
+	ctx := context.Background()
+	req := createRequest(t, s, enum.MembershipRoleReader)
+
+	_, err := s.ctrl.Approve(ctx, requesterSession(), enum.AccessRequestParentSpace, "public", req.ID,
+		&ApproveInput{})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Fatalf("expected requester to not be authorized to approve, got %v", err)
+	}
+
+	req, err = s.ctrl.Approve(ctx, adminSession(), enum.AccessRequestParentSpace, "public", req.ID,
+		&ApproveInput{Role: enum.MembershipRoleContributor, Reason: " welcome "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.State != enum.AccessRequestStateApproved {
+		t.Errorf("expected state %q, got %q", enum.AccessRequestStateApproved, req.State)
+	}
+	if req.DecisionReason != "welcome" {
+		t.Errorf("expected trimmed reason, got %q", req.DecisionReason)
+	}
+	if req.Decider == nil || req.Decider.ID != testAdminID {
+		t.Errorf("expected decider to be backfilled, got %+v", req.Decider)
+	}
+
+	membership := s.membershipStore.memberships[types.MembershipKey{SpaceID: 1, PrincipalID: testRequesterID}]
+	if membership == nil {
+		t.Fatalf("expected membership to be created")
+	}
+	if membership.Role != enum.MembershipRoleContributor {
+		t.Errorf("expected overridden role %q, got %q", enum.MembershipRoleContributor, membership.Role)
+	}
+	if membership.CreatedBy != testAdminID {
+		t.Errorf("expected membership to be created by the admin, got %d", membership.CreatedBy)
+	}
+
+	// one event for the creation and one for the decision.
+	if len(s.producer.streams) != 2 {
+		t.Errorf("expected two events, got %d", len(s.producer.streams))
+	}
+
+	_, err = s.ctrl.Deny(ctx, adminSession(), enum.AccessRequestParentSpace, "public", req.ID, &DenyInput{})
+	if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request for an already decided request, got %v", err)
+	}
+}
+
+func TestApproveKeepsExistingMembership(t *testing.T) {
+	s := setup(t)
+	req := createRequest(t, s, enum.MembershipRoleReader)
+
+	// the requester became a member after the request was created.
+	key := types.MembershipKey{SpaceID: 1, PrincipalID: testRequesterID}
+	expires := time.Now().Add(time.Hour).UnixMilli()
+	s.membershipStore.memberships[key] = &types.Membership{
+		MembershipKey: key,
+		Role:          enum.MembershipRoleSpaceOwner,
+		Expires:       &expires,
+	}
+
+	_, err := s.ctrl.Approve(context.Background(), adminSession(), enum.AccessRequestParentSpace, "public", req.ID,
+		&ApproveInput{})
+	if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusConflict {
+		t.Fatalf("expected conflict for an existing member, got %v", err)
+	}
+
+	membership := s.membershipStore.memberships[key]
+	if membership.Role != enum.MembershipRoleSpaceOwner {
+		t.Errorf("expected role to stay %q, got %q", enum.MembershipRoleSpaceOwner, membership.Role)
+	}
+	if membership.Expires == nil || *membership.Expires != expires {
+		t.Errorf("expected expiry to stay unchanged, got %v", membership.Expires)
+	}
+
+	stored, _ := s.requestStore.Find(context.Background(), req.ID)
+	if stored.State != enum.AccessRequestStatePending {
+		t.Errorf("expected request to stay pending, got %q", stored.State)
+	}
+}
+
+func TestApproveReplacesExpiredMembership(t *testing.T) {
+	s := setup(t)
+	req := createRequest(t, s, enum.MembershipRoleContributor)
+
+	key := types.MembershipKey{SpaceID: 1, PrincipalID: testRequesterID}
+	expired := time.Now().Add(-time.Hour).UnixMilli()
+	s.membershipStore.memberships[key] = &types.Membership{
+		MembershipKey: key,
+		Role:          enum.MembershipRoleReader,
+		Expires:       &expired,
+	}
+
+	_, err := s.ctrl.Approve(context.Background(), adminSession(), enum.AccessRequestParentSpace, "public", req.ID,
+		&ApproveInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	membership := s.membershipStore.memberships[key]
+	if membership.Role != enum.MembershipRoleContributor {
+		t.Errorf("expected role %q, got %q", enum.MembershipRoleContributor, membership.Role)
+	}
+	if membership.Expires != nil {
+		t.Errorf("expected new membership without expiry, got %d", *membership.Expires)
+	}
+}
+
+func TestDeny(t *testing.T) {
+	s := setup(t)
+	ctx := context.Background()
+	req := createRequest(t, s, enum.MembershipRoleReader)
+
+	_, err := s.ctrl.Deny(ctx, requesterSession(), enum.AccessRequestParentSpace, "public", req.ID, &DenyInput{})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Fatalf("expected requester to not be authorized to deny, got %v", err)
+	}
+
+	req, err = s.ctrl.Deny(ctx, adminSession(), enum.AccessRequestParentSpace, "public", req.ID,
+		&DenyInput{Reason: "no"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.State != enum.AccessRequestStateDenied {
+		t.Errorf("expected state %q, got %q", enum.AccessRequestStateDenied, req.State)
+	}
+	if req.DecisionReason != "no" {
+		t.Errorf("expected reason %q, got %q", "no", req.DecisionReason)
+	}
+	if len(s.membershipStore.memberships) != 0 {
+		t.Errorf("expected no membership to be created")
+	}
+
+	// the requester can find the decision of their own request.
+	found, err := s.ctrl.Find(ctx, requesterSession(), enum.AccessRequestParentSpace, "public", req.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.State != enum.AccessRequestStateDenied {
+		t.Errorf("expected found state %q, got %q", enum.AccessRequestStateDenied, found.State)
+	}
+
+	_, err = s.ctrl.Approve(ctx, adminSession(), enum.AccessRequestParentSpace, "public", req.ID, &ApproveInput{})
+	if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request for an already decided request, got %v", err)
+	}
+}
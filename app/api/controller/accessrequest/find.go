@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find returns an access request. Besides the space admins, users can find their own requests.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	accessRequestID int64,
+) (*types.AccessRequest, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	space, repo, err := c.getParent(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.getRequestVerifyOwnership(ctx, space, repo, accessRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PrincipalID != session.Principal.ID {
+		err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+	}
+
+	if err = c.backfillPrincipals(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
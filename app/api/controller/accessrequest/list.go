@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the access requests of a space, or the ones made through a repo.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.AccessRequestParent,
+	parentRef string,
+	filter *types.AccessRequestFilter,
+) ([]*types.AccessRequest, int64, error) {
+	space, repo, err := c.getParentCheckAccess(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if repo != nil {
+		filter.RepoID = repo.ID
+	}
+
+	var reqs []*types.AccessRequest
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		reqs, err = c.accessRequestStore.List(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list access requests: %w", err)
+		}
+
+		if filter.Page == 1 && len(reqs) < filter.Size {
+			count = int64(len(reqs))
+			return nil
+		}
+
+		count, err = c.accessRequestStore.Count(ctx, space.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count access requests: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = c.backfillPrincipals(ctx, reqs...); err != nil {
+		return nil, 0, err
+	}
+
+	return reqs, count, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Download returns the attachment together with a reader of its content.
+// The caller is responsible for closing the reader.
+func (c *Controller) Download(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	fileRef string,
+) (*types.Attachment, io.ReadCloser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := c.attachmentStore.FindBySHA256(ctx, repo.ID, fileRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find attachment: %w", err)
+	}
+
+	content, err := c.blobStore.Download(ctx, blobPath(attachment))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil, usererror.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download attachment content: %w", err)
+	}
+
+	return attachment, content, nil
+}
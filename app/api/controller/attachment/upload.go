@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/uploadscan"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UploadInput struct {
+	// ContentType is the media type of the content as provided by the client.
+	// It's detected from the content if it's empty or generic.
+	ContentType string
+	Content     io.Reader
+}
+
+type UploadOutput struct {
+	*types.Attachment
+	// FileRef is the reference of the attachment within its repository.
+	FileRef string `json:"file_ref"`
+	// URL is the url under which the attachment can be embedded into markdown.
+	URL string `json:"url"`
+}
+
+// Upload stores a file attached to a pull request description or comment.
+// Uploading the same content to a repository twice returns the existing attachment.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UploadInput,
+) (*UploadOutput, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize, err := c.settings.RepoAttachmentMaxSize(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max attachment size: %w", err)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(in.Content, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment content: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, usererror.BadRequest("The attachment can't be empty.")
+	}
+	if int64(len(content)) > maxSize {
+		return nil, usererror.Newf(http.StatusRequestEntityTooLarge,
+			"The attachment exceeds the max size of %d bytes.", maxSize)
+	}
+
+	checksum := sha256.Sum256(content)
+	sha := hex.EncodeToString(checksum[:])
+
+	attachment, err := c.attachmentStore.FindBySHA256(ctx, repo.ID, sha)
+	if err == nil {
+		return c.mapToOutput(repo, attachment), nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find attachment: %w", err)
+	}
+
+	err = c.uploadScan.Check(ctx, uploadscan.Upload{
+		Source:     enum.UploadSourceAttachment,
+		RepoID:     repo.ID,
+		Name:       sha,
+		Content:    content,
+		UploadedBy: session.Principal.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachment = &types.Attachment{
+		RepoID:      repo.ID,
+		SHA256:      sha,
+		ContentType: sanitizeContentType(in.ContentType, content),
+		Size:        int64(len(content)),
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if err = c.blobStore.Upload(ctx, bytes.NewReader(content), blobPath(attachment)); err != nil {
+		return nil, fmt.Errorf("failed to upload attachment content: %w", err)
+	}
+
+	err = c.attachmentStore.Create(ctx, attachment)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the same content got uploaded concurrently, the blob written by both is identical.
+		attachment, err = c.attachmentStore.FindBySHA256(ctx, repo.ID, sha)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return c.mapToOutput(repo, attachment), nil
+}
+
+func (c *Controller) mapToOutput(repo *types.Repository, attachment *types.Attachment) *UploadOutput {
+	return &UploadOutput{
+		Attachment: attachment,
+		FileRef:    attachment.SHA256,
+		URL:        c.urlProvider.GetAPIURL() + "/v1/repos/" + repo.Path + "/+/uploads/" + attachment.SHA256,
+	}
+}
+
+// sanitizeContentType returns the media type provided by the client,
+// or the media type detected from the content if none or only a generic one was provided.
+func sanitizeContentType(contentType string, content []byte) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(content))
+	}
+
+	return mediaType
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/attestation"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx               dbtx.Transactor
+	authorizer       authz.Authorizer
+	repoStore        store.RepoStore
+	pipelineStore    store.PipelineStore
+	executionStore   store.ExecutionStore
+	attestationStore store.AttestationStore
+	attestationSvc   *attestation.Service
+	gitRPCClient     gitrpc.Interface
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	attestationStore store.AttestationStore,
+	attestationSvc *attestation.Service,
+	gitRPCClient gitrpc.Interface,
+) *Controller {
+	return &Controller{
+		tx:               tx,
+		authorizer:       authorizer,
+		repoStore:        repoStore,
+		pipelineStore:    pipelineStore,
+		executionStore:   executionStore,
+		attestationStore: attestationStore,
+		attestationSvc:   attestationSvc,
+		gitRPCClient:     gitRPCClient,
+	}
+}
+
+// getRepoCheckAccess fetches the repo and verifies that the principal has the requested permission.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
+
+// findExecution fetches the execution of the pipeline of the repository.
+func (c *Controller) findExecution(
+	ctx context.Context,
+	repo *types.Repository,
+	pipelineUID string,
+	executionNum int64,
+) (*types.Execution, error) {
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return execution, nil
+}
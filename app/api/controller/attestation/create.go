@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/attestation"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxEnvelopeSize is the max size of the DSSE envelope of an attestation.
+const maxEnvelopeSize = 1 << 20
+
+type CreateInput struct {
+	// Envelope is the DSSE envelope containing the signed in-toto statement.
+	Envelope json.RawMessage `json:"envelope"`
+}
+
+// Create attaches an attestation to a commit of the repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *CreateInput,
+) (*types.Attestation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gitrpc.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("Invalid commit SHA provided.")
+	}
+
+	_, err = c.gitRPCClient.GetCommit(ctx, &gitrpc.GetCommitParams{
+		ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+		SHA:        commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	return c.create(ctx, session, repo, commitSHA, nil, in)
+}
+
+// CreateForExecution attaches an attestation to a pipeline execution and the commit it was executed for.
+func (c *Controller) CreateForExecution(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	in *CreateInput,
+) (*types.Attestation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := c.findExecution(ctx, repo, pipelineUID, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.After == "" {
+		return nil, usererror.BadRequest("The execution has no commit to attest.")
+	}
+
+	return c.create(ctx, session, repo, execution.After, &execution.ID, in)
+}
+
+func (c *Controller) create(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	commitSHA string,
+	executionID *int64,
+	in *CreateInput,
+) (*types.Attestation, error) {
+	if len(in.Envelope) == 0 {
+		return nil, usererror.BadRequest("An envelope is required.")
+	}
+
+	if len(in.Envelope) > maxEnvelopeSize {
+		return nil, usererror.BadRequestf("The envelope can be at most %d bytes large.", maxEnvelopeSize)
+	}
+
+	_, statement, err := attestation.ParseEnvelope(in.Envelope)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid attestation: %s.", err)
+	}
+
+	if !statement.HasCommit(commitSHA) {
+		return nil, usererror.BadRequestf("Commit %s isn't a subject of the attestation.", commitSHA)
+	}
+
+	a := &types.Attestation{
+		RepoID:        repo.ID,
+		CommitSHA:     commitSHA,
+		ExecutionID:   executionID,
+		PredicateType: statement.PredicateType,
+		Envelope:      in.Envelope,
+		CreatedBy:     session.Principal.ID,
+		Created:       time.Now().UnixMilli(),
+	}
+
+	if err = c.attestationSvc.Verify(ctx, repo, a); err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.attestationStore.Create(ctx, a); err != nil {
+			return fmt.Errorf("failed to create attestation: %w", err)
+		}
+
+		return c.attestationSvc.ReportCheck(ctx, repo, commitSHA)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
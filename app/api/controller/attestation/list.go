@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the attestations of a commit of the repository.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) ([]*types.Attestation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gitrpc.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("Invalid commit SHA provided.")
+	}
+
+	attestations, err := c.attestationStore.ListByCommit(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attestations: %w", err)
+	}
+
+	return attestations, nil
+}
+
+// ListForExecution lists the attestations of a pipeline execution.
+func (c *Controller) ListForExecution(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) ([]*types.Attestation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := c.findExecution(ctx, repo, pipelineUID, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	attestations, err := c.attestationStore.ListByExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attestations: %w", err)
+	}
+
+	return attestations, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Verify verifies an attestation again with the currently trusted keys of the repository,
+// e.g. after a key was added or revoked.
+func (c *Controller) Verify(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	attestationID int64,
+) (*types.Attestation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := c.attestationStore.Find(ctx, attestationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attestation: %w", err)
+	}
+
+	if a.RepoID != repo.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if err = c.attestationSvc.Verify(ctx, repo, a); err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.attestationStore.UpdateStatus(ctx, a); err != nil {
+			return fmt.Errorf("failed to update attestation status: %w", err)
+		}
+
+		return c.attestationSvc.ReportCheck(ctx, repo, a.CommitSHA)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
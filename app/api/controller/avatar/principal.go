@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindPrincipal returns the avatar of the principal in the variant closest to the requested size.
+func (c *Controller) FindPrincipal(
+	ctx context.Context,
+	session *auth.Session,
+	principalUID string,
+	size int,
+) (*types.AvatarImage, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	principal, err := c.principalStore.FindByUID(ctx, principalUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	return c.avatarSvc.Get(ctx, enum.AvatarOwnerTypePrincipal, principal.ID, size)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UploadSpace stores the image as avatar of the space.
+func (c *Controller) UploadSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	content io.Reader,
+) (*types.Avatar, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.avatarSvc.Upload(ctx, enum.AvatarOwnerTypeSpace, space.ID, session.Principal.ID, content)
+}
+
+// DeleteSpace removes the uploaded avatar of the space.
+func (c *Controller) DeleteSpace(ctx context.Context, session *auth.Session, spaceRef string) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.avatarSvc.Delete(ctx, enum.AvatarOwnerTypeSpace, space.ID)
+}
+
+// FindSpace returns the avatar of the space in the variant closest to the requested size.
+func (c *Controller) FindSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	size int,
+) (*types.AvatarImage, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.avatarSvc.Get(ctx, enum.AvatarOwnerTypeSpace, space.ID, size)
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UploadUser stores the image as avatar of the current user.
+func (c *Controller) UploadUser(
+	ctx context.Context,
+	session *auth.Session,
+	content io.Reader,
+) (*types.Avatar, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	return c.avatarSvc.Upload(ctx, enum.AvatarOwnerTypePrincipal, session.Principal.ID, session.Principal.ID, content)
+}
+
+// DeleteUser removes the uploaded avatar of the current user.
+func (c *Controller) DeleteUser(ctx context.Context, session *auth.Session) error {
+	if session == nil {
+		return usererror.ErrUnauthorized
+	}
+
+	return c.avatarSvc.Delete(ctx, enum.AvatarOwnerTypePrincipal, session.Principal.ID)
+}
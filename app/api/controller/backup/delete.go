@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes a repository backup and its snapshot.
+func (c *Controller) Delete(ctx context.Context, id int64) error {
+	backup, err := c.backupStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	if backup.State == enum.BackupStatePending || backup.State == enum.BackupStateRunning {
+		return usererror.BadRequest("The backup is in progress.")
+	}
+
+	return c.backup.Delete(ctx, backup)
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// List lists the repository backups matching the provided filter.
+func (c *Controller) List(
+	ctx context.Context,
+	filter types.BackupFilter,
+) ([]*types.Backup, int64, error) {
+	backups, err := c.backupStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	count, err := c.backupStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count backups: %w", err)
+	}
+
+	return backups, count, nil
+}
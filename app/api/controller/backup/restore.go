@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	backupservice "github.com/harness/gitness/app/services/backup"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type RestoreInput struct {
+	// ParentRef is the space the repository is restored into.
+	// The repository is restored to its original path if neither ParentRef nor UID are provided.
+	ParentRef string `json:"parent_ref"`
+	UID       string `json:"uid"`
+	// Overwrite replaces the repository at the path, which is moved to the trash once the restore completes.
+	Overwrite bool `json:"overwrite"`
+}
+
+// restoreTarget is the location a backup is restored to.
+type restoreTarget struct {
+	parentID int64
+	uid      string
+	path     string
+	// existing is the repository currently at the path, if any.
+	existing *types.Repository
+}
+
+// Restore restores a repository backup to its original path or a new path.
+// The repository is in the importing state until the restore completes.
+func (c *Controller) Restore(
+	ctx context.Context,
+	session *auth.Session,
+	id int64,
+	in *RestoreInput,
+) (*types.Repository, error) {
+	backup, err := c.backupStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	target, err := c.getRestoreTarget(ctx, backup, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.existing != nil && !in.Overwrite {
+		return nil, usererror.ConflictWithPayload(
+			fmt.Sprintf("Repository '%s' already exists, set overwrite to replace it.", target.path))
+	}
+
+	repo, err := c.backup.Restore(ctx, backup, target.parentID, target.uid, target.existing, &session.Principal)
+	if errors.Is(err, backupservice.ErrNotRestorable) {
+		return nil, usererror.BadRequest("Only successful backups can be restored.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// RestoreDryRun returns what would be overwritten by restoring a repository backup, without restoring it.
+func (c *Controller) RestoreDryRun(
+	ctx context.Context,
+	_ *auth.Session,
+	id int64,
+	in *RestoreInput,
+) (*types.BackupRestoreImpact, error) {
+	backup, err := c.backupStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	target, err := c.getRestoreTarget(ctx, backup, in)
+	if err != nil {
+		return nil, err
+	}
+
+	impact, err := c.backup.RestoreImpact(ctx, backup, target.path, target.existing)
+	if errors.Is(err, backupservice.ErrNotRestorable) {
+		return nil, usererror.BadRequest("Only successful backups can be restored.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
+
+// getRestoreTarget resolves the location the backup is restored to,
+// which is the original path of the repository unless a new one is provided.
+func (c *Controller) getRestoreTarget(
+	ctx context.Context,
+	backup *types.Backup,
+	in *RestoreInput,
+) (*restoreTarget, error) {
+	parentRef, uid := in.ParentRef, in.UID
+	switch {
+	case parentRef == "" && uid == "":
+		var err error
+		parentRef, uid, err = paths.DisectLeaf(backup.RepoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to disect original path of the repository: %w", err)
+		}
+	case parentRef == "" || uid == "":
+		return nil, usererror.BadRequest("Both the parent space and the identifier of the repository must be provided.")
+	}
+
+	if err := c.uidCheck(uid, false); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, parentRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space: %w", err)
+	}
+
+	target := &restoreTarget{
+		parentID: space.ID,
+		uid:      uid,
+		path:     paths.Concatinate(space.Path, uid),
+	}
+
+	target.existing, err = c.repoStore.FindByRef(ctx, target.path)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return target, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository at the restore path: %w", err)
+	}
+
+	if target.existing.Importing {
+		return nil, usererror.BadRequest("The repository at the restore path is being imported.")
+	}
+
+	return target, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"net"
+	"net/url"
+
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// integrationMaxURLLength defines the max allowed length of a chat integration URL.
+const integrationMaxURLLength = 2048
+
+// checkProvider validates the chat provider of an integration.
+func checkProvider(provider enum.ChatProvider) error {
+	if _, ok := provider.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided chat provider '%s' is invalid.", provider)
+	}
+
+	return nil
+}
+
+// checkURL validates the incoming webhook url of a chat integration.
+// Chat providers are public services, hence only https urls of public hosts are accepted.
+func checkURL(rawURL string) error {
+	if len(rawURL) > integrationMaxURLLength {
+		return check.NewValidationErrorf("The URL of a chat integration can be at most %d characters long.",
+			integrationMaxURLLength)
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return check.NewValidationErrorf("The provided chat integration url is invalid: %s", err)
+	}
+
+	if parsedURL.Scheme != "https" {
+		return check.NewValidationError("The scheme of a chat integration URL must be https.")
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		return check.NewValidationError("The URL of a chat integration has to have a non-empty host.")
+	}
+
+	if host == "localhost" {
+		return check.NewValidationError("localhost is not allowed.")
+	}
+
+	if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.IsPrivate()) {
+		return check.NewValidationError("Loopback and private IP addresses are not allowed.")
+	}
+
+	return nil
+}
+
+// checkTriggers validates the triggers of a chat integration.
+func checkTriggers(triggers []enum.ChatIntegrationTrigger) error {
+	// ignore duplicates here, should be deduplicated later
+	for _, trigger := range triggers {
+		if _, ok := trigger.Sanitize(); !ok {
+			return check.NewValidationErrorf("The provided chat integration trigger '%s' is invalid.", trigger)
+		}
+	}
+
+	return nil
+}
+
+// deduplicateTriggers de-duplicates the triggers provided by the user.
+func deduplicateTriggers(in []enum.ChatIntegrationTrigger) []enum.ChatIntegrationTrigger {
+	if len(in) == 0 {
+		return []enum.ChatIntegrationTrigger{}
+	}
+
+	triggerSet := make(map[enum.ChatIntegrationTrigger]bool, len(in))
+	out := make([]enum.ChatIntegrationTrigger, 0, len(in))
+	for _, trigger := range in {
+		if triggerSet[trigger] {
+			continue
+		}
+		triggerSet[trigger] = true
+		out = append(out, trigger)
+	}
+
+	return out
+}
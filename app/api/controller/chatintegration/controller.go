@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer       authz.Authorizer
+	integrationStore store.ChatIntegrationStore
+	repoStore        store.RepoStore
+	spaceStore       store.SpaceStore
+	encrypter        encrypt.Encrypter
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	integrationStore store.ChatIntegrationStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	encrypter encrypt.Encrypter,
+) *Controller {
+	return &Controller{
+		authorizer:       authorizer,
+		integrationStore: integrationStore,
+		repoStore:        repoStore,
+		spaceStore:       spaceStore,
+		encrypter:        encrypter,
+	}
+}
+
+// getParentCheckAccess resolves the repo or space owning the chat integrations and verifies
+// that the principal is allowed to view (or edit) it. It returns the id of the parent.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	edit bool,
+) (int64, error) {
+	switch parentType {
+	case enum.WebhookParentRepo:
+		if parentRef == "" {
+			return 0, usererror.BadRequest("A valid repository reference must be provided.")
+		}
+
+		repo, err := c.repoStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		permission := enum.PermissionRepoView
+		if edit {
+			permission = enum.PermissionRepoEdit
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false); err != nil {
+			return 0, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		return repo.ID, nil
+
+	case enum.WebhookParentSpace:
+		if parentRef == "" {
+			return 0, usererror.BadRequest("A valid space reference must be provided.")
+		}
+
+		space, err := c.spaceStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		permission := enum.PermissionSpaceView
+		if edit {
+			permission = enum.PermissionSpaceEdit
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+			return 0, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		return space.ID, nil
+
+	default:
+		return 0, fmt.Errorf("chat integration parent type '%s' is not supported", parentType)
+	}
+}
+
+// getIntegrationVerifyOwnership returns the chat integration and ensures it belongs to the parent.
+func (c *Controller) getIntegrationVerifyOwnership(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	integrationID int64,
+) (*types.ChatIntegration, error) {
+	if integrationID <= 0 {
+		return nil, usererror.BadRequest("A valid chat integration ID must be provided.")
+	}
+
+	integration, err := c.integrationStore.Find(ctx, integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chat integration with id %d: %w", integrationID, err)
+	}
+
+	// ensure the integration actually belongs to the parent
+	if integration.ParentType != parentType || integration.ParentID != parentID {
+		return nil, fmt.Errorf("chat integration doesn't belong to requested %s. Returning error %w",
+			parentType, usererror.ErrNotFound)
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	DisplayName string                        `json:"display_name"`
+	Provider    enum.ChatProvider             `json:"provider"`
+	URL         string                        `json:"url"`
+	Enabled     bool                          `json:"enabled"`
+	Triggers    []enum.ChatIntegrationTrigger `json:"triggers"`
+}
+
+// Create creates a new chat integration for a repo or space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	in *CreateInput,
+) (*types.ChatIntegration, error) {
+	parentID, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	encryptedURL, err := c.encrypter.Encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt chat integration url: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	integration := &types.ChatIntegration{
+		ParentID:    parentID,
+		ParentType:  parentType,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		DisplayName: in.DisplayName,
+		Provider:    in.Provider,
+		URL:         string(encryptedURL),
+		Enabled:     in.Enabled,
+		Triggers:    deduplicateTriggers(in.Triggers),
+	}
+
+	err = c.integrationStore.Create(ctx, integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+func checkCreateInput(in *CreateInput) error {
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+	if err := checkProvider(in.Provider); err != nil {
+		return err
+	}
+	if err := checkURL(in.URL); err != nil {
+		return err
+	}
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+
+	return nil
+}
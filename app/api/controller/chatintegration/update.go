@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	DisplayName *string                       `json:"display_name"`
+	Provider    *enum.ChatProvider            `json:"provider"`
+	URL         *string                       `json:"url"`
+	Enabled     *bool                         `json:"enabled"`
+	Triggers    []enum.ChatIntegrationTrigger `json:"triggers"`
+}
+
+// Update updates an existing chat integration of a repo or space.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	integrationID int64,
+	in *UpdateInput,
+) (*types.ChatIntegration, error) {
+	parentID, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := c.getIntegrationVerifyOwnership(ctx, parentType, parentID, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	// update integration struct (only for values that are provided)
+	if in.DisplayName != nil {
+		integration.DisplayName = *in.DisplayName
+	}
+	if in.Provider != nil {
+		integration.Provider = *in.Provider
+	}
+	if in.URL != nil {
+		encryptedURL, err := c.encrypter.Encrypt(*in.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt chat integration url: %w", err)
+		}
+		integration.URL = string(encryptedURL)
+	}
+	if in.Enabled != nil {
+		integration.Enabled = *in.Enabled
+	}
+	if in.Triggers != nil {
+		integration.Triggers = deduplicateTriggers(in.Triggers)
+	}
+
+	if err = c.integrationStore.Update(ctx, integration); err != nil {
+		return nil, fmt.Errorf("failed to update chat integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+func checkUpdateInput(in *UpdateInput) error {
+	if in.DisplayName != nil {
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+	if in.Provider != nil {
+		if err := checkProvider(*in.Provider); err != nil {
+			return err
+		}
+	}
+	if in.URL != nil {
+		if err := checkURL(*in.URL); err != nil {
+			return err
+		}
+	}
+	if in.Triggers != nil {
+		if err := checkTriggers(in.Triggers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
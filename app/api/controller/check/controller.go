@@ -30,11 +30,13 @@ import (
 )
 
 type Controller struct {
-	tx           dbtx.Transactor
-	authorizer   authz.Authorizer
-	repoStore    store.RepoStore
-	checkStore   store.CheckStore
-	gitRPCClient gitrpc.Interface
+	tx            dbtx.Transactor
+	authorizer    authz.Authorizer
+	repoStore     store.RepoStore
+	checkStore    store.CheckStore
+	reqCheckStore store.ReqCheckStore
+	pullReqStore  store.PullReqStore
+	gitRPCClient  gitrpc.Interface
 }
 
 func NewController(
@@ -42,14 +44,18 @@ func NewController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	checkStore store.CheckStore,
+	reqCheckStore store.ReqCheckStore,
+	pullReqStore store.PullReqStore,
 	gitRPCClient gitrpc.Interface,
 ) *Controller {
 	return &Controller{
-		tx:           tx,
-		authorizer:   authorizer,
-		repoStore:    repoStore,
-		checkStore:   checkStore,
-		gitRPCClient: gitRPCClient,
+		tx:            tx,
+		authorizer:    authorizer,
+		repoStore:     repoStore,
+		checkStore:    checkStore,
+		reqCheckStore: reqCheckStore,
+		pullReqStore:  pullReqStore,
+		gitRPCClient:  gitRPCClient,
 	}
 }
 
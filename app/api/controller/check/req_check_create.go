@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReqCheckCreateInput struct {
+	BranchPattern string `json:"branch_pattern"`
+	CheckUID      string `json:"check_uid"`
+}
+
+// Validate validates and sanitizes the ReqCheckCreateInput data.
+func (in *ReqCheckCreateInput) Validate() error {
+	in.BranchPattern = strings.TrimSpace(in.BranchPattern)
+	if in.BranchPattern == "" {
+		return usererror.BadRequest("Branch pattern is missing")
+	}
+
+	if _, err := path.Match(in.BranchPattern, ""); err != nil {
+		return usererror.BadRequestf("Invalid branch pattern: %s", in.BranchPattern)
+	}
+
+	if in.CheckUID == "" {
+		return usererror.BadRequest("Status check UID is missing")
+	}
+
+	if !matcherCheckUID.MatchString(in.CheckUID) {
+		return usererror.BadRequestf("Status check UID must match the regular expression: %s", regexpCheckUID)
+	}
+
+	return nil
+}
+
+// ReqCheckCreate adds a new required status check for a repository.
+func (c *Controller) ReqCheckCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ReqCheckCreateInput,
+) (*types.ReqCheck, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access access to repo: %w", err)
+	}
+
+	if errValidate := in.Validate(); errValidate != nil {
+		return nil, errValidate
+	}
+
+	existing, err := c.reqCheckStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list required status checks for repo=%s: %w", repo.UID, err)
+	}
+
+	for _, reqCheck := range existing {
+		if reqCheck.BranchPattern == in.BranchPattern && reqCheck.CheckUID == in.CheckUID {
+			return nil, usererror.ErrDuplicate
+		}
+	}
+
+	reqCheck := &types.ReqCheck{
+		CreatedBy:     session.Principal.ID,
+		Created:       time.Now().UnixMilli(),
+		RepoID:        repo.ID,
+		BranchPattern: in.BranchPattern,
+		CheckUID:      in.CheckUID,
+		AddedBy:       *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.reqCheckStore.Create(ctx, reqCheck)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create required status check for repo=%s: %w", repo.UID, err)
+	}
+
+	return reqCheck, nil
+}
+
+// ReqCheckCreateDryRun returns the open pull requests that would be affected by adding
+// a new required status check, without adding it.
+func (c *Controller) ReqCheckCreateDryRun(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ReqCheckCreateInput,
+) (*types.ReqCheckImpact, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access access to repo: %w", err)
+	}
+
+	if errValidate := in.Validate(); errValidate != nil {
+		return nil, errValidate
+	}
+
+	return c.reqCheckImpact(ctx, repo, &types.ReqCheck{
+		RepoID:        repo.ID,
+		BranchPattern: in.BranchPattern,
+		CheckUID:      in.CheckUID,
+	})
+}
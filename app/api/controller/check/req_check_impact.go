@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// reqCheckImpactPageSize is the number of open pull requests processed at once.
+const reqCheckImpactPageSize = 100
+
+// reqCheckImpact returns the open pull requests whose target branch is matched by the required status check.
+func (c *Controller) reqCheckImpact(
+	ctx context.Context,
+	repo *types.Repository,
+	reqCheck *types.ReqCheck,
+) (*types.ReqCheckImpact, error) {
+	impact := &types.ReqCheckImpact{
+		PullReqNumbers: []int64{},
+	}
+
+	for page := 1; ; page++ {
+		pullReqs, err := c.pullReqStore.List(ctx, &types.PullReqFilter{
+			Page:         page,
+			Size:         reqCheckImpactPageSize,
+			TargetRepoID: repo.ID,
+			States:       []enum.PullReqState{enum.PullReqStateOpen},
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open pull requests for repo=%s: %w", repo.UID, err)
+		}
+
+		for _, pr := range pullReqs {
+			if reqCheck.Matches(pr.TargetBranch) {
+				impact.PullReqNumbers = append(impact.PullReqNumbers, pr.Number)
+			}
+		}
+
+		if len(pullReqs) < reqCheckImpactPageSize {
+			return impact, nil
+		}
+	}
+}
@@ -33,6 +33,8 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	checkStore store.CheckStore,
+	reqCheckStore store.ReqCheckStore,
+	pullReqStore store.PullReqStore,
 	rpcClient gitrpc.Interface,
 ) *Controller {
 	return NewController(
@@ -40,6 +42,8 @@ func ProvideController(
 		authorizer,
 		repoStore,
 		checkStore,
+		reqCheckStore,
+		pullReqStore,
 		rpcClient,
 	)
 }
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelock
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ConflictLockKey is the key of the existing lock in the payload of the conflict error returned by Create.
+const ConflictLockKey = "lock"
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	lockStore          store.FileLockStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	lockStore store.FileLockStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		lockStore:          lockStore,
+		principalInfoCache: principalInfoCache,
+	}
+}
+
+// getRepoCheckAccess fetches the repo and verifies that the principal has the requested permission.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
+
+// backfillOwners sets the owner principal info of the provided locks.
+func (c *Controller) backfillOwners(ctx context.Context, locks ...*types.FileLock) error {
+	ids := make([]int64, len(locks))
+	for i, lock := range locks {
+		ids[i] = lock.OwnerID
+	}
+
+	infos, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load lock owners: %w", err)
+	}
+
+	for _, lock := range locks {
+		lock.Owner = infos[lock.OwnerID]
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const fileLockMaxPathLength = 700
+
+type CreateInput struct {
+	Path string `json:"path"`
+}
+
+func (in *CreateInput) sanitize() error {
+	p, err := sanitizePath(in.Path)
+	if err != nil {
+		return err
+	}
+
+	in.Path = p
+
+	return nil
+}
+
+// sanitizePath normalizes a repository relative path, the same path is always stored the same way.
+func sanitizePath(p string) (string, error) {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "", usererror.BadRequest("A path is required.")
+	}
+
+	p = path.Clean(p)
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return "", usererror.BadRequestf("The path '%s' is not a valid repository path.", p)
+	}
+
+	if len(p) > fileLockMaxPathLength {
+		return "", check.NewValidationErrorf("The path can be at most %d characters long.", fileLockMaxPathLength)
+	}
+
+	return p, nil
+}
+
+// Create locks a path of the repository for the principal.
+// In case the path is already locked, a conflict error containing the existing lock is returned.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.FileLock, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	lock := &types.FileLock{
+		RepoID:  repo.ID,
+		Path:    in.Path,
+		OwnerID: session.Principal.ID,
+		Created: time.Now().UnixMilli(),
+	}
+
+	err = c.lockStore.Create(ctx, lock)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, c.lockedError(ctx, repo.ID, in.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file lock: %w", err)
+	}
+
+	lock.Owner = session.Principal.ToPrincipalInfo()
+
+	return lock, nil
+}
+
+// lockedError returns the conflict error for an already locked path, including the existing lock.
+func (c *Controller) lockedError(ctx context.Context, repoID int64, p string) error {
+	locks, err := c.lockStore.ListByPaths(ctx, repoID, []string{p})
+	if err != nil {
+		return fmt.Errorf("failed to find existing file lock: %w", err)
+	}
+	if len(locks) == 0 {
+		// the lock got removed in the meantime
+		return usererror.ErrDuplicate
+	}
+
+	if err = c.backfillOwners(ctx, locks[0]); err != nil {
+		return err
+	}
+
+	return usererror.ConflictWithPayload(fmt.Sprintf("The path '%s' is already locked.", p),
+		map[string]any{ConflictLockKey: locks[0]})
+}
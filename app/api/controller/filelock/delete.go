@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelock
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete removes a file lock of the repository.
+// Locks owned by other principals can only be removed with force by principals allowed to edit the repository.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	lockID int64,
+	force bool,
+) (*types.FileLock, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lockStore.Find(ctx, lockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file lock: %w", err)
+	}
+
+	if lock.RepoID != repo.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if lock.OwnerID != session.Principal.ID {
+		if !force {
+			return nil, usererror.Forbidden("The lock is owned by another user, it can only be removed with force.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+			return nil, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+	}
+
+	if err = c.lockStore.Delete(ctx, lock.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete file lock: %w", err)
+	}
+
+	if err = c.backfillOwners(ctx, lock); err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
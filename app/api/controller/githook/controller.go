@@ -22,6 +22,11 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/githookplugin"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/pathprotection"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types"
@@ -52,29 +57,53 @@ type BaseInput struct {
 }
 
 type Controller struct {
-	authorizer     authz.Authorizer
-	principalStore store.PrincipalStore
-	repoStore      store.RepoStore
-	gitReporter    *eventsgit.Reporter
-	pullreqStore   store.PullReqStore
-	urlProvider    url.Provider
+	authorizer        authz.Authorizer
+	principalStore    store.PrincipalStore
+	repoCache         store.RepoCache
+	gitReporter       *eventsgit.Reporter
+	pullreqStore      store.PullReqStore
+	renameStore       store.BranchRenameStore
+	lockStore         store.FileLockStore
+	urlProvider       url.Provider
+	quotaEnforcer     *quota.Enforcer
+	pathProtection    *pathprotection.Enforcer
+	pluginManager     *githookplugin.Manager
+	pushedBranchCache store.PushedBranchCache
+	sseStreamer       sse.Streamer
+	instanceSettings  *instancesettings.Service
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
-	repoStore store.RepoStore,
+	repoCache store.RepoCache,
 	gitReporter *eventsgit.Reporter,
 	pullreqStore store.PullReqStore,
+	renameStore store.BranchRenameStore,
+	lockStore store.FileLockStore,
 	urlProvider url.Provider,
+	quotaEnforcer *quota.Enforcer,
+	pathProtection *pathprotection.Enforcer,
+	pluginManager *githookplugin.Manager,
+	pushedBranchCache store.PushedBranchCache,
+	sseStreamer sse.Streamer,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
-		authorizer:     authorizer,
-		principalStore: principalStore,
-		repoStore:      repoStore,
-		gitReporter:    gitReporter,
-		pullreqStore:   pullreqStore,
-		urlProvider:    urlProvider,
+		authorizer:        authorizer,
+		principalStore:    principalStore,
+		repoCache:         repoCache,
+		gitReporter:       gitReporter,
+		pullreqStore:      pullreqStore,
+		renameStore:       renameStore,
+		lockStore:         lockStore,
+		urlProvider:       urlProvider,
+		quotaEnforcer:     quotaEnforcer,
+		pathProtection:    pathProtection,
+		pluginManager:     pluginManager,
+		pushedBranchCache: pushedBranchCache,
+		sseStreamer:       sseStreamer,
+		instanceSettings:  instanceSettings,
 	}
 }
 
@@ -84,7 +113,7 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, usererror.BadRequest("A valid repository reference must be provided.")
 	}
 
-	repo, err := c.repoStore.Find(ctx, repoID)
+	repo, err := c.repoCache.Get(ctx, repoID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find repo with id %d: %w", repoID, err)
 	}
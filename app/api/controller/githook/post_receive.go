@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/githookplugin"
 	"github.com/harness/gitness/githook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -60,7 +62,10 @@ func (c *Controller) PostReceive(
 	out := &githook.Output{}
 
 	// handle branch updates related to PRs - best effort
-	c.handlePRMessaging(ctx, repo, in, out)
+	c.handlePRMessaging(ctx, repo, principalID, in, out)
+
+	// run git hook plugins - best effort
+	c.runPostReceivePlugins(ctx, repo, principalID, in, out)
 
 	return out, nil
 }
@@ -93,14 +98,14 @@ func (c *Controller) reportBranchEvent(
 	branchUpdate githook.ReferenceUpdate,
 ) {
 	switch {
-	case branchUpdate.Old == types.NilSHA:
+	case types.IsNilSHA(branchUpdate.Old):
 		c.gitReporter.BranchCreated(ctx, &events.BranchCreatedPayload{
 			RepoID:      repoID,
 			PrincipalID: principalID,
 			Ref:         branchUpdate.Ref,
 			SHA:         branchUpdate.New,
 		})
-	case branchUpdate.New == types.NilSHA:
+	case types.IsNilSHA(branchUpdate.New):
 		c.gitReporter.BranchDeleted(ctx, &events.BranchDeletedPayload{
 			RepoID:      repoID,
 			PrincipalID: principalID,
@@ -126,14 +131,14 @@ func (c *Controller) reportTagEvent(
 	tagUpdate githook.ReferenceUpdate,
 ) {
 	switch {
-	case tagUpdate.Old == types.NilSHA:
+	case types.IsNilSHA(tagUpdate.Old):
 		c.gitReporter.TagCreated(ctx, &events.TagCreatedPayload{
 			RepoID:      repoID,
 			PrincipalID: principalID,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.New,
 		})
-	case tagUpdate.New == types.NilSHA:
+	case types.IsNilSHA(tagUpdate.New):
 		c.gitReporter.TagDeleted(ctx, &events.TagDeletedPayload{
 			RepoID:      repoID,
 			PrincipalID: principalID,
@@ -154,17 +159,18 @@ func (c *Controller) reportTagEvent(
 }
 
 // handlePRMessaging checks any single branch push for pr information and returns an according response if needed.
-// TODO: If it is a new branch, or an update on a branch without any PR, it also sends out an SSE for pr creation.
+// If it is a new branch, or an update on a branch without any PR, it also sends out an SSE for pr creation.
 func (c *Controller) handlePRMessaging(
 	ctx context.Context,
 	repo *types.Repository,
+	principalID int64,
 	in *githook.PostReceiveInput,
 	out *githook.Output,
 ) {
 	// skip anything that was a batch push / isn't branch related / isn't updating/creating a branch.
 	if len(in.RefUpdates) != 1 ||
 		!strings.HasPrefix(in.RefUpdates[0].Ref, gitReferenceNamePrefixBranch) ||
-		in.RefUpdates[0].New == types.NilSHA {
+		types.IsNilSHA(in.RefUpdates[0].New) {
 		return
 	}
 
@@ -210,5 +216,44 @@ func (c *Controller) handlePRMessaging(
 		"  "+c.urlProvider.GenerateUICompareURL(repo.Path, repo.DefaultBranch, branchName),
 	)
 
-	// TODO: store latest pushed branch for user in cache and send out SSE
+	if branchName == repo.DefaultBranch {
+		return
+	}
+
+	c.storePushedBranch(ctx, repo, &types.PushedBranch{
+		RepoID:      repo.ID,
+		PrincipalID: principalID,
+		Branch:      branchName,
+		SHA:         in.RefUpdates[0].New,
+		Pushed:      time.Now().UnixMilli(),
+	})
+}
+
+// storePushedBranch stores the latest pushed branch of the user in the cache
+// and sends out an SSE so the UI can offer to create a PR for it.
+func (c *Controller) storePushedBranch(ctx context.Context, repo *types.Repository, pushed *types.PushedBranch) {
+	if err := c.pushedBranchCache.Set(ctx, pushed); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to store pushed branch '%s' of repo '%s'", pushed.Branch, repo.Path)
+		return
+	}
+
+	if err := c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchPushed, pushed); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish branch pushed event for repo '%s'", repo.Path)
+	}
+}
+
+func (c *Controller) runPostReceivePlugins(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in *githook.PostReceiveInput,
+	out *githook.Output,
+) {
+	pluginOut, err := c.pluginManager.Run(ctx, githookplugin.HookPostReceive, repo, principalID, in.RefUpdates)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to run post-receive git hook plugins")
+		return
+	}
+
+	out.Messages = append(out.Messages, pluginOut.Messages...)
 }
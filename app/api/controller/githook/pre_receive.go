@@ -16,11 +16,15 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/githookplugin"
 	"github.com/harness/gitness/githook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -44,14 +48,74 @@ func (c *Controller) PreReceive(
 		return nil, err
 	}
 
+	maintenanceOutput, err := c.blockMaintenanceMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if maintenanceOutput != nil {
+		return maintenanceOutput, nil
+	}
+
 	branchOutput := c.blockDefaultBranchDeletion(repo, in)
 	if branchOutput != nil {
 		return branchOutput, nil
 	}
 
+	renameOutput, err := c.blockRenamedBranchCreation(ctx, repo, in)
+	if err != nil {
+		return nil, err
+	}
+	if renameOutput != nil {
+		return renameOutput, nil
+	}
+
+	quotaOutput, err := c.enforceSizeQuota(ctx, repo, in)
+	if err != nil {
+		return nil, err
+	}
+	if quotaOutput != nil {
+		return quotaOutput, nil
+	}
+
+	lockOutput, err := c.enforceFileLocks(ctx, repo, principalID, in)
+	if err != nil {
+		return nil, err
+	}
+	if lockOutput != nil {
+		return lockOutput, nil
+	}
+
+	protectionOutput, err := c.enforcePathProtections(ctx, repo, principalID, in)
+	if err != nil {
+		return nil, err
+	}
+	if protectionOutput != nil {
+		return protectionOutput, nil
+	}
+
+	pluginOutput, err := c.pluginManager.Run(ctx, githookplugin.HookPreReceive, repo, principalID, in.RefUpdates)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: Branch Protection, Block non-brach/tag refs (?), ...
 
-	return &githook.Output{}, nil
+	return pluginOutput, nil
+}
+
+// blockMaintenanceMode rejects all pushes while the instance is in maintenance mode.
+func (c *Controller) blockMaintenanceMode(ctx context.Context) (*githook.Output, error) {
+	mode, err := c.instanceSettings.MaintenanceMode(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	if !mode.Enabled {
+		return nil, nil
+	}
+
+	return &githook.Output{
+		Error: ptr.String(mode.Message),
+	}, nil
 }
 
 func (c *Controller) blockDefaultBranchDeletion(repo *types.Repository,
@@ -59,7 +123,7 @@ func (c *Controller) blockDefaultBranchDeletion(repo *types.Repository,
 	repoDefaultBranchRef := gitReferenceNamePrefixBranch + repo.DefaultBranch
 
 	for _, refUpdate := range in.RefUpdates {
-		if refUpdate.New == types.NilSHA && refUpdate.Ref == repoDefaultBranchRef {
+		if types.IsNilSHA(refUpdate.New) && refUpdate.Ref == repoDefaultBranchRef {
 			return &githook.Output{
 				Error: ptr.String(usererror.ErrDefaultBranchCantBeDeleted.Error()),
 			}
@@ -67,3 +131,133 @@ func (c *Controller) blockDefaultBranchDeletion(repo *types.Repository,
 	}
 	return nil
 }
+
+// blockRenamedBranchCreation rejects pushes that would recreate a renamed branch
+// and explains the pusher how to update the local clone.
+func (c *Controller) blockRenamedBranchCreation(ctx context.Context, repo *types.Repository,
+	in *githook.PreReceiveInput) (*githook.Output, error) {
+	for _, refUpdate := range in.RefUpdates {
+		if !types.IsNilSHA(refUpdate.Old) || !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+		rename, err := c.renameStore.Find(ctx, repo.ID, branchName)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find rename of branch '%s': %w", branchName, err)
+		}
+
+		return &githook.Output{
+			Messages: []string{
+				fmt.Sprintf("The branch '%s' has been renamed to '%s'.", rename.OldName, rename.NewName),
+				"To update your local clone, run:",
+				fmt.Sprintf("  git branch -m %s %s", rename.OldName, rename.NewName),
+				"  git fetch origin",
+				fmt.Sprintf("  git branch -u origin/%s %s", rename.NewName, rename.NewName),
+				"  git remote set-head origin -a",
+			},
+			Error: ptr.String(fmt.Sprintf("branch '%s' has been renamed to '%s'", rename.OldName, rename.NewName)),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// enforceSizeQuota rejects the push in case the received objects would exceed the storage quota of any
+// space containing the repository.
+func (c *Controller) enforceSizeQuota(ctx context.Context, repo *types.Repository,
+	in *githook.PreReceiveInput) (*githook.Output, error) {
+	err := c.quotaEnforcer.CheckSize(ctx, repo.ParentID, in.IncomingObjectsSize)
+
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) {
+		return &githook.Output{
+			Error: ptr.String(uErr.Error()),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check storage quota: %w", err)
+	}
+
+	return nil, nil
+}
+
+// enforceFileLocks rejects the push in case it changes any paths that are locked by other principals.
+func (c *Controller) enforceFileLocks(ctx context.Context, repo *types.Repository, principalID int64,
+	in *githook.PreReceiveInput) (*githook.Output, error) {
+	seen := map[string]struct{}{}
+	paths := []string{}
+	for _, refPaths := range in.ChangedPaths {
+		for _, p := range refPaths {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				paths = append(paths, p)
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	locks, err := c.lockStore.ListByPaths(ctx, repo.ID, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file locks: %w", err)
+	}
+
+	messages := []string{}
+	for _, lock := range locks {
+		if lock.OwnerID == principalID {
+			continue
+		}
+
+		owner, err := c.principalStore.Find(ctx, lock.OwnerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find owner of file lock: %w", err)
+		}
+
+		messages = append(messages, fmt.Sprintf("  %s (locked by %s)", lock.Path, owner.DisplayName))
+	}
+
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return &githook.Output{
+		Messages: append([]string{"The push changes files that are locked by other users:"}, messages...),
+		Error:    ptr.String(fmt.Sprintf("%d locked file(s) can't be changed", len(messages))),
+	}, nil
+}
+
+// enforcePathProtections rejects the push in case it changes protected files the principal isn't allowed to change.
+func (c *Controller) enforcePathProtections(ctx context.Context, repo *types.Repository, principalID int64,
+	in *githook.PreReceiveInput) (*githook.Output, error) {
+	changedPaths := make(map[string][]string, len(in.ChangedPaths))
+	for ref, paths := range in.ChangedPaths {
+		if strings.HasPrefix(ref, gitReferenceNamePrefixBranch) {
+			changedPaths[ref[len(gitReferenceNamePrefixBranch):]] = paths
+		}
+	}
+
+	violations, err := c.pathProtection.Check(ctx, repo, principalID, changedPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check path protections: %w", err)
+	}
+
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	messages := []string{"The push changes protected files you aren't allowed to change:"}
+	for _, violation := range violations {
+		messages = append(messages, fmt.Sprintf("  %s (branch '%s')", violation.Path, violation.Branch))
+	}
+
+	return &githook.Output{
+		Messages: messages,
+		Error:    ptr.String(fmt.Sprintf("%d protected file change(s) aren't allowed", len(violations))),
+	}, nil
+}
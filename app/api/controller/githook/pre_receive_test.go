@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/githook"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const commitSHA = "1111111111111111111111111111111111111111"
+
+type fakeRenameStore struct {
+	store.BranchRenameStore
+}
+
+func (fakeRenameStore) Find(_ context.Context, _ int64, oldName string) (*types.BranchRename, error) {
+	if oldName != "master" {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.BranchRename{OldName: "master", NewName: "main"}, nil
+}
+
+func TestBlockRenamedBranchCreation(t *testing.T) {
+	c := &Controller{renameStore: fakeRenameStore{}}
+	repo := &types.Repository{ID: 1}
+
+	tests := []struct {
+		name      string
+		update    githook.ReferenceUpdate
+		wantBlock bool
+	}{
+		{
+			name:      "recreate renamed branch",
+			update:    githook.ReferenceUpdate{Ref: "refs/heads/master", Old: types.NilSHA, New: commitSHA},
+			wantBlock: true,
+		},
+		{
+			name:   "create other branch",
+			update: githook.ReferenceUpdate{Ref: "refs/heads/feature", Old: types.NilSHA, New: commitSHA},
+		},
+		{
+			name:   "create tag with renamed name",
+			update: githook.ReferenceUpdate{Ref: "refs/tags/master", Old: types.NilSHA, New: commitSHA},
+		},
+		{
+			name:   "update existing branch",
+			update: githook.ReferenceUpdate{Ref: "refs/heads/master", Old: commitSHA, New: commitSHA},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &githook.PreReceiveInput{RefUpdates: []githook.ReferenceUpdate{test.update}}
+
+			out, err := c.blockRenamedBranchCreation(context.Background(), repo, in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !test.wantBlock {
+				if out != nil {
+					t.Errorf("expected the push not to be blocked, got %v", out.Messages)
+				}
+				return
+			}
+
+			if out == nil || out.Error == nil {
+				t.Fatalf("expected the push to be blocked")
+			}
+			if !strings.Contains(strings.Join(out.Messages, "\n"), "git branch -m master main") {
+				t.Errorf("want instructions to rename the local branch, got %v", out.Messages)
+			}
+		})
+	}
+}
@@ -17,6 +17,11 @@ package githook
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/githookplugin"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/pathprotection"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 
@@ -29,7 +34,12 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(authorizer authz.Authorizer, principalStore store.PrincipalStore,
-	repoStore store.RepoStore, gitReporter *eventsgit.Reporter, pullreqStore store.PullReqStore,
-	urlProvider url.Provider) *Controller {
-	return NewController(authorizer, principalStore, repoStore, gitReporter, pullreqStore, urlProvider)
+	repoCache store.RepoCache, gitReporter *eventsgit.Reporter, pullreqStore store.PullReqStore,
+	renameStore store.BranchRenameStore, lockStore store.FileLockStore, urlProvider url.Provider,
+	quotaEnforcer *quota.Enforcer, pathProtection *pathprotection.Enforcer,
+	pluginManager *githookplugin.Manager, pushedBranchCache store.PushedBranchCache,
+	sseStreamer sse.Streamer, instanceSettings *instancesettings.Service) *Controller {
+	return NewController(authorizer, principalStore, repoCache, gitReporter, pullreqStore, renameStore,
+		lockStore, urlProvider, quotaEnforcer, pathProtection, pluginManager, pushedBranchCache, sseStreamer,
+		instanceSettings)
 }
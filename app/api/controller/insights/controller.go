@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	insightsStore      store.InsightsStore
+	repoStore          store.RepoStore
+	spaceStore         store.SpaceStore
+	pullReqStore       store.PullReqStore
+	userGroupStore     store.UserGroupMemberStore
+	principalInfoCache store.PrincipalInfoCache
+	settings           *settings.Service
+	gitRPCClient       gitrpc.Interface
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	insightsStore store.InsightsStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	pullReqStore store.PullReqStore,
+	userGroupStore store.UserGroupMemberStore,
+	principalInfoCache store.PrincipalInfoCache,
+	settings *settings.Service,
+	gitRPCClient gitrpc.Interface,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		insightsStore:      insightsStore,
+		repoStore:          repoStore,
+		spaceStore:         spaceStore,
+		pullReqStore:       pullReqStore,
+		userGroupStore:     userGroupStore,
+		principalInfoCache: principalInfoCache,
+		settings:           settings,
+		gitRPCClient:       gitRPCClient,
+	}
+}
+
+// getParentCheckAccess resolves the repo or space the insights are requested for
+// and verifies that the principal is allowed to view it. It returns the id of the parent.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+) (int64, error) {
+	switch parentType {
+	case enum.WebhookParentRepo:
+		repo, err := c.getRepoCheckAccess(ctx, session, parentRef)
+		if err != nil {
+			return 0, err
+		}
+
+		return repo.ID, nil
+
+	case enum.WebhookParentSpace:
+		if parentRef == "" {
+			return 0, usererror.BadRequest("A valid space reference must be provided.")
+		}
+
+		space, err := c.spaceStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+			return 0, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		return space.ID, nil
+
+	default:
+		return 0, fmt.Errorf("insights parent type '%s' is not supported", parentType)
+	}
+}
+
+// getRepoCheckAccess fetches the repo and verifies that the principal is allowed to view it.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
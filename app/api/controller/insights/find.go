@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// dayMillis is the length of a day in milliseconds, the granularity of all aggregated statistics.
+	dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+	defaultRangeDays  = 30
+	maxRangeDays      = 366
+	topContributorMax = 10
+)
+
+// Find returns the contribution statistics of a repo or space (including all subspaces) for the requested range.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	filter types.InsightsFilter,
+) (*types.Insights, error) {
+	parentID, err := c.getParentCheckAccess(ctx, session, parentType, parentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err = sanitizeFilter(filter, defaultRangeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := c.insightsStore.ListDays(ctx, parentType, parentID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insights days: %w", err)
+	}
+
+	contributors, err := c.insightsStore.CountContributors(ctx, parentType, parentID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count contributors: %w", err)
+	}
+
+	topContributors, err := c.insightsStore.ListContributors(ctx, parentType, parentID, filter, topContributorMax)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top contributors: %w", err)
+	}
+
+	res := &types.Insights{
+		InsightsFilter:  filter,
+		Contributors:    contributors,
+		Activity:        days,
+		TopContributors: topContributors,
+	}
+
+	var mergeTimeTotal, reviewTimeTotal int64
+	for _, d := range days {
+		res.Commits += d.Commits
+		res.PullReqsOpened += d.PullReqsOpened
+		res.PullReqsMerged += d.PullReqsMerged
+		res.PullReqsReviewed += d.PullReqsReviewed
+		mergeTimeTotal += d.MergeTimeTotal
+		reviewTimeTotal += d.ReviewTimeTotal
+	}
+
+	if res.PullReqsMerged > 0 {
+		res.AvgCycleTime = mergeTimeTotal / res.PullReqsMerged
+	}
+	if res.PullReqsReviewed > 0 {
+		res.AvgReviewTurnaround = reviewTimeTotal / res.PullReqsReviewed
+	}
+
+	weeks := float64(filter.Until-filter.Since) / float64(7*dayMillis)
+	res.MergesPerWeek = float64(res.PullReqsMerged) / weeks
+
+	return res, nil
+}
+
+// sanitizeFilter applies the defaults to the filter and extends it to full days.
+func sanitizeFilter(filter types.InsightsFilter, defaultDays int64) (types.InsightsFilter, error) {
+	if filter.Until == 0 {
+		filter.Until = time.Now().UnixMilli()
+	}
+	if filter.Since == 0 {
+		filter.Since = filter.Until - defaultDays*dayMillis
+	}
+
+	filter.Since = filter.Since / dayMillis * dayMillis
+	filter.Until = (filter.Until + dayMillis - 1) / dayMillis * dayMillis
+
+	if filter.Since >= filter.Until {
+		return filter, usererror.BadRequest("The start of the range has to be before its end.")
+	}
+
+	if filter.Until-filter.Since > maxRangeDays*dayMillis {
+		return filter, usererror.BadRequestf("The range can't be longer than %d days.", maxRangeDays)
+	}
+
+	return filter, nil
+}
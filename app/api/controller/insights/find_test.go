@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+	allowed bool
+}
+
+func (a fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource,
+	enum.Permission) (bool, error) {
+	return a.allowed, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{ID: 1, Path: "space/repo"}, nil
+}
+
+type fakeInsightsStore struct {
+	store.InsightsStore
+	days   []*types.InsightsDay
+	filter types.InsightsFilter
+}
+
+func (s *fakeInsightsStore) ListDays(_ context.Context, _ enum.WebhookParent, _ int64,
+	filter types.InsightsFilter) ([]*types.InsightsDay, error) {
+	s.filter = filter
+	return s.days, nil
+}
+
+func (s *fakeInsightsStore) CountContributors(context.Context, enum.WebhookParent, int64,
+	types.InsightsFilter) (int64, error) {
+	return 3, nil
+}
+
+func (s *fakeInsightsStore) ListContributors(context.Context, enum.WebhookParent, int64,
+	types.InsightsFilter, int) ([]*types.InsightsContributor, error) {
+	return []*types.InsightsContributor{{Email: "jane@example.com", Commits: 5}}, nil
+}
+
+func wantStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != status {
+		t.Errorf("want error with status %d, got %v", status, err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{}
+	insightsStore := &fakeInsightsStore{days: []*types.InsightsDay{
+		{Day: 0, Commits: 4, PullReqsOpened: 2, PullReqsMerged: 1, MergeTimeTotal: 3000,
+			PullReqsReviewed: 2, ReviewTimeTotal: 500},
+		{Day: dayMillis, Commits: 1, PullReqsMerged: 1, MergeTimeTotal: 1000},
+	}}
+	c := &Controller{
+		authorizer:    fakeAuthorizer{allowed: true},
+		insightsStore: insightsStore,
+		repoStore:     fakeRepoStore{},
+	}
+
+	// the range is extended to full days.
+	filter := types.InsightsFilter{Since: dayMillis / 2, Until: 13*dayMillis + 1}
+
+	insights, err := c.Find(ctx, session, enum.WebhookParentRepo, "space/repo", filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := (types.InsightsFilter{Since: 0, Until: 14 * dayMillis}); insightsStore.filter != want {
+		t.Errorf("want filter %+v, got %+v", want, insightsStore.filter)
+	}
+	if insights.Commits != 5 || insights.PullReqsOpened != 2 || insights.PullReqsMerged != 2 ||
+		insights.Contributors != 3 || len(insights.TopContributors) != 1 {
+		t.Errorf("unexpected totals %+v", insights)
+	}
+	if insights.AvgCycleTime != 2000 || insights.AvgReviewTurnaround != 250 || insights.MergesPerWeek != 1 {
+		t.Errorf("want cycle time 2000, review turnaround 250 and 1 merge per week, got %d, %d and %f",
+			insights.AvgCycleTime, insights.AvgReviewTurnaround, insights.MergesPerWeek)
+	}
+}
+
+func TestFindInvalidRange(t *testing.T) {
+	c := &Controller{
+		authorizer:    fakeAuthorizer{allowed: true},
+		insightsStore: &fakeInsightsStore{},
+		repoStore:     fakeRepoStore{},
+	}
+
+	for _, filter := range []types.InsightsFilter{
+		{Since: 10 * dayMillis, Until: 5 * dayMillis},
+		{Since: dayMillis, Until: (maxRangeDays + 2) * dayMillis},
+	} {
+		_, err := c.Find(context.Background(), &auth.Session{}, enum.WebhookParentRepo, "space/repo", filter)
+		wantStatus(t, err, http.StatusBadRequest)
+	}
+}
+
+func TestFindForbidden(t *testing.T) {
+	c := &Controller{
+		authorizer:    fakeAuthorizer{allowed: false},
+		insightsStore: &fakeInsightsStore{},
+		repoStore:     fakeRepoStore{},
+	}
+
+	_, err := c.Find(context.Background(), &auth.Session{}, enum.WebhookParentRepo, "space/repo",
+		types.InsightsFilter{})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("want not authorized error, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// pullReqAgingMax is the max number of aging pull requests included in a report.
+const pullReqAgingMax = 1000
+
+// PullReqAging returns the open pull requests of a space (including all subspaces)
+// that exceed the age or idle threshold, grouped by repository, author or team.
+func (c *Controller) PullReqAging(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter types.PullReqAgingFilter,
+) (*types.PullReqAgingReport, error) {
+	spaceID, err := c.getParentCheckAccess(ctx, session, enum.WebhookParentSpace, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	if filter.GroupBy, ok = filter.GroupBy.Sanitize(); !ok {
+		return nil, usererror.BadRequestf("Unsupported group by value '%s'.", filter.GroupBy)
+	}
+
+	if filter.MaxAgeDays < 0 || filter.MaxIdleDays < 0 {
+		return nil, usererror.BadRequest("Pull request aging thresholds can't be negative.")
+	}
+
+	if filter.MaxAgeDays == 0 || filter.MaxIdleDays == 0 {
+		sla, err := c.settings.SpacePullReqSLA(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request SLA of the space: %w", err)
+		}
+
+		if filter.MaxAgeDays == 0 {
+			filter.MaxAgeDays = sla.MaxAgeDays
+		}
+		if filter.MaxIdleDays == 0 {
+			filter.MaxIdleDays = sla.MaxIdleDays
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	var createdBefore, idleBefore int64
+	if filter.MaxAgeDays > 0 {
+		createdBefore = now - int64(filter.MaxAgeDays)*dayMillis
+	}
+	if filter.MaxIdleDays > 0 {
+		idleBefore = now - int64(filter.MaxIdleDays)*dayMillis
+	}
+
+	prs, err := c.pullReqStore.ListAging(ctx, spaceID, createdBefore, idleBefore, pullReqAgingMax)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aging pull requests: %w", err)
+	}
+
+	if err = c.fillRepoPaths(ctx, prs); err != nil {
+		return nil, err
+	}
+
+	keysOf, err := c.pullReqAgingKeys(ctx, filter.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	groupMap := make(map[string]*types.PullReqAgingGroup)
+	for _, pr := range prs {
+		pr.AgeDays = int((now - pr.Created) / dayMillis)
+		pr.IdleDays = int((now - pr.LastActivity) / dayMillis)
+		pr.ExceedsAge = createdBefore > 0 && pr.Created < createdBefore
+		pr.ExceedsIdle = idleBefore > 0 && pr.LastActivity < idleBefore
+
+		keys, err := keysOf(pr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			group, ok := groupMap[key]
+			if !ok {
+				group = &types.PullReqAgingGroup{Key: key}
+				groupMap[key] = group
+			}
+
+			group.Count++
+			if pr.ExceedsAge {
+				group.ExceedingAge++
+			}
+			if pr.ExceedsIdle {
+				group.ExceedingIdle++
+			}
+			group.PullReqs = append(group.PullReqs, pr)
+		}
+	}
+
+	groups := make([]*types.PullReqAgingGroup, 0, len(groupMap))
+	for _, group := range groupMap {
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	return &types.PullReqAgingReport{
+		PullReqAgingFilter: filter,
+		Total:              len(prs),
+		Groups:             groups,
+	}, nil
+}
+
+// fillRepoPaths sets the path of the target repository of all pull requests.
+func (c *Controller) fillRepoPaths(ctx context.Context, prs []*types.PullReqAging) error {
+	repoIDs := make([]int64, 0, len(prs))
+	for _, pr := range prs {
+		repoIDs = append(repoIDs, pr.RepoID)
+	}
+
+	repos, err := c.repoStore.ListByIDs(ctx, repoIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list repositories of aging pull requests: %w", err)
+	}
+
+	paths := make(map[int64]string, len(repos))
+	for _, repo := range repos {
+		paths[repo.ID] = repo.Path
+	}
+
+	for _, pr := range prs {
+		pr.RepoPath = paths[pr.RepoID]
+	}
+
+	return nil
+}
+
+// pullReqAgingKeys returns a function that returns the keys of the groups a pull request belongs to.
+// With grouping by team a pull request belongs to all user groups its author is a member of,
+// or to the group with the empty key if the author isn't member of any.
+func (c *Controller) pullReqAgingKeys(
+	ctx context.Context,
+	groupBy enum.PullReqAgingGroupBy,
+) (func(pr *types.PullReqAging) ([]string, error), error) {
+	switch groupBy {
+	case enum.PullReqAgingGroupByRepo:
+		return func(pr *types.PullReqAging) ([]string, error) {
+			return []string{pr.RepoPath}, nil
+		}, nil
+
+	case enum.PullReqAgingGroupByAuthor:
+		return func(pr *types.PullReqAging) ([]string, error) {
+			return []string{pr.Author.UID}, nil
+		}, nil
+
+	case enum.PullReqAgingGroupByTeam:
+		teams := make(map[int64][]string)
+		return func(pr *types.PullReqAging) ([]string, error) {
+			if keys, ok := teams[pr.Author.ID]; ok {
+				return keys, nil
+			}
+
+			groupIDs, err := c.userGroupStore.ListGroupIDs(ctx, pr.Author.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list user groups of the author: %w", err)
+			}
+
+			groupInfos, err := c.principalInfoCache.Map(ctx, groupIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get user group infos: %w", err)
+			}
+
+			keys := make([]string, 0, len(groupInfos))
+			for _, groupID := range groupIDs {
+				if info, ok := groupInfos[groupID]; ok {
+					keys = append(keys, info.UID)
+				}
+			}
+			if len(keys) == 0 {
+				keys = []string{""}
+			}
+
+			teams[pr.Author.ID] = keys
+
+			return keys, nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("pull request aging group by '%s' is not supported", groupBy)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	defaultPulseDays = 7
+
+	// pulseListMax is the maximum number of entries returned per list of the pulse.
+	pulseListMax = 10
+
+	// pulseRefsScanMax is the number of most recently updated branches and tags that are inspected.
+	pulseRefsScanMax = 50
+)
+
+// PulseBranch is a branch that received commits in the pulse window.
+type PulseBranch struct {
+	Name   string        `json:"name"`
+	SHA    string        `json:"sha"`
+	Commit *types.Commit `json:"commit,omitempty"`
+}
+
+// PulseTag is a tag that was created in the pulse window.
+type PulseTag struct {
+	Name   string           `json:"name"`
+	SHA    string           `json:"sha"`
+	Title  string           `json:"title,omitempty"`
+	Tagger *types.Signature `json:"tagger,omitempty"`
+}
+
+// Pulse summarizes the recent activity of a repository.
+type Pulse struct {
+	types.InsightsFilter
+
+	Commits        int64 `json:"commits"`
+	PullReqsOpened int64 `json:"pullreqs_opened"`
+	PullReqsMerged int64 `json:"pullreqs_merged"`
+	OpenPullReqs   int   `json:"open_pullreqs"`
+
+	MergedPullReqs  []*types.PullReq             `json:"merged_pullreqs"`
+	ActiveBranches  []PulseBranch                `json:"active_branches"`
+	RecentTags      []PulseTag                   `json:"recent_tags"`
+	TopContributors []*types.InsightsContributor `json:"top_contributors"`
+}
+
+// Pulse returns a summary of the recent activity of a repository.
+// Counters and contributors are read from the pre-aggregated insights,
+// only the most recently updated branches and tags are inspected.
+func (c *Controller) Pulse(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter types.InsightsFilter,
+) (*Pulse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err = sanitizeFilter(filter, defaultPulseDays)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := c.insightsStore.ListDays(ctx, enum.WebhookParentRepo, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insights days: %w", err)
+	}
+
+	topContributors, err := c.insightsStore.ListContributors(ctx, enum.WebhookParentRepo, repo.ID,
+		filter, pulseListMax)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top contributors: %w", err)
+	}
+
+	res := &Pulse{
+		InsightsFilter:  filter,
+		OpenPullReqs:    repo.NumOpenPulls,
+		TopContributors: topContributors,
+	}
+
+	for _, d := range days {
+		res.Commits += d.Commits
+		res.PullReqsOpened += d.PullReqsOpened
+		res.PullReqsMerged += d.PullReqsMerged
+	}
+
+	res.MergedPullReqs, err = c.listMergedPullReqs(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ActiveBranches, err = c.listActiveBranches(ctx, repo, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res.RecentTags, err = c.listRecentTags(ctx, repo, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Controller) listMergedPullReqs(
+	ctx context.Context,
+	repoID int64,
+	filter types.InsightsFilter,
+) ([]*types.PullReq, error) {
+	pullReqs, err := c.pullReqStore.List(ctx, &types.PullReqFilter{
+		Size:         pulseListMax,
+		TargetRepoID: repoID,
+		States:       []enum.PullReqState{enum.PullReqStateMerged},
+		Sort:         enum.PullReqSortMerged,
+		Order:        enum.OrderDesc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	res := make([]*types.PullReq, 0, len(pullReqs))
+	for _, pr := range pullReqs {
+		if pr.Merged == nil || *pr.Merged < filter.Since || *pr.Merged >= filter.Until {
+			continue
+		}
+		res = append(res, pr)
+	}
+
+	return res, nil
+}
+
+func (c *Controller) listActiveBranches(
+	ctx context.Context,
+	repo *types.Repository,
+	filter types.InsightsFilter,
+) ([]PulseBranch, error) {
+	rpcOut, err := c.gitRPCClient.ListBranches(ctx, &gitrpc.ListBranchesParams{
+		ReadParams:    gitrpc.CreateRPCReadParams(repo),
+		IncludeCommit: true,
+		Sort:          gitrpc.BranchSortOptionDate,
+		Order:         gitrpc.SortOrderDesc,
+		Page:          1,
+		PageSize:      pulseRefsScanMax,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	res := make([]PulseBranch, 0, pulseListMax)
+	for i := range rpcOut.Branches {
+		branch := &rpcOut.Branches[i]
+		if branch.Commit == nil || !inRange(branch.Commit.Committer.When.UnixMilli(), filter) {
+			continue
+		}
+
+		commit, err := controller.MapCommit(branch.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+
+		res = append(res, PulseBranch{
+			Name:   branch.Name,
+			SHA:    branch.SHA,
+			Commit: commit,
+		})
+		if len(res) == pulseListMax {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (c *Controller) listRecentTags(
+	ctx context.Context,
+	repo *types.Repository,
+	filter types.InsightsFilter,
+) ([]PulseTag, error) {
+	rpcOut, err := c.gitRPCClient.ListCommitTags(ctx, &gitrpc.ListCommitTagsParams{
+		ReadParams:    gitrpc.CreateRPCReadParams(repo),
+		IncludeCommit: true,
+		Sort:          gitrpc.TagSortOptionDate,
+		Order:         gitrpc.SortOrderDesc,
+		Page:          1,
+		PageSize:      pulseRefsScanMax,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	res := make([]PulseTag, 0, pulseListMax)
+	for i := range rpcOut.Tags {
+		tag := &rpcOut.Tags[i]
+
+		// lightweight tags have no tagger, fall back to the date of the tagged commit.
+		var when int64
+		switch {
+		case tag.Tagger != nil:
+			when = tag.Tagger.When.UnixMilli()
+		case tag.Commit != nil:
+			when = tag.Commit.Committer.When.UnixMilli()
+		default:
+			continue
+		}
+
+		if !inRange(when, filter) {
+			continue
+		}
+
+		var tagger *types.Signature
+		if tag.Tagger != nil {
+			tagger, err = controller.MapSignature(tag.Tagger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map tagger: %w", err)
+			}
+		}
+
+		res = append(res, PulseTag{
+			Name:   tag.Name,
+			SHA:    tag.SHA,
+			Title:  tag.Title,
+			Tagger: tagger,
+		})
+		if len(res) == pulseListMax {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func inRange(ts int64, filter types.InsightsFilter) bool {
+	return ts >= filter.Since && ts < filter.Until
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxTextSize is the max size of a markdown document that can be rendered.
+const maxTextSize = 1 << 20
+
+type RenderInput struct {
+	Text string `json:"text"`
+
+	// RepoRef is the optional repository the document belongs to, relative links are resolved against it.
+	RepoRef string `json:"repo_ref"`
+	// GitRef is the git reference relative links are resolved at, defaults to the default branch of the repository.
+	GitRef string `json:"git_ref"`
+	// Path is the path of the document within the repository, e.g. of a README file.
+	Path string `json:"path"`
+}
+
+type RenderOutput struct {
+	HTML string `json:"html"`
+}
+
+// Render renders a markdown document to sanitized HTML.
+// It's used for the previews of pull request descriptions, comments and markdown files alike,
+// to render them the same way everywhere.
+func (c *Controller) Render(
+	ctx context.Context,
+	session *auth.Session,
+	in *RenderInput,
+) (*RenderOutput, error) {
+	if len(in.Text) > maxTextSize {
+		return nil, usererror.BadRequestf("The markdown text exceeds the max size of %d bytes.", maxTextSize)
+	}
+
+	opts := markdown.Options{}
+	if in.RepoRef != "" {
+		repo, err := c.repoStore.FindByRef(ctx, in.RepoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false); err != nil {
+			return nil, fmt.Errorf("failed to verify authorization: %w", err)
+		}
+
+		opts = c.repoOptions(repo, in.GitRef, in.Path)
+	}
+
+	html, err := c.renderer.Render([]byte(in.Text), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderOutput{
+		HTML: string(html),
+	}, nil
+}
+
+// repoOptions returns the render options resolving relative links to the files of the repository.
+// Links point to the files in the UI, images to their raw content.
+func (c *Controller) repoOptions(repo *types.Repository, gitRef string, docPath string) markdown.Options {
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	dir := ""
+	if docPath != "" {
+		dir = path.Dir(docPath)
+	}
+
+	return markdown.Options{
+		Dir: dir,
+		ResolveLink: func(p string) string {
+			return c.urlProvider.GenerateUIRepoURL(repo.Path) + "/files/" + gitRef + "/~/" + p
+		},
+		ResolveImage: func(p string) string {
+			return c.urlProvider.GetAPIURL() + "/v1/repos/" + repo.Path + "/+/raw/" + p +
+				"?git_ref=" + url.QueryEscape(gitRef)
+		},
+	}
+}
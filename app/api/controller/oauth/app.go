@@ -0,0 +1,440 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	appNameMaxLength        = 256
+	appDescriptionMaxLength = 1024
+	appMaxRedirectURIs      = 10
+)
+
+// CreateAppInput is used for registering a new OAuth app.
+type CreateAppInput struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	HomepageURL  string            `json:"homepage_url"`
+	RedirectURIs []string          `json:"redirect_uris"`
+	Scopes       []enum.OAuthScope `json:"scopes"`
+}
+
+// UpdateAppInput is used for updating an OAuth app.
+type UpdateAppInput struct {
+	Name         *string           `json:"name"`
+	Description  *string           `json:"description"`
+	HomepageURL  *string           `json:"homepage_url"`
+	RedirectURIs []string          `json:"redirect_uris"`
+	Scopes       []enum.OAuthScope `json:"scopes"`
+}
+
+// CreateAppOutput is returned when registering an OAuth app or regenerating its client secret.
+// NOTE: The client secret is only returned once, it can't be retrieved later on.
+type CreateAppOutput struct {
+	types.OAuthApp
+	ClientSecret string `json:"client_secret"`
+}
+
+// CreateApp registers a new OAuth app owned by the user.
+func (c *Controller) CreateApp(ctx context.Context,
+	session *auth.Session,
+	in *CreateAppInput,
+) (*CreateAppOutput, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	app := &types.OAuthApp{
+		Name:         in.Name,
+		Description:  in.Description,
+		HomepageURL:  in.HomepageURL,
+		RedirectURIs: in.RedirectURIs,
+		Scopes:       in.Scopes,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+	}
+
+	if err := sanitizeApp(app); err != nil {
+		return nil, err
+	}
+
+	clientID, err := generateRandomString(15)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client id: %w", err)
+	}
+
+	clientSecret, encryptedSecret, err := c.generateClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	app.ClientID = clientID
+	app.ClientSecret = encryptedSecret
+
+	err = c.appStore.Create(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth app: %w", err)
+	}
+
+	return &CreateAppOutput{
+		OAuthApp:     *app,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// ListApps returns the OAuth apps registered by the user.
+func (c *Controller) ListApps(ctx context.Context, session *auth.Session) ([]*types.OAuthApp, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	apps, err := c.appStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth apps: %w", err)
+	}
+
+	return apps, nil
+}
+
+// FindApp returns an OAuth app registered by the user.
+func (c *Controller) FindApp(ctx context.Context, session *auth.Session, appID int64) (*types.OAuthApp, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	return c.getOwnedApp(ctx, session, appID)
+}
+
+// UpdateApp updates an OAuth app registered by the user.
+func (c *Controller) UpdateApp(ctx context.Context,
+	session *auth.Session,
+	appID int64,
+	in *UpdateAppInput,
+) (*types.OAuthApp, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	app, err := c.getOwnedApp(ctx, session, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name != nil {
+		app.Name = *in.Name
+	}
+	if in.Description != nil {
+		app.Description = *in.Description
+	}
+	if in.HomepageURL != nil {
+		app.HomepageURL = *in.HomepageURL
+	}
+	if in.RedirectURIs != nil {
+		app.RedirectURIs = in.RedirectURIs
+	}
+	if in.Scopes != nil {
+		app.Scopes = in.Scopes
+	}
+
+	if err = sanitizeApp(app); err != nil {
+		return nil, err
+	}
+
+	app.Updated = time.Now().UnixMilli()
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err = c.appStore.Update(ctx, app)
+		if err != nil {
+			return fmt.Errorf("failed to update oauth app: %w", err)
+		}
+
+		return c.restrictAuthorizations(ctx, app)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// RegenerateClientSecret replaces the client secret of an OAuth app registered by the user.
+// The previous secret stops working immediately, already issued access tokens stay valid.
+func (c *Controller) RegenerateClientSecret(ctx context.Context,
+	session *auth.Session,
+	appID int64,
+) (*CreateAppOutput, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	app, err := c.getOwnedApp(ctx, session, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecret, encryptedSecret, err := c.generateClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	app.ClientSecret = encryptedSecret
+	app.Updated = time.Now().UnixMilli()
+
+	err = c.appStore.Update(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update oauth app: %w", err)
+	}
+
+	return &CreateAppOutput{
+		OAuthApp:     *app,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// DeleteApp deletes an OAuth app registered by the user, including all authorizations users gave to it.
+func (c *Controller) DeleteApp(ctx context.Context, session *auth.Session, appID int64) error {
+	if err := checkUserSession(session); err != nil {
+		return err
+	}
+
+	app, err := c.getOwnedApp(ctx, session, appID)
+	if err != nil {
+		return err
+	}
+
+	err = c.appStore.Delete(ctx, app.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth app: %w", err)
+	}
+
+	return nil
+}
+
+// FindAppInfo returns the public information of an OAuth app (e.g. for showing it on the consent screen).
+func (c *Controller) FindAppInfo(ctx context.Context,
+	session *auth.Session,
+	clientID string,
+) (*types.OAuthAppInfo, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	app, err := c.appStore.FindByClientID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth app: %w", err)
+	}
+
+	return appInfo(app), nil
+}
+
+func (c *Controller) getOwnedApp(ctx context.Context, session *auth.Session, appID int64) (*types.OAuthApp, error) {
+	app, err := c.appStore.Find(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth app: %w", err)
+	}
+
+	// don't leak the existence of apps of other users
+	if app.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return app, nil
+}
+
+// generateClientSecret returns a new client secret in plain text and in its encrypted form.
+func (c *Controller) generateClientSecret() (string, string, error) {
+	clientSecret, err := generateRandomString(32)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	encryptedSecret, err := c.encrypter.Encrypt(clientSecret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt client secret: %w", err)
+	}
+
+	return clientSecret, string(encryptedSecret), nil
+}
+
+// restrictAuthorizations removes the scopes the app isn't allowed to request anymore from all its authorizations.
+// Authorizations that are left without any scope are deleted.
+func (c *Controller) restrictAuthorizations(ctx context.Context, app *types.OAuthApp) error {
+	authorizations, err := c.authorizationStore.ListByApp(ctx, app.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list oauth authorizations of app: %w", err)
+	}
+
+	for _, authorization := range authorizations {
+		scopes := intersectScopes(authorization.Scopes, app.Scopes)
+		if len(scopes) == len(authorization.Scopes) {
+			continue
+		}
+
+		if len(scopes) == 0 {
+			if err = c.authorizationStore.Delete(ctx, authorization.ID); err != nil {
+				return fmt.Errorf("failed to delete oauth authorization: %w", err)
+			}
+			continue
+		}
+
+		authorization.Scopes = scopes
+		authorization.Updated = app.Updated
+
+		if err = c.authorizationStore.UpdateScopes(ctx, authorization); err != nil {
+			return fmt.Errorf("failed to update oauth authorization: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// intersectScopes returns the scopes that are contained in both lists.
+func intersectScopes(scopes []enum.OAuthScope, allowed []enum.OAuthScope) []enum.OAuthScope {
+	res := make([]enum.OAuthScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if enum.OAuthScopesContain(allowed, scope) {
+			res = append(res, scope)
+		}
+	}
+
+	return res
+}
+
+func appInfo(app *types.OAuthApp) *types.OAuthAppInfo {
+	return &types.OAuthAppInfo{
+		ClientID:    app.ClientID,
+		Name:        app.Name,
+		Description: app.Description,
+		HomepageURL: app.HomepageURL,
+	}
+}
+
+// sanitizeApp trims and validates the user provided fields of the app.
+func sanitizeApp(app *types.OAuthApp) error {
+	app.Name = strings.TrimSpace(app.Name)
+	app.Description = strings.TrimSpace(app.Description)
+	app.HomepageURL = strings.TrimSpace(app.HomepageURL)
+
+	if app.Name == "" {
+		return usererror.BadRequest("Name is required.")
+	}
+	if len(app.Name) > appNameMaxLength {
+		return usererror.BadRequestf("Name can't be longer than %d characters.", appNameMaxLength)
+	}
+	if len(app.Description) > appDescriptionMaxLength {
+		return usererror.BadRequestf("Description can't be longer than %d characters.", appDescriptionMaxLength)
+	}
+
+	if app.HomepageURL != "" {
+		if err := validateAbsoluteURL(app.HomepageURL); err != nil {
+			return usererror.BadRequestf("Homepage URL is invalid: %s", err)
+		}
+	}
+
+	if len(app.RedirectURIs) == 0 {
+		return usererror.BadRequest("At least one redirect URI is required.")
+	}
+	if len(app.RedirectURIs) > appMaxRedirectURIs {
+		return usererror.BadRequestf("An app can't have more than %d redirect URIs.", appMaxRedirectURIs)
+	}
+
+	for i := range app.RedirectURIs {
+		app.RedirectURIs[i] = strings.TrimSpace(app.RedirectURIs[i])
+		if err := validateRedirectURI(app.RedirectURIs[i]); err != nil {
+			return usererror.BadRequestf("Redirect URI '%s' is invalid: %s", app.RedirectURIs[i], err)
+		}
+	}
+
+	if len(app.Scopes) == 0 {
+		return usererror.BadRequest("At least one scope is required.")
+	}
+
+	scopes := make([]enum.OAuthScope, 0, len(app.Scopes))
+	for _, scope := range app.Scopes {
+		sanitized, ok := scope.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Scope '%s' is unknown.", scope)
+		}
+
+		if !enum.OAuthScopesContain(scopes, sanitized) {
+			scopes = append(scopes, sanitized)
+		}
+	}
+	app.Scopes = scopes
+
+	return nil
+}
+
+func validateAbsoluteURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New("failed to parse url")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("only http and https urls are supported")
+	}
+	if u.Host == "" {
+		return errors.New("url has to be absolute")
+	}
+
+	return nil
+}
+
+// validateRedirectURI validates a redirect uri as required by RFC 6749 (absolute, without fragment).
+// To protect the authorization codes in transit, plain http is only allowed for loopback addresses (RFC 8252).
+func validateRedirectURI(raw string) error {
+	if err := validateAbsoluteURL(raw); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(raw, " \t\r\n#") {
+		return errors.New("url can't contain whitespaces or a fragment")
+	}
+
+	u, _ := url.Parse(raw)
+	if u.User != nil {
+		return errors.New("url can't contain user information")
+	}
+	if u.Scheme == "http" && !isLoopbackHost(u.Hostname()) {
+		return errors.New("http is only allowed for loopback addresses, use https instead")
+	}
+
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListAuthorizations returns all OAuth apps the user gave consent to access gitness on their behalf.
+func (c *Controller) ListAuthorizations(ctx context.Context,
+	session *auth.Session,
+) ([]*types.OAuthAuthorization, error) {
+	if err := checkUserSession(session); err != nil {
+		return nil, err
+	}
+
+	authorizations, err := c.authorizationStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth authorizations: %w", err)
+	}
+
+	return authorizations, nil
+}
+
+// RevokeAuthorization revokes the consent of the user for an OAuth app.
+// All access tokens issued to the app for the user become invalid immediately.
+func (c *Controller) RevokeAuthorization(ctx context.Context,
+	session *auth.Session,
+	authorizationID int64,
+) error {
+	if err := checkUserSession(session); err != nil {
+		return err
+	}
+
+	authorization, err := c.authorizationStore.Find(ctx, authorizationID)
+	if err != nil {
+		return fmt.Errorf("failed to find oauth authorization: %w", err)
+	}
+
+	if authorization.PrincipalID != session.Principal.ID {
+		return usererror.ErrNotFound
+	}
+
+	err = c.authorizationStore.Delete(ctx, authorization.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth authorization: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	responseTypeCode = "code"
+
+	codeChallengeMethodPlain = "plain"
+	codeChallengeMethodS256  = "S256"
+)
+
+// AuthorizeInput contains the parameters of an OAuth2 authorization request (RFC 6749 section 4.1.1),
+// including the optional PKCE (RFC 7636) and OpenID Connect parameters.
+type AuthorizeInput struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+
+	// Approve contains the decision of the user on the consent screen.
+	// If not provided, the user is sent to the consent screen unless the app is already authorized for the scopes.
+	Approve *bool `json:"approve,omitempty"`
+
+	// ConsentToken is the token passed to the consent screen, it's required together with the decision of the user.
+	ConsentToken string `json:"consent_token,omitempty"`
+}
+
+// AuthorizeOutput contains the uri the user agent has to be redirected to.
+type AuthorizeOutput struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// Authorize handles an OAuth2 authorization request of an app on behalf of the user.
+// Depending on the state, the user is redirected to the sign in screen, the consent screen,
+// or back to the app with either an authorization code or an error.
+func (c *Controller) Authorize(ctx context.Context,
+	session *auth.Session,
+	in *AuthorizeInput,
+) (*AuthorizeOutput, error) {
+	app, err := c.appStore.FindByClientID(ctx, in.ClientID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("Unknown client id.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth app: %w", err)
+	}
+
+	// never redirect to an unregistered uri, errors are shown to the user instead.
+	redirectURI, err := resolveRedirectURI(app, in.RedirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// from here on errors are reported to the app via the redirect uri.
+	if in.ResponseType != responseTypeCode {
+		return redirectWithError(redirectURI, in.State, "unsupported_response_type",
+			"Only the authorization code flow is supported."), nil
+	}
+
+	scopes, ok := enum.ParseOAuthScopes(in.Scope)
+	if !ok || len(scopes) == 0 {
+		return redirectWithError(redirectURI, in.State, "invalid_scope",
+			"The requested scope is invalid or empty."), nil
+	}
+	if len(intersectScopes(scopes, app.Scopes)) != len(scopes) {
+		return redirectWithError(redirectURI, in.State, "invalid_scope",
+			"The requested scope exceeds the scopes registered for the app."), nil
+	}
+
+	codeChallengeMethod := in.CodeChallengeMethod
+	switch {
+	case in.CodeChallenge == "" && codeChallengeMethod != "":
+		return redirectWithError(redirectURI, in.State, "invalid_request",
+			"The code challenge method requires a code challenge."), nil
+	case in.CodeChallenge != "" && codeChallengeMethod == "":
+		codeChallengeMethod = codeChallengeMethodPlain
+	case codeChallengeMethod != "" &&
+		codeChallengeMethod != codeChallengeMethodPlain && codeChallengeMethod != codeChallengeMethodS256:
+		return redirectWithError(redirectURI, in.State, "invalid_request",
+			"The code challenge method is not supported."), nil
+	}
+
+	if session == nil {
+		if in.Approve != nil {
+			return nil, usererror.ErrUnauthorized
+		}
+
+		returnURL := c.issuer() + "/authorize?" + in.query().Encode()
+		return &AuthorizeOutput{RedirectURI: c.urlProvider.GenerateUISignInURL(returnURL)}, nil
+	}
+
+	if isRestrictedSession(session) {
+		return nil, usererror.Forbidden("Restricted credentials can't be used to authorize OAuth apps.")
+	}
+
+	// the decision has to be submitted by the consent screen shown to the user for this request.
+	if in.Approve != nil && !verifyConsentToken(&session.Principal, in, in.ConsentToken, time.Now()) {
+		return nil, usererror.Forbidden("The consent token is invalid or expired.")
+	}
+
+	if in.Approve != nil && !*in.Approve {
+		return redirectWithError(redirectURI, in.State, "access_denied",
+			"The user denied the request."), nil
+	}
+
+	authorization, err := c.authorizationStore.FindByAppAndPrincipal(ctx, app.ID, session.Principal.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find oauth authorization: %w", err)
+	}
+
+	// ask for consent in case the user didn't authorize the app for all requested scopes yet
+	if in.Approve == nil && !authorizationCovers(authorization, scopes) {
+		query := in.query()
+		query.Set("consent_token", generateConsentToken(&session.Principal, in, time.Now().Add(consentTokenLifetime)))
+
+		return &AuthorizeOutput{RedirectURI: c.urlProvider.GenerateUIOAuthConsentURL(query)}, nil
+	}
+
+	code, err := generateRandomString(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate authorization code: %w", err)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		authorization, err = c.upsertAuthorization(ctx, authorization, app.ID, session.Principal.ID, scopes, now)
+		if err != nil {
+			return err
+		}
+
+		// opportunistically clean up codes that were never exchanged
+		if _, err = c.codeStore.DeleteExpiredBefore(ctx, now); err != nil {
+			return fmt.Errorf("failed to delete expired oauth codes: %w", err)
+		}
+
+		err = c.codeStore.Create(ctx, &types.OAuthAuthorizationCode{
+			CodeHash:            hashCode(code),
+			AuthorizationID:     authorization.ID,
+			RedirectURI:         in.RedirectURI,
+			Scopes:              scopes,
+			CodeChallenge:       in.CodeChallenge,
+			CodeChallengeMethod: codeChallengeMethod,
+			Nonce:               in.Nonce,
+			Expires:             now.Add(c.codeLifetime).UnixMilli(),
+			Created:             now.UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create oauth code: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"code": {code}}
+	if in.State != "" {
+		params.Set("state", in.State)
+	}
+
+	return &AuthorizeOutput{RedirectURI: appendQuery(redirectURI, params)}, nil
+}
+
+// upsertAuthorization creates the authorization of the app or extends the existing one with the scopes.
+func (c *Controller) upsertAuthorization(
+	ctx context.Context,
+	authorization *types.OAuthAuthorization,
+	appID int64,
+	principalID int64,
+	scopes []enum.OAuthScope,
+	now time.Time,
+) (*types.OAuthAuthorization, error) {
+	if authorization == nil {
+		authorization = &types.OAuthAuthorization{
+			AppID:       appID,
+			PrincipalID: principalID,
+			Scopes:      scopes,
+			Created:     now.UnixMilli(),
+			Updated:     now.UnixMilli(),
+		}
+
+		if err := c.authorizationStore.Create(ctx, authorization); err != nil {
+			return nil, fmt.Errorf("failed to create oauth authorization: %w", err)
+		}
+
+		return authorization, nil
+	}
+
+	if authorizationCovers(authorization, scopes) {
+		return authorization, nil
+	}
+
+	for _, scope := range scopes {
+		if !enum.OAuthScopesContain(authorization.Scopes, scope) {
+			authorization.Scopes = append(authorization.Scopes, scope)
+		}
+	}
+	authorization.Updated = now.UnixMilli()
+
+	if err := c.authorizationStore.UpdateScopes(ctx, authorization); err != nil {
+		return nil, fmt.Errorf("failed to update oauth authorization: %w", err)
+	}
+
+	return authorization, nil
+}
+
+func redirectWithError(redirectURI, state, code, description string) *AuthorizeOutput {
+	params := url.Values{
+		"error":             {code},
+		"error_description": {description},
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	return &AuthorizeOutput{RedirectURI: appendQuery(redirectURI, params)}
+}
+
+// resolveRedirectURI returns the redirect uri to use for the request.
+// The uri is optional in case the app only has a single redirect uri registered.
+func resolveRedirectURI(app *types.OAuthApp, redirectURI string) (string, error) {
+	if redirectURI == "" {
+		if len(app.RedirectURIs) != 1 {
+			return "", usererror.BadRequest("A redirect URI is required.")
+		}
+
+		return app.RedirectURIs[0], nil
+	}
+
+	for _, registered := range app.RedirectURIs {
+		if registered == redirectURI {
+			return redirectURI, nil
+		}
+	}
+
+	return "", usererror.BadRequest("The redirect URI isn't registered for the app.")
+}
+
+// authorizationCovers returns true if the authorization contains all provided scopes.
+func authorizationCovers(authorization *types.OAuthAuthorization, scopes []enum.OAuthScope) bool {
+	if authorization == nil {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !enum.OAuthScopesContain(authorization.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendQuery adds the params to the query of the uri (registered redirect uris are guaranteed to be valid).
+func appendQuery(uri string, params url.Values) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+func (in *AuthorizeInput) query() url.Values {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	set("response_type", in.ResponseType)
+	set("client_id", in.ClientID)
+	set("redirect_uri", in.RedirectURI)
+	set("scope", in.Scope)
+	set("state", in.State)
+	set("nonce", in.Nonce)
+	set("code_challenge", in.CodeChallenge)
+	set("code_challenge_method", in.CodeChallengeMethod)
+
+	return query
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	appurl "github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeURLProvider struct {
+	appurl.Provider
+}
+
+func (fakeURLProvider) GetAPIURL() string { return "https://gitness.example.com/api" }
+
+func (fakeURLProvider) GenerateUIOAuthConsentURL(query url.Values) string {
+	return "https://gitness.example.com/oauth/authorize?" + query.Encode()
+}
+
+type fakeAppStore struct {
+	store.OAuthAppStore
+	app *types.OAuthApp
+}
+
+func (s fakeAppStore) FindByClientID(_ context.Context, clientID string) (*types.OAuthApp, error) {
+	if clientID != s.app.ClientID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.app, nil
+}
+
+type fakeAuthorizationStore struct {
+	store.OAuthAuthorizationStore
+	created []*types.OAuthAuthorization
+}
+
+func (s *fakeAuthorizationStore) FindByAppAndPrincipal(
+	context.Context,
+	int64,
+	int64,
+) (*types.OAuthAuthorization, error) {
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeAuthorizationStore) Create(_ context.Context, authorization *types.OAuthAuthorization) error {
+	s.created = append(s.created, authorization)
+	return nil
+}
+
+type fakeCodeStore struct {
+	store.OAuthCodeStore
+}
+
+func (fakeCodeStore) DeleteExpiredBefore(context.Context, time.Time) (int64, error) { return 0, nil }
+
+func (fakeCodeStore) Create(context.Context, *types.OAuthAuthorizationCode) error { return nil }
+
+func setupAuthorizeController() (*Controller, *fakeAuthorizationStore) {
+	authorizationStore := &fakeAuthorizationStore{}
+	app := &types.OAuthApp{
+		ID:           1,
+		ClientID:     "client",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []enum.OAuthScope{enum.OAuthScopeOpenID, enum.OAuthScopeRepoRead},
+	}
+
+	return &Controller{
+		tx:                 fakeTransactor{},
+		urlProvider:        fakeURLProvider{},
+		appStore:           fakeAppStore{app: app},
+		authorizationStore: authorizationStore,
+		codeStore:          fakeCodeStore{},
+		codeLifetime:       time.Minute,
+	}, authorizationStore
+}
+
+func authorizeInput() *AuthorizeInput {
+	return &AuthorizeInput{
+		ResponseType: responseTypeCode,
+		ClientID:     "client",
+		Scope:        "openid repo:read",
+		State:        "state",
+	}
+}
+
+func TestAuthorizeConsentRequiresConsentToken(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Salt: "salt"}}
+
+	c, authorizationStore := setupAuthorizeController()
+
+	// the consent screen receives the consent token with the request.
+	out, err := c.Authorize(ctx, session, authorizeInput())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	consentURL, err := url.Parse(out.RedirectURI)
+	if err != nil {
+		t.Fatalf("failed to parse consent url: %s", err)
+	}
+	consentToken := consentURL.Query().Get("consent_token")
+	if consentToken == "" {
+		t.Fatalf("expected consent token in consent url %q", out.RedirectURI)
+	}
+
+	approve := true
+	otherSession := &auth.Session{Principal: types.Principal{ID: 2, UID: "other", Salt: "other-salt"}}
+	otherScopeInput := authorizeInput()
+	otherScopeInput.Scope = "openid"
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		in      *AuthorizeInput
+		token   string
+	}{
+		{name: "missing token", session: session, in: authorizeInput(), token: ""},
+		{name: "malformed token", session: session, in: authorizeInput(), token: "malformed"},
+		{name: "token of other user", session: otherSession, in: authorizeInput(), token: consentToken},
+		{name: "token of other request", session: session, in: otherScopeInput, token: consentToken},
+		{
+			name:    "expired token",
+			session: session,
+			in:      authorizeInput(),
+			token:   generateConsentToken(&session.Principal, authorizeInput(), time.Now().Add(-time.Minute)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.in.Approve = &approve
+			test.in.ConsentToken = test.token
+
+			_, err := c.Authorize(ctx, test.session, test.in)
+
+			var userErr *usererror.Error
+			if !errors.As(err, &userErr) || userErr.Status != http.StatusForbidden {
+				t.Errorf("expected forbidden error, got %v", err)
+			}
+		})
+	}
+
+	if len(authorizationStore.created) != 0 {
+		t.Fatalf("expected no authorization to be created, got %d", len(authorizationStore.created))
+	}
+
+	in := authorizeInput()
+	in.Approve = &approve
+	in.ConsentToken = consentToken
+
+	out, err = c.Authorize(ctx, session, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(out.RedirectURI, "https://app.example.com/callback?code=") {
+		t.Errorf("expected redirect to the app with a code, got %q", out.RedirectURI)
+	}
+	if len(authorizationStore.created) != 1 {
+		t.Errorf("expected authorization to be created, got %d", len(authorizationStore.created))
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// consentTokenLifetime is the time the user has to decide on the consent screen.
+const consentTokenLifetime = 30 * time.Minute
+
+// generateConsentToken returns the token the consent screen has to submit together with the decision
+// of the user. The token is bound to the user and the authorization request, and signed with the salt
+// of the user, which prevents other sites from submitting a decision on behalf of the user.
+func generateConsentToken(principal *types.Principal, in *AuthorizeInput, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + consentTokenSignature(principal, in, exp)
+}
+
+// verifyConsentToken returns true if the token was generated for the user and the authorization request
+// and didn't expire yet.
+func verifyConsentToken(principal *types.Principal, in *AuthorizeInput, token string, now time.Time) bool {
+	exp, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || now.Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(consentTokenSignature(principal, in, exp)))
+}
+
+func consentTokenSignature(principal *types.Principal, in *AuthorizeInput, exp string) string {
+	mac := hmac.New(sha256.New, []byte(principal.Salt))
+	for _, value := range []string{
+		"oauth-consent",
+		strconv.FormatInt(principal.ID, 10),
+		in.ClientID,
+		in.RedirectURI,
+		in.Scope,
+		exp,
+	} {
+		// values are separated by a null byte to prevent ambiguous concatenations.
+		mac.Write([]byte(value))
+		mac.Write([]byte{0})
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
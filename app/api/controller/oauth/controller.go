@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+type Controller struct {
+	tx                  dbtx.Transactor
+	encrypter           encrypt.Encrypter
+	urlProvider         url.Provider
+	authorizer          authz.Authorizer
+	principalStore      store.PrincipalStore
+	spaceStore          store.SpaceStore
+	appStore            store.OAuthAppStore
+	authorizationStore  store.OAuthAuthorizationStore
+	codeStore           store.OAuthCodeStore
+	accessTokenLifetime time.Duration
+	codeLifetime        time.Duration
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	encrypter encrypt.Encrypter,
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	appStore store.OAuthAppStore,
+	authorizationStore store.OAuthAuthorizationStore,
+	codeStore store.OAuthCodeStore,
+	accessTokenLifetime time.Duration,
+	codeLifetime time.Duration,
+) *Controller {
+	return &Controller{
+		tx:                  tx,
+		encrypter:           encrypter,
+		urlProvider:         urlProvider,
+		authorizer:          authorizer,
+		principalStore:      principalStore,
+		spaceStore:          spaceStore,
+		appStore:            appStore,
+		authorizationStore:  authorizationStore,
+		codeStore:           codeStore,
+		accessTokenLifetime: accessTokenLifetime,
+		codeLifetime:        codeLifetime,
+	}
+}
+
+// issuer returns the issuer identifier of gitness as OpenID Connect provider.
+func (c *Controller) issuer() string {
+	return c.urlProvider.GetAPIURL() + "/v1/oauth"
+}
+
+// checkUserSession ensures the session belongs to a user that authenticated with unrestricted credentials.
+// Credentials with restricted authorization (e.g. OAuth access tokens or repo git credentials)
+// are never allowed to manage OAuth apps or authorizations on behalf of the user.
+func checkUserSession(session *auth.Session) error {
+	if session == nil {
+		return usererror.ErrUnauthorized
+	}
+
+	if isRestrictedSession(session) {
+		return usererror.Forbidden("Restricted credentials can't be used to manage OAuth apps and authorizations.")
+	}
+
+	return nil
+}
+
+// isRestrictedSession returns true if the session metadata restricts the authorization of the session.
+func isRestrictedSession(session *auth.Session) bool {
+	return session.Metadata != nil && session.Metadata.ImpactsAuthorization()
+}
+
+// generateRandomString returns a url safe random string with the provided number of bytes of entropy.
+func generateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashCode returns the hash under which an authorization code is stored.
+func hashCode(code string) string {
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
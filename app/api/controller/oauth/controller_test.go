@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRestrictedSessionsRejected(t *testing.T) {
+	ctx := context.Background()
+	principal := types.Principal{ID: 1, UID: "user", Salt: "salt", Type: enum.PrincipalTypeUser}
+
+	sessions := map[string]*auth.Session{
+		"oauth access token": {
+			Principal: principal,
+			Metadata:  &auth.OAuthMetadata{AppID: 1, AuthorizationID: 1, Scopes: []enum.OAuthScope{enum.OAuthScopeUserRead}},
+		},
+		"repo git credential": {
+			Principal: principal,
+			Metadata:  &auth.RepoAccessMetadata{RepoID: 1, Role: enum.MembershipRoleSpaceOwner},
+		},
+		"ephemeral membership": {
+			Principal: principal,
+			Metadata:  &auth.MembershipMetadata{SpaceID: 1, Role: enum.MembershipRoleSpaceOwner},
+		},
+	}
+
+	operations := map[string]func(c *Controller, session *auth.Session) error{
+		"create app": func(c *Controller, session *auth.Session) error {
+			_, err := c.CreateApp(ctx, session, &CreateAppInput{Name: "app"})
+			return err
+		},
+		"list apps": func(c *Controller, session *auth.Session) error {
+			_, err := c.ListApps(ctx, session)
+			return err
+		},
+		"regenerate client secret": func(c *Controller, session *auth.Session) error {
+			_, err := c.RegenerateClientSecret(ctx, session, 1)
+			return err
+		},
+		"list authorizations": func(c *Controller, session *auth.Session) error {
+			_, err := c.ListAuthorizations(ctx, session)
+			return err
+		},
+		"authorize": func(c *Controller, session *auth.Session) error {
+			_, err := c.Authorize(ctx, session, authorizeInput())
+			return err
+		},
+	}
+
+	for sessionName, session := range sessions {
+		for opName, op := range operations {
+			t.Run(sessionName+"/"+opName, func(t *testing.T) {
+				c, authorizationStore := setupAuthorizeController()
+
+				err := op(c, session)
+
+				var userErr *usererror.Error
+				if !errors.As(err, &userErr) || userErr.Status != http.StatusForbidden {
+					t.Errorf("expected forbidden error, got %v", err)
+				}
+				if len(authorizationStore.created) != 0 {
+					t.Errorf("expected no authorization to be created, got %d", len(authorizationStore.created))
+				}
+			})
+		}
+	}
+}
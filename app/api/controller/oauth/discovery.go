@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// DiscoveryDocument is the OpenID Connect provider metadata (OpenID Connect Discovery section 3).
+type DiscoveryDocument struct {
+	Issuer                            string            `json:"issuer"`
+	AuthorizationEndpoint             string            `json:"authorization_endpoint"`
+	TokenEndpoint                     string            `json:"token_endpoint"`
+	UserInfoEndpoint                  string            `json:"userinfo_endpoint"`
+	RevocationEndpoint                string            `json:"revocation_endpoint"`
+	ScopesSupported                   []enum.OAuthScope `json:"scopes_supported"`
+	ResponseTypesSupported            []string          `json:"response_types_supported"`
+	GrantTypesSupported               []string          `json:"grant_types_supported"`
+	SubjectTypesSupported             []string          `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string          `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string          `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string          `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string          `json:"claims_supported"`
+}
+
+// Discovery returns the OpenID Connect provider metadata of gitness.
+func (c *Controller) Discovery() *DiscoveryDocument {
+	issuer := c.issuer()
+	scopes, _ := enum.GetAllOAuthScopes()
+
+	return &DiscoveryDocument{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     issuer + "/token",
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		RevocationEndpoint:                issuer + "/revoke",
+		ScopesSupported:                   scopes,
+		ResponseTypesSupported:            []string{responseTypeCode},
+		GrantTypesSupported:               []string{grantTypeAuthorizationCode},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"HS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{codeChallengeMethodPlain, codeChallengeMethodS256},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "nonce", "preferred_username", "name", "email",
+		},
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSpaceIntegrations returns the OAuth apps that were authorized by members of the space (or any of its parents),
+// together with the access each of the members granted to them.
+func (c *Controller) ListSpaceIntegrations(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.OAuthIntegration, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	spaceIDs := []int64{space.ID}
+	for parentID := space.ParentID; parentID > 0; {
+		parent, err := c.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space: %w", err)
+		}
+
+		spaceIDs = append(spaceIDs, parent.ID)
+		parentID = parent.ParentID
+	}
+
+	authorizations, err := c.authorizationStore.ListBySpaces(ctx, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth authorizations of space members: %w", err)
+	}
+
+	// authorizations are ordered by app, group them accordingly.
+	integrations := make([]*types.OAuthIntegration, 0)
+	var current *types.OAuthIntegration
+	for _, authorization := range authorizations {
+		if current == nil || current.App.ClientID != authorization.App.ClientID {
+			current = &types.OAuthIntegration{
+				App:            *authorization.App,
+				Scopes:         []enum.OAuthScope{},
+				Authorizations: []types.OAuthIntegrationAccess{},
+			}
+			integrations = append(integrations, current)
+		}
+
+		for _, scope := range authorization.Scopes {
+			if !enum.OAuthScopesContain(current.Scopes, scope) {
+				current.Scopes = append(current.Scopes, scope)
+			}
+		}
+
+		current.Authorizations = append(current.Authorizations, types.OAuthIntegrationAccess{
+			Principal: *authorization.Principal,
+			Scopes:    authorization.Scopes,
+			Created:   authorization.Created,
+			Updated:   authorization.Updated,
+		})
+	}
+
+	return integrations, nil
+}
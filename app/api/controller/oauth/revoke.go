@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/jwt"
+	gitness_store "github.com/harness/gitness/store"
+
+	gojwt "github.com/golang-jwt/jwt"
+)
+
+// RevokeInput contains the parameters of an OAuth2 token revocation request (RFC 7009 section 2.1).
+type RevokeInput struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+// Revoke revokes an access token issued to the app.
+// As access tokens aren't stored, the whole authorization of the user is revoked,
+// which invalidates all access tokens the app was issued for the user.
+// As required by RFC 7009, invalid or unknown tokens don't result in an error.
+// Errors returned to the app are of type *Error.
+func (c *Controller) Revoke(ctx context.Context, in *RevokeInput) error {
+	app, _, err := c.authenticateClient(ctx, in.ClientID, in.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	if in.Token == "" {
+		return errInvalidRequest("The token is required.")
+	}
+
+	claims := &jwt.Claims{}
+	_, err = gojwt.ParseWithClaims(in.Token, claims, func(token *gojwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*gojwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+
+		principal, findErr := c.principalStore.Find(ctx, claims.PrincipalID)
+		if findErr != nil {
+			return nil, findErr
+		}
+
+		return []byte(principal.Salt), nil
+	})
+	if err != nil || claims.OAuth == nil {
+		return nil
+	}
+
+	authorization, err := c.authorizationStore.Find(ctx, claims.OAuth.AuthorizationID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find oauth authorization: %w", err)
+	}
+
+	// apps are only allowed to revoke their own tokens, don't leak anything about tokens of other apps.
+	if authorization.AppID != app.ID || authorization.PrincipalID != claims.PrincipalID {
+		return nil
+	}
+
+	err = c.authorizationStore.Delete(ctx, authorization.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth authorization: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/jwt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gojwt "github.com/golang-jwt/jwt"
+)
+
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	tokenTypeBearer            = "Bearer"
+)
+
+// Error is an OAuth2 error response as defined in RFC 6749 section 5.2.
+type Error struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func errInvalidRequest(description string) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: "invalid_request", Description: description}
+}
+
+func errInvalidClient() *Error {
+	return &Error{Status: http.StatusUnauthorized, Code: "invalid_client", Description: "Client authentication failed."}
+}
+
+func errInvalidGrant(description string) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: "invalid_grant", Description: description}
+}
+
+// TokenInput contains the parameters of an OAuth2 access token request (RFC 6749 section 4.1.3).
+type TokenInput struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	ClientID     string
+	ClientSecret string
+}
+
+// TokenOutput is the OAuth2 access token response (RFC 6749 section 5.1).
+type TokenOutput struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+	IDToken     string `json:"id_token,omitempty"`
+}
+
+// Token exchanges an authorization code for an access token of the app.
+// Errors returned to the app are of type *Error.
+func (c *Controller) Token(ctx context.Context, in *TokenInput) (*TokenOutput, error) {
+	app, clientSecret, err := c.authenticateClient(ctx, in.ClientID, in.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.GrantType != grantTypeAuthorizationCode {
+		return nil, &Error{
+			Status:      http.StatusBadRequest,
+			Code:        "unsupported_grant_type",
+			Description: "Only the authorization code grant is supported.",
+		}
+	}
+
+	if in.Code == "" {
+		return nil, errInvalidRequest("The code is required.")
+	}
+
+	code, err := c.codeStore.Consume(ctx, hashCode(in.Code))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errInvalidGrant("The code is invalid or was already used.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume oauth code: %w", err)
+	}
+
+	now := time.Now()
+	if code.Expires < now.UnixMilli() {
+		return nil, errInvalidGrant("The code is expired.")
+	}
+
+	authorization, err := c.authorizationStore.Find(ctx, code.AuthorizationID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errInvalidGrant("The authorization was revoked.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth authorization: %w", err)
+	}
+
+	if authorization.AppID != app.ID {
+		return nil, errInvalidGrant("The code was issued to another client.")
+	}
+
+	if code.RedirectURI != in.RedirectURI {
+		return nil, errInvalidGrant("The redirect URI doesn't match the one of the authorization request.")
+	}
+
+	if !verifyCodeChallenge(code, in.CodeVerifier) {
+		return nil, errInvalidGrant("The code verifier is invalid.")
+	}
+
+	// the scopes registered for the app might have been reduced since the code was issued.
+	code.Scopes = intersectScopes(code.Scopes, app.Scopes)
+	if len(code.Scopes) == 0 {
+		return nil, errInvalidGrant("None of the granted scopes is registered for the app anymore.")
+	}
+
+	principal, err := c.principalStore.Find(ctx, authorization.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	accessToken, err := jwt.GenerateForOAuth(
+		principal.ID,
+		authorization.ID,
+		code.Scopes,
+		c.accessTokenLifetime,
+		principal.Salt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	out := &TokenOutput{
+		AccessToken: accessToken,
+		TokenType:   tokenTypeBearer,
+		ExpiresIn:   int64(c.accessTokenLifetime.Seconds()),
+		Scope:       enum.FormatOAuthScopes(code.Scopes),
+	}
+
+	if enum.OAuthScopesContain(code.Scopes, enum.OAuthScopeOpenID) {
+		out.IDToken, err = c.generateIDToken(app, principal, code, clientSecret, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// authenticateClient verifies the client credentials and returns the app and its decrypted client secret.
+func (c *Controller) authenticateClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+) (*types.OAuthApp, string, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, "", errInvalidClient()
+	}
+
+	app, err := c.appStore.FindByClientID(ctx, clientID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, "", errInvalidClient()
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find oauth app: %w", err)
+	}
+
+	secret, err := c.encrypter.Decrypt([]byte(app.ClientSecret))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt client secret: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(clientSecret)) != 1 {
+		return nil, "", errInvalidClient()
+	}
+
+	return app, secret, nil
+}
+
+func (c *Controller) generateIDToken(
+	app *types.OAuthApp,
+	principal *types.Principal,
+	code *types.OAuthAuthorizationCode,
+	clientSecret string,
+	now time.Time,
+) (string, error) {
+	claims := jwt.IDTokenClaims{
+		StandardClaims: gojwt.StandardClaims{
+			Issuer:    c.issuer(),
+			Subject:   strconv.FormatInt(principal.ID, 10),
+			Audience:  app.ClientID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(c.accessTokenLifetime).Unix(),
+		},
+		Nonce: code.Nonce,
+	}
+
+	if enum.OAuthScopesContain(code.Scopes, enum.OAuthScopeProfile) {
+		claims.PreferredUsername = principal.UID
+		claims.Name = principal.DisplayName
+	}
+	if enum.OAuthScopesContain(code.Scopes, enum.OAuthScopeEmail) {
+		claims.Email = principal.Email
+	}
+
+	idToken, err := jwt.GenerateIDToken(claims, clientSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate id token: %w", err)
+	}
+
+	return idToken, nil
+}
+
+// verifyCodeChallenge verifies the PKCE code verifier against the challenge of the authorization request.
+func verifyCodeChallenge(code *types.OAuthAuthorizationCode, verifier string) bool {
+	if code.CodeChallenge == "" {
+		return true
+	}
+
+	if verifier == "" {
+		return false
+	}
+
+	challenge := verifier
+	if code.CodeChallengeMethod == codeChallengeMethodS256 {
+		h := sha256.Sum256([]byte(verifier))
+		challenge = base64.RawURLEncoding.EncodeToString(h[:])
+	}
+
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(code.CodeChallenge)) == 1
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UserInfo contains the claims about the authenticated user (OpenID Connect Core section 5.3).
+type UserInfo struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Email             string `json:"email,omitempty"`
+}
+
+// UserInfo returns the claims about the user the access token was issued for.
+// For OAuth access tokens, only the claims covered by the granted scopes are returned.
+func (c *Controller) UserInfo(_ context.Context, session *auth.Session) (*UserInfo, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	hasScope := func(enum.OAuthScope) bool { return true }
+	if oauthMetadata, ok := session.Metadata.(*auth.OAuthMetadata); ok {
+		hasScope = func(scope enum.OAuthScope) bool {
+			return enum.OAuthScopesContain(oauthMetadata.Scopes, scope)
+		}
+	}
+
+	if !hasScope(enum.OAuthScopeOpenID) {
+		return nil, usererror.Forbidden("The access token wasn't issued for the openid scope.")
+	}
+
+	info := &UserInfo{
+		Subject: strconv.FormatInt(session.Principal.ID, 10),
+	}
+	if hasScope(enum.OAuthScopeProfile) {
+		info.PreferredUsername = session.Principal.UID
+		info.Name = session.Principal.DisplayName
+	}
+	if hasScope(enum.OAuthScopeEmail) {
+		info.Email = session.Principal.Email
+	}
+
+	return info, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+)
+
+// CodeOwners maps the changed files of the pull request to their owners as defined by the CODEOWNERS file
+// of the target branch, and shows which owners approved the pull request.
+func (c *Controller) CodeOwners(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.CodeOwnersSummary, error) {
+	repo, pr, err := c.getRepoAndPullReqForDiff(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	return c.codeOwnersSummary(ctx, repo, pr, reviewers)
+}
+
+func (c *Controller) codeOwnersSummary(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	reviewers []*types.PullReqReviewer,
+) (*types.CodeOwnersSummary, error) {
+	file, err := c.codeOwners.Get(ctx, repo, pr.TargetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code owners file: %w", err)
+	}
+
+	paths, err := c.changedPaths(ctx, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := c.codeOwners.Summarize(ctx, file, paths, reviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize code owners: %w", err)
+	}
+
+	return summary, nil
+}
+
+// changedPaths returns the paths of all files changed by the pull request, including the old paths of renames.
+func (c *Controller) changedPaths(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) ([]string, error) {
+	reader := gitrpc.NewStreamReader(c.gitRPCClient.Diff(ctx, &gitrpc.DiffParams{
+		ReadParams:   gitrpc.CreateRPCReadParams(repo),
+		BaseRef:      pr.MergeBaseSHA,
+		HeadRef:      pr.SourceSHA,
+		IncludePatch: false,
+	}))
+
+	paths := []string{}
+	for {
+		fileDiff, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read next file diff: %w", err)
+		}
+
+		switch fileDiff.Status {
+		case gitrpc.FileDiffStatusDeleted:
+			paths = append(paths, fileDiff.OldPath)
+		case gitrpc.FileDiffStatusRenamed:
+			paths = append(paths, fileDiff.OldPath, fileDiff.Path)
+		case gitrpc.FileDiffStatusAdded, gitrpc.FileDiffStatusModified:
+			paths = append(paths, fileDiff.Path)
+		case gitrpc.FileDiffStatusUndefined:
+		}
+	}
+
+	return paths, nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/gitrpc"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -103,6 +104,10 @@ func (c *Controller) CommentCreate(
 		return nil, errValidate
 	}
 
+	if err = c.checkCanComment(ctx, session, repo, pr); err != nil {
+		return nil, err
+	}
+
 	act := getCommentActivity(session, pr, in)
 
 	switch {
@@ -177,6 +182,20 @@ func (c *Controller) CommentCreate(
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
+	var parentID int64
+	if act.ParentID != nil {
+		parentID = *act.ParentID
+	}
+
+	// subscribe the mentioned users before the event is reported, so they get notified about this comment.
+	c.subscribeMentioned(ctx, &session.Principal, repo, pr, act.Text)
+
+	c.eventReporter.CommentCreated(ctx, &pullreqevents.CommentCreatedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ActivityID: act.ID,
+		ParentID:   parentID,
+	})
+
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.CommentCount++
 		if act.IsBlocking() {
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -62,6 +63,10 @@ func (c *Controller) CommentUpdate(
 		return nil, errValidate
 	}
 
+	if err = c.checkCanComment(ctx, session, repo, pr); err != nil {
+		return nil, err
+	}
+
 	act, err := c.getCommentCheckEditAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comment: %w", err)
@@ -81,6 +86,13 @@ func (c *Controller) CommentUpdate(
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
 
+	c.subscribeMentioned(ctx, &session.Principal, repo, pr, act.Text)
+
+	c.eventReporter.CommentUpdated(ctx, &pullreqevents.CommentUpdatedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ActivityID: act.ID,
+	})
+
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
 	}
@@ -23,8 +23,14 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/authorship"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/mergecheck"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -45,15 +51,26 @@ type Controller struct {
 	codeCommentView     store.CodeCommentView
 	reviewStore         store.PullReqReviewStore
 	reviewerStore       store.PullReqReviewerStore
+	subscriberStore     store.PullReqSubscriberStore
+	userGroupStore      store.UserGroupMemberStore
 	repoStore           store.RepoStore
 	principalStore      store.PrincipalStore
 	fileViewStore       store.PullReqFileViewStore
+	pushStore           store.PullReqPushStore
+	checkStore          store.CheckStore
+	reqCheckStore       store.ReqCheckStore
 	gitRPCClient        gitrpc.Interface
 	eventReporter       *pullreqevents.Reporter
 	mtxManager          lock.MutexManager
 	codeCommentMigrator *codecomments.Migrator
 	pullreqService      *pullreq.Service
 	sseStreamer         sse.Streamer
+	settings            *settings.Service
+	mergeChecks         *mergecheck.Service
+	codeOwners          *codeowners.Service
+	authorship          *authorship.Service
+	wordDiff            *worddiff.Service
+	instanceSettings    *instancesettings.Service
 }
 
 func NewController(
@@ -65,15 +82,26 @@ func NewController(
 	codeCommentView store.CodeCommentView,
 	pullreqReviewStore store.PullReqReviewStore,
 	pullreqReviewerStore store.PullReqReviewerStore,
+	pullreqSubscriberStore store.PullReqSubscriberStore,
+	userGroupStore store.UserGroupMemberStore,
 	repoStore store.RepoStore,
 	principalStore store.PrincipalStore,
 	fileViewStore store.PullReqFileViewStore,
+	pushStore store.PullReqPushStore,
+	checkStore store.CheckStore,
+	reqCheckStore store.ReqCheckStore,
 	gitRPCClient gitrpc.Interface,
 	eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager,
 	codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service,
 	sseStreamer sse.Streamer,
+	settings *settings.Service,
+	mergeChecks *mergecheck.Service,
+	codeOwners *codeowners.Service,
+	authorship *authorship.Service,
+	wordDiff *worddiff.Service,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -84,15 +112,26 @@ func NewController(
 		codeCommentView:     codeCommentView,
 		reviewStore:         pullreqReviewStore,
 		reviewerStore:       pullreqReviewerStore,
+		subscriberStore:     pullreqSubscriberStore,
+		userGroupStore:      userGroupStore,
 		repoStore:           repoStore,
 		principalStore:      principalStore,
 		fileViewStore:       fileViewStore,
+		pushStore:           pushStore,
+		checkStore:          checkStore,
+		reqCheckStore:       reqCheckStore,
 		gitRPCClient:        gitRPCClient,
 		codeCommentMigrator: codeCommentMigrator,
 		eventReporter:       eventReporter,
 		mtxManager:          mtxManager,
 		pullreqService:      pullreqService,
 		sseStreamer:         sseStreamer,
+		settings:            settings,
+		mergeChecks:         mergeChecks,
+		codeOwners:          codeOwners,
+		authorship:          authorship,
+		wordDiff:            wordDiff,
+		instanceSettings:    instanceSettings,
 	}
 }
 
@@ -134,7 +134,7 @@ func (c *Controller) FileViewAdd(
 	}
 
 	// in case of deleted file set sha to nilsha - that's how git diff treats it, too.
-	sha := types.NilSHA
+	sha := types.NilSHAFor(pr.MergeBaseSHA)
 	if inNode != nil {
 		sha = inNode.Node.SHA
 	}
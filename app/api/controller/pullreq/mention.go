@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxMentions = 50
+
+var (
+	mentionRegexp    = regexp.MustCompile(`(?:^|[^\w@/.-])@([a-zA-Z_][a-zA-Z0-9-_.]*)`)
+	codeBlockRegexp  = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRegexp = regexp.MustCompile("`[^`\n]*`")
+)
+
+// parseMentions returns the unique principal UIDs mentioned in the text with the @uid syntax.
+// Mentions inside code blocks and inline code are ignored.
+func parseMentions(text string) []string {
+	text = codeBlockRegexp.ReplaceAllString(text, "")
+	text = inlineCodeRegexp.ReplaceAllString(text, "")
+
+	matches := mentionRegexp.FindAllStringSubmatch(text, -1)
+
+	uids := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		uid := strings.TrimRight(match[1], ".")
+		if uid == "" {
+			continue
+		}
+
+		key := strings.ToLower(uid)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		uids = append(uids, uid)
+		if len(uids) == maxMentions {
+			break
+		}
+	}
+
+	return uids
+}
+
+// subscribeMentioned subscribes all users mentioned in the text to the pull request.
+// A mentioned user group (team) subscribes all of its users.
+// Users without read access to the repository and the actor are skipped,
+// and users that explicitly unsubscribed from the pull request are left unsubscribed.
+func (c *Controller) subscribeMentioned(
+	ctx context.Context,
+	actor *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	text string,
+) {
+	uids := parseMentions(text)
+	if len(uids) == 0 {
+		return
+	}
+
+	principals, err := c.principalStore.FindManyByUID(ctx, uids)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find mentioned principals")
+		return
+	}
+
+	users, err := c.expandMentionedGroups(ctx, principals)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to expand mentioned user groups")
+		return
+	}
+
+	now := time.Now().UnixMilli()
+	for _, principal := range users {
+		if principal.ID == actor.ID || principal.Blocked {
+			continue
+		}
+
+		session := &auth.Session{Principal: *principal}
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+			continue
+		}
+
+		err = c.subscriberStore.CreateIfNotExists(ctx, &types.PullReqSubscriber{
+			PullReqID:   pr.ID,
+			PrincipalID: principal.ID,
+			Subscribed:  true,
+			Created:     now,
+			Updated:     now,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to subscribe mentioned user %d to pull request", principal.ID)
+		}
+	}
+}
+
+// expandMentionedGroups returns the mentioned users along with all users of the mentioned user groups.
+// Other types of principals are ignored.
+func (c *Controller) expandMentionedGroups(
+	ctx context.Context,
+	principals []*types.Principal,
+) ([]*types.Principal, error) {
+	users := make([]*types.Principal, 0, len(principals))
+	seen := make(map[int64]struct{}, len(principals))
+	for _, principal := range principals {
+		//nolint:exhaustive // only users and user groups can be mentioned
+		switch principal.Type {
+		case enum.PrincipalTypeUser:
+			if _, ok := seen[principal.ID]; !ok {
+				seen[principal.ID] = struct{}{}
+				users = append(users, principal)
+			}
+		case enum.PrincipalTypeUserGroup:
+			userIDs, err := c.userGroupStore.ListUserIDs(ctx, principal.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list users of user group '%s': %w", principal.UID, err)
+			}
+
+			for _, userID := range userIDs {
+				if _, ok := seen[userID]; ok {
+					continue
+				}
+				seen[userID] = struct{}{}
+
+				user, err := c.principalStore.Find(ctx, userID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to find user %d: %w", userID, err)
+				}
+
+				users = append(users, user)
+			}
+		}
+	}
+
+	return users, nil
+}
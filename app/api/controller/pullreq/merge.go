@@ -17,6 +17,8 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
@@ -35,6 +37,10 @@ import (
 type MergeInput struct {
 	Method    enum.MergeMethod `json:"method"`
 	SourceSHA string           `json:"source_sha"`
+	// DeleteSourceBranch overrides the repository setting for deleting the source branch after the merge.
+	DeleteSourceBranch *bool `json:"delete_source_branch"`
+	// Author is the requested author of the merge commit (defaults to the acting principal).
+	Author *types.Identity `json:"author"`
 }
 
 // Merge merges the pull request.
@@ -112,6 +118,26 @@ func (c *Controller) Merge(
 		}
 	}
 
+	if err = c.verifySettings(ctx, targetRepo, pr, reviewers, method); err != nil {
+		return types.MergeResponse{}, err
+	}
+
+	if err = c.verifyRequiredChecks(ctx, targetRepo, pr); err != nil {
+		return types.MergeResponse{}, err
+	}
+
+	mergeCheckViolations, err := c.mergeChecks.Run(ctx, targetRepo, pr, &session.Principal)
+	if err != nil {
+		return types.MergeResponse{}, err
+	}
+	for _, violation := range mergeCheckViolations {
+		if violation.Required {
+			return types.MergeResponse{
+				MergeCheckViolations: mergeCheckViolations,
+			}, nil
+		}
+	}
+
 	sourceRepo := targetRepo
 	if pr.SourceRepoID != pr.TargetRepoID {
 		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
@@ -134,6 +160,12 @@ func (c *Controller) Merge(
 		mergeTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
 	}
 
+	author, err := c.authorship.Resolve(ctx, targetRepo, &session.Principal,
+		enum.CommitAuthorOperationMerge, in.Author)
+	if err != nil {
+		return types.MergeResponse{}, err
+	}
+
 	now := time.Now()
 	var mergeOutput gitrpc.MergeOutput
 	mergeOutput, err = c.gitRPCClient.Merge(ctx, &gitrpc.MergeParams{
@@ -145,7 +177,7 @@ func (c *Controller) Merge(
 		Message:         "",
 		Committer:       rpcIdentityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		CommitterDate:   &now,
-		Author:          rpcIdentityFromPrincipal(session.Principal),
+		Author:          author.Identity,
 		AuthorDate:      &now,
 		RefType:         gitrpcenum.RefTypeBranch,
 		RefName:         pr.TargetBranch,
@@ -161,6 +193,10 @@ func (c *Controller) Merge(
 		return types.MergeResponse{}, fmt.Errorf("merge check execution failed: %w", err)
 	}
 
+	mergeDuration.WithLabelValues(string(in.Method)).Observe(time.Since(now).Seconds())
+
+	c.authorship.Record(ctx, author, mergeOutput.MergeSHA)
+
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.State = enum.PullReqStateMerged
 
@@ -183,6 +219,9 @@ func (c *Controller) Merge(
 		return types.MergeResponse{}, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
+	timeToMerge.WithLabelValues(string(in.Method)).Observe(
+		(time.Duration(*pr.Merged-pr.Created) * time.Millisecond).Seconds())
+
 	activityPayload := &types.PullRequestActivityPayloadMerge{
 		MergeMethod: in.Method,
 		MergeSHA:    mergeOutput.MergeSHA,
@@ -202,7 +241,130 @@ func (c *Controller) Merge(
 		SourceSHA:   mergeOutput.HeadSHA,
 	})
 
+	branchDeleted := c.deleteSourceBranchAfterMerge(ctx, session, targetRepo, sourceRepo, pr,
+		mergeOutput.HeadSHA, in.DeleteSourceBranch)
+
 	return types.MergeResponse{
-		SHA: sha,
+		SHA:                  sha,
+		MergeCheckViolations: mergeCheckViolations,
+		BranchDeleted:        branchDeleted,
 	}, nil
 }
+
+// verifySettings verifies that the pull request satisfies the merge settings of the target repository.
+func (c *Controller) verifySettings(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	reviewers []*types.PullReqReviewer,
+	method enum.MergeMethod,
+) error {
+	methods, err := c.settings.RepoMergeMethods(ctx, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to get allowed merge methods: %w", err)
+	}
+
+	allowed := false
+	for _, m := range methods {
+		if enum.MergeMethod(m) == method {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return usererror.BadRequestf("The merge method '%s' is not allowed for this repository.", method)
+	}
+
+	rules, err := c.settings.RepoPullReqRules(ctx, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request rules: %w", err)
+	}
+
+	approvals := 0
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved {
+			approvals++
+		}
+	}
+	if approvals < rules.MinApprovals {
+		return usererror.BadRequestf("The pull request requires at least %d approvals, it has %d.",
+			rules.MinApprovals, approvals)
+	}
+
+	if rules.RequireResolvedComments && pr.UnresolvedCount > 0 {
+		return usererror.BadRequest("All comments of the pull request have to be resolved.")
+	}
+
+	if rules.RequireCodeOwners {
+		summary, err := c.codeOwnersSummary(ctx, targetRepo, pr, reviewers)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range summary.Entries {
+			if entry.Approved {
+				continue
+			}
+
+			owners := make([]string, len(entry.Owners))
+			for i, owner := range entry.Owners {
+				owners[i] = owner.Identifier
+			}
+
+			return usererror.BadRequestf("The changes to '%s' require the approval of a code owner (%s).",
+				entry.Pattern, strings.Join(owners, ", "))
+		}
+	}
+
+	return nil
+}
+
+// verifyRequiredChecks verifies that all required status checks of the target branch
+// succeeded for the head commit of the pull request.
+// The required status checks that didn't succeed are returned as the payload of the error.
+func (c *Controller) verifyRequiredChecks(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) error {
+	reqChecks, err := c.reqCheckStore.ListForBranch(ctx, targetRepo.ID, pr.TargetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list required status checks: %w", err)
+	}
+
+	if len(reqChecks) == 0 {
+		return nil
+	}
+
+	checks, err := c.checkStore.ListAll(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status checks of the pull request head: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checks))
+	for _, check := range checks {
+		statuses[check.UID] = check.Status
+	}
+
+	var violations []types.ReqCheckViolation
+	for _, reqCheck := range reqChecks {
+		status := statuses[reqCheck.CheckUID]
+		if status == enum.CheckStatusSuccess {
+			continue
+		}
+
+		violations = append(violations, types.ReqCheckViolation{
+			CheckUID:      reqCheck.CheckUID,
+			BranchPattern: reqCheck.BranchPattern,
+			Status:        status,
+		})
+	}
+
+	if len(violations) > 0 {
+		return usererror.NewWithPayload(http.StatusUnprocessableEntity,
+			"The required status checks of the target branch didn't succeed.",
+			map[string]any{"check_violations": violations})
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeReqCheckStore struct {
+	store.ReqCheckStore
+	reqChecks []*types.ReqCheck
+}
+
+func (s fakeReqCheckStore) ListForBranch(context.Context, int64, string) ([]*types.ReqCheck, error) {
+	return s.reqChecks, nil
+}
+
+type fakeCheckStore struct {
+	store.CheckStore
+	checks []types.Check
+}
+
+func (s fakeCheckStore) ListAll(context.Context, int64, string) ([]types.Check, error) {
+	return s.checks, nil
+}
+
+func TestVerifyRequiredChecks(t *testing.T) {
+	reqChecks := []*types.ReqCheck{
+		{BranchPattern: "main", CheckUID: "build"},
+		{BranchPattern: "*", CheckUID: "lint"},
+	}
+
+	tests := []struct {
+		name           string
+		checks         []types.Check
+		wantViolations []types.ReqCheckViolation
+	}{
+		{
+			name: "all-succeeded",
+			checks: []types.Check{
+				{UID: "build", Status: enum.CheckStatusSuccess},
+				{UID: "lint", Status: enum.CheckStatusSuccess},
+			},
+		},
+		{
+			name: "failed-and-missing",
+			checks: []types.Check{
+				{UID: "build", Status: enum.CheckStatusFailure},
+			},
+			wantViolations: []types.ReqCheckViolation{
+				{CheckUID: "build", BranchPattern: "main", Status: enum.CheckStatusFailure},
+				{CheckUID: "lint", BranchPattern: "*"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				reqCheckStore: fakeReqCheckStore{reqChecks: reqChecks},
+				checkStore:    fakeCheckStore{checks: test.checks},
+			}
+
+			repo := &types.Repository{ID: 1}
+			pr := &types.PullReq{TargetBranch: "main", SourceSHA: "abc"}
+			err := c.verifyRequiredChecks(context.Background(), repo, pr)
+
+			if test.wantViolations == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusUnprocessableEntity {
+				t.Fatalf("want unprocessable entity error, got %v", err)
+			}
+			if got := uErr.Values["check_violations"]; !reflect.DeepEqual(got, test.wantViolations) {
+				t.Errorf("want violations %v, got %v", test.wantViolations, got)
+			}
+		})
+	}
+}
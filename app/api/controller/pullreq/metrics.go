@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// mergeDuration tracks the duration of the git merge operation of pull requests.
+	mergeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "pullreq",
+		Name:      "merge_duration_seconds",
+		Help:      "Duration of the merge operation of pull requests per merge method.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"method"})
+
+	// timeToMerge tracks the time between the creation and the merge of pull requests.
+	timeToMerge = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "pullreq",
+		Name:      "time_to_merge_seconds",
+		Help:      "Time between the creation and the merge of pull requests per merge method.",
+		// from one minute to roughly a month.
+		Buckets: prometheus.ExponentialBuckets(60, 4, 9),
+	}, []string{"method"})
+)
@@ -114,6 +114,8 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("pullreq creation failed: %w", err)
 	}
 
+	c.recordPush(ctx, pr, session.Principal.ID, false)
+
 	c.eventReporter.Created(ctx, &pullreqevents.CreatedPayload{
 		Base:         eventBase(pr, &session.Principal),
 		SourceBranch: in.SourceBranch,
@@ -121,6 +123,8 @@ func (c *Controller) Create(
 		SourceSHA:    sourceSHA,
 	})
 
+	c.subscribeMentioned(ctx, &session.Principal, targetRepo, pr, pr.Description)
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
 	}
@@ -138,7 +142,7 @@ func newPullReq(
 	sourceSHA, mergeBaseSHA string,
 ) *types.PullReq {
 	now := time.Now().UnixMilli()
-	return &types.PullReq{
+	pr := &types.PullReq{
 		ID:               0, // the ID will be populated in the data layer
 		Version:          0,
 		Number:           number,
@@ -164,4 +168,8 @@ func newPullReq(
 		Author:           *session.Principal.ToPrincipalInfo(),
 		Merger:           nil,
 	}
+
+	setTaskCounts(pr)
+
+	return pr
 }
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RawDiff writes the raw git diff of the pull request (between its merge base and source sha).
+func (c *Controller) RawDiff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	filePaths []string,
+	diffOpts types.DiffOptions,
+	w io.Writer,
+) error {
+	repo, pr, err := c.getRepoAndPullReqForDiff(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return err
+	}
+
+	return c.gitRPCClient.RawDiff(ctx, &gitrpc.DiffParams{
+		ReadParams: gitrpc.CreateRPCReadParams(repo),
+		BaseRef:    pr.MergeBaseSHA,
+		HeadRef:    pr.SourceSHA,
+		Paths:      filePaths,
+		Options:    controller.MapDiffOptions(diffOpts),
+	}, w)
+}
+
+// Diff streams the changed files of the pull request (between its merge base and source sha).
+// Files exceeding the configured diff limits are returned collapsed, without patch,
+// and can be loaded individually by providing their paths.
+// If requested together with the patch, the word-level changes of modified lines are included.
+func (c *Controller) Diff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	includePatch bool,
+	includeWordDiff bool,
+	filePaths []string,
+	diffOpts types.DiffOptions,
+) (types.Stream[*gitrpc.FileDiff], error) {
+	repo, pr, err := c.getRepoAndPullReqForDiff(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := c.instanceSettings.DiffLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff limits: %w", err)
+	}
+
+	reader := gitrpc.NewStreamReader(c.gitRPCClient.Diff(ctx, &gitrpc.DiffParams{
+		ReadParams:   gitrpc.CreateRPCReadParams(repo),
+		BaseRef:      pr.MergeBaseSHA,
+		HeadRef:      pr.SourceSHA,
+		IncludePatch: includePatch,
+		Paths:        filePaths,
+		Limits:       limits,
+		Options:      controller.MapDiffOptions(diffOpts),
+	}))
+
+	if includePatch && includeWordDiff {
+		return c.wordDiff.Stream(ctx, repo, reader), nil
+	}
+
+	return reader, nil
+}
+
+func (c *Controller) getRepoAndPullReqForDiff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.Repository, *types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	return repo, pr, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LockInput struct {
+	Reason enum.PullReqLockReason `json:"reason"`
+}
+
+func (in *LockInput) Check() error {
+	if in.Reason == "" {
+		return nil
+	}
+
+	reason, ok := in.Reason.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid lock reason: %s", in.Reason)
+	}
+
+	in.Reason = reason
+
+	return nil
+}
+
+// Lock locks the conversation of a pull request, after which only users with push access can comment.
+func (c *Controller) Lock(ctx context.Context,
+	session *auth.Session, repoRef string, pullreqNum int64, in *LockInput,
+) (*types.PullReq, error) {
+	if err := in.Check(); err != nil {
+		return nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.Locked != nil {
+		return pr, nil
+	}
+
+	var reason *enum.PullReqLockReason
+	if in.Reason != "" {
+		reason = &in.Reason
+	}
+
+	lockedBy := session.Principal.ID
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		now := time.Now().UnixMilli()
+		pr.LockedBy = &lockedBy
+		pr.Locked = &now
+		pr.LockReason = reason
+		pr.ActivitySeq++ // because we need to add the activity entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	pr.Locker = session.Principal.ToPrincipalInfo()
+
+	payload := &types.PullRequestActivityPayloadLock{
+		Reason: reason,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after lock")
+	}
+
+	c.eventReporter.Locked(ctx, &pullreqevents.LockedPayload{
+		Base:   eventBase(pr, &session.Principal),
+		Reason: reason,
+	})
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
+
+// Unlock unlocks the conversation of a pull request.
+func (c *Controller) Unlock(ctx context.Context,
+	session *auth.Session, repoRef string, pullreqNum int64,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.Locked == nil {
+		return pr, nil
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.LockedBy = nil
+		pr.Locked = nil
+		pr.LockReason = nil
+		pr.ActivitySeq++ // because we need to add the activity entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	pr.Locker = nil
+
+	payload := &types.PullRequestActivityPayloadUnlock{}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after unlock")
+	}
+
+	c.eventReporter.Unlocked(ctx, &pullreqevents.UnlockedPayload{
+		Base: eventBase(pr, &session.Principal),
+	})
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
+
+// checkCanComment verifies that the principal is allowed to comment on the pull request,
+// on locked conversations only users with push access to the repository can comment,
+// reply, edit comments or change the status of comments.
+func (c *Controller) checkCanComment(ctx context.Context,
+	session *auth.Session, repo *types.Repository, pr *types.PullReq,
+) error {
+	if pr.Locked == nil {
+		return nil
+	}
+
+	err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return usererror.Forbidden("The conversation is locked, only users with push access can comment.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check push access: %w", err)
+	}
+
+	return nil
+}
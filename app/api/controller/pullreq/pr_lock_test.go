@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testReaderID = 2
+	testPusherID = 3
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// fakeAuthorizer grants repo view to everyone and repo push to the pusher only.
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(_ context.Context, session *auth.Session, _ *types.Scope, _ *types.Resource,
+	permission enum.Permission) (bool, error) {
+	switch permission {
+	case enum.PermissionRepoView:
+		return true, nil
+	case enum.PermissionRepoPush:
+		return session.Principal.ID == testPusherID, nil
+	default:
+		return false, nil
+	}
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s *fakePullReqStore) FindByNumber(context.Context, int64, int64) (*types.PullReq, error) {
+	clone := *s.pr
+	return &clone, nil
+}
+
+func (s *fakePullReqStore) Update(_ context.Context, pr *types.PullReq) error {
+	*s.pr = *pr
+	return nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(_ context.Context, pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error) (*types.PullReq, error) {
+	clone := *pr
+	if err := mutateFn(&clone); err != nil {
+		return nil, err
+	}
+	*s.pr = clone
+	return &clone, nil
+}
+
+func (s *fakePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(context.Background(), pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	activities map[int64]*types.PullReqActivity
+}
+
+func (s *fakeActivityStore) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	act, ok := s.activities[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	clone := *act
+	return &clone, nil
+}
+
+func (s *fakeActivityStore) Create(_ context.Context, act *types.PullReqActivity) error {
+	act.ID = int64(len(s.activities) + 100)
+	clone := *act
+	s.activities[act.ID] = &clone
+	return nil
+}
+
+func (s *fakeActivityStore) Update(_ context.Context, act *types.PullReqActivity) error {
+	clone := *act
+	s.activities[act.ID] = &clone
+	return nil
+}
+
+func (s *fakeActivityStore) UpdateOptLock(_ context.Context, act *types.PullReqActivity,
+	mutateFn func(act *types.PullReqActivity) error) (*types.PullReqActivity, error) {
+	clone := *act
+	if err := mutateFn(&clone); err != nil {
+		return nil, err
+	}
+	return &clone, s.Update(context.Background(), &clone)
+}
+
+func (s *fakeActivityStore) CountUnresolved(context.Context, int64) (int, error) {
+	return 0, nil
+}
+
+type fakeStreamProducer struct{}
+
+func (fakeStreamProducer) Send(context.Context, string, map[string]interface{}) (string, error) {
+	return "1-0", nil
+}
+
+type fakeSSEStreamer struct {
+	sse.Streamer
+}
+
+func (fakeSSEStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+// setupLockedPullReq returns a controller with a locked pull request that has a comment of the reader (ID 10)
+// and a comment of the pusher (ID 11).
+func setupLockedPullReq(t *testing.T) (*Controller, *fakeActivityStore) {
+	t.Helper()
+
+	eventsSystem, err := events.NewSystem(func(string, string) (events.StreamConsumer, error) {
+		return nil, nil
+	}, fakeStreamProducer{})
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request reporter: %v", err)
+	}
+
+	locked := int64(1)
+	pr := &types.PullReq{ID: 1, Number: 1, TargetRepoID: 1, Locked: &locked}
+
+	comment := func(id, createdBy int64) *types.PullReqActivity {
+		return &types.PullReqActivity{
+			ID:        id,
+			RepoID:    1,
+			PullReqID: 1,
+			CreatedBy: createdBy,
+			Kind:      enum.PullReqActivityKindComment,
+			Type:      enum.PullReqActivityTypeComment,
+			Text:      "comment",
+		}
+	}
+
+	activityStore := &fakeActivityStore{activities: map[int64]*types.PullReqActivity{
+		10: comment(10, testReaderID),
+		11: comment(11, testPusherID),
+	}}
+
+	return &Controller{
+		tx:            fakeTransactor{},
+		authorizer:    fakeAuthorizer{},
+		repoStore:     fakeRepoStore{repo: &types.Repository{ID: 1, ParentID: 1, Path: "space/repo"}},
+		pullreqStore:  &fakePullReqStore{pr: pr},
+		activityStore: activityStore,
+		eventReporter: eventReporter,
+		sseStreamer:   fakeSSEStreamer{},
+	}, activityStore
+}
+
+func TestLockedPullReqComments(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, c *Controller, session *auth.Session) error
+	}{
+		{
+			name: "comment",
+			run: func(ctx context.Context, c *Controller, session *auth.Session) error {
+				_, err := c.CommentCreate(ctx, session, "space/repo", 1, &CommentCreateInput{Text: "new"})
+				return err
+			},
+		},
+		{
+			name: "reply",
+			run: func(ctx context.Context, c *Controller, session *auth.Session) error {
+				_, err := c.CommentCreate(ctx, session, "space/repo", 1,
+					&CommentCreateInput{Text: "reply", ParentID: 11})
+				return err
+			},
+		},
+		{
+			name: "edit",
+			run: func(ctx context.Context, c *Controller, session *auth.Session) error {
+				// edit the own comment of the session's principal.
+				commentID := int64(10)
+				if session.Principal.ID == testPusherID {
+					commentID = 11
+				}
+				_, err := c.CommentUpdate(ctx, session, "space/repo", 1, commentID,
+					&CommentUpdateInput{Text: "edited"})
+				return err
+			},
+		},
+		{
+			name: "status",
+			run: func(ctx context.Context, c *Controller, session *auth.Session) error {
+				_, err := c.CommentStatus(ctx, session, "space/repo", 1, 10,
+					&CommentStatusInput{Status: enum.PullReqCommentStatusResolved})
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			c, activityStore := setupLockedPullReq(t)
+			before := len(activityStore.activities)
+			reader := &auth.Session{Principal: types.Principal{ID: testReaderID, UID: "reader"}}
+
+			err := test.run(ctx, c, reader)
+			if uErr := (*usererror.Error)(nil); !errors.As(err, &uErr) || uErr.Status != http.StatusForbidden {
+				t.Fatalf("expected reader to be forbidden, got %v", err)
+			}
+			if act := activityStore.activities[10]; act.Text != "comment" || act.Resolved != nil {
+				t.Errorf("expected comment of the reader to be unchanged")
+			}
+			if len(activityStore.activities) != before {
+				t.Errorf("expected no activity to be created for the reader")
+			}
+
+			pusher := &auth.Session{Principal: types.Principal{ID: testPusherID, UID: "pusher"}}
+			if err = test.run(ctx, c, pusher); err != nil {
+				t.Fatalf("expected pusher to be allowed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUnlockedPullReqCommentByReader(t *testing.T) {
+	c, activityStore := setupLockedPullReq(t)
+	c.pullreqStore.(*fakePullReqStore).pr.Locked = nil
+
+	reader := &auth.Session{Principal: types.Principal{ID: testReaderID, UID: "reader"}}
+	act, err := c.CommentCreate(context.Background(), reader, "space/repo", 1, &CommentCreateInput{Text: "new"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := activityStore.activities[act.ID]; !ok {
+		t.Errorf("expected comment to be created")
+	}
+}
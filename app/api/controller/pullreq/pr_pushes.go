@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ListPushes returns all recorded pushes to the source branch of the pull request.
+func (c *Controller) ListPushes(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]*types.PullReqPush, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	pushes, err := c.pushStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request pushes: %w", err)
+	}
+
+	return pushes, nil
+}
+
+// PushDiff streams the changes of the source branch of the pull request between two of its pushes.
+// If not provided, the diff starts from the push last reviewed by the current user and ends with the latest push.
+// If the merge base changed between the two pushes (the branch was rebased or the target branch merged in),
+// the diff is limited to the files changed by the pull request, to hide changes brought in from the target branch.
+func (c *Controller) PushDiff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	fromPush int64,
+	toPush int64,
+	includePatch bool,
+	filePaths []string,
+	diffOpts types.DiffOptions,
+) (types.Stream[*gitrpc.FileDiff], error) {
+	repo, pr, err := c.getRepoAndPullReqForDiff(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := c.findFromPush(ctx, session, pr, fromPush)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *types.PullReqPush
+	if toPush > 0 {
+		to, err = c.pushStore.Find(ctx, pr.ID, toPush)
+	} else {
+		to, err = c.pushStore.FindLatest(ctx, pr.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the end push: %w", err)
+	}
+
+	if len(filePaths) == 0 && from.MergeBaseSHA != to.MergeBaseSHA {
+		filePaths, err = c.pushChangedFiles(ctx, repo, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(filePaths) == 0 {
+			chData := make(chan *gitrpc.FileDiff)
+			chErr := make(chan error)
+			close(chData)
+			close(chErr)
+			return gitrpc.NewStreamReader(chData, chErr), nil
+		}
+	}
+
+	limits, err := c.instanceSettings.DiffLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff limits: %w", err)
+	}
+
+	return gitrpc.NewStreamReader(c.gitRPCClient.Diff(ctx, &gitrpc.DiffParams{
+		ReadParams:   gitrpc.CreateRPCReadParams(repo),
+		BaseRef:      from.SourceSHA,
+		HeadRef:      to.SourceSHA,
+		IncludePatch: includePatch,
+		Paths:        filePaths,
+		Limits:       limits,
+		Options:      controller.MapDiffOptions(diffOpts),
+	})), nil
+}
+
+// findFromPush returns the push with the provided number,
+// or the push that was last reviewed by the principal if the number isn't provided.
+func (c *Controller) findFromPush(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	fromPush int64,
+) (*types.PullReqPush, error) {
+	if fromPush > 0 {
+		push, err := c.pushStore.Find(ctx, pr.ID, fromPush)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the start push: %w", err)
+		}
+
+		return push, nil
+	}
+
+	reviewer, err := c.reviewerStore.Find(ctx, pr.ID, session.Principal.ID)
+	if errors.Is(err, store.ErrResourceNotFound) || (err == nil && reviewer.SHA == "") {
+		return nil, usererror.BadRequest("The start push must be provided if the pull request wasn't reviewed yet")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request reviewer: %w", err)
+	}
+
+	push, err := c.pushStore.FindBySourceSHA(ctx, pr.ID, reviewer.SHA)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("The last reviewed commit doesn't match any push, the start push must be provided")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the last reviewed push: %w", err)
+	}
+
+	return push, nil
+}
+
+// pushChangedFiles returns the paths of all files changed by the pull request in either of the two pushes.
+func (c *Controller) pushChangedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	pushes ...*types.PullReqPush,
+) ([]string, error) {
+	paths := make([]string, 0)
+	seen := make(map[string]struct{})
+	add := func(path string) {
+		if _, ok := seen[path]; ok || path == "" {
+			return
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	for _, push := range pushes {
+		out, err := c.gitRPCClient.GetDiffHunkHeaders(ctx, gitrpc.GetDiffHunkHeadersParams{
+			ReadParams:      gitrpc.CreateRPCReadParams(repo),
+			SourceCommitSHA: push.MergeBaseSHA,
+			TargetCommitSHA: push.SourceSHA,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get files changed in push %d: %w", push.Number, err)
+		}
+
+		for _, file := range out.Files {
+			add(file.FileHeader.OldName)
+			add(file.FileHeader.NewName)
+		}
+	}
+
+	return paths, nil
+}
+
+// recordPush records the current state of the source branch of the pull request as a new push.
+func (c *Controller) recordPush(ctx context.Context, pr *types.PullReq, principalID int64, forced bool) {
+	err := c.pushStore.Create(ctx, &types.PullReqPush{
+		PullReqID:    pr.ID,
+		SourceSHA:    pr.SourceSHA,
+		MergeBaseSHA: pr.MergeBaseSHA,
+		Forced:       forced,
+		CreatedBy:    principalID,
+		Created:      time.Now().UnixMilli(),
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to record pull request push")
+	}
+}
+
+// isAncestor returns true if the commit sha1 is an ancestor of the commit sha2.
+func (c *Controller) isAncestor(ctx context.Context, repo *types.Repository, sha1, sha2 string) bool {
+	out, err := c.gitRPCClient.MergeBase(ctx, gitrpc.MergeBaseParams{
+		ReadParams: gitrpc.CreateRPCReadParams(repo),
+		Ref1:       sha1,
+		Ref2:       sha2,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find merge base of %s and %s", sha1, sha2)
+		return false
+	}
+
+	return out.MergeBaseSHA == sha1
+}
@@ -99,6 +99,7 @@ func (c *Controller) State(ctx context.Context,
 
 	oldState := pr.State
 	oldDraft := pr.IsDraft
+	oldSourceSHA := pr.SourceSHA
 
 	type change int
 	const (
@@ -166,6 +167,10 @@ func (c *Controller) State(ctx context.Context,
 		return nil, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
+	if stateChange == changeReopen && pr.SourceSHA != oldSourceSHA {
+		c.recordPush(ctx, pr, session.Principal.ID, !c.isAncestor(ctx, sourceRepo, oldSourceSHA, pr.SourceSHA))
+	}
+
 	payload := &types.PullRequestActivityPayloadStateChange{
 		Old:      oldState,
 		New:      pr.State,
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateTaskInput struct {
+	Checked bool `json:"checked"`
+}
+
+// ListTasks returns the items of the task lists in the description of a pull request.
+func (c *Controller) ListTasks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]markdown.Task, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	tasks := markdown.ParseTasks([]byte(pr.Description))
+	if tasks == nil {
+		tasks = []markdown.Task{}
+	}
+
+	return tasks, nil
+}
+
+// UpdateTask checks or unchecks an item of the task lists in the description of a pull request,
+// without the caller having to edit the description itself.
+func (c *Controller) UpdateTask(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	taskIndex int,
+	in *UpdateTaskInput,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	var (
+		task    markdown.Task
+		changed bool
+	)
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		description, toggled, errSet := markdown.SetTaskChecked([]byte(pr.Description), taskIndex, in.Checked)
+		if errors.Is(errSet, markdown.ErrTaskNotFound) {
+			return usererror.NotFound(
+				fmt.Sprintf("The pull request description doesn't have a task with index %d.", taskIndex))
+		}
+		if errSet != nil {
+			return errSet
+		}
+
+		task = toggled
+
+		changed = string(description) != pr.Description
+		if !changed {
+			return nil
+		}
+
+		pr.Description = string(description)
+		setTaskCounts(pr)
+		pr.Edited = time.Now().UnixMilli()
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request task: %w", err)
+	}
+
+	if !changed {
+		return pr, nil
+	}
+
+	payload := &types.PullRequestActivityPayloadTaskToggle{
+		Index:   task.Index,
+		Text:    task.Text,
+		Checked: task.Checked,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after task toggle")
+	}
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
+
+// setTaskCounts updates the number of total and completed tasks of the pull request from its description.
+func setTaskCounts(pr *types.PullReq) {
+	pr.TaskCount, pr.TaskCompletedCount = markdown.CountTasks([]byte(pr.Description))
+	pr.Stats.Tasks = pr.TaskCount
+	pr.Stats.TasksCompleted = pr.TaskCompletedCount
+}
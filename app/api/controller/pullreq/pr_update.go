@@ -84,11 +84,13 @@ func (c *Controller) Update(ctx context.Context,
 	}
 
 	needToWriteActivity := in.Title != pr.Title
+	descriptionChanged := in.Description != pr.Description
 	oldTitle := pr.Title
 
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.Title = in.Title
 		pr.Description = in.Description
+		setTaskCounts(pr)
 		pr.Edited = time.Now().UnixMilli()
 		if needToWriteActivity {
 			pr.ActivitySeq++
@@ -110,6 +112,10 @@ func (c *Controller) Update(ctx context.Context,
 		}
 	}
 
+	if descriptionChanged {
+		c.subscribeMentioned(ctx, &session.Principal, targetRepo, pr, pr.Description)
+	}
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
 	}
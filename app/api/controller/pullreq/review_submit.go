@@ -23,6 +23,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/gitrpc"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -115,7 +116,11 @@ func (c *Controller) ReviewSubmit(
 		}
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA)
-		return err
+		if err != nil {
+			return err
+		}
+
+		return c.updateGroupReviewers(ctx, session, pr, review, commitSHA)
 	})
 	if err != nil {
 		return nil, err
@@ -139,6 +144,14 @@ func (c *Controller) ReviewSubmit(
 		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after review submit")
 	}
 
+	c.eventReporter.ReviewSubmitted(ctx, &pullreqevents.ReviewSubmittedPayload{
+		Base:      eventBase(pr, &session.Principal),
+		ReviewID:  review.ID,
+		Decision:  review.Decision,
+		CommitSHA: commitSHA,
+		Message:   in.Message,
+	})
+
 	return review, nil
 }
 
@@ -179,3 +192,33 @@ func (c *Controller) updateReviewer(ctx context.Context, session *auth.Session,
 
 	return reviewer, nil
 }
+
+// updateGroupReviewers updates the reviewer entries of all user groups the principal is a member of.
+// A review requested from a user group is fulfilled by the latest review of any of its users.
+func (c *Controller) updateGroupReviewers(ctx context.Context, session *auth.Session,
+	pr *types.PullReq, review *types.PullReqReview, sha string) error {
+	groupIDs, err := c.userGroupStore.ListGroupIDs(ctx, session.Principal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list user groups of the reviewer: %w", err)
+	}
+
+	for _, groupID := range groupIDs {
+		reviewer, err := c.reviewerStore.Find(ctx, pr.ID, groupID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find user group reviewer: %w", err)
+		}
+
+		reviewer.LatestReviewID = &review.ID
+		reviewer.ReviewDecision = review.Decision
+		reviewer.SHA = sha
+
+		if err = c.reviewerStore.Update(ctx, reviewer); err != nil {
+			return fmt.Errorf("failed to update user group reviewer: %w", err)
+		}
+	}
+
+	return nil
+}
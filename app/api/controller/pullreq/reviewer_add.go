@@ -23,6 +23,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -31,10 +32,12 @@ import (
 )
 
 type ReviewerAddInput struct {
+	// ReviewerID is the ID of the user or the user group requested to review the pull request.
 	ReviewerID int64 `json:"reviewer_id"`
 }
 
 // ReviewerAdd adds a new reviewer to the pull request.
+// The reviewer can be a user group, in which case a review of any of its users fulfills the request.
 func (c *Controller) ReviewerAdd(
 	ctx context.Context,
 	session *auth.Session,
@@ -93,6 +96,7 @@ func (c *Controller) ReviewerAdd(
 	}
 
 	var reviewer *types.PullReqReviewer
+	var created bool
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		reviewer, err = c.reviewerStore.Find(ctx, pr.ID, in.ReviewerID)
@@ -105,6 +109,7 @@ func (c *Controller) ReviewerAdd(
 		}
 
 		reviewer = newPullReqReviewer(session, pr, repo, reviewerInfo, addedByInfo, reviewerType, in)
+		created = true
 
 		return c.reviewerStore.Create(ctx, reviewer)
 	})
@@ -112,6 +117,13 @@ func (c *Controller) ReviewerAdd(
 		return nil, fmt.Errorf("failed to create pull request reviewer: %w", err)
 	}
 
+	if created {
+		c.eventReporter.ReviewerAdded(ctx, &pullreqevents.ReviewerAddedPayload{
+			Base:       eventBase(pr, &session.Principal),
+			ReviewerID: reviewer.PrincipalID,
+		})
+	}
+
 	return reviewer, err
 }
 
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// sourceBranchRestoreWindow is the time after the deletion of the source branch during which it can be restored.
+const sourceBranchRestoreWindow = 24 * time.Hour
+
+// deleteSourceBranchAfterMerge deletes the source branch of a just merged pull request,
+// if enabled by the merge input or the repository settings. The branch is only deleted when it's safe,
+// i.e. it still points to the merged commit and no other open pull request uses it.
+// Failures are only logged, as the pull request is already merged.
+func (c *Controller) deleteSourceBranchAfterMerge(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	sourceSHA string,
+	deleteSourceBranch *bool,
+) bool {
+	if deleteSourceBranch == nil {
+		enabled, err := c.settings.RepoDeleteSourceBranch(ctx, targetRepo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to get delete source branch setting")
+			return false
+		}
+		deleteSourceBranch = &enabled
+	}
+
+	if !*deleteSourceBranch || pr.SourceBranch == sourceRepo.DefaultBranch {
+		return false
+	}
+
+	if sourceRepo.ID != targetRepo.ID {
+		err := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("no permission to delete source branch after merge")
+			return false
+		}
+	}
+
+	openCount, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: sourceRepo.ID,
+		SourceBranch: pr.SourceBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to count open pull requests of source branch")
+		return false
+	}
+	if openCount > 0 {
+		return false
+	}
+
+	// don't delete the branch in case it got updated since the merge.
+	ref, err := c.gitRPCClient.GetRef(ctx, gitrpc.GetRefParams{
+		ReadParams: gitrpc.ReadParams{RepoUID: sourceRepo.GitUID},
+		Name:       pr.SourceBranch,
+		Type:       gitrpcenum.RefTypeBranch,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get source branch after merge")
+		return false
+	}
+	if ref.SHA != sourceSHA {
+		return false
+	}
+
+	writeParams, err := controller.CreateRPCWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params")
+		return false
+	}
+
+	err = c.gitRPCClient.DeleteBranch(ctx, &gitrpc.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  pr.SourceBranch,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete source branch after merge")
+		return false
+	}
+
+	c.writeSourceBranchActivity(ctx, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadBranchDelete{SHA: sourceSHA})
+
+	return true
+}
+
+// RestoreSourceBranch restores the source branch of a merged pull request
+// that got deleted after the merge, as long as it's within the restore window.
+func (c *Controller) RestoreSourceBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged {
+		return nil, usererror.BadRequest("Only the source branch of a merged pull request can be restored.")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false); err != nil {
+		return nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	activities, err := c.activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{
+		After: time.Now().Add(-sourceBranchRestoreWindow).UnixMilli(),
+		Types: []enum.PullReqActivityType{
+			enum.PullReqActivityTypeBranchDelete,
+			enum.PullReqActivityTypeBranchRestore,
+		},
+		Kinds: []enum.PullReqActivityKind{enum.PullReqActivityKindSystem},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch activities of pull request: %w", err)
+	}
+
+	if len(activities) == 0 || activities[len(activities)-1].Type != enum.PullReqActivityTypeBranchDelete {
+		return nil, usererror.BadRequestf(
+			"The source branch can only be restored within %s after it got deleted.", sourceBranchRestoreWindow)
+	}
+
+	payload, err := activities[len(activities)-1].GetPayload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch delete activity payload: %w", err)
+	}
+
+	deletePayload, ok := payload.(*types.PullRequestActivityPayloadBranchDelete)
+	if !ok {
+		return nil, fmt.Errorf("unexpected branch delete activity payload type %T", payload)
+	}
+
+	writeParams, err := controller.CreateRPCWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	_, err = c.gitRPCClient.CreateBranch(ctx, &gitrpc.CreateBranchParams{
+		WriteParams: writeParams,
+		BranchName:  pr.SourceBranch,
+		Target:      deletePayload.SHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pr = c.writeSourceBranchActivity(ctx, pr, session.Principal.ID,
+		&types.PullRequestActivityPayloadBranchRestore{SHA: deletePayload.SHA})
+
+	return pr, nil
+}
+
+// writeSourceBranchActivity writes a system activity about the source branch of the pull request.
+// Failures are only logged, as the branch operation has already been performed.
+func (c *Controller) writeSourceBranchActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) *types.PullReq {
+	updatedPR, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++ // because we need to write the activity entry
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request activity sequence")
+		return pr
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, updatedPR, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request '%s' activity", payload.ActivityType())
+	}
+
+	return updatedPR
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Subscribe subscribes the current user to the pull request,
+// so the user gets notified about the future activity of the pull request.
+func (c *Controller) Subscribe(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.PullReqSubscriber, error) {
+	return c.setSubscription(ctx, session, repoRef, prNum, true)
+}
+
+// Unsubscribe unsubscribes the current user from the pull request.
+// The user won't be notified about the future activity of the pull request,
+// even if mentioned in a comment, until subscribed again.
+func (c *Controller) Unsubscribe(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) error {
+	_, err := c.setSubscription(ctx, session, repoRef, prNum, false)
+	return err
+}
+
+func (c *Controller) setSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	subscribed bool,
+) (*types.PullReqSubscriber, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	subscriber := &types.PullReqSubscriber{
+		PullReqID:   pr.ID,
+		PrincipalID: session.Principal.ID,
+		Subscribed:  subscribed,
+		Created:     now,
+		Updated:     now,
+		Principal:   *session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.subscriberStore.Upsert(ctx, subscriber); err != nil {
+		return nil, fmt.Errorf("failed to update pull request subscription: %w", err)
+	}
+
+	return subscriber, nil
+}
@@ -17,8 +17,14 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/authorship"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/mergecheck"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -38,16 +44,23 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	pullReqStore store.PullReqStore, pullReqActivityStore store.PullReqActivityStore,
 	codeCommentsView store.CodeCommentView,
 	pullReqReviewStore store.PullReqReviewStore, pullReqReviewerStore store.PullReqReviewerStore,
+	pullReqSubscriberStore store.PullReqSubscriberStore, userGroupStore store.UserGroupMemberStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, fileViewStore store.PullReqFileViewStore,
+	pushStore store.PullReqPushStore,
+	checkStore store.CheckStore, reqCheckStore store.ReqCheckStore,
 	rpcClient gitrpc.Interface, eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
-	pullreqService *pullreq.Service, sseStreamer sse.Streamer,
+	pullreqService *pullreq.Service, sseStreamer sse.Streamer, settings *settings.Service,
+	mergeChecks *mergecheck.Service, codeOwners *codeowners.Service, authorship *authorship.Service,
+	wordDiff *worddiff.Service, instanceSettings *instancesettings.Service,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
 		codeCommentsView,
-		pullReqReviewStore, pullReqReviewerStore,
-		repoStore, principalStore, fileViewStore,
+		pullReqReviewStore, pullReqReviewerStore, pullReqSubscriberStore, userGroupStore,
+		repoStore, principalStore, fileViewStore, pushStore,
+		checkStore, reqCheckStore,
 		rpcClient, eventReporter,
-		mtxManager, codeCommentMigrator, pullreqService, sseStreamer)
+		mtxManager, codeCommentMigrator, pullreqService, sseStreamer, settings,
+		mergeChecks, codeOwners, authorship, wordDiff, instanceSettings)
 }
@@ -22,7 +22,9 @@ import (
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -42,6 +44,8 @@ type CommitFilesOptions struct {
 	Branch    string             `json:"branch"`
 	NewBranch string             `json:"new_branch"`
 	Actions   []CommitFileAction `json:"actions"`
+	// Author is the requested author of the commit (defaults to the acting principal).
+	Author *types.Identity `json:"author"`
 }
 
 // CommitFilesResponse holds commit id.
@@ -75,6 +79,20 @@ func (c *Controller) CommitFiles(ctx context.Context,
 			rawPayload = []byte(action.Payload)
 		}
 
+		// uploaded content is scanned for viruses before it's committed
+		if action.Action != gitrpc.DeleteAction && len(rawPayload) > 0 {
+			err = c.uploadScan.Check(ctx, uploadscan.Upload{
+				Source:     enum.UploadSourceWeb,
+				RepoID:     repo.ID,
+				Name:       action.Path,
+				Content:    rawPayload,
+				UploadedBy: session.Principal.ID,
+			})
+			if err != nil {
+				return CommitFilesResponse{}, err
+			}
+		}
+
 		actions[i] = gitrpc.CommitFileAction{
 			Action:  action.Action,
 			Path:    action.Path,
@@ -88,6 +106,12 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return CommitFilesResponse{}, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	author, err := c.authorship.Resolve(ctx, repo, &session.Principal,
+		enum.CommitAuthorOperationCommitFiles, in.Author)
+	if err != nil {
+		return CommitFilesResponse{}, err
+	}
+
 	now := time.Now()
 	commit, err := c.gitRPCClient.CommitFiles(ctx, &gitrpc.CommitFilesParams{
 		WriteParams:   writeParams,
@@ -98,12 +122,15 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		Actions:       actions,
 		Committer:     rpcIdentityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		CommitterDate: &now,
-		Author:        rpcIdentityFromPrincipal(session.Principal),
+		Author:        author.Identity,
 		AuthorDate:    &now,
 	})
 	if err != nil {
 		return CommitFilesResponse{}, err
 	}
+
+	c.authorship.Record(ctx, author, commit.CommitID)
+
 	return CommitFilesResponse{
 		CommitID: commit.CommitID,
 	}, nil
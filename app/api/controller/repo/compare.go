@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// compareCommitsMax is the maximum number of commits returned by the compare API.
+	compareCommitsMax = 250
+
+	// forkNetworkMaxDepth limits the number of parent lookups done when resolving the root of a fork network.
+	forkNetworkMaxDepth = 32
+)
+
+// CompareOutput contains the result of comparing two refs, potentially across repositories of a fork network.
+type CompareOutput struct {
+	BaseRepoPath string `json:"base_repo_path"`
+	HeadRepoPath string `json:"head_repo_path"`
+	BaseRef      string `json:"base_ref"`
+	HeadRef      string `json:"head_ref"`
+	BaseSHA      string `json:"base_sha"`
+	HeadSHA      string `json:"head_sha"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+
+	// Ahead is the number of commits the head ref is ahead of the base ref.
+	Ahead int32 `json:"ahead"`
+	// Behind is the number of commits the head ref is behind the base ref.
+	Behind int32 `json:"behind"`
+
+	// Commits contains (up to compareCommitsMax of) the commits the head ref is ahead of the base ref.
+	Commits   []types.Commit  `json:"commits"`
+	DiffStats types.DiffStats `json:"diff_stats"`
+
+	// Mergeable is only set if the head ref is ahead of the base ref and the caller is authenticated.
+	Mergeable     *bool    `json:"mergeable,omitempty"`
+	ConflictFiles []string `json:"conflict_files,omitempty"`
+}
+
+// Compare compares two refs of a repository. The head ref can be located in a different
+// repository (headRepoRef) as long as both repositories are part of the same fork network.
+//
+//nolint:gocognit,funlen // no need to refactor
+func (c *Controller) Compare(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	headRepoRef string,
+	diffPath string,
+) (*CompareOutput, error) {
+	baseRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	headRepo := baseRepo
+	if headRepoRef != "" {
+		headRepo, err = c.getRepoCheckAccess(ctx, session, headRepoRef, enum.PermissionRepoView, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if headRepo.ID != baseRepo.ID {
+		var sameNetwork bool
+		sameNetwork, err = c.inSameForkNetwork(ctx, baseRepo, headRepo)
+		if err != nil {
+			return nil, err
+		}
+		if !sameNetwork {
+			return nil, usererror.BadRequest("The repositories aren't part of the same fork network.")
+		}
+	}
+
+	info, err := parseDiffPath(diffPath)
+	if err != nil {
+		return nil, err
+	}
+
+	readParams := CreateRPCReadParams(baseRepo)
+
+	baseOut, err := c.gitRPCClient.FetchCommit(ctx, &gitrpc.FetchCommitParams{
+		ReadParams:    readParams,
+		SourceRepoUID: baseRepo.GitUID,
+		Ref:           info.BaseRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base ref: %w", err)
+	}
+
+	// makes the head commit available in the base repo (no-op if it's the same repo).
+	headOut, err := c.gitRPCClient.FetchCommit(ctx, &gitrpc.FetchCommitParams{
+		ReadParams:    readParams,
+		SourceRepoUID: headRepo.GitUID,
+		Ref:           info.HeadRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve head ref: %w", err)
+	}
+
+	out := &CompareOutput{
+		BaseRepoPath: baseRepo.Path,
+		HeadRepoPath: headRepo.Path,
+		BaseRef:      info.BaseRef,
+		HeadRef:      info.HeadRef,
+		BaseSHA:      baseOut.SHA,
+		HeadSHA:      headOut.SHA,
+		Commits:      []types.Commit{},
+	}
+
+	mergeBaseOut, err := c.gitRPCClient.MergeBase(ctx, gitrpc.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       out.BaseSHA,
+		Ref2:       out.HeadSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	out.MergeBaseSHA = mergeBaseOut.MergeBaseSHA
+
+	divergencesOut, err := c.gitRPCClient.GetCommitDivergences(ctx, &gitrpc.GetCommitDivergencesParams{
+		ReadParams: readParams,
+		Requests:   []gitrpc.CommitDivergenceRequest{{From: out.HeadSHA, To: out.BaseSHA}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit divergence: %w", err)
+	}
+	if len(divergencesOut.Divergences) > 0 {
+		out.Ahead = divergencesOut.Divergences[0].Ahead
+		out.Behind = divergencesOut.Divergences[0].Behind
+	}
+
+	statsOut, err := c.gitRPCClient.DiffStats(ctx, &gitrpc.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    out.BaseSHA,
+		HeadRef:    out.HeadSHA,
+		MergeBase:  info.MergeBase,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+	out.DiffStats = types.DiffStats{
+		Commits:      statsOut.Commits,
+		FilesChanged: statsOut.FilesChanged,
+	}
+
+	if out.Ahead == 0 {
+		return out, nil
+	}
+
+	commitsOut, err := c.gitRPCClient.ListCommits(ctx, &gitrpc.ListCommitsParams{
+		ReadParams: readParams,
+		GitREF:     out.HeadSHA,
+		After:      out.BaseSHA,
+		Limit:      compareCommitsMax,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	verifications, err := c.publicKeys.Verify(ctx, headRepo.ID, commitsOut.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	out.Commits = make([]types.Commit, len(commitsOut.Commits))
+	for i := range commitsOut.Commits {
+		var commit *types.Commit
+		commit, err = controller.MapCommit(&commitsOut.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commit.Verification = verifications[commit.SHA]
+		out.Commits[i] = *commit
+	}
+
+	// the merge check requires an actor, so it's skipped for anonymous requests.
+	if session == nil {
+		return out, nil
+	}
+
+	writeParams, err := CreateRPCWriteParams(ctx, c.urlProvider, session, baseRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc write params: %w", err)
+	}
+
+	mergeable := true
+	_, err = c.gitRPCClient.Merge(ctx, &gitrpc.MergeParams{
+		WriteParams: writeParams,
+		BaseBranch:  out.BaseSHA,
+		HeadRepoUID: headRepo.GitUID,
+		HeadBranch:  out.HeadSHA,
+	})
+	if gitrpc.ErrorStatus(err) == gitrpc.StatusNotMergeable {
+		mergeable = false
+		out.ConflictFiles = gitrpc.AsConflictFilesError(err)
+	} else if err != nil {
+		return nil, fmt.Errorf("merge check execution failed: %w", err)
+	}
+	out.Mergeable = &mergeable
+
+	return out, nil
+}
+
+// inSameForkNetwork returns true in case both repositories share the same root of the fork network.
+func (c *Controller) inSameForkNetwork(ctx context.Context, repo1, repo2 *types.Repository) (bool, error) {
+	root1, err := c.findForkNetworkRoot(ctx, repo1)
+	if err != nil {
+		return false, err
+	}
+
+	root2, err := c.findForkNetworkRoot(ctx, repo2)
+	if err != nil {
+		return false, err
+	}
+
+	return root1 == root2, nil
+}
+
+// findForkNetworkRoot returns the id of the repository at the root of the fork network of the repository.
+func (c *Controller) findForkNetworkRoot(ctx context.Context, repo *types.Repository) (int64, error) {
+	current := repo
+	for i := 0; current.ForkID != 0; i++ {
+		if i >= forkNetworkMaxDepth {
+			return 0, fmt.Errorf("fork network of repo %d exceeds max depth of %d", repo.ID, forkNetworkMaxDepth)
+		}
+
+		parent, err := c.repoStore.Find(ctx, current.ForkID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the parent was deleted, the network ends here.
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to find parent repo %d: %w", current.ForkID, err)
+		}
+
+		current = parent
+	}
+
+	return current.ID, nil
+}
@@ -25,7 +25,15 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/authorship"
+	"github.com/harness/gitness/app/services/filerender"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/uploadscan"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/gitrpc"
@@ -37,6 +45,7 @@ import (
 
 type Controller struct {
 	defaultBranch  string
+	sha256Enabled  bool
 	tx             dbtx.Transactor
 	urlProvider    url.Provider
 	uidCheck       check.PathUID
@@ -45,12 +54,28 @@ type Controller struct {
 	spaceStore     store.SpaceStore
 	pipelineStore  store.PipelineStore
 	principalStore store.PrincipalStore
+	pullReqStore   store.PullReqStore
+	renameStore    store.BranchRenameStore
 	gitRPCClient   gitrpc.Interface
 	importer       *importer.Repository
+	quotaEnforcer  *quota.Enforcer
+	settings       *settings.Service
+	publicKeys     *publickey.Service
+	authorship     *authorship.Service
+	topicStore     store.RepoTopicStore
+	starStore      store.RepoStarStore
+	pushedBranches store.PushedBranchCache
+	refWatchStore  store.RefWatchStore
+	uploadScan     *uploadscan.Service
+	fileRender     *filerender.Service
+	wordDiff       *worddiff.Service
+
+	instanceSettings *instancesettings.Service
 }
 
 func NewController(
 	defaultBranch string,
+	sha256Enabled bool,
 	tx dbtx.Transactor,
 	urlProvider url.Provider,
 	uidCheck check.PathUID,
@@ -59,21 +84,50 @@ func NewController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
+	pullReqStore store.PullReqStore,
+	renameStore store.BranchRenameStore,
 	gitRPCClient gitrpc.Interface,
 	importer *importer.Repository,
+	quotaEnforcer *quota.Enforcer,
+	settings *settings.Service,
+	publicKeys *publickey.Service,
+	authorship *authorship.Service,
+	topicStore store.RepoTopicStore,
+	starStore store.RepoStarStore,
+	pushedBranches store.PushedBranchCache,
+	refWatchStore store.RefWatchStore,
+	uploadScan *uploadscan.Service,
+	fileRender *filerender.Service,
+	wordDiff *worddiff.Service,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
-		defaultBranch:  defaultBranch,
-		tx:             tx,
-		urlProvider:    urlProvider,
-		uidCheck:       uidCheck,
-		authorizer:     authorizer,
-		repoStore:      repoStore,
-		spaceStore:     spaceStore,
-		pipelineStore:  pipelineStore,
-		principalStore: principalStore,
-		gitRPCClient:   gitRPCClient,
-		importer:       importer,
+		defaultBranch:    defaultBranch,
+		sha256Enabled:    sha256Enabled,
+		tx:               tx,
+		urlProvider:      urlProvider,
+		uidCheck:         uidCheck,
+		authorizer:       authorizer,
+		repoStore:        repoStore,
+		spaceStore:       spaceStore,
+		pipelineStore:    pipelineStore,
+		principalStore:   principalStore,
+		pullReqStore:     pullReqStore,
+		renameStore:      renameStore,
+		gitRPCClient:     gitRPCClient,
+		importer:         importer,
+		quotaEnforcer:    quotaEnforcer,
+		settings:         settings,
+		publicKeys:       publicKeys,
+		authorship:       authorship,
+		topicStore:       topicStore,
+		starStore:        starStore,
+		pushedBranches:   pushedBranches,
+		refWatchStore:    refWatchStore,
+		uploadScan:       uploadScan,
+		fileRender:       fileRender,
+		wordDiff:         wordDiff,
+		instanceSettings: instanceSettings,
 	}
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/gitrpc"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
 	"github.com/harness/gitness/resources"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -42,15 +43,16 @@ var (
 )
 
 type CreateInput struct {
-	ParentRef     string `json:"parent_ref"`
-	UID           string `json:"uid"`
-	DefaultBranch string `json:"default_branch"`
-	Description   string `json:"description"`
-	IsPublic      bool   `json:"is_public"`
-	ForkID        int64  `json:"fork_id"`
-	Readme        bool   `json:"readme"`
-	License       string `json:"license"`
-	GitIgnore     string `json:"git_ignore"`
+	ParentRef     string               `json:"parent_ref"`
+	UID           string               `json:"uid"`
+	DefaultBranch string               `json:"default_branch"`
+	Description   string               `json:"description"`
+	IsPublic      *bool                `json:"is_public"`
+	ForkID        int64                `json:"fork_id"`
+	Readme        bool                 `json:"readme"`
+	License       string               `json:"license"`
+	GitIgnore     string               `json:"git_ignore"`
+	ObjectFormat  enum.GitObjectFormat `json:"object_format"`
 }
 
 // Create creates a new repository.
@@ -60,10 +62,26 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
+	if in.DefaultBranch == "" {
+		in.DefaultBranch, err = c.settings.DefaultBranch(ctx, parentSpace.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default branch of space: %w", err)
+		}
+	}
+
 	if err := c.sanitizeCreateInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	if err = c.quotaEnforcer.CheckRepoCreation(ctx, parentSpace.ID, 1); err != nil {
+		return nil, err
+	}
+
+	isPublic, err := c.instanceSettings.DefaultPublic(ctx, in.IsPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default visibility: %w", err)
+	}
+
 	gitRPCResp, err := c.createGitRPCRepository(ctx, session, in)
 	if err != nil {
 		return nil, fmt.Errorf("error creating repository on GitRPC: %w", err)
@@ -76,7 +94,7 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		UID:           in.UID,
 		GitUID:        gitRPCResp.UID,
 		Description:   in.Description,
-		IsPublic:      in.IsPublic,
+		IsPublic:      isPublic,
 		CreatedBy:     session.Principal.ID,
 		Created:       now,
 		Updated:       now,
@@ -91,6 +109,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, fmt.Errorf("failed to create repository in storage: %w", err)
 	}
 
+	c.settings.ApplyRepoDefaults(ctx, session, repo)
+
 	// backfil GitURL
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 
@@ -140,6 +160,15 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		in.DefaultBranch = c.defaultBranch
 	}
 
+	objectFormat, ok := in.ObjectFormat.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Object format '%s' is not supported.", in.ObjectFormat)
+	}
+	if objectFormat == enum.GitObjectFormatSHA256 && !c.sha256Enabled {
+		return usererror.BadRequest("Repositories using the sha256 object format are not enabled.")
+	}
+	in.ObjectFormat = objectFormat
+
 	return nil
 }
 
@@ -202,6 +231,7 @@ func (c *Controller) createGitRPCRepository(ctx context.Context, session *auth.S
 		AuthorDate:    &now,
 		Committer:     committer,
 		CommitterDate: &now,
+		ObjectFormat:  gitrpcenum.ObjectFormat(in.ObjectFormat),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repo on gitrpc: %w", err)
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// CheckCreateOnPush verifies that a push to the non-existent repository path can create the repository.
+// Anonymous sessions get ErrNotAuthenticated before the parent space is looked up, and all other failures
+// (missing space, disabled push to create setting, missing permission) result in ErrResourceNotFound,
+// to not disclose which spaces exist.
+func (c *Controller) CheckCreateOnPush(
+	ctx context.Context,
+	session *auth.Session,
+	repoPath string,
+) (*types.Space, string, error) {
+	if session == nil {
+		return nil, "", apiauth.ErrNotAuthenticated
+	}
+
+	parentRef, uid, err := paths.DisectLeaf(repoPath)
+	if err != nil || parentRef == "" {
+		return nil, "", gitness_store.ErrResourceNotFound
+	}
+
+	space, err := c.getSpaceCheckAuthRepoCreation(ctx, session, parentRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || errors.Is(err, apiauth.ErrNotAuthorized) {
+		return nil, "", gitness_store.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	pushToCreate, err := c.settings.PushToCreate(ctx, space.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get push to create setting: %w", err)
+	}
+	if !pushToCreate {
+		return nil, "", gitness_store.ErrResourceNotFound
+	}
+
+	return space, uid, nil
+}
+
+// CreateOnPush creates an empty repository for a push to a non-existent repository path.
+// It's expected to be called only once the pushed data is received, and the caller has to
+// call PurgeEmptyOnPush once the push completed to not leave behind repositories of failed pushes.
+func (c *Controller) CreateOnPush(
+	ctx context.Context,
+	session *auth.Session,
+	repoPath string,
+) (*types.Repository, error) {
+	space, uid, err := c.CheckCreateOnPush(ctx, session, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository is created without any files so the pushed history can be accepted as is.
+	return c.Create(ctx, session, &CreateInput{
+		ParentRef: space.Path,
+		UID:       uid,
+	})
+}
+
+// PurgeEmptyOnPush purges a repository created on push in case the push failed or none of the
+// pushed references got accepted (e.g. rejected by a pre-receive hook).
+// It returns true in case the repository was purged.
+func (c *Controller) PurgeEmptyOnPush(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pushErr error,
+) (bool, error) {
+	if pushErr == nil {
+		hasRefs, err := c.hasRefs(ctx, repo)
+		if err != nil {
+			return false, err
+		}
+		if hasRefs {
+			return false, nil
+		}
+	}
+
+	if err := c.PurgeNoAuth(ctx, session, repo); err != nil {
+		return false, fmt.Errorf("failed to purge repository created on push: %w", err)
+	}
+
+	return true, nil
+}
+
+func (c *Controller) hasRefs(ctx context.Context, repo *types.Repository) (bool, error) {
+	readParams := CreateRPCReadParams(repo)
+
+	branches, err := c.gitRPCClient.ListBranches(ctx, &gitrpc.ListBranchesParams{
+		ReadParams: readParams,
+		Page:       1,
+		PageSize:   1,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list branches: %w", err)
+	}
+	if len(branches.Branches) > 0 {
+		return true, nil
+	}
+
+	tags, err := c.gitRPCClient.ListCommitTags(ctx, &gitrpc.ListCommitTagsParams{
+		ReadParams: readParams,
+		Page:       1,
+		PageSize:   1,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return len(tags.Tags) > 0, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeDenyAuthorizer struct {
+	fakeAuthorizer
+}
+
+func (fakeDenyAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource,
+	enum.Permission) (bool, error) {
+	return false, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	lookups int
+}
+
+func (s *fakeSpaceStore) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	s.lookups++
+	if spaceRef != "space" {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Space{ID: 1, Path: "space"}, nil
+}
+
+type fakeSettingStore struct {
+	store.SettingStore
+	pushToCreate bool
+}
+
+func (s fakeSettingStore) ListInherited(context.Context, int64) ([]*types.Setting, error) {
+	value, _ := json.Marshal(s.pushToCreate)
+	spaceID := int64(1)
+	return []*types.Setting{{SpaceID: &spaceID, Key: enum.SettingKeyPushToCreate, Value: value}}, nil
+}
+
+func TestCheckCreateOnPush(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 7}}
+
+	tests := []struct {
+		name         string
+		session      *auth.Session
+		repoPath     string
+		authorizer   authz.Authorizer
+		pushToCreate bool
+		wantErr      error
+		wantLookups  int
+	}{
+		{
+			name:         "anonymous",
+			repoPath:     "space/repo",
+			authorizer:   fakeAuthorizer{},
+			pushToCreate: true,
+			wantErr:      apiauth.ErrNotAuthenticated,
+		},
+		{
+			name:         "anonymous-missing-space",
+			repoPath:     "other/repo",
+			authorizer:   fakeAuthorizer{},
+			pushToCreate: true,
+			wantErr:      apiauth.ErrNotAuthenticated,
+		},
+		{
+			name:         "missing-space",
+			session:      session,
+			repoPath:     "other/repo",
+			authorizer:   fakeAuthorizer{},
+			pushToCreate: true,
+			wantErr:      gitness_store.ErrResourceNotFound,
+			wantLookups:  1,
+		},
+		{
+			name:         "no-permission",
+			session:      session,
+			repoPath:     "space/repo",
+			authorizer:   fakeDenyAuthorizer{},
+			pushToCreate: true,
+			wantErr:      gitness_store.ErrResourceNotFound,
+			wantLookups:  1,
+		},
+		{
+			name:        "disabled",
+			session:     session,
+			repoPath:    "space/repo",
+			authorizer:  fakeAuthorizer{},
+			wantErr:     gitness_store.ErrResourceNotFound,
+			wantLookups: 1,
+		},
+		{
+			name:         "allowed",
+			session:      session,
+			repoPath:     "space/repo",
+			authorizer:   fakeAuthorizer{},
+			pushToCreate: true,
+			wantLookups:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spaceStore := &fakeSpaceStore{}
+			c := &Controller{
+				authorizer: test.authorizer,
+				spaceStore: spaceStore,
+				settings: settings.NewService("main", 0, fakeTransactor{},
+					fakeSettingStore{pushToCreate: test.pushToCreate}, spaceStore, nil),
+			}
+
+			space, uid, err := c.CheckCreateOnPush(context.Background(), test.session, test.repoPath)
+			if !errors.Is(err, test.wantErr) || (test.wantErr == nil && err != nil) {
+				t.Fatalf("want error %v, got %v", test.wantErr, err)
+			}
+			if test.wantErr == nil && (space.ID != 1 || uid != "repo") {
+				t.Errorf("want repo in space 1, got uid %q in space %d", uid, space.ID)
+			}
+			if spaceStore.lookups != test.wantLookups {
+				t.Errorf("want %d space lookups, got %d", test.wantLookups, spaceStore.lookups)
+			}
+		})
+	}
+}
+
+type fakePushGitRPC struct {
+	gitrpc.Interface
+	branches int
+	tags     int
+	deleted  bool
+}
+
+func (g *fakePushGitRPC) ListBranches(context.Context, *gitrpc.ListBranchesParams) (*gitrpc.ListBranchesOutput,
+	error) {
+	return &gitrpc.ListBranchesOutput{Branches: make([]gitrpc.Branch, g.branches)}, nil
+}
+
+func (g *fakePushGitRPC) ListCommitTags(context.Context, *gitrpc.ListCommitTagsParams) (*gitrpc.ListCommitTagsOutput,
+	error) {
+	return &gitrpc.ListCommitTagsOutput{Tags: make([]gitrpc.CommitTag, g.tags)}, nil
+}
+
+func (g *fakePushGitRPC) DeleteRepository(context.Context, *gitrpc.DeleteRepositoryParams) error {
+	g.deleted = true
+	return nil
+}
+
+type fakePurgeRepoStore struct {
+	store.RepoStore
+	deleted bool
+}
+
+func (s *fakePurgeRepoStore) Delete(context.Context, int64) error {
+	s.deleted = true
+	return nil
+}
+
+func TestPurgeEmptyOnPush(t *testing.T) {
+	tests := []struct {
+		name       string
+		branches   int
+		tags       int
+		pushErr    error
+		wantPurged bool
+	}{
+		{name: "branch-pushed", branches: 1},
+		{name: "tag-pushed", tags: 1},
+		{name: "all-rejected", wantPurged: true},
+		{name: "push-failed", branches: 1, pushErr: errors.New("aborted"), wantPurged: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitRPC := &fakePushGitRPC{branches: test.branches, tags: test.tags}
+			repoStore := &fakePurgeRepoStore{}
+			c := &Controller{
+				tx:           fakeTransactor{},
+				urlProvider:  fakeURLProvider{},
+				repoStore:    repoStore,
+				gitRPCClient: gitRPC,
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: 7}}
+			repo := &types.Repository{ID: 1, Path: "space/repo", GitUID: "git-uid"}
+			purged, err := c.PurgeEmptyOnPush(context.Background(), session, repo, test.pushErr)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if purged != test.wantPurged || repoStore.deleted != test.wantPurged || gitRPC.deleted != test.wantPurged {
+				t.Errorf("want purged %t, got %t (repo deleted %t, git repo deleted %t)",
+					test.wantPurged, purged, repoStore.deleted, gitRPC.deleted)
+			}
+		})
+	}
+}
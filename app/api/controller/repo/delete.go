@@ -17,6 +17,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -27,7 +28,8 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// Delete deletes a repo.
+// Delete moves a repo to the trash.
+// Repositories that are being imported are deleted permanently.
 func (c *Controller) Delete(ctx context.Context, session *auth.Session, repoRef string) error {
 	// note: can't use c.getRepoCheckAccess because import job for repositories being imported must be cancelled.
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
@@ -45,25 +47,92 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, repoRef
 			return fmt.Errorf("failed to cancel repository import")
 		}
 
-		return c.DeleteNoAuth(ctx, session, repo)
+		return c.PurgeNoAuth(ctx, session, repo)
 	}
 
 	log.Ctx(ctx).Info().Msgf("Delete request received for repo %s , id: %d", repo.Path, repo.ID)
 
-	return c.DeleteNoAuth(ctx, session, repo)
+	return c.SoftDeleteNoAuth(ctx, repo, time.Now().UnixMilli())
 }
 
-func (c *Controller) DeleteNoAuth(ctx context.Context, session *auth.Session, repo *types.Repository) error {
-	if err := c.DeleteGitRPCRepositories(ctx, session, repo); err != nil {
-		return err
+// DeleteDryRun returns the resources that would be affected by deleting the repo, without deleting it.
+func (c *Controller) DeleteDryRun(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.DeleteImpact, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := c.repoStore.Delete(ctx, repo.ID); err != nil {
-		return err
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoDelete, false); err != nil {
+		return nil, err
+	}
+
+	impact := &types.DeleteImpact{
+		Spaces: []types.ImpactedResource{},
+		Repos:  []types.ImpactedResource{},
+	}
+
+	if err = c.AddDeleteImpactNoAuth(ctx, impact, repo); err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
+
+// AddDeleteImpactNoAuth adds the repo and its open pull requests to the impact of a deletion
+// - no authorization is verified.
+// WARNING this is meant for internal calls only.
+func (c *Controller) AddDeleteImpactNoAuth(
+	ctx context.Context,
+	impact *types.DeleteImpact,
+	repo *types.Repository,
+) error {
+	openPullReqs, err := c.pullReqStore.Count(ctx, &types.PullReqFilter{
+		TargetRepoID: repo.ID,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count open pull requests of repo %d: %w", repo.ID, err)
+	}
+
+	impact.Repos = append(impact.Repos, types.ImpactedResource{ID: repo.ID, Path: repo.Path})
+	impact.OpenPullReqs += openPullReqs
+
+	return nil
+}
+
+// SoftDeleteNoAuth moves the repo to the trash - no authorization is verified.
+// WARNING this is meant for internal calls only.
+func (c *Controller) SoftDeleteNoAuth(ctx context.Context, repo *types.Repository, deletedAt int64) error {
+	if err := c.repoStore.SoftDelete(ctx, repo.ID, deletedAt); err != nil {
+		return fmt.Errorf("failed to soft delete repo %d: %w", repo.ID, err)
 	}
 	return nil
 }
 
+// PurgeNoAuth deletes the repo permanently, including its git repository - no authorization is verified.
+// The repo has to be either in the trash or still being imported.
+// WARNING this is meant for internal calls only.
+func (c *Controller) PurgeNoAuth(ctx context.Context, session *auth.Session, repo *types.Repository) error {
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		if repo.Deleted != nil {
+			err = c.repoStore.Purge(ctx, repo.ID, *repo.Deleted)
+		} else {
+			err = c.repoStore.Delete(ctx, repo.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete repo %d: %w", repo.ID, err)
+		}
+
+		// the git repository is deleted last, a failure rolls back the deletion of the repo entry.
+		return c.DeleteGitRPCRepositories(ctx, session, repo)
+	})
+}
+
 func (c *Controller) DeleteGitRPCRepositories(
 	ctx context.Context,
 	session *auth.Session, repo *types.Repository,
@@ -16,10 +16,12 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/gitrpc"
@@ -32,6 +34,8 @@ func (c *Controller) RawDiff(
 	session *auth.Session,
 	repoRef string,
 	path string,
+	filePaths []string,
+	diffOpts types.DiffOptions,
 	w io.Writer,
 ) error {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
@@ -49,6 +53,8 @@ func (c *Controller) RawDiff(
 		BaseRef:    info.BaseRef,
 		HeadRef:    info.HeadRef,
 		MergeBase:  info.MergeBase,
+		Paths:      filePaths,
+		Options:    controller.MapDiffOptions(diffOpts),
 	}, w)
 }
 
@@ -57,6 +63,7 @@ func (c *Controller) CommitDiff(
 	session *auth.Session,
 	repoRef string,
 	sha string,
+	diffOpts types.DiffOptions,
 	w io.Writer,
 ) error {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
@@ -64,9 +71,10 @@ func (c *Controller) CommitDiff(
 		return err
 	}
 
-	return c.gitRPCClient.CommitDiff(ctx, &gitrpc.GetCommitParams{
+	return c.gitRPCClient.CommitDiff(ctx, &gitrpc.CommitDiffParams{
 		ReadParams: CreateRPCReadParams(repo),
 		SHA:        sha,
+		Options:    controller.MapDiffOptions(diffOpts),
 	}, w)
 }
 
@@ -133,6 +141,9 @@ func (c *Controller) Diff(
 	repoRef string,
 	path string,
 	includePatch bool,
+	includeWordDiff bool,
+	filePaths []string,
+	diffOpts types.DiffOptions,
 ) (types.Stream[*gitrpc.FileDiff], error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -148,13 +159,25 @@ func (c *Controller) Diff(
 		return nil, err
 	}
 
+	limits, err := c.instanceSettings.DiffLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff limits: %w", err)
+	}
+
 	reader := gitrpc.NewStreamReader(c.gitRPCClient.Diff(ctx, &gitrpc.DiffParams{
 		ReadParams:   gitrpc.CreateRPCReadParams(repo),
 		BaseRef:      info.BaseRef,
 		HeadRef:      info.HeadRef,
 		MergeBase:    info.MergeBase,
 		IncludePatch: includePatch,
+		Paths:        filePaths,
+		Limits:       limits,
+		Options:      controller.MapDiffOptions(diffOpts),
 	}))
 
+	if includePatch && includeWordDiff {
+		return c.wordDiff.Stream(ctx, repo, reader), nil
+	}
+
 	return reader, nil
 }
@@ -50,5 +50,11 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	verifications, err := c.publicKeys.Verify(ctx, repo.ID, []gitrpc.Commit{rpcCommit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+	commit.Verification = verifications[commit.SHA]
+
 	return commit, nil
 }
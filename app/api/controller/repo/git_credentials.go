@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// gitCredentialsLifetime specifies the lifetime of ephemeral git credentials.
+	gitCredentialsLifetime = time.Hour
+)
+
+// CreateGitCredentialsInput used for requesting ephemeral git credentials.
+type CreateGitCredentialsInput struct {
+	// Push indicates whether the credentials allow pushing to the repo (read-only otherwise).
+	Push bool `json:"push"`
+}
+
+// GitCredentials contains ephemeral credentials that can be used for git operations over http.
+type GitCredentials struct {
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// CreateGitCredentials issues short-lived git credentials that only grant access to the repo.
+// This allows callers like CI pipelines to exchange their ambient identity for repo scoped credentials
+// instead of relying on long-lived personal access tokens.
+func (c *Controller) CreateGitCredentials(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateGitCredentialsInput,
+) (*GitCredentials, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	// prevent extending the lifetime of ephemeral repo access indefinitely
+	if _, ok := session.Metadata.(*auth.RepoAccessMetadata); ok {
+		return nil, usererror.Forbidden("Git credentials can't be used to create new git credentials.")
+	}
+
+	permission := enum.PermissionRepoView
+	role := enum.MembershipRoleReader
+	if in.Push {
+		permission = enum.PermissionRepoPush
+		role = enum.MembershipRoleContributor
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, false)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(gitCredentialsLifetime)
+	token, err := jwt.GenerateWithRepoAccess(
+		session.Principal.ID,
+		repo.ID,
+		role,
+		gitCredentialsLifetime,
+		session.Principal.Salt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwt: %w", err)
+	}
+
+	return &GitCredentials{
+		Username:  session.Principal.UID,
+		Password:  token,
+		ExpiresAt: expiresAt.UnixMilli(),
+	}, nil
+}
@@ -46,7 +46,11 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
-	remoteRepository, err := importer.LoadRepositoryFromProvider(ctx, in.Provider, in.ProviderRepo)
+	if err = c.quotaEnforcer.CheckRepoCreation(ctx, parentSpace.ID, 1); err != nil {
+		return nil, err
+	}
+
+	remoteRepository, err := c.importer.LoadRepositoryFromProvider(ctx, in.Provider, in.ProviderRepo)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +75,8 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, err
 	}
 
+	c.settings.ApplyRepoDefaults(ctx, session, repo)
+
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 
 	return repo, nil
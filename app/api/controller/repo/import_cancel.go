@@ -47,5 +47,5 @@ func (c *Controller) ImportCancel(ctx context.Context,
 		return fmt.Errorf("failed to cancel repository import")
 	}
 
-	return c.DeleteNoAuth(ctx, session, repo)
+	return c.PurgeNoAuth(ctx, session, repo)
 }
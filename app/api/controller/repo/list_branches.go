@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/gitrpc"
 	"github.com/harness/gitness/types"
@@ -29,13 +30,24 @@ type Branch struct {
 	Name   string        `json:"name"`
 	SHA    string        `json:"sha"`
 	Commit *types.Commit `json:"commit,omitempty"`
+	// Divergence is the ahead/behind count of the branch relative to the default branch.
+	Divergence *CommitDivergence `json:"divergence,omitempty"`
+	// PullReqNumbers are the numbers of the open pull requests with the branch as source.
+	PullReqNumbers []int64 `json:"pullreq_numbers,omitempty"`
+}
+
+// ListBranchesOptions specifies the optional information included for each listed branch.
+type ListBranchesOptions struct {
+	IncludeCommit     bool
+	IncludeDivergence bool
+	IncludePullReqs   bool
 }
 
 // ListBranches lists the branches of a repo.
 func (c *Controller) ListBranches(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
-	includeCommit bool,
+	opts ListBranchesOptions,
 	filter *types.BranchFilter,
 ) ([]Branch, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
@@ -45,7 +57,7 @@ func (c *Controller) ListBranches(ctx context.Context,
 
 	rpcOut, err := c.gitRPCClient.ListBranches(ctx, &gitrpc.ListBranchesParams{
 		ReadParams:    CreateRPCReadParams(repo),
-		IncludeCommit: includeCommit,
+		IncludeCommit: opts.IncludeCommit,
 		Query:         filter.Query,
 		Sort:          mapToRPCBranchSortOption(filter.Sort),
 		Order:         mapToRPCSortOrder(filter.Order),
@@ -64,9 +76,96 @@ func (c *Controller) ListBranches(ctx context.Context,
 		}
 	}
 
+	if opts.IncludeDivergence {
+		if err = c.populateBranchDivergences(ctx, repo, branches); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.IncludePullReqs {
+		if err = c.populateBranchPullReqs(ctx, repo, branches); err != nil {
+			return nil, err
+		}
+	}
+
 	return branches, nil
 }
 
+// populateBranchDivergences calculates the ahead/behind counts of all branches
+// relative to the default branch of the repo using a single gitrpc call.
+func (c *Controller) populateBranchDivergences(ctx context.Context, repo *types.Repository, branches []Branch) error {
+	if len(branches) == 0 {
+		return nil
+	}
+
+	params := &gitrpc.GetCommitDivergencesParams{
+		ReadParams: CreateRPCReadParams(repo),
+		Requests:   make([]gitrpc.CommitDivergenceRequest, len(branches)),
+	}
+	for i := range branches {
+		params.Requests[i] = gitrpc.CommitDivergenceRequest{
+			From: branches[i].Name,
+			To:   repo.DefaultBranch,
+		}
+	}
+
+	rpcOut, err := c.gitRPCClient.GetCommitDivergences(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to get branch divergences: %w", err)
+	}
+
+	for i := range rpcOut.Divergences {
+		if i >= len(branches) {
+			break
+		}
+		branches[i].Divergence = &CommitDivergence{
+			Ahead:  rpcOut.Divergences[i].Ahead,
+			Behind: rpcOut.Divergences[i].Behind,
+		}
+	}
+
+	return nil
+}
+
+// populateBranchPullReqs finds the open pull requests of all branches using a single database query.
+func (c *Controller) populateBranchPullReqs(ctx context.Context, repo *types.Repository, branches []Branch) error {
+	if len(branches) == 0 {
+		return nil
+	}
+
+	branchNames := make([]string, len(branches))
+	for i := range branches {
+		branchNames[i] = branches[i].Name
+	}
+
+	pullReqs, err := c.pullReqStore.List(ctx, &types.PullReqFilter{
+		Size:           request.PerPageMax,
+		SourceRepoID:   repo.ID,
+		SourceBranches: branchNames,
+		States:         []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:           enum.PullReqSortNumber,
+		Order:          enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list open pull requests of branches: %w", err)
+	}
+
+	branchIdx := make(map[string]int, len(branches))
+	for i := range branches {
+		branchIdx[branches[i].Name] = i
+	}
+
+	for _, pr := range pullReqs {
+		idx, ok := branchIdx[pr.SourceBranch]
+		if !ok {
+			continue
+		}
+		branches[idx].PullReqNumbers = append(branches[idx].PullReqNumbers, pr.Number)
+	}
+
+	return nil
+}
+
 func mapToRPCBranchSortOption(o enum.BranchSortOption) gitrpc.BranchSortOption {
 	switch o {
 	case enum.BranchSortOptionDate:
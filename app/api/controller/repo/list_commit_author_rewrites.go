@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListCommitAuthorRewrites lists the commits created via the API whose requested author
+// got replaced by the identity of the acting principal.
+func (c *Controller) ListCommitAuthorRewrites(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.CommitAuthorRewriteFilter,
+) ([]*types.CommitAuthorRewrite, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.authorship.List(ctx, repo, filter)
+}
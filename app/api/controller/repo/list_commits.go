@@ -57,6 +57,11 @@ func (c *Controller) ListCommits(ctx context.Context,
 		return types.ListCommitResponse{}, err
 	}
 
+	verifications, err := c.publicKeys.Verify(ctx, repo.ID, rpcOut.Commits)
+	if err != nil {
+		return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
 	commits := make([]types.Commit, len(rpcOut.Commits))
 	for i := range rpcOut.Commits {
 		var commit *types.Commit
@@ -64,6 +69,7 @@ func (c *Controller) ListCommits(ctx context.Context,
 		if err != nil {
 			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
 		}
+		commit.Verification = verifications[commit.SHA]
 		commits[i] = *commit
 	}
 
@@ -21,6 +21,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -83,6 +84,49 @@ func (c *Controller) Move(ctx context.Context,
 	return repo, nil
 }
 
+// MoveDryRun returns the path change of the repository caused by moving it, without moving it.
+func (c *Controller) MoveDryRun(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MoveInput,
+) (*types.PathChange, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("can't move a repo that is being imported")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, err
+	}
+
+	change := &types.PathChange{
+		ID:      repo.ID,
+		OldPath: repo.Path,
+		NewPath: repo.Path,
+	}
+
+	if !in.hasChanges(repo) {
+		return change, nil
+	}
+
+	if err = c.sanitizeMoveInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentPath, _, err := paths.DisectLeaf(repo.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disect path of repo: %w", err)
+	}
+
+	change.NewPath = paths.Concatinate(parentPath, *in.UID)
+
+	return change, nil
+}
+
 func (c *Controller) sanitizeMoveInput(in *MoveInput) error {
 	if in.UID != nil {
 		if err := c.uidCheck(*in.UID, false); err != nil {
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindPushedBranch returns the branch recently pushed by the caller to the repository,
+// or nil if there is none or a pull request has been opened for it in the meantime.
+func (c *Controller) FindPushedBranch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PushedBranch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pushed, err := c.pushedBranches.Get(ctx, repo.ID, session.Principal.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pushed branch: %w", err)
+	}
+
+	count, err := c.pullReqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: repo.ID,
+		SourceBranch: pushed.Branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pull requests of pushed branch: %w", err)
+	}
+
+	if count > 0 {
+		return nil, nil
+	}
+
+	return pushed, nil
+}
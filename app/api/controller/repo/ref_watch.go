@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const refWatchPatternMaxLength = 255
+
+type CreateRefWatchInput struct {
+	Type enum.RefWatchType `json:"type"`
+	// Pattern is matched against the branch or tag name, e.g. "release/*".
+	Pattern string `json:"pattern"`
+}
+
+func (in *CreateRefWatchInput) sanitize() error {
+	var ok bool
+	if in.Type, ok = in.Type.Sanitize(); !ok {
+		return usererror.BadRequestf("Invalid ref watch type '%s'.", in.Type)
+	}
+
+	in.Pattern = strings.TrimSpace(in.Pattern)
+	if in.Pattern == "" {
+		return usererror.BadRequest("A pattern has to be provided.")
+	}
+	if len(in.Pattern) > refWatchPatternMaxLength {
+		return usererror.BadRequestf("The pattern can be at most %d characters long.", refWatchPatternMaxLength)
+	}
+	if _, err := path.Match(in.Pattern, ""); err != nil {
+		return usererror.BadRequestf("Invalid pattern '%s'.", in.Pattern)
+	}
+
+	return nil
+}
+
+// ListRefWatches returns the ref watches of the caller in the repository.
+func (c *Controller) ListRefWatches(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.RefWatch, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	watches, err := c.refWatchStore.List(ctx, repo.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ref watches: %w", err)
+	}
+
+	return watches, nil
+}
+
+// CreateRefWatch subscribes the caller to pushes to the branches or tags matching the pattern.
+func (c *Controller) CreateRefWatch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateRefWatchInput,
+) (*types.RefWatch, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	watch := &types.RefWatch{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		Type:        in.Type,
+		Pattern:     in.Pattern,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.refWatchStore.Create(ctx, watch)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("The ref watch already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ref watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// DeleteRefWatch removes a ref watch of the caller.
+func (c *Controller) DeleteRefWatch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	refWatchID int64,
+) error {
+	if session == nil {
+		return usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return err
+	}
+
+	watch, err := c.refWatchStore.Find(ctx, refWatchID)
+	if err != nil {
+		return fmt.Errorf("failed to find ref watch: %w", err)
+	}
+
+	// ref watches of other principals are treated as not existing.
+	if watch.RepoID != repo.ID || watch.PrincipalID != session.Principal.ID {
+		return usererror.ErrNotFound
+	}
+
+	if err = c.refWatchStore.Delete(ctx, watch.ID); err != nil {
+		return fmt.Errorf("failed to delete ref watch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RenameDefaultBranchInput is used for renaming the default branch of a repo.
+type RenameDefaultBranchInput struct {
+	Name string `json:"name"`
+}
+
+// RenameDefaultBranch renames the default branch of a repo.
+// Open pull requests from and to the branch are retargeted to the new branch,
+// and pushes to the old branch are rejected with instructions on how to update local clones.
+func (c *Controller) RenameDefaultBranch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RenameDefaultBranchInput,
+) (*types.Repository, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	in.Name = strings.TrimSpace(in.Name)
+	oldName := repo.DefaultBranch
+	if in.Name == oldName {
+		return nil, usererror.BadRequestf("The default branch is already named '%s'.", oldName)
+	}
+
+	writeParams, err := CreateRPCWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the branch is renamed by creating the new branch and removing the old one
+	// once nothing is referencing it anymore.
+	_, err = c.gitRPCClient.CreateBranch(ctx, &gitrpc.CreateBranchParams{
+		WriteParams: writeParams,
+		BranchName:  in.Name,
+		Target:      oldName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.gitRPCClient.UpdateDefaultBranch(ctx, &gitrpc.UpdateDefaultBranchParams{
+		WriteParams:   writeParams,
+		DefaultBranch: in.Name,
+	})
+	if err != nil {
+		c.deleteBranchBestEffort(ctx, writeParams, in.Name)
+		return nil, fmt.Errorf("failed to update default branch: %w", err)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = in.Name
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repo: %w", err)
+		}
+
+		if err = c.retargetPullReqs(ctx, repo.ID, oldName, in.Name); err != nil {
+			return err
+		}
+
+		err = c.renameStore.Upsert(ctx, &types.BranchRename{
+			RepoID:    repo.ID,
+			OldName:   oldName,
+			NewName:   in.Name,
+			CreatedBy: session.Principal.ID,
+			Created:   time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store branch rename: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		// revert the git changes, the rename didn't happen as far as gitness is concerned.
+		if errRevert := c.gitRPCClient.UpdateDefaultBranch(ctx, &gitrpc.UpdateDefaultBranchParams{
+			WriteParams:   writeParams,
+			DefaultBranch: oldName,
+		}); errRevert != nil {
+			log.Ctx(ctx).Warn().Err(errRevert).Msg("failed to revert default branch after failed rename")
+		} else {
+			c.deleteBranchBestEffort(ctx, writeParams, in.Name)
+		}
+
+		return nil, err
+	}
+
+	err = c.gitRPCClient.DeleteBranch(ctx, &gitrpc.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  oldName,
+	})
+	if err != nil {
+		// the rename itself succeeded, the old branch just stays around.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete old default branch '%s' after rename", oldName)
+	}
+
+	// backfill repo url
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	return repo, nil
+}
+
+// retargetPullReqs moves all open pull requests from and to the old branch of the repo to the new branch.
+func (c *Controller) retargetPullReqs(ctx context.Context, repoID int64, oldName, newName string) error {
+	filters := []*types.PullReqFilter{
+		{TargetRepoID: repoID, TargetBranch: oldName},
+		{SourceRepoID: repoID, SourceBranch: oldName},
+	}
+
+	for _, filter := range filters {
+		filter.States = []enum.PullReqState{enum.PullReqStateOpen}
+
+		// retargeted pull requests don't match the filter anymore, hence always the first page is fetched.
+		for {
+			prs, err := c.pullReqStore.List(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("failed to list pull requests: %w", err)
+			}
+
+			if len(prs) == 0 {
+				break
+			}
+
+			for _, pr := range prs {
+				_, err = c.pullReqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+					if pr.TargetRepoID == repoID && pr.TargetBranch == oldName {
+						pr.TargetBranch = newName
+					}
+					if pr.SourceRepoID == repoID && pr.SourceBranch == oldName {
+						pr.SourceBranch = newName
+					}
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to retarget pull request %d: %w", pr.Number, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) deleteBranchBestEffort(ctx context.Context, writeParams gitrpc.WriteParams, branchName string) {
+	err := c.gitRPCClient.DeleteBranch(ctx, &gitrpc.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  branchName,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete branch '%s'", branchName)
+	}
+}
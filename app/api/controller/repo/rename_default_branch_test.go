@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource,
+	enum.Permission) (bool, error) {
+	return true, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL() string { return "http://localhost:3000/api" }
+
+func (fakeURLProvider) GenerateGITCloneURL(repoPath string) string {
+	return "http://localhost:3000/git/" + repoPath + ".git"
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	repo := *s.repo
+	return &repo, nil
+}
+
+func (s *fakeRepoStore) UpdateOptLock(_ context.Context, repo *types.Repository,
+	mutateFn func(repo *types.Repository) error) (*types.Repository, error) {
+	clone := *repo
+	if err := mutateFn(&clone); err != nil {
+		return nil, err
+	}
+	*s.repo = clone
+	return &clone, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *fakePullReqStore) List(_ context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	var result []*types.PullReq
+	for _, pr := range s.prs {
+		if pr.State != enum.PullReqStateOpen {
+			continue
+		}
+		if (filter.TargetBranch != "" && pr.TargetBranch == filter.TargetBranch) ||
+			(filter.SourceBranch != "" && pr.SourceBranch == filter.SourceBranch) {
+			result = append(result, pr)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(_ context.Context, pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error) (*types.PullReq, error) {
+	if err := mutateFn(pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+type fakeRenameStore struct {
+	store.BranchRenameStore
+	renames []*types.BranchRename
+	err     error
+}
+
+func (s *fakeRenameStore) Upsert(_ context.Context, rename *types.BranchRename) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.renames = append(s.renames, rename)
+	return nil
+}
+
+// fakeGitRPC records the branch operations executed on the repository.
+type fakeGitRPC struct {
+	gitrpc.Interface
+	calls []string
+}
+
+func (g *fakeGitRPC) CreateBranch(_ context.Context, params *gitrpc.CreateBranchParams) (*gitrpc.CreateBranchOutput,
+	error) {
+	g.calls = append(g.calls, "create "+params.BranchName+" from "+params.Target)
+	return &gitrpc.CreateBranchOutput{}, nil
+}
+
+func (g *fakeGitRPC) UpdateDefaultBranch(_ context.Context, params *gitrpc.UpdateDefaultBranchParams) error {
+	g.calls = append(g.calls, "head "+params.DefaultBranch)
+	return nil
+}
+
+func (g *fakeGitRPC) DeleteBranch(_ context.Context, params *gitrpc.DeleteBranchParams) error {
+	g.calls = append(g.calls, "delete "+params.BranchName)
+	return nil
+}
+
+func newRenameTestController(renameErr error) (*Controller, *fakeRepoStore, *fakePullReqStore,
+	*fakeRenameStore, *fakeGitRPC) {
+	repoStore := &fakeRepoStore{repo: &types.Repository{ID: 1, Path: "space/repo", DefaultBranch: "master"}}
+	pullReqStore := &fakePullReqStore{prs: []*types.PullReq{
+		{Number: 1, State: enum.PullReqStateOpen, SourceRepoID: 1, SourceBranch: "feature",
+			TargetRepoID: 1, TargetBranch: "master"},
+		{Number: 2, State: enum.PullReqStateOpen, SourceRepoID: 1, SourceBranch: "master",
+			TargetRepoID: 1, TargetBranch: "release"},
+		{Number: 3, State: enum.PullReqStateMerged, SourceRepoID: 1, SourceBranch: "fix",
+			TargetRepoID: 1, TargetBranch: "master"},
+	}}
+	renameStore := &fakeRenameStore{err: renameErr}
+	gitRPC := &fakeGitRPC{}
+
+	c := &Controller{
+		tx:           fakeTransactor{},
+		urlProvider:  fakeURLProvider{},
+		authorizer:   fakeAuthorizer{},
+		repoStore:    repoStore,
+		pullReqStore: pullReqStore,
+		renameStore:  renameStore,
+		gitRPCClient: gitRPC,
+	}
+
+	return c, repoStore, pullReqStore, renameStore, gitRPC
+}
+
+func TestRenameDefaultBranch(t *testing.T) {
+	c, repoStore, pullReqStore, renameStore, gitRPC := newRenameTestController(nil)
+	session := &auth.Session{Principal: types.Principal{ID: 7}}
+
+	repo, err := c.RenameDefaultBranch(context.Background(), session, "space/repo",
+		&RenameDefaultBranchInput{Name: " main "})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if repo.DefaultBranch != "main" || repoStore.repo.DefaultBranch != "main" {
+		t.Errorf("want default branch main, got %q", repo.DefaultBranch)
+	}
+	want := []string{"create main from master", "head main", "delete master"}
+	if !reflect.DeepEqual(gitRPC.calls, want) {
+		t.Errorf("want git operations %v, got %v", want, gitRPC.calls)
+	}
+
+	prs := pullReqStore.prs
+	if prs[0].TargetBranch != "main" || prs[1].SourceBranch != "main" || prs[2].TargetBranch != "master" {
+		t.Errorf("want open pull requests retargeted only, got targets %q, %q and source %q",
+			prs[0].TargetBranch, prs[2].TargetBranch, prs[1].SourceBranch)
+	}
+
+	if len(renameStore.renames) != 1 || renameStore.renames[0].OldName != "master" ||
+		renameStore.renames[0].NewName != "main" || renameStore.renames[0].CreatedBy != 7 {
+		t.Errorf("want the rename from master to main recorded, got %v", renameStore.renames)
+	}
+}
+
+func TestRenameDefaultBranchSameName(t *testing.T) {
+	c, _, _, _, gitRPC := newRenameTestController(nil)
+
+	_, err := c.RenameDefaultBranch(context.Background(), &auth.Session{}, "space/repo",
+		&RenameDefaultBranchInput{Name: "master"})
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("want bad request error, got %v", err)
+	}
+	if len(gitRPC.calls) != 0 {
+		t.Errorf("expected no git operations, got %v", gitRPC.calls)
+	}
+}
+
+func TestRenameDefaultBranchRevert(t *testing.T) {
+	c, _, _, _, gitRPC := newRenameTestController(errors.New("db failure"))
+	session := &auth.Session{Principal: types.Principal{ID: 7}}
+
+	_, err := c.RenameDefaultBranch(context.Background(), session, "space/repo",
+		&RenameDefaultBranchInput{Name: "main"})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	want := []string{"create main from master", "head main", "head master", "delete main"}
+	if !reflect.DeepEqual(gitRPC.calls, want) {
+		t.Errorf("want git operations %v, got %v", want, gitRPC.calls)
+	}
+}
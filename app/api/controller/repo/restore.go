@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RestoreInput is used for restoring a repo from the trash.
+type RestoreInput struct {
+	NewUID *string `json:"new_uid"`
+}
+
+// Restore restores a repo deleted at the provided time from the trash.
+func (c *Controller) Restore(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	deletedAt int64,
+	in *RestoreInput,
+) (*types.Repository, error) {
+	repo, err := c.repoStore.FindByRefAndDeleted(ctx, repoRef, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoDelete, false); err != nil {
+		return nil, err
+	}
+
+	if _, err = c.spaceStore.Find(ctx, repo.ParentID); errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("The parent space of the repository is deleted, restore it first.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find parent space: %w", err)
+	}
+
+	uid := repo.UID
+	if in.NewUID != nil {
+		uid = *in.NewUID
+	}
+	if err = c.uidCheck(uid, false); err != nil {
+		return nil, err
+	}
+
+	err = c.repoStore.Restore(ctx, repo.ID, deletedAt, uid)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload(fmt.Sprintf("A repository with the uid '%s' already exists, "+
+			"provide a new uid to restore the repository.", uid))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore repo: %w", err)
+	}
+
+	repo, err = c.repoStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find restored repo: %w", err)
+	}
+
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	return repo, nil
+}
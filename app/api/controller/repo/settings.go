@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateSettingInput is used for setting the value of a repository setting.
+type UpdateSettingInput struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// ListSettings returns the effective value of all settings of a repository.
+func (c *Controller) ListSettings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.EffectiveSetting, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.settings.RepoSettings(ctx, repo)
+}
+
+// UpdateSetting sets the value of a setting of a repository.
+// If ifMatch is provided, it must match the ETag of the current effective value of the setting.
+func (c *Controller) UpdateSetting(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key enum.SettingKey,
+	in *UpdateSettingInput,
+	ifMatch string,
+) ([]*types.EffectiveSetting, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Value) == 0 {
+		return nil, usererror.BadRequest("A value is required.")
+	}
+
+	if err = c.settings.UpdateRepo(ctx, session.Principal.ID, repo, key, in.Value, ifMatch); err != nil {
+		return nil, err
+	}
+
+	return c.settings.RepoSettings(ctx, repo)
+}
+
+// DeleteSetting removes the value of a setting of a repository.
+func (c *Controller) DeleteSetting(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key enum.SettingKey,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.settings.DeleteRepo(ctx, repo, key)
+}
@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// staleBranchesPageSize is the number of branches processed at once when building a stale branch report.
+const staleBranchesPageSize = request.PerPageMax
+
+// StaleBranch is a branch flagged by the stale branch policy of the repository.
+type StaleBranch struct {
+	Branch
+	// Stale indicates that the branch didn't get any commits for longer than allowed by the policy.
+	Stale bool `json:"stale"`
+	// Merged indicates that the branch is merged into the default branch.
+	Merged bool `json:"merged"`
+	// Delete indicates that the branch gets deleted by the next cleanup.
+	// Branches with open pull requests are never deleted.
+	Delete bool `json:"delete"`
+}
+
+// StaleBranchReport contains all branches flagged by the stale branch policy of the repository.
+type StaleBranchReport struct {
+	Policy   types.StaleBranchPolicy `json:"policy"`
+	Branches []StaleBranch           `json:"branches"`
+}
+
+// ListStaleBranches returns a dry-run report of the stale and merged branches of a repo.
+func (c *Controller) ListStaleBranches(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*StaleBranchReport, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.staleBranchReport(ctx, repo)
+}
+
+// CleanupStaleBranchesNoAuth deletes the branches of the repo that the stale branch policy flags for deletion.
+// It returns the number of deleted branches.
+func (c *Controller) CleanupStaleBranchesNoAuth(ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) (int, error) {
+	report, err := c.staleBranchReport(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	if !report.Policy.Cleanup && !report.Policy.CleanupMerged {
+		return 0, nil
+	}
+
+	writeParams, err := CreateRPCWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	n := 0
+	for i := range report.Branches {
+		if !report.Branches[i].Delete {
+			continue
+		}
+
+		err = c.gitRPCClient.DeleteBranch(ctx, &gitrpc.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  report.Branches[i].Name,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete stale branch '%s' of repo %d",
+				report.Branches[i].Name, repo.ID)
+			continue
+		}
+
+		n++
+	}
+
+	return n, nil
+}
+
+func (c *Controller) staleBranchReport(ctx context.Context, repo *types.Repository) (*StaleBranchReport, error) {
+	policy, err := c.settings.RepoStaleBranchPolicy(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale branch policy: %w", err)
+	}
+
+	report := &StaleBranchReport{
+		Policy:   policy,
+		Branches: []StaleBranch{},
+	}
+
+	staleBefore := time.Now().AddDate(0, 0, -policy.StaleAfterDays)
+
+	for page := int32(1); ; page++ {
+		rpcOut, err := c.gitRPCClient.ListBranches(ctx, &gitrpc.ListBranchesParams{
+			ReadParams:    CreateRPCReadParams(repo),
+			IncludeCommit: true,
+			Sort:          gitrpc.BranchSortOptionName,
+			Order:         gitrpc.SortOrderAsc,
+			Page:          page,
+			PageSize:      staleBranchesPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		branches, err := c.flagStaleBranches(ctx, repo, policy, staleBefore, rpcOut.Branches)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Branches = append(report.Branches, branches...)
+
+		if len(rpcOut.Branches) < staleBranchesPageSize {
+			return report, nil
+		}
+	}
+}
+
+// flagStaleBranches returns the branches flagged as stale or merged out of one page of branches.
+func (c *Controller) flagStaleBranches(
+	ctx context.Context,
+	repo *types.Repository,
+	policy types.StaleBranchPolicy,
+	staleBefore time.Time,
+	rpcBranches []gitrpc.Branch,
+) ([]StaleBranch, error) {
+	candidates := make([]Branch, 0, len(rpcBranches))
+	for i := range rpcBranches {
+		if rpcBranches[i].Name == repo.DefaultBranch || isBranchExcluded(policy, rpcBranches[i].Name) {
+			continue
+		}
+
+		branch, err := mapBranch(rpcBranches[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map branch: %w", err)
+		}
+
+		candidates = append(candidates, branch)
+	}
+
+	if err := c.populateBranchDivergences(ctx, repo, candidates); err != nil {
+		return nil, err
+	}
+
+	mergedSHAs, err := c.mergedPullReqSHAs(ctx, repo, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	flagged := make([]Branch, 0, len(candidates))
+	res := make([]StaleBranch, 0, len(candidates))
+	for i := range candidates {
+		branch := StaleBranch{
+			Branch: candidates[i],
+			Stale:  candidates[i].Commit != nil && candidates[i].Commit.Committer.When.Before(staleBefore),
+			Merged: (candidates[i].Divergence != nil && candidates[i].Divergence.Ahead == 0) ||
+				mergedSHAs[candidates[i].SHA],
+		}
+		if !branch.Stale && !branch.Merged {
+			continue
+		}
+
+		flagged = append(flagged, candidates[i])
+		res = append(res, branch)
+	}
+
+	if err = c.populateBranchPullReqs(ctx, repo, flagged); err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		res[i].PullReqNumbers = flagged[i].PullReqNumbers
+		res[i].Delete = len(res[i].PullReqNumbers) == 0 &&
+			((policy.Cleanup && res[i].Stale) || (policy.CleanupMerged && res[i].Merged))
+	}
+
+	return res, nil
+}
+
+// mergedPullReqSHAs returns the source SHAs of merged pull requests from the branches.
+// It detects branches merged using squash or rebase, which aren't reachable from the default branch.
+func (c *Controller) mergedPullReqSHAs(
+	ctx context.Context,
+	repo *types.Repository,
+	branches []Branch,
+) (map[string]bool, error) {
+	if len(branches) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	branchNames := make([]string, len(branches))
+	for i := range branches {
+		branchNames[i] = branches[i].Name
+	}
+
+	pullReqs, err := c.pullReqStore.List(ctx, &types.PullReqFilter{
+		Size:           request.PerPageMax,
+		SourceRepoID:   repo.ID,
+		SourceBranches: branchNames,
+		States:         []enum.PullReqState{enum.PullReqStateMerged},
+		Sort:           enum.PullReqSortMerged,
+		Order:          enum.OrderDesc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests of branches: %w", err)
+	}
+
+	res := make(map[string]bool, len(pullReqs))
+	for _, pr := range pullReqs {
+		res[pr.SourceSHA] = true
+	}
+
+	return res, nil
+}
+
+func isBranchExcluded(policy types.StaleBranchPolicy, branchName string) bool {
+	for _, pattern := range policy.ExcludedBranches {
+		if ok, _ := path.Match(pattern, branchName); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+type StarOutput struct {
+	Starred bool `json:"starred"`
+}
+
+// FindStar returns whether the repository is starred by the caller.
+func (c *Controller) FindStar(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*StarOutput, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	starred, err := c.starStore.IsStarred(ctx, repo.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check repo star: %w", err)
+	}
+
+	return &StarOutput{Starred: starred}, nil
+}
+
+// Star adds the repository to the starred repositories of the caller.
+func (c *Controller) Star(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*StarOutput, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.starStore.Star(ctx, repo.ID, session.Principal.ID); err != nil {
+		return nil, fmt.Errorf("failed to star repo: %w", err)
+	}
+
+	return &StarOutput{Starred: true}, nil
+}
+
+// Unstar removes the repository from the starred repositories of the caller.
+func (c *Controller) Unstar(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*StarOutput, error) {
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.starStore.Unstar(ctx, repo.ID, session.Principal.ID); err != nil {
+		return nil, fmt.Errorf("failed to unstar repo: %w", err)
+	}
+
+	return &StarOutput{Starred: false}, nil
+}
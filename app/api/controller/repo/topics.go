@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxRepoTopics = 20
+
+var topicRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+type UpdateTopicsInput struct {
+	Topics []string `json:"topics"`
+}
+
+// ListTopics lists the topics of a repository.
+func (c *Controller) ListTopics(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]string, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := c.topicStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo topics: %w", err)
+	}
+
+	return topics, nil
+}
+
+// UpdateTopics replaces the topics of a repository.
+func (c *Controller) UpdateTopics(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UpdateTopicsInput,
+) ([]string, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := sanitizeTopics(in.Topics)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.topicStore.Set(ctx, repo.ID, topics)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repo topics: %w", err)
+	}
+
+	return topics, nil
+}
+
+// ListTopicSummaries lists the topics of all repositories visible to the caller,
+// together with the number of visible repositories using them.
+func (c *Controller) ListTopicSummaries(ctx context.Context,
+	session *auth.Session,
+	filter *types.TopicFilter,
+) ([]types.TopicSummary, int, error) {
+	repoTopics, err := c.topicStore.ListAll(ctx, filter.Query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list repo topics: %w", err)
+	}
+
+	repoIDs := make([]int64, 0, len(repoTopics))
+	seen := make(map[int64]struct{}, len(repoTopics))
+	for _, repoTopic := range repoTopics {
+		if _, ok := seen[repoTopic.RepoID]; !ok {
+			seen[repoTopic.RepoID] = struct{}{}
+			repoIDs = append(repoIDs, repoTopic.RepoID)
+		}
+	}
+
+	repos, err := c.listVisibleRepos(ctx, session, repoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	visible := make(map[int64]struct{}, len(repos))
+	for _, repo := range repos {
+		visible[repo.ID] = struct{}{}
+	}
+
+	// repo topics are ordered by topic, so all entries of a topic are adjacent.
+	summaries := []types.TopicSummary{}
+	for _, repoTopic := range repoTopics {
+		if _, ok := visible[repoTopic.RepoID]; !ok {
+			continue
+		}
+
+		if n := len(summaries); n > 0 && summaries[n-1].Topic == repoTopic.Topic {
+			summaries[n-1].RepoCount++
+			continue
+		}
+
+		summaries = append(summaries, types.TopicSummary{Topic: repoTopic.Topic, RepoCount: 1})
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].RepoCount > summaries[j].RepoCount
+	})
+
+	return paginate(summaries, filter.Page, filter.Size), len(summaries), nil
+}
+
+// ListReposByTopic lists all repositories with the topic that are visible to the caller, across all spaces.
+func (c *Controller) ListReposByTopic(ctx context.Context,
+	session *auth.Session,
+	topic string,
+	filter *types.TopicFilter,
+) ([]*types.Repository, int, error) {
+	repoIDs, err := c.topicStore.ListRepoIDs(ctx, strings.ToLower(topic))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list repos by topic: %w", err)
+	}
+
+	repos, err := c.listVisibleRepos(ctx, session, repoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return paginate(repos, filter.Page, filter.Size), len(repos), nil
+}
+
+func (c *Controller) listVisibleRepos(
+	ctx context.Context,
+	session *auth.Session,
+	repoIDs []int64,
+) ([]*types.Repository, error) {
+	repos, err := c.repoStore.ListByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repos: %w", err)
+	}
+
+	repos, err = apiauth.FilterRepos(ctx, c.authorizer, session, repos, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter repos by access: %w", err)
+	}
+
+	return repos, nil
+}
+
+// sanitizeTopics normalizes the topics to lower case, removes duplicates and validates them.
+func sanitizeTopics(in []string) ([]string, error) {
+	topics := make([]string, 0, len(in))
+	seen := make(map[string]struct{}, len(in))
+	for _, topic := range in {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if _, ok := seen[topic]; ok {
+			continue
+		}
+		seen[topic] = struct{}{}
+
+		if !topicRegex.MatchString(topic) {
+			return nil, usererror.BadRequestf("Invalid topic '%s': topics have to start with a letter or number, "+
+				"can only contain lowercase letters, numbers and hyphens, and can't be longer than 50 characters.", topic)
+		}
+
+		topics = append(topics, topic)
+	}
+
+	if len(topics) > maxRepoTopics {
+		return nil, usererror.BadRequestf("A repository can't have more than %d topics.", maxRepoTopics)
+	}
+
+	sort.Strings(topics)
+
+	return topics, nil
+}
+
+// paginate returns the items of the requested page.
+func paginate[T any](items []T, page int, size int) []T {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		return items
+	}
+
+	start := (page - 1) * size
+	if start >= len(items) {
+		return []T{}
+	}
+
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end]
+}
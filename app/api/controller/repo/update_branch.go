@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateBranchInput used for fast-forwarding a branch.
+type UpdateBranchInput struct {
+	// Target is the commit (or points to the commit) the branch will be pointing to.
+	// The branch has to be an ancestor of the target.
+	Target string `json:"target"`
+
+	// ExpectedSHA is an optional value, if provided the update fails in case the branch isn't pointing to it.
+	ExpectedSHA string `json:"expected_sha"`
+}
+
+// UpdateBranch fast-forwards an existing branch of a repo to the provided target.
+// The update goes through the regular push flow, hence all server side hooks and protections apply.
+func (c *Controller) UpdateBranch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branchName string,
+	in *UpdateBranchInput,
+) (*Branch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Target == "" {
+		return nil, usererror.BadRequest("A target has to be provided.")
+	}
+
+	readParams := CreateRPCReadParams(repo)
+
+	branchOut, err := c.gitRPCClient.GetBranch(ctx, &gitrpc.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: branchName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch from gitrpc: %w", err)
+	}
+	currentSHA := branchOut.Branch.SHA
+
+	if in.ExpectedSHA != "" && in.ExpectedSHA != currentSHA {
+		return nil, usererror.BadRequestf("Branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
+			branchName, currentSHA, in.ExpectedSHA)
+	}
+
+	// resolve the target to a commit sha (no objects are fetched as it's the same repo)
+	targetOut, err := c.gitRPCClient.FetchCommit(ctx, &gitrpc.FetchCommitParams{
+		ReadParams:    readParams,
+		SourceRepoUID: repo.GitUID,
+		Ref:           in.Target,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target: %w", err)
+	}
+
+	if targetOut.SHA == currentSHA {
+		branch, errMap := mapBranch(branchOut.Branch)
+		if errMap != nil {
+			return nil, fmt.Errorf("failed to map branch: %w", errMap)
+		}
+
+		return &branch, nil
+	}
+
+	mergeBaseOut, err := c.gitRPCClient.MergeBase(ctx, gitrpc.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       currentSHA,
+		Ref2:       targetOut.SHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	if mergeBaseOut.MergeBaseSHA != currentSHA {
+		return nil, usererror.ErrNotFastForward
+	}
+
+	writeParams, err := CreateRPCWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the old value guarantees the branch wasn't changed since the fast-forward check
+	err = c.gitRPCClient.UpdateRef(ctx, gitrpc.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitrpcenum.RefTypeBranch,
+		Name:        branchName,
+		NewValue:    targetOut.SHA,
+		OldValue:    currentSHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	branchOut, err = c.gitRPCClient.GetBranch(ctx, &gitrpc.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: branchName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated branch from gitrpc: %w", err)
+	}
+
+	branch, err := mapBranch(branchOut.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map branch: %w", err)
+	}
+
+	return &branch, nil
+}
@@ -16,7 +16,15 @@ package repo
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/authorship"
+	"github.com/harness/gitness/app/services/filerender"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/uploadscan"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/gitrpc"
@@ -35,11 +43,17 @@ var WireSet = wire.NewSet(
 func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
 	uidCheck check.PathUID, authorizer authz.Authorizer, repoStore store.RepoStore,
 	spaceStore store.SpaceStore, pipelineStore store.PipelineStore,
-	principalStore store.PrincipalStore, rpcClient gitrpc.Interface,
-	importer *importer.Repository,
+	principalStore store.PrincipalStore, pullReqStore store.PullReqStore,
+	renameStore store.BranchRenameStore, rpcClient gitrpc.Interface,
+	importer *importer.Repository, quotaEnforcer *quota.Enforcer, settings *settings.Service,
+	publicKeys *publickey.Service, authorship *authorship.Service, topicStore store.RepoTopicStore,
+	starStore store.RepoStarStore, pushedBranches store.PushedBranchCache, refWatchStore store.RefWatchStore,
+	uploadScan *uploadscan.Service, fileRender *filerender.Service, wordDiff *worddiff.Service,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
-	return NewController(config.Git.DefaultBranch, tx, urlProvider,
+	return NewController(config.Git.DefaultBranch, config.Git.SHA256Enabled, tx, urlProvider,
 		uidCheck, authorizer, repoStore,
-		spaceStore, pipelineStore, principalStore, rpcClient,
-		importer)
+		spaceStore, pipelineStore, principalStore, pullReqStore, renameStore, rpcClient,
+		importer, quotaEnforcer, settings, publicKeys, authorship, topicStore, starStore, pushedBranches,
+		refWatchStore, uploadScan, fileRender, wordDiff, instanceSettings)
 }
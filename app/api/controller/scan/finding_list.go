@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/gitrpc"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListFindings lists the scan findings of a commit of the repository.
+// If no commit is provided, the findings of the head of the default branch are returned.
+func (c *Controller) ListFindings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.ScanFindingFilter,
+) ([]*types.ScanFinding, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.CommitSHA == "" {
+		ref, err := c.gitRPCClient.GetRef(ctx, gitrpc.GetRefParams{
+			ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+			Name:       repo.DefaultBranch,
+			Type:       gitrpcenum.RefTypeBranch,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get head of the default branch: %w", err)
+		}
+
+		filter.CommitSHA = ref.SHA
+	}
+
+	count, err := c.findingStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count scan findings: %w", err)
+	}
+
+	findings, err := c.findingStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scan findings: %w", err)
+	}
+
+	return findings, count, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const scanSuppressionMaxReasonLength = 1024
+
+type CreateSuppressionInput struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+	// Package restricts the suppression to a single package, empty suppresses the vulnerability in all packages.
+	Package string `json:"package"`
+	Reason  string `json:"reason"`
+	// Expires is the time (unix milliseconds) after which the suppression no longer applies, 0 if it never expires.
+	Expires int64 `json:"expires"`
+}
+
+func (in *CreateSuppressionInput) sanitize() error {
+	in.VulnerabilityID = strings.TrimSpace(in.VulnerabilityID)
+	in.Package = strings.TrimSpace(in.Package)
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if in.VulnerabilityID == "" {
+		return usererror.BadRequest("A vulnerability id is required.")
+	}
+
+	if in.Reason == "" {
+		return usererror.BadRequest("A reason is required to suppress findings.")
+	}
+
+	if len(in.Reason) > scanSuppressionMaxReasonLength {
+		return check.NewValidationErrorf("The reason can be at most %d characters long.",
+			scanSuppressionMaxReasonLength)
+	}
+
+	if in.Expires < 0 || (in.Expires > 0 && in.Expires <= time.Now().UnixMilli()) {
+		return usererror.BadRequest("The expiry of a suppression has to be in the future.")
+	}
+
+	return nil
+}
+
+// CreateSuppression suppresses findings of a vulnerability in the repository.
+func (c *Controller) CreateSuppression(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateSuppressionInput,
+) (*types.ScanSuppression, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	suppression := &types.ScanSuppression{
+		RepoID:          repo.ID,
+		VulnerabilityID: in.VulnerabilityID,
+		Package:         in.Package,
+		Reason:          in.Reason,
+		CreatedBy:       session.Principal.ID,
+		Created:         time.Now().UnixMilli(),
+		Expires:         in.Expires,
+	}
+
+	if err = c.suppressionStore.Create(ctx, suppression); err != nil {
+		return nil, fmt.Errorf("failed to create scan suppression: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// ListSuppressions lists the suppressions of the repository.
+func (c *Controller) ListSuppressions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.ScanSuppression, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressions, err := c.suppressionStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scan suppressions: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// DeleteSuppression removes a suppression of the repository, its findings are reported again.
+func (c *Controller) DeleteSuppression(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	suppressionID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	suppression, err := c.suppressionStore.Find(ctx, suppressionID)
+	if err != nil {
+		return fmt.Errorf("failed to find scan suppression: %w", err)
+	}
+
+	if suppression.RepoID != repo.ID {
+		return usererror.ErrNotFound
+	}
+
+	if err = c.suppressionStore.Delete(ctx, suppression.ID); err != nil {
+		return fmt.Errorf("failed to delete scan suppression: %w", err)
+	}
+
+	return nil
+}
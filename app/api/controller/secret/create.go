@@ -41,6 +41,11 @@ type CreateInput struct {
 	SpaceRef    string `json:"space_ref"` // Ref of the parent space
 	UID         string `json:"uid"`
 	Data        string `json:"data"`
+	Inheritable bool   `json:"inheritable"`
+
+	// Provider is the secret manager storing the value. For external providers,
+	// the data is the reference of the secret in it, e.g. "secret/data/ci#token".
+	Provider enum.SecretProvider `json:"provider"`
 }
 
 func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.Secret, error) {
@@ -64,6 +69,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		CreatedBy:   session.Principal.ID,
 		Description: in.Description,
 		Data:        in.Data,
+		Inheritable: in.Inheritable,
+		Provider:    in.Provider,
 		SpaceID:     parentSpace.ID,
 		UID:         in.UID,
 		Created:     now,
@@ -93,10 +100,30 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	provider, ok := in.Provider.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unknown secret provider: %s", in.Provider)
+	}
+	in.Provider = provider
+
+	if err := checkSecretData(in.Provider, in.Data); err != nil {
+		return err
+	}
+
 	in.Description = strings.TrimSpace(in.Description)
 	return check.Description(in.Description)
 }
 
+// checkSecretData verifies that the data of a secret stored in an external provider isn't empty,
+// as it contains the reference to the secret.
+func checkSecretData(provider enum.SecretProvider, data string) error {
+	if provider != enum.SecretProviderGitness && strings.TrimSpace(data) == "" {
+		return usererror.BadRequestf("Secret stored in the provider '%s' requires a reference", provider)
+	}
+
+	return nil
+}
+
 // helper function returns the same secret with encrypted data.
 func enc(encrypt encrypt.Encrypter, secret *types.Secret) (*types.Secret, error) {
 	if secret == nil {
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -31,6 +32,9 @@ type UpdateInput struct {
 	UID         *string `json:"uid"`
 	Description *string `json:"description"`
 	Data        *string `json:"data"`
+	Inheritable *bool   `json:"inheritable"`
+
+	Provider *enum.SecretProvider `json:"provider"`
 }
 
 func (c *Controller) Update(
@@ -73,6 +77,12 @@ func (c *Controller) Update(
 			}
 			original.Data = string(data)
 		}
+		if in.Inheritable != nil {
+			original.Inheritable = *in.Inheritable
+		}
+		if in.Provider != nil {
+			original.Provider = *in.Provider
+		}
 
 		return nil
 	})
@@ -92,5 +102,22 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Provider != nil {
+		provider, ok := in.Provider.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unknown secret provider: %s", *in.Provider)
+		}
+		*in.Provider = provider
+
+		// changing the provider invalidates the existing data, so it has to be provided as well.
+		data := ""
+		if in.Data != nil {
+			data = *in.Data
+		}
+		if err := checkSecretData(provider, data); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
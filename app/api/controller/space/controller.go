@@ -18,8 +18,12 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -53,6 +57,16 @@ type Controller struct {
 	membershipStore store.MembershipStore
 	importer        *importer.Repository
 	exporter        *exporter.Repository
+	spaceTreeCache  store.SpaceTreeCache
+	spacePathCache  store.SpacePathCache
+	spaceEvictor    store.SpaceEvictor
+	repoEvictor     store.RepoEvictor
+	spaceReporter   *spaceevents.Reporter
+	quotaStore      store.SpaceQuotaStore
+	quotaEnforcer   *quota.Enforcer
+	settings        *settings.Service
+
+	instanceSettings *instancesettings.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -61,6 +75,10 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
+	spaceTreeCache store.SpaceTreeCache, spacePathCache store.SpacePathCache,
+	spaceEvictor store.SpaceEvictor, repoEvictor store.RepoEvictor, spaceReporter *spaceevents.Reporter,
+	quotaStore store.SpaceQuotaStore, quotaEnforcer *quota.Enforcer, settings *settings.Service,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -81,5 +99,40 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		membershipStore:     membershipStore,
 		importer:            importer,
 		exporter:            exporter,
+		spaceTreeCache:      spaceTreeCache,
+		spacePathCache:      spacePathCache,
+		spaceEvictor:        spaceEvictor,
+		repoEvictor:         repoEvictor,
+		spaceReporter:       spaceReporter,
+		quotaStore:          quotaStore,
+		quotaEnforcer:       quotaEnforcer,
+		settings:            settings,
+		instanceSettings:    instanceSettings,
+	}
+}
+
+// listVisibleBatchSize is the number of children that are loaded and permission checked at once
+// when listing the children of a space that's only accessible because it's public.
+const listVisibleBatchSize = 100
+
+// paginate returns the requested page of the items, using the same defaults as the store.
+func paginate[T any](items []T, page int, size int) []T {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = listVisibleBatchSize
 	}
+
+	start := (page - 1) * size
+	if start >= len(items) {
+		return []T{}
+	}
+
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end]
 }
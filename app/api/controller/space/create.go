@@ -40,7 +40,7 @@ type CreateInput struct {
 	ParentRef   string `json:"parent_ref"`
 	UID         string `json:"uid"`
 	Description string `json:"description"`
-	IsPublic    bool   `json:"is_public"`
+	IsPublic    *bool  `json:"is_public"`
 }
 
 // Create creates a new space.
@@ -68,6 +68,9 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	// the path might have been occupied by the alias of a moved space
+	c.spacePathCache.Evict(ctx, space.Path)
+
 	return space, nil
 }
 
@@ -93,19 +96,24 @@ func (c *Controller) createSpaceInnerInTX(
 		}
 	}
 
+	isPublic, err := c.instanceSettings.DefaultPublic(ctx, in.IsPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default visibility: %w", err)
+	}
+
 	now := time.Now().UnixMilli()
 	space := &types.Space{
 		Version:     0,
 		ParentID:    parentID,
 		UID:         in.UID,
 		Description: in.Description,
-		IsPublic:    in.IsPublic,
+		IsPublic:    isPublic,
 		Path:        spacePath,
 		CreatedBy:   session.Principal.ID,
 		Created:     now,
 		Updated:     now,
 	}
-	err := c.spaceStore.Create(ctx, space)
+	err = c.spaceStore.Create(ctx, space)
 	if err != nil {
 		return nil, fmt.Errorf("space creation failed: %w", err)
 	}
@@ -119,6 +127,12 @@ func (c *Controller) createSpaceInnerInTX(
 		Created:   now,
 		Updated:   now,
 	}
+	// a new space takes precedence over the alias of a moved space
+	err = c.spacePathStore.DeleteAliasSegment(ctx, parentID, space.UID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete alias path segment: %w", err)
+	}
+
 	err = c.spacePathStore.InsertSegment(ctx, pathSegment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert primary path segment: %w", err)
@@ -167,17 +181,30 @@ func (c *Controller) getSpaceCheckAuthSpaceCreation(
 		return 0, fmt.Errorf("failed to get parent space: %w", err)
 	}
 
+	if err = c.checkAuthSpaceCreation(ctx, session, parentSpace); err != nil {
+		return 0, err
+	}
+
+	return parentSpace.ID, nil
+}
+
+// checkAuthSpaceCreation checks whether the principal is allowed to create spaces in the parent space.
+func (c *Controller) checkAuthSpaceCreation(
+	ctx context.Context,
+	session *auth.Session,
+	parentSpace *types.Space,
+) error {
 	// create is a special case - check permission without specific resource
 	scope := &types.Scope{SpacePath: parentSpace.Path}
 	resource := &types.Resource{
 		Type: enum.ResourceTypeSpace,
 		Name: "",
 	}
-	if err = apiauth.Check(ctx, c.authorizer, session, scope, resource, enum.PermissionSpaceCreate); err != nil {
-		return 0, fmt.Errorf("authorization failed: %w", err)
+	if err := apiauth.Check(ctx, c.authorizer, session, scope, resource, enum.PermissionSpaceCreate); err != nil {
+		return fmt.Errorf("authorization failed: %w", err)
 	}
 
-	return parentSpace.ID, nil
+	return nil
 }
 
 func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
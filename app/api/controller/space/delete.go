@@ -17,15 +17,16 @@ package space
 import (
 	"context"
 	"fmt"
-	"math"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
-// Delete deletes a space.
+// Delete moves a space, including all its sub spaces and repositories, to the trash.
 func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef string) error {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
@@ -35,59 +36,136 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef
 		return err
 	}
 
-	return c.DeleteNoAuth(ctx, session, space.ID)
+	return c.SoftDeleteNoAuth(ctx, space, time.Now().UnixMilli())
 }
 
-// DeleteNoAuth deletes the space - no authorization is verified.
-// WARNING this is meant for internal calls only.
-func (c *Controller) DeleteNoAuth(ctx context.Context, session *auth.Session, spaceID int64) error {
-	filter := &types.SpaceFilter{
-		Page:  1,
-		Size:  math.MaxInt,
-		Query: "",
-		Order: enum.OrderAsc,
-		Sort:  enum.SpaceAttrNone,
-	}
-	subSpaces, _, err := c.ListSpacesNoAuth(ctx, spaceID, filter)
+// DeleteDryRun returns the sub spaces, repositories, open pull requests and memberships
+// that would be affected by deleting the space, without deleting it.
+func (c *Controller) DeleteDryRun(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.DeleteImpact, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
-		return fmt.Errorf("failed to list space %d sub spaces: %w", spaceID, err)
+		return nil, err
+	}
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceDelete, false); err != nil {
+		return nil, err
 	}
-	for _, space := range subSpaces {
-		err = c.DeleteNoAuth(ctx, session, space.ID)
+
+	nodes, err := c.spaceStore.ListTree(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space %d sub spaces: %w", space.ID, err)
+	}
+
+	impact := &types.DeleteImpact{
+		Spaces: make([]types.ImpactedResource, len(nodes)),
+		Repos:  []types.ImpactedResource{},
+	}
+
+	spacePaths := treePaths(space, nodes)
+	spaceIDs := make([]int64, len(nodes))
+	for i, node := range nodes {
+		spaceIDs[i] = node.ID
+		impact.Spaces[i] = types.ImpactedResource{ID: node.ID, Path: spacePaths[node.ID]}
+
+		memberships, err := c.membershipStore.CountUsers(ctx, node.ID, types.MembershipUserFilter{})
 		if err != nil {
-			return fmt.Errorf("failed to delete space %d: %w", space.ID, err)
+			return nil, fmt.Errorf("failed to count memberships of space %d: %w", node.ID, err)
 		}
+		impact.Memberships += memberships
 	}
-	err = c.deleteRepositoriesNoAuth(ctx, session, spaceID)
+
+	repos, err := c.repoStore.ListByParentIDs(ctx, spaceIDs)
 	if err != nil {
-		return fmt.Errorf("failed to delete repositories of space %d: %w", spaceID, err)
+		return nil, fmt.Errorf("failed to list repositories of space %d: %w", space.ID, err)
 	}
-	err = c.spaceStore.Delete(ctx, spaceID)
-	if err != nil {
-		return fmt.Errorf("spaceStore failed to delete space %d: %w", spaceID, err)
+
+	for _, repo := range repos {
+		if err = c.repoCtrl.AddDeleteImpactNoAuth(ctx, impact, repo); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+
+	return impact, nil
 }
 
-// deleteRepositoriesNoAuth deletes all repositories in a space - no authorization is verified.
+// SoftDeleteNoAuth moves the space, including all its sub spaces and repositories,
+// to the trash - no authorization is verified.
+// All entities are marked with the same deletion time, which is used to restore them together.
 // WARNING this is meant for internal calls only.
-func (c *Controller) deleteRepositoriesNoAuth(ctx context.Context, session *auth.Session, spaceID int64) error {
-	filter := &types.RepoFilter{
-		Page:  1,
-		Size:  int(math.MaxInt),
-		Query: "",
-		Order: enum.OrderAsc,
-		Sort:  enum.RepoAttrNone,
-	}
-	repos, _, err := c.ListRepositoriesNoAuth(ctx, spaceID, filter)
+func (c *Controller) SoftDeleteNoAuth(ctx context.Context, space *types.Space, deletedAt int64) error {
+	nodes, err := c.spaceStore.ListTree(ctx, space.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list space %d sub spaces: %w", space.ID, err)
+	}
+
+	spaceIDs := make([]int64, len(nodes))
+	for i, node := range nodes {
+		spaceIDs[i] = node.ID
+	}
+
+	repos, err := c.repoStore.ListByParentIDs(ctx, spaceIDs)
 	if err != nil {
-		return fmt.Errorf("failed to list space repositories: %w", err)
+		return fmt.Errorf("failed to list repositories of space %d: %w", space.ID, err)
 	}
+
 	for _, repo := range repos {
-		err = c.repoCtrl.DeleteNoAuth(ctx, session, repo)
-		if err != nil {
-			return fmt.Errorf("failed to delete repository %d: %w", repo.ID, err)
+		if !repo.Importing {
+			continue
+		}
+		if err = c.importer.Cancel(ctx, repo); err != nil {
+			return fmt.Errorf("failed to cancel import of repository %d: %w", repo.ID, err)
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, repo := range repos {
+			if err := c.repoCtrl.SoftDeleteNoAuth(ctx, repo, deletedAt); err != nil {
+				return err
+			}
+		}
+
+		// sub spaces are deleted first, the root space is the first node of the tree.
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if err := c.spaceStore.SoftDelete(ctx, nodes[i].ID, deletedAt); err != nil {
+				return fmt.Errorf("failed to soft delete space %d: %w", nodes[i].ID, err)
+			}
+		}
+
+		// releases the path of the space, the paths of the sub spaces can't be resolved anymore.
+		if err := c.spacePathStore.DeleteSegments(ctx, space.ID); err != nil {
+			return fmt.Errorf("failed to delete path segments of space %d: %w", space.ID, err)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	// the paths of all sub spaces are evicted as well, otherwise they'd still resolve to the deleted spaces.
+	spacePaths := treePaths(space, nodes)
+	for _, node := range nodes {
+		c.spaceEvictor.Evict(ctx, node.ID)
+		c.spacePathCache.Evict(ctx, spacePaths[node.ID])
+	}
+
 	return nil
 }
+
+// treePaths returns the paths of all nodes of the space tree, indexed by the space ID.
+func treePaths(space *types.Space, nodes []*types.SpaceTreeNode) map[int64]string {
+	// nodes are ordered by depth, parents are always resolved before their children.
+	spacePaths := make(map[int64]string, len(nodes))
+	for i, node := range nodes {
+		if i == 0 {
+			spacePaths[node.ID] = space.Path
+		} else {
+			spacePaths[node.ID] = paths.Concatinate(spacePaths[node.ParentID], node.UID)
+		}
+	}
+
+	return spacePaths
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeTreeSpaceStore struct {
+	store.SpaceStore
+	nodes   []*types.SpaceTreeNode
+	deleted []int64
+}
+
+func (s *fakeTreeSpaceStore) ListTree(context.Context, int64) ([]*types.SpaceTreeNode, error) {
+	return s.nodes, nil
+}
+
+func (s *fakeTreeSpaceStore) SoftDelete(_ context.Context, id int64, _ int64) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+type fakeSpacePathStore struct {
+	store.SpacePathStore
+}
+
+func (fakeSpacePathStore) DeleteSegments(context.Context, int64) error {
+	return nil
+}
+
+type fakeRepoParentStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoParentStore) ListByParentIDs(context.Context, []int64) ([]*types.Repository, error) {
+	return nil, nil
+}
+
+type fakeSpacePathCache struct {
+	store.SpacePathCache
+	keys []string
+}
+
+func (c *fakeSpacePathCache) Evict(_ context.Context, key string) {
+	c.keys = append(c.keys, key)
+}
+
+type fakeSpaceEvictor struct {
+	keys []int64
+}
+
+func (e *fakeSpaceEvictor) Evict(_ context.Context, key int64) {
+	e.keys = append(e.keys, key)
+}
+
+func TestSoftDeleteEvictsSubSpacePaths(t *testing.T) {
+	spaceStore := &fakeTreeSpaceStore{nodes: []*types.SpaceTreeNode{
+		{ID: 1, UID: "space"},
+		{ID: 2, ParentID: 1, UID: "sub"},
+		{ID: 3, ParentID: 1, UID: "other"},
+		{ID: 4, ParentID: 2, UID: "nested"},
+	}}
+	spacePathCache := &fakeSpacePathCache{}
+	spaceEvictor := &fakeSpaceEvictor{}
+
+	c := &Controller{
+		tx:             fakeTransactor{},
+		spaceStore:     spaceStore,
+		spacePathStore: fakeSpacePathStore{},
+		repoStore:      fakeRepoParentStore{},
+		spacePathCache: spacePathCache,
+		spaceEvictor:   spaceEvictor,
+	}
+
+	space := &types.Space{ID: 1, Path: "root/space"}
+	if err := c.SoftDeleteNoAuth(context.Background(), space, 1000); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []int64{4, 3, 2, 1}; !reflect.DeepEqual(spaceStore.deleted, want) {
+		t.Errorf("want spaces %v deleted, got %v", want, spaceStore.deleted)
+	}
+	if want := []int64{1, 2, 3, 4}; !reflect.DeepEqual(spaceEvictor.keys, want) {
+		t.Errorf("want spaces %v evicted, got %v", want, spaceEvictor.keys)
+	}
+
+	sort.Strings(spacePathCache.keys)
+	want := []string{"root/space", "root/space/other", "root/space/sub", "root/space/sub/nested"}
+	if !reflect.DeepEqual(spacePathCache.keys, want) {
+		t.Errorf("want paths %v evicted, got %v", want, spacePathCache.keys)
+	}
+}
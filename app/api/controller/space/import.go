@@ -47,7 +47,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
-	remoteRepositories, err := importer.LoadRepositoriesFromProviderSpace(ctx, in.Provider, in.ProviderSpace)
+	remoteRepositories, err := c.importer.LoadRepositoriesFromProviderSpace(ctx, in.Provider, in.ProviderSpace)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +56,12 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, usererror.BadRequestf("found no repositories at %s", in.ProviderSpace)
 	}
 
+	err = c.quotaEnforcer.CheckRepoCreation(ctx, parentSpaceID, int64(len(remoteRepositories)))
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]*types.Repository, len(remoteRepositories))
 	repoIDs := make([]int64, len(remoteRepositories))
 	cloneURLs := make([]string, len(remoteRepositories))
 
@@ -75,6 +81,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 				return fmt.Errorf("failed to create repository in storage: %w", err)
 			}
 
+			repos[i] = repo
 			repoIDs[i] = repo.ID
 			cloneURLs[i] = remoteRepository.CloneURL
 		}
@@ -91,6 +98,10 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, err
 	}
 
+	for _, repo := range repos {
+		c.settings.ApplyRepoDefaults(ctx, session, repo)
+	}
+
 	return space, nil
 }
 
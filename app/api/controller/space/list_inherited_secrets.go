@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListInheritedSecrets lists all secrets available in a space,
+// including the inheritable secrets of its ancestor spaces.
+func (c *Controller) ListInheritedSecrets(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.Secret, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space: %w", err)
+	}
+
+	err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSecretView, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not authorize: %w", err)
+	}
+
+	secrets, err := c.secretStore.ListAllInherited(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inherited secrets: %w", err)
+	}
+
+	return secrets, nil
+}
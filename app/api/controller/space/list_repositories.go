@@ -25,6 +25,8 @@ import (
 )
 
 // ListRepositories lists the repositories of a space.
+// If the space is only accessible because it's public, the repositories the caller can't view are omitted
+// from both the page and the returned count.
 func (c *Controller) ListRepositories(ctx context.Context, session *auth.Session,
 	spaceRef string, filter *types.RepoFilter) ([]*types.Repository, int64, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
@@ -32,10 +34,47 @@ func (c *Controller) ListRepositories(ctx context.Context, session *auth.Session
 		return nil, 0, err
 	}
 
-	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionRepoView, true); err != nil {
+	publicOnly, err := apiauth.CheckSpaceOrPublic(ctx, c.authorizer, session, space, enum.PermissionRepoView)
+	if err != nil {
 		return nil, 0, err
 	}
-	return c.ListRepositoriesNoAuth(ctx, space.ID, filter)
+
+	if !publicOnly {
+		return c.ListRepositoriesNoAuth(ctx, space.ID, filter)
+	}
+
+	// visibility is only known after the permission checks, so the page and count are computed in memory.
+	batchFilter := *filter
+	batchFilter.Size = listVisibleBatchSize
+
+	var repos []*types.Repository
+	for batchFilter.Page = 1; ; batchFilter.Page++ {
+		batch, err := c.repoStore.List(ctx, space.ID, &batchFilter)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list child repos: %w", err)
+		}
+
+		visible, err := apiauth.FilterRepos(ctx, c.authorizer, session, batch, enum.PermissionRepoView, true)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to filter child repos: %w", err)
+		}
+
+		repos = append(repos, visible...)
+
+		if len(batch) < listVisibleBatchSize {
+			break
+		}
+	}
+
+	count := int64(len(repos))
+	repos = paginate(repos, filter.Page, filter.Size)
+
+	// backfill URLs
+	for _, repo := range repos {
+		repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	}
+
+	return repos, count, nil
 }
 
 // ListRepositoriesNoAuth list repositories WITHOUT checking for PermissionRepoView.
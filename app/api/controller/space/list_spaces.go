@@ -26,6 +26,8 @@ import (
 )
 
 // ListSpaces lists the child spaces of a space.
+// If the space is only accessible because it's public, the child spaces the caller can't view are omitted
+// from both the page and the returned count.
 func (c *Controller) ListSpaces(ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
@@ -36,10 +38,40 @@ func (c *Controller) ListSpaces(ctx context.Context,
 		return nil, 0, err
 	}
 
-	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true); err != nil {
+	publicOnly, err := apiauth.CheckSpaceOrPublic(ctx, c.authorizer, session, space, enum.PermissionSpaceView)
+	if err != nil {
 		return nil, 0, err
 	}
-	return c.ListSpacesNoAuth(ctx, space.ID, filter)
+
+	if !publicOnly {
+		return c.ListSpacesNoAuth(ctx, space.ID, filter)
+	}
+
+	// visibility is only known after the permission checks, so the page and count are computed in memory.
+	batchFilter := *filter
+	batchFilter.Size = listVisibleBatchSize
+
+	var spaces []*types.Space
+	for batchFilter.Page = 1; ; batchFilter.Page++ {
+		batch, err := c.spaceStore.List(ctx, space.ID, &batchFilter)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list child spaces: %w", err)
+		}
+
+		// child spaces can be accessible through memberships in the child spaces themselves.
+		visible, err := apiauth.FilterSpaces(ctx, c.authorizer, session, batch, enum.PermissionSpaceView, true)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to filter child spaces: %w", err)
+		}
+
+		spaces = append(spaces, visible...)
+
+		if len(batch) < listVisibleBatchSize {
+			break
+		}
+	}
+
+	return paginate(spaces, filter.Page, filter.Size), int64(len(spaces)), nil
 }
 
 // ListSpacesNoAuth lists spaces WITHOUT checking PermissionSpaceView.
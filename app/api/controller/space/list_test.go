@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeAuthorizer denies all single checks and only allows the batch checks of resources with a granted name.
+type fakeAuthorizer struct {
+	authz.Authorizer
+	granted map[string]bool
+}
+
+func (a *fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource,
+	enum.Permission) (bool, error) {
+	return false, nil
+}
+
+func (a *fakeAuthorizer) CheckMany(_ context.Context, _ *auth.Session,
+	permissionChecks ...types.PermissionCheck) ([]bool, error) {
+	results := make([]bool, len(permissionChecks))
+	for i, check := range permissionChecks {
+		results[i] = a.granted[check.Resource.Name]
+	}
+	return results, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GenerateGITCloneURL(repoPath string) string {
+	return "https://gitness.example.com/git/" + repoPath + ".git"
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	space    *types.Space
+	children []*types.Space
+}
+
+func (s *fakeSpaceStore) FindByRef(context.Context, string) (*types.Space, error) {
+	return s.space, nil
+}
+
+func (s *fakeSpaceStore) List(_ context.Context, _ int64, filter *types.SpaceFilter) ([]*types.Space, error) {
+	return paginate(s.children, filter.Page, filter.Size), nil
+}
+
+func (s *fakeSpaceStore) Count(context.Context, int64, *types.SpaceFilter) (int64, error) {
+	return int64(len(s.children)), nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s *fakeRepoStore) List(_ context.Context, _ int64, filter *types.RepoFilter) ([]*types.Repository, error) {
+	return paginate(s.repos, filter.Page, filter.Size), nil
+}
+
+func (s *fakeRepoStore) Count(context.Context, int64, *types.RepoFilter) (int64, error) {
+	return int64(len(s.repos)), nil
+}
+
+// setupListController returns a controller for a public space with 150 repos and child spaces.
+// Every third child is public, and the caller is granted access to the private child with index 1.
+func setupListController() *Controller {
+	space := &types.Space{ID: 1, Path: "root", IsPublic: true}
+	spaceStore := &fakeSpaceStore{space: space}
+	repoStore := &fakeRepoStore{}
+	for i := 0; i < 150; i++ {
+		name := fmt.Sprintf("child-%03d", i)
+		spaceStore.children = append(spaceStore.children,
+			&types.Space{ID: int64(i + 2), Path: "root/" + name, UID: name, IsPublic: i%3 == 0})
+		repoStore.repos = append(repoStore.repos,
+			&types.Repository{ID: int64(i + 1), Path: "root/" + name, UID: name, IsPublic: i%3 == 0})
+	}
+
+	return &Controller{
+		urlProvider: fakeURLProvider{},
+		authorizer:  &fakeAuthorizer{granted: map[string]bool{"child-001": true}},
+		spaceStore:  spaceStore,
+		repoStore:   repoStore,
+	}
+}
+
+func TestListRepositoriesPublicOnlyCountsVisible(t *testing.T) {
+	ctrl := setupListController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	tests := []struct {
+		name     string
+		filter   types.RepoFilter
+		expected []string
+	}{
+		{
+			name:     "first-page",
+			filter:   types.RepoFilter{Page: 1, Size: 3},
+			expected: []string{"child-000", "child-001", "child-003"},
+		},
+		{
+			name:     "page-after-batch",
+			filter:   types.RepoFilter{Page: 17, Size: 3},
+			expected: []string{"child-141", "child-144", "child-147"},
+		},
+		{
+			name:     "page-out-of-range",
+			filter:   types.RepoFilter{Page: 18, Size: 3},
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos, count, err := ctrl.ListRepositories(context.Background(), session, "root", &test.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// 50 public repos and the one the caller is granted access to.
+			if count != 51 {
+				t.Errorf("expected count 51, got %d", count)
+			}
+
+			names := make([]string, len(repos))
+			for i, repo := range repos {
+				names[i] = repo.UID
+				if repo.GitURL == "" {
+					t.Errorf("expected git url of repo %q to be set", repo.UID)
+				}
+			}
+			if fmt.Sprint(names) != fmt.Sprint(test.expected) {
+				t.Errorf("expected repos %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
+
+func TestListSpacesPublicOnlyCountsVisible(t *testing.T) {
+	ctrl := setupListController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	spaces, count, err := ctrl.ListSpaces(context.Background(), session, "root",
+		&types.SpaceFilter{Page: 2, Size: 25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 51 {
+		t.Errorf("expected count 51, got %d", count)
+	}
+	if len(spaces) != 25 {
+		t.Fatalf("expected 25 spaces, got %d", len(spaces))
+	}
+	// the second page starts after child-000, child-001 and 23 more public spaces.
+	if spaces[0].UID != "child-072" {
+		t.Errorf("expected page to start with child-072, got %q", spaces[0].UID)
+	}
+	for _, space := range spaces {
+		if !space.IsPublic {
+			t.Errorf("expected private space %q to be omitted", space.UID)
+		}
+	}
+}
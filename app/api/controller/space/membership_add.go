@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -30,8 +31,11 @@ import (
 )
 
 type MembershipAddInput struct {
+	// UserUID is the UID of the user or the user group to add.
 	UserUID string              `json:"user_uid"`
 	Role    enum.MembershipRole `json:"role"`
+	// Expires is the optional time (unix milliseconds) when the membership is revoked.
+	Expires *int64 `json:"expires"`
 }
 
 func (in *MembershipAddInput) Validate() error {
@@ -52,6 +56,21 @@ func (in *MembershipAddInput) Validate() error {
 
 	in.Role = role
 
+	if in.Expires != nil {
+		if err := checkMembershipExpires(*in.Expires); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkMembershipExpires verifies that the expiry time of a membership is in the future.
+func checkMembershipExpires(expires int64) error {
+	if expires <= time.Now().UnixMilli() {
+		return usererror.BadRequest("Membership expiry time must be in the future")
+	}
+
 	return nil
 }
 
@@ -75,13 +94,19 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
-	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	user, err := c.findMemberPrincipal(ctx, in.UserUID)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
 	} else if err != nil {
 		return nil, fmt.Errorf("failed to find the user: %w", err)
 	}
 
+	if user.Type == enum.PrincipalTypeUserGroup {
+		if err = c.checkUserGroupVisibility(ctx, space, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	now := time.Now().UnixMilli()
 
 	membership := types.Membership{
@@ -93,6 +118,7 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		Created:   now,
 		Updated:   now,
 		Role:      in.Role,
+		Expires:   in.Expires,
 	}
 
 	err = c.membershipStore.Create(ctx, &membership)
@@ -108,3 +134,42 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 
 	return result, nil
 }
+
+// findMemberPrincipal returns the user or the user group with the provided UID.
+// Other types of principals can't be space members through the membership APIs.
+func (c *Controller) findMemberPrincipal(ctx context.Context, uid string) (*types.Principal, error) {
+	principal, err := c.principalStore.FindByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.Type != enum.PrincipalTypeUser && principal.Type != enum.PrincipalTypeUserGroup {
+		return nil, store.ErrResourceNotFound
+	}
+
+	return principal, nil
+}
+
+// checkUserGroupVisibility verifies that the user group is defined in the space or in one of its ancestors.
+func (c *Controller) checkUserGroupVisibility(ctx context.Context, space *types.Space, groupID int64) error {
+	group, err := c.principalStore.FindUserGroup(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	if group.SpaceID == space.ID {
+		return nil
+	}
+
+	groupSpace, err := c.spaceStore.Find(ctx, group.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find space of the user group: %w", err)
+	}
+
+	if !paths.IsAncesterOf(groupSpace.Path, space.Path) {
+		return usererror.BadRequestf(
+			"User group '%s' isn't defined in the space or any of its parent spaces", group.UID)
+	}
+
+	return nil
+}
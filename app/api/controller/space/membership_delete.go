@@ -39,7 +39,7 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return err
 	}
 
-	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	user, err := c.findMemberPrincipal(ctx, userUID)
 	if err != nil {
 		return fmt.Errorf("failed to find user by uid: %w", err)
 	}
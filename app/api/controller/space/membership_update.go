@@ -27,10 +27,21 @@ import (
 
 type MembershipUpdateInput struct {
 	Role enum.MembershipRole `json:"role"`
+	// Expires changes the time (unix milliseconds) when the membership is revoked, 0 removes the expiry.
+	Expires *int64 `json:"expires"`
 }
 
 func (in *MembershipUpdateInput) Validate() error {
+	if in.Expires != nil && *in.Expires != 0 {
+		if err := checkMembershipExpires(*in.Expires); err != nil {
+			return err
+		}
+	}
+
 	if in.Role == "" {
+		if in.Expires != nil {
+			return nil
+		}
 		return usererror.BadRequest("Role must be provided")
 	}
 
@@ -46,7 +57,7 @@ func (in *MembershipUpdateInput) Validate() error {
 	return nil
 }
 
-// MembershipUpdate changes the role of an existing membership.
+// MembershipUpdate changes the role or the expiry of an existing membership.
 func (c *Controller) MembershipUpdate(ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
@@ -67,7 +78,7 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
-	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	user, err := c.findMemberPrincipal(ctx, userUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by uid: %w", err)
 	}
@@ -80,11 +91,29 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to find membership for update: %w", err)
 	}
 
-	if membership.Role == in.Role {
-		return membership, nil
+	changed := false
+
+	if in.Role != "" && membership.Role != in.Role {
+		membership.Role = in.Role
+		changed = true
+	}
+
+	if in.Expires != nil {
+		var expires *int64
+		if *in.Expires != 0 {
+			expires = in.Expires
+		}
+
+		if !equalExpires(membership.Expires, expires) {
+			membership.Expires = expires
+			membership.ExpiryNotified = nil // a changed expiry is notified again
+			changed = true
+		}
 	}
 
-	membership.Role = in.Role
+	if !changed {
+		return membership, nil
+	}
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
 	if err != nil {
@@ -93,3 +122,11 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 
 	return membership, nil
 }
+
+func equalExpires(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -16,71 +16,229 @@ package space
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/app/paths"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
 // MoveInput is used for moving a space.
 type MoveInput struct {
 	UID *string `json:"uid"`
+	// ParentRef is the new parent of the space - empty string or zero move the space to the root.
+	ParentRef *string `json:"parent_ref"`
 }
 
-func (i *MoveInput) hasChanges(space *types.Space) bool {
-	if i.UID != nil && *i.UID != space.UID {
-		return true
-	}
+// moveTarget is the sanitized destination of a space move.
+type moveTarget struct {
+	parentID   int64
+	parentPath string
+	uid        string
+}
 
-	return false
+func (t *moveTarget) hasChanges(space *types.Space) bool {
+	return t.uid != space.UID || t.parentID != space.ParentID
 }
 
-// Move moves a space to a new UID.
-// TODO: Add support for moving to other parents and alias.
-//
-//nolint:gocognit // refactor if needed
+func (t *moveTarget) path() string {
+	return paths.Concatinate(t.parentPath, t.uid)
+}
+
+// Move moves a space to a new UID and/or a new parent.
+// The old paths of the space and its descendants keep resolving as aliases.
+// TODO: Add support for alias.
 func (c *Controller) Move(
 	ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
 	in *MoveInput,
 ) (*types.Space, error) {
-	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	space, target, err := c.prepareMove(ctx, session, spaceRef, in)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
-		return nil, err
+	// exit early if there are no changes
+	if !target.hasChanges(space) {
+		return space, nil
 	}
 
-	if err = c.sanitizeMoveInput(in, space.ParentID == 0); err != nil {
-		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	oldParentID := space.ParentID
+	oldPath := space.Path
+
+	var impact *types.SpaceMoveImpact
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// (re-)calculate the impact in the transaction to ensure correctness
+		impact, err = c.moveImpact(ctx, space, target)
+		if err != nil {
+			return err
+		}
+
+		return c.moveInner(ctx, session, space, target)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// exit early if there are no changes
-	if !in.hasChanges(space) {
-		return space, nil
+	// paths of all descendants changed, evict them from the caches of all instances.
+	for _, change := range impact.Spaces {
+		c.spaceEvictor.Evict(ctx, change.ID)
+	}
+	for _, change := range impact.Repos {
+		c.repoEvictor.Evict(ctx, change.ID)
 	}
 
-	if err = c.moveInner(
-		ctx,
-		session,
-		space,
-		in.UID,
-	); err != nil {
+	// the new path might have been occupied by the alias of another space
+	c.spacePathCache.Evict(ctx, space.Path)
+
+	c.spaceReporter.Moved(ctx, &spaceevents.MovedPayload{
+		SpaceID:     space.ID,
+		PrincipalID: session.Principal.ID,
+		OldParentID: oldParentID,
+		NewParentID: space.ParentID,
+		OldPath:     oldPath,
+		NewPath:     space.Path,
+	})
+
+	return space, nil
+}
+
+// MoveDryRun returns the spaces and repositories whose paths would change by moving the space,
+// without moving it.
+func (c *Controller) MoveDryRun(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MoveInput,
+) (*types.SpaceMoveImpact, error) {
+	space, target, err := c.prepareMove(ctx, session, spaceRef, in)
+	if err != nil {
 		return nil, err
 	}
 
-	return space, nil
+	if !target.hasChanges(space) {
+		return &types.SpaceMoveImpact{
+			Spaces: []types.PathChange{},
+			Repos:  []types.PathChange{},
+		}, nil
+	}
+
+	return c.moveImpact(ctx, space, target)
 }
 
-func (c *Controller) sanitizeMoveInput(in *MoveInput, isRoot bool) error {
+// prepareMove finds the space, sanitizes the move input and checks that the principal
+// is allowed to move the space to its destination.
+func (c *Controller) prepareMove(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MoveInput,
+) (*types.Space, *moveTarget, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, nil, err
+	}
+
+	parentPath, _, err := paths.DisectLeaf(space.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to disect path '%s': %w", space.Path, err)
+	}
+
+	target := &moveTarget{
+		parentID:   space.ParentID,
+		parentPath: parentPath,
+		uid:        space.UID,
+	}
+
+	if in.ParentRef != nil {
+		var parent *types.Space
+		parent, err = c.findMoveParent(ctx, *in.ParentRef)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		target.parentID = 0
+		target.parentPath = ""
+		if parent != nil {
+			target.parentID = parent.ID
+			target.parentPath = parent.Path
+		}
+
+		// the principal has to be allowed to create the space in its new parent
+		if target.parentID != space.ParentID {
+			if err = c.checkAuthMoveParent(ctx, session, parent); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	if in.UID != nil {
-		if err := c.uidCheck(*in.UID, isRoot); err != nil {
+		target.uid = *in.UID
+	}
+
+	if err = c.sanitizeMoveInput(target, in.UID != nil || target.parentID != space.ParentID); err != nil {
+		return nil, nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	return space, target, nil
+}
+
+// findMoveParent returns the new parent space of a move or nil in case the space is moved to the root.
+func (c *Controller) findMoveParent(ctx context.Context, parentRef string) (*types.Space, error) {
+	parentRefAsID, err := strconv.ParseInt(parentRef, 10, 64)
+	if err == nil && parentRefAsID < 0 {
+		return nil, errParentIDNegative
+	}
+	if (err == nil && parentRefAsID == 0) || (len(strings.TrimSpace(parentRef)) == 0) {
+		return nil, nil
+	}
+
+	if !c.nestedSpacesEnabled {
+		// TODO (Nested Spaces): Remove once support is added
+		return nil, errNestedSpacesNotSupported
+	}
+
+	parent, err := c.spaceStore.FindByRef(ctx, parentRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent space: %w", err)
+	}
+
+	return parent, nil
+}
+
+func (c *Controller) checkAuthMoveParent(ctx context.Context, session *auth.Session, parent *types.Space) error {
+	if parent == nil {
+		// TODO: Restrict top level space creation - should be move to authorizer?
+		if session == nil {
+			return fmt.Errorf("anonymous user not allowed to create top level spaces: %w", usererror.ErrUnauthorized)
+		}
+
+		return nil
+	}
+
+	return c.checkAuthSpaceCreation(ctx, session, parent)
+}
+
+func (c *Controller) sanitizeMoveInput(target *moveTarget, uidChanged bool) error {
+	// an unchanged uid could be invalid in the new parent (e.g. when moving a space to the root)
+	if uidChanged {
+		if err := c.uidCheck(target.uid, target.parentID == 0); err != nil {
 			return err
 		}
 	}
@@ -88,46 +246,161 @@ func (c *Controller) sanitizeMoveInput(in *MoveInput, isRoot bool) error {
 	return nil
 }
 
-func (c *Controller) moveInner(
+// moveImpact returns the spaces and repositories whose paths change when moving the space to the target.
+// It fails in case the space would be moved into itself or the new paths would be too deep.
+func (c *Controller) moveImpact(
 	ctx context.Context,
-	session *auth.Session,
 	space *types.Space,
-	inUID *string,
-) error {
-	return c.tx.WithTx(ctx, func(ctx context.Context) error {
-		// delete old primary segment
-		err := c.spacePathStore.DeletePrimarySegment(ctx, space.ID)
-		if err != nil {
-			return fmt.Errorf("failed to delete primary path segment: %w", err)
+	target *moveTarget,
+) (*types.SpaceMoveImpact, error) {
+	nodes, err := c.spaceStore.ListTree(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space tree: %w", err)
+	}
+
+	impact := &types.SpaceMoveImpact{
+		Spaces: make([]types.PathChange, len(nodes)),
+	}
+
+	// nodes are ordered by depth, parents are always resolved before their children.
+	oldPaths := make(map[int64]string, len(nodes))
+	newPaths := make(map[int64]string, len(nodes))
+	spaceIDs := make([]int64, len(nodes))
+	for i, node := range nodes {
+		if node.ID == target.parentID {
+			return nil, usererror.BadRequest("Space can't be moved into itself or one of its subspaces.")
 		}
 
-		// update space with move inputs
-		if inUID != nil {
-			space.UID = *inUID
+		if i == 0 {
+			oldPaths[node.ID] = space.Path
+			newPaths[node.ID] = target.path()
+		} else {
+			oldPaths[node.ID] = paths.Concatinate(oldPaths[node.ParentID], node.UID)
+			newPaths[node.ID] = paths.Concatinate(newPaths[node.ParentID], node.UID)
 		}
 
-		// add new primary segment using updated space data
-		now := time.Now().UnixMilli()
-		newPrimarySegment := &types.SpacePathSegment{
-			ParentID:  space.ParentID,
-			UID:       space.UID,
-			SpaceID:   space.ID,
-			IsPrimary: true,
-			CreatedBy: session.Principal.ID,
-			Created:   now,
-			Updated:   now,
+		if err = check.PathDepth(newPaths[node.ID], true); err != nil {
+			return nil, fmt.Errorf("path of space '%s' is invalid after move: %w", oldPaths[node.ID], err)
 		}
-		err = c.spacePathStore.InsertSegment(ctx, newPrimarySegment)
-		if err != nil {
-			return fmt.Errorf("failed to create new primary path segment: %w", err)
+
+		spaceIDs[i] = node.ID
+		impact.Spaces[i] = types.PathChange{
+			ID:      node.ID,
+			OldPath: oldPaths[node.ID],
+			NewPath: newPaths[node.ID],
 		}
+	}
 
-		// update space itself
-		err = c.spaceStore.Update(ctx, space)
-		if err != nil {
-			return fmt.Errorf("failed to update the space in the db: %w", err)
+	repos, err := c.repoStore.ListByParentIDs(ctx, spaceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	impact.Repos = make([]types.PathChange, len(repos))
+	for i, repo := range repos {
+		newPath := paths.Concatinate(newPaths[repo.ParentID], repo.UID)
+		if err = check.PathDepth(newPath, false); err != nil {
+			return nil, fmt.Errorf("path of repository '%s' is invalid after move: %w", repo.Path, err)
+		}
+
+		impact.Repos[i] = types.PathChange{
+			ID:      repo.ID,
+			OldPath: repo.Path,
+			NewPath: newPath,
+		}
+	}
+
+	return impact, nil
+}
+
+// moveInner moves the space within the transaction of the context.
+func (c *Controller) moveInner(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	target *moveTarget,
+) error {
+	// keep the old primary segment as alias to keep old paths working
+	err := c.spacePathStore.AliasPrimarySegment(ctx, space.ID)
+	if err != nil {
+		return fmt.Errorf("failed to alias primary path segment: %w", err)
+	}
+
+	// the space takes precedence over any alias at its destination (including its own ones)
+	err = c.spacePathStore.DeleteAliasSegment(ctx, target.parentID, target.uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete alias path segment: %w", err)
+	}
+
+	movedToRoot := target.parentID == 0 && space.ParentID != 0
+
+	// update space with move inputs
+	space.ParentID = target.parentID
+	space.UID = target.uid
+
+	// add new primary segment using updated space data
+	now := time.Now().UnixMilli()
+	newPrimarySegment := &types.SpacePathSegment{
+		ParentID:  space.ParentID,
+		UID:       space.UID,
+		SpaceID:   space.ID,
+		IsPrimary: true,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	err = c.spacePathStore.InsertSegment(ctx, newPrimarySegment)
+	if err != nil {
+		return fmt.Errorf("failed to create new primary path segment: %w", err)
+	}
+
+	// update space itself
+	err = c.spaceStore.Update(ctx, space)
+	if err != nil {
+		return fmt.Errorf("failed to update the space in the db: %w", err)
+	}
+
+	// a top level space loses all inherited memberships, ensure the user keeps access (same as on creation)
+	if movedToRoot {
+		if err = c.ensureOwnerMembership(ctx, session, space, now); err != nil {
+			return err
 		}
+	}
 
+	return nil
+}
+
+func (c *Controller) ensureOwnerMembership(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	now int64,
+) error {
+	key := types.MembershipKey{
+		SpaceID:     space.ID,
+		PrincipalID: session.Principal.ID,
+	}
+
+	_, err := c.membershipStore.Find(ctx, key)
+	if err == nil {
 		return nil
-	})
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	membership := &types.Membership{
+		MembershipKey: key,
+		Role:          enum.MembershipRoleSpaceOwner,
+
+		// membership has been created by the system
+		CreatedBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err = c.membershipStore.Create(ctx, membership); err != nil {
+		return fmt.Errorf("failed to make user owner of the space: %w", err)
+	}
+
+	return nil
 }
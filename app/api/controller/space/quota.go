@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateQuotaInput is used for setting the quota of a space.
+type UpdateQuotaInput struct {
+	MaxRepos *int64 `json:"max_repos"`
+	MaxSize  *int64 `json:"max_size"`
+}
+
+func (in *UpdateQuotaInput) sanitize() error {
+	if in.MaxRepos != nil && *in.MaxRepos < 0 {
+		return usererror.BadRequest("The maximum number of repositories can't be negative.")
+	}
+	if in.MaxSize != nil && *in.MaxSize < 0 {
+		return usererror.BadRequest("The maximum storage size can't be negative.")
+	}
+
+	return nil
+}
+
+// QuotaReport returns the usage of a space together with all quotas that apply to it.
+func (c *Controller) QuotaReport(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceQuotaReport, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	usage, err := c.quotaStore.Usage(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage of space: %w", err)
+	}
+
+	quotas, err := c.quotaStore.ListInherited(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotas of space: %w", err)
+	}
+
+	report := &types.SpaceQuotaReport{
+		Usage:  *usage,
+		Quotas: make([]*types.SpaceQuotaUsage, len(quotas)),
+	}
+
+	for i, quota := range quotas {
+		quotaSpace := space
+		quotaUsage := usage
+		if quota.SpaceID != space.ID {
+			quotaSpace, err = c.spaceStore.Find(ctx, quota.SpaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space %d: %w", quota.SpaceID, err)
+			}
+
+			quotaUsage, err = c.quotaStore.Usage(ctx, quota.SpaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get usage of space %d: %w", quota.SpaceID, err)
+			}
+		}
+
+		report.Quotas[i] = &types.SpaceQuotaUsage{
+			SpaceQuota: *quota,
+			Path:       quotaSpace.Path,
+			Usage:      *quotaUsage,
+		}
+	}
+
+	return report, nil
+}
+
+// UpdateQuota sets the quota of a space. Quotas can only be managed by admins.
+func (c *Controller) UpdateQuota(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *UpdateQuotaInput,
+) (*types.SpaceQuota, error) {
+	if !session.Principal.Admin {
+		return nil, apiauth.ErrNotAuthorized
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	err = c.quotaStore.Upsert(ctx, &types.SpaceQuota{
+		SpaceID:   space.ID,
+		MaxRepos:  in.MaxRepos,
+		MaxSize:   in.MaxSize,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update quota of space: %w", err)
+	}
+
+	return c.quotaStore.Find(ctx, space.ID)
+}
+
+// DeleteQuota removes the quota of a space. Quotas can only be managed by admins.
+func (c *Controller) DeleteQuota(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	if !session.Principal.Admin {
+		return apiauth.ErrNotAuthorized
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = c.quotaStore.Delete(ctx, space.ID); err != nil {
+		return fmt.Errorf("failed to delete quota of space: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RestoreInput is used for restoring a space from the trash.
+type RestoreInput struct {
+	NewUID *string `json:"new_uid"`
+}
+
+// Restore restores a space deleted at the provided time from the trash,
+// including all its sub spaces and repositories that were deleted together with it.
+func (c *Controller) Restore(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	deletedAt int64,
+	in *RestoreInput,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRefAndDeleted(ctx, spaceRef, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted space: %w", err)
+	}
+
+	if err = c.checkAuthSpaceRestore(ctx, session, space); err != nil {
+		return nil, err
+	}
+
+	uid := space.UID
+	if in.NewUID != nil {
+		uid = *in.NewUID
+	}
+	if err = c.uidCheck(uid, space.ParentID == 0); err != nil {
+		return nil, err
+	}
+
+	spaceIDs, err := c.spaceStore.ListDeletedTreeIDs(ctx, space.ID, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted sub spaces: %w", err)
+	}
+
+	repos, err := c.repoStore.ListDeletedByParentIDs(ctx, spaceIDs, deletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted repositories: %w", err)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.restoreInner(ctx, session, space, uid, spaceIDs, repos)
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload(fmt.Sprintf("A space with the uid '%s' already exists, "+
+			"provide a new uid to restore the space.", uid))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range spaceIDs {
+		c.spaceEvictor.Evict(ctx, id)
+	}
+	for _, repo := range repos {
+		c.repoEvictor.Evict(ctx, repo.ID)
+	}
+
+	space, err = c.spaceStore.Find(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find restored space: %w", err)
+	}
+
+	// the path might have been occupied by the alias of a moved space
+	c.spacePathCache.Evict(ctx, space.Path)
+
+	return space, nil
+}
+
+// restoreInner restores the space within the transaction of the context.
+func (c *Controller) restoreInner(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	uid string,
+	spaceIDs []int64,
+	repos []*types.Repository,
+) error {
+	// the restored space takes precedence over any alias at its destination
+	err := c.spacePathStore.DeleteAliasSegment(ctx, space.ParentID, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete alias path segment: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = c.spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		ParentID:  space.ParentID,
+		UID:       uid,
+		SpaceID:   space.ID,
+		IsPrimary: true,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create primary path segment: %w", err)
+	}
+
+	for _, id := range spaceIDs {
+		if err = c.spaceStore.Restore(ctx, id, *space.Deleted); err != nil {
+			return fmt.Errorf("failed to restore space %d: %w", id, err)
+		}
+	}
+
+	for _, repo := range repos {
+		if err = c.repoStore.Restore(ctx, repo.ID, *space.Deleted, repo.UID); err != nil {
+			return fmt.Errorf("failed to restore repository %d: %w", repo.ID, err)
+		}
+	}
+
+	if uid == space.UID {
+		return nil
+	}
+
+	restored, err := c.spaceStore.Find(ctx, space.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find restored space: %w", err)
+	}
+
+	restored.UID = uid
+	if err = c.spaceStore.Update(ctx, restored); err != nil {
+		return fmt.Errorf("failed to update the uid of the restored space: %w", err)
+	}
+
+	return nil
+}
+
+// checkAuthSpaceRestore checks whether the principal is allowed to restore the space.
+// The path of a deleted space can't be resolved anymore, hence the permission is checked on the parent space.
+// Top level spaces can only be restored by admins.
+func (c *Controller) checkAuthSpaceRestore(ctx context.Context, session *auth.Session, space *types.Space) error {
+	if space.ParentID == 0 {
+		if !session.Principal.Admin {
+			return apiauth.ErrNotAuthorized
+		}
+		return nil
+	}
+
+	parentSpace, err := c.spaceStore.Find(ctx, space.ParentID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.BadRequest("The parent space is deleted, restore it first.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find parent space: %w", err)
+	}
+
+	return apiauth.CheckSpace(ctx, c.authorizer, session, parentSpace, enum.PermissionSpaceDelete, false)
+}
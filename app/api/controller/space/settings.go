@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"encoding/json"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateSettingInput is used for setting the value of a space setting.
+type UpdateSettingInput struct {
+	Value json.RawMessage `json:"value"`
+	// Locked prevents descendant spaces and repositories from overriding the value.
+	Locked bool `json:"locked"`
+	// Override removes the values defined by descendant spaces and repositories.
+	Override bool `json:"override"`
+}
+
+// ListSettings returns the effective value of all settings of a space.
+func (c *Controller) ListSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.EffectiveSetting, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	return c.settings.SpaceSettings(ctx, space.ID)
+}
+
+// UpdateSetting sets the value of a setting of a space.
+// If ifMatch is provided, it must match the ETag of the current effective value of the setting.
+func (c *Controller) UpdateSetting(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	key enum.SettingKey,
+	in *UpdateSettingInput,
+	ifMatch string,
+) ([]*types.EffectiveSetting, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if len(in.Value) == 0 {
+		return nil, usererror.BadRequest("A value is required.")
+	}
+
+	err = c.settings.UpdateSpace(ctx, session.Principal.ID, space.ID, key,
+		in.Value, in.Locked, in.Override, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.settings.SpaceSettings(ctx, space.ID)
+}
+
+// DeleteSetting removes the value of a setting of a space.
+func (c *Controller) DeleteSetting(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	key enum.SettingKey,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	return c.settings.DeleteSpace(ctx, space.ID, key)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Tree returns the space and all its descendants with the number of repositories,
+// the number of members and the storage used by the repositories of each space.
+// The totals of a node include the repositories of all its descendants.
+func (c *Controller) Tree(ctx context.Context, session *auth.Session, spaceRef string) (*types.SpaceTreeNode, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	nodes, err := c.spaceTreeCache.Get(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space tree: %w", err)
+	}
+
+	return buildSpaceTree(space, nodes)
+}
+
+// buildSpaceTree links the flat list of nodes ordered by depth into a tree rooted at the space.
+// The nodes are copied as they are shared with the cache.
+func buildSpaceTree(space *types.Space, nodes []*types.SpaceTreeNode) (*types.SpaceTreeNode, error) {
+	if len(nodes) == 0 || nodes[0].ID != space.ID {
+		return nil, fmt.Errorf("space tree of space %d doesn't start with the space", space.ID)
+	}
+
+	tree := make([]*types.SpaceTreeNode, len(nodes))
+	byID := make(map[int64]*types.SpaceTreeNode, len(nodes))
+	for i, node := range nodes {
+		n := *node
+		n.Children = []*types.SpaceTreeNode{}
+		n.TotalNumRepos = n.NumRepos
+		n.TotalRepoSize = n.RepoSize
+
+		if i == 0 {
+			n.Path = space.Path
+		} else {
+			parent, ok := byID[n.ParentID]
+			if !ok {
+				return nil, fmt.Errorf("parent %d of space %d not found in space tree", n.ParentID, n.ID)
+			}
+			n.Path = paths.Concatinate(parent.Path, n.UID)
+			parent.Children = append(parent.Children, &n)
+		}
+
+		tree[i] = &n
+		byID[n.ID] = &n
+	}
+
+	// nodes are ordered by depth, walk them backwards to sum up the totals from the leaves to the root.
+	for i := len(tree) - 1; i > 0; i-- {
+		parent := byID[tree[i].ParentID]
+		parent.TotalNumRepos += tree[i].TotalNumRepos
+		parent.TotalRepoSize += tree[i].TotalRepoSize
+	}
+
+	return tree[0], nil
+}
@@ -17,8 +17,12 @@ package space
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	spaceevents "github.com/harness/gitness/app/events/space"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -40,11 +44,15 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore,
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller, membershipStore store.MembershipStore, importer *importer.Repository,
-	exporter *exporter.Repository,
+	exporter *exporter.Repository, spaceTreeCache store.SpaceTreeCache, spacePathCache store.SpacePathCache,
+	spaceEvictor store.SpaceEvictor, repoEvictor store.RepoEvictor, spaceReporter *spaceevents.Reporter,
+	quotaStore store.SpaceQuotaStore, quotaEnforcer *quota.Enforcer, settings *settings.Service,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, uidCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
-		repoCtrl, membershipStore, importer, exporter)
+		repoCtrl, membershipStore, importer, exporter, spaceTreeCache, spacePathCache,
+		spaceEvictor, repoEvictor, spaceReporter, quotaStore, quotaEnforcer, settings, instanceSettings)
 }
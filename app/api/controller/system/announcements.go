@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxAnnouncementMessageLength = 1024
+
+// CreateAnnouncementInput is used for creating an announcement.
+type CreateAnnouncementInput struct {
+	Message     string                    `json:"message"`
+	Severity    enum.AnnouncementSeverity `json:"severity"`
+	Starts      int64                     `json:"starts"`
+	Ends        int64                     `json:"ends"`
+	Dismissible *bool                     `json:"dismissible"`
+}
+
+// UpdateAnnouncementInput is used for updating an announcement.
+type UpdateAnnouncementInput struct {
+	Message     *string                    `json:"message"`
+	Severity    *enum.AnnouncementSeverity `json:"severity"`
+	Starts      *int64                     `json:"starts"`
+	Ends        *int64                     `json:"ends"`
+	Dismissible *bool                      `json:"dismissible"`
+}
+
+// ListAnnouncements lists all announcements, including the scheduled and expired ones.
+func (c *Controller) ListAnnouncements(
+	ctx context.Context,
+	filter types.AnnouncementFilter,
+) ([]*types.Announcement, int64, error) {
+	announcements, err := c.announcementStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list announcements: %w", err)
+	}
+
+	count, err := c.announcementStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count announcements: %w", err)
+	}
+
+	return announcements, count, nil
+}
+
+// FindAnnouncement returns the announcement with the provided id.
+func (c *Controller) FindAnnouncement(ctx context.Context, id int64) (*types.Announcement, error) {
+	announcement, err := c.announcementStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find announcement: %w", err)
+	}
+
+	return announcement, nil
+}
+
+// CreateAnnouncement creates a new announcement.
+func (c *Controller) CreateAnnouncement(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateAnnouncementInput,
+) (*types.Announcement, error) {
+	now := time.Now().UnixMilli()
+	announcement := &types.Announcement{
+		Message:     in.Message,
+		Severity:    in.Severity,
+		Starts:      in.Starts,
+		Ends:        in.Ends,
+		Dismissible: in.Dismissible == nil || *in.Dismissible,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := sanitizeAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+
+	if err := c.announcementStore.Create(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	return announcement, nil
+}
+
+// UpdateAnnouncement updates an existing announcement.
+// Changing the message shows the announcement again to users who already dismissed it.
+func (c *Controller) UpdateAnnouncement(
+	ctx context.Context,
+	id int64,
+	in *UpdateAnnouncementInput,
+) (*types.Announcement, error) {
+	announcement, err := c.FindAnnouncement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldMessage := announcement.Message
+
+	if in.Message != nil {
+		announcement.Message = *in.Message
+	}
+	if in.Severity != nil {
+		announcement.Severity = *in.Severity
+	}
+	if in.Starts != nil {
+		announcement.Starts = *in.Starts
+	}
+	if in.Ends != nil {
+		announcement.Ends = *in.Ends
+	}
+	if in.Dismissible != nil {
+		announcement.Dismissible = *in.Dismissible
+	}
+	announcement.Updated = time.Now().UnixMilli()
+
+	if err = sanitizeAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.announcementStore.Update(ctx, announcement); err != nil {
+			return fmt.Errorf("failed to update announcement: %w", err)
+		}
+
+		if announcement.Message == oldMessage {
+			return nil
+		}
+
+		if err := c.announcementStore.ResetDismissals(ctx, announcement.ID); err != nil {
+			return fmt.Errorf("failed to reset announcement dismissals: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+// DeleteAnnouncement deletes an announcement.
+func (c *Controller) DeleteAnnouncement(ctx context.Context, id int64) error {
+	if _, err := c.FindAnnouncement(ctx, id); err != nil {
+		return err
+	}
+
+	if err := c.announcementStore.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+
+	return nil
+}
+
+func sanitizeAnnouncement(announcement *types.Announcement) error {
+	announcement.Message = strings.TrimSpace(announcement.Message)
+	if announcement.Message == "" {
+		return usererror.BadRequest("The announcement message is required.")
+	}
+	if len(announcement.Message) > maxAnnouncementMessageLength {
+		return usererror.BadRequestf("The announcement message can have at most %d characters.",
+			maxAnnouncementMessageLength)
+	}
+
+	severity, ok := announcement.Severity.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported announcement severity '%s'.", announcement.Severity)
+	}
+	announcement.Severity = severity
+
+	if announcement.Starts < 0 || announcement.Ends < 0 {
+		return usererror.BadRequest("The start and end time of the announcement can't be negative.")
+	}
+	if announcement.Ends != 0 && announcement.Ends <= announcement.Starts {
+		return usererror.BadRequest("The announcement has to end after it starts.")
+	}
+
+	return nil
+}
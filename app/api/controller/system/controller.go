@@ -17,19 +17,73 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/deadletter"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keyrotation"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore    store.PrincipalStore
+	config            *types.Config
+	keyRotation       *keyrotation.Service
+	scheduler         *job.Scheduler
+	jobStore          store.JobStore
+	deadLetterStore   store.EventDeadLetterStore
+	redeliverer       *deadletter.Redeliverer
+	tx                dbtx.Transactor
+	announcementStore store.AnnouncementStore
+	gitTracker        *gitmetrics.Tracker
+	db                *sqlx.DB
+
+	uploadQuarantineStore store.UploadQuarantineStore
+	uploadScan            *uploadscan.Service
+	instanceSettings      *instancesettings.Service
+	drainer               *drain.Service
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	keyRotation *keyrotation.Service,
+	scheduler *job.Scheduler,
+	jobStore store.JobStore,
+	deadLetterStore store.EventDeadLetterStore,
+	redeliverer *deadletter.Redeliverer,
+	tx dbtx.Transactor,
+	announcementStore store.AnnouncementStore,
+	gitTracker *gitmetrics.Tracker,
+	db *sqlx.DB,
+	uploadQuarantineStore store.UploadQuarantineStore,
+	uploadScan *uploadscan.Service,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:    principalStore,
+		config:            config,
+		keyRotation:       keyRotation,
+		scheduler:         scheduler,
+		jobStore:          jobStore,
+		deadLetterStore:   deadLetterStore,
+		redeliverer:       redeliverer,
+		tx:                tx,
+		announcementStore: announcementStore,
+		gitTracker:        gitTracker,
+		db:                db,
+
+		uploadQuarantineStore: uploadQuarantineStore,
+		uploadScan:            uploadScan,
+		instanceSettings:      instanceSettings,
+		drainer:               drainer,
 	}
 }
 
@@ -39,5 +93,14 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return usrCount == 0 || c.config.UserSignupEnabled, nil
+	if usrCount == 0 {
+		return true, nil
+	}
+
+	return c.instanceSettings.UserSignupEnabled(ctx)
+}
+
+// GetMaintenanceMode returns the maintenance mode of the instance.
+func (c *Controller) GetMaintenanceMode(ctx context.Context) (types.MaintenanceMode, error) {
+	return c.instanceSettings.MaintenanceMode(ctx)
 }
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// EventDeadLetterRedelivery contains the result of redelivering a dead-lettered event.
+type EventDeadLetterRedelivery struct {
+	MessageID string `json:"message_id"`
+}
+
+// ListEventDeadLetters lists the dead-lettered events matching the provided filter.
+func (c *Controller) ListEventDeadLetters(
+	ctx context.Context,
+	filter types.EventDeadLetterFilter,
+) ([]*types.EventDeadLetter, int64, error) {
+	deadLetters, err := c.deadLetterStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list event dead letters: %w", err)
+	}
+
+	count, err := c.deadLetterStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count event dead letters: %w", err)
+	}
+
+	return deadLetters, count, nil
+}
+
+// FindEventDeadLetter returns the dead-lettered event with the provided id.
+func (c *Controller) FindEventDeadLetter(ctx context.Context, id int64) (*types.EventDeadLetter, error) {
+	deadLetter, err := c.deadLetterStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find event dead letter: %w", err)
+	}
+
+	return deadLetter, nil
+}
+
+// RedeliverEventDeadLetter sends the dead-lettered event again to the consumer group that failed to process it.
+func (c *Controller) RedeliverEventDeadLetter(ctx context.Context, id int64) (*EventDeadLetterRedelivery, error) {
+	deadLetter, err := c.FindEventDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID, err := c.redeliverer.Redeliver(ctx, deadLetter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver event dead letter: %w", err)
+	}
+
+	return &EventDeadLetterRedelivery{MessageID: messageID}, nil
+}
+
+// DeleteEventDeadLetter discards the dead-lettered event.
+func (c *Controller) DeleteEventDeadLetter(ctx context.Context, id int64) error {
+	if _, err := c.FindEventDeadLetter(ctx, id); err != nil {
+		return err
+	}
+
+	if err := c.deadLetterStore.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete event dead letter: %w", err)
+	}
+
+	return nil
+}
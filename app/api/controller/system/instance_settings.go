@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInstanceSettingInput is used for changing the value of an instance setting.
+type UpdateInstanceSettingInput struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// ListInstanceSettings returns the effective value of all instance settings.
+func (c *Controller) ListInstanceSettings(ctx context.Context) ([]*types.EffectiveInstanceSetting, error) {
+	return c.instanceSettings.List(ctx)
+}
+
+// UpdateInstanceSetting changes the value of an instance setting at runtime.
+// The change is recorded and applies to all instances.
+func (c *Controller) UpdateInstanceSetting(
+	ctx context.Context,
+	session *auth.Session,
+	key enum.InstanceSettingKey,
+	in *UpdateInstanceSettingInput,
+) (*types.EffectiveInstanceSetting, error) {
+	if len(in.Value) == 0 {
+		return nil, usererror.BadRequest("A value is required.")
+	}
+
+	return c.instanceSettings.Update(ctx, session.Principal.ID, key, in.Value)
+}
+
+// ResetInstanceSetting removes the runtime value of an instance setting, the server configuration applies afterwards.
+func (c *Controller) ResetInstanceSetting(
+	ctx context.Context,
+	session *auth.Session,
+	key enum.InstanceSettingKey,
+) error {
+	return c.instanceSettings.Reset(ctx, session.Principal.ID, key)
+}
+
+// ListInstanceSettingChanges returns the recorded changes of instance settings, newest first.
+func (c *Controller) ListInstanceSettingChanges(
+	ctx context.Context,
+	filter types.InstanceSettingChangeFilter,
+) ([]*types.InstanceSettingChange, int64, error) {
+	return c.instanceSettings.ListChanges(ctx, filter)
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/types"
+)
+
+// ListJobs lists the background jobs matching the provided filter.
+func (c *Controller) ListJobs(ctx context.Context, filter types.JobFilter) ([]*types.Job, int64, error) {
+	jobs, err := c.jobStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	count, err := c.jobStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	return jobs, count, nil
+}
+
+// FindJob returns the background job with the provided uid.
+func (c *Controller) FindJob(ctx context.Context, jobUID string) (*types.Job, error) {
+	j, err := c.jobStore.Find(ctx, jobUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+
+	return j, nil
+}
+
+// CancelJob cancels a scheduled or running background job.
+func (c *Controller) CancelJob(ctx context.Context, jobUID string) (*types.Job, error) {
+	err := c.scheduler.CancelJob(ctx, jobUID)
+	if errors.Is(err, job.ErrRecurringJob) {
+		return nil, usererror.BadRequest("Recurring jobs can't be canceled.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	return c.FindJob(ctx, jobUID)
+}
+
+// RetryJob reschedules a failed or canceled background job.
+func (c *Controller) RetryJob(ctx context.Context, jobUID string) (*types.Job, error) {
+	err := c.scheduler.RetryJob(ctx, jobUID)
+	if errors.Is(err, job.ErrRecurringJob) {
+		return nil, usererror.BadRequest("Recurring jobs can't be retried.")
+	}
+	if errors.Is(err, job.ErrNotRetryable) {
+		return nil, usererror.New(http.StatusConflict, "Only failed or canceled jobs can be retried.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry job: %w", err)
+	}
+
+	return c.FindJob(ctx, jobUID)
+}
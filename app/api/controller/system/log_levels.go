@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/loglevel"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GetLogLevels returns the log levels currently used by this instance.
+func (c *Controller) GetLogLevels(_ context.Context) loglevel.Settings {
+	return loglevel.Get()
+}
+
+// UpdateLogLevels updates the log levels used by this instance.
+// The change isn't persisted and only applies to new requests, events and jobs.
+func (c *Controller) UpdateLogLevels(ctx context.Context, in *loglevel.Settings) (loglevel.Settings, error) {
+	if err := loglevel.Update(*in); err != nil {
+		return loglevel.Settings{}, usererror.BadRequest(err.Error())
+	}
+
+	settings := loglevel.Get()
+
+	log.Ctx(ctx).Info().
+		Str("log.default", settings.Default).
+		Interface("log.subsystems", settings.Subsystems).
+		Interface("log.spaces", settings.Spaces).
+		Msg("log levels updated")
+
+	return settings, nil
+}
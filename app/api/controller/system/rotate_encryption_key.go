@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/keyrotation"
+	"github.com/harness/gitness/types"
+)
+
+// RotateEncryptionKey starts the re-encryption of all stored secrets with the current master key.
+func (c *Controller) RotateEncryptionKey(ctx context.Context) (types.JobProgress, error) {
+	err := c.keyRotation.Run(ctx)
+	if errors.Is(err, keyrotation.ErrNotSupported) {
+		return types.JobProgress{}, usererror.BadRequest(
+			"Encryption key rotation requires envelope encryption to be configured.")
+	}
+	if errors.Is(err, keyrotation.ErrAlreadyRunning) {
+		return types.JobProgress{}, usererror.New(http.StatusConflict,
+			"Encryption key rotation is already in progress.")
+	}
+	if err != nil {
+		return types.JobProgress{}, fmt.Errorf("failed to start encryption key rotation: %w", err)
+	}
+
+	return c.EncryptionKeyRotationProgress(ctx)
+}
+
+// EncryptionKeyRotationProgress returns the progress of the latest encryption key rotation.
+func (c *Controller) EncryptionKeyRotationProgress(ctx context.Context) (types.JobProgress, error) {
+	progress, err := c.keyRotation.GetProgress(ctx)
+	if errors.Is(err, keyrotation.ErrNotFound) {
+		return types.JobProgress{}, usererror.NotFound("No recent or ongoing encryption key rotation found.")
+	}
+	if err != nil {
+		return types.JobProgress{}, fmt.Errorf("failed to retrieve encryption key rotation progress: %w", err)
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListUploadQuarantines lists the uploads flagged by the virus scanner matching the provided filter.
+func (c *Controller) ListUploadQuarantines(
+	ctx context.Context,
+	filter types.UploadQuarantineFilter,
+) ([]*types.UploadQuarantine, int64, error) {
+	quarantines, err := c.uploadQuarantineStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list upload quarantines: %w", err)
+	}
+
+	count, err := c.uploadQuarantineStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count upload quarantines: %w", err)
+	}
+
+	return quarantines, count, nil
+}
+
+// FindUploadQuarantine returns the upload flagged by the virus scanner with the provided id.
+func (c *Controller) FindUploadQuarantine(ctx context.Context, id int64) (*types.UploadQuarantine, error) {
+	quarantine, err := c.uploadQuarantineStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find upload quarantine: %w", err)
+	}
+
+	return quarantine, nil
+}
+
+// ReleaseUploadQuarantine marks the quarantined content as safe, so it's accepted when uploaded again.
+func (c *Controller) ReleaseUploadQuarantine(
+	ctx context.Context,
+	session *auth.Session,
+	id int64,
+) (*types.UploadQuarantine, error) {
+	return c.uploadScan.Release(ctx, id, session.Principal.ID)
+}
+
+// DeleteUploadQuarantine discards the quarantined upload, the content is scanned again when it's uploaded.
+func (c *Controller) DeleteUploadQuarantine(ctx context.Context, id int64) error {
+	return c.uploadScan.Delete(ctx, id)
+}
@@ -15,9 +15,19 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/deadletter"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keyrotation"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/google/wire"
 )
 
@@ -26,6 +36,24 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	keyRotation *keyrotation.Service,
+	scheduler *job.Scheduler,
+	jobStore store.JobStore,
+	deadLetterStore store.EventDeadLetterStore,
+	redeliverer *deadletter.Redeliverer,
+	tx dbtx.Transactor,
+	announcementStore store.AnnouncementStore,
+	gitTracker *gitmetrics.Tracker,
+	db *sqlx.DB,
+	uploadQuarantineStore store.UploadQuarantineStore,
+	uploadScan *uploadscan.Service,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
+) *Controller {
+	return NewController(principalStore, config, keyRotation, scheduler, jobStore, deadLetterStore, redeliverer,
+		tx, announcementStore, gitTracker, db, uploadQuarantineStore, uploadScan, instanceSettings,
+		drainer)
 }
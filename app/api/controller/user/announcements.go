@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAnnouncements lists the announcements currently shown to the user.
+func (c *Controller) ListAnnouncements(ctx context.Context, session *auth.Session) ([]*types.Announcement, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	announcements, err := c.announcementStore.ListActive(ctx, session.Principal.ID, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// DismissAnnouncement hides the announcement for the user.
+func (c *Controller) DismissAnnouncement(ctx context.Context, session *auth.Session, id int64) error {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	announcement, err := c.announcementStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find announcement: %w", err)
+	}
+
+	if !announcement.Dismissible {
+		return usererror.BadRequest("The announcement can't be dismissed.")
+	}
+
+	err = c.announcementStore.Dismiss(ctx, announcement.ID, session.Principal.ID, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to dismiss announcement: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAnnouncementStore struct {
+	store.AnnouncementStore
+	announcements []*types.Announcement
+	dismissed     map[int64]int64
+}
+
+func (s *fakeAnnouncementStore) Find(_ context.Context, id int64) (*types.Announcement, error) {
+	for _, announcement := range s.announcements {
+		if announcement.ID == id {
+			return announcement, nil
+		}
+	}
+	return nil, errors.New("announcement not found")
+}
+
+func (s *fakeAnnouncementStore) ListActive(_ context.Context, principalID int64, _ int64) ([]*types.Announcement,
+	error) {
+	var res []*types.Announcement
+	for _, announcement := range s.announcements {
+		if s.dismissed[announcement.ID] != principalID {
+			res = append(res, announcement)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeAnnouncementStore) Dismiss(_ context.Context, id int64, principalID int64, _ int64) error {
+	s.dismissed[id] = principalID
+	return nil
+}
+
+func setupAnnouncementsController() *Controller {
+	return &Controller{
+		authorizer: authz.NewMembershipAuthorizer(nil, nil, nil, nil, fakeAccessRepoStore{}),
+		principalStore: fakePrincipalStore{users: map[int64]*types.User{
+			1: {ID: 1, UID: "alice"},
+		}},
+		announcementStore: &fakeAnnouncementStore{
+			announcements: []*types.Announcement{{ID: 1, Message: "Maintenance on Sunday.", Dismissible: true}},
+			dismissed:     map[int64]int64{},
+		},
+	}
+}
+
+func TestAnnouncementsAccess(t *testing.T) {
+	userSession := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+
+	type operation struct {
+		name string
+		call func(ctx context.Context, c *Controller, session *auth.Session) error
+	}
+
+	list := operation{"list", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.ListAnnouncements(ctx, session)
+		return err
+	}}
+	dismiss := operation{"dismiss", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		return c.DismissAnnouncement(ctx, session, 1)
+	}}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed []operation
+		denied  []operation
+	}{
+		{
+			name:    "user session",
+			session: userSession,
+			allowed: []operation{list, dismiss},
+		},
+		{
+			name:    "oauth openid scope",
+			session: oauthSession(enum.OAuthScopeOpenID),
+			denied:  []operation{list, dismiss},
+		},
+		{
+			name:    "oauth user read scope",
+			session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: []operation{list},
+			denied:  []operation{dismiss},
+		},
+		{
+			name:    "repo git credential",
+			session: repoAccessSession(),
+			denied:  []operation{list, dismiss},
+		},
+	}
+
+	for _, test := range tests {
+		for _, op := range test.allowed {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				if err := op.call(context.Background(), setupAnnouncementsController(), test.session); err != nil {
+					t.Errorf("expected %s to be allowed, got error: %s", op.name, err)
+				}
+			})
+		}
+		for _, op := range test.denied {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				c := setupAnnouncementsController()
+				err := op.call(context.Background(), c, test.session)
+				if !errors.Is(err, apiauth.ErrNotAuthorized) {
+					t.Errorf("expected %s to be denied, got error: %v", op.name, err)
+				}
+				if len(c.announcementStore.(*fakeAnnouncementStore).dismissed) != 0 {
+					t.Errorf("expected %s not to dismiss the announcement", op.name)
+				}
+			})
+		}
+	}
+}
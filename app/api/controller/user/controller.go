@@ -16,8 +16,13 @@ package user
 
 import (
 	"context"
+	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -28,12 +33,25 @@ import (
 )
 
 type Controller struct {
-	tx                dbtx.Transactor
-	principalUIDCheck check.PrincipalUID
-	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
+	tx                 dbtx.Transactor
+	principalUIDCheck  check.PrincipalUID
+	authorizer         authz.Authorizer
+	principalStore     store.PrincipalStore
+	tokenStore         store.TokenStore
+	membershipStore    store.MembershipStore
+	announcementStore  store.AnnouncementStore
+	userReporter       *userevents.Reporter
+	userEmailStore     store.UserEmailStore
+	notificationStore  store.NotificationStore
+	publicKeyStore     store.PublicKeyStore
+	publicKeyService   *publickey.Service
+	repoStore          store.RepoStore
+	repoStarStore      store.RepoStarStore
+	feedEventStore     store.FeedEventStore
+	principalInfoCache store.PrincipalInfoCache
+	pullReqStore       store.PullReqStore
+	contributionStore  store.ContributionStore
+	savedReplyStore    store.SavedReplyStore
 }
 
 func NewController(
@@ -43,14 +61,40 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	announcementStore store.AnnouncementStore,
+	userReporter *userevents.Reporter,
+	userEmailStore store.UserEmailStore,
+	notificationStore store.NotificationStore,
+	publicKeyStore store.PublicKeyStore,
+	publicKeyService *publickey.Service,
+	repoStore store.RepoStore,
+	repoStarStore store.RepoStarStore,
+	feedEventStore store.FeedEventStore,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqStore store.PullReqStore,
+	contributionStore store.ContributionStore,
+	savedReplyStore store.SavedReplyStore,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
+		tx:                 tx,
+		principalUIDCheck:  principalUIDCheck,
+		authorizer:         authorizer,
+		principalStore:     principalStore,
+		tokenStore:         tokenStore,
+		membershipStore:    membershipStore,
+		announcementStore:  announcementStore,
+		userReporter:       userReporter,
+		userEmailStore:     userEmailStore,
+		notificationStore:  notificationStore,
+		publicKeyStore:     publicKeyStore,
+		publicKeyService:   publicKeyService,
+		repoStore:          repoStore,
+		repoStarStore:      repoStarStore,
+		feedEventStore:     feedEventStore,
+		principalInfoCache: principalInfoCache,
+		pullReqStore:       pullReqStore,
+		contributionStore:  contributionStore,
+		savedReplyStore:    savedReplyStore,
 	}
 }
 
@@ -68,6 +112,20 @@ func findUserFromEmail(ctx context.Context,
 	return principalStore.FindUserByEmail(ctx, email)
 }
 
+// checkSessionUser ensures the session has the permission on the user of the session.
+// Sessions with restricted authorization (e.g. OAuth scopes or repo git credentials) are limited accordingly.
+func (c *Controller) checkSessionUser(ctx context.Context,
+	session *auth.Session,
+	permission enum.Permission,
+) error {
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return apiauth.CheckUser(ctx, c.authorizer, session, user, permission)
+}
+
 func isUserTokenType(tokenType enum.TokenType) bool {
 	return tokenType == enum.TokenTypePAT || tokenType == enum.TokenTypeSession
 }
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	userevents "github.com/harness/gitness/app/events/user"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const emailVerificationTokenValidity = 24 * time.Hour
+
+type AddEmailInput struct {
+	Email string `json:"email"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+// ListEmails lists the additional email addresses of the user.
+func (c *Controller) ListEmails(ctx context.Context, session *auth.Session) ([]*types.UserEmail, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	emails, err := c.userEmailStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user emails: %w", err)
+	}
+
+	return emails, nil
+}
+
+// AddEmail adds an unverified email address to the user and requests its verification.
+func (c *Controller) AddEmail(ctx context.Context, session *auth.Session,
+	in *AddEmailInput) (*types.UserEmail, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	in.Email = strings.TrimSpace(in.Email)
+	if err := check.Email(in.Email); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkEmailAvailable(ctx, session.Principal.ID, in.Email); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	email := &types.UserEmail{
+		PrincipalID: session.Principal.ID,
+		Email:       in.Email,
+		Created:     now,
+		Updated:     now,
+	}
+
+	token, err := resetEmailVerificationToken(email, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.userEmailStore.Create(ctx, email)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.BadRequest("The email address was already added.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user email: %w", err)
+	}
+
+	c.reportEmailVerificationRequested(ctx, email, token)
+
+	return email, nil
+}
+
+// ResendEmailVerification requests the verification of an unverified email address again.
+func (c *Controller) ResendEmailVerification(ctx context.Context, session *auth.Session, id int64) error {
+	email, err := c.findUserEmail(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	if email.Verified != nil {
+		return usererror.BadRequest("The email address is already verified.")
+	}
+
+	now := time.Now().UnixMilli()
+
+	token, err := resetEmailVerificationToken(email, now)
+	if err != nil {
+		return err
+	}
+	email.Updated = now
+
+	if err = c.userEmailStore.Update(ctx, email); err != nil {
+		return fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	c.reportEmailVerificationRequested(ctx, email, token)
+
+	return nil
+}
+
+// VerifyEmail verifies an email address of the user using the token sent to that address.
+func (c *Controller) VerifyEmail(ctx context.Context, session *auth.Session,
+	id int64, in *VerifyEmailInput) (*types.UserEmail, error) {
+	email, err := c.findUserEmail(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if email.Verified != nil {
+		return email, nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	if email.VerificationExpires < now ||
+		subtle.ConstantTimeCompare([]byte(hashEmailVerificationToken(in.Token)), []byte(email.VerificationToken)) != 1 {
+		return nil, usererror.BadRequest("The verification token is invalid or expired.")
+	}
+
+	// the address could have been verified or set as primary email by another user in the meantime.
+	if err = c.checkEmailAvailable(ctx, session.Principal.ID, email.Email); err != nil {
+		return nil, err
+	}
+
+	email.Verified = &now
+	email.VerificationToken = ""
+	email.VerificationExpires = 0
+	email.Updated = now
+
+	err = c.userEmailStore.Update(ctx, email)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("The email address is already used by another user.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	return email, nil
+}
+
+// SetPrimaryEmail makes a verified email address the primary email of the user.
+// The previous primary email is kept as verified additional email address.
+func (c *Controller) SetPrimaryEmail(ctx context.Context, session *auth.Session, id int64) (*types.User, error) {
+	email, err := c.findUserEmail(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if email.Verified == nil {
+		return nil, usererror.BadRequest("Only verified email addresses can be set as primary email.")
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = c.principalStore.FindUser(ctx, session.Principal.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+
+		user.Email, email.Email = email.Email, user.Email
+		user.Updated = now
+		email.Verified = &now
+		email.Updated = now
+
+		if err = c.userEmailStore.Update(ctx, email); err != nil {
+			return fmt.Errorf("failed to update user email: %w", err)
+		}
+
+		if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteEmail removes an additional email address of the user.
+func (c *Controller) DeleteEmail(ctx context.Context, session *auth.Session, id int64) error {
+	email, err := c.findUserEmail(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.userEmailStore.Delete(ctx, email.ID); err != nil {
+		return fmt.Errorf("failed to delete user email: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) findUserEmail(ctx context.Context,
+	session *auth.Session,
+	id int64,
+	permission enum.Permission,
+) (*types.UserEmail, error) {
+	if err := c.checkSessionUser(ctx, session, permission); err != nil {
+		return nil, err
+	}
+
+	email, err := c.userEmailStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user email: %w", err)
+	}
+
+	// don't leak the existence of emails of other users.
+	if email.PrincipalID != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return email, nil
+}
+
+// checkEmailAvailable ensures the email address isn't the primary or a verified email of any user.
+func (c *Controller) checkEmailAvailable(ctx context.Context, principalID int64, email string) error {
+	owner, err := c.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	if owner.ID == principalID {
+		return usererror.BadRequest("The email address is already verified for the user.")
+	}
+
+	return usererror.ConflictWithPayload("The email address is already used by another user.")
+}
+
+func (c *Controller) reportEmailVerificationRequested(ctx context.Context, email *types.UserEmail, token string) {
+	c.userReporter.EmailVerificationRequested(ctx, &userevents.EmailVerificationRequestedPayload{
+		UserID:  email.PrincipalID,
+		EmailID: email.ID,
+		Email:   email.Email,
+		Token:   token,
+		Expires: email.VerificationExpires,
+	})
+}
+
+// resetEmailVerificationToken generates a new verification token for the email and returns it.
+// Only the hash of the token is stored with the email.
+func resetEmailVerificationToken(email *types.UserEmail, now int64) (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+
+	token := hex.EncodeToString(buf)
+
+	email.VerificationToken = hashEmailVerificationToken(token)
+	email.VerificationExpires = now + emailVerificationTokenValidity.Milliseconds()
+
+	return token, nil
+}
+
+func hashEmailVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeEmailPrincipalStore struct {
+	fakePrincipalStore
+}
+
+func (s fakeEmailPrincipalStore) UpdateUser(_ context.Context, user *types.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+type fakeUserEmailStore struct {
+	store.UserEmailStore
+	emails map[int64]*types.UserEmail
+}
+
+func (s *fakeUserEmailStore) Find(_ context.Context, id int64) (*types.UserEmail, error) {
+	email, ok := s.emails[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return email, nil
+}
+
+func (s *fakeUserEmailStore) Update(_ context.Context, email *types.UserEmail) error {
+	s.emails[email.ID] = email
+	return nil
+}
+
+func (s *fakeUserEmailStore) List(_ context.Context, principalID int64) ([]*types.UserEmail, error) {
+	var res []*types.UserEmail
+	for _, email := range s.emails {
+		if email.PrincipalID == principalID {
+			res = append(res, email)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeUserEmailStore) Delete(_ context.Context, id int64) error {
+	delete(s.emails, id)
+	return nil
+}
+
+func setupEmailsController() *Controller {
+	verified := int64(1)
+	return &Controller{
+		tx:         fakeTransactor{},
+		authorizer: authz.NewMembershipAuthorizer(nil, nil, nil, nil, fakeAccessRepoStore{}),
+		principalStore: fakeEmailPrincipalStore{fakePrincipalStore{users: map[int64]*types.User{
+			1: {ID: 1, UID: "alice", Email: "alice@example.com"},
+		}}},
+		userEmailStore: &fakeUserEmailStore{
+			emails: map[int64]*types.UserEmail{
+				1: {ID: 1, PrincipalID: 1, Email: "alice@work.example.com", Verified: &verified},
+				2: {ID: 2, PrincipalID: 1, Email: "alice@home.example.com"},
+			},
+		},
+	}
+}
+
+func TestEmailsAccess(t *testing.T) {
+	userSession := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+
+	type operation struct {
+		name string
+		call func(ctx context.Context, c *Controller, session *auth.Session) error
+	}
+
+	list := operation{"list", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.ListEmails(ctx, session)
+		return err
+	}}
+	add := operation{"add", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.AddEmail(ctx, session, &AddEmailInput{Email: "alice@other.example.com"})
+		return err
+	}}
+	resend := operation{"resend", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		return c.ResendEmailVerification(ctx, session, 2)
+	}}
+	verify := operation{"verify", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.VerifyEmail(ctx, session, 2, &VerifyEmailInput{Token: "token"})
+		return err
+	}}
+	setPrimary := operation{"set primary", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.SetPrimaryEmail(ctx, session, 1)
+		return err
+	}}
+	remove := operation{"delete", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		return c.DeleteEmail(ctx, session, 2)
+	}}
+
+	all := []operation{list, add, resend, verify, setPrimary, remove}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed []operation
+		denied  []operation
+	}{
+		{
+			name:    "user session",
+			session: userSession,
+			allowed: []operation{list, setPrimary, remove},
+		},
+		{
+			name:    "oauth openid scope",
+			session: oauthSession(enum.OAuthScopeOpenID),
+			denied:  all,
+		},
+		{
+			name:    "oauth user read scope",
+			session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: []operation{list},
+			denied:  []operation{add, resend, verify, setPrimary, remove},
+		},
+		{
+			name:    "repo git credential",
+			session: repoAccessSession(),
+			denied:  all,
+		},
+	}
+
+	for _, test := range tests {
+		for _, op := range test.allowed {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				if err := op.call(context.Background(), setupEmailsController(), test.session); err != nil {
+					t.Errorf("expected %s to be allowed, got error: %s", op.name, err)
+				}
+			})
+		}
+		for _, op := range test.denied {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				err := op.call(context.Background(), setupEmailsController(), test.session)
+				if !errors.Is(err, apiauth.ErrNotAuthorized) {
+					t.Errorf("expected %s to be denied, got error: %v", op.name, err)
+				}
+			})
+		}
+	}
+}
+
+func TestSetPrimaryEmail(t *testing.T) {
+	c := setupEmailsController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+
+	user, err := c.SetPrimaryEmail(context.Background(), session, 1)
+	if err != nil {
+		t.Fatalf("failed to set primary email: %s", err)
+	}
+	if user.Email != "alice@work.example.com" {
+		t.Errorf("expected the verified email to be primary, got %s", user.Email)
+	}
+
+	previous, _ := c.userEmailStore.Find(context.Background(), 1)
+	if previous.Email != "alice@example.com" || previous.Verified == nil {
+		t.Errorf("expected the previous primary email to be kept as verified email, got %+v", previous)
+	}
+
+	if _, err = c.SetPrimaryEmail(context.Background(), session, 2); err == nil {
+		t.Error("expected unverified email not to be set as primary")
+	}
+}
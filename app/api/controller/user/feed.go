@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListStarredRepos lists the repositories starred by the user that the user can still access.
+func (c *Controller) ListStarredRepos(ctx context.Context,
+	session *auth.Session,
+	pagination types.Pagination,
+) ([]*types.Repository, int, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, 0, err
+	}
+
+	repoIDs, err := c.repoStarStore.ListRepoIDs(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list starred repo ids: %w", err)
+	}
+
+	repos, err := c.listVisibleRepos(ctx, session, repoIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(repos)
+	start := (pagination.Page - 1) * pagination.Size
+	if start < 0 || start > total {
+		start = total
+	}
+	end := start + pagination.Size
+	if end > total {
+		end = total
+	}
+
+	return repos[start:end], total, nil
+}
+
+// ListFeed lists the activity of the repositories starred by the user
+// and of the repositories the user contributed to, newest first.
+func (c *Controller) ListFeed(ctx context.Context,
+	session *auth.Session,
+	pagination types.Pagination,
+) ([]*types.FeedEvent, int64, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, 0, err
+	}
+
+	starredIDs, err := c.repoStarStore.ListRepoIDs(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list starred repo ids: %w", err)
+	}
+
+	contributedIDs, err := c.feedEventStore.ListContributedRepoIDs(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list contributed repo ids: %w", err)
+	}
+
+	repos, err := c.listVisibleRepos(ctx, session, append(starredIDs, contributedIDs...))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(repos) == 0 {
+		return []*types.FeedEvent{}, 0, nil
+	}
+
+	repoPaths := make(map[int64]string, len(repos))
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoPaths[repo.ID] = repo.Path
+		repoIDs[i] = repo.ID
+	}
+
+	feedEvents, err := c.feedEventStore.List(ctx, repoIDs, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list feed events: %w", err)
+	}
+
+	count, err := c.feedEventStore.Count(ctx, repoIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count feed events: %w", err)
+	}
+
+	principalIDs := make([]int64, len(feedEvents))
+	for i, feedEvent := range feedEvents {
+		principalIDs[i] = feedEvent.PrincipalID
+	}
+
+	actors, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load feed event actors: %w", err)
+	}
+
+	for _, feedEvent := range feedEvents {
+		feedEvent.RepoPath = repoPaths[feedEvent.RepoID]
+		feedEvent.Actor = actors[feedEvent.PrincipalID]
+	}
+
+	return feedEvents, count, nil
+}
+
+// listVisibleRepos returns the (deduplicated) repositories with the provided ids the user can view.
+func (c *Controller) listVisibleRepos(
+	ctx context.Context,
+	session *auth.Session,
+	repoIDs []int64,
+) ([]*types.Repository, error) {
+	seen := make(map[int64]struct{}, len(repoIDs))
+	uniqueIDs := make([]int64, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
+
+	repos, err := c.repoStore.ListByIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repos: %w", err)
+	}
+
+	repos, err = apiauth.FilterRepos(ctx, c.authorizer, session, repos, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter repos by access: %w", err)
+	}
+
+	return repos, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeRepoAuthorizer grants access to the repositories of the space "member" only,
+// all other checks are done by the embedded authorizer.
+type fakeRepoAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeRepoAuthorizer) CheckMany(_ context.Context, _ *auth.Session,
+	checks ...types.PermissionCheck) ([]bool, error) {
+	results := make([]bool, len(checks))
+	for i, check := range checks {
+		results[i] = check.Scope.SpacePath == "member"
+	}
+	return results, nil
+}
+
+type fakeFeedRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+	ids   []int64
+}
+
+func (s *fakeFeedRepoStore) ListByIDs(_ context.Context, ids []int64) ([]*types.Repository, error) {
+	s.ids = ids
+	var result []*types.Repository
+	for _, id := range ids {
+		if repo, ok := s.repos[id]; ok {
+			result = append(result, repo)
+		}
+	}
+	return result, nil
+}
+
+type fakeRepoStarStore struct {
+	store.RepoStarStore
+	repoIDs []int64
+}
+
+func (s fakeRepoStarStore) ListRepoIDs(context.Context, int64) ([]int64, error) {
+	return s.repoIDs, nil
+}
+
+type fakeFeedEventStore struct {
+	store.FeedEventStore
+	contributedIDs []int64
+	events         []*types.FeedEvent
+	repoIDs        []int64
+}
+
+func (s *fakeFeedEventStore) ListContributedRepoIDs(context.Context, int64) ([]int64, error) {
+	return s.contributedIDs, nil
+}
+
+func (s *fakeFeedEventStore) List(_ context.Context, repoIDs []int64, _ types.Pagination) ([]*types.FeedEvent,
+	error) {
+	s.repoIDs = repoIDs
+	return s.events, nil
+}
+
+func (s *fakeFeedEventStore) Count(context.Context, []int64) (int64, error) {
+	return int64(len(s.events)), nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	result := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		result[id] = &types.PrincipalInfo{ID: id}
+	}
+	return result, nil
+}
+
+func newFakeRepoAuthorizer() fakeRepoAuthorizer {
+	return fakeRepoAuthorizer{authz.NewMembershipAuthorizer(nil, nil, nil, nil, fakeAccessRepoStore{})}
+}
+
+func feedTestPrincipalStore() fakePrincipalStore {
+	return fakePrincipalStore{users: map[int64]*types.User{1: {ID: 1, UID: "alice"}}}
+}
+
+func feedTestSession() *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+}
+
+func feedTestRepos() map[int64]*types.Repository {
+	return map[int64]*types.Repository{
+		1: {ID: 1, Path: "member/starred"},
+		2: {ID: 2, Path: "member/contributed"},
+		3: {ID: 3, Path: "public/repo", IsPublic: true},
+		4: {ID: 4, Path: "private/repo"},
+	}
+}
+
+func TestListFeed(t *testing.T) {
+	repoStore := &fakeFeedRepoStore{repos: feedTestRepos()}
+	feedEventStore := &fakeFeedEventStore{
+		contributedIDs: []int64{1, 2, 4},
+		events: []*types.FeedEvent{
+			{ID: 2, RepoID: 2, PrincipalID: 8},
+			{ID: 1, RepoID: 1, PrincipalID: 9},
+		},
+	}
+	c := &Controller{
+		authorizer:         newFakeRepoAuthorizer(),
+		principalStore:     feedTestPrincipalStore(),
+		repoStore:          repoStore,
+		repoStarStore:      fakeRepoStarStore{repoIDs: []int64{1, 3}},
+		feedEventStore:     feedEventStore,
+		principalInfoCache: fakePrincipalInfoCache{},
+	}
+
+	events, count, err := c.ListFeed(context.Background(), feedTestSession(), types.Pagination{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []int64{1, 3, 2, 4}; !reflect.DeepEqual(repoStore.ids, want) {
+		t.Errorf("want deduplicated starred and contributed repos %v, got %v", want, repoStore.ids)
+	}
+	if want := []int64{1, 3, 2}; !reflect.DeepEqual(feedEventStore.repoIDs, want) {
+		t.Errorf("want events of the visible repos %v, got %v", want, feedEventStore.repoIDs)
+	}
+	if count != 2 || len(events) != 2 {
+		t.Fatalf("want 2 events, got %d of %d", len(events), count)
+	}
+	if events[0].RepoPath != "member/contributed" || events[0].Actor == nil || events[0].Actor.ID != 8 {
+		t.Errorf("want the event enriched with repo path and actor, got %+v", events[0])
+	}
+}
+
+func TestListFeedWithoutRepos(t *testing.T) {
+	feedEventStore := &fakeFeedEventStore{}
+	c := &Controller{
+		authorizer:     newFakeRepoAuthorizer(),
+		principalStore: feedTestPrincipalStore(),
+		repoStore:      &fakeFeedRepoStore{repos: feedTestRepos()},
+		repoStarStore:  fakeRepoStarStore{repoIDs: []int64{4}},
+		feedEventStore: feedEventStore,
+	}
+
+	events, count, err := c.ListFeed(context.Background(), feedTestSession(), types.Pagination{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(events) != 0 || count != 0 || feedEventStore.repoIDs != nil {
+		t.Errorf("expected an empty feed without listing events")
+	}
+}
+
+func TestListStarredRepos(t *testing.T) {
+	c := &Controller{
+		authorizer:     newFakeRepoAuthorizer(),
+		principalStore: feedTestPrincipalStore(),
+		repoStore:      &fakeFeedRepoStore{repos: feedTestRepos()},
+		repoStarStore:  fakeRepoStarStore{repoIDs: []int64{1, 2, 3, 4}},
+	}
+
+	tests := []struct {
+		pagination types.Pagination
+		want       []int64
+	}{
+		{pagination: types.Pagination{Page: 1, Size: 2}, want: []int64{1, 2}},
+		{pagination: types.Pagination{Page: 2, Size: 2}, want: []int64{3}},
+		{pagination: types.Pagination{Page: 3, Size: 2}, want: []int64{}},
+	}
+
+	for _, test := range tests {
+		repos, total, err := c.ListStarredRepos(context.Background(), feedTestSession(), test.pagination)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		ids := make([]int64, len(repos))
+		for i, repo := range repos {
+			ids[i] = repo.ID
+		}
+		if total != 3 || !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("page %d: want repos %v of 3, got %v of %d", test.pagination.Page, test.want, ids, total)
+		}
+	}
+}
+
+func TestFeedAccess(t *testing.T) {
+	c := &Controller{
+		authorizer:         newFakeRepoAuthorizer(),
+		principalStore:     feedTestPrincipalStore(),
+		repoStore:          &fakeFeedRepoStore{repos: feedTestRepos()},
+		repoStarStore:      fakeRepoStarStore{repoIDs: []int64{1}},
+		feedEventStore:     &fakeFeedEventStore{},
+		principalInfoCache: fakePrincipalInfoCache{},
+	}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed bool
+	}{
+		{name: "user session", session: feedTestSession(), allowed: true},
+		{name: "oauth openid scope", session: oauthSession(enum.OAuthScopeOpenID), allowed: false},
+		{name: "oauth user read scope", session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: true},
+		{name: "repo git credential", session: repoAccessSession(), allowed: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, errFeed := c.ListFeed(context.Background(), test.session, types.Pagination{Page: 1, Size: 10})
+			_, _, errStarred := c.ListStarredRepos(context.Background(), test.session,
+				types.Pagination{Page: 1, Size: 10})
+
+			for name, err := range map[string]error{"feed": errFeed, "starred repos": errStarred} {
+				switch {
+				case test.allowed && err != nil:
+					t.Errorf("expected %s to be allowed, got error: %s", name, err)
+				case !test.allowed && !errors.Is(err, apiauth.ErrNotAuthorized):
+					t.Errorf("expected %s to be denied, got error: %v", name, err)
+				}
+			}
+		})
+	}
+}
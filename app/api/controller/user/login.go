@@ -27,6 +27,8 @@ import (
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
@@ -35,6 +37,8 @@ import (
 type LoginInput struct {
 	LoginIdentifier string `json:"login_identifier"`
 	Password        string `json:"password"`
+	// NewPassword replaces the password of users that are required to reset it.
+	NewPassword *string `json:"new_password"`
 }
 
 /*
@@ -69,6 +73,22 @@ func (c *Controller) Login(ctx context.Context, session *auth.Session,
 		return nil, usererror.ErrNotFound
 	}
 
+	now := time.Now().UnixMilli()
+	switch user.State(now) {
+	case enum.PrincipalStateBlocked:
+		return nil, usererror.Forbidden("The account is blocked.")
+	case enum.PrincipalStateSuspended:
+		return nil, usererror.Forbidden(fmt.Sprintf("The account is suspended until %s.",
+			time.UnixMilli(user.SuspendedUntil).UTC().Format(time.RFC3339)))
+	case enum.PrincipalStateActive:
+	}
+
+	if user.PasswordResetRequired {
+		if err = c.resetPassword(ctx, user, in.NewPassword, now); err != nil {
+			return nil, err
+		}
+	}
+
 	tokenUID, err := generateSessionTokenUID()
 	if err != nil {
 		return nil, err
@@ -81,6 +101,35 @@ func (c *Controller) Login(ctx context.Context, session *auth.Session,
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
+func (c *Controller) resetPassword(ctx context.Context, user *types.User, newPassword *string, now int64) error {
+	if newPassword == nil {
+		return usererror.Forbidden("A password reset is required, please provide a new password.")
+	}
+
+	if err := check.Password(*newPassword); err != nil {
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(*newPassword)) == nil {
+		return usererror.BadRequest("The new password has to be different from the current password.")
+	}
+
+	hash, err := hashPassword([]byte(*newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user.Password = string(hash)
+	user.PasswordResetRequired = false
+	user.Updated = now
+
+	if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
 func generateSessionTokenUID() (string, error) {
 	r, err := rand.Int(rand.Reader, big.NewInt(10000))
 	if err != nil {
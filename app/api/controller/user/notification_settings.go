@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateNotificationSettingsInput struct {
+	Delivery        *enum.NotificationDelivery `json:"delivery"`
+	ReviewRequested *bool                      `json:"review_requested"`
+	Comments        *bool                      `json:"comments"`
+	Merged          *bool                      `json:"merged"`
+	PipelineFailed  *bool                      `json:"pipeline_failed"`
+}
+
+func (in *UpdateNotificationSettingsInput) sanitize() error {
+	if in.Delivery != nil {
+		delivery, ok := in.Delivery.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Notification delivery '%s' is not supported.", *in.Delivery)
+		}
+		in.Delivery = &delivery
+	}
+
+	return nil
+}
+
+// FindNotificationSettings returns the email notification settings of the current user.
+func (c *Controller) FindNotificationSettings(ctx context.Context,
+	session *auth.Session) (*types.NotificationSettings, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.findNotificationSettings(ctx, session.Principal.ID)
+}
+
+// UpdateNotificationSettings updates the email notification settings of the current user.
+func (c *Controller) UpdateNotificationSettings(ctx context.Context, session *auth.Session,
+	in *UpdateNotificationSettingsInput) (*types.NotificationSettings, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.findNotificationSettings(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Delivery != nil {
+		settings.Delivery = *in.Delivery
+	}
+	if in.ReviewRequested != nil {
+		settings.ReviewRequested = *in.ReviewRequested
+	}
+	if in.Comments != nil {
+		settings.Comments = *in.Comments
+	}
+	if in.Merged != nil {
+		settings.Merged = *in.Merged
+	}
+	if in.PipelineFailed != nil {
+		settings.PipelineFailed = *in.PipelineFailed
+	}
+
+	settings.Updated = time.Now().UnixMilli()
+
+	err = c.notificationStore.UpsertSettings(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (c *Controller) findNotificationSettings(ctx context.Context,
+	principalID int64) (*types.NotificationSettings, error) {
+	settings, err := c.notificationStore.FindSettings(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return types.DefaultNotificationSettings(principalID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeNotificationStore struct {
+	store.NotificationStore
+	settings map[int64]*types.NotificationSettings
+}
+
+func (s *fakeNotificationStore) FindSettings(_ context.Context, principalID int64) (*types.NotificationSettings,
+	error) {
+	settings, ok := s.settings[principalID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return settings, nil
+}
+
+func (s *fakeNotificationStore) UpsertSettings(_ context.Context, settings *types.NotificationSettings) error {
+	s.settings[settings.PrincipalID] = settings
+	return nil
+}
+
+func setupNotificationSettingsController() *Controller {
+	return &Controller{
+		authorizer: authz.NewMembershipAuthorizer(nil, nil, nil, nil, fakeAccessRepoStore{}),
+		principalStore: fakePrincipalStore{users: map[int64]*types.User{
+			1: {ID: 1, UID: "alice"},
+		}},
+		notificationStore: &fakeNotificationStore{settings: map[int64]*types.NotificationSettings{}},
+	}
+}
+
+func TestNotificationSettingsAccess(t *testing.T) {
+	userSession := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+	disabled := false
+
+	type operation struct {
+		name string
+		call func(ctx context.Context, c *Controller, session *auth.Session) error
+	}
+
+	find := operation{"find", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.FindNotificationSettings(ctx, session)
+		return err
+	}}
+	update := operation{"update", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.UpdateNotificationSettings(ctx, session, &UpdateNotificationSettingsInput{Comments: &disabled})
+		return err
+	}}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed []operation
+		denied  []operation
+	}{
+		{
+			name:    "user session",
+			session: userSession,
+			allowed: []operation{find, update},
+		},
+		{
+			name:    "oauth openid scope",
+			session: oauthSession(enum.OAuthScopeOpenID),
+			denied:  []operation{find, update},
+		},
+		{
+			name:    "oauth user read scope",
+			session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: []operation{find},
+			denied:  []operation{update},
+		},
+		{
+			name:    "repo git credential",
+			session: repoAccessSession(),
+			denied:  []operation{find, update},
+		},
+	}
+
+	for _, test := range tests {
+		for _, op := range test.allowed {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				c := setupNotificationSettingsController()
+				if err := op.call(context.Background(), c, test.session); err != nil {
+					t.Errorf("expected %s to be allowed, got error: %s", op.name, err)
+				}
+			})
+		}
+		for _, op := range test.denied {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				c := setupNotificationSettingsController()
+				err := op.call(context.Background(), c, test.session)
+				if !errors.Is(err, apiauth.ErrNotAuthorized) {
+					t.Errorf("expected %s to be denied, got error: %v", op.name, err)
+				}
+				if len(c.notificationStore.(*fakeNotificationStore).settings) != 0 {
+					t.Errorf("expected %s not to change the settings", op.name)
+				}
+			})
+		}
+	}
+}
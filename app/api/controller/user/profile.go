@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// profileContributionDays is the number of days (including today) of contributions returned for user profiles.
+const profileContributionDays = 365
+
+// FindProfile returns the profile of the user with the contributions of the past year.
+// Only contributions to repositories the current principal can view are taken into account.
+func (c *Controller) FindProfile(ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.Profile, error) {
+	user, repoIDs, err := c.getProfileUserAndRepos(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).
+		AddDate(0, 0, 1-profileContributionDays)
+
+	contributions, err := c.contributionStore.List(ctx, user.ID, since.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributions: %w", err)
+	}
+
+	profile := &types.Profile{
+		User:          user.ToPrincipalInfo(),
+		Contributions: []types.ContributionDay{},
+		Totals:        map[enum.ContributionType]int{},
+	}
+
+	// contributions are ordered by creation time, so all contributions of a day are consecutive.
+	for _, contribution := range contributions {
+		if _, ok := repoIDs[contribution.RepoID]; !ok {
+			continue
+		}
+
+		profile.Totals[contribution.Type]++
+
+		date := time.UnixMilli(contribution.Created).UTC().Format("2006-01-02")
+		if n := len(profile.Contributions); n > 0 && profile.Contributions[n-1].Date == date {
+			profile.Contributions[n-1].Count++
+			continue
+		}
+
+		profile.Contributions = append(profile.Contributions, types.ContributionDay{Date: date, Count: 1})
+	}
+
+	return profile, nil
+}
+
+// ListProfilePullReqs lists the pull requests opened by the user
+// in the repositories the current principal can view.
+func (c *Controller) ListProfilePullReqs(ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	filter *types.PullReqFilter,
+) ([]*types.PullReq, int64, error) {
+	user, repoIDs, err := c.getProfileUserAndRepos(ctx, session, userUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.listProfilePullReqs(ctx, repoIDs, &types.PullReqFilter{
+		Page:      filter.Page,
+		Size:      filter.Size,
+		Query:     filter.Query,
+		States:    filter.States,
+		Sort:      filter.Sort,
+		Order:     filter.Order,
+		CreatedBy: user.ID,
+	})
+}
+
+// ListProfileReviews lists the pull requests reviewed by the user
+// in the repositories the current principal can view.
+func (c *Controller) ListProfileReviews(ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	filter *types.PullReqFilter,
+) ([]*types.PullReq, int64, error) {
+	user, repoIDs, err := c.getProfileUserAndRepos(ctx, session, userUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.listProfilePullReqs(ctx, repoIDs, &types.PullReqFilter{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		Query:      filter.Query,
+		States:     filter.States,
+		Sort:       filter.Sort,
+		Order:      filter.Order,
+		ReviewedBy: user.ID,
+	})
+}
+
+func (c *Controller) listProfilePullReqs(ctx context.Context,
+	repoIDs map[int64]struct{},
+	filter *types.PullReqFilter,
+) ([]*types.PullReq, int64, error) {
+	if len(repoIDs) == 0 {
+		return []*types.PullReq{}, 0, nil
+	}
+
+	filter.TargetRepoIDs = make([]int64, 0, len(repoIDs))
+	for id := range repoIDs {
+		filter.TargetRepoIDs = append(filter.TargetRepoIDs, id)
+	}
+
+	pullReqs, err := c.pullReqStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	count, err := c.pullReqStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pull requests: %w", err)
+	}
+
+	return pullReqs, count, nil
+}
+
+// getProfileUserAndRepos returns the user together with the ids of the repositories
+// the user contributed to that the current principal can view.
+func (c *Controller) getProfileUserAndRepos(ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.User, map[int64]struct{}, error) {
+	if session == nil {
+		return nil, nil, usererror.ErrUnauthorized
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	contributedIDs, err := c.contributionStore.ListRepoIDs(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list contributed repo ids: %w", err)
+	}
+
+	repos, err := c.listVisibleRepos(ctx, session, contributedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repoIDs := make(map[int64]struct{}, len(repos))
+	for _, repo := range repos {
+		repoIDs[repo.ID] = struct{}{}
+	}
+
+	return user, repoIDs, nil
+}
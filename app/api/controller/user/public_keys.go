@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publickey"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxPublicKeyIdentifierLength = 100
+
+type CreatePublicKeyInput struct {
+	Identifier string `json:"identifier"`
+	Content    string `json:"content"`
+}
+
+// ListPublicKeys lists the public keys of the user.
+func (c *Controller) ListPublicKeys(ctx context.Context, session *auth.Session) ([]*types.PublicKey, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	keys, err := c.publicKeyStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CreatePublicKey adds a PGP or SSH public key to the user, which is used to verify commit signatures.
+func (c *Controller) CreatePublicKey(ctx context.Context, session *auth.Session,
+	in *CreatePublicKeyInput) (*types.PublicKey, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if in.Identifier == "" || len(in.Identifier) > maxPublicKeyIdentifierLength {
+		return nil, usererror.BadRequestf("Identifier has to be between 1 and %d characters long.",
+			maxPublicKeyIdentifierLength)
+	}
+
+	parsed, err := publickey.ParseKey(in.Content)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid public key: %s", err)
+	}
+
+	key := &types.PublicKey{
+		PrincipalID: session.Principal.ID,
+		Identifier:  in.Identifier,
+		Scheme:      parsed.Scheme,
+		Fingerprint: parsed.Fingerprint,
+		Content:     strings.TrimSpace(in.Content),
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.publicKeyStore.Create(ctx, key)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("The public key was already added.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	// signatures made with the key might have been cached as made by an unknown key.
+	if err = c.publicKeyService.Invalidate(ctx, parsed); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// DeletePublicKey removes a public key of the user.
+func (c *Controller) DeletePublicKey(ctx context.Context, session *auth.Session, id int64) error {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	key, err := c.publicKeyStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	// don't leak the existence of keys of other users.
+	if key.PrincipalID != session.Principal.ID {
+		return usererror.ErrNotFound
+	}
+
+	if err = c.publicKeyStore.Delete(ctx, key.ID); err != nil {
+		return fmt.Errorf("failed to delete public key: %w", err)
+	}
+
+	parsed, err := publickey.ParseKey(key.Content)
+	if err != nil {
+		return fmt.Errorf("failed to parse stored public key: %w", err)
+	}
+
+	if err = c.publicKeyService.Invalidate(ctx, parsed); err != nil {
+		return err
+	}
+
+	return nil
+}
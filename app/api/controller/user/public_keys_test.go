@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeAccessRepoStore returns the repo a repo git credential grants access to.
+type fakeAccessRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeAccessRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, Path: "space/repo"}, nil
+}
+
+type fakePublicKeyStore struct {
+	store.PublicKeyStore
+	keys   map[int64]*types.PublicKey
+	nextID int64
+}
+
+func (s *fakePublicKeyStore) Find(_ context.Context, id int64) (*types.PublicKey, error) {
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return key, nil
+}
+
+func (s *fakePublicKeyStore) Create(_ context.Context, key *types.PublicKey) error {
+	s.nextID++
+	key.ID = s.nextID
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *fakePublicKeyStore) Delete(_ context.Context, id int64) error {
+	delete(s.keys, id)
+	return nil
+}
+
+func (s *fakePublicKeyStore) List(_ context.Context, principalID int64) ([]*types.PublicKey, error) {
+	var res []*types.PublicKey
+	for _, key := range s.keys {
+		if key.PrincipalID == principalID {
+			res = append(res, key)
+		}
+	}
+	return res, nil
+}
+
+type fakeCommitVerificationStore struct {
+	store.CommitVerificationStore
+}
+
+func (fakeCommitVerificationStore) DeleteByKeyIDs(context.Context, []string) error {
+	return nil
+}
+
+func repoAccessSession() *auth.Session {
+	return &auth.Session{
+		Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser},
+		Metadata:  &auth.RepoAccessMetadata{RepoID: 1, Role: enum.MembershipRoleSpaceOwner},
+	}
+}
+
+func newSSHPublicKey(t *testing.T) string {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %s", err)
+	}
+
+	return string(ssh.MarshalAuthorizedKey(sshPub))
+}
+
+func setupPublicKeysController(t *testing.T) *Controller {
+	t.Helper()
+
+	content := newSSHPublicKey(t)
+	parsed, err := publickey.ParseKey(content)
+	if err != nil {
+		t.Fatalf("failed to parse key: %s", err)
+	}
+
+	return &Controller{
+		authorizer: authz.NewMembershipAuthorizer(nil, nil, nil, nil, fakeAccessRepoStore{}),
+		principalStore: fakePrincipalStore{users: map[int64]*types.User{
+			1: {ID: 1, UID: "alice"},
+		}},
+		publicKeyStore: &fakePublicKeyStore{
+			keys: map[int64]*types.PublicKey{
+				1: {ID: 1, PrincipalID: 1, Identifier: "laptop", Scheme: parsed.Scheme,
+					Fingerprint: parsed.Fingerprint, Content: content},
+			},
+			nextID: 1,
+		},
+		publicKeyService: publickey.NewService(nil, fakeCommitVerificationStore{}, nil, nil),
+	}
+}
+
+func TestPublicKeysAccess(t *testing.T) {
+	userSession := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+
+	type operation struct {
+		name string
+		call func(ctx context.Context, c *Controller, session *auth.Session) error
+	}
+
+	list := operation{"list", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.ListPublicKeys(ctx, session)
+		return err
+	}}
+	create := operation{"create", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.CreatePublicKey(ctx, session, &CreatePublicKeyInput{
+			Identifier: "desktop",
+			Content:    newSSHPublicKey(t),
+		})
+		return err
+	}}
+	remove := operation{"delete", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		return c.DeletePublicKey(ctx, session, 1)
+	}}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed []operation
+		denied  []operation
+	}{
+		{
+			name:    "user session",
+			session: userSession,
+			allowed: []operation{list, create, remove},
+		},
+		{
+			name:    "oauth openid scope",
+			session: oauthSession(enum.OAuthScopeOpenID),
+			denied:  []operation{list, create, remove},
+		},
+		{
+			name:    "oauth user read scope",
+			session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: []operation{list},
+			denied:  []operation{create, remove},
+		},
+		{
+			name:    "repo git credential",
+			session: repoAccessSession(),
+			denied:  []operation{list, create, remove},
+		},
+	}
+
+	for _, test := range tests {
+		for _, op := range test.allowed {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				if err := op.call(context.Background(), setupPublicKeysController(t), test.session); err != nil {
+					t.Errorf("expected %s to be allowed, got error: %s", op.name, err)
+				}
+			})
+		}
+		for _, op := range test.denied {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				err := op.call(context.Background(), setupPublicKeysController(t), test.session)
+				if !errors.Is(err, apiauth.ErrNotAuthorized) {
+					t.Errorf("expected %s to be denied, got error: %v", op.name, err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxSavedReplyIdentifierLength = 100
+	maxSavedReplyContentLength    = 65536
+)
+
+type CreateSavedReplyInput struct {
+	Identifier string `json:"identifier"`
+	Content    string `json:"content"`
+}
+
+func (in *CreateSavedReplyInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if err := checkSavedReplyIdentifier(in.Identifier); err != nil {
+		return err
+	}
+
+	return checkSavedReplyContent(in.Content)
+}
+
+type UpdateSavedReplyInput struct {
+	Identifier *string `json:"identifier"`
+	Content    *string `json:"content"`
+}
+
+func (in *UpdateSavedReplyInput) sanitize() error {
+	if in.Identifier != nil {
+		*in.Identifier = strings.TrimSpace(*in.Identifier)
+		if err := checkSavedReplyIdentifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.Content != nil {
+		if err := checkSavedReplyContent(*in.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkSavedReplyIdentifier(identifier string) error {
+	if identifier == "" || len(identifier) > maxSavedReplyIdentifierLength {
+		return usererror.BadRequestf("Identifier has to be between 1 and %d characters long.",
+			maxSavedReplyIdentifierLength)
+	}
+
+	return nil
+}
+
+func checkSavedReplyContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return usererror.BadRequest("Content can't be empty.")
+	}
+	if len(content) > maxSavedReplyContentLength {
+		return usererror.BadRequestf("Content can't be longer than %d characters.", maxSavedReplyContentLength)
+	}
+
+	return nil
+}
+
+// ListSavedReplies lists the saved replies of the user.
+func (c *Controller) ListSavedReplies(ctx context.Context,
+	session *auth.Session,
+	filter types.ListQueryFilter,
+) ([]*types.SavedReply, int64, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, 0, err
+	}
+
+	replies, err := c.savedReplyStore.List(ctx, session.Principal.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list saved replies: %w", err)
+	}
+
+	count, err := c.savedReplyStore.Count(ctx, session.Principal.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count saved replies: %w", err)
+	}
+
+	return replies, count, nil
+}
+
+// FindSavedReply returns a saved reply of the user.
+func (c *Controller) FindSavedReply(ctx context.Context,
+	session *auth.Session,
+	id int64,
+) (*types.SavedReply, error) {
+	return c.getSavedReply(ctx, session, id, enum.PermissionUserView)
+}
+
+// CreateSavedReply adds a saved reply to the user.
+func (c *Controller) CreateSavedReply(ctx context.Context,
+	session *auth.Session,
+	in *CreateSavedReplyInput,
+) (*types.SavedReply, error) {
+	if err := c.checkSessionUser(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	reply := &types.SavedReply{
+		PrincipalID: session.Principal.ID,
+		Identifier:  in.Identifier,
+		Content:     in.Content,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err := c.savedReplyStore.Create(ctx, reply)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("A saved reply with this identifier already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+// UpdateSavedReply updates a saved reply of the user.
+func (c *Controller) UpdateSavedReply(ctx context.Context,
+	session *auth.Session,
+	id int64,
+	in *UpdateSavedReplyInput,
+) (*types.SavedReply, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	reply, err := c.getSavedReply(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Identifier != nil {
+		reply.Identifier = *in.Identifier
+	}
+	if in.Content != nil {
+		reply.Content = *in.Content
+	}
+	reply.Updated = time.Now().UnixMilli()
+
+	err = c.savedReplyStore.Update(ctx, reply)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.ConflictWithPayload("A saved reply with this identifier already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update saved reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+// DeleteSavedReply removes a saved reply of the user.
+func (c *Controller) DeleteSavedReply(ctx context.Context,
+	session *auth.Session,
+	id int64,
+) error {
+	reply, err := c.getSavedReply(ctx, session, id, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.savedReplyStore.Delete(ctx, reply.ID); err != nil {
+		return fmt.Errorf("failed to delete saved reply: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) getSavedReply(ctx context.Context,
+	session *auth.Session,
+	id int64,
+	permission enum.Permission,
+) (*types.SavedReply, error) {
+	if err := c.checkSessionUser(ctx, session, permission); err != nil {
+		return nil, err
+	}
+
+	reply, err := c.savedReplyStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find saved reply: %w", err)
+	}
+
+	// don't leak the existence of saved replies of other users.
+	if reply.PrincipalID != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return reply, nil
+}
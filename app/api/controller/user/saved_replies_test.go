@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	users map[int64]*types.User
+}
+
+func (s fakePrincipalStore) FindUser(_ context.Context, id int64) (*types.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return user, nil
+}
+
+type fakeSavedReplyStore struct {
+	store.SavedReplyStore
+	replies map[int64]*types.SavedReply
+	nextID  int64
+}
+
+func (s *fakeSavedReplyStore) Find(_ context.Context, id int64) (*types.SavedReply, error) {
+	reply, ok := s.replies[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return reply, nil
+}
+
+func (s *fakeSavedReplyStore) Create(_ context.Context, reply *types.SavedReply) error {
+	s.nextID++
+	reply.ID = s.nextID
+	s.replies[reply.ID] = reply
+	return nil
+}
+
+func (s *fakeSavedReplyStore) Update(_ context.Context, reply *types.SavedReply) error {
+	s.replies[reply.ID] = reply
+	return nil
+}
+
+func (s *fakeSavedReplyStore) Delete(_ context.Context, id int64) error {
+	delete(s.replies, id)
+	return nil
+}
+
+func (s *fakeSavedReplyStore) List(
+	_ context.Context,
+	principalID int64,
+	_ types.ListQueryFilter,
+) ([]*types.SavedReply, error) {
+	var res []*types.SavedReply
+	for _, reply := range s.replies {
+		if reply.PrincipalID == principalID {
+			res = append(res, reply)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeSavedReplyStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	res, err := s.List(ctx, principalID, filter)
+	return int64(len(res)), err
+}
+
+func setupSavedRepliesController() *Controller {
+	return &Controller{
+		authorizer: authz.NewMembershipAuthorizer(nil, nil, nil, nil, nil),
+		principalStore: fakePrincipalStore{users: map[int64]*types.User{
+			1: {ID: 1, UID: "alice"},
+			2: {ID: 2, UID: "bob"},
+		}},
+		savedReplyStore: &fakeSavedReplyStore{
+			replies: map[int64]*types.SavedReply{
+				1: {ID: 1, PrincipalID: 1, Identifier: "lgtm", Content: "Looks good to me."},
+				2: {ID: 2, PrincipalID: 2, Identifier: "nit", Content: "Nitpick."},
+			},
+			nextID: 2,
+		},
+	}
+}
+
+func oauthSession(scopes ...enum.OAuthScope) *auth.Session {
+	return &auth.Session{
+		Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser},
+		Metadata:  &auth.OAuthMetadata{AppID: 1, AuthorizationID: 1, Scopes: scopes},
+	}
+}
+
+func TestSavedRepliesAccess(t *testing.T) {
+	userSession := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+	content := "Updated."
+
+	type operation struct {
+		name string
+		call func(ctx context.Context, c *Controller, session *auth.Session) error
+	}
+
+	list := operation{"list", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, _, err := c.ListSavedReplies(ctx, session, types.ListQueryFilter{})
+		return err
+	}}
+	find := operation{"find", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.FindSavedReply(ctx, session, 1)
+		return err
+	}}
+	create := operation{"create", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.CreateSavedReply(ctx, session, &CreateSavedReplyInput{Identifier: "new", Content: "New."})
+		return err
+	}}
+	update := operation{"update", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		_, err := c.UpdateSavedReply(ctx, session, 1, &UpdateSavedReplyInput{Content: &content})
+		return err
+	}}
+	remove := operation{"delete", func(ctx context.Context, c *Controller, session *auth.Session) error {
+		return c.DeleteSavedReply(ctx, session, 1)
+	}}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		allowed []operation
+		denied  []operation
+	}{
+		{
+			name:    "user session",
+			session: userSession,
+			allowed: []operation{list, find, create, update, remove},
+		},
+		{
+			name:    "oauth openid scope",
+			session: oauthSession(enum.OAuthScopeOpenID),
+			denied:  []operation{list, find, create, update, remove},
+		},
+		{
+			name:    "oauth user read scope",
+			session: oauthSession(enum.OAuthScopeOpenID, enum.OAuthScopeUserRead),
+			allowed: []operation{list, find},
+			denied:  []operation{create, update, remove},
+		},
+	}
+
+	for _, test := range tests {
+		for _, op := range test.allowed {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				if err := op.call(context.Background(), setupSavedRepliesController(), test.session); err != nil {
+					t.Errorf("expected %s to be allowed, got error: %s", op.name, err)
+				}
+			})
+		}
+		for _, op := range test.denied {
+			t.Run(test.name+"/"+op.name, func(t *testing.T) {
+				err := op.call(context.Background(), setupSavedRepliesController(), test.session)
+				if !errors.Is(err, apiauth.ErrNotAuthorized) {
+					t.Errorf("expected %s to be denied, got error: %v", op.name, err)
+				}
+			})
+		}
+	}
+}
+
+func TestSavedReplyOfOtherUserNotFound(t *testing.T) {
+	c := setupSavedRepliesController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "alice", Type: enum.PrincipalTypeUser}}
+
+	if _, err := c.FindSavedReply(context.Background(), session, 2); !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("expected not found for saved reply of other user, got error: %v", err)
+	}
+	if err := c.DeleteSavedReply(context.Background(), session, 2); !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("expected not found for saved reply of other user, got error: %v", err)
+	}
+}
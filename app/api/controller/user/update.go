@@ -66,6 +66,7 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
 		user.Password = string(hash)
+		user.PasswordResetRequired = false
 	}
 	user.Updated = time.Now().UnixMilli()
 
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+)
+
+type UpdateStateInput struct {
+	State enum.PrincipalState `json:"state"`
+	// SuspendedUntil is the time until which the user is suspended, required for the suspended state.
+	SuspendedUntil int64 `json:"suspended_until"`
+}
+
+// UpdateState blocks, suspends or reactivates a user.
+// Blocked and suspended users can't login, use their tokens or access repositories.
+func (c *Controller) UpdateState(ctx context.Context, session *auth.Session,
+	userUID string, in *UpdateStateInput) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	if err = sanitizeUpdateStateInput(in, now); err != nil {
+		return nil, err
+	}
+
+	if in.State != enum.PrincipalStateActive && user.ID == session.Principal.ID {
+		return nil, usererror.BadRequest("Users can't block or suspend themselves.")
+	}
+
+	oldState := user.State(now)
+
+	switch in.State {
+	case enum.PrincipalStateBlocked:
+		user.Blocked = true
+		user.SuspendedUntil = 0
+	case enum.PrincipalStateSuspended:
+		user.Blocked = false
+		user.SuspendedUntil = in.SuspendedUntil
+	case enum.PrincipalStateActive:
+		user.Blocked = false
+		user.SuspendedUntil = 0
+	}
+	user.Updated = now
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	c.userReporter.StateUpdated(ctx, &userevents.StateUpdatedPayload{
+		UserID:         user.ID,
+		PrincipalID:    session.Principal.ID,
+		OldState:       oldState,
+		NewState:       in.State,
+		SuspendedUntil: user.SuspendedUntil,
+	})
+
+	return user, nil
+}
+
+func sanitizeUpdateStateInput(in *UpdateStateInput, now int64) error {
+	state, ok := in.State.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported user state '%s'.", in.State)
+	}
+	in.State = state
+
+	if in.State != enum.PrincipalStateSuspended {
+		in.SuspendedUntil = 0
+		return nil
+	}
+
+	if in.SuspendedUntil <= now {
+		return usererror.BadRequest("The suspension has to end in the future.")
+	}
+
+	return nil
+}
+
+// ForcePasswordReset requires the user to set a new password on the next login.
+// All existing sessions and tokens of the user are invalidated.
+func (c *Controller) ForcePasswordReset(ctx context.Context, session *auth.Session,
+	userUID string) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	user.PasswordResetRequired = true
+	// rotating the salt invalidates all JWTs issued for the user.
+	user.Salt = uniuri.NewLen(uniuri.UUIDLen)
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	c.userReporter.PasswordResetForced(ctx, &userevents.PasswordResetForcedPayload{
+		UserID:      user.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
+	return user, nil
+}
@@ -16,6 +16,8 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -35,6 +37,19 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	announcementStore store.AnnouncementStore,
+	userReporter *userevents.Reporter,
+	userEmailStore store.UserEmailStore,
+	notificationStore store.NotificationStore,
+	publicKeyStore store.PublicKeyStore,
+	publicKeyService *publickey.Service,
+	repoStore store.RepoStore,
+	repoStarStore store.RepoStarStore,
+	feedEventStore store.FeedEventStore,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqStore store.PullReqStore,
+	contributionStore store.ContributionStore,
+	savedReplyStore store.SavedReplyStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +57,18 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		announcementStore,
+		userReporter,
+		userEmailStore,
+		notificationStore,
+		publicKeyStore,
+		publicKeyService,
+		repoStore,
+		repoStarStore,
+		feedEventStore,
+		principalInfoCache,
+		pullReqStore,
+		contributionStore,
+		savedReplyStore)
 }
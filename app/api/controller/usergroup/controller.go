@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                dbtx.Transactor
+	principalUIDCheck check.PrincipalUID
+	authorizer        authz.Authorizer
+	principalStore    store.PrincipalStore
+	spaceStore        store.SpaceStore
+	memberStore       store.UserGroupMemberStore
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	principalUIDCheck check.PrincipalUID,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	memberStore store.UserGroupMemberStore,
+) *Controller {
+	return &Controller{
+		tx:                tx,
+		principalUIDCheck: principalUIDCheck,
+		authorizer:        authorizer,
+		principalStore:    principalStore,
+		spaceStore:        spaceStore,
+		memberStore:       memberStore,
+	}
+}
+
+// getSpaceCheckAccess returns the space and verifies that the principal has the requested permission on it.
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return space, nil
+}
+
+// getGroupVerifyOwnership returns the user group and ensures it's defined in the space.
+func (c *Controller) getGroupVerifyOwnership(
+	ctx context.Context,
+	space *types.Space,
+	groupUID string,
+) (*types.UserGroup, error) {
+	if groupUID == "" {
+		return nil, usererror.BadRequest("A valid user group UID must be provided.")
+	}
+
+	group, err := c.principalStore.FindUserGroupByUID(ctx, groupUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group '%s': %w", groupUID, err)
+	}
+
+	// ensure the group actually belongs to the space
+	if group.SpaceID != space.ID {
+		return nil, fmt.Errorf("user group doesn't belong to the requested space. Returning error %w",
+			usererror.ErrNotFound)
+	}
+
+	return group, nil
+}
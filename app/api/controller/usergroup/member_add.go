@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MemberAddInput struct {
+	PrincipalUID string `json:"principal_uid"`
+}
+
+// MemberAdd adds a user or a nested user group to the user group.
+func (c *Controller) MemberAdd(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	groupUID string,
+	in *MemberAddInput,
+) (*types.UserGroupMember, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.getGroupVerifyOwnership(ctx, space, groupUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.PrincipalUID == "" {
+		return nil, usererror.BadRequest("Principal UID must be provided")
+	}
+
+	principal, err := c.principalStore.FindByUID(ctx, in.PrincipalUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Principal '%s' not found", in.PrincipalUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	//nolint:exhaustive // only users and user groups can be members of a user group
+	switch principal.Type {
+	case enum.PrincipalTypeUser:
+	case enum.PrincipalTypeUserGroup:
+		if err = c.checkNestedGroup(ctx, space, group, principal); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, usererror.BadRequest("Only users and user groups can be added to a user group")
+	}
+
+	member := &types.UserGroupMember{
+		UserGroupID: group.ID,
+		PrincipalID: principal.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+		Principal:   *principal.ToPrincipalInfo(),
+		AddedBy:     *session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.memberStore.Create(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to add user group member: %w", err)
+	}
+
+	return member, nil
+}
+
+// checkNestedGroup verifies that the user group can be nested in the parent group:
+// the nested group has to be defined in the same space or in one of its ancestors,
+// and nesting it must not create a cycle.
+func (c *Controller) checkNestedGroup(
+	ctx context.Context,
+	space *types.Space,
+	parent *types.UserGroup,
+	principal *types.Principal,
+) error {
+	if principal.ID == parent.ID {
+		return usererror.BadRequest("A user group can't be a member of itself")
+	}
+
+	nested, err := c.principalStore.FindUserGroup(ctx, principal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find nested user group: %w", err)
+	}
+
+	if nested.SpaceID != space.ID {
+		nestedSpace, err := c.spaceStore.Find(ctx, nested.SpaceID)
+		if err != nil {
+			return fmt.Errorf("failed to find space of the nested user group: %w", err)
+		}
+
+		if !paths.IsAncesterOf(nestedSpace.Path, space.Path) {
+			return usererror.BadRequestf(
+				"User group '%s' isn't defined in the space or any of its parent spaces", nested.UID)
+		}
+	}
+
+	// the parent group must not be a (transitive) member of the nested group
+	ancestorIDs, err := c.memberStore.ListGroupIDs(ctx, parent.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list user groups of the user group: %w", err)
+	}
+
+	for _, id := range ancestorIDs {
+		if id == nested.ID {
+			return usererror.BadRequestf(
+				"User group '%s' is already a parent of user group '%s'", nested.UID, parent.UID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MemberDelete removes a user or a nested user group from the user group.
+func (c *Controller) MemberDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	groupUID string,
+	principalUID string,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	group, err := c.getGroupVerifyOwnership(ctx, space, groupUID)
+	if err != nil {
+		return err
+	}
+
+	principal, err := c.principalStore.FindByUID(ctx, principalUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Principal '%s' not found", principalUID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if err = c.memberStore.Delete(ctx, group.ID, principal.ID); err != nil {
+		return fmt.Errorf("failed to delete user group member: %w", err)
+	}
+
+	return nil
+}
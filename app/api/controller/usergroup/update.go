@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	Email       *string `json:"email"`
+	DisplayName *string `json:"display_name"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Email != nil {
+		*in.Email = strings.TrimSpace(*in.Email)
+		if err := check.Email(*in.Email); err != nil {
+			return err
+		}
+	}
+
+	if in.DisplayName != nil {
+		*in.DisplayName = strings.TrimSpace(*in.DisplayName)
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates the details of the user group.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	groupUID string,
+	in *UpdateInput,
+) (*types.UserGroup, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := c.getGroupVerifyOwnership(ctx, space, groupUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Email != nil {
+		group.Email = *in.Email
+	}
+	if in.DisplayName != nil {
+		group.DisplayName = *in.DisplayName
+	}
+	group.Updated = time.Now().UnixMilli()
+
+	if err = c.principalStore.UpdateUserGroup(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to update user group: %w", err)
+	}
+
+	return group, nil
+}
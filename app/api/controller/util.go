@@ -56,6 +56,15 @@ func CreateRPCWriteParams(ctx context.Context, urlProvider url.Provider,
 	}, nil
 }
 
+// MapDiffOptions maps the diff options of the API to the diff options of gitrpc.
+func MapDiffOptions(opts types.DiffOptions) gitrpc.DiffOptions {
+	return gitrpc.DiffOptions{
+		Algorithm:        gitrpc.DiffAlgorithm(opts.Algorithm),
+		IgnoreWhitespace: opts.IgnoreWhitespace,
+		IgnoreBlankLines: opts.IgnoreBlankLines,
+	}
+}
+
 func MapCommit(c *gitrpc.Commit) (*types.Commit, error) {
 	if c == nil {
 		return nil, fmt.Errorf("commit is nil")
@@ -15,9 +15,12 @@
 package webhook
 
 import (
-	"net"
+	"errors"
+	"fmt"
 	"net/url"
 
+	"github.com/harness/gitness/app/outbound"
+
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -30,7 +33,7 @@ const (
 )
 
 // checkURL validates the url of a webhook.
-func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error {
+func checkURL(rawURL string, policy outbound.Policy) error {
 	// check URL
 	if len(rawURL) > webhookMaxURLLength {
 		return check.NewValidationErrorf("The URL of a webhook can be at most %d characters long.",
@@ -47,25 +50,56 @@ func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error
 		return check.NewValidationError("The URL of a webhook has to have a non-empty host.")
 	}
 
-	// basic validation for loopback / private network addresses (only sanitary to give user an early error)
-	// IMPORTANT: during webook execution loopback / private network addresses are blocked (handles DNS resolution)
+	// basic validation of the host against the outbound policy (only sanitary to give user an early error)
+	// IMPORTANT: during webook execution the resolved addresses are validated (handles DNS resolution)
+	if err = checkHost(host, policy); err != nil {
+		return err
+	}
 
-	if host == "localhost" {
-		return check.NewValidationError("localhost is not allowed.")
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return check.NewValidationError("The scheme of a webhook must be either http or https.")
 	}
 
-	if ip := net.ParseIP(host); ip != nil {
-		if !allowLoopback && ip.IsLoopback() {
-			return check.NewValidationError("Loopback IP addresses are not allowed.")
-		}
+	return nil
+}
 
-		if !allowPrivateNetwork && ip.IsPrivate() {
-			return check.NewValidationError("Private IP addresses are not allowed.")
-		}
+// checkProxyURL validates the url of the proxy of a webhook (empty means the configured proxy is used).
+func checkProxyURL(rawURL string, policy outbound.Policy) error {
+	if rawURL == "" {
+		return nil
 	}
 
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return check.NewValidationError("The scheme of a webhook must be either http or https.")
+	if len(rawURL) > webhookMaxURLLength {
+		return check.NewValidationErrorf("The proxy URL of a webhook can be at most %d characters long.",
+			webhookMaxURLLength)
+	}
+
+	proxyURL, err := outbound.ParseProxyURL(rawURL)
+	if err != nil {
+		return check.NewValidationErrorf("The provided proxy url is invalid: %s", err)
+	}
+
+	// credentials would be exposed as part of the webhook, they have to be configured globally.
+	if proxyURL.User != nil {
+		return check.NewValidationError("The proxy URL of a webhook can't contain credentials.")
+	}
+
+	// the proxy is provided by the user, hence it's subject to the same restrictions as the webhook url.
+	return checkHost(proxyURL.Hostname(), policy)
+}
+
+// checkHost validates the host against the outbound policy.
+func checkHost(host string, policy outbound.Policy) error {
+	err := policy.CheckHost(host)
+	switch {
+	case errors.Is(err, outbound.ErrLoopbackNotAllowed):
+		return check.NewValidationError("Loopback IP addresses are not allowed.")
+	case errors.Is(err, outbound.ErrPrivateNetworkNotAllowed):
+		return check.NewValidationError("Private IP addresses are not allowed.")
+	case errors.Is(err, outbound.ErrTargetDenied):
+		return check.NewValidationErrorf("The host '%s' is not allowed: %s", host, err)
+	case err != nil:
+		return fmt.Errorf("failed to check host: %w", err)
 	}
 
 	return nil
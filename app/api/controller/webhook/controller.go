@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
@@ -30,36 +31,32 @@ import (
 )
 
 type Controller struct {
-	allowLoopback       bool
-	allowPrivateNetwork bool
-
 	authorizer            authz.Authorizer
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	repoStore             store.RepoStore
 	webhookService        *webhook.Service
 	encrypter             encrypt.Encrypter
+	instanceSettings      *instancesettings.Service
 }
 
 func NewController(
-	allowLoopback bool,
-	allowPrivateNetwork bool,
 	authorizer authz.Authorizer,
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
-		allowLoopback:         allowLoopback,
-		allowPrivateNetwork:   allowPrivateNetwork,
 		authorizer:            authorizer,
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
 		webhookService:        webhookService,
 		encrypter:             encrypter,
+		instanceSettings:      instanceSettings,
 	}
 }
 
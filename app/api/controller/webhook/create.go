@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/outbound"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -32,6 +33,7 @@ type CreateInput struct {
 	Secret      string                `json:"secret"`
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
+	ProxyURL    string                `json:"proxy_url"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
 }
 
@@ -43,15 +45,35 @@ func (c *Controller) Create(
 	in *CreateInput,
 	internal bool,
 ) (*types.Webhook, error) {
-	now := time.Now().UnixMilli()
-
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return nil, err
 	}
 
+	return c.CreateNoAuth(ctx, session, repo, in, internal)
+}
+
+// CreateNoAuth creates a new webhook for the repo - no authorization is verified.
+// WARNING this is meant for internal calls only.
+func (c *Controller) CreateNoAuth(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	in *CreateInput,
+	internal bool,
+) (*types.Webhook, error) {
+	now := time.Now().UnixMilli()
+
 	// validate input
-	err = checkCreateInput(in, c.allowLoopback, c.allowPrivateNetwork || internal)
+	policy, err := c.instanceSettings.OutboundPolicy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbound policy: %w", err)
+	}
+
+	// internal webhooks are allowed to target private networks
+	policy.AllowPrivateNetwork = policy.AllowPrivateNetwork || internal
+
+	err = checkCreateInput(in, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +101,7 @@ func (c *Controller) Create(
 		Secret:                string(encryptedSecret),
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
+		ProxyURL:              in.ProxyURL,
 		Triggers:              deduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
 	}
@@ -91,14 +114,27 @@ func (c *Controller) Create(
 	return hook, nil
 }
 
-func checkCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwork bool) error {
+// CheckCreateInput validates the input for creating a (non-internal) webhook.
+func (c *Controller) CheckCreateInput(ctx context.Context, in *CreateInput) error {
+	policy, err := c.instanceSettings.OutboundPolicy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get outbound policy: %w", err)
+	}
+
+	return checkCreateInput(in, policy)
+}
+
+func checkCreateInput(in *CreateInput, policy outbound.Policy) error {
 	if err := check.DisplayName(in.DisplayName); err != nil {
 		return err
 	}
 	if err := check.Description(in.Description); err != nil {
 		return err
 	}
-	if err := checkURL(in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+	if err := checkURL(in.URL, policy); err != nil {
+		return err
+	}
+	if err := checkProxyURL(in.ProxyURL, policy); err != nil {
 		return err
 	}
 	if err := checkSecret(in.Secret); err != nil {
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// PayloadSchemas contains the JSON schemas of the payloads of all webhook triggers.
+type PayloadSchemas struct {
+	Version  string                                     `json:"version"`
+	Triggers map[enum.WebhookTrigger]*jsonschema.Schema `json:"triggers"`
+}
+
+// ListPayloadSchemas returns the JSON schemas of the payloads of all webhook triggers.
+// The schemas are public, they only describe the payload format.
+func (c *Controller) ListPayloadSchemas() (*PayloadSchemas, error) {
+	triggers, _ := enum.GetAllWebhookTriggers()
+
+	out := &PayloadSchemas{
+		Version:  webhook.PayloadVersion,
+		Triggers: make(map[enum.WebhookTrigger]*jsonschema.Schema, len(triggers)),
+	}
+
+	for _, trigger := range triggers {
+		schema, err := webhook.PayloadSchema(trigger)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Triggers[trigger] = schema
+	}
+
+	return out, nil
+}
+
+// FindPayloadSchema returns the JSON schema of the payload of the webhook trigger.
+func (c *Controller) FindPayloadSchema(trigger enum.WebhookTrigger) (*jsonschema.Schema, error) {
+	schema, err := webhook.PayloadSchema(trigger)
+	if errors.Is(err, webhook.ErrNoPayloadSchema) {
+		return nil, usererror.NotFound(fmt.Sprintf("No payload schema found for trigger '%s'.", trigger))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payload schema: %w", err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TestInput is the input for sending a test delivery to a webhook.
+type TestInput struct {
+	// Trigger is the trigger the sample payload is generated for.
+	// If not provided, the first trigger the webhook is registered for is used (or branch_created for all triggers).
+	Trigger enum.WebhookTrigger `json:"trigger"`
+}
+
+// Test sends a signed sample payload to the webhook and returns the resulting execution,
+// which contains the response status, duration and the beginning of the response body.
+func (c *Controller) Test(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookID int64,
+	in *TestInput,
+) (*types.WebhookExecution, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	// get the webhook and ensure it belongs to us
+	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	triggerType := in.Trigger
+	switch {
+	case triggerType != "":
+		var ok bool
+		if triggerType, ok = triggerType.Sanitize(); !ok {
+			return nil, check.NewValidationErrorf("The trigger '%s' is unknown.", in.Trigger)
+		}
+	case len(webhook.Triggers) > 0:
+		triggerType = webhook.Triggers[0]
+	default:
+		triggerType = enum.WebhookTriggerBranchCreated
+	}
+
+	executionResult, err := c.webhookService.TestWebhook(ctx, webhook, triggerType, &session.Principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test webhook: %w", err)
+	}
+
+	// log execution error so we have the necessary debug information if needed
+	if executionResult.Err != nil {
+		log.Ctx(ctx).Warn().Err(executionResult.Err).Msgf(
+			"test of webhook %d (execution id: %d) had an error",
+			webhook.ID, executionResult.Execution.ID)
+	}
+
+	return executionResult.Execution, nil
+}
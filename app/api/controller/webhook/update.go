@@ -16,9 +16,14 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -31,16 +36,19 @@ type UpdateInput struct {
 	Secret      *string               `json:"secret"`
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
+	ProxyURL    *string               `json:"proxy_url"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
 }
 
 // Update updates an existing webhook.
+// If ifMatch is provided, it must match the current version of the webhook.
 func (c *Controller) Update(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	webhookID int64,
 	in *UpdateInput,
+	ifMatch string,
 ) (*types.Webhook, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
 	if err != nil {
@@ -53,8 +61,17 @@ func (c *Controller) Update(
 		return nil, err
 	}
 
+	if ifMatch != "" && ifMatch != strconv.FormatInt(hook.Version, 10) {
+		return nil, usererror.ErrPreconditionFailed
+	}
+
 	// validate input
-	if err = checkUpdateInput(in, c.allowLoopback, c.allowPrivateNetwork); err != nil {
+	policy, err := c.instanceSettings.OutboundPolicy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbound policy: %w", err)
+	}
+
+	if err = checkUpdateInput(in, policy); err != nil {
 		return nil, err
 	}
 
@@ -81,18 +98,25 @@ func (c *Controller) Update(
 	if in.Insecure != nil {
 		hook.Insecure = *in.Insecure
 	}
+	if in.ProxyURL != nil {
+		hook.ProxyURL = *in.ProxyURL
+	}
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
 
-	if err = c.webhookStore.Update(ctx, hook); err != nil {
+	err = c.webhookStore.Update(ctx, hook)
+	if ifMatch != "" && errors.Is(err, store.ErrVersionConflict) {
+		return nil, usererror.ErrPreconditionFailed
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	return hook, nil
 }
 
-func checkUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwork bool) error {
+func checkUpdateInput(in *UpdateInput, policy outbound.Policy) error {
 	if in.DisplayName != nil {
 		if err := check.DisplayName(*in.DisplayName); err != nil {
 			return err
@@ -104,7 +128,12 @@ func checkUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwork b
 		}
 	}
 	if in.URL != nil {
-		if err := checkURL(*in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+		if err := checkURL(*in.URL, policy); err != nil {
+			return err
+		}
+	}
+	if in.ProxyURL != nil {
+		if err := checkProxyURL(*in.ProxyURL, policy); err != nil {
 			return err
 		}
 	}
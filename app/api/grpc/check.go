@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/grpc/rpc"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type checkService struct {
+	rpc.UnimplementedCheckServiceServer
+	checkCtrl *check.Controller
+}
+
+func (s *checkService) ReportCheck(
+	ctx context.Context,
+	in *rpc.ReportCheckRequest,
+) (*rpc.ReportCheckResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	c, err := s.checkCtrl.Report(ctx, session, in.GetRepoRef(), in.GetCommitSha(), &check.ReportInput{
+		CheckUID: in.GetCheckUid(),
+		Status:   enum.CheckStatus(in.GetStatus()),
+		Summary:  in.GetSummary(),
+		Link:     in.GetLink(),
+		Payload:  types.CheckPayload{Kind: enum.CheckPayloadKindEmpty},
+	}, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.ReportCheckResponse{
+		Check: mapCheck(c),
+	}, nil
+}
+
+func (s *checkService) ListChecks(
+	ctx context.Context,
+	in *rpc.ListChecksRequest,
+) (*rpc.ListChecksResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	page, size := pagination(in.GetPage(), in.GetSize())
+	checks, total, err := s.checkCtrl.ListChecks(ctx, session, in.GetRepoRef(), in.GetCommitSha(),
+		types.CheckListOptions{
+			Page: page,
+			Size: size,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*rpc.Check, len(checks))
+	for i := range checks {
+		out[i] = mapCheck(&checks[i])
+	}
+
+	return &rpc.ListChecksResponse{
+		Checks: out,
+		Total:  int64(total),
+	}, nil
+}
+
+func mapCheck(c *types.Check) *rpc.Check {
+	return &rpc.Check{
+		Id:         c.ID,
+		Uid:        c.UID,
+		Status:     string(c.Status),
+		Summary:    c.Summary,
+		Link:       c.Link,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		ReportedBy: mapPrincipal(&c.ReportedBy),
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authn"
+
+	"github.com/rs/zerolog/log"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authInterceptor authenticates the caller with the same tokens accepted by the REST API.
+// The token is read from the "authorization" metadata, and the call continues without
+// a session if none was provided, leaving it to the controllers to permit anonymous access.
+func authInterceptor(authenticator authn.Authenticator) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo,
+		handler gogrpc.UnaryHandler) (interface{}, error) {
+		// the authenticator works on http requests, so the metadata is translated into one
+		r, err := http.NewRequestWithContext(ctx, http.MethodPost, info.FullMethod, nil)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to prepare authentication")
+		}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, v := range md.Get(request.HeaderAuthorization) {
+				r.Header.Add(request.HeaderAuthorization, v)
+			}
+		}
+
+		session, err := authenticator.Authenticate(r)
+		switch {
+		case errors.Is(err, authn.ErrNoAuthData):
+			return handler(ctx, req)
+		case errors.Is(err, authn.ErrPrincipalInactive):
+			return nil, status.Error(codes.PermissionDenied, "principal is inactive")
+		case err != nil:
+			log.Ctx(ctx).Warn().Err(err).Msg("grpc authentication failed")
+			return nil, status.Error(codes.Unauthenticated, usererror.ErrUnauthorized.Message)
+		}
+
+		return handler(request.WithAuthSession(ctx, session), req)
+	}
+}
+
+// errInterceptor converts the errors returned by the controllers into gRPC status errors.
+func errInterceptor() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo,
+		handler gogrpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		uerr := usererror.Translate(err)
+		return nil, status.Error(httpStatusToCode(uerr.Status), uerr.Message)
+	}
+}
+
+func httpStatusToCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
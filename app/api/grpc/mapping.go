@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"github.com/harness/gitness/app/api/grpc/rpc"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+func mapPrincipal(p *types.PrincipalInfo) *rpc.Principal {
+	return &rpc.Principal{
+		Id:          p.ID,
+		Uid:         p.UID,
+		DisplayName: p.DisplayName,
+		Email:       p.Email,
+		Type:        string(p.Type),
+	}
+}
+
+// pagination applies the same defaults and limits to the page and size as the REST API.
+func pagination(page, size int32) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+
+	switch {
+	case size <= 0:
+		size = request.PerPageDefault
+	case size > request.PerPageMax:
+		size = request.PerPageMax
+	}
+
+	return int(page), int(size)
+}
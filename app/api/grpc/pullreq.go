@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/grpc/rpc"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type pullReqService struct {
+	rpc.UnimplementedPullReqServiceServer
+	pullreqCtrl *pullreq.Controller
+}
+
+func (s *pullReqService) FindPullReq(
+	ctx context.Context,
+	in *rpc.FindPullReqRequest,
+) (*rpc.FindPullReqResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	pr, err := s.pullreqCtrl.Find(ctx, session, in.GetRepoRef(), in.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.FindPullReqResponse{
+		PullReq: mapPullReq(pr),
+	}, nil
+}
+
+func (s *pullReqService) ListPullReqs(
+	ctx context.Context,
+	in *rpc.ListPullReqsRequest,
+) (*rpc.ListPullReqsResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	// use map to eliminate duplicates, same as the REST API does
+	m := make(map[enum.PullReqState]struct{})
+	for _, s := range in.GetStates() {
+		if state, ok := enum.PullReqState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+	states := make([]enum.PullReqState, 0, len(m))
+	for state := range m {
+		states = append(states, state)
+	}
+
+	sort, _ := enum.PullReqSort("").Sanitize()
+
+	page, size := pagination(in.GetPage(), in.GetSize())
+	list, total, err := s.pullreqCtrl.List(ctx, session, in.GetRepoRef(), &types.PullReqFilter{
+		Page:         page,
+		Size:         size,
+		Query:        in.GetQuery(),
+		SourceBranch: in.GetSourceBranch(),
+		TargetBranch: in.GetTargetBranch(),
+		States:       states,
+		Sort:         sort,
+		Order:        enum.OrderDefault,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*rpc.PullReq, len(list))
+	for i, pr := range list {
+		out[i] = mapPullReq(pr)
+	}
+
+	return &rpc.ListPullReqsResponse{
+		PullReqs: out,
+		Total:    total,
+	}, nil
+}
+
+func mapPullReq(pr *types.PullReq) *rpc.PullReq {
+	out := &rpc.PullReq{
+		Number:       pr.Number,
+		State:        string(pr.State),
+		IsDraft:      pr.IsDraft,
+		Title:        pr.Title,
+		Description:  pr.Description,
+		SourceRepoId: pr.SourceRepoID,
+		SourceBranch: pr.SourceBranch,
+		SourceSha:    pr.SourceSHA,
+		TargetRepoId: pr.TargetRepoID,
+		TargetBranch: pr.TargetBranch,
+		MergeBaseSha: pr.MergeBaseSHA,
+		Created:      pr.Created,
+		Edited:       pr.Edited,
+		Author:       mapPrincipal(&pr.Author),
+	}
+	if pr.MergeSHA != nil {
+		out.MergeSha = *pr.MergeSHA
+	}
+	if pr.Merged != nil {
+		out.Merged = *pr.Merged
+	}
+
+	return out
+}
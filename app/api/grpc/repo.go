@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/grpc/rpc"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type repositoryService struct {
+	rpc.UnimplementedRepositoryServiceServer
+	repoCtrl *repo.Controller
+}
+
+func (s *repositoryService) FindRepository(
+	ctx context.Context,
+	in *rpc.FindRepositoryRequest,
+) (*rpc.FindRepositoryResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	repository, err := s.repoCtrl.Find(ctx, session, in.GetRepoRef())
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.FindRepositoryResponse{
+		Repository: mapRepository(repository),
+	}, nil
+}
+
+func (s *repositoryService) ListBranches(
+	ctx context.Context,
+	in *rpc.ListBranchesRequest,
+) (*rpc.ListBranchesResponse, error) {
+	session, _ := request.AuthSessionFrom(ctx)
+
+	page, size := pagination(in.GetPage(), in.GetSize())
+	branches, err := s.repoCtrl.ListBranches(ctx, session, in.GetRepoRef(), repo.ListBranchesOptions{},
+		&types.BranchFilter{
+			Query: in.GetQuery(),
+			Sort:  enum.BranchSortOptionDefault,
+			Order: enum.OrderDefault,
+			Page:  page,
+			Size:  size,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*rpc.Branch, len(branches))
+	for i := range branches {
+		out[i] = &rpc.Branch{
+			Name: branches[i].Name,
+			Sha:  branches[i].SHA,
+		}
+	}
+
+	return &rpc.ListBranchesResponse{
+		Branches: out,
+	}, nil
+}
+
+func mapRepository(repo *types.Repository) *rpc.Repository {
+	return &rpc.Repository{
+		Id:            repo.ID,
+		ParentId:      repo.ParentID,
+		Uid:           repo.UID,
+		Path:          repo.Path,
+		Description:   repo.Description,
+		IsPublic:      repo.IsPublic,
+		DefaultBranch: repo.DefaultBranch,
+		ForkId:        repo.ForkID,
+		Size:          repo.Size,
+		Created:       repo.Created,
+		Updated:       repo.Updated,
+		GitUrl:        repo.GitURL,
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpc implements the gRPC API that mirrors key REST controllers
+// for low-latency internal service-to-service integrations.
+package grpc
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/grpc/rpc"
+	"github.com/harness/gitness/app/auth/authn"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	gogrpc "google.golang.org/grpc"
+)
+
+// Server is the gRPC server of the gitness API.
+type Server struct {
+	*gogrpc.Server
+	Port int
+}
+
+func NewServer(
+	port int,
+	authenticator authn.Authenticator,
+	repoCtrl *repo.Controller,
+	pullreqCtrl *pullreq.Controller,
+	checkCtrl *check.Controller,
+) *Server {
+	s := gogrpc.NewServer(
+		gogrpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			grpc_recovery.UnaryServerInterceptor(),
+			otelgrpc.UnaryServerInterceptor(),
+			authInterceptor(authenticator),
+			errInterceptor(),
+		)),
+	)
+
+	rpc.RegisterRepositoryServiceServer(s, &repositoryService{repoCtrl: repoCtrl})
+	rpc.RegisterPullReqServiceServer(s, &pullReqService{pullreqCtrl: pullreqCtrl})
+	rpc.RegisterCheckServiceServer(s, &checkService{checkCtrl: checkCtrl})
+
+	return &Server{
+		Server: s,
+		Port:   port,
+	}
+}
+
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
+	if err != nil {
+		return err
+	}
+	return s.Server.Serve(lis)
+}
+
+func (s *Server) Stop() error {
+	s.Server.GracefulStop()
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attachment
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/attachment"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleDownload returns a http.HandlerFunc that writes the content of an attachment.
+func HandleDownload(attachmentCtrl *attachment.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		fileRef, err := request.GetAttachmentRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		a, content, err := attachmentCtrl.Download(ctx, session, repoRef, fileRef)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		defer func() {
+			if errClose := content.Close(); errClose != nil {
+				log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close attachment content reader")
+			}
+		}()
+
+		// only images are displayed inline, anything else (including scriptable svg images) is downloaded.
+		disposition := "attachment"
+		if strings.HasPrefix(a.ContentType, "image/") && a.ContentType != "image/svg+xml" {
+			disposition = "inline"
+		}
+
+		w.Header().Set("Content-Type", a.ContentType)
+		w.Header().Set("Content-Length", fmt.Sprint(a.Size))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=%q", disposition, a.SHA256))
+		w.Header().Set("Content-Security-Policy", "sandbox")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		render.Reader(ctx, w, http.StatusOK, content)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleReqCheckDelete is an HTTP handler for removing a required status check from a repository.
+// In dry run mode it returns the open pull requests affected by the required status check instead.
+func HandleReqCheckDelete(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		reqCheckID, err := request.GetReqCheckIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		dryRun, err := request.ParseDryRun(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if dryRun {
+			impact, err := checkCtrl.ReqCheckDeleteDryRun(ctx, session, repoRef, reqCheckID)
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			render.JSON(w, http.StatusOK, impact)
+			return
+		}
+
+		err = checkCtrl.ReqCheckDelete(ctx, session, repoRef, reqCheckID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
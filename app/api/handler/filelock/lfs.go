@@ -0,0 +1,330 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelock
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/filelock"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// The handlers below implement the Git LFS File Locking API on top of the file locks of a repo.
+// For more details see https://github.com/git-lfs/git-lfs/blob/main/docs/api/locking.md
+
+const (
+	lfsContentType  = "application/vnd.git-lfs+json"
+	lfsDefaultLimit = 100
+)
+
+type lfsLockOwner struct {
+	Name string `json:"name"`
+}
+
+type lfsLock struct {
+	ID       string        `json:"id"`
+	Path     string        `json:"path"`
+	LockedAt time.Time     `json:"locked_at"`
+	Owner    *lfsLockOwner `json:"owner,omitempty"`
+}
+
+type lfsRef struct {
+	Name string `json:"name"`
+}
+
+type lfsCreateRequest struct {
+	Path string  `json:"path"`
+	Ref  *lfsRef `json:"ref,omitempty"`
+}
+
+type lfsVerifyRequest struct {
+	Cursor string  `json:"cursor,omitempty"`
+	Limit  int     `json:"limit,omitempty"`
+	Ref    *lfsRef `json:"ref,omitempty"`
+}
+
+type lfsUnlockRequest struct {
+	Force bool    `json:"force"`
+	Ref   *lfsRef `json:"ref,omitempty"`
+}
+
+type lfsError struct {
+	Message string   `json:"message"`
+	Lock    *lfsLock `json:"lock,omitempty"`
+}
+
+// HandleLFSList returns a http.HandlerFunc that lists the file locks of a repo in the LFS format.
+func HandleLFSList(lockCtrl *filelock.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		page, size, err := parseLFSCursor(r.URL.Query().Get("cursor"), r.URL.Query().Get("limit"))
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		filter := &types.FileLockFilter{
+			Page: page,
+			Size: size,
+			Path: r.URL.Query().Get(request.QueryParamPath),
+		}
+
+		if id := r.URL.Query().Get("id"); id != "" {
+			filter.ID, err = strconv.ParseInt(id, 10, 64)
+			if err != nil || filter.ID < 1 {
+				renderLFSError(w, usererror.BadRequest("Invalid lock id."))
+				return
+			}
+		}
+
+		locks, totalCount, err := lockCtrl.List(ctx, session, repoRef, filter)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		renderLFS(w, http.StatusOK, struct {
+			Locks      []*lfsLock `json:"locks"`
+			NextCursor string     `json:"next_cursor,omitempty"`
+		}{
+			Locks:      mapToLFSLocks(locks),
+			NextCursor: nextLFSCursor(page, size, totalCount),
+		})
+	}
+}
+
+// HandleLFSCreate returns a http.HandlerFunc that locks a path of a repo in the LFS format.
+func HandleLFSCreate(lockCtrl *filelock.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		in := new(lfsCreateRequest)
+		if err = json.NewDecoder(r.Body).Decode(in); err != nil {
+			renderLFSError(w, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		lock, err := lockCtrl.Create(ctx, session, repoRef, &filelock.CreateInput{Path: in.Path})
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		renderLFS(w, http.StatusCreated, struct {
+			Lock *lfsLock `json:"lock"`
+		}{
+			Lock: mapToLFSLock(lock),
+		})
+	}
+}
+
+// HandleLFSVerify returns a http.HandlerFunc that lists the file locks of a repo in the LFS format,
+// split into the locks owned by the principal and the locks owned by others.
+func HandleLFSVerify(lockCtrl *filelock.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		in := new(lfsVerifyRequest)
+		if err = json.NewDecoder(r.Body).Decode(in); err != nil {
+			renderLFSError(w, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		page, size, err := parseLFSCursor(in.Cursor, strconv.Itoa(in.Limit))
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		filter := &types.FileLockFilter{
+			Page: page,
+			Size: size,
+		}
+
+		locks, totalCount, err := lockCtrl.List(ctx, session, repoRef, filter)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		ours := make([]*lfsLock, 0)
+		theirs := make([]*lfsLock, 0)
+		for _, lock := range locks {
+			if session != nil && lock.OwnerID == session.Principal.ID {
+				ours = append(ours, mapToLFSLock(lock))
+			} else {
+				theirs = append(theirs, mapToLFSLock(lock))
+			}
+		}
+
+		renderLFS(w, http.StatusOK, struct {
+			Ours       []*lfsLock `json:"ours"`
+			Theirs     []*lfsLock `json:"theirs"`
+			NextCursor string     `json:"next_cursor,omitempty"`
+		}{
+			Ours:       ours,
+			Theirs:     theirs,
+			NextCursor: nextLFSCursor(page, size, totalCount),
+		})
+	}
+}
+
+// HandleLFSUnlock returns a http.HandlerFunc that removes a file lock of a repo in the LFS format.
+func HandleLFSUnlock(lockCtrl *filelock.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		lockID, err := request.GetFileLockIDFromPath(r)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		in := new(lfsUnlockRequest)
+		if err = json.NewDecoder(r.Body).Decode(in); err != nil {
+			renderLFSError(w, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		lock, err := lockCtrl.Delete(ctx, session, repoRef, lockID, in.Force)
+		if err != nil {
+			renderLFSError(w, err)
+			return
+		}
+
+		renderLFS(w, http.StatusOK, struct {
+			Lock *lfsLock `json:"lock"`
+		}{
+			Lock: mapToLFSLock(lock),
+		})
+	}
+}
+
+// parseLFSCursor converts the opaque LFS cursor (the page number) and limit to a page and page size.
+func parseLFSCursor(cursor string, limit string) (int, int, error) {
+	page := 1
+	if cursor != "" {
+		var err error
+		page, err = strconv.Atoi(cursor)
+		if err != nil || page < 1 {
+			return 0, 0, usererror.BadRequest("Invalid cursor.")
+		}
+	}
+
+	size := lfsDefaultLimit
+	if limit != "" && limit != "0" {
+		var err error
+		size, err = strconv.Atoi(limit)
+		if err != nil || size < 1 {
+			return 0, 0, usererror.BadRequest("Invalid limit.")
+		}
+	}
+
+	return page, size, nil
+}
+
+func nextLFSCursor(page int, size int, totalCount int64) string {
+	if int64(page*size) >= totalCount {
+		return ""
+	}
+
+	return strconv.Itoa(page + 1)
+}
+
+func mapToLFSLock(lock *types.FileLock) *lfsLock {
+	res := &lfsLock{
+		ID:       strconv.FormatInt(lock.ID, 10),
+		Path:     lock.Path,
+		LockedAt: time.UnixMilli(lock.Created).UTC(),
+	}
+
+	if lock.Owner != nil {
+		res.Owner = &lfsLockOwner{Name: lock.Owner.DisplayName}
+	}
+
+	return res
+}
+
+func mapToLFSLocks(locks []*types.FileLock) []*lfsLock {
+	res := make([]*lfsLock, len(locks))
+	for i, lock := range locks {
+		res[i] = mapToLFSLock(lock)
+	}
+
+	return res
+}
+
+func renderLFSError(w http.ResponseWriter, err error) {
+	log.Warn().Msgf("lfs operation resulted in user facing error. Internal details: %s", err)
+
+	uErr := usererror.Translate(err)
+	res := &lfsError{Message: uErr.Message}
+
+	// a conflict contains the lock that is already held on the path.
+	if lock, ok := uErr.Values[filelock.ConflictLockKey].(*types.FileLock); ok {
+		res.Lock = mapToLFSLock(lock)
+	}
+
+	if uErr.Status == http.StatusUnauthorized {
+		w.Header().Add("WWW-Authenticate", `Basic realm="Git LFS"`)
+	}
+
+	renderLFS(w, uErr.Status, res)
+}
+
+func renderLFS(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", lfsContentType)
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Err(err).Msgf("failed to write LFS response")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/oauth"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleAuthorize handles the authorization request of an OAuth app by redirecting the user agent.
+func HandleAuthorize(oauthCtrl *oauth.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		query := r.URL.Query()
+		in := &oauth.AuthorizeInput{
+			ResponseType:        query.Get("response_type"),
+			ClientID:            query.Get("client_id"),
+			RedirectURI:         query.Get("redirect_uri"),
+			Scope:               query.Get("scope"),
+			State:               query.Get("state"),
+			Nonce:               query.Get("nonce"),
+			CodeChallenge:       query.Get("code_challenge"),
+			CodeChallengeMethod: query.Get("code_challenge_method"),
+		}
+
+		out, err := oauthCtrl.Authorize(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		http.Redirect(w, r, out.RedirectURI, http.StatusFound)
+	}
+}
+
+// HandleAuthorizeConsent handles the decision of the user on the consent screen of an OAuth app.
+// Only json requests are accepted, as browsers don't submit them cross-site without a CORS preflight.
+func HandleAuthorizeConsent(oauthCtrl *oauth.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			render.ErrorMessagef(w, http.StatusUnsupportedMediaType, "The request body has to be of type application/json.")
+			return
+		}
+
+		in := new(oauth.AuthorizeInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		if in.Approve == nil {
+			render.BadRequestf(w, "The decision of the user is required.")
+			return
+		}
+
+		if in.ConsentToken == "" {
+			render.BadRequestf(w, "The consent token is required.")
+			return
+		}
+
+		out, err := oauthCtrl.Authorize(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleAuthorizeConsentRequiresJSON(t *testing.T) {
+	for _, contentType := range []string{"", "application/x-www-form-urlencoded", "multipart/form-data", "text/plain"} {
+		t.Run(contentType, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/oauth/authorize",
+				strings.NewReader(`{"client_id":"client","approve":true,"consent_token":"token"}`))
+			if contentType != "" {
+				r.Header.Set("Content-Type", contentType)
+			}
+			w := httptest.NewRecorder()
+
+			// the request is rejected before it reaches the controller.
+			HandleAuthorizeConsent(nil)(w, r)
+
+			if w.Code != http.StatusUnsupportedMediaType {
+				t.Errorf("want status %d, got %d", http.StatusUnsupportedMediaType, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/oauth"
+)
+
+// HandleRevoke revokes an access token issued to an app.
+func HandleRevoke(oauthCtrl *oauth.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if err := r.ParseForm(); err != nil {
+			renderInvalidBody(w)
+			return
+		}
+
+		in := &oauth.RevokeInput{
+			Token:         r.PostForm.Get("token"),
+			TokenTypeHint: r.PostForm.Get("token_type_hint"),
+		}
+		in.ClientID, in.ClientSecret = clientCredentials(r)
+
+		err := oauthCtrl.Revoke(ctx, in)
+		if err != nil {
+			renderError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
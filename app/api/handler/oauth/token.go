@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oauth
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/harness/gitness/app/api/controller/oauth"
+	"github.com/harness/gitness/app/api/render"
+)
+
+// HandleToken exchanges an authorization code for an access token.
+func HandleToken(oauthCtrl *oauth.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if err := r.ParseForm(); err != nil {
+			renderInvalidBody(w)
+			return
+		}
+
+		in := &oauth.TokenInput{
+			GrantType:    r.PostForm.Get("grant_type"),
+			Code:         r.PostForm.Get("code"),
+			RedirectURI:  r.PostForm.Get("redirect_uri"),
+			CodeVerifier: r.PostForm.Get("code_verifier"),
+		}
+		in.ClientID, in.ClientSecret = clientCredentials(r)
+
+		out, err := oauthCtrl.Token(ctx, in)
+		if err != nil {
+			renderError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// clientCredentials returns the client credentials provided either via basic auth or the request body.
+// NOTE: the form of the request has to be parsed already.
+func clientCredentials(r *http.Request) (string, string) {
+	// client credentials are form url encoded when provided via basic auth (RFC 6749 section 2.3.1)
+	if username, password, ok := r.BasicAuth(); ok {
+		clientID, _ := url.QueryUnescape(username)
+		clientSecret, _ := url.QueryUnescape(password)
+		return clientID, clientSecret
+	}
+
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// renderError renders OAuth errors as defined by RFC 6749, all other errors are rendered as usual.
+func renderError(w http.ResponseWriter, err error) {
+	var oauthErr *oauth.Error
+	if errors.As(err, &oauthErr) {
+		render.JSON(w, oauthErr.Status, oauthErr)
+		return
+	}
+
+	render.TranslatedUserError(w, err)
+}
+
+func renderInvalidBody(w http.ResponseWriter) {
+	render.JSON(w, http.StatusBadRequest, &oauth.Error{
+		Code:        "invalid_request",
+		Description: "The request body is invalid.",
+	})
+}
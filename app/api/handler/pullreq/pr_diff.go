@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleDiff returns the diff of the pull request.
+func HandleDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		filePaths, _ := request.QueryParamList(r, request.QueryParamPath)
+
+		diffOpts, err := request.ParseDiffOptions(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
+			err = pullreqCtrl.RawDiff(ctx, session, repoRef, pullreqNumber, filePaths, diffOpts, w)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusOK)
+			}
+			return
+		}
+
+		_, includePatch := request.QueryParam(r, request.QueryParamIncludePatch)
+		_, includeWordDiff := request.QueryParam(r, request.QueryParamIncludeWordDiff)
+		stream, err := pullreqCtrl.Diff(ctx, session, repoRef, pullreqNumber, includePatch, includeWordDiff,
+			filePaths, diffOpts)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSONArrayDynamic(ctx, w, stream)
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 /*
  * Deletes a repository.
+ * In dry run mode it returns the resources affected by the deletion instead.
  */
 func HandleDelete(repoCtrl *repo.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +36,23 @@ func HandleDelete(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		dryRun, err := request.ParseDryRun(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if dryRun {
+			impact, err := repoCtrl.DeleteDryRun(ctx, session, repoRef)
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			render.JSON(w, http.StatusOK, impact)
+			return
+		}
+
 		err = repoCtrl.Delete(ctx, session, repoRef)
 		if err != nil {
 			render.TranslatedUserError(w, err)
@@ -35,17 +35,26 @@ func HandleDiff(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		path := request.GetOptionalRemainderFromPath(r)
+		filePaths, _ := request.QueryParamList(r, request.QueryParamPath)
+
+		diffOpts, err := request.ParseDiffOptions(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 
 		if strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
-			err := repoCtrl.RawDiff(ctx, session, repoRef, path, w)
+			err := repoCtrl.RawDiff(ctx, session, repoRef, path, filePaths, diffOpts, w)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusOK)
 			}
 			return
 		}
 
-		_, includePatch := request.QueryParam(r, "include_patch")
-		stream, err := repoCtrl.Diff(ctx, session, repoRef, path, includePatch)
+		_, includePatch := request.QueryParam(r, request.QueryParamIncludePatch)
+		_, includeWordDiff := request.QueryParam(r, request.QueryParamIncludeWordDiff)
+		stream, err := repoCtrl.Diff(ctx, session, repoRef, path, includePatch, includeWordDiff, filePaths,
+			diffOpts)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
@@ -72,7 +81,13 @@ func HandleCommitDiff(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = repoCtrl.CommitDiff(ctx, session, repoRef, commitSHA, w)
+		diffOpts, err := request.ParseDiffOptions(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		err = repoCtrl.CommitDiff(ctx, session, repoRef, commitSHA, diffOpts, w)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
@@ -15,21 +15,28 @@
 package repo
 
 import (
+	"bytes"
 	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	repoctrl "github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/gitmetrics"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/hlog"
@@ -38,6 +45,12 @@ import (
 
 type CtxRepoType string
 
+const (
+	serviceReceivePack = "receive-pack"
+
+	purgeOnPushTimeout = 30 * time.Second
+)
+
 type GitAuthError struct {
 	AccountID string
 }
@@ -46,7 +59,8 @@ func (e GitAuthError) Error() string {
 	return fmt.Sprintf("Authentication failed for account %s", e.AccountID)
 }
 
-func GetInfoRefs(client gitrpc.Interface, repoStore store.RepoStore, authorizer authz.Authorizer) http.HandlerFunc {
+func GetInfoRefs(client gitrpc.Interface, repoStore store.RepoStore, authorizer authz.Authorizer,
+	repoCtrl *repoctrl.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
@@ -57,6 +71,21 @@ func GetInfoRefs(client gitrpc.Interface, repoStore store.RepoStore, authorizer
 		}
 
 		repo, err := repoStore.FindByRef(ctx, repoRef)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) && getServiceType(r) == serviceReceivePack {
+			// a push to a non-existent repository might create it (if enabled for the space).
+			// The repository is only created once the pushed data is received (see serviceRPC).
+			if _, _, err = repoCtrl.CheckCreateOnPush(ctx, session, repoRef); err != nil {
+				writeCreateOnPushError(w, repoRef, err)
+				return
+			}
+
+			setHeaderNoCache(w)
+			w.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-advertisement", serviceReceivePack))
+			if _, err = w.Write(emptyReceivePackAdvertisement()); err != nil {
+				log.Ctx(ctx).Err(err).Msg("failed to write receive-pack advertisement for push to create")
+			}
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -103,12 +132,44 @@ func GetInfoRefs(client gitrpc.Interface, repoStore store.RepoStore, authorizer
 	}
 }
 
+// writeCreateOnPushError writes the http error for a push to a repository that can't be created.
+// Callers that aren't allowed to create the repository get the same response regardless of the space existing.
+func writeCreateOnPushError(w http.ResponseWriter, repoRef string, err error) {
+	switch {
+	case errors.Is(err, apiauth.ErrNotAuthenticated):
+		accountID, _, _ := paths.DisectRoot(repoRef)
+		basicAuth(w, accountID)
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+		http.Error(w, usererror.ErrNotFound.Error(), http.StatusNotFound)
+	default:
+		uErr := usererror.Translate(err)
+		http.Error(w, uErr.Error(), uErr.Status)
+	}
+}
+
+// emptyReceivePackAdvertisement returns the receive-pack ref advertisement of an empty repository.
+// It's used for pushes to repositories that are only created once the pushed data is received.
+// The capabilities are limited to the ones supported by all git versions able to run gitness.
+func emptyReceivePackAdvertisement() []byte {
+	var buf bytes.Buffer
+	buf.Write(packetWrite("# service=git-" + serviceReceivePack + "\n"))
+	buf.WriteString("0000")
+	buf.Write(packetWrite(strings.Repeat("0", 40) + " capabilities^{}\x00" +
+		"report-status delete-refs side-band-64k quiet atomic ofs-delta\n"))
+	buf.WriteString("0000")
+	return buf.Bytes()
+}
+
+func packetWrite(str string) []byte {
+	return []byte(fmt.Sprintf("%04x%s", len(str)+4, str))
+}
+
 func GetUploadPack(client gitrpc.Interface, urlProvider url.Provider,
-	repoStore store.RepoStore, authorizer authz.Authorizer) http.HandlerFunc {
+	repoStore store.RepoStore, authorizer authz.Authorizer, tracker *gitmetrics.Tracker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const service = "upload-pack"
 
-		if err := serviceRPC(w, r, client, urlProvider, repoStore, authorizer, service, false,
+		if err := serviceRPC(w, r, client, urlProvider, repoStore, nil, authorizer, tracker, service, false,
 			enum.PermissionRepoView, true); err != nil {
 			if errors.Is(err, apiauth.ErrNotAuthorized) {
 				http.Error(w, err.Error(), http.StatusForbidden)
@@ -120,11 +181,11 @@ func GetUploadPack(client gitrpc.Interface, urlProvider url.Provider,
 	}
 }
 
-func PostReceivePack(client gitrpc.Interface, urlProvider url.Provider,
-	repoStore store.RepoStore, authorizer authz.Authorizer) http.HandlerFunc {
+func PostReceivePack(client gitrpc.Interface, urlProvider url.Provider, repoStore store.RepoStore,
+	repoCtrl *repoctrl.Controller, authorizer authz.Authorizer, tracker *gitmetrics.Tracker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		const service = "receive-pack"
-		if err := serviceRPC(w, r, client, urlProvider, repoStore, authorizer, service, true,
+		const service = serviceReceivePack
+		if err := serviceRPC(w, r, client, urlProvider, repoStore, repoCtrl, authorizer, tracker, service, true,
 			enum.PermissionRepoPush, false); err != nil {
 			var authError *GitAuthError
 			if errors.As(err, &authError) {
@@ -135,6 +196,10 @@ func PostReceivePack(client gitrpc.Interface, urlProvider url.Provider,
 				http.Error(w, err.Error(), http.StatusForbidden)
 				return
 			}
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				http.Error(w, usererror.ErrNotFound.Error(), http.StatusNotFound)
+				return
+			}
 
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -148,12 +213,14 @@ func serviceRPC(
 	client gitrpc.Interface,
 	urlProvider url.Provider,
 	repoStore store.RepoStore,
+	repoCtrl *repoctrl.Controller,
 	authorizer authz.Authorizer,
+	tracker *gitmetrics.Tracker,
 	service string,
 	isWriteOperation bool,
 	permission enum.Permission,
 	orPublic bool,
-) error {
+) (err error) {
 	ctx := r.Context()
 	log := hlog.FromRequest(r)
 	defer func() {
@@ -169,6 +236,23 @@ func serviceRPC(
 	}
 
 	repo, err := repoStore.FindByRef(ctx, repoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) && repoCtrl != nil {
+		// the repository of a push to create is only created once the pushed data is received,
+		// and it's purged again in case the push doesn't leave any references behind.
+		repo, err = repoCtrl.CreateOnPush(ctx, session, repoRef)
+		if errors.Is(err, apiauth.ErrNotAuthenticated) {
+			accountID, _, _ := paths.DisectRoot(repoRef)
+			return &GitAuthError{
+				AccountID: accountID,
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Info().Msgf("created repository '%s' on push", repo.Path)
+		defer purgeEmptyOnPush(ctx, repoCtrl, session, repo, &err)
+	}
 	if err != nil {
 		return err
 	}
@@ -198,9 +282,10 @@ func serviceRPC(
 			return err
 		}
 	}
+	reqReader := gitmetrics.NewRequestReader(reqBody, service)
 	params := &gitrpc.ServicePackParams{
 		Service:     service,
-		Data:        reqBody,
+		Data:        reqReader,
 		Options:     nil,
 		GitProtocol: r.Header.Get("Git-Protocol"),
 	}
@@ -218,7 +303,52 @@ func serviceRPC(
 		params.ReadParams = &readParams
 	}
 
-	return client.ServicePack(ctx, w, params)
+	respWriter := gitmetrics.NewResponseWriter(w)
+	started := time.Now()
+
+	err = client.ServicePack(ctx, respWriter, params)
+
+	op := types.GitOperation{
+		Service:      service,
+		RepoID:       repo.ID,
+		RepoPath:     repo.Path,
+		Started:      started.UnixMilli(),
+		Duration:     time.Since(started).Milliseconds(),
+		RequestSize:  reqReader.Size(),
+		ResponseSize: respWriter.Size(),
+		RefCount:     reqReader.RefCount(),
+	}
+	if session != nil {
+		op.PrincipalID = session.Principal.ID
+	}
+	if err != nil {
+		op.Error = err.Error()
+	}
+	tracker.Record(ctx, op)
+
+	return err
+}
+
+// purgeEmptyOnPush purges a repository that was created on push in case the push didn't succeed.
+// A separate context is used as the request context is canceled in case the client aborted the push.
+func purgeEmptyOnPush(
+	ctx context.Context,
+	repoCtrl *repoctrl.Controller,
+	session *auth.Session,
+	repo *types.Repository,
+	pushErr *error,
+) {
+	cleanupCtx, cancel := context.WithTimeout(log.Ctx(ctx).WithContext(context.Background()), purgeOnPushTimeout)
+	defer cancel()
+
+	purged, err := repoCtrl.PurgeEmptyOnPush(cleanupCtx, session, repo, *pushErr)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to purge repository '%s' created on push", repo.Path)
+		return
+	}
+	if purged {
+		log.Ctx(ctx).Info().Msgf("purged repository '%s' created by an unsuccessful push", repo.Path)
+	}
 }
 
 func setHeaderNoCache(w http.ResponseWriter) {
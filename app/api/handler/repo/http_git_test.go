@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+func TestEmptyReceivePackAdvertisement(t *testing.T) {
+	want := "001f# service=git-receive-pack\n" +
+		"0000" +
+		"007c0000000000000000000000000000000000000000 capabilities^{}\x00" +
+		"report-status delete-refs side-band-64k quiet atomic ofs-delta\n" +
+		"0000"
+
+	if got := string(emptyReceivePackAdvertisement()); got != want {
+		t.Errorf("want advertisement %q, got %q", want, got)
+	}
+}
+
+func TestWriteCreateOnPushError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantRealm  string
+	}{
+		{
+			name:       "not-authenticated",
+			err:        apiauth.ErrNotAuthenticated,
+			wantStatus: http.StatusUnauthorized,
+			wantRealm:  `Basic realm="space"`,
+		},
+		{
+			name:       "not-found",
+			err:        gitness_store.ErrResourceNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "internal",
+			err:        errors.New("failure"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeCreateOnPushError(w, "space/sub/repo", test.err)
+
+			if w.Code != test.wantStatus {
+				t.Errorf("want status %d, got %d", test.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != test.wantRealm {
+				t.Errorf("want authenticate header %q, got %q", test.wantRealm, got)
+			}
+		})
+	}
+}
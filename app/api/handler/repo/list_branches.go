@@ -41,9 +41,25 @@ func HandleListBranches(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		includeDivergence, err := request.GetIncludeDivergenceFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		includePullReqs, err := request.GetIncludePullReqsFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
 		filter := request.ParseBranchFilter(r)
 
-		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, includeCommit, filter)
+		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, repo.ListBranchesOptions{
+			IncludeCommit:     includeCommit,
+			IncludeDivergence: includeDivergence,
+			IncludePullReqs:   includePullReqs,
+		}, filter)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
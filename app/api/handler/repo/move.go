@@ -24,6 +24,7 @@ import (
 )
 
 // HandleMove moves an existing repo.
+// In dry run mode it returns the path change of the repo instead.
 func HandleMove(repoCtrl *repo.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -41,6 +42,23 @@ func HandleMove(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		dryRun, err := request.ParseDryRun(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if dryRun {
+			impact, err := repoCtrl.MoveDryRun(ctx, session, repoRef, in)
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			render.JSON(w, http.StatusOK, impact)
+			return
+		}
+
 		repo, err := repoCtrl.Move(ctx, session, repoRef, in)
 		if err != nil {
 			render.TranslatedUserError(w, err)
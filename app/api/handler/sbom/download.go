@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/sbom"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleDownload returns a http.HandlerFunc that writes the document of an SBOM.
+func HandleDownload(sbomCtrl *sbom.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		sbomID, err := request.GetSBOMIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		s, doc, err := sbomCtrl.Download(ctx, session, repoRef, sbomID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		defer func() {
+			if errClose := doc.Close(); errClose != nil {
+				log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close sbom document reader")
+			}
+		}()
+
+		w.Header().Add("Content-Type", "application/json")
+		w.Header().Add("Content-Length", fmt.Sprint(s.Size))
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=%q",
+			fmt.Sprintf("sbom-%s.%s.json", strings.ReplaceAll(s.Tag, "/", "-"), s.Format)))
+
+		render.Reader(ctx, w, http.StatusOK, doc)
+	}
+}
@@ -23,6 +23,7 @@ import (
 )
 
 // HandleDelete handles the delete space HTTP API.
+// In dry run mode it returns the resources affected by the deletion instead.
 func HandleDelete(spaceCtrl *space.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -33,6 +34,23 @@ func HandleDelete(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
+		dryRun, err := request.ParseDryRun(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if dryRun {
+			impact, err := spaceCtrl.DeleteDryRun(ctx, session, spaceRef)
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			render.JSON(w, http.StatusOK, impact)
+			return
+		}
+
 		err = spaceCtrl.Delete(ctx, session, spaceRef)
 		if err != nil {
 			render.TranslatedUserError(w, err)
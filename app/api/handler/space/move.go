@@ -24,6 +24,7 @@ import (
 )
 
 // HandleMove moves an existing space.
+// In dry run mode it returns the spaces and repositories whose paths would change instead.
 func HandleMove(spaceCtrl *space.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -41,6 +42,23 @@ func HandleMove(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
+		dryRun, err := request.ParseDryRun(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if dryRun {
+			impact, err := spaceCtrl.MoveDryRun(ctx, session, spaceRef, in)
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			render.JSON(w, http.StatusOK, impact)
+			return
+		}
+
 		res, err := spaceCtrl.Move(ctx, session, spaceRef, in)
 		if err != nil {
 			render.TranslatedUserError(w, err)
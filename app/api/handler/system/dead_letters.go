@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleListEventDeadLetters returns an http.HandlerFunc that lists the dead-lettered events.
+func HandleListEventDeadLetters(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		filter := request.ParseEventDeadLetterFilter(r)
+
+		deadLetters, totalCount, err := sysCtrl.ListEventDeadLetters(ctx, filter)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
+		render.JSON(w, http.StatusOK, deadLetters)
+	}
+}
+
+// HandleFindEventDeadLetter returns an http.HandlerFunc that returns a dead-lettered event.
+func HandleFindEventDeadLetter(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := request.GetDeadLetterIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		deadLetter, err := sysCtrl.FindEventDeadLetter(ctx, id)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, deadLetter)
+	}
+}
+
+// HandleRedeliverEventDeadLetter returns an http.HandlerFunc that redelivers a dead-lettered event.
+func HandleRedeliverEventDeadLetter(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := request.GetDeadLetterIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		redelivery, err := sysCtrl.RedeliverEventDeadLetter(ctx, id)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, redelivery)
+	}
+}
+
+// HandleDeleteEventDeadLetter returns an http.HandlerFunc that discards a dead-lettered event.
+func HandleDeleteEventDeadLetter(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := request.GetDeadLetterIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		err = sysCtrl.DeleteEventDeadLetter(ctx, id)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
@@ -19,10 +19,12 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/types"
 )
 
 type ConfigOutput struct {
-	UserSignupAllowed bool `json:"user_signup_allowed"`
+	UserSignupAllowed bool                  `json:"user_signup_allowed"`
+	Maintenance       types.MaintenanceMode `json:"maintenance"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -36,8 +38,16 @@ func HandleGetConfig(sysCtrl *system.Controller) http.HandlerFunc {
 			render.TranslatedUserError(w, err)
 			return
 		}
+
+		maintenance, err := sysCtrl.GetMaintenanceMode(ctx)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			UserSignupAllowed: userSignupAllowed,
+			Maintenance:       maintenance,
 		})
 	}
 }
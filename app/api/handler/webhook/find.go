@@ -16,6 +16,7 @@ package webhook
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
@@ -46,6 +47,7 @@ func HandleFind(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
+		render.ETag(w, strconv.FormatInt(webhook.Version, 10))
 		render.JSON(w, http.StatusOK, webhook)
 	}
 }
@@ -17,6 +17,7 @@ package webhook
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
@@ -48,12 +49,13 @@ func HandleUpdate(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		hook, err := webhookCtrl.Update(ctx, session, repoRef, webhookID, in)
+		hook, err := webhookCtrl.Update(ctx, session, repoRef, webhookID, in, request.GetIfMatchFromHeader(r))
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
 		}
 
+		render.ETag(w, strconv.FormatInt(hook.Version, 10))
 		render.JSON(w, http.StatusOK, hook)
 	}
 }
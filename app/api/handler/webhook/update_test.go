@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource,
+	enum.Permission) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{ID: 1, Path: "space/repo"}, nil
+}
+
+// fakeWebhookStore updates the webhook with optimistic locking like the database store does.
+// With concurrentUpdate the stored webhook gets updated between finding and updating it.
+type fakeWebhookStore struct {
+	store.WebhookStore
+	hook             types.Webhook
+	concurrentUpdate bool
+}
+
+func (s *fakeWebhookStore) Find(context.Context, int64) (*types.Webhook, error) {
+	hook := s.hook
+	if s.concurrentUpdate {
+		s.hook.Version++
+	}
+	return &hook, nil
+}
+
+func (s *fakeWebhookStore) Update(_ context.Context, hook *types.Webhook) error {
+	if hook.Version != s.hook.Version {
+		return gitness_store.ErrVersionConflict
+	}
+	hook.Version++
+	s.hook = *hook
+	return nil
+}
+
+type fakeInstanceSettingCache struct{}
+
+func (fakeInstanceSettingCache) Stats() (int64, int64) { return 0, 0 }
+
+func (fakeInstanceSettingCache) Get(context.Context, enum.InstanceSettingKey) (*types.InstanceSetting, error) {
+	return nil, nil
+}
+
+func (fakeInstanceSettingCache) Evict(context.Context, enum.InstanceSettingKey) {}
+
+func TestHandleUpdateIfMatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		ifMatch          string
+		concurrentUpdate bool
+		wantStatus       int
+		wantETag         string
+		wantVersion      int64
+	}{
+		{name: "no precondition", wantStatus: http.StatusOK, wantETag: `"3"`, wantVersion: 3},
+		{name: "matching version", ifMatch: `"2"`, wantStatus: http.StatusOK, wantETag: `"3"`, wantVersion: 3},
+		{name: "stale version", ifMatch: `"1"`, wantStatus: http.StatusPreconditionFailed, wantVersion: 2},
+		{name: "concurrent update", ifMatch: `"2"`, concurrentUpdate: true,
+			wantStatus: http.StatusPreconditionFailed, wantVersion: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookStore := &fakeWebhookStore{
+				hook: types.Webhook{
+					ID:          1,
+					Version:     2,
+					ParentID:    1,
+					ParentType:  enum.WebhookParentRepo,
+					DisplayName: "hook",
+				},
+				concurrentUpdate: test.concurrentUpdate,
+			}
+			instanceSettings := instancesettings.NewService(instancesettings.Defaults{}, nil, nil,
+				fakeInstanceSettingCache{}, nil)
+			ctrl := webhook.NewController(fakeAuthorizer{}, webhookStore, nil, fakeRepoStore{}, nil, nil,
+				instanceSettings)
+
+			router := chi.NewRouter()
+			router.Patch("/repos/{repo_ref}/webhooks/{webhook_id}", HandleUpdate(ctrl))
+
+			r := httptest.NewRequest(http.MethodPatch, "/repos/space%2Frepo/webhooks/1",
+				strings.NewReader(`{"display_name":"updated"}`))
+			if test.ifMatch != "" {
+				r.Header.Set(request.HeaderIfMatch, test.ifMatch)
+			}
+			r = r.WithContext(request.WithAuthSession(r.Context(), &auth.Session{}))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			if w.Code != test.wantStatus {
+				t.Errorf("want status %d, got %d: %s", test.wantStatus, w.Code, w.Body.String())
+			}
+			if etag := w.Header().Get("ETag"); etag != test.wantETag {
+				t.Errorf("want ETag %q, got %q", test.wantETag, etag)
+			}
+			if webhookStore.hook.Version != test.wantVersion {
+				t.Errorf("want stored version %d, got %d", test.wantVersion, webhookStore.hook.Version)
+			}
+		})
+	}
+}
@@ -57,6 +57,12 @@ func performAuthentication(
 					log.Warn().Err(err).Msg("authentication failed")
 				}
 
+				// blocked and suspended principals are rejected even if authentication is optional
+				if errors.Is(err, authn.ErrPrincipalInactive) {
+					render.Forbidden(w)
+					return
+				}
+
 				if required {
 					render.Unauthorized(w)
 					return
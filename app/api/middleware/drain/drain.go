@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/inflight"
+)
+
+// Start returns a middleware that tracks the requests in flight using the provided tracker.
+// New requests are rejected while the tracker is draining.
+func Start(tracker *inflight.Tracker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done, err := tracker.Start()
+			if errors.Is(err, inflight.ErrDraining) {
+				render.UserError(w, usererror.New(http.StatusServiceUnavailable,
+					"The server is shutting down, please try again."))
+				return
+			}
+			defer done()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Join returns a middleware that tracks the requests in flight using the provided tracker.
+// Requests are never rejected, it is meant for requests that are part of other requests in flight.
+func Join(tracker *inflight.Tracker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done := tracker.Join()
+			defer done()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -16,11 +16,17 @@ package logging
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/loglevel"
 
+	"github.com/go-chi/chi"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zerologr"
 	"github.com/rs/xid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
@@ -28,6 +34,9 @@ import (
 
 const (
 	requestIDHeader = "X-Request-Id"
+
+	// routeUnmatched is the route logged for requests that don't match any route.
+	routeUnmatched = "unmatched"
 )
 
 // HLogRequestIDHandler provides a middleware that injects request_id into the logging and execution context.
@@ -77,3 +86,62 @@ func HLogAccessLogHandler() func(http.Handler) http.Handler {
 		},
 	)
 }
+
+// HLogRouteHandler provides a middleware that injects a logger with the route of the request and
+// the referenced repo / space into the logging context.
+// The logger uses the log level configured for the subsystem and the space of the request.
+// NOTE: the middleware has to be registered before any other middleware that updates the logging context.
+func HLogRouteHandler(subsystem loglevel.Subsystem, routes chi.Routes) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// find the route upfront using a dedicated routing context to not interfere with the actual routing.
+			path := r.URL.RawPath
+			if path == "" {
+				path = r.URL.Path
+			}
+			route := routeUnmatched
+			rctx := chi.NewRouteContext()
+			if routes.Match(rctx, r.Method, path) {
+				route = rctx.RoutePattern()
+			}
+
+			logCtx := zerolog.Ctx(ctx).With().
+				Str("http.route", route)
+
+			// paths are unescaped
+			var spacePath string
+			if ref, err := url.PathUnescape(rctx.URLParam(request.PathParamRepoRef)); err == nil && ref != "" {
+				logCtx = logCtx.Str("repo_ref", ref)
+				spacePath, _, _ = paths.DisectLeaf(ref)
+			} else if ref, err = url.PathUnescape(rctx.URLParam(request.PathParamSpaceRef)); err == nil && ref != "" {
+				logCtx = logCtx.Str("space_ref", ref)
+				spacePath = ref
+			}
+
+			log := loglevel.Logger(logCtx.Logger(), subsystem, spacePath)
+			ctx = log.WithContext(ctx)
+			ctx = logr.NewContext(ctx, zerologr.New(&log))
+
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// HLogPrincipalHandler provides a middleware that injects the authenticated principal (if any) into the logging context.
+func HLogPrincipalHandler() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal, ok := request.PrincipalFrom(r.Context()); ok {
+				zerolog.Ctx(r.Context()).UpdateContext(func(c zerolog.Context) zerolog.Context {
+					return c.
+						Str("principal_uid", principal.UID).
+						Str("principal_type", string(principal.Type))
+				})
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
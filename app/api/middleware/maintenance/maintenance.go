@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/instancesettings"
+)
+
+// Handler returns a middleware that rejects unsafe (write) requests while the instance is in maintenance mode.
+// Requests with a path starting with one of the exempt prefixes are always served,
+// e.g. to allow users to log in and admins to end the maintenance.
+func Handler(
+	instanceSettings *instancesettings.Service,
+	exemptPrefixes ...string,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) || isExempt(r.URL.Path, exemptPrefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mode, err := instanceSettings.MaintenanceMode(r.Context())
+			if err != nil {
+				render.TranslatedUserError(w, err)
+				return
+			}
+
+			if mode.Enabled {
+				render.UserError(w, usererror.New(http.StatusServiceUnavailable, mode.Message))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func isExempt(path string, exemptPrefixes []string) bool {
+	for _, prefix := range exemptPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
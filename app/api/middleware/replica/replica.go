@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replica
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+// cookieName is the name of the cookie keeping the reads of a client on the primary database after a write.
+const cookieName = "gitness_db_primary_until"
+
+// Handler returns a middleware that allows safe (read-only) requests to read from database replicas.
+// To let clients read their own writes, any unsafe request keeps the following requests of the client
+// on the primary database for the sticky duration (which should cover the expected replication lag).
+func Handler(stickiness time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isSafeMethod(r.Method) {
+				until := time.Now().Add(stickiness)
+				http.SetCookie(w, &http.Cookie{
+					Name:     cookieName,
+					Value:    strconv.FormatInt(until.UnixMilli(), 10),
+					Path:     "/",
+					Expires:  until,
+					HttpOnly: true,
+					SameSite: http.SameSiteLaxMode,
+				})
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if isSticky(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(dbtx.WithReplicaReads(r.Context())))
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isSticky returns true if the client has to read from the primary database because of a recent write.
+func isSticky(r *http.Request) bool {
+	cookie, err := r.Cookie(cookieName)
+	if err != nil {
+		return false
+	}
+
+	until, err := strconv.ParseInt(cookie.Value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().UnixMilli() < until
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/accessrequest"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type repoAccessRequestRequest struct {
+	repoRequest
+	ID int64 `path:"access_request_id"`
+}
+
+type createRepoAccessRequestRequest struct {
+	repoRequest
+	accessrequest.CreateInput
+}
+
+type approveRepoAccessRequestRequest struct {
+	repoAccessRequestRequest
+	accessrequest.ApproveInput
+}
+
+type denyRepoAccessRequestRequest struct {
+	repoAccessRequestRequest
+	accessrequest.DenyInput
+}
+
+type spaceAccessRequestRequest struct {
+	spaceRequest
+	ID int64 `path:"access_request_id"`
+}
+
+type createSpaceAccessRequestRequest struct {
+	spaceRequest
+	accessrequest.CreateInput
+}
+
+type approveSpaceAccessRequestRequest struct {
+	spaceAccessRequestRequest
+	accessrequest.ApproveInput
+}
+
+type denySpaceAccessRequestRequest struct {
+	spaceAccessRequestRequest
+	accessrequest.DenyInput
+}
+
+// accessRequestRequests groups the request types of the access request operations of a parent.
+type accessRequestRequests struct {
+	parent  interface{}
+	request interface{}
+	create  interface{}
+	approve interface{}
+	deny    interface{}
+}
+
+var queryParameterStateAccessRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the access requests to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.AccessRequestState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+func accessRequestOperations(reflector *openapi3.Reflector) {
+	accessRequestParentOperations(reflector, "/repos/{repo_ref}", "Repo", accessRequestRequests{
+		parent:  new(repoRequest),
+		request: new(repoAccessRequestRequest),
+		create:  new(createRepoAccessRequestRequest),
+		approve: new(approveRepoAccessRequestRequest),
+		deny:    new(denyRepoAccessRequestRequest),
+	})
+	accessRequestParentOperations(reflector, "/spaces/{space_ref}", "Space", accessRequestRequests{
+		parent:  new(spaceRequest),
+		request: new(spaceAccessRequestRequest),
+		create:  new(createSpaceAccessRequestRequest),
+		approve: new(approveSpaceAccessRequestRequest),
+		deny:    new(denySpaceAccessRequestRequest),
+	})
+}
+
+//nolint:funlen
+func accessRequestParentOperations(
+	reflector *openapi3.Reflector,
+	parentPath string,
+	parentName string,
+	requests accessRequestRequests,
+) {
+	path := parentPath + "/access-requests"
+	pathRequest := path + "/{access_request_id}"
+
+	createAccessRequest := openapi3.Operation{}
+	createAccessRequest.WithTags("access request")
+	createAccessRequest.WithMapOfAnything(
+		map[string]interface{}{"operationId": "create" + parentName + "AccessRequest"})
+	_ = reflector.SetRequest(&createAccessRequest, requests.create, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(types.AccessRequest), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&createAccessRequest, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, path, createAccessRequest)
+
+	listAccessRequests := openapi3.Operation{}
+	listAccessRequests.WithTags("access request")
+	listAccessRequests.WithMapOfAnything(
+		map[string]interface{}{"operationId": "list" + parentName + "AccessRequests"})
+	listAccessRequests.WithParameters(queryParameterStateAccessRequest, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listAccessRequests, requests.parent, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listAccessRequests, new([]types.AccessRequest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listAccessRequests, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listAccessRequests, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listAccessRequests, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path, listAccessRequests)
+
+	getAccessRequest := openapi3.Operation{}
+	getAccessRequest.WithTags("access request")
+	getAccessRequest.WithMapOfAnything(
+		map[string]interface{}{"operationId": "get" + parentName + "AccessRequest"})
+	_ = reflector.SetRequest(&getAccessRequest, requests.request, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getAccessRequest, new(types.AccessRequest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getAccessRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getAccessRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getAccessRequest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getAccessRequest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, pathRequest, getAccessRequest)
+
+	approveAccessRequest := openapi3.Operation{}
+	approveAccessRequest.WithTags("access request")
+	approveAccessRequest.WithMapOfAnything(
+		map[string]interface{}{"operationId": "approve" + parentName + "AccessRequest"})
+	_ = reflector.SetRequest(&approveAccessRequest, requests.approve, http.MethodPost)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(types.AccessRequest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&approveAccessRequest, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, pathRequest+"/approve", approveAccessRequest)
+
+	denyAccessRequest := openapi3.Operation{}
+	denyAccessRequest.WithTags("access request")
+	denyAccessRequest.WithMapOfAnything(
+		map[string]interface{}{"operationId": "deny" + parentName + "AccessRequest"})
+	_ = reflector.SetRequest(&denyAccessRequest, requests.deny, http.MethodPost)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(types.AccessRequest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&denyAccessRequest, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, pathRequest+"/deny", denyAccessRequest)
+}
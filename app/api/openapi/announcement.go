@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type announcementRequest struct {
+	ID int64 `path:"announcement_id"`
+}
+
+type adminCreateAnnouncementRequest struct {
+	system.CreateAnnouncementInput
+}
+
+type adminUpdateAnnouncementRequest struct {
+	announcementRequest
+	system.UpdateAnnouncementInput
+}
+
+// announcementOperations registers the endpoints of the instance announcements.
+func announcementOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAnnouncements"})
+	opList.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Announcement), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/announcements", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("admin")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateAnnouncement"})
+	_ = reflector.SetRequest(&opCreate, new(adminCreateAnnouncementRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Announcement), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/announcements", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindAnnouncement"})
+	_ = reflector.SetRequest(&opFind, new(announcementRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Announcement), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/announcements/{announcement_id}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateAnnouncement"})
+	_ = reflector.SetRequest(&opUpdate, new(adminUpdateAnnouncementRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Announcement), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/announcements/{announcement_id}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteAnnouncement"})
+	_ = reflector.SetRequest(&opDelete, new(announcementRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/announcements/{announcement_id}", opDelete)
+
+	opListActive := openapi3.Operation{}
+	opListActive.WithTags("user")
+	opListActive.WithMapOfAnything(map[string]interface{}{"operationId": "listAnnouncements"})
+	_ = reflector.SetRequest(&opListActive, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListActive, new([]*types.Announcement), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListActive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListActive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/announcements", opListActive)
+
+	opDismiss := openapi3.Operation{}
+	opDismiss.WithTags("user")
+	opDismiss.WithMapOfAnything(map[string]interface{}{"operationId": "dismissAnnouncement"})
+	_ = reflector.SetRequest(&opDismiss, new(announcementRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDismiss, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDismiss, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDismiss, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDismiss, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDismiss, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/announcements/{announcement_id}/dismiss", opDismiss)
+}
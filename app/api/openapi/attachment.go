@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/attachment"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type attachmentRequest struct {
+	repoRequest
+	Ref string `path:"attachment_ref"`
+}
+
+// attachmentRequestBody is the raw content of an uploaded attachment.
+var attachmentRequestBody = openapi3.RequestBodyOrRef{
+	RequestBody: &openapi3.RequestBody{
+		Required: ptr.Bool(true),
+		Content: map[string]openapi3.MediaType{
+			"application/octet-stream": {
+				Schema: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type:   ptrSchemaType(openapi3.SchemaTypeString),
+						Format: ptr.String("binary"),
+					},
+				},
+			},
+		},
+	},
+}
+
+func attachmentOperations(reflector *openapi3.Reflector) {
+	const tag = "attachment"
+
+	uploadAttachment := openapi3.Operation{}
+	uploadAttachment.WithTags(tag)
+	uploadAttachment.WithMapOfAnything(map[string]interface{}{"operationId": "uploadAttachment"})
+	_ = reflector.SetRequest(&uploadAttachment, new(repoRequest), http.MethodPost)
+	uploadAttachment.WithRequestBody(attachmentRequestBody)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(attachment.UploadOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&uploadAttachment, new(usererror.Error), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/uploads", uploadAttachment)
+
+	downloadAttachment := openapi3.Operation{}
+	downloadAttachment.WithTags(tag)
+	downloadAttachment.WithMapOfAnything(map[string]interface{}{"operationId": "downloadAttachment"})
+	_ = reflector.SetRequest(&downloadAttachment, new(attachmentRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&downloadAttachment, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&downloadAttachment, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&downloadAttachment, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&downloadAttachment, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&downloadAttachment, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/uploads/{attachment_ref}", downloadAttachment)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/attestation"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type attestationRequest struct {
+	repoRequest
+	ID int64 `path:"attestation_id"`
+}
+
+func attestationOperations(reflector *openapi3.Reflector) {
+	const tag = "attestation"
+
+	createAttestation := openapi3.Operation{}
+	createAttestation.WithTags(tag)
+	createAttestation.WithMapOfAnything(map[string]interface{}{"operationId": "createAttestation"})
+	_ = reflector.SetRequest(&createAttestation, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		attestation.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createAttestation, new(types.Attestation), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createAttestation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createAttestation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createAttestation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createAttestation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createAttestation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/attestations/commits/{commit_sha}",
+		createAttestation)
+
+	listAttestations := openapi3.Operation{}
+	listAttestations.WithTags(tag)
+	listAttestations.WithMapOfAnything(map[string]interface{}{"operationId": "listAttestations"})
+	_ = reflector.SetRequest(&listAttestations, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listAttestations, new([]types.Attestation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listAttestations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listAttestations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listAttestations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listAttestations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/attestations/commits/{commit_sha}",
+		listAttestations)
+
+	verifyAttestation := openapi3.Operation{}
+	verifyAttestation.WithTags(tag)
+	verifyAttestation.WithMapOfAnything(map[string]interface{}{"operationId": "verifyAttestation"})
+	_ = reflector.SetRequest(&verifyAttestation, new(attestationRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&verifyAttestation, new(types.Attestation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&verifyAttestation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&verifyAttestation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&verifyAttestation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&verifyAttestation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/attestations/{attestation_id}/verify",
+		verifyAttestation)
+
+	createExecutionAttestation := openapi3.Operation{}
+	createExecutionAttestation.WithTags(tag)
+	createExecutionAttestation.WithMapOfAnything(
+		map[string]interface{}{"operationId": "createExecutionAttestation"})
+	_ = reflector.SetRequest(&createExecutionAttestation, struct {
+		executionRequest
+		attestation.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(types.Attestation), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createExecutionAttestation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/attestations",
+		createExecutionAttestation)
+
+	listExecutionAttestations := openapi3.Operation{}
+	listExecutionAttestations.WithTags(tag)
+	listExecutionAttestations.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listExecutionAttestations"})
+	_ = reflector.SetRequest(&listExecutionAttestations, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listExecutionAttestations, new([]types.Attestation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listExecutionAttestations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listExecutionAttestations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listExecutionAttestations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listExecutionAttestations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/attestations",
+		listExecutionAttestations)
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type principalAvatarRequest struct {
+	PrincipalUID string `path:"principal_uid"`
+}
+
+var queryParameterAvatarSize = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAvatarSize,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The size of the avatar in pixels, the closest available variant is returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
+func avatarOperations(reflector *openapi3.Reflector) {
+	const tag = "avatar"
+
+	opUploadUser := openapi3.Operation{}
+	opUploadUser.WithTags(tag)
+	opUploadUser.WithMapOfAnything(map[string]interface{}{"operationId": "uploadUserAvatar"})
+	_ = reflector.SetRequest(&opUploadUser, nil, http.MethodPut)
+	opUploadUser.WithRequestBody(attachmentRequestBody)
+	_ = reflector.SetJSONResponse(&opUploadUser, new(types.Avatar), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUploadUser, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUploadUser, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUploadUser, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUploadUser, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/avatar", opUploadUser)
+
+	opDeleteUser := openapi3.Operation{}
+	opDeleteUser.WithTags(tag)
+	opDeleteUser.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserAvatar"})
+	_ = reflector.SetRequest(&opDeleteUser, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteUser, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteUser, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/avatar", opDeleteUser)
+
+	opFindPrincipal := openapi3.Operation{}
+	opFindPrincipal.WithTags(tag)
+	opFindPrincipal.WithMapOfAnything(map[string]interface{}{"operationId": "getPrincipalAvatar"})
+	opFindPrincipal.WithParameters(queryParameterAvatarSize)
+	_ = reflector.SetRequest(&opFindPrincipal, new(principalAvatarRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opFindPrincipal, http.StatusOK, "image/png")
+	_ = reflector.SetJSONResponse(&opFindPrincipal, nil, http.StatusNotModified)
+	_ = reflector.SetJSONResponse(&opFindPrincipal, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindPrincipal, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPrincipal, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPrincipal, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals/{principal_uid}/avatar", opFindPrincipal)
+
+	opUploadSpace := openapi3.Operation{}
+	opUploadSpace.WithTags(tag)
+	opUploadSpace.WithMapOfAnything(map[string]interface{}{"operationId": "uploadSpaceAvatar"})
+	_ = reflector.SetRequest(&opUploadSpace, new(spaceRequest), http.MethodPut)
+	opUploadSpace.WithRequestBody(attachmentRequestBody)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(types.Avatar), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUploadSpace, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/avatar", opUploadSpace)
+
+	opDeleteSpace := openapi3.Operation{}
+	opDeleteSpace.WithTags(tag)
+	opDeleteSpace.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceAvatar"})
+	_ = reflector.SetRequest(&opDeleteSpace, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/avatar", opDeleteSpace)
+
+	opFindSpace := openapi3.Operation{}
+	opFindSpace.WithTags(tag)
+	opFindSpace.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceAvatar"})
+	opFindSpace.WithParameters(queryParameterAvatarSize)
+	_ = reflector.SetRequest(&opFindSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opFindSpace, http.StatusOK, "image/png")
+	_ = reflector.SetJSONResponse(&opFindSpace, nil, http.StatusNotModified)
+	_ = reflector.SetJSONResponse(&opFindSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/avatar", opFindSpace)
+}
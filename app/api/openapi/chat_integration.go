@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/chatintegration"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type repoChatIntegrationRequest struct {
+	repoRequest
+	ID int64 `path:"chat_integration_id"`
+}
+
+type createRepoChatIntegrationRequest struct {
+	repoRequest
+	chatintegration.CreateInput
+}
+
+type updateRepoChatIntegrationRequest struct {
+	repoChatIntegrationRequest
+	chatintegration.UpdateInput
+}
+
+type spaceChatIntegrationRequest struct {
+	spaceRequest
+	ID int64 `path:"chat_integration_id"`
+}
+
+type createSpaceChatIntegrationRequest struct {
+	spaceRequest
+	chatintegration.CreateInput
+}
+
+type updateSpaceChatIntegrationRequest struct {
+	spaceChatIntegrationRequest
+	chatintegration.UpdateInput
+}
+
+// chatIntegrationRequests groups the request types of the chat integration operations of a parent.
+type chatIntegrationRequests struct {
+	parent      interface{}
+	integration interface{}
+	create      interface{}
+	update      interface{}
+}
+
+func chatIntegrationOperations(reflector *openapi3.Reflector) {
+	chatIntegrationParentOperations(reflector, "/repos/{repo_ref}", "Repo", chatIntegrationRequests{
+		parent:      new(repoRequest),
+		integration: new(repoChatIntegrationRequest),
+		create:      new(createRepoChatIntegrationRequest),
+		update:      new(updateRepoChatIntegrationRequest),
+	})
+	chatIntegrationParentOperations(reflector, "/spaces/{space_ref}", "Space", chatIntegrationRequests{
+		parent:      new(spaceRequest),
+		integration: new(spaceChatIntegrationRequest),
+		create:      new(createSpaceChatIntegrationRequest),
+		update:      new(updateSpaceChatIntegrationRequest),
+	})
+}
+
+//nolint:funlen
+func chatIntegrationParentOperations(
+	reflector *openapi3.Reflector,
+	parentPath string,
+	parentName string,
+	requests chatIntegrationRequests,
+) {
+	path := parentPath + "/chat-integrations"
+	pathIntegration := path + "/{chat_integration_id}"
+
+	createChatIntegration := openapi3.Operation{}
+	createChatIntegration.WithTags("chat integration")
+	createChatIntegration.WithMapOfAnything(
+		map[string]interface{}{"operationId": "create" + parentName + "ChatIntegration"})
+	_ = reflector.SetRequest(&createChatIntegration, requests.create, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createChatIntegration, new(types.ChatIntegration), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createChatIntegration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createChatIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createChatIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createChatIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, path, createChatIntegration)
+
+	listChatIntegrations := openapi3.Operation{}
+	listChatIntegrations.WithTags("chat integration")
+	listChatIntegrations.WithMapOfAnything(
+		map[string]interface{}{"operationId": "list" + parentName + "ChatIntegrations"})
+	_ = reflector.SetRequest(&listChatIntegrations, requests.parent, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listChatIntegrations, new([]types.ChatIntegration), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listChatIntegrations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listChatIntegrations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listChatIntegrations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path, listChatIntegrations)
+
+	getChatIntegration := openapi3.Operation{}
+	getChatIntegration.WithTags("chat integration")
+	getChatIntegration.WithMapOfAnything(
+		map[string]interface{}{"operationId": "get" + parentName + "ChatIntegration"})
+	_ = reflector.SetRequest(&getChatIntegration, requests.integration, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getChatIntegration, new(types.ChatIntegration), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getChatIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getChatIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getChatIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getChatIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, pathIntegration, getChatIntegration)
+
+	updateChatIntegration := openapi3.Operation{}
+	updateChatIntegration.WithTags("chat integration")
+	updateChatIntegration.WithMapOfAnything(
+		map[string]interface{}{"operationId": "update" + parentName + "ChatIntegration"})
+	_ = reflector.SetRequest(&updateChatIntegration, requests.update, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(types.ChatIntegration), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateChatIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, pathIntegration, updateChatIntegration)
+
+	deleteChatIntegration := openapi3.Operation{}
+	deleteChatIntegration.WithTags("chat integration")
+	deleteChatIntegration.WithMapOfAnything(
+		map[string]interface{}{"operationId": "delete" + parentName + "ChatIntegration"})
+	_ = reflector.SetRequest(&deleteChatIntegration, requests.integration, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteChatIntegration, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteChatIntegration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteChatIntegration, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteChatIntegration, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteChatIntegration, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, pathIntegration, deleteChatIntegration)
+}
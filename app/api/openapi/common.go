@@ -46,6 +46,22 @@ var queryParameterPage = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterDryRun = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamDryRun,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the operation should only be validated. " +
+			"If set, the impact of the operation is returned instead of applying it."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterOrder = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamOrder,
@@ -82,6 +98,21 @@ var queryParameterLimit = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterDeletedAt = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamDeletedAt,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The time the resource was deleted at (unix millis)."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
 var queryParameterAfter = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamAfter,
@@ -96,3 +127,18 @@ var queryParameterAfter = openapi3.ParameterOrRef{
 		},
 	},
 }
+
+var headerParameterIfMatch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.HeaderIfMatch,
+		In:   openapi3.ParameterInHeader,
+		Description: ptr.String("The entity tag of the resource as last seen by the client. " +
+			"If it doesn't match the current entity tag, the update is rejected."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
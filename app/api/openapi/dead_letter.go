@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type adminEventDeadLetterRequest struct {
+	ID int64 `path:"dead_letter_id"`
+}
+
+var queryParameterStreamDeadLetter = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamStream,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The stream of the dead-lettered events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterGroupDeadLetter = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamGroup,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The consumer group of the dead-lettered events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+// eventDeadLetterOperations registers the admin endpoints of the dead-lettered events.
+func eventDeadLetterOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListEventDeadLetters"})
+	opList.WithParameters(queryParameterStreamDeadLetter, queryParameterGroupDeadLetter,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.EventDeadLetter), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/events/dead-letters", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindEventDeadLetter"})
+	_ = reflector.SetRequest(&opFind, new(adminEventDeadLetterRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.EventDeadLetter), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/events/dead-letters/{dead_letter_id}", opFind)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteEventDeadLetter"})
+	_ = reflector.SetRequest(&opDelete, new(adminEventDeadLetterRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/events/dead-letters/{dead_letter_id}", opDelete)
+
+	opRedeliver := openapi3.Operation{}
+	opRedeliver.WithTags("admin")
+	opRedeliver.WithMapOfAnything(map[string]interface{}{"operationId": "adminRedeliverEventDeadLetter"})
+	_ = reflector.SetRequest(&opRedeliver, new(adminEventDeadLetterRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRedeliver, new(system.EventDeadLetterRedelivery), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRedeliver, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRedeliver, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRedeliver, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRedeliver, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/admin/events/dead-letters/{dead_letter_id}/redeliver", opRedeliver)
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/filelock"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type fileLockRequest struct {
+	repoRequest
+	ID int64 `path:"file_lock_id"`
+}
+
+var queryParameterFileLockPath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return the lock of this path."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterFileLockOwnerID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamOwnerID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return the locks owned by the principal with this id."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterFileLockForce = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamForce,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Remove the lock even though it's owned by another principal."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+func fileLockOperations(reflector *openapi3.Reflector) {
+	const tag = "file_lock"
+
+	createLock := openapi3.Operation{}
+	createLock.WithTags(tag)
+	createLock.WithMapOfAnything(map[string]interface{}{"operationId": "createFileLock"})
+	_ = reflector.SetRequest(&createLock, struct {
+		repoRequest
+		filelock.CreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createLock, new(types.FileLock), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createLock, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createLock, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createLock, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createLock, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createLock, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/locks", createLock)
+
+	listLocks := openapi3.Operation{}
+	listLocks.WithTags(tag)
+	listLocks.WithMapOfAnything(map[string]interface{}{"operationId": "listFileLocks"})
+	listLocks.WithParameters(queryParameterFileLockPath, queryParameterFileLockOwnerID,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listLocks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listLocks, new([]types.FileLock), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listLocks, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listLocks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listLocks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listLocks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listLocks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/locks", listLocks)
+
+	deleteLock := openapi3.Operation{}
+	deleteLock.WithTags(tag)
+	deleteLock.WithMapOfAnything(map[string]interface{}{"operationId": "deleteFileLock"})
+	deleteLock.WithParameters(queryParameterFileLockForce)
+	_ = reflector.SetRequest(&deleteLock, new(fileLockRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteLock, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteLock, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteLock, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteLock, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteLock, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/locks/{file_lock_id}", deleteLock)
+}
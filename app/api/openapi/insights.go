@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/insights"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterInsightsSince = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSince,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in ms) since when statistics are returned (default: 30 days ago, 7 for pulse)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterInsightsUntil = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUntil,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in ms) until when statistics should be returned. Defaults to now."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterMaxAgeDays = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMaxAgeDays,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Days after creation after which an open pull request is aging. Defaults to the space setting."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterMaxIdleDays = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMaxIdleDays,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Days without activity after which an open pull request is aging. Defaults to the space setting."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterPullReqAgingGroupBy = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamGroupBy,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("How the aging pull requests are grouped."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(enum.PullReqAgingGroupByRepo)),
+				Enum:    enum.PullReqAgingGroupBy("").Enum(),
+			},
+		},
+	},
+}
+
+func insightsOperations(reflector *openapi3.Reflector) {
+	insightsParentOperations(reflector, "/repos/{repo_ref}", "Repo", new(repoRequest))
+	insightsParentOperations(reflector, "/spaces/{space_ref}", "Space", new(spaceRequest))
+
+	opPulse := openapi3.Operation{}
+	opPulse.WithTags("insights")
+	opPulse.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoPulse"})
+	opPulse.WithParameters(queryParameterInsightsSince, queryParameterInsightsUntil)
+	_ = reflector.SetRequest(&opPulse, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPulse, new(insights.Pulse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPulse, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPulse, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPulse, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPulse, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPulse, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pulse", opPulse)
+
+	opAging := openapi3.Operation{}
+	opAging.WithTags("insights")
+	opAging.WithMapOfAnything(map[string]interface{}{"operationId": "listSpacePullReqAging"})
+	opAging.WithParameters(queryParameterMaxAgeDays, queryParameterMaxIdleDays, queryParameterPullReqAgingGroupBy)
+	_ = reflector.SetRequest(&opAging, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAging, new(types.PullReqAgingReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAging, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAging, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAging, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAging, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAging, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/insights/pullreq-aging", opAging)
+}
+
+func insightsParentOperations(
+	reflector *openapi3.Reflector,
+	parentPath string,
+	parentName string,
+	parentRequest interface{},
+) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("insights")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "find" + parentName + "Insights"})
+	opFind.WithParameters(queryParameterInsightsSince, queryParameterInsightsUntil)
+	_ = reflector.SetRequest(&opFind, parentRequest, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Insights), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, parentPath+"/insights", opFind)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type instanceSettingRequest struct {
+	Key enum.InstanceSettingKey `path:"setting_key"`
+}
+
+type adminUpdateInstanceSettingRequest struct {
+	instanceSettingRequest
+	system.UpdateInstanceSettingInput
+}
+
+var queryParameterKeyInstanceSettingChanges = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamKey,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The key of the instance setting the changes belong to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.InstanceSettingKey("").Enum(),
+			},
+		},
+	},
+}
+
+// instanceSettingOperations registers the endpoints of the instance settings.
+func instanceSettingOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListInstanceSettings"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.EffectiveInstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/settings", opList)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateInstanceSetting"})
+	_ = reflector.SetRequest(&opUpdate, new(adminUpdateInstanceSettingRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.EffectiveInstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/settings/{setting_key}", opUpdate)
+
+	opReset := openapi3.Operation{}
+	opReset.WithTags("admin")
+	opReset.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetInstanceSetting"})
+	_ = reflector.SetRequest(&opReset, new(instanceSettingRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opReset, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/settings/{setting_key}", opReset)
+
+	opChanges := openapi3.Operation{}
+	opChanges.WithTags("admin")
+	opChanges.WithMapOfAnything(map[string]interface{}{"operationId": "adminListInstanceSettingChanges"})
+	opChanges.WithParameters(queryParameterKeyInstanceSettingChanges, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opChanges, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opChanges, new([]*types.InstanceSettingChange), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opChanges, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opChanges, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opChanges, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/settings/changes", opChanges)
+}
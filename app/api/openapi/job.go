@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type adminJobRequest struct {
+	JobUID string `path:"job_uid"`
+}
+
+var queryParameterStateJob = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the jobs to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.JobState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterTypeJob = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the jobs to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryJob = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the jobs by their uid."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+// jobOperations registers the admin endpoints of the background jobs.
+func jobOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListJobs"})
+	opList.WithParameters(queryParameterStateJob, queryParameterTypeJob, queryParameterQueryJob,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/jobs", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindJob"})
+	_ = reflector.SetRequest(&opFind, new(adminJobRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/jobs/{job_uid}", opFind)
+
+	opCancel := openapi3.Operation{}
+	opCancel.WithTags("admin")
+	opCancel.WithMapOfAnything(map[string]interface{}{"operationId": "adminCancelJob"})
+	_ = reflector.SetRequest(&opCancel, new(adminJobRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCancel, new(types.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/jobs/{job_uid}/cancel", opCancel)
+
+	opRetry := openapi3.Operation{}
+	opRetry.WithTags("admin")
+	opRetry.WithMapOfAnything(map[string]interface{}{"operationId": "adminRetryJob"})
+	_ = reflector.SetRequest(&opRetry, new(adminJobRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRetry, new(types.Job), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRetry, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/jobs/{job_uid}/retry", opRetry)
+}
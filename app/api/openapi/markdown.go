@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/markdown"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type renderMarkdownRequest struct {
+	markdown.RenderInput
+}
+
+func markdownOperations(reflector *openapi3.Reflector) {
+	renderMarkdown := openapi3.Operation{}
+	renderMarkdown.WithTags("markdown")
+	renderMarkdown.WithMapOfAnything(map[string]interface{}{"operationId": "renderMarkdown"})
+	_ = reflector.SetRequest(&renderMarkdown, new(renderMarkdownRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(markdown.RenderOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&renderMarkdown, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/markdown", renderMarkdown)
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/oauth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type oauthAppRequest struct {
+	ID int64 `path:"oauth_app_id"`
+}
+
+type createOAuthAppRequest struct {
+	oauth.CreateAppInput
+}
+
+type updateOAuthAppRequest struct {
+	oauthAppRequest
+	oauth.UpdateAppInput
+}
+
+type revokeOAuthAuthorizationRequest struct {
+	ID int64 `path:"oauth_authorization_id"`
+}
+
+type oauthRevokeRequest struct {
+	Token         string `formData:"token"`
+	TokenTypeHint string `formData:"token_type_hint"`
+	ClientID      string `formData:"client_id"`
+	ClientSecret  string `formData:"client_secret"`
+}
+
+type oauthAppInfoRequest struct {
+	ClientID string `path:"client_id"`
+}
+
+type oauthAuthorizeRequest struct {
+	ResponseType        string `query:"response_type"`
+	ClientID            string `query:"client_id"`
+	RedirectURI         string `query:"redirect_uri"`
+	Scope               string `query:"scope"`
+	State               string `query:"state"`
+	Nonce               string `query:"nonce"`
+	CodeChallenge       string `query:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method"`
+}
+
+type oauthAuthorizeConsentRequest struct {
+	oauth.AuthorizeInput
+}
+
+type oauthTokenRequest struct {
+	GrantType    string `formData:"grant_type"`
+	Code         string `formData:"code"`
+	RedirectURI  string `formData:"redirect_uri"`
+	CodeVerifier string `formData:"code_verifier"`
+	ClientID     string `formData:"client_id"`
+	ClientSecret string `formData:"client_secret"`
+}
+
+//nolint:funlen // api spec generation no need for checking func complexity
+func oauthOperations(reflector *openapi3.Reflector) {
+	opCreateApp := openapi3.Operation{}
+	opCreateApp.WithTags("oauth")
+	opCreateApp.WithMapOfAnything(map[string]interface{}{"operationId": "createOAuthApp"})
+	_ = reflector.SetRequest(&opCreateApp, new(createOAuthAppRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateApp, new(oauth.CreateAppOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateApp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateApp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateApp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateApp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/oauth-apps", opCreateApp)
+
+	opListApps := openapi3.Operation{}
+	opListApps.WithTags("oauth")
+	opListApps.WithMapOfAnything(map[string]interface{}{"operationId": "listOAuthApps"})
+	_ = reflector.SetRequest(&opListApps, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListApps, []types.OAuthApp{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListApps, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListApps, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListApps, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/oauth-apps", opListApps)
+
+	opFindApp := openapi3.Operation{}
+	opFindApp.WithTags("oauth")
+	opFindApp.WithMapOfAnything(map[string]interface{}{"operationId": "findOAuthApp"})
+	_ = reflector.SetRequest(&opFindApp, new(oauthAppRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindApp, new(types.OAuthApp), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindApp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindApp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindApp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindApp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/oauth-apps/{oauth_app_id}", opFindApp)
+
+	opUpdateApp := openapi3.Operation{}
+	opUpdateApp.WithTags("oauth")
+	opUpdateApp.WithMapOfAnything(map[string]interface{}{"operationId": "updateOAuthApp"})
+	_ = reflector.SetRequest(&opUpdateApp, new(updateOAuthAppRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(types.OAuthApp), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateApp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/oauth-apps/{oauth_app_id}", opUpdateApp)
+
+	opDeleteApp := openapi3.Operation{}
+	opDeleteApp.WithTags("oauth")
+	opDeleteApp.WithMapOfAnything(map[string]interface{}{"operationId": "deleteOAuthApp"})
+	_ = reflector.SetRequest(&opDeleteApp, new(oauthAppRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteApp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteApp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteApp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteApp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteApp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/oauth-apps/{oauth_app_id}", opDeleteApp)
+
+	opRegenerateClientSecret := openapi3.Operation{}
+	opRegenerateClientSecret.WithTags("oauth")
+	opRegenerateClientSecret.WithMapOfAnything(map[string]interface{}{"operationId": "regenerateOAuthAppClientSecret"})
+	_ = reflector.SetRequest(&opRegenerateClientSecret, new(oauthAppRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegenerateClientSecret, new(oauth.CreateAppOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRegenerateClientSecret, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRegenerateClientSecret, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRegenerateClientSecret, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRegenerateClientSecret, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/user/oauth-apps/{oauth_app_id}/client-secret", opRegenerateClientSecret)
+
+	opListAuthorizations := openapi3.Operation{}
+	opListAuthorizations.WithTags("oauth")
+	opListAuthorizations.WithMapOfAnything(map[string]interface{}{"operationId": "listOAuthAuthorizations"})
+	_ = reflector.SetRequest(&opListAuthorizations, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListAuthorizations, []types.OAuthAuthorization{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListAuthorizations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListAuthorizations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListAuthorizations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/oauth-authorizations", opListAuthorizations)
+
+	opRevokeAuthorization := openapi3.Operation{}
+	opRevokeAuthorization.WithTags("oauth")
+	opRevokeAuthorization.WithMapOfAnything(map[string]interface{}{"operationId": "revokeOAuthAuthorization"})
+	_ = reflector.SetRequest(&opRevokeAuthorization, new(revokeOAuthAuthorizationRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRevokeAuthorization, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRevokeAuthorization, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevokeAuthorization, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevokeAuthorization, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevokeAuthorization, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/oauth-authorizations/{oauth_authorization_id}", opRevokeAuthorization)
+
+	opFindAppInfo := openapi3.Operation{}
+	opFindAppInfo.WithTags("oauth")
+	opFindAppInfo.WithMapOfAnything(map[string]interface{}{"operationId": "findOAuthAppInfo"})
+	_ = reflector.SetRequest(&opFindAppInfo, new(oauthAppInfoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindAppInfo, new(types.OAuthAppInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindAppInfo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindAppInfo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindAppInfo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oauth/apps/{client_id}", opFindAppInfo)
+
+	opDiscovery := openapi3.Operation{}
+	opDiscovery.WithTags("oauth")
+	opDiscovery.WithMapOfAnything(map[string]interface{}{"operationId": "oauthDiscovery"})
+	_ = reflector.SetRequest(&opDiscovery, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDiscovery, new(oauth.DiscoveryDocument), http.StatusOK)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oauth/.well-known/openid-configuration", opDiscovery)
+
+	opAuthorize := openapi3.Operation{}
+	opAuthorize.WithTags("oauth")
+	opAuthorize.WithMapOfAnything(map[string]interface{}{"operationId": "oauthAuthorize"})
+	_ = reflector.SetRequest(&opAuthorize, new(oauthAuthorizeRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAuthorize, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opAuthorize, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuthorize, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuthorize, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oauth/authorize", opAuthorize)
+
+	opAuthorizeConsent := openapi3.Operation{}
+	opAuthorizeConsent.WithTags("oauth")
+	opAuthorizeConsent.WithMapOfAnything(map[string]interface{}{"operationId": "oauthAuthorizeConsent"})
+	_ = reflector.SetRequest(&opAuthorizeConsent, new(oauthAuthorizeConsentRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(oauth.AuthorizeOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAuthorizeConsent, new(usererror.Error), http.StatusUnsupportedMediaType)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/oauth/authorize", opAuthorizeConsent)
+
+	opToken := openapi3.Operation{}
+	opToken.WithTags("oauth")
+	opToken.WithMapOfAnything(map[string]interface{}{"operationId": "oauthToken"})
+	_ = reflector.SetRequest(&opToken, new(oauthTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opToken, new(oauth.TokenOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opToken, new(oauth.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opToken, new(oauth.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/oauth/token", opToken)
+
+	opRevoke := openapi3.Operation{}
+	opRevoke.WithTags("oauth")
+	opRevoke.WithMapOfAnything(map[string]interface{}{"operationId": "oauthRevoke"})
+	_ = reflector.SetRequest(&opRevoke, new(oauthRevokeRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevoke, nil, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevoke, new(oauth.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevoke, new(oauth.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevoke, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/oauth/revoke", opRevoke)
+
+	opListSpaceIntegrations := openapi3.Operation{}
+	opListSpaceIntegrations.WithTags("oauth")
+	opListSpaceIntegrations.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceOAuthIntegrations"})
+	_ = reflector.SetRequest(&opListSpaceIntegrations, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSpaceIntegrations, []types.OAuthIntegration{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSpaceIntegrations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSpaceIntegrations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSpaceIntegrations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSpaceIntegrations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/oauth-integrations", opListSpaceIntegrations)
+
+	opUserInfo := openapi3.Operation{}
+	opUserInfo.WithTags("oauth")
+	opUserInfo.WithMapOfAnything(map[string]interface{}{"operationId": "oauthUserInfo"})
+	_ = reflector.SetRequest(&opUserInfo, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserInfo, new(oauth.UserInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserInfo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserInfo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserInfo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oauth/userinfo", opUserInfo)
+}
@@ -60,7 +60,25 @@ func Generate() *openapi3.Spec {
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
 	webhookOperations(&reflector)
+	chatIntegrationOperations(&reflector)
+	accessRequestOperations(&reflector)
+	insightsOperations(&reflector)
+	scanOperations(&reflector)
+	sbomOperations(&reflector)
+	attachmentOperations(&reflector)
+	avatarOperations(&reflector)
+	attestationOperations(&reflector)
+	fileLockOperations(&reflector)
+	userGroupOperations(&reflector)
 	checkOperations(&reflector)
+	jobOperations(&reflector)
+	eventDeadLetterOperations(&reflector)
+	uploadQuarantineOperations(&reflector)
+	backupOperations(&reflector)
+	announcementOperations(&reflector)
+	instanceSettingOperations(&reflector)
+	oauthOperations(&reflector)
+	markdownOperations(&reflector)
 
 	//
 	// define security scheme
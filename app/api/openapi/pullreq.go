@@ -20,6 +20,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/gitrpc"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -55,10 +57,21 @@ type statePullReqRequest struct {
 	pullreq.StateInput
 }
 
+type lockPullReqRequest struct {
+	pullReqRequest
+	pullreq.LockInput
+}
+
 type listPullReqActivitiesRequest struct {
 	pullReqRequest
 }
 
+type updatePullReqTaskRequest struct {
+	pullReqRequest
+	Index int `path:"pullreq_task_index"`
+	pullreq.UpdateTaskInput
+}
+
 type mergePullReq struct {
 	pullReqRequest
 	pullreq.MergeInput
@@ -125,7 +138,7 @@ var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
 		In:          openapi3.ParameterInQuery,
-		Description: ptr.String("The substring by which the pull requests are filtered."),
+		Description: ptr.String("The text by which the pull requests are filtered."),
 		Required:    ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
 			Schema: &openapi3.Schema{
@@ -135,6 +148,21 @@ var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterSearchCommentsPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSearchComments,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If true, the query also matches the comments of the pull requests."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterSourceRepoRefPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        "source_repo_ref",
@@ -283,6 +311,36 @@ var queryParameterBeforePullRequestActivity = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterFromPushPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFromPush,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The number of the push the diff starts from. Defaults to the push last reviewed by the current user."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
+var queryParameterToPushPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamToPush,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The number of the push the diff ends with. Defaults to the latest push."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func pullReqOperations(reflector *openapi3.Reflector) {
 	createPullReq := openapi3.Operation{}
@@ -302,8 +360,8 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	listPullReq.WithParameters(
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
-		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
-		queryParameterOrder, queryParameterSortPullRequest,
+		queryParameterQueryPullRequest, queryParameterSearchCommentsPullRequest,
+		queryParameterCreatedByPullRequest, queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReq), http.StatusOK)
@@ -346,6 +404,27 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&statePullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq/{pullreq_number}/state", statePullReq)
 
+	lockPullReq := openapi3.Operation{}
+	lockPullReq.WithTags("pullreq")
+	lockPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "lockPullReq"})
+	_ = reflector.SetRequest(&lockPullReq, new(lockPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&lockPullReq, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&lockPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&lockPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&lockPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&lockPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/pullreq/{pullreq_number}/lock", lockPullReq)
+
+	unlockPullReq := openapi3.Operation{}
+	unlockPullReq.WithTags("pullreq")
+	unlockPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "unlockPullReq"})
+	_ = reflector.SetRequest(&unlockPullReq, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&unlockPullReq, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&unlockPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&unlockPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&unlockPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/pullreq/{pullreq_number}/lock", unlockPullReq)
+
 	listPullReqActivities := openapi3.Operation{}
 	listPullReqActivities.WithTags("pullreq")
 	listPullReqActivities.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqActivities"})
@@ -361,6 +440,31 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/activities", listPullReqActivities)
 
+	listPullReqTasks := openapi3.Operation{}
+	listPullReqTasks.WithTags("pullreq")
+	listPullReqTasks.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTasks"})
+	_ = reflector.SetRequest(&listPullReqTasks, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listPullReqTasks, new([]markdown.Task), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listPullReqTasks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listPullReqTasks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listPullReqTasks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listPullReqTasks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/tasks", listPullReqTasks)
+
+	updatePullReqTask := openapi3.Operation{}
+	updatePullReqTask.WithTags("pullreq")
+	updatePullReqTask.WithMapOfAnything(map[string]interface{}{"operationId": "updatePullReqTask"})
+	_ = reflector.SetRequest(&updatePullReqTask, new(updatePullReqTaskRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updatePullReqTask, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/tasks/{pullreq_task_index}", updatePullReqTask)
+
 	commentCreatePullReq := openapi3.Operation{}
 	commentCreatePullReq.WithTags("pullreq")
 	commentCreatePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentCreatePullReq"})
@@ -456,6 +560,43 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&reviewSubmit, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews", reviewSubmit)
+
+	subscribe := openapi3.Operation{}
+	subscribe.WithTags("pullreq")
+	subscribe.WithMapOfAnything(map[string]interface{}{"operationId": "subscribePullReq"})
+	_ = reflector.SetRequest(&subscribe, new(pullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&subscribe, new(types.PullReqSubscriber), http.StatusOK)
+	_ = reflector.SetJSONResponse(&subscribe, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&subscribe, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&subscribe, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&subscribe, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/subscribe", subscribe)
+
+	unsubscribe := openapi3.Operation{}
+	unsubscribe.WithTags("pullreq")
+	unsubscribe.WithMapOfAnything(map[string]interface{}{"operationId": "unsubscribePullReq"})
+	_ = reflector.SetRequest(&unsubscribe, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&unsubscribe, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&unsubscribe, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&unsubscribe, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&unsubscribe, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&unsubscribe, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/subscribe", unsubscribe)
+
+	subscriberList := openapi3.Operation{}
+	subscriberList.WithTags("pullreq")
+	subscriberList.WithMapOfAnything(map[string]interface{}{"operationId": "subscriberListPullReq"})
+	_ = reflector.SetRequest(&subscriberList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&subscriberList, new([]*types.PullReqSubscriber), http.StatusOK)
+	_ = reflector.SetJSONResponse(&subscriberList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&subscriberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&subscriberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&subscriberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/subscribers", subscriberList)
+
 	mergePullReqOp := openapi3.Operation{}
 	mergePullReqOp.WithTags("pullreq")
 	mergePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergePullReqOp"})
@@ -471,6 +612,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	restoreSourceBranchOp := openapi3.Operation{}
+	restoreSourceBranchOp.WithTags("pullreq")
+	restoreSourceBranchOp.WithMapOfAnything(map[string]interface{}{"operationId": "restoreSourceBranch"})
+	_ = reflector.SetRequest(&restoreSourceBranchOp, new(pullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&restoreSourceBranchOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/source-branch/restore", restoreSourceBranchOp)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -483,6 +637,47 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/commits", opListCommits)
 
+	opDiff := openapi3.Operation{}
+	opDiff.WithTags("pullreq")
+	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "diffPullReq"})
+	opDiff.WithParameters(queryParameterIncludePatch, queryParameterIncludeWordDiff, queryParameterDiffPath,
+		queryParameterDiffAlgorithm, queryParameterIgnoreWhitespace, queryParameterIgnoreBlankLines)
+	_ = reflector.SetRequest(&opDiff, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opDiff, http.StatusOK, "text/plain")
+	_ = reflector.SetJSONResponse(&opDiff, []gitrpc.FileDiff{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/diff", opDiff)
+
+	opListPushes := openapi3.Operation{}
+	opListPushes.WithTags("pullreq")
+	opListPushes.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqPushes"})
+	_ = reflector.SetRequest(&opListPushes, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushes, []types.PullReqPush{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushes, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushes, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushes, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPushes, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/pushes", opListPushes)
+
+	opPushDiff := openapi3.Operation{}
+	opPushDiff.WithTags("pullreq")
+	opPushDiff.WithMapOfAnything(map[string]interface{}{"operationId": "diffPullReqPushes"})
+	opPushDiff.WithParameters(queryParameterFromPushPullRequest, queryParameterToPushPullRequest,
+		queryParameterIncludePatch, queryParameterDiffPath,
+		queryParameterDiffAlgorithm, queryParameterIgnoreWhitespace, queryParameterIgnoreBlankLines)
+	_ = reflector.SetRequest(&opPushDiff, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushDiff, []gitrpc.FileDiff{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushDiff, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushDiff, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushDiff, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushDiff, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushDiff, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/pushes/diff", opPushDiff)
+
 	opMetaData := openapi3.Operation{}
 	opMetaData.WithTags("pullreq")
 	opMetaData.WithMapOfAnything(map[string]interface{}{"operationId": "pullReqMetaData"})
@@ -494,6 +689,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMetaData, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/metadata", opMetaData)
 
+	opCodeOwners := openapi3.Operation{}
+	opCodeOwners.WithTags("pullreq")
+	opCodeOwners.WithMapOfAnything(map[string]interface{}{"operationId": "codeOwnersPullReq"})
+	_ = reflector.SetRequest(&opCodeOwners, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCodeOwners, new(types.CodeOwnersSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCodeOwners, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCodeOwners, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCodeOwners, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCodeOwners, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/codeowners", opCodeOwners)
+
 	recheckPullReq := openapi3.Operation{}
 	recheckPullReq.WithTags("pullreq")
 	recheckPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "recheckPullReq"})
@@ -52,6 +52,35 @@ type moveRepoRequest struct {
 	repo.MoveInput
 }
 
+type renameDefaultBranchRequest struct {
+	repoRequest
+	repo.RenameDefaultBranchInput
+}
+
+type updateRepoTopicsRequest struct {
+	repoRequest
+	repo.UpdateTopicsInput
+}
+
+type topicRequest struct {
+	Topic string `path:"topic"`
+}
+
+type repoSettingRequest struct {
+	repoRequest
+	Key enum.SettingKey `path:"setting_key"`
+}
+
+type updateRepoSettingRequest struct {
+	repoSettingRequest
+	repo.UpdateSettingInput
+}
+
+type restoreRepoRequest struct {
+	repoRequest
+	repo.RestoreInput
+}
+
 type getContentRequest struct {
 	repoRequest
 	Path string `path:"path"`
@@ -132,6 +161,27 @@ type getBranchRequest struct {
 	BranchName string `path:"branch_name"`
 }
 
+type updateBranchRequest struct {
+	repoRequest
+	BranchName string `path:"branch_name"`
+	repo.UpdateBranchInput
+}
+
+type createRefWatchRequest struct {
+	repoRequest
+	repo.CreateRefWatchInput
+}
+
+type createGitCredentialsRequest struct {
+	repoRequest
+	repo.CreateGitCredentialsInput
+}
+
+type refWatchRequest struct {
+	repoRequest
+	ID int64 `path:"ref_watch_id"`
+}
+
 type deleteBranchRequest struct {
 	repoRequest
 	BranchName string `path:"branch_name"`
@@ -156,6 +206,21 @@ type getRawDiffRequest struct {
 	Range string `path:"range" example:"main..dev"`
 }
 
+var queryParameterHeadRepoRef = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamHeadRepoRef,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The repository (path or id) containing the head ref. " +
+			"It has to be part of the same fork network. If no value is provided the repository itself is used."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -215,6 +280,132 @@ var queryParameterUntil = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludePatch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludePatch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the patch of the files should be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIncludeWordDiff = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamIncludeWordDiff,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the word-level changes of modified lines should be included " +
+			"in the response. Only applies if the patch is included."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterDiffAlgorithm = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamDiffAlgorithm,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The diff algorithm used by git. If not provided, the default algorithm of git is used."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.DiffAlgorithm("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterIgnoreWhitespace = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIgnoreWhitespace,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether whitespace should be ignored when comparing lines."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIgnoreBlankLines = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIgnoreBlankLines,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether changes whose lines are all blank should be ignored."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterDiffPath = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamPath,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The paths of the files the diff is restricted to (e.g. to load collapsed files). " +
+			"Files requested explicitly don't count towards the max number of files."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterIncludeDivergence = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeDivergence,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether ahead/behind counts to the default branch should be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIncludePullReqs = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludePullReqs,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the numbers of open pull requests should be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterIncludeCommit = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamIncludeCommit,
@@ -356,6 +547,34 @@ var queryParameterAfterCommits = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterSHA = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSHA,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The sha of the commit to filter by."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryTopic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the topics by their name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterCommitter = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamCommitter,
@@ -422,8 +641,10 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("repository")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepository"})
+	opDelete.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDelete, new(repoRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(types.DeleteImpact), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
@@ -433,6 +654,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opMove := openapi3.Operation{}
 	opMove.WithTags("repository")
 	opMove.WithMapOfAnything(map[string]interface{}{"operationId": "moveRepository"})
+	opMove.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opMove, new(moveRepoRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&opMove, new(types.Repository), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusBadRequest)
@@ -441,6 +663,79 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/move", opMove)
 
+	opRenameDefaultBranch := openapi3.Operation{}
+	opRenameDefaultBranch.WithTags("repository")
+	opRenameDefaultBranch.WithMapOfAnything(map[string]interface{}{"operationId": "renameDefaultBranch"})
+	_ = reflector.SetRequest(&opRenameDefaultBranch, new(renameDefaultBranchRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRenameDefaultBranch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/default-branch/rename", opRenameDefaultBranch)
+
+	opListStaleBranches := openapi3.Operation{}
+	opListStaleBranches.WithTags("repository")
+	opListStaleBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listStaleBranches"})
+	_ = reflector.SetRequest(&opListStaleBranches, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(repo.StaleBranchReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stale-branches", opListStaleBranches)
+
+	opRestore := openapi3.Operation{}
+	opRestore.WithTags("repository")
+	opRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restoreRepository"})
+	opRestore.WithParameters(queryParameterDeletedAt)
+	_ = reflector.SetRequest(&opRestore, new(restoreRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRestore, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/restore", opRestore)
+
+	opListSettings := openapi3.Operation{}
+	opListSettings.WithTags("repository")
+	opListSettings.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositorySettings"})
+	_ = reflector.SetRequest(&opListSettings, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSettings, []types.EffectiveSetting{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/settings", opListSettings)
+
+	opUpdateSetting := openapi3.Operation{}
+	opUpdateSetting.WithTags("repository")
+	opUpdateSetting.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepositorySetting"})
+	opUpdateSetting.WithParameters(headerParameterIfMatch)
+	_ = reflector.SetRequest(&opUpdateSetting, new(updateRepoSettingRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, []types.EffectiveSetting{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/settings/{setting_key}", opUpdateSetting)
+
+	opDeleteSetting := openapi3.Operation{}
+	opDeleteSetting.WithTags("repository")
+	opDeleteSetting.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepositorySetting"})
+	_ = reflector.SetRequest(&opDeleteSetting, new(repoSettingRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/settings/{setting_key}", opDeleteSetting)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -489,6 +784,20 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opRender := openapi3.Operation{}
+	opRender.WithTags("repository")
+	opRender.WithMapOfAnything(map[string]interface{}{"operationId": "renderFile"})
+	opRender.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opRender, new(getContentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRender, new(types.RenderedFile), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusUnprocessableEntity)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRender, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/render/{path}", opRender)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -560,6 +869,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetBranch, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/branches/{branch_name}", opGetBranch)
 
+	opUpdateBranch := openapi3.Operation{}
+	opUpdateBranch.WithTags("repository")
+	opUpdateBranch.WithMapOfAnything(map[string]interface{}{"operationId": "updateBranch"})
+	_ = reflector.SetRequest(&opUpdateBranch, new(updateBranchRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(repo.Branch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateBranch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/branches/{branch_name}", opUpdateBranch)
+
 	opDeleteBranch := openapi3.Operation{}
 	opDeleteBranch.WithTags("repository")
 	opDeleteBranch.WithMapOfAnything(map[string]interface{}{"operationId": "deleteBranch"})
@@ -575,6 +897,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListBranches.WithTags("repository")
 	opListBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listBranches"})
 	opListBranches.WithParameters(queryParameterIncludeCommit,
+		queryParameterIncludeDivergence, queryParameterIncludePullReqs,
 		queryParameterQueryBranches, queryParameterOrder, queryParameterSortBranch,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&opListBranches, new(listBranchesRequest), http.MethodGet)
@@ -633,11 +956,163 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCommitFiles, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits", opCommitFiles)
 
+	opListCommitAuthorRewrites := openapi3.Operation{}
+	opListCommitAuthorRewrites.WithTags("repository")
+	opListCommitAuthorRewrites.WithMapOfAnything(map[string]interface{}{"operationId": "listCommitAuthorRewrites"})
+	opListCommitAuthorRewrites.WithParameters(queryParameterPage, queryParameterLimit, queryParameterSHA)
+	_ = reflector.SetRequest(&opListCommitAuthorRewrites, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListCommitAuthorRewrites, []types.CommitAuthorRewrite{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListCommitAuthorRewrites, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListCommitAuthorRewrites, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListCommitAuthorRewrites, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListCommitAuthorRewrites, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/author-rewrites",
+		opListCommitAuthorRewrites)
+
+	opListTopics := openapi3.Operation{}
+	opListTopics.WithTags("repository")
+	opListTopics.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoTopics"})
+	_ = reflector.SetRequest(&opListTopics, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListTopics, []string{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/topics", opListTopics)
+
+	opUpdateTopics := openapi3.Operation{}
+	opUpdateTopics.WithTags("repository")
+	opUpdateTopics.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoTopics"})
+	_ = reflector.SetRequest(&opUpdateTopics, new(updateRepoTopicsRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, []string{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateTopics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/topics", opUpdateTopics)
+
+	opFindStar := openapi3.Operation{}
+	opFindStar.WithTags("repository")
+	opFindStar.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoStar"})
+	_ = reflector.SetRequest(&opFindStar, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindStar, new(repo.StarOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindStar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindStar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindStar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindStar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/star", opFindStar)
+
+	opStar := openapi3.Operation{}
+	opStar.WithTags("repository")
+	opStar.WithMapOfAnything(map[string]interface{}{"operationId": "starRepo"})
+	_ = reflector.SetRequest(&opStar, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStar, new(repo.StarOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/star", opStar)
+
+	opUnstar := openapi3.Operation{}
+	opUnstar.WithTags("repository")
+	opUnstar.WithMapOfAnything(map[string]interface{}{"operationId": "unstarRepo"})
+	_ = reflector.SetRequest(&opUnstar, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnstar, new(repo.StarOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/star", opUnstar)
+
+	opListRefWatches := openapi3.Operation{}
+	opListRefWatches.WithTags("repository")
+	opListRefWatches.WithMapOfAnything(map[string]interface{}{"operationId": "listRefWatches"})
+	_ = reflector.SetRequest(&opListRefWatches, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRefWatches, []types.RefWatch{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRefWatches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListRefWatches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListRefWatches, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListRefWatches, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/ref-watches", opListRefWatches)
+
+	opCreateRefWatch := openapi3.Operation{}
+	opCreateRefWatch.WithTags("repository")
+	opCreateRefWatch.WithMapOfAnything(map[string]interface{}{"operationId": "createRefWatch"})
+	_ = reflector.SetRequest(&opCreateRefWatch, new(createRefWatchRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(types.RefWatch), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreateRefWatch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/ref-watches", opCreateRefWatch)
+
+	opDeleteRefWatch := openapi3.Operation{}
+	opDeleteRefWatch.WithTags("repository")
+	opDeleteRefWatch.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRefWatch"})
+	_ = reflector.SetRequest(&opDeleteRefWatch, new(refWatchRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteRefWatch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteRefWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteRefWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteRefWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteRefWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/ref-watches/{ref_watch_id}", opDeleteRefWatch)
+
+	opFindPushedBranch := openapi3.Operation{}
+	opFindPushedBranch.WithTags("repository")
+	opFindPushedBranch.WithMapOfAnything(map[string]interface{}{"operationId": "findPushedBranch"})
+	_ = reflector.SetRequest(&opFindPushedBranch, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, new(types.PushedBranch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPushedBranch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pushed-branch", opFindPushedBranch)
+
+	opCreateGitCredentials := openapi3.Operation{}
+	opCreateGitCredentials.WithTags("repository")
+	opCreateGitCredentials.WithMapOfAnything(map[string]interface{}{"operationId": "createGitCredentials"})
+	_ = reflector.SetRequest(&opCreateGitCredentials, new(createGitCredentialsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(repo.GitCredentials), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateGitCredentials, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/git-credentials", opCreateGitCredentials)
+
+	opTopicSummaries := openapi3.Operation{}
+	opTopicSummaries.WithTags("repository")
+	opTopicSummaries.WithMapOfAnything(map[string]interface{}{"operationId": "listTopics"})
+	opTopicSummaries.WithParameters(queryParameterQueryTopic, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opTopicSummaries, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTopicSummaries, []types.TopicSummary{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTopicSummaries, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTopicSummaries, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/topics", opTopicSummaries)
+
+	opReposByTopic := openapi3.Operation{}
+	opReposByTopic.WithTags("repository")
+	opReposByTopic.WithMapOfAnything(map[string]interface{}{"operationId": "listReposByTopic"})
+	opReposByTopic.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opReposByTopic, new(topicRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReposByTopic, []types.Repository{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReposByTopic, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReposByTopic, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReposByTopic, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/topics/{topic}/repos", opReposByTopic)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("repository")
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiff"})
+	opDiff.WithParameters(queryParameterIncludePatch, queryParameterIncludeWordDiff, queryParameterDiffPath,
+		queryParameterDiffAlgorithm, queryParameterIgnoreWhitespace, queryParameterIgnoreBlankLines)
 	_ = reflector.SetRequest(&opDiff, new(getRawDiffRequest), http.MethodGet)
 	_ = reflector.SetStringResponse(&opDiff, http.StatusOK, "text/plain")
 	_ = reflector.SetJSONResponse(&opDiff, []gitrpc.FileDiff{}, http.StatusOK)
@@ -649,6 +1124,8 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opCommitDiff := openapi3.Operation{}
 	opCommitDiff.WithTags("repository")
 	opCommitDiff.WithMapOfAnything(map[string]interface{}{"operationId": "getCommitDiff"})
+	opCommitDiff.WithParameters(queryParameterDiffAlgorithm, queryParameterIgnoreWhitespace,
+		queryParameterIgnoreBlankLines)
 	_ = reflector.SetRequest(&opCommitDiff, new(GetCommitRequest), http.MethodGet)
 	_ = reflector.SetStringResponse(&opCommitDiff, http.StatusOK, "text/plain")
 	_ = reflector.SetJSONResponse(&opCommitDiff, new(usererror.Error), http.StatusInternalServerError)
@@ -676,4 +1153,17 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMergeCheck, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opMergeCheck, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/merge-check/{range}", opMergeCheck)
+
+	opCompare := openapi3.Operation{}
+	opCompare.WithTags("repository")
+	opCompare.WithMapOfAnything(map[string]interface{}{"operationId": "compare"})
+	opCompare.WithParameters(queryParameterHeadRepoRef)
+	_ = reflector.SetRequest(&opCompare, new(getRawDiffRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCompare, new(repo.CompareOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/compare/{range}", opCompare)
 }
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type sbomRequest struct {
+	repoRequest
+	ID int64 `path:"sbom_id"`
+}
+
+var queryParameterSBOMTag = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTag,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return the SBOMs of the tag with this name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+func sbomOperations(reflector *openapi3.Reflector) {
+	const tag = "sbom"
+
+	listSBOMs := openapi3.Operation{}
+	listSBOMs.WithTags(tag)
+	listSBOMs.WithMapOfAnything(map[string]interface{}{"operationId": "listSBOMs"})
+	listSBOMs.WithParameters(queryParameterSBOMTag)
+	_ = reflector.SetRequest(&listSBOMs, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSBOMs, new([]types.SBOM), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSBOMs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSBOMs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSBOMs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listSBOMs, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/sboms", listSBOMs)
+
+	downloadSBOM := openapi3.Operation{}
+	downloadSBOM.WithTags(tag)
+	downloadSBOM.WithMapOfAnything(map[string]interface{}{"operationId": "downloadSBOM"})
+	_ = reflector.SetRequest(&downloadSBOM, new(sbomRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&downloadSBOM, http.StatusOK, "application/json")
+	_ = reflector.SetJSONResponse(&downloadSBOM, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&downloadSBOM, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&downloadSBOM, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&downloadSBOM, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/sboms/{sbom_id}/download", downloadSBOM)
+}
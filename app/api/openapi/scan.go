@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/scan"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type scanSuppressionRequest struct {
+	repoRequest
+	ID int64 `path:"scan_suppression_id"`
+}
+
+var queryParameterScanCommitSHA = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCommitSHA,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The commit for which findings are returned. Defaults to the default branch head."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterScanner = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamScanner,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return findings reported by the scanner with this name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterScanMinSeverity = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMinSeverity,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return findings with at least this severity."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.ScanSeverity("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterScanIncludeSuppressed = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeSuppressed,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether suppressed findings should be returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+func scanOperations(reflector *openapi3.Reflector) {
+	const tag = "scan"
+
+	listFindings := openapi3.Operation{}
+	listFindings.WithTags(tag)
+	listFindings.WithMapOfAnything(map[string]interface{}{"operationId": "listScanFindings"})
+	listFindings.WithParameters(queryParameterScanCommitSHA, queryParameterScanner,
+		queryParameterScanMinSeverity, queryParameterScanIncludeSuppressed,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listFindings, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listFindings, new([]types.ScanFinding), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/scan/findings", listFindings)
+
+	createSuppression := openapi3.Operation{}
+	createSuppression.WithTags(tag)
+	createSuppression.WithMapOfAnything(map[string]interface{}{"operationId": "createScanSuppression"})
+	_ = reflector.SetRequest(&createSuppression, struct {
+		repoRequest
+		scan.CreateSuppressionInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createSuppression, new(types.ScanSuppression), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createSuppression, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createSuppression, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createSuppression, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createSuppression, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createSuppression, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/scan/suppressions", createSuppression)
+
+	listSuppressions := openapi3.Operation{}
+	listSuppressions.WithTags(tag)
+	listSuppressions.WithMapOfAnything(map[string]interface{}{"operationId": "listScanSuppressions"})
+	_ = reflector.SetRequest(&listSuppressions, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSuppressions, new([]types.ScanSuppression), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSuppressions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSuppressions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSuppressions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listSuppressions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/scan/suppressions", listSuppressions)
+
+	deleteSuppression := openapi3.Operation{}
+	deleteSuppression.WithTags(tag)
+	deleteSuppression.WithMapOfAnything(map[string]interface{}{"operationId": "deleteScanSuppression"})
+	_ = reflector.SetRequest(&deleteSuppression, new(scanSuppressionRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteSuppression, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteSuppression, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteSuppression, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteSuppression, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteSuppression, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/scan/suppressions/{scan_suppression_id}", deleteSuppression)
+}
@@ -45,11 +45,47 @@ type moveSpaceRequest struct {
 	space.MoveInput
 }
 
+type restoreSpaceRequest struct {
+	spaceRequest
+	space.RestoreInput
+}
+
+type spaceSettingRequest struct {
+	spaceRequest
+	Key enum.SettingKey `path:"setting_key"`
+}
+
+type updateSpaceSettingRequest struct {
+	spaceSettingRequest
+	space.UpdateSettingInput
+}
+
+type updateSpaceQuotaRequest struct {
+	spaceRequest
+	space.UpdateQuotaInput
+}
+
 type exportSpaceRequest struct {
 	spaceRequest
 	space.ExportInput
 }
 
+var queryParameterDryRunMoveSpace = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamDryRun,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the space should only be validated for the move. " +
+			"If set, the spaces and repositories whose paths would change are returned instead of the space."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterSortRepo = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -220,8 +256,10 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("space")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpace"})
+	opDelete.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDelete, new(spaceRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(types.DeleteImpact), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
@@ -231,6 +269,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opMove := openapi3.Operation{}
 	opMove.WithTags("space")
 	opMove.WithMapOfAnything(map[string]interface{}{"operationId": "moveSpace"})
+	opMove.WithParameters(queryParameterDryRunMoveSpace)
 	_ = reflector.SetRequest(&opMove, new(moveSpaceRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&opMove, new(types.Space), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusBadRequest)
@@ -239,6 +278,20 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/move", opMove)
 
+	opRestore := openapi3.Operation{}
+	opRestore.WithTags("space")
+	opRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restoreSpace"})
+	opRestore.WithParameters(queryParameterDeletedAt)
+	_ = reflector.SetRequest(&opRestore, new(restoreSpaceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRestore, new(types.Space), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/restore", opRestore)
+
 	opSpaces := openapi3.Operation{}
 	opSpaces.WithTags("space")
 	opSpaces.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaces"})
@@ -266,6 +319,98 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repos", opRepos)
 
+	opTree := openapi3.Operation{}
+	opTree.WithTags("space")
+	opTree.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceTree"})
+	_ = reflector.SetRequest(&opTree, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTree, new(types.SpaceTreeNode), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTree, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTree, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTree, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTree, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/tree", opTree)
+
+	opTrash := openapi3.Operation{}
+	opTrash.WithTags("space")
+	opTrash.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceTrash"})
+	_ = reflector.SetRequest(&opTrash, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTrash, new(types.Trash), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTrash, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTrash, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTrash, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTrash, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/trash", opTrash)
+
+	opQuotaReport := openapi3.Operation{}
+	opQuotaReport.WithTags("space")
+	opQuotaReport.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceQuota"})
+	_ = reflector.SetRequest(&opQuotaReport, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opQuotaReport, new(types.SpaceQuotaReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaReport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaReport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaReport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaReport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/quota", opQuotaReport)
+
+	opQuotaUpdate := openapi3.Operation{}
+	opQuotaUpdate.WithTags("space")
+	opQuotaUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceQuota"})
+	_ = reflector.SetRequest(&opQuotaUpdate, new(updateSpaceQuotaRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(types.SpaceQuota), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/quota", opQuotaUpdate)
+
+	opQuotaDelete := openapi3.Operation{}
+	opQuotaDelete.WithTags("space")
+	opQuotaDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceQuota"})
+	_ = reflector.SetRequest(&opQuotaDelete, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/quota", opQuotaDelete)
+
+	opListSettings := openapi3.Operation{}
+	opListSettings.WithTags("space")
+	opListSettings.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceSettings"})
+	_ = reflector.SetRequest(&opListSettings, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSettings, []types.EffectiveSetting{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/settings", opListSettings)
+
+	opUpdateSetting := openapi3.Operation{}
+	opUpdateSetting.WithTags("space")
+	opUpdateSetting.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceSetting"})
+	opUpdateSetting.WithParameters(headerParameterIfMatch)
+	_ = reflector.SetRequest(&opUpdateSetting, new(updateSpaceSettingRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, []types.EffectiveSetting{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateSetting, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/settings/{setting_key}", opUpdateSetting)
+
+	opDeleteSetting := openapi3.Operation{}
+	opDeleteSetting.WithTags("space")
+	opDeleteSetting.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceSetting"})
+	_ = reflector.SetRequest(&opDeleteSetting, new(spaceSettingRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteSetting, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/settings/{setting_key}", opDeleteSetting)
+
 	opTemplates := openapi3.Operation{}
 	opTemplates.WithTags("space")
 	opTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listTemplates"})
@@ -302,6 +447,17 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSecrets, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/secrets", opSecrets)
 
+	opInheritedSecrets := openapi3.Operation{}
+	opInheritedSecrets.WithTags("space")
+	opInheritedSecrets.WithMapOfAnything(map[string]interface{}{"operationId": "listInheritedSecrets"})
+	_ = reflector.SetRequest(&opInheritedSecrets, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInheritedSecrets, []types.Secret{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInheritedSecrets, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInheritedSecrets, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInheritedSecrets, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInheritedSecrets, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/secrets/inherited", opInheritedSecrets)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("space")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listServiceAccounts"})
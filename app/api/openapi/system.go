@@ -19,6 +19,8 @@ import (
 
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/loglevel"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +36,78 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opRotate := openapi3.Operation{}
+	opRotate.WithTags("admin")
+	opRotate.WithMapOfAnything(map[string]interface{}{"operationId": "adminRotateEncryptionKey"})
+	_ = reflector.SetRequest(&opRotate, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRotate, new(types.JobProgress), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opRotate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRotate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRotate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRotate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRotate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/encryption/rotate", opRotate)
+
+	opRotateProgress := openapi3.Operation{}
+	opRotateProgress.WithTags("admin")
+	opRotateProgress.WithMapOfAnything(map[string]interface{}{"operationId": "adminEncryptionKeyRotationProgress"})
+	_ = reflector.SetRequest(&opRotateProgress, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRotateProgress, new(types.JobProgress), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRotateProgress, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRotateProgress, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRotateProgress, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRotateProgress, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/encryption/rotate", opRotateProgress)
+
+	opListSlowGitOperations := openapi3.Operation{}
+	opListSlowGitOperations.WithTags("admin")
+	opListSlowGitOperations.WithMapOfAnything(map[string]interface{}{"operationId": "adminListSlowGitOperations"})
+	_ = reflector.SetRequest(&opListSlowGitOperations, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSlowGitOperations, new([]types.GitOperation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSlowGitOperations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSlowGitOperations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSlowGitOperations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/git/slow-operations", opListSlowGitOperations)
+
+	opMigrationStatus := openapi3.Operation{}
+	opMigrationStatus.WithTags("admin")
+	opMigrationStatus.WithMapOfAnything(map[string]interface{}{"operationId": "adminMigrationStatus"})
+	_ = reflector.SetRequest(&opMigrationStatus, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMigrationStatus, new(types.MigrationStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMigrationStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMigrationStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMigrationStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/migrations", opMigrationStatus)
+
+	opGetLogLevels := openapi3.Operation{}
+	opGetLogLevels.WithTags("admin")
+	opGetLogLevels.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetLogLevels"})
+	_ = reflector.SetRequest(&opGetLogLevels, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetLogLevels, new(loglevel.Settings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetLogLevels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetLogLevels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetLogLevels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/logging/levels", opGetLogLevels)
+
+	opUpdateLogLevels := openapi3.Operation{}
+	opUpdateLogLevels.WithTags("admin")
+	opUpdateLogLevels.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateLogLevels"})
+	_ = reflector.SetRequest(&opUpdateLogLevels, new(loglevel.Settings), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateLogLevels, new(loglevel.Settings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateLogLevels, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateLogLevels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateLogLevels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateLogLevels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/logging/levels", opUpdateLogLevels)
+
+	opGetDrainStatus := openapi3.Operation{}
+	opGetDrainStatus.WithTags("admin")
+	opGetDrainStatus.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetDrainStatus"})
+	_ = reflector.SetRequest(&opGetDrainStatus, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetDrainStatus, new(types.DrainStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetDrainStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetDrainStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetDrainStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/drain", opGetDrainStatus)
 }
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type adminUploadQuarantineRequest struct {
+	ID int64 `path:"upload_quarantine_id"`
+}
+
+var queryParameterStatusUploadQuarantine = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The status of the quarantined uploads to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.UploadQuarantineStatus("").Enum(),
+			},
+		},
+	},
+}
+
+// uploadQuarantineOperations registers the admin endpoints of the uploads flagged by the virus scanner.
+func uploadQuarantineOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListUploadQuarantines"})
+	opList.WithParameters(queryParameterStatusUploadQuarantine, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.UploadQuarantine), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/upload-quarantines", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindUploadQuarantine"})
+	_ = reflector.SetRequest(&opFind, new(adminUploadQuarantineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.UploadQuarantine), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/upload-quarantines/{upload_quarantine_id}", opFind)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteUploadQuarantine"})
+	_ = reflector.SetRequest(&opDelete, new(adminUploadQuarantineRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/upload-quarantines/{upload_quarantine_id}", opDelete)
+
+	opRelease := openapi3.Operation{}
+	opRelease.WithTags("admin")
+	opRelease.WithMapOfAnything(map[string]interface{}{"operationId": "adminReleaseUploadQuarantine"})
+	_ = reflector.SetRequest(&opRelease, new(adminUploadQuarantineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRelease, new(types.UploadQuarantine), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRelease, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRelease, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRelease, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRelease, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/admin/upload-quarantines/{upload_quarantine_id}/release", opRelease)
+}
@@ -31,6 +31,36 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type userEmailRequest struct {
+	ID int64 `path:"email_id"`
+}
+
+type publicKeyRequest struct {
+	ID int64 `path:"public_key_id"`
+}
+
+type verifyUserEmailRequest struct {
+	userEmailRequest
+	user.VerifyEmailInput
+}
+
+type userProfileRequest struct {
+	UserUID string `path:"user_uid"`
+}
+
+type savedReplyRequest struct {
+	ID int64 `path:"saved_reply_id"`
+}
+
+type updateSavedReplyRequest struct {
+	savedReplyRequest
+	user.UpdateSavedReplyInput
+}
+
+type updateNotificationSettingsRequest struct {
+	user.UpdateNotificationSettingsInput
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -45,6 +75,20 @@ var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQuerySavedReplies = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the saved replies are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -99,4 +143,208 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opListEmails := openapi3.Operation{}
+	opListEmails.WithTags("user")
+	opListEmails.WithMapOfAnything(map[string]interface{}{"operationId": "listUserEmails"})
+	_ = reflector.SetRequest(&opListEmails, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListEmails, new([]*types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListEmails, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/emails", opListEmails)
+
+	opAddEmail := openapi3.Operation{}
+	opAddEmail.WithTags("user")
+	opAddEmail.WithMapOfAnything(map[string]interface{}{"operationId": "addUserEmail"})
+	_ = reflector.SetRequest(&opAddEmail, new(user.AddEmailInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(types.UserEmail), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails", opAddEmail)
+
+	opDeleteEmail := openapi3.Operation{}
+	opDeleteEmail.WithTags("user")
+	opDeleteEmail.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserEmail"})
+	_ = reflector.SetRequest(&opDeleteEmail, new(userEmailRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteEmail, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/emails/{email_id}", opDeleteEmail)
+
+	opVerifyEmail := openapi3.Operation{}
+	opVerifyEmail.WithTags("user")
+	opVerifyEmail.WithMapOfAnything(map[string]interface{}{"operationId": "verifyUserEmail"})
+	_ = reflector.SetRequest(&opVerifyEmail, new(verifyUserEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{email_id}/verify", opVerifyEmail)
+
+	opResendVerification := openapi3.Operation{}
+	opResendVerification.WithTags("user")
+	opResendVerification.WithMapOfAnything(map[string]interface{}{"operationId": "resendUserEmailVerification"})
+	_ = reflector.SetRequest(&opResendVerification, new(userEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opResendVerification, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opResendVerification, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResendVerification, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opResendVerification, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{email_id}/resend-verification",
+		opResendVerification)
+
+	opSetPrimaryEmail := openapi3.Operation{}
+	opSetPrimaryEmail.WithTags("user")
+	opSetPrimaryEmail.WithMapOfAnything(map[string]interface{}{"operationId": "setPrimaryUserEmail"})
+	_ = reflector.SetRequest(&opSetPrimaryEmail, new(userEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{email_id}/primary", opSetPrimaryEmail)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("user")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKeys"})
+	_ = reflector.SetRequest(&opListPublicKeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]*types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListPublicKeys)
+
+	opCreatePublicKey := openapi3.Operation{}
+	opCreatePublicKey.WithTags("user")
+	opCreatePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreatePublicKey, new(user.CreatePublicKeyInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreatePublicKey)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("user")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(publicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_id}", opDeletePublicKey)
+
+	opListSavedReplies := openapi3.Operation{}
+	opListSavedReplies.WithTags("user")
+	opListSavedReplies.WithMapOfAnything(map[string]interface{}{"operationId": "listSavedReplies"})
+	opListSavedReplies.WithParameters(queryParameterQuerySavedReplies, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListSavedReplies, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSavedReplies, new([]*types.SavedReply), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSavedReplies, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/saved-replies", opListSavedReplies)
+
+	opCreateSavedReply := openapi3.Operation{}
+	opCreateSavedReply.WithTags("user")
+	opCreateSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "createSavedReply"})
+	_ = reflector.SetRequest(&opCreateSavedReply, new(user.CreateSavedReplyInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(types.SavedReply), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/saved-replies", opCreateSavedReply)
+
+	opFindSavedReply := openapi3.Operation{}
+	opFindSavedReply.WithTags("user")
+	opFindSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "findSavedReply"})
+	_ = reflector.SetRequest(&opFindSavedReply, new(savedReplyRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindSavedReply, new(types.SavedReply), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindSavedReply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/saved-replies/{saved_reply_id}", opFindSavedReply)
+
+	opUpdateSavedReply := openapi3.Operation{}
+	opUpdateSavedReply.WithTags("user")
+	opUpdateSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "updateSavedReply"})
+	_ = reflector.SetRequest(&opUpdateSavedReply, new(updateSavedReplyRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(types.SavedReply), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opUpdateSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/saved-replies/{saved_reply_id}", opUpdateSavedReply)
+
+	opDeleteSavedReply := openapi3.Operation{}
+	opDeleteSavedReply.WithTags("user")
+	opDeleteSavedReply.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSavedReply"})
+	_ = reflector.SetRequest(&opDeleteSavedReply, new(savedReplyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteSavedReply, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/saved-replies/{saved_reply_id}", opDeleteSavedReply)
+
+	opListStarredRepos := openapi3.Operation{}
+	opListStarredRepos.WithTags("user")
+	opListStarredRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listStarredRepos"})
+	opListStarredRepos.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListStarredRepos, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListStarredRepos, new([]*types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListStarredRepos, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/starred", opListStarredRepos)
+
+	opListFeed := openapi3.Operation{}
+	opListFeed.WithTags("user")
+	opListFeed.WithMapOfAnything(map[string]interface{}{"operationId": "listFeed"})
+	opListFeed.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListFeed, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListFeed, new([]*types.FeedEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListFeed, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/feed", opListFeed)
+
+	opFindProfile := openapi3.Operation{}
+	opFindProfile.WithTags("user")
+	opFindProfile.WithMapOfAnything(map[string]interface{}{"operationId": "findProfile"})
+	_ = reflector.SetRequest(&opFindProfile, new(userProfileRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindProfile, new(types.Profile), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindProfile, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindProfile, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindProfile, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/profile", opFindProfile)
+
+	opListProfilePullReqs := openapi3.Operation{}
+	opListProfilePullReqs.WithTags("user")
+	opListProfilePullReqs.WithMapOfAnything(map[string]interface{}{"operationId": "listProfilePullReqs"})
+	opListProfilePullReqs.WithParameters(queryParameterStatePullRequest, queryParameterQueryPullRequest,
+		queryParameterOrder, queryParameterSortPullRequest, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListProfilePullReqs, new(userProfileRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListProfilePullReqs, new([]types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListProfilePullReqs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListProfilePullReqs, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opListProfilePullReqs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/profile/pullreqs", opListProfilePullReqs)
+
+	opListProfileReviews := openapi3.Operation{}
+	opListProfileReviews.WithTags("user")
+	opListProfileReviews.WithMapOfAnything(map[string]interface{}{"operationId": "listProfileReviews"})
+	opListProfileReviews.WithParameters(queryParameterStatePullRequest, queryParameterQueryPullRequest,
+		queryParameterOrder, queryParameterSortPullRequest, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListProfileReviews, new(userProfileRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListProfileReviews, new([]types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListProfileReviews, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListProfileReviews, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opListProfileReviews, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/profile/reviews", opListProfileReviews)
+
+	opFindNotificationSettings := openapi3.Operation{}
+	opFindNotificationSettings.WithTags("user")
+	opFindNotificationSettings.WithMapOfAnything(map[string]interface{}{"operationId": "getUserNotificationSettings"})
+	_ = reflector.SetRequest(&opFindNotificationSettings, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opFindNotificationSettings)
+
+	opUpdateNotificationSettings := openapi3.Operation{}
+	opUpdateNotificationSettings.WithTags("user")
+	opUpdateNotificationSettings.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateUserNotificationSettings"})
+	_ = reflector.SetRequest(&opUpdateNotificationSettings, new(updateNotificationSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notifications", opUpdateNotificationSettings)
 }
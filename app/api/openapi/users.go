@@ -55,6 +55,12 @@ type (
 		adminUsersRequest
 		user.UpdateAdminInput
 	}
+
+	// updateStateRequest is the request for blocking, suspending or reactivating the user.
+	updateStateRequest struct {
+		adminUsersRequest
+		user.UpdateStateInput
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -109,6 +115,25 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}/admin", opUpdateAdmin)
 
+	opUpdateState := openapi3.Operation{}
+	opUpdateState.WithTags("admin")
+	opUpdateState.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserState"})
+	_ = reflector.SetRequest(&opUpdateState, new(updateStateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}/state", opUpdateState)
+
+	opForcePasswordReset := openapi3.Operation{}
+	opForcePasswordReset.WithTags("admin")
+	opForcePasswordReset.WithMapOfAnything(map[string]interface{}{"operationId": "forceUserPasswordReset"})
+	_ = reflector.SetRequest(&opForcePasswordReset, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opForcePasswordReset, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opForcePasswordReset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opForcePasswordReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/password-reset", opForcePasswordReset)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("admin")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteUser"})
@@ -69,6 +69,21 @@ type webhookExecutionRequest struct {
 	ID int64 `path:"webhook_execution_id"`
 }
 
+type testWebhookRequest struct {
+	webhookRequest
+	webhook.TestInput
+}
+
+// webhookPayloadSchemas mirrors webhook.PayloadSchemas, the schemas themselves are free-form JSON documents.
+type webhookPayloadSchemas struct {
+	Version  string                                         `json:"version"`
+	Triggers map[enum.WebhookTrigger]map[string]interface{} `json:"triggers"`
+}
+
+type webhookPayloadSchemaRequest struct {
+	Trigger enum.WebhookTrigger `path:"webhook_trigger"`
+}
+
 type getWebhookExecutionRequest struct {
 	webhookExecutionRequest
 }
@@ -134,9 +149,11 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	updateWebhook := openapi3.Operation{}
 	updateWebhook.WithTags("webhook")
 	updateWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "updateWebhook"})
+	updateWebhook.WithParameters(headerParameterIfMatch)
 	_ = reflector.SetRequest(&updateWebhook, new(updateWebhookRequest), http.MethodPatch)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(webhookType), http.StatusOK)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusPreconditionFailed)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&updateWebhook, new(usererror.Error), http.StatusForbidden)
@@ -178,4 +195,35 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&getWebhookExecution, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/webhooks/{webhook_id}/executions/{webhook_execution_id}", getWebhookExecution)
+
+	testWebhook := openapi3.Operation{}
+	testWebhook.WithTags("webhook")
+	testWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testWebhook"})
+	_ = reflector.SetRequest(&testWebhook, new(testWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_id}/test", testWebhook)
+
+	listWebhookPayloadSchemas := openapi3.Operation{}
+	listWebhookPayloadSchemas.WithTags("webhook")
+	listWebhookPayloadSchemas.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhookPayloadSchemas"})
+	_ = reflector.SetRequest(&listWebhookPayloadSchemas, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listWebhookPayloadSchemas, new(webhookPayloadSchemas), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listWebhookPayloadSchemas, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/webhooks/schemas", listWebhookPayloadSchemas)
+
+	getWebhookPayloadSchema := openapi3.Operation{}
+	getWebhookPayloadSchema.WithTags("webhook")
+	getWebhookPayloadSchema.WithMapOfAnything(map[string]interface{}{"operationId": "getWebhookPayloadSchema"})
+	_ = reflector.SetRequest(&getWebhookPayloadSchema, new(webhookPayloadSchemaRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getWebhookPayloadSchema, new(map[string]interface{}), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getWebhookPayloadSchema, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getWebhookPayloadSchema, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/webhooks/schemas/{webhook_trigger}", getWebhookPayloadSchema)
 }
@@ -21,6 +21,11 @@ import (
 	"strconv"
 )
 
+// ETag writes the entity tag of the returned resource to the ETag header.
+func ETag(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", strconv.Quote(etag))
+}
+
 // format string for the link header value.
 var linkf = `<%s>; rel="%s"`
 
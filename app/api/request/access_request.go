@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamAccessRequestID = "access_request_id"
+)
+
+func GetAccessRequestIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAccessRequestID)
+}
+
+// GetAccessRequestParentRefFromPath returns the reference of the repo or space the access is requested for.
+func GetAccessRequestParentRefFromPath(r *http.Request, parentType enum.AccessRequestParent) (string, error) {
+	if parentType == enum.AccessRequestParentSpace {
+		return GetSpaceRefFromPath(r)
+	}
+
+	return GetRepoRefFromPath(r)
+}
+
+// ParseAccessRequestFilter extracts the access request query parameters from the url.
+func ParseAccessRequestFilter(r *http.Request) *types.AccessRequestFilter {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.AccessRequestState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.AccessRequestState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.AccessRequestState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return &types.AccessRequestFilter{
+		Page:   ParsePage(r),
+		Size:   ParseLimit(r),
+		States: states,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamBackupID = "backup_id"
+)
+
+// GetBackupIDFromPath extracts the backup id from the url.
+func GetBackupIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamBackupID)
+}
+
+// ParseBackupFilter extracts the backup query parameters from the url.
+func ParseBackupFilter(r *http.Request) (types.BackupFilter, error) {
+	repoID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamRepoID, 0)
+	if err != nil {
+		return types.BackupFilter{}, err
+	}
+
+	state, _ := enum.BackupState(r.URL.Query().Get(QueryParamState)).Sanitize()
+
+	return types.BackupFilter{
+		Page:   ParsePage(r),
+		Size:   ParseLimit(r),
+		RepoID: repoID,
+		State:  state,
+	}, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamDeadLetterID = "dead_letter_id"
+
+	QueryParamStream = "stream"
+	QueryParamGroup  = "group"
+)
+
+// GetDeadLetterIDFromPath extracts the event dead letter id from the url.
+func GetDeadLetterIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamDeadLetterID)
+}
+
+// ParseEventDeadLetterFilter extracts the event dead letter query parameters from the url.
+func ParseEventDeadLetterFilter(r *http.Request) types.EventDeadLetterFilter {
+	return types.EventDeadLetterFilter{
+		Page:      ParsePage(r),
+		Size:      ParseLimit(r),
+		StreamID:  r.URL.Query().Get(QueryParamStream),
+		GroupName: r.URL.Query().Get(QueryParamGroup),
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamFileLockID = "file_lock_id"
+
+	QueryParamOwnerID = "owner_id"
+	QueryParamForce   = "force"
+)
+
+// GetFileLockIDFromPath extracts the file lock id from the url.
+func GetFileLockIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamFileLockID)
+}
+
+// ParseFileLockFilter extracts the file lock query parameters from the url.
+func ParseFileLockFilter(r *http.Request) (*types.FileLockFilter, error) {
+	ownerID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamOwnerID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FileLockFilter{
+		Page:    ParsePage(r),
+		Size:    ParseLimit(r),
+		Path:    r.URL.Query().Get(QueryParamPath),
+		OwnerID: ownerID,
+	}, nil
+}
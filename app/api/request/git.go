@@ -16,21 +16,31 @@ package request
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 const (
-	QueryParamGitRef        = "git_ref"
-	QueryParamIncludeCommit = "include_commit"
-	PathParamCommitSHA      = "commit_sha"
-	QueryParamLineFrom      = "line_from"
-	QueryParamLineTo        = "line_to"
-	QueryParamPath          = "path"
-	QueryParamSince         = "since"
-	QueryParamUntil         = "until"
-	QueryParamCommitter     = "committer"
+	QueryParamGitRef            = "git_ref"
+	QueryParamIncludeCommit     = "include_commit"
+	QueryParamIncludePatch      = "include_patch"
+	QueryParamIncludeWordDiff   = "include_word_diff"
+	QueryParamDiffAlgorithm     = "diff_algorithm"
+	QueryParamIgnoreWhitespace  = "ignore_whitespace"
+	QueryParamIgnoreBlankLines  = "ignore_blank_lines"
+	QueryParamIncludeDivergence = "include_divergence"
+	QueryParamIncludePullReqs   = "include_pullreqs"
+	PathParamCommitSHA          = "commit_sha"
+	QueryParamLineFrom          = "line_from"
+	QueryParamLineTo            = "line_to"
+	QueryParamPath              = "path"
+	QueryParamSince             = "since"
+	QueryParamUntil             = "until"
+	QueryParamCommitter         = "committer"
+	QueryParamSHA               = "sha"
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
@@ -41,10 +51,45 @@ func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
 
+func GetIncludeDivergenceFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludeDivergence, deflt)
+}
+
+func GetIncludePullReqsFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludePullReqs, deflt)
+}
+
 func GetCommitSHAFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamCommitSHA)
 }
 
+// ParseDiffOptions extracts the diff algorithm and whitespace handling options from the url.
+func ParseDiffOptions(r *http.Request) (types.DiffOptions, error) {
+	opts := types.DiffOptions{}
+
+	if algorithm := QueryParamOrDefault(r, QueryParamDiffAlgorithm, ""); algorithm != "" {
+		var ok bool
+		opts.Algorithm, ok = enum.DiffAlgorithm(strings.ToLower(algorithm)).Sanitize()
+		if !ok {
+			return types.DiffOptions{}, usererror.BadRequestf("Unknown diff algorithm '%s'.", algorithm)
+		}
+	}
+
+	var err error
+
+	opts.IgnoreWhitespace, err = QueryParamAsBoolOrDefault(r, QueryParamIgnoreWhitespace, false)
+	if err != nil {
+		return types.DiffOptions{}, err
+	}
+
+	opts.IgnoreBlankLines, err = QueryParamAsBoolOrDefault(r, QueryParamIgnoreBlankLines, false)
+	if err != nil {
+		return types.DiffOptions{}, err
+	}
+
+	return opts, nil
+}
+
 // ParseSortBranch extracts the branch sort parameter from the url.
 func ParseSortBranch(r *http.Request) enum.BranchSortOption {
 	return enum.ParseBranchSortOption(
@@ -105,3 +150,12 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 		Committer: QueryParamOrDefault(r, QueryParamCommitter, ""),
 	}, nil
 }
+
+// ParseCommitAuthorRewriteFilter extracts the commit author rewrite filter from the url.
+func ParseCommitAuthorRewriteFilter(r *http.Request) *types.CommitAuthorRewriteFilter {
+	return &types.CommitAuthorRewriteFilter{
+		Page: ParsePage(r),
+		Size: ParseLimit(r),
+		SHA:  QueryParamOrDefault(r, QueryParamSHA, ""),
+	}
+}
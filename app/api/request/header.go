@@ -14,9 +14,28 @@
 
 package request
 
+import (
+	"net/http"
+	"strings"
+)
+
 const (
 	// TODO: have shared constants across all services?
 	HeaderRequestID     = "X-Request-Id"
 	HeaderUserAgent     = "User-Agent"
 	HeaderAuthorization = "Authorization"
+	HeaderIfMatch       = "If-Match"
 )
+
+// GetIfMatchFromHeader returns the entity tag of the If-Match header without quotes, or empty if not provided.
+// The wildcard "*" matches any entity and is therefore treated as not provided.
+func GetIfMatchFromHeader(r *http.Request) string {
+	etag := strings.TrimSpace(r.Header.Get(HeaderIfMatch))
+	if etag == "*" {
+		return ""
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+
+	return strings.Trim(etag, `"`)
+}
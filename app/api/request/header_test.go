@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIfMatchFromHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "*", want: ""},
+		{header: `"3"`, want: "3"},
+		{header: `W/"3"`, want: "3"},
+		{header: " 3 ", want: "3"},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPatch, "/", nil)
+		if test.header != "" {
+			r.Header.Set(HeaderIfMatch, test.header)
+		}
+
+		if got := GetIfMatchFromHeader(r); got != test.want {
+			t.Errorf("GetIfMatchFromHeader(%q) = %q, want %q", test.header, got, test.want)
+		}
+	}
+}
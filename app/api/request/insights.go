@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamMaxAgeDays  = "max_age_days"
+	QueryParamMaxIdleDays = "max_idle_days"
+	QueryParamGroupBy     = "group_by"
+)
+
+// ParseInsightsFilter extracts the insights time range (in unix milliseconds) from the url.
+func ParseInsightsFilter(r *http.Request) (types.InsightsFilter, error) {
+	// since is optional, defaults to 30 days before until if set to 0
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return types.InsightsFilter{}, err
+	}
+	// until is optional, defaults to now if set to 0
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return types.InsightsFilter{}, err
+	}
+
+	return types.InsightsFilter{
+		Since: since,
+		Until: until,
+	}, nil
+}
+
+// ParsePullReqAgingFilter extracts the pull request aging thresholds and grouping from the url.
+func ParsePullReqAgingFilter(r *http.Request) (types.PullReqAgingFilter, error) {
+	// max_age_days is optional, defaults to the pull request SLA of the space
+	maxAgeDays, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMaxAgeDays, 0)
+	if err != nil {
+		return types.PullReqAgingFilter{}, err
+	}
+	// max_idle_days is optional, defaults to the pull request SLA of the space
+	maxIdleDays, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMaxIdleDays, 0)
+	if err != nil {
+		return types.PullReqAgingFilter{}, err
+	}
+
+	return types.PullReqAgingFilter{
+		PullReqSLA: types.PullReqSLA{
+			MaxAgeDays:  int(maxAgeDays),
+			MaxIdleDays: int(maxIdleDays),
+		},
+		GroupBy: enum.PullReqAgingGroupBy(r.URL.Query().Get(QueryParamGroupBy)),
+	}, nil
+}
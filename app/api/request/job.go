@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamJobUID = "job_uid"
+)
+
+// GetJobUIDFromPath extracts the job uid from the url.
+func GetJobUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamJobUID)
+}
+
+// parseJobStates extracts the job states from the url.
+func parseJobStates(r *http.Request) []enum.JobState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.JobState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.JobState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.JobState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseJobFilter extracts the job query parameters from the url.
+func ParseJobFilter(r *http.Request) types.JobFilter {
+	return types.JobFilter{
+		Page:   ParsePage(r),
+		Size:   ParseLimit(r),
+		Query:  ParseQuery(r),
+		Type:   r.URL.Query().Get(QueryParamType),
+		States: parseJobStates(r),
+	}
+}
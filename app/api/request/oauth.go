@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+)
+
+const (
+	PathParamOAuthAppID           = "oauth_app_id"
+	PathParamOAuthAuthorizationID = "oauth_authorization_id"
+	PathParamOAuthClientID        = "client_id"
+)
+
+// GetOAuthAppIDFromPath extracts the OAuth app id from the url.
+func GetOAuthAppIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamOAuthAppID)
+}
+
+// GetOAuthAuthorizationIDFromPath extracts the OAuth authorization id from the url.
+func GetOAuthAuthorizationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamOAuthAuthorizationID)
+}
+
+// GetOAuthClientIDFromPath extracts the OAuth client id from the url.
+func GetOAuthClientIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamOAuthClientID)
+}
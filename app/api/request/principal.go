@@ -26,6 +26,9 @@ const (
 	PathParamUserUID           = "user_uid"
 	PathParamUserID            = "user_id"
 	PathParamServiceAccountUID = "sa_uid"
+	PathParamUserEmailID       = "email_id"
+	PathParamPublicKeyID       = "public_key_id"
+	PathParamSavedReplyID      = "saved_reply_id"
 
 	QueryParamPrincipalID = "principal_id"
 )
@@ -48,6 +51,21 @@ func GetUserUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamUserUID)
 }
 
+// GetUserEmailIDFromPath returns the user email id from the request path.
+func GetUserEmailIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamUserEmailID)
+}
+
+// GetPublicKeyIDFromPath returns the public key id from the request path.
+func GetPublicKeyIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPublicKeyID)
+}
+
+// GetSavedReplyIDFromPath returns the saved reply id from the request path.
+func GetSavedReplyIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamSavedReplyID)
+}
+
 func GetServiceAccountUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamServiceAccountUID)
 }
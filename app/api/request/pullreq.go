@@ -16,7 +16,9 @@ package request
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -25,6 +27,11 @@ const (
 	PathParamPullReqNumber    = "pullreq_number"
 	PathParamPullReqCommentID = "pullreq_comment_id"
 	PathParamReviewerID       = "pullreq_reviewer_id"
+	PathParamPullReqTaskIndex = "pullreq_task_index"
+
+	QueryParamSearchComments = "search_comments"
+	QueryParamFromPush       = "from"
+	QueryParamToPush         = "to"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -35,6 +42,37 @@ func GetReviewerIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamReviewerID)
 }
 
+// GetPullReqTaskIndexFromPath extracts the zero based index of a task of the pull request description from the url.
+func GetPullReqTaskIndexFromPath(r *http.Request) (int, error) {
+	rawValue, err := PathParamOrError(r, PathParamPullReqTaskIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	index, err := strconv.Atoi(rawValue)
+	if err != nil || index < 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", PathParamPullReqTaskIndex)
+	}
+
+	return index, nil
+}
+
+// ParsePushRange extracts the numbers of the pull request pushes to diff from the url,
+// a zero value is returned for a push that isn't provided.
+func ParsePushRange(r *http.Request) (int64, int64, error) {
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamFromPush, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamToPush, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return from, to, nil
+}
+
 func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
@@ -70,17 +108,22 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	searchComments, err := QueryParamAsBoolOrDefault(r, QueryParamSearchComments, false)
+	if err != nil {
+		return nil, err
+	}
 	return &types.PullReqFilter{
-		Page:          ParsePage(r),
-		Size:          ParseLimit(r),
-		Query:         ParseQuery(r),
-		CreatedBy:     createdBy,
-		SourceRepoRef: r.URL.Query().Get("source_repo_ref"),
-		SourceBranch:  r.URL.Query().Get("source_branch"),
-		TargetBranch:  r.URL.Query().Get("target_branch"),
-		States:        parsePullReqStates(r),
-		Sort:          ParseSortPullReq(r),
-		Order:         ParseOrder(r),
+		Page:           ParsePage(r),
+		Size:           ParseLimit(r),
+		Query:          ParseQuery(r),
+		SearchComments: searchComments,
+		CreatedBy:      createdBy,
+		SourceRepoRef:  r.URL.Query().Get("source_repo_ref"),
+		SourceBranch:   r.URL.Query().Get("source_branch"),
+		TargetBranch:   r.URL.Query().Get("target_branch"),
+		States:         parsePullReqStates(r),
+		Sort:           ParseSortPullReq(r),
+		Order:          ParseOrder(r),
 	}, nil
 }
 
@@ -25,6 +25,9 @@ import (
 const (
 	PathParamRepoRef = "repo_ref"
 	QueryParamRepoID = "repo_id"
+	PathParamTopic   = "topic"
+
+	QueryParamHeadRepoRef = "head_repo_ref"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -37,6 +40,11 @@ func GetRepoRefFromPath(r *http.Request) (string, error) {
 	return url.PathUnescape(rawRef)
 }
 
+// GetHeadRepoRefFromQuery returns the optional head repo ref from the request query.
+func GetHeadRepoRefFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamHeadRepoRef, "")
+}
+
 // GetRepoIDFromQuery returns the repo id from the request query.
 func GetRepoIDFromQuery(r *http.Request) (int64, error) {
 	return QueryParamAsPositiveInt64(r, QueryParamRepoID)
@@ -59,3 +67,17 @@ func ParseRepoFilter(r *http.Request) *types.RepoFilter {
 		Size:  ParseLimit(r),
 	}
 }
+
+// GetTopicFromPath returns the topic from the request path.
+func GetTopicFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamTopic)
+}
+
+// ParseTopicFilter extracts the topic filter from the url.
+func ParseTopicFilter(r *http.Request) *types.TopicFilter {
+	return &types.TopicFilter{
+		Query: ParseQuery(r),
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+	}
+}
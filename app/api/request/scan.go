@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamScanSuppressionID = "scan_suppression_id"
+
+	QueryParamCommitSHA         = "commit_sha"
+	QueryParamScanner           = "scanner"
+	QueryParamMinSeverity       = "min_severity"
+	QueryParamIncludeSuppressed = "include_suppressed"
+)
+
+// GetScanSuppressionIDFromPath extracts the scan suppression id from the url.
+func GetScanSuppressionIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamScanSuppressionID)
+}
+
+// ParseScanFindingFilter extracts the scan finding query parameters from the url.
+func ParseScanFindingFilter(r *http.Request) (*types.ScanFindingFilter, error) {
+	includeSuppressed, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeSuppressed, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var minSeverity enum.ScanSeverity
+	if v := r.URL.Query().Get(QueryParamMinSeverity); v != "" {
+		var ok bool
+		minSeverity, ok = enum.ScanSeverity(v).Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Unknown severity '%s'.", v)
+		}
+	}
+
+	return &types.ScanFindingFilter{
+		Page:              ParsePage(r),
+		Size:              ParseLimit(r),
+		CommitSHA:         r.URL.Query().Get(QueryParamCommitSHA),
+		Scanner:           r.URL.Query().Get(QueryParamScanner),
+		MinSeverity:       minSeverity,
+		IncludeSuppressed: includeSuppressed,
+	}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamSettingKey = "setting_key"
+
+	QueryParamKey = "key"
+)
+
+// GetSettingKeyFromPath extracts the setting key from the url.
+func GetSettingKeyFromPath(r *http.Request) (enum.SettingKey, error) {
+	rawKey, err := PathParamOrError(r, PathParamSettingKey)
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := enum.SettingKey(rawKey).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Unknown setting '%s'.", rawKey)
+	}
+
+	return key, nil
+}
+
+// GetInstanceSettingKeyFromPath extracts the instance setting key from the url.
+func GetInstanceSettingKeyFromPath(r *http.Request) (enum.InstanceSettingKey, error) {
+	rawKey, err := PathParamOrError(r, PathParamSettingKey)
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := enum.InstanceSettingKey(rawKey).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Unknown instance setting '%s'.", rawKey)
+	}
+
+	return key, nil
+}
+
+// ParseInstanceSettingChangeFilter extracts the instance setting change query parameters from the url.
+func ParseInstanceSettingChangeFilter(r *http.Request) types.InstanceSettingChangeFilter {
+	key, _ := enum.InstanceSettingKey(r.URL.Query().Get(QueryParamKey)).Sanitize()
+
+	return types.InstanceSettingChangeFilter{
+		Page: ParsePage(r),
+		Size: ParseLimit(r),
+		Key:  key,
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamUploadQuarantineID = "upload_quarantine_id"
+
+	QueryParamStatus = "status"
+)
+
+// GetUploadQuarantineIDFromPath extracts the upload quarantine id from the url.
+func GetUploadQuarantineIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamUploadQuarantineID)
+}
+
+// ParseUploadQuarantineFilter extracts the upload quarantine query parameters from the url.
+func ParseUploadQuarantineFilter(r *http.Request) types.UploadQuarantineFilter {
+	status, _ := enum.UploadQuarantineStatus(r.URL.Query().Get(QueryParamStatus)).Sanitize()
+
+	return types.UploadQuarantineFilter{
+		Page:   ParsePage(r),
+		Size:   ParseLimit(r),
+		Status: status,
+	}
+}
@@ -46,6 +46,9 @@ const (
 	QueryParamLimit = "limit"
 	PerPageDefault  = 30
 	PerPageMax      = 100
+
+	QueryParamDryRun    = "dry_run"
+	QueryParamDeletedAt = "deleted_at"
 )
 
 // GetCookie tries to retrieve the cookie from the request or returns false if it doesn't exist.
@@ -207,6 +210,16 @@ func GetRemainderFromPath(r *http.Request) (string, error) {
 }
 
 // ParseQuery extracts the query parameter from the url.
+// ParseDryRun extracts the dry run flag from the url.
+func ParseDryRun(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamDryRun, false)
+}
+
+// ParseDeletedAt extracts the deletion time (unix millis) of a resource in the trash from the url.
+func ParseDeletedAt(r *http.Request) (int64, error) {
+	return QueryParamAsPositiveInt64(r, QueryParamDeletedAt)
+}
+
 func ParseQuery(r *http.Request) string {
 	return r.URL.Query().Get(QueryParamQuery)
 }
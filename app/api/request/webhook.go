@@ -24,6 +24,7 @@ import (
 const (
 	PathParamWebhookID          = "webhook_id"
 	PathParamWebhookExecutionID = "webhook_execution_id"
+	PathParamWebhookTrigger     = "webhook_trigger"
 )
 
 func GetWebhookIDFromPath(r *http.Request) (int64, error) {
@@ -34,6 +35,15 @@ func GetWebhookExecutionIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamWebhookExecutionID)
 }
 
+func GetWebhookTriggerFromPath(r *http.Request) (enum.WebhookTrigger, error) {
+	trigger, err := PathParamOrError(r, PathParamWebhookTrigger)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.WebhookTrigger(trigger), nil
+}
+
 // ParseWebhookFilter extracts the Webhook query parameters for listing from the url.
 func ParseWebhookFilter(r *http.Request) *types.WebhookFilter {
 	return &types.WebhookFilter{
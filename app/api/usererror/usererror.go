@@ -44,6 +44,9 @@ var (
 	// ErrNotMergeable is returned when a branch can't be merged.
 	ErrNotMergeable = New(http.StatusPreconditionFailed, "Branch can't be merged")
 
+	// ErrNotFastForward is returned when a branch update isn't a fast-forward of the branch.
+	ErrNotFastForward = New(http.StatusConflict, "The update isn't a fast-forward of the branch")
+
 	// ErrNoChange is returned when no change was found based on the request.
 	ErrNoChange = New(http.StatusBadRequest, "No Change")
 
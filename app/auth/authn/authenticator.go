@@ -24,6 +24,9 @@ import (
 var (
 	// ErrNoAuthData that is returned if the authorizer doesn't find any data in the request that can be used for auth.
 	ErrNoAuthData = errors.New("the request doesn't contain any auth data that can be used by the Authorizer")
+
+	// ErrPrincipalInactive is returned if the principal was verified but is blocked or suspended.
+	ErrPrincipalInactive = errors.New("the principal is blocked or suspended")
 )
 
 // Authenticator is an abstraction of an entity that's responsible for authenticating principals
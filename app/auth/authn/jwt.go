@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	gojwt "github.com/dgrijalva/jwt-go"
 )
@@ -34,20 +36,23 @@ var _ Authenticator = (*JWTAuthenticator)(nil)
 
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
-	cookieName     string
-	principalStore store.PrincipalStore
-	tokenStore     store.TokenStore
+	cookieName              string
+	principalCache          store.PrincipalCache
+	tokenStore              store.TokenStore
+	oauthAuthorizationStore store.OAuthAuthorizationStore
 }
 
 func NewTokenAuthenticator(
-	principalStore store.PrincipalStore,
+	principalCache store.PrincipalCache,
 	tokenStore store.TokenStore,
+	oauthAuthorizationStore store.OAuthAuthorizationStore,
 	cookieName string,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
-		cookieName:     cookieName,
-		principalStore: principalStore,
-		tokenStore:     tokenStore,
+		cookieName:              cookieName,
+		principalCache:          principalCache,
+		tokenStore:              tokenStore,
+		oauthAuthorizationStore: oauthAuthorizationStore,
 	}
 }
 
@@ -63,7 +68,7 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 	var err error
 	claims := &jwt.Claims{}
 	parsed, err := gojwt.ParseWithClaims(str, claims, func(token_ *gojwt.Token) (interface{}, error) {
-		principal, err = a.principalStore.Find(ctx, claims.PrincipalID)
+		principal, err = a.principalCache.Get(ctx, claims.PrincipalID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get principal for token: %w", err)
 		}
@@ -81,6 +86,10 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, errors.New("invalid HMAC signature for JWT")
 	}
 
+	if state := principal.State(time.Now().UnixMilli()); state != enum.PrincipalStateActive {
+		return nil, fmt.Errorf("principal %d is %s: %w", principal.ID, state, ErrPrincipalInactive)
+	}
+
 	var metadata auth.Metadata
 	switch {
 	case claims.Token != nil:
@@ -90,6 +99,13 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		}
 	case claims.Membership != nil:
 		metadata = a.metadataFromMembershipClaims(claims.Membership)
+	case claims.RepoAccess != nil:
+		metadata = a.metadataFromRepoAccessClaims(claims.RepoAccess)
+	case claims.OAuth != nil:
+		metadata, err = a.metadataFromOAuthClaims(ctx, principal, claims.OAuth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata from oauth claims: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
@@ -133,6 +149,48 @@ func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	}
 }
 
+func (a *JWTAuthenticator) metadataFromRepoAccessClaims(
+	raClaims *jwt.SubClaimsRepoAccess,
+) auth.Metadata {
+	// the repo is verified by the authorizer as part of every permission check
+	return &auth.RepoAccessMetadata{
+		RepoID: raClaims.RepoID,
+		Role:   raClaims.Role,
+	}
+}
+
+func (a *JWTAuthenticator) metadataFromOAuthClaims(
+	ctx context.Context,
+	principal *types.Principal,
+	oaClaims *jwt.SubClaimsOAuth,
+) (auth.Metadata, error) {
+	// ensure the authorization wasn't revoked
+	authorization, err := a.oauthAuthorizationStore.Find(ctx, oaClaims.AuthorizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oauth authorization in db: %w", err)
+	}
+
+	// protect against faked JWTs for other principals in case of single salt leak
+	if principal.ID != authorization.PrincipalID {
+		return nil, fmt.Errorf("JWT was for principal %d while oauth authorization was for principal %d",
+			principal.ID, authorization.PrincipalID)
+	}
+
+	// the authorization might have been reduced since the token was issued
+	scopes := make([]enum.OAuthScope, 0, len(oaClaims.Scopes))
+	for _, scope := range oaClaims.Scopes {
+		if enum.OAuthScopesContain(authorization.Scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &auth.OAuthMetadata{
+		AppID:           authorization.AppID,
+		AuthorizationID: authorization.ID,
+		Scopes:          scopes,
+	}, nil
+}
+
 func extractToken(r *http.Request, cookieName string) string {
 	// Check query param first (as that's most immediately visible to caller)
 	if queryToken, ok := request.GetAccessTokenFromQuery(r); ok {
@@ -28,8 +28,9 @@ var WireSet = wire.NewSet(
 
 func ProvideAuthenticator(
 	config *types.Config,
-	principalStore store.PrincipalStore,
+	principalCache store.PrincipalCache,
 	tokenStore store.TokenStore,
+	oauthAuthorizationStore store.OAuthAuthorizationStore,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(principalCache, tokenStore, oauthAuthorizationStore, config.Token.CookieName)
 }
@@ -55,4 +55,16 @@ type Authorizer interface {
 	CheckAll(ctx context.Context,
 		session *auth.Session,
 		permissionChecks ...types.PermissionCheck) (bool, error)
+
+	/*
+	 * Checks for each of the provided permission checks whether the principal of the current session
+	 * with the provided metadata has the permission to execute the action on the resource within the scope.
+	 * Unlike repeated calls of Check, the memberships required for all checks are resolved at once.
+	 * Returns
+	 *		([]bool, nil) - whether the action of the permission check with the same index is permitted
+	 *		(nil, err)    - an error occurred while performing the permission checks and all actions should be denied
+	 */
+	CheckMany(ctx context.Context,
+		session *auth.Session,
+		permissionChecks ...types.PermissionCheck) ([]bool, error)
 }
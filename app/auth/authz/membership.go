@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -31,16 +32,25 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
-	spaceStore      store.SpaceStore
+	spaceCache      store.SpaceCache
+	spacePathCache  store.SpacePathCache
+	membershipStore store.MembershipStore
+	repoStore       store.RepoStore
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
-	spaceStore store.SpaceStore,
+	spaceCache store.SpaceCache,
+	spacePathCache store.SpacePathCache,
+	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
-		spaceStore:      spaceStore,
+		spaceCache:      spaceCache,
+		spacePathCache:  spacePathCache,
+		membershipStore: membershipStore,
+		repoStore:       repoStore,
 	}
 }
 
@@ -72,11 +82,232 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
-	if session.Principal.Admin {
-		return true, nil // system admin can call any API
+	// ephemeral repo access is limited to a single repo, even for admins
+	if repoAccessMetadata, ok := session.Metadata.(*auth.RepoAccessMetadata); ok {
+		repo, err := a.repoStore.Find(ctx, repoAccessMetadata.RepoID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		return repoAccessAllows(repoAccessMetadata, repo, scope, resource, permission), nil
+	}
+
+	// oauth access is limited to the permissions covered by the granted scopes
+	oauthMetadata, hasOAuthMetadata := session.Metadata.(*auth.OAuthMetadata)
+	if hasOAuthMetadata && !oauthScopesAllow(oauthMetadata.Scopes, permission) {
+		return false, nil
+	}
+
+	spacePath, decided, allowed := spacePathForCheck(session, scope, resource, permission)
+	if decided {
+		return allowed, nil
+	}
+
+	// ephemeral membership overrides any other space memberships of the principal
+	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
+		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
+	}
+
+	// ensure we aren't bypassing unknown metadata with impact on authorization
+	if !hasOAuthMetadata && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
+	}
+
+	return a.permissionCache.Get(ctx, PermissionCacheKey{
+		PrincipalID: session.Principal.ID,
+		SpaceRef:    spacePath,
+		Permission:  permission,
+	})
+}
+
+func (a *MembershipAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
+	permissionChecks ...types.PermissionCheck) (bool, error) {
+	if len(permissionChecks) == 0 {
+		return false, ErrNoPermissionCheckProvided
+	}
+
+	results, err := a.CheckMany(ctx, session, permissionChecks...)
+	if err != nil {
+		return false, err
+	}
+
+	for _, allowed := range results {
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (a *MembershipAuthorizer) CheckMany(ctx context.Context, session *auth.Session,
+	permissionChecks ...types.PermissionCheck) ([]bool, error) {
+	results := make([]bool, len(permissionChecks))
+
+	// public access - not expected to come here as of now (have to refactor that part)
+	if session == nil {
+		log.Ctx(ctx).Warn().Msgf(
+			"public access request for %d permission checks got to authorizer",
+			len(permissionChecks),
+		)
+		return results, nil
+	}
+
+	log.Ctx(ctx).Debug().Msgf(
+		"[MembershipAuthorizer] %s with id '%d' requests %d permission checks with metadata %#v",
+		session.Principal.Type,
+		session.Principal.ID,
+		len(permissionChecks),
+		session.Metadata,
+	)
+
+	// ephemeral repo access is limited to a single repo, even for admins
+	if repoAccessMetadata, ok := session.Metadata.(*auth.RepoAccessMetadata); ok {
+		repo, err := a.repoStore.Find(ctx, repoAccessMetadata.RepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		for i := range permissionChecks {
+			p := &permissionChecks[i]
+			results[i] = repoAccessAllows(repoAccessMetadata, repo, &p.Scope, &p.Resource, p.Permission)
+		}
+
+		return results, nil
+	}
+
+	membershipMetadata, hasMembershipMetadata := session.Metadata.(*auth.MembershipMetadata)
+	oauthMetadata, hasOAuthMetadata := session.Metadata.(*auth.OAuthMetadata)
+	if !hasMembershipMetadata && !hasOAuthMetadata &&
+		session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+		return nil, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
+	}
+
+	// spacePaths contains the space path deciding each check that depends on memberships.
+	spacePaths := make(map[int]string)
+	for i := range permissionChecks {
+		p := &permissionChecks[i]
+
+		// oauth access is limited to the permissions covered by the granted scopes
+		if hasOAuthMetadata && !oauthScopesAllow(oauthMetadata.Scopes, p.Permission) {
+			continue
+		}
+
+		spacePath, decided, allowed := spacePathForCheck(session, &p.Scope, &p.Resource, p.Permission)
+		if decided {
+			results[i] = allowed
+			continue
+		}
+
+		spacePaths[i] = spacePath
 	}
 
-	var spacePath string
+	if len(spacePaths) == 0 {
+		return results, nil
+	}
+
+	// ephemeral membership overrides any other space memberships of the principal
+	if hasMembershipMetadata {
+		space, err := a.spaceCache.Get(ctx, membershipMetadata.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		for i, spacePath := range spacePaths {
+			results[i] = paths.IsAncesterOf(space.Path, spacePath) &&
+				roleHasPermission(membershipMetadata.Role, permissionChecks[i].Permission)
+		}
+
+		return results, nil
+	}
+
+	roles, err := a.findAncestorRoles(ctx, session.Principal.ID, spacePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, spacePath := range spacePaths {
+		for _, role := range roles[spacePath] {
+			if roleHasPermission(role, permissionChecks[i].Permission) {
+				results[i] = true
+				break
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// findAncestorRoles returns for each of the provided space paths the membership roles
+// the principal has in the space itself and all its ancestors, using a single membership query.
+func (a *MembershipAuthorizer) findAncestorRoles(
+	ctx context.Context,
+	principalID int64,
+	spacePaths map[int]string,
+) (map[string][]enum.MembershipRole, error) {
+	ancestorIDs := make(map[string][]int64, len(spacePaths))
+	spaceIDs := make(map[string]int64)
+	for _, spacePath := range spacePaths {
+		if _, ok := ancestorIDs[spacePath]; ok {
+			continue
+		}
+
+		ancestorPath := ""
+		for _, segment := range paths.Segments(spacePath) {
+			ancestorPath = paths.Concatinate(ancestorPath, segment)
+
+			spaceID, ok := spaceIDs[ancestorPath]
+			if !ok {
+				path, err := a.spacePathCache.Get(ctx, ancestorPath)
+				if err != nil {
+					return nil, fmt.Errorf("failed to find space '%s': %w", ancestorPath, err)
+				}
+
+				spaceID = path.SpaceID
+				spaceIDs[ancestorPath] = spaceID
+			}
+
+			ancestorIDs[spacePath] = append(ancestorIDs[spacePath], spaceID)
+		}
+	}
+
+	ids := make([]int64, 0, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		ids = append(ids, spaceID)
+	}
+
+	memberships, err := a.membershipStore.FindManyForPrincipal(ctx, principalID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find memberships: %w", err)
+	}
+
+	// a principal can have multiple memberships in a space (directly and through user groups)
+	spaceRoles := make(map[int64][]enum.MembershipRole, len(memberships))
+	for _, membership := range memberships {
+		spaceRoles[membership.SpaceID] = append(spaceRoles[membership.SpaceID], membership.Role)
+	}
+
+	roles := make(map[string][]enum.MembershipRole, len(ancestorIDs))
+	for spacePath, ids := range ancestorIDs {
+		for _, spaceID := range ids {
+			roles[spacePath] = append(roles[spacePath], spaceRoles[spaceID]...)
+		}
+	}
+
+	return roles, nil
+}
+
+// spacePathForCheck returns the path of the space whose memberships decide the permission check.
+// Checks that don't depend on memberships are decided right away (decided is true and allowed holds the result).
+func spacePathForCheck(
+	session *auth.Session,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (spacePath string, decided bool, allowed bool) {
+	if session.Principal.Admin {
+		return "", true, true // system admin can call any API
+	}
 
 	//nolint:exhaustive // we want to fail on anything else
 	switch resource.Type {
@@ -105,46 +336,21 @@ func (a *MembershipAuthorizer) Check(
 		// a user is allowed to view / edit themselves
 		if resource.Name == session.Principal.UID &&
 			(permission == enum.PermissionUserView || permission == enum.PermissionUserEdit) {
-			return true, nil
+			return "", true, true
 		}
 
 		// everything else is reserved for admins only (like operations on users other than yourself, or setting admin)
-		return false, nil
+		return "", true, false
 
 	// Service operations aren't exposed to users
 	case enum.ResourceTypeService:
-		return false, nil
+		return "", true, false
 
 	default:
-		return false, nil
-	}
-
-	// ephemeral membership overrides any other space memberships of the principal
-	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
-		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
+		return "", true, false
 	}
 
-	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
-		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
-	}
-
-	return a.permissionCache.Get(ctx, PermissionCacheKey{
-		PrincipalID: session.Principal.ID,
-		SpaceRef:    spacePath,
-		Permission:  permission,
-	})
-}
-
-func (a *MembershipAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
-	permissionChecks ...types.PermissionCheck) (bool, error) {
-	for _, p := range permissionChecks {
-		if _, err := a.Check(ctx, session, &p.Scope, &p.Resource, p.Permission); err != nil {
-			return false, err
-		}
-	}
-
-	return true, nil
+	return spacePath, false, false
 }
 
 // checkWithMembershipMetadata checks access using the ephemeral membership provided in the metadata.
@@ -154,7 +360,7 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	requestedSpacePath string,
 	requestedPermission enum.Permission,
 ) (bool, error) {
-	space, err := a.spaceStore.Find(ctx, membershipMetadata.SpaceID)
+	space, err := a.spaceCache.Get(ctx, membershipMetadata.SpaceID)
 	if err != nil {
 		return false, fmt.Errorf("failed to find space: %w", err)
 	}
@@ -178,3 +384,23 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// repoAccessAllows returns true if the ephemeral repo access grants the permission on the resource.
+// Only the repo itself can be accessed - any other resource (including its parent spaces) is off limits.
+func repoAccessAllows(
+	repoAccessMetadata *auth.RepoAccessMetadata,
+	repo *types.Repository,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) bool {
+	if resource.Type != enum.ResourceTypeRepo {
+		return false
+	}
+
+	if !strings.EqualFold(paths.Concatinate(scope.SpacePath, resource.Name), repo.Path) {
+		return false
+	}
+
+	return roleHasPermission(repoAccessMetadata.Role, permission)
+}
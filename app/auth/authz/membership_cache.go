@@ -16,15 +16,12 @@ package authz
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/cache"
-	gitness_store "github.com/harness/gitness/store"
-	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"golang.org/x/exp/slices"
@@ -39,17 +36,20 @@ type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
+	spaceCache store.SpaceCache,
 	membershipStore store.MembershipStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
 		spaceStore:      spaceStore,
+		spaceCache:      spaceCache,
 		membershipStore: membershipStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
 	spaceStore      store.SpaceStore
+	spaceCache      store.SpaceCache
 	membershipStore store.MembershipStore
 }
 
@@ -66,32 +66,31 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	// limit the depth to be safe (e.g. root/space1/space2 => maxDepth of 3)
 	maxDepth := len(paths.Segments(spaceRef))
 
+	// Collect the starting space and all its ancestors.
+	spaceIDs := make([]int64, 0, maxDepth)
 	for depth := 0; depth < maxDepth; depth++ {
-		// Find the membership in the current space.
-		membership, err := g.membershipStore.Find(ctx, types.MembershipKey{
-			SpaceID:     space.ID,
-			PrincipalID: principalID,
-		})
-		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
-			return false, fmt.Errorf("failed to find membership: %w", err)
-		}
-
-		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
-		}
-
-		// If membership with the requested permission has not been found in the current space,
-		// move to the parent space, if any.
+		spaceIDs = append(spaceIDs, space.ID)
 
 		if space.ParentID == 0 {
-			return false, nil
+			break
 		}
 
-		space, err = g.spaceStore.Find(ctx, space.ParentID)
+		parentID := space.ParentID
+		space, err = g.spaceCache.Get(ctx, parentID)
 		if err != nil {
-			return false, fmt.Errorf("failed to find parent space with id %d: %w", space.ParentID, err)
+			return false, fmt.Errorf("failed to find parent space with id %d: %w", parentID, err)
+		}
+	}
+
+	// Find the memberships of the principal (including the ones of its user groups) in any of the spaces.
+	memberships, err := g.membershipStore.FindManyForPrincipal(ctx, principalID, spaceIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to find memberships: %w", err)
+	}
+
+	for _, membership := range memberships {
+		if roleHasPermission(membership.Role, key.Permission) {
+			return true, nil
 		}
 	}
 
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeSpacePathCache map[string]int64
+
+func (c fakeSpacePathCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c fakeSpacePathCache) Get(_ context.Context, key string) (*types.SpacePath, error) {
+	spaceID, ok := c[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.SpacePath{Value: key, SpaceID: spaceID}, nil
+}
+
+func (c fakeSpacePathCache) Evict(context.Context, string) {}
+
+type fakeMembershipStore struct {
+	store.MembershipStore
+	memberships []types.Membership
+	groups      map[int64][]int64
+	calls       int
+}
+
+func (s *fakeMembershipStore) FindManyForPrincipal(
+	_ context.Context,
+	principalID int64,
+	spaceIDs []int64,
+) ([]types.Membership, error) {
+	s.calls++
+
+	principalIDs := append([]int64{principalID}, s.groups[principalID]...)
+
+	var res []types.Membership
+	for _, m := range s.memberships {
+		for _, spaceID := range spaceIDs {
+			for _, id := range principalIDs {
+				if m.PrincipalID == id && m.SpaceID == spaceID {
+					res = append(res, m)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+func TestMembershipAuthorizerCheckMany(t *testing.T) {
+	spacePathCache := fakeSpacePathCache{"root": 1, "root/a": 2, "root/b": 3}
+	repoStore := fakeRepoStore{repos: map[int64]*types.Repository{1: {ID: 1, Path: "root/a/repo"}}}
+	checks := []types.PermissionCheck{
+		{
+			Scope:      types.Scope{SpacePath: "root"},
+			Resource:   types.Resource{Type: enum.ResourceTypeSpace, Name: "a"},
+			Permission: enum.PermissionSpaceView,
+		},
+		{
+			Scope:      types.Scope{SpacePath: "root"},
+			Resource:   types.Resource{Type: enum.ResourceTypeSpace, Name: "b"},
+			Permission: enum.PermissionSpaceView,
+		},
+		{
+			Scope:      types.Scope{SpacePath: "root/a"},
+			Resource:   types.Resource{Type: enum.ResourceTypeRepo, Name: "repo"},
+			Permission: enum.PermissionRepoView,
+		},
+		{
+			Scope:      types.Scope{SpacePath: "root/a"},
+			Resource:   types.Resource{Type: enum.ResourceTypeRepo, Name: "repo"},
+			Permission: enum.PermissionRepoDelete,
+		},
+		{
+			Resource:   types.Resource{Type: enum.ResourceTypeUser, Name: "user"},
+			Permission: enum.PermissionUserView,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		session     *auth.Session
+		memberships []types.Membership
+		groups      map[int64][]int64
+		expected    []bool
+		expectCalls int
+	}{
+		{
+			name:     "no-session",
+			session:  nil,
+			expected: []bool{false, false, false, false, false},
+		},
+		{
+			name:     "admin",
+			session:  &auth.Session{Principal: types.Principal{ID: 7, UID: "admin", Admin: true}},
+			expected: []bool{true, true, true, true, true},
+		},
+		{
+			name: "repo-access-limits-admin",
+			session: &auth.Session{
+				Principal: types.Principal{ID: 7, UID: "admin", Admin: true},
+				Metadata:  &auth.RepoAccessMetadata{RepoID: 1, Role: enum.MembershipRoleReader},
+			},
+			expected: []bool{false, false, true, false, false},
+		},
+		{
+			name: "oauth-scopes-limit-admin",
+			session: &auth.Session{
+				Principal: types.Principal{ID: 7, UID: "admin", Admin: true},
+				Metadata:  &auth.OAuthMetadata{AppID: 1, AuthorizationID: 1, Scopes: []enum.OAuthScope{enum.OAuthScopeRepoRead}},
+			},
+			expected: []bool{true, true, true, false, false},
+		},
+		{
+			name: "oauth-scopes-limit-membership",
+			session: &auth.Session{
+				Principal: types.Principal{ID: 7, UID: "user"},
+				Metadata:  &auth.OAuthMetadata{AppID: 1, AuthorizationID: 1, Scopes: []enum.OAuthScope{enum.OAuthScopeRepoRead}},
+			},
+			memberships: []types.Membership{
+				{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 7}, Role: enum.MembershipRoleSpaceOwner},
+			},
+			expected:    []bool{true, true, true, false, false},
+			expectCalls: 1,
+		},
+		{
+			name:        "no-memberships",
+			session:     &auth.Session{Principal: types.Principal{ID: 7, UID: "user"}},
+			expected:    []bool{false, false, false, false, true},
+			expectCalls: 1,
+		},
+		{
+			name:    "membership-in-child-space",
+			session: &auth.Session{Principal: types.Principal{ID: 7, UID: "user"}},
+			memberships: []types.Membership{
+				{MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: 7}, Role: enum.MembershipRoleReader},
+				{MembershipKey: types.MembershipKey{SpaceID: 3, PrincipalID: 8}, Role: enum.MembershipRoleReader},
+			},
+			expected:    []bool{true, false, true, false, true},
+			expectCalls: 1,
+		},
+		{
+			name:    "membership-in-root-space",
+			session: &auth.Session{Principal: types.Principal{ID: 7, UID: "user"}},
+			memberships: []types.Membership{
+				{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 7}, Role: enum.MembershipRoleReader},
+				{MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: 7}, Role: enum.MembershipRoleSpaceOwner},
+			},
+			expected:    []bool{true, true, true, true, true},
+			expectCalls: 1,
+		},
+		{
+			name:    "membership-through-user-group",
+			session: &auth.Session{Principal: types.Principal{ID: 7, UID: "user"}},
+			memberships: []types.Membership{
+				{MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: 7}, Role: enum.MembershipRoleReader},
+				{MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: 9}, Role: enum.MembershipRoleSpaceOwner},
+			},
+			groups:      map[int64][]int64{7: {9}},
+			expected:    []bool{true, false, true, true, true},
+			expectCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			membershipStore := &fakeMembershipStore{memberships: test.memberships, groups: test.groups}
+			authorizer := NewMembershipAuthorizer(nil, nil, spacePathCache, membershipStore, repoStore)
+
+			results, err := authorizer.CheckMany(context.Background(), test.session, checks...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := test.expected, results; !reflect.DeepEqual(want, got) {
+				t.Errorf("failed - want=%v, got=%v", want, got)
+			}
+			if want, got := test.expectCalls, membershipStore.calls; want != got {
+				t.Errorf("failed - want membership queries=%d, got=%d", want, got)
+			}
+		})
+	}
+}
+
+// TestMembershipAuthorizerCheckOAuthScopesBeforeAdmin verifies that the scopes of an OAuth token
+// restrict admins as well, the scopes have to be checked before the admin bypass.
+func TestMembershipAuthorizerCheckOAuthScopesBeforeAdmin(t *testing.T) {
+	scope := &types.Scope{SpacePath: "root"}
+	repo := &types.Resource{Type: enum.ResourceTypeRepo, Name: "repo"}
+	user := &types.Resource{Type: enum.ResourceTypeUser, Name: "other"}
+
+	tests := []struct {
+		name       string
+		scopes     []enum.OAuthScope
+		resource   *types.Resource
+		permission enum.Permission
+		expected   bool
+	}{
+		{
+			name:       "openid-denies-repo-view",
+			scopes:     []enum.OAuthScope{enum.OAuthScopeOpenID},
+			resource:   repo,
+			permission: enum.PermissionRepoView,
+			expected:   false,
+		},
+		{
+			name:       "repo-read-allows-repo-view",
+			scopes:     []enum.OAuthScope{enum.OAuthScopeRepoRead},
+			resource:   repo,
+			permission: enum.PermissionRepoView,
+			expected:   true,
+		},
+		{
+			name:       "repo-read-denies-repo-push",
+			scopes:     []enum.OAuthScope{enum.OAuthScopeRepoRead},
+			resource:   repo,
+			permission: enum.PermissionRepoPush,
+			expected:   false,
+		},
+		{
+			name:       "repo-write-denies-user-edit",
+			scopes:     []enum.OAuthScope{enum.OAuthScopeRepoWrite},
+			resource:   user,
+			permission: enum.PermissionUserEdit,
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{
+				Principal: types.Principal{ID: 7, UID: "admin", Admin: true},
+				Metadata:  &auth.OAuthMetadata{AppID: 1, AuthorizationID: 1, Scopes: test.scopes},
+			}
+			authorizer := NewMembershipAuthorizer(nil, nil, nil, nil, nil)
+
+			allowed, err := authorizer.Check(context.Background(), session, scope, test.resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if allowed != test.expected {
+				t.Errorf("failed - want=%t, got=%t", test.expected, allowed)
+			}
+		})
+	}
+}
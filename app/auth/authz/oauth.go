@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// oauthScopePermissions defines the permissions granted by each OAuth scope.
+// NOTE: scopes only restrict the permissions of the user, they never grant permissions the user doesn't have.
+// Scopes missing in the map (like openid) only grant access to identity information.
+var oauthScopePermissions = map[enum.OAuthScope][]enum.Permission{
+	enum.OAuthScopeUserRead: {
+		enum.PermissionUserView,
+	},
+	enum.OAuthScopeRepoRead: {
+		enum.PermissionSpaceView,
+		enum.PermissionRepoView,
+		enum.PermissionPipelineView,
+	},
+	enum.OAuthScopeRepoWrite: {
+		enum.PermissionSpaceView,
+		enum.PermissionRepoView,
+		enum.PermissionRepoPush,
+		enum.PermissionRepoReportCommitCheck,
+		enum.PermissionPipelineView,
+		enum.PermissionPipelineExecute,
+	},
+}
+
+// oauthScopesAllow returns true if any of the OAuth scopes covers the permission.
+func oauthScopesAllow(scopes []enum.OAuthScope, permission enum.Permission) bool {
+	for _, scope := range scopes {
+		for _, p := range oauthScopePermissions[scope] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
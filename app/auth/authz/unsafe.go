@@ -60,3 +60,16 @@ func (a *UnsafeAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
 
 	return true, nil
 }
+
+func (a *UnsafeAuthorizer) CheckMany(ctx context.Context, session *auth.Session,
+	permissionChecks ...types.PermissionCheck) ([]bool, error) {
+	results := make([]bool, len(permissionChecks))
+	for i, p := range permissionChecks {
+		if _, err := a.Check(ctx, session, &p.Scope, &p.Resource, p.Permission); err != nil {
+			return nil, err
+		}
+		results[i] = true
+	}
+
+	return results, nil
+}
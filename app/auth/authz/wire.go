@@ -28,14 +28,21 @@ var WireSet = wire.NewSet(
 	ProvidePermissionCache,
 )
 
-func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore)
+func ProvideAuthorizer(
+	pCache PermissionCache,
+	spaceCache store.SpaceCache,
+	spacePathCache store.SpacePathCache,
+	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+) Authorizer {
+	return NewMembershipAuthorizer(pCache, spaceCache, spacePathCache, membershipStore, repoStore)
 }
 
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
+	spaceCache store.SpaceCache,
 	membershipStore store.MembershipStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceStore, spaceCache, membershipStore, permissionCacheTimeout)
 }
@@ -46,3 +46,24 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// RepoAccessMetadata contains information about an ephemeral access grant limited to a single repository.
+type RepoAccessMetadata struct {
+	RepoID int64
+	Role   enum.MembershipRole
+}
+
+func (m *RepoAccessMetadata) ImpactsAuthorization() bool {
+	return true
+}
+
+// OAuthMetadata contains information about the OAuth authorization the access token was issued for.
+type OAuthMetadata struct {
+	AppID           int64
+	AuthorizationID int64
+	Scopes          []enum.OAuthScope
+}
+
+func (m *OAuthMetadata) ImpactsAuthorization() bool {
+	return true
+}
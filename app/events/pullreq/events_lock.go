@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const LockedEvent events.EventType = "locked"
+
+type LockedPayload struct {
+	Base
+	Reason *enum.PullReqLockReason `json:"reason,omitempty"`
+}
+
+func (r *Reporter) Locked(ctx context.Context, payload *LockedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, LockedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request locked event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request locked event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterLocked(fn events.HandlerFunc[*LockedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, LockedEvent, fn, opts...)
+}
+
+const UnlockedEvent events.EventType = "unlocked"
+
+type UnlockedPayload struct {
+	Base
+}
+
+func (r *Reporter) Unlocked(ctx context.Context, payload *UnlockedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, UnlockedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request unlocked event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request unlocked event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterUnlocked(fn events.HandlerFunc[*UnlockedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, UnlockedEvent, fn, opts...)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const AccessRequestCreatedEvent events.EventType = "access-request-created"
+
+type AccessRequestCreatedPayload struct {
+	AccessRequestID int64 `json:"access_request_id"`
+	SpaceID         int64 `json:"space_id"`
+	PrincipalID     int64 `json:"principal_id"`
+}
+
+func (r *Reporter) AccessRequestCreated(ctx context.Context, payload *AccessRequestCreatedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, AccessRequestCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send access request created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported access request created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterAccessRequestCreated(fn events.HandlerFunc[*AccessRequestCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, AccessRequestCreatedEvent, fn, opts...)
+}
+
+const AccessRequestDecidedEvent events.EventType = "access-request-decided"
+
+type AccessRequestDecidedPayload struct {
+	AccessRequestID int64                   `json:"access_request_id"`
+	SpaceID         int64                   `json:"space_id"`
+	PrincipalID     int64                   `json:"principal_id"`
+	State           enum.AccessRequestState `json:"state"`
+}
+
+func (r *Reporter) AccessRequestDecided(ctx context.Context, payload *AccessRequestDecidedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, AccessRequestDecidedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send access request decided event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported access request decided event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterAccessRequestDecided(fn events.HandlerFunc[*AccessRequestDecidedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, AccessRequestDecidedEvent, fn, opts...)
+}
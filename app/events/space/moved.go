@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MovedEvent events.EventType = "moved"
+
+type MovedPayload struct {
+	SpaceID     int64  `json:"space_id"`
+	PrincipalID int64  `json:"principal_id"`
+	OldParentID int64  `json:"old_parent_id"`
+	NewParentID int64  `json:"new_parent_id"`
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+}
+
+func (r *Reporter) Moved(ctx context.Context, payload *MovedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MovedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send space moved event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported space moved event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMoved(fn events.HandlerFunc[*MovedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, MovedEvent, fn, opts...)
+}
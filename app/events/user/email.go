@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const EmailVerificationRequestedEvent events.EventType = "email-verification-requested"
+
+// EmailVerificationRequestedPayload contains the plain verification token,
+// it's meant to be delivered to the email address that has to be verified.
+type EmailVerificationRequestedPayload struct {
+	UserID  int64  `json:"user_id"`
+	EmailID int64  `json:"email_id"`
+	Email   string `json:"email"`
+	Token   string `json:"token"`
+	Expires int64  `json:"expires"`
+}
+
+func (r *Reporter) EmailVerificationRequested(ctx context.Context, payload *EmailVerificationRequestedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, EmailVerificationRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send user email verification requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported user email verification requested event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterEmailVerificationRequested(fn events.HandlerFunc[*EmailVerificationRequestedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, EmailVerificationRequestedEvent, fn, opts...)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const StateUpdatedEvent events.EventType = "state-updated"
+
+type StateUpdatedPayload struct {
+	UserID         int64               `json:"user_id"`
+	PrincipalID    int64               `json:"principal_id"`
+	OldState       enum.PrincipalState `json:"old_state"`
+	NewState       enum.PrincipalState `json:"new_state"`
+	SuspendedUntil int64               `json:"suspended_until"`
+}
+
+func (r *Reporter) StateUpdated(ctx context.Context, payload *StateUpdatedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, StateUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send user state updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported user state updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterStateUpdated(fn events.HandlerFunc[*StateUpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, StateUpdatedEvent, fn, opts...)
+}
+
+const PasswordResetForcedEvent events.EventType = "password-reset-forced"
+
+type PasswordResetForcedPayload struct {
+	UserID      int64 `json:"user_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (r *Reporter) PasswordResetForced(ctx context.Context, payload *PasswordResetForcedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, PasswordResetForcedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send user password reset forced event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported user password reset forced event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterPasswordResetForced(fn events.HandlerFunc[*PasswordResetForcedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, PasswordResetForcedEvent, fn, opts...)
+}
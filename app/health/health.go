@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health verifies the availability of the dependencies of gitness.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status describes the availability of the system or one of its dependencies.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker verifies the availability of a single dependency.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx).
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// DependencyStatus describes the result of the check of a single dependency.
+type DependencyStatus struct {
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration int64  `json:"duration_ms"`
+}
+
+// Report describes the result of the checks of all dependencies.
+type Report struct {
+	Status       Status             `json:"status"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+type dependency struct {
+	name    string
+	checker Checker
+}
+
+// Service runs the checks of all registered dependencies.
+type Service struct {
+	timeout      time.Duration
+	dependencies []dependency
+}
+
+// NewService returns a new Service that aborts each check after the provided timeout.
+func NewService(timeout time.Duration) *Service {
+	return &Service{
+		timeout: timeout,
+	}
+}
+
+// Register adds a dependency that is verified as part of every check.
+// NOTE: not thread safe, dependencies are expected to be registered during startup.
+func (s *Service) Register(name string, checker Checker) {
+	s.dependencies = append(s.dependencies, dependency{
+		name:    name,
+		checker: checker,
+	})
+}
+
+// Check verifies all registered dependencies concurrently.
+// The system is only reported as up if all dependencies are up.
+func (s *Service) Check(ctx context.Context) Report {
+	report := Report{
+		Status:       StatusUp,
+		Dependencies: make([]DependencyStatus, len(s.dependencies)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range s.dependencies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report.Dependencies[i] = s.check(ctx, s.dependencies[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, dependency := range report.Dependencies {
+		if dependency.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (s *Service) check(ctx context.Context, dependency dependency) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dependency.checker.Check(ctx)
+
+	status := DependencyStatus{
+		Name:     dependency.name,
+		Status:   StatusUp,
+		Duration: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+
+	return status
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestServiceCheck(t *testing.T) {
+	up := CheckerFunc(func(context.Context) error { return nil })
+	down := CheckerFunc(func(context.Context) error { return errors.New("connection refused") })
+	slow := CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	tests := []struct {
+		name       string
+		checkers   map[string]Checker
+		wantStatus Status
+		wantDown   []string
+	}{
+		{name: "no dependencies", wantStatus: StatusUp},
+		{name: "all up", checkers: map[string]Checker{"a": up, "b": up}, wantStatus: StatusUp},
+		{name: "one down", checkers: map[string]Checker{"a": up, "b": down}, wantStatus: StatusDown, wantDown: []string{"b"}},
+		{name: "timeout", checkers: map[string]Checker{"a": slow}, wantStatus: StatusDown, wantDown: []string{"a"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewService(10 * time.Millisecond)
+			for name, checker := range test.checkers {
+				s.Register(name, checker)
+			}
+
+			report := s.Check(context.Background())
+			if report.Status != test.wantStatus {
+				t.Errorf("want status %s, got %s", test.wantStatus, report.Status)
+			}
+			if len(report.Dependencies) != len(test.checkers) {
+				t.Fatalf("want %d dependencies, got %d", len(test.checkers), len(report.Dependencies))
+			}
+
+			var gotDown []string
+			for _, dependency := range report.Dependencies {
+				if dependency.Status == StatusDown {
+					gotDown = append(gotDown, dependency.Name)
+					if dependency.Error == "" {
+						t.Errorf("expected error for dependency %s", dependency.Name)
+					}
+				}
+			}
+			if len(gotDown) != len(test.wantDown) || (len(gotDown) > 0 && gotDown[0] != test.wantDown[0]) {
+				t.Errorf("want down %v, got %v", test.wantDown, gotDown)
+			}
+		})
+	}
+}
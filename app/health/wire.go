@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/events"
+	gitrpcserver "github.com/harness/gitness/gitrpc/server"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+// ProvideService provides the health service verifying all dependencies used with the provided configuration.
+func ProvideService(
+	config *types.Config,
+	db *sqlx.DB,
+	redisClient redis.UniversalClient,
+	gitrpcConfig gitrpcserver.Config,
+) (*Service, error) {
+	s := NewService(config.Health.Timeout)
+
+	s.Register("database", NewDatabaseChecker(db))
+
+	// redis is only verified if any component is using it.
+	if config.Events.Mode == events.ModeRedis ||
+		config.PubSub.Provider == pubsub.ProviderRedis ||
+		config.Lock.Provider == lock.RedisProvider {
+		s.Register("redis", NewRedisChecker(redisClient))
+	}
+
+	if config.Logs.S3.Bucket != "" {
+		checker, err := NewS3Checker(config.Logs.S3.Bucket, config.Logs.S3.Endpoint, config.Logs.S3.PathStyle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob store health checker: %w", err)
+		}
+		s.Register("blob_store", checker)
+	}
+
+	s.Register("git_filesystem", NewFilesystemChecker(gitrpcConfig.GitRoot))
+
+	return s, nil
+}
@@ -36,6 +36,8 @@ type Claims struct {
 
 	Token      *SubClaimsToken      `json:"tkn,omitempty"`
 	Membership *SubClaimsMembership `json:"ms,omitempty"`
+	RepoAccess *SubClaimsRepoAccess `json:"ra,omitempty"`
+	OAuth      *SubClaimsOAuth      `json:"oa,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -50,6 +52,28 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// SubClaimsRepoAccess contains the single repository the JWT grants access to.
+type SubClaimsRepoAccess struct {
+	Role   enum.MembershipRole `json:"role,omitempty"`
+	RepoID int64               `json:"rid,omitempty"`
+}
+
+// SubClaimsOAuth contains the OAuth authorization the JWT was issued for.
+type SubClaimsOAuth struct {
+	AuthorizationID int64             `json:"aid,omitempty"`
+	Scopes          []enum.OAuthScope `json:"scp,omitempty"`
+}
+
+// IDTokenClaims defines the claims of an OpenID Connect ID token.
+type IDTokenClaims struct {
+	jwt.StandardClaims
+
+	Nonce             string `json:"nonce,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Email             string `json:"email,omitempty"`
+}
+
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
 	var expiresAt int64
@@ -111,3 +135,79 @@ func GenerateWithMembership(
 
 	return res, nil
 }
+
+// GenerateWithRepoAccess generates a jwt that only grants access to the given repository.
+func GenerateWithRepoAccess(
+	principalID int64,
+	repoID int64,
+	role enum.MembershipRole,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		RepoAccess: &SubClaimsRepoAccess{
+			RepoID: repoID,
+			Role:   role,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// GenerateForOAuth generates a jwt used as access token of an OAuth app.
+func GenerateForOAuth(
+	principalID int64,
+	authorizationID int64,
+	scopes []enum.OAuthScope,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		OAuth: &SubClaimsOAuth{
+			AuthorizationID: authorizationID,
+			Scopes:          scopes,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// GenerateIDToken generates an OpenID Connect ID token signed with the client secret of the OAuth app.
+func GenerateIDToken(claims IDTokenClaims, clientSecret string) (string, error) {
+	res, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(clientSecret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign id token")
+	}
+
+	return res, nil
+}
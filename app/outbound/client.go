@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PolicyFunc returns the outbound policy currently in effect.
+type PolicyFunc func(ctx context.Context) (Policy, error)
+
+// ClientOptions defines the options of a client created by the ClientFactory.
+type ClientOptions struct {
+	// Timeout is the time limit of a request, including redirects and reading the response body.
+	// 0 means no time limit.
+	Timeout time.Duration
+
+	// InsecureSkipVerify disables the verification of TLS certificates.
+	InsecureSkipVerify bool
+
+	// AllowLoopback allows loopback addresses regardless of the policy.
+	// NOTE: Deny rules still apply.
+	AllowLoopback bool
+
+	// AllowPrivateNetwork allows private network addresses regardless of the policy.
+	// NOTE: Deny rules still apply.
+	AllowPrivateNetwork bool
+}
+
+// ClientFactory creates http clients that only connect to targets permitted by the outbound policy.
+// The policy is evaluated whenever a client establishes a new connection, so changes take effect without restart
+// (existing keep-alive connections are reused until they are closed).
+//
+// Requests are sent via the configured proxy, or the proxy provided using WithProxy.
+// The configured proxies are trusted, connections to them aren't subject to the policy if they're used as proxy.
+// IMPORTANT: A proxy resolves the target on our behalf, hence the addresses of a proxied target are validated
+// using a local lookup, which the proxy could resolve differently (see Policy.CheckProxied).
+type ClientFactory struct {
+	policy        PolicyFunc
+	proxySelector *proxySelector
+}
+
+func NewClientFactory(proxyConfig ProxyConfig, policy PolicyFunc) (*ClientFactory, error) {
+	proxySelector, err := newProxySelector(proxyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy config: %w", err)
+	}
+
+	return &ClientFactory{
+		policy:        policy,
+		proxySelector: proxySelector,
+	}, nil
+}
+
+// Proxy returns the url of the proxy that is used for requests to the target,
+// or nil if the target is connected to directly.
+func (f *ClientFactory) Proxy(ctx context.Context, target *url.URL) (*url.URL, error) {
+	return f.proxySelector.proxy(ctx, target)
+}
+
+// Client returns a new http client with the provided options.
+func (f *ClientFactory) Client(opts ClientOptions) *http.Client {
+	// Clone http.DefaultTransport (used by http.DefaultClient)
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+
+	tr.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := f.proxySelector.proxy(req.Context(), req.URL)
+		if err != nil || proxyURL == nil {
+			return nil, err
+		}
+
+		policy, err := f.clientPolicy(req.Context(), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		// the proxy resolves the target, refuse targets that resolve to denied addresses locally.
+		if err = policy.CheckProxied(req.Context(), req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+
+		return proxyURL, nil
+	}
+
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	//nolint:gosec // only if explicitly requested
+	tr.TLSClientConfig.InsecureSkipVerify = opts.InsecureSkipVerify
+
+	// create basic net.Dialer (Similar to what is used by http.DefaultTransport)
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	tr.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address '%s': %w", addr, err)
+		}
+
+		// the configured proxies are trusted when the transport connects to them as proxy,
+		// the policy was applied to the actual target already (see Proxy above).
+		if proxyDialFrom(ctx) == addr {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		policy, err := f.clientPolicy(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := policy.Resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		// connect to the validated addresses directly (in order, like the default dialer),
+		// the host name isn't resolved a second time to prevent DNS rebinding.
+		var con net.Conn
+		for _, ip := range ips {
+			con, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return con, nil
+			}
+		}
+
+		return nil, err
+	}
+
+	// the client is similar to http.DefaultClient, just with custom http.Transport
+	return &http.Client{
+		Transport: &proxyDialTransport{Transport: tr, proxySelector: f.proxySelector},
+		Timeout:   opts.Timeout,
+	}
+}
+
+type proxyDialCtxKey struct{}
+
+// proxyDialFrom returns the address of the configured proxy the request is sent to, if any.
+func proxyDialFrom(ctx context.Context) string {
+	addr, _ := ctx.Value(proxyDialCtxKey{}).(string)
+	return addr
+}
+
+// proxyDialTransport marks the requests that are sent via one of the configured proxies,
+// so only the connections to the proxy established for those requests bypass the policy.
+// Any other connection to the address of a proxy (e.g. a webhook targeting the proxy) is subject to the policy.
+type proxyDialTransport struct {
+	*http.Transport
+	proxySelector *proxySelector
+}
+
+func (t *proxyDialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	proxyURL, err := t.proxySelector.proxy(req.Context(), req.URL)
+	if err == nil && proxyURL != nil {
+		if addr := canonicalAddr(proxyURL); t.proxySelector.isConfiguredProxy(addr) {
+			req = req.WithContext(context.WithValue(req.Context(), proxyDialCtxKey{}, addr))
+		}
+	}
+
+	return t.Transport.RoundTrip(req)
+}
+
+// clientPolicy returns the outbound policy adjusted to the options of the client.
+func (f *ClientFactory) clientPolicy(ctx context.Context, opts ClientOptions) (Policy, error) {
+	policy, err := f.policy(ctx)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to get outbound policy: %w", err)
+	}
+
+	policy.AllowLoopback = policy.AllowLoopback || opts.AllowLoopback
+	policy.AllowPrivateNetwork = policy.AllowPrivateNetwork || opts.AllowPrivateNetwork
+
+	return policy, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientProxiedTargetIsResolved(t *testing.T) {
+	lookupIPAddr = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "public.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		case "internal.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.35")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+		case "denied.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("203.0.113.7")}}, nil
+		default:
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+	}
+	t.Cleanup(func() { lookupIPAddr = net.DefaultResolver.LookupIPAddr })
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		proxied.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(proxy.Close)
+
+	deny, err := ParseRules([]string{"203.0.113.0/24"})
+	if err != nil {
+		t.Fatalf("failed to parse rules: %s", err)
+	}
+
+	factory, err := NewClientFactory(ProxyConfig{HTTPProxy: proxy.URL}, func(context.Context) (Policy, error) {
+		return Policy{Deny: deny}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to create client factory: %s", err)
+	}
+	client := factory.Client(ClientOptions{})
+
+	tests := []struct {
+		name    string
+		target  string
+		want    error
+		proxied bool
+	}{
+		{name: "public", target: "http://public.example.com/hook", proxied: true},
+		{name: "private-address", target: "http://internal.example.com/hook", want: ErrPrivateNetworkNotAllowed},
+		{name: "denied-address", target: "http://denied.example.com/hook", want: ErrTargetDenied},
+		{name: "private-ip", target: "http://10.0.0.1/hook", want: ErrPrivateNetworkNotAllowed},
+		// the proxy might be able to resolve hosts that can't be resolved locally.
+		{name: "unresolvable", target: "http://unknown.example.com/hook", proxied: true},
+		// the proxy is only trusted if it's used as proxy, direct requests to it are subject to the policy.
+		{name: "proxy-address", target: proxy.URL + "/hook", want: ErrLoopbackNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := proxied.Load()
+
+			resp, err := client.Get(test.target)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			if test.want == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !errors.Is(err, test.want) {
+				t.Fatalf("want %v, got %v", test.want, err)
+			}
+
+			if got := proxied.Load() > before; got != test.proxied {
+				t.Errorf("want proxied %t, got %t", test.proxied, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+var (
+	// ErrTargetDenied is returned if the target matches a deny rule.
+	ErrTargetDenied             = errors.New("target denied")
+	ErrLoopbackNotAllowed       = errors.New("loopback not allowed")
+	ErrPrivateNetworkNotAllowed = errors.New("private network not allowed")
+)
+
+// lookupIPAddr resolves the addresses of a host, it's replaced in tests.
+var lookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+// Policy defines the targets outbound requests are allowed to connect to.
+// Deny rules take precedence over everything else,
+// allow rules exempt matching targets from the loopback and private network restrictions.
+type Policy struct {
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
+	Allow               []Rule
+	Deny                []Rule
+}
+
+// CheckHost validates the host of a target without resolving it.
+// It's meant to give users early feedback, the resolved addresses are validated when connecting.
+func (p Policy) CheckHost(host string) error {
+	host = normalizeHost(host)
+
+	allowed, err := p.checkHostName(host)
+	if err != nil {
+		return err
+	}
+
+	if host == "localhost" {
+		return p.checkIP(host, net.IPv4(127, 0, 0, 1), allowed)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return p.checkIP(host, ip, allowed)
+	}
+
+	return nil
+}
+
+// CheckProxied validates the host of a target that is connected to via a proxy.
+// The proxy resolves the target on its own, hence the host is resolved locally as well to refuse targets
+// with addresses that aren't permitted. Hosts that can't be resolved locally are only validated by name,
+// as the proxy might be using different name servers.
+// NOTE: This is best effort, the proxy could still resolve the host to different addresses.
+func (p Policy) CheckProxied(ctx context.Context, host string) error {
+	_, err := p.Resolve(ctx, host)
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return p.CheckHost(host)
+	}
+
+	return err
+}
+
+// Resolve resolves the host and returns its addresses after validating all of them against the policy.
+// IMPORTANT: Connect to the returned addresses instead of the host name,
+// resolving the host name again could return different addresses (DNS rebinding).
+func (p Policy) Resolve(ctx context.Context, host string) ([]net.IP, error) {
+	host = normalizeHost(host)
+
+	allowed, err := p.checkHostName(host)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := lookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		ips = make([]net.IP, len(addrs))
+		for i := range addrs {
+			ips[i] = addrs[i].IP
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	for _, ip := range ips {
+		if err = p.checkIP(host, ip, allowed); err != nil {
+			return nil, err
+		}
+	}
+
+	return ips, nil
+}
+
+// checkHostName checks the host name against the host name rules.
+// It returns true if the host is explicitly allowed.
+func (p Policy) checkHostName(host string) (bool, error) {
+	for _, rule := range p.Deny {
+		if rule.matchesHost(host) {
+			return false, fmt.Errorf("%w: host '%s' matches rule '%s'", ErrTargetDenied, host, rule)
+		}
+	}
+
+	for _, rule := range p.Allow {
+		if rule.matchesHost(host) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// checkIP checks an address of the host against the address rules and the network restrictions.
+func (p Policy) checkIP(host string, ip net.IP, allowed bool) error {
+	for _, rule := range p.Deny {
+		if rule.matchesIP(ip) {
+			return fmt.Errorf("%w: address %s of '%s' matches rule '%s'", ErrTargetDenied, ip, host, rule)
+		}
+	}
+
+	if allowed {
+		return nil
+	}
+
+	for _, rule := range p.Allow {
+		if rule.matchesIP(ip) {
+			return nil
+		}
+	}
+
+	if !p.AllowLoopback && isLoopback(ip) {
+		return fmt.Errorf("%w: '%s' resolved to %s", ErrLoopbackNotAllowed, host, ip)
+	}
+
+	if !p.AllowPrivateNetwork && isPrivate(ip) {
+		return fmt.Errorf("%w: '%s' resolved to %s", ErrPrivateNetworkNotAllowed, host, ip)
+	}
+
+	return nil
+}
+
+// isLoopback returns true for loopback addresses, the unspecified address is treated the same
+// as connecting to it ends up on the local host.
+func isLoopback(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsUnspecified()
+}
+
+// isPrivate returns true for private network addresses, including link-local addresses
+// which are used by the metadata endpoints of cloud providers (e.g. 169.254.169.254).
+func isPrivate(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{raw: "10.1.2.3/8", want: "10.0.0.0/8"},
+		{raw: " 192.168.1.1 ", want: "192.168.1.1"},
+		{raw: "fd00::/8", want: "fd00::/8"},
+		{raw: "Git.Example.com.", want: "git.example.com"},
+		{raw: "*.example.com", want: "*.example.com"},
+		{raw: "", wantErr: true},
+		{raw: "10.0.0.0/33", wantErr: true},
+		{raw: "*", wantErr: true},
+		{raw: "exa mple.com", wantErr: true},
+		{raw: "http://example.com", wantErr: true},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseRule(test.raw)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("rule %q: expected an error, got %q", test.raw, rule)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("rule %q: unexpected error: %s", test.raw, err)
+			continue
+		}
+
+		if rule.String() != test.want {
+			t.Errorf("rule %q: want %q, got %q", test.raw, test.want, rule)
+		}
+	}
+}
+
+func TestPolicyCheckHost(t *testing.T) {
+	mustParse := func(raw ...string) []Rule {
+		rules, err := ParseRules(raw)
+		if err != nil {
+			t.Fatalf("failed to parse rules: %s", err)
+		}
+		return rules
+	}
+
+	policy := Policy{
+		Allow: mustParse("10.1.0.0/16", "*.corp.example.com"),
+		Deny:  mustParse("203.0.113.0/24", "evil.example.com", "secret.corp.example.com"),
+	}
+
+	tests := []struct {
+		host string
+		want error
+	}{
+		{host: "example.com", want: nil},
+		{host: "8.8.8.8", want: nil},
+		{host: "localhost", want: ErrLoopbackNotAllowed},
+		{host: "127.0.0.1", want: ErrLoopbackNotAllowed},
+		{host: "0.0.0.0", want: ErrLoopbackNotAllowed},
+		{host: "::ffff:127.0.0.1", want: ErrLoopbackNotAllowed},
+		{host: "10.0.0.1", want: ErrPrivateNetworkNotAllowed},
+		{host: "169.254.169.254", want: ErrPrivateNetworkNotAllowed},
+		{host: "10.1.2.3", want: nil},
+		{host: "git.corp.example.com", want: nil},
+		{host: "corp.example.com", want: nil},
+		{host: "203.0.113.7", want: ErrTargetDenied},
+		{host: "EVIL.example.com.", want: ErrTargetDenied},
+		{host: "secret.corp.example.com", want: ErrTargetDenied},
+	}
+
+	for _, test := range tests {
+		err := policy.CheckHost(test.host)
+		if !errors.Is(err, test.want) || (test.want == nil && err != nil) {
+			t.Errorf("host %q: want %v, got %v", test.host, test.want, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig defines the proxy used for outbound requests.
+// If none of the values are set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+type proxyCtxKey struct{}
+
+// WithProxy returns a copy of the context that makes the clients of the ClientFactory
+// send the request via the provided proxy, regardless of the proxy configuration.
+func WithProxy(ctx context.Context, proxyURL *url.URL) context.Context {
+	return context.WithValue(ctx, proxyCtxKey{}, proxyURL)
+}
+
+func proxyFrom(ctx context.Context) *url.URL {
+	proxyURL, _ := ctx.Value(proxyCtxKey{}).(*url.URL)
+	return proxyURL
+}
+
+// ParseProxyURL parses and validates the URL of a proxy.
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme '%s', supported are http, https and socks5",
+			proxyURL.Scheme)
+	}
+
+	if proxyURL.Hostname() == "" {
+		return nil, fmt.Errorf("proxy url '%s' is missing the host", rawURL)
+	}
+
+	return proxyURL, nil
+}
+
+// proxySelector selects the proxy of a request based on the proxy configuration.
+type proxySelector struct {
+	proxyFunc func(*url.URL) (*url.URL, error)
+
+	// addrs contains the addresses of the configured proxies.
+	addrs map[string]struct{}
+}
+
+func newProxySelector(config ProxyConfig) (*proxySelector, error) {
+	cfg := &httpproxy.Config{
+		HTTPProxy:  config.HTTPProxy,
+		HTTPSProxy: config.HTTPSProxy,
+		NoProxy:    config.NoProxy,
+	}
+	if cfg.HTTPProxy == "" && cfg.HTTPSProxy == "" && cfg.NoProxy == "" {
+		cfg = httpproxy.FromEnvironment()
+	}
+
+	s := &proxySelector{
+		proxyFunc: cfg.ProxyFunc(),
+		addrs:     map[string]struct{}{},
+	}
+
+	for _, raw := range []string{cfg.HTTPProxy, cfg.HTTPSProxy} {
+		if raw == "" {
+			continue
+		}
+
+		// same as httpproxy, proxies configured without scheme are http proxies
+		if !strings.Contains(raw, "://") {
+			raw = "http://" + raw
+		}
+
+		proxyURL, err := ParseProxyURL(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		s.addrs[canonicalAddr(proxyURL)] = struct{}{}
+	}
+
+	return s, nil
+}
+
+// proxy returns the proxy to use for the target, or nil if the target should be connected to directly.
+func (s *proxySelector) proxy(ctx context.Context, target *url.URL) (*url.URL, error) {
+	if proxyURL := proxyFrom(ctx); proxyURL != nil {
+		return proxyURL, nil
+	}
+
+	return s.proxyFunc(target)
+}
+
+// isConfiguredProxy returns true if the address is the address of one of the configured proxies.
+func (s *proxySelector) isConfiguredProxy(addr string) bool {
+	_, ok := s.addrs[addr]
+	return ok
+}
+
+// canonicalAddr returns the host:port of the url, using the default port of the scheme if none is provided.
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
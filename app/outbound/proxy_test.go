@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestProxySelector(t *testing.T) {
+	selector, err := newProxySelector(ProxyConfig{
+		HTTPProxy:  "proxy.example.com:3128",
+		HTTPSProxy: "https://secure-proxy.example.com",
+		NoProxy:    "internal.example.com,10.0.0.0/8",
+	})
+	if err != nil {
+		t.Fatalf("failed to create proxy selector: %s", err)
+	}
+
+	override, _ := url.Parse("socks5://override.example.com:1080")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		target string
+		want   string
+	}{
+		{name: "http", ctx: context.Background(),
+			target: "http://git.example.com/repo", want: "http://proxy.example.com:3128"},
+		{name: "https", ctx: context.Background(),
+			target: "https://git.example.com/repo", want: "https://secure-proxy.example.com"},
+		{name: "no proxy host", ctx: context.Background(),
+			target: "https://internal.example.com/hook", want: ""},
+		{name: "no proxy cidr", ctx: context.Background(),
+			target: "https://10.1.2.3/hook", want: ""},
+		{name: "override", ctx: WithProxy(context.Background(), override),
+			target: "https://internal.example.com/hook", want: "socks5://override.example.com:1080"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, _ := url.Parse(test.target)
+
+			proxyURL, err := selector.proxy(test.ctx, target)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got := ""
+			if proxyURL != nil {
+				got = proxyURL.String()
+			}
+
+			if got != test.want {
+				t.Errorf("want proxy %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	for _, addr := range []string{"proxy.example.com:3128", "secure-proxy.example.com:443"} {
+		if !selector.isConfiguredProxy(addr) {
+			t.Errorf("expected %s to be a configured proxy", addr)
+		}
+	}
+	if selector.isConfiguredProxy("override.example.com:1080") {
+		t.Error("expected override.example.com:1080 not to be a configured proxy")
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	valid := []string{"http://proxy:3128", "https://proxy.example.com", "socks5://10.0.0.1:1080"}
+	for _, raw := range valid {
+		if _, err := ParseProxyURL(raw); err != nil {
+			t.Errorf("proxy %q: unexpected error: %s", raw, err)
+		}
+	}
+
+	invalid := []string{"", "proxy:3128", "ftp://proxy.example.com", "http://", "http://%zz"}
+	for _, raw := range invalid {
+		if _, err := ParseProxyURL(raw); err == nil {
+			t.Errorf("proxy %q: expected an error", raw)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	// wildcardPrefix is the prefix of host name rules that match any subdomain.
+	wildcardPrefix = "*."
+
+	// hostNameMaxLength is the max length of a host name.
+	hostNameMaxLength = 253
+)
+
+var hostNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// Rule is an allow or deny rule of the outbound policy.
+// Supported formats are IP addresses ("10.0.0.1"), CIDR blocks ("10.0.0.0/8"),
+// host names ("git.example.com") and wildcard host names that match any subdomain ("*.example.com").
+type Rule struct {
+	raw      string
+	network  *net.IPNet
+	host     string
+	wildcard bool
+}
+
+// ParseRule parses a single rule.
+func ParseRule(raw string) (Rule, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" {
+		return Rule{}, errors.New("rule can't be empty")
+	}
+
+	if strings.Contains(s, "/") {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid CIDR block '%s'", raw)
+		}
+
+		return Rule{raw: network.String(), network: network}, nil
+	}
+
+	if ip := net.ParseIP(s); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = 8 * net.IPv4len
+		}
+
+		return Rule{raw: ip.String(), network: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}}, nil
+	}
+
+	wildcard := strings.HasPrefix(s, wildcardPrefix)
+	host := strings.TrimSuffix(strings.TrimPrefix(s, wildcardPrefix), ".")
+	if len(host) > hostNameMaxLength || !hostNameRegex.MatchString(host) {
+		return Rule{}, fmt.Errorf("invalid host name '%s'", raw)
+	}
+
+	if wildcard {
+		return Rule{raw: wildcardPrefix + host, host: host, wildcard: true}, nil
+	}
+
+	return Rule{raw: host, host: host}, nil
+}
+
+// ParseRules parses a list of rules.
+func ParseRules(raw []string) ([]Rule, error) {
+	rules := make([]Rule, len(raw))
+	for i := range raw {
+		rule, err := ParseRule(raw[i])
+		if err != nil {
+			return nil, err
+		}
+
+		rules[i] = rule
+	}
+
+	return rules, nil
+}
+
+// String returns the canonical form of the rule.
+func (r Rule) String() string {
+	return r.raw
+}
+
+// matchesHost returns true if the rule is a host name rule matching the (normalized) host.
+func (r Rule) matchesHost(host string) bool {
+	if r.host == "" {
+		return false
+	}
+
+	if r.wildcard {
+		return strings.HasSuffix(host, "."+r.host)
+	}
+
+	return host == r.host
+}
+
+// matchesIP returns true if the rule is an address rule containing the ip.
+func (r Rule) matchesIP(ip net.IP) bool {
+	return r.network != nil && r.network.Contains(ip)
+}
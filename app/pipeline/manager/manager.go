@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secret"
+	"github.com/harness/gitness/app/services/drain"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
@@ -34,6 +37,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -140,12 +144,15 @@ type Manager struct {
 	Repos     store.RepoStore
 	Scheduler scheduler.Scheduler
 	Secrets   store.SecretStore
+	Resolver  secret.Resolver
 	// Status  store.StatusService
 	Stages store.StageStore
 	Steps  store.StepStore
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	Reporter *pipelineevents.Reporter
+	Drainer  *drain.Service
 }
 
 func New(
@@ -161,9 +168,12 @@ func New(
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	secretStore store.SecretStore,
+	secretResolver secret.Resolver,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter,
+	drainer *drain.Service,
 ) *Manager {
 	return &Manager{
 		Config:      config,
@@ -178,9 +188,12 @@ func New(
 		Repos:       repoStore,
 		Scheduler:   scheduler,
 		Secrets:     secretStore,
+		Resolver:    secretResolver,
 		Stages:      stageStore,
 		Steps:       stepStore,
 		Users:       userStore,
+		Reporter:    reporter,
+		Drainer:     drainer,
 	}
 }
 
@@ -196,6 +209,13 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Logger()
 	log.Debug().Msg("manager: request queue item")
 
+	// don't dispatch new stages while the instance is draining (the runner keeps polling until canceled).
+	if m.Drainer.Draining() {
+		log.Debug().Msg("manager: instance is draining")
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
 	stage, err := m.Scheduler.Request(ctx, scheduler.Filter{
 		Kind:    args.Kind,
 		Type:    args.Type,
@@ -310,14 +330,27 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		Str("repo", repo.GetGitUID()).
 		Logger()
 
-	// TODO: Currently we fetch all the secrets from the same space.
-	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	// Fetch the secrets of the parent space together with the inheritable secrets of its ancestors.
+	secrets, err := m.Secrets.ListAllInherited(noContext, repo.ParentID)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: cannot list secrets")
 		return nil, err
 	}
 
+	// audit which secrets (and from which space) are exposed to the execution.
+	secretAudit := zerolog.Dict()
+	for _, s := range secrets {
+		secretAudit.Int64(s.UID, s.SpaceID)
+	}
+	log.Info().Dict("secrets", secretAudit).Msg("manager: secrets provided to stage")
+
+	// Resolve the values of the secrets, fetching them from external secret managers where required.
+	secrets, err = m.Resolver.Resolve(ctx, secrets)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot resolve secrets")
+		return nil, err
+	}
+
 	// Fetch contents of YAML from the execution ref at the pipeline config path.
 	file, err := m.FileService.Get(noContext, repo, pipeline.ConfigPath, execution.After)
 	if err != nil {
@@ -448,6 +481,7 @@ func (m *Manager) AfterStage(ctx context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+		Reporter:    m.Reporter,
 	}
 	return t.do(noContext, stage)
 }
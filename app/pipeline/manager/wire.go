@@ -15,8 +15,11 @@
 package manager
 
 import (
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secret"
+	"github.com/harness/gitness/app/services/drain"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -47,11 +50,15 @@ func ProvideExecutionManager(
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	secretStore store.SecretStore,
+	secretResolver secret.Resolver,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
-	userStore store.PrincipalStore) ExecutionManager {
+	userStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter,
+	drainer *drain.Service) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, logStore,
-		logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore)
+		logStream, checkStore, repoStore, scheduler, secretStore, secretResolver, stageStore, stepStore, userStore,
+		reporter, drainer)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+type awsProvider struct {
+	client *secretsmanager.SecretsManager
+}
+
+// NewAWSProvider returns a provider that reads secrets from AWS Secrets Manager.
+// The reference of the secret is the name or ARN of the secret followed by an optional key,
+// e.g. "ci/deploy#token". Credentials are taken from the default AWS credentials chain.
+func NewAWSProvider(region, endpoint string) (Provider, error) {
+	config := &aws.Config{}
+	if region != "" {
+		config.Region = aws.String(region)
+	}
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws session: %w", err)
+	}
+
+	return &awsProvider{
+		client: secretsmanager.New(sess),
+	}, nil
+}
+
+func (p *awsProvider) Fetch(ctx context.Context, ref string, maxTTL time.Duration) (*Lease, error) {
+	id, key := splitRef(ref)
+	if id == "" {
+		return nil, fmt.Errorf("aws secret reference is empty")
+	}
+
+	out, err := p.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret from aws secrets manager: %w", err)
+	}
+
+	value, err := extractKey(aws.StringValue(out.SecretString), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLease(value, maxTTL, maxTTL), nil
+}
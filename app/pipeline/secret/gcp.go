@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+type gcpProvider struct {
+	service *secretmanager.Service
+}
+
+// NewGCPProvider returns a provider that reads secrets from GCP Secret Manager.
+// The reference of the secret is the resource name of the secret (version) followed by an optional key,
+// e.g. "projects/p/secrets/deploy#token". If no version is provided the latest version is used.
+// Credentials are taken from the application default credentials.
+func NewGCPProvider(ctx context.Context) (Provider, error) {
+	service, err := secretmanager.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcp secret manager client: %w", err)
+	}
+
+	return &gcpProvider{
+		service: service,
+	}, nil
+}
+
+func (p *gcpProvider) Fetch(ctx context.Context, ref string, maxTTL time.Duration) (*Lease, error) {
+	name, key := splitRef(ref)
+	if name == "" {
+		return nil, fmt.Errorf("gcp secret reference is empty")
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	out, err := p.service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret from gcp secret manager: %w", err)
+	}
+
+	var raw []byte
+	if out.Payload != nil {
+		raw, err = base64.StdEncoding.DecodeString(out.Payload.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gcp secret payload: %w", err)
+		}
+	}
+
+	value, err := extractKey(string(raw), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLease(value, maxTTL, maxTTL), nil
+}
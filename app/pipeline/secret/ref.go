@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// splitRef splits the reference of an external secret into the path and the (optional) key.
+// The key is used to select a single field from secrets stored as JSON documents,
+// e.g. "secret/data/ci#token".
+func splitRef(ref string) (string, string) {
+	path, key, _ := strings.Cut(strings.TrimSpace(ref), "#")
+	return path, key
+}
+
+// extractKey returns the value of a single field of a JSON document.
+// If the key is empty, the raw value is returned.
+func extractKey(raw string, key string) (string, error) {
+	if key == "" {
+		return raw, nil
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return "", fmt.Errorf("secret isn't a JSON document, can't select key %s: %w", key, err)
+	}
+
+	return fieldValue(fields, key)
+}
+
+func fieldValue(fields map[string]interface{}, key string) (string, error) {
+	v, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret doesn't contain the key %s", key)
+	}
+
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal value of the key %s: %w", key, err)
+	}
+
+	return string(data), nil
+}
+
+// newLease returns a lease for the value that is valid for the provided duration, capped by maxTTL.
+func newLease(value string, ttl, maxTTL time.Duration) *Lease {
+	if ttl <= 0 || ttl > maxTTL {
+		ttl = maxTTL
+	}
+
+	return &Lease{
+		Value:   value,
+		Expires: time.Now().Add(ttl),
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import "testing"
+
+func TestExtractKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "no key", raw: "plain", key: "", want: "plain"},
+		{name: "string field", raw: `{"token":"abc"}`, key: "token", want: "abc"},
+		{name: "number field", raw: `{"port":5432}`, key: "port", want: "5432"},
+		{name: "missing field", raw: `{"token":"abc"}`, key: "user", wantErr: true},
+		{name: "not json", raw: "plain", key: "token", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := extractKey(test.raw, test.key)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	// Lease is a secret value fetched from an external secret manager
+	// that can be reused until it expires.
+	Lease struct {
+		Value   string
+		Expires time.Time
+	}
+
+	// Provider fetches secret values from an external secret manager.
+	Provider interface {
+		// Fetch returns the value of the secret with the provided reference.
+		// maxTTL is the longest duration the returned lease is allowed to be valid for.
+		Fetch(ctx context.Context, ref string, maxTTL time.Duration) (*Lease, error)
+	}
+
+	// Resolver resolves the values of pipeline secrets at execution time.
+	Resolver interface {
+		// Resolve returns copies of the secrets with the plaintext values,
+		// fetching the values of the secrets stored in external secret managers.
+		Resolve(ctx context.Context, secrets []*types.Secret) ([]*types.Secret, error)
+	}
+)
+
+type resolver struct {
+	encrypter encrypt.Encrypter
+	providers map[enum.SecretProvider]Provider
+	leaseTTL  time.Duration
+
+	leasesMx sync.Mutex
+	leases   map[leaseKey]*Lease
+}
+
+type leaseKey struct {
+	provider enum.SecretProvider
+	ref      string
+}
+
+func NewResolver(
+	encrypter encrypt.Encrypter,
+	providers map[enum.SecretProvider]Provider,
+	leaseTTL time.Duration,
+) Resolver {
+	return &resolver{
+		encrypter: encrypter,
+		providers: providers,
+		leaseTTL:  leaseTTL,
+		leases:    make(map[leaseKey]*Lease),
+	}
+}
+
+func (r *resolver) Resolve(ctx context.Context, secrets []*types.Secret) ([]*types.Secret, error) {
+	resolved := make([]*types.Secret, len(secrets))
+	for i, secret := range secrets {
+		data, err := r.encrypter.Decrypt([]byte(secret.Data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret %s: %w", secret.UID, err)
+		}
+
+		if secret.Provider != "" && secret.Provider != enum.SecretProviderGitness {
+			data, err = r.fetch(ctx, secret.Provider, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch secret %s from %s: %w", secret.UID, secret.Provider, err)
+			}
+		}
+
+		s := *secret
+		s.Data = data
+		resolved[i] = &s
+	}
+
+	return resolved, nil
+}
+
+func (r *resolver) fetch(ctx context.Context, providerType enum.SecretProvider, ref string) (string, error) {
+	provider, ok := r.providers[providerType]
+	if !ok {
+		return "", fmt.Errorf("secret provider %s is not configured", providerType)
+	}
+
+	key := leaseKey{provider: providerType, ref: ref}
+	now := time.Now()
+
+	r.leasesMx.Lock()
+	lease, ok := r.leases[key]
+	r.leasesMx.Unlock()
+
+	if ok && now.Before(lease.Expires) {
+		return lease.Value, nil
+	}
+
+	lease, err := provider.Fetch(ctx, ref, r.leaseTTL)
+	if err != nil {
+		return "", err
+	}
+
+	r.leasesMx.Lock()
+	r.leases[key] = lease
+	r.purgeExpired(now)
+	r.leasesMx.Unlock()
+
+	return lease.Value, nil
+}
+
+// purgeExpired removes all expired leases. Must be called with the leases mutex acquired.
+func (r *resolver) purgeExpired(now time.Time) {
+	for key, lease := range r.leases {
+		if !now.Before(lease.Expires) {
+			delete(r.leases, key)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// vaultDefaultKey is the key used when no key is selected in the reference of a vault secret.
+const vaultDefaultKey = "value"
+
+type vaultProvider struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+}
+
+// NewVaultProvider returns a provider that reads secrets from HashiCorp Vault using the provided client.
+// The reference of the secret is the path of the secret followed by an optional key,
+// e.g. "secret/data/ci#token". Both KV v1 and KV v2 secret engines are supported.
+func NewVaultProvider(client *http.Client, address, token, namespace string) Provider {
+	return &vaultProvider{
+		client:    client,
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		namespace: namespace,
+	}
+}
+
+type vaultResponse struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+}
+
+func (p *vaultProvider) Fetch(ctx context.Context, ref string, maxTTL time.Duration) (*Lease, error) {
+	path, key := splitRef(ref)
+	if path == "" {
+		return nil, fmt.Errorf("vault secret reference is empty")
+	}
+	if key == "" {
+		key = vaultDefaultKey
+	}
+
+	url := p.address + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret from vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned unexpected status code %d for %s", resp.StatusCode, path)
+	}
+
+	out := vaultResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	// KV v2 secret engines nest the secret fields in an inner data object.
+	fields := out.Data
+	if inner, ok := fields["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := fields["metadata"]; hasMetadata {
+			fields = inner
+		}
+	}
+
+	value, err := fieldValue(fields, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLease(value, time.Duration(out.LeaseDuration)*time.Second, maxTTL), nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideResolver,
+)
+
+// ProvideResolver provides a secret resolver with all configured external secret managers.
+func ProvideResolver(
+	config *types.Config,
+	encrypter encrypt.Encrypter,
+	clientFactory *outbound.ClientFactory,
+) (Resolver, error) {
+	providers := map[enum.SecretProvider]Provider{}
+
+	if config.Secrets.Vault.Address != "" {
+		// vault is configured by the operator and usually runs in the private network.
+		client := clientFactory.Client(outbound.ClientOptions{
+			Timeout:             10 * time.Second,
+			AllowLoopback:       true,
+			AllowPrivateNetwork: true,
+		})
+		providers[enum.SecretProviderVault] = NewVaultProvider(
+			client,
+			config.Secrets.Vault.Address,
+			config.Secrets.Vault.Token,
+			config.Secrets.Vault.Namespace,
+		)
+	}
+
+	if config.Secrets.AWS.Enabled {
+		provider, err := NewAWSProvider(config.Secrets.AWS.Region, config.Secrets.AWS.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		providers[enum.SecretProviderAWS] = provider
+	}
+
+	if config.Secrets.GCP.Enabled {
+		provider, err := NewGCPProvider(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		providers[enum.SecretProviderGCP] = provider
+	}
+
+	return NewResolver(encrypter, providers, config.Secrets.LeaseTTL), nil
+}
@@ -18,16 +18,28 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/accessrequest"
+	"github.com/harness/gitness/app/api/controller/attachment"
+	"github.com/harness/gitness/app/api/controller/attestation"
+	"github.com/harness/gitness/app/api/controller/avatar"
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/app/api/controller/chatintegration"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/filelock"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/insights"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/markdown"
+	"github.com/harness/gitness/app/api/controller/oauth"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/sbom"
+	"github.com/harness/gitness/app/api/controller/scan"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -35,19 +47,32 @@ import (
 	"github.com/harness/gitness/app/api/controller/template"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
+	handleraccessrequest "github.com/harness/gitness/app/api/handler/accessrequest"
 	"github.com/harness/gitness/app/api/handler/account"
+	handlerattachment "github.com/harness/gitness/app/api/handler/attachment"
+	handlerattestation "github.com/harness/gitness/app/api/handler/attestation"
+	handleravatar "github.com/harness/gitness/app/api/handler/avatar"
+	handlerbackup "github.com/harness/gitness/app/api/handler/backup"
+	handlerchatintegration "github.com/harness/gitness/app/api/handler/chatintegration"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
+	handlerfilelock "github.com/harness/gitness/app/api/handler/filelock"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
+	handlerinsights "github.com/harness/gitness/app/api/handler/insights"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
+	handlermarkdown "github.com/harness/gitness/app/api/handler/markdown"
+	handleroauth "github.com/harness/gitness/app/api/handler/oauth"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlersbom "github.com/harness/gitness/app/api/handler/sbom"
+	handlerscan "github.com/harness/gitness/app/api/handler/scan"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -55,16 +80,25 @@ import (
 	handlertemplate "github.com/harness/gitness/app/api/handler/template"
 	handlertrigger "github.com/harness/gitness/app/api/handler/trigger"
 	handleruser "github.com/harness/gitness/app/api/handler/user"
+	handlerusergroup "github.com/harness/gitness/app/api/handler/usergroup"
 	"github.com/harness/gitness/app/api/handler/users"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewaredrain "github.com/harness/gitness/app/api/middleware/drain"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/maintenance"
+	"github.com/harness/gitness/app/api/middleware/metrics"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	"github.com/harness/gitness/app/api/middleware/replica"
+	"github.com/harness/gitness/app/api/middleware/tracing"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/githook"
+	"github.com/harness/gitness/loglevel"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -83,6 +117,10 @@ var (
 	// terminatedPathPrefixesAPI is the list of prefixes that will require resolving terminated paths.
 	terminatedPathPrefixesAPI = []string{"/v1/spaces/", "/v1/repos/",
 		"/v1/secrets/", "/v1/connectors", "/v1/templates"}
+
+	// maintenanceExemptPrefixesAPI is the list of prefixes of unsafe requests that are served in maintenance mode.
+	maintenanceExemptPrefixesAPI = []string{"/v1/admin/", "/v1/internal/", "/v1/login", "/v1/logout",
+		"/v1/oauth/token", "/v1/markdown"}
 )
 
 // NewAPIHandler returns a new APIHandler.
@@ -101,12 +139,27 @@ func NewAPIHandler(
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
 	webhookCtrl *webhook.Controller,
+	chatIntegrationCtrl *chatintegration.Controller,
+	accessRequestCtrl *accessrequest.Controller,
+	insightsCtrl *insights.Controller,
+	scanCtrl *scan.Controller,
+	sbomCtrl *sbom.Controller,
+	attachmentCtrl *attachment.Controller,
+	avatarCtrl *avatar.Controller,
+	attestationCtrl *attestation.Controller,
+	lockCtrl *filelock.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
+	userGroupCtrl *usergroup.Controller,
 	userCtrl *user.Controller,
 	principalCtrl principal.Controller,
 	checkCtrl *check.Controller,
 	sysCtrl *system.Controller,
+	backupCtrl *backup.Controller,
+	oauthCtrl *oauth.Controller,
+	markdownCtrl *markdown.Controller,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -115,7 +168,12 @@ func NewAPIHandler(
 	r.Use(middleware.NoCache)
 	r.Use(middleware.Recoverer)
 
+	// trace requests and record request count and latency per route.
+	r.Use(tracing.Handler("api"))
+	r.Use(metrics.Handler())
+
 	// configure logging middleware.
+	r.Use(logging.HLogRouteHandler(loglevel.SubsystemAPI, r))
 	r.Use(hlog.URLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
@@ -125,13 +183,24 @@ func NewAPIHandler(
 	// configure cors middleware
 	r.Use(corsHandler(config))
 
+	// serve read-only requests from the database read replicas (if any).
+	if len(config.Database.ReplicaDatasources) > 0 {
+		r.Use(replica.Handler(config.Database.ReplicaStickiness))
+	}
+
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
+	r.Use(logging.HLogPrincipalHandler())
+
+	// reject changes while the instance is in maintenance mode.
+	r.Use(maintenance.Handler(instanceSettings, maintenanceExemptPrefixesAPI...))
 
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, config, repoCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
-			webhookCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl)
+			webhookCtrl, chatIntegrationCtrl, accessRequestCtrl, insightsCtrl, scanCtrl, sbomCtrl, attachmentCtrl, avatarCtrl,
+			attestationCtrl, lockCtrl, githookCtrl, saCtrl, userGroupCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, backupCtrl, oauthCtrl,
+			markdownCtrl, drainer)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -165,30 +234,67 @@ func setupRoutesV1(r chi.Router,
 	spaceCtrl *space.Controller,
 	pullreqCtrl *pullreq.Controller,
 	webhookCtrl *webhook.Controller,
+	chatIntegrationCtrl *chatintegration.Controller,
+	accessRequestCtrl *accessrequest.Controller,
+	insightsCtrl *insights.Controller,
+	scanCtrl *scan.Controller,
+	sbomCtrl *sbom.Controller,
+	attachmentCtrl *attachment.Controller,
+	avatarCtrl *avatar.Controller,
+	attestationCtrl *attestation.Controller,
+	lockCtrl *filelock.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
+	userGroupCtrl *usergroup.Controller,
 	userCtrl *user.Controller,
 	principalCtrl principal.Controller,
 	checkCtrl *check.Controller,
 	sysCtrl *system.Controller,
+	backupCtrl *backup.Controller,
+	oauthCtrl *oauth.Controller,
+	markdownCtrl *markdown.Controller,
+	drainer *drain.Service,
 ) {
-	setupSpaces(r, spaceCtrl)
-	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, spaceCtrl, chatIntegrationCtrl, accessRequestCtrl, insightsCtrl, userGroupCtrl, oauthCtrl, avatarCtrl)
+	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl,
+		chatIntegrationCtrl, accessRequestCtrl, insightsCtrl, scanCtrl, sbomCtrl, attachmentCtrl, attestationCtrl, lockCtrl,
+		checkCtrl)
+	setupTopics(r, repoCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, userCtrl, oauthCtrl, avatarCtrl)
 	setupServiceAccounts(r, saCtrl)
-	setupPrincipals(r, principalCtrl)
-	setupInternal(r, githookCtrl)
-	setupAdmin(r, userCtrl)
+	setupPrincipals(r, principalCtrl, avatarCtrl)
+	setupUsers(r, userCtrl)
+	setupInternal(r, githookCtrl, drainer)
+	setupAdmin(r, userCtrl, sysCtrl, backupCtrl)
 	setupAccount(r, userCtrl, sysCtrl, config)
 	setupSystem(r, sysCtrl)
 	setupResources(r)
+	setupWebhookSchemas(r, webhookCtrl)
 	setupPlugins(r, pluginCtrl)
+	setupOAuth(r, oauthCtrl)
+	setupMarkdown(r, markdownCtrl)
 }
 
-func setupSpaces(r chi.Router, spaceCtrl *space.Controller) {
+func setupTopics(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/topics", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleListTopicSummaries(repoCtrl))
+		r.Get(fmt.Sprintf("/{%s}/repos", request.PathParamTopic), handlerrepo.HandleListReposByTopic(repoCtrl))
+	})
+}
+
+func setupSpaces(
+	r chi.Router,
+	spaceCtrl *space.Controller,
+	chatIntegrationCtrl *chatintegration.Controller,
+	accessRequestCtrl *accessrequest.Controller,
+	insightsCtrl *insights.Controller,
+	userGroupCtrl *usergroup.Controller,
+	oauthCtrl *oauth.Controller,
+	avatarCtrl *avatar.Controller,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -203,15 +309,39 @@ func setupSpaces(r chi.Router, spaceCtrl *space.Controller) {
 			r.Get("/events", handlerspace.HandleEvents(spaceCtrl))
 
 			r.Post("/move", handlerspace.HandleMove(spaceCtrl))
+			r.Post("/restore", handlerspace.HandleRestore(spaceCtrl))
 			r.Get("/spaces", handlerspace.HandleListSpaces(spaceCtrl))
 			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
+			r.Get("/tree", handlerspace.HandleTree(spaceCtrl))
+			r.Get("/trash", handlerspace.HandleListTrash(spaceCtrl))
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
+			r.Get("/secrets/inherited", handlerspace.HandleListInheritedSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
 			r.Get("/templates", handlerspace.HandleListTemplates(spaceCtrl))
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 
+			r.Route("/avatar", func(r chi.Router) {
+				r.Get("/", handleravatar.HandleFindSpace(avatarCtrl))
+				r.Put("/", handleravatar.HandleUploadSpace(avatarCtrl))
+				r.Delete("/", handleravatar.HandleDeleteSpace(avatarCtrl))
+			})
+
+			r.Route("/quota", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleQuotaReport(spaceCtrl))
+				r.Put("/", handlerspace.HandleUpdateQuota(spaceCtrl))
+				r.Delete("/", handlerspace.HandleDeleteQuota(spaceCtrl))
+			})
+
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleListSettings(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamSettingKey), func(r chi.Router) {
+					r.Put("/", handlerspace.HandleUpdateSetting(spaceCtrl))
+					r.Delete("/", handlerspace.HandleDeleteSetting(spaceCtrl))
+				})
+			})
+
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
@@ -220,6 +350,32 @@ func setupSpaces(r chi.Router, spaceCtrl *space.Controller) {
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			setupChatIntegrations(r, chatIntegrationCtrl, enum.WebhookParentSpace)
+			setupAccessRequests(r, accessRequestCtrl, enum.AccessRequestParentSpace)
+			r.Get("/insights", handlerinsights.HandleFind(insightsCtrl, enum.WebhookParentSpace))
+			r.Get("/insights/pullreq-aging", handlerinsights.HandlePullReqAging(insightsCtrl))
+			r.Get("/oauth-integrations", handleroauth.HandleListSpaceIntegrations(oauthCtrl))
+			setupUserGroups(r, userGroupCtrl)
+		})
+	})
+}
+
+func setupUserGroups(r chi.Router, userGroupCtrl *usergroup.Controller) {
+	r.Route("/usergroups", func(r chi.Router) {
+		r.Post("/", handlerusergroup.HandleCreate(userGroupCtrl))
+		r.Get("/", handlerusergroup.HandleList(userGroupCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupUID), func(r chi.Router) {
+			r.Get("/", handlerusergroup.HandleFind(userGroupCtrl))
+			r.Patch("/", handlerusergroup.HandleUpdate(userGroupCtrl))
+			r.Delete("/", handlerusergroup.HandleDelete(userGroupCtrl))
+
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", handlerusergroup.HandleMemberList(userGroupCtrl))
+				r.Post("/", handlerusergroup.HandleMemberAdd(userGroupCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamPrincipalUID),
+					handlerusergroup.HandleMemberDelete(userGroupCtrl))
+			})
 		})
 	})
 }
@@ -232,6 +388,14 @@ func setupRepos(r chi.Router,
 	logCtrl *logs.Controller,
 	pullreqCtrl *pullreq.Controller,
 	webhookCtrl *webhook.Controller,
+	chatIntegrationCtrl *chatintegration.Controller,
+	accessRequestCtrl *accessrequest.Controller,
+	insightsCtrl *insights.Controller,
+	scanCtrl *scan.Controller,
+	sbomCtrl *sbom.Controller,
+	attachmentCtrl *attachment.Controller,
+	attestationCtrl *attestation.Controller,
+	lockCtrl *filelock.Controller,
 	checkCtrl *check.Controller,
 ) {
 	r.Route("/repos", func(r chi.Router) {
@@ -245,10 +409,20 @@ func setupRepos(r chi.Router,
 			r.Delete("/", handlerrepo.HandleDelete(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
+			r.Post("/default-branch/rename", handlerrepo.HandleRenameDefaultBranch(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleListSettings(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamSettingKey), func(r chi.Router) {
+					r.Put("/", handlerrepo.HandleUpdateSetting(repoCtrl))
+					r.Delete("/", handlerrepo.HandleDeleteSetting(repoCtrl))
+				})
+			})
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
@@ -266,12 +440,17 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/render", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleRender(repoCtrl))
+			})
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
 
 				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
 				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
+				r.Get("/author-rewrites", handlerrepo.HandleListCommitAuthorRewrites(repoCtrl))
 
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
@@ -280,13 +459,32 @@ func setupRepos(r chi.Router,
 				})
 			})
 
+			r.Get("/topics", handlerrepo.HandleListTopics(repoCtrl))
+			r.Put("/topics", handlerrepo.HandleUpdateTopics(repoCtrl))
+
+			r.Get("/star", handlerrepo.HandleFindStar(repoCtrl))
+			r.Put("/star", handlerrepo.HandleStar(repoCtrl))
+			r.Delete("/star", handlerrepo.HandleUnstar(repoCtrl))
+
+			r.Route("/ref-watches", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleListRefWatches(repoCtrl))
+				r.Post("/", handlerrepo.HandleCreateRefWatch(repoCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamRefWatchID), handlerrepo.HandleDeleteRefWatch(repoCtrl))
+			})
+
+			r.Post("/git-credentials", handlerrepo.HandleCreateGitCredentials(repoCtrl))
+
 			// branch operations
+			r.Get("/stale-branches", handlerrepo.HandleListStaleBranches(repoCtrl))
+			r.Get("/pushed-branch", handlerrepo.HandleFindPushedBranch(repoCtrl))
+
 			r.Route("/branches", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListBranches(repoCtrl))
 				r.Post("/", handlerrepo.HandleCreateBranch(repoCtrl))
 
 				// per branch operations (can't be grouped in single route)
 				r.Get("/*", handlerrepo.HandleGetBranch(repoCtrl))
+				r.Patch("/*", handlerrepo.HandleUpdateBranch(repoCtrl))
 				r.Delete("/*", handlerrepo.HandleDeleteBranch(repoCtrl))
 			})
 
@@ -307,14 +505,28 @@ func setupRepos(r chi.Router,
 			r.Route("/merge-check", func(r chi.Router) {
 				r.Post("/*", handlerrepo.HandleMergeCheck(repoCtrl))
 			})
+			r.Route("/compare", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleCompare(repoCtrl))
+			})
 
 			SetupPullReq(r, pullreqCtrl)
 
 			setupWebhook(r, webhookCtrl)
 
-			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
+			setupChatIntegrations(r, chatIntegrationCtrl, enum.WebhookParentRepo)
+			setupAccessRequests(r, accessRequestCtrl, enum.AccessRequestParentRepo)
+			r.Get("/insights", handlerinsights.HandleFind(insightsCtrl, enum.WebhookParentRepo))
+			r.Get("/pulse", handlerinsights.HandlePulse(insightsCtrl))
+
+			setupScan(r, scanCtrl)
+			setupSBOMs(r, sbomCtrl)
+			setupAttachments(r, attachmentCtrl)
+
+			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, attestationCtrl)
 
 			SetupChecks(r, checkCtrl)
+			setupAttestations(r, attestationCtrl)
+			setupFileLocks(r, lockCtrl)
 		})
 	})
 }
@@ -325,7 +537,8 @@ func setupPipelines(
 	pipelineCtrl *pipeline.Controller,
 	executionCtrl *execution.Controller,
 	triggerCtrl *trigger.Controller,
-	logCtrl *logs.Controller) {
+	logCtrl *logs.Controller,
+	attestationCtrl *attestation.Controller) {
 	r.Route("/pipelines", func(r chi.Router) {
 		r.Get("/", handlerrepo.HandleListPipelines(repoCtrl))
 		// Create takes path and parentId via body, not uri
@@ -335,7 +548,7 @@ func setupPipelines(
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
-			setupExecutions(r, executionCtrl, logCtrl)
+			setupExecutions(r, executionCtrl, logCtrl, attestationCtrl)
 			setupTriggers(r, triggerCtrl)
 		})
 	})
@@ -393,6 +606,7 @@ func setupExecutions(
 	r chi.Router,
 	executionCtrl *execution.Controller,
 	logCtrl *logs.Controller,
+	attestationCtrl *attestation.Controller,
 ) {
 	r.Route("/executions", func(r chi.Router) {
 		r.Get("/", handlerexecution.HandleList(executionCtrl))
@@ -401,6 +615,10 @@ func setupExecutions(
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Route("/attestations", func(r chi.Router) {
+				r.Get("/", handlerattestation.HandleListForExecution(attestationCtrl))
+				r.Post("/", handlerattestation.HandleCreateForExecution(attestationCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -431,8 +649,11 @@ func setupTriggers(
 	})
 }
 
-func setupInternal(r chi.Router, githookCtrl *controllergithook.Controller) {
+func setupInternal(r chi.Router, githookCtrl *controllergithook.Controller, drainer *drain.Service) {
 	r.Route("/internal", func(r chi.Router) {
+		// git hooks are part of git operations in flight and are never rejected while draining.
+		r.Use(middlewaredrain.Join(drainer.GitHooks()))
+
 		SetupGitHooks(r, githookCtrl)
 	})
 }
@@ -454,8 +675,14 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
+			r.Put("/lock", handlerpullreq.HandleLock(pullreqCtrl))
+			r.Delete("/lock", handlerpullreq.HandleUnlock(pullreqCtrl))
 			r.Post("/recheck", handlerpullreq.HandleRecheck(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
+			r.Route("/tasks", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleListTasks(pullreqCtrl))
+				r.Put(fmt.Sprintf("/{%s}", request.PathParamPullReqTaskIndex), handlerpullreq.HandleUpdateTask(pullreqCtrl))
+			})
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqCommentID), func(r chi.Router) {
@@ -474,9 +701,21 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
+			r.Route("/subscribe", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleSubscribe(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleUnsubscribe(pullreqCtrl))
+			})
+			r.Get("/subscribers", handlerpullreq.HandleSubscriberList(pullreqCtrl))
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/source-branch/restore", handlerpullreq.HandleRestoreSourceBranch(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
+			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.Route("/pushes", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandlePushList(pullreqCtrl))
+				r.Get("/diff", handlerpullreq.HandlePushDiff(pullreqCtrl))
+			})
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
+			r.Get("/codeowners", handlerpullreq.HandleCodeOwners(pullreqCtrl))
 
 			r.Route("/file-views", func(r chi.Router) {
 				r.Put("/", handlerpullreq.HandleFileViewAdd(pullreqCtrl))
@@ -487,6 +726,61 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	})
 }
 
+func setupChatIntegrations(r chi.Router, chatIntegrationCtrl *chatintegration.Controller,
+	parentType enum.WebhookParent) {
+	r.Route("/chat-integrations", func(r chi.Router) {
+		r.Post("/", handlerchatintegration.HandleCreate(chatIntegrationCtrl, parentType))
+		r.Get("/", handlerchatintegration.HandleList(chatIntegrationCtrl, parentType))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamChatIntegrationID), func(r chi.Router) {
+			r.Get("/", handlerchatintegration.HandleFind(chatIntegrationCtrl, parentType))
+			r.Patch("/", handlerchatintegration.HandleUpdate(chatIntegrationCtrl, parentType))
+			r.Delete("/", handlerchatintegration.HandleDelete(chatIntegrationCtrl, parentType))
+		})
+	})
+}
+
+func setupAccessRequests(r chi.Router, accessRequestCtrl *accessrequest.Controller,
+	parentType enum.AccessRequestParent) {
+	r.Route("/access-requests", func(r chi.Router) {
+		r.Post("/", handleraccessrequest.HandleCreate(accessRequestCtrl, parentType))
+		r.Get("/", handleraccessrequest.HandleList(accessRequestCtrl, parentType))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamAccessRequestID), func(r chi.Router) {
+			r.Get("/", handleraccessrequest.HandleFind(accessRequestCtrl, parentType))
+			r.Post("/approve", handleraccessrequest.HandleApprove(accessRequestCtrl, parentType))
+			r.Post("/deny", handleraccessrequest.HandleDeny(accessRequestCtrl, parentType))
+		})
+	})
+}
+
+func setupScan(r chi.Router, scanCtrl *scan.Controller) {
+	r.Route("/scan", func(r chi.Router) {
+		r.Get("/findings", handlerscan.HandleListFindings(scanCtrl))
+
+		r.Route("/suppressions", func(r chi.Router) {
+			r.Post("/", handlerscan.HandleCreateSuppression(scanCtrl))
+			r.Get("/", handlerscan.HandleListSuppressions(scanCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamScanSuppressionID),
+				handlerscan.HandleDeleteSuppression(scanCtrl))
+		})
+	})
+}
+
+func setupSBOMs(r chi.Router, sbomCtrl *sbom.Controller) {
+	r.Route("/sboms", func(r chi.Router) {
+		r.Get("/", handlersbom.HandleList(sbomCtrl))
+		r.Get(fmt.Sprintf("/{%s}/download", request.PathParamSBOMID), handlersbom.HandleDownload(sbomCtrl))
+	})
+}
+
+func setupAttachments(r chi.Router, attachmentCtrl *attachment.Controller) {
+	r.Route("/uploads", func(r chi.Router) {
+		r.Post("/", handlerattachment.HandleUpload(attachmentCtrl))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamAttachmentRef), handlerattachment.HandleDownload(attachmentCtrl))
+	})
+}
+
 func setupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl))
@@ -496,6 +790,7 @@ func setupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFind(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdate(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDelete(webhookCtrl))
+			r.Post("/test", handlerwebhook.HandleTest(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutions(webhookCtrl))
@@ -509,16 +804,42 @@ func setupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	})
 }
 
+func setupAttestations(r chi.Router, attestationCtrl *attestation.Controller) {
+	r.Route("/attestations", func(r chi.Router) {
+		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
+			r.Get("/", handlerattestation.HandleList(attestationCtrl))
+			r.Post("/", handlerattestation.HandleCreate(attestationCtrl))
+		})
+		r.Post(fmt.Sprintf("/{%s}/verify", request.PathParamAttestationID),
+			handlerattestation.HandleVerify(attestationCtrl))
+	})
+}
+
+func setupFileLocks(r chi.Router, lockCtrl *filelock.Controller) {
+	r.Route("/locks", func(r chi.Router) {
+		r.Get("/", handlerfilelock.HandleList(lockCtrl))
+		r.Post("/", handlerfilelock.HandleCreate(lockCtrl))
+		r.Delete(fmt.Sprintf("/{%s}", request.PathParamFileLockID), handlerfilelock.HandleDelete(lockCtrl))
+	})
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
 		})
+		r.Route("/required", func(r chi.Router) {
+			r.Get("/", handlercheck.HandleReqCheckList(checkCtrl))
+			r.Post("/", handlercheck.HandleReqCheckCreate(checkCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamReqCheckID), func(r chi.Router) {
+				r.Delete("/", handlercheck.HandleReqCheckDelete(checkCtrl))
+			})
+		})
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupUser(r chi.Router, userCtrl *user.Controller, oauthCtrl *oauth.Controller, avatarCtrl *avatar.Controller) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
@@ -526,6 +847,50 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
 
+		r.Put("/avatar", handleravatar.HandleUploadUser(avatarCtrl))
+		r.Delete("/avatar", handleravatar.HandleDeleteUser(avatarCtrl))
+
+		r.Route("/emails", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListEmails(userCtrl))
+			r.Post("/", handleruser.HandleAddEmail(userCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamUserEmailID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeleteEmail(userCtrl))
+				r.Post("/verify", handleruser.HandleVerifyEmail(userCtrl))
+				r.Post("/resend-verification", handleruser.HandleResendEmailVerification(userCtrl))
+				r.Post("/primary", handleruser.HandleSetPrimaryEmail(userCtrl))
+			})
+		})
+
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyID), handleruser.HandleDeletePublicKey(userCtrl))
+		})
+
+		r.Route("/saved-replies", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListSavedReplies(userCtrl))
+			r.Post("/", handleruser.HandleCreateSavedReply(userCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSavedReplyID), func(r chi.Router) {
+				r.Get("/", handleruser.HandleFindSavedReply(userCtrl))
+				r.Patch("/", handleruser.HandleUpdateSavedReply(userCtrl))
+				r.Delete("/", handleruser.HandleDeleteSavedReply(userCtrl))
+			})
+		})
+
+		r.Get("/starred", handleruser.HandleListStarredRepos(userCtrl))
+		r.Get("/feed", handleruser.HandleListFeed(userCtrl))
+
+		r.Get("/notifications", handleruser.HandleFindNotificationSettings(userCtrl))
+		r.Patch("/notifications", handleruser.HandleUpdateNotificationSettings(userCtrl))
+
+		r.Route("/announcements", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListAnnouncements(userCtrl))
+			r.Post(fmt.Sprintf("/{%s}/dismiss", request.PathParamAnnouncementID),
+				handleruser.HandleDismissAnnouncement(userCtrl))
+		})
+
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypePAT))
@@ -537,6 +902,24 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			})
 		})
 
+		r.Route("/oauth-apps", func(r chi.Router) {
+			r.Get("/", handleroauth.HandleListApps(oauthCtrl))
+			r.Post("/", handleroauth.HandleCreateApp(oauthCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamOAuthAppID), func(r chi.Router) {
+				r.Get("/", handleroauth.HandleFindApp(oauthCtrl))
+				r.Patch("/", handleroauth.HandleUpdateApp(oauthCtrl))
+				r.Delete("/", handleroauth.HandleDeleteApp(oauthCtrl))
+				r.Post("/client-secret", handleroauth.HandleRegenerateClientSecret(oauthCtrl))
+			})
+		})
+
+		r.Route("/oauth-authorizations", func(r chi.Router) {
+			r.Get("/", handleroauth.HandleListAuthorizations(oauthCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamOAuthAuthorizationID),
+				handleroauth.HandleRevokeAuthorization(oauthCtrl))
+		})
+
 		// SESSION TOKENS
 		r.Route("/sessions", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypeSession))
@@ -587,15 +970,102 @@ func setupResources(r chi.Router) {
 	})
 }
 
-func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
+func setupMarkdown(r chi.Router, markdownCtrl *markdown.Controller) {
+	r.Post("/markdown", handlermarkdown.HandleRender(markdownCtrl))
+}
+
+func setupWebhookSchemas(r chi.Router, webhookCtrl *webhook.Controller) {
+	r.Route("/webhooks/schemas", func(r chi.Router) {
+		r.Get("/", handlerwebhook.HandleListPayloadSchemas(webhookCtrl))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamWebhookTrigger), handlerwebhook.HandleFindPayloadSchema(webhookCtrl))
+	})
+}
+
+func setupPrincipals(r chi.Router, principalCtrl principal.Controller, avatarCtrl *avatar.Controller) {
 	r.Route("/principals", func(r chi.Router) {
 		r.Get("/", handlerprincipal.HandleList(principalCtrl))
+		r.Get(fmt.Sprintf("/{%s}/avatar", request.PathParamPrincipalUID), handleravatar.HandleFindPrincipal(avatarCtrl))
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupUsers(r chi.Router, userCtrl *user.Controller) {
+	r.Route(fmt.Sprintf("/users/{%s}/profile", request.PathParamUserUID), func(r chi.Router) {
+		r.Get("/", handleruser.HandleFindProfile(userCtrl))
+		r.Get("/pullreqs", handleruser.HandleListProfilePullReqs(userCtrl))
+		r.Get("/reviews", handleruser.HandleListProfileReviews(userCtrl))
+	})
+}
+
+func setupAdmin(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, backupCtrl *backup.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Route("/announcements", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListAnnouncements(sysCtrl))
+			r.Post("/", handlersystem.HandleCreateAnnouncement(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamAnnouncementID), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindAnnouncement(sysCtrl))
+				r.Patch("/", handlersystem.HandleUpdateAnnouncement(sysCtrl))
+				r.Delete("/", handlersystem.HandleDeleteAnnouncement(sysCtrl))
+			})
+		})
+		r.Route("/backups", func(r chi.Router) {
+			r.Get("/", handlerbackup.HandleList(backupCtrl))
+			r.Post("/", handlerbackup.HandleCreate(backupCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamBackupID), func(r chi.Router) {
+				r.Get("/", handlerbackup.HandleFind(backupCtrl))
+				r.Delete("/", handlerbackup.HandleDelete(backupCtrl))
+				r.Post("/restore", handlerbackup.HandleRestore(backupCtrl))
+			})
+		})
+		r.Get("/drain", handlersystem.HandleGetDrainStatus(sysCtrl))
+		r.Route("/encryption/rotate", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleEncryptionKeyRotationProgress(sysCtrl))
+			r.Post("/", handlersystem.HandleRotateEncryptionKey(sysCtrl))
+		})
+		r.Route("/events/dead-letters", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListEventDeadLetters(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamDeadLetterID), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindEventDeadLetter(sysCtrl))
+				r.Delete("/", handlersystem.HandleDeleteEventDeadLetter(sysCtrl))
+				r.Post("/redeliver", handlersystem.HandleRedeliverEventDeadLetter(sysCtrl))
+			})
+		})
+		r.Get("/git/slow-operations", handlersystem.HandleListSlowGitOperations(sysCtrl))
+		r.Get("/migrations", handlersystem.HandleMigrationStatus(sysCtrl))
+		r.Route("/jobs", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListJobs(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamJobUID), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindJob(sysCtrl))
+				r.Post("/cancel", handlersystem.HandleCancelJob(sysCtrl))
+				r.Post("/retry", handlersystem.HandleRetryJob(sysCtrl))
+			})
+		})
+		r.Route("/logging/levels", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleGetLogLevels(sysCtrl))
+			r.Patch("/", handlersystem.HandleUpdateLogLevels(sysCtrl))
+		})
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListInstanceSettings(sysCtrl))
+			r.Get("/changes", handlersystem.HandleListInstanceSettingChanges(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSettingKey), func(r chi.Router) {
+				r.Put("/", handlersystem.HandleUpdateInstanceSetting(sysCtrl))
+				r.Delete("/", handlersystem.HandleResetInstanceSetting(sysCtrl))
+			})
+		})
+		r.Route("/upload-quarantines", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListUploadQuarantines(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamUploadQuarantineID), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindUploadQuarantine(sysCtrl))
+				r.Delete("/", handlersystem.HandleDeleteUploadQuarantine(sysCtrl))
+				r.Post("/release", handlersystem.HandleReleaseUploadQuarantine(sysCtrl))
+			})
+		})
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -605,11 +1075,25 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+				r.Patch("/state", handleruser.HandleUpdateState(userCtrl))
+				r.Post("/password-reset", handleruser.HandleForcePasswordReset(userCtrl))
 			})
 		})
 	})
 }
 
+func setupOAuth(r chi.Router, oauthCtrl *oauth.Controller) {
+	r.Route("/oauth", func(r chi.Router) {
+		r.Get("/.well-known/openid-configuration", handleroauth.HandleDiscovery(oauthCtrl))
+		r.Get("/authorize", handleroauth.HandleAuthorize(oauthCtrl))
+		r.Post("/authorize", handleroauth.HandleAuthorizeConsent(oauthCtrl))
+		r.Post("/token", handleroauth.HandleToken(oauthCtrl))
+		r.Post("/revoke", handleroauth.HandleRevoke(oauthCtrl))
+		r.Get("/userinfo", handleroauth.HandleUserInfo(oauthCtrl))
+		r.Get(fmt.Sprintf("/apps/{%s}", request.PathParamOAuthClientID), handleroauth.HandleFindAppInfo(oauthCtrl))
+	})
+}
+
 func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
@@ -18,18 +18,27 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/filelock"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerfilelock "github.com/harness/gitness/app/api/handler/filelock"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
+	middlewaredrain "github.com/harness/gitness/app/api/middleware/drain"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/maintenance"
+	"github.com/harness/gitness/app/api/middleware/tracing"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/loglevel"
 	"github.com/harness/gitness/types"
 
 	"github.com/go-chi/chi"
@@ -51,6 +60,10 @@ func NewGitHandler(
 	authorizer authz.Authorizer,
 	client gitrpc.Interface,
 	repoCtrl *repo.Controller,
+	lockCtrl *filelock.Controller,
+	tracker *gitmetrics.Tracker,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -59,7 +72,11 @@ func NewGitHandler(
 	r.Use(middleware.NoCache)
 	r.Use(middleware.Recoverer)
 
+	// trace git http requests.
+	r.Use(tracing.Handler("git"))
+
 	// configure logging middleware.
+	r.Use(logging.HLogRouteHandler(loglevel.SubsystemGit, r))
 	r.Use(hlog.URLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
@@ -67,6 +84,7 @@ func NewGitHandler(
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
+	r.Use(logging.HLogPrincipalHandler())
 
 	r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 		// routes that aren't coming from git
@@ -79,10 +97,14 @@ func NewGitHandler(
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthz.BlockSessionToken)
 
+			// track git operations in flight, new operations are rejected while draining.
+			r.Use(middlewaredrain.Start(drainer.GitOperations()))
+
 			// smart protocol
-			r.Handle("/git-upload-pack", handlerrepo.GetUploadPack(client, urlProvider, repoStore, authorizer))
-			r.Post("/git-receive-pack", handlerrepo.PostReceivePack(client, urlProvider, repoStore, authorizer))
-			r.Get("/info/refs", handlerrepo.GetInfoRefs(client, repoStore, authorizer))
+			r.Handle("/git-upload-pack", handlerrepo.GetUploadPack(client, urlProvider, repoStore, authorizer, tracker))
+			r.Post("/git-receive-pack",
+				handlerrepo.PostReceivePack(client, urlProvider, repoStore, repoCtrl, authorizer, tracker))
+			r.Get("/info/refs", handlerrepo.GetInfoRefs(client, repoStore, authorizer, repoCtrl))
 
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler(repoStore))
@@ -90,9 +112,20 @@ func NewGitHandler(
 			r.Get("/objects/info/http-alternates", stubGitHandler(repoStore))
 			r.Get("/objects/info/packs", stubGitHandler(repoStore))
 			r.Get("/objects/info/{file:[^/]*}", stubGitHandler(repoStore))
-			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:[0-9a-f]{38}}", stubGitHandler(repoStore))
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.pack", stubGitHandler(repoStore))
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.idx", stubGitHandler(repoStore))
+			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:(?:[0-9a-f]{38}|[0-9a-f]{62})}", stubGitHandler(repoStore))
+			r.Get("/objects/pack/pack-{file:(?:[0-9a-f]{40}|[0-9a-f]{64})}.pack", stubGitHandler(repoStore))
+			r.Get("/objects/pack/pack-{file:(?:[0-9a-f]{40}|[0-9a-f]{64})}.idx", stubGitHandler(repoStore))
+
+			// lfs file locking (pushes are rejected in maintenance mode by the pre-receive hook)
+			r.Route("/info/lfs/locks", func(r chi.Router) {
+				blockInMaintenance := maintenance.Handler(instanceSettings)
+
+				r.Get("/", handlerfilelock.HandleLFSList(lockCtrl))
+				r.With(blockInMaintenance).Post("/", handlerfilelock.HandleLFSCreate(lockCtrl))
+				r.Post("/verify", handlerfilelock.HandleLFSVerify(lockCtrl))
+				r.With(blockInMaintenance).Post(fmt.Sprintf("/{%s}/unlock", request.PathParamFileLockID),
+					handlerfilelock.HandleLFSUnlock(lockCtrl))
+			})
 		})
 	})
 
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/health"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// HealthHandler is an abstraction of an http handler that exposes the liveness and readiness of the system.
+type HealthHandler interface {
+	http.Handler
+}
+
+// NewHealthHandler returns a new HealthHandler.
+func NewHealthHandler(healthSvc *health.Service) HealthHandler {
+	r := chi.NewRouter()
+	r.Use(middleware.NoCache)
+	r.Use(middleware.Recoverer)
+
+	// liveness only verifies the server is able to serve requests.
+	r.Get(HealthMount, func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, http.StatusOK, health.Report{
+			Status:       health.StatusUp,
+			Dependencies: []health.DependencyStatus{},
+		})
+	})
+
+	// readiness verifies all dependencies are available.
+	r.Get(ReadyMount, func(w http.ResponseWriter, r *http.Request) {
+		report := healthSvc.Check(r.Context())
+
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+
+		render.JSON(w, status, report)
+	})
+
+	return r
+}
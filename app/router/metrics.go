@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+
+	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsHandler is an abstraction of an http handler that exposes the prometheus metrics.
+type MetricsHandler interface {
+	http.Handler
+}
+
+// NewMetricsHandler returns a new MetricsHandler.
+func NewMetricsHandler(config *types.Config, authenticator authn.Authenticator) MetricsHandler {
+	r := chi.NewRouter()
+	r.Use(middleware.NoCache)
+	r.Use(middleware.Recoverer)
+
+	if config.Metrics.RequireAdmin {
+		r.Use(middlewareauthn.Attempt(authenticator))
+		r.Use(middlewareprincipal.RestrictToAdmin())
+	}
+
+	r.Handle(MetricsMount, promhttp.Handler())
+
+	return r
+}
@@ -29,14 +29,19 @@ import (
 )
 
 const (
-	APIMount = "/api"
-	GitMount = "/git"
+	APIMount     = "/api"
+	GitMount     = "/git"
+	MetricsMount = "/metrics"
+	HealthMount  = "/healthz"
+	ReadyMount   = "/readyz"
 )
 
 type Router struct {
-	api APIHandler
-	git GitHandler
-	web WebHandler
+	api     APIHandler
+	git     GitHandler
+	web     WebHandler
+	metrics MetricsHandler
+	health  HealthHandler
 
 	// gitHost describes the optional host via which git traffic is identified.
 	// Note: always stored as lowercase.
@@ -49,12 +54,16 @@ func NewRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	metrics MetricsHandler,
+	health HealthHandler,
 	gitHost string,
 ) *Router {
 	return &Router{
-		api: api,
-		git: git,
-		web: web,
+		api:     api,
+		git:     git,
+		web:     web,
+		metrics: metrics,
+		health:  health,
 
 		gitHost: strings.ToLower(gitHost),
 	}
@@ -116,7 +125,35 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 
 	/*
-	 * 3. WEB
+	 * 3. METRICS
+	 *
+	 * The prometheus metrics are exposed on "/metrics" (only if enabled).
+	 */
+	if r.metrics != nil && req.URL.Path == MetricsMount {
+		log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Str("http.handler", "metrics")
+		})
+
+		r.metrics.ServeHTTP(w, req)
+		return
+	}
+
+	/*
+	 * 4. HEALTH
+	 *
+	 * The liveness and readiness of the system are exposed on "/healthz" and "/readyz".
+	 */
+	if req.URL.Path == HealthMount || req.URL.Path == ReadyMount {
+		log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Str("http.handler", "health")
+		})
+
+		r.health.ServeHTTP(w, req)
+		return
+	}
+
+	/*
+	 * 5. WEB
 	 *
 	 * Everything else will be routed to web (or return 404)
 	 */
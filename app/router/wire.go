@@ -17,16 +17,28 @@ package router
 import (
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller/accessrequest"
+	"github.com/harness/gitness/app/api/controller/attachment"
+	"github.com/harness/gitness/app/api/controller/attestation"
+	"github.com/harness/gitness/app/api/controller/avatar"
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/app/api/controller/chatintegration"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/filelock"
 	"github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/insights"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/markdown"
+	"github.com/harness/gitness/app/api/controller/oauth"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/sbom"
+	"github.com/harness/gitness/app/api/controller/scan"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -34,9 +46,14 @@ import (
 	"github.com/harness/gitness/app/api/controller/template"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/health"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/gitrpc"
@@ -51,6 +68,8 @@ var WireSet = wire.NewSet(
 	ProvideGitHandler,
 	ProvideAPIHandler,
 	ProvideWebHandler,
+	ProvideMetricsHandler,
+	ProvideHealthHandler,
 )
 
 func ProvideRouter(
@@ -58,6 +77,8 @@ func ProvideRouter(
 	api APIHandler,
 	git GitHandler,
 	web WebHandler,
+	metrics MetricsHandler,
+	healthHandler HealthHandler,
 	urlProvider url.Provider,
 ) *Router {
 	// use url provider as it has the latest data.
@@ -71,7 +92,7 @@ func ProvideRouter(
 		gitRoutingHost = gitHostname
 	}
 
-	return NewRouter(api, git, web, gitRoutingHost)
+	return NewRouter(api, git, web, metrics, healthHandler, gitRoutingHost)
 }
 
 func ProvideGitHandler(
@@ -82,6 +103,10 @@ func ProvideGitHandler(
 	authorizer authz.Authorizer,
 	client gitrpc.Interface,
 	repoCtrl *repo.Controller,
+	lockCtrl *filelock.Controller,
+	tracker *gitmetrics.Tracker,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
 ) GitHandler {
 	return NewGitHandler(
 		config,
@@ -91,6 +116,10 @@ func ProvideGitHandler(
 		authorizer,
 		client,
 		repoCtrl,
+		lockCtrl,
+		tracker,
+		instanceSettings,
+		drainer,
 	)
 }
 
@@ -109,18 +138,49 @@ func ProvideAPIHandler(
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
 	webhookCtrl *webhook.Controller,
+	chatIntegrationCtrl *chatintegration.Controller,
+	accessRequestCtrl *accessrequest.Controller,
+	insightsCtrl *insights.Controller,
+	scanCtrl *scan.Controller,
+	sbomCtrl *sbom.Controller,
+	attachmentCtrl *attachment.Controller,
+	avatarCtrl *avatar.Controller,
+	attestationCtrl *attestation.Controller,
+	lockCtrl *filelock.Controller,
 	githookCtrl *githook.Controller,
 	saCtrl *serviceaccount.Controller,
+	userGroupCtrl *usergroup.Controller,
 	userCtrl *user.Controller,
 	principalCtrl principal.Controller,
 	checkCtrl *check.Controller,
 	sysCtrl *system.Controller,
+	backupCtrl *backup.Controller,
+	oauthCtrl *oauth.Controller,
+	markdownCtrl *markdown.Controller,
+	instanceSettings *instancesettings.Service,
+	drainer *drain.Service,
 ) APIHandler {
 	return NewAPIHandler(config, authenticator, repoCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl)
+		chatIntegrationCtrl, accessRequestCtrl, insightsCtrl, scanCtrl, sbomCtrl, attachmentCtrl, avatarCtrl, attestationCtrl, lockCtrl, githookCtrl,
+		saCtrl,
+		userGroupCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, backupCtrl, oauthCtrl, markdownCtrl,
+		instanceSettings, drainer)
 }
 
 func ProvideWebHandler(config *types.Config) WebHandler {
 	return NewWebHandler(config)
 }
+
+// ProvideMetricsHandler provides the handler of the prometheus metrics endpoint.
+// It returns nil if the metrics endpoint is disabled.
+func ProvideHealthHandler(healthSvc *health.Service) HealthHandler {
+	return NewHealthHandler(healthSvc)
+}
+
+func ProvideMetricsHandler(config *types.Config, authenticator authn.Authenticator) MetricsHandler {
+	if !config.Metrics.Enabled {
+		return nil
+	}
+	return NewMetricsHandler(config, authenticator)
+}
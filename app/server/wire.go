@@ -30,9 +30,12 @@ func ProvideServer(config *types.Config, router *router.Router) *Server {
 	return &Server{
 		http.NewServer(
 			http.Config{
-				Port:     config.Server.HTTP.Port,
-				Acme:     config.Server.Acme.Enabled,
-				AcmeHost: config.Server.Acme.Host,
+				Port:               config.Server.HTTP.Port,
+				Acme:               config.Server.Acme.Enabled,
+				AcmeHost:           config.Server.Acme.Host,
+				Cert:               config.Server.HTTP.TLS.CertFile,
+				Key:                config.Server.HTTP.TLS.KeyFile,
+				CertReloadInterval: config.Server.HTTP.TLS.ReloadInterval,
 			},
 			router,
 		),
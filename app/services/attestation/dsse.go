@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PayloadTypeInToto is the DSSE payload type of in-toto statements.
+const PayloadTypeInToto = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope (https://github.com/secure-systems-lab/dsse).
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Statement is an in-toto statement (https://github.com/in-toto/attestation).
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// commitDigestAlgorithms are the digest algorithms of subjects identifying a git commit.
+var commitDigestAlgorithms = []string{"gitCommit", "sha1", "sha256"}
+
+// ParseEnvelope parses the DSSE envelope and the in-toto statement it contains.
+// The signatures aren't verified.
+func ParseEnvelope(data []byte) (*Envelope, *Statement, error) {
+	env := &Envelope{}
+	if err := json.Unmarshal(data, env); err != nil {
+		return nil, nil, fmt.Errorf("invalid envelope: %w", err)
+	}
+
+	if env.PayloadType != PayloadTypeInToto {
+		return nil, nil, fmt.Errorf("unsupported payload type '%s', expected '%s'", env.PayloadType, PayloadTypeInToto)
+	}
+
+	if len(env.Signatures) == 0 {
+		return nil, nil, errors.New("the envelope isn't signed")
+	}
+
+	payload, err := decodeBase64(env.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid payload encoding: %w", err)
+	}
+
+	statement := &Statement{}
+	if err = json.Unmarshal(payload, statement); err != nil {
+		return nil, nil, fmt.Errorf("invalid in-toto statement: %w", err)
+	}
+
+	if statement.PredicateType == "" {
+		return nil, nil, errors.New("the in-toto statement has no predicate type")
+	}
+
+	return env, statement, nil
+}
+
+// HasCommit returns whether the commit is a subject of the statement.
+func (s *Statement) HasCommit(sha string) bool {
+	for _, subject := range s.Subject {
+		for _, alg := range commitDigestAlgorithms {
+			if digest, ok := subject.Digest[alg]; ok && strings.EqualFold(digest, sha) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Verify verifies the signatures of the envelope with the trusted keys.
+// It returns the verification status, the name of the key that verified the envelope and a status message.
+func Verify(env *Envelope, keys []types.AttestationKey) (enum.AttestationStatus, string, string) {
+	payload, err := decodeBase64(env.Payload)
+	if err != nil {
+		return enum.AttestationStatusInvalid, "", "The payload isn't base64 encoded."
+	}
+
+	msg := pae(env.PayloadType, payload)
+
+	var mismatchedKey string
+	for _, signature := range env.Signatures {
+		sig, errDecode := decodeBase64(signature.Sig)
+		if errDecode != nil {
+			continue
+		}
+
+		for _, key := range keys {
+			pub, errParse := parsePublicKey(key.PublicKey)
+			if errParse != nil {
+				continue
+			}
+
+			if verifySignature(pub, msg, sig) {
+				return enum.AttestationStatusVerified, key.Name, fmt.Sprintf("Signed by trusted key '%s'.", key.Name)
+			}
+
+			if signature.KeyID != "" && signature.KeyID == key.Name {
+				mismatchedKey = key.Name
+			}
+		}
+	}
+
+	if mismatchedKey != "" {
+		return enum.AttestationStatusInvalid, "",
+			fmt.Sprintf("The signature of trusted key '%s' doesn't match the payload.", mismatchedKey)
+	}
+
+	return enum.AttestationStatusUnverified, "", "The attestation isn't signed by any trusted key."
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload, which is the signed message.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func verifySignature(pub crypto.PublicKey, msg []byte, sig []byte) bool {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, msg, sig)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(msg)
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(msg)
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+			return true
+		}
+		return rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+	default:
+		return false
+	}
+}
+
+func parsePublicKey(value string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// decodeBase64 decodes standard or url encoded base64, as both are used by DSSE implementations.
+func decodeBase64(s string) ([]byte, error) {
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+
+	return base64.URLEncoding.DecodeString(s)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testCommitSHA = "1d0e5e6d3a0d2dd1b8c1b5f6b3c6b6e0b2b1c3d4"
+
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	payload, _ := json.Marshal(Statement{
+		Type:          "https://in-toto.io/Statement/v1",
+		Subject:       []Subject{{Name: "repo", Digest: map[string]string{"gitCommit": testCommitSHA}}},
+		PredicateType: "https://slsa.dev/provenance/v1",
+	})
+	sig := ed25519.Sign(priv, pae(PayloadTypeInToto, payload))
+
+	envelope := func(keyID string, sig []byte) []byte {
+		data, _ := json.Marshal(Envelope{
+			PayloadType: PayloadTypeInToto,
+			Payload:     base64.StdEncoding.EncodeToString(payload),
+			Signatures:  []Signature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+		})
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		envelope []byte
+		keys     []types.AttestationKey
+		want     enum.AttestationStatus
+		wantKey  string
+	}{
+		{
+			name:     "signed by trusted key",
+			envelope: envelope("", sig),
+			keys: []types.AttestationKey{
+				{Name: "other", PublicKey: pemKey(t, otherPub)},
+				{Name: "ci", PublicKey: pemKey(t, pub)},
+			},
+			want:    enum.AttestationStatusVerified,
+			wantKey: "ci",
+		},
+		{
+			name:     "no trusted keys",
+			envelope: envelope("", sig),
+			want:     enum.AttestationStatusUnverified,
+		},
+		{
+			name:     "signed by unknown key",
+			envelope: envelope("", sig),
+			keys:     []types.AttestationKey{{Name: "other", PublicKey: pemKey(t, otherPub)}},
+			want:     enum.AttestationStatusUnverified,
+		},
+		{
+			name:     "signature of trusted key doesn't match",
+			envelope: envelope("ci", []byte("forged")),
+			keys:     []types.AttestationKey{{Name: "ci", PublicKey: pemKey(t, pub)}},
+			want:     enum.AttestationStatusInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		env, statement, err := ParseEnvelope(test.envelope)
+		if err != nil {
+			t.Errorf("%s: failed to parse envelope: %s", test.name, err)
+			continue
+		}
+
+		if !statement.HasCommit(testCommitSHA) {
+			t.Errorf("%s: want commit %s to be a subject of the statement", test.name, testCommitSHA)
+		}
+
+		got, gotKey, _ := Verify(env, test.keys)
+		if got != test.want || gotKey != test.wantKey {
+			t.Errorf("%s: want status %q with key %q, got %q with key %q", test.name, test.want, test.wantKey, got, gotKey)
+		}
+	}
+}
+
+func pemKey(t *testing.T, pub ed25519.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
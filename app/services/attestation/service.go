@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckUID is the UID of the status check reporting the provenance verification status of commits.
+const CheckUID = "provenance"
+
+// Service verifies attestations with the trusted keys of their repository
+// and reports the verification status of commits as status check.
+type Service struct {
+	settings         *settings.Service
+	attestationStore store.AttestationStore
+	checkStore       store.CheckStore
+}
+
+func NewService(
+	settings *settings.Service,
+	attestationStore store.AttestationStore,
+	checkStore store.CheckStore,
+) *Service {
+	return &Service{
+		settings:         settings,
+		attestationStore: attestationStore,
+		checkStore:       checkStore,
+	}
+}
+
+// Verify verifies the signatures of the attestation with the currently trusted keys of the repository
+// and updates its verification status. The status isn't stored.
+func (s *Service) Verify(ctx context.Context, repo *types.Repository, attestation *types.Attestation) error {
+	env, _, err := ParseEnvelope(attestation.Envelope)
+	if err != nil {
+		return fmt.Errorf("failed to parse envelope of attestation: %w", err)
+	}
+
+	keys, err := s.settings.RepoAttestationKeys(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get attestation keys: %w", err)
+	}
+
+	attestation.Status, attestation.KeyName, attestation.StatusMessage = Verify(env, keys)
+	attestation.Verified = time.Now().UnixMilli()
+
+	return nil
+}
+
+// ReportCheck reports the aggregated verification status of all attestations of the commit as status check.
+// Any invalid attestation fails the check, otherwise at least one verified attestation is required.
+func (s *Service) ReportCheck(ctx context.Context, repo *types.Repository, commitSHA string) error {
+	attestations, err := s.attestationStore.ListByCommit(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list attestations of commit: %w", err)
+	}
+
+	counts := map[enum.AttestationStatus]int{}
+	for _, attestation := range attestations {
+		counts[attestation.Status]++
+	}
+
+	var status enum.CheckStatus
+	switch {
+	case counts[enum.AttestationStatusInvalid] > 0:
+		status = enum.CheckStatusFailure
+	case counts[enum.AttestationStatusVerified] > 0:
+		status = enum.CheckStatusSuccess
+	default:
+		status = enum.CheckStatusFailure
+	}
+
+	summary := fmt.Sprintf("%d verified, %d unverified and %d invalid attestations.",
+		counts[enum.AttestationStatusVerified],
+		counts[enum.AttestationStatusUnverified],
+		counts[enum.AttestationStatusInvalid])
+
+	data, err := json.Marshal(types.CheckPayloadText{
+		Details: "The provenance attestations of this commit were verified with the trusted keys of the repository.",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.checkStore.Upsert(ctx, &types.Check{
+		RepoID:    repo.ID,
+		CommitSHA: commitSHA,
+		UID:       CheckUID,
+		Status:    status,
+		Summary:   summary,
+		Created:   now,
+		Updated:   now,
+		CreatedBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Metadata:  []byte("{}"),
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindRaw,
+			Data: data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report provenance check: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service resolves the author of commits created via the API.
+// If enforced by the repository settings, the requested author is replaced by the verified identity
+// of the acting principal and the original author metadata is kept in an audit trail.
+type Service struct {
+	settings       *settings.Service
+	userEmailStore store.UserEmailStore
+	rewriteStore   store.CommitAuthorRewriteStore
+}
+
+func NewService(
+	settings *settings.Service,
+	userEmailStore store.UserEmailStore,
+	rewriteStore store.CommitAuthorRewriteStore,
+) *Service {
+	return &Service{
+		settings:       settings,
+		userEmailStore: userEmailStore,
+		rewriteStore:   rewriteStore,
+	}
+}
+
+// Author is the resolved author of a commit.
+type Author struct {
+	Identity *gitrpc.Identity
+	// rewrite is the audit record in case the requested author got replaced.
+	rewrite *types.CommitAuthorRewrite
+}
+
+// Resolve returns the author of a commit created by the principal in the repository.
+// If no author is requested, the principal is the author.
+func (s *Service) Resolve(
+	ctx context.Context,
+	repo *types.Repository,
+	principal *types.Principal,
+	operation enum.CommitAuthorOperation,
+	requested *types.Identity,
+) (*Author, error) {
+	identity := &gitrpc.Identity{
+		Name:  principal.DisplayName,
+		Email: principal.Email,
+	}
+
+	if requested == nil {
+		return &Author{Identity: identity}, nil
+	}
+
+	requestedName := strings.TrimSpace(requested.Name)
+	requestedEmail := strings.TrimSpace(requested.Email)
+	if requestedName == "" && requestedEmail == "" {
+		return &Author{Identity: identity}, nil
+	}
+
+	enforce, err := s.settings.RepoEnforceCommitAuthor(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit author enforcement setting: %w", err)
+	}
+
+	if !enforce {
+		if requestedName != "" {
+			identity.Name = requestedName
+		}
+		if requestedEmail != "" {
+			identity.Email = requestedEmail
+		}
+
+		return &Author{Identity: identity}, nil
+	}
+
+	// the principal is allowed to author commits with any of its verified emails.
+	if requestedEmail != "" {
+		verified, err := s.isVerifiedEmail(ctx, principal, requestedEmail)
+		if err != nil {
+			return nil, err
+		}
+		if verified {
+			identity.Email = requestedEmail
+		}
+	}
+
+	if identity.Name == requestedName && strings.EqualFold(identity.Email, requestedEmail) {
+		return &Author{Identity: identity}, nil
+	}
+
+	return &Author{
+		Identity: identity,
+		rewrite: &types.CommitAuthorRewrite{
+			RepoID:         repo.ID,
+			Operation:      operation,
+			PrincipalID:    principal.ID,
+			RequestedName:  requestedName,
+			RequestedEmail: requestedEmail,
+			AuthorName:     identity.Name,
+			AuthorEmail:    identity.Email,
+		},
+	}, nil
+}
+
+// Record stores the audit record of the commit in case its requested author got replaced.
+// Failures are only logged as the commit was already created.
+func (s *Service) Record(ctx context.Context, author *Author, sha string) {
+	if author == nil || author.rewrite == nil {
+		return
+	}
+
+	rewrite := *author.rewrite
+	rewrite.SHA = sha
+	rewrite.Created = time.Now().UnixMilli()
+
+	if err := s.rewriteStore.Create(ctx, &rewrite); err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to record rewritten author of commit %s", sha)
+	}
+}
+
+// List returns the audit records of commits of the repository whose requested author got replaced.
+func (s *Service) List(
+	ctx context.Context,
+	repo *types.Repository,
+	filter *types.CommitAuthorRewriteFilter,
+) ([]*types.CommitAuthorRewrite, int64, error) {
+	count, err := s.rewriteStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count commit author rewrites: %w", err)
+	}
+
+	rewrites, err := s.rewriteStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list commit author rewrites: %w", err)
+	}
+
+	return rewrites, count, nil
+}
+
+func (s *Service) isVerifiedEmail(ctx context.Context, principal *types.Principal, email string) (bool, error) {
+	if strings.EqualFold(principal.Email, email) {
+		return true, nil
+	}
+
+	userEmail, err := s.userEmailStore.FindVerified(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find verified email: %w", err)
+	}
+
+	return userEmail.PrincipalID == principal.ID, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorship
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeSettingStore struct {
+	store.SettingStore
+	enforce bool
+}
+
+func (fakeSettingStore) ListInherited(context.Context, int64) ([]*types.Setting, error) {
+	return nil, nil
+}
+
+func (s fakeSettingStore) ListRepo(_ context.Context, repoID int64) ([]*types.Setting, error) {
+	value, _ := json.Marshal(s.enforce)
+	return []*types.Setting{{RepoID: &repoID, Key: enum.SettingKeyEnforceCommitAuthor, Value: value}}, nil
+}
+
+// fakeUserEmailStore contains the verified secondary emails of the principals 1 and 2.
+type fakeUserEmailStore struct {
+	store.UserEmailStore
+}
+
+func (fakeUserEmailStore) FindVerified(_ context.Context, email string) (*types.UserEmail, error) {
+	switch strings.ToLower(email) {
+	case "jane@work.example.com":
+		return &types.UserEmail{PrincipalID: 1, Email: email}, nil
+	case "john@example.com":
+		return &types.UserEmail{PrincipalID: 2, Email: email}, nil
+	default:
+		return nil, gitness_store.ErrResourceNotFound
+	}
+}
+
+type fakeRewriteStore struct {
+	store.CommitAuthorRewriteStore
+	rewrites []*types.CommitAuthorRewrite
+}
+
+func (s *fakeRewriteStore) Create(_ context.Context, rewrite *types.CommitAuthorRewrite) error {
+	s.rewrites = append(s.rewrites, rewrite)
+	return nil
+}
+
+func TestResolve(t *testing.T) {
+	principal := &types.Principal{ID: 1, DisplayName: "Jane", Email: "jane@example.com"}
+
+	tests := []struct {
+		name        string
+		enforce     bool
+		requested   *types.Identity
+		want        gitrpc.Identity
+		wantRewrite bool
+	}{
+		{
+			name: "no requested author",
+			want: gitrpc.Identity{Name: "Jane", Email: "jane@example.com"},
+		},
+		{
+			name:      "requested author not enforced",
+			requested: &types.Identity{Name: "John", Email: "john@example.com"},
+			want:      gitrpc.Identity{Name: "John", Email: "john@example.com"},
+		},
+		{
+			name:        "requested author of other user",
+			enforce:     true,
+			requested:   &types.Identity{Name: "John", Email: "john@example.com"},
+			want:        gitrpc.Identity{Name: "Jane", Email: "jane@example.com"},
+			wantRewrite: true,
+		},
+		{
+			name:        "requested unverified email",
+			enforce:     true,
+			requested:   &types.Identity{Name: "Jane", Email: "jane@unverified.example.com"},
+			want:        gitrpc.Identity{Name: "Jane", Email: "jane@example.com"},
+			wantRewrite: true,
+		},
+		{
+			name:      "requested verified secondary email",
+			enforce:   true,
+			requested: &types.Identity{Name: "Jane", Email: "Jane@Work.example.com"},
+			want:      gitrpc.Identity{Name: "Jane", Email: "Jane@Work.example.com"},
+		},
+		{
+			name:        "requested other name",
+			enforce:     true,
+			requested:   &types.Identity{Name: "J. Doe", Email: "jane@example.com"},
+			want:        gitrpc.Identity{Name: "Jane", Email: "jane@example.com"},
+			wantRewrite: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settingsService := settings.NewService("main", 0, nil, fakeSettingStore{enforce: test.enforce}, nil, nil)
+			rewriteStore := &fakeRewriteStore{}
+			s := NewService(settingsService, fakeUserEmailStore{}, rewriteStore)
+
+			repo := &types.Repository{ID: 3}
+			author, err := s.Resolve(context.Background(), repo, principal, enum.CommitAuthorOperationMerge,
+				test.requested)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if *author.Identity != test.want {
+				t.Errorf("want author %+v, got %+v", test.want, *author.Identity)
+			}
+
+			s.Record(context.Background(), author, "abc")
+
+			if !test.wantRewrite {
+				if len(rewriteStore.rewrites) != 0 {
+					t.Errorf("expected no rewrite to be recorded")
+				}
+				return
+			}
+
+			if len(rewriteStore.rewrites) != 1 {
+				t.Fatalf("want a single recorded rewrite, got %d", len(rewriteStore.rewrites))
+			}
+			rewrite := rewriteStore.rewrites[0]
+			if rewrite.SHA != "abc" || rewrite.RepoID != repo.ID || rewrite.PrincipalID != principal.ID ||
+				rewrite.Operation != enum.CommitAuthorOperationMerge ||
+				rewrite.RequestedName != test.requested.Name || rewrite.RequestedEmail != test.requested.Email ||
+				rewrite.AuthorName != test.want.Name || rewrite.AuthorEmail != test.want.Email {
+				t.Errorf("unexpected rewrite %+v", rewrite)
+			}
+		})
+	}
+}
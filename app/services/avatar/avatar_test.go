@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestVariantSize(t *testing.T) {
+	tests := []struct {
+		requested int
+		want      int
+	}{
+		{requested: 0, want: DefaultSize},
+		{requested: 1, want: 32},
+		{requested: 32, want: 32},
+		{requested: 33, want: 64},
+		{requested: 200, want: 256},
+		{requested: 1000, want: 256},
+	}
+	for _, test := range tests {
+		if got := VariantSize(test.requested); got != test.want {
+			t.Errorf("VariantSize(%d) = %d, want %d", test.requested, got, test.want)
+		}
+	}
+}
+
+func TestIdenticon(t *testing.T) {
+	const size = 64
+
+	img := Identicon{}.Generate("principal:1", size)
+	if img.Bounds() != image.Rect(0, 0, size, size) {
+		t.Fatalf("unexpected bounds %v", img.Bounds())
+	}
+
+	again := Identicon{}.Generate("principal:1", size)
+	other := Identicon{}.Generate("principal:2", size)
+
+	differs := false
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if img.At(x, y) != again.At(x, y) {
+				t.Fatalf("identicon isn't deterministic at (%d, %d)", x, y)
+			}
+			if img.At(x, y) != img.At(size-1-x, y) {
+				t.Fatalf("identicon isn't symmetric at (%d, %d)", x, y)
+			}
+			if img.At(x, y) != other.At(x, y) {
+				differs = true
+			}
+		}
+	}
+	if !differs {
+		t.Errorf("identicons of different seeds are identical")
+	}
+}
+
+func TestCropAndResize(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	src := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			src.SetRGBA(x, y, red)
+		}
+	}
+
+	square := cropSquare(src)
+	if square.Bounds() != image.Rect(0, 0, 200, 200) {
+		t.Fatalf("unexpected bounds of cropped image %v", square.Bounds())
+	}
+
+	for _, size := range []int{32, 256} {
+		resized := resize(square, size)
+		if resized.Bounds() != image.Rect(0, 0, size, size) {
+			t.Fatalf("unexpected bounds of resized image %v", resized.Bounds())
+		}
+		if got := resized.RGBAAt(size/2, size/2); got != red {
+			t.Errorf("unexpected color %v of image resized to %d", got, size)
+		}
+	}
+}
+
+func TestDecodeImageRejectsInvalidContent(t *testing.T) {
+	if _, err := decodeImage([]byte("<svg></svg>")); err == nil {
+		t.Errorf("expected non-raster image to be rejected")
+	}
+}
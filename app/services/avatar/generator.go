@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+const (
+	// GeneratorIdenticon generates identicons for principals and spaces without uploaded avatar.
+	GeneratorIdenticon = "identicon"
+	// GeneratorNone doesn't generate avatars, principals and spaces without uploaded avatar have none.
+	GeneratorNone = "none"
+)
+
+// Generator generates the avatar of principals and spaces without uploaded avatar.
+// The generated image has to be deterministic for the seed, as generated avatars are cached.
+type Generator interface {
+	Generate(seed string, size int) image.Image
+}
+
+// GeneratorByName returns the generator with the provided name, nil for GeneratorNone.
+func GeneratorByName(name string) (Generator, error) {
+	switch name {
+	case GeneratorIdenticon:
+		return Identicon{}, nil
+	case GeneratorNone:
+		return nil, nil //nolint:nilnil // no generator is a valid choice
+	default:
+		return nil, fmt.Errorf("avatar generator '%s' is not supported", name)
+	}
+}
+
+// identiconCells is the number of cells of an identicon in each dimension.
+const identiconCells = 5
+
+// Identicon generates Gravatar-like identicons: a horizontally symmetric pattern of cells
+// in a color derived from the seed.
+type Identicon struct{}
+
+func (Identicon) Generate(seed string, size int) image.Image {
+	sum := sha256.Sum256([]byte(seed))
+
+	background := color.RGBA{R: 240, G: 240, B: 240, A: 255}
+	foreground := hslToRGB(
+		float64(uint16(sum[0])<<8|uint16(sum[1]))/65536*360,
+		0.45+float64(sum[2])/255*0.2,
+		0.45+float64(sum[3])/255*0.15,
+	)
+
+	// the left columns (including the middle one) are derived from the seed and mirrored to the right.
+	var cells [identiconCells][identiconCells]bool
+	for row := 0; row < identiconCells; row++ {
+		for col := 0; col < (identiconCells+1)/2; col++ {
+			on := sum[4+row*identiconCells+col]&1 == 1
+			cells[row][col] = on
+			cells[row][identiconCells-1-col] = on
+		}
+	}
+
+	padding := size / 12
+	inner := size - 2*padding
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			// the right half mirrors the pixels of the left half, so the image is symmetric despite rounding.
+			mx := x
+			if mx >= size/2 {
+				mx = size - 1 - x
+			}
+
+			c := background
+			if mx >= padding && y >= padding && y < padding+inner &&
+				cells[(y-padding)*identiconCells/inner][(mx-padding)*identiconCells/inner] {
+				c = foreground
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// hslToRGB converts a color from HSL (hue in degrees, saturation and lightness in [0, 1]) to RGB.
+func hslToRGB(h, s, l float64) color.RGBA {
+	c := (1 - abs(2*l-1)) * s
+	hp := h / 60
+	x := c * (1 - abs(mod2(hp)-1))
+
+	var r, g, b float64
+	switch {
+	case hp < 1:
+		r, g, b = c, x, 0
+	case hp < 2:
+		r, g, b = x, c, 0
+	case hp < 3:
+		r, g, b = 0, c, x
+	case hp < 4:
+		r, g, b = 0, x, c
+	case hp < 5:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	m := l - c/2
+
+	return color.RGBA{
+		R: uint8((r + m) * 255),
+		G: uint8((g + m) * 255),
+		B: uint8((b + m) * 255),
+		A: 255,
+	}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// mod2 returns v modulo 2 for non-negative values.
+func mod2(v float64) float64 {
+	return v - 2*float64(int(v/2))
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+
+	// register the decoders of the supported image formats.
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// maxDimension is the max width and height of uploaded avatar images.
+const maxDimension = 4096
+
+// decodeImage decodes the uploaded image, the dimensions are checked before the image is decoded.
+func decodeImage(content []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, usererror.BadRequest("The avatar has to be a PNG, JPEG or GIF image.")
+	}
+
+	if config.Width > maxDimension || config.Height > maxDimension {
+		return nil, usererror.BadRequestf("The avatar image can't be larger than %dx%d pixels.",
+			maxDimension, maxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, usererror.BadRequest("The avatar image is corrupted.")
+	}
+
+	return img, nil
+}
+
+// cropSquare returns the largest centered square of the image.
+func cropSquare(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+
+	offset := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, offset, draw.Src)
+
+	return dst
+}
+
+// resize scales the square image to the provided size.
+// Every pixel is the average of the source pixels it covers, which gives smooth results when scaling down.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for dy := 0; dy < size; dy++ {
+		y0, y1 := sourceRange(dy, size, side)
+		for dx := 0; dx < size; dx++ {
+			x0, x1 := sourceRange(dx, size, side)
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				row := src.Pix[y*src.Stride:]
+				for x := x0; x < x1; x++ {
+					p := row[x*4 : x*4+4]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					b += uint64(p[2])
+					a += uint64(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(dx, dy)
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// sourceRange returns the range of source pixels covered by the destination pixel (at least one pixel).
+func sourceRange(i, size, side int) (int, int) {
+	start := i * side / size
+	end := (i + 1) * side / size
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
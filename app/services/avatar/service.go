@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DefaultSize is the size of the avatar variant served if no size is requested.
+const DefaultSize = 64
+
+// Sizes are the sizes (in pixels) of the square variants in which avatars are served.
+var Sizes = []int{32, 64, 128, 256}
+
+// Config holds the configuration of the avatar service.
+type Config struct {
+	MaxSize       int64
+	CacheDuration time.Duration
+}
+
+// Service stores avatars uploaded for principals and spaces and serves them in all size variants.
+// Principals and spaces without uploaded avatar get the avatar created by the generator (if any).
+type Service struct {
+	config      Config
+	avatarStore store.AvatarStore
+	blobStore   blob.Store
+	generator   Generator
+	cache       cache.Cache[cacheKey, *types.AvatarImage]
+}
+
+// cacheKey identifies an avatar image, either an uploaded one (by checksum) or a generated one (by seed).
+type cacheKey struct {
+	sha256 string
+	seed   string
+	size   int
+}
+
+func NewService(
+	config Config,
+	avatarStore store.AvatarStore,
+	blobStore blob.Store,
+	generator Generator,
+) *Service {
+	s := &Service{
+		config:      config,
+		avatarStore: avatarStore,
+		blobStore:   blobStore,
+		generator:   generator,
+	}
+	s.cache = cache.New[cacheKey, *types.AvatarImage](imageGetter{s: s}, config.CacheDuration)
+
+	return s
+}
+
+// VariantSize returns the size of the variant used for the requested size:
+// the smallest variant that isn't smaller than the requested size, or the largest variant.
+func VariantSize(requested int) int {
+	if requested <= 0 {
+		return DefaultSize
+	}
+
+	for _, size := range Sizes {
+		if size >= requested {
+			return size
+		}
+	}
+
+	return Sizes[len(Sizes)-1]
+}
+
+// Upload stores the image as avatar of the principal or space, replacing any previous avatar.
+// The image is cropped to a square and re-encoded as PNG in all size variants.
+func (s *Service) Upload(
+	ctx context.Context,
+	ownerType enum.AvatarOwnerType,
+	ownerID int64,
+	createdBy int64,
+	in io.Reader,
+) (*types.Avatar, error) {
+	content, err := io.ReadAll(io.LimitReader(in, s.config.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar content: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, usererror.BadRequest("The avatar image can't be empty.")
+	}
+	if int64(len(content)) > s.config.MaxSize {
+		return nil, usererror.Newf(http.StatusRequestEntityTooLarge,
+			"The avatar image exceeds the max size of %d bytes.", s.config.MaxSize)
+	}
+
+	img, err := decodeImage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(content)
+	sha := hex.EncodeToString(checksum[:])
+
+	square := cropSquare(img)
+	for _, size := range Sizes {
+		var variant []byte
+		variant, err = encodePNG(resize(square, size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode avatar of size %d: %w", size, err)
+		}
+
+		if err = s.blobStore.Upload(ctx, bytes.NewReader(variant), blobPath(sha, size)); err != nil {
+			return nil, fmt.Errorf("failed to upload avatar of size %d: %w", size, err)
+		}
+	}
+
+	avatar := &types.Avatar{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		SHA256:    sha,
+		CreatedBy: createdBy,
+		Updated:   time.Now().UnixMilli(),
+	}
+
+	if err = s.avatarStore.Upsert(ctx, avatar); err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	return avatar, nil
+}
+
+// Delete removes the uploaded avatar of the principal or space.
+// The uploaded images are kept in the blob store, as the same image might be used by other avatars.
+func (s *Service) Delete(ctx context.Context, ownerType enum.AvatarOwnerType, ownerID int64) error {
+	if err := s.avatarStore.Delete(ctx, ownerType, ownerID); err != nil {
+		return fmt.Errorf("failed to delete avatar: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the avatar image of the principal or space in the variant closest to the requested size.
+func (s *Service) Get(
+	ctx context.Context,
+	ownerType enum.AvatarOwnerType,
+	ownerID int64,
+	size int,
+) (*types.AvatarImage, error) {
+	key := cacheKey{size: VariantSize(size)}
+
+	avatar, err := s.avatarStore.Find(ctx, ownerType, ownerID)
+	switch {
+	case err == nil:
+		key.sha256 = avatar.SHA256
+	case errors.Is(err, gitness_store.ErrResourceNotFound) && s.generator != nil:
+		key.seed = fmt.Sprintf("%s:%d", ownerType, ownerID)
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+		return nil, usererror.ErrNotFound
+	default:
+		return nil, fmt.Errorf("failed to find avatar: %w", err)
+	}
+
+	return s.cache.Get(ctx, key)
+}
+
+type imageGetter struct {
+	s *Service
+}
+
+func (g imageGetter) Find(ctx context.Context, key cacheKey) (*types.AvatarImage, error) {
+	if key.sha256 == "" {
+		content, err := encodePNG(g.s.generator.Generate(key.seed, key.size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode generated avatar: %w", err)
+		}
+
+		checksum := sha256.Sum256([]byte(key.seed))
+
+		return &types.AvatarImage{
+			ETag:        fmt.Sprintf("g-%x-%d", checksum[:8], key.size),
+			ContentType: "image/png",
+			Content:     content,
+		}, nil
+	}
+
+	reader, err := g.s.blobStore.Download(ctx, blobPath(key.sha256, key.size))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, usererror.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download avatar: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+
+	return &types.AvatarImage{
+		ETag:        fmt.Sprintf("%s-%d", key.sha256[:16], key.size),
+		ContentType: "image/png",
+		Content:     content,
+	}, nil
+}
+
+// blobPath returns the path of a size variant of an uploaded avatar in the blob store.
+func blobPath(sha256 string, size int) string {
+	return fmt.Sprintf("avatars/%s/%d.png", sha256, size)
+}
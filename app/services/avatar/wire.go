@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	avatarStore store.AvatarStore,
+	blobStore blob.Store,
+) (*Service, error) {
+	generator, err := GeneratorByName(config.Avatar.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(Config{
+		MaxSize:       config.Avatar.MaxSize,
+		CacheDuration: config.Avatar.CacheDuration,
+	}, avatarStore, blobStore, generator), nil
+}
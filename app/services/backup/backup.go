@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pullReqBatchSize is the number of pull requests read at once during the metadata export.
+const pullReqBatchSize = 100
+
+// backupJob takes the snapshot of a single repository.
+type backupJob struct {
+	s *Service
+}
+
+// Handle takes the snapshot of the repository and applies the retention to its backups.
+func (j *backupJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	backupID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid backup job input: %w", err)
+	}
+
+	backup, err := j.s.backupStore.Find(ctx, backupID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "backup was deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	repo, err := j.s.repoStore.Find(ctx, backup.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		j.s.updateState(ctx, backup, enum.BackupStateFailed, "repository was deleted")
+		return "repository was deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	j.s.updateState(ctx, backup, enum.BackupStateRunning, "")
+
+	if err = j.s.snapshot(ctx, repo, backup); err != nil {
+		j.s.updateState(ctx, backup, enum.BackupStateFailed, err.Error())
+		return "", err
+	}
+
+	j.s.updateState(ctx, backup, enum.BackupStateSucceeded, "")
+
+	if err = j.s.applyRetention(ctx, repo.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repo.ID).
+			Msg("failed to apply backup retention")
+	}
+
+	return fmt.Sprintf("backed up repository '%s' (%d bytes)", repo.Path, backup.BundleSize), nil
+}
+
+// snapshot uploads the metadata export and the git bundle of the repository to the blob store.
+func (s *Service) snapshot(ctx context.Context, repo *types.Repository, backup *types.Backup) error {
+	metadata, err := s.exportMetadata(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backup metadata: %w", err)
+	}
+
+	if err = s.blobStore.Upload(ctx, bytes.NewReader(raw), metadataPath(backup)); err != nil {
+		return fmt.Errorf("failed to upload backup metadata: %w", err)
+	}
+
+	backup.MetadataSize = int64(len(raw))
+
+	// the bundle is streamed from the git server straight into the blob store.
+	pr, pw := io.Pipe()
+	bundle := &countingWriter{w: pw}
+	errCh := make(chan error, 1)
+	go func() {
+		err := s.git.CreateBundle(ctx, &gitrpc.CreateBundleParams{
+			ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+		}, bundle)
+		_ = pw.CloseWithError(err)
+		errCh <- err
+	}()
+
+	errUpload := s.blobStore.Upload(ctx, pr, bundlePath(backup))
+	// unblock the bundle creation in case the upload stopped reading early.
+	_ = pr.CloseWithError(errUpload)
+
+	if err = <-errCh; err != nil {
+		return fmt.Errorf("failed to create git bundle: %w", err)
+	}
+	if errUpload != nil {
+		return fmt.Errorf("failed to upload git bundle: %w", errUpload)
+	}
+
+	backup.BundleSize = bundle.n
+
+	return nil
+}
+
+// exportMetadata reads the metadata of the repository in a single read-only transaction.
+func (s *Service) exportMetadata(ctx context.Context, repo *types.Repository) (*types.BackupMetadata, error) {
+	metadata := &types.BackupMetadata{
+		Version: metadataVersion,
+		Created: time.Now().UnixMilli(),
+		Repository: types.BackupRepository{
+			ID:            repo.ID,
+			Path:          repo.Path,
+			Description:   repo.Description,
+			IsPublic:      repo.IsPublic,
+			DefaultBranch: repo.DefaultBranch,
+			PullReqSeq:    repo.PullReqSeq,
+			CreatedBy:     repo.CreatedBy,
+			Created:       repo.Created,
+		},
+		PullReqs: []types.BackupPullReq{},
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for page := 1; ; page++ {
+			prs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+				Page:         page,
+				Size:         pullReqBatchSize,
+				TargetRepoID: repo.ID,
+				Sort:         enum.PullReqSortNumber,
+				Order:        enum.OrderAsc,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list pull requests: %w", err)
+			}
+
+			for _, pr := range prs {
+				if pr.SourceRepoID != pr.TargetRepoID {
+					continue
+				}
+				metadata.PullReqs = append(metadata.PullReqs, exportPullReq(pr))
+			}
+
+			if len(prs) < pullReqBatchSize {
+				return nil
+			}
+		}
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export repository metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func exportPullReq(pr *types.PullReq) types.BackupPullReq {
+	return types.BackupPullReq{
+		Number:         pr.Number,
+		CreatedBy:      pr.CreatedBy,
+		Created:        pr.Created,
+		Edited:         pr.Edited,
+		State:          pr.State,
+		IsDraft:        pr.IsDraft,
+		Title:          pr.Title,
+		Description:    pr.Description,
+		SourceBranch:   pr.SourceBranch,
+		SourceSHA:      pr.SourceSHA,
+		TargetBranch:   pr.TargetBranch,
+		MergedBy:       pr.MergedBy,
+		Merged:         pr.Merged,
+		MergeMethod:    pr.MergeMethod,
+		MergeTargetSHA: pr.MergeTargetSHA,
+		MergeBaseSHA:   pr.MergeBaseSHA,
+		MergeSHA:       pr.MergeSHA,
+	}
+}
+
+// applyRetention deletes the backups of the repository that are older than the last
+// RetentionCount successful backups. Backups that are still in progress are never deleted.
+func (s *Service) applyRetention(ctx context.Context, repoID int64) error {
+	backups, err := s.backupStore.ListByRepo(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	kept := 0
+	for _, backup := range backups {
+		if kept < s.config.RetentionCount {
+			if backup.State == enum.BackupStateSucceeded {
+				kept++
+			}
+			continue
+		}
+
+		if backup.State == enum.BackupStatePending || backup.State == enum.BackupStateRunning {
+			continue
+		}
+
+		if err = s.Delete(ctx, backup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the snapshot and the backup.
+func (s *Service) Delete(ctx context.Context, backup *types.Backup) error {
+	if err := s.blobStore.Delete(ctx, bundlePath(backup)); err != nil {
+		return fmt.Errorf("failed to delete git bundle of backup %d: %w", backup.ID, err)
+	}
+
+	if err := s.blobStore.Delete(ctx, metadataPath(backup)); err != nil {
+		return fmt.Errorf("failed to delete metadata of backup %d: %w", backup.ID, err)
+	}
+
+	if err := s.backupStore.Delete(ctx, backup.ID); err != nil {
+		return fmt.Errorf("failed to delete backup %d: %w", backup.ID, err)
+	}
+
+	return nil
+}
+
+// updateState updates the state of the backup, failures are only logged as they don't affect the snapshot.
+func (s *Service) updateState(ctx context.Context, backup *types.Backup, state enum.BackupState, msg string) {
+	backup.State = state
+	backup.Error = msg
+	backup.Updated = time.Now().UnixMilli()
+
+	if err := s.backupStore.Update(ctx, backup); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("backup_id", backup.ID).
+			Msgf("failed to update backup state to %s", state)
+	}
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,421 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotRestorable is returned if the backup didn't complete successfully.
+var ErrNotRestorable = errors.New("only successful backups can be restored")
+
+// restoreInput is the data of a restore job.
+type restoreInput struct {
+	BackupID      int64  `json:"backup_id"`
+	RepoID        int64  `json:"repo_id"`
+	UID           string `json:"uid"`
+	ReplaceRepoID int64  `json:"replace_repo_id,omitempty"`
+}
+
+// Restore creates a new repository from the snapshot of the backup.
+// The repository is in the importing state until the restore job completes.
+// If replace is provided, the new repository is created under a temporary UID and takes over the UID
+// of the replaced repository once the restore completes, at which point the replaced repository is moved to the trash.
+func (s *Service) Restore(
+	ctx context.Context,
+	backup *types.Backup,
+	parentID int64,
+	uid string,
+	replace *types.Repository,
+	principal *types.Principal,
+) (*types.Repository, error) {
+	if backup.State != enum.BackupStateSucceeded {
+		return nil, ErrNotRestorable
+	}
+
+	metadata, err := s.readMetadata(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	in := restoreInput{
+		BackupID: backup.ID,
+		UID:      uid,
+	}
+
+	repoUID := uid
+	if replace != nil {
+		in.ReplaceRepoID = replace.ID
+		repoUID = fmt.Sprintf("%s-restoring-%d", uid, backup.ID)
+	}
+
+	now := time.Now().UnixMilli()
+	repo := &types.Repository{
+		ParentID:      parentID,
+		UID:           repoUID,
+		GitUID:        fmt.Sprintf("restoring-%d-%d", backup.ID, now), // the git UID is set by the job handler
+		Description:   metadata.Repository.Description,
+		IsPublic:      metadata.Repository.IsPublic,
+		CreatedBy:     principal.ID,
+		Created:       now,
+		Updated:       now,
+		DefaultBranch: metadata.Repository.DefaultBranch,
+		Importing:     true,
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repoStore.Create(ctx, repo); err != nil {
+			return fmt.Errorf("failed to create repository: %w", err)
+		}
+
+		in.RepoID = repo.ID
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal restore job input: %w", err)
+		}
+
+		err = s.scheduler.RunJob(ctx, job.Definition{
+			UID:        restoreJobUID(repo.ID),
+			Type:       jobTypeRestore,
+			MaxRetries: 0,
+			Timeout:    s.config.Timeout,
+			Data:       string(data),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start restore job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// RestoreImpact returns what is overwritten by restoring the backup to the path, without restoring it.
+// The replaced repository is the repository currently at the path, if any.
+func (s *Service) RestoreImpact(
+	ctx context.Context,
+	backup *types.Backup,
+	path string,
+	replace *types.Repository,
+) (*types.BackupRestoreImpact, error) {
+	if backup.State != enum.BackupStateSucceeded {
+		return nil, ErrNotRestorable
+	}
+
+	metadata, err := s.readMetadata(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &types.BackupRestoreImpact{
+		Path:             path,
+		LostPullReqs:     []int64{},
+		RevertedPullReqs: []int64{},
+		RestoredPullReqs: len(metadata.PullReqs),
+	}
+
+	if replace == nil {
+		return impact, nil
+	}
+
+	impact.Repo = &types.ImpactedResource{ID: replace.ID, Path: replace.Path}
+
+	backedUp := make(map[int64]struct{}, len(metadata.PullReqs))
+	for i := range metadata.PullReqs {
+		backedUp[metadata.PullReqs[i].Number] = struct{}{}
+	}
+
+	for page := 1; ; page++ {
+		prs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+			Page:         page,
+			Size:         pullReqBatchSize,
+			TargetRepoID: replace.ID,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range prs {
+			_, ok := backedUp[pr.Number]
+			switch {
+			case !ok:
+				impact.LostPullReqs = append(impact.LostPullReqs, pr.Number)
+			case pr.Updated > backup.Created:
+				impact.RevertedPullReqs = append(impact.RevertedPullReqs, pr.Number)
+			}
+		}
+
+		if len(prs) < pullReqBatchSize {
+			return impact, nil
+		}
+	}
+}
+
+func (s *Service) readMetadata(ctx context.Context, backup *types.Backup) (*types.BackupMetadata, error) {
+	rc, err := s.blobStore.Download(ctx, metadataPath(backup))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download metadata of backup: %w", err)
+	}
+	defer rc.Close()
+
+	metadata := &types.BackupMetadata{}
+	if err = json.NewDecoder(rc).Decode(metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata of backup: %w", err)
+	}
+
+	if metadata.Version > metadataVersion {
+		return nil, fmt.Errorf("unsupported backup metadata version %d", metadata.Version)
+	}
+
+	return metadata, nil
+}
+
+// restoreJob restores the snapshot of a backup into a new repository.
+type restoreJob struct {
+	s *Service
+}
+
+// Handle restores the git repository and the pull requests, then marks the repository as available.
+func (j *restoreJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var in restoreInput
+	if err := json.Unmarshal([]byte(data), &in); err != nil {
+		return "", fmt.Errorf("failed to unmarshal restore job input: %w", err)
+	}
+
+	repo, err := j.s.repoStore.Find(ctx, in.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if !repo.Importing {
+		return "", fmt.Errorf("repository %s is not being restored", repo.UID)
+	}
+
+	backup, err := j.s.backupStore.Find(ctx, in.BackupID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	metadata, err := j.s.readMetadata(ctx, backup)
+	if err != nil {
+		return "", err
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Int64("backup.id", backup.ID).
+		Logger()
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	writeParams, err := j.s.createGitRepository(ctx, &systemPrincipal, repo)
+	if err != nil {
+		return "", err
+	}
+
+	err = func() error {
+		bundle, err := j.s.blobStore.Download(ctx, bundlePath(backup))
+		if err != nil {
+			return fmt.Errorf("failed to download git bundle: %w", err)
+		}
+		defer bundle.Close()
+
+		err = j.s.git.RestoreBundle(ctx, &gitrpc.RestoreBundleParams{
+			WriteParams: writeParams,
+			Bundle:      bundle,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore git bundle: %w", err)
+		}
+
+		return j.s.restoreMetadata(ctx, repo, writeParams.RepoUID, &in, metadata)
+	}()
+	if err != nil {
+		log.Error().Err(err).Msg("failed repository restore - cleanup git repository")
+
+		errDel := j.s.git.DeleteRepository(ctx, &gitrpc.DeleteRepositoryParams{WriteParams: writeParams})
+		if errDel != nil {
+			log.Warn().Err(errDel).Msg("failed to delete git repository after failed restore")
+		}
+
+		return "", fmt.Errorf("failed to restore repository: %w", err)
+	}
+
+	log.Info().Msg("completed repository restore")
+
+	return fmt.Sprintf("restored backup %d into repository '%s'", backup.ID, repo.Path), nil
+}
+
+// restoreMetadata recreates the pull requests with their original numbers and completes the restore.
+func (s *Service) restoreMetadata(
+	ctx context.Context,
+	repo *types.Repository,
+	gitUID string,
+	in *restoreInput,
+	metadata *types.BackupMetadata,
+) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if in.ReplaceRepoID != 0 {
+			err := s.repoStore.SoftDelete(ctx, in.ReplaceRepoID, time.Now().UnixMilli())
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to move replaced repository to the trash: %w", err)
+			}
+		}
+
+		var numOpen, numClosed, numMerged int
+		pullReqSeq := metadata.Repository.PullReqSeq
+
+		for i := range metadata.PullReqs {
+			pr := importPullReq(&metadata.PullReqs[i], repo.ID)
+			if err := s.pullreqStore.Create(ctx, pr); err != nil {
+				return fmt.Errorf("failed to create pull request #%d: %w", pr.Number, err)
+			}
+
+			switch pr.State {
+			case enum.PullReqStateOpen:
+				numOpen++
+			case enum.PullReqStateClosed:
+				numClosed++
+			case enum.PullReqStateMerged:
+				numMerged++
+			}
+
+			if pr.Number > pullReqSeq {
+				pullReqSeq = pr.Number
+			}
+		}
+
+		_, err := s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			if !repo.Importing {
+				return errors.New("repository has already finished restoring")
+			}
+
+			if in.UID != "" {
+				repo.UID = in.UID
+			}
+			repo.GitUID = gitUID
+			repo.PullReqSeq = pullReqSeq
+			repo.NumPulls = len(metadata.PullReqs)
+			repo.NumOpenPulls = numOpen
+			repo.NumClosedPulls = numClosed
+			repo.NumMergedPulls = numMerged
+			repo.Importing = false
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository after restore: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func importPullReq(in *types.BackupPullReq, repoID int64) *types.PullReq {
+	now := time.Now().UnixMilli()
+	return &types.PullReq{
+		Number:           in.Number,
+		CreatedBy:        in.CreatedBy,
+		Created:          in.Created,
+		Updated:          now,
+		Edited:           in.Edited,
+		State:            in.State,
+		IsDraft:          in.IsDraft,
+		Title:            in.Title,
+		Description:      in.Description,
+		SourceRepoID:     repoID,
+		SourceBranch:     in.SourceBranch,
+		SourceSHA:        in.SourceSHA,
+		TargetRepoID:     repoID,
+		TargetBranch:     in.TargetBranch,
+		MergedBy:         in.MergedBy,
+		Merged:           in.Merged,
+		MergeMethod:      in.MergeMethod,
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		MergeTargetSHA:   in.MergeTargetSHA,
+		MergeBaseSHA:     in.MergeBaseSHA,
+		MergeSHA:         in.MergeSHA,
+	}
+}
+
+// createGitRepository creates the empty git repository the bundle is restored into.
+func (s *Service) createGitRepository(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) (gitrpc.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+	)
+	if err != nil {
+		return gitrpc.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	defaultBranch := repo.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = s.config.DefaultBranch
+	}
+
+	now := time.Now()
+	identity := gitrpc.Identity{
+		Name:  principal.DisplayName,
+		Email: principal.Email,
+	}
+
+	resp, err := s.git.CreateRepository(ctx, &gitrpc.CreateRepositoryParams{
+		Actor:         identity,
+		EnvVars:       envVars,
+		DefaultBranch: defaultBranch,
+		Author:        &identity,
+		AuthorDate:    &now,
+		Committer:     &identity,
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return gitrpc.WriteParams{}, fmt.Errorf("failed to create empty git repository: %w", err)
+	}
+
+	return gitrpc.WriteParams{
+		Actor:   identity,
+		RepoUID: resp.UID,
+		EnvVars: envVars,
+	}, nil
+}
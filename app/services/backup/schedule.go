@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const repoBatchSize = 100
+
+// scheduleJob starts the backups of all repositories.
+type scheduleJob struct {
+	s *Service
+}
+
+// Handle starts a backup job for every repository, except the ones that are being imported.
+func (j *scheduleJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !j.s.config.Enabled {
+		return "scheduled backups are disabled", nil
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	started := 0
+	afterID := int64(0)
+	for {
+		repos, err := j.s.repoStore.ListAll(ctx, afterID, repoBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			afterID = repo.ID
+
+			if repo.Importing {
+				continue
+			}
+
+			_, err = j.s.Trigger(ctx, repo, enum.BackupTriggerScheduled, systemPrincipal.ID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", repo.ID).
+					Msg("failed to start repository backup")
+				continue
+			}
+			started++
+		}
+
+		if len(repos) < repoBatchSize {
+			break
+		}
+	}
+
+	result := fmt.Sprintf("started %d backups", started)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
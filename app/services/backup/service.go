@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup takes snapshots of repositories and restores them.
+//
+// A snapshot consists of a git bundle with all references of the repository (branches, tags and pull request
+// references) and a JSON export of the repository metadata (repository settings and pull requests).
+// Both are stored in the blob store under backups/<repo_id>/<backup_id>/. The metadata is exported before the
+// bundle is created, so every pull request in the metadata has its references in the bundle.
+//
+// Snapshots are restored into a new repository with the restore admin API (or `gitness backups restore`):
+// the repository is created in the importing state, the bundle is fetched into an empty git repository,
+// the pull requests are recreated with their original numbers and finally the repository becomes available.
+// Comments, reviews and other pull request activities aren't part of the snapshot.
+//
+// A snapshot can also be restored over an existing repository, usually to the original path of the repository.
+// The restored repository is created under a temporary identifier and takes over the path in the same
+// transaction that moves the replaced repository to the trash, so the replaced repository stays available
+// until the restore completes and can still be recovered from the trash afterwards.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
+)
+
+const (
+	jobTypeSchedule        = "gitness:backup:schedule"
+	jobMaxDurationSchedule = 10 * time.Minute
+
+	jobTypeBackup  = "gitness:backup:backup"
+	jobTypeRestore = "gitness:backup:restore"
+
+	// metadataVersion is the version of the metadata export, it's incremented on incompatible changes.
+	metadataVersion = 1
+)
+
+type Config struct {
+	// Enabled turns on the scheduled backups of all repositories, on-demand backups are always available.
+	Enabled bool
+	Cron    string
+	// RetentionCount is the number of successful backups kept per repository.
+	RetentionCount int
+	Timeout        time.Duration
+	MaxRetries     int
+	DefaultBranch  string
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if _, err := cronexpr.Parse(c.Cron); err != nil {
+		return fmt.Errorf("config.Cron is invalid: %w", err)
+	}
+	if c.RetentionCount < 1 {
+		return errors.New("config.RetentionCount has to be a positive number")
+	}
+	if c.Timeout < time.Minute {
+		return errors.New("config.Timeout has to be at least a minute")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.DefaultBranch == "" {
+		return errors.New("config.DefaultBranch is required")
+	}
+
+	return nil
+}
+
+// Service takes scheduled and on-demand snapshots of repositories, applies the retention
+// and restores snapshots into new repositories. Snapshots are taken and restored by background jobs.
+type Service struct {
+	config       Config
+	tx           dbtx.Transactor
+	repoStore    store.RepoStore
+	pullreqStore store.PullReqStore
+	backupStore  store.BackupStore
+	blobStore    blob.Store
+	git          gitrpc.Interface
+	urlProvider  gitnessurl.Provider
+	scheduler    *job.Scheduler
+	executor     *job.Executor
+}
+
+func NewService(
+	config Config,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	backupStore store.BackupStore,
+	blobStore blob.Store,
+	git gitrpc.Interface,
+	urlProvider gitnessurl.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided backup service config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:       config,
+		tx:           tx,
+		repoStore:    repoStore,
+		pullreqStore: pullreqStore,
+		backupStore:  backupStore,
+		blobStore:    blobStore,
+		git:          git,
+		urlProvider:  urlProvider,
+		scheduler:    scheduler,
+		executor:     executor,
+	}, nil
+}
+
+// Register registers the backup job handlers and schedules the periodic backups if they're enabled.
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobTypeSchedule, &scheduleJob{s: s}); err != nil {
+		return fmt.Errorf("failed to register backup schedule job handler: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypeBackup, &backupJob{s: s}); err != nil {
+		return fmt.Errorf("failed to register backup job handler: %w", err)
+	}
+
+	if err := s.executor.Register(jobTypeRestore, &restoreJob{s: s}); err != nil {
+		return fmt.Errorf("failed to register restore job handler: %w", err)
+	}
+
+	if !s.config.Enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeSchedule, jobTypeSchedule, s.config.Cron, jobMaxDurationSchedule)
+	if err != nil {
+		return fmt.Errorf("failed to schedule backup job: %w", err)
+	}
+
+	return nil
+}
+
+// Trigger starts a backup of the repository.
+func (s *Service) Trigger(
+	ctx context.Context,
+	repo *types.Repository,
+	trigger enum.BackupTrigger,
+	principalID int64,
+) (*types.Backup, error) {
+	now := time.Now().UnixMilli()
+	backup := &types.Backup{
+		RepoID:    repo.ID,
+		RepoPath:  repo.Path,
+		State:     enum.BackupStatePending,
+		Trigger:   trigger,
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.backupStore.Create(ctx, backup); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+
+		err := s.scheduler.RunJob(ctx, job.Definition{
+			UID:        backupJobUID(backup.ID),
+			Type:       jobTypeBackup,
+			MaxRetries: s.config.MaxRetries,
+			Timeout:    s.config.Timeout,
+			Data:       strconv.FormatInt(backup.ID, 10),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start backup job: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+func backupJobUID(backupID int64) string {
+	return "backup-" + strconv.FormatInt(backupID, 10)
+}
+
+func restoreJobUID(repoID int64) string {
+	return "backup-restore-" + strconv.FormatInt(repoID, 10)
+}
+
+func bundlePath(backup *types.Backup) string {
+	return fmt.Sprintf("backups/%d/%d/repo.bundle", backup.RepoID, backup.ID)
+}
+
+func metadataPath(backup *types.Backup) string {
+	return fmt.Sprintf("backups/%d/%d/metadata.json", backup.RepoID, backup.ID)
+}
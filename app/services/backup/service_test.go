@@ -0,0 +1,386 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s fakePullReqStore) List(_ context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	var result []*types.PullReq
+	for _, pr := range s.prs {
+		if pr.TargetRepoID == filter.TargetRepoID {
+			result = append(result, pr)
+		}
+	}
+	return result, nil
+}
+
+// fakeBackupStore keeps the backups ordered newest first, like the database store.
+type fakeBackupStore struct {
+	store.BackupStore
+	backups []*types.Backup
+}
+
+func (s *fakeBackupStore) Find(_ context.Context, id int64) (*types.Backup, error) {
+	for _, backup := range s.backups {
+		if backup.ID == id {
+			return backup, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeBackupStore) Update(_ context.Context, backup *types.Backup) error {
+	for i := range s.backups {
+		if s.backups[i].ID == backup.ID {
+			s.backups[i] = backup
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeBackupStore) ListByRepo(_ context.Context, repoID int64) ([]*types.Backup, error) {
+	var result []*types.Backup
+	for _, backup := range s.backups {
+		if backup.RepoID == repoID {
+			result = append(result, backup)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeBackupStore) Delete(_ context.Context, id int64) error {
+	for i := range s.backups {
+		if s.backups[i].ID == id {
+			s.backups = append(s.backups[:i], s.backups[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeBackupStore) ids() []int64 {
+	ids := make([]int64, len(s.backups))
+	for i, backup := range s.backups {
+		ids[i] = backup.ID
+	}
+	return ids
+}
+
+type fakeBlobStore struct {
+	blob.Store
+	mx    sync.Mutex
+	files map[string][]byte
+}
+
+func (s *fakeBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.files[filePath] = data
+
+	return nil
+}
+
+func (s *fakeBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, filePath string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	delete(s.files, filePath)
+	return nil
+}
+
+type fakeGitRPC struct {
+	gitrpc.Interface
+	bundle []byte
+	err    error
+}
+
+func (g fakeGitRPC) CreateBundle(_ context.Context, _ *gitrpc.CreateBundleParams, w io.Writer) error {
+	if g.err != nil {
+		return g.err
+	}
+	_, err := w.Write(g.bundle)
+	return err
+}
+
+func newTestService(backups *fakeBackupStore, blobs *fakeBlobStore, git gitrpc.Interface,
+	prs []*types.PullReq) *Service {
+	return &Service{
+		config: Config{RetentionCount: 2},
+		tx:     fakeTransactor{},
+		repoStore: fakeRepoStore{repos: map[int64]*types.Repository{
+			1: {ID: 1, Path: "space/repo", GitUID: "git-1", DefaultBranch: "main", PullReqSeq: 2},
+		}},
+		pullreqStore: fakePullReqStore{prs: prs},
+		backupStore:  backups,
+		blobStore:    blobs,
+		git:          git,
+	}
+}
+
+func TestBackupJob(t *testing.T) {
+	backups := &fakeBackupStore{backups: []*types.Backup{
+		{ID: 6, RepoID: 1, State: enum.BackupStatePending},
+		{ID: 5, RepoID: 1, State: enum.BackupStateRunning},
+		{ID: 4, RepoID: 1, State: enum.BackupStateSucceeded},
+		{ID: 3, RepoID: 1, State: enum.BackupStateFailed},
+		{ID: 2, RepoID: 1, State: enum.BackupStateSucceeded},
+		{ID: 1, RepoID: 1, State: enum.BackupStateSucceeded},
+		{ID: 7, RepoID: 2, State: enum.BackupStateSucceeded},
+	}}
+	blobs := &fakeBlobStore{files: map[string][]byte{}}
+	for _, backup := range backups.backups {
+		if backup.State == enum.BackupStateSucceeded {
+			blobs.files[bundlePath(backup)] = []byte("bundle")
+			blobs.files[metadataPath(backup)] = []byte("{}")
+		}
+	}
+
+	prs := []*types.PullReq{
+		{Number: 1, SourceRepoID: 1, TargetRepoID: 1, Title: "first", State: enum.PullReqStateMerged},
+		{Number: 2, SourceRepoID: 3, TargetRepoID: 1, Title: "fork", State: enum.PullReqStateOpen},
+	}
+
+	s := newTestService(backups, blobs, fakeGitRPC{bundle: []byte("git bundle")}, prs)
+
+	j := &backupJob{s: s}
+	if _, err := j.Handle(context.Background(), "6", nil); err != nil {
+		t.Fatalf("failed to handle backup job: %v", err)
+	}
+
+	backup, err := backups.Find(context.Background(), 6)
+	if err != nil {
+		t.Fatalf("backup was deleted: %v", err)
+	}
+	if backup.State != enum.BackupStateSucceeded || backup.Error != "" {
+		t.Errorf("expected succeeded backup, got %s (%q)", backup.State, backup.Error)
+	}
+	if backup.BundleSize != int64(len("git bundle")) {
+		t.Errorf("expected bundle size %d, got %d", len("git bundle"), backup.BundleSize)
+	}
+	if got := string(blobs.files[bundlePath(backup)]); got != "git bundle" {
+		t.Errorf("unexpected bundle content %q", got)
+	}
+
+	raw := blobs.files[metadataPath(backup)]
+	if backup.MetadataSize != int64(len(raw)) {
+		t.Errorf("expected metadata size %d, got %d", len(raw), backup.MetadataSize)
+	}
+
+	metadata := &types.BackupMetadata{}
+	if err = json.Unmarshal(raw, metadata); err != nil {
+		t.Fatalf("failed to decode metadata: %v", err)
+	}
+	if metadata.Version != metadataVersion || metadata.Repository.Path != "space/repo" ||
+		metadata.Repository.PullReqSeq != 2 {
+		t.Errorf("unexpected repository metadata: %+v", metadata.Repository)
+	}
+	if len(metadata.PullReqs) != 1 || metadata.PullReqs[0].Number != 1 {
+		t.Errorf("expected only the pull request without fork, got %+v", metadata.PullReqs)
+	}
+
+	// the two newest successful backups are kept, older ones are deleted unless they're in progress.
+	if want := []int64{6, 5, 4, 7}; !reflect.DeepEqual(backups.ids(), want) {
+		t.Errorf("expected backups %v after retention, got %v", want, backups.ids())
+	}
+	for _, id := range []int64{1, 2} {
+		deleted := &types.Backup{ID: id, RepoID: 1}
+		if _, ok := blobs.files[bundlePath(deleted)]; ok {
+			t.Errorf("expected bundle of backup %d to be deleted", id)
+		}
+		if _, ok := blobs.files[metadataPath(deleted)]; ok {
+			t.Errorf("expected metadata of backup %d to be deleted", id)
+		}
+	}
+	if _, ok := blobs.files[bundlePath(&types.Backup{ID: 4, RepoID: 1})]; !ok {
+		t.Error("expected bundle of backup 4 to be kept")
+	}
+}
+
+func TestBackupJobFailure(t *testing.T) {
+	backups := &fakeBackupStore{backups: []*types.Backup{
+		{ID: 2, RepoID: 1, State: enum.BackupStatePending},
+		{ID: 1, RepoID: 1, State: enum.BackupStateFailed},
+	}}
+	blobs := &fakeBlobStore{files: map[string][]byte{}}
+
+	s := newTestService(backups, blobs, fakeGitRPC{err: errors.New("git failure")}, nil)
+
+	j := &backupJob{s: s}
+	if _, err := j.Handle(context.Background(), "2", nil); err == nil {
+		t.Fatal("expected backup job to fail")
+	}
+
+	backup, _ := backups.Find(context.Background(), 2)
+	if backup.State != enum.BackupStateFailed || backup.Error == "" {
+		t.Errorf("expected failed backup with error, got %s (%q)", backup.State, backup.Error)
+	}
+
+	// the retention isn't applied after a failed backup.
+	if want := []int64{2, 1}; !reflect.DeepEqual(backups.ids(), want) {
+		t.Errorf("expected backups %v, got %v", want, backups.ids())
+	}
+}
+
+func TestBackupJobDeletedRepo(t *testing.T) {
+	backups := &fakeBackupStore{backups: []*types.Backup{
+		{ID: 1, RepoID: 99, State: enum.BackupStatePending},
+	}}
+
+	s := newTestService(backups, &fakeBlobStore{files: map[string][]byte{}}, fakeGitRPC{}, nil)
+
+	j := &backupJob{s: s}
+	if _, err := j.Handle(context.Background(), "1", nil); err != nil {
+		t.Fatalf("expected no error for deleted repository, got %v", err)
+	}
+
+	if backups.backups[0].State != enum.BackupStateFailed {
+		t.Errorf("expected failed backup, got %s", backups.backups[0].State)
+	}
+}
+
+func TestRestoreImpact(t *testing.T) {
+	created := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	backup := &types.Backup{ID: 1, RepoID: 1, State: enum.BackupStateSucceeded, Created: created}
+
+	metadata, _ := json.Marshal(types.BackupMetadata{
+		Version:  metadataVersion,
+		PullReqs: []types.BackupPullReq{{Number: 1}, {Number: 2}},
+	})
+	blobs := &fakeBlobStore{files: map[string][]byte{metadataPath(backup): metadata}}
+
+	prs := []*types.PullReq{
+		{Number: 1, TargetRepoID: 1, Updated: created - 1},
+		{Number: 2, TargetRepoID: 1, Updated: created + 1},
+		{Number: 3, TargetRepoID: 1, Updated: created + 1},
+	}
+
+	s := newTestService(&fakeBackupStore{}, blobs, fakeGitRPC{}, prs)
+
+	replace := &types.Repository{ID: 1, Path: "space/repo"}
+	impact, err := s.RestoreImpact(context.Background(), backup, "space/repo", replace)
+	if err != nil {
+		t.Fatalf("failed to get restore impact: %v", err)
+	}
+
+	want := &types.BackupRestoreImpact{
+		Path:             "space/repo",
+		Repo:             &types.ImpactedResource{ID: 1, Path: "space/repo"},
+		LostPullReqs:     []int64{3},
+		RevertedPullReqs: []int64{2},
+		RestoredPullReqs: 2,
+	}
+	if !reflect.DeepEqual(impact, want) {
+		t.Errorf("expected impact %+v, got %+v", want, impact)
+	}
+
+	impact, err = s.RestoreImpact(context.Background(), backup, "space/new", nil)
+	if err != nil {
+		t.Fatalf("failed to get restore impact: %v", err)
+	}
+	if impact.Repo != nil || len(impact.LostPullReqs) != 0 || impact.RestoredPullReqs != 2 {
+		t.Errorf("expected no impact on a new path, got %+v", impact)
+	}
+
+	_, err = s.RestoreImpact(context.Background(), &types.Backup{State: enum.BackupStateFailed}, "space/repo", nil)
+	if !errors.Is(err, ErrNotRestorable) {
+		t.Errorf("expected ErrNotRestorable for failed backup, got %v", err)
+	}
+}
+
+func TestConfigPrepare(t *testing.T) {
+	valid := Config{
+		Cron:           "0 2 * * *",
+		RetentionCount: 7,
+		Timeout:        time.Hour,
+		DefaultBranch:  "main",
+	}
+	if err := valid.Prepare(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	tests := map[string]func(c *Config){
+		"invalid cron":      func(c *Config) { c.Cron = "every day" },
+		"no retention":      func(c *Config) { c.RetentionCount = 0 },
+		"short timeout":     func(c *Config) { c.Timeout = time.Second },
+		"negative retries":  func(c *Config) { c.MaxRetries = -1 },
+		"no default branch": func(c *Config) { c.DefaultBranch = "" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := valid
+			mutate(&config)
+			if err := config.Prepare(); err == nil {
+				t.Error("expected invalid config")
+			}
+		})
+	}
+}
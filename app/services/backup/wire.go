@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	backupStore store.BackupStore,
+	blobStore blob.Store,
+	git gitrpc.Interface,
+	urlProvider gitnessurl.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	return NewService(
+		config,
+		tx,
+		repoStore,
+		pullreqStore,
+		backupStore,
+		blobStore,
+		git,
+		urlProvider,
+		scheduler,
+		executor,
+	)
+}
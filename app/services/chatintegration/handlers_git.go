@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+const gitReferenceNamePrefixBranch = "refs/heads/"
+
+// handleEventBranchCreated posts a push message for a newly created branch.
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	branch := strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixBranch)
+
+	return s.deliver(ctx, repo, enum.ChatIntegrationTriggerPush, &message{
+		Title: fmt.Sprintf("[%s] %s created branch %s", repo.Path, principal.DisplayName, branch),
+		Text:  fmt.Sprintf("Branch %s points to %s.", branch, shortSHA(event.Payload.SHA)),
+		URL:   s.urlProvider.GenerateUIRepoURL(repo.Path),
+	})
+}
+
+// handleEventBranchUpdated posts a push message for an updated branch.
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	branch := strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixBranch)
+
+	text := fmt.Sprintf("Branch %s was updated from %s to %s.",
+		branch, shortSHA(event.Payload.OldSHA), shortSHA(event.Payload.NewSHA))
+	if event.Payload.Forced {
+		text = fmt.Sprintf("Branch %s was force-pushed from %s to %s.",
+			branch, shortSHA(event.Payload.OldSHA), shortSHA(event.Payload.NewSHA))
+	}
+
+	return s.deliver(ctx, repo, enum.ChatIntegrationTriggerPush, &message{
+		Title: fmt.Sprintf("[%s] %s pushed to %s", repo.Path, principal.DisplayName, branch),
+		Text:  text,
+		URL:   s.urlProvider.GenerateUICompareURL(repo.Path, event.Payload.OldSHA, event.Payload.NewSHA),
+	})
+}
+
+func shortSHA(sha string) string {
+	const shortLength = 8
+	if len(sha) <= shortLength {
+		return sha
+	}
+
+	return sha[:shortLength]
+}
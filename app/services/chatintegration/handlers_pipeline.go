@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventExecutionCompleted posts a message about the result of a pipeline execution.
+func (s *Service) handleEventExecutionCompleted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionCompletedPayload]) error {
+	var trigger enum.ChatIntegrationTrigger
+	switch event.Payload.Status {
+	case enum.CIStatusSuccess:
+		trigger = enum.ChatIntegrationTriggerPipelineSucceeded
+	case enum.CIStatusFailure, enum.CIStatusError:
+		trigger = enum.ChatIntegrationTriggerPipelineFailed
+	default:
+		// other final states (e.g. killed or skipped) aren't reported.
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, event.Payload.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	return s.deliver(ctx, repo, trigger, &message{
+		Title: fmt.Sprintf("[%s] Pipeline %s #%d: %s", repo.Path, pipeline.UID, event.Payload.Number,
+			event.Payload.Status),
+		Text: fmt.Sprintf("Execution #%d of pipeline %s finished with status %s.",
+			event.Payload.Number, pipeline.UID, event.Payload.Status),
+		URL: s.urlProvider.GenerateUIRepoURL(repo.Path),
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventPullReqCreated posts a message about a newly created pull request.
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, enum.ChatIntegrationTriggerPullReqCreated,
+		func(actor, branches string) (string, string) {
+			return "opened", fmt.Sprintf("%s wants to merge %s.", actor, branches)
+		})
+}
+
+// handleEventPullReqMerged posts a message about a merged pull request.
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, enum.ChatIntegrationTriggerPullReqMerged,
+		func(actor, branches string) (string, string) {
+			return "merged", fmt.Sprintf("%s merged %s using %s.", actor, branches, event.Payload.MergeMethod)
+		})
+}
+
+// handleEventPullReqClosed posts a message about a pull request closed without merging.
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, enum.ChatIntegrationTriggerPullReqClosed,
+		func(actor, _ string) (string, string) {
+			return "closed", fmt.Sprintf("%s closed the pull request.", actor)
+		})
+}
+
+// handleEventPullReqReviewReminder posts a message about a requested review that is overdue.
+func (s *Service) handleEventPullReqReviewReminder(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewReminderPayload]) error {
+	reviewer, err := s.principalStore.Find(ctx, event.Payload.ReviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to find reviewer: %w", err)
+	}
+
+	pending := time.Since(time.UnixMilli(event.Payload.Requested))
+
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, enum.ChatIntegrationTriggerPullReqReviewReminder,
+		func(actor, _ string) (string, string) {
+			return "awaits review", fmt.Sprintf("%s requested a review from %s %d hours ago, the review is still pending.",
+				actor, reviewer.DisplayName, int(pending.Hours()))
+		})
+}
+
+// handlePullReqEvent loads the data of the pull request event and delivers the message
+// with the action and text provided by the describe function.
+func (s *Service) handlePullReqEvent(
+	ctx context.Context,
+	base *pullreqevents.Base,
+	trigger enum.ChatIntegrationTrigger,
+	describe func(actor, branches string) (action string, text string),
+) error {
+	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, base.PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	action, text := describe(principal.DisplayName, fmt.Sprintf("%s into %s", pr.SourceBranch, pr.TargetBranch))
+
+	return s.deliver(ctx, repo, trigger, &message{
+		Title: fmt.Sprintf("[%s] Pull request #%d %s: %s", repo.Path, pr.Number, action, pr.Title),
+		Text:  text,
+		URL:   s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number),
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// message is the provider independent representation of a chat message.
+type message struct {
+	Title string
+	Text  string
+	URL   string
+}
+
+// format converts the message into the payload expected by the incoming webhook of the chat provider.
+func (m *message) format(provider enum.ChatProvider) ([]byte, error) {
+	var payload any
+	switch provider {
+	case enum.ChatProviderSlack:
+		payload = m.slackPayload()
+	case enum.ChatProviderTeams:
+		payload = m.teamsPayload()
+	case enum.ChatProviderDiscord:
+		payload = m.discordPayload()
+	default:
+		return nil, fmt.Errorf("chat provider '%s' is not supported", provider)
+	}
+
+	// chat providers don't render html, keep the characters as they are.
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(payload); err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// slackPayload formats the message using the slack mrkdwn syntax.
+func (m *message) slackPayload() any {
+	text := fmt.Sprintf("*%s*", escapeSlack(m.Title))
+	if m.URL != "" {
+		text = fmt.Sprintf("*<%s|%s>*", m.URL, escapeSlack(m.Title))
+	}
+	if m.Text != "" {
+		text += "\n" + escapeSlack(m.Text)
+	}
+
+	return struct {
+		Text string `json:"text"`
+	}{
+		Text: text,
+	}
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+// teamsPayload formats the message as legacy actionable message card supported by teams connectors.
+func (m *message) teamsPayload() any {
+	var actions []teamsAction
+	if m.URL != "" {
+		actions = []teamsAction{{
+			Type:    "OpenUri",
+			Name:    "View",
+			Targets: []teamsTarget{{OS: "default", URI: m.URL}},
+		}}
+	}
+
+	return struct {
+		Type            string        `json:"@type"`
+		Context         string        `json:"@context"`
+		Summary         string        `json:"summary"`
+		Title           string        `json:"title"`
+		Text            string        `json:"text,omitempty"`
+		PotentialAction []teamsAction `json:"potentialAction,omitempty"`
+	}{
+		Type:            "MessageCard",
+		Context:         "https://schema.org/extensions",
+		Summary:         m.Title,
+		Title:           m.Title,
+		Text:            m.Text,
+		PotentialAction: actions,
+	}
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// discordPayload formats the message as a single discord embed.
+func (m *message) discordPayload() any {
+	return struct {
+		Embeds []discordEmbed `json:"embeds"`
+	}{
+		Embeds: []discordEmbed{{
+			Title:       m.Title,
+			Description: m.Text,
+			URL:         m.URL,
+		}},
+	}
+}
+
+// slackEscaper escapes the control characters of the slack message format.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeSlack(s string) string {
+	return slackEscaper.Replace(s)
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMessageFormat(t *testing.T) {
+	msg := &message{
+		Title: "[space/repo] Pull request #1 opened: <fix>",
+		Text:  "user wants to merge a into b.",
+		URL:   "https://gitness.example.com/space/repo/pulls/1",
+	}
+
+	tests := []struct {
+		name     string
+		provider enum.ChatProvider
+		want     string
+	}{
+		{
+			name:     "slack",
+			provider: enum.ChatProviderSlack,
+			want: `{"text":"*<https://gitness.example.com/space/repo/pulls/1|` +
+				`[space/repo] Pull request #1 opened: &lt;fix&gt;>*\n` +
+				`user wants to merge a into b."}`,
+		},
+		{
+			name:     "teams",
+			provider: enum.ChatProviderTeams,
+			want: `{"@type":"MessageCard","@context":"https://schema.org/extensions",` +
+				`"summary":"[space/repo] Pull request #1 opened: <fix>",` +
+				`"title":"[space/repo] Pull request #1 opened: <fix>",` +
+				`"text":"user wants to merge a into b.",` +
+				`"potentialAction":[{"@type":"OpenUri","name":"View",` +
+				`"targets":[{"os":"default","uri":"https://gitness.example.com/space/repo/pulls/1"}]}]}`,
+		},
+		{
+			name:     "discord",
+			provider: enum.ChatProviderDiscord,
+			want: `{"embeds":[{"title":"[space/repo] Pull request #1 opened: <fix>",` +
+				`"description":"user wants to merge a into b.",` +
+				`"url":"https://gitness.example.com/space/repo/pulls/1"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := msg.format(tt.provider)
+			if err != nil {
+				t.Fatalf("format() returned unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageFormatUnknownProvider(t *testing.T) {
+	msg := &message{Title: "title"}
+	if _, err := msg.format("irc"); err == nil {
+		t.Error("format() expected error for unknown provider")
+	}
+}
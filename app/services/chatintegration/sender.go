@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/version"
+)
+
+const (
+	// sendTimeLimit defines the time limit of a single request to the chat provider.
+	sendTimeLimit = 10 * time.Second
+
+	// retryBackoffBase defines the wait time before the first retry, it doubles with every retry.
+	retryBackoffBase = 1 * time.Second
+
+	// retryWaitLimit defines the maximum wait time between retries (including Retry-After response headers).
+	retryWaitLimit = 1 * time.Minute
+
+	// responseBodyBytesLimit defines the maximum number of bytes read from an error response body.
+	responseBodyBytesLimit = 1024
+)
+
+// sendError is returned by the sender in case the chat provider didn't accept the message.
+type sendError struct {
+	statusCode int
+	body       string
+	retryAfter time.Duration
+}
+
+func (e *sendError) Error() string {
+	return fmt.Sprintf("chat provider responded with status code %d: %s", e.statusCode, e.body)
+}
+
+// retriable returns true if the request can be repeated (rate limited or server error).
+func (e *sendError) retriable() bool {
+	return e.statusCode == http.StatusTooManyRequests || e.statusCode >= http.StatusInternalServerError
+}
+
+// sender posts messages to the incoming webhooks of chat providers.
+// Messages to the same integration are rate limited, failed requests are retried with exponential backoff.
+type sender struct {
+	client      *http.Client
+	userAgent   string
+	minInterval time.Duration
+	maxRetries  int
+
+	mx       sync.Mutex
+	nextSend map[int64]time.Time
+}
+
+func newSender(
+	clientFactory *outbound.ClientFactory,
+	userAgentIdentity string,
+	rateLimit int,
+	maxRetries int,
+) *sender {
+	minInterval := time.Duration(0)
+	if rateLimit > 0 {
+		minInterval = time.Minute / time.Duration(rateLimit)
+	}
+
+	return &sender{
+		client:      clientFactory.Client(outbound.ClientOptions{Timeout: sendTimeLimit}),
+		userAgent:   fmt.Sprintf("%s/%s", userAgentIdentity, version.Version),
+		minInterval: minInterval,
+		maxRetries:  maxRetries,
+		nextSend:    make(map[int64]time.Time),
+	}
+}
+
+// send posts the payload to the url of the integration, retrying on retriable errors.
+func (s *sender) send(ctx context.Context, integrationID int64, url string, payload []byte) error {
+	var err error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := retryBackoffBase << (attempt - 1)
+
+			var sErr *sendError
+			if errors.As(err, &sErr) && sErr.retryAfter > wait {
+				wait = sErr.retryAfter
+			}
+			if wait > retryWaitLimit {
+				wait = retryWaitLimit
+			}
+
+			if err = sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+
+		if err = s.wait(ctx, integrationID); err != nil {
+			return err
+		}
+
+		err = s.post(ctx, url, payload)
+		if err == nil {
+			return nil
+		}
+
+		var sErr *sendError
+		if errors.As(err, &sErr) && !sErr.retriable() {
+			return err
+		}
+	}
+
+	return fmt.Errorf("failed to send message after %d retries: %w", s.maxRetries, err)
+}
+
+// wait blocks until the rate limit allows sending another message to the integration.
+func (s *sender) wait(ctx context.Context, integrationID int64) error {
+	s.mx.Lock()
+	now := time.Now()
+	sendAt := s.nextSend[integrationID]
+	if sendAt.Before(now) {
+		sendAt = now
+	}
+	s.nextSend[integrationID] = sendAt.Add(s.minInterval)
+	s.mx.Unlock()
+
+	return sleep(ctx, time.Until(sendAt))
+}
+
+func (s *sender) post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Add("User-Agent", s.userAgent)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyBytesLimit))
+
+	sErr := &sendError{
+		statusCode: resp.StatusCode,
+		body:       string(body),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		sErr.retryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return sErr
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:chatintegration"
+
+	// handlerIdleTimeout is higher than for other services as rate limiting and retries can delay the handlers.
+	handlerIdleTimeout = 5 * time.Minute
+)
+
+type Config struct {
+	EventReaderName   string
+	UserAgentIdentity string
+	Concurrency       int
+	MaxRetries        int
+	// RateLimit is the maximum number of messages per minute sent to a single chat integration.
+	RateLimit int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.UserAgentIdentity == "" {
+		return errors.New("config.UserAgentIdentity is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.RateLimit < 0 {
+		return errors.New("config.RateLimit can't be negative")
+	}
+
+	return nil
+}
+
+// Service posts messages about repository activity to the configured chat integrations.
+type Service struct {
+	integrationStore store.ChatIntegrationStore
+	repoStore        store.RepoStore
+	pullreqStore     store.PullReqStore
+	principalStore   store.PrincipalStore
+	pipelineStore    store.PipelineStore
+	urlProvider      url.Provider
+	encrypter        encrypt.Encrypter
+	sender           *sender
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	integrationStore store.ChatIntegrationStore,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
+	pipelineStore store.PipelineStore,
+	urlProvider url.Provider,
+	encrypter encrypt.Encrypter,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	clientFactory *outbound.ClientFactory,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided chat integration service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		integrationStore: integrationStore,
+		repoStore:        repoStore,
+		pullreqStore:     pullreqStore,
+		principalStore:   principalStore,
+		pipelineStore:    pipelineStore,
+		urlProvider:      urlProvider,
+		encrypter:        encrypter,
+		sender:           newSender(clientFactory, config.UserAgentIdentity, config.RateLimit, config.MaxRetries),
+	}
+
+	handlerOptions := stream.WithHandlerOptions(
+		stream.WithIdleTimeout(handlerIdleTimeout),
+		stream.WithMaxRetries(config.MaxRetries),
+	)
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for chat integrations: %w", err)
+	}
+
+	_, err = prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterReviewReminder(service.handleEventPullReqReviewReminder)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pr event reader for chat integrations: %w", err)
+	}
+
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterExecutionCompleted(service.handleEventExecutionCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for chat integrations: %w", err)
+	}
+
+	return service, nil
+}
+
+// deliver posts the message to all integrations of the repo (or its spaces) registered for the trigger.
+// Delivery failures are only logged, as retries are handled by the sender and retrying the event
+// would post duplicate messages to integrations that already received it.
+func (s *Service) deliver(
+	ctx context.Context,
+	repo *types.Repository,
+	trigger enum.ChatIntegrationTrigger,
+	msg *message,
+) error {
+	integrations, err := s.integrationStore.ListForRepo(ctx, repo.ID, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to list chat integrations for repo %d: %w", repo.ID, err)
+	}
+
+	for _, integration := range integrations {
+		if !integration.IsTriggeredBy(trigger) {
+			continue
+		}
+
+		if err := s.deliverTo(ctx, integration, msg); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("chat_integration_id", integration.ID).
+				Str("trigger", string(trigger)).
+				Msg("failed to deliver chat integration message")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deliverTo(ctx context.Context, integration *types.ChatIntegration, msg *message) error {
+	payload, err := msg.format(integration.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to format message: %w", err)
+	}
+
+	url, err := s.encrypter.Decrypt([]byte(integration.URL))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt url: %w", err)
+	}
+
+	return s.sender.send(ctx, integration.ID, url, payload)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chatintegration
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	integrationStore store.ChatIntegrationStore,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	principalStore store.PrincipalStore,
+	pipelineStore store.PipelineStore,
+	urlProvider url.Provider,
+	encrypter encrypt.Encrypter,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	clientFactory *outbound.ClientFactory,
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		integrationStore,
+		repoStore,
+		pullreqStore,
+		principalStore,
+		pipelineStore,
+		urlProvider,
+		encrypter,
+		gitReaderFactory,
+		prReaderFactory,
+		pipelineReaderFactory,
+		clientFactory,
+	)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeStaleBranches        = "gitness:cleanup:stale-branches"
+	jobCronStaleBranches        = "17 4 * * *" // At 04:17 every day.
+	jobMaxDurationStaleBranches = 1 * time.Hour
+
+	// staleBranchesRepoBatchSize is the number of repositories fetched at once from the db.
+	staleBranchesRepoBatchSize = 100
+)
+
+type staleBranchesCleanupJob struct {
+	repoCtrl  *repo.Controller
+	repoStore store.RepoStore
+}
+
+func newStaleBranchesCleanupJob(
+	repoCtrl *repo.Controller,
+	repoStore store.RepoStore,
+) *staleBranchesCleanupJob {
+	return &staleBranchesCleanupJob{
+		repoCtrl:  repoCtrl,
+		repoStore: repoStore,
+	}
+}
+
+// Handle deletes the stale and merged branches of all repositories that opted in to the cleanup.
+func (j *staleBranchesCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	session := bootstrap.NewSystemServiceSession()
+
+	var (
+		afterID  int64
+		numRepos int
+		deleted  int
+	)
+	for {
+		repos, err := j.repoStore.ListAll(ctx, afterID, staleBranchesRepoBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			afterID = repo.ID
+			if repo.Importing {
+				continue
+			}
+
+			n, err := j.repoCtrl.CleanupStaleBranchesNoAuth(ctx, session, repo)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to cleanup stale branches of repo %d", repo.ID)
+				continue
+			}
+
+			if n > 0 {
+				numRepos++
+				deleted += n
+			}
+		}
+
+		if len(repos) < staleBranchesRepoBatchSize {
+			break
+		}
+	}
+
+	result := "no stale branches found"
+	if deleted > 0 {
+		result = fmt.Sprintf("deleted %d stale branches in %d repositories", deleted, numRepos)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDeadLetters        = "gitness:cleanup:dead-letters"
+	jobCronDeadLetters        = "9 */6 * * *" // At minute 9 past every 6th hour.
+	jobMaxDurationDeadLetters = 1 * time.Minute
+)
+
+type deadLettersCleanupJob struct {
+	retentionTime time.Duration
+
+	deadLetterStore store.EventDeadLetterStore
+}
+
+func newDeadLettersCleanupJob(
+	retentionTime time.Duration,
+	deadLetterStore store.EventDeadLetterStore,
+) *deadLettersCleanupJob {
+	return &deadLettersCleanupJob{
+		retentionTime: retentionTime,
+
+		deadLetterStore: deadLetterStore,
+	}
+}
+
+// Handle purges event dead letters that are past the retention time.
+func (j *deadLettersCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging event dead letters older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.deadLetterStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old event dead letters: %w", err)
+	}
+
+	result := "no old event dead letters found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d event dead letters", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeFeedEvents        = "gitness:cleanup:feed-events"
+	jobCronFeedEvents        = "27 3 * * *" // At 03:27 every day.
+	jobMaxDurationFeedEvents = 1 * time.Minute
+)
+
+type feedEventsCleanupJob struct {
+	retentionTime time.Duration
+
+	feedEventStore store.FeedEventStore
+}
+
+func newFeedEventsCleanupJob(
+	retentionTime time.Duration,
+	feedEventStore store.FeedEventStore,
+) *feedEventsCleanupJob {
+	return &feedEventsCleanupJob{
+		retentionTime: retentionTime,
+
+		feedEventStore: feedEventStore,
+	}
+}
+
+// Handle purges feed events that are past the retention time.
+func (j *feedEventsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging feed events older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.feedEventStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old feed events: %w", err)
+	}
+
+	result := "no old feed events found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d feed events", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
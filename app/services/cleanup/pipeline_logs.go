@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineLogs        = "gitness:cleanup:pipeline-logs"
+	jobCronPipelineLogs        = "37 3 * * *" // At 03:37 every day.
+	jobMaxDurationPipelineLogs = 1 * time.Hour
+
+	// pipelineLogsRepoBatchSize is the number of repositories fetched at once from the db.
+	pipelineLogsRepoBatchSize = 100
+
+	// pipelineLogsStepBatchSize is the number of steps with expired logs fetched at once from the db.
+	pipelineLogsStepBatchSize = 100
+)
+
+type pipelineLogsCleanupJob struct {
+	retentionTime time.Duration
+
+	repoStore store.RepoStore
+	stepStore store.StepStore
+	logStore  store.LogStore
+	settings  *settings.Service
+}
+
+func newPipelineLogsCleanupJob(
+	retentionTime time.Duration,
+	repoStore store.RepoStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
+	settings *settings.Service,
+) *pipelineLogsCleanupJob {
+	return &pipelineLogsCleanupJob{
+		retentionTime: retentionTime,
+
+		repoStore: repoStore,
+		stepStore: stepStore,
+		logStore:  logStore,
+		settings:  settings,
+	}
+}
+
+// Handle purges the logs of pipeline steps that are past the retention time.
+// The retention policy of a repository overrides the system wide retention.
+func (j *pipelineLogsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	var (
+		afterID int64
+		deleted int
+	)
+	for {
+		repos, err := j.repoStore.ListAll(ctx, afterID, pipelineLogsRepoBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			afterID = repo.ID
+
+			n, err := j.purgeRepo(ctx, repo)
+			deleted += n
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to purge pipeline logs of repo %d", repo.ID)
+				continue
+			}
+		}
+
+		if len(repos) < pipelineLogsRepoBatchSize {
+			break
+		}
+	}
+
+	result := "no expired pipeline logs found"
+	if deleted > 0 {
+		result = fmt.Sprintf("deleted logs of %d pipeline steps", deleted)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *pipelineLogsCleanupJob) purgeRepo(ctx context.Context, repo *types.Repository) (int, error) {
+	policy, err := j.settings.RepoRetentionPolicy(ctx, repo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find retention policy: %w", err)
+	}
+
+	retentionTime := j.retentionTime
+	if policy.PipelineLogsDays > 0 {
+		retentionTime = time.Duration(policy.PipelineLogsDays) * 24 * time.Hour
+	}
+	if retentionTime == 0 {
+		return 0, nil
+	}
+
+	stoppedBefore := time.Now().Add(-retentionTime)
+
+	deleted := 0
+	for {
+		stepIDs, err := j.stepStore.ListIDsWithExpiredLogs(ctx, repo.ID, stoppedBefore, pipelineLogsStepBatchSize)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list steps with expired logs: %w", err)
+		}
+
+		purged := make([]int64, 0, len(stepIDs))
+		for _, stepID := range stepIDs {
+			err = j.logStore.Delete(ctx, stepID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete logs of step %d", stepID)
+				continue
+			}
+
+			purged = append(purged, stepID)
+		}
+
+		if err = j.stepStore.MarkLogsPurged(ctx, purged); err != nil {
+			return deleted, fmt.Errorf("failed to mark logs as purged: %w", err)
+		}
+
+		deleted += len(purged)
+
+		// stop in case of failures, otherwise the same steps would be listed over and over again.
+		if len(stepIDs) < pipelineLogsStepBatchSize || len(purged) < len(stepIDs) {
+			return deleted, nil
+		}
+	}
+}
@@ -20,12 +20,21 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime time.Duration
+	// WebhookExecutionsMax is the maximum number of executions kept per webhook (0 means no limit).
+	WebhookExecutionsMax int
+	// PipelineLogsRetentionTime is the duration after which pipeline logs are purged (0 keeps logs forever).
+	PipelineLogsRetentionTime time.Duration
+	DeadLettersRetentionTime  time.Duration
+	DeletedRetentionTime      time.Duration
+	FeedEventsRetentionTime   time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -35,6 +44,21 @@ func (c *Config) Prepare() error {
 	if c.WebhookExecutionsRetentionTime <= 0 {
 		return errors.New("config.WebhookExecutionsRetentionTime has to be provided")
 	}
+	if c.WebhookExecutionsMax < 0 {
+		return errors.New("config.WebhookExecutionsMax can't be negative")
+	}
+	if c.PipelineLogsRetentionTime < 0 {
+		return errors.New("config.PipelineLogsRetentionTime can't be negative")
+	}
+	if c.DeadLettersRetentionTime <= 0 {
+		return errors.New("config.DeadLettersRetentionTime has to be provided")
+	}
+	if c.DeletedRetentionTime <= 0 {
+		return errors.New("config.DeletedRetentionTime has to be provided")
+	}
+	if c.FeedEventsRetentionTime <= 0 {
+		return errors.New("config.FeedEventsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -43,16 +67,34 @@ type Service struct {
 	config                Config
 	scheduler             *job.Scheduler
 	executor              *job.Executor
+	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
+	repoCtrl              *repo.Controller
+	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
+	stepStore             store.StepStore
+	logStore              store.LogStore
+	deadLetterStore       store.EventDeadLetterStore
+	feedEventStore        store.FeedEventStore
+	settings              *settings.Service
 }
 
 func NewService(
 	config Config,
 	scheduler *job.Scheduler,
 	executor *job.Executor,
+	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	repoCtrl *repo.Controller,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
+	deadLetterStore store.EventDeadLetterStore,
+	feedEventStore store.FeedEventStore,
+	settings *settings.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -63,8 +105,17 @@ func NewService(
 
 		scheduler:             scheduler,
 		executor:              executor,
+		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
+		repoCtrl:              repoCtrl,
+		repoStore:             repoStore,
+		spaceStore:            spaceStore,
+		stepStore:             stepStore,
+		logStore:              logStore,
+		deadLetterStore:       deadLetterStore,
+		feedEventStore:        feedEventStore,
+		settings:              settings,
 	}, nil
 }
 
@@ -93,6 +144,39 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 		return fmt.Errorf("failed to schedule webhook executions job: %w", err)
 	}
 
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineLogs,
+		jobTypePipelineLogs,
+		jobCronPipelineLogs,
+		jobMaxDurationPipelineLogs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline logs job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDeadLetters,
+		jobTypeDeadLetters,
+		jobCronDeadLetters,
+		jobMaxDurationDeadLetters,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule dead letters job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeFeedEvents,
+		jobTypeFeedEvents,
+		jobCronFeedEvents,
+		jobMaxDurationFeedEvents,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule feed events job: %w", err)
+	}
+
 	err = s.scheduler.AddRecurring(
 		ctx,
 		jobTypeTokens,
@@ -104,6 +188,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 		return fmt.Errorf("failed to schedule token job: %w", err)
 	}
 
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeTrash,
+		jobTypeTrash,
+		jobCronTrash,
+		jobMaxDurationTrash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule trash job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeStaleBranches,
+		jobTypeStaleBranches,
+		jobCronStaleBranches,
+		jobMaxDurationStaleBranches,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule stale branches job: %w", err)
+	}
+
 	return nil
 }
 
@@ -113,12 +219,49 @@ func (s *Service) registerJobHandlers() error {
 		jobTypeWebhookExecutions,
 		newWebhookExecutionsCleanupJob(
 			s.config.WebhookExecutionsRetentionTime,
+			s.config.WebhookExecutionsMax,
+			s.webhookStore,
 			s.webhookExecutionStore,
+			s.repoStore,
+			s.settings,
 		),
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for webhook executions cleanup: %w", err)
 	}
 
+	if err := s.executor.Register(
+		jobTypePipelineLogs,
+		newPipelineLogsCleanupJob(
+			s.config.PipelineLogsRetentionTime,
+			s.repoStore,
+			s.stepStore,
+			s.logStore,
+			s.settings,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline logs cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeDeadLetters,
+		newDeadLettersCleanupJob(
+			s.config.DeadLettersRetentionTime,
+			s.deadLetterStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for dead letters cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeFeedEvents,
+		newFeedEventsCleanupJob(
+			s.config.FeedEventsRetentionTime,
+			s.feedEventStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for feed events cleanup: %w", err)
+	}
+
 	if err := s.executor.Register(
 		jobTypeTokens,
 		newTokensCleanupJob(
@@ -128,5 +271,27 @@ func (s *Service) registerJobHandlers() error {
 		return fmt.Errorf("failed to register job handler for token cleanup: %w", err)
 	}
 
+	if err := s.executor.Register(
+		jobTypeTrash,
+		newTrashCleanupJob(
+			s.config.DeletedRetentionTime,
+			s.repoCtrl,
+			s.repoStore,
+			s.spaceStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for trash cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeStaleBranches,
+		newStaleBranchesCleanupJob(
+			s.repoCtrl,
+			s.repoStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for stale branches cleanup: %w", err)
+	}
+
 	return nil
 }
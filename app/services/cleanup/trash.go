@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeTrash        = "gitness:cleanup:trash"
+	jobCronTrash        = "33 */2 * * *" // At minute 33 past every 2nd hour.
+	jobMaxDurationTrash = 30 * time.Minute
+
+	// trashPurgeBatchSize is the number of deleted entities fetched at once from the db.
+	trashPurgeBatchSize = 50
+)
+
+type trashCleanupJob struct {
+	retentionTime time.Duration
+
+	repoCtrl   *repo.Controller
+	repoStore  store.RepoStore
+	spaceStore store.SpaceStore
+}
+
+func newTrashCleanupJob(
+	retentionTime time.Duration,
+	repoCtrl *repo.Controller,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+) *trashCleanupJob {
+	return &trashCleanupJob{
+		retentionTime: retentionTime,
+
+		repoCtrl:   repoCtrl,
+		repoStore:  repoStore,
+		spaceStore: spaceStore,
+	}
+}
+
+// Handle permanently deletes all repositories and spaces that are in the trash for longer than the retention time.
+// Repositories are purged first, as spaces can only be purged once they don't contain any repositories anymore.
+func (j *trashCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	deletedBefore := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging trash older than %s (aka deleted before %s)",
+		j.retentionTime,
+		deletedBefore.Format(time.RFC3339Nano))
+
+	numRepos, err := j.purgeRepos(ctx, deletedBefore.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge deleted repositories: %w", err)
+	}
+
+	numSpaces, err := j.purgeSpaces(ctx, deletedBefore.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge deleted spaces: %w", err)
+	}
+
+	result := "no expired trash found"
+	if numRepos > 0 || numSpaces > 0 {
+		result = fmt.Sprintf("purged %d repositories and %d spaces", numRepos, numSpaces)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *trashCleanupJob) purgeRepos(ctx context.Context, deletedBefore int64) (int, error) {
+	session := bootstrap.NewSystemServiceSession()
+
+	n := 0
+	for {
+		repos, err := j.repoStore.ListDeletedBefore(ctx, deletedBefore, trashPurgeBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list deleted repositories: %w", err)
+		}
+
+		purged := 0
+		for _, repo := range repos {
+			if err = j.repoCtrl.PurgeNoAuth(ctx, session, repo); err != nil {
+				// the repo stays in the trash and is picked up again by the next run.
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to purge repo %d", repo.ID)
+				continue
+			}
+			purged++
+		}
+
+		n += purged
+
+		// stop in case nothing can be purged to avoid fetching the same failing repositories again.
+		if len(repos) < trashPurgeBatchSize || purged == 0 {
+			return n, nil
+		}
+	}
+}
+
+func (j *trashCleanupJob) purgeSpaces(ctx context.Context, deletedBefore int64) (int, error) {
+	n := 0
+	for {
+		spaces, err := j.spaceStore.ListDeletedBefore(ctx, deletedBefore, trashPurgeBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list deleted spaces: %w", err)
+		}
+
+		purged := 0
+		for _, space := range spaces {
+			err = j.spaceStore.Purge(ctx, space.ID, *space.Deleted)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				// the space was either purged together with its parent or still contains repositories.
+				continue
+			}
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to purge space %d", space.ID)
+				continue
+			}
+			purged++
+		}
+
+		n += purged
+
+		if len(spaces) < trashPurgeBatchSize || purged == 0 {
+			return n, nil
+		}
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
@@ -28,46 +31,145 @@ import (
 const (
 	jobTypeWebhookExecutions        = "gitness:cleanup:webhook-executions"
 	jobCronWebhookExecutions        = "21 */4 * * *" // At minute 21 past every 4th hour.
-	jobMaxDurationWebhookExecutions = 1 * time.Minute
+	jobMaxDurationWebhookExecutions = 10 * time.Minute
+
+	// webhookBatchSize is the number of webhooks fetched at once from the db.
+	webhookBatchSize = 100
 )
 
 type webhookExecutionsCleanupJob struct {
 	retentionTime time.Duration
+	maxExecutions int
 
+	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
+	repoStore             store.RepoStore
+	settings              *settings.Service
 }
 
 func newWebhookExecutionsCleanupJob(
 	retentionTime time.Duration,
+	maxExecutions int,
+	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
+	repoStore store.RepoStore,
+	settings *settings.Service,
 ) *webhookExecutionsCleanupJob {
 	return &webhookExecutionsCleanupJob{
 		retentionTime: retentionTime,
+		maxExecutions: maxExecutions,
 
+		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
+		repoStore:             repoStore,
+		settings:              settings,
 	}
 }
 
-// Handle purges old webhook executions that are past the retention time.
+// Handle purges webhook executions that are past the retention time or exceed the maximum number of executions.
+// The retention policy of the space or repository of a webhook overrides the system wide retention.
 func (j *webhookExecutionsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
-	olderThan := time.Now().Add(-j.retentionTime)
-
 	log.Ctx(ctx).Info().Msgf(
-		"start purging webhook executions older than %s (aka created before %s)",
+		"start purging webhook executions older than %s or exceeding %d executions (unless overridden)",
 		j.retentionTime,
-		olderThan.Format(time.RFC3339Nano))
+		j.maxExecutions)
 
-	n, err := j.webhookExecutionStore.DeleteOld(ctx, olderThan)
-	if err != nil {
-		return "", fmt.Errorf("failed to delete old webhook executions: %w", err)
+	// webhooks of the same parent share the retention policy
+	policies := map[string]types.RetentionPolicy{}
+
+	var (
+		afterID int64
+		deleted int64
+	)
+	for {
+		webhooks, err := j.webhookStore.ListAfter(ctx, afterID, webhookBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		for _, webhook := range webhooks {
+			afterID = webhook.ID
+
+			parentKey := fmt.Sprintf("%s:%d", webhook.ParentType, webhook.ParentID)
+			policy, ok := policies[parentKey]
+			if !ok {
+				policy, err = j.findRetentionPolicy(ctx, webhook)
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).Msgf("failed to find retention policy of webhook %d", webhook.ID)
+					continue
+				}
+				policies[parentKey] = policy
+			}
+
+			n, err := j.purge(ctx, webhook.ID, policy)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to purge executions of webhook %d", webhook.ID)
+				continue
+			}
+
+			deleted += n
+		}
+
+		if len(webhooks) < webhookBatchSize {
+			break
+		}
 	}
 
 	result := "no old webhook executions found"
-	if n > 0 {
-		result = fmt.Sprintf("deleted %d webhook executions", n)
+	if deleted > 0 {
+		result = fmt.Sprintf("deleted %d webhook executions", deleted)
 	}
 
 	log.Ctx(ctx).Info().Msg(result)
 
 	return result, nil
 }
+
+func (j *webhookExecutionsCleanupJob) findRetentionPolicy(
+	ctx context.Context,
+	webhook *types.Webhook,
+) (types.RetentionPolicy, error) {
+	switch webhook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := j.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return types.RetentionPolicy{}, fmt.Errorf("failed to find repo %d: %w", webhook.ParentID, err)
+		}
+		return j.settings.RepoRetentionPolicy(ctx, repo)
+	case enum.WebhookParentSpace:
+		return j.settings.SpaceRetentionPolicy(ctx, webhook.ParentID)
+	default:
+		return types.RetentionPolicy{}, nil
+	}
+}
+
+func (j *webhookExecutionsCleanupJob) purge(
+	ctx context.Context,
+	webhookID int64,
+	policy types.RetentionPolicy,
+) (int64, error) {
+	retentionTime := j.retentionTime
+	if policy.WebhookExecutionsDays > 0 {
+		retentionTime = time.Duration(policy.WebhookExecutionsDays) * 24 * time.Hour
+	}
+
+	n, err := j.webhookExecutionStore.DeleteOld(ctx, webhookID, time.Now().Add(-retentionTime))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old webhook executions: %w", err)
+	}
+
+	maxExecutions := j.maxExecutions
+	if policy.WebhookExecutionsMax > 0 {
+		maxExecutions = policy.WebhookExecutionsMax
+	}
+	if maxExecutions == 0 {
+		return n, nil
+	}
+
+	excess, err := j.webhookExecutionStore.DeleteExcess(ctx, webhookID, maxExecutions)
+	if err != nil {
+		return n, fmt.Errorf("failed to delete excess webhook executions: %w", err)
+	}
+
+	return n + excess, nil
+}
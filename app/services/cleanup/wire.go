@@ -15,7 +15,9 @@
 package cleanup
 
 import (
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -29,14 +31,32 @@ func ProvideService(
 	config Config,
 	scheduler *job.Scheduler,
 	executor *job.Executor,
+	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	repoCtrl *repo.Controller,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	stepStore store.StepStore,
+	logStore store.LogStore,
+	deadLetterStore store.EventDeadLetterStore,
+	feedEventStore store.FeedEventStore,
+	settings *settings.Service,
 ) (*Service, error) {
 	return NewService(
 		config,
 		scheduler,
 		executor,
+		webhookStore,
 		webhookExecutionStore,
 		tokenStore,
+		repoCtrl,
+		repoStore,
+		spaceStore,
+		stepStore,
+		logStore,
+		deadLetterStore,
+		feedEventStore,
+		settings,
 	)
 }
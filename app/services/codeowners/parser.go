@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/harness/gitness/app/services/pathprotection"
+)
+
+// Rule is a rule of a CODEOWNERS file, assigning owners to the files matching the pattern.
+type Rule struct {
+	LineNumber int
+	Pattern    string
+	Owners     []string
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Path  string
+	Rules []Rule
+}
+
+// Parse parses the content of a CODEOWNERS file. Lines that can't be parsed are ignored.
+func Parse(path string, content []byte) *File {
+	file := &File{Path: path}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		file.Rules = append(file.Rules, Rule{
+			LineNumber: lineNumber,
+			Pattern:    fields[0],
+			Owners:     fields[1:],
+		})
+	}
+
+	return file
+}
+
+// Match returns the rule that applies to the file with the provided path, nil if there is none.
+// Like with git ignore files, the last matching rule takes precedence.
+func (f *File) Match(path string) *Rule {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if matchPattern(f.Rules[i].Pattern, path) {
+			return &f.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+// matchPattern matches the path against a CODEOWNERS pattern, which follows the git ignore syntax:
+// patterns containing a slash are relative to the repository root, others match at any depth.
+// A pattern matching a directory matches all files within the directory.
+func matchPattern(pattern string, path string) bool {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+
+	return pathprotection.MatchPath(pattern, path) || pathprotection.MatchPath(pattern+"/**", path)
+}
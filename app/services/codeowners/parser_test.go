@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import "testing"
+
+func TestFileMatch(t *testing.T) {
+	file := Parse("CODEOWNERS", []byte(`
+# default owners
+*                @core
+
+*.go             @backend dev@example.com
+/docs/           @docs
+infra/**/*.tf    @platform
+README.md
+`))
+
+	tests := []struct {
+		path        string
+		wantPattern string
+	}{
+		{path: "main.go", wantPattern: "*.go"},
+		{path: "app/server/main.go", wantPattern: "*.go"},
+		{path: "docs/index.md", wantPattern: "/docs/"},
+		{path: "app/docs/index.md", wantPattern: "*"},
+		{path: "infra/modules/net/main.tf", wantPattern: "infra/**/*.tf"},
+		{path: "sub/infra/main.tf", wantPattern: "*"},
+		{path: "app/README.md", wantPattern: "README.md"},
+		{path: "Makefile", wantPattern: "*"},
+	}
+
+	for _, test := range tests {
+		rule := file.Match(test.path)
+		if rule == nil {
+			t.Errorf("no rule matched %q, want %q", test.path, test.wantPattern)
+			continue
+		}
+		if rule.Pattern != test.wantPattern {
+			t.Errorf("rule %q matched %q, want %q", rule.Pattern, test.path, test.wantPattern)
+		}
+	}
+
+	if rule := file.Match("README.md"); rule == nil || len(rule.Owners) != 0 {
+		t.Errorf("expected rule without owners to match README.md")
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxFileSize is the max size of CODEOWNERS files, larger files are ignored.
+const maxFileSize = 1 << 20
+
+// filePaths are the locations searched for the CODEOWNERS file, in order.
+var filePaths = []string{
+	".gitness/CODEOWNERS",
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Service resolves the owners of files based on the CODEOWNERS file of a repository.
+type Service struct {
+	gitRPCClient   gitrpc.Interface
+	principalStore store.PrincipalStore
+	memberStore    store.UserGroupMemberStore
+}
+
+func NewService(
+	gitRPCClient gitrpc.Interface,
+	principalStore store.PrincipalStore,
+	memberStore store.UserGroupMemberStore,
+) *Service {
+	return &Service{
+		gitRPCClient:   gitRPCClient,
+		principalStore: principalStore,
+		memberStore:    memberStore,
+	}
+}
+
+// Get returns the CODEOWNERS file of the repository at the provided git reference, nil if there is none.
+func (s *Service) Get(ctx context.Context, repo *types.Repository, ref string) (*File, error) {
+	readParams := gitrpc.CreateRPCReadParams(repo)
+
+	for _, path := range filePaths {
+		node, err := s.gitRPCClient.GetTreeNode(ctx, &gitrpc.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     ref,
+			Path:       path,
+		})
+		if gitrpc.ErrorStatus(err) == gitrpc.StatusPathNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tree node of '%s': %w", path, err)
+		}
+		if node.Node.Type != gitrpc.TreeNodeTypeBlob {
+			continue
+		}
+
+		blob, err := s.gitRPCClient.GetBlob(ctx, &gitrpc.GetBlobParams{
+			ReadParams: readParams,
+			SHA:        node.Node.SHA,
+			SizeLimit:  maxFileSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blob of '%s': %w", path, err)
+		}
+
+		content, err := io.ReadAll(blob.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read content of '%s': %w", path, err)
+		}
+
+		return Parse(path, content), nil
+	}
+
+	return nil, nil
+}
+
+// Summarize maps the changed files to their owners and marks the owners that approved,
+// based on the provided reviewers of a pull request.
+func (s *Service) Summarize(
+	ctx context.Context,
+	file *File,
+	paths []string,
+	reviewers []*types.PullReqReviewer,
+) (*types.CodeOwnersSummary, error) {
+	summary := &types.CodeOwnersSummary{
+		Entries:      []types.CodeOwnersEntry{},
+		UnownedPaths: []string{},
+	}
+
+	if file == nil {
+		summary.UnownedPaths = append(summary.UnownedPaths, paths...)
+		return summary, nil
+	}
+
+	summary.File = file.Path
+
+	approvers := make(map[int64]types.PrincipalInfo)
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionApproved {
+			approvers[reviewer.PrincipalID] = reviewer.Reviewer
+		}
+	}
+
+	entries := make(map[int]int) // line number of the rule -> index of the entry
+	owners := make(map[string]types.CodeOwner)
+	for _, path := range paths {
+		rule := file.Match(path)
+		if rule == nil || len(rule.Owners) == 0 {
+			summary.UnownedPaths = append(summary.UnownedPaths, path)
+			continue
+		}
+
+		if idx, ok := entries[rule.LineNumber]; ok {
+			summary.Entries[idx].Paths = append(summary.Entries[idx].Paths, path)
+			continue
+		}
+
+		entry := types.CodeOwnersEntry{
+			Pattern:    rule.Pattern,
+			LineNumber: rule.LineNumber,
+			Paths:      []string{path},
+			Owners:     make([]types.CodeOwner, 0, len(rule.Owners)),
+		}
+
+		for _, identifier := range rule.Owners {
+			owner, ok := owners[identifier]
+			if !ok {
+				var err error
+				owner, err = s.resolveOwner(ctx, identifier, approvers)
+				if err != nil {
+					return nil, err
+				}
+				owners[identifier] = owner
+			}
+
+			entry.Owners = append(entry.Owners, owner)
+			entry.Approved = entry.Approved || len(owner.ApprovedBy) > 0
+		}
+
+		entries[rule.LineNumber] = len(summary.Entries)
+		summary.Entries = append(summary.Entries, entry)
+	}
+
+	return summary, nil
+}
+
+// resolveOwner resolves the principal of an owner and the approvals given on its behalf.
+// Owners are referenced either by "@uid" of a user or user group, or by the email address of a user.
+func (s *Service) resolveOwner(
+	ctx context.Context,
+	identifier string,
+	approvers map[int64]types.PrincipalInfo,
+) (types.CodeOwner, error) {
+	owner := types.CodeOwner{
+		Identifier: identifier,
+		ApprovedBy: []types.PrincipalInfo{},
+	}
+
+	var (
+		principal *types.Principal
+		err       error
+	)
+	if strings.HasPrefix(identifier, "@") {
+		principal, err = s.principalStore.FindByUID(ctx, identifier[1:])
+	} else {
+		principal, err = s.principalStore.FindByEmail(ctx, identifier)
+	}
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return owner, nil
+	}
+	if err != nil {
+		return types.CodeOwner{}, fmt.Errorf("failed to find code owner '%s': %w", identifier, err)
+	}
+
+	owner.Principal = principal.ToPrincipalInfo()
+
+	if principal.Type != enum.PrincipalTypeUserGroup {
+		if approver, ok := approvers[principal.ID]; ok {
+			owner.ApprovedBy = append(owner.ApprovedBy, approver)
+		}
+		return owner, nil
+	}
+
+	memberIDs, err := s.memberStore.ListUserIDs(ctx, principal.ID)
+	if err != nil {
+		return types.CodeOwner{}, fmt.Errorf("failed to list members of code owner '%s': %w", identifier, err)
+	}
+
+	for _, memberID := range memberIDs {
+		if approver, ok := approvers[memberID]; ok {
+			owner.ApprovedBy = append(owner.ApprovedBy, approver)
+		}
+	}
+
+	return owner, nil
+}
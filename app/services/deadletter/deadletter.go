@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+var _ events.DeadLetterQueue = (*Queue)(nil)
+
+// Queue persists the messages that event readers failed to process in the database.
+type Queue struct {
+	store store.EventDeadLetterStore
+}
+
+func NewQueue(deadLetterStore store.EventDeadLetterStore) *Queue {
+	return &Queue{
+		store: deadLetterStore,
+	}
+}
+
+// Add persists the dead-lettered message.
+func (q *Queue) Add(ctx context.Context, deadLetter stream.DeadLetter) error {
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(deadLetter.Values); err != nil {
+		return fmt.Errorf("failed to encode dead letter payload: %w", err)
+	}
+
+	msg := &types.EventDeadLetter{
+		StreamID:  deadLetter.StreamID,
+		GroupName: deadLetter.GroupName,
+		MessageID: deadLetter.MessageID,
+		Payload:   buff.Bytes(),
+		Retries:   deadLetter.Retries,
+		LastError: deadLetter.LastError,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	if err := q.store.Create(ctx, msg); err != nil {
+		return fmt.Errorf("failed to store dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// Redeliverer sends dead-lettered messages back to the consumer group that failed to process them.
+type Redeliverer struct {
+	store    store.EventDeadLetterStore
+	producer events.StreamProducer
+}
+
+func NewRedeliverer(deadLetterStore store.EventDeadLetterStore, producer events.StreamProducer) *Redeliverer {
+	return &Redeliverer{
+		store:    deadLetterStore,
+		producer: producer,
+	}
+}
+
+// Redeliver sends the dead-lettered message to its stream again (only for its consumer group)
+// and removes it from the dead letters. It returns the ID of the new stream message.
+func (r *Redeliverer) Redeliver(ctx context.Context, deadLetter *types.EventDeadLetter) (string, error) {
+	payload, err := decodePayload(deadLetter.Payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode dead letter payload: %w", err)
+	}
+
+	messageID, err := r.producer.Send(ctx, deadLetter.StreamID, events.WithTargetGroup(payload, deadLetter.GroupName))
+	if err != nil {
+		return "", fmt.Errorf("failed to send dead letter to stream '%s': %w", deadLetter.StreamID, err)
+	}
+
+	if err = r.store.Delete(ctx, deadLetter.ID); err != nil {
+		return "", fmt.Errorf("failed to delete redelivered dead letter: %w", err)
+	}
+
+	return messageID, nil
+}
+
+func decodePayload(raw []byte) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
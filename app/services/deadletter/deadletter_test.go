@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+type memoryDeadLetterStore struct {
+	store.EventDeadLetterStore
+	deadLetters map[int64]*types.EventDeadLetter
+}
+
+func (s *memoryDeadLetterStore) Create(_ context.Context, deadLetter *types.EventDeadLetter) error {
+	deadLetter.ID = int64(len(s.deadLetters) + 1)
+	s.deadLetters[deadLetter.ID] = deadLetter
+	return nil
+}
+
+func (s *memoryDeadLetterStore) Delete(_ context.Context, id int64) error {
+	delete(s.deadLetters, id)
+	return nil
+}
+
+type recordingProducer struct {
+	streamID string
+	payload  map[string]interface{}
+}
+
+func (p *recordingProducer) Send(_ context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	p.streamID = streamID
+	p.payload = payload
+	return "1-0", nil
+}
+
+func TestRedeliverToFailedGroup(t *testing.T) {
+	deadLetterStore := &memoryDeadLetterStore{deadLetters: map[int64]*types.EventDeadLetter{}}
+	producer := &recordingProducer{}
+	queue := NewQueue(deadLetterStore)
+	redeliverer := NewRedeliverer(deadLetterStore, producer)
+
+	event := []byte{0x1, 0x2, 0x3}
+	err := queue.Add(context.Background(), stream.DeadLetter{
+		StreamID:  "events:git:branch-created",
+		GroupName: "gitness:pullreq",
+		MessageID: "42-0",
+		Values:    map[string]interface{}{"event": event},
+		Retries:   3,
+		LastError: "boom",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadLetter, ok := deadLetterStore.deadLetters[1]
+	if !ok || deadLetter.LastError != "boom" || deadLetter.Retries != 3 {
+		t.Fatalf("unexpected dead letter stored: %#v", deadLetter)
+	}
+
+	messageID, err := redeliverer.Redeliver(context.Background(), deadLetter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageID != "1-0" || producer.streamID != "events:git:branch-created" {
+		t.Errorf("unexpected redelivery: message=%s, stream=%s", messageID, producer.streamID)
+	}
+	if got, ok := producer.payload["event"].([]byte); !ok || !bytes.Equal(got, event) {
+		t.Errorf("event payload not preserved, got %#v", producer.payload["event"])
+	}
+	if producer.payload["target_group"] != "gitness:pullreq" {
+		t.Errorf("redelivered payload isn't restricted to the failed group, got %#v", producer.payload)
+	}
+	if len(deadLetterStore.deadLetters) != 0 {
+		t.Errorf("redelivered dead letter wasn't removed")
+	}
+}
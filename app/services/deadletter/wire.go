@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadletter
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideQueue,
+	ProvideEventsDeadLetterQueue,
+	ProvideRedeliverer,
+)
+
+func ProvideQueue(deadLetterStore store.EventDeadLetterStore) *Queue {
+	return NewQueue(deadLetterStore)
+}
+
+// ProvideEventsDeadLetterQueue provides the dead letter queue used by the events system
+// (nil if dead-lettering is disabled).
+func ProvideEventsDeadLetterQueue(config *types.Config, queue *Queue) events.DeadLetterQueue {
+	if !config.Events.DeadLetters.Enabled {
+		return nil
+	}
+
+	return queue
+}
+
+func ProvideRedeliverer(deadLetterStore store.EventDeadLetterStore, eventsSystem *events.System) *Redeliverer {
+	return NewRedeliverer(deadLetterStore, eventsSystem.StreamProducer())
+}
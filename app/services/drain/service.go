@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/inflight"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	// InstanceID is the id of the instance that is drained.
+	InstanceID string
+
+	// GitTimeout is the max time to wait for git operations in flight (including their hooks).
+	GitTimeout time.Duration
+
+	// EventsTimeout is the max time to wait for event handlers in flight.
+	EventsTimeout time.Duration
+}
+
+// Service coordinates draining the instance before it shuts down.
+// While draining, new git operations and pipeline dispatches are rejected,
+// and the git operations, git hooks and event handlers in flight are waited for.
+type Service struct {
+	config Config
+
+	gitOperations *inflight.Tracker
+	gitHooks      *inflight.Tracker
+	eventHandlers *inflight.Tracker
+
+	mx        sync.Mutex
+	started   int64
+	completed int64
+}
+
+func NewService(config Config, eventHandlers *inflight.Tracker) *Service {
+	return &Service{
+		config:        config,
+		gitOperations: inflight.NewTracker(),
+		gitHooks:      inflight.NewTracker(),
+		eventHandlers: eventHandlers,
+	}
+}
+
+// GitOperations returns the tracker of git operations (clones, fetches, pushes, ...) in flight.
+func (s *Service) GitOperations() *inflight.Tracker {
+	return s.gitOperations
+}
+
+// GitHooks returns the tracker of the server side git hooks in flight.
+// Hooks are part of the git operations in flight, hence they have to join the tracker.
+func (s *Service) GitHooks() *inflight.Tracker {
+	return s.gitHooks
+}
+
+// Draining returns true if the instance is draining.
+func (s *Service) Draining() bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.started != 0
+}
+
+// Drain stops accepting new work and blocks until the work in flight completed or the timeouts expired.
+// Draining can't be undone, it should only be started before the instance shuts down.
+func (s *Service) Drain(ctx context.Context) {
+	s.mx.Lock()
+	if s.started != 0 {
+		s.mx.Unlock()
+		return
+	}
+	s.started = time.Now().UnixMilli()
+	s.mx.Unlock()
+
+	s.gitOperations.Drain()
+	s.gitHooks.Drain()
+	s.eventHandlers.Drain()
+
+	log.Ctx(ctx).Info().
+		Int("git_operations", s.gitOperations.InFlight()).
+		Int("git_hooks", s.gitHooks.InFlight()).
+		Int("event_handlers", s.eventHandlers.InFlight()).
+		Msg("draining started")
+
+	// the git hooks are triggered by the git operations in flight, hence they share the timeout.
+	gitCtx, cancelGit := context.WithTimeout(ctx, s.config.GitTimeout)
+	wait(gitCtx, "git operations", s.gitOperations)
+	wait(gitCtx, "git hooks", s.gitHooks)
+	cancelGit()
+
+	// the event handlers are waited for last, as git operations trigger events.
+	eventsCtx, cancelEvents := context.WithTimeout(ctx, s.config.EventsTimeout)
+	wait(eventsCtx, "event handlers", s.eventHandlers)
+	cancelEvents()
+
+	s.mx.Lock()
+	s.completed = time.Now().UnixMilli()
+	s.mx.Unlock()
+
+	log.Ctx(ctx).Info().Msg("draining completed")
+}
+
+func wait(ctx context.Context, name string, tracker *inflight.Tracker) {
+	if err := tracker.Wait(ctx); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int("in_flight", tracker.InFlight()).
+			Msgf("stopped waiting for %s in flight", name)
+		return
+	}
+
+	log.Ctx(ctx).Info().Msgf("all %s in flight completed", name)
+}
+
+// Status returns the drain status of the instance.
+func (s *Service) Status() *types.DrainStatus {
+	s.mx.Lock()
+	started, completed := s.started, s.completed
+	s.mx.Unlock()
+
+	return &types.DrainStatus{
+		Instance:      s.config.InstanceID,
+		Draining:      started != 0,
+		Started:       started,
+		Completed:     completed,
+		GitOperations: s.gitOperations.InFlight(),
+		GitHooks:      s.gitHooks.InFlight(),
+		EventHandlers: s.eventHandlers.InFlight(),
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/gotidy/ptr"
 	"github.com/rs/zerolog/log"
 )
 
@@ -188,7 +189,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		UID:           repository.UID,
 		DefaultBranch: repository.DefaultBranch,
 		Description:   repository.Description,
-		IsPublic:      repository.IsPublic,
+		IsPublic:      ptr.Bool(repository.IsPublic),
 		Readme:        false,
 		License:       "",
 		GitIgnore:     "",
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feed
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	p := event.Payload
+	return s.record(ctx, p.RepoID, p.PrincipalID, enum.FeedEventTypeBranchCreated, p.Ref, p.SHA, 0)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	p := event.Payload
+	return s.record(ctx, p.RepoID, p.PrincipalID, enum.FeedEventTypeBranchUpdated, p.Ref, p.NewSHA, 0)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	p := event.Payload
+	return s.record(ctx, p.RepoID, p.PrincipalID, enum.FeedEventTypeBranchDeleted, p.Ref, p.SHA, 0)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	p := event.Payload
+	return s.record(ctx, p.RepoID, p.PrincipalID, enum.FeedEventTypeTagCreated, p.Ref, p.SHA, 0)
+}
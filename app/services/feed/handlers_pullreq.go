@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feed
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.recordPullReq(ctx, &event.Payload.Base, enum.FeedEventTypePullReqCreated, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.recordPullReq(ctx, &event.Payload.Base, enum.FeedEventTypePullReqMerged, event.Payload.MergeSHA)
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.recordPullReq(ctx, &event.Payload.Base, enum.FeedEventTypePullReqClosed, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.recordPullReq(ctx, &event.Payload.Base, enum.FeedEventTypePullReqReopened, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleEventPullReqCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload]) error {
+	return s.recordPullReq(ctx, &event.Payload.Base, enum.FeedEventTypePullReqComment, "")
+}
+
+// recordPullReq records the pull request event in the feed of the target repository.
+func (s *Service) recordPullReq(
+	ctx context.Context,
+	base *pullreqevents.Base,
+	eventType enum.FeedEventType,
+	sha string,
+) error {
+	return s.record(ctx, base.TargetRepoID, base.PrincipalID, eventType, "", sha, base.Number)
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	eventsReaderGroupName = "gitness:feed"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service records repository activity for the personal feed of users.
+type Service struct {
+	feedEventStore store.FeedEventStore
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	feedEventStore store.FeedEventStore,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided feed service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		feedEventStore: feedEventStore,
+	}
+
+	const idleTimeout = 1 * time.Minute
+	handlerOptions := stream.WithHandlerOptions(
+		stream.WithIdleTimeout(idleTimeout),
+		stream.WithMaxRetries(config.MaxRetries),
+	)
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for feed: %w", err)
+	}
+
+	_, err = prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(stream.WithConcurrency(config.Concurrency), handlerOptions)
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+			_ = r.RegisterCommentCreated(service.handleEventPullReqCommentCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pr event reader for feed: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) record(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	eventType enum.FeedEventType,
+	ref string,
+	sha string,
+	pullReqNumber int64,
+) error {
+	err := s.feedEventStore.Create(ctx, &types.FeedEvent{
+		RepoID:        repoID,
+		PrincipalID:   principalID,
+		Type:          eventType,
+		Ref:           ref,
+		SHA:           sha,
+		PullReqNumber: pullReqNumber,
+		Created:       time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s feed event: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerender
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// geoJSONTypes are the valid types of GeoJSON root objects, see RFC 7946.
+var geoJSONTypes = map[string]bool{
+	"FeatureCollection":  true,
+	"Feature":            true,
+	"GeometryCollection": true,
+	"Point":              true,
+	"MultiPoint":         true,
+	"LineString":         true,
+	"MultiLineString":    true,
+	"Polygon":            true,
+	"MultiPolygon":       true,
+}
+
+type geoJSONObject struct {
+	Type     string            `json:"type"`
+	Features []json.RawMessage `json:"features"`
+	BBox     []float64         `json:"bbox"`
+}
+
+// renderGeoJSON validates the GeoJSON document and computes its feature count and bounding box.
+// The bounding box of the document is used if provided, otherwise it's computed from all coordinates.
+func renderGeoJSON(content []byte) (*types.RenderedGeoJSON, error) {
+	obj := geoJSONObject{}
+	if err := json.Unmarshal(content, &obj); err != nil {
+		return nil, errInvalidContent(enum.FileRenderTypeGeoJSON, err)
+	}
+
+	if !geoJSONTypes[obj.Type] {
+		return nil, errInvalidContent(enum.FileRenderTypeGeoJSON, fmt.Errorf("unknown type '%s'", obj.Type))
+	}
+
+	rendered := &types.RenderedGeoJSON{
+		Type: obj.Type,
+		BBox: obj.BBox,
+		Data: json.RawMessage(content),
+	}
+
+	switch obj.Type {
+	case "FeatureCollection":
+		rendered.FeatureCount = len(obj.Features)
+	case "Feature":
+		rendered.FeatureCount = 1
+	}
+
+	if len(rendered.BBox) == 0 {
+		var doc any
+		if err := json.Unmarshal(content, &doc); err != nil {
+			return nil, errInvalidContent(enum.FileRenderTypeGeoJSON, err)
+		}
+
+		box := bbox{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+		box.addObject(doc)
+		if box.valid() {
+			rendered.BBox = []float64{box.minX, box.minY, box.maxX, box.maxY}
+		}
+	}
+
+	return rendered, nil
+}
+
+type bbox struct {
+	minX, minY, maxX, maxY float64
+}
+
+func (b *bbox) valid() bool {
+	return b.minX <= b.maxX && b.minY <= b.maxY
+}
+
+// addObject extends the bounding box with the coordinates of all geometries found in the object.
+func (b *bbox) addObject(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, val := range v {
+			if key == "coordinates" {
+				b.addCoordinates(val)
+				continue
+			}
+			b.addObject(val)
+		}
+	case []any:
+		for _, val := range v {
+			b.addObject(val)
+		}
+	}
+}
+
+// addCoordinates extends the bounding box with the positions of the (nested) coordinate arrays.
+func (b *bbox) addCoordinates(v any) {
+	arr, ok := v.([]any)
+	if !ok || len(arr) == 0 {
+		return
+	}
+
+	// a position is an array of numbers, with longitude and latitude as first two elements.
+	if x, ok := arr[0].(float64); ok {
+		if len(arr) < 2 {
+			return
+		}
+		y, ok := arr[1].(float64)
+		if !ok {
+			return
+		}
+		b.minX = math.Min(b.minX, x)
+		b.minY = math.Min(b.minY, y)
+		b.maxX = math.Max(b.maxX, x)
+		b.maxY = math.Max(b.maxY, y)
+		return
+	}
+
+	for _, val := range arr {
+		b.addCoordinates(val)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// notebook is the subset of the Jupyter notebook format (nbformat 4) used for rendering.
+type notebook struct {
+	NBFormat int `json:"nbformat"`
+	Metadata struct {
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+	Cells []notebookCell `json:"cells"`
+}
+
+type notebookCell struct {
+	CellType       string           `json:"cell_type"`
+	Source         multilineString  `json:"source"`
+	ExecutionCount *int             `json:"execution_count"`
+	Outputs        []notebookOutput `json:"outputs"`
+}
+
+type notebookOutput struct {
+	OutputType string                     `json:"output_type"`
+	Name       string                     `json:"name"`
+	Text       multilineString            `json:"text"`
+	Data       map[string]json.RawMessage `json:"data"`
+	EName      string                     `json:"ename"`
+	EValue     string                     `json:"evalue"`
+	Traceback  []string                   `json:"traceback"`
+}
+
+// multilineString is a notebook string, which is stored either as a string or as a list of lines.
+type multilineString string
+
+func (s *multilineString) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = multilineString(str)
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return errors.New("expected a string or a list of strings")
+	}
+
+	*s = multilineString(strings.Join(lines, ""))
+
+	return nil
+}
+
+// outputImageTypes are the image mime types of outputs returned as base64 data, in order of preference.
+// SVG images are excluded as they can contain scripts.
+var outputImageTypes = []string{"image/png", "image/jpeg", "image/gif"}
+
+// ansiEscape matches the terminal color codes used in tracebacks.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+func renderNotebook(renderer *markdown.Renderer, content []byte) (*types.RenderedNotebook, error) {
+	nb := notebook{}
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return nil, errInvalidContent(enum.FileRenderTypeNotebook, err)
+	}
+
+	if nb.NBFormat != 4 {
+		return nil, errInvalidContent(enum.FileRenderTypeNotebook,
+			fmt.Errorf("notebook format %d is not supported", nb.NBFormat))
+	}
+
+	rendered := &types.RenderedNotebook{
+		Language: nb.Metadata.LanguageInfo.Name,
+		Cells:    make([]types.RenderedNotebookCell, 0, len(nb.Cells)),
+	}
+	if rendered.Language == "" {
+		rendered.Language = nb.Metadata.KernelSpec.Language
+	}
+
+	for _, cell := range nb.Cells {
+		out := types.RenderedNotebookCell{
+			Type: cell.CellType,
+		}
+
+		switch cell.CellType {
+		case "markdown":
+			html, err := renderer.Render([]byte(cell.Source), markdown.Options{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render markdown cell: %w", err)
+			}
+			out.HTML = string(html)
+		case "code":
+			out.Source = string(cell.Source)
+			out.ExecutionCount = cell.ExecutionCount
+			for _, output := range cell.Outputs {
+				renderedOutput, err := renderNotebookOutput(renderer, output)
+				if err != nil {
+					return nil, err
+				}
+				if renderedOutput != nil {
+					out.Outputs = append(out.Outputs, *renderedOutput)
+				}
+			}
+		default:
+			out.Source = string(cell.Source)
+		}
+
+		rendered.Cells = append(rendered.Cells, out)
+	}
+
+	return rendered, nil
+}
+
+// renderNotebookOutput converts the output of a code cell, picking the richest representation that can be
+// displayed safely. It returns nil for outputs without a supported representation.
+func renderNotebookOutput(
+	renderer *markdown.Renderer,
+	output notebookOutput,
+) (*types.RenderedNotebookOutput, error) {
+	switch output.OutputType {
+	case "stream":
+		return &types.RenderedNotebookOutput{
+			Type:     output.OutputType,
+			MimeType: "text/plain",
+			Text:     string(output.Text),
+		}, nil
+	case "error":
+		text := output.EName + ": " + output.EValue
+		if len(output.Traceback) > 0 {
+			text = ansiEscape.ReplaceAllString(strings.Join(output.Traceback, "\n"), "")
+		}
+		return &types.RenderedNotebookOutput{
+			Type:     output.OutputType,
+			MimeType: "text/plain",
+			Text:     text,
+		}, nil
+	case "display_data", "execute_result":
+	default:
+		return nil, nil
+	}
+
+	for _, mimeType := range outputImageTypes {
+		var data multilineString
+		if err := unmarshalOutputData(output.Data, mimeType, &data); err != nil {
+			return nil, err
+		}
+		if data != "" {
+			return &types.RenderedNotebookOutput{
+				Type:     output.OutputType,
+				MimeType: mimeType,
+				Data:     strings.Join(strings.Fields(string(data)), ""),
+			}, nil
+		}
+	}
+
+	var text multilineString
+
+	if err := unmarshalOutputData(output.Data, "text/markdown", &text); err != nil {
+		return nil, err
+	}
+	if text != "" {
+		html, err := renderer.Render([]byte(text), markdown.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render markdown output: %w", err)
+		}
+		return &types.RenderedNotebookOutput{
+			Type:     output.OutputType,
+			MimeType: "text/markdown",
+			HTML:     string(html),
+		}, nil
+	}
+
+	// html outputs (e.g. data frames) usually come with a plain text representation.
+	// Untrusted html is never rendered, it's only shown as text if there's no other representation.
+	for _, mimeType := range []string{"text/plain", "text/html"} {
+		if err := unmarshalOutputData(output.Data, mimeType, &text); err != nil {
+			return nil, err
+		}
+		if text != "" {
+			return &types.RenderedNotebookOutput{
+				Type:     output.OutputType,
+				MimeType: "text/plain",
+				Text:     string(text),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func unmarshalOutputData(data map[string]json.RawMessage, mimeType string, v *multilineString) error {
+	raw, ok := data[mimeType]
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return errInvalidContent(enum.FileRenderTypeNotebook, fmt.Errorf("invalid '%s' output: %w", mimeType, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerender
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTypeForPath(t *testing.T) {
+	tests := map[string]enum.FileRenderType{
+		"nb/analysis.ipynb": enum.FileRenderTypeNotebook,
+		"data.CSV":          enum.FileRenderTypeCSV,
+		"data.tsv":          enum.FileRenderTypeCSV,
+		"map.geojson":       enum.FileRenderTypeGeoJSON,
+		"README.md":         "",
+	}
+	for path, want := range tests {
+		got, _ := TypeForPath(path)
+		if got != want {
+			t.Errorf("%s: expected %q, got %q", path, want, got)
+		}
+	}
+}
+
+func TestRenderNotebook(t *testing.T) {
+	const nb = `{
+  "nbformat": 4,
+  "metadata": {"kernelspec": {"language": "python"}},
+  "cells": [
+    {"cell_type": "markdown", "source": ["# Title\n", "text"]},
+    {"cell_type": "code", "execution_count": 1, "source": "print(1)", "outputs": [
+      {"output_type": "stream", "name": "stdout", "text": ["1\n"]},
+      {"output_type": "display_data", "data": {"text/html": "<b onclick=\"x()\">b</b><script>x()</script>"}},
+      {"output_type": "display_data", "data": {"text/html": "<table></table>", "text/plain": "df"}},
+      {"output_type": "execute_result", "data": {"image/png": "iVBO\nRw0K", "text/plain": "<Figure>"}},
+      {"output_type": "error", "ename": "E", "evalue": "v", "traceback": ["\u001b[0;31mE\u001b[0m: v"]}
+    ]}
+  ]
+}`
+
+	rendered, err := renderNotebook(markdown.NewRenderer(), []byte(nb))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rendered.Language != "python" || len(rendered.Cells) != 2 {
+		t.Fatalf("unexpected notebook: %+v", rendered)
+	}
+	if !strings.Contains(rendered.Cells[0].HTML, "<h1") {
+		t.Errorf("markdown cell not rendered: %q", rendered.Cells[0].HTML)
+	}
+
+	outputs := rendered.Cells[1].Outputs
+	if len(outputs) != 5 {
+		t.Fatalf("expected 5 outputs, got %d", len(outputs))
+	}
+	if outputs[0].Text != "1\n" {
+		t.Errorf("unexpected stream output: %q", outputs[0].Text)
+	}
+	if outputs[1].HTML != "" || outputs[1].MimeType != "text/plain" ||
+		outputs[1].Text != `<b onclick="x()">b</b><script>x()</script>` {
+		t.Errorf("html output without text representation not returned as text: %+v", outputs[1])
+	}
+	if outputs[2].HTML != "" || outputs[2].Text != "df" {
+		t.Errorf("html output not replaced by its text representation: %+v", outputs[2])
+	}
+	if outputs[3].MimeType != "image/png" || outputs[3].Data != "iVBORw0K" {
+		t.Errorf("unexpected image output: %+v", outputs[3])
+	}
+	if outputs[4].Text != "E: v" {
+		t.Errorf("unexpected error output: %q", outputs[4].Text)
+	}
+
+	if _, err = renderNotebook(markdown.NewRenderer(), []byte(`{"nbformat": 3}`)); err == nil {
+		t.Error("expected error for unsupported notebook format")
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	table, err := renderTable([]byte("\xef\xbb\xbfa\tb\n1\t2\n3\t4\n5\t6\n"), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Join(table.Headers, ",") != "a,b" {
+		t.Errorf("unexpected headers: %v", table.Headers)
+	}
+	if len(table.Rows) != 2 || !table.Truncated {
+		t.Errorf("expected 2 rows and truncation, got %v (truncated=%t)", table.Rows, table.Truncated)
+	}
+}
+
+func TestRenderGeoJSON(t *testing.T) {
+	const doc = `{"type": "FeatureCollection", "features": [
+  {"type": "Feature", "geometry": {"type": "Point", "coordinates": [10, -5]}},
+  {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[-3, 2], [4, 8.5]]}}
+]}`
+
+	rendered, err := renderGeoJSON([]byte(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rendered.FeatureCount != 2 {
+		t.Errorf("expected 2 features, got %d", rendered.FeatureCount)
+	}
+
+	want := []float64{-3, -5, 10, 8.5}
+	if len(rendered.BBox) != 4 {
+		t.Fatalf("unexpected bbox: %v", rendered.BBox)
+	}
+	for i := range want {
+		if rendered.BBox[i] != want[i] {
+			t.Errorf("expected bbox %v, got %v", want, rendered.BBox)
+			break
+		}
+	}
+
+	if _, err = renderGeoJSON([]byte(`{"type": "Circle"}`)); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
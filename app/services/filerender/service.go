@@ -0,0 +1,176 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerender
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Config holds the limits of the file render service.
+type Config struct {
+	MaxSize       int64
+	MaxRows       int
+	CacheDuration time.Duration
+}
+
+// Service renders rich previews of notebooks, CSV and GeoJSON files stored in repositories.
+// The rendered output only depends on the content of the blob, so it's cached by blob SHA.
+type Service struct {
+	config       Config
+	gitRPCClient gitrpc.Interface
+	markdown     *markdown.Renderer
+	cache        cache.Cache[cacheKey, *types.RenderedFile]
+}
+
+type cacheKey struct {
+	repoUID    string
+	sha        string
+	renderType enum.FileRenderType
+}
+
+func NewService(
+	config Config,
+	gitRPCClient gitrpc.Interface,
+	markdownRenderer *markdown.Renderer,
+) *Service {
+	s := &Service{
+		config:       config,
+		gitRPCClient: gitRPCClient,
+		markdown:     markdownRenderer,
+	}
+	s.cache = cache.New[cacheKey, *types.RenderedFile](renderGetter{s: s}, config.CacheDuration)
+
+	return s
+}
+
+// TypeForPath returns the render type of the file based on its extension.
+func TypeForPath(filePath string) (enum.FileRenderType, bool) {
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".ipynb":
+		return enum.FileRenderTypeNotebook, true
+	case ".csv", ".tsv":
+		return enum.FileRenderTypeCSV, true
+	case ".geojson":
+		return enum.FileRenderTypeGeoJSON, true
+	default:
+		return "", false
+	}
+}
+
+// Render returns the rich preview of the file of the repo at the provided git reference and path.
+func (s *Service) Render(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	filePath string,
+) (*types.RenderedFile, error) {
+	renderType, ok := TypeForPath(filePath)
+	if !ok {
+		return nil, usererror.BadRequestf("Rich rendering isn't supported for '%s'.", filePath)
+	}
+
+	node, err := s.gitRPCClient.GetTreeNode(ctx, &gitrpc.GetTreeNodeParams{
+		ReadParams: gitrpc.CreateRPCReadParams(repo),
+		GitREF:     gitRef,
+		Path:       filePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
+	}
+
+	if node.Node.Type != gitrpc.TreeNodeTypeBlob {
+		return nil, usererror.BadRequestf("Object in '%s' at '/%s' is of type '%s'. Only files can be rendered.",
+			gitRef, filePath, node.Node.Type)
+	}
+
+	rendered, err := s.cache.Get(ctx, cacheKey{
+		repoUID:    repo.GitUID,
+		sha:        node.Node.SHA,
+		renderType: renderType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the cached object is shared, so the path is set on a copy.
+	out := *rendered
+	out.Path = filePath
+
+	return &out, nil
+}
+
+type renderGetter struct {
+	s *Service
+}
+
+func (g renderGetter) Find(ctx context.Context, key cacheKey) (*types.RenderedFile, error) {
+	blob, err := g.s.gitRPCClient.GetBlob(ctx, &gitrpc.GetBlobParams{
+		ReadParams: gitrpc.ReadParams{RepoUID: key.repoUID},
+		SHA:        key.sha,
+		SizeLimit:  g.s.config.MaxSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	if blob.Size > g.s.config.MaxSize {
+		return nil, usererror.Newf(http.StatusUnprocessableEntity,
+			"File is too large to be rendered (%d bytes), the limit is %d bytes.", blob.Size, g.s.config.MaxSize)
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	rendered := &types.RenderedFile{
+		Type: key.renderType,
+		SHA:  key.sha,
+		Size: blob.Size,
+	}
+
+	switch key.renderType {
+	case enum.FileRenderTypeNotebook:
+		rendered.Notebook, err = renderNotebook(g.s.markdown, content)
+	case enum.FileRenderTypeCSV:
+		rendered.Table, err = renderTable(content, g.s.config.MaxRows)
+	case enum.FileRenderTypeGeoJSON:
+		rendered.GeoJSON, err = renderGeoJSON(content)
+	default:
+		err = fmt.Errorf("unknown render type '%s'", key.renderType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rendered, nil
+}
+
+func errInvalidContent(renderType enum.FileRenderType, err error) error {
+	return usererror.Newf(http.StatusUnprocessableEntity, "File isn't a valid %s file: %s", renderType, err)
+}
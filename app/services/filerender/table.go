@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerender
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// utf8BOM is the byte order mark some editors add to the start of CSV files.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// renderTable splits a CSV or TSV file into its header and rows, the delimiter is detected from the first line.
+// Rows beyond maxRows are dropped and the table is marked as truncated.
+func renderTable(content []byte, maxRows int) (*types.RenderedTable, error) {
+	content = bytes.TrimPrefix(content, utf8BOM)
+
+	r := csv.NewReader(bytes.NewReader(content))
+	r.Comma = detectDelimiter(content)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	table := &types.RenderedTable{
+		Headers: []string{},
+		Rows:    [][]string{},
+	}
+
+	headers, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return table, nil
+	}
+	if err != nil {
+		return nil, errInvalidContent(enum.FileRenderTypeCSV, err)
+	}
+
+	table.Headers = headers
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errInvalidContent(enum.FileRenderTypeCSV, err)
+		}
+
+		if len(table.Rows) >= maxRows {
+			table.Truncated = true
+			break
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, nil
+}
+
+// detectDelimiter returns a tab if the first line contains more tabs than commas, a comma otherwise.
+func detectDelimiter(content []byte) rune {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(firstLine, []byte("\t")) > bytes.Count(firstLine, []byte(",")) {
+		return '\t'
+	}
+
+	return ','
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/harness/gitness/githook"
+
+	"github.com/gotidy/ptr"
+)
+
+// execPlugin executes an external executable.
+type execPlugin struct {
+	name    string
+	command []string
+}
+
+func (p *execPlugin) Run(ctx context.Context, in *Input) (*githook.Output, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	//nolint:gosec // the command is defined by the operator.
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	runErr := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || ctx.Err() != nil) {
+		return nil, fmt.Errorf("failed to execute plugin: %w", runErr)
+	}
+
+	out := &githook.Output{}
+	if stdout.Len() > 0 {
+		if err = json.Unmarshal(stdout.Bytes(), out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plugin output: %w", err)
+		}
+	}
+
+	// a non-zero exit code always rejects the push.
+	if exitErr != nil && out.Error == nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = fmt.Sprintf("rejected by git hook plugin '%s'", p.name)
+		}
+		out.Error = ptr.String(msg)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/githook"
+)
+
+// httpResponseMax is the max size of a plugin response that is read.
+const httpResponseMax = 1 << 20 // 1 MiB
+
+// httpPlugin calls an http endpoint.
+type httpPlugin struct {
+	url    string
+	client *http.Client
+}
+
+func (p *httpPlugin) Run(ctx context.Context, in *Input) (*githook.Output, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call plugin: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("plugin responded with status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpResponseMax))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin response: %w", err)
+	}
+
+	out := &githook.Output{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err = json.Unmarshal(body, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plugin output: %w", err)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/githook"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type registration struct {
+	plugin   Plugin
+	hooks    map[Hook]struct{}
+	timeout  time.Duration
+	failOpen bool
+}
+
+// Manager runs the plugins that are enabled for a repository.
+type Manager struct {
+	settings       *settings.Service
+	httpClient     *http.Client
+	defaultTimeout time.Duration
+	plugins        map[string]registration
+	order          []string
+}
+
+// NewManager returns a new manager, http plugins are called using the provided client.
+func NewManager(settings *settings.Service, httpClient *http.Client, defaultTimeout time.Duration) *Manager {
+	return &Manager{
+		settings:       settings,
+		httpClient:     httpClient,
+		defaultTimeout: defaultTimeout,
+		plugins:        map[string]registration{},
+	}
+}
+
+// Register registers a plugin under the provided name.
+// A zero timeout falls back to the default timeout of the manager.
+func (m *Manager) Register(
+	name string,
+	plugin Plugin,
+	hooks []Hook,
+	timeout time.Duration,
+	failOpen bool,
+) error {
+	if name == "" {
+		return fmt.Errorf("plugin name is required")
+	}
+	if _, ok := m.plugins[name]; ok {
+		return fmt.Errorf("plugin '%s' is already registered", name)
+	}
+	if len(hooks) == 0 {
+		return fmt.Errorf("plugin '%s' has no hooks", name)
+	}
+
+	hookSet := make(map[Hook]struct{}, len(hooks))
+	for _, hook := range hooks {
+		if hook != HookPreReceive && hook != HookPostReceive {
+			return fmt.Errorf("plugin '%s' has unknown hook '%s'", name, hook)
+		}
+		hookSet[hook] = struct{}{}
+	}
+
+	if timeout <= 0 {
+		timeout = m.defaultTimeout
+	}
+
+	m.plugins[name] = registration{
+		plugin:   plugin,
+		hooks:    hookSet,
+		timeout:  timeout,
+		failOpen: failOpen,
+	}
+	m.order = append(m.order, name)
+
+	return nil
+}
+
+// RegisterDefinition creates and registers the plugin of the provided definition.
+func (m *Manager) RegisterDefinition(def Definition) error {
+	var plugin Plugin
+	switch def.Type {
+	case TypeExec:
+		if len(def.Command) == 0 {
+			return fmt.Errorf("exec plugin '%s' has no command", def.Name)
+		}
+		plugin = &execPlugin{name: def.Name, command: def.Command}
+	case TypeHTTP:
+		if def.URL == "" {
+			return fmt.Errorf("http plugin '%s' has no url", def.Name)
+		}
+		plugin = &httpPlugin{url: def.URL, client: m.httpClient}
+	default:
+		return fmt.Errorf("plugin '%s' has unknown type '%s'", def.Name, def.Type)
+	}
+
+	return m.Register(def.Name, plugin, def.Hooks, time.Duration(def.Timeout), def.FailOpen)
+}
+
+// LoadDefinitions registers all plugins defined in the provided JSON file.
+func (m *Manager) LoadDefinitions(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin definitions: %w", err)
+	}
+
+	var defs []Definition
+	if err = json.Unmarshal(data, &defs); err != nil {
+		return fmt.Errorf("failed to parse plugin definitions: %w", err)
+	}
+
+	for _, def := range defs {
+		if err = m.RegisterDefinition(def); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run executes all plugins of the hook that are enabled for the repository, in registration order.
+// For pre-receive the first rejection stops the execution and is returned,
+// failing plugins reject the push unless they are configured to fail open.
+// For post-receive failures are only logged.
+func (m *Manager) Run(
+	ctx context.Context,
+	hook Hook,
+	repo *types.Repository,
+	principalID int64,
+	refUpdates []githook.ReferenceUpdate,
+) (*githook.Output, error) {
+	out := &githook.Output{}
+	if len(m.plugins) == 0 {
+		return out, nil
+	}
+
+	enabled, err := m.settings.RepoGithookPlugins(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled git hook plugins: %w", err)
+	}
+
+	enabledSet := make(map[string]struct{}, len(enabled))
+	for _, name := range enabled {
+		enabledSet[name] = struct{}{}
+	}
+
+	in := &Input{
+		Hook:        hook,
+		RepoID:      repo.ID,
+		RepoPath:    repo.Path,
+		PrincipalID: principalID,
+		RefUpdates:  refUpdates,
+	}
+
+	for _, name := range m.order {
+		if _, ok := enabledSet[name]; !ok {
+			continue
+		}
+
+		reg := m.plugins[name]
+		if _, ok := reg.hooks[hook]; !ok {
+			continue
+		}
+
+		pluginOut, err := m.runPlugin(ctx, reg, in)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("plugin", name).
+				Str("hook", string(hook)).
+				Msg("git hook plugin failed")
+
+			if hook == HookPreReceive && !reg.failOpen {
+				msg := fmt.Sprintf("git hook plugin '%s' failed", name)
+				out.Error = &msg
+				return out, nil
+			}
+
+			continue
+		}
+
+		out.Messages = append(out.Messages, pluginOut.Messages...)
+
+		if pluginOut.Error != nil && hook == HookPreReceive {
+			out.Error = pluginOut.Error
+			return out, nil
+		}
+	}
+
+	return out, nil
+}
+
+func (m *Manager) runPlugin(ctx context.Context, reg registration, in *Input) (*githook.Output, error) {
+	ctx, cancel := context.WithTimeout(ctx, reg.timeout)
+	defer cancel()
+
+	return reg.plugin.Run(ctx, in)
+}
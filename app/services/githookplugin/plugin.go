@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookplugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/githook"
+)
+
+// Hook is the git hook a plugin is executed for.
+type Hook string
+
+const (
+	HookPreReceive  Hook = "pre-receive"
+	HookPostReceive Hook = "post-receive"
+)
+
+// Input is the input provided to plugins.
+type Input struct {
+	Hook        Hook                      `json:"hook"`
+	RepoID      int64                     `json:"repo_id"`
+	RepoPath    string                    `json:"repo_path"`
+	PrincipalID int64                     `json:"principal_id"`
+	RefUpdates  []githook.ReferenceUpdate `json:"ref_updates"`
+}
+
+// Plugin is custom logic executed as part of the server side git hooks.
+// For pre-receive hooks an output with an error rejects the push,
+// for post-receive hooks only the messages of the output are shown to the user.
+type Plugin interface {
+	Run(ctx context.Context, in *Input) (*githook.Output, error)
+}
+
+// Type is the type of plugins operators can define in the plugin configuration.
+type Type string
+
+const (
+	// TypeExec plugins are external executables. They get the input as JSON on stdin
+	// and can write the output as JSON to stdout. A non-zero exit code rejects the push.
+	TypeExec Type = "exec"
+	// TypeHTTP plugins are http endpoints. They get the input as JSON in a POST request
+	// and can return the output as JSON. A non-2xx status code is treated as failure.
+	TypeHTTP Type = "http"
+)
+
+// Definition is the definition of a plugin in the plugin configuration.
+type Definition struct {
+	Name  string `json:"name"`
+	Type  Type   `json:"type"`
+	Hooks []Hook `json:"hooks"`
+
+	// Command is the executable and its arguments of exec plugins.
+	Command []string `json:"command,omitempty"`
+	// URL is the endpoint of http plugins.
+	URL string `json:"url,omitempty"`
+
+	// Timeout is the max duration of a single execution (e.g. "5s").
+	Timeout Duration `json:"timeout,omitempty"`
+	// FailOpen accepts pushes in case the plugin fails during pre-receive instead of rejecting them.
+	FailOpen bool `json:"fail_open,omitempty"`
+}
+
+// Duration is a time.Duration that is read from a JSON string like "5s".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
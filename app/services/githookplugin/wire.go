@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookplugin
+
+import (
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideManager,
+)
+
+func ProvideManager(
+	config *types.Config,
+	settings *settings.Service,
+	clientFactory *outbound.ClientFactory,
+) (*Manager, error) {
+	// the plugins are configured by the operator and usually run in the private network.
+	httpClient := clientFactory.Client(outbound.ClientOptions{
+		AllowLoopback:       true,
+		AllowPrivateNetwork: true,
+	})
+
+	manager := NewManager(settings, httpClient, config.GithookPlugins.DefaultTimeout)
+
+	if config.GithookPlugins.ConfigPath == "" {
+		return manager, nil
+	}
+
+	if err := manager.LoadDefinitions(config.GithookPlugins.ConfigPath); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitmetrics
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+const (
+	serviceUploadPack  = "upload-pack"
+	serviceReceivePack = "receive-pack"
+
+	// pktLinePrefixLen is the number of payload bytes of a pkt-line needed to classify it.
+	pktLinePrefixLen = 9
+)
+
+// RequestReader counts the bytes read from a git smart http request body and the pkt-lines
+// of its first section (up to the first flush-pkt) that reference refs:
+// ref update commands for receive-pack and wanted objects for upload-pack.
+type RequestReader struct {
+	r       io.ReadCloser
+	service string
+
+	size     int64
+	refCount int
+
+	// pkt-line parsing state
+	done      bool
+	hdr       [4]byte
+	hdrLen    int
+	remaining int
+	prefix    []byte
+}
+
+func NewRequestReader(r io.ReadCloser, service string) *RequestReader {
+	return &RequestReader{
+		r:       r,
+		service: service,
+		prefix:  make([]byte, 0, pktLinePrefixLen),
+	}
+}
+
+func (c *RequestReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.size += int64(n)
+	if !c.done {
+		c.parse(p[:n])
+	}
+
+	return n, err
+}
+
+func (c *RequestReader) Close() error {
+	return c.r.Close()
+}
+
+// Size returns the number of bytes read so far.
+func (c *RequestReader) Size() int64 {
+	return c.size
+}
+
+// RefCount returns the number of ref related pkt-lines read so far.
+func (c *RequestReader) RefCount() int {
+	return c.refCount
+}
+
+func (c *RequestReader) parse(data []byte) {
+	for len(data) > 0 && !c.done {
+		if c.remaining == 0 {
+			k := copy(c.hdr[c.hdrLen:], data)
+			c.hdrLen += k
+			data = data[k:]
+			if c.hdrLen < len(c.hdr) {
+				return
+			}
+			c.hdrLen = 0
+
+			length, err := strconv.ParseUint(string(c.hdr[:]), 16, 16)
+			switch {
+			case err != nil || length == 0:
+				// the flush-pkt ends the section with the commands, stop on malformed input as well.
+				c.done = true
+			case length <= 4:
+				// delim-pkt, response-end-pkt or empty line - nothing to count.
+			default:
+				c.remaining = int(length) - 4
+				c.prefix = c.prefix[:0]
+			}
+
+			continue
+		}
+
+		k := c.remaining
+		if k > len(data) {
+			k = len(data)
+		}
+
+		if need := pktLinePrefixLen - len(c.prefix); need > 0 {
+			if need > k {
+				need = k
+			}
+			c.prefix = append(c.prefix, data[:need]...)
+		}
+
+		c.remaining -= k
+		data = data[k:]
+
+		if c.remaining == 0 {
+			c.countLine()
+		}
+	}
+}
+
+func (c *RequestReader) countLine() {
+	switch c.service {
+	case serviceUploadPack:
+		if bytes.HasPrefix(c.prefix, []byte("want ")) {
+			c.refCount++
+		}
+	case serviceReceivePack:
+		if !bytes.HasPrefix(c.prefix, []byte("shallow ")) && !bytes.HasPrefix(c.prefix, []byte("push-cert")) {
+			c.refCount++
+		}
+	}
+}
+
+// ResponseWriter counts the bytes written to the wrapped writer.
+type ResponseWriter struct {
+	w    io.Writer
+	size int64
+}
+
+func NewResponseWriter(w io.Writer) *ResponseWriter {
+	return &ResponseWriter{w: w}
+}
+
+func (c *ResponseWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.size += int64(n)
+	return n, err
+}
+
+// Size returns the number of bytes written so far.
+func (c *ResponseWriter) Size() int64 {
+	return c.size
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitmetrics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRequestReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  string
+		body     string
+		refCount int
+	}{
+		{
+			name:    "push with two ref updates",
+			service: serviceReceivePack,
+			body: pktLine(strings.Repeat("0", 40)+" "+strings.Repeat("a", 40)+" refs/heads/main\x00report-status\n") +
+				pktLine(strings.Repeat("b", 40)+" "+strings.Repeat("c", 40)+" refs/heads/dev\n") +
+				"0000PACK",
+			refCount: 2,
+		},
+		{
+			name:    "fetch with wants and haves",
+			service: serviceUploadPack,
+			body: pktLine("want "+strings.Repeat("a", 40)+"\n") +
+				pktLine("want "+strings.Repeat("b", 40)+"\n") +
+				"0000" +
+				pktLine("have "+strings.Repeat("c", 40)+"\n") +
+				pktLine("done\n"),
+			refCount: 2,
+		},
+		{
+			name:     "malformed",
+			service:  serviceUploadPack,
+			body:     "zzzzwant",
+			refCount: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// read byte by byte to verify the parser handles split pkt-lines.
+			r := NewRequestReader(io.NopCloser(&oneByteReader{r: strings.NewReader(test.body)}), test.service)
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if r.Size() != int64(len(test.body)) {
+				t.Errorf("expected size %d, got %d", len(test.body), r.Size())
+			}
+			if r.RefCount() != test.refCount {
+				t.Errorf("expected ref count %d, got %d", test.refCount, r.RefCount())
+			}
+		})
+	}
+}
+
+func pktLine(payload string) string {
+	return fmt.Sprintf("%04x%s", len(payload)+4, payload)
+}
+
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p[:1])
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitmetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	operationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "operations_total",
+		Help:      "Total number of git http operations.",
+	}, []string{"service", "result"})
+
+	operationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of git http operations.",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"service"})
+
+	operationRequestBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "operation_request_bytes",
+		Help:      "Size of the data received by git http operations (includes the pack of pushes).",
+		Buckets:   prometheus.ExponentialBuckets(1024, 4, 10), // 1KiB to 256MiB
+	}, []string{"service"})
+
+	operationResponseBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "operation_response_bytes",
+		Help:      "Size of the data sent by git http operations (includes the pack of fetches).",
+		Buckets:   prometheus.ExponentialBuckets(1024, 4, 10), // 1KiB to 256MiB
+	}, []string{"service"})
+
+	operationRefs = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "operation_refs",
+		Help:      "Number of refs updated by pushes or wanted by fetches.",
+		Buckets:   []float64{1, 2, 5, 10, 50, 100, 500, 1000},
+	}, []string{"service"})
+)
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitmetrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Tracker records metrics of git http operations and keeps the most recent slow operations in memory.
+// The slow operations are per instance and lost on restart, they're only meant for diagnosing repositories.
+type Tracker struct {
+	threshold time.Duration
+	max       int
+
+	mx   sync.Mutex
+	slow []types.GitOperation
+}
+
+func NewTracker(threshold time.Duration, maxSlow int) *Tracker {
+	return &Tracker{
+		threshold: threshold,
+		max:       maxSlow,
+		slow:      make([]types.GitOperation, 0, maxSlow),
+	}
+}
+
+// Record records the metrics of a finished git operation.
+// Operations exceeding the slow operation threshold are logged and kept.
+func (t *Tracker) Record(ctx context.Context, op types.GitOperation) {
+	result := resultSuccess
+	if op.Error != "" {
+		result = resultError
+	}
+
+	operationsTotal.WithLabelValues(op.Service, result).Inc()
+	operationDuration.WithLabelValues(op.Service).Observe((time.Duration(op.Duration) * time.Millisecond).Seconds())
+	operationRequestBytes.WithLabelValues(op.Service).Observe(float64(op.RequestSize))
+	operationResponseBytes.WithLabelValues(op.Service).Observe(float64(op.ResponseSize))
+	operationRefs.WithLabelValues(op.Service).Observe(float64(op.RefCount))
+
+	if t.threshold <= 0 || time.Duration(op.Duration)*time.Millisecond < t.threshold {
+		return
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("git.service", op.Service).
+		Str("repo.path", op.RepoPath).
+		Int64("git.duration_ms", op.Duration).
+		Int64("git.request_size", op.RequestSize).
+		Int64("git.response_size", op.ResponseSize).
+		Int("git.ref_count", op.RefCount).
+		Msg("slow git operation")
+
+	if t.max <= 0 {
+		return
+	}
+
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if len(t.slow) >= t.max {
+		copy(t.slow, t.slow[1:])
+		t.slow = t.slow[:len(t.slow)-1]
+	}
+	t.slow = append(t.slow, op)
+}
+
+// ListSlow returns the slow operations kept by this instance, most recent first.
+func (t *Tracker) ListSlow() []types.GitOperation {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	res := make([]types.GitOperation, len(t.slow))
+	for i := range t.slow {
+		res[len(t.slow)-1-i] = t.slow[i]
+	}
+
+	return res
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitshard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+var _ gitrpc.ShardRouter = (*Router)(nil)
+
+// Router routes the repositories to the shards (gitrpc servers) storing them using the routing table.
+// New repositories are assigned to the shard storing the fewest repositories, repositories without
+// an entry in the routing table (created before sharding was enabled) are stored on the default shard.
+type Router struct {
+	repoShardStore store.RepositoryShardStore
+	shards         []string
+	defaultShard   string
+
+	// routes caches the shards of the repositories, as the shard of a repository never changes.
+	routes sync.Map
+}
+
+func NewRouter(repoShardStore store.RepositoryShardStore, shards []string, defaultShard string) *Router {
+	sorted := make([]string, len(shards))
+	copy(sorted, shards)
+	sort.Strings(sorted)
+
+	return &Router{
+		repoShardStore: repoShardStore,
+		shards:         sorted,
+		defaultShard:   defaultShard,
+	}
+}
+
+// Route returns the shard storing the existing repository with the provided uid.
+func (r *Router) Route(ctx context.Context, repoUID string) (string, error) {
+	if shard, ok := r.routes.Load(repoUID); ok {
+		return shard.(string), nil
+	}
+
+	shard := r.defaultShard
+
+	repoShard, err := r.repoShardStore.Find(ctx, repoUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", err
+	}
+	if err == nil {
+		shard = repoShard.Shard
+	}
+
+	r.routes.Store(repoUID, shard)
+
+	return shard, nil
+}
+
+// Assign returns the shard a new repository with the provided uid is created on.
+func (r *Router) Assign(ctx context.Context, repoUID string) (string, error) {
+	if len(r.shards) == 0 {
+		return "", errors.New("no shards configured")
+	}
+
+	counts, err := r.repoShardStore.CountByShard(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to count repositories by shard: %w", err)
+	}
+
+	err = r.repoShardStore.Create(ctx, &types.RepositoryShard{
+		GitUID:  repoUID,
+		Shard:   leastUsedShard(r.shards, counts),
+		Created: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to assign repository to shard: %w", err)
+	}
+
+	// read the entry back, as the repository might have been assigned to a shard already (e.g. on a retry).
+	repoShard, err := r.repoShardStore.Find(ctx, repoUID)
+	if err != nil {
+		return "", err
+	}
+
+	r.routes.Store(repoUID, repoShard.Shard)
+
+	return repoShard.Shard, nil
+}
+
+// leastUsedShard returns the shard storing the fewest repositories (the first in order in case of a tie).
+func leastUsedShard(shards []string, counts map[string]int64) string {
+	shard := shards[0]
+	for _, s := range shards[1:] {
+		if counts[s] < counts[shard] {
+			shard = s
+		}
+	}
+
+	return shard
+}
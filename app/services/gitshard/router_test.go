@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitshard
+
+import "testing"
+
+func TestLeastUsedShard(t *testing.T) {
+	tests := []struct {
+		name   string
+		shards []string
+		counts map[string]int64
+		want   string
+	}{
+		{
+			name:   "no repositories",
+			shards: []string{"a", "b", "c"},
+			counts: map[string]int64{},
+			want:   "a",
+		},
+		{
+			name:   "fewest repositories",
+			shards: []string{"a", "b", "c"},
+			counts: map[string]int64{"a": 3, "b": 1, "c": 2},
+			want:   "b",
+		},
+		{
+			name:   "new shard",
+			shards: []string{"a", "b", "c"},
+			counts: map[string]int64{"a": 3, "b": 1},
+			want:   "c",
+		},
+		{
+			name:   "removed shard is ignored",
+			shards: []string{"b", "c"},
+			counts: map[string]int64{"a": 0, "b": 2, "c": 2},
+			want:   "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leastUsedShard(tt.shards, tt.counts); got != tt.want {
+				t.Errorf("leastUsedShard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
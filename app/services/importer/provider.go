@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/outbound"
 	"github.com/harness/gitness/types"
 
 	"github.com/drone/go-scm/scm"
@@ -50,6 +51,9 @@ type Provider struct {
 	Host     string       `json:"host"`
 	Username string       `json:"username"`
 	Password string       `json:"password"`
+	// ProxyURL is the url of the proxy used to connect to the provider (optional).
+	// If empty, the configured proxy is used.
+	ProxyURL string `json:"proxy_url"`
 }
 
 type RepositoryInfo struct {
@@ -91,7 +95,22 @@ func hash(s string) string {
 	return base32.StdEncoding.EncodeToString(h.Sum(nil)[:10])
 }
 
-func getClient(provider Provider, authReq bool) (*scm.Client, error) {
+// withProxy returns a copy of the context that makes the clients send requests to the provider
+// via the proxy of the provider. The context is returned unchanged if the provider has no proxy.
+func (p Provider) withProxy(ctx context.Context) (context.Context, error) {
+	if p.ProxyURL == "" {
+		return ctx, nil
+	}
+
+	proxyURL, err := outbound.ParseProxyURL(p.ProxyURL)
+	if err != nil {
+		return nil, usererror.BadRequestf("scm provider proxy invalid: %s", err.Error())
+	}
+
+	return outbound.WithProxy(ctx, proxyURL), nil
+}
+
+func (r *Repository) getClient(provider Provider, authReq bool) (*scm.Client, error) {
 	if authReq && (provider.Username == "" || provider.Password == "") {
 		return nil, usererror.BadRequest("scm provider authentication credentials missing")
 	}
@@ -127,18 +146,33 @@ func getClient(provider Provider, authReq bool) (*scm.Client, error) {
 		return nil, usererror.BadRequestf("unsupported scm provider: %s", provider)
 	}
 
+	// providers can be hosted in the private network, the deny rules of the outbound policy still apply.
+	c.Client = r.clientFactory.Client(outbound.ClientOptions{
+		AllowLoopback:       true,
+		AllowPrivateNetwork: true,
+	})
+
 	if provider.Password != "" {
-		c.Client = &http.Client{
-			Transport: &oauth2.Transport{
-				Source: oauth2.StaticTokenSource(&scm.Token{Token: provider.Password}),
-			},
+		c.Client.Transport = &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&scm.Token{Token: provider.Password}),
+			Base:   c.Client.Transport,
 		}
 	}
 
 	return c, nil
 }
-func LoadRepositoryFromProvider(ctx context.Context, provider Provider, repoSlug string) (RepositoryInfo, error) {
-	scmClient, err := getClient(provider, false)
+
+func (r *Repository) LoadRepositoryFromProvider(
+	ctx context.Context,
+	provider Provider,
+	repoSlug string,
+) (RepositoryInfo, error) {
+	scmClient, err := r.getClient(provider, false)
+	if err != nil {
+		return RepositoryInfo{}, err
+	}
+
+	ctx, err = provider.withProxy(ctx)
 	if err != nil {
 		return RepositoryInfo{}, err
 	}
@@ -161,12 +195,17 @@ func LoadRepositoryFromProvider(ctx context.Context, provider Provider, repoSlug
 	}, nil
 }
 
-func LoadRepositoriesFromProviderSpace(
+func (r *Repository) LoadRepositoriesFromProviderSpace(
 	ctx context.Context,
 	provider Provider,
 	spaceSlug string,
 ) ([]RepositoryInfo, error) {
-	scmClient, err := getClient(provider, true)
+	scmClient, err := r.getClient(provider, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = provider.withProxy(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -20,12 +20,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -61,6 +65,9 @@ type Repository struct {
 	encrypter     encrypt.Encrypter
 	scheduler     *job.Scheduler
 	sseStreamer   sse.Streamer
+
+	instanceSettings *instancesettings.Service
+	clientFactory    *outbound.ClientFactory
 }
 
 var _ job.Handler = (*Repository)(nil)
@@ -82,6 +89,7 @@ type Input struct {
 	GitUser   string         `json:"git_user"`
 	GitPass   string         `json:"git_pass"`
 	CloneURL  string         `json:"clone_url"`
+	ProxyURL  string         `json:"proxy_url,omitempty"`
 	Pipelines PipelineOption `json:"pipelines"`
 }
 
@@ -99,11 +107,16 @@ func (r *Repository) Run(
 	cloneURL string,
 	pipelines PipelineOption,
 ) error {
+	if err := r.checkCloneURL(ctx, cloneURL); err != nil {
+		return err
+	}
+
 	jobDef, err := r.getJobDef(JobIDFromRepoID(repo.ID), Input{
 		RepoID:    repo.ID,
 		GitUser:   provider.Username,
 		GitPass:   provider.Password,
 		CloneURL:  cloneURL,
+		ProxyURL:  provider.ProxyURL,
 		Pipelines: pipelines,
 	})
 	if err != nil {
@@ -126,6 +139,12 @@ func (r *Repository) RunMany(ctx context.Context,
 			len(repoIDs), len(cloneURLs))
 	}
 
+	for _, cloneURL := range cloneURLs {
+		if err := r.checkCloneURL(ctx, cloneURL); err != nil {
+			return err
+		}
+	}
+
 	n := len(repoIDs)
 	defs := make([]job.Definition, n)
 
@@ -138,6 +157,7 @@ func (r *Repository) RunMany(ctx context.Context,
 			GitUser:   provider.Username,
 			GitPass:   provider.Password,
 			CloneURL:  cloneURL,
+			ProxyURL:  provider.ProxyURL,
 			Pipelines: pipelines,
 		})
 		if err != nil {
@@ -155,6 +175,56 @@ func (r *Repository) RunMany(ctx context.Context,
 	return nil
 }
 
+// checkCloneURL validates the host of the clone URL against the outbound policy.
+// Repositories can be imported from private networks, hence only the deny rules apply.
+// NOTE: The repository is cloned by git, which resolves the host name on its own.
+func (r *Repository) checkCloneURL(ctx context.Context, cloneURL string) error {
+	repoURL, err := url.Parse(cloneURL)
+	if err != nil {
+		return usererror.BadRequestf("Invalid clone URL: %s", err)
+	}
+
+	policy, err := r.instanceSettings.OutboundPolicy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get outbound policy: %w", err)
+	}
+
+	policy.AllowLoopback = true
+	policy.AllowPrivateNetwork = true
+
+	_, err = policy.Resolve(ctx, repoURL.Hostname())
+	if errors.Is(err, outbound.ErrTargetDenied) {
+		return usererror.BadRequestf("Importing from '%s' is not allowed.", repoURL.Hostname())
+	}
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) && dnsError.IsNotFound {
+		return usererror.BadRequestf("Host '%s' of the clone URL was not found.", repoURL.Hostname())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve host of clone URL: %w", err)
+	}
+
+	return nil
+}
+
+// getProxyURL returns the url of the proxy git uses to clone the repository,
+// which is either the proxy of the import or the configured proxy.
+func (r *Repository) getProxyURL(ctx context.Context, repoURL *url.URL, importProxyURL string) (string, error) {
+	if importProxyURL != "" {
+		return importProxyURL, nil
+	}
+
+	proxyURL, err := r.clientFactory.Proxy(ctx, repoURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to get proxy for clone URL: %w", err)
+	}
+	if proxyURL == nil {
+		return "", nil
+	}
+
+	return proxyURL.String(), nil
+}
+
 func (r *Repository) getJobDef(jobUID string, input Input) (job.Definition, error) {
 	data, err := json.Marshal(input)
 	if err != nil {
@@ -218,6 +288,16 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		return "", fmt.Errorf("failed to parse git clone URL: %w", err)
 	}
 
+	// the policy could have changed since the import was started
+	if err = r.checkCloneURL(ctx, input.CloneURL); err != nil {
+		return "", err
+	}
+
+	proxyURL, err := r.getProxyURL(ctx, repoURL, input.ProxyURL)
+	if err != nil {
+		return "", err
+	}
+
 	repoURL.User = url.UserPassword(input.GitUser, input.GitPass)
 	cloneURLWithAuth := repoURL.String()
 
@@ -249,7 +329,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 		log.Info().Msg("sync repository")
 
-		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth)
+		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth, proxyURL)
 		if err != nil {
 			return fmt.Errorf("failed to sync git repository from '%s': %w", input.CloneURL, err)
 		}
@@ -382,6 +462,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repo *types.Repository,
 	sourceCloneURL string,
+	proxyURL string,
 ) (string, error) {
 	writeParams, err := r.createRPCWriteParams(ctx, principal, repo)
 	if err != nil {
@@ -392,6 +473,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 		WriteParams:       writeParams,
 		Source:            sourceCloneURL,
 		CreateIfNotExists: false,
+		ProxyURL:          proxyURL,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to sync repository: %w", err)
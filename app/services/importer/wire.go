@@ -15,6 +15,8 @@
 package importer
 
 import (
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -43,6 +45,8 @@ func ProvideRepoImporter(
 	scheduler *job.Scheduler,
 	executor *job.Executor,
 	sseStreamer sse.Streamer,
+	instanceSettings *instancesettings.Service,
+	clientFactory *outbound.ClientFactory,
 ) (*Repository, error) {
 	importer := &Repository{
 		defaultBranch: config.Git.DefaultBranch,
@@ -55,6 +59,9 @@ func ProvideRepoImporter(
 		encrypter:     encrypter,
 		scheduler:     scheduler,
 		sseStreamer:   sseStreamer,
+
+		instanceSettings: instanceSettings,
+		clientFactory:    clientFactory,
 	}
 
 	err := executor.Register(jobType, importer)
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeAggregate        = "gitness:insights:aggregate"
+	jobCronAggregate        = "30 * * * *" // At minute 30 of every hour.
+	jobMaxDurationAggregate = 30 * time.Minute
+
+	// dayMillis is the length of a day in milliseconds, the granularity of all aggregated statistics.
+	dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+	// initialBackfillDays is the number of days aggregated for repositories without any statistics.
+	initialBackfillDays = 90
+
+	repoBatchSize   = 100
+	commitPageLimit = 100
+)
+
+// Service periodically aggregates the contribution statistics of all repositories into summary tables.
+type Service struct {
+	tx            dbtx.Transactor
+	repoStore     store.RepoStore
+	insightsStore store.InsightsStore
+	gitRPCClient  gitrpc.Interface
+	scheduler     *job.Scheduler
+	executor      *job.Executor
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	insightsStore store.InsightsStore,
+	gitRPCClient gitrpc.Interface,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return &Service{
+		tx:            tx,
+		repoStore:     repoStore,
+		insightsStore: insightsStore,
+		gitRPCClient:  gitRPCClient,
+		scheduler:     scheduler,
+		executor:      executor,
+	}
+}
+
+// Register registers and schedules the job aggregating the repository statistics.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeAggregate, s)
+	if err != nil {
+		return fmt.Errorf("failed to register insights aggregation job handler: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypeAggregate, jobTypeAggregate, jobCronAggregate, jobMaxDurationAggregate)
+	if err != nil {
+		return fmt.Errorf("failed to schedule insights aggregation job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle aggregates the statistics of all repositories.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	today := time.Now().UnixMilli() / dayMillis * dayMillis
+
+	aggregated := 0
+	afterID := int64(0)
+	for {
+		repos, err := s.repoStore.ListAll(ctx, afterID, repoBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			if err := s.aggregateRepo(ctx, repo, today); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", repo.ID).
+					Msg("failed to aggregate repository insights")
+				continue
+			}
+			aggregated++
+		}
+
+		if len(repos) < repoBatchSize {
+			break
+		}
+
+		afterID = repos[len(repos)-1].ID
+	}
+
+	result := fmt.Sprintf("aggregated insights of %d repositories", aggregated)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// aggregateRepo recomputes the statistics of the repository since the last aggregated day.
+// The last day is recomputed as well, as it might not have been complete at the time.
+func (s *Service) aggregateRepo(ctx context.Context, repo *types.Repository, today int64) error {
+	if repo.Importing {
+		return nil
+	}
+
+	since, err := s.insightsStore.FindLatestDay(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find latest aggregated day: %w", err)
+	}
+
+	if since == 0 {
+		since = today - initialBackfillDays*dayMillis
+	}
+
+	days, err := s.insightsStore.AggregatePullReqs(ctx, repo.ID, since)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate pull requests: %w", err)
+	}
+
+	dayMap := make(map[int64]*types.InsightsDay, len(days))
+	for _, d := range days {
+		dayMap[d.Day] = d
+	}
+
+	contributors, err := s.aggregateCommits(ctx, repo, since, dayMap)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate commits: %w", err)
+	}
+
+	days = make([]*types.InsightsDay, 0, len(dayMap))
+	for _, d := range dayMap {
+		days = append(days, d)
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		return s.insightsStore.Replace(ctx, repo.ID, since, days, contributors)
+	})
+}
+
+// aggregateCommits counts the commits on the default branch of the repository per day and per contributor.
+// Commits are attributed to the day they were committed and to the email of their author.
+func (s *Service) aggregateCommits(
+	ctx context.Context,
+	repo *types.Repository,
+	since int64,
+	days map[int64]*types.InsightsDay,
+) ([]*types.InsightsContributorDay, error) {
+	type contributorKey struct {
+		day   int64
+		email string
+	}
+	contributors := map[contributorKey]*types.InsightsContributorDay{}
+
+	for page := int32(1); ; page++ {
+		out, err := s.gitRPCClient.ListCommits(ctx, &gitrpc.ListCommitsParams{
+			ReadParams: gitrpc.CreateRPCReadParams(repo),
+			GitREF:     repo.DefaultBranch,
+			Page:       page,
+			Limit:      commitPageLimit,
+			Since:      since / 1000,
+		})
+		if gitrpc.ErrorStatus(err) == gitrpc.StatusNotFound {
+			// the repository is empty, there is nothing to aggregate.
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+
+		for i := range out.Commits {
+			commit := &out.Commits[i]
+
+			day := commit.Committer.When.UnixMilli() / dayMillis * dayMillis
+			if day < since {
+				continue
+			}
+
+			d, ok := days[day]
+			if !ok {
+				d = &types.InsightsDay{RepoID: repo.ID, Day: day}
+				days[day] = d
+			}
+			d.Commits++
+
+			email := strings.ToLower(commit.Author.Identity.Email)
+			key := contributorKey{day: day, email: email}
+			c, ok := contributors[key]
+			if !ok {
+				c = &types.InsightsContributorDay{
+					RepoID: repo.ID,
+					Day:    day,
+					InsightsContributor: types.InsightsContributor{
+						Email: email,
+						Name:  commit.Author.Identity.Name,
+					},
+				}
+				contributors[key] = c
+			}
+			c.Commits++
+		}
+
+		if len(out.Commits) < commitPageLimit {
+			break
+		}
+	}
+
+	res := make([]*types.InsightsContributorDay, 0, len(contributors))
+	for _, c := range contributors {
+		res = append(res, c)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package insights
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s fakeRepoStore) ListAll(_ context.Context, afterID int64, limit int) ([]*types.Repository, error) {
+	var result []*types.Repository
+	for _, repo := range s.repos {
+		if repo.ID > afterID && len(result) < limit {
+			result = append(result, repo)
+		}
+	}
+	return result, nil
+}
+
+type fakeInsightsStore struct {
+	store.InsightsStore
+	latestDay    int64
+	pullReqDays  []*types.InsightsDay
+	since        int64
+	days         []*types.InsightsDay
+	contributors []*types.InsightsContributorDay
+}
+
+func (s *fakeInsightsStore) FindLatestDay(context.Context, int64) (int64, error) {
+	return s.latestDay, nil
+}
+
+func (s *fakeInsightsStore) AggregatePullReqs(context.Context, int64, int64) ([]*types.InsightsDay, error) {
+	return s.pullReqDays, nil
+}
+
+func (s *fakeInsightsStore) Replace(_ context.Context, _ int64, since int64,
+	days []*types.InsightsDay, contributors []*types.InsightsContributorDay) error {
+	s.since = since
+	s.days = days
+	s.contributors = contributors
+	return nil
+}
+
+// fakeGitRPC returns the commits on the default branch, newest first.
+type fakeGitRPC struct {
+	gitrpc.Interface
+	commits []gitrpc.Commit
+}
+
+func (g fakeGitRPC) ListCommits(_ context.Context, params *gitrpc.ListCommitsParams) (*gitrpc.ListCommitsOutput,
+	error) {
+	if len(g.commits) == 0 {
+		return nil, gitrpc.NewError(gitrpc.StatusNotFound, "reference not found")
+	}
+
+	start := int(params.Page-1) * int(params.Limit)
+	end := start + int(params.Limit)
+	if start > len(g.commits) {
+		start = len(g.commits)
+	}
+	if end > len(g.commits) {
+		end = len(g.commits)
+	}
+	return &gitrpc.ListCommitsOutput{Commits: g.commits[start:end]}, nil
+}
+
+func commitAt(when time.Time, name, email string) gitrpc.Commit {
+	return gitrpc.Commit{
+		Author:    gitrpc.Signature{Identity: gitrpc.Identity{Name: name, Email: email}, When: when},
+		Committer: gitrpc.Signature{Identity: gitrpc.Identity{Name: name, Email: email}, When: when},
+	}
+}
+
+func TestAggregateRepo(t *testing.T) {
+	today := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	before := today.AddDate(0, 0, -2)
+
+	insightsStore := &fakeInsightsStore{
+		latestDay: yesterday.UnixMilli(),
+		pullReqDays: []*types.InsightsDay{
+			{RepoID: 1, Day: yesterday.UnixMilli(), PullReqsMerged: 1, MergeTimeTotal: 1000},
+		},
+	}
+
+	var commits []gitrpc.Commit
+	commits = append(commits,
+		commitAt(today.Add(2*time.Hour), "Jane", "Jane@example.com"),
+		commitAt(today.Add(time.Hour), "Jane", "jane@example.com"),
+		commitAt(yesterday.Add(time.Hour), "John", "john@example.com"),
+		// commits before the aggregated range are ignored.
+		commitAt(before.Add(time.Hour), "John", "john@example.com"),
+	)
+	for i := 0; i < commitPageLimit; i++ {
+		commits = append(commits, commitAt(before, "John", "john@example.com"))
+	}
+
+	s := NewService(fakeTransactor{}, nil, insightsStore, fakeGitRPC{commits: commits}, nil, nil)
+
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+	if err := s.aggregateRepo(context.Background(), repo, today.UnixMilli()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if insightsStore.since != yesterday.UnixMilli() {
+		t.Errorf("want aggregation since the latest aggregated day %d, got %d",
+			yesterday.UnixMilli(), insightsStore.since)
+	}
+
+	days := map[int64]*types.InsightsDay{}
+	for _, d := range insightsStore.days {
+		days[d.Day] = d
+	}
+	if d := days[today.UnixMilli()]; len(days) != 2 || d == nil || d.Commits != 2 {
+		t.Fatalf("want 2 days with 2 commits today, got %d days", len(days))
+	}
+	if d := days[yesterday.UnixMilli()]; d.Commits != 1 || d.PullReqsMerged != 1 || d.MergeTimeTotal != 1000 {
+		t.Errorf("want commits and pull request stats of yesterday combined, got %+v", d)
+	}
+
+	contributors := map[string]int64{}
+	for _, c := range insightsStore.contributors {
+		if c.Day == today.UnixMilli() {
+			contributors[c.Email] += c.Commits
+		}
+	}
+	if len(contributors) != 1 || contributors["jane@example.com"] != 2 {
+		t.Errorf("want the commits of today attributed to a single contributor, got %v", contributors)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	insightsStore := &fakeInsightsStore{}
+	repoStore := fakeRepoStore{repos: []*types.Repository{
+		{ID: 1, DefaultBranch: "main"},
+		{ID: 2, DefaultBranch: "main"},
+	}}
+
+	s := NewService(fakeTransactor{}, repoStore, insightsStore, fakeGitRPC{}, nil, nil)
+
+	result, err := s.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "aggregated insights of 2 repositories"; result != want {
+		t.Errorf("want result %q, got %q", want, result)
+	}
+
+	today := time.Now().UnixMilli() / dayMillis * dayMillis
+	if want := today - initialBackfillDays*dayMillis; insightsStore.since != want {
+		t.Errorf("want initial backfill since %d, got %d", want, insightsStore.since)
+	}
+	if len(insightsStore.days) != 0 || len(insightsStore.contributors) != 0 {
+		t.Errorf("expected no statistics for empty repositories")
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Defaults are the values of the server configuration that apply to instance settings
+// which weren't changed at runtime.
+type Defaults struct {
+	UserSignupEnabled          bool
+	DefaultVisibility          enum.Visibility
+	DiffMaxFiles               int
+	DiffMaxFileLines           int
+	MaintenanceMode            bool
+	MaintenanceMessage         string
+	WebhookAllowLoopback       bool
+	WebhookAllowPrivateNetwork bool
+	OutboundAllowRules         []string
+	OutboundDenyRules          []string
+}
+
+// Service manages the instance settings that can be changed at runtime by admins.
+//
+// Settings that weren't changed at runtime use the value of the server configuration.
+// Every instance caches the settings in memory, changes are propagated to all instances with cache evictions.
+type Service struct {
+	defaults               Defaults
+	tx                     dbtx.Transactor
+	instanceSettingStore   store.InstanceSettingStore
+	instanceSettingCache   store.InstanceSettingCache
+	instanceSettingEvictor store.InstanceSettingEvictor
+}
+
+func NewService(
+	defaults Defaults,
+	tx dbtx.Transactor,
+	instanceSettingStore store.InstanceSettingStore,
+	instanceSettingCache store.InstanceSettingCache,
+	instanceSettingEvictor store.InstanceSettingEvictor,
+) *Service {
+	return &Service{
+		defaults:               defaults,
+		tx:                     tx,
+		instanceSettingStore:   instanceSettingStore,
+		instanceSettingCache:   instanceSettingCache,
+		instanceSettingEvictor: instanceSettingEvictor,
+	}
+}
+
+// List returns the effective value of all instance settings.
+func (s *Service) List(ctx context.Context) ([]*types.EffectiveInstanceSetting, error) {
+	settings, err := s.instanceSettingStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instance settings: %w", err)
+	}
+
+	changed := make(map[enum.InstanceSettingKey]*types.InstanceSetting, len(settings))
+	for _, setting := range settings {
+		changed[setting.Key] = setting
+	}
+
+	keys, _ := enum.GetAllInstanceSettingKeys()
+
+	res := make([]*types.EffectiveInstanceSetting, len(keys))
+	for i, key := range keys {
+		res[i], err = s.effective(key, changed[key])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Update changes the value of an instance setting and records the change.
+func (s *Service) Update(
+	ctx context.Context,
+	principalID int64,
+	key enum.InstanceSettingKey,
+	value json.RawMessage,
+) (*types.EffectiveInstanceSetting, error) {
+	value, err := s.sanitize(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	setting := &types.InstanceSetting{
+		Key:       key,
+		Value:     value,
+		UpdatedBy: principalID,
+		Updated:   now,
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		old, err := s.find(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if old != nil && bytes.Equal(old.Value, value) {
+			setting = old
+			return nil
+		}
+
+		if err = s.instanceSettingStore.Upsert(ctx, setting); err != nil {
+			return fmt.Errorf("failed to update instance setting: %w", err)
+		}
+
+		return s.recordChange(ctx, principalID, key, old, value, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.instanceSettingEvictor.Evict(ctx, key)
+
+	return s.effective(key, setting)
+}
+
+// Reset removes the runtime value of an instance setting, the server configuration applies afterwards.
+func (s *Service) Reset(ctx context.Context, principalID int64, key enum.InstanceSettingKey) error {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		old, err := s.find(ctx, key)
+		if err != nil || old == nil {
+			return err
+		}
+
+		if err = s.instanceSettingStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete instance setting: %w", err)
+		}
+
+		return s.recordChange(ctx, principalID, key, old, nil, time.Now().UnixMilli())
+	})
+	if err != nil {
+		return err
+	}
+
+	s.instanceSettingEvictor.Evict(ctx, key)
+
+	return nil
+}
+
+// ListChanges returns the recorded changes of instance settings, newest first.
+func (s *Service) ListChanges(
+	ctx context.Context,
+	filter types.InstanceSettingChangeFilter,
+) ([]*types.InstanceSettingChange, int64, error) {
+	var (
+		changes []*types.InstanceSettingChange
+		count   int64
+	)
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		changes, err = s.instanceSettingStore.ListChanges(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list instance setting changes: %w", err)
+		}
+
+		count, err = s.instanceSettingStore.CountChanges(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count instance setting changes: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return changes, count, nil
+}
+
+// find returns the runtime value of the instance setting, or nil if it wasn't changed at runtime.
+func (s *Service) find(ctx context.Context, key enum.InstanceSettingKey) (*types.InstanceSetting, error) {
+	setting, err := s.instanceSettingStore.Find(ctx, key)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // settings that weren't changed at runtime don't have a value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find instance setting: %w", err)
+	}
+
+	return setting, nil
+}
+
+func (s *Service) recordChange(
+	ctx context.Context,
+	principalID int64,
+	key enum.InstanceSettingKey,
+	old *types.InstanceSetting,
+	value json.RawMessage,
+	now int64,
+) error {
+	change := &types.InstanceSettingChange{
+		Key:       key,
+		NewValue:  value,
+		ChangedBy: principalID,
+		Changed:   now,
+	}
+	if old != nil {
+		change.OldValue = old.Value
+	}
+
+	if err := s.instanceSettingStore.CreateChange(ctx, change); err != nil {
+		return fmt.Errorf("failed to record change of instance setting: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("instance_setting.key", string(key)).
+		RawJSON("instance_setting.old_value", nullJSON(change.OldValue)).
+		RawJSON("instance_setting.new_value", nullJSON(change.NewValue)).
+		Int64("principal.id", principalID).
+		Msg("instance setting changed")
+
+	return nil
+}
+
+// effective returns the effective value of the instance setting,
+// the setting is nil if the setting wasn't changed at runtime.
+func (s *Service) effective(
+	key enum.InstanceSettingKey,
+	setting *types.InstanceSetting,
+) (*types.EffectiveInstanceSetting, error) {
+	if setting != nil {
+		return &types.EffectiveInstanceSetting{
+			Key:       key,
+			Value:     setting.Value,
+			UpdatedBy: setting.UpdatedBy,
+			Updated:   setting.Updated,
+		}, nil
+	}
+
+	value, err := s.defaultValue(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EffectiveInstanceSetting{
+		Key:     key,
+		Value:   value,
+		Default: true,
+	}, nil
+}
+
+func nullJSON(value json.RawMessage) []byte {
+	if value == nil {
+		return []byte("null")
+	}
+
+	return value
+}
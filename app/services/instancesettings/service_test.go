@@ -0,0 +1,343 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeInstanceSettingStore struct {
+	store.InstanceSettingStore
+	settings map[enum.InstanceSettingKey]*types.InstanceSetting
+	changes  []*types.InstanceSettingChange
+}
+
+func (s *fakeInstanceSettingStore) Find(_ context.Context, key enum.InstanceSettingKey) (*types.InstanceSetting,
+	error) {
+	setting, ok := s.settings[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return setting, nil
+}
+
+func (s *fakeInstanceSettingStore) List(context.Context) ([]*types.InstanceSetting, error) {
+	result := make([]*types.InstanceSetting, 0, len(s.settings))
+	for _, setting := range s.settings {
+		result = append(result, setting)
+	}
+	return result, nil
+}
+
+func (s *fakeInstanceSettingStore) Upsert(_ context.Context, setting *types.InstanceSetting) error {
+	s.settings[setting.Key] = setting
+	return nil
+}
+
+func (s *fakeInstanceSettingStore) Delete(_ context.Context, key enum.InstanceSettingKey) error {
+	delete(s.settings, key)
+	return nil
+}
+
+func (s *fakeInstanceSettingStore) CreateChange(_ context.Context, change *types.InstanceSettingChange) error {
+	s.changes = append(s.changes, change)
+	return nil
+}
+
+// fakeInstanceSettingCache reads through to the store, like the cache of the server without evictions.
+type fakeInstanceSettingCache struct {
+	store *fakeInstanceSettingStore
+}
+
+func (fakeInstanceSettingCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c fakeInstanceSettingCache) Get(_ context.Context, key enum.InstanceSettingKey) (*types.InstanceSetting,
+	error) {
+	return c.store.settings[key], nil
+}
+
+func (fakeInstanceSettingCache) Evict(context.Context, enum.InstanceSettingKey) {}
+
+type fakeInstanceSettingEvictor struct {
+	evicted []enum.InstanceSettingKey
+}
+
+func (e *fakeInstanceSettingEvictor) Evict(_ context.Context, key enum.InstanceSettingKey) {
+	e.evicted = append(e.evicted, key)
+}
+
+func newTestService() (*Service, *fakeInstanceSettingStore, *fakeInstanceSettingEvictor) {
+	settingStore := &fakeInstanceSettingStore{settings: map[enum.InstanceSettingKey]*types.InstanceSetting{}}
+	evictor := &fakeInstanceSettingEvictor{}
+
+	defaults := Defaults{
+		UserSignupEnabled: true,
+		DefaultVisibility: enum.VisibilityPrivate,
+		DiffMaxFiles:      100,
+		DiffMaxFileLines:  1000,
+	}
+
+	s := NewService(defaults, fakeTransactor{}, settingStore, fakeInstanceSettingCache{settingStore}, evictor)
+
+	return s, settingStore, evictor
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	s, settingStore, evictor := newTestService()
+
+	setting, err := s.Update(ctx, 1, enum.InstanceSettingKeyDiffMaxFiles, json.RawMessage(`50`))
+	if err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+	if setting.Default || string(setting.Value) != "50" || setting.UpdatedBy != 1 {
+		t.Errorf("expected runtime value 50 updated by 1, got %+v", setting)
+	}
+
+	limits, err := s.DiffLimits(ctx)
+	if err != nil {
+		t.Fatalf("failed to get diff limits: %v", err)
+	}
+	if limits.MaxFiles != 50 || limits.MaxFileLines != 1000 {
+		t.Errorf("expected diff limits 50/1000, got %d/%d", limits.MaxFiles, limits.MaxFileLines)
+	}
+
+	// updating to the same value doesn't record a change.
+	if _, err = s.Update(ctx, 2, enum.InstanceSettingKeyDiffMaxFiles, json.RawMessage(` 50 `)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+	if stored := settingStore.settings[enum.InstanceSettingKeyDiffMaxFiles]; stored.UpdatedBy != 1 {
+		t.Errorf("expected unchanged setting to keep its author, got %d", stored.UpdatedBy)
+	}
+
+	if _, err = s.Update(ctx, 2, enum.InstanceSettingKeyDiffMaxFiles, json.RawMessage(`0`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+
+	if len(settingStore.changes) != 2 {
+		t.Fatalf("expected 2 recorded changes, got %d", len(settingStore.changes))
+	}
+	if change := settingStore.changes[0]; change.OldValue != nil || string(change.NewValue) != "50" {
+		t.Errorf("expected first change from default to 50, got %s -> %s", change.OldValue, change.NewValue)
+	}
+	if change := settingStore.changes[1]; string(change.OldValue) != "50" || string(change.NewValue) != "0" ||
+		change.ChangedBy != 2 {
+		t.Errorf("expected second change from 50 to 0 by 2, got %s -> %s by %d",
+			change.OldValue, change.NewValue, change.ChangedBy)
+	}
+
+	if len(evictor.evicted) != 3 {
+		t.Errorf("expected the setting to be evicted on every update, got %d evictions", len(evictor.evicted))
+	}
+}
+
+func TestUpdateSanitizes(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   enum.InstanceSettingKey
+		value string
+		want  string
+	}{
+		{
+			name:  "visibility is compacted",
+			key:   enum.InstanceSettingKeyDefaultVisibility,
+			value: ` "public" `,
+			want:  `"public"`,
+		},
+		{
+			name:  "message is trimmed",
+			key:   enum.InstanceSettingKeyMaintenanceMessage,
+			value: `"  back soon  "`,
+			want:  `"back soon"`,
+		},
+		{
+			name:  "rules are canonical",
+			key:   enum.InstanceSettingKeyOutboundAllowRules,
+			value: `["10.0.0.1/8", " Git.Example.com. ", "*.Example.org"]`,
+			want:  `["10.0.0.0/8","git.example.com","*.example.org"]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, _, _ := newTestService()
+
+			setting, err := s.Update(context.Background(), 1, test.key, json.RawMessage(test.value))
+			if err != nil {
+				t.Fatalf("failed to update instance setting: %v", err)
+			}
+			if string(setting.Value) != test.want {
+				t.Errorf("expected value %s, got %s", test.want, setting.Value)
+			}
+		})
+	}
+}
+
+func TestUpdateInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   enum.InstanceSettingKey
+		value string
+	}{
+		{name: "unknown key", key: "unknown", value: `true`},
+		{name: "wrong type", key: enum.InstanceSettingKeyMaintenanceMode, value: `"yes"`},
+		{name: "unknown visibility", key: enum.InstanceSettingKeyDefaultVisibility, value: `"internal"`},
+		{name: "negative limit", key: enum.InstanceSettingKeyDiffMaxFileLines, value: `-1`},
+		{name: "invalid rule", key: enum.InstanceSettingKeyOutboundDenyRules, value: `["10.0.0.0/99"]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, settingStore, evictor := newTestService()
+
+			_, err := s.Update(context.Background(), 1, test.key, json.RawMessage(test.value))
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+				t.Fatalf("expected bad request, got %v", err)
+			}
+			if len(settingStore.settings) != 0 || len(settingStore.changes) != 0 || len(evictor.evicted) != 0 {
+				t.Error("expected invalid value not to be stored")
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	ctx := context.Background()
+	s, settingStore, evictor := newTestService()
+
+	if _, err := s.Update(ctx, 1, enum.InstanceSettingKeyUserSignupEnabled, json.RawMessage(`false`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+
+	enabled, err := s.UserSignupEnabled(ctx)
+	if err != nil || enabled {
+		t.Fatalf("expected user signup to be disabled at runtime, got %t (%v)", enabled, err)
+	}
+
+	if err = s.Reset(ctx, 2, enum.InstanceSettingKeyUserSignupEnabled); err != nil {
+		t.Fatalf("failed to reset instance setting: %v", err)
+	}
+
+	enabled, err = s.UserSignupEnabled(ctx)
+	if err != nil || !enabled {
+		t.Errorf("expected user signup of the configuration after reset, got %t (%v)", enabled, err)
+	}
+
+	if len(settingStore.changes) != 2 {
+		t.Fatalf("expected 2 recorded changes, got %d", len(settingStore.changes))
+	}
+	if change := settingStore.changes[1]; string(change.OldValue) != "false" || change.NewValue != nil {
+		t.Errorf("expected reset to be recorded as change to null, got %s -> %s", change.OldValue, change.NewValue)
+	}
+
+	// resetting a setting that wasn't changed at runtime is a no-op.
+	if err = s.Reset(ctx, 2, enum.InstanceSettingKeyUserSignupEnabled); err != nil {
+		t.Fatalf("failed to reset instance setting: %v", err)
+	}
+	if len(settingStore.changes) != 2 {
+		t.Errorf("expected no change to be recorded, got %d changes", len(settingStore.changes))
+	}
+	if len(evictor.evicted) != 3 {
+		t.Errorf("expected 3 evictions, got %d", len(evictor.evicted))
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestService()
+
+	if _, err := s.Update(ctx, 1, enum.InstanceSettingKeyDefaultVisibility, json.RawMessage(`"public"`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+
+	settings, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list instance settings: %v", err)
+	}
+
+	keys, _ := enum.GetAllInstanceSettingKeys()
+	if len(settings) != len(keys) {
+		t.Fatalf("expected all %d instance settings, got %d", len(keys), len(settings))
+	}
+
+	for _, setting := range settings {
+		switch setting.Key {
+		case enum.InstanceSettingKeyDefaultVisibility:
+			if setting.Default || string(setting.Value) != `"public"` {
+				t.Errorf("expected runtime visibility, got %+v", setting)
+			}
+		case enum.InstanceSettingKeyDiffMaxFiles:
+			if !setting.Default || string(setting.Value) != "100" {
+				t.Errorf("expected default diff max files, got %+v", setting)
+			}
+		default:
+			if !setting.Default {
+				t.Errorf("expected default value of %s, got %+v", setting.Key, setting)
+			}
+		}
+	}
+
+	isPublic, err := s.DefaultPublic(ctx, nil)
+	if err != nil || !isPublic {
+		t.Errorf("expected public default visibility, got %t (%v)", isPublic, err)
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	ctx := context.Background()
+	s, _, _ := newTestService()
+
+	if _, err := s.Update(ctx, 1, enum.InstanceSettingKeyMaintenanceMessage, json.RawMessage(`"upgrade"`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+
+	mode, err := s.MaintenanceMode(ctx)
+	if err != nil {
+		t.Fatalf("failed to get maintenance mode: %v", err)
+	}
+	if mode.Enabled || mode.Message != "" {
+		t.Errorf("expected no message while maintenance is disabled, got %+v", mode)
+	}
+
+	if _, err = s.Update(ctx, 1, enum.InstanceSettingKeyMaintenanceMode, json.RawMessage(`true`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+	if mode, err = s.MaintenanceMode(ctx); err != nil || !mode.Enabled || mode.Message != "upgrade" {
+		t.Errorf("expected maintenance mode with message, got %+v (%v)", mode, err)
+	}
+
+	if _, err = s.Update(ctx, 1, enum.InstanceSettingKeyMaintenanceMessage, json.RawMessage(`""`)); err != nil {
+		t.Fatalf("failed to update instance setting: %v", err)
+	}
+	if mode, err = s.MaintenanceMode(ctx); err != nil || mode.Message != defaultMaintenanceMessage {
+		t.Errorf("expected default maintenance message, got %+v (%v)", mode, err)
+	}
+}
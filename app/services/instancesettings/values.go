@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UserSignupEnabled returns whether users are allowed to sign up on their own.
+func (s *Service) UserSignupEnabled(ctx context.Context) (bool, error) {
+	var enabled bool
+	err := s.resolveValue(ctx, enum.InstanceSettingKeyUserSignupEnabled, &enabled)
+	return enabled, err
+}
+
+// DefaultVisibility returns the visibility of new spaces and repositories created without one.
+func (s *Service) DefaultVisibility(ctx context.Context) (enum.Visibility, error) {
+	var visibility enum.Visibility
+	err := s.resolveValue(ctx, enum.InstanceSettingKeyDefaultVisibility, &visibility)
+	return visibility, err
+}
+
+// DefaultPublic returns whether new spaces and repositories are public unless isPublic is provided.
+func (s *Service) DefaultPublic(ctx context.Context, isPublic *bool) (bool, error) {
+	if isPublic != nil {
+		return *isPublic, nil
+	}
+
+	visibility, err := s.DefaultVisibility(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return visibility == enum.VisibilityPublic, nil
+}
+
+// DiffLimits returns the limits applied to diffs returned by the API.
+func (s *Service) DiffLimits(ctx context.Context) (gitrpc.DiffLimits, error) {
+	var limits gitrpc.DiffLimits
+
+	err := s.resolveValue(ctx, enum.InstanceSettingKeyDiffMaxFiles, &limits.MaxFiles)
+	if err != nil {
+		return gitrpc.DiffLimits{}, err
+	}
+
+	err = s.resolveValue(ctx, enum.InstanceSettingKeyDiffMaxFileLines, &limits.MaxFileLines)
+	if err != nil {
+		return gitrpc.DiffLimits{}, err
+	}
+
+	return limits, nil
+}
+
+// defaultMaintenanceMessage is shown to users during maintenance if no message is configured.
+const defaultMaintenanceMessage = "The instance is undergoing maintenance, changes are temporarily disabled. " +
+	"Please try again later."
+
+// maxMaintenanceMessageLength is the max length of the maintenance message.
+const maxMaintenanceMessageLength = 1024
+
+// MaintenanceMode returns the maintenance mode of the instance.
+// The message is only provided if the maintenance mode is enabled.
+func (s *Service) MaintenanceMode(ctx context.Context) (types.MaintenanceMode, error) {
+	var mode types.MaintenanceMode
+
+	err := s.resolveValue(ctx, enum.InstanceSettingKeyMaintenanceMode, &mode.Enabled)
+	if err != nil || !mode.Enabled {
+		return types.MaintenanceMode{}, err
+	}
+
+	err = s.resolveValue(ctx, enum.InstanceSettingKeyMaintenanceMessage, &mode.Message)
+	if err != nil {
+		return types.MaintenanceMode{}, err
+	}
+
+	if mode.Message == "" {
+		mode.Message = defaultMaintenanceMessage
+	}
+
+	return mode, nil
+}
+
+// OutboundPolicy returns the policy of the targets outbound requests (e.g. webhooks) are allowed to connect to.
+func (s *Service) OutboundPolicy(ctx context.Context) (outbound.Policy, error) {
+	var policy outbound.Policy
+
+	err := s.resolveValue(ctx, enum.InstanceSettingKeyWebhookAllowLoopback, &policy.AllowLoopback)
+	if err != nil {
+		return outbound.Policy{}, err
+	}
+
+	err = s.resolveValue(ctx, enum.InstanceSettingKeyWebhookAllowPrivateNetwork, &policy.AllowPrivateNetwork)
+	if err != nil {
+		return outbound.Policy{}, err
+	}
+
+	policy.Allow, err = s.resolveRules(ctx, enum.InstanceSettingKeyOutboundAllowRules)
+	if err != nil {
+		return outbound.Policy{}, err
+	}
+
+	policy.Deny, err = s.resolveRules(ctx, enum.InstanceSettingKeyOutboundDenyRules)
+	if err != nil {
+		return outbound.Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (s *Service) resolveRules(ctx context.Context, key enum.InstanceSettingKey) ([]outbound.Rule, error) {
+	var raw []string
+	if err := s.resolveValue(ctx, key, &raw); err != nil {
+		return nil, err
+	}
+
+	rules, err := outbound.ParseRules(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of instance setting '%s': %w", key, err)
+	}
+
+	return rules, nil
+}
+
+// resolveValue decodes the effective value of the instance setting into dst.
+func (s *Service) resolveValue(ctx context.Context, key enum.InstanceSettingKey, dst interface{}) error {
+	setting, err := s.instanceSettingCache.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get instance setting '%s': %w", key, err)
+	}
+
+	effective, err := s.effective(key, setting)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(effective.Value, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal value of instance setting '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+// defaultValue returns the value of the server configuration for an instance setting.
+func (s *Service) defaultValue(key enum.InstanceSettingKey) (json.RawMessage, error) {
+	var value interface{}
+	switch key {
+	case enum.InstanceSettingKeyDefaultVisibility:
+		value = s.defaults.DefaultVisibility
+	case enum.InstanceSettingKeyDiffMaxFileLines:
+		value = s.defaults.DiffMaxFileLines
+	case enum.InstanceSettingKeyDiffMaxFiles:
+		value = s.defaults.DiffMaxFiles
+	case enum.InstanceSettingKeyMaintenanceMode:
+		value = s.defaults.MaintenanceMode
+	case enum.InstanceSettingKeyMaintenanceMessage:
+		value = s.defaults.MaintenanceMessage
+	case enum.InstanceSettingKeyUserSignupEnabled:
+		value = s.defaults.UserSignupEnabled
+	case enum.InstanceSettingKeyWebhookAllowLoopback:
+		value = s.defaults.WebhookAllowLoopback
+	case enum.InstanceSettingKeyWebhookAllowPrivateNetwork:
+		value = s.defaults.WebhookAllowPrivateNetwork
+	case enum.InstanceSettingKeyOutboundAllowRules:
+		value = s.defaults.OutboundAllowRules
+	case enum.InstanceSettingKeyOutboundDenyRules:
+		value = s.defaults.OutboundDenyRules
+	default:
+		return nil, fmt.Errorf("unknown instance setting '%s'", key)
+	}
+
+	return json.Marshal(value)
+}
+
+// sanitize validates the value of an instance setting and returns it in its canonical form.
+func (s *Service) sanitize(key enum.InstanceSettingKey, value json.RawMessage) (json.RawMessage, error) {
+	var (
+		res interface{}
+		err error
+	)
+
+	switch key {
+	case enum.InstanceSettingKeyDefaultVisibility:
+		res, err = sanitizeVisibility(key, value)
+	case enum.InstanceSettingKeyDiffMaxFileLines,
+		enum.InstanceSettingKeyDiffMaxFiles:
+		res, err = sanitizeLimit(key, value)
+	case enum.InstanceSettingKeyMaintenanceMessage:
+		res, err = sanitizeMessage(key, value)
+	case enum.InstanceSettingKeyMaintenanceMode,
+		enum.InstanceSettingKeyUserSignupEnabled,
+		enum.InstanceSettingKeyWebhookAllowLoopback,
+		enum.InstanceSettingKeyWebhookAllowPrivateNetwork:
+		var enabled bool
+		err = decodeValue(key, value, &enabled)
+		res = enabled
+	case enum.InstanceSettingKeyOutboundAllowRules,
+		enum.InstanceSettingKeyOutboundDenyRules:
+		res, err = sanitizeRules(key, value)
+	default:
+		return nil, usererror.BadRequestf("Unknown instance setting '%s'.", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(res)
+}
+
+func sanitizeVisibility(key enum.InstanceSettingKey, value json.RawMessage) (enum.Visibility, error) {
+	var visibility enum.Visibility
+	if err := decodeValue(key, value, &visibility); err != nil {
+		return "", err
+	}
+
+	visibility, ok := visibility.Sanitize()
+	if !ok || visibility == "" {
+		return "", usererror.BadRequestf("The visibility has to be one of %v.", enum.Visibility("").Enum())
+	}
+
+	return visibility, nil
+}
+
+func sanitizeLimit(key enum.InstanceSettingKey, value json.RawMessage) (int, error) {
+	var limit int
+	if err := decodeValue(key, value, &limit); err != nil {
+		return 0, err
+	}
+
+	if limit < 0 {
+		return 0, usererror.BadRequestf("The value of '%s' can't be negative, 0 disables the limit.", key)
+	}
+
+	return limit, nil
+}
+
+func sanitizeMessage(key enum.InstanceSettingKey, value json.RawMessage) (string, error) {
+	var message string
+	if err := decodeValue(key, value, &message); err != nil {
+		return "", err
+	}
+
+	message = strings.TrimSpace(message)
+	if len(message) > maxMaintenanceMessageLength {
+		return "", usererror.BadRequestf("The value of '%s' can be at most %d characters long.",
+			key, maxMaintenanceMessageLength)
+	}
+
+	return message, nil
+}
+
+func sanitizeRules(key enum.InstanceSettingKey, value json.RawMessage) ([]string, error) {
+	var raw []string
+	if err := decodeValue(key, value, &raw); err != nil {
+		return nil, err
+	}
+
+	rules, err := outbound.ParseRules(raw)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid rule for instance setting '%s': %s", key, err)
+	}
+
+	// store the canonical form of the rules
+	sanitized := make([]string, len(rules))
+	for i := range rules {
+		sanitized[i] = rules[i].String()
+	}
+
+	return sanitized, nil
+}
+
+// decodeValue strictly decodes the value of an instance setting.
+func decodeValue(key enum.InstanceSettingKey, value json.RawMessage, dst interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return usererror.BadRequestf("Invalid value for instance setting '%s': %s", key, err)
+	}
+
+	return nil
+}
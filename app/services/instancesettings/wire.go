@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/outbound"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+	ProvideOutboundClientFactory,
+)
+
+func ProvideService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	instanceSettingStore store.InstanceSettingStore,
+	instanceSettingCache store.InstanceSettingCache,
+	instanceSettingEvictor store.InstanceSettingEvictor,
+) (*Service, error) {
+	// fail early on invalid rules, they are used whenever an outbound request is made
+	if _, err := outbound.ParseRules(config.Webhook.AllowRules); err != nil {
+		return nil, fmt.Errorf("invalid outbound allow rules: %w", err)
+	}
+	if _, err := outbound.ParseRules(config.Webhook.DenyRules); err != nil {
+		return nil, fmt.Errorf("invalid outbound deny rules: %w", err)
+	}
+
+	defaults := Defaults{
+		UserSignupEnabled:          config.UserSignupEnabled,
+		DefaultVisibility:          enum.VisibilityPrivate,
+		DiffMaxFiles:               config.Git.DiffMaxFiles,
+		DiffMaxFileLines:           config.Git.DiffMaxFileLines,
+		WebhookAllowLoopback:       config.Webhook.AllowLoopback,
+		WebhookAllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
+		OutboundAllowRules:         config.Webhook.AllowRules,
+		OutboundDenyRules:          config.Webhook.DenyRules,
+	}
+
+	return NewService(defaults, tx, instanceSettingStore, instanceSettingCache, instanceSettingEvictor), nil
+}
+
+// ProvideOutboundClientFactory provides the factory of http clients for outbound requests
+// that enforces the outbound policy of the instance settings.
+func ProvideOutboundClientFactory(config *types.Config, service *Service) (*outbound.ClientFactory, error) {
+	proxyConfig := outbound.ProxyConfig{
+		HTTPProxy:  config.Proxy.HTTP,
+		HTTPSProxy: config.Proxy.HTTPS,
+		NoProxy:    config.Proxy.NoProxy,
+	}
+
+	return outbound.NewClientFactory(proxyConfig, service.OutboundPolicy)
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+const queueCollectorTimeout = 5 * time.Second
+
+var (
+	queueReadyDesc = prometheus.NewDesc(
+		"gitness_job_queue_ready",
+		"Number of background jobs that are ready for execution.",
+		nil, nil,
+	)
+	queueRunningDesc = prometheus.NewDesc(
+		"gitness_job_queue_running",
+		"Number of background jobs that are currently being run.",
+		nil, nil,
+	)
+)
+
+// queueCollector is a prometheus collector that reports the depth of the background job queue.
+// The values are read from the database on every collection, as jobs are shared by all instances.
+type queueCollector struct {
+	store store.JobStore
+}
+
+func newQueueCollector(jobStore store.JobStore) *queueCollector {
+	return &queueCollector{store: jobStore}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueReadyDesc
+	ch <- queueRunningDesc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueCollectorTimeout)
+	defer cancel()
+
+	ready, err := c.store.CountReady(ctx, time.Now())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to count ready jobs for metrics")
+	} else {
+		ch <- prometheus.MustNewConstMetric(queueReadyDesc, prometheus.GaugeValue, float64(ready))
+	}
+
+	running, err := c.store.CountRunning(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to count running jobs for metrics")
+	} else {
+		ch <- prometheus.MustNewConstMetric(queueRunningDesc, prometheus.GaugeValue, float64(running))
+	}
+}
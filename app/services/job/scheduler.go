@@ -24,12 +24,22 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/loglevel"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/gorhill/cronexpr"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	// ErrRecurringJob is returned if a recurring job is canceled or retried.
+	ErrRecurringJob = errors.New("operation isn't supported for recurring jobs")
+
+	// ErrNotRetryable is returned if a job that hasn't failed or hasn't been canceled is retried.
+	ErrNotRetryable = errors.New("only failed or canceled jobs can be retried")
 )
 
 // Scheduler controls execution of background jobs.
@@ -207,7 +217,7 @@ func (s *Scheduler) CancelJob(ctx context.Context, jobUID string) error {
 	}
 
 	if job.IsRecurring {
-		return errors.New("can't cancel recurring jobs")
+		return ErrRecurringJob
 	}
 
 	if job.State != enum.JobStateScheduled && job.State != enum.JobStateRunning {
@@ -238,6 +248,49 @@ func (s *Scheduler) CancelJob(ctx context.Context, jobUID string) error {
 	return s.pubsubService.Publish(ctx, PubSubTopicCancelJob, []byte(jobUID))
 }
 
+// RetryJob reschedules a failed or canceled job for immediate execution.
+func (s *Scheduler) RetryJob(ctx context.Context, jobUID string) error {
+	mx, err := globalLock(ctx, s.mxManager)
+	if err != nil {
+		return fmt.Errorf("failed to obtain global lock to retry a job: %w", err)
+	}
+
+	defer func() {
+		if err := mx.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to release global lock after retrying a job")
+		}
+	}()
+
+	job, err := s.store.Find(ctx, jobUID)
+	if err != nil {
+		return fmt.Errorf("failed to find job to retry: %w", err)
+	}
+
+	if job.IsRecurring {
+		return ErrRecurringJob
+	}
+
+	if job.State != enum.JobStateFailed && job.State != enum.JobStateCanceled {
+		return ErrNotRetryable
+	}
+
+	now := time.Now().UnixMilli()
+
+	job.Updated = now
+	job.State = enum.JobStateScheduled
+	job.Scheduled = now
+	job.ConsecutiveFailures = 0
+
+	err = s.store.UpdateExecution(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to update job to retry it: %w", err)
+	}
+
+	s.scheduleProcessing(time.UnixMilli(job.Scheduled))
+
+	return nil
+}
+
 func (s *Scheduler) handleCancelJob(payload []byte) error {
 	jobUID := string(payload)
 	if jobUID == "" {
@@ -282,6 +335,7 @@ func (s *Scheduler) RunJob(ctx context.Context, def Definition) error {
 	}
 
 	job := def.toNewJob()
+	job.TraceContext = traceContextFrom(ctx)
 
 	if err := s.store.Create(ctx, job); err != nil {
 		return fmt.Errorf("failed to add new job to the database: %w", err)
@@ -299,6 +353,8 @@ func (s *Scheduler) RunJobs(ctx context.Context, groupID string, defs []Definiti
 		return nil
 	}
 
+	traceContext := traceContextFrom(ctx)
+
 	jobs := make([]*types.Job, len(defs))
 	for i, def := range defs {
 		if err := def.Validate(); err != nil {
@@ -306,6 +362,7 @@ func (s *Scheduler) RunJobs(ctx context.Context, groupID string, defs []Definiti
 		}
 		jobs[i] = def.toNewJob()
 		jobs[i].GroupID = groupID
+		jobs[i].TraceContext = traceContext
 	}
 
 	for _, job := range jobs {
@@ -372,10 +429,11 @@ func (s *Scheduler) processReadyJobs(ctx context.Context, now time.Time) (int, t
 	}
 
 	for _, job := range jobs {
-		jobCtx := log.Ctx(ctx).With().
+		jobLog := loglevel.Logger(log.Ctx(ctx).With().
 			Str("job.UID", job.UID).
 			Str("job.Type", job.Type).
-			Logger().WithContext(ctx)
+			Logger(), loglevel.SubsystemJobs, "")
+		jobCtx := jobLog.WithContext(ctx)
 
 		// Update the job fields for the new execution
 		s.preExec(job)
@@ -419,17 +477,23 @@ func (s *Scheduler) availableSlots(ctx context.Context) (int, error) {
 func (s *Scheduler) runJob(ctx context.Context, j *types.Job) {
 	s.wgRunning.Add(1)
 	go func(ctx context.Context,
-		jobUID, jobType, jobData string,
+		jobUID, jobType, jobData, jobTraceContext string,
 		jobRunDeadline int64,
 	) {
 		defer s.wgRunning.Done()
 
+		ctx, span := startJobSpan(ctx, jobUID, jobType, jobTraceContext)
+		defer span.End()
+
 		log.Ctx(ctx).Debug().Msg("started job")
 
 		timeStart := time.Now()
 
 		// Run the job
 		execResult, execFailure := s.doExec(ctx, jobUID, jobType, jobData, jobRunDeadline)
+		if execFailure != "" {
+			span.SetStatus(codes.Error, execFailure)
+		}
 
 		// Use the context.Background() because we want to update the job even if the job's context is done.
 		// The context can be done because the job exceeded its deadline or the server is shutting down.
@@ -502,7 +566,7 @@ func (s *Scheduler) runJob(ctx context.Context, j *types.Job) {
 		if err := publishStateChange(backgroundCtx, s.pubsubService, job); err != nil {
 			log.Ctx(ctx).Err(err).Msg("failed to publish job state change")
 		}
-	}(ctx, j.UID, j.Type, j.Data, j.RunDeadline)
+	}(ctx, j.UID, j.Type, j.Data, j.TraceContext, j.RunDeadline)
 }
 
 // preExec updates the provided types.Job before execution.
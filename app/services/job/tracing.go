@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/tracing"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/harness/gitness/app/services/job")
+
+// traceContextFrom returns the serialized trace context of ctx,
+// used to continue the trace of the caller once the job gets executed.
+func traceContextFrom(ctx context.Context) string {
+	carrier := tracing.Inject(ctx)
+	if len(carrier) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(carrier)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to encode trace context of job")
+		return ""
+	}
+
+	return string(data)
+}
+
+// startJobSpan starts the span of a job execution, continuing the trace in which the job was created (if any).
+func startJobSpan(ctx context.Context, jobUID, jobType, traceContext string) (context.Context, trace.Span) {
+	if traceContext != "" {
+		carrier := map[string]string{}
+		if err := json.Unmarshal([]byte(traceContext), &carrier); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to decode trace context of job")
+		} else {
+			ctx = tracing.Extract(ctx, carrier)
+		}
+	}
+
+	return tracer.Start(ctx, "job.run "+jobType,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("job.uid", jobUID),
+			attribute.String("job.type", jobType),
+		))
+}
@@ -15,12 +15,16 @@
 package job
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var WireSet = wire.NewSet(
@@ -45,6 +49,11 @@ func ProvideScheduler(
 	pubsubService pubsub.PubSub,
 	config *types.Config,
 ) (*Scheduler, error) {
+	err := prometheus.Register(newQueueCollector(jobStore))
+	if err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil, fmt.Errorf("failed to register job queue metrics: %w", err)
+	}
+
 	return NewScheduler(
 		jobStore,
 		executor,
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyrotation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "encryption_key_rotation"
+	jobUID         = jobType
+	jobMaxRetries  = 2
+	jobMaxDuration = 2 * time.Hour
+	batchSize      = 100
+)
+
+var (
+	// ErrNotSupported is returned if the configured encrypter doesn't support master key rotation.
+	ErrNotSupported = errors.New("encryption key rotation requires envelope encryption to be configured")
+
+	// ErrAlreadyRunning is returned if a key rotation is already in progress.
+	ErrAlreadyRunning = errors.New("encryption key rotation is already in progress")
+
+	// ErrNotFound is returned if no key rotation was started.
+	ErrNotFound = errors.New("encryption key rotation not found")
+)
+
+// Result contains the number of values re-encrypted with the current master key.
+type Result struct {
+	Secrets  int `json:"secrets"`
+	Webhooks int `json:"webhooks"`
+}
+
+// Service re-encrypts all stored secrets and webhook credentials with the current master key.
+// Values are re-encrypted one by one in a background job, which allows to rotate the master key
+// without downtime: before the rotation the new key is made the current one with the previous
+// keys still configured, afterwards the previous keys can be removed.
+type Service struct {
+	encrypter    encrypt.Encrypter
+	scheduler    *job.Scheduler
+	secretStore  store.SecretStore
+	webhookStore store.WebhookStore
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Run starts the background job that re-encrypts all values with the current master key.
+func (s *Service) Run(ctx context.Context) error {
+	if _, ok := s.encrypter.(encrypt.KeyRotator); !ok {
+		return ErrNotSupported
+	}
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get key rotation job progress: %w", err)
+	}
+	if err == nil {
+		if !progress.State.IsCompleted() {
+			return ErrAlreadyRunning
+		}
+
+		// remove the job of the previous rotation, so that a new one can be started.
+		if _, err = s.scheduler.PurgeJobsByGroupID(ctx, jobType); err != nil {
+			return fmt.Errorf("failed to purge previous key rotation job: %w", err)
+		}
+	}
+
+	return s.scheduler.RunJobs(ctx, jobType, []job.Definition{{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       "",
+	}})
+}
+
+// GetProgress returns the progress of the latest key rotation.
+func (s *Service) GetProgress(ctx context.Context) (types.JobProgress, error) {
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return types.JobProgress{}, ErrNotFound
+	}
+	if err != nil {
+		return types.JobProgress{}, fmt.Errorf("failed to get key rotation job progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Handle is the key rotation job handler.
+func (s *Service) Handle(ctx context.Context, _ string, progress job.ProgressReporter) (string, error) {
+	rotator, ok := s.encrypter.(encrypt.KeyRotator)
+	if !ok {
+		return "", ErrNotSupported
+	}
+
+	result := Result{}
+
+	err := s.rotateSecrets(ctx, rotator, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt secrets: %w", err)
+	}
+
+	// the secrets are the first half of the job.
+	if err = progress(job.ProgressMax/2, ""); err != nil {
+		return "", err
+	}
+
+	err = s.rotateWebhooks(ctx, rotator, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encrypt webhook secrets: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int("secrets", result.Secrets).
+		Int("webhooks", result.Webhooks).
+		Msg("completed encryption key rotation")
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal key rotation result: %w", err)
+	}
+
+	return string(resultJSON), nil
+}
+
+func (s *Service) rotateSecrets(ctx context.Context, rotator encrypt.KeyRotator, result *Result) error {
+	var afterID int64
+	for {
+		secrets, err := s.secretStore.ListAfter(ctx, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, secret := range secrets {
+			afterID = secret.ID
+
+			if !rotator.NeedsRotation([]byte(secret.Data)) {
+				continue
+			}
+
+			_, err = s.secretStore.UpdateOptLock(ctx, secret, func(secret *types.Secret) error {
+				data, err := s.reencrypt(rotator, secret.Data)
+				if err != nil {
+					return fmt.Errorf("failed to re-encrypt secret %d: %w", secret.ID, err)
+				}
+				secret.Data = data
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Secrets++
+		}
+
+		if len(secrets) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Service) rotateWebhooks(ctx context.Context, rotator encrypt.KeyRotator, result *Result) error {
+	var afterID int64
+	for {
+		hooks, err := s.webhookStore.ListAfter(ctx, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, hook := range hooks {
+			afterID = hook.ID
+
+			if hook.Secret == "" || !rotator.NeedsRotation([]byte(hook.Secret)) {
+				continue
+			}
+
+			_, err = s.webhookStore.UpdateOptLock(ctx, hook, func(hook *types.Webhook) error {
+				data, err := s.reencrypt(rotator, hook.Secret)
+				if err != nil {
+					return fmt.Errorf("failed to re-encrypt secret of webhook %d: %w", hook.ID, err)
+				}
+				hook.Secret = data
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Webhooks++
+		}
+
+		if len(hooks) < batchSize {
+			return nil
+		}
+	}
+}
+
+// reencrypt re-encrypts the value with the current master key, unless that already happened concurrently.
+func (s *Service) reencrypt(rotator encrypt.KeyRotator, ciphertext string) (string, error) {
+	if !rotator.NeedsRotation([]byte(ciphertext)) {
+		return ciphertext, nil
+	}
+
+	plaintext, err := s.encrypter.Decrypt([]byte(ciphertext))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := s.encrypter.Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
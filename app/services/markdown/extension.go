@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+const (
+	languageMermaid = "mermaid"
+	languageMath    = "math"
+)
+
+// kindMath is the node kind of math expressions.
+var kindMath = ast.NewNodeKind("Math")
+
+// mathNode is an inline math expression delimited by $ or $$ (display mode).
+type mathNode struct {
+	ast.BaseInline
+	Display bool
+	Value   []byte
+}
+
+func (n *mathNode) Kind() ast.NodeKind {
+	return kindMath
+}
+
+func (n *mathNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Value": string(n.Value)}, nil)
+}
+
+// mathParser parses inline math expressions.
+// Like on GitHub, the opening $ mustn't be followed by a space and the closing $ mustn't be preceded by a space
+// or followed by a digit, to not mistake amounts of money for expressions.
+type mathParser struct{}
+
+func (p *mathParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p *mathParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+
+	delim := 1
+	if len(line) > 1 && line[1] == '$' {
+		delim = 2
+	}
+
+	content := line[delim:]
+	if len(content) == 0 || util.IsSpace(content[0]) {
+		return nil
+	}
+
+	closer := bytes.Repeat([]byte{'$'}, delim)
+	end := bytes.Index(content, closer)
+	if end <= 0 || util.IsSpace(content[end-1]) {
+		return nil
+	}
+	if after := end + delim; after < len(content) && (content[after] >= '0' && content[after] <= '9') {
+		return nil
+	}
+
+	node := &mathNode{
+		Display: delim == 2,
+		Value:   append([]byte(nil), content[:end]...),
+	}
+	block.Advance(2*delim + end)
+
+	return node
+}
+
+// extensionRenderer renders math expressions and the fenced code blocks of mermaid diagrams and math expressions.
+// Other fenced code blocks are rendered like by the default renderer.
+type extensionRenderer struct{}
+
+func (r *extensionRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMath, r.renderMath)
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *extensionRenderer) renderMath(
+	w util.BufWriter,
+	_ []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*mathNode)
+	if n.Display {
+		_, _ = w.WriteString(`<span class="math math-display">`)
+	} else {
+		_, _ = w.WriteString(`<span class="math math-inline">`)
+	}
+	html.DefaultWriter.RawWrite(w, n.Value)
+	_, _ = w.WriteString("</span>")
+
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *extensionRenderer) renderFencedCodeBlock(
+	w util.BufWriter,
+	source []byte,
+	node ast.Node,
+	entering bool,
+) (ast.WalkStatus, error) {
+	n := node.(*ast.FencedCodeBlock)
+	language := string(n.Language(source))
+
+	switch {
+	case language == languageMermaid && entering:
+		_, _ = w.WriteString(`<pre class="mermaid">`)
+		writeLines(w, source, n)
+	case language == languageMermaid:
+		_, _ = w.WriteString("</pre>\n")
+	case language == languageMath && entering:
+		_, _ = w.WriteString(`<div class="math math-display">`)
+		writeLines(w, source, n)
+	case language == languageMath:
+		_, _ = w.WriteString("</div>\n")
+	case entering:
+		_, _ = w.WriteString("<pre><code")
+		if language != "" {
+			_, _ = w.WriteString(` class="language-`)
+			html.DefaultWriter.Write(w, []byte(language))
+			_, _ = w.WriteString(`"`)
+		}
+		_ = w.WriteByte('>')
+		writeLines(w, source, n)
+	default:
+		_, _ = w.WriteString("</code></pre>\n")
+	}
+
+	return ast.WalkContinue, nil
+}
+
+func writeLines(w util.BufWriter, source []byte, n ast.Node) {
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		html.DefaultWriter.RawWrite(w, line.Value(source))
+	}
+}
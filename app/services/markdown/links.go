@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// optionsKey is the key of the render options in the parser context.
+var optionsKey = parser.NewContextKey()
+
+// linkRel is the rel attribute of the links in rendered documents.
+var linkRel = []byte("nofollow noopener noreferrer")
+
+// linkTransformer resolves the relative links and image sources of a document using the render options.
+// Links and image sources with schemes that aren't explicitly allowed are removed.
+type linkTransformer struct{}
+
+func (t *linkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	opts, _ := pc.Get(optionsKey).(Options)
+
+	// unsafe autolinks are replaced after the walk, replacing nodes while walking stops the walk.
+	var unsafeAutoLinks []*ast.AutoLink
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Link:
+			n.Destination = resolve(n.Destination, opts.Dir, opts.ResolveLink)
+			if !isSafeURL(string(n.Destination), "http", "https", "mailto") {
+				n.Destination = nil
+			}
+			n.SetAttributeString("rel", linkRel)
+		case *ast.Image:
+			n.Destination = resolve(n.Destination, opts.Dir, opts.ResolveImage)
+			if !isSafeURL(string(n.Destination), "http", "https") {
+				n.Destination = nil
+			}
+		case *ast.AutoLink:
+			if !isSafeURL(string(n.URL(reader.Source())), "http", "https", "mailto") {
+				unsafeAutoLinks = append(unsafeAutoLinks, n)
+				return ast.WalkSkipChildren, nil
+			}
+			n.SetAttributeString("rel", linkRel)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	// unsafe autolinks are rendered as text.
+	for _, n := range unsafeAutoLinks {
+		n.Parent().ReplaceChild(n.Parent(), n, ast.NewString(n.Label(reader.Source())))
+	}
+}
+
+// resolve returns the url of a relative destination, other destinations are returned as is.
+// Query and fragment of the relative destination are kept.
+func resolve(dest []byte, dir string, resolver func(string) string) []byte {
+	if resolver == nil || len(dest) == 0 || dest[0] == '#' {
+		return dest
+	}
+
+	u, err := url.Parse(string(dest))
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return dest
+	}
+
+	p := u.Path
+	if !strings.HasPrefix(p, "/") {
+		p = path.Join("/", dir, p)
+	}
+	p = strings.TrimPrefix(path.Clean(p), "/")
+
+	resolved := resolver(p)
+	if u.RawQuery != "" {
+		if strings.Contains(resolved, "?") {
+			resolved += "&" + u.RawQuery
+		} else {
+			resolved += "?" + u.RawQuery
+		}
+	}
+	if u.Fragment != "" {
+		resolved += "#" + u.EscapedFragment()
+	}
+
+	return []byte(resolved)
+}
+
+// isSafeURL returns whether the url is relative or uses one of the provided schemes.
+func isSafeURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		return true
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	for _, allowed := range schemes {
+		if scheme == allowed {
+			return true
+		}
+	}
+
+	return false
+}
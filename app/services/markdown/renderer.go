@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// Options are the options of rendering a single markdown document.
+type Options struct {
+	// ResolveLink returns the url of a relative link target, relative links are kept as is if it's nil.
+	// The provided path is cleaned and relative to the root of the repository.
+	ResolveLink func(path string) string
+	// ResolveImage returns the url of a relative image source, relative sources are kept as is if it's nil.
+	// The provided path is cleaned and relative to the root of the repository.
+	ResolveImage func(path string) string
+	// Dir is the directory of the markdown document within the repository, relative links are resolved against it.
+	Dir string
+}
+
+// Renderer renders GitHub flavored markdown to safe HTML.
+// Raw HTML is omitted and links with schemes other than http, https and mailto are removed.
+// Besides tables, task lists, strikethrough and autolinks it supports mermaid diagrams and math expressions.
+// Diagrams and expressions are rendered as annotated text, leaving their visualization to the client.
+type Renderer struct {
+	md goldmark.Markdown
+}
+
+func NewRenderer() *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+			extension.Strikethrough,
+			extension.Linkify,
+			extension.TaskList,
+		),
+		goldmark.WithParserOptions(
+			parser.WithInlineParsers(util.Prioritized(&mathParser{}, 500)),
+			parser.WithASTTransformers(util.Prioritized(&linkTransformer{}, 100)),
+		),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&extensionRenderer{}, 100)),
+		),
+	)
+
+	return &Renderer{
+		md: md,
+	}
+}
+
+// Render renders the markdown source to safe HTML.
+func (r *Renderer) Render(source []byte, opts Options) ([]byte, error) {
+	pc := parser.NewContext()
+	pc.Set(optionsKey, opts)
+
+	var buf bytes.Buffer
+	if err := r.md.Convert(source, &buf, parser.WithContext(pc)); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
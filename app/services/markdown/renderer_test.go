@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "table",
+			source:   "| a | b |\n|:--|--:|\n| 1 | 2 |",
+			contains: []string{`<th align="left">a</th>`, `<td align="right">2</td>`},
+		},
+		{
+			name:     "task list",
+			source:   "- [x] done\n- [ ] todo",
+			contains: []string{`<input checked="" disabled="" type="checkbox"> done`},
+		},
+		{
+			name:     "mermaid",
+			source:   "```mermaid\ngraph TD; A-->B\n```",
+			contains: []string{`<pre class="mermaid">graph TD; A--&gt;B`},
+		},
+		{
+			name:   "math",
+			source: "Euler: $e^{i\\pi} + 1 = 0$, but $5 and $10 are amounts.\n\n```math\n\\frac{1}{2}\n```",
+			contains: []string{`<span class="math math-inline">e^{i\pi} + 1 = 0</span>`, `$5 and $10`,
+				`<div class="math math-display">\frac{1}{2}`},
+		},
+		{
+			name:     "scripts",
+			source:   "<script>alert(1)</script><style>p{}</style>\n\ntext <script>alert(2)</script>",
+			contains: []string{"<p>text <!-- raw HTML omitted -->alert(2)<!-- raw HTML omitted --></p>"},
+			excludes: []string{"<script", "alert(1)", "<style"},
+		},
+		{
+			name:     "event handlers",
+			source:   `<div onclick="alert(1)" class="mermaid">ok</div>`,
+			excludes: []string{"onclick", "<div"},
+		},
+		{
+			name: "dangerous urls",
+			source: "[a](javascript:alert(1)) [b](JavaScript:alert(1)) [c](vbscript:x) <javascript:alert(1)> " +
+				"![d](data:text/html,x) ![e](file:///etc/passwd) <a href=\"data:text/html,x\">f</a>",
+			contains: []string{`<a href="" rel="nofollow noopener noreferrer">a</a>`, `<img src="" alt="d">`,
+				"javascript:alert(1)"},
+			excludes: []string{`href="javascript`, `href="JavaScript`, `href="vbscript`, `src="data:`,
+				`src="file:`, `href="data:`},
+		},
+		{
+			name:   "links",
+			source: "[a](https://example.com) https://example.org <user@example.com>",
+			contains: []string{`<a href="https://example.com" rel="nofollow noopener noreferrer">a</a>`,
+				`<a href="https://example.org" rel="nofollow noopener noreferrer">https://example.org</a>`,
+				`<a href="mailto:user@example.com" rel="nofollow noopener noreferrer">user@example.com</a>`},
+		},
+		{
+			name:     "raw html",
+			source:   "<details><summary>S</summary>\n\nhidden\n</details>\n<input type=\"text\"><iframe src=\"x\"></iframe>",
+			contains: []string{"<p>hidden</p>"},
+			excludes: []string{"<details", "<input", "iframe"},
+		},
+	}
+
+	r := NewRenderer()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := r.Render([]byte(test.source), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for _, s := range test.contains {
+				if !strings.Contains(string(out), s) {
+					t.Errorf("expected output to contain %q, got: %s", s, out)
+				}
+			}
+			for _, s := range test.excludes {
+				if strings.Contains(string(out), s) {
+					t.Errorf("expected output to not contain %q, got: %s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderRelativeLinks(t *testing.T) {
+	opts := Options{
+		Dir:          "docs",
+		ResolveLink:  func(p string) string { return "https://ui/files/main/~/" + p },
+		ResolveImage: func(p string) string { return "https://api/raw/" + p },
+	}
+
+	source := "[a](../README.md#usage) [b](/LICENSE) [c](https://example.com) [d](#anchor) ![e](img/logo.png?v=1)"
+
+	out, err := NewRenderer().Render([]byte(source), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, s := range []string{
+		`href="https://ui/files/main/~/README.md#usage"`,
+		`href="https://ui/files/main/~/LICENSE"`,
+		`href="https://example.com"`,
+		`href="#anchor"`,
+		`src="https://api/raw/docs/img/logo.png?v=1"`,
+	} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("expected output to contain %q, got: %s", s, out)
+		}
+	}
+}
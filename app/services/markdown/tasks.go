@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extensionast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// ErrTaskNotFound is returned if a document doesn't contain the requested task.
+var ErrTaskNotFound = errors.New("task not found")
+
+// taskParser parses the task lists of documents, tables are parsed to not mistake their content for tasks.
+var taskParser = goldmark.New(
+	goldmark.WithExtensions(extension.Table, extension.TaskList),
+).Parser()
+
+// Task is an item of a task list.
+type Task struct {
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+
+	// offset is the position of the checkbox marker ('[') in the source.
+	offset int
+}
+
+// ParseTasks returns the items of all task lists of the document, in order of appearance.
+// Checkboxes outside of list items, e.g. in code blocks or tables, aren't tasks.
+func ParseTasks(source []byte) []Task {
+	doc := taskParser.Parse(text.NewReader(source))
+
+	var tasks []Task
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		checkbox, ok := node.(*extensionast.TaskCheckBox)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		// the checkbox is the first inline of the text block of a list item.
+		lines := checkbox.Parent().Lines()
+		if lines.Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+		line := lines.At(0)
+
+		value := line.Value(source)
+		taskText := ""
+		if end := bytes.IndexByte(value, ']'); end >= 0 {
+			taskText = string(bytes.TrimSpace(value[end+1:]))
+		}
+
+		tasks = append(tasks, Task{
+			Index:   len(tasks),
+			Text:    taskText,
+			Checked: checkbox.IsChecked,
+			offset:  line.Start,
+		})
+
+		return ast.WalkContinue, nil
+	})
+
+	return tasks
+}
+
+// CountTasks returns the number of tasks of the document and how many of them are completed.
+func CountTasks(source []byte) (total int, completed int) {
+	tasks := ParseTasks(source)
+	for _, task := range tasks {
+		if task.Checked {
+			completed++
+		}
+	}
+
+	return len(tasks), completed
+}
+
+// SetTaskChecked returns the document with the task of the provided index checked or unchecked,
+// together with the updated task. The rest of the document is left untouched.
+func SetTaskChecked(source []byte, index int, checked bool) ([]byte, Task, error) {
+	tasks := ParseTasks(source)
+	if index < 0 || index >= len(tasks) {
+		return nil, Task{}, ErrTaskNotFound
+	}
+
+	task := tasks[index]
+	task.Checked = checked
+
+	marker := byte(' ')
+	if checked {
+		marker = 'x'
+	}
+
+	result := make([]byte, len(source))
+	copy(result, source)
+	result[task.offset+1] = marker
+
+	return result, task, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package markdown
+
+import (
+	"testing"
+)
+
+const taskSource = "Intro\n\n- [ ] first\n- [x] second\n  - [X] nested\n\n```\n- [ ] in code\n```\n\n| a |\n|---|\n| [ ] cell |\n"
+
+func TestParseTasks(t *testing.T) {
+	tasks := ParseTasks([]byte(taskSource))
+
+	expected := []Task{
+		{Index: 0, Text: "first", Checked: false},
+		{Index: 1, Text: "second", Checked: true},
+		{Index: 2, Text: "nested", Checked: true},
+	}
+	if len(tasks) != len(expected) {
+		t.Fatalf("expected %d tasks, got %d: %+v", len(expected), len(tasks), tasks)
+	}
+
+	for i := range expected {
+		tasks[i].offset = 0
+		if tasks[i] != expected[i] {
+			t.Errorf("expected task %+v, got %+v", expected[i], tasks[i])
+		}
+	}
+}
+
+func TestSetTaskChecked(t *testing.T) {
+	out, task, err := SetTaskChecked([]byte(taskSource), 0, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if task.Text != "first" || !task.Checked {
+		t.Errorf("unexpected task %+v", task)
+	}
+
+	out, _, err = SetTaskChecked(out, 2, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "Intro\n\n- [x] first\n- [x] second\n  - [ ] nested\n\n```\n- [ ] in code\n```\n\n| a |\n|---|\n| [ ] cell |\n"
+	if string(out) != expected {
+		t.Errorf("unexpected document:\n%s", out)
+	}
+
+	if total, completed := CountTasks(out); total != 3 || completed != 2 {
+		t.Errorf("expected 2 of 3 tasks completed, got %d of %d", completed, total)
+	}
+
+	if _, _, err = SetTaskChecked(out, 3, true); err != ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
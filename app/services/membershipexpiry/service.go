@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membershipexpiry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeExpiry        = "gitness:membershipexpiry:expiry"
+	jobCronExpiry        = "*/10 * * * *" // Every 10 minutes.
+	jobMaxDurationExpiry = 5 * time.Minute
+)
+
+type Config struct {
+	// ExpiryNotice is how long before the expiry of a membership the member is notified about it.
+	ExpiryNotice time.Duration
+}
+
+// Service periodically revokes expired space memberships and notifies the members
+// about memberships that are about to expire.
+// Expired memberships don't grant any permissions even before they get revoked by the job.
+type Service struct {
+	config          Config
+	membershipStore store.MembershipStore
+	spaceStore      store.SpaceStore
+	notifier        *notification.Service
+	scheduler       *job.Scheduler
+	executor        *job.Executor
+}
+
+func NewService(
+	config Config,
+	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	notifier *notification.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return &Service{
+		config:          config,
+		membershipStore: membershipStore,
+		spaceStore:      spaceStore,
+		notifier:        notifier,
+		scheduler:       scheduler,
+		executor:        executor,
+	}
+}
+
+// Register registers and schedules the job revoking the expired memberships.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeExpiry, s)
+	if err != nil {
+		return fmt.Errorf("failed to register membership expiry job handler: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypeExpiry, jobTypeExpiry, jobCronExpiry, jobMaxDurationExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to schedule membership expiry job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle revokes the expired memberships and sends the notifications about the pending expiries.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	memberships, err := s.membershipStore.ListExpiring(ctx, now.Add(s.config.ExpiryNotice).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list expiring memberships: %w", err)
+	}
+
+	revoked := 0
+	notified := 0
+	for i := range memberships {
+		membership := &memberships[i]
+
+		if *membership.Expires <= now.UnixMilli() {
+			ok, err := s.revoke(ctx, membership, now)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("space_id", membership.SpaceID).
+					Int64("principal_id", membership.PrincipalID).
+					Msg("failed to revoke expired membership")
+				continue
+			}
+			if ok {
+				revoked++
+			}
+			continue
+		}
+
+		if membership.ExpiryNotified != nil {
+			continue
+		}
+
+		if err = s.notifyExpiring(ctx, membership, now); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("space_id", membership.SpaceID).
+				Int64("principal_id", membership.PrincipalID).
+				Msg("failed to notify about expiring membership")
+			continue
+		}
+		notified++
+	}
+
+	result := fmt.Sprintf("revoked %d expired memberships, notified about %d expiring memberships",
+		revoked, notified)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) revoke(ctx context.Context, membership *types.Membership, now time.Time) (bool, error) {
+	// the expiry is checked again when deleting, the membership could have been extended in the meantime.
+	ok, err := s.membershipStore.DeleteExpired(ctx, membership.MembershipKey, now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to delete membership: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("space_id", membership.SpaceID).
+		Int64("principal_id", membership.PrincipalID).
+		Str("role", string(membership.Role)).
+		Int64("expires", *membership.Expires).
+		Msg("revoked expired membership")
+
+	if s.notifier == nil {
+		return true, nil
+	}
+
+	space, err := s.spaceStore.Find(ctx, membership.SpaceID)
+	if err != nil {
+		// the membership is revoked, only the notification is skipped.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find space of revoked membership")
+		return true, nil
+	}
+
+	s.notifier.NotifyMembershipExpired(ctx, space, membership)
+
+	return true, nil
+}
+
+func (s *Service) notifyExpiring(ctx context.Context, membership *types.Membership, now time.Time) error {
+	// the notification time is updated first, a failure to notify skips a single notification
+	// while the other order could notify the member every time the job runs.
+	err := s.membershipStore.UpdateExpiryNotified(ctx, membership.MembershipKey, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to update expiry notification time: %w", err)
+	}
+
+	if s.notifier == nil {
+		return nil
+	}
+
+	space, err := s.spaceStore.Find(ctx, membership.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find space: %w", err)
+	}
+
+	s.notifier.NotifyMembershipExpiring(ctx, space, membership)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membershipexpiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeMembershipStore struct {
+	store.MembershipStore
+	memberships []types.Membership
+	deleted     []types.MembershipKey
+	notified    []types.MembershipKey
+	failNotify  bool
+}
+
+func (s *fakeMembershipStore) ListExpiring(_ context.Context, before int64) ([]types.Membership, error) {
+	var result []types.Membership
+	for _, m := range s.memberships {
+		if m.Expires != nil && *m.Expires <= before {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeMembershipStore) DeleteExpired(_ context.Context, key types.MembershipKey, now int64) (bool, error) {
+	for _, m := range s.memberships {
+		if m.MembershipKey == key && *m.Expires <= now {
+			s.deleted = append(s.deleted, key)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeMembershipStore) UpdateExpiryNotified(_ context.Context, key types.MembershipKey, _ int64) error {
+	if s.failNotify {
+		return errors.New("update failed")
+	}
+	s.notified = append(s.notified, key)
+	return nil
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Now().UnixMilli()
+	expired := now - time.Minute.Milliseconds()
+	expiring := now + time.Hour.Milliseconds()
+	later := now + 48*time.Hour.Milliseconds()
+
+	membershipStore := &fakeMembershipStore{
+		memberships: []types.Membership{
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 1}, Expires: &expired},
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 2}, Expires: &expiring},
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 3}, Expires: &expiring, ExpiryNotified: &now},
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 4}, Expires: &later},
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 5}},
+		},
+	}
+
+	s := NewService(Config{ExpiryNotice: 24 * time.Hour}, membershipStore, nil, nil, nil, nil)
+
+	result, err := s.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "revoked 1 expired memberships, notified about 1 expiring memberships"; result != want {
+		t.Errorf("want result %q, got %q", want, result)
+	}
+	if len(membershipStore.deleted) != 1 || membershipStore.deleted[0].PrincipalID != 1 {
+		t.Errorf("want the expired membership of principal 1 revoked, got %v", membershipStore.deleted)
+	}
+	if len(membershipStore.notified) != 1 || membershipStore.notified[0].PrincipalID != 2 {
+		t.Errorf("want the member 2 notified, got %v", membershipStore.notified)
+	}
+}
+
+func TestHandleNotificationFailure(t *testing.T) {
+	expiring := time.Now().Add(time.Hour).UnixMilli()
+
+	membershipStore := &fakeMembershipStore{
+		memberships: []types.Membership{
+			{MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 1}, Expires: &expiring},
+		},
+		failNotify: true,
+	}
+
+	s := NewService(Config{ExpiryNotice: 24 * time.Hour}, membershipStore, nil, nil, nil, nil)
+
+	result, err := s.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "revoked 0 expired memberships, notified about 0 expiring memberships"; result != want {
+		t.Errorf("want result %q, got %q", want, result)
+	}
+	if len(membershipStore.deleted) != 0 {
+		t.Errorf("expected no membership to be revoked, got %v", membershipStore.deleted)
+	}
+}
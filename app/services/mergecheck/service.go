@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergecheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const responseMax = 1 << 20 // 1 MiB
+
+// Input is sent as JSON body to the merge check providers.
+type Input struct {
+	RepoID        int64  `json:"repo_id"`
+	RepoPath      string `json:"repo_path"`
+	PullReqNumber int64  `json:"pullreq_number"`
+	Title         string `json:"title"`
+	SourceBranch  string `json:"source_branch"`
+	SourceSHA     string `json:"source_sha"`
+	TargetBranch  string `json:"target_branch"`
+	PrincipalID   int64  `json:"principal_id"`
+	PrincipalUID  string `json:"principal_uid"`
+}
+
+// Output can be returned as JSON body by the merge check providers.
+// An empty body of a 2xx response is treated as passed, any non-2xx response as failure.
+type Output struct {
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Service consults the external merge check providers of repositories before pull requests are merged.
+type Service struct {
+	settings       *settings.Service
+	client         *http.Client
+	defaultTimeout time.Duration
+}
+
+func NewService(settings *settings.Service, client *http.Client, defaultTimeout time.Duration) *Service {
+	return &Service{
+		settings:       settings,
+		client:         client,
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+// Run calls all merge check providers of the repository concurrently
+// and returns the violations of the providers that didn't pass.
+func (s *Service) Run(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principal *types.Principal,
+) ([]types.MergeCheckViolation, error) {
+	providers, err := s.settings.RepoMergeChecks(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge check providers: %w", err)
+	}
+
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	in := &Input{
+		RepoID:        repo.ID,
+		RepoPath:      repo.Path,
+		PullReqNumber: pr.Number,
+		Title:         pr.Title,
+		SourceBranch:  pr.SourceBranch,
+		SourceSHA:     pr.SourceSHA,
+		TargetBranch:  pr.TargetBranch,
+		PrincipalID:   principal.ID,
+		PrincipalUID:  principal.UID,
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merge check input: %w", err)
+	}
+
+	outputs := make([]*Output, len(providers))
+	wg := sync.WaitGroup{}
+	for i := range providers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i] = s.call(ctx, &providers[i], data)
+		}(i)
+	}
+	wg.Wait()
+
+	var violations []types.MergeCheckViolation
+	for i, out := range outputs {
+		if out.Passed {
+			continue
+		}
+
+		violations = append(violations, types.MergeCheckViolation{
+			Name:     providers[i].Name,
+			Required: providers[i].Required,
+			Message:  out.Message,
+		})
+	}
+
+	return violations, nil
+}
+
+// call calls a single merge check provider. Errors are converted into a failed output.
+func (s *Service) call(ctx context.Context, provider *types.MergeCheckProvider, data []byte) *Output {
+	timeout := s.defaultTimeout
+	if provider.TimeoutSeconds > 0 {
+		timeout = time.Duration(provider.TimeoutSeconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out, err := s.post(ctx, provider.URL, data)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Output{Message: fmt.Sprintf("The merge check timed out after %s.", timeout)}
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("merge_check", provider.Name).
+			Msg("merge check provider failed")
+
+		return &Output{Message: "The merge check provider couldn't be reached or responded with an error."}
+	}
+
+	return out
+}
+
+func (s *Service) post(ctx context.Context, url string, data []byte) (*Output, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge check request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call merge check provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("merge check provider responded with status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, responseMax))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merge check response: %w", err)
+	}
+
+	out := &Output{Passed: true}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err = json.Unmarshal(body, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal merge check response: %w", err)
+		}
+	}
+
+	return out, nil
+}
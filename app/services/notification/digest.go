@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigest        = "gitness:notification:digest"
+	jobCronDigest        = "0 * * * *" // At minute 0 of every hour.
+	jobMaxDurationDigest = 10 * time.Minute
+
+	// digestBatchSize is the maximum number of digest items processed per batch.
+	digestBatchSize = 1000
+)
+
+type digestJob struct {
+	mailer            Mailer
+	notificationStore store.NotificationStore
+	principalStore    store.PrincipalStore
+}
+
+func newDigestJob(
+	mailer Mailer,
+	notificationStore store.NotificationStore,
+	principalStore store.PrincipalStore,
+) *digestJob {
+	return &digestJob{
+		mailer:            mailer,
+		notificationStore: notificationStore,
+		principalStore:    principalStore,
+	}
+}
+
+// Handle sends a single digest mail to every user with pending notifications.
+func (j *digestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	sent := 0
+	for {
+		items, err := j.notificationStore.ListDigestItems(ctx, digestBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list digest notifications: %w", err)
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		// items are ordered by principal - group them to send one mail per principal.
+		ids := make([]int64, 0, len(items))
+		for start := 0; start < len(items); {
+			end := start + 1
+			for end < len(items) && items[end].PrincipalID == items[start].PrincipalID {
+				end++
+			}
+
+			if err := j.sendDigest(ctx, items[start:end]); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("principal_id", items[start].PrincipalID).
+					Msg("failed to send notification digest")
+			} else {
+				sent++
+			}
+
+			for _, item := range items[start:end] {
+				ids = append(ids, item.ID)
+			}
+
+			start = end
+		}
+
+		// digest items are removed even if sending failed to avoid resending them indefinitely.
+		if err := j.notificationStore.DeleteDigestItems(ctx, ids); err != nil {
+			return "", fmt.Errorf("failed to delete digest notifications: %w", err)
+		}
+
+		if len(items) < digestBatchSize {
+			break
+		}
+	}
+
+	result := "no pending notifications found"
+	if sent > 0 {
+		result = fmt.Sprintf("sent %d notification digests", sent)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *digestJob) sendDigest(ctx context.Context, items []*types.NotificationDigestItem) error {
+	principal, err := j.principalStore.Find(ctx, items[0].PrincipalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if principal.Email == "" {
+		return nil
+	}
+
+	var body strings.Builder
+	for _, item := range items {
+		body.WriteString(item.Subject)
+		body.WriteString("\n\n")
+		body.WriteString(item.Body)
+		body.WriteString("\n---\n\n")
+	}
+
+	return j.mailer.Send(ctx, &Mail{
+		To:      principal.Email,
+		Subject: fmt.Sprintf("Gitness notification digest (%d updates)", len(items)),
+		Body:    body.String(),
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventAccessRequestCreated notifies the space admins about a new access request.
+func (s *Service) handleEventAccessRequestCreated(ctx context.Context,
+	event *events.Event[*spaceevents.AccessRequestCreatedPayload]) error {
+	req, space, requester, err := s.fetchAccessRequestEventData(ctx, event.Payload.AccessRequestID)
+	if err != nil {
+		return err
+	}
+
+	adminIDs, err := s.spaceAdmins(ctx, space)
+	if err != nil {
+		return err
+	}
+
+	target := space.Path
+	if req.RepoID != nil {
+		repo, err := s.repoStore.Find(ctx, *req.RepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		target = repo.Path
+	}
+
+	subject := fmt.Sprintf("[%s] %s requested access", space.Path, requester.DisplayName)
+	body := fmt.Sprintf("%s requested the %s role in %s.\n", requester.DisplayName, req.Role, target)
+	if req.Message != "" {
+		body += "\n" + req.Message + "\n"
+	}
+	body += "\nA space admin can approve or deny the request.\n"
+
+	s.notify(ctx, notificationKindAccessRequest, recipients(event.Payload.PrincipalID, adminIDs...), subject, body)
+
+	return nil
+}
+
+// handleEventAccessRequestDecided notifies the requester about the decision about an access request.
+func (s *Service) handleEventAccessRequestDecided(ctx context.Context,
+	event *events.Event[*spaceevents.AccessRequestDecidedPayload]) error {
+	req, space, _, err := s.fetchAccessRequestEventData(ctx, event.Payload.AccessRequestID)
+	if err != nil {
+		return err
+	}
+
+	var subject, body string
+	switch req.State {
+	case enum.AccessRequestStateApproved:
+		subject = fmt.Sprintf("[%s] Access request approved", space.Path)
+		body = fmt.Sprintf("The access request for %s got approved, you are now a member with the %s role.\n",
+			space.Path, req.Role)
+	case enum.AccessRequestStateDenied:
+		subject = fmt.Sprintf("[%s] Access request denied", space.Path)
+		body = fmt.Sprintf("The access request for %s got denied.\n", space.Path)
+	default:
+		return nil
+	}
+
+	if req.DecisionReason != "" {
+		body += "\n" + req.DecisionReason + "\n"
+	}
+
+	s.notify(ctx, notificationKindAccessRequest, recipients(event.Payload.PrincipalID, req.PrincipalID), subject, body)
+
+	return nil
+}
+
+func (s *Service) fetchAccessRequestEventData(
+	ctx context.Context,
+	accessRequestID int64,
+) (*types.AccessRequest, *types.Space, *types.Principal, error) {
+	req, err := s.accessRequestStore.Find(ctx, accessRequestID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find access request: %w", err)
+	}
+
+	space, err := s.spaceStore.Find(ctx, req.SpaceID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	requester, err := s.principalStore.Find(ctx, req.PrincipalID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find requester: %w", err)
+	}
+
+	return req, space, requester, nil
+}
+
+// spaceAdmins returns the users that are owners of the space or of any of its parent spaces.
+func (s *Service) spaceAdmins(ctx context.Context, space *types.Space) ([]int64, error) {
+	spaceIDs := []int64{space.ID}
+	for parentID := space.ParentID; parentID != 0; {
+		parent, err := s.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space: %w", err)
+		}
+
+		spaceIDs = append(spaceIDs, parent.ID)
+		parentID = parent.ParentID
+	}
+
+	ownerIDs, err := s.membershipStore.ListPrincipalIDsByRole(ctx, spaceIDs, enum.MembershipRoleSpaceOwner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space owners: %w", err)
+	}
+
+	// memberships of user groups are notified to all users of the group.
+	adminIDs, err := s.expandUserGroups(ctx, ownerIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the space admins: %w", err)
+	}
+
+	return adminIDs, nil
+}
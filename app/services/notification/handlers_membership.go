@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NotifyMembershipExpiring notifies the member and the principal that added the member
+// about the upcoming expiry of a space membership.
+func (s *Service) NotifyMembershipExpiring(ctx context.Context, space *types.Space, membership *types.Membership) {
+	if membership.Expires == nil {
+		return
+	}
+
+	expires := time.UnixMilli(*membership.Expires).UTC().Format(time.RFC1123)
+	subject := fmt.Sprintf("[%s] Membership expires on %s", space.Path, expires)
+	body := fmt.Sprintf("The %s membership in %s expires on %s, after which the access is revoked.\n\n"+
+		"A space admin can extend the membership before it expires.\n",
+		membership.Role, space.Path, expires)
+
+	s.notifyMembership(ctx, membership, subject, body, membership.CreatedBy)
+}
+
+// NotifyMembershipExpired notifies the member about the revocation of an expired space membership.
+func (s *Service) NotifyMembershipExpired(ctx context.Context, space *types.Space, membership *types.Membership) {
+	subject := fmt.Sprintf("[%s] Membership expired", space.Path)
+	body := fmt.Sprintf("The %s membership in %s expired and the access has been revoked.\n",
+		membership.Role, space.Path)
+
+	s.notifyMembership(ctx, membership, subject, body)
+}
+
+func (s *Service) notifyMembership(
+	ctx context.Context,
+	membership *types.Membership,
+	subject string,
+	body string,
+	principalIDs ...int64,
+) {
+	// memberships of user groups are notified to all users of the group.
+	memberIDs, err := s.expandUserGroups(ctx, membership.PrincipalID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("principal_id", membership.PrincipalID).
+			Msg("failed to find the members to notify about membership expiry")
+		return
+	}
+
+	// there's no actor, the job expires the memberships.
+	s.notify(ctx, notificationKindMembership, recipients(0, append(memberIDs, principalIDs...)...), subject, body)
+}
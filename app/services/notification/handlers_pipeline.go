@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventExecutionCompleted notifies the principal that triggered a failed pipeline execution.
+func (s *Service) handleEventExecutionCompleted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionCompletedPayload]) error {
+	if event.Payload.Status != enum.CIStatusFailure && event.Payload.Status != enum.CIStatusError {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, event.Payload.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	subject := fmt.Sprintf("[%s] Pipeline %s failed (#%d)", repo.Path, pipeline.UID, event.Payload.Number)
+	body := fmt.Sprintf("Execution #%d of pipeline %s finished with status %s.\n\n%s\n",
+		event.Payload.Number, pipeline.UID, event.Payload.Status, s.urlProvider.GenerateUIRepoURL(repo.Path))
+
+	// the actor isn't excluded, the principal that triggered the execution is the one to be notified.
+	s.notify(ctx, notificationKindPipelineFailed, recipients(0, event.Payload.PrincipalID), subject, body)
+
+	return nil
+}
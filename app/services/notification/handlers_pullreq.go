@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+// handleEventReviewerAdded notifies the reviewer (or all users of the reviewer user group) about the requested review.
+func (s *Service) handleEventReviewerAdded(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload]) error {
+	pr, repo, actor, err := s.fetchPullReqEventData(ctx, &event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs, err := s.expandUserGroups(ctx, event.Payload.ReviewerID)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[%s] Review requested: %s (#%d)", repo.Path, pr.Title, pr.Number)
+	body := fmt.Sprintf("%s requested your review on pull request #%d %q.\n\n%s\n",
+		actor.DisplayName, pr.Number, pr.Title, s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number))
+
+	s.notify(ctx, notificationKindReviewRequested,
+		recipients(event.Payload.PrincipalID, reviewerIDs...), subject, body)
+
+	return nil
+}
+
+// handleEventReviewReminder reminds the reviewer (or all users of the reviewer user group) about the overdue review.
+func (s *Service) handleEventReviewReminder(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewReminderPayload]) error {
+	pr, repo, actor, err := s.fetchPullReqEventData(ctx, &event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs, err := s.expandUserGroups(ctx, event.Payload.ReviewerID)
+	if err != nil {
+		return err
+	}
+
+	pending := time.Since(time.UnixMilli(event.Payload.Requested))
+
+	subject := fmt.Sprintf("[%s] Review reminder: %s (#%d)", repo.Path, pr.Title, pr.Number)
+	body := fmt.Sprintf("%s requested your review on pull request #%d %q %d hours ago.\n\n%s\n",
+		actor.DisplayName, pr.Number, pr.Title, int(pending.Hours()),
+		s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number))
+
+	// the reminder isn't caused by an action of the principal who requested the review, hence nobody is excluded.
+	s.notify(ctx, notificationKindReviewRequested, recipients(0, reviewerIDs...), subject, body)
+
+	return nil
+}
+
+// handleEventCommentCreated notifies the participants of the pull request about a new comment.
+func (s *Service) handleEventCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload]) error {
+	pr, repo, actor, err := s.fetchPullReqEventData(ctx, &event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	act, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request activity: %w", err)
+	}
+
+	participantIDs, err := s.pullReqParticipants(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[%s] New comment: %s (#%d)", repo.Path, pr.Title, pr.Number)
+	body := fmt.Sprintf("%s commented on pull request #%d %q:\n\n%s\n\n%s\n",
+		actor.DisplayName, pr.Number, pr.Title, act.Text, s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number))
+
+	s.notify(ctx, notificationKindComment,
+		recipients(event.Payload.PrincipalID, participantIDs...), subject, body)
+
+	return nil
+}
+
+// handleEventMerged notifies the participants of the pull request about the merge.
+func (s *Service) handleEventMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	pr, repo, actor, err := s.fetchPullReqEventData(ctx, &event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	participantIDs, err := s.pullReqParticipants(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[%s] Merged: %s (#%d)", repo.Path, pr.Title, pr.Number)
+	body := fmt.Sprintf("%s merged pull request #%d %q into %s using %s.\n\n%s\n",
+		actor.DisplayName, pr.Number, pr.Title, pr.TargetBranch, event.Payload.MergeMethod,
+		s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number))
+
+	s.notify(ctx, notificationKindMerged,
+		recipients(event.Payload.PrincipalID, participantIDs...), subject, body)
+
+	return nil
+}
+
+func (s *Service) fetchPullReqEventData(
+	ctx context.Context,
+	base *pullreqevents.Base,
+) (*types.PullReq, *types.Repository, *types.Principal, error) {
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	actor, err := s.principalStore.Find(ctx, base.PrincipalID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	return pr, repo, actor, nil
+}
+
+// pullReqParticipants returns the author, the reviewers and the subscribers of the pull request.
+// Reviewer user groups are replaced by their users, and users that explicitly unsubscribed
+// from the pull request are excluded.
+func (s *Service) pullReqParticipants(ctx context.Context, pr *types.PullReq) ([]int64, error) {
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	subscribers, err := s.subscriberStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request subscribers: %w", err)
+	}
+
+	unsubscribed := make(map[int64]struct{})
+	for _, subscriber := range subscribers {
+		if !subscriber.Subscribed {
+			unsubscribed[subscriber.PrincipalID] = struct{}{}
+		}
+	}
+
+	ids := make([]int64, 0, len(reviewers)+len(subscribers)+1)
+	ids = append(ids, pr.CreatedBy)
+	for _, reviewer := range reviewers {
+		ids = append(ids, reviewer.PrincipalID)
+	}
+	for _, subscriber := range subscribers {
+		if subscriber.Subscribed {
+			ids = append(ids, subscriber.PrincipalID)
+		}
+	}
+
+	ids, err = s.expandUserGroups(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	participantIDs := ids[:0]
+	for _, id := range ids {
+		if _, ok := unsubscribed[id]; !ok {
+			participantIDs = append(participantIDs, id)
+		}
+	}
+
+	return participantIDs, nil
+}
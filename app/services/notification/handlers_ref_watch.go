@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// NotifyRefWatchers notifies the watchers of a ref about a push to it. The actor isn't notified.
+func (s *Service) NotifyRefWatchers(ctx context.Context, repo *types.Repository, triggered *types.RefWatchTriggered) {
+	subject := fmt.Sprintf("[%s] %s %s was updated", repo.Path, triggered.Type, triggered.Name)
+	body := fmt.Sprintf("The %s %s now points to commit %s.\n\n%s\n",
+		triggered.Type, triggered.Name, triggered.SHA, s.urlProvider.GenerateUIRepoURL(repo.Path))
+
+	s.notify(ctx, notificationKindRefWatch, recipients(triggered.ActorID, triggered.PrincipalIDs...), subject, body)
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mail is a plain text email.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer is an abstraction of a component that sends emails.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	FromMail string
+	Insecure bool
+}
+
+func (c *SMTPConfig) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Host == "" {
+		return errors.New("config.Host is required")
+	}
+	if c.Port <= 0 {
+		return errors.New("config.Port has to be a positive number")
+	}
+	if c.FromMail == "" {
+		return errors.New("config.FromMail is required")
+	}
+
+	return nil
+}
+
+// SMTPMailer sends emails using an SMTP server, upgrading the connection to TLS if supported by the server.
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) (*SMTPMailer, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided smtp config is invalid: %w", err)
+	}
+
+	return &SMTPMailer{
+		config: config,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail *Mail) error {
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		//nolint:gosec // skipping verification is an explicit opt-in of the admin.
+		tlsConfig := &tls.Config{ServerName: m.config.Host, InsecureSkipVerify: m.config.Insecure}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate with smtp server: %w", err)
+		}
+	}
+
+	if err = client.Mail(m.config.FromMail); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	if err = client.Rcpt(mail.To); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start mail data: %w", err)
+	}
+
+	if _, err = w.Write(m.message(mail)); err != nil {
+		return fmt.Errorf("failed to write mail data: %w", err)
+	}
+
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("failed to send mail data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) message(mail *Mail) []byte {
+	b := strings.Builder{}
+	b.WriteString("From: " + m.config.FromMail + "\r\n")
+	b.WriteString("To: " + mail.To + "\r\n")
+	b.WriteString("Subject: " + strings.NewReplacer("\r", "", "\n", " ").Replace(mail.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
@@ -0,0 +1,357 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:notification"
+)
+
+type Config struct {
+	Enabled         bool
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	SMTP            SMTPConfig
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service sends email notifications to users about activity they are involved in.
+// Depending on the preferences of the user, the notifications are sent immediately or as periodic digest.
+type Service struct {
+	mailer             Mailer
+	notificationStore  store.NotificationStore
+	principalStore     store.PrincipalStore
+	repoStore          store.RepoStore
+	pullreqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	reviewerStore      store.PullReqReviewerStore
+	subscriberStore    store.PullReqSubscriberStore
+	userGroupStore     store.UserGroupMemberStore
+	pipelineStore      store.PipelineStore
+	spaceStore         store.SpaceStore
+	membershipStore    store.MembershipStore
+	accessRequestStore store.AccessRequestStore
+	urlProvider        url.Provider
+	scheduler          *job.Scheduler
+	executor           *job.Executor
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	mailer Mailer,
+	notificationStore store.NotificationStore,
+	principalStore store.PrincipalStore,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	subscriberStore store.PullReqSubscriberStore,
+	userGroupStore store.UserGroupMemberStore,
+	pipelineStore store.PipelineStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	accessRequestStore store.AccessRequestStore,
+	urlProvider url.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	spaceReaderFactory *events.ReaderFactory[*spaceevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided notification service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		mailer:             mailer,
+		notificationStore:  notificationStore,
+		principalStore:     principalStore,
+		repoStore:          repoStore,
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		reviewerStore:      reviewerStore,
+		subscriberStore:    subscriberStore,
+		userGroupStore:     userGroupStore,
+		pipelineStore:      pipelineStore,
+		spaceStore:         spaceStore,
+		membershipStore:    membershipStore,
+		accessRequestStore: accessRequestStore,
+		urlProvider:        urlProvider,
+		scheduler:          scheduler,
+		executor:           executor,
+	}
+
+	_, err := prReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterReviewerAdded(service.handleEventReviewerAdded)
+			_ = r.RegisterReviewReminder(service.handleEventReviewReminder)
+			_ = r.RegisterCommentCreated(service.handleEventCommentCreated)
+			_ = r.RegisterMerged(service.handleEventMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pr event reader for notifications: %w", err)
+	}
+
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterExecutionCompleted(service.handleEventExecutionCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for notifications: %w", err)
+	}
+
+	_, err = spaceReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *spaceevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterAccessRequestCreated(service.handleEventAccessRequestCreated)
+			_ = r.RegisterAccessRequestDecided(service.handleEventAccessRequestDecided)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch space event reader for notifications: %w", err)
+	}
+
+	return service, nil
+}
+
+// Register registers and schedules the job sending the notification digests.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeDigest, newDigestJob(s.mailer, s.notificationStore, s.principalStore))
+	if err != nil {
+		return fmt.Errorf("failed to register notification digest job handler: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypeDigest, jobTypeDigest, jobCronDigest, jobMaxDurationDigest)
+	if err != nil {
+		return fmt.Errorf("failed to schedule notification digest job: %w", err)
+	}
+
+	return nil
+}
+
+// notificationKind defines the kinds of notifications users can opt out of.
+type notificationKind int
+
+const (
+	notificationKindReviewRequested notificationKind = iota
+	notificationKindComment
+	notificationKindMerged
+	notificationKindPipelineFailed
+	notificationKindRefWatch
+	notificationKindMembership
+	notificationKindAccessRequest
+)
+
+func (k notificationKind) enabled(settings *types.NotificationSettings) bool {
+	switch k {
+	case notificationKindReviewRequested:
+		return settings.ReviewRequested
+	case notificationKindComment:
+		return settings.Comments
+	case notificationKindMerged:
+		return settings.Merged
+	case notificationKindPipelineFailed:
+		return settings.PipelineFailed
+	case notificationKindRefWatch:
+		// ref watches are explicit subscriptions, hence they can't be opted out of separately.
+		return true
+	case notificationKindMembership:
+		// changes to the access of the user can't be opted out of.
+		return true
+	case notificationKindAccessRequest:
+		// access requests wait for a decision of the space admins, hence they can't be opted out of.
+		return true
+	default:
+		return false
+	}
+}
+
+// notify delivers the notification to all recipients according to their preferences.
+// Failures for individual recipients are logged and don't stop the delivery to other recipients.
+func (s *Service) notify(
+	ctx context.Context,
+	kind notificationKind,
+	recipientIDs []int64,
+	subject string,
+	body string,
+) {
+	for _, recipientID := range recipientIDs {
+		if err := s.notifyRecipient(ctx, kind, recipientID, subject, body); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal_id", recipientID).
+				Msg("failed to notify recipient")
+		}
+	}
+}
+
+func (s *Service) notifyRecipient(
+	ctx context.Context,
+	kind notificationKind,
+	recipientID int64,
+	subject string,
+	body string,
+) error {
+	recipient, err := s.principalStore.Find(ctx, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to find recipient: %w", err)
+	}
+
+	if recipient.Type != enum.PrincipalTypeUser || recipient.Email == "" ||
+		recipient.State(time.Now().UnixMilli()) != enum.PrincipalStateActive {
+		return nil
+	}
+
+	settings, err := s.notificationStore.FindSettings(ctx, recipientID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		settings = types.DefaultNotificationSettings(recipientID)
+	} else if err != nil {
+		return fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	if !kind.enabled(settings) {
+		return nil
+	}
+
+	switch settings.Delivery {
+	case enum.NotificationDeliveryImmediate:
+		err = s.mailer.Send(ctx, &Mail{
+			To:      recipient.Email,
+			Subject: subject,
+			Body:    body,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send mail: %w", err)
+		}
+	case enum.NotificationDeliveryDigest:
+		err = s.notificationStore.CreateDigestItem(ctx, &types.NotificationDigestItem{
+			PrincipalID: recipientID,
+			Subject:     subject,
+			Body:        body,
+			Created:     time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store digest notification: %w", err)
+		}
+	case enum.NotificationDeliveryDisabled:
+	}
+
+	return nil
+}
+
+// expandUserGroups replaces the user groups among the provided principals with all their users.
+func (s *Service) expandUserGroups(ctx context.Context, principalIDs ...int64) ([]int64, error) {
+	result := make([]int64, 0, len(principalIDs))
+	for _, id := range principalIDs {
+		principal, err := s.principalStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find principal %d: %w", id, err)
+		}
+
+		if principal.Type != enum.PrincipalTypeUserGroup {
+			result = append(result, id)
+			continue
+		}
+
+		userIDs, err := s.userGroupStore.ListUserIDs(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users of user group %d: %w", id, err)
+		}
+
+		result = append(result, userIDs...)
+	}
+
+	return result, nil
+}
+
+// recipients returns the provided principals without duplicates and without the actor.
+func recipients(actorID int64, principalIDs ...int64) []int64 {
+	seen := map[int64]struct{}{actorID: {}}
+	result := make([]int64, 0, len(principalIDs))
+	for _, id := range principalIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+
+	return result
+}
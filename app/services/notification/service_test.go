@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeMailer struct {
+	mails []*Mail
+}
+
+func (m *fakeMailer) Send(_ context.Context, mail *Mail) error {
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+func (m *fakeMailer) recipients() []string {
+	result := make([]string, len(m.mails))
+	for i, mail := range m.mails {
+		result[i] = mail.To
+	}
+	sort.Strings(result)
+	return result
+}
+
+type fakeNotificationStore struct {
+	store.NotificationStore
+	settings map[int64]*types.NotificationSettings
+	digest   []*types.NotificationDigestItem
+	deleted  []int64
+}
+
+func (s *fakeNotificationStore) FindSettings(_ context.Context, principalID int64) (*types.NotificationSettings,
+	error) {
+	settings, ok := s.settings[principalID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return settings, nil
+}
+
+func (s *fakeNotificationStore) CreateDigestItem(_ context.Context, item *types.NotificationDigestItem) error {
+	item.ID = int64(len(s.digest) + 1)
+	s.digest = append(s.digest, item)
+	return nil
+}
+
+func (s *fakeNotificationStore) ListDigestItems(_ context.Context, limit int) ([]*types.NotificationDigestItem,
+	error) {
+	var result []*types.NotificationDigestItem
+	for _, item := range s.digest {
+		if len(result) < limit && !s.isDeleted(item.ID) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeNotificationStore) DeleteDigestItems(_ context.Context, ids []int64) error {
+	s.deleted = append(s.deleted, ids...)
+	return nil
+}
+
+func (s *fakeNotificationStore) isDeleted(id int64) bool {
+	for _, deleted := range s.deleted {
+		if deleted == id {
+			return true
+		}
+	}
+	return false
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+type fakeUserGroupStore struct {
+	store.UserGroupMemberStore
+	members map[int64][]int64
+}
+
+func (s fakeUserGroupStore) ListUserIDs(_ context.Context, userGroupID int64) ([]int64, error) {
+	return s.members[userGroupID], nil
+}
+
+func testPrincipals() fakePrincipalStore {
+	return fakePrincipalStore{principals: map[int64]*types.Principal{
+		1: {ID: 1, Type: enum.PrincipalTypeUser, Email: "immediate@example.com"},
+		2: {ID: 2, Type: enum.PrincipalTypeUser, Email: "digest@example.com"},
+		3: {ID: 3, Type: enum.PrincipalTypeUser, Email: "opted-out@example.com"},
+		4: {ID: 4, Type: enum.PrincipalTypeServiceAccount, Email: "sa@example.com"},
+		5: {ID: 5, Type: enum.PrincipalTypeUser},
+		6: {ID: 6, Type: enum.PrincipalTypeUser, Email: "blocked@example.com", Blocked: true},
+		7: {ID: 7, Type: enum.PrincipalTypeUserGroup},
+	}}
+}
+
+func TestNotify(t *testing.T) {
+	mailer := &fakeMailer{}
+	notificationStore := &fakeNotificationStore{settings: map[int64]*types.NotificationSettings{
+		2: {PrincipalID: 2, Delivery: enum.NotificationDeliveryDigest, Comments: true},
+		3: {PrincipalID: 3, Delivery: enum.NotificationDeliveryImmediate, Comments: false, Merged: true},
+	}}
+	s := &Service{
+		mailer:            mailer,
+		notificationStore: notificationStore,
+		principalStore:    testPrincipals(),
+	}
+
+	s.notify(context.Background(), notificationKindComment, []int64{1, 2, 3, 4, 5, 6, 99}, "subject", "body")
+
+	if want := []string{"immediate@example.com"}; !reflect.DeepEqual(mailer.recipients(), want) {
+		t.Errorf("want immediate mails to %v, got %v", want, mailer.recipients())
+	}
+	if len(notificationStore.digest) != 1 || notificationStore.digest[0].PrincipalID != 2 {
+		t.Errorf("want a single digest item for principal 2, got %d items", len(notificationStore.digest))
+	}
+
+	mailer.mails = nil
+	s.notify(context.Background(), notificationKindMerged, []int64{3}, "subject", "body")
+
+	if want := []string{"opted-out@example.com"}; !reflect.DeepEqual(mailer.recipients(), want) {
+		t.Errorf("want mails of enabled kinds sent, got %v", mailer.recipients())
+	}
+}
+
+func TestRecipients(t *testing.T) {
+	s := &Service{
+		principalStore: testPrincipals(),
+		userGroupStore: fakeUserGroupStore{members: map[int64][]int64{7: {1, 2, 3}}},
+	}
+
+	ids, err := s.expandUserGroups(context.Background(), 7, 2, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []int64{1, 3, 5}; !reflect.DeepEqual(recipients(2, ids...), want) {
+		t.Errorf("want recipients %v without the actor and duplicates, got %v", want, recipients(2, ids...))
+	}
+}
+
+func TestDigestJob(t *testing.T) {
+	mailer := &fakeMailer{}
+	notificationStore := &fakeNotificationStore{digest: []*types.NotificationDigestItem{
+		{ID: 1, PrincipalID: 1, Subject: "first", Body: "body"},
+		{ID: 2, PrincipalID: 1, Subject: "second", Body: "body"},
+		{ID: 3, PrincipalID: 2, Subject: "third", Body: "body"},
+	}}
+	j := newDigestJob(mailer, notificationStore, testPrincipals())
+
+	result, err := j.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "sent 2 notification digests"; result != want {
+		t.Errorf("want result %q, got %q", want, result)
+	}
+	if want := []string{"digest@example.com", "immediate@example.com"}; !reflect.DeepEqual(mailer.recipients(), want) {
+		t.Errorf("want digests sent to %v, got %v", want, mailer.recipients())
+	}
+	for _, mail := range mailer.mails {
+		if mail.To == "immediate@example.com" && mail.Subject != "Gitness notification digest (2 updates)" {
+			t.Errorf("want a single digest with both updates, got subject %q", mail.Subject)
+		}
+	}
+	if len(notificationStore.deleted) != 3 {
+		t.Errorf("want all digest items deleted, got %v", notificationStore.deleted)
+	}
+}
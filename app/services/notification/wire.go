@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+// ProvideService provides the notification service, nil is returned in case notifications are disabled.
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	notificationStore store.NotificationStore,
+	principalStore store.PrincipalStore,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	subscriberStore store.PullReqSubscriberStore,
+	userGroupStore store.UserGroupMemberStore,
+	pipelineStore store.PipelineStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	accessRequestStore store.AccessRequestStore,
+	urlProvider url.Provider,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	spaceReaderFactory *events.ReaderFactory[*spaceevents.Reader],
+) (*Service, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	mailer, err := NewSMTPMailer(config.SMTP)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(
+		ctx,
+		config,
+		mailer,
+		notificationStore,
+		principalStore,
+		repoStore,
+		pullreqStore,
+		activityStore,
+		reviewerStore,
+		subscriberStore,
+		userGroupStore,
+		pipelineStore,
+		spaceStore,
+		membershipStore,
+		accessRequestStore,
+		urlProvider,
+		scheduler,
+		executor,
+		prReaderFactory,
+		pipelineReaderFactory,
+		spaceReaderFactory,
+	)
+}
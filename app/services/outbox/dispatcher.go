@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	dispatchLockKey    = "event_outbox"
+	dispatchLockExpiry = time.Minute
+
+	retryBackoffMin = time.Second
+	retryBackoffMax = 5 * time.Minute
+)
+
+// Dispatcher delivers the messages persisted in the outbox to the event streams.
+// Messages are delivered at least once and in the order they have been reported per stream.
+// Failed deliveries are retried with an exponential backoff.
+type Dispatcher struct {
+	outbox       *Outbox
+	store        store.EventOutboxStore
+	producer     events.StreamProducer
+	mxManager    lock.MutexManager
+	pollInterval time.Duration
+	batchSize    int
+}
+
+func NewDispatcher(
+	outbox *Outbox,
+	outboxStore store.EventOutboxStore,
+	producer events.StreamProducer,
+	mxManager lock.MutexManager,
+	pollInterval time.Duration,
+	batchSize int,
+) *Dispatcher {
+	return &Dispatcher{
+		outbox:       outbox,
+		store:        outboxStore,
+		producer:     producer,
+		mxManager:    mxManager,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+	}
+}
+
+// Run delivers pending messages until the context is canceled.
+// Messages are delivered when reported by this instance and periodically (reported by other instances or retries).
+func (d *Dispatcher) Run(ctx context.Context) error {
+	log.Ctx(ctx).Debug().Msg("event outbox dispatcher: starting")
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Ctx(ctx).Debug().Msg("event outbox dispatcher: stopped")
+			return nil
+		case <-ticker.C:
+		case <-d.outbox.signal:
+		}
+	}
+}
+
+// dispatch delivers all messages that are ready for delivery.
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	// only a single instance delivers messages at a time to preserve their order.
+	mx, err := d.mxManager.NewMutex(dispatchLockKey, lock.WithExpiry(dispatchLockExpiry), lock.WithTries(1))
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("event outbox dispatcher: failed to create lock")
+		return
+	}
+
+	if err = mx.Lock(ctx); err != nil {
+		// most likely another instance is delivering the messages.
+		log.Ctx(ctx).Debug().Err(err).Msg("event outbox dispatcher: skipped, failed to obtain lock")
+		return
+	}
+
+	defer func() {
+		if err := mx.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Err(err).Msg("event outbox dispatcher: failed to release lock")
+		}
+	}()
+
+	for ctx.Err() == nil {
+		msgs, err := d.store.ListReady(ctx, time.Now(), d.batchSize)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msg("event outbox dispatcher: failed to list ready messages")
+			return
+		}
+
+		// streams with a failed delivery are skipped for the rest of the batch to preserve the order.
+		failedStreams := map[string]struct{}{}
+		for _, msg := range msgs {
+			if _, failed := failedStreams[msg.StreamID]; failed {
+				continue
+			}
+
+			if err = d.deliver(ctx, msg); err != nil {
+				failedStreams[msg.StreamID] = struct{}{}
+			}
+		}
+
+		if len(msgs) < d.batchSize || len(failedStreams) > 0 {
+			return
+		}
+	}
+}
+
+// deliver sends the message to its stream and removes it from the outbox.
+// In case of a failure the next delivery attempt is scheduled.
+func (d *Dispatcher) deliver(ctx context.Context, msg *types.EventOutboxMessage) error {
+	payload, err := decodePayload(msg.Payload)
+	if err != nil {
+		// the message can never be delivered - drop it.
+		log.Ctx(ctx).Error().Err(err).
+			Int64("outbox.id", msg.ID).
+			Str("outbox.stream_id", msg.StreamID).
+			Msg("event outbox dispatcher: dropping message with invalid payload")
+		return d.store.Delete(ctx, msg.ID)
+	}
+
+	_, sendErr := d.producer.Send(ctx, msg.StreamID, payload)
+	if sendErr != nil {
+		msg.Attempts++
+		msg.NextAttempt = time.Now().Add(retryBackoff(msg.Attempts)).UnixMilli()
+		msg.LastError = sendErr.Error()
+
+		log.Ctx(ctx).Warn().Err(sendErr).
+			Int64("outbox.id", msg.ID).
+			Str("outbox.stream_id", msg.StreamID).
+			Int("outbox.attempts", msg.Attempts).
+			Msg("event outbox dispatcher: failed to deliver message")
+
+		if err = d.store.UpdateAttempt(ctx, msg); err != nil {
+			log.Ctx(ctx).Err(err).Int64("outbox.id", msg.ID).
+				Msg("event outbox dispatcher: failed to update delivery attempt")
+		}
+
+		return fmt.Errorf("failed to deliver message: %w", sendErr)
+	}
+
+	if err = d.store.Delete(ctx, msg.ID); err != nil {
+		// the message will be delivered again.
+		log.Ctx(ctx).Err(err).Int64("outbox.id", msg.ID).
+			Msg("event outbox dispatcher: failed to delete delivered message")
+		return err
+	}
+
+	return nil
+}
+
+// retryBackoff returns the delay before the next delivery attempt.
+func retryBackoff(attempts int) time.Duration {
+	backoff := retryBackoffMin
+	for i := 1; i < attempts && backoff < retryBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > retryBackoffMax {
+		return retryBackoffMax
+	}
+
+	return backoff
+}
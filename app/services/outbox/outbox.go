@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+var _ events.Outbox = (*Outbox)(nil)
+
+// Outbox persists reported events in the database until they are delivered by the Dispatcher.
+// If the context contains a database transaction, the events are persisted as part of it,
+// meaning they are only delivered if the triggering database change is committed.
+type Outbox struct {
+	store  store.EventOutboxStore
+	signal chan struct{}
+}
+
+func NewOutbox(outboxStore store.EventOutboxStore) *Outbox {
+	return &Outbox{
+		store:  outboxStore,
+		signal: make(chan struct{}, 1),
+	}
+}
+
+// Enqueue persists the message for the provided stream and notifies the dispatcher.
+func (o *Outbox) Enqueue(ctx context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(payload); err != nil {
+		return "", fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	msg := &types.EventOutboxMessage{
+		StreamID:    streamID,
+		Payload:     buff.Bytes(),
+		Created:     now,
+		NextAttempt: now,
+	}
+
+	if err := o.store.Create(ctx, msg); err != nil {
+		return "", fmt.Errorf("failed to store outbox message: %w", err)
+	}
+
+	o.notify()
+
+	return fmt.Sprintf("outbox-%d", msg.ID), nil
+}
+
+// notify wakes up the dispatcher without blocking (a pending notification is sufficient).
+func (o *Outbox) notify() {
+	select {
+	case o.signal <- struct{}{}:
+	default:
+	}
+}
+
+func decodePayload(raw []byte) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type memoryOutboxStore struct {
+	store.EventOutboxStore
+	msgs []*types.EventOutboxMessage
+}
+
+func (s *memoryOutboxStore) Create(_ context.Context, msg *types.EventOutboxMessage) error {
+	msg.ID = int64(len(s.msgs) + 1)
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func TestOutboxPayloadRoundTrip(t *testing.T) {
+	outboxStore := &memoryOutboxStore{}
+	outbox := NewOutbox(outboxStore)
+
+	in := map[string]interface{}{
+		"event": []byte{0x1, 0x2, 0x3},
+		"trace": `{"traceparent":"00-01"}`,
+	}
+
+	id, err := outbox.Enqueue(context.Background(), "events:git:branch-created", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "outbox-1" || len(outboxStore.msgs) != 1 {
+		t.Fatalf("unexpected outbox state after enqueue: id=%s, messages=%d", id, len(outboxStore.msgs))
+	}
+
+	out, err := decodePayload(outboxStore.msgs[0].Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event, ok := out["event"].([]byte); !ok || !bytes.Equal(event, in["event"].([]byte)) {
+		t.Errorf("event payload not preserved, got %#v", out["event"])
+	}
+	if trace, ok := out["trace"].(string); !ok || trace != in["trace"] {
+		t.Errorf("trace payload not preserved, got %#v", out["trace"])
+	}
+
+	select {
+	case <-outbox.signal:
+	default:
+		t.Error("expected dispatcher to be notified")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 5, want: 16 * time.Second},
+		{attempts: 9, want: 256 * time.Second},
+		{attempts: 10, want: retryBackoffMax},
+		{attempts: 100, want: retryBackoffMax},
+	}
+	for _, test := range tests {
+		if got := retryBackoff(test.attempts); got != test.want {
+			t.Errorf("attempts=%d: want %s, got %s", test.attempts, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbox
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideOutbox,
+	ProvideEventsOutbox,
+	ProvideDispatcher,
+)
+
+func ProvideOutbox(outboxStore store.EventOutboxStore) *Outbox {
+	return NewOutbox(outboxStore)
+}
+
+// ProvideEventsOutbox provides the outbox used by the events system (nil if the outbox is disabled).
+func ProvideEventsOutbox(config *types.Config, outbox *Outbox) events.Outbox {
+	if !config.Events.Outbox.Enabled {
+		return nil
+	}
+
+	return outbox
+}
+
+// ProvideDispatcher provides the dispatcher of the outbox.
+// NOTE: the dispatcher is running even if the outbox is disabled, to deliver the remaining messages.
+func ProvideDispatcher(
+	config *types.Config,
+	outbox *Outbox,
+	outboxStore store.EventOutboxStore,
+	eventsSystem *events.System,
+	mxManager lock.MutexManager,
+) *Dispatcher {
+	return NewDispatcher(
+		outbox,
+		outboxStore,
+		eventsSystem.StreamProducer(),
+		mxManager,
+		config.Events.Outbox.PollInterval,
+		config.Events.Outbox.BatchSize,
+	)
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathprotection
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// Violation is a change of a protected file the principal isn't allowed to make.
+type Violation struct {
+	Branch string
+	Path   string
+}
+
+// Enforcer verifies that principals only change protected files they are allowed to change.
+type Enforcer struct {
+	settings    *settings.Service
+	memberStore store.UserGroupMemberStore
+}
+
+func NewEnforcer(
+	settings *settings.Service,
+	memberStore store.UserGroupMemberStore,
+) *Enforcer {
+	return &Enforcer{
+		settings:    settings,
+		memberStore: memberStore,
+	}
+}
+
+// Check returns the changes of protected files the principal isn't allowed to make.
+// The changed paths are provided per branch name.
+func (e *Enforcer) Check(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	changedPaths map[string][]string,
+) ([]Violation, error) {
+	if len(changedPaths) == 0 {
+		return nil, nil
+	}
+
+	protections, err := e.settings.RepoPathProtections(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get path protections: %w", err)
+	}
+
+	// the user groups of the principal are only loaded if needed.
+	var groupIDs []int64
+
+	violations := []Violation{}
+	for _, protection := range protections {
+		if slices.Contains(protection.AllowedPrincipalIDs, principalID) {
+			continue
+		}
+
+		if len(protection.AllowedUserGroupIDs) > 0 && groupIDs == nil {
+			groupIDs, err = e.memberStore.ListGroupIDs(ctx, principalID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list user groups of principal: %w", err)
+			}
+		}
+
+		if containsAny(protection.AllowedUserGroupIDs, groupIDs) {
+			continue
+		}
+
+		for branch, paths := range changedPaths {
+			if !matchBranch(protection.Branches, branch) {
+				continue
+			}
+
+			for _, p := range paths {
+				if matchAnyPath(protection.Paths, p) {
+					violations = append(violations, Violation{Branch: branch, Path: p})
+				}
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+func containsAny(values []int64, candidates []int64) bool {
+	for _, candidate := range candidates {
+		if slices.Contains(values, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchBranch(patterns []string, branch string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAnyPath(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if MatchPath(pattern, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchPath returns whether the path matches the pattern. Each segment of the pattern is matched
+// against a segment of the path using path.Match, a "**" segment matches any number of segments.
+func MatchPath(pattern string, p string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+func matchSegments(patterns []string, segments []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			// try to match the rest of the pattern against any suffix of the path.
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(patterns[1:], segments[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+			return false
+		}
+
+		patterns = patterns[1:]
+		segments = segments[1:]
+	}
+
+	return len(segments) == 0
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathprotection
+
+import "testing"
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "infra/**", path: "infra/main.tf", want: true},
+		{pattern: "infra/**", path: "infra/modules/net/main.tf", want: true},
+		{pattern: "infra/**", path: "infrastructure/main.tf", want: false},
+		{pattern: "infra/*", path: "infra/modules/main.tf", want: false},
+		{pattern: "**/*.tf", path: "main.tf", want: true},
+		{pattern: "**/*.tf", path: "infra/modules/main.tf", want: true},
+		{pattern: "**/*.tf", path: "infra/README.md", want: false},
+		{pattern: "docs/**/index.md", path: "docs/index.md", want: true},
+		{pattern: "docs/**/index.md", path: "docs/a/b/index.md", want: true},
+		{pattern: "go.mod", path: "go.mod", want: true},
+		{pattern: "go.mod", path: "sub/go.mod", want: false},
+	}
+
+	for _, test := range tests {
+		if got := MatchPath(test.pattern, test.path); got != test.want {
+			t.Errorf("MatchPath(%q, %q) = %t, want %t", test.pattern, test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+)
+
+// Key is a parsed public key.
+type Key struct {
+	Scheme enum.PublicKeyScheme
+	// Fingerprint is the fingerprint of the (primary) key.
+	Fingerprint string
+	// KeyIDs are the ids under which signatures reference the key (and its sub keys).
+	KeyIDs []string
+}
+
+// ParseKey parses an armored PGP public key or an SSH public key in authorized_keys format.
+func ParseKey(content string) (*Key, error) {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, pgpPublicKeyHeader) {
+		return parsePGPKey(content)
+	}
+
+	return parseSSHKey(content)
+}
+
+func parsePGPKey(content string) (*Key, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PGP public key: %w", err)
+	}
+	if len(entities) != 1 {
+		return nil, errors.New("exactly one PGP public key has to be provided")
+	}
+
+	entity := entities[0]
+	keyIDs := make([]string, 0, len(entity.Subkeys)+1)
+	keyIDs = append(keyIDs, pgpKeyID(entity.PrimaryKey.KeyId))
+	for _, subkey := range entity.Subkeys {
+		keyIDs = append(keyIDs, pgpKeyID(subkey.PublicKey.KeyId))
+	}
+
+	return &Key{
+		Scheme:      enum.PublicKeySchemePGP,
+		Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+		KeyIDs:      keyIDs,
+	}, nil
+}
+
+func parseSSHKey(content string) (*Key, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SSH public key: %w", err)
+	}
+
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+
+	return &Key{
+		Scheme:      enum.PublicKeySchemeSSH,
+		Fingerprint: fingerprint,
+		KeyIDs:      []string{fingerprint},
+	}, nil
+}
+
+// pgpKeyID returns the long key id as it's shown by gpg.
+func pgpKeyID(id uint64) string {
+	return fmt.Sprintf("%016X", id)
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// Service verifies commit signatures against the public keys of the users.
+// Verification results are cached per repository and commit, and are invalidated
+// whenever a key referenced by a cached result is added or removed.
+type Service struct {
+	publicKeyStore     store.PublicKeyStore
+	verificationStore  store.CommitVerificationStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewService(
+	publicKeyStore store.PublicKeyStore,
+	verificationStore store.CommitVerificationStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *Service {
+	return &Service{
+		publicKeyStore:     publicKeyStore,
+		verificationStore:  verificationStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+	}
+}
+
+// Invalidate removes all cached verification results of signatures made with the provided key.
+func (s *Service) Invalidate(ctx context.Context, key *Key) error {
+	if err := s.verificationStore.DeleteByKeyIDs(ctx, key.KeyIDs); err != nil {
+		return fmt.Errorf("failed to delete cached commit verifications: %w", err)
+	}
+
+	return nil
+}
+
+// Verify returns the verification results of all signed commits, keyed by the commit sha.
+// Unsigned commits don't have an entry in the result.
+func (s *Service) Verify(
+	ctx context.Context,
+	repoID int64,
+	commits []gitrpc.Commit,
+) (map[string]*types.CommitVerification, error) {
+	shas := make([]string, 0, len(commits))
+	for i := range commits {
+		if commits[i].Signature != nil {
+			shas = append(shas, commits[i].SHA)
+		}
+	}
+
+	if len(shas) == 0 {
+		return map[string]*types.CommitVerification{}, nil
+	}
+
+	cached, err := s.verificationStore.ListBySHAs(ctx, repoID, shas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached commit verifications: %w", err)
+	}
+
+	verifications := make(map[string]*types.CommitVerification, len(shas))
+	for _, v := range cached {
+		verifications[v.SHA] = v
+	}
+
+	for i := range commits {
+		commit := &commits[i]
+		if commit.Signature == nil || verifications[commit.SHA] != nil {
+			continue
+		}
+
+		v, err := s.verify(ctx, commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", commit.SHA, err)
+		}
+
+		v.RepoID = repoID
+		v.SHA = commit.SHA
+		v.Created = time.Now().UnixMilli()
+
+		if err = s.verificationStore.Upsert(ctx, v); err != nil {
+			return nil, fmt.Errorf("failed to store commit verification: %w", err)
+		}
+
+		verifications[commit.SHA] = v
+	}
+
+	if err = s.backfillSigners(ctx, verifications); err != nil {
+		return nil, err
+	}
+
+	return verifications, nil
+}
+
+func (s *Service) backfillSigners(ctx context.Context, verifications map[string]*types.CommitVerification) error {
+	signerIDs := make([]int64, 0, len(verifications))
+	for _, v := range verifications {
+		if v.SignerID != nil {
+			signerIDs = append(signerIDs, *v.SignerID)
+		}
+	}
+
+	if len(signerIDs) == 0 {
+		return nil
+	}
+
+	signers, err := s.principalInfoCache.Map(ctx, signerIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load signers: %w", err)
+	}
+
+	for _, v := range verifications {
+		if v.SignerID != nil {
+			v.Signer = signers[*v.SignerID]
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) verify(ctx context.Context, commit *gitrpc.Commit) (*types.CommitVerification, error) {
+	signature := strings.TrimSpace(commit.Signature.Signature)
+
+	switch {
+	case strings.HasPrefix(signature, pgpSignatureHeader):
+		return s.verifyPGP(ctx, commit, signature)
+	case strings.HasPrefix(signature, sshSignatureHeader):
+		return s.verifySSH(ctx, commit, signature)
+	default:
+		// other signature formats (e.g. x509) can't be associated with any known key.
+		return &types.CommitVerification{
+			Status: enum.CommitVerificationStatusUnknownKey,
+		}, nil
+	}
+}
+
+// verifyPGP verifies a PGP signature using the keys of the user owning the committer email.
+func (s *Service) verifyPGP(
+	ctx context.Context,
+	commit *gitrpc.Commit,
+	signature string,
+) (*types.CommitVerification, error) {
+	v := &types.CommitVerification{
+		Scheme: enum.PublicKeySchemePGP,
+		Status: enum.CommitVerificationStatusBad,
+	}
+
+	keyID, ok := pgpSignatureKeyID(signature)
+	if !ok {
+		return v, nil
+	}
+	v.KeyID = keyID
+
+	principal, err := s.principalStore.FindByEmail(ctx, commit.Committer.Identity.Email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		v.Status = enum.CommitVerificationStatusUnknownKey
+		return v, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find committer: %w", err)
+	}
+
+	keys, err := s.publicKeyStore.List(ctx, principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys of committer: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.Scheme != enum.PublicKeySchemePGP {
+			continue
+		}
+
+		keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.Content))
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to read stored PGP key %d", key.ID)
+			continue
+		}
+
+		var id uint64
+		if _, err = fmt.Sscanf(keyID, "%X", &id); err != nil || len(keyRing.KeysById(id)) == 0 {
+			continue
+		}
+
+		// the signature has to be valid at the time of the commit, not now.
+		config := &packet.Config{Time: func() time.Time { return commit.Committer.When }}
+		_, err = openpgp.CheckArmoredDetachedSignature(keyRing,
+			strings.NewReader(commit.Signature.Payload), strings.NewReader(signature), config)
+		if err != nil {
+			return v, nil
+		}
+
+		v.Status = enum.CommitVerificationStatusGood
+		v.SignerID = &principal.ID
+
+		return v, nil
+	}
+
+	v.Status = enum.CommitVerificationStatusUnknownKey
+
+	return v, nil
+}
+
+// verifySSH verifies an SSH signature using the stored key matching the key embedded in the signature.
+func (s *Service) verifySSH(
+	ctx context.Context,
+	commit *gitrpc.Commit,
+	signature string,
+) (*types.CommitVerification, error) {
+	v := &types.CommitVerification{
+		Scheme: enum.PublicKeySchemeSSH,
+		Status: enum.CommitVerificationStatusBad,
+	}
+
+	sig, err := parseSSHSignature(signature)
+	if err != nil {
+		return v, nil
+	}
+	v.KeyID = ssh.FingerprintSHA256(sig.publicKey)
+
+	key, err := s.publicKeyStore.FindByFingerprint(ctx, v.KeyID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		v.Status = enum.CommitVerificationStatusUnknownKey
+		return v, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	if err = sig.verify(sshSigNamespaceGit, []byte(commit.Signature.Payload)); err != nil {
+		return v, nil
+	}
+
+	v.Status = enum.CommitVerificationStatusGood
+	v.SignerID = &key.PrincipalID
+
+	return v, nil
+}
+
+// pgpSignatureKeyID returns the long id of the key that issued the armored PGP signature.
+func pgpSignatureKeyID(signature string) (string, bool) {
+	block, err := armor.Decode(strings.NewReader(signature))
+	if err != nil {
+		return "", false
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "", false
+	}
+
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case sig.IssuerKeyId != nil:
+		return pgpKeyID(*sig.IssuerKeyId), true
+	case len(sig.IssuerFingerprint) >= 8:
+		return fmt.Sprintf("%X", sig.IssuerFingerprint[len(sig.IssuerFingerprint)-8:]), true
+	default:
+		return "", false
+	}
+}
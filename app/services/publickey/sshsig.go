@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSigMagic is the preamble of SSH signatures, see
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const sshSigMagic = "SSHSIG"
+
+// sshSigNamespaceGit is the namespace git uses when signing commits and tags.
+const sshSigNamespaceGit = "git"
+
+type sshSigBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      []byte
+	HashAlgorithm string
+	Signature     []byte
+}
+
+type sshSigSignedData struct {
+	Namespace     string
+	Reserved      []byte
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// sshSignature is a parsed armored SSH signature.
+type sshSignature struct {
+	publicKey ssh.PublicKey
+	blob      sshSigBlob
+	signature *ssh.Signature
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	block, _ := pem.Decode([]byte(armored))
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return nil, errors.New("signature isn't an armored SSH signature")
+	}
+
+	if len(block.Bytes) < len(sshSigMagic) || string(block.Bytes[:len(sshSigMagic)]) != sshSigMagic {
+		return nil, errors.New("signature is missing the SSH signature preamble")
+	}
+
+	blob := sshSigBlob{}
+	if err := ssh.Unmarshal(block.Bytes[len(sshSigMagic):], &blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SSH signature: %w", err)
+	}
+
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("unsupported SSH signature version %d", blob.Version)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key of SSH signature: %w", err)
+	}
+
+	signature := &ssh.Signature{}
+	if err = ssh.Unmarshal(blob.Signature, signature); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature of SSH signature: %w", err)
+	}
+
+	return &sshSignature{
+		publicKey: publicKey,
+		blob:      blob,
+		signature: signature,
+	}, nil
+}
+
+// verify verifies that the signature was created for the provided message in the provided namespace.
+func (s *sshSignature) verify(namespace string, message []byte) error {
+	if s.blob.Namespace != namespace {
+		return fmt.Errorf("signature was created for namespace %q", s.blob.Namespace)
+	}
+
+	var h hash.Hash
+	switch s.blob.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported hash algorithm %q", s.blob.HashAlgorithm)
+	}
+	h.Write(message)
+
+	signedData := append([]byte(sshSigMagic), ssh.Marshal(sshSigSignedData{
+		Namespace:     s.blob.Namespace,
+		Reserved:      s.blob.Reserved,
+		HashAlgorithm: s.blob.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	return s.publicKey.Verify(signedData, s.signature)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHKey and testSSHSignature were created using `ssh-keygen -Y sign -n git` on testSSHMessage.
+const (
+	testSSHKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKhcDB9R2u2I3cLX6si0the0omrhcZ8/WbswogoumTEI"
+
+	testSSHMessage = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author A <a@example.com> 1700000000 +0000\n" +
+		"committer A <a@example.com> 1700000000 +0000\n" +
+		"\n" +
+		"initial\n"
+
+	testSSHSignature = `-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAADMAAAALc3NoLWVkMjU1MTkAAAAgqFwMH1Ha7YjdwtfqyLS2F7Siau
+Fxnz9ZuzCiCi6ZMQgAAAADZ2l0AAAAAAAAAAZzaGE1MTIAAABTAAAAC3NzaC1lZDI1NTE5
+AAAAQLNCKRaVFN0g8ikOYDwTwvVLKho6PuUU+DQd4xUu5bGXRq5MWyLJmg2r+i6heTVZ1j
+7jhYluHj9HvG5aamyFEAw=
+-----END SSH SIGNATURE-----
+`
+)
+
+func TestSSHSignature(t *testing.T) {
+	key, err := ParseKey(testSSHKey)
+	if err != nil {
+		t.Fatalf("failed to parse key: %s", err)
+	}
+
+	sig, err := parseSSHSignature(testSSHSignature)
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	if got := ssh.FingerprintSHA256(sig.publicKey); got != key.Fingerprint {
+		t.Errorf("expected signature key %q, got %q", key.Fingerprint, got)
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		message   string
+		valid     bool
+	}{
+		{name: "valid", namespace: sshSigNamespaceGit, message: testSSHMessage, valid: true},
+		{name: "modified message", namespace: sshSigNamespaceGit, message: testSSHMessage + "x", valid: false},
+		{name: "other namespace", namespace: "file", message: testSSHMessage, valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := sig.verify(test.namespace, []byte(test.message))
+			if test.valid && err != nil {
+				t.Errorf("expected signature to be valid, got: %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected signature to be invalid")
+			}
+		})
+	}
+}
+
+func TestParseSSHSignatureInvalid(t *testing.T) {
+	if _, err := parseSSHSignature("-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----"); err == nil {
+		t.Error("expected error for non SSH signature")
+	}
+}
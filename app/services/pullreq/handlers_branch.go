@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -96,6 +97,19 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			return err
 		}
 
+		err = s.pushStore.Create(ctx, &types.PullReqPush{
+			PullReqID:    pr.ID,
+			SourceSHA:    pr.SourceSHA,
+			MergeBaseSHA: pr.MergeBaseSHA,
+			Forced:       event.Payload.Forced,
+			CreatedBy:    event.Payload.PrincipalID,
+			Created:      time.Now().UnixMilli(),
+		})
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(err).Msgf("failed to record pull request push after branch update")
+		}
+
 		payload := &types.PullRequestActivityPayloadBranchUpdate{
 			Old: event.Payload.OldSHA,
 			New: event.Payload.NewSHA,
@@ -183,6 +197,178 @@ func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
 	return nil
 }
 
+// handleBranchDelete handles branch delete events.
+// It closes the open pull requests of the deleted source branch
+// and retargets or closes the open pull requests of the deleted target branch.
+func (s *Service) handleBranchDelete(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	if err := s.closePullReqOnBranchDelete(ctx, event); err != nil {
+		return err
+	}
+
+	return s.retargetPullReqOnTargetBranchDelete(ctx, event)
+}
+
+// retargetPullReqOnTargetBranchDelete handles branch delete events.
+// Depending on the repository setting, every open pull request targeting the deleted branch
+// is either retargeted to the default branch of the repository or closed.
+func (s *Service) retargetPullReqOnTargetBranchDelete(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	branch, err := getBranchFromRef(event.Payload.Ref)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Send()
+		return nil
+	}
+
+	const largeLimit = 1000000
+
+	pullreqList, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Page:         0,
+		Size:         largeLimit,
+		TargetRepoID: event.Payload.RepoID,
+		TargetBranch: branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get list of open pull requests targeting the deleted branch: %w", err)
+	}
+
+	if len(pullreqList) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	action, err := s.settings.RepoTargetBranchDelete(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get target branch delete setting: %w", err)
+	}
+
+	for _, pr := range pullreqList {
+		retarget := action == enum.PullReqTargetDeleteActionRetarget &&
+			repo.DefaultBranch != branch &&
+			(pr.SourceRepoID != pr.TargetRepoID || pr.SourceBranch != repo.DefaultBranch)
+
+		if retarget {
+			err = s.retargetPullReq(ctx, repo, pr, event.Payload.PrincipalID)
+		} else {
+			err = s.closePullReqOnTargetBranchDelete(ctx, repo, pr, event.Payload.PrincipalID)
+		}
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msgf("failed to process pull request %d after target branch delete", pr.Number)
+		}
+	}
+
+	return nil
+}
+
+// retargetPullReq changes the target branch of the pull request to the default branch of the repository.
+func (s *Service) retargetPullReq(ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+) error {
+	mergeBaseInfo, err := s.gitRPCClient.MergeBase(ctx, gitrpc.MergeBaseParams{
+		ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       pr.SourceSHA,
+		Ref2:       repo.DefaultBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get merge base with the default branch: %w", err)
+	}
+
+	oldTargetBranch := pr.TargetBranch
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++ // because we need to write the activity
+
+		pr.TargetBranch = repo.DefaultBranch
+		pr.MergeBaseSHA = mergeBaseInfo.MergeBaseSHA
+
+		// reset merge-check fields for new run
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		pr.MergeTargetSHA = nil
+		pr.MergeSHA = nil
+		pr.MergeConflicts = nil
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retarget pull request: %w", err)
+	}
+
+	_, errAct := s.activityStore.CreateWithPayload(ctx, pr, principalID,
+		&types.PullRequestActivityPayloadTargetChange{Old: oldTargetBranch, New: pr.TargetBranch})
+	if errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after retarget")
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
+
+// closePullReqOnTargetBranchDelete closes the pull request after its target branch got deleted.
+func (s *Service) closePullReqOnTargetBranchDelete(ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+) error {
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++ // because we need to write the activity
+
+		pr.State = enum.PullReqStateClosed
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		pr.MergeSHA = nil
+		pr.MergeConflicts = nil
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to close pull request after target branch delete: %w", err)
+	}
+
+	_, errAct := s.activityStore.CreateWithPayload(ctx, pr, principalID,
+		&types.PullRequestActivityPayloadStateChange{
+			Old:      enum.PullReqStateOpen,
+			New:      enum.PullReqStateClosed,
+			OldDraft: pr.IsDraft,
+			NewDraft: pr.IsDraft,
+			Message:  fmt.Sprintf("The target branch '%s' got deleted.", pr.TargetBranch),
+		})
+	if errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after target branch delete")
+	}
+
+	s.pullreqEvReporter.Closed(ctx, &pullreqevents.ClosedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		SourceSHA: pr.SourceSHA,
+	})
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullrequesUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
+
 // forEveryOpenPR is utility function that executes the provided function
 // for every open pull request created with the source branch given as a git ref.
 func (s *Service) forEveryOpenPR(ctx context.Context,
@@ -33,7 +33,6 @@ import (
 
 const (
 	cancelMergeCheckKey = "cancel_merge_check_for_sha"
-	nilSHA              = "0000000000000000000000000000000000000000"
 )
 
 // mergeCheckOnCreated handles pull request Created events.
@@ -45,7 +44,7 @@ func (s *Service) mergeCheckOnCreated(ctx context.Context,
 		ctx,
 		event.Payload.TargetRepoID,
 		event.Payload.Number,
-		nilSHA,
+		types.NilSHAFor(event.Payload.SourceSHA),
 		event.Payload.SourceSHA,
 	)
 }
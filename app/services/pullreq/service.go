@@ -25,6 +25,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -45,8 +46,10 @@ type Service struct {
 	codeCommentView     store.CodeCommentView
 	codeCommentMigrator *codecomments.Migrator
 	fileViewStore       store.PullReqFileViewStore
+	pushStore           store.PullReqPushStore
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
+	settings            *settings.Service
 
 	cancelMutex        sync.Mutex
 	cancelMergeability map[string]context.CancelFunc
@@ -68,9 +71,11 @@ func New(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	pushStore store.PullReqPushStore,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	settings *settings.Service,
 ) (*Service, error) {
 	service := &Service{
 		pullreqEvReporter:   pullreqEvReporter,
@@ -83,9 +88,11 @@ func New(ctx context.Context,
 		urlProvider:         urlProvider,
 		codeCommentMigrator: codeCommentMigrator,
 		fileViewStore:       fileViewStore,
+		pushStore:           pushStore,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
+		settings:            settings,
 	}
 
 	var err error
@@ -104,7 +111,7 @@ func New(ctx context.Context,
 				))
 
 			_ = r.RegisterBranchUpdated(service.triggerPREventOnBranchUpdate)
-			_ = r.RegisterBranchDeleted(service.closePullReqOnBranchDelete)
+			_ = r.RegisterBranchDeleted(service.handleBranchDelete)
 
 			return nil
 		})
@@ -20,6 +20,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -48,11 +49,13 @@ func ProvideService(ctx context.Context,
 	codeCommentView store.CodeCommentView,
 	codeCommentMigrator *codecomments.Migrator,
 	fileViewStore store.PullReqFileViewStore,
+	pushStore store.PullReqPushStore,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	settings *settings.Service,
 ) (*Service, error) {
 	return New(ctx, config, gitReaderFactory, pullReqEvFactory, pullReqEvReporter, gitRPCClient,
 		repoGitInfoCache, repoStore, pullreqStore, activityStore,
-		codeCommentView, codeCommentMigrator, fileViewStore, pubsub, urlProvider, sseStreamer)
+		codeCommentView, codeCommentMigrator, fileViewStore, pushStore, pubsub, urlProvider, sseStreamer, settings)
 }
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Enforcer verifies that actions don't exceed the quotas of the spaces they affect.
+// The quota of a space applies to the space itself and all its descendants.
+type Enforcer struct {
+	quotaStore store.SpaceQuotaStore
+	spaceStore store.SpaceStore
+}
+
+func NewEnforcer(
+	quotaStore store.SpaceQuotaStore,
+	spaceStore store.SpaceStore,
+) *Enforcer {
+	return &Enforcer{
+		quotaStore: quotaStore,
+		spaceStore: spaceStore,
+	}
+}
+
+// CheckRepoCreation verifies that numRepos repositories can be created in the space.
+func (e *Enforcer) CheckRepoCreation(ctx context.Context, spaceID int64, numRepos int64) error {
+	return e.check(ctx, spaceID, func(quota *types.SpaceQuota, usage *types.SpaceUsage) error {
+		if quota.MaxRepos == nil || usage.NumRepos+numRepos <= *quota.MaxRepos {
+			return nil
+		}
+
+		return e.errQuotaExceeded(ctx, quota.SpaceID, fmt.Sprintf("its limit of %d repositories", *quota.MaxRepos))
+	})
+}
+
+// CheckSize verifies that the git storage used within the space can grow by the provided size (in KiB).
+func (e *Enforcer) CheckSize(ctx context.Context, spaceID int64, size int64) error {
+	if size <= 0 {
+		return nil
+	}
+
+	return e.check(ctx, spaceID, func(quota *types.SpaceQuota, usage *types.SpaceUsage) error {
+		if quota.MaxSize == nil || usage.Size+size <= *quota.MaxSize {
+			return nil
+		}
+
+		return e.errQuotaExceeded(ctx, quota.SpaceID, fmt.Sprintf("its storage limit of %d KiB", *quota.MaxSize))
+	})
+}
+
+func (e *Enforcer) check(
+	ctx context.Context,
+	spaceID int64,
+	fn func(quota *types.SpaceQuota, usage *types.SpaceUsage) error,
+) error {
+	quotas, err := e.quotaStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list quotas of space %d: %w", spaceID, err)
+	}
+
+	for _, quota := range quotas {
+		usage, err := e.quotaStore.Usage(ctx, quota.SpaceID)
+		if err != nil {
+			return fmt.Errorf("failed to get usage of space %d: %w", quota.SpaceID, err)
+		}
+
+		if err = fn(quota, usage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Enforcer) errQuotaExceeded(ctx context.Context, spaceID int64, limit string) error {
+	space, err := e.spaceStore.Find(ctx, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find space %d: %w", spaceID, err)
+	}
+
+	return usererror.Forbidden(fmt.Sprintf("The action would exceed the quota of space '%s': %s.", space.Path, limit))
+}
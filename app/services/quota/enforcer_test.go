@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// fakeQuotaStore contains a child space 2 within the root space 1.
+type fakeQuotaStore struct {
+	store.SpaceQuotaStore
+	quotas map[int64]*types.SpaceQuota
+	usages map[int64]*types.SpaceUsage
+}
+
+func (s *fakeQuotaStore) ListInherited(_ context.Context, spaceID int64) ([]*types.SpaceQuota, error) {
+	var result []*types.SpaceQuota
+	for id := spaceID; id > 0; id-- {
+		if quota, ok := s.quotas[id]; ok {
+			result = append(result, quota)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeQuotaStore) Usage(_ context.Context, spaceID int64) (*types.SpaceUsage, error) {
+	return s.usages[spaceID], nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	if id == 1 {
+		return &types.Space{ID: 1, Path: "root"}, nil
+	}
+	return &types.Space{ID: id, Path: "root/child"}, nil
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
+
+func TestEnforcer(t *testing.T) {
+	quotaStore := &fakeQuotaStore{
+		quotas: map[int64]*types.SpaceQuota{
+			1: {SpaceID: 1, MaxRepos: ptr(5)},
+			2: {SpaceID: 2, MaxSize: ptr(100)},
+		},
+		usages: map[int64]*types.SpaceUsage{
+			1: {NumRepos: 4, Size: 500},
+			2: {NumRepos: 2, Size: 90},
+		},
+	}
+	e := NewEnforcer(quotaStore, fakeSpaceStore{})
+
+	tests := []struct {
+		name      string
+		check     func(ctx context.Context) error
+		wantSpace string
+	}{
+		{
+			name:  "repo within limit",
+			check: func(ctx context.Context) error { return e.CheckRepoCreation(ctx, 2, 1) },
+		},
+		{
+			name:      "repos exceed limit of ancestor",
+			check:     func(ctx context.Context) error { return e.CheckRepoCreation(ctx, 2, 2) },
+			wantSpace: "root",
+		},
+		{
+			name:  "size within limit",
+			check: func(ctx context.Context) error { return e.CheckSize(ctx, 2, 10) },
+		},
+		{
+			name:      "size exceeds limit",
+			check:     func(ctx context.Context) error { return e.CheckSize(ctx, 2, 11) },
+			wantSpace: "root/child",
+		},
+		{
+			name:  "size not limited",
+			check: func(ctx context.Context) error { return e.CheckSize(ctx, 1, 1000) },
+		},
+		{
+			name:  "no growth",
+			check: func(ctx context.Context) error { return e.CheckSize(ctx, 2, -50) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.check(context.Background())
+
+			if test.wantSpace == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusForbidden {
+				t.Fatalf("want forbidden error, got %v", err)
+			}
+			if !strings.Contains(uErr.Message, "'"+test.wantSpace+"'") {
+				t.Errorf("want error about the quota of space %q, got %q", test.wantSpace, uErr.Message)
+			}
+		})
+	}
+}
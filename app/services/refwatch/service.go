@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	eventsReaderGroupName = "gitness:refwatch"
+
+	refPrefixBranch = "refs/heads/"
+	refPrefixTag    = "refs/tags/"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service notifies the watchers of refs about pushes to them.
+// Watchers are always notified in-app (server sent event), and by email in case notifications are enabled.
+type Service struct {
+	refWatchStore store.RefWatchStore
+	repoStore     store.RepoStore
+	sseStreamer   sse.Streamer
+	notifier      *notification.Service
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	refWatchStore store.RefWatchStore,
+	repoStore store.RepoStore,
+	sseStreamer sse.Streamer,
+	notifier *notification.Service,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided ref watch service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		refWatchStore: refWatchStore,
+		repoStore:     repoStore,
+		sseStreamer:   sseStreamer,
+		notifier:      notifier,
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for ref watches: %w", err)
+	}
+
+	return service, nil
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	p := event.Payload
+	return s.trigger(ctx, p.RepoID, p.PrincipalID, enum.RefWatchTypeBranch,
+		strings.TrimPrefix(p.Ref, refPrefixBranch), p.SHA)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	p := event.Payload
+	return s.trigger(ctx, p.RepoID, p.PrincipalID, enum.RefWatchTypeBranch,
+		strings.TrimPrefix(p.Ref, refPrefixBranch), p.NewSHA)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	p := event.Payload
+	return s.trigger(ctx, p.RepoID, p.PrincipalID, enum.RefWatchTypeTag,
+		strings.TrimPrefix(p.Ref, refPrefixTag), p.SHA)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	p := event.Payload
+	return s.trigger(ctx, p.RepoID, p.PrincipalID, enum.RefWatchTypeTag,
+		strings.TrimPrefix(p.Ref, refPrefixTag), p.NewSHA)
+}
+
+// trigger notifies all principals watching the ref, except the actor that pushed to it.
+func (s *Service) trigger(
+	ctx context.Context,
+	repoID int64,
+	actorID int64,
+	refWatchType enum.RefWatchType,
+	name string,
+	sha string,
+) error {
+	watches, err := s.refWatchStore.ListByType(ctx, repoID, refWatchType)
+	if err != nil {
+		return fmt.Errorf("failed to list ref watches: %w", err)
+	}
+
+	principalIDs := matchingPrincipals(watches, actorID, name)
+	if len(principalIDs) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	triggered := &types.RefWatchTriggered{
+		RepoID:       repo.ID,
+		RepoPath:     repo.Path,
+		Type:         refWatchType,
+		Name:         name,
+		SHA:          sha,
+		ActorID:      actorID,
+		PrincipalIDs: principalIDs,
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeRefWatchTriggered, triggered); err != nil {
+		return fmt.Errorf("failed to publish ref watch event: %w", err)
+	}
+
+	if s.notifier != nil {
+		s.notifier.NotifyRefWatchers(ctx, repo, triggered)
+	}
+
+	return nil
+}
+
+// matchingPrincipals returns the principals with at least one watch matching the ref name, excluding the actor.
+func matchingPrincipals(watches []*types.RefWatch, actorID int64, name string) []int64 {
+	seen := map[int64]struct{}{actorID: {}}
+	var principalIDs []int64
+	for _, watch := range watches {
+		if _, ok := seen[watch.PrincipalID]; ok {
+			continue
+		}
+
+		if ok, _ := path.Match(watch.Pattern, name); !ok {
+			continue
+		}
+
+		seen[watch.PrincipalID] = struct{}{}
+		principalIDs = append(principalIDs, watch.PrincipalID)
+	}
+
+	return principalIDs
+}
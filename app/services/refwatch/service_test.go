@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refwatch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestMatchingPrincipals(t *testing.T) {
+	watches := []*types.RefWatch{
+		{PrincipalID: 1, Pattern: "main"},
+		{PrincipalID: 2, Pattern: "release/*"},
+		{PrincipalID: 3, Pattern: "*"},
+		{PrincipalID: 3, Pattern: "main"},
+		{PrincipalID: 4, Pattern: "main"},
+	}
+
+	tests := []struct {
+		name    string
+		actorID int64
+		want    []int64
+	}{
+		{name: "main", actorID: 4, want: []int64{1, 3}},
+		{name: "release/1.0", actorID: 0, want: []int64{2}},
+		{name: "feature", actorID: 3, want: nil},
+	}
+
+	for _, test := range tests {
+		if got := matchingPrincipals(watches, test.actorID, test.name); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("matchingPrincipals(%q, %d) = %v, want %v", test.name, test.actorID, got, test.want)
+		}
+	}
+}
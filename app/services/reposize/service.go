@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+)
+
+const (
+	eventsReaderGroupName = "gitness:reposize"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+
+	return nil
+}
+
+// Service keeps the storage size of repositories up to date by recomputing it whenever references are changed.
+type Service struct {
+	repoStore    store.RepoStore
+	gitRPCClient gitrpc.Interface
+}
+
+func New(
+	ctx context.Context,
+	config Config,
+	repoStore store.RepoStore,
+	gitRPCClient gitrpc.Interface,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided repo size service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		repoStore:    repoStore,
+		gitRPCClient: gitRPCClient,
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(handleEvent(service, func(p *gitevents.BranchCreatedPayload) int64 {
+				return p.RepoID
+			}))
+			_ = r.RegisterBranchUpdated(handleEvent(service, func(p *gitevents.BranchUpdatedPayload) int64 {
+				return p.RepoID
+			}))
+			_ = r.RegisterBranchDeleted(handleEvent(service, func(p *gitevents.BranchDeletedPayload) int64 {
+				return p.RepoID
+			}))
+			_ = r.RegisterTagCreated(handleEvent(service, func(p *gitevents.TagCreatedPayload) int64 {
+				return p.RepoID
+			}))
+			_ = r.RegisterTagUpdated(handleEvent(service, func(p *gitevents.TagUpdatedPayload) int64 {
+				return p.RepoID
+			}))
+			_ = r.RegisterTagDeleted(handleEvent(service, func(p *gitevents.TagDeletedPayload) int64 {
+				return p.RepoID
+			}))
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// handleEvent returns an event handler that updates the size of the repository the event belongs to.
+func handleEvent[T any](s *Service, repoID func(T) int64) events.HandlerFunc[T] {
+	return func(ctx context.Context, event *events.Event[T]) error {
+		return s.UpdateSize(ctx, repoID(event.Payload))
+	}
+}
+
+// UpdateSize recomputes the storage size of the repository and stores it.
+func (s *Service) UpdateSize(ctx context.Context, repoID int64) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the repo got deleted in the meantime, nothing to update.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo %d: %w", repoID, err)
+	}
+
+	out, err := s.gitRPCClient.GetRepositorySize(ctx, &gitrpc.GetRepositorySizeParams{
+		ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get size of repo %d: %w", repoID, err)
+	}
+
+	if err = s.repoStore.UpdateSize(ctx, repo.ID, out.Size); err != nil {
+		return fmt.Errorf("failed to update size of repo %d: %w", repoID, err)
+	}
+
+	return nil
+}
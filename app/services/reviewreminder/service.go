@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewreminder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReminders        = "gitness:reviewreminder:reminders"
+	jobCronReminders        = "15 * * * *" // At minute 15 of every hour.
+	jobMaxDurationReminders = 30 * time.Minute
+
+	// minSLA is the granularity of the review SLA, reviewers requested more recently are never overdue.
+	minSLA = time.Hour
+)
+
+// Service periodically reminds requested reviewers that didn't review within the review SLA
+// configured for the repository (or its spaces). The reminders are reported as pull request events,
+// which are delivered as notifications and chat integration messages.
+type Service struct {
+	reviewerStore store.PullReqReviewerStore
+	pullreqStore  store.PullReqStore
+	repoStore     store.RepoStore
+	settings      *settings.Service
+	eventReporter *pullreqevents.Reporter
+	scheduler     *job.Scheduler
+	executor      *job.Executor
+}
+
+func NewService(
+	reviewerStore store.PullReqReviewerStore,
+	pullreqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	settings *settings.Service,
+	eventReporter *pullreqevents.Reporter,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return &Service{
+		reviewerStore: reviewerStore,
+		pullreqStore:  pullreqStore,
+		repoStore:     repoStore,
+		settings:      settings,
+		eventReporter: eventReporter,
+		scheduler:     scheduler,
+		executor:      executor,
+	}
+}
+
+// Register registers and schedules the job sending the review reminders.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.executor.Register(jobTypeReminders, s)
+	if err != nil {
+		return fmt.Errorf("failed to register review reminder job handler: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(ctx, jobTypeReminders, jobTypeReminders, jobCronReminders, jobMaxDurationReminders)
+	if err != nil {
+		return fmt.Errorf("failed to schedule review reminder job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle reminds all reviewers with overdue reviews.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	reviewers, err := s.reviewerStore.ListPendingReminders(ctx, now.Add(-minSLA).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list pending reviewers: %w", err)
+	}
+
+	// reviewers are ordered by repository - the policy is resolved once per repository.
+	policies := make(map[int64]types.ReviewReminderPolicy)
+
+	reminded := 0
+	for _, reviewer := range reviewers {
+		policy, ok := policies[reviewer.RepoID]
+		if !ok {
+			policy, err = s.repoPolicy(ctx, reviewer.RepoID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", reviewer.RepoID).
+					Msg("failed to resolve review reminder policy")
+			}
+			policies[reviewer.RepoID] = policy
+		}
+
+		if !isOverdue(reviewer, policy, now) {
+			continue
+		}
+
+		if err = s.remind(ctx, reviewer, now); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_id", reviewer.PullReqID).
+				Int64("principal_id", reviewer.PrincipalID).
+				Msg("failed to remind reviewer")
+			continue
+		}
+		reminded++
+	}
+
+	result := "no overdue reviews found"
+	if reminded > 0 {
+		result = fmt.Sprintf("reminded %d reviewers", reminded)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) repoPolicy(ctx context.Context, repoID int64) (types.ReviewReminderPolicy, error) {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return types.ReviewReminderPolicy{}, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	return s.settings.RepoReviewReminderPolicy(ctx, repo)
+}
+
+func (s *Service) remind(ctx context.Context, reviewer *types.PullReqReviewer, now time.Time) error {
+	pr, err := s.pullreqStore.Find(ctx, reviewer.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	// the reminder time is updated first, a failure to report the event skips a single reminder
+	// while the other order could remind the reviewer every time the job runs.
+	err = s.reviewerStore.UpdateReminded(ctx, reviewer.PullReqID, reviewer.PrincipalID, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to update reminder time: %w", err)
+	}
+
+	s.eventReporter.ReviewReminder(ctx, &pullreqevents.ReviewReminderPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  reviewer.CreatedBy,
+			Number:       pr.Number,
+		},
+		ReviewerID: reviewer.PrincipalID,
+		Requested:  reviewer.Created,
+	})
+
+	return nil
+}
+
+// isOverdue returns true if the review is pending for longer than the SLA,
+// counted from the review request or the last reminder.
+func isOverdue(reviewer *types.PullReqReviewer, policy types.ReviewReminderPolicy, now time.Time) bool {
+	if policy.SLAHours <= 0 {
+		return false
+	}
+
+	since := reviewer.Created
+	if reviewer.Reminded > since {
+		since = reviewer.Reminded
+	}
+
+	return now.Sub(time.UnixMilli(since)) >= time.Duration(policy.SLAHours)*time.Hour
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewreminder
+
+import (
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	reviewerStore store.PullReqReviewerStore,
+	pullreqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	settings *settings.Service,
+	eventReporter *pullreqevents.Reporter,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) *Service {
+	return NewService(reviewerStore, pullreqStore, repoStore, settings, eventReporter, scheduler, executor)
+}
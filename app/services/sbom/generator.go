@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Input is provided to the generator as JSON on stdin.
+type Input struct {
+	RepoID    int64           `json:"repo_id"`
+	RepoPath  string          `json:"repo_path"`
+	Tag       string          `json:"tag"`
+	CommitSHA string          `json:"commit_sha"`
+	Format    enum.SBOMFormat `json:"format"`
+	// Dir is the directory containing the files of the tag, they are stored using their path in the repository.
+	Dir   string   `json:"dir"`
+	Files []string `json:"files"`
+}
+
+// generate executes the generator command, which has to write the JSON encoded SBOM document
+// in the requested format to stdout. A non-zero exit code fails the generation.
+func (s *Service) generate(ctx context.Context, in *Input) ([]byte, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal generator input: %w", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	//nolint:gosec // the command is defined by the operator.
+	cmd := exec.CommandContext(ctx, s.config.Command[0], s.config.Command[1:]...)
+	cmd.Dir = in.Dir
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to execute sbom generator: %w (stderr: %s)",
+			err, strings.TrimSpace(stderr.String()))
+	}
+
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("sbom generator returned an empty %s document", in.Format)
+	}
+
+	if stdout.Len() > s.config.MaxSize {
+		return nil, fmt.Errorf("generated %s document exceeds the max size of %d bytes", in.Format, s.config.MaxSize)
+	}
+
+	return stdout.Bytes(), nil
+}
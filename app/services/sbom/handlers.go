@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+)
+
+const gitReferenceNamePrefixTag = "refs/tags/"
+
+// handleEventTagCreated schedules the generation of the SBOMs of a newly created tag.
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.schedule(ctx, event.Payload.RepoID,
+		strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixTag), event.Payload.SHA)
+}
+
+// handleEventTagUpdated schedules the regeneration of the SBOMs of a tag that was moved to another commit.
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.schedule(ctx, event.Payload.RepoID,
+		strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixTag), event.Payload.NewSHA)
+}
+
+// handleEventTagDeleted deletes the SBOMs of a deleted tag.
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.deleteTag(ctx, event.Payload.RepoID, strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixTag))
+}
@@ -0,0 +1,365 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/gitrpc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:sbom"
+
+	jobType = "gitness:sbom:generate"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	Command         []string
+	Formats         []enum.SBOMFormat
+	Paths           []string
+	Timeout         time.Duration
+	MaxFileSize     int
+	MaxSize         int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if len(c.Command) == 0 {
+		// sbom generation is disabled, nothing else to validate.
+		return nil
+	}
+	if len(c.Formats) == 0 {
+		return errors.New("config.Formats requires at least one format")
+	}
+	for _, f := range c.Formats {
+		if _, ok := f.Sanitize(); !ok {
+			return fmt.Errorf("config.Formats contains unknown format '%s'", f)
+		}
+	}
+	if len(c.Paths) == 0 {
+		return errors.New("config.Paths requires at least one path")
+	}
+	if c.Timeout < time.Second {
+		return errors.New("config.Timeout has to be at least a second")
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+	if c.MaxSize < 1 {
+		return errors.New("config.MaxSize has to be a positive number")
+	}
+
+	return nil
+}
+
+// Service generates software bills of materials (SBOMs) for pushed tags using an external generator
+// and attaches them to the tag. The generation runs as a background job.
+type Service struct {
+	config       Config
+	repoStore    store.RepoStore
+	sbomStore    store.SBOMStore
+	blobStore    blob.Store
+	gitRPCClient gitrpc.Interface
+	scheduler    *job.Scheduler
+}
+
+var _ job.Handler = (*Service)(nil)
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	repoStore store.RepoStore,
+	sbomStore store.SBOMStore,
+	blobStore blob.Store,
+	gitRPCClient gitrpc.Interface,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided sbom service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:       config,
+		repoStore:    repoStore,
+		sbomStore:    sbomStore,
+		blobStore:    blobStore,
+		gitRPCClient: gitRPCClient,
+		scheduler:    scheduler,
+	}
+
+	// nothing to do without a generator
+	if len(config.Command) == 0 {
+		return service, nil
+	}
+
+	if err := executor.Register(jobType, service); err != nil {
+		return nil, fmt.Errorf("failed to register sbom generation job handler: %w", err)
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// BlobPath returns the path of the SBOM document in the blob store.
+// The tag name is hashed as it can contain characters not supported by all blob store providers.
+func BlobPath(sbom *types.SBOM) string {
+	return blobPath(sbom.RepoID, sbom.Tag, sbom.Format)
+}
+
+func blobPath(repoID int64, tag string, format enum.SBOMFormat) string {
+	tagHash := sha256.Sum256([]byte(tag))
+	return fmt.Sprintf("sboms/%d/%s/%s", repoID, hex.EncodeToString(tagHash[:]), format)
+}
+
+// jobInput is the data of an sbom generation job.
+type jobInput struct {
+	RepoID    int64  `json:"repo_id"`
+	Tag       string `json:"tag"`
+	CommitSHA string `json:"commit_sha"`
+}
+
+// schedule starts a background job generating the SBOMs of the tag.
+func (s *Service) schedule(ctx context.Context, repoID int64, tag string, sha string) error {
+	data, err := json.Marshal(jobInput{
+		RepoID:    repoID,
+		Tag:       tag,
+		CommitSHA: sha,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal sbom job input: %w", err)
+	}
+
+	// the job uid is derived from the input, so a redelivered event doesn't generate the SBOMs twice.
+	uid := sha256.Sum256(data)
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        "sbom-" + hex.EncodeToString(uid[:]),
+		Type:       jobType,
+		MaxRetries: s.config.MaxRetries,
+		Timeout:    s.config.Timeout * time.Duration(len(s.config.Formats)),
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule sbom generation job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle generates the SBOMs of a tag in all configured formats.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var in jobInput
+	if err := json.Unmarshal([]byte(data), &in); err != nil {
+		return "", fmt.Errorf("failed to unmarshal sbom job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, in.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "repository was deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "gitness-sbom-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create sbom directory: %w", err)
+	}
+	defer func() {
+		if errRemove := os.RemoveAll(dir); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msgf("failed to remove sbom directory '%s'", dir)
+		}
+	}()
+
+	files, err := s.writeFiles(ctx, repo, in.CommitSHA, dir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, format := range s.config.Formats {
+		if err = s.generateFormat(ctx, repo, &in, format, dir, files); err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("generated %d sboms for tag '%s'", len(s.config.Formats), in.Tag), nil
+}
+
+func (s *Service) generateFormat(
+	ctx context.Context,
+	repo *types.Repository,
+	in *jobInput,
+	format enum.SBOMFormat,
+	dir string,
+	files []string,
+) error {
+	genCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	doc, err := s.generate(genCtx, &Input{
+		RepoID:    repo.ID,
+		RepoPath:  repo.Path,
+		Tag:       in.Tag,
+		CommitSHA: in.CommitSHA,
+		Format:    format,
+		Dir:       dir,
+		Files:     files,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the document is uploaded first, so the metadata never references a missing document.
+	if err = s.blobStore.Upload(ctx, bytes.NewReader(doc), blobPath(repo.ID, in.Tag, format)); err != nil {
+		return fmt.Errorf("failed to upload %s sbom: %w", format, err)
+	}
+
+	err = s.sbomStore.Upsert(ctx, &types.SBOM{
+		RepoID:    repo.ID,
+		Tag:       in.Tag,
+		CommitSHA: in.CommitSHA,
+		Format:    format,
+		Size:      int64(len(doc)),
+		Created:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store %s sbom: %w", format, err)
+	}
+
+	return nil
+}
+
+// deleteTag deletes all SBOMs of the tag.
+func (s *Service) deleteTag(ctx context.Context, repoID int64, tag string) error {
+	sboms, err := s.sbomStore.List(ctx, repoID, tag)
+	if err != nil {
+		return fmt.Errorf("failed to list sboms of tag: %w", err)
+	}
+
+	for _, sbom := range sboms {
+		if err = s.blobStore.Delete(ctx, BlobPath(sbom)); err != nil {
+			return fmt.Errorf("failed to delete %s sbom document: %w", sbom.Format, err)
+		}
+
+		if err = s.sbomStore.Delete(ctx, sbom.ID); err != nil {
+			return fmt.Errorf("failed to delete %s sbom: %w", sbom.Format, err)
+		}
+	}
+
+	return nil
+}
+
+// writeFiles writes the files of the commit matching the configured paths into dir.
+// It returns the paths of the written files.
+func (s *Service) writeFiles(ctx context.Context, repo *types.Repository, sha string, dir string) ([]string, error) {
+	var files []string
+	for _, p := range s.config.Paths {
+		dirPath, pattern := path.Split(strings.Trim(p, "/"))
+
+		resp, err := s.gitRPCClient.MatchFiles(ctx, &gitrpc.MatchFilesParams{
+			ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+			Ref:        sha,
+			DirPath:    strings.TrimSuffix(dirPath, "/"),
+			Pattern:    pattern,
+			MaxSize:    s.config.MaxFileSize,
+		})
+		if gitrpc.ErrorStatus(err) == gitrpc.StatusPathNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read files matching '%s': %w", p, err)
+		}
+
+		for _, file := range resp.Files {
+			dst := filepath.Join(dir, filepath.FromSlash(file.Path))
+			if !strings.HasPrefix(dst, filepath.Clean(dir)+string(filepath.Separator)) {
+				return nil, fmt.Errorf("file path '%s' is outside of the sbom directory", file.Path)
+			}
+
+			if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+				return nil, fmt.Errorf("failed to create directory for '%s': %w", file.Path, err)
+			}
+
+			if err = os.WriteFile(dst, file.Content, 0o600); err != nil {
+				return nil, fmt.Errorf("failed to write file '%s': %w", file.Path, err)
+			}
+
+			files = append(files, file.Path)
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+)
+
+const gitReferenceNamePrefixBranch = "refs/heads/"
+
+// handleEventBranchCreated scans a newly created branch if it's the default branch of the repository.
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.handleBranchEvent(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.SHA)
+}
+
+// handleEventBranchUpdated scans an updated branch if it's the default branch of the repository.
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.handleBranchEvent(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.NewSHA)
+}
+
+// handleEventPullReqCreated scans the source branch of a newly created pull request.
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, event.Payload.SourceSHA)
+}
+
+// handleEventPullReqBranchUpdated scans the new head of the source branch of a pull request.
+func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, event.Payload.NewSHA)
+}
+
+// handleEventPullReqReopened scans the source branch of a reopened pull request.
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.handlePullReqEvent(ctx, &event.Payload.Base, event.Payload.SourceSHA)
+}
+
+func (s *Service) handleBranchEvent(ctx context.Context, repoID int64, ref string, sha string) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if strings.TrimPrefix(ref, gitReferenceNamePrefixBranch) != repo.DefaultBranch {
+		return nil
+	}
+
+	return s.scan(ctx, repo, repo, sha)
+}
+
+func (s *Service) handlePullReqEvent(ctx context.Context, base *pullreqevents.Base, sha string) error {
+	targetRepo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repo: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if base.SourceRepoID != base.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, base.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find source repo: %w", err)
+		}
+	}
+
+	// status checks of pull requests are reported for the head commit in the target repository.
+	return s.scan(ctx, targetRepo, sourceRepo, sha)
+}
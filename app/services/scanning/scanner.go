@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Definition defines an external scanner executable.
+type Definition struct {
+	Name string        `json:"name"`
+	Kind enum.ScanKind `json:"kind"`
+	// Command is the executable and its arguments. It gets the input as JSON on stdin
+	// and has to write the output as JSON to stdout. A non-zero exit code fails the scan.
+	Command []string `json:"command"`
+	// Paths are the files of the repository provided to the scanner, e.g. "go.sum" or "deploy/Dockerfile".
+	// The last element of a path can be a glob pattern, e.g. "requirements*.txt".
+	Paths []string `json:"paths"`
+	// TimeoutSeconds is the max duration of a single scan, 0 uses the system default.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// Input is provided to the scanners.
+type Input struct {
+	RepoID    int64         `json:"repo_id"`
+	RepoPath  string        `json:"repo_path"`
+	CommitSHA string        `json:"commit_sha"`
+	Kind      enum.ScanKind `json:"kind"`
+	// Dir is the directory containing the files to scan, they are stored using their path in the repository.
+	Dir   string   `json:"dir"`
+	Files []string `json:"files"`
+}
+
+// Output is returned by the scanners.
+type Output struct {
+	Findings []OutputFinding `json:"findings"`
+}
+
+// OutputFinding is a single vulnerability reported by a scanner.
+type OutputFinding struct {
+	VulnerabilityID string            `json:"vulnerability_id"`
+	Package         string            `json:"package"`
+	Version         string            `json:"version"`
+	FixedVersion    string            `json:"fixed_version"`
+	Severity        enum.ScanSeverity `json:"severity"`
+	Title           string            `json:"title"`
+	Path            string            `json:"path"`
+	URL             string            `json:"url"`
+}
+
+// run executes the scanner command.
+func (d *Definition) run(ctx context.Context, in *Input) (*Output, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scanner input: %w", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	//nolint:gosec // the command is defined by the operator.
+	cmd := exec.CommandContext(ctx, d.Command[0], d.Command[1:]...)
+	cmd.Dir = in.Dir
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to execute scanner '%s': %w (stderr: %s)",
+			d.Name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	out := &Output{}
+	if stdout.Len() > 0 {
+		if err = json.Unmarshal(stdout.Bytes(), out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal output of scanner '%s': %w", d.Name, err)
+		}
+	}
+
+	return out, nil
+}
+
+func (d *Definition) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("scanner name is required")
+	}
+	if _, ok := d.Kind.Sanitize(); !ok {
+		return fmt.Errorf("scanner '%s' has unknown kind '%s'", d.Name, d.Kind)
+	}
+	if len(d.Command) == 0 {
+		return fmt.Errorf("scanner '%s' has no command", d.Name)
+	}
+	if len(d.Paths) == 0 {
+		return fmt.Errorf("scanner '%s' has no paths", d.Name)
+	}
+	if d.TimeoutSeconds < 0 {
+		return fmt.Errorf("scanner '%s' has a negative timeout", d.Name)
+	}
+
+	return nil
+}
+
+// LoadDefinitions loads the scanner definitions from a JSON file.
+func LoadDefinitions(path string) ([]Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scanner definitions: %w", err)
+	}
+
+	var defs []Definition
+	if err = json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse scanner definitions: %w", err)
+	}
+
+	return defs, nil
+}
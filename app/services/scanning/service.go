@@ -0,0 +1,390 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:scanning"
+
+	// checkUIDPrefix is the prefix of the UIDs of the status checks reported for scans.
+	checkUIDPrefix = "scan-"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	DefaultTimeout  time.Duration
+	FailSeverity    enum.ScanSeverity
+	MaxFileSize     int
+	Scanners        []Definition
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.DefaultTimeout <= 0 {
+		return errors.New("config.DefaultTimeout has to be a positive duration")
+	}
+	if _, ok := c.FailSeverity.Sanitize(); !ok {
+		return fmt.Errorf("config.FailSeverity '%s' is unknown", c.FailSeverity)
+	}
+	if c.MaxFileSize < 1 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+
+	seen := make(map[string]struct{}, len(c.Scanners))
+	for i := range c.Scanners {
+		if err := c.Scanners[i].validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[c.Scanners[i].Name]; ok {
+			return fmt.Errorf("scanner '%s' is defined more than once", c.Scanners[i].Name)
+		}
+		seen[c.Scanners[i].Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// Service runs the configured dependency and container scanners as status checks
+// for pull requests and pushes to the default branch and stores their findings.
+type Service struct {
+	config       Config
+	tx           dbtx.Transactor
+	repoStore    store.RepoStore
+	checkStore   store.CheckStore
+	findingStore store.ScanFindingStore
+	gitRPCClient gitrpc.Interface
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	checkStore store.CheckStore,
+	findingStore store.ScanFindingStore,
+	gitRPCClient gitrpc.Interface,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided scanning service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:       config,
+		tx:           tx,
+		repoStore:    repoStore,
+		checkStore:   checkStore,
+		findingStore: findingStore,
+		gitRPCClient: gitRPCClient,
+	}
+
+	// nothing to do without scanners
+	if len(config.Scanners) == 0 {
+		return service, nil
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 15 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 15 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pr events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// scan runs all scanners for a commit. The files are read from gitRepo, while status checks and findings
+// are stored for repo. Both differ only for pull requests from forks.
+func (s *Service) scan(ctx context.Context, repo *types.Repository, gitRepo *types.Repository, sha string) error {
+	for i := range s.config.Scanners {
+		scanner := &s.config.Scanners[i]
+
+		if err := s.runScanner(ctx, scanner, repo, gitRepo, sha); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("scanner", scanner.Name).
+				Str("commit_sha", sha).
+				Msg("scan failed")
+
+			if errCheck := s.reportCheck(ctx, repo, scanner, sha, enum.CheckStatusError,
+				"The scan failed."); errCheck != nil {
+				return errCheck
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) runScanner(
+	ctx context.Context,
+	scanner *Definition,
+	repo *types.Repository,
+	gitRepo *types.Repository,
+	sha string,
+) error {
+	err := s.reportCheck(ctx, repo, scanner, sha, enum.CheckStatusRunning, "The scan is in progress.")
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "gitness-scan-*")
+	if err != nil {
+		return fmt.Errorf("failed to create scan directory: %w", err)
+	}
+	defer func() {
+		if errRemove := os.RemoveAll(dir); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msgf("failed to remove scan directory '%s'", dir)
+		}
+	}()
+
+	files, err := s.writeFiles(ctx, gitRepo, sha, scanner.Paths, dir)
+	if err != nil {
+		return err
+	}
+
+	var out *Output
+	if len(files) > 0 {
+		timeout := s.config.DefaultTimeout
+		if scanner.TimeoutSeconds > 0 {
+			timeout = time.Duration(scanner.TimeoutSeconds) * time.Second
+		}
+
+		scanCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		out, err = scanner.run(scanCtx, &Input{
+			RepoID:    repo.ID,
+			RepoPath:  repo.Path,
+			CommitSHA: sha,
+			Kind:      scanner.Kind,
+			Dir:       dir,
+			Files:     files,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		out = &Output{}
+	}
+
+	now := time.Now().UnixMilli()
+	findings := make([]*types.ScanFinding, len(out.Findings))
+	for i, f := range out.Findings {
+		severity, ok := f.Severity.Sanitize()
+		if !ok {
+			severity = enum.ScanSeverityUnknown
+		}
+
+		findings[i] = &types.ScanFinding{
+			Kind:            scanner.Kind,
+			VulnerabilityID: f.VulnerabilityID,
+			Package:         f.Package,
+			Version:         f.Version,
+			FixedVersion:    f.FixedVersion,
+			Severity:        severity,
+			Title:           f.Title,
+			Path:            f.Path,
+			URL:             f.URL,
+			Created:         now,
+		}
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		return s.findingStore.Replace(ctx, repo.ID, sha, scanner.Name, findings)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store scan findings: %w", err)
+	}
+
+	// suppressed findings don't fail the check
+	failing, err := s.findingStore.Count(ctx, repo.ID, &types.ScanFindingFilter{
+		CommitSHA:   sha,
+		Scanner:     scanner.Name,
+		MinSeverity: s.config.FailSeverity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count failing scan findings: %w", err)
+	}
+
+	status := enum.CheckStatusSuccess
+	if failing > 0 {
+		status = enum.CheckStatusFailure
+	}
+
+	summary := fmt.Sprintf("%d findings, %d unsuppressed with severity %s or higher.",
+		len(findings), failing, s.config.FailSeverity)
+	if len(files) == 0 {
+		summary = "No files to scan."
+	}
+
+	return s.reportCheck(ctx, repo, scanner, sha, status, summary)
+}
+
+// writeFiles writes the files of the commit matching the paths of a scanner into dir.
+// It returns the paths of the written files.
+func (s *Service) writeFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	sha string,
+	paths []string,
+	dir string,
+) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		dirPath, pattern := path.Split(strings.Trim(p, "/"))
+
+		resp, err := s.gitRPCClient.MatchFiles(ctx, &gitrpc.MatchFilesParams{
+			ReadParams: gitrpc.ReadParams{RepoUID: repo.GitUID},
+			Ref:        sha,
+			DirPath:    strings.TrimSuffix(dirPath, "/"),
+			Pattern:    pattern,
+			MaxSize:    s.config.MaxFileSize,
+		})
+		if gitrpc.ErrorStatus(err) == gitrpc.StatusPathNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read files matching '%s': %w", p, err)
+		}
+
+		for _, file := range resp.Files {
+			dst := filepath.Join(dir, filepath.FromSlash(file.Path))
+			if !strings.HasPrefix(dst, filepath.Clean(dir)+string(filepath.Separator)) {
+				return nil, fmt.Errorf("file path '%s' is outside of the scan directory", file.Path)
+			}
+
+			if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+				return nil, fmt.Errorf("failed to create directory for '%s': %w", file.Path, err)
+			}
+
+			if err = os.WriteFile(dst, file.Content, 0o600); err != nil {
+				return nil, fmt.Errorf("failed to write file '%s': %w", file.Path, err)
+			}
+
+			files = append(files, file.Path)
+		}
+	}
+
+	return files, nil
+}
+
+// reportCheck creates or updates the status check of a scanner for a commit.
+func (s *Service) reportCheck(
+	ctx context.Context,
+	repo *types.Repository,
+	scanner *Definition,
+	sha string,
+	status enum.CheckStatus,
+	summary string,
+) error {
+	data, err := json.Marshal(types.CheckPayloadText{
+		Details: fmt.Sprintf("The %s scan '%s' reported its findings for this commit.", scanner.Kind, scanner.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = s.checkStore.Upsert(ctx, &types.Check{
+		RepoID:    repo.ID,
+		CommitSHA: sha,
+		UID:       checkUIDPrefix + scanner.Name,
+		Status:    status,
+		Summary:   summary,
+		Created:   now,
+		Updated:   now,
+		CreatedBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Metadata:  []byte("{}"),
+		Payload: types.CheckPayload{
+			Kind: enum.CheckPayloadKindRaw,
+			Data: data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to report scan check: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Service manages the settings of spaces and repositories.
+//
+// A setting defined on a space applies to all its descendant spaces and repositories, unless they define their own
+// value. A locked setting can't be overridden by any descendant, and the value of the most distant space locking
+// the setting takes precedence.
+type Service struct {
+	defaultBranch     string
+	attachmentMaxSize int64
+	tx                dbtx.Transactor
+	settingStore      store.SettingStore
+	spaceStore        store.SpaceStore
+	webhookCtrl       *webhook.Controller
+}
+
+func NewService(
+	defaultBranch string,
+	attachmentMaxSize int64,
+	tx dbtx.Transactor,
+	settingStore store.SettingStore,
+	spaceStore store.SpaceStore,
+	webhookCtrl *webhook.Controller,
+) *Service {
+	return &Service{
+		defaultBranch:     defaultBranch,
+		attachmentMaxSize: attachmentMaxSize,
+		tx:                tx,
+		settingStore:      settingStore,
+		spaceStore:        spaceStore,
+		webhookCtrl:       webhookCtrl,
+	}
+}
+
+// SpaceSettings returns the effective value of all settings of the space.
+func (s *Service) SpaceSettings(ctx context.Context, spaceID int64) ([]*types.EffectiveSetting, error) {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	return s.resolveAll(settings, spaceID)
+}
+
+// RepoSettings returns the effective value of all settings of the repository.
+func (s *Service) RepoSettings(ctx context.Context, repo *types.Repository) ([]*types.EffectiveSetting, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resolveAll(settings, 0)
+}
+
+// UpdateSpace sets the value of a setting of the space.
+// With override all values defined by descendant spaces and repositories are removed.
+// If ifMatch is provided, the update fails unless it matches the ETag of the current effective value.
+func (s *Service) UpdateSpace(
+	ctx context.Context,
+	principalID int64,
+	spaceID int64,
+	key enum.SettingKey,
+	value json.RawMessage,
+	locked bool,
+	override bool,
+	ifMatch string,
+) error {
+	value, err := s.sanitize(ctx, key, value)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkNotLocked(ctx, spaceID, key, true); err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	setting := &types.Setting{
+		SpaceID:   &spaceID,
+		Key:       key,
+		Value:     value,
+		Locked:    locked,
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if ifMatch != "" {
+			settings, err := s.settingStore.ListInherited(ctx, spaceID)
+			if err != nil {
+				return fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+			}
+
+			if err = s.checkETag(settings, spaceID, key, ifMatch); err != nil {
+				return err
+			}
+		}
+
+		if err := s.settingStore.Upsert(ctx, setting); err != nil {
+			return fmt.Errorf("failed to update setting: %w", err)
+		}
+
+		if !override {
+			return nil
+		}
+
+		if err := s.settingStore.DeleteDescendants(ctx, spaceID, key); err != nil {
+			return fmt.Errorf("failed to delete settings of descendants: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteSpace removes the value of a setting of the space, the inherited value applies afterwards.
+func (s *Service) DeleteSpace(ctx context.Context, spaceID int64, key enum.SettingKey) error {
+	if err := s.settingStore.DeleteSpace(ctx, spaceID, key); err != nil {
+		return fmt.Errorf("failed to delete setting: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRepo sets the value of a setting of the repository.
+// If ifMatch is provided, the update fails unless it matches the ETag of the current effective value.
+func (s *Service) UpdateRepo(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	key enum.SettingKey,
+	value json.RawMessage,
+	ifMatch string,
+) error {
+	value, err := s.sanitize(ctx, key, value)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkNotLocked(ctx, repo.ParentID, key, false); err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	setting := &types.Setting{
+		RepoID:    &repo.ID,
+		Key:       key,
+		Value:     value,
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if ifMatch != "" {
+			settings, err := s.listRepoSettings(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			if err = s.checkETag(settings, 0, key, ifMatch); err != nil {
+				return err
+			}
+		}
+
+		if err := s.settingStore.Upsert(ctx, setting); err != nil {
+			return fmt.Errorf("failed to update setting: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteRepo removes the value of a setting of the repository, the inherited value applies afterwards.
+func (s *Service) DeleteRepo(ctx context.Context, repo *types.Repository, key enum.SettingKey) error {
+	if err := s.settingStore.DeleteRepo(ctx, repo.ID, key); err != nil {
+		return fmt.Errorf("failed to delete setting: %w", err)
+	}
+
+	return nil
+}
+
+// checkNotLocked returns an error in case the setting is locked by the space or any of its ancestors.
+// With excludeSpace the lock of the space itself is ignored.
+func (s *Service) checkNotLocked(ctx context.Context, spaceID int64, key enum.SettingKey, excludeSpace bool) error {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	for _, setting := range settings {
+		if setting.Key != key || !setting.Locked || (excludeSpace && *setting.SpaceID == spaceID) {
+			continue
+		}
+
+		space, err := s.spaceStore.Find(ctx, *setting.SpaceID)
+		if err != nil {
+			return fmt.Errorf("failed to find space %d: %w", *setting.SpaceID, err)
+		}
+
+		return usererror.Forbidden(fmt.Sprintf("The setting '%s' is locked by space '%s'.", key, space.Path))
+	}
+
+	return nil
+}
+
+// checkETag returns an error in case the ETag of the effective value of the setting doesn't match ifMatch.
+func (s *Service) checkETag(
+	settings []*types.Setting,
+	ownerSpaceID int64,
+	key enum.SettingKey,
+	ifMatch string,
+) error {
+	current, err := s.resolve(settings, ownerSpaceID, key)
+	if err != nil {
+		return err
+	}
+
+	if current.ETag != ifMatch {
+		return usererror.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+// listRepoSettings returns the settings of all ancestor spaces followed by the settings of the repository.
+func (s *Service) listRepoSettings(ctx context.Context, repo *types.Repository) ([]*types.Setting, error) {
+	inherited, err := s.settingStore.ListInherited(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings of space %d: %w", repo.ParentID, err)
+	}
+
+	own, err := s.settingStore.ListRepo(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings of repo %d: %w", repo.ID, err)
+	}
+
+	return append(inherited, own...), nil
+}
+
+// resolve returns the effective value of the setting with the provided key.
+// The settings have to be ordered from the most distant to the closest owner,
+// ownerSpaceID is the space the value is resolved for (0 for repositories).
+func (s *Service) resolve(
+	settings []*types.Setting,
+	ownerSpaceID int64,
+	key enum.SettingKey,
+) (*types.EffectiveSetting, error) {
+	var res *types.EffectiveSetting
+	for _, setting := range settings {
+		if setting.Key != key {
+			continue
+		}
+		if res != nil && res.Locked {
+			break
+		}
+
+		res = &types.EffectiveSetting{
+			Key:    setting.Key,
+			Value:  setting.Value,
+			Locked: setting.Locked,
+		}
+		if setting.SpaceID != nil {
+			res.SpaceID = *setting.SpaceID
+			res.Inherited = *setting.SpaceID != ownerSpaceID
+		}
+	}
+
+	if res != nil {
+		res.ETag = settingETag(res)
+		return res, nil
+	}
+
+	value, err := s.systemDefault(key)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &types.EffectiveSetting{
+		Key:       key,
+		Value:     value,
+		Inherited: true,
+	}
+	res.ETag = settingETag(res)
+
+	return res, nil
+}
+
+// settingETag returns the entity tag of the effective setting, derived from its value and origin.
+func settingETag(setting *types.EffectiveSetting) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d:%t:%t:", setting.SpaceID, setting.Locked, setting.Inherited)
+	_, _ = h.Write(setting.Value)
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (s *Service) resolveAll(settings []*types.Setting, ownerSpaceID int64) ([]*types.EffectiveSetting, error) {
+	keys, _ := enum.GetAllSettingKeys()
+
+	res := make([]*types.EffectiveSetting, len(keys))
+	for i, key := range keys {
+		var err error
+		res[i], err = s.resolve(settings, ownerSpaceID, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	rootSpaceID  = 1
+	childSpaceID = 2
+)
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// fakeSettingStore stores the settings of the child space 2 within the root space 1.
+type fakeSettingStore struct {
+	store.SettingStore
+	settings []*types.Setting
+}
+
+func (s *fakeSettingStore) ListInherited(_ context.Context, spaceID int64) ([]*types.Setting, error) {
+	var result []*types.Setting
+	for id := int64(rootSpaceID); id <= spaceID; id++ {
+		for _, setting := range s.settings {
+			if setting.SpaceID != nil && *setting.SpaceID == id {
+				result = append(result, setting)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeSettingStore) ListRepo(_ context.Context, repoID int64) ([]*types.Setting, error) {
+	var result []*types.Setting
+	for _, setting := range s.settings {
+		if setting.RepoID != nil && *setting.RepoID == repoID {
+			result = append(result, setting)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeSettingStore) Upsert(_ context.Context, setting *types.Setting) error {
+	for i, existing := range s.settings {
+		if existing.Key == setting.Key && sameOwner(existing.SpaceID, setting.SpaceID) &&
+			sameOwner(existing.RepoID, setting.RepoID) {
+			s.settings[i] = setting
+			return nil
+		}
+	}
+	s.settings = append(s.settings, setting)
+	return nil
+}
+
+func (s *fakeSettingStore) DeleteDescendants(_ context.Context, spaceID int64, key enum.SettingKey) error {
+	kept := s.settings[:0]
+	for _, setting := range s.settings {
+		if setting.Key != key || (setting.SpaceID != nil && *setting.SpaceID <= spaceID) {
+			kept = append(kept, setting)
+		}
+	}
+	s.settings = kept
+	return nil
+}
+
+func sameOwner(a, b *int64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	if id == rootSpaceID {
+		return &types.Space{ID: id, Path: "root"}, nil
+	}
+	return &types.Space{ID: id, Path: "root/child"}, nil
+}
+
+func newTestService(settings ...*types.Setting) (*Service, *fakeSettingStore) {
+	settingStore := &fakeSettingStore{settings: settings}
+	return NewService("main", 0, fakeTransactor{}, settingStore, fakeSpaceStore{}, nil), settingStore
+}
+
+func spaceSetting(spaceID int64, value string, locked bool) *types.Setting {
+	return &types.Setting{
+		SpaceID: &spaceID,
+		Key:     enum.SettingKeyDefaultBranch,
+		Value:   json.RawMessage(`"` + value + `"`),
+		Locked:  locked,
+	}
+}
+
+func findSetting(t *testing.T, settings []*types.EffectiveSetting, key enum.SettingKey) *types.EffectiveSetting {
+	t.Helper()
+	for _, setting := range settings {
+		if setting.Key == key {
+			return setting
+		}
+	}
+	t.Fatalf("setting %s not found", key)
+	return nil
+}
+
+func wantUserError(t *testing.T, err error, status int) {
+	t.Helper()
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != status {
+		t.Errorf("want error with status %d, got %v", status, err)
+	}
+}
+
+func TestDefaultBranchInheritance(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings []*types.Setting
+		want     string
+	}{
+		{name: "system default", want: "main"},
+		{name: "inherited", settings: []*types.Setting{spaceSetting(rootSpaceID, "develop", false)}, want: "develop"},
+		{
+			name: "overridden by closer space",
+			settings: []*types.Setting{
+				spaceSetting(rootSpaceID, "develop", false),
+				spaceSetting(childSpaceID, "trunk", false),
+			},
+			want: "trunk",
+		},
+		{
+			name: "locked by distant space",
+			settings: []*types.Setting{
+				spaceSetting(rootSpaceID, "develop", true),
+				spaceSetting(childSpaceID, "trunk", false),
+			},
+			want: "develop",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, _ := newTestService(test.settings...)
+
+			branch, err := s.DefaultBranch(context.Background(), childSpaceID)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if branch != test.want {
+				t.Errorf("want default branch %q, got %q", test.want, branch)
+			}
+		})
+	}
+}
+
+func TestSpaceSettingsOrigin(t *testing.T) {
+	s, _ := newTestService(spaceSetting(rootSpaceID, "develop", true))
+
+	for _, test := range []struct {
+		spaceID       int64
+		wantInherited bool
+	}{
+		{spaceID: rootSpaceID, wantInherited: false},
+		{spaceID: childSpaceID, wantInherited: true},
+	} {
+		settings, err := s.SpaceSettings(context.Background(), test.spaceID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		setting := findSetting(t, settings, enum.SettingKeyDefaultBranch)
+		if setting.SpaceID != rootSpaceID || !setting.Locked || setting.Inherited != test.wantInherited {
+			t.Errorf("space %d: want locked setting of space %d with inherited %t, got space %d, locked %t, "+
+				"inherited %t", test.spaceID, rootSpaceID, test.wantInherited, setting.SpaceID, setting.Locked,
+				setting.Inherited)
+		}
+	}
+}
+
+func TestUpdateLockedSetting(t *testing.T) {
+	ctx := context.Background()
+	s, settingStore := newTestService(spaceSetting(rootSpaceID, "develop", true))
+	value := json.RawMessage(`"trunk"`)
+
+	err := s.UpdateSpace(ctx, 1, childSpaceID, enum.SettingKeyDefaultBranch, value, false, false, "")
+	wantUserError(t, err, http.StatusForbidden)
+
+	repo := &types.Repository{ID: 1, ParentID: childSpaceID}
+	err = s.UpdateRepo(ctx, 1, repo, enum.SettingKeyDefaultBranch, value, "")
+	wantUserError(t, err, http.StatusForbidden)
+
+	// the space locking the setting can still change it.
+	err = s.UpdateSpace(ctx, 1, rootSpaceID, enum.SettingKeyDefaultBranch, value, true, false, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(settingStore.settings) != 1 || string(settingStore.settings[0].Value) != `"trunk"` {
+		t.Errorf("want the updated setting of the root space, got %d settings", len(settingStore.settings))
+	}
+}
+
+func TestUpdateSpaceOverride(t *testing.T) {
+	ctx := context.Background()
+	s, settingStore := newTestService(spaceSetting(childSpaceID, "trunk", false))
+	value := json.RawMessage(`"develop"`)
+
+	err := s.UpdateSpace(ctx, 1, rootSpaceID, enum.SettingKeyDefaultBranch, value, false, true, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	branch, err := s.DefaultBranch(ctx, childSpaceID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if branch != "develop" || len(settingStore.settings) != 1 {
+		t.Errorf("want the overriding value only, got %q with %d settings", branch, len(settingStore.settings))
+	}
+}
+
+func TestUpdateRepoIfMatch(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(spaceSetting(rootSpaceID, "develop", false))
+	repo := &types.Repository{ID: 1, ParentID: childSpaceID}
+
+	settings, err := s.RepoSettings(ctx, repo)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	etag := findSetting(t, settings, enum.SettingKeyDefaultBranch).ETag
+
+	err = s.UpdateRepo(ctx, 1, repo, enum.SettingKeyDefaultBranch, json.RawMessage(`"trunk"`), etag)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the value changed, the ETag isn't current anymore.
+	err = s.UpdateRepo(ctx, 1, repo, enum.SettingKeyDefaultBranch, json.RawMessage(`"next"`), etag)
+	wantUserError(t, err, http.StatusPreconditionFailed)
+
+	settings, err = s.RepoSettings(ctx, repo)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if setting := findSetting(t, settings, enum.SettingKeyDefaultBranch); string(setting.Value) != `"trunk"` ||
+		setting.Inherited {
+		t.Errorf("want own value \"trunk\" of the repo, got %s", setting.Value)
+	}
+}
@@ -0,0 +1,843 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultStaleAfterDays is the number of days without commits after which branches are stale by default.
+const defaultStaleAfterDays = 90
+
+// defaultPullReqMaxAgeDays and defaultPullReqMaxIdleDays are the default thresholds for aging pull requests.
+const (
+	defaultPullReqMaxAgeDays  = 14
+	defaultPullReqMaxIdleDays = 7
+)
+
+// maxMergeCheckTimeoutSeconds is the max timeout of external merge check providers.
+const maxMergeCheckTimeoutSeconds = 60
+
+// DefaultBranch returns the default branch for new repositories of the space.
+func (s *Service) DefaultBranch(ctx context.Context, spaceID int64) (string, error) {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	var defaultBranch string
+	if err = s.resolveValue(settings, enum.SettingKeyDefaultBranch, &defaultBranch); err != nil {
+		return "", err
+	}
+
+	return defaultBranch, nil
+}
+
+// PushToCreate returns whether repositories of the space can be created by pushing to them.
+func (s *Service) PushToCreate(ctx context.Context, spaceID int64) (bool, error) {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	var pushToCreate bool
+	if err = s.resolveValue(settings, enum.SettingKeyPushToCreate, &pushToCreate); err != nil {
+		return false, err
+	}
+
+	return pushToCreate, nil
+}
+
+// RepoAttestationKeys returns the public keys trusted to sign attestations of the repository.
+func (s *Service) RepoAttestationKeys(ctx context.Context, repo *types.Repository) ([]types.AttestationKey, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []types.AttestationKey
+	if err = s.resolveValue(settings, enum.SettingKeyAttestationKeys, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RepoGithookPlugins returns the names of the server side git hook plugins enabled for the repository.
+func (s *Service) RepoGithookPlugins(ctx context.Context, repo *types.Repository) ([]string, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var plugins []string
+	if err = s.resolveValue(settings, enum.SettingKeyGithookPlugins, &plugins); err != nil {
+		return nil, err
+	}
+
+	return plugins, nil
+}
+
+// RepoMergeChecks returns the external merge check providers of the repository.
+func (s *Service) RepoMergeChecks(ctx context.Context, repo *types.Repository) ([]types.MergeCheckProvider, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var providers []types.MergeCheckProvider
+	if err = s.resolveValue(settings, enum.SettingKeyMergeChecks, &providers); err != nil {
+		return nil, err
+	}
+
+	return providers, nil
+}
+
+// RepoMergeMethods returns the merge methods allowed for pull requests of the repository.
+func (s *Service) RepoMergeMethods(
+	ctx context.Context,
+	repo *types.Repository,
+) ([]gitrpcenum.MergeMethod, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []gitrpcenum.MergeMethod
+	if err = s.resolveValue(settings, enum.SettingKeyMergeMethods, &methods); err != nil {
+		return nil, err
+	}
+
+	return methods, nil
+}
+
+// RepoDeleteSourceBranch returns whether source branches get deleted after pull requests of the repository are merged.
+func (s *Service) RepoDeleteSourceBranch(ctx context.Context, repo *types.Repository) (bool, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return false, err
+	}
+
+	var deleteSourceBranch bool
+	if err = s.resolveValue(settings, enum.SettingKeyDeleteSourceBranch, &deleteSourceBranch); err != nil {
+		return false, err
+	}
+
+	return deleteSourceBranch, nil
+}
+
+// RepoAttachmentMaxSize returns the max size in bytes of files attached to pull requests of the repository.
+func (s *Service) RepoAttachmentMaxSize(ctx context.Context, repo *types.Repository) (int64, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	var maxSize int64
+	if err = s.resolveValue(settings, enum.SettingKeyAttachmentMaxSize, &maxSize); err != nil {
+		return 0, err
+	}
+
+	return maxSize, nil
+}
+
+// RepoEnforceCommitAuthor returns whether commits created via the API are authored by the acting principal.
+func (s *Service) RepoEnforceCommitAuthor(ctx context.Context, repo *types.Repository) (bool, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return false, err
+	}
+
+	var enforce bool
+	if err = s.resolveValue(settings, enum.SettingKeyEnforceCommitAuthor, &enforce); err != nil {
+		return false, err
+	}
+
+	return enforce, nil
+}
+
+// RepoPathProtections returns the path protections of the repository.
+func (s *Service) RepoPathProtections(ctx context.Context, repo *types.Repository) ([]types.PathProtection, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var protections []types.PathProtection
+	if err = s.resolveValue(settings, enum.SettingKeyPathProtections, &protections); err != nil {
+		return nil, err
+	}
+
+	return protections, nil
+}
+
+// RepoPullReqRules returns the rules pull requests of the repository have to satisfy before they can be merged.
+func (s *Service) RepoPullReqRules(ctx context.Context, repo *types.Repository) (types.PullReqRules, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return types.PullReqRules{}, err
+	}
+
+	var rules types.PullReqRules
+	if err = s.resolveValue(settings, enum.SettingKeyPullReqRules, &rules); err != nil {
+		return types.PullReqRules{}, err
+	}
+
+	return rules, nil
+}
+
+// SpacePullReqSLA returns the thresholds above which open pull requests of the space are aging.
+func (s *Service) SpacePullReqSLA(ctx context.Context, spaceID int64) (types.PullReqSLA, error) {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return types.PullReqSLA{}, fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	var sla types.PullReqSLA
+	if err = s.resolveValue(settings, enum.SettingKeyPullReqSLA, &sla); err != nil {
+		return types.PullReqSLA{}, err
+	}
+
+	return sla, nil
+}
+
+// SpaceRetentionPolicy returns the retention policy overrides of the space.
+func (s *Service) SpaceRetentionPolicy(ctx context.Context, spaceID int64) (types.RetentionPolicy, error) {
+	settings, err := s.settingStore.ListInherited(ctx, spaceID)
+	if err != nil {
+		return types.RetentionPolicy{}, fmt.Errorf("failed to list settings of space %d: %w", spaceID, err)
+	}
+
+	var policy types.RetentionPolicy
+	if err = s.resolveValue(settings, enum.SettingKeyRetention, &policy); err != nil {
+		return types.RetentionPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// RepoRetentionPolicy returns the retention policy overrides of the repository.
+func (s *Service) RepoRetentionPolicy(ctx context.Context, repo *types.Repository) (types.RetentionPolicy, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return types.RetentionPolicy{}, err
+	}
+
+	var policy types.RetentionPolicy
+	if err = s.resolveValue(settings, enum.SettingKeyRetention, &policy); err != nil {
+		return types.RetentionPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// RepoReviewReminderPolicy returns the policy for reminding reviewers of pull requests of the repository.
+func (s *Service) RepoReviewReminderPolicy(
+	ctx context.Context,
+	repo *types.Repository,
+) (types.ReviewReminderPolicy, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return types.ReviewReminderPolicy{}, err
+	}
+
+	var policy types.ReviewReminderPolicy
+	if err = s.resolveValue(settings, enum.SettingKeyReviewReminders, &policy); err != nil {
+		return types.ReviewReminderPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// RepoStaleBranchPolicy returns the policy for stale branches of the repository.
+func (s *Service) RepoStaleBranchPolicy(ctx context.Context, repo *types.Repository) (types.StaleBranchPolicy, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return types.StaleBranchPolicy{}, err
+	}
+
+	var policy types.StaleBranchPolicy
+	if err = s.resolveValue(settings, enum.SettingKeyStaleBranches, &policy); err != nil {
+		return types.StaleBranchPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// RepoTargetBranchDelete returns the action applied to open pull requests of the repository
+// when their target branch is deleted.
+func (s *Service) RepoTargetBranchDelete(
+	ctx context.Context,
+	repo *types.Repository,
+) (enum.PullReqTargetDeleteAction, error) {
+	settings, err := s.listRepoSettings(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	var action enum.PullReqTargetDeleteAction
+	if err = s.resolveValue(settings, enum.SettingKeyTargetBranchDelete, &action); err != nil {
+		return "", err
+	}
+
+	return action, nil
+}
+
+// ApplyRepoDefaults applies the defaults of the parent spaces to a newly created repository.
+// Failures are only logged, as the repository has already been created.
+func (s *Service) ApplyRepoDefaults(ctx context.Context, session *auth.Session, repo *types.Repository) {
+	settings, err := s.settingStore.ListInherited(ctx, repo.ParentID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to list settings of space %d", repo.ParentID)
+		return
+	}
+
+	var templates []types.WebhookTemplate
+	if err = s.resolveValue(settings, enum.SettingKeyWebhookTemplates, &templates); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to resolve webhook templates")
+		return
+	}
+
+	for i := range templates {
+		_, err = s.webhookCtrl.CreateNoAuth(ctx, session, repo, templateToCreateInput(&templates[i]), false)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to create webhook '%s' from template for repo %d",
+				templates[i].DisplayName, repo.ID)
+		}
+	}
+}
+
+func (s *Service) resolveValue(settings []*types.Setting, key enum.SettingKey, dst interface{}) error {
+	setting, err := s.resolve(settings, 0, key)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(setting.Value, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal value of setting '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+// systemDefault returns the value of a setting that isn't defined by any space or repository.
+func (s *Service) systemDefault(key enum.SettingKey) (json.RawMessage, error) {
+	var value interface{}
+	switch key {
+	case enum.SettingKeyAttachmentMaxSize:
+		value = s.attachmentMaxSize
+	case enum.SettingKeyAttestationKeys:
+		value = []types.AttestationKey{}
+	case enum.SettingKeyDefaultBranch:
+		value = s.defaultBranch
+	case enum.SettingKeyDeleteSourceBranch:
+		value = false
+	case enum.SettingKeyEnforceCommitAuthor:
+		value = false
+	case enum.SettingKeyGithookPlugins:
+		value = []string{}
+	case enum.SettingKeyMergeChecks:
+		value = []types.MergeCheckProvider{}
+	case enum.SettingKeyMergeMethods:
+		value = gitrpcenum.MergeMethods
+	case enum.SettingKeyPathProtections:
+		value = []types.PathProtection{}
+	case enum.SettingKeyPullReqRules:
+		value = types.PullReqRules{}
+	case enum.SettingKeyPullReqSLA:
+		value = types.PullReqSLA{
+			MaxAgeDays:  defaultPullReqMaxAgeDays,
+			MaxIdleDays: defaultPullReqMaxIdleDays,
+		}
+	case enum.SettingKeyPushToCreate:
+		value = false
+	case enum.SettingKeyRetention:
+		value = types.RetentionPolicy{}
+	case enum.SettingKeyReviewReminders:
+		value = types.ReviewReminderPolicy{}
+	case enum.SettingKeyStaleBranches:
+		value = types.StaleBranchPolicy{
+			StaleAfterDays:   defaultStaleAfterDays,
+			ExcludedBranches: []string{},
+		}
+	case enum.SettingKeyTargetBranchDelete:
+		value = enum.PullReqTargetDeleteActionRetarget
+	case enum.SettingKeyWebhookTemplates:
+		value = []types.WebhookTemplate{}
+	default:
+		return nil, fmt.Errorf("unknown setting '%s'", key)
+	}
+
+	return json.Marshal(value)
+}
+
+// sanitize validates the value of a setting and returns it in its canonical form.
+func (s *Service) sanitize(ctx context.Context, key enum.SettingKey, value json.RawMessage) (json.RawMessage, error) {
+	var (
+		res interface{}
+		err error
+	)
+
+	switch key {
+	case enum.SettingKeyAttachmentMaxSize:
+		res, err = s.sanitizeAttachmentMaxSize(value)
+	case enum.SettingKeyAttestationKeys:
+		res, err = s.sanitizeAttestationKeys(value)
+	case enum.SettingKeyDefaultBranch:
+		res, err = s.sanitizeDefaultBranch(value)
+	case enum.SettingKeyDeleteSourceBranch:
+		res, err = s.sanitizeDeleteSourceBranch(value)
+	case enum.SettingKeyEnforceCommitAuthor:
+		res, err = s.sanitizeEnforceCommitAuthor(value)
+	case enum.SettingKeyGithookPlugins:
+		res, err = s.sanitizeGithookPlugins(value)
+	case enum.SettingKeyMergeChecks:
+		res, err = s.sanitizeMergeChecks(value)
+	case enum.SettingKeyMergeMethods:
+		res, err = s.sanitizeMergeMethods(value)
+	case enum.SettingKeyPathProtections:
+		res, err = s.sanitizePathProtections(value)
+	case enum.SettingKeyPullReqRules:
+		res, err = s.sanitizePullReqRules(value)
+	case enum.SettingKeyPullReqSLA:
+		res, err = s.sanitizePullReqSLA(value)
+	case enum.SettingKeyPushToCreate:
+		res, err = s.sanitizePushToCreate(value)
+	case enum.SettingKeyRetention:
+		res, err = s.sanitizeRetentionPolicy(value)
+	case enum.SettingKeyReviewReminders:
+		res, err = s.sanitizeReviewReminderPolicy(value)
+	case enum.SettingKeyStaleBranches:
+		res, err = s.sanitizeStaleBranchPolicy(value)
+	case enum.SettingKeyTargetBranchDelete:
+		res, err = s.sanitizeTargetBranchDelete(value)
+	case enum.SettingKeyWebhookTemplates:
+		res, err = s.sanitizeWebhookTemplates(ctx, value)
+	default:
+		return nil, usererror.BadRequestf("Unknown setting '%s'.", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(res)
+}
+
+func (s *Service) sanitizeAttachmentMaxSize(value json.RawMessage) (int64, error) {
+	var maxSize int64
+	if err := decodeValue(enum.SettingKeyAttachmentMaxSize, value, &maxSize); err != nil {
+		return 0, err
+	}
+
+	if maxSize < 1 {
+		return 0, usererror.BadRequest("The max size of attachments has to be a positive number of bytes.")
+	}
+
+	return maxSize, nil
+}
+
+func (s *Service) sanitizeAttestationKeys(value json.RawMessage) ([]types.AttestationKey, error) {
+	var keys []types.AttestationKey
+	if err := decodeValue(enum.SettingKeyAttestationKeys, value, &keys); err != nil {
+		return nil, err
+	}
+
+	if keys == nil {
+		keys = []types.AttestationKey{}
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for i := range keys {
+		keys[i].Name = strings.TrimSpace(keys[i].Name)
+		name := keys[i].Name
+		if name == "" {
+			return nil, usererror.BadRequest("Attestation key names can't be empty.")
+		}
+		if _, ok := seen[name]; ok {
+			return nil, usererror.BadRequestf("Duplicate attestation key '%s'.", name)
+		}
+		seen[name] = struct{}{}
+
+		keys[i].PublicKey = strings.TrimSpace(keys[i].PublicKey)
+		if !isSupportedPublicKey(keys[i].PublicKey) {
+			return nil, usererror.BadRequestf(
+				"Attestation key '%s' requires a PEM encoded ed25519, ECDSA or RSA public key.", name)
+		}
+	}
+
+	return keys, nil
+}
+
+// isSupportedPublicKey returns whether the value is a PEM encoded public key supported for verifying attestations.
+func isSupportedPublicKey(value string) bool {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return false
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Service) sanitizeDefaultBranch(value json.RawMessage) (string, error) {
+	var branch string
+	if err := decodeValue(enum.SettingKeyDefaultBranch, value, &branch); err != nil {
+		return "", err
+	}
+
+	if branch == "" || strings.ContainsAny(branch, " \t\r\n") {
+		return "", usererror.BadRequest("The default branch must be a non-empty branch name without whitespaces.")
+	}
+
+	return branch, nil
+}
+
+func (s *Service) sanitizeDeleteSourceBranch(value json.RawMessage) (bool, error) {
+	var deleteSourceBranch bool
+	if err := decodeValue(enum.SettingKeyDeleteSourceBranch, value, &deleteSourceBranch); err != nil {
+		return false, err
+	}
+
+	return deleteSourceBranch, nil
+}
+
+func (s *Service) sanitizeEnforceCommitAuthor(value json.RawMessage) (bool, error) {
+	var enforce bool
+	if err := decodeValue(enum.SettingKeyEnforceCommitAuthor, value, &enforce); err != nil {
+		return false, err
+	}
+
+	return enforce, nil
+}
+
+func (s *Service) sanitizeGithookPlugins(value json.RawMessage) ([]string, error) {
+	var plugins []string
+	if err := decodeValue(enum.SettingKeyGithookPlugins, value, &plugins); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(plugins))
+	res := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		plugin = strings.TrimSpace(plugin)
+		if plugin == "" {
+			return nil, usererror.BadRequest("Git hook plugin names can't be empty.")
+		}
+		if _, ok := seen[plugin]; ok {
+			continue
+		}
+		seen[plugin] = struct{}{}
+		res = append(res, plugin)
+	}
+
+	return res, nil
+}
+
+func (s *Service) sanitizeMergeChecks(value json.RawMessage) ([]types.MergeCheckProvider, error) {
+	var providers []types.MergeCheckProvider
+	if err := decodeValue(enum.SettingKeyMergeChecks, value, &providers); err != nil {
+		return nil, err
+	}
+
+	if providers == nil {
+		providers = []types.MergeCheckProvider{}
+	}
+
+	seen := make(map[string]struct{}, len(providers))
+	for i := range providers {
+		providers[i].Name = strings.TrimSpace(providers[i].Name)
+		name := providers[i].Name
+		if name == "" {
+			return nil, usererror.BadRequest("Merge check names can't be empty.")
+		}
+		if _, ok := seen[name]; ok {
+			return nil, usererror.BadRequestf("Duplicate merge check '%s'.", name)
+		}
+		seen[name] = struct{}{}
+
+		parsedURL, err := url.Parse(providers[i].URL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return nil, usererror.BadRequestf("Merge check '%s' requires a valid http or https URL.", name)
+		}
+
+		if providers[i].TimeoutSeconds < 0 || providers[i].TimeoutSeconds > maxMergeCheckTimeoutSeconds {
+			return nil, usererror.BadRequestf("The timeout of merge check '%s' must be between 0 and %d seconds.",
+				name, maxMergeCheckTimeoutSeconds)
+		}
+	}
+
+	return providers, nil
+}
+
+func (s *Service) sanitizeMergeMethods(value json.RawMessage) ([]gitrpcenum.MergeMethod, error) {
+	var methods []gitrpcenum.MergeMethod
+	if err := decodeValue(enum.SettingKeyMergeMethods, value, &methods); err != nil {
+		return nil, err
+	}
+
+	if len(methods) == 0 {
+		return nil, usererror.BadRequest("At least one merge method has to be allowed.")
+	}
+
+	seen := make(map[gitrpcenum.MergeMethod]struct{}, len(methods))
+	res := make([]gitrpcenum.MergeMethod, 0, len(methods))
+	for _, method := range methods {
+		if _, ok := method.Sanitize(); !ok {
+			return nil, usererror.BadRequestf("Unsupported merge method '%s'.", method)
+		}
+		if _, ok := seen[method]; ok {
+			continue
+		}
+		seen[method] = struct{}{}
+		res = append(res, method)
+	}
+
+	return res, nil
+}
+
+func (s *Service) sanitizePathProtections(value json.RawMessage) ([]types.PathProtection, error) {
+	var protections []types.PathProtection
+	if err := decodeValue(enum.SettingKeyPathProtections, value, &protections); err != nil {
+		return nil, err
+	}
+
+	if protections == nil {
+		protections = []types.PathProtection{}
+	}
+
+	for i := range protections {
+		protection := &protections[i]
+
+		if protection.Branches == nil {
+			protection.Branches = []string{}
+		}
+		for _, pattern := range protection.Branches {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, usererror.BadRequestf("Invalid branch pattern '%s'.", pattern)
+			}
+		}
+
+		if len(protection.Paths) == 0 {
+			return nil, usererror.BadRequest("Path protections require at least one path pattern.")
+		}
+		for j, pattern := range protection.Paths {
+			pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+			if !isValidPathPattern(pattern) {
+				return nil, usererror.BadRequestf("Invalid path pattern '%s'.", protection.Paths[j])
+			}
+			protection.Paths[j] = pattern
+		}
+
+		if protection.AllowedPrincipalIDs == nil {
+			protection.AllowedPrincipalIDs = []int64{}
+		}
+		if protection.AllowedUserGroupIDs == nil {
+			protection.AllowedUserGroupIDs = []int64{}
+		}
+	}
+
+	return protections, nil
+}
+
+// isValidPathPattern returns whether all segments of a path pattern are valid patterns.
+func isValidPathPattern(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "" {
+			return false
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Service) sanitizePullReqRules(value json.RawMessage) (types.PullReqRules, error) {
+	var rules types.PullReqRules
+	if err := decodeValue(enum.SettingKeyPullReqRules, value, &rules); err != nil {
+		return types.PullReqRules{}, err
+	}
+
+	if rules.MinApprovals < 0 {
+		return types.PullReqRules{}, usererror.BadRequest("The minimum number of approvals can't be negative.")
+	}
+
+	return rules, nil
+}
+
+func (s *Service) sanitizePushToCreate(value json.RawMessage) (bool, error) {
+	var pushToCreate bool
+	if err := decodeValue(enum.SettingKeyPushToCreate, value, &pushToCreate); err != nil {
+		return false, err
+	}
+
+	return pushToCreate, nil
+}
+
+func (s *Service) sanitizeRetentionPolicy(value json.RawMessage) (types.RetentionPolicy, error) {
+	var policy types.RetentionPolicy
+	if err := decodeValue(enum.SettingKeyRetention, value, &policy); err != nil {
+		return types.RetentionPolicy{}, err
+	}
+
+	if policy.WebhookExecutionsDays < 0 || policy.WebhookExecutionsMax < 0 || policy.PipelineLogsDays < 0 {
+		return types.RetentionPolicy{}, usererror.BadRequest("Retention values can't be negative.")
+	}
+
+	return policy, nil
+}
+
+func (s *Service) sanitizePullReqSLA(value json.RawMessage) (types.PullReqSLA, error) {
+	var sla types.PullReqSLA
+	if err := decodeValue(enum.SettingKeyPullReqSLA, value, &sla); err != nil {
+		return types.PullReqSLA{}, err
+	}
+
+	if sla.MaxAgeDays < 0 || sla.MaxIdleDays < 0 {
+		return types.PullReqSLA{}, usererror.BadRequest("Pull request SLA thresholds can't be negative.")
+	}
+
+	return sla, nil
+}
+
+func (s *Service) sanitizeReviewReminderPolicy(value json.RawMessage) (types.ReviewReminderPolicy, error) {
+	var policy types.ReviewReminderPolicy
+	if err := decodeValue(enum.SettingKeyReviewReminders, value, &policy); err != nil {
+		return types.ReviewReminderPolicy{}, err
+	}
+
+	if policy.SLAHours < 0 {
+		return types.ReviewReminderPolicy{}, usererror.BadRequest("The review SLA can't be negative.")
+	}
+
+	return policy, nil
+}
+
+func (s *Service) sanitizeStaleBranchPolicy(value json.RawMessage) (types.StaleBranchPolicy, error) {
+	var policy types.StaleBranchPolicy
+	if err := decodeValue(enum.SettingKeyStaleBranches, value, &policy); err != nil {
+		return types.StaleBranchPolicy{}, err
+	}
+
+	if policy.StaleAfterDays < 1 {
+		return types.StaleBranchPolicy{}, usererror.BadRequest("Branches can only be stale after at least one day.")
+	}
+
+	if policy.ExcludedBranches == nil {
+		policy.ExcludedBranches = []string{}
+	}
+
+	for _, pattern := range policy.ExcludedBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return types.StaleBranchPolicy{}, usererror.BadRequestf("Invalid excluded branch pattern '%s'.", pattern)
+		}
+	}
+
+	return policy, nil
+}
+
+func (s *Service) sanitizeTargetBranchDelete(value json.RawMessage) (enum.PullReqTargetDeleteAction, error) {
+	var action enum.PullReqTargetDeleteAction
+	if err := decodeValue(enum.SettingKeyTargetBranchDelete, value, &action); err != nil {
+		return "", err
+	}
+
+	sanitized, ok := action.Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Unsupported target branch delete action '%s'.", action)
+	}
+
+	return sanitized, nil
+}
+
+func (s *Service) sanitizeWebhookTemplates(
+	ctx context.Context,
+	value json.RawMessage,
+) ([]types.WebhookTemplate, error) {
+	var templates []types.WebhookTemplate
+	if err := decodeValue(enum.SettingKeyWebhookTemplates, value, &templates); err != nil {
+		return nil, err
+	}
+
+	if templates == nil {
+		templates = []types.WebhookTemplate{}
+	}
+
+	for i := range templates {
+		if err := s.webhookCtrl.CheckCreateInput(ctx, templateToCreateInput(&templates[i])); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// decodeValue strictly decodes the value of a setting.
+func decodeValue(key enum.SettingKey, value json.RawMessage, dst interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return usererror.BadRequestf("Invalid value for setting '%s': %s", key, err)
+	}
+
+	return nil
+}
+
+func templateToCreateInput(template *types.WebhookTemplate) *webhook.CreateInput {
+	return &webhook.CreateInput{
+		DisplayName: template.DisplayName,
+		Description: template.Description,
+		URL:         template.URL,
+		Enabled:     template.Enabled,
+		Insecure:    template.Insecure,
+		ProxyURL:    template.ProxyURL,
+		Triggers:    template.Triggers,
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uploadscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// clamAVChunkSize is the size of the chunks the content is streamed to clamd in.
+const clamAVChunkSize = 32 * 1024
+
+var _ Scanner = (*ClamAV)(nil)
+
+// ClamAV scans content with a clamd daemon using the INSTREAM command.
+type ClamAV struct {
+	address string
+}
+
+func NewClamAV(address string) *ClamAV {
+	return &ClamAV{address: address}
+}
+
+func (s *ClamAV) Name() string {
+	return BackendClamAV
+}
+
+func (s *ClamAV) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// the "z" prefix makes clamd use null terminated commands and responses
+	if _, err = conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("failed to send command to clamd: %w", err)
+	}
+
+	// the content is sent in chunks prefixed with their length, a zero length chunk terminates the stream
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, errRead := content.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err = conn.Write(buf[:4+n]); err != nil {
+				return Result{}, fmt.Errorf("failed to stream content to clamd: %w", err)
+			}
+		}
+		if errors.Is(errRead, io.EOF) {
+			break
+		}
+		if errRead != nil {
+			return Result{}, fmt.Errorf("failed to read content: %w", errRead)
+		}
+	}
+
+	if _, err = conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("failed to terminate stream to clamd: %w", err)
+	}
+
+	response, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("failed to read clamd response: %w", err)
+	}
+
+	return parseClamAVResponse(strings.TrimRight(response, "\x00\n"))
+}
+
+// parseClamAVResponse parses responses like "stream: OK" or "stream: Eicar-Signature FOUND".
+func parseClamAVResponse(response string) (Result, error) {
+	_, verdict, ok := strings.Cut(response, ": ")
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected clamd response: %q", response)
+	}
+
+	switch {
+	case verdict == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{
+			Infected:  true,
+			Signature: strings.TrimSuffix(verdict, " FOUND"),
+		}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd failed to scan content: %s", verdict)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uploadscan
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const icapDefaultPort = "1344"
+
+var _ Scanner = (*ICAP)(nil)
+
+// ICAP scans content with an ICAP (RFC 3507) service using RESPMOD requests.
+// Clean content is expected to be acknowledged with "204 No Content",
+// infected content is detected by the de-facto standard X-Infection-Found and X-Violations-Found headers.
+type ICAP struct {
+	url  *url.URL
+	host string
+}
+
+func NewICAP(address string) (*ICAP, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse icap service url: %w", err)
+	}
+	if u.Scheme != "icap" || u.Host == "" {
+		return nil, fmt.Errorf("icap service url '%s' must be of the form icap://host[:port]/service", address)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), icapDefaultPort)
+	}
+
+	return &ICAP{url: u, host: host}, nil
+}
+
+func (s *ICAP) Name() string {
+	return BackendICAP
+}
+
+func (s *ICAP) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.host)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to icap service: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// the content is encapsulated as the body of an http response
+	const resHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", s.url.String())
+	fmt.Fprintf(w, "Host: %s\r\n", s.url.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(resHeader))
+	_, _ = w.WriteString(resHeader)
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, errRead := content.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			_, _ = w.Write(buf[:n])
+			_, _ = w.WriteString("\r\n")
+		}
+		if errors.Is(errRead, io.EOF) {
+			break
+		}
+		if errRead != nil {
+			return Result{}, fmt.Errorf("failed to read content: %w", errRead)
+		}
+	}
+	_, _ = w.WriteString("0\r\n\r\n")
+
+	// errors of the buffered writes are sticky and returned by the flush
+	if err = w.Flush(); err != nil {
+		return Result{}, fmt.Errorf("failed to send content to icap service: %w", err)
+	}
+
+	r := textproto.NewReader(bufio.NewReader(conn))
+
+	statusLine, err := r.ReadLine()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read icap response: %w", err)
+	}
+
+	header, err := r.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("failed to read icap response headers: %w", err)
+	}
+
+	return parseICAPResponse(statusLine, header)
+}
+
+func parseICAPResponse(statusLine string, header textproto.MIMEHeader) (Result, error) {
+	parts := strings.SplitN(statusLine, " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "ICAP/") {
+		return Result{}, fmt.Errorf("unexpected icap response: %q", statusLine)
+	}
+
+	code, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected icap response status: %q", statusLine)
+	}
+
+	if code != 200 && code != 204 {
+		return Result{}, fmt.Errorf("icap service failed to scan content: %s", statusLine)
+	}
+
+	// e.g. "Type=0; Resolution=2; Threat=Eicar-Signature;"
+	if infection := header.Get("X-Infection-Found"); infection != "" {
+		return Result{
+			Infected:  true,
+			Signature: icapThreat(infection),
+		}, nil
+	}
+
+	// e.g. "1\r\n\tfile\r\n\tEicar-Signature\r\n\t0\r\n\t0", the continuation lines are folded into the value
+	if violations := header.Get("X-Violations-Found"); violations != "" {
+		signature := violations
+		if fields := strings.Fields(violations); len(fields) >= 3 {
+			signature = fields[2]
+		}
+		return Result{
+			Infected:  true,
+			Signature: signature,
+		}, nil
+	}
+
+	return Result{}, nil
+}
+
+func icapThreat(infection string) string {
+	for _, field := range strings.Split(infection, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if ok && strings.EqualFold(key, "Threat") {
+			return value
+		}
+	}
+
+	return infection
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uploadscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	BackendClamAV = "clamav"
+	BackendICAP   = "icap"
+)
+
+// Result is the verdict of a virus scanner for a piece of content.
+type Result struct {
+	Infected bool
+	// Signature is the name of the detected threat, if any.
+	Signature string
+}
+
+// Scanner scans content for viruses and other malware.
+type Scanner interface {
+	// Name returns the name of the scanner backend.
+	Name() string
+
+	// Scan scans the content provided by the reader.
+	Scan(ctx context.Context, content io.Reader) (Result, error)
+}
+
+// NewScanner returns the scanner of the provided backend, or nil if the backend is empty.
+func NewScanner(backend string, address string) (Scanner, error) {
+	switch backend {
+	case "":
+		return nil, nil
+	case BackendClamAV:
+		return NewClamAV(address), nil
+	case BackendICAP:
+		return NewICAP(address)
+	default:
+		return nil, fmt.Errorf("unknown upload scan backend '%s'", backend)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uploadscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// serve accepts a single connection on a local listener and handles it with the provided function.
+func serve(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}()
+
+	return lis.Addr().String()
+}
+
+// fakeClamd reads an INSTREAM request and reports the content as infected if it contains the EICAR string.
+func fakeClamd(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	if cmd, _ := r.ReadString(0); cmd != "zINSTREAM\x00" {
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var content bytes.Buffer
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+			return
+		}
+	}
+
+	if strings.Contains(content.String(), eicar) {
+		_, _ = conn.Write([]byte("stream: Eicar-Signature FOUND\x00"))
+		return
+	}
+	_, _ = conn.Write([]byte("stream: OK\x00"))
+}
+
+// fakeICAP reads a RESPMOD request and reports the content as infected if it contains the EICAR string.
+func fakeICAP(conn net.Conn) {
+	r := textproto.NewReader(bufio.NewReader(conn))
+	if line, _ := r.ReadLine(); !strings.HasPrefix(line, "RESPMOD icap://") {
+		_, _ = conn.Write([]byte("ICAP/1.0 400 Bad Request\r\n\r\n"))
+		return
+	}
+	if _, err := r.ReadMIMEHeader(); err != nil { // icap headers
+		return
+	}
+	if _, err := r.ReadLine(); err != nil { // encapsulated http status line
+		return
+	}
+	if _, err := r.ReadMIMEHeader(); err != nil { // encapsulated http headers
+		return
+	}
+
+	var content bytes.Buffer
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+		size, err := strconv.ParseInt(line, 16, 64)
+		if err != nil || size == 0 {
+			break
+		}
+		if _, err = io.CopyN(&content, r.R, size); err != nil {
+			return
+		}
+		if _, err = r.ReadLine(); err != nil { // chunk terminator
+			return
+		}
+	}
+
+	if strings.Contains(content.String(), eicar) {
+		_, _ = conn.Write([]byte("ICAP/1.0 200 OK\r\n" +
+			"X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Signature;\r\n\r\n"))
+		return
+	}
+	_, _ = conn.Write([]byte("ICAP/1.0 204 No Content\r\n\r\n"))
+}
+
+func TestScanners(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Result
+	}{
+		{name: "clean", content: "hello world", want: Result{}},
+		{name: "empty", content: "", want: Result{}},
+		{name: "infected", content: eicar, want: Result{Infected: true, Signature: "Eicar-Signature"}},
+		{name: "large", content: strings.Repeat("a", 100*1024) + eicar,
+			want: Result{Infected: true, Signature: "Eicar-Signature"}},
+	}
+
+	for _, test := range tests {
+		t.Run("clamav/"+test.name, func(t *testing.T) {
+			scanner := NewClamAV(serve(t, fakeClamd))
+
+			got, err := scanner.Scan(context.Background(), strings.NewReader(test.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+
+		t.Run("icap/"+test.name, func(t *testing.T) {
+			scanner, err := NewICAP("icap://" + serve(t, fakeICAP) + "/avscan")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := scanner.Scan(context.Background(), strings.NewReader(test.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseICAPViolations(t *testing.T) {
+	header := textproto.MIMEHeader{}
+	header.Set("X-Violations-Found", "1 file Eicar-Signature 0 0")
+
+	got, err := parseICAPResponse("ICAP/1.0 200 OK", header)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (Result{Infected: true, Signature: "Eicar-Signature"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err = parseICAPResponse("ICAP/1.0 500 Server Error", textproto.MIMEHeader{}); err == nil {
+		t.Error("expected error for failed icap response")
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uploadscan
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// quarantineBlobDir is the directory of the blob store containing the content of quarantined uploads.
+const quarantineBlobDir = "upload-quarantine"
+
+type Config struct {
+	// Backend is the scanner backend, one of clamav or icap. Uploads aren't scanned if it's empty.
+	Backend string
+	// Address is the address of clamd (host:port) or the url of the icap service (icap://host[:port]/service).
+	Address string
+	Timeout time.Duration
+	// MaxSize is the max size of an upload that can be scanned.
+	MaxSize int64
+	// FailOpen accepts uploads that can't be scanned, e.g. because the scanner is unavailable.
+	FailOpen bool
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Backend == "" {
+		return nil
+	}
+	if c.Address == "" {
+		return errors.New("config.Address is required")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("config.Timeout has to be a positive duration")
+	}
+	if c.MaxSize < 1 {
+		return errors.New("config.MaxSize has to be a positive number")
+	}
+	return nil
+}
+
+// Service scans uploaded content for viruses before it's accepted, and quarantines infected content.
+type Service struct {
+	config          Config
+	scanner         Scanner
+	quarantineStore store.UploadQuarantineStore
+	blobStore       blob.Store
+}
+
+func NewService(
+	config Config,
+	quarantineStore store.UploadQuarantineStore,
+	blobStore blob.Store,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided upload scan config is invalid: %w", err)
+	}
+
+	scanner, err := NewScanner(config.Backend, config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		config:          config,
+		scanner:         scanner,
+		quarantineStore: quarantineStore,
+		blobStore:       blobStore,
+	}, nil
+}
+
+// Upload is content uploaded by a principal that has to be checked before it's accepted.
+type Upload struct {
+	Source enum.UploadSource
+	// RepoID is the repository the content was uploaded to, zero if it doesn't belong to a repository.
+	RepoID     int64
+	Name       string
+	Content    []byte
+	UploadedBy int64
+}
+
+// Check scans the uploaded content and returns a user facing error if the upload is rejected.
+// Content that was released from the quarantine by an admin is accepted without scanning it again.
+func (s *Service) Check(ctx context.Context, upload Upload) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	checksum := sha256.Sum256(upload.Content)
+	sha := hex.EncodeToString(checksum[:])
+
+	existing, err := s.quarantineStore.FindBySHA256(ctx, sha)
+	switch {
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to find upload quarantine: %w", err)
+	case existing.Status == enum.UploadQuarantineStatusReleased:
+		return nil
+	default:
+		return errQuarantined(upload.Name, existing)
+	}
+
+	if int64(len(upload.Content)) > s.config.MaxSize {
+		if s.config.FailOpen {
+			return nil
+		}
+		return usererror.Newf(http.StatusRequestEntityTooLarge,
+			"The upload '%s' exceeds the max size of %d bytes that can be scanned for viruses.",
+			upload.Name, s.config.MaxSize)
+	}
+
+	scanCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	result, err := s.scanner.Scan(scanCtx, bytes.NewReader(upload.Content))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("scanner", s.scanner.Name()).
+			Str("upload_name", upload.Name).
+			Msg("failed to scan upload for viruses")
+
+		if s.config.FailOpen {
+			return nil
+		}
+		return usererror.New(http.StatusServiceUnavailable,
+			"The upload couldn't be scanned for viruses, please try again later.")
+	}
+
+	if !result.Infected {
+		return nil
+	}
+
+	quarantine, err := s.quarantine(ctx, upload, sha, result)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Warn().
+		Int64("quarantine_id", quarantine.ID).
+		Str("signature", result.Signature).
+		Str("upload_name", upload.Name).
+		Int64("uploaded_by", upload.UploadedBy).
+		Msg("quarantined infected upload")
+
+	return errQuarantined(upload.Name, quarantine)
+}
+
+func (s *Service) quarantine(
+	ctx context.Context,
+	upload Upload,
+	sha string,
+	result Result,
+) (*types.UploadQuarantine, error) {
+	err := s.blobStore.Upload(ctx, bytes.NewReader(upload.Content), quarantineBlobPath(sha))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store quarantined upload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	quarantine := &types.UploadQuarantine{
+		SHA256:     sha,
+		Source:     upload.Source,
+		RepoID:     upload.RepoID,
+		Name:       upload.Name,
+		Size:       int64(len(upload.Content)),
+		Scanner:    s.scanner.Name(),
+		Signature:  result.Signature,
+		Status:     enum.UploadQuarantineStatusQuarantined,
+		UploadedBy: upload.UploadedBy,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err = s.quarantineStore.Create(ctx, quarantine)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the same content was quarantined concurrently
+		return s.quarantineStore.FindBySHA256(ctx, sha)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload quarantine: %w", err)
+	}
+
+	return quarantine, nil
+}
+
+// Release marks the quarantined content as safe, it's accepted without scanning when it's uploaded again.
+func (s *Service) Release(ctx context.Context, id int64, releasedBy int64) (*types.UploadQuarantine, error) {
+	quarantine, err := s.quarantineStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find upload quarantine: %w", err)
+	}
+
+	if quarantine.Status == enum.UploadQuarantineStatusReleased {
+		return quarantine, nil
+	}
+
+	quarantine.Status = enum.UploadQuarantineStatusReleased
+	quarantine.ReleasedBy = &releasedBy
+	quarantine.Updated = time.Now().UnixMilli()
+
+	if err = s.quarantineStore.UpdateStatus(ctx, quarantine); err != nil {
+		return nil, fmt.Errorf("failed to release upload quarantine: %w", err)
+	}
+
+	// the content is kept in the quarantine only as long as it's rejected
+	if err = s.blobStore.Delete(ctx, quarantineBlobPath(quarantine.SHA256)); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("quarantine_id", id).Msg("failed to delete released upload content")
+	}
+
+	return quarantine, nil
+}
+
+// Delete removes the quarantined upload and its content. The same content is scanned again if it's uploaded.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	quarantine, err := s.quarantineStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find upload quarantine: %w", err)
+	}
+
+	if err = s.blobStore.Delete(ctx, quarantineBlobPath(quarantine.SHA256)); err != nil {
+		return fmt.Errorf("failed to delete quarantined upload content: %w", err)
+	}
+
+	if err = s.quarantineStore.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete upload quarantine: %w", err)
+	}
+
+	return nil
+}
+
+func quarantineBlobPath(sha string) string {
+	return path.Join(quarantineBlobDir, sha)
+}
+
+func errQuarantined(name string, quarantine *types.UploadQuarantine) error {
+	return usererror.NewWithPayload(http.StatusUnprocessableEntity,
+		fmt.Sprintf("The upload '%s' was quarantined because the virus scanner detected '%s'.",
+			name, quarantine.Signature),
+		map[string]any{"quarantine_id": quarantine.ID})
+}
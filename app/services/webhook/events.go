@@ -28,16 +28,16 @@ import (
 	"go.uber.org/multierr"
 )
 
-func generateTriggerIDFromEventID(eventID string) string {
-	return fmt.Sprintf("event-%s", eventID)
+func generateTriggerIDFromEventKey(eventKey string) string {
+	return fmt.Sprintf("event-%s", eventKey)
 }
 
 // triggerForEventWithRepo triggers all webhooks for the given repo and triggerType
-// using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
+// using the eventKey to generate a deterministic triggerID and using the output of bodyFn as payload.
 // The method tries to find the repository and principal and provides both to the bodyFn to generate the body.
 // NOTE: technically we could avoid this call if we send the data via the event (though then events will get big).
 func (s *Service) triggerForEventWithRepo(ctx context.Context,
-	triggerType enum.WebhookTrigger, eventID string, principalID int64, repoID int64,
+	triggerType enum.WebhookTrigger, eventKey string, principalID int64, repoID int64,
 	createBodyFn func(*types.Principal, *types.Repository) (any, error)) error {
 	principal, err := s.findPrincipalForEvent(ctx, principalID)
 	if err != nil {
@@ -55,16 +55,16 @@ func (s *Service) triggerForEventWithRepo(ctx context.Context,
 		return fmt.Errorf("body creation function failed: %w", err)
 	}
 
-	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
+	return s.triggerForEvent(ctx, eventKey, enum.WebhookParentRepo, repo.ID, triggerType, body)
 }
 
 // triggerForEventWithPullReq triggers all webhooks for the given repo and triggerType
-// using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
+// using the eventKey to generate a deterministic triggerID and using the output of bodyFn as payload.
 // The method tries to find the pullreq, principal, target repo, and source repo
 // and provides all to the bodyFn to generate the body.
 // NOTE: technically we could avoid this call if we send the data via the event (though then events will get big).
 func (s *Service) triggerForEventWithPullReq(ctx context.Context,
-	triggerType enum.WebhookTrigger, eventID string, principalID int64, prID int64,
+	triggerType enum.WebhookTrigger, eventKey string, principalID int64, prID int64,
 	createBodyFn func(principal *types.Principal, pr *types.PullReq,
 		targetRepo *types.Repository, sourceRepo *types.Repository) (any, error)) error {
 	principal, err := s.findPrincipalForEvent(ctx, principalID)
@@ -96,7 +96,7 @@ func (s *Service) triggerForEventWithPullReq(ctx context.Context,
 		return fmt.Errorf("body creation function failed: %w", err)
 	}
 
-	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, targetRepo.ID, triggerType, body)
+	return s.triggerForEvent(ctx, eventKey, enum.WebhookParentRepo, targetRepo.ID, triggerType, body)
 }
 
 // findRepositoryForEvent finds the repository for the provided repoID.
@@ -148,10 +148,10 @@ func (s *Service) findPrincipalForEvent(ctx context.Context, principalID int64)
 }
 
 // triggerForEvent triggers all webhooks for the given parentType/ID and triggerType
-// using the eventID to generate a deterministic triggerID and sending the provided body as payload.
-func (s *Service) triggerForEvent(ctx context.Context, eventID string,
+// using the eventKey to generate a deterministic triggerID and sending the provided body as payload.
+func (s *Service) triggerForEvent(ctx context.Context, eventKey string,
 	parentType enum.WebhookParent, parentID int64, triggerType enum.WebhookTrigger, body any) error {
-	triggerID := generateTriggerIDFromEventID(eventID)
+	triggerID := generateTriggerIDFromEventKey(eventKey)
 
 	results, err := s.triggerWebhooksFor(ctx, parentType, parentID, triggerID, triggerType, body)
 
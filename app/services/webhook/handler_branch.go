@@ -32,19 +32,33 @@ type ReferencePayload struct {
 	ReferenceSegment
 	ReferenceDetailsSegment
 	ReferenceUpdateSegment
+	ReferenceCommitsSegment
 }
 
+// maxCommitsInPayload is the maximum number of new commits included in a reference payload.
+const maxCommitsInPayload = 20
+
 // handleEventBranchCreated handles branch created events
 // and triggers branch created webhooks for the source repo.
 func (s *Service) handleEventBranchCreated(ctx context.Context,
 	event *events.Event[*gitevents.BranchCreatedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchCreated,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.SHA)
 			if err != nil {
 				return nil, err
 			}
+
+			// compare new branches against the default branch (unless it's the default branch itself)
+			baseRef := ""
+			if event.Payload.Ref != gitReferenceNamePrefixBranch+repo.DefaultBranch {
+				baseRef = repo.DefaultBranch
+			}
+			commitsSegment, err := s.fetchReferenceCommitsForEvent(ctx, repo.GitUID, baseRef, event.Payload.SHA)
+			if err != nil {
+				return nil, err
+			}
 			repoInfo := repositoryInfoFrom(repo, s.urlProvider)
 
 			return &ReferencePayload{
@@ -64,9 +78,10 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 					Commit: &commitInfo,
 				},
 				ReferenceUpdateSegment: ReferenceUpdateSegment{
-					OldSHA: types.NilSHA,
+					OldSHA: types.NilSHAFor(event.Payload.SHA),
 					Forced: false,
 				},
+				ReferenceCommitsSegment: commitsSegment,
 			}, nil
 		})
 }
@@ -76,12 +91,17 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 func (s *Service) handleEventBranchUpdated(ctx context.Context,
 	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchUpdated,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.NewSHA)
 			if err != nil {
 				return nil, err
 			}
+			commitsSegment, err := s.fetchReferenceCommitsForEvent(ctx, repo.GitUID,
+				event.Payload.OldSHA, event.Payload.NewSHA)
+			if err != nil {
+				return nil, err
+			}
 			repoInfo := repositoryInfoFrom(repo, s.urlProvider)
 
 			return &ReferencePayload{
@@ -104,6 +124,7 @@ func (s *Service) handleEventBranchUpdated(ctx context.Context,
 					OldSHA: event.Payload.OldSHA,
 					Forced: event.Payload.Forced,
 				},
+				ReferenceCommitsSegment: commitsSegment,
 			}, nil
 		})
 }
@@ -113,7 +134,7 @@ func (s *Service) handleEventBranchUpdated(ctx context.Context,
 func (s *Service) handleEventBranchDeleted(ctx context.Context,
 	event *events.Event[*gitevents.BranchDeletedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchDeleted,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			repoInfo := repositoryInfoFrom(repo, s.urlProvider)
 
@@ -130,7 +151,7 @@ func (s *Service) handleEventBranchDeleted(ctx context.Context,
 					},
 				},
 				ReferenceDetailsSegment: ReferenceDetailsSegment{
-					SHA:    types.NilSHA,
+					SHA:    types.NilSHAFor(event.Payload.SHA),
 					Commit: nil,
 				},
 				ReferenceUpdateSegment: ReferenceUpdateSegment{
@@ -161,3 +182,65 @@ func (s *Service) fetchCommitInfoForEvent(ctx context.Context, repoUID string, s
 
 	return commitInfoFrom(out.Commit), nil
 }
+
+// fetchReferenceCommitsForEvent fetches the (bounded) list of commits reachable from sha but not from baseRef,
+// together with the total commit count and the summary of the files changed between baseRef and sha.
+// If baseRef is empty, only the latest commits of sha are listed and no changed files summary is provided.
+func (s *Service) fetchReferenceCommitsForEvent(ctx context.Context, repoUID string,
+	baseRef string, sha string) (ReferenceCommitsSegment, error) {
+	readParams := gitrpc.ReadParams{
+		RepoUID: repoUID,
+	}
+
+	out, err := s.gitRPCClient.ListCommits(ctx, &gitrpc.ListCommitsParams{
+		ReadParams: readParams,
+		GitREF:     sha,
+		After:      baseRef,
+		Page:       1,
+		Limit:      maxCommitsInPayload,
+	})
+	if gitrpc.ErrorStatus(err) == gitrpc.StatusNotFound {
+		// the base might not exist (anymore) - the enrichment is best effort, send the payload without it.
+		return ReferenceCommitsSegment{}, nil
+	}
+	if err != nil {
+		return ReferenceCommitsSegment{}, fmt.Errorf("failed to list commits of sha '%s': %w", sha, err)
+	}
+
+	segment := ReferenceCommitsSegment{
+		Commits:           make([]CommitInfo, len(out.Commits)),
+		TotalCommitsCount: out.TotalCommits,
+	}
+	for i := range out.Commits {
+		segment.Commits[i] = commitInfoFrom(out.Commits[i])
+	}
+	if segment.TotalCommitsCount < len(segment.Commits) {
+		segment.TotalCommitsCount = len(segment.Commits)
+	}
+
+	if baseRef == "" || len(segment.Commits) == 0 {
+		return segment, nil
+	}
+
+	stat, err := s.gitRPCClient.DiffShortStat(ctx, &gitrpc.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    baseRef,
+		HeadRef:    sha,
+		MergeBase:  true,
+	})
+	if gitrpc.ErrorStatus(err) == gitrpc.StatusNotFound {
+		return segment, nil
+	}
+	if err != nil {
+		return ReferenceCommitsSegment{}, fmt.Errorf("failed to get diff stats between '%s' and '%s': %w",
+			baseRef, sha, err)
+	}
+
+	segment.ChangedFiles = &ChangedFilesInfo{
+		Files:     stat.Files,
+		Additions: stat.Additions,
+		Deletions: stat.Deletions,
+	}
+
+	return segment, nil
+}
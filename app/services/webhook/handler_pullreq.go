@@ -43,7 +43,7 @@ type PullReqCreatedPayload struct {
 func (s *Service) handleEventPullReqCreated(ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload]) error {
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCreated,
-		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		event.Key, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
 			if err != nil {
@@ -90,7 +90,7 @@ type PullReqReopenedPayload PullReqCreatedPayload
 func (s *Service) handleEventPullReqReopened(ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload]) error {
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReopened,
-		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		event.Key, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
 			if err != nil {
@@ -144,7 +144,7 @@ type PullReqBranchUpdatedPayload struct {
 func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqBranchUpdated,
-		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		event.Key, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.NewSHA)
 			if err != nil {
@@ -198,7 +198,7 @@ type PullReqClosedPayload struct {
 func (s *Service) handleEventPullReqClosed(ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload]) error {
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqClosed,
-		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		event.Key, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
 			if err != nil {
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PullReqReviewSubmittedPayload describes the body of the pullreq review submitted trigger.
+type PullReqReviewSubmittedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqReviewSegment
+}
+
+// handleEventPullReqReviewSubmitted handles review submitted events for pull requests
+// and triggers pullreq review submitted webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload]) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReviewSubmitted,
+		event.Key, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			return &PullReqReviewSubmittedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqReviewSegment: PullReqReviewSegment{
+					Review: ReviewInfo{
+						ID:       event.Payload.ReviewID,
+						Decision: event.Payload.Decision,
+						SHA:      event.Payload.CommitSHA,
+						Message:  event.Payload.Message,
+					},
+				},
+			}, nil
+		})
+}
+
+// PullReqCommentPayload describes the body of the pullreq comment created, updated and resolved triggers.
+type PullReqCommentPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqCommentSegment
+}
+
+// handleEventPullReqCommentCreated handles comment created events for pull requests
+// and triggers pullreq comment created webhooks for the target repo.
+func (s *Service) handleEventPullReqCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload]) error {
+	return s.triggerForEventWithPullReqComment(ctx, enum.WebhookTriggerPullReqCommentCreated,
+		event.Key, &event.Payload.Base, event.Payload.ActivityID)
+}
+
+// handleEventPullReqCommentUpdated handles comment updated events for pull requests
+// and triggers pullreq comment updated webhooks for the target repo.
+func (s *Service) handleEventPullReqCommentUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentUpdatedPayload]) error {
+	return s.triggerForEventWithPullReqComment(ctx, enum.WebhookTriggerPullReqCommentUpdated,
+		event.Key, &event.Payload.Base, event.Payload.ActivityID)
+}
+
+// handleEventPullReqCommentStatusUpdated handles comment status updated events for pull requests
+// and triggers pullreq comment resolved webhooks for the target repo in case the comment got resolved.
+func (s *Service) handleEventPullReqCommentStatusUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload]) error {
+	if event.Payload.Status != enum.PullReqCommentStatusResolved {
+		return nil
+	}
+
+	return s.triggerForEventWithPullReqComment(ctx, enum.WebhookTriggerPullReqCommentResolved,
+		event.Key, &event.Payload.Base, event.Payload.ActivityID)
+}
+
+// triggerForEventWithPullReqComment triggers the pullreq comment webhooks with the latest state of the comment.
+func (s *Service) triggerForEventWithPullReqComment(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventKey string, base *pullreqevents.Base, activityID int64) error {
+	act, err := s.findCommentForEvent(ctx, activityID)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForEventWithPullReq(ctx, triggerType, eventKey, base.PrincipalID, base.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			return &PullReqCommentPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				PullReqCommentSegment: PullReqCommentSegment{
+					Comment: commentInfoFrom(act),
+				},
+			}, nil
+		})
+}
+
+// findCommentForEvent finds the pull request comment for the provided activityID.
+func (s *Service) findCommentForEvent(ctx context.Context, activityID int64) (*types.PullReqActivity, error) {
+	act, err := s.activityStore.Find(ctx, activityID)
+
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of the comment being deleted by now
+		return nil, events.NewDiscardEventErrorf("comment with id '%d' doesn't exist anymore", activityID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, fmt.Errorf("failed to get comment for id '%d': %w", activityID, err)
+	}
+
+	if act.Deleted != nil {
+		return nil, events.NewDiscardEventErrorf("comment with id '%d' got deleted", activityID)
+	}
+
+	return act, nil
+}
@@ -28,7 +28,7 @@ import (
 func (s *Service) handleEventTagCreated(ctx context.Context,
 	event *events.Event[*gitevents.TagCreatedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerTagCreated,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.SHA)
 			if err != nil {
@@ -53,7 +53,7 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 					Commit: &commitInfo,
 				},
 				ReferenceUpdateSegment: ReferenceUpdateSegment{
-					OldSHA: types.NilSHA,
+					OldSHA: types.NilSHAFor(event.Payload.SHA),
 					Forced: false,
 				},
 			}, nil
@@ -65,7 +65,7 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 func (s *Service) handleEventTagUpdated(ctx context.Context,
 	event *events.Event[*gitevents.TagUpdatedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerTagUpdated,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.NewSHA)
 			if err != nil {
@@ -102,7 +102,7 @@ func (s *Service) handleEventTagUpdated(ctx context.Context,
 func (s *Service) handleEventTagDeleted(ctx context.Context,
 	event *events.Event[*gitevents.TagDeletedPayload]) error {
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerTagDeleted,
-		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		event.Key, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
 			repoInfo := repositoryInfoFrom(repo, s.urlProvider)
 
@@ -119,7 +119,7 @@ func (s *Service) handleEventTagDeleted(ctx context.Context,
 					},
 				},
 				ReferenceDetailsSegment: ReferenceDetailsSegment{
-					SHA:    types.NilSHA,
+					SHA:    types.NilSHAFor(event.Payload.SHA),
 					Commit: nil,
 				},
 				ReferenceUpdateSegment: ReferenceUpdateSegment{
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	deliveriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "Total number of webhook deliveries per trigger and result.",
+	}, []string{"trigger", "result"})
+
+	deliveryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "webhook",
+		Name:      "delivery_duration_seconds",
+		Help:      "Duration of the webhook deliveries per result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+)
+
+func observeDelivery(triggerType enum.WebhookTrigger, result enum.WebhookExecutionResult, duration time.Duration) {
+	deliveriesTotal.WithLabelValues(string(triggerType), string(result)).Inc()
+	deliveryDuration.WithLabelValues(string(result)).Observe(duration.Seconds())
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/xid"
+)
+
+const (
+	// sampleTriggerIDPrefix is the prefix of the trigger id of test deliveries.
+	// It allows consumers to distinguish test deliveries from real ones.
+	sampleTriggerIDPrefix = "test-"
+
+	sampleSHA         = "5a7c8e9f0b1d2c3e4f5a6b7c8d9e0f1a2b3c4d5e"
+	sampleOldSHA      = "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"
+	sampleBranch      = "feature/sample"
+	sampleTag         = "v1.0.0"
+	samplePullReqNum  = 1
+	sampleReviewID    = 1
+	sampleCommentID   = 1
+	sampleCommentText = "This is a sample comment."
+)
+
+// TestWebhook sends a signed sample payload of the provided trigger type to the webhook,
+// independent of whether the webhook is enabled or registered for the trigger.
+// The sample payload uses the repository of the webhook and the provided principal,
+// all other values (references, commits, pull request, ...) are made up.
+// The execution is stored like any other execution and can be retriggered.
+func (s *Service) TestWebhook(
+	ctx context.Context,
+	webhook *types.Webhook,
+	triggerType enum.WebhookTrigger,
+	principal *types.Principal,
+) (*TriggerResult, error) {
+	body, err := s.samplePayload(ctx, webhook, triggerType, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sample payload: %w", err)
+	}
+
+	triggerID := sampleTriggerIDPrefix + xid.New().String()
+
+	execution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+	return &TriggerResult{
+		TriggerID:   triggerID,
+		TriggerType: triggerType,
+		Webhook:     webhook,
+		Execution:   execution,
+		Err:         err,
+	}, nil
+}
+
+//nolint:funlen // sample payloads for all triggers, no need to split it up.
+func (s *Service) samplePayload(
+	ctx context.Context,
+	webhook *types.Webhook,
+	triggerType enum.WebhookTrigger,
+	principal *types.Principal,
+) (any, error) {
+	repoInfo, err := s.sampleRepositoryInfo(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	base := BaseSegment{
+		Trigger:   triggerType,
+		Repo:      repoInfo,
+		Principal: principalInfoFrom(principal),
+	}
+	commit := CommitInfo{
+		SHA:     sampleSHA,
+		Message: "Sample commit",
+		Author: SignatureInfo{
+			Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+			When:     now,
+		},
+		Committer: SignatureInfo{
+			Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+			When:     now,
+		},
+	}
+	branchRef := ReferenceInfo{Name: gitReferenceNamePrefixBranch + sampleBranch, Repo: repoInfo}
+	targetRef := ReferenceInfo{Name: gitReferenceNamePrefixBranch + repoInfo.DefaultBranch, Repo: repoInfo}
+	pullReq := PullReqInfo{
+		Number:       samplePullReqNum,
+		State:        enum.PullReqStateOpen,
+		Title:        "Sample pull request",
+		SourceRepoID: repoInfo.ID,
+		SourceBranch: sampleBranch,
+		TargetRepoID: repoInfo.ID,
+		TargetBranch: repoInfo.DefaultBranch,
+	}
+
+	switch triggerType {
+	case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerTagCreated:
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: sampleReference(triggerType, repoInfo)},
+			ReferenceDetailsSegment: ReferenceDetailsSegment{SHA: sampleSHA, Commit: &commit},
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: types.NilSHA},
+			ReferenceCommitsSegment: sampleCommitsSegment(triggerType, commit),
+		}, nil
+
+	case enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerTagUpdated:
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: sampleReference(triggerType, repoInfo)},
+			ReferenceDetailsSegment: ReferenceDetailsSegment{SHA: sampleSHA, Commit: &commit},
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+			ReferenceCommitsSegment: sampleCommitsSegment(triggerType, commit),
+		}, nil
+
+	case enum.WebhookTriggerBranchDeleted, enum.WebhookTriggerTagDeleted:
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: sampleReference(triggerType, repoInfo)},
+			ReferenceDetailsSegment: ReferenceDetailsSegment{SHA: types.NilSHA},
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleSHA},
+		}, nil
+
+	case enum.WebhookTriggerPullReqCreated, enum.WebhookTriggerPullReqReopened:
+		return &PullReqCreatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                PullReqSegment{PullReq: pullReq},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{TargetRef: targetRef},
+			ReferenceSegment:              ReferenceSegment{Ref: branchRef},
+			ReferenceDetailsSegment:       ReferenceDetailsSegment{SHA: sampleSHA, Commit: &commit},
+		}, nil
+
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                PullReqSegment{PullReq: pullReq},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{TargetRef: targetRef},
+			ReferenceSegment:              ReferenceSegment{Ref: branchRef},
+			ReferenceDetailsSegment:       ReferenceDetailsSegment{SHA: sampleSHA, Commit: &commit},
+			ReferenceUpdateSegment:        ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}, nil
+
+	case enum.WebhookTriggerPullReqClosed:
+		pullReq.State = enum.PullReqStateClosed
+		return &PullReqClosedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                PullReqSegment{PullReq: pullReq},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{TargetRef: targetRef},
+			ReferenceSegment:              ReferenceSegment{Ref: branchRef},
+			ReferenceDetailsSegment:       ReferenceDetailsSegment{SHA: sampleSHA, Commit: &commit},
+		}, nil
+
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		return &PullReqReviewSubmittedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                PullReqSegment{PullReq: pullReq},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{TargetRef: targetRef},
+			ReferenceSegment:              ReferenceSegment{Ref: branchRef},
+			PullReqReviewSegment: PullReqReviewSegment{Review: ReviewInfo{
+				ID:       sampleReviewID,
+				Decision: enum.PullReqReviewDecisionApproved,
+				SHA:      sampleSHA,
+				Message:  "Looks good to me.",
+			}},
+		}, nil
+
+	case enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerPullReqCommentUpdated,
+		enum.WebhookTriggerPullReqCommentResolved:
+		comment := CommentInfo{
+			ID:      sampleCommentID,
+			Text:    sampleCommentText,
+			Created: now.UnixMilli(),
+			Updated: now.UnixMilli(),
+			Edited:  now.UnixMilli(),
+		}
+		if triggerType == enum.WebhookTriggerPullReqCommentResolved {
+			resolved := now.UnixMilli()
+			comment.Resolved = &resolved
+		}
+
+		return &PullReqCommentPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                PullReqSegment{PullReq: pullReq},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{TargetRef: targetRef},
+			ReferenceSegment:              ReferenceSegment{Ref: branchRef},
+			PullReqCommentSegment:         PullReqCommentSegment{Comment: comment},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("no sample payload available for trigger '%s'", triggerType)
+	}
+}
+
+// sampleRepositoryInfo returns the info of the repository the webhook belongs to,
+// or a made up repository in case the webhook doesn't belong to a repository.
+func (s *Service) sampleRepositoryInfo(ctx context.Context, webhook *types.Webhook) (RepositoryInfo, error) {
+	if webhook.ParentType != enum.WebhookParentRepo {
+		return RepositoryInfo{
+			ID:            0,
+			Path:          "sample/repo",
+			UID:           "repo",
+			DefaultBranch: "main",
+			GitURL:        s.urlProvider.GenerateGITCloneURL("sample/repo"),
+		}, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+	if err != nil {
+		return RepositoryInfo{}, fmt.Errorf("failed to find repo %d: %w", webhook.ParentID, err)
+	}
+
+	return repositoryInfoFrom(repo, s.urlProvider), nil
+}
+
+func sampleReference(triggerType enum.WebhookTrigger, repoInfo RepositoryInfo) ReferenceInfo {
+	switch triggerType {
+	case enum.WebhookTriggerTagCreated, enum.WebhookTriggerTagUpdated, enum.WebhookTriggerTagDeleted:
+		return ReferenceInfo{Name: "refs/tags/" + sampleTag, Repo: repoInfo}
+	default:
+		return ReferenceInfo{Name: gitReferenceNamePrefixBranch + sampleBranch, Repo: repoInfo}
+	}
+}
+
+// sampleCommitsSegment returns the commits segment for branch triggers (tag payloads don't contain commits).
+func sampleCommitsSegment(triggerType enum.WebhookTrigger, commit CommitInfo) ReferenceCommitsSegment {
+	if triggerType != enum.WebhookTriggerBranchCreated && triggerType != enum.WebhookTriggerBranchUpdated {
+		return ReferenceCommitsSegment{}
+	}
+
+	return ReferenceCommitsSegment{
+		Commits:           []CommitInfo{commit},
+		TotalCommitsCount: 1,
+		ChangedFiles:      &ChangedFilesInfo{Files: 1, Additions: 1, Deletions: 0},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// ErrNoPayloadSchema is returned in case there's no payload schema for the requested trigger.
+var ErrNoPayloadSchema = errors.New("no payload schema available for trigger")
+
+// payloadTypes maps each trigger to the type of the payload that's sent for it.
+// IMPORTANT: keep in sync with the payloads generated by the event handlers.
+var payloadTypes = map[enum.WebhookTrigger]any{
+	enum.WebhookTriggerBranchCreated:          ReferencePayload{},
+	enum.WebhookTriggerBranchUpdated:          ReferencePayload{},
+	enum.WebhookTriggerBranchDeleted:          ReferencePayload{},
+	enum.WebhookTriggerTagCreated:             ReferencePayload{},
+	enum.WebhookTriggerTagUpdated:             ReferencePayload{},
+	enum.WebhookTriggerTagDeleted:             ReferencePayload{},
+	enum.WebhookTriggerPullReqCreated:         PullReqCreatedPayload{},
+	enum.WebhookTriggerPullReqReopened:        PullReqReopenedPayload{},
+	enum.WebhookTriggerPullReqBranchUpdated:   PullReqBranchUpdatedPayload{},
+	enum.WebhookTriggerPullReqClosed:          PullReqClosedPayload{},
+	enum.WebhookTriggerPullReqReviewSubmitted: PullReqReviewSubmittedPayload{},
+	enum.WebhookTriggerPullReqCommentCreated:  PullReqCommentPayload{},
+	enum.WebhookTriggerPullReqCommentUpdated:  PullReqCommentPayload{},
+	enum.WebhookTriggerPullReqCommentResolved: PullReqCommentPayload{},
+}
+
+// PayloadSchema returns the JSON schema (draft-07) of the payload that's sent for the trigger
+// in the current payload version.
+func PayloadSchema(trigger enum.WebhookTrigger) (*jsonschema.Schema, error) {
+	payloadType, ok := payloadTypes[trigger]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrNoPayloadSchema, trigger)
+	}
+
+	reflector := jsonschema.Reflector{}
+	schema, err := reflector.Reflect(payloadType, jsonschema.RootRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payload schema for trigger '%s': %w", trigger, err)
+	}
+
+	schema.WithSchema("http://json-schema.org/draft-07/schema#")
+	schema.WithTitle(fmt.Sprintf("%s payload (version %s)", trigger, PayloadVersion))
+
+	return &schema, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPayloadSchemaAllTriggers(t *testing.T) {
+	triggers, _ := enum.GetAllWebhookTriggers()
+	for _, trigger := range triggers {
+		schema, err := PayloadSchema(trigger)
+		if err != nil {
+			t.Errorf("trigger %s: unexpected error: %s", trigger, err)
+			continue
+		}
+
+		if schema.Ref == nil || len(schema.Definitions) == 0 {
+			t.Errorf("trigger %s: expected schema with definitions", trigger)
+		}
+	}
+
+	if _, err := PayloadSchema("unknown"); err == nil {
+		t.Error("expected error for unknown trigger")
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/outbound"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -41,12 +42,10 @@ type Config struct {
 	UserAgentIdentity string
 	// HeaderIdentity specifies the identity used for headers in webhook calls (e.g. X-Gitness-Trigger, ...).
 	// NOTE: If no value is provided, the UserAgentIdentity will be used.
-	HeaderIdentity      string
-	EventReaderName     string
-	Concurrency         int
-	MaxRetries          int
-	AllowPrivateNetwork bool
-	AllowLoopback       bool
+	HeaderIdentity  string
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
 }
 
 func (c *Config) Prepare() error {
@@ -81,6 +80,7 @@ type Service struct {
 	urlProvider           url.Provider
 	repoStore             store.RepoStore
 	pullreqStore          store.PullReqStore
+	activityStore         store.PullReqActivityStore
 	principalStore        store.PrincipalStore
 	gitRPCClient          gitrpc.Interface
 	encrypter             encrypt.Encrypter
@@ -98,8 +98,9 @@ func NewService(ctx context.Context, config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
-	repoStore store.RepoStore, pullreqStore store.PullReqStore, urlProvider url.Provider,
-	principalStore store.PrincipalStore, gitRPCClient gitrpc.Interface, encrypter encrypt.Encrypter,
+	repoStore store.RepoStore, pullreqStore store.PullReqStore, activityStore store.PullReqActivityStore,
+	urlProvider url.Provider, principalStore store.PrincipalStore, gitRPCClient gitrpc.Interface,
+	encrypter encrypt.Encrypter, clientFactory *outbound.ClientFactory,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -109,16 +110,20 @@ func NewService(ctx context.Context, config Config,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
 		pullreqStore:          pullreqStore,
+		activityStore:         activityStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		gitRPCClient:          gitRPCClient,
 		encrypter:             encrypter,
 
-		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClient:   clientFactory.Client(outbound.ClientOptions{}),
+		insecureHTTPClient: clientFactory.Client(outbound.ClientOptions{InsecureSkipVerify: true}),
 
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClientInternal: clientFactory.Client(outbound.ClientOptions{AllowPrivateNetwork: true}),
+		insecureHTTPClientInternal: clientFactory.Client(outbound.ClientOptions{
+			InsecureSkipVerify:  true,
+			AllowPrivateNetwork: true,
+		}),
 
 		config: config,
 	}
@@ -163,6 +168,10 @@ func NewService(ctx context.Context, config Config,
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterCommentCreated(service.handleEventPullReqCommentCreated)
+			_ = r.RegisterCommentUpdated(service.handleEventPullReqCommentUpdated)
+			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
 
 			return nil
 		})
@@ -28,6 +28,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/harness/gitness/app/outbound"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -195,6 +196,8 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		execution.Duration = int64(time.Since(start))
 		execution.Created = time.Now().UnixMilli()
 
+		observeDelivery(triggerType, execution.Result, time.Duration(execution.Duration))
+
 		// TODO: what if saving execution failed? For now we will rerun it in case of error or not show it in history
 		err := s.webhookExecutionStore.Create(oCtx, &execution)
 		if err != nil {
@@ -221,6 +224,18 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 	ctx, cancel := context.WithTimeout(ctx, webhookTimeLimit)
 	defer cancel()
 
+	// send the request via the proxy of the webhook if configured
+	if webhook.ProxyURL != "" {
+		proxyURL, err := outbound.ParseProxyURL(webhook.ProxyURL)
+		if err != nil {
+			execution.Error = err.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return &execution, err
+		}
+
+		ctx = outbound.WithProxy(ctx, proxyURL)
+	}
+
 	// create request from webhook and body
 	req, err := s.prepareHTTPRequest(ctx, &execution, triggerType, webhook, body)
 	if err != nil {
@@ -336,6 +351,9 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add(s.toXHeader("Trigger"), string(triggerType))
+	req.Header.Add(s.toXHeader("Payload-Version"), PayloadVersion)
+	// the idempotency key stays the same for retries and retriggers of a trigger, allowing consumers to deduplicate.
+	req.Header.Add(s.toXHeader("Idempotency-Key"), execution.TriggerID)
 	req.Header.Add(s.toXHeader("Webhook-Id"), fmt.Sprint(webhook.ID))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -23,6 +23,19 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// PayloadVersion is the version of the webhook payload format, sent with every webhook call.
+//
+// Version history:
+//   - 1.0: branch, tag and pull request state triggers.
+//   - 1.1: pull request review and comment triggers.
+//   - 1.2: new commits and changed files summary for branch created and updated triggers.
+//
+// Ordering guarantees: each event triggers a webhook at least once, but calls for different events
+// aren't guaranteed to arrive in the order the events happened (e.g. due to retries).
+// Payloads always contain the latest state of the pull request and its comments at the time of the call,
+// consumers can use the `updated` time of comments to detect outdated calls.
+const PayloadVersion = "1.2"
+
 /*
  * The idea of segments is to expose similar fields using the same structure.
  * This makes consumption on webhook payloads easier as we ensure related webhooks have similar payload formats.
@@ -47,6 +60,14 @@ type ReferenceDetailsSegment struct {
 	Commit *CommitInfo `json:"commit,omitempty"`
 }
 
+// ReferenceCommitsSegment contains the commits introduced by a reference update for webhooks.
+// NOTE: Commits is bounded, TotalCommitsCount contains the total number of new commits.
+type ReferenceCommitsSegment struct {
+	Commits           []CommitInfo      `json:"commits,omitempty"`
+	TotalCommitsCount int               `json:"total_commits_count,omitempty"`
+	ChangedFiles      *ChangedFilesInfo `json:"changed_files,omitempty"`
+}
+
 // ReferenceUpdateSegment contains extra details for reference update related payloads for webhooks.
 type ReferenceUpdateSegment struct {
 	OldSHA string `json:"old_sha"`
@@ -63,6 +84,16 @@ type PullReqSegment struct {
 	PullReq PullReqInfo `json:"pull_req"`
 }
 
+// PullReqCommentSegment contains details for all pull req comment related payloads for webhooks.
+type PullReqCommentSegment struct {
+	Comment CommentInfo `json:"comment"`
+}
+
+// PullReqReviewSegment contains details for all pull req review related payloads for webhooks.
+type PullReqReviewSegment struct {
+	Review ReviewInfo `json:"review"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -157,6 +188,13 @@ func commitInfoFrom(commit gitrpc.Commit) CommitInfo {
 	}
 }
 
+// ChangedFilesInfo describes the summary of the files changed by a reference update for a webhook payload.
+type ChangedFilesInfo struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
 // SignatureInfo describes the commit signature related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type SignatureInfo struct {
@@ -193,3 +231,66 @@ type ReferenceInfo struct {
 	Name string         `json:"name"`
 	Repo RepositoryInfo `json:"repo"`
 }
+
+// CommentInfo describes the pullreq comment related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CommentInfo struct {
+	ID          int64            `json:"id"`
+	ParentID    *int64           `json:"parent_id,omitempty"`
+	Text        string           `json:"text"`
+	Created     int64            `json:"created"`
+	Updated     int64            `json:"updated"`
+	Edited      int64            `json:"edited"`
+	Resolved    *int64           `json:"resolved,omitempty"`
+	CodeComment *CodeCommentInfo `json:"code_comment,omitempty"`
+}
+
+// commentInfoFrom gets the CommentInfo from a types.PullReqActivity.
+func commentInfoFrom(act *types.PullReqActivity) CommentInfo {
+	info := CommentInfo{
+		ID:       act.ID,
+		ParentID: act.ParentID,
+		Text:     act.Text,
+		Created:  act.Created,
+		Updated:  act.Updated,
+		Edited:   act.Edited,
+		Resolved: act.Resolved,
+	}
+
+	if act.IsValidCodeComment() {
+		info.CodeComment = &CodeCommentInfo{
+			Path:         act.CodeComment.Path,
+			SourceSHA:    act.CodeComment.SourceSHA,
+			MergeBaseSHA: act.CodeComment.MergeBaseSHA,
+			LineNew:      act.CodeComment.LineNew,
+			SpanNew:      act.CodeComment.SpanNew,
+			LineOld:      act.CodeComment.LineOld,
+			SpanOld:      act.CodeComment.SpanOld,
+			Outdated:     act.CodeComment.Outdated,
+		}
+	}
+
+	return info
+}
+
+// CodeCommentInfo describes the code comment related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CodeCommentInfo struct {
+	Path         string `json:"path"`
+	SourceSHA    string `json:"source_sha"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+	LineNew      int    `json:"line_new"`
+	SpanNew      int    `json:"span_new"`
+	LineOld      int    `json:"line_old"`
+	SpanOld      int    `json:"span_old"`
+	Outdated     bool   `json:"outdated"`
+}
+
+// ReviewInfo describes the pullreq review related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ReviewInfo struct {
+	ID       int64                      `json:"id"`
+	Decision enum.PullReqReviewDecision `json:"decision"`
+	SHA      string                     `json:"sha"`
+	Message  string                     `json:"message"`
+}
@@ -19,6 +19,7 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/outbound"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -37,9 +38,10 @@ func ProvideService(ctx context.Context, config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
-	repoStore store.RepoStore, pullreqStore store.PullReqStore, urlProvider url.Provider,
-	principalStore store.PrincipalStore, gitRPCClient gitrpc.Interface, encrypter encrypt.Encrypter) (*Service, error) {
+	repoStore store.RepoStore, pullreqStore store.PullReqStore, activityStore store.PullReqActivityStore,
+	urlProvider url.Provider, principalStore store.PrincipalStore, gitRPCClient gitrpc.Interface,
+	encrypter encrypt.Encrypter, clientFactory *outbound.ClientFactory) (*Service, error) {
 	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore,
-		urlProvider, principalStore, gitRPCClient, encrypter)
+		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
+		urlProvider, principalStore, gitRPCClient, encrypter, clientFactory)
 }
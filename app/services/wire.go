@@ -15,12 +15,26 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/backup"
+	"github.com/harness/gitness/app/services/chatintegration"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/drain"
+	"github.com/harness/gitness/app/services/feed"
+	"github.com/harness/gitness/app/services/insights"
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/membershipexpiry"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/outbox"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/refwatch"
+	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/sbom"
+	"github.com/harness/gitness/app/services/scanning"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/lock"
 
 	"github.com/google/wire"
 )
@@ -30,12 +44,26 @@ var WireSet = wire.NewSet(
 )
 
 type Services struct {
-	Webhook         *webhook.Service
-	PullReq         *pullreq.Service
-	Trigger         *trigger.Service
-	JobScheduler    *job.Scheduler
-	MetricCollector *metric.Collector
-	Cleanup         *cleanup.Service
+	Webhook          *webhook.Service
+	PullReq          *pullreq.Service
+	Trigger          *trigger.Service
+	JobScheduler     *job.Scheduler
+	MetricCollector  *metric.Collector
+	Cleanup          *cleanup.Service
+	EventOutbox      *outbox.Dispatcher
+	LeaderElector    *lock.Elector
+	RepoSize         *reposize.Service
+	Notification     *notification.Service
+	ChatIntegration  *chatintegration.Service
+	Insights         *insights.Service
+	Scanning         *scanning.Service
+	SBOM             *sbom.Service
+	Feed             *feed.Service
+	RefWatch         *refwatch.Service
+	ReviewReminder   *reviewreminder.Service
+	MembershipExpiry *membershipexpiry.Service
+	Backup           *backup.Service
+	Drain            *drain.Service
 }
 
 func ProvideServices(
@@ -45,13 +73,41 @@ func ProvideServices(
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.Collector,
 	cleanupSvc *cleanup.Service,
+	eventOutbox *outbox.Dispatcher,
+	leaderElector *lock.Elector,
+	repoSizeSvc *reposize.Service,
+	notificationSvc *notification.Service,
+	chatIntegrationSvc *chatintegration.Service,
+	insightsSvc *insights.Service,
+	scanningSvc *scanning.Service,
+	sbomSvc *sbom.Service,
+	feedSvc *feed.Service,
+	refWatchSvc *refwatch.Service,
+	reviewReminderSvc *reviewreminder.Service,
+	membershipExpirySvc *membershipexpiry.Service,
+	backupSvc *backup.Service,
+	drainSvc *drain.Service,
 ) Services {
 	return Services{
-		Webhook:         webhooksSvc,
-		PullReq:         pullReqSvc,
-		Trigger:         triggerSvc,
-		JobScheduler:    jobScheduler,
-		MetricCollector: metricCollector,
-		Cleanup:         cleanupSvc,
+		Webhook:          webhooksSvc,
+		PullReq:          pullReqSvc,
+		Trigger:          triggerSvc,
+		JobScheduler:     jobScheduler,
+		MetricCollector:  metricCollector,
+		Cleanup:          cleanupSvc,
+		EventOutbox:      eventOutbox,
+		LeaderElector:    leaderElector,
+		RepoSize:         repoSizeSvc,
+		Notification:     notificationSvc,
+		ChatIntegration:  chatIntegrationSvc,
+		Insights:         insightsSvc,
+		Scanning:         scanningSvc,
+		SBOM:             sbomSvc,
+		Feed:             feedSvc,
+		RefWatch:         refWatchSvc,
+		ReviewReminder:   reviewReminderSvc,
+		MembershipExpiry: membershipExpirySvc,
+		Backup:           backupSvc,
+		Drain:            drainSvc,
 	}
 }
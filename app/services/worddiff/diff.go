@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worddiff
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/gitrpc"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// maxLineLength is the max length of lines, in characters, for which the word diff is computed.
+const maxLineLength = 1000
+
+// computeLines pairs the removed and added lines of every change between the old and new content
+// and returns the changed words of each pair.
+func computeLines(lang language, oldContent, newContent string) []gitrpc.WordDiffLine {
+	oldLines := splitLines(oldContent)
+	newLines := splitLines(newContent)
+
+	enc := newEncoder()
+	diffs := diffmatchpatch.New().DiffMainRunes(enc.encode(oldLines), enc.encode(newLines), false)
+
+	result := []gitrpc.WordDiffLine{}
+
+	var removed, added []int
+	flush := func() {
+		for i := 0; i < len(removed) && i < len(added); i++ {
+			line, ok := computeLine(lang, oldLines[removed[i]], newLines[added[i]])
+			if !ok {
+				continue
+			}
+			line.OldLine = removed[i] + 1
+			line.NewLine = added[i] + 1
+			result = append(result, line)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	oldIdx, newIdx := 0, 0
+	for _, d := range diffs {
+		n := runeCount(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			flush()
+			oldIdx += n
+			newIdx += n
+		case diffmatchpatch.DiffDelete:
+			for i := 0; i < n; i++ {
+				removed = append(removed, oldIdx)
+				oldIdx++
+			}
+		case diffmatchpatch.DiffInsert:
+			for i := 0; i < n; i++ {
+				added = append(added, newIdx)
+				newIdx++
+			}
+		}
+	}
+	flush()
+
+	return result
+}
+
+// computeLine returns the changed ranges of the old and new line.
+// It returns false if the lines have nothing in common, as highlighting the whole line doesn't add anything.
+func computeLine(lang language, oldLine, newLine string) (gitrpc.WordDiffLine, bool) {
+	if runeCount(oldLine) > maxLineLength || runeCount(newLine) > maxLineLength {
+		return gitrpc.WordDiffLine{}, false
+	}
+
+	oldTokens := lang.tokenize(oldLine)
+	newTokens := lang.tokenize(newLine)
+
+	enc := newEncoder()
+	diffs := diffmatchpatch.New().DiffMainRunes(enc.encode(oldTokens), enc.encode(newTokens), false)
+
+	line := gitrpc.WordDiffLine{
+		Old: []gitrpc.WordDiffRange{},
+		New: []gitrpc.WordDiffRange{},
+	}
+
+	common := false
+	oldIdx, newIdx := 0, 0 // token indexes
+	oldPos, newPos := 0, 0 // character positions
+	for _, d := range diffs {
+		n := runeCount(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			for i := 0; i < n; i++ {
+				common = common || strings.TrimSpace(oldTokens[oldIdx]) != ""
+				oldPos += runeCount(oldTokens[oldIdx])
+				newPos += runeCount(newTokens[newIdx])
+				oldIdx++
+				newIdx++
+			}
+		case diffmatchpatch.DiffDelete:
+			start := oldPos
+			for i := 0; i < n; i++ {
+				oldPos += runeCount(oldTokens[oldIdx])
+				oldIdx++
+			}
+			line.Old = appendRange(line.Old, start, oldPos)
+		case diffmatchpatch.DiffInsert:
+			start := newPos
+			for i := 0; i < n; i++ {
+				newPos += runeCount(newTokens[newIdx])
+				newIdx++
+			}
+			line.New = appendRange(line.New, start, newPos)
+		}
+	}
+
+	if !common || (len(line.Old) == 0 && len(line.New) == 0) {
+		return gitrpc.WordDiffLine{}, false
+	}
+
+	return line, true
+}
+
+// appendRange appends the range, merging it with the last range if they are adjacent.
+func appendRange(ranges []gitrpc.WordDiffRange, start, end int) []gitrpc.WordDiffRange {
+	if n := len(ranges); n > 0 && ranges[n-1].End == start {
+		ranges[n-1].End = end
+		return ranges
+	}
+
+	return append(ranges, gitrpc.WordDiffRange{Start: start, End: end})
+}
+
+// splitLines splits the content into lines, a trailing newline doesn't start a new line.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// encoder maps each distinct line or token to a single rune, so they can be diffed as characters.
+type encoder struct {
+	runes map[string]rune
+	next  rune
+}
+
+func newEncoder() *encoder {
+	return &encoder{
+		runes: map[string]rune{},
+		next:  1,
+	}
+}
+
+func (e *encoder) encode(values []string) []rune {
+	encoded := make([]rune, len(values))
+	for i, v := range values {
+		r, ok := e.runes[v]
+		if !ok {
+			r = e.next
+			e.runes[v] = r
+			e.next++
+			// skip the surrogate range, the runes are converted to strings by the diff library.
+			if e.next == 0xD800 {
+				e.next = 0xE000
+			}
+		}
+		encoded[i] = r
+	}
+
+	return encoded
+}
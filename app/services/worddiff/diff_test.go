@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worddiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/gitrpc"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		path string
+		line string
+		want []string
+	}{
+		{path: "main.go", line: "x := a.b(1)", want: []string{"x", " ", ":", "=", " ", "a", ".", "b", "(", "1", ")"}},
+		{path: "style.css", line: "margin-top: 0", want: []string{"margin-top", ":", " ", "0"}},
+		{path: "app.js", line: "$el.x", want: []string{"$el", ".", "x"}},
+	}
+	for _, test := range tests {
+		got := languageForPath(test.path).tokenize(test.line)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected %q, got %q", test.path, test.want, got)
+		}
+	}
+}
+
+func TestComputeLines(t *testing.T) {
+	oldContent := "package main\n\nfunc a() int { return 1 }\nvar x = 1\n"
+	newContent := "package main\n\nfunc a() int { return 42 }\nvar totally_different\n"
+
+	got := computeLines(languageForPath("main.go"), oldContent, newContent)
+
+	want := []gitrpc.WordDiffLine{
+		{
+			OldLine: 3,
+			NewLine: 3,
+			Old:     []gitrpc.WordDiffRange{{Start: 22, End: 23}},
+			New:     []gitrpc.WordDiffRange{{Start: 22, End: 24}},
+		},
+		{
+			OldLine: 4,
+			NewLine: 4,
+			Old:     []gitrpc.WordDiffRange{{Start: 4, End: 9}},
+			New:     []gitrpc.WordDiffRange{{Start: 4, End: 21}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
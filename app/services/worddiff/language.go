@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worddiff
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// language defines how lines of a file are split into tokens.
+type language struct {
+	name string
+	// identChars are the characters, besides letters, digits and '_', that are part of identifiers.
+	identChars string
+}
+
+var languageText = language{name: "text"}
+
+// languages maps file extensions to their language.
+var languages = map[string]language{
+	".c":     {name: "c"},
+	".h":     {name: "c"},
+	".cc":    {name: "cpp"},
+	".cpp":   {name: "cpp"},
+	".hpp":   {name: "cpp"},
+	".cs":    {name: "csharp"},
+	".go":    {name: "go"},
+	".java":  {name: "java", identChars: "$"},
+	".kt":    {name: "kotlin", identChars: "$"},
+	".scala": {name: "scala", identChars: "$"},
+	".rs":    {name: "rust"},
+	".swift": {name: "swift"},
+	".py":    {name: "python"},
+	".rb":    {name: "ruby", identChars: "?!@$"},
+	".php":   {name: "php", identChars: "$"},
+	".js":    {name: "javascript", identChars: "$"},
+	".jsx":   {name: "javascript", identChars: "$"},
+	".mjs":   {name: "javascript", identChars: "$"},
+	".ts":    {name: "typescript", identChars: "$"},
+	".tsx":   {name: "typescript", identChars: "$"},
+	".sh":    {name: "shell", identChars: "$"},
+	".bash":  {name: "shell", identChars: "$"},
+	".ps1":   {name: "powershell", identChars: "$-"},
+	".css":   {name: "css", identChars: "-"},
+	".scss":  {name: "scss", identChars: "-$"},
+	".less":  {name: "less", identChars: "-@"},
+	".html":  {name: "html", identChars: "-"},
+	".xml":   {name: "xml", identChars: "-:."},
+	".yaml":  {name: "yaml", identChars: "-"},
+	".yml":   {name: "yaml", identChars: "-"},
+	".toml":  {name: "toml", identChars: "-"},
+	".json":  {name: "json"},
+	".sql":   {name: "sql"},
+	".lisp":  {name: "lisp", identChars: "-?!*"},
+	".clj":   {name: "clojure", identChars: "-?!*"},
+	".el":    {name: "lisp", identChars: "-?!*"},
+	".md":    {name: "markdown"},
+}
+
+// languageForPath returns the language of the file based on its extension.
+func languageForPath(filePath string) language {
+	if lang, ok := languages[strings.ToLower(path.Ext(filePath))]; ok {
+		return lang
+	}
+
+	return languageText
+}
+
+// tokenize splits the line into identifiers, whitespace runs and single punctuation characters.
+func (l language) tokenize(line string) []string {
+	var tokens []string
+
+	start := 0
+	kind := tokenNone
+	for i, r := range line {
+		k := l.kindOf(r)
+		if k != kind || k == tokenPunct {
+			if i > start {
+				tokens = append(tokens, line[start:i])
+			}
+			start = i
+			kind = k
+		}
+	}
+
+	if start < len(line) {
+		tokens = append(tokens, line[start:])
+	}
+
+	return tokens
+}
+
+type tokenKind int
+
+const (
+	tokenNone tokenKind = iota
+	tokenIdent
+	tokenSpace
+	tokenPunct
+)
+
+func (l language) kindOf(r rune) tokenKind {
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(l.identChars, r):
+		return tokenIdent
+	case unicode.IsSpace(r):
+		return tokenSpace
+	default:
+		return tokenPunct
+	}
+}
+
+func runeCount(s string) int {
+	return utf8.RuneCountInString(s)
+}
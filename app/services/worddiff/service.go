@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worddiff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/gitrpc"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// maxBlobSize is the max size of files for which the word diff is computed.
+	maxBlobSize = 1 << 20
+
+	// cacheDuration is how long computed word diffs are kept in memory.
+	// Word diffs only depend on the blob pair, so they never get stale.
+	cacheDuration = 30 * time.Minute
+)
+
+// Service computes word-level intra-line diffs of changed files.
+type Service struct {
+	gitRPCClient gitrpc.Interface
+	cache        cache.Cache[cacheKey, *gitrpc.WordDiff]
+}
+
+type cacheKey struct {
+	repoUID string
+	oldSHA  string
+	newSHA  string
+	lang    language
+}
+
+func NewService(gitRPCClient gitrpc.Interface) *Service {
+	s := &Service{
+		gitRPCClient: gitRPCClient,
+	}
+	s.cache = cache.New[cacheKey, *gitrpc.WordDiff](wordDiffGetter{s: s}, cacheDuration)
+
+	return s
+}
+
+// Stream wraps the stream of file diffs and adds the word diff to each modified text file.
+func (s *Service) Stream(
+	ctx context.Context,
+	repo *types.Repository,
+	stream types.Stream[*gitrpc.FileDiff],
+) types.Stream[*gitrpc.FileDiff] {
+	return &wordDiffStream{
+		ctx:    ctx,
+		s:      s,
+		repo:   repo,
+		stream: stream,
+	}
+}
+
+// Get returns the word diff of the file, nil if it can't be computed for the file.
+func (s *Service) Get(ctx context.Context, repo *types.Repository, file *gitrpc.FileDiff) (*gitrpc.WordDiff, error) {
+	if file.IsBinary || file.IsSubmodule || file.IsCollapsed || file.Patch == nil ||
+		file.OldSHA == "" || file.SHA == "" || file.OldSHA == file.SHA {
+		return nil, nil
+	}
+
+	if file.Status != gitrpc.FileDiffStatusModified && file.Status != gitrpc.FileDiffStatusRenamed {
+		return nil, nil
+	}
+
+	return s.cache.Get(ctx, cacheKey{
+		repoUID: repo.GitUID,
+		oldSHA:  file.OldSHA,
+		newSHA:  file.SHA,
+		lang:    languageForPath(file.Path),
+	})
+}
+
+type wordDiffStream struct {
+	ctx    context.Context
+	s      *Service
+	repo   *types.Repository
+	stream types.Stream[*gitrpc.FileDiff]
+}
+
+func (w *wordDiffStream) Next() (*gitrpc.FileDiff, error) {
+	file, err := w.stream.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	file.WordDiff, err = w.s.Get(w.ctx, w.repo, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute word diff of '%s': %w", file.Path, err)
+	}
+
+	return file, nil
+}
+
+type wordDiffGetter struct {
+	s *Service
+}
+
+func (g wordDiffGetter) Find(ctx context.Context, key cacheKey) (*gitrpc.WordDiff, error) {
+	readParams := gitrpc.ReadParams{RepoUID: key.repoUID}
+
+	oldContent, ok, err := g.s.readBlob(ctx, readParams, key.oldSHA)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	newContent, ok, err := g.s.readBlob(ctx, readParams, key.newSHA)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &gitrpc.WordDiff{
+		Language: key.lang.name,
+		Lines:    computeLines(key.lang, oldContent, newContent),
+	}, nil
+}
+
+// readBlob returns the content of the blob, false if it exceeds the max size.
+func (s *Service) readBlob(ctx context.Context, readParams gitrpc.ReadParams, sha string) (string, bool, error) {
+	blob, err := s.gitRPCClient.GetBlob(ctx, &gitrpc.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  maxBlobSize,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read blob '%s': %w", sha, err)
+	}
+
+	if blob.Size > maxBlobSize {
+		return "", false, nil
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read content of blob '%s': %w", sha, err)
+	}
+
+	return string(content), true, nil
+}
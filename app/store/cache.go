@@ -15,8 +15,11 @@
 package store
 
 import (
+	"context"
+
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type (
@@ -28,4 +31,41 @@ type (
 
 	// RepoGitInfoCache caches repository IDs to values GitUID.
 	RepoGitInfoCache cache.Cache[int64, *types.RepositoryGitInfo]
+
+	// RepoCache caches repository IDs to repositories.
+	RepoCache cache.Cache[int64, *types.Repository]
+
+	// RepoEvictor evicts repositories from the repository caches of all instances.
+	RepoEvictor cache.Evictor[int64]
+
+	// SpaceCache caches space IDs to spaces.
+	SpaceCache cache.Cache[int64, *types.Space]
+
+	// SpaceEvictor evicts spaces from the space caches of all instances.
+	SpaceEvictor cache.Evictor[int64]
+
+	// PrincipalCache caches principal IDs to principals.
+	PrincipalCache cache.Cache[int64, *types.Principal]
+
+	// SpaceTreeCache caches space IDs to the flat list of nodes of the space tree beneath the space.
+	SpaceTreeCache cache.Cache[int64, []*types.SpaceTreeNode]
+
+	// PrincipalEvictor evicts principals from the principal caches of all instances.
+	PrincipalEvictor cache.Evictor[int64]
+
+	// InstanceSettingCache caches the keys of instance settings to their values changed at runtime.
+	// The value is nil if the setting wasn't changed at runtime.
+	InstanceSettingCache cache.Cache[enum.InstanceSettingKey, *types.InstanceSetting]
+
+	// InstanceSettingEvictor evicts instance settings from the instance setting caches of all instances.
+	InstanceSettingEvictor cache.Evictor[enum.InstanceSettingKey]
+
+	// PushedBranchCache stores the branch most recently pushed by a principal to a repository for a short time.
+	PushedBranchCache interface {
+		// Get returns the branch most recently pushed by the principal to the repository.
+		Get(ctx context.Context, repoID, principalID int64) (*types.PushedBranch, error)
+
+		// Set stores the branch as the one most recently pushed by the principal to the repository.
+		Set(ctx context.Context, pushed *types.PushedBranch) error
+	}
 )
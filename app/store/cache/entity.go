@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	topicEvictRepo      = "evict_repo"
+	topicEvictSpace     = "evict_space"
+	topicEvictPrincipal = "evict_principal"
+
+	topicEvictInstanceSetting = "evict_instance_setting"
+)
+
+// newEntityCache returns a read-through cache of entities identified by their ID
+// using the cache provider from the config.
+func newEntityCache[V any](
+	config *types.Config,
+	redisClient redis.UniversalClient,
+	name string,
+	getter cache.Getter[int64, V],
+) cache.Cache[int64, V] {
+	switch config.Cache.Provider {
+	case cache.ProviderRedis:
+		return cache.NewRedis[int64, V](
+			redisClient,
+			getter,
+			func(id int64) string {
+				return fmt.Sprintf("cache:%s:%d", name, id)
+			},
+			cache.GobCodec[V]{},
+			config.Cache.TTL,
+		)
+	case cache.ProviderMemory:
+		fallthrough
+	default:
+		return cache.New[int64, V](getter, config.Cache.TTL)
+	}
+}
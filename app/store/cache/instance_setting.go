@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// instanceSettingCacheGetter is used to hook an InstanceSettingStore as source of an InstanceSettingCache.
+// Settings that weren't changed at runtime are cached as nil, so they don't hit the database on every lookup.
+type instanceSettingCacheGetter struct {
+	instanceSettingStore store.InstanceSettingStore
+}
+
+func (g *instanceSettingCacheGetter) Find(
+	ctx context.Context,
+	key enum.InstanceSettingKey,
+) (*types.InstanceSetting, error) {
+	setting, err := g.instanceSettingStore.Find(ctx, key)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // nil is the cached value of settings that weren't changed
+	}
+
+	return setting, err
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// pushedBranchTTL is the duration for which a pushed branch is remembered.
+const pushedBranchTTL = 1 * time.Hour
+
+type pushedBranchKey struct {
+	repoID      int64
+	principalID int64
+}
+
+// newPushedBranchCache returns a pushed branch cache using the cache provider from the config.
+func newPushedBranchCache(
+	config *types.Config,
+	redisClient redis.UniversalClient,
+) store.PushedBranchCache {
+	switch config.Cache.Provider {
+	case cache.ProviderRedis:
+		return &pushedBranchRedisCache{
+			client: redisClient,
+			ttl:    pushedBranchTTL,
+		}
+	case cache.ProviderMemory:
+		fallthrough
+	default:
+		return &pushedBranchMemoryCache{
+			entries: make(map[pushedBranchKey]*types.PushedBranch),
+			ttl:     pushedBranchTTL,
+		}
+	}
+}
+
+type pushedBranchMemoryCache struct {
+	mx      sync.Mutex
+	entries map[pushedBranchKey]*types.PushedBranch
+	ttl     time.Duration
+}
+
+// Get implements store.PushedBranchCache.
+func (c *pushedBranchMemoryCache) Get(
+	_ context.Context,
+	repoID int64,
+	principalID int64,
+) (*types.PushedBranch, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	pushed, ok := c.entries[pushedBranchKey{repoID: repoID, principalID: principalID}]
+	if !ok || time.Since(time.UnixMilli(pushed.Pushed)) > c.ttl {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return pushed, nil
+}
+
+// Set implements store.PushedBranchCache. Expired entries are purged on every write.
+func (c *pushedBranchMemoryCache) Set(_ context.Context, pushed *types.PushedBranch) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for key, entry := range c.entries {
+		if time.Since(time.UnixMilli(entry.Pushed)) > c.ttl {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[pushedBranchKey{repoID: pushed.RepoID, principalID: pushed.PrincipalID}] = pushed
+
+	return nil
+}
+
+type pushedBranchRedisCache struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+func pushedBranchRedisKey(repoID, principalID int64) string {
+	return fmt.Sprintf("cache:pushed_branch:%d:%d", repoID, principalID)
+}
+
+// Get implements store.PushedBranchCache.
+func (c *pushedBranchRedisCache) Get(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+) (*types.PushedBranch, error) {
+	raw, err := c.client.Get(ctx, pushedBranchRedisKey(repoID, principalID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pushed branch from redis: %w", err)
+	}
+
+	pushed := &types.PushedBranch{}
+	if err = json.Unmarshal([]byte(raw), pushed); err != nil {
+		return nil, fmt.Errorf("failed to decode pushed branch: %w", err)
+	}
+
+	return pushed, nil
+}
+
+// Set implements store.PushedBranchCache.
+func (c *pushedBranchRedisCache) Set(ctx context.Context, pushed *types.PushedBranch) error {
+	raw, err := json.Marshal(pushed)
+	if err != nil {
+		return fmt.Errorf("failed to encode pushed branch: %w", err)
+	}
+
+	err = c.client.Set(ctx, pushedBranchRedisKey(pushed.RepoID, pushed.PrincipalID), raw, c.ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set pushed branch in redis: %w", err)
+	}
+
+	return nil
+}
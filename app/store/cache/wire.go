@@ -15,12 +15,16 @@
 package cache
 
 import (
+	"context"
 	"time"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
 )
 
@@ -29,6 +33,16 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalInfoCache,
 	ProvidePathCache,
 	ProvideRepoGitInfoCache,
+	ProvideRepoEvictor,
+	ProvideRepoCache,
+	ProvideSpaceEvictor,
+	ProvideSpaceCache,
+	ProvidePrincipalEvictor,
+	ProvidePrincipalCache,
+	ProvideSpaceTreeCache,
+	ProvidePushedBranchCache,
+	ProvideInstanceSettingEvictor,
+	ProvideInstanceSettingCache,
 )
 
 // ProvidePrincipalInfoCache provides a cache for storing types.PrincipalInfo objects.
@@ -55,3 +69,92 @@ func ProvidePathCache(
 func ProvideRepoGitInfoCache(getter store.RepoGitInfoView) store.RepoGitInfoCache {
 	return cache.New[int64, *types.RepositoryGitInfo](getter, 15*time.Minute)
 }
+
+func ProvideRepoEvictor(bus pubsub.PubSub) store.RepoEvictor {
+	return cache.NewPubSubEvictor[int64](topicEvictRepo, bus)
+}
+
+func ProvideRepoCache(
+	ctx context.Context,
+	config *types.Config,
+	getter store.RepoStore,
+	bus pubsub.PubSub,
+	redisClient redis.UniversalClient,
+) store.RepoCache {
+	c := newEntityCache[*types.Repository](config, redisClient, "repo", getter)
+	cache.NewPubSubEvictor[int64](topicEvictRepo, bus).Subscribe(ctx, c)
+	return c
+}
+
+func ProvideSpaceEvictor(bus pubsub.PubSub) store.SpaceEvictor {
+	return cache.NewPubSubEvictor[int64](topicEvictSpace, bus)
+}
+
+func ProvideSpaceCache(
+	ctx context.Context,
+	config *types.Config,
+	getter store.SpaceStore,
+	bus pubsub.PubSub,
+	redisClient redis.UniversalClient,
+) store.SpaceCache {
+	c := newEntityCache[*types.Space](config, redisClient, "space", getter)
+	cache.NewPubSubEvictor[int64](topicEvictSpace, bus).Subscribe(ctx, c)
+	return c
+}
+
+func ProvidePrincipalEvictor(bus pubsub.PubSub) store.PrincipalEvictor {
+	return cache.NewPubSubEvictor[int64](topicEvictPrincipal, bus)
+}
+
+// ProvidePrincipalCache provides the principal cache.
+// Evictions of principals are applied to the principal info cache as well.
+func ProvidePrincipalCache(
+	ctx context.Context,
+	config *types.Config,
+	getter store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	bus pubsub.PubSub,
+	redisClient redis.UniversalClient,
+) store.PrincipalCache {
+	c := newEntityCache[*types.Principal](config, redisClient, "principal", getter)
+	cache.NewPubSubEvictor[int64](topicEvictPrincipal, bus).Subscribe(ctx, c, principalInfoCache)
+	return c
+}
+
+// ProvideSpaceTreeCache provides a cache for storing the space trees with their statistics.
+// The statistics change with every push, so entries are only kept for a short time and never evicted.
+func ProvideSpaceTreeCache(spaceStore store.SpaceStore) store.SpaceTreeCache {
+	return cache.New[int64, []*types.SpaceTreeNode](
+		&spaceTreeCacheGetter{
+			spaceStore: spaceStore,
+		},
+		1*time.Minute)
+}
+
+// ProvidePushedBranchCache provides the cache of the branches recently pushed by users.
+func ProvidePushedBranchCache(
+	config *types.Config,
+	redisClient redis.UniversalClient,
+) store.PushedBranchCache {
+	return newPushedBranchCache(config, redisClient)
+}
+
+func ProvideInstanceSettingEvictor(bus pubsub.PubSub) store.InstanceSettingEvictor {
+	return cache.NewPubSubEvictor[enum.InstanceSettingKey](topicEvictInstanceSetting, bus)
+}
+
+// ProvideInstanceSettingCache provides the cache of the instance settings changed at runtime.
+// Every instance keeps its own copy in memory, changes are propagated with evictions.
+func ProvideInstanceSettingCache(
+	ctx context.Context,
+	instanceSettingStore store.InstanceSettingStore,
+	bus pubsub.PubSub,
+) store.InstanceSettingCache {
+	c := cache.New[enum.InstanceSettingKey, *types.InstanceSetting](
+		&instanceSettingCacheGetter{
+			instanceSettingStore: instanceSettingStore,
+		},
+		1*time.Minute)
+	cache.NewPubSubEvictor[enum.InstanceSettingKey](topicEvictInstanceSetting, bus).Subscribe(ctx, c)
+	return c
+}
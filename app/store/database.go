@@ -39,7 +39,7 @@ type (
 		// If a UID isn't found, it's not returned in the list.
 		FindManyByUID(ctx context.Context, uids []string) ([]*types.Principal, error)
 
-		// FindByEmail finds the principal by email.
+		// FindByEmail finds the principal by its primary or any verified email.
 		FindByEmail(ctx context.Context, email string) (*types.Principal, error)
 
 		/*
@@ -100,6 +100,28 @@ type (
 		CountServiceAccounts(ctx context.Context,
 			parentType enum.ParentResourceType, parentID int64) (int64, error)
 
+		/*
+		 * USER GROUP RELATED OPERATIONS.
+		 */
+
+		// FindUserGroup finds the user group by id.
+		FindUserGroup(ctx context.Context, id int64) (*types.UserGroup, error)
+
+		// FindUserGroupByUID finds the user group by uid.
+		FindUserGroupByUID(ctx context.Context, uid string) (*types.UserGroup, error)
+
+		// CreateUserGroup saves the user group.
+		CreateUserGroup(ctx context.Context, group *types.UserGroup) error
+
+		// UpdateUserGroup updates the user group details.
+		UpdateUserGroup(ctx context.Context, group *types.UserGroup) error
+
+		// DeleteUserGroup deletes the user group.
+		DeleteUserGroup(ctx context.Context, id int64) error
+
+		// ListUserGroups returns a list of user groups defined in a space.
+		ListUserGroups(ctx context.Context, spaceID int64) ([]*types.UserGroup, error)
+
 		/*
 		 * SERVICE RELATED OPERATIONS.
 		 */
@@ -146,6 +168,16 @@ type (
 
 		// DeletePrimarySegment deletes the primary segment of a space.
 		DeletePrimarySegment(ctx context.Context, spaceID int64) error
+
+		// AliasPrimarySegment turns the primary segment of a space into an alias,
+		// which keeps the path resolving to the space.
+		AliasPrimarySegment(ctx context.Context, spaceID int64) error
+
+		// DeleteAliasSegment deletes the alias segment with the provided uid of any space within a parent.
+		DeleteAliasSegment(ctx context.Context, parentID int64, uid string) error
+
+		// DeleteSegments deletes all segments (primary and aliases) of a space.
+		DeleteSegments(ctx context.Context, spaceID int64) error
 	}
 
 	// SpaceStore defines the space data storage.
@@ -156,6 +188,10 @@ type (
 		// FindByRef finds the space using the spaceRef as either the id or the space path.
 		FindByRef(ctx context.Context, spaceRef string) (*types.Space, error)
 
+		// FindByRefAndDeleted finds the space deleted at the provided time
+		// using the spaceRef as either the id or the space path.
+		FindByRefAndDeleted(ctx context.Context, spaceRef string, deleted int64) (*types.Space, error)
+
 		// Create creates a new space
 		Create(ctx context.Context, space *types.Space) error
 
@@ -166,6 +202,16 @@ type (
 		UpdateOptLock(ctx context.Context, space *types.Space,
 			mutateFn func(space *types.Space) error) (*types.Space, error)
 
+		// SoftDelete moves the space to the trash.
+		SoftDelete(ctx context.Context, id int64, deletedAt int64) error
+
+		// Restore restores the space deleted at the provided time.
+		Restore(ctx context.Context, id int64, deletedAt int64) error
+
+		// Purge deletes the space deleted at the provided time permanently, including all its descendants.
+		// Spaces that still contain repositories aren't purged.
+		Purge(ctx context.Context, id int64, deletedAt int64) error
+
 		// Delete deletes the space.
 		Delete(ctx context.Context, id int64) error
 
@@ -174,6 +220,19 @@ type (
 
 		// List returns a list of child spaces in a space.
 		List(ctx context.Context, id int64, opts *types.SpaceFilter) ([]*types.Space, error)
+
+		// ListTree returns the space and all its descendants with the statistics of each space,
+		// ordered by depth. Paths, totals and children of the nodes aren't set.
+		ListTree(ctx context.Context, id int64) ([]*types.SpaceTreeNode, error)
+
+		// ListDeletedTreeIDs returns the IDs of the space and all its descendants deleted at the provided time.
+		ListDeletedTreeIDs(ctx context.Context, id int64, deletedAt int64) ([]int64, error)
+
+		// ListDeleted returns the deleted child spaces of a space.
+		ListDeleted(ctx context.Context, id int64) ([]*types.Space, error)
+
+		// ListDeletedBefore returns up to limit spaces deleted before the provided time.
+		ListDeletedBefore(ctx context.Context, deletedBefore int64, limit int) ([]*types.Space, error)
 	}
 
 	// RepoStore defines the repository data storage.
@@ -184,6 +243,10 @@ type (
 		// FindByRef finds the repo using the repoRef as either the id or the repo path.
 		FindByRef(ctx context.Context, repoRef string) (*types.Repository, error)
 
+		// FindByRefAndDeleted finds the repo deleted at the provided time
+		// using the repoRef as either the id or the repo path.
+		FindByRefAndDeleted(ctx context.Context, repoRef string, deleted int64) (*types.Repository, error)
+
 		// Create a new repo.
 		Create(ctx context.Context, repo *types.Repository) error
 
@@ -194,6 +257,18 @@ type (
 		UpdateOptLock(ctx context.Context, repo *types.Repository,
 			mutateFn func(repository *types.Repository) error) (*types.Repository, error)
 
+		// UpdateSize updates the storage size (in KiB) of the repo.
+		UpdateSize(ctx context.Context, id int64, size int64) error
+
+		// SoftDelete moves the repo to the trash.
+		SoftDelete(ctx context.Context, id int64, deletedAt int64) error
+
+		// Restore restores the repo deleted at the provided time using the provided uid.
+		Restore(ctx context.Context, id int64, deletedAt int64, uid string) error
+
+		// Purge deletes the repo deleted at the provided time permanently.
+		Purge(ctx context.Context, id int64, deletedAt int64) error
+
 		// Delete the repo.
 		Delete(ctx context.Context, id int64) error
 
@@ -202,6 +277,24 @@ type (
 
 		// List returns a list of repos in a space.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
+		// ListByParentIDs returns all repositories of the provided spaces.
+		ListByParentIDs(ctx context.Context, parentIDs []int64) ([]*types.Repository, error)
+
+		// ListByIDs returns all (not deleted) repositories with the provided ids ordered by path.
+		ListByIDs(ctx context.Context, ids []int64) ([]*types.Repository, error)
+
+		// ListDeletedByParentIDs returns all repositories of the provided spaces deleted at the provided time.
+		ListDeletedByParentIDs(ctx context.Context, parentIDs []int64, deletedAt int64) ([]*types.Repository, error)
+
+		// ListDeleted returns the deleted repositories of a space.
+		ListDeleted(ctx context.Context, parentID int64) ([]*types.Repository, error)
+
+		// ListDeletedBefore returns up to limit repositories deleted before the provided time.
+		ListDeletedBefore(ctx context.Context, deletedBefore int64, limit int) ([]*types.Repository, error)
+
+		// ListAll returns up to limit non-deleted repositories of all spaces with an id greater than afterID.
+		ListAll(ctx context.Context, afterID int64, limit int) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -213,15 +306,52 @@ type (
 	MembershipStore interface {
 		Find(ctx context.Context, key types.MembershipKey) (*types.Membership, error)
 		FindUser(ctx context.Context, key types.MembershipKey) (*types.MembershipUser, error)
+		// FindManyForPrincipal returns the memberships of the principal in any of the provided spaces,
+		// including the memberships of the user groups the principal is a (transitive) member of.
+		FindManyForPrincipal(ctx context.Context, principalID int64, spaceIDs []int64) ([]types.Membership, error)
 		Create(ctx context.Context, membership *types.Membership) error
 		Update(ctx context.Context, membership *types.Membership) error
 		Delete(ctx context.Context, key types.MembershipKey) error
+
+		// DeleteExpired deletes the membership if it's expired at the provided time,
+		// it returns false if the membership doesn't exist or isn't expired.
+		DeleteExpired(ctx context.Context, key types.MembershipKey, now int64) (bool, error)
+
+		// ListExpiring returns the memberships (including already expired ones) that expire before the provided time.
+		ListExpiring(ctx context.Context, before int64) ([]types.Membership, error)
+
+		// UpdateExpiryNotified sets the time the member got notified about the upcoming expiry of the membership.
+		UpdateExpiryNotified(ctx context.Context, key types.MembershipKey, notified int64) error
+
+		// ListPrincipalIDsByRole returns the principals with an unexpired membership
+		// of the provided role in any of the provided spaces.
+		ListPrincipalIDsByRole(ctx context.Context, spaceIDs []int64, role enum.MembershipRole) ([]int64, error)
+
 		CountUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) (int64, error)
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
 		ListSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) ([]types.MembershipSpace, error)
 	}
 
+	// UserGroupMemberStore defines the user group member data storage.
+	UserGroupMemberStore interface {
+		// Create adds the principal to the user group.
+		Create(ctx context.Context, member *types.UserGroupMember) error
+
+		// Delete removes the principal from the user group.
+		Delete(ctx context.Context, groupID, principalID int64) error
+
+		// List returns the direct members of the user group.
+		List(ctx context.Context, groupID int64) ([]*types.UserGroupMember, error)
+
+		// ListUserIDs returns the IDs of all users of the user group, including users of nested groups.
+		ListUserIDs(ctx context.Context, groupID int64) ([]int64, error)
+
+		// ListGroupIDs returns the IDs of all user groups the principal is a member of,
+		// either directly or through nested groups.
+		ListGroupIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
 	// TokenStore defines the token data storage.
 	TokenStore interface {
 		// Find finds the token by id
@@ -284,6 +414,11 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// ListAging returns up to limit open pull requests of the space (including all subspaces),
+		// that were either created before createdBefore or have no activity since idleBefore.
+		ListAging(ctx context.Context, spaceID int64, createdBefore, idleBefore int64,
+			limit int) ([]*types.PullReqAging, error)
 	}
 
 	PullReqActivityStore interface {
@@ -342,6 +477,24 @@ type (
 		Create(ctx context.Context, v *types.PullReqReview) error
 	}
 
+	// PullReqPushStore defines the storage of the pushes to the source branch of pull requests.
+	PullReqPushStore interface {
+		// Find returns the push of the pull request with the provided number.
+		Find(ctx context.Context, prID, number int64) (*types.PullReqPush, error)
+
+		// FindBySourceSHA returns the latest push of the pull request with the provided source sha.
+		FindBySourceSHA(ctx context.Context, prID int64, sha string) (*types.PullReqPush, error)
+
+		// FindLatest returns the latest push of the pull request.
+		FindLatest(ctx context.Context, prID int64) (*types.PullReqPush, error)
+
+		// Create records a new push, the number of the push is assigned by the store.
+		Create(ctx context.Context, push *types.PullReqPush) error
+
+		// List returns all pushes of the pull request, ordered by number.
+		List(ctx context.Context, prID int64) ([]*types.PullReqPush, error)
+	}
+
 	// PullReqReviewerStore defines the pull request reviewer storage.
 	PullReqReviewerStore interface {
 		// Find returns the pull request reviewer or an error if it doesn't exist.
@@ -358,6 +511,29 @@ type (
 
 		// List returns all pull request reviewers for the pull request.
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
+
+		// UpdateReminded sets the time the reviewer was last reminded about the pending review.
+		UpdateReminded(ctx context.Context, prID, principalID, reminded int64) error
+
+		// ListPendingReminders returns the requested reviewers of open pull requests that haven't reviewed yet,
+		// and were neither requested nor reminded after the provided time.
+		ListPendingReminders(ctx context.Context, before int64) ([]*types.PullReqReviewer, error)
+	}
+
+	// PullReqSubscriberStore defines the pull request subscriber data storage.
+	PullReqSubscriberStore interface {
+		// Find returns the subscription of the principal to the pull request.
+		Find(ctx context.Context, prID, principalID int64) (*types.PullReqSubscriber, error)
+
+		// Upsert creates or overwrites the subscription of the principal to the pull request.
+		Upsert(ctx context.Context, subscriber *types.PullReqSubscriber) error
+
+		// CreateIfNotExists creates the subscription unless the principal already has one
+		// (including an explicit unsubscription).
+		CreateIfNotExists(ctx context.Context, subscriber *types.PullReqSubscriber) error
+
+		// List returns all subscription entries of the pull request, including unsubscribed ones.
+		List(ctx context.Context, prID int64) ([]*types.PullReqSubscriber, error)
 	}
 
 	// PullReqFileViewStore stores information about what file a user viewed.
@@ -400,6 +576,9 @@ type (
 		// List lists the webhooks for a given parent type and id.
 		List(ctx context.Context, parentType enum.WebhookParent, parentID int64,
 			opts *types.WebhookFilter) ([]*types.Webhook, error)
+
+		// ListAfter lists up to limit webhooks of all parents with an ID greater than afterID, ordered by ID.
+		ListAfter(ctx context.Context, afterID int64, limit int) ([]*types.Webhook, error)
 	}
 
 	// WebhookExecutionStore defines the webhook execution data storage.
@@ -410,8 +589,11 @@ type (
 		// Create creates a new webhook execution entry.
 		Create(ctx context.Context, hook *types.WebhookExecution) error
 
-		// DeleteOld removes all executions that are older than the provided time.
-		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+		// DeleteOld removes all executions of the webhook that are older than the provided time.
+		DeleteOld(ctx context.Context, webhookID int64, olderThan time.Time) (int64, error)
+
+		// DeleteExcess removes all executions of the webhook except the latest keep executions.
+		DeleteExcess(ctx context.Context, webhookID int64, keep int) (int64, error)
 
 		// ListForWebhook lists the webhook executions for a given webhook id.
 		ListForWebhook(ctx context.Context, webhookID int64,
@@ -431,6 +613,9 @@ type (
 		// List returns a list of status check results for a specific commit in a repo.
 		List(ctx context.Context, repoID int64, commitSHA string, opts types.CheckListOptions) ([]types.Check, error)
 
+		// ListAll returns all status check results for a specific commit in a repo.
+		ListAll(ctx context.Context, repoID int64, commitSHA string) ([]types.Check, error)
+
 		// ListRecent returns a list of recently executed status checks in a repository.
 		ListRecent(ctx context.Context, repoID int64, since time.Time) ([]string, error)
 	}
@@ -442,6 +627,9 @@ type (
 		// List returns a list of required status checks for a repo.
 		List(ctx context.Context, repoID int64) ([]*types.ReqCheck, error)
 
+		// ListForBranch returns a list of required status checks that apply to a branch of a repo.
+		ListForBranch(ctx context.Context, repoID int64, branch string) ([]*types.ReqCheck, error)
+
 		// Delete removes a required status checks for a repo.
 		Delete(ctx context.Context, repoID, reqCheckID int64) error
 	}
@@ -450,6 +638,12 @@ type (
 		// Find fetches a job by its unique identifier.
 		Find(ctx context.Context, uid string) (*types.Job, error)
 
+		// List returns a list of jobs matching the provided filter, most recently created first.
+		List(ctx context.Context, filter types.JobFilter) ([]*types.Job, error)
+
+		// Count returns the number of jobs matching the provided filter.
+		Count(ctx context.Context, filter types.JobFilter) (int64, error)
+
 		// ListByGroupID fetches all jobs for a group id
 		ListByGroupID(ctx context.Context, groupID string) ([]*types.Job, error)
 
@@ -475,6 +669,9 @@ type (
 		// CountRunning returns number of jobs that are currently being run.
 		CountRunning(ctx context.Context) (int, error)
 
+		// CountReady returns number of jobs that are ready for execution.
+		CountReady(ctx context.Context, now time.Time) (int, error)
+
 		// ListReady returns a list of jobs that are ready for execution.
 		ListReady(ctx context.Context, now time.Time, limit int) ([]*types.Job, error)
 
@@ -488,6 +685,621 @@ type (
 		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
 	}
 
+	EventOutboxStore interface {
+		// Create persists a new message in the outbox.
+		Create(ctx context.Context, msg *types.EventOutboxMessage) error
+
+		// ListReady returns up to limit messages that are ready for delivery, in the order they have been created.
+		ListReady(ctx context.Context, now time.Time, limit int) ([]*types.EventOutboxMessage, error)
+
+		// UpdateAttempt updates the delivery attempt information of a message.
+		UpdateAttempt(ctx context.Context, msg *types.EventOutboxMessage) error
+
+		// Delete removes a delivered message from the outbox.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	EventDeadLetterStore interface {
+		// Find returns the dead letter with the provided id.
+		Find(ctx context.Context, id int64) (*types.EventDeadLetter, error)
+
+		// Create persists a new dead letter.
+		Create(ctx context.Context, deadLetter *types.EventDeadLetter) error
+
+		// List returns the dead letters matching the filter, newest first.
+		List(ctx context.Context, filter types.EventDeadLetterFilter) ([]*types.EventDeadLetter, error)
+
+		// Count returns the number of dead letters matching the filter.
+		Count(ctx context.Context, filter types.EventDeadLetterFilter) (int64, error)
+
+		// Delete removes a dead letter.
+		Delete(ctx context.Context, id int64) error
+
+		// DeleteOld removes all dead letters that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	RepositoryShardStore interface {
+		// Find returns the shard of the repository with the provided git uid.
+		Find(ctx context.Context, gitUID string) (*types.RepositoryShard, error)
+
+		// Create persists the shard of a repository, unless the repository is already assigned to a shard.
+		Create(ctx context.Context, repoShard *types.RepositoryShard) error
+
+		// CountByShard returns the number of repositories stored on each shard.
+		CountByShard(ctx context.Context) (map[string]int64, error)
+	}
+
+	// SpaceQuotaStore defines the space quota data storage.
+	SpaceQuotaStore interface {
+		// Find returns the quota of the space.
+		Find(ctx context.Context, spaceID int64) (*types.SpaceQuota, error)
+
+		// Upsert creates or updates the quota of a space.
+		Upsert(ctx context.Context, quota *types.SpaceQuota) error
+
+		// Delete deletes the quota of the space.
+		Delete(ctx context.Context, spaceID int64) error
+
+		// ListInherited returns the quotas of the space and all its ancestors,
+		// ordered from the closest to the most distant space.
+		ListInherited(ctx context.Context, spaceID int64) ([]*types.SpaceQuota, error)
+
+		// Usage returns the resources used by the space and all its descendants.
+		Usage(ctx context.Context, spaceID int64) (*types.SpaceUsage, error)
+	}
+
+	// SettingStore defines the settings data storage.
+	SettingStore interface {
+		// ListSpace returns all settings defined on the space.
+		ListSpace(ctx context.Context, spaceID int64) ([]*types.Setting, error)
+
+		// ListRepo returns all settings defined on the repository.
+		ListRepo(ctx context.Context, repoID int64) ([]*types.Setting, error)
+
+		// ListInherited returns all settings defined on the space and its ancestors,
+		// ordered from the most distant to the closest space.
+		ListInherited(ctx context.Context, spaceID int64) ([]*types.Setting, error)
+
+		// Upsert creates or updates the setting of a space or a repository.
+		Upsert(ctx context.Context, setting *types.Setting) error
+
+		// DeleteSpace deletes the setting with the provided key of the space.
+		DeleteSpace(ctx context.Context, spaceID int64, key enum.SettingKey) error
+
+		// DeleteRepo deletes the setting with the provided key of the repository.
+		DeleteRepo(ctx context.Context, repoID int64, key enum.SettingKey) error
+
+		// DeleteDescendants deletes the setting with the provided key of all descendant spaces of the space
+		// and all repositories within the space or any of its descendants.
+		DeleteDescendants(ctx context.Context, spaceID int64, key enum.SettingKey) error
+	}
+
+	// InstanceSettingStore defines the storage of instance settings changed at runtime and their changes.
+	InstanceSettingStore interface {
+		// Find returns the instance setting with the provided key.
+		Find(ctx context.Context, key enum.InstanceSettingKey) (*types.InstanceSetting, error)
+
+		// List returns all instance settings changed at runtime.
+		List(ctx context.Context) ([]*types.InstanceSetting, error)
+
+		// Upsert creates or updates an instance setting.
+		Upsert(ctx context.Context, setting *types.InstanceSetting) error
+
+		// Delete removes the instance setting with the provided key, the server configuration applies afterwards.
+		Delete(ctx context.Context, key enum.InstanceSettingKey) error
+
+		// CreateChange persists the audit record of a change of an instance setting.
+		CreateChange(ctx context.Context, change *types.InstanceSettingChange) error
+
+		// ListChanges returns the changes of instance settings matching the filter, newest first.
+		ListChanges(ctx context.Context, filter types.InstanceSettingChangeFilter) ([]*types.InstanceSettingChange, error)
+
+		// CountChanges returns the number of changes of instance settings matching the filter.
+		CountChanges(ctx context.Context, filter types.InstanceSettingChangeFilter) (int64, error)
+	}
+
+	// UserEmailStore defines the storage of additional user email addresses.
+	UserEmailStore interface {
+		// Find returns the user email with the provided id.
+		Find(ctx context.Context, id int64) (*types.UserEmail, error)
+
+		// FindVerified returns the verified user email matching the provided address (case insensitive).
+		FindVerified(ctx context.Context, email string) (*types.UserEmail, error)
+
+		// Create persists a new user email.
+		Create(ctx context.Context, email *types.UserEmail) error
+
+		// Update updates an existing user email.
+		Update(ctx context.Context, email *types.UserEmail) error
+
+		// List returns all emails of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.UserEmail, error)
+
+		// Delete removes a user email.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// NotificationStore defines the storage of notification settings and pending digest notifications.
+	NotificationStore interface {
+		// FindSettings returns the notification settings of the principal.
+		FindSettings(ctx context.Context, principalID int64) (*types.NotificationSettings, error)
+
+		// UpsertSettings creates or updates the notification settings of a principal.
+		UpsertSettings(ctx context.Context, settings *types.NotificationSettings) error
+
+		// CreateDigestItem stores a notification for the next digest of the principal.
+		CreateDigestItem(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListDigestItems returns pending digest notifications ordered by principal.
+		ListDigestItems(ctx context.Context, limit int) ([]*types.NotificationDigestItem, error)
+
+		// DeleteDigestItems removes the digest notifications with the provided ids.
+		DeleteDigestItems(ctx context.Context, ids []int64) error
+	}
+
+	// ChatIntegrationStore defines the chat integration data storage.
+	ChatIntegrationStore interface {
+		// Find finds the chat integration by id.
+		Find(ctx context.Context, id int64) (*types.ChatIntegration, error)
+
+		// Create creates a new chat integration.
+		Create(ctx context.Context, integration *types.ChatIntegration) error
+
+		// Update updates an existing chat integration.
+		Update(ctx context.Context, integration *types.ChatIntegration) error
+
+		// Delete deletes the chat integration for the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the chat integrations for a given parent type and id.
+		List(ctx context.Context, parentType enum.WebhookParent, parentID int64) ([]*types.ChatIntegration, error)
+
+		// ListForRepo lists the chat integrations of the repo and of all spaces containing it.
+		ListForRepo(ctx context.Context, repoID int64, spaceID int64) ([]*types.ChatIntegration, error)
+	}
+
+	// ScanFindingStore defines the storage of security scan findings.
+	ScanFindingStore interface {
+		// Replace replaces the findings of a scanner for a commit of a repository.
+		Replace(ctx context.Context, repoID int64, commitSHA string, scanner string,
+			findings []*types.ScanFinding) error
+
+		// List returns the findings of a repository matching the filter, most severe first.
+		List(ctx context.Context, repoID int64, filter *types.ScanFindingFilter) ([]*types.ScanFinding, error)
+
+		// Count returns the number of findings of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.ScanFindingFilter) (int64, error)
+	}
+
+	// ScanSuppressionStore defines the storage of suppressions of security scan findings.
+	ScanSuppressionStore interface {
+		// Find finds the suppression by id.
+		Find(ctx context.Context, id int64) (*types.ScanSuppression, error)
+
+		// Create creates a new suppression.
+		Create(ctx context.Context, suppression *types.ScanSuppression) error
+
+		// Delete deletes the suppression with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all suppressions of a repository.
+		List(ctx context.Context, repoID int64) ([]*types.ScanSuppression, error)
+	}
+
+	// FileLockStore defines the storage of advisory file locks.
+	FileLockStore interface {
+		// Find finds the lock by id.
+		Find(ctx context.Context, id int64) (*types.FileLock, error)
+
+		// Create creates a new lock, it returns store.ErrDuplicate in case the path is already locked.
+		Create(ctx context.Context, lock *types.FileLock) error
+
+		// Delete deletes the lock with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the locks of a repository matching the filter.
+		List(ctx context.Context, repoID int64, filter *types.FileLockFilter) ([]*types.FileLock, error)
+
+		// Count returns the number of locks of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.FileLockFilter) (int64, error)
+
+		// ListByPaths returns the locks of a repository held on any of the provided paths.
+		ListByPaths(ctx context.Context, repoID int64, paths []string) ([]*types.FileLock, error)
+	}
+
+	// PublicKeyStore defines the storage of public keys of users.
+	PublicKeyStore interface {
+		// Find finds the public key by id.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByFingerprint finds the public key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error)
+
+		// Create creates a new public key, it returns store.ErrDuplicate in case the key already exists.
+		Create(ctx context.Context, key *types.PublicKey) error
+
+		// Delete deletes the public key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all public keys of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.PublicKey, error)
+	}
+
+	// SavedReplyStore defines the storage of saved replies of users.
+	SavedReplyStore interface {
+		// Find finds the saved reply by id.
+		Find(ctx context.Context, id int64) (*types.SavedReply, error)
+
+		// Create creates a new saved reply, it returns store.ErrDuplicate
+		// in case the user already has a saved reply with the same identifier.
+		Create(ctx context.Context, reply *types.SavedReply) error
+
+		// Update updates the identifier and the content of the saved reply.
+		Update(ctx context.Context, reply *types.SavedReply) error
+
+		// Delete deletes the saved reply with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the saved replies of the principal.
+		List(ctx context.Context, principalID int64, filter types.ListQueryFilter) ([]*types.SavedReply, error)
+
+		// Count returns the number of saved replies of the principal.
+		Count(ctx context.Context, principalID int64, filter types.ListQueryFilter) (int64, error)
+	}
+
+	// AccessRequestStore defines the storage of requests of users to become members of spaces.
+	AccessRequestStore interface {
+		// Find finds the access request by id.
+		Find(ctx context.Context, id int64) (*types.AccessRequest, error)
+
+		// FindPending finds the pending access request of the principal for the space.
+		FindPending(ctx context.Context, spaceID int64, principalID int64) (*types.AccessRequest, error)
+
+		// Create creates a new access request.
+		Create(ctx context.Context, req *types.AccessRequest) error
+
+		// UpdateDecision stores the decision about a pending access request,
+		// it returns store.ErrVersionConflict in case the request has already been decided.
+		UpdateDecision(ctx context.Context, req *types.AccessRequest) error
+
+		// List returns the access requests of a space matching the filter.
+		List(ctx context.Context, spaceID int64, filter *types.AccessRequestFilter) ([]*types.AccessRequest, error)
+
+		// Count returns the number of access requests of a space matching the filter.
+		Count(ctx context.Context, spaceID int64, filter *types.AccessRequestFilter) (int64, error)
+	}
+
+	// CommitVerificationStore defines the storage of cached commit signature verification results.
+	CommitVerificationStore interface {
+		// ListBySHAs returns the cached verification results of the provided commits of a repository.
+		ListBySHAs(ctx context.Context, repoID int64, shas []string) ([]*types.CommitVerification, error)
+
+		// Upsert creates or updates the verification result of a commit.
+		Upsert(ctx context.Context, verification *types.CommitVerification) error
+
+		// DeleteByKeyIDs removes all verification results of signatures made by any of the provided keys.
+		DeleteByKeyIDs(ctx context.Context, keyIDs []string) error
+	}
+
+	// CommitAuthorRewriteStore defines the storage of the audit trail of rewritten commit authors.
+	CommitAuthorRewriteStore interface {
+		// Create creates a new commit author rewrite record.
+		Create(ctx context.Context, rewrite *types.CommitAuthorRewrite) error
+
+		// List returns the commit author rewrites of a repository matching the filter.
+		List(ctx context.Context, repoID int64,
+			filter *types.CommitAuthorRewriteFilter) ([]*types.CommitAuthorRewrite, error)
+
+		// Count returns the number of commit author rewrites of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.CommitAuthorRewriteFilter) (int64, error)
+	}
+
+	// RepoTopicStore defines the storage of repository topics.
+	RepoTopicStore interface {
+		// List returns the topics of the repository.
+		List(ctx context.Context, repoID int64) ([]string, error)
+
+		// Set replaces the topics of the repository.
+		Set(ctx context.Context, repoID int64, topics []string) error
+
+		// ListAll returns the topics of all repositories that contain the query (if provided).
+		ListAll(ctx context.Context, query string) ([]*types.RepoTopic, error)
+
+		// ListRepoIDs returns the ids of all repositories with the topic.
+		ListRepoIDs(ctx context.Context, topic string) ([]int64, error)
+	}
+
+	// RepoStarStore defines the storage of repositories starred by principals.
+	RepoStarStore interface {
+		// Star marks the repository as starred by the principal, starring a repository twice is a no-op.
+		Star(ctx context.Context, repoID, principalID int64) error
+
+		// Unstar removes the star of the principal from the repository.
+		Unstar(ctx context.Context, repoID, principalID int64) error
+
+		// IsStarred returns whether the repository is starred by the principal.
+		IsStarred(ctx context.Context, repoID, principalID int64) (bool, error)
+
+		// ListRepoIDs returns the ids of all repositories starred by the principal.
+		ListRepoIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
+	// RefWatchStore defines the storage of the ref watches of principals.
+	RefWatchStore interface {
+		// Find finds the ref watch by id.
+		Find(ctx context.Context, id int64) (*types.RefWatch, error)
+
+		// Create creates a new ref watch.
+		Create(ctx context.Context, watch *types.RefWatch) error
+
+		// Delete deletes the ref watch with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the ref watches of the principal in the repository.
+		List(ctx context.Context, repoID, principalID int64) ([]*types.RefWatch, error)
+
+		// ListByType returns all ref watches of the repository for the provided type of refs.
+		ListByType(ctx context.Context, repoID int64, refWatchType enum.RefWatchType) ([]*types.RefWatch, error)
+	}
+
+	// OAuthAppStore defines the storage of OAuth apps.
+	OAuthAppStore interface {
+		// Find finds the OAuth app by id.
+		Find(ctx context.Context, id int64) (*types.OAuthApp, error)
+
+		// FindByClientID finds the OAuth app by its client id.
+		FindByClientID(ctx context.Context, clientID string) (*types.OAuthApp, error)
+
+		// Create creates a new OAuth app.
+		Create(ctx context.Context, app *types.OAuthApp) error
+
+		// Update updates an existing OAuth app.
+		Update(ctx context.Context, app *types.OAuthApp) error
+
+		// Delete deletes the OAuth app with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the OAuth apps created by the principal.
+		List(ctx context.Context, createdBy int64) ([]*types.OAuthApp, error)
+	}
+
+	// OAuthAuthorizationStore defines the storage of the consents users gave to OAuth apps.
+	OAuthAuthorizationStore interface {
+		// Find finds the authorization by id.
+		Find(ctx context.Context, id int64) (*types.OAuthAuthorization, error)
+
+		// FindByAppAndPrincipal finds the authorization the principal gave to the app.
+		FindByAppAndPrincipal(ctx context.Context, appID, principalID int64) (*types.OAuthAuthorization, error)
+
+		// Create creates a new authorization.
+		Create(ctx context.Context, authorization *types.OAuthAuthorization) error
+
+		// UpdateScopes updates the scopes of an existing authorization.
+		UpdateScopes(ctx context.Context, authorization *types.OAuthAuthorization) error
+
+		// Delete deletes the authorization with the provided id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all authorizations of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.OAuthAuthorization, error)
+
+		// ListByApp returns all authorizations users gave to the app.
+		ListByApp(ctx context.Context, appID int64) ([]*types.OAuthAuthorization, error)
+
+		// ListBySpaces returns all authorizations given by principals that are members of any of the spaces.
+		ListBySpaces(ctx context.Context, spaceIDs []int64) ([]*types.OAuthAuthorization, error)
+	}
+
+	// OAuthCodeStore defines the storage of OAuth authorization codes.
+	OAuthCodeStore interface {
+		// Create stores a new authorization code.
+		Create(ctx context.Context, code *types.OAuthAuthorizationCode) error
+
+		// Consume finds the authorization code by its hash and deletes it, so it can't be used again.
+		Consume(ctx context.Context, codeHash string) (*types.OAuthAuthorizationCode, error)
+
+		// DeleteExpiredBefore deletes all authorization codes that expired before the provided time.
+		DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	// UploadQuarantineStore defines the storage of uploaded content flagged by the virus scanner.
+	UploadQuarantineStore interface {
+		// Find returns the quarantined upload with the provided id.
+		Find(ctx context.Context, id int64) (*types.UploadQuarantine, error)
+
+		// FindBySHA256 returns the quarantined upload with the provided content checksum.
+		FindBySHA256(ctx context.Context, sha256 string) (*types.UploadQuarantine, error)
+
+		// Create persists a new quarantined upload.
+		Create(ctx context.Context, quarantine *types.UploadQuarantine) error
+
+		// UpdateStatus updates the status of a quarantined upload.
+		UpdateStatus(ctx context.Context, quarantine *types.UploadQuarantine) error
+
+		// List returns the quarantined uploads matching the filter, newest first.
+		List(ctx context.Context, filter types.UploadQuarantineFilter) ([]*types.UploadQuarantine, error)
+
+		// Count returns the number of quarantined uploads matching the filter.
+		Count(ctx context.Context, filter types.UploadQuarantineFilter) (int64, error)
+
+		// Delete removes a quarantined upload.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// BackupStore defines the storage of repository backups. The snapshots themselves are in the blob store.
+	BackupStore interface {
+		// Find returns the backup with the provided id.
+		Find(ctx context.Context, id int64) (*types.Backup, error)
+
+		// Create persists a new backup.
+		Create(ctx context.Context, backup *types.Backup) error
+
+		// Update updates the state, the sizes and the error of a backup.
+		Update(ctx context.Context, backup *types.Backup) error
+
+		// List returns the backups matching the filter, newest first.
+		List(ctx context.Context, filter types.BackupFilter) ([]*types.Backup, error)
+
+		// Count returns the number of backups matching the filter.
+		Count(ctx context.Context, filter types.BackupFilter) (int64, error)
+
+		// ListByRepo returns all backups of a repository, newest first.
+		ListByRepo(ctx context.Context, repoID int64) ([]*types.Backup, error)
+
+		// Delete removes a backup.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// FeedEventStore defines the storage of repository activity shown in the personal feed of users.
+	FeedEventStore interface {
+		// Create persists a new feed event.
+		Create(ctx context.Context, event *types.FeedEvent) error
+
+		// List returns the feed events of the provided repositories, newest first.
+		List(ctx context.Context, repoIDs []int64, pagination types.Pagination) ([]*types.FeedEvent, error)
+
+		// Count returns the number of feed events of the provided repositories.
+		Count(ctx context.Context, repoIDs []int64) (int64, error)
+
+		// ListContributedRepoIDs returns the ids of all repositories with activity of the principal.
+		ListContributedRepoIDs(ctx context.Context, principalID int64) ([]int64, error)
+
+		// DeleteOld removes all feed events that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// ContributionStore defines the read access to the contributions of principals,
+	// which are derived from pull requests, reviews, comments and feed events.
+	ContributionStore interface {
+		// List returns the contributions of the principal created at or after the provided time (unix millis).
+		List(ctx context.Context, principalID int64, since int64) ([]*types.Contribution, error)
+
+		// ListRepoIDs returns the ids of all repositories the principal contributed to.
+		ListRepoIDs(ctx context.Context, principalID int64) ([]int64, error)
+	}
+
+	// AttestationStore defines the storage of attestations of commits and pipeline executions.
+	AttestationStore interface {
+		// Find finds the attestation by id.
+		Find(ctx context.Context, id int64) (*types.Attestation, error)
+
+		// Create creates a new attestation.
+		Create(ctx context.Context, attestation *types.Attestation) error
+
+		// UpdateStatus updates the verification status of the attestation.
+		UpdateStatus(ctx context.Context, attestation *types.Attestation) error
+
+		// ListByCommit returns all attestations of a commit.
+		ListByCommit(ctx context.Context, repoID int64, commitSHA string) ([]*types.Attestation, error)
+
+		// ListByExecution returns all attestations of a pipeline execution.
+		ListByExecution(ctx context.Context, executionID int64) ([]*types.Attestation, error)
+	}
+
+	// SBOMStore defines the storage of the metadata of generated software bills of materials.
+	// The documents themselves are kept in the blob store.
+	SBOMStore interface {
+		// Find finds the SBOM by id.
+		Find(ctx context.Context, id int64) (*types.SBOM, error)
+
+		// Upsert creates the SBOM or replaces the existing SBOM of the same tag and format.
+		Upsert(ctx context.Context, sbom *types.SBOM) error
+
+		// Delete deletes the SBOM with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the SBOMs of a repository, optionally limited to a single tag.
+		List(ctx context.Context, repoID int64, tag string) ([]*types.SBOM, error)
+	}
+
+	// AttachmentStore defines the storage of the metadata of files attached to pull requests.
+	// The content of the files is kept in the blob store.
+	AttachmentStore interface {
+		// FindBySHA256 returns the attachment of the repository with the provided content checksum.
+		FindBySHA256(ctx context.Context, repoID int64, sha256 string) (*types.Attachment, error)
+
+		// Create persists a new attachment.
+		Create(ctx context.Context, attachment *types.Attachment) error
+	}
+
+	// AvatarStore defines the storage of the metadata of avatars uploaded for principals and spaces.
+	// The images are kept in the blob store.
+	AvatarStore interface {
+		// Find returns the avatar of the principal or space.
+		Find(ctx context.Context, ownerType enum.AvatarOwnerType, ownerID int64) (*types.Avatar, error)
+
+		// Upsert creates or replaces the avatar of the principal or space.
+		Upsert(ctx context.Context, avatar *types.Avatar) error
+
+		// Delete deletes the avatar of the principal or space.
+		Delete(ctx context.Context, ownerType enum.AvatarOwnerType, ownerID int64) error
+	}
+
+	// BranchRenameStore defines the storage of renamed branches.
+	BranchRenameStore interface {
+		// Find returns the rename of the branch with the provided (old) name.
+		Find(ctx context.Context, repoID int64, oldName string) (*types.BranchRename, error)
+
+		// Upsert records the branch rename, overwriting any previous rename of a branch with the same name.
+		Upsert(ctx context.Context, rename *types.BranchRename) error
+	}
+
+	// InsightsStore defines the storage of the aggregated contribution statistics.
+	InsightsStore interface {
+		// FindLatestDay returns the most recent day aggregated for the repo, or 0 if there is none.
+		FindLatestDay(ctx context.Context, repoID int64) (int64, error)
+
+		// AggregatePullReqs computes the daily pull request statistics of the repo since the provided day.
+		AggregatePullReqs(ctx context.Context, repoID int64, since int64) ([]*types.InsightsDay, error)
+
+		// Replace replaces all aggregated statistics of the repo since the provided day.
+		Replace(ctx context.Context, repoID int64, since int64,
+			days []*types.InsightsDay, contributors []*types.InsightsContributorDay) error
+
+		// ListDays returns the statistics of a repo or space (including all subspaces) summed up per day.
+		ListDays(ctx context.Context, parentType enum.WebhookParent, parentID int64,
+			filter types.InsightsFilter) ([]*types.InsightsDay, error)
+
+		// ListContributors returns up to limit contributors of a repo or space, ordered by number of commits.
+		ListContributors(ctx context.Context, parentType enum.WebhookParent, parentID int64,
+			filter types.InsightsFilter, limit int) ([]*types.InsightsContributor, error)
+
+		// CountContributors returns the number of distinct contributors of a repo or space.
+		CountContributors(ctx context.Context, parentType enum.WebhookParent, parentID int64,
+			filter types.InsightsFilter) (int64, error)
+	}
+
+	// AnnouncementStore defines the announcement data storage.
+	AnnouncementStore interface {
+		// Find returns the announcement with the provided id.
+		Find(ctx context.Context, id int64) (*types.Announcement, error)
+
+		// Create persists a new announcement.
+		Create(ctx context.Context, announcement *types.Announcement) error
+
+		// Update updates an existing announcement.
+		Update(ctx context.Context, announcement *types.Announcement) error
+
+		// List returns the announcements matching the filter, newest first.
+		List(ctx context.Context, filter types.AnnouncementFilter) ([]*types.Announcement, error)
+
+		// Count returns the number of announcements matching the filter.
+		Count(ctx context.Context, filter types.AnnouncementFilter) (int64, error)
+
+		// ListActive returns the announcements shown at the provided time that the principal didn't dismiss.
+		ListActive(ctx context.Context, principalID int64, now int64) ([]*types.Announcement, error)
+
+		// Dismiss marks the announcement as dismissed by the principal.
+		Dismiss(ctx context.Context, id int64, principalID int64, now int64) error
+
+		// ResetDismissals removes all dismissals of the announcement.
+		ResetDismissals(ctx context.Context, id int64) error
+
+		// Delete removes an announcement.
+		Delete(ctx context.Context, id int64) error
+	}
+
 	PipelineStore interface {
 		// Find returns a pipeline given a pipeline ID from the datastore.
 		Find(ctx context.Context, id int64) (*types.Pipeline, error)
@@ -556,6 +1368,13 @@ type (
 
 		// ListAll lists all the secrets in a given space.
 		ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error)
+
+		// ListAllInherited lists all the secrets in a given space including
+		// the inheritable secrets of its ancestor spaces.
+		ListAllInherited(ctx context.Context, spaceID int64) ([]*types.Secret, error)
+
+		// ListAfter lists up to limit secrets of all spaces with an ID greater than afterID, ordered by ID.
+		ListAfter(ctx context.Context, afterID int64, limit int) ([]*types.Secret, error)
 	}
 
 	ExecutionStore interface {
@@ -617,6 +1436,13 @@ type (
 		// Update tries to update a step and returns an optimistic locking error if it was
 		// unable to do so.
 		Update(ctx context.Context, e *types.Step) error
+
+		// ListIDsWithExpiredLogs returns up to limit IDs of steps of the repository that stopped before
+		// the provided time and whose logs haven't been purged yet.
+		ListIDsWithExpiredLogs(ctx context.Context, repoID int64, stoppedBefore time.Time, limit int) ([]int64, error)
+
+		// MarkLogsPurged marks the logs of the steps as purged.
+		MarkLogsPurged(ctx context.Context, ids []int64) error
 	}
 
 	ConnectorStore interface {
@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AccessRequestStore = (*AccessRequestStore)(nil)
+
+// NewAccessRequestStore returns a new AccessRequestStore.
+func NewAccessRequestStore(db *sqlx.DB) *AccessRequestStore {
+	return &AccessRequestStore{
+		db: db,
+	}
+}
+
+// AccessRequestStore implements store.AccessRequestStore backed by a relational database.
+type AccessRequestStore struct {
+	db *sqlx.DB
+}
+
+// accessRequest is an internal representation used to store access request data in the database.
+type accessRequest struct {
+	ID             int64                   `db:"access_request_id"`
+	SpaceID        int64                   `db:"access_request_space_id"`
+	RepoID         null.Int                `db:"access_request_repo_id"`
+	PrincipalID    int64                   `db:"access_request_principal_id"`
+	Role           enum.MembershipRole     `db:"access_request_role"`
+	Message        string                  `db:"access_request_message"`
+	State          enum.AccessRequestState `db:"access_request_state"`
+	Created        int64                   `db:"access_request_created"`
+	Updated        int64                   `db:"access_request_updated"`
+	DecidedBy      null.Int                `db:"access_request_decided_by"`
+	Decided        null.Int                `db:"access_request_decided"`
+	DecisionReason string                  `db:"access_request_decision_reason"`
+}
+
+const (
+	accessRequestColumns = `
+		 access_request_id
+		,access_request_space_id
+		,access_request_repo_id
+		,access_request_principal_id
+		,access_request_role
+		,access_request_message
+		,access_request_state
+		,access_request_created
+		,access_request_updated
+		,access_request_decided_by
+		,access_request_decided
+		,access_request_decision_reason`
+
+	accessRequestSelectBase = `
+	SELECT` + accessRequestColumns + `
+	FROM access_requests`
+)
+
+// Find finds the access request by id.
+func (s *AccessRequestStore) Find(ctx context.Context, id int64) (*types.AccessRequest, error) {
+	const sqlQuery = accessRequestSelectBase + `
+		WHERE access_request_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &accessRequest{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find access request")
+	}
+
+	return mapToAccessRequest(dst), nil
+}
+
+// FindPending finds the pending access request of the principal for the space.
+func (s *AccessRequestStore) FindPending(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+) (*types.AccessRequest, error) {
+	const sqlQuery = accessRequestSelectBase + `
+		WHERE access_request_space_id = $1 AND
+		      access_request_principal_id = $2 AND
+		      access_request_state = $3`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &accessRequest{}
+	err := db.GetContext(ctx, dst, sqlQuery, spaceID, principalID, enum.AccessRequestStatePending)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pending access request")
+	}
+
+	return mapToAccessRequest(dst), nil
+}
+
+// Create creates a new access request.
+func (s *AccessRequestStore) Create(ctx context.Context, req *types.AccessRequest) error {
+	const sqlQuery = `
+		INSERT INTO access_requests (
+			 access_request_space_id
+			,access_request_repo_id
+			,access_request_principal_id
+			,access_request_role
+			,access_request_message
+			,access_request_state
+			,access_request_created
+			,access_request_updated
+			,access_request_decided_by
+			,access_request_decided
+			,access_request_decision_reason
+		) values (
+			 :access_request_space_id
+			,:access_request_repo_id
+			,:access_request_principal_id
+			,:access_request_role
+			,:access_request_message
+			,:access_request_state
+			,:access_request_created
+			,:access_request_updated
+			,:access_request_decided_by
+			,:access_request_decided
+			,:access_request_decision_reason
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAccessRequest(req))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind access request object")
+	}
+
+	if req.ID, err = database.InsertReturningID(ctx, db, query, "access_request_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateDecision stores the decision about a pending access request,
+// it returns store.ErrVersionConflict in case the request has already been decided.
+func (s *AccessRequestStore) UpdateDecision(ctx context.Context, req *types.AccessRequest) error {
+	const sqlQuery = `
+		UPDATE access_requests
+		SET
+			 access_request_role = :access_request_role
+			,access_request_state = :access_request_state
+			,access_request_updated = :access_request_updated
+			,access_request_decided_by = :access_request_decided_by
+			,access_request_decided = :access_request_decided
+			,access_request_decision_reason = :access_request_decision_reason
+		WHERE access_request_id = :access_request_id AND
+		      access_request_state = '` + string(enum.AccessRequestStatePending) + `'`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAccessRequest(req))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind access request object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update access request")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// List returns the access requests of a space matching the filter, newest first.
+func (s *AccessRequestStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AccessRequestFilter,
+) ([]*types.AccessRequest, error) {
+	stmt := database.Builder.
+		Select(accessRequestColumns).
+		From("access_requests").
+		Where("access_request_space_id = ?", spaceID)
+
+	stmt = applyAccessRequestFilter(stmt, filter)
+
+	stmt = stmt.
+		OrderBy("access_request_created DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*accessRequest{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToAccessRequests(dst), nil
+}
+
+// Count returns the number of access requests of a space matching the filter.
+func (s *AccessRequestStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.AccessRequestFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("access_requests").
+		Where("access_request_space_id = ?", spaceID)
+
+	stmt = applyAccessRequestFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func applyAccessRequestFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AccessRequestFilter,
+) squirrel.SelectBuilder {
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"access_request_state": filter.States})
+	}
+
+	if filter.RepoID > 0 {
+		stmt = stmt.Where("access_request_repo_id = ?", filter.RepoID)
+	}
+
+	return stmt
+}
+
+func mapToAccessRequest(in *accessRequest) *types.AccessRequest {
+	return &types.AccessRequest{
+		ID:             in.ID,
+		SpaceID:        in.SpaceID,
+		RepoID:         in.RepoID.Ptr(),
+		PrincipalID:    in.PrincipalID,
+		Role:           in.Role,
+		Message:        in.Message,
+		State:          in.State,
+		Created:        in.Created,
+		Updated:        in.Updated,
+		DecidedBy:      in.DecidedBy.Ptr(),
+		Decided:        in.Decided.Ptr(),
+		DecisionReason: in.DecisionReason,
+	}
+}
+
+func mapToAccessRequests(in []*accessRequest) []*types.AccessRequest {
+	res := make([]*types.AccessRequest, len(in))
+	for i := range in {
+		res[i] = mapToAccessRequest(in[i])
+	}
+
+	return res
+}
+
+func mapToInternalAccessRequest(in *types.AccessRequest) *accessRequest {
+	return &accessRequest{
+		ID:             in.ID,
+		SpaceID:        in.SpaceID,
+		RepoID:         null.IntFromPtr(in.RepoID),
+		PrincipalID:    in.PrincipalID,
+		Role:           in.Role,
+		Message:        in.Message,
+		State:          in.State,
+		Created:        in.Created,
+		Updated:        in.Updated,
+		DecidedBy:      null.IntFromPtr(in.DecidedBy),
+		Decided:        null.IntFromPtr(in.Decided),
+		DecisionReason: in.DecisionReason,
+	}
+}
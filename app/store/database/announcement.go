@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AnnouncementStore = (*AnnouncementStore)(nil)
+
+func NewAnnouncementStore(db *sqlx.DB) *AnnouncementStore {
+	return &AnnouncementStore{
+		db: db,
+	}
+}
+
+type AnnouncementStore struct {
+	db *sqlx.DB
+}
+
+const (
+	announcementColumns = `
+		 announcement_id
+		,announcement_message
+		,announcement_severity
+		,announcement_starts
+		,announcement_ends
+		,announcement_dismissible
+		,announcement_created_by
+		,announcement_created
+		,announcement_updated`
+
+	announcementSelectBase = `
+	SELECT` + announcementColumns + `
+	FROM announcements`
+)
+
+// Find returns the announcement with the provided id.
+func (s *AnnouncementStore) Find(ctx context.Context, id int64) (*types.Announcement, error) {
+	const sqlQuery = announcementSelectBase + `
+	WHERE announcement_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	result := &types.Announcement{}
+	if err := db.GetContext(ctx, result, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find announcement")
+	}
+
+	return result, nil
+}
+
+// Create persists a new announcement.
+func (s *AnnouncementStore) Create(ctx context.Context, announcement *types.Announcement) error {
+	const sqlQuery = `
+		INSERT INTO announcements (
+			 announcement_message
+			,announcement_severity
+			,announcement_starts
+			,announcement_ends
+			,announcement_dismissible
+			,announcement_created_by
+			,announcement_created
+			,announcement_updated
+		) VALUES (
+			 :announcement_message
+			,:announcement_severity
+			,:announcement_starts
+			,:announcement_ends
+			,:announcement_dismissible
+			,:announcement_created_by
+			,:announcement_created
+			,:announcement_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, announcement)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind announcement object")
+	}
+
+	if announcement.ID, err = database.InsertReturningID(ctx, db, query, "announcement_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing announcement.
+func (s *AnnouncementStore) Update(ctx context.Context, announcement *types.Announcement) error {
+	const sqlQuery = `
+		UPDATE announcements
+		SET
+			 announcement_message = :announcement_message
+			,announcement_severity = :announcement_severity
+			,announcement_starts = :announcement_starts
+			,announcement_ends = :announcement_ends
+			,announcement_dismissible = :announcement_dismissible
+			,announcement_updated = :announcement_updated
+		WHERE announcement_id = :announcement_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, announcement)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind announcement object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+// List returns the announcements matching the filter, newest first.
+func (s *AnnouncementStore) List(
+	ctx context.Context,
+	filter types.AnnouncementFilter,
+) ([]*types.Announcement, error) {
+	stmt := database.Builder.
+		Select(announcementColumns).
+		From("announcements").
+		OrderBy("announcement_id desc").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list announcements query to sql: %w", err)
+	}
+
+	result := make([]*types.Announcement, 0)
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list announcements query")
+	}
+
+	return result, nil
+}
+
+// Count returns the number of announcements matching the filter.
+func (s *AnnouncementStore) Count(ctx context.Context, _ types.AnnouncementFilter) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM announcements`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count announcements query")
+	}
+
+	return count, nil
+}
+
+// ListActive returns the announcements shown at the provided time that the principal didn't dismiss,
+// ordered by severity and start time.
+func (s *AnnouncementStore) ListActive(
+	ctx context.Context,
+	principalID int64,
+	now int64,
+) ([]*types.Announcement, error) {
+	const sqlQuery = announcementSelectBase + `
+	WHERE announcement_starts <= $1
+		AND (announcement_ends = 0 OR announcement_ends > $1)
+		AND (NOT announcement_dismissible OR NOT EXISTS (
+			SELECT 1
+			FROM announcement_dismissals
+			WHERE announcement_dismissal_announcement_id = announcement_id
+				AND announcement_dismissal_principal_id = $2
+		))
+	ORDER BY
+		CASE announcement_severity WHEN 'critical' THEN 0 WHEN 'warning' THEN 1 ELSE 2 END,
+		announcement_starts DESC,
+		announcement_id DESC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	result := make([]*types.Announcement, 0)
+	if err := db.SelectContext(ctx, &result, sqlQuery, now, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list active announcements query")
+	}
+
+	return result, nil
+}
+
+// Dismiss marks the announcement as dismissed by the principal.
+func (s *AnnouncementStore) Dismiss(ctx context.Context, id int64, principalID int64, now int64) error {
+	const sqlQueryInsert = `
+		INSERT INTO announcement_dismissals (
+			 announcement_dismissal_announcement_id
+			,announcement_dismissal_principal_id
+			,announcement_dismissal_created
+		) VALUES ($1, $2, $3)`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT DO NOTHING`
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE announcement_dismissal_created = announcement_dismissal_created`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id, principalID, now); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to dismiss announcement")
+	}
+
+	return nil
+}
+
+// ResetDismissals removes all dismissals of the announcement, e.g. after its message changed.
+func (s *AnnouncementStore) ResetDismissals(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM announcement_dismissals
+		WHERE announcement_dismissal_announcement_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to reset announcement dismissals")
+	}
+
+	return nil
+}
+
+// Delete removes an announcement.
+func (s *AnnouncementStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM announcements
+		WHERE announcement_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete announcement")
+	}
+
+	return nil
+}
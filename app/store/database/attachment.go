@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AttachmentStore = (*AttachmentStore)(nil)
+
+func NewAttachmentStore(db *sqlx.DB) *AttachmentStore {
+	return &AttachmentStore{
+		db: db,
+	}
+}
+
+type AttachmentStore struct {
+	db *sqlx.DB
+}
+
+const (
+	attachmentColumns = `
+		 attachment_id
+		,attachment_repo_id
+		,attachment_sha256
+		,attachment_content_type
+		,attachment_size
+		,attachment_created_by
+		,attachment_created`
+
+	attachmentSelectBase = `
+	SELECT` + attachmentColumns + `
+	FROM attachments`
+)
+
+// FindBySHA256 returns the attachment of the repository with the provided content checksum.
+func (s *AttachmentStore) FindBySHA256(ctx context.Context, repoID int64, sha256 string) (*types.Attachment, error) {
+	const sqlQuery = attachmentSelectBase + `
+	WHERE attachment_repo_id = $1 AND attachment_sha256 = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.Attachment{}
+	if err := db.GetContext(ctx, result, sqlQuery, repoID, sha256); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find attachment by sha256")
+	}
+
+	return result, nil
+}
+
+// Create persists a new attachment.
+func (s *AttachmentStore) Create(ctx context.Context, attachment *types.Attachment) error {
+	const sqlQuery = `
+		INSERT INTO attachments (
+			 attachment_repo_id
+			,attachment_sha256
+			,attachment_content_type
+			,attachment_size
+			,attachment_created_by
+			,attachment_created
+		) VALUES (
+			 :attachment_repo_id
+			,:attachment_sha256
+			,:attachment_content_type
+			,:attachment_size
+			,:attachment_created_by
+			,:attachment_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, attachment)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind attachment object")
+	}
+
+	if attachment.ID, err = database.InsertReturningID(ctx, db, query, "attachment_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
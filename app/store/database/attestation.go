@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AttestationStore = (*AttestationStore)(nil)
+
+// NewAttestationStore returns a new AttestationStore.
+func NewAttestationStore(db *sqlx.DB) *AttestationStore {
+	return &AttestationStore{
+		db: db,
+	}
+}
+
+// AttestationStore implements store.AttestationStore backed by a relational database.
+type AttestationStore struct {
+	db *sqlx.DB
+}
+
+// attestation is an internal representation used to store attestation data in the database.
+type attestation struct {
+	ID            int64                  `db:"attestation_id"`
+	RepoID        int64                  `db:"attestation_repo_id"`
+	CommitSHA     string                 `db:"attestation_commit_sha"`
+	ExecutionID   null.Int               `db:"attestation_execution_id"`
+	PredicateType string                 `db:"attestation_predicate_type"`
+	Envelope      string                 `db:"attestation_envelope"`
+	Status        enum.AttestationStatus `db:"attestation_status"`
+	KeyName       string                 `db:"attestation_key_name"`
+	StatusMessage string                 `db:"attestation_status_message"`
+	CreatedBy     int64                  `db:"attestation_created_by"`
+	Created       int64                  `db:"attestation_created"`
+	Verified      int64                  `db:"attestation_verified"`
+}
+
+const (
+	attestationColumns = `
+		 attestation_id
+		,attestation_repo_id
+		,attestation_commit_sha
+		,attestation_execution_id
+		,attestation_predicate_type
+		,attestation_envelope
+		,attestation_status
+		,attestation_key_name
+		,attestation_status_message
+		,attestation_created_by
+		,attestation_created
+		,attestation_verified`
+
+	attestationSelectBase = `
+	SELECT` + attestationColumns + `
+	FROM attestations`
+)
+
+// Find finds the attestation by id.
+func (s *AttestationStore) Find(ctx context.Context, id int64) (*types.Attestation, error) {
+	const sqlQuery = attestationSelectBase + `
+		WHERE attestation_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &attestation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToAttestation(dst), nil
+}
+
+// Create creates a new attestation.
+func (s *AttestationStore) Create(ctx context.Context, attestation *types.Attestation) error {
+	const sqlQuery = `
+		INSERT INTO attestations (
+			 attestation_repo_id
+			,attestation_commit_sha
+			,attestation_execution_id
+			,attestation_predicate_type
+			,attestation_envelope
+			,attestation_status
+			,attestation_key_name
+			,attestation_status_message
+			,attestation_created_by
+			,attestation_created
+			,attestation_verified
+		) values (
+			 :attestation_repo_id
+			,:attestation_commit_sha
+			,:attestation_execution_id
+			,:attestation_predicate_type
+			,:attestation_envelope
+			,:attestation_status
+			,:attestation_key_name
+			,:attestation_status_message
+			,:attestation_created_by
+			,:attestation_created
+			,:attestation_verified
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAttestation(attestation))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind attestation object")
+	}
+
+	if attestation.ID, err = database.InsertReturningID(ctx, db, query, "attestation_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the verification status of the attestation.
+func (s *AttestationStore) UpdateStatus(ctx context.Context, attestation *types.Attestation) error {
+	const sqlQuery = `
+		UPDATE attestations
+		SET
+			 attestation_status = :attestation_status
+			,attestation_key_name = :attestation_key_name
+			,attestation_status_message = :attestation_status_message
+			,attestation_verified = :attestation_verified
+		WHERE attestation_id = :attestation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAttestation(attestation))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind attestation object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+// ListByCommit returns all attestations of a commit.
+func (s *AttestationStore) ListByCommit(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]*types.Attestation, error) {
+	const sqlQuery = attestationSelectBase + `
+		WHERE attestation_repo_id = $1 AND attestation_commit_sha = $2
+		ORDER BY attestation_id ASC`
+
+	return s.list(ctx, sqlQuery, repoID, commitSHA)
+}
+
+// ListByExecution returns all attestations of a pipeline execution.
+func (s *AttestationStore) ListByExecution(ctx context.Context, executionID int64) ([]*types.Attestation, error) {
+	const sqlQuery = attestationSelectBase + `
+		WHERE attestation_execution_id = $1
+		ORDER BY attestation_id ASC`
+
+	return s.list(ctx, sqlQuery, executionID)
+}
+
+func (s *AttestationStore) list(ctx context.Context, sqlQuery string, args ...interface{}) ([]*types.Attestation, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*attestation{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res := make([]*types.Attestation, len(dst))
+	for i := range dst {
+		res[i] = mapToAttestation(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToAttestation(in *attestation) *types.Attestation {
+	return &types.Attestation{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		CommitSHA:     in.CommitSHA,
+		ExecutionID:   in.ExecutionID.Ptr(),
+		PredicateType: in.PredicateType,
+		Envelope:      json.RawMessage(in.Envelope),
+		Status:        in.Status,
+		KeyName:       in.KeyName,
+		StatusMessage: in.StatusMessage,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Verified:      in.Verified,
+	}
+}
+
+func mapToInternalAttestation(in *types.Attestation) *attestation {
+	return &attestation{
+		ID:            in.ID,
+		RepoID:        in.RepoID,
+		CommitSHA:     in.CommitSHA,
+		ExecutionID:   null.IntFromPtr(in.ExecutionID),
+		PredicateType: in.PredicateType,
+		Envelope:      string(in.Envelope),
+		Status:        in.Status,
+		KeyName:       in.KeyName,
+		StatusMessage: in.StatusMessage,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+		Verified:      in.Verified,
+	}
+}
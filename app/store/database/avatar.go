@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AvatarStore = (*AvatarStore)(nil)
+
+// NewAvatarStore returns a new AvatarStore.
+func NewAvatarStore(db *sqlx.DB) *AvatarStore {
+	return &AvatarStore{
+		db: db,
+	}
+}
+
+// AvatarStore implements store.AvatarStore backed by a relational database.
+type AvatarStore struct {
+	db *sqlx.DB
+}
+
+// avatar is an internal representation used to store avatars in the database.
+type avatar struct {
+	ID          int64    `db:"avatar_id"`
+	PrincipalID null.Int `db:"avatar_principal_id"`
+	SpaceID     null.Int `db:"avatar_space_id"`
+	SHA256      string   `db:"avatar_sha256"`
+	CreatedBy   int64    `db:"avatar_created_by"`
+	Updated     int64    `db:"avatar_updated"`
+}
+
+const (
+	avatarColumns = `
+		 avatar_id
+		,avatar_principal_id
+		,avatar_space_id
+		,avatar_sha256
+		,avatar_created_by
+		,avatar_updated`
+)
+
+// Find returns the avatar of the principal or space.
+func (s *AvatarStore) Find(
+	ctx context.Context,
+	ownerType enum.AvatarOwnerType,
+	ownerID int64,
+) (*types.Avatar, error) {
+	ownerColumn, err := avatarOwnerColumn(ownerType)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery := `
+	SELECT` + avatarColumns + `
+	FROM avatars
+	WHERE ` + ownerColumn + ` = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &avatar{}
+	if err = db.GetContext(ctx, dst, sqlQuery, ownerID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find avatar")
+	}
+
+	return mapAvatar(dst)
+}
+
+// Upsert creates or replaces the avatar of the principal or space.
+func (s *AvatarStore) Upsert(ctx context.Context, a *types.Avatar) error {
+	ownerColumn, err := avatarOwnerColumn(a.OwnerType)
+	if err != nil {
+		return err
+	}
+
+	const sqlQueryInsert = `
+	INSERT INTO avatars (
+		 avatar_principal_id
+		,avatar_space_id
+		,avatar_sha256
+		,avatar_created_by
+		,avatar_updated
+	) VALUES (
+		 :avatar_principal_id
+		,:avatar_space_id
+		,:avatar_sha256
+		,:avatar_created_by
+		,:avatar_updated
+	)`
+
+	const sqlQueryUpdate = `
+		 avatar_sha256 = :avatar_sha256
+		,avatar_created_by = :avatar_created_by
+		,avatar_updated = :avatar_updated`
+
+	sqlQuery := sqlQueryInsert + `
+	ON CONFLICT (` + ownerColumn + `) DO
+	UPDATE SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+	ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	in, err := mapToInternalAvatar(a)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, in)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind avatar object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the avatar of the principal or space.
+func (s *AvatarStore) Delete(ctx context.Context, ownerType enum.AvatarOwnerType, ownerID int64) error {
+	ownerColumn, err := avatarOwnerColumn(ownerType)
+	if err != nil {
+		return err
+	}
+
+	sqlQuery := `
+	DELETE FROM avatars
+	WHERE ` + ownerColumn + ` = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, ownerID); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete query failed")
+	}
+
+	return nil
+}
+
+// avatarOwnerColumn returns the column referencing the owner of an avatar.
+func avatarOwnerColumn(ownerType enum.AvatarOwnerType) (string, error) {
+	switch ownerType {
+	case enum.AvatarOwnerTypePrincipal:
+		return "avatar_principal_id", nil
+	case enum.AvatarOwnerTypeSpace:
+		return "avatar_space_id", nil
+	default:
+		return "", fmt.Errorf("avatar owner type '%s' is not supported", ownerType)
+	}
+}
+
+func mapAvatar(in *avatar) (*types.Avatar, error) {
+	res := &types.Avatar{
+		SHA256:    in.SHA256,
+		CreatedBy: in.CreatedBy,
+		Updated:   in.Updated,
+	}
+
+	switch {
+	case in.PrincipalID.Valid:
+		res.OwnerType = enum.AvatarOwnerTypePrincipal
+		res.OwnerID = in.PrincipalID.Int64
+	case in.SpaceID.Valid:
+		res.OwnerType = enum.AvatarOwnerTypeSpace
+		res.OwnerID = in.SpaceID.Int64
+	default:
+		return nil, fmt.Errorf("neither principalID nor spaceID are set for avatar %d", in.ID)
+	}
+
+	return res, nil
+}
+
+func mapToInternalAvatar(in *types.Avatar) (*avatar, error) {
+	res := &avatar{
+		SHA256:    in.SHA256,
+		CreatedBy: in.CreatedBy,
+		Updated:   in.Updated,
+	}
+
+	switch in.OwnerType {
+	case enum.AvatarOwnerTypePrincipal:
+		res.PrincipalID = null.IntFrom(in.OwnerID)
+	case enum.AvatarOwnerTypeSpace:
+		res.SpaceID = null.IntFrom(in.OwnerID)
+	default:
+		return nil, fmt.Errorf("avatar owner type '%s' is not supported", in.OwnerType)
+	}
+
+	return res, nil
+}
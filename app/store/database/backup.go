@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.BackupStore = (*BackupStore)(nil)
+
+func NewBackupStore(db *sqlx.DB) *BackupStore {
+	return &BackupStore{
+		db: db,
+	}
+}
+
+type BackupStore struct {
+	db *sqlx.DB
+}
+
+const (
+	backupColumns = `
+		 backup_id
+		,backup_repo_id
+		,backup_repo_path
+		,backup_state
+		,backup_trigger
+		,backup_bundle_size
+		,backup_metadata_size
+		,backup_error
+		,backup_created_by
+		,backup_created
+		,backup_updated`
+
+	backupSelectBase = `
+	SELECT` + backupColumns + `
+	FROM backups`
+)
+
+// Find returns the backup with the provided id.
+func (s *BackupStore) Find(ctx context.Context, id int64) (*types.Backup, error) {
+	const sqlQuery = backupSelectBase + `
+	WHERE backup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.Backup{}
+	if err := db.GetContext(ctx, result, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find backup")
+	}
+
+	return result, nil
+}
+
+// Create persists a new backup.
+func (s *BackupStore) Create(ctx context.Context, backup *types.Backup) error {
+	const sqlQuery = `
+		INSERT INTO backups (
+			 backup_repo_id
+			,backup_repo_path
+			,backup_state
+			,backup_trigger
+			,backup_bundle_size
+			,backup_metadata_size
+			,backup_error
+			,backup_created_by
+			,backup_created
+			,backup_updated
+		) VALUES (
+			 :backup_repo_id
+			,:backup_repo_path
+			,:backup_state
+			,:backup_trigger
+			,:backup_bundle_size
+			,:backup_metadata_size
+			,:backup_error
+			,:backup_created_by
+			,:backup_created
+			,:backup_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, backup)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind backup object")
+	}
+
+	if backup.ID, err = database.InsertReturningID(ctx, db, query, "backup_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state, the sizes and the error of a backup.
+func (s *BackupStore) Update(ctx context.Context, backup *types.Backup) error {
+	const sqlQuery = `
+		UPDATE backups
+		SET
+			 backup_state = :backup_state
+			,backup_bundle_size = :backup_bundle_size
+			,backup_metadata_size = :backup_metadata_size
+			,backup_error = :backup_error
+			,backup_updated = :backup_updated
+		WHERE backup_id = :backup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, backup)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind backup object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update backup")
+	}
+
+	return nil
+}
+
+// List returns the backups matching the filter, newest first.
+func (s *BackupStore) List(ctx context.Context, filter types.BackupFilter) ([]*types.Backup, error) {
+	stmt := database.Builder.
+		Select(backupColumns).
+		From("backups")
+
+	stmt = applyBackupFilter(stmt, filter)
+	stmt = stmt.OrderBy("backup_id desc")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list backups query to sql: %w", err)
+	}
+
+	result := make([]*types.Backup, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list backups query")
+	}
+
+	return result, nil
+}
+
+// Count returns the number of backups matching the filter.
+func (s *BackupStore) Count(ctx context.Context, filter types.BackupFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("backups")
+
+	stmt = applyBackupFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count backups query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count backups query")
+	}
+
+	return count, nil
+}
+
+// ListByRepo returns all backups of a repository, newest first.
+func (s *BackupStore) ListByRepo(ctx context.Context, repoID int64) ([]*types.Backup, error) {
+	const sqlQuery = backupSelectBase + `
+	WHERE backup_repo_id = $1
+	ORDER BY backup_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := make([]*types.Backup, 0)
+	if err := db.SelectContext(ctx, &result, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list backups of repository")
+	}
+
+	return result, nil
+}
+
+// Delete removes a backup.
+func (s *BackupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM backups
+		WHERE backup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete backup")
+	}
+
+	return nil
+}
+
+func applyBackupFilter(
+	stmt squirrel.SelectBuilder,
+	filter types.BackupFilter,
+) squirrel.SelectBuilder {
+	if filter.RepoID != 0 {
+		stmt = stmt.Where("backup_repo_id = ?", filter.RepoID)
+	}
+
+	if filter.State != "" {
+		stmt = stmt.Where("backup_state = ?", filter.State)
+	}
+
+	return stmt
+}
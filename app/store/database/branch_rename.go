@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.BranchRenameStore = (*BranchRenameStore)(nil)
+
+// NewBranchRenameStore returns a new BranchRenameStore.
+func NewBranchRenameStore(db *sqlx.DB) *BranchRenameStore {
+	return &BranchRenameStore{
+		db: db,
+	}
+}
+
+// BranchRenameStore implements store.BranchRenameStore backed by a relational database.
+type BranchRenameStore struct {
+	db *sqlx.DB
+}
+
+// branchRename is an internal representation used to store branch renames in the database.
+type branchRename struct {
+	RepoID    int64  `db:"branch_rename_repo_id"`
+	OldName   string `db:"branch_rename_old_name"`
+	NewName   string `db:"branch_rename_new_name"`
+	CreatedBy int64  `db:"branch_rename_created_by"`
+	Created   int64  `db:"branch_rename_created"`
+}
+
+const (
+	branchRenameColumns = `
+		 branch_rename_repo_id
+		,branch_rename_old_name
+		,branch_rename_new_name
+		,branch_rename_created_by
+		,branch_rename_created`
+)
+
+// Find returns the rename of the branch with the provided (old) name.
+func (s *BranchRenameStore) Find(ctx context.Context, repoID int64, oldName string) (*types.BranchRename, error) {
+	const sqlQuery = `
+	SELECT` + branchRenameColumns + `
+	FROM branch_renames
+	WHERE branch_rename_repo_id = $1 AND branch_rename_old_name = $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &branchRename{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, oldName); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find branch rename")
+	}
+
+	return mapBranchRename(dst), nil
+}
+
+// Upsert records the branch rename, overwriting any previous rename of a branch with the same name.
+// Renames of other branches that pointed to the old name are updated to point to the new name,
+// and a rename pointing back to the new name is removed.
+func (s *BranchRenameStore) Upsert(ctx context.Context, rename *types.BranchRename) error {
+	const sqlQueryChain = `
+	UPDATE branch_renames
+	SET branch_rename_new_name = $3
+	WHERE branch_rename_repo_id = $1 AND branch_rename_new_name = $2`
+
+	const sqlQueryDelete = `
+	DELETE FROM branch_renames
+	WHERE branch_rename_repo_id = $1 AND branch_rename_old_name = $2`
+
+	const sqlQueryInsert = `
+	INSERT INTO branch_renames (
+		 branch_rename_repo_id
+		,branch_rename_old_name
+		,branch_rename_new_name
+		,branch_rename_created_by
+		,branch_rename_created
+	) VALUES (
+		 :branch_rename_repo_id
+		,:branch_rename_old_name
+		,:branch_rename_new_name
+		,:branch_rename_created_by
+		,:branch_rename_created
+	)`
+
+	const sqlQueryUpdate = `
+		 branch_rename_new_name = :branch_rename_new_name
+		,branch_rename_created_by = :branch_rename_created_by
+		,branch_rename_created = :branch_rename_created`
+
+	sqlQueryUpsert := sqlQueryInsert + `
+	ON CONFLICT (branch_rename_repo_id, branch_rename_old_name) DO
+	UPDATE SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQueryUpsert = sqlQueryInsert + `
+	ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryChain, rename.RepoID, rename.OldName, rename.NewName); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update chained branch renames")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, rename.RepoID, rename.NewName); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete reverted branch rename")
+	}
+
+	query, arg, err := db.BindNamed(sqlQueryUpsert, mapInternalBranchRename(rename))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind branch rename object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func mapBranchRename(in *branchRename) *types.BranchRename {
+	return &types.BranchRename{
+		RepoID:    in.RepoID,
+		OldName:   in.OldName,
+		NewName:   in.NewName,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+	}
+}
+
+func mapInternalBranchRename(in *types.BranchRename) *branchRename {
+	return &branchRename{
+		RepoID:    in.RepoID,
+		OldName:   in.OldName,
+		NewName:   in.NewName,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ChatIntegrationStore = (*ChatIntegrationStore)(nil)
+
+// NewChatIntegrationStore returns a new ChatIntegrationStore.
+func NewChatIntegrationStore(db *sqlx.DB) *ChatIntegrationStore {
+	return &ChatIntegrationStore{
+		db: db,
+	}
+}
+
+// ChatIntegrationStore implements store.ChatIntegrationStore backed by a relational database.
+type ChatIntegrationStore struct {
+	db *sqlx.DB
+}
+
+// chatIntegration is an internal representation used to store chat integration data in the database.
+type chatIntegration struct {
+	ID        int64    `db:"chat_integration_id"`
+	Version   int64    `db:"chat_integration_version"`
+	RepoID    null.Int `db:"chat_integration_repo_id"`
+	SpaceID   null.Int `db:"chat_integration_space_id"`
+	CreatedBy int64    `db:"chat_integration_created_by"`
+	Created   int64    `db:"chat_integration_created"`
+	Updated   int64    `db:"chat_integration_updated"`
+
+	DisplayName string            `db:"chat_integration_display_name"`
+	Provider    enum.ChatProvider `db:"chat_integration_provider"`
+	URL         string            `db:"chat_integration_url"`
+	Enabled     bool              `db:"chat_integration_enabled"`
+	Triggers    string            `db:"chat_integration_triggers"`
+}
+
+const (
+	chatIntegrationColumns = `
+		 chat_integration_id
+		,chat_integration_version
+		,chat_integration_repo_id
+		,chat_integration_space_id
+		,chat_integration_created_by
+		,chat_integration_created
+		,chat_integration_updated
+		,chat_integration_display_name
+		,chat_integration_provider
+		,chat_integration_url
+		,chat_integration_enabled
+		,chat_integration_triggers`
+
+	chatIntegrationSelectBase = `
+	SELECT` + chatIntegrationColumns + `
+	FROM chat_integrations`
+)
+
+// Find finds the chat integration by id.
+func (s *ChatIntegrationStore) Find(ctx context.Context, id int64) (*types.ChatIntegration, error) {
+	const sqlQuery = chatIntegrationSelectBase + `
+		WHERE chat_integration_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &chatIntegration{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToChatIntegration(dst)
+}
+
+// Create creates a new chat integration.
+func (s *ChatIntegrationStore) Create(ctx context.Context, integration *types.ChatIntegration) error {
+	const sqlQuery = `
+		INSERT INTO chat_integrations (
+			 chat_integration_repo_id
+			,chat_integration_space_id
+			,chat_integration_created_by
+			,chat_integration_created
+			,chat_integration_updated
+			,chat_integration_display_name
+			,chat_integration_provider
+			,chat_integration_url
+			,chat_integration_enabled
+			,chat_integration_triggers
+		) values (
+			 :chat_integration_repo_id
+			,:chat_integration_space_id
+			,:chat_integration_created_by
+			,:chat_integration_created
+			,:chat_integration_updated
+			,:chat_integration_display_name
+			,:chat_integration_provider
+			,:chat_integration_url
+			,:chat_integration_enabled
+			,:chat_integration_triggers
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIntegration, err := mapToInternalChatIntegration(integration)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIntegration)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind chat integration object")
+	}
+
+	if integration.ID, err = database.InsertReturningID(ctx, db, query, "chat_integration_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing chat integration.
+func (s *ChatIntegrationStore) Update(ctx context.Context, integration *types.ChatIntegration) error {
+	const sqlQuery = `
+		UPDATE chat_integrations
+		SET
+			 chat_integration_version = :chat_integration_version
+			,chat_integration_updated = :chat_integration_updated
+			,chat_integration_display_name = :chat_integration_display_name
+			,chat_integration_provider = :chat_integration_provider
+			,chat_integration_url = :chat_integration_url
+			,chat_integration_enabled = :chat_integration_enabled
+			,chat_integration_triggers = :chat_integration_triggers
+		WHERE chat_integration_id = :chat_integration_id
+			AND chat_integration_version = :chat_integration_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbIntegration, err := mapToInternalChatIntegration(integration)
+	if err != nil {
+		return err
+	}
+
+	// update Version (used for optimistic locking) and Updated time
+	dbIntegration.Version++
+	dbIntegration.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbIntegration)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind chat integration object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update chat integration")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	integration.Version = dbIntegration.Version
+	integration.Updated = dbIntegration.Updated
+
+	return nil
+}
+
+// Delete deletes the chat integration for the given id.
+func (s *ChatIntegrationStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM chat_integrations
+		WHERE chat_integration_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List lists the chat integrations for a given parent type and id.
+func (s *ChatIntegrationStore) List(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+) ([]*types.ChatIntegration, error) {
+	stmt := database.Builder.
+		Select(chatIntegrationColumns).
+		From("chat_integrations").
+		OrderBy("chat_integration_id ASC")
+
+	switch parentType {
+	case enum.WebhookParentRepo:
+		stmt = stmt.Where("chat_integration_repo_id = ?", parentID)
+	case enum.WebhookParentSpace:
+		stmt = stmt.Where("chat_integration_space_id = ?", parentID)
+	default:
+		return nil, fmt.Errorf("chat integration parent type '%s' is not supported", parentType)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	return s.list(ctx, sql, args...)
+}
+
+// ListForRepo lists the chat integrations of the repo and of all spaces containing it.
+func (s *ChatIntegrationStore) ListForRepo(
+	ctx context.Context,
+	repoID int64,
+	spaceID int64,
+) ([]*types.ChatIntegration, error) {
+	const sqlQuery = `
+	WITH RECURSIVE space_ancestors(space_ancestor_id, space_ancestor_parent_id) AS (
+		SELECT space_id, space_parent_id
+		FROM spaces
+		WHERE space_id = $2
+	UNION
+		SELECT space_id, space_parent_id
+		FROM spaces
+		JOIN space_ancestors ON space_id = space_ancestor_parent_id
+	)
+	SELECT` + chatIntegrationColumns + `
+	FROM chat_integrations
+	WHERE chat_integration_repo_id = $1
+		OR chat_integration_space_id IN (SELECT space_ancestor_id FROM space_ancestors)
+	ORDER BY chat_integration_id ASC`
+
+	return s.list(ctx, sqlQuery, repoID, spaceID)
+}
+
+func (s *ChatIntegrationStore) list(
+	ctx context.Context,
+	sqlQuery string,
+	args ...any,
+) ([]*types.ChatIntegration, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*chatIntegration{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res := make([]*types.ChatIntegration, len(dst))
+	for i := range dst {
+		integration, err := mapToChatIntegration(dst[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = integration
+	}
+
+	return res, nil
+}
+
+func mapToChatIntegration(in *chatIntegration) (*types.ChatIntegration, error) {
+	res := &types.ChatIntegration{
+		ID:          in.ID,
+		Version:     in.Version,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		DisplayName: in.DisplayName,
+		Provider:    in.Provider,
+		URL:         in.URL,
+		Enabled:     in.Enabled,
+		Triggers:    chatIntegrationTriggersFromString(in.Triggers),
+	}
+
+	switch {
+	case in.RepoID.Valid && in.SpaceID.Valid:
+		return nil, fmt.Errorf("both repoID and spaceID are set for chat integration %d", in.ID)
+	case in.RepoID.Valid:
+		res.ParentType = enum.WebhookParentRepo
+		res.ParentID = in.RepoID.Int64
+	case in.SpaceID.Valid:
+		res.ParentType = enum.WebhookParentSpace
+		res.ParentID = in.SpaceID.Int64
+	default:
+		return nil, fmt.Errorf("neither repoID nor spaceID are set for chat integration %d", in.ID)
+	}
+
+	return res, nil
+}
+
+func mapToInternalChatIntegration(in *types.ChatIntegration) (*chatIntegration, error) {
+	res := &chatIntegration{
+		ID:          in.ID,
+		Version:     in.Version,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+		DisplayName: in.DisplayName,
+		Provider:    in.Provider,
+		URL:         in.URL,
+		Enabled:     in.Enabled,
+		Triggers:    chatIntegrationTriggersToString(in.Triggers),
+	}
+
+	switch in.ParentType {
+	case enum.WebhookParentRepo:
+		res.RepoID = null.IntFrom(in.ParentID)
+	case enum.WebhookParentSpace:
+		res.SpaceID = null.IntFrom(in.ParentID)
+	default:
+		return nil, fmt.Errorf("chat integration parent type '%s' is not supported", in.ParentType)
+	}
+
+	return res, nil
+}
+
+func chatIntegrationTriggersFromString(triggersString string) []enum.ChatIntegrationTrigger {
+	if triggersString == "" {
+		return []enum.ChatIntegrationTrigger{}
+	}
+
+	rawTriggers := strings.Split(triggersString, triggersSeparator)
+
+	triggers := make([]enum.ChatIntegrationTrigger, len(rawTriggers))
+	for i, rawTrigger := range rawTriggers {
+		// ASSUMPTION: trigger is valid value (as we wrote it to DB)
+		triggers[i] = enum.ChatIntegrationTrigger(rawTrigger)
+	}
+
+	return triggers
+}
+
+func chatIntegrationTriggersToString(triggers []enum.ChatIntegrationTrigger) string {
+	rawTriggers := make([]string, len(triggers))
+	for i := range triggers {
+		rawTriggers[i] = string(triggers[i])
+	}
+
+	return strings.Join(rawTriggers, triggersSeparator)
+}
@@ -86,7 +86,7 @@ type check struct {
 
 // Upsert creates new or updates an existing status check result.
 func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
-	const sqlQuery = `
+	const sqlQueryInsert = `
 	INSERT INTO checks (
 		 check_created_by
 		,check_created
@@ -115,9 +115,9 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 		,:check_metadata
 		,:check_payload_kind
 		,:check_payload_version
-	)
-	ON CONFLICT (check_repo_id, check_commit_sha, check_uid) DO
-	UPDATE SET
+	)`
+
+	const sqlQueryUpdate = `
 		 check_updated = :check_updated
 		,check_status = :check_status
 		,check_summary = :check_summary
@@ -125,7 +125,17 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 		,check_payload = :check_payload
 		,check_metadata = :check_metadata
 		,check_payload_kind = :check_payload_kind
-		,check_payload_version = :check_payload_version
+		,check_payload_version = :check_payload_version`
+
+	// mysql doesn't support RETURNING, the stored values are selected after the upsert instead.
+	if database.IsMySQL(s.db) {
+		return s.upsertWithSeparateSelect(ctx, check, sqlQueryInsert+`
+	ON DUPLICATE KEY UPDATE`+sqlQueryUpdate)
+	}
+
+	const sqlQuery = sqlQueryInsert + `
+	ON CONFLICT (check_repo_id, check_commit_sha, check_uid) DO
+	UPDATE SET` + sqlQueryUpdate + `
 	RETURNING check_id, check_created_by, check_created`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -142,6 +152,32 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 	return nil
 }
 
+func (s *CheckStore) upsertWithSeparateSelect(ctx context.Context, check *types.Check, sqlQueryUpsert string) error {
+	const sqlQuerySelect = `
+	SELECT check_id, check_created_by, check_created
+	FROM checks
+	WHERE check_repo_id = $1 AND check_commit_sha = $2 AND check_uid = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQueryUpsert, mapInternalCheck(check))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind status check object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	err = db.QueryRowContext(ctx, sqlQuerySelect, check.RepoID, check.CommitSHA, check.UID).
+		Scan(&check.ID, &check.CreatedBy, &check.Created)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to find upserted status check")
+	}
+
+	return nil
+}
+
 // Count counts status check results for a specific commit in a repo.
 func (s *CheckStore) Count(ctx context.Context,
 	repoID int64,
@@ -159,7 +195,7 @@ func (s *CheckStore) Count(ctx context.Context,
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -192,7 +228,7 @@ func (s *CheckStore) List(ctx context.Context,
 
 	dst := make([]*check, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to execute list status checks query")
@@ -206,6 +242,39 @@ func (s *CheckStore) List(ctx context.Context,
 	return result, nil
 }
 
+// ListAll returns all status check results for a specific commit in a repo.
+func (s *CheckStore) ListAll(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]types.Check, error) {
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_commit_sha = ?", commitSHA).
+		OrderBy("check_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*check, 0)
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to execute list all status checks query")
+	}
+
+	result, err := s.mapSliceCheck(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // ListRecent returns a list of recently executed status checks in a repository.
 func (s *CheckStore) ListRecent(ctx context.Context, repoID int64, since time.Time) ([]string, error) {
 	stmt := database.Builder.
@@ -222,7 +291,7 @@ func (s *CheckStore) ListRecent(ctx context.Context, repoID int64, since time.Ti
 
 	dst := make([]string, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to execute list recent status checks query")
@@ -82,8 +82,7 @@ func (s *ReqCheckStore) Create(ctx context.Context, reqCheck *types.ReqCheck) er
 		,:reqcheck_repo_id
 		,:reqcheck_branch_pattern
 		,:reqcheck_check_uid
-	)
-	RETURNING reqcheck_id`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -92,7 +91,7 @@ func (s *ReqCheckStore) Create(ctx context.Context, reqCheck *types.ReqCheck) er
 		return database.ProcessSQLErrorf(err, "Failed to bind required status check object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&reqCheck.ID); err != nil {
+	if reqCheck.ID, err = database.InsertReturningID(ctx, db, query, "reqcheck_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -114,7 +113,7 @@ func (s *ReqCheckStore) List(ctx context.Context, repoID int64) ([]*types.ReqChe
 
 	dst := make([]*reqCheck, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to execute list required status checks query")
@@ -128,6 +127,24 @@ func (s *ReqCheckStore) List(ctx context.Context, repoID int64) ([]*types.ReqChe
 	return result, nil
 }
 
+// ListForBranch returns a list of required status checks that apply to a branch of a repo.
+func (s *ReqCheckStore) ListForBranch(ctx context.Context, repoID int64, branch string) ([]*types.ReqCheck, error) {
+	reqChecks, err := s.List(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	// branch patterns are globs, so the filtering can't be done by the database.
+	result := make([]*types.ReqCheck, 0, len(reqChecks))
+	for _, reqCheck := range reqChecks {
+		if reqCheck.Matches(branch) {
+			result = append(result, reqCheck)
+		}
+	}
+
+	return result, nil
+}
+
 // Delete removes a required status checks for a repo.
 func (s *ReqCheckStore) Delete(ctx context.Context, repoID, reqCheckID int64) error {
 	stmt := database.Builder.
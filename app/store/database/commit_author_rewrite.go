@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CommitAuthorRewriteStore = (*CommitAuthorRewriteStore)(nil)
+
+const commitAuthorRewriteColumns = `
+	 commit_author_rewrite_id
+	,commit_author_rewrite_repo_id
+	,commit_author_rewrite_sha
+	,commit_author_rewrite_operation
+	,commit_author_rewrite_principal_id
+	,commit_author_rewrite_requested_name
+	,commit_author_rewrite_requested_email
+	,commit_author_rewrite_author_name
+	,commit_author_rewrite_author_email
+	,commit_author_rewrite_created`
+
+// NewCommitAuthorRewriteStore returns a new CommitAuthorRewriteStore.
+func NewCommitAuthorRewriteStore(db *sqlx.DB) *CommitAuthorRewriteStore {
+	return &CommitAuthorRewriteStore{
+		db: db,
+	}
+}
+
+// CommitAuthorRewriteStore implements store.CommitAuthorRewriteStore backed by a relational database.
+type CommitAuthorRewriteStore struct {
+	db *sqlx.DB
+}
+
+// Create creates a new commit author rewrite record.
+func (s *CommitAuthorRewriteStore) Create(ctx context.Context, rewrite *types.CommitAuthorRewrite) error {
+	const sqlQuery = `
+		INSERT INTO commit_author_rewrites (
+			 commit_author_rewrite_repo_id
+			,commit_author_rewrite_sha
+			,commit_author_rewrite_operation
+			,commit_author_rewrite_principal_id
+			,commit_author_rewrite_requested_name
+			,commit_author_rewrite_requested_email
+			,commit_author_rewrite_author_name
+			,commit_author_rewrite_author_email
+			,commit_author_rewrite_created
+		) values (
+			 :commit_author_rewrite_repo_id
+			,:commit_author_rewrite_sha
+			,:commit_author_rewrite_operation
+			,:commit_author_rewrite_principal_id
+			,:commit_author_rewrite_requested_name
+			,:commit_author_rewrite_requested_email
+			,:commit_author_rewrite_author_name
+			,:commit_author_rewrite_author_email
+			,:commit_author_rewrite_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, rewrite)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind commit author rewrite object")
+	}
+
+	if rewrite.ID, err = database.InsertReturningID(ctx, db, query, "commit_author_rewrite_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns the commit author rewrites of a repository matching the filter.
+func (s *CommitAuthorRewriteStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.CommitAuthorRewriteFilter,
+) ([]*types.CommitAuthorRewrite, error) {
+	stmt := database.Builder.
+		Select(commitAuthorRewriteColumns).
+		From("commit_author_rewrites").
+		Where("commit_author_rewrite_repo_id = ?", repoID)
+
+	stmt = applyCommitAuthorRewriteFilter(stmt, filter)
+
+	stmt = stmt.
+		OrderBy("commit_author_rewrite_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.CommitAuthorRewrite{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return dst, nil
+}
+
+// Count returns the number of commit author rewrites of a repository matching the filter.
+func (s *CommitAuthorRewriteStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.CommitAuthorRewriteFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("commit_author_rewrites").
+		Where("commit_author_rewrite_repo_id = ?", repoID)
+
+	stmt = applyCommitAuthorRewriteFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func applyCommitAuthorRewriteFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.CommitAuthorRewriteFilter,
+) squirrel.SelectBuilder {
+	if filter.SHA != "" {
+		stmt = stmt.Where("commit_author_rewrite_sha = ?", filter.SHA)
+	}
+
+	return stmt
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CommitVerificationStore = (*CommitVerificationStore)(nil)
+
+// commitVerificationBatchSize is the max number of values queried at once to stay below the parameter limits of the DBs.
+const commitVerificationBatchSize = 500
+
+const commitVerificationColumns = `
+	 commit_verification_repo_id
+	,commit_verification_sha
+	,commit_verification_status
+	,commit_verification_scheme
+	,commit_verification_key_id
+	,commit_verification_signer_id
+	,commit_verification_created`
+
+// NewCommitVerificationStore returns a new CommitVerificationStore.
+func NewCommitVerificationStore(db *sqlx.DB) *CommitVerificationStore {
+	return &CommitVerificationStore{
+		db: db,
+	}
+}
+
+// CommitVerificationStore implements a store.CommitVerificationStore backed by a relational database.
+type CommitVerificationStore struct {
+	db *sqlx.DB
+}
+
+// ListBySHAs returns the cached verification results of the provided commits of a repository.
+func (s *CommitVerificationStore) ListBySHAs(
+	ctx context.Context,
+	repoID int64,
+	shas []string,
+) ([]*types.CommitVerification, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	res := []*types.CommitVerification{}
+	for len(shas) > 0 {
+		batch := shas
+		if len(batch) > commitVerificationBatchSize {
+			batch = batch[:commitVerificationBatchSize]
+		}
+		shas = shas[len(batch):]
+
+		stmt := database.Builder.
+			Select(commitVerificationColumns).
+			From("commit_verifications").
+			Where("commit_verification_repo_id = ?", repoID).
+			Where(squirrel.Eq{"commit_verification_sha": batch})
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+		}
+
+		dst := []*types.CommitVerification{}
+		if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Select query failed")
+		}
+
+		res = append(res, dst...)
+	}
+
+	return res, nil
+}
+
+// Upsert creates or updates the verification result of a commit.
+func (s *CommitVerificationStore) Upsert(ctx context.Context, verification *types.CommitVerification) error {
+	const sqlQueryInsert = `
+	INSERT INTO commit_verifications (` + commitVerificationColumns + `
+	) VALUES (
+		 :commit_verification_repo_id
+		,:commit_verification_sha
+		,:commit_verification_status
+		,:commit_verification_scheme
+		,:commit_verification_key_id
+		,:commit_verification_signer_id
+		,:commit_verification_created
+	)`
+
+	const sqlQueryUpdate = `
+		 commit_verification_status = :commit_verification_status
+		,commit_verification_scheme = :commit_verification_scheme
+		,commit_verification_key_id = :commit_verification_key_id
+		,commit_verification_signer_id = :commit_verification_signer_id
+		,commit_verification_created = :commit_verification_created`
+
+	sqlQuery := sqlQueryInsert + `
+	ON CONFLICT (commit_verification_repo_id, commit_verification_sha) DO
+	UPDATE SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+	ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, verification)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind commit verification object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// DeleteByKeyIDs removes all verification results of signatures made by any of the provided keys.
+func (s *CommitVerificationStore) DeleteByKeyIDs(ctx context.Context, keyIDs []string) error {
+	if len(keyIDs) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Delete("commit_verifications").
+		Where(squirrel.Eq{"commit_verification_key_id": keyIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete commit verifications")
+	}
+
+	return nil
+}
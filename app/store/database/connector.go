@@ -64,7 +64,7 @@ type connectorStore struct {
 func (s *connectorStore) Find(ctx context.Context, id int64) (*types.Connector, error) {
 	const findQueryStmt = connectorQueryBase + `
 		WHERE connector_id = $1`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Connector)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
@@ -77,7 +77,7 @@ func (s *connectorStore) Find(ctx context.Context, id int64) (*types.Connector,
 func (s *connectorStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Connector, error) {
 	const findQueryStmt = connectorQueryBase + `
 		WHERE connector_space_id = $1 AND connector_uid = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Connector)
 	if err := db.GetContext(ctx, dst, findQueryStmt, spaceID, uid); err != nil {
@@ -107,7 +107,7 @@ func (s *connectorStore) Create(ctx context.Context, connector *types.Connector)
 		,:connector_created
 		,:connector_updated
 		,:connector_version
-	) RETURNING connector_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(connectorInsertStmt, connector)
@@ -115,7 +115,7 @@ func (s *connectorStore) Create(ctx context.Context, connector *types.Connector)
 		return database.ProcessSQLErrorf(err, "Failed to bind connector object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&connector.ID); err != nil {
+	if connector.ID, err = database.InsertReturningID(ctx, db, query, "connector_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "connector query failed")
 	}
 
@@ -215,7 +215,7 @@ func (s *connectorStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Connector{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -271,7 +271,7 @@ func (s *connectorStore) Count(ctx context.Context, parentID int64, filter types
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
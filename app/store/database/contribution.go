@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ContributionStore = (*ContributionStore)(nil)
+
+// NewContributionStore returns a new ContributionStore.
+func NewContributionStore(db *sqlx.DB) *ContributionStore {
+	return &ContributionStore{
+		db: db,
+	}
+}
+
+// ContributionStore implements store.ContributionStore backed by a relational database.
+type ContributionStore struct {
+	db *sqlx.DB
+}
+
+// contributionsSubQuery selects the contributions of principal $1 created at or after $2:
+// opened pull requests, submitted reviews, pull request comments and pushes (only kept as long as the feed events).
+const contributionsSubQuery = `
+	SELECT
+		 pullreq_target_repo_id AS contribution_repo_id
+		,'` + string(enum.ContributionTypePullReq) + `' AS contribution_type
+		,pullreq_created AS contribution_created
+	FROM pullreqs
+	WHERE pullreq_created_by = $1 AND pullreq_created >= $2
+	UNION ALL
+	SELECT
+		 pullreq_target_repo_id
+		,'` + string(enum.ContributionTypeReview) + `'
+		,pullreq_review_created
+	FROM pullreq_reviews
+	JOIN pullreqs ON pullreq_id = pullreq_review_pullreq_id
+	WHERE pullreq_review_created_by = $1 AND pullreq_review_created >= $2
+	UNION ALL
+	SELECT
+		 pullreq_activity_repo_id
+		,'` + string(enum.ContributionTypeComment) + `'
+		,pullreq_activity_created
+	FROM pullreq_activities
+	WHERE pullreq_activity_created_by = $1 AND pullreq_activity_created >= $2 AND
+		pullreq_activity_kind IN ($3, $4) AND pullreq_activity_deleted IS NULL
+	UNION ALL
+	SELECT
+		 feed_event_repo_id
+		,'` + string(enum.ContributionTypePush) + `'
+		,feed_event_created
+	FROM feed_events
+	WHERE feed_event_principal_id = $1 AND feed_event_created >= $2 AND
+		feed_event_type IN ($5, $6, $7)`
+
+func contributionsArgs(principalID int64, since int64) []interface{} {
+	return []interface{}{
+		principalID,
+		since,
+		enum.PullReqActivityKindComment,
+		enum.PullReqActivityKindChangeComment,
+		enum.FeedEventTypeBranchCreated,
+		enum.FeedEventTypeBranchUpdated,
+		enum.FeedEventTypeTagCreated,
+	}
+}
+
+// List returns the contributions of the principal created at or after the provided time (unix millis).
+func (s *ContributionStore) List(
+	ctx context.Context,
+	principalID int64,
+	since int64,
+) ([]*types.Contribution, error) {
+	const sqlQuery = `
+	SELECT contribution_repo_id, contribution_type, contribution_created
+	FROM (` + contributionsSubQuery + `
+	) contributions
+	ORDER BY contribution_created`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.Contribution{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, contributionsArgs(principalID, since)...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list contributions")
+	}
+
+	return dst, nil
+}
+
+// ListRepoIDs returns the ids of all repositories the principal contributed to.
+func (s *ContributionStore) ListRepoIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT DISTINCT contribution_repo_id
+	FROM (` + contributionsSubQuery + `
+	) contributions`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery, contributionsArgs(principalID, 0)...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list contributed repo ids")
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.EventDeadLetterStore = (*EventDeadLetterStore)(nil)
+
+func NewEventDeadLetterStore(db *sqlx.DB) *EventDeadLetterStore {
+	return &EventDeadLetterStore{
+		db: db,
+	}
+}
+
+type EventDeadLetterStore struct {
+	db *sqlx.DB
+}
+
+const (
+	eventDeadLetterColumns = `
+		 event_dead_letter_id
+		,event_dead_letter_stream_id
+		,event_dead_letter_group_name
+		,event_dead_letter_message_id
+		,event_dead_letter_payload
+		,event_dead_letter_retries
+		,event_dead_letter_last_error
+		,event_dead_letter_created`
+
+	eventDeadLetterSelectBase = `
+	SELECT` + eventDeadLetterColumns + `
+	FROM event_dead_letters`
+)
+
+// Find returns the dead letter with the provided id.
+func (s *EventDeadLetterStore) Find(ctx context.Context, id int64) (*types.EventDeadLetter, error) {
+	const sqlQuery = eventDeadLetterSelectBase + `
+	WHERE event_dead_letter_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.EventDeadLetter{}
+	if err := db.GetContext(ctx, result, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find event dead letter")
+	}
+
+	return result, nil
+}
+
+// Create persists a new dead letter.
+func (s *EventDeadLetterStore) Create(ctx context.Context, deadLetter *types.EventDeadLetter) error {
+	const sqlQuery = `
+		INSERT INTO event_dead_letters (
+			 event_dead_letter_stream_id
+			,event_dead_letter_group_name
+			,event_dead_letter_message_id
+			,event_dead_letter_payload
+			,event_dead_letter_retries
+			,event_dead_letter_last_error
+			,event_dead_letter_created
+		) VALUES (
+			 :event_dead_letter_stream_id
+			,:event_dead_letter_group_name
+			,:event_dead_letter_message_id
+			,:event_dead_letter_payload
+			,:event_dead_letter_retries
+			,:event_dead_letter_last_error
+			,:event_dead_letter_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, deadLetter)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind event dead letter object")
+	}
+
+	if deadLetter.ID, err = database.InsertReturningID(ctx, db, query, "event_dead_letter_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns the dead letters matching the filter, newest first.
+func (s *EventDeadLetterStore) List(
+	ctx context.Context,
+	filter types.EventDeadLetterFilter,
+) ([]*types.EventDeadLetter, error) {
+	stmt := database.Builder.
+		Select(eventDeadLetterColumns).
+		From("event_dead_letters")
+
+	stmt = applyEventDeadLetterFilter(stmt, filter)
+	stmt = stmt.OrderBy("event_dead_letter_id desc")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list event dead letters query to sql: %w", err)
+	}
+
+	result := make([]*types.EventDeadLetter, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list event dead letters query")
+	}
+
+	return result, nil
+}
+
+// Count returns the number of dead letters matching the filter.
+func (s *EventDeadLetterStore) Count(ctx context.Context, filter types.EventDeadLetterFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("event_dead_letters")
+
+	stmt = applyEventDeadLetterFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count event dead letters query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count event dead letters query")
+	}
+
+	return count, nil
+}
+
+// Delete removes a dead letter.
+func (s *EventDeadLetterStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM event_dead_letters
+		WHERE event_dead_letter_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete event dead letter")
+	}
+
+	return nil
+}
+
+// DeleteOld removes all dead letters that are older than the provided time.
+func (s *EventDeadLetterStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM event_dead_letters
+		WHERE event_dead_letter_created < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, olderThan.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to delete old event dead letters")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to get number of deleted event dead letters")
+	}
+
+	return n, nil
+}
+
+func applyEventDeadLetterFilter(
+	stmt squirrel.SelectBuilder,
+	filter types.EventDeadLetterFilter,
+) squirrel.SelectBuilder {
+	if filter.StreamID != "" {
+		stmt = stmt.Where("event_dead_letter_stream_id = ?", filter.StreamID)
+	}
+
+	if filter.GroupName != "" {
+		stmt = stmt.Where("event_dead_letter_group_name = ?", filter.GroupName)
+	}
+
+	return stmt
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.EventOutboxStore = (*EventOutboxStore)(nil)
+
+func NewEventOutboxStore(db *sqlx.DB) *EventOutboxStore {
+	return &EventOutboxStore{
+		db: db,
+	}
+}
+
+type EventOutboxStore struct {
+	db *sqlx.DB
+}
+
+const (
+	eventOutboxColumns = `
+		 event_outbox_id
+		,event_outbox_stream_id
+		,event_outbox_payload
+		,event_outbox_created
+		,event_outbox_attempts
+		,event_outbox_next_attempt
+		,event_outbox_last_error`
+)
+
+// Create persists a new message in the outbox.
+// If the context contains a transaction, the message is only persisted if the transaction is committed.
+func (s *EventOutboxStore) Create(ctx context.Context, msg *types.EventOutboxMessage) error {
+	const sqlQuery = `
+		INSERT INTO event_outbox (
+			 event_outbox_stream_id
+			,event_outbox_payload
+			,event_outbox_created
+			,event_outbox_attempts
+			,event_outbox_next_attempt
+			,event_outbox_last_error
+		) VALUES (
+			 :event_outbox_stream_id
+			,:event_outbox_payload
+			,:event_outbox_created
+			,:event_outbox_attempts
+			,:event_outbox_next_attempt
+			,:event_outbox_last_error
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, msg)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind event outbox message object")
+	}
+
+	if msg.ID, err = database.InsertReturningID(ctx, db, query, "event_outbox_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// ListReady returns up to limit messages that are ready for delivery, in the order they have been created.
+func (s *EventOutboxStore) ListReady(ctx context.Context, now time.Time, limit int) ([]*types.EventOutboxMessage, error) {
+	stmt := database.Builder.
+		Select(eventOutboxColumns).
+		From("event_outbox").
+		Where("event_outbox_next_attempt <= ?", now.UnixMilli()).
+		OrderBy("event_outbox_id asc").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list ready event outbox messages query to sql: %w", err)
+	}
+
+	result := make([]*types.EventOutboxMessage, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list ready event outbox messages query")
+	}
+
+	return result, nil
+}
+
+// UpdateAttempt updates the delivery attempt information of a message.
+func (s *EventOutboxStore) UpdateAttempt(ctx context.Context, msg *types.EventOutboxMessage) error {
+	const sqlQuery = `
+		UPDATE event_outbox
+		SET
+			 event_outbox_attempts = :event_outbox_attempts
+			,event_outbox_next_attempt = :event_outbox_next_attempt
+			,event_outbox_last_error = :event_outbox_last_error
+		WHERE event_outbox_id = :event_outbox_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, msg)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind event outbox message object for update")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update event outbox message")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a delivered message from the outbox.
+func (s *EventOutboxStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM event_outbox
+		WHERE event_outbox_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete event outbox message")
+	}
+
+	return nil
+}
@@ -131,7 +131,7 @@ func (s *executionStore) Find(ctx context.Context, id int64) (*types.Execution,
 	SELECT` + executionColumns + `
 	FROM executions
 	WHERE execution_id = $1`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(execution)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
@@ -150,7 +150,7 @@ func (s *executionStore) FindByNumber(
 	SELECT` + executionColumns + `
 	FROM executions
 	WHERE execution_pipeline_id = $1 AND execution_number = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(execution)
 	if err := db.GetContext(ctx, dst, findQueryStmt, pipelineID, executionNum); err != nil {
@@ -234,7 +234,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_created
 		,:execution_updated
 		,:execution_version
-	) RETURNING execution_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(executionInsertStmt, mapExecutionToInternal(execution))
@@ -242,7 +242,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		return database.ProcessSQLErrorf(err, "Failed to bind execution object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&execution.ID); err != nil {
+	if execution.ID, err = database.InsertReturningID(ctx, db, query, "execution_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Execution query failed")
 	}
 
@@ -323,7 +323,7 @@ func (s *executionStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*execution{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -348,7 +348,7 @@ func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, er
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.FeedEventStore = (*FeedEventStore)(nil)
+
+const feedEventColumns = `
+	 feed_event_id
+	,feed_event_repo_id
+	,feed_event_principal_id
+	,feed_event_type
+	,feed_event_ref
+	,feed_event_sha
+	,feed_event_pullreq_number
+	,feed_event_created`
+
+// NewFeedEventStore returns a new FeedEventStore.
+func NewFeedEventStore(db *sqlx.DB) *FeedEventStore {
+	return &FeedEventStore{
+		db: db,
+	}
+}
+
+// FeedEventStore implements store.FeedEventStore backed by a relational database.
+type FeedEventStore struct {
+	db *sqlx.DB
+}
+
+// Create persists a new feed event.
+func (s *FeedEventStore) Create(ctx context.Context, event *types.FeedEvent) error {
+	const sqlQuery = `
+		INSERT INTO feed_events (
+			 feed_event_repo_id
+			,feed_event_principal_id
+			,feed_event_type
+			,feed_event_ref
+			,feed_event_sha
+			,feed_event_pullreq_number
+			,feed_event_created
+		) values (
+			 :feed_event_repo_id
+			,:feed_event_principal_id
+			,:feed_event_type
+			,:feed_event_ref
+			,:feed_event_sha
+			,:feed_event_pullreq_number
+			,:feed_event_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, event)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind feed event object")
+	}
+
+	if event.ID, err = database.InsertReturningID(ctx, db, query, "feed_event_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns the feed events of the provided repositories, newest first.
+func (s *FeedEventStore) List(
+	ctx context.Context,
+	repoIDs []int64,
+	pagination types.Pagination,
+) ([]*types.FeedEvent, error) {
+	stmt := database.Builder.
+		Select(feedEventColumns).
+		From("feed_events").
+		Where(squirrel.Eq{"feed_event_repo_id": repoIDs}).
+		OrderBy("feed_event_id DESC").
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.FeedEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return dst, nil
+}
+
+// Count returns the number of feed events of the provided repositories.
+func (s *FeedEventStore) Count(ctx context.Context, repoIDs []int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("feed_events").
+		Where(squirrel.Eq{"feed_event_repo_id": repoIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// ListContributedRepoIDs returns the ids of all repositories with activity of the principal.
+func (s *FeedEventStore) ListContributedRepoIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+		SELECT DISTINCT feed_event_repo_id
+		FROM feed_events
+		WHERE feed_event_principal_id = $1
+		ORDER BY feed_event_repo_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list contributed repo ids")
+	}
+
+	return dst, nil
+}
+
+// DeleteOld removes all feed events that are older than the provided time.
+func (s *FeedEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM feed_events
+		WHERE feed_event_created < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, olderThan.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to delete old feed events")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to get number of deleted feed events")
+	}
+
+	return n, nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.FileLockStore = (*FileLockStore)(nil)
+
+// fileLockPathBatchSize is the max number of paths queried at once to stay below the parameter limits of the DBs.
+const fileLockPathBatchSize = 500
+
+// NewFileLockStore returns a new FileLockStore.
+func NewFileLockStore(db *sqlx.DB) *FileLockStore {
+	return &FileLockStore{
+		db: db,
+	}
+}
+
+// FileLockStore implements store.FileLockStore backed by a relational database.
+type FileLockStore struct {
+	db *sqlx.DB
+}
+
+// fileLock is an internal representation used to store file lock data in the database.
+type fileLock struct {
+	ID      int64  `db:"file_lock_id"`
+	RepoID  int64  `db:"file_lock_repo_id"`
+	Path    string `db:"file_lock_path"`
+	OwnerID int64  `db:"file_lock_owner_id"`
+	Created int64  `db:"file_lock_created"`
+}
+
+const (
+	fileLockColumns = `
+		 file_lock_id
+		,file_lock_repo_id
+		,file_lock_path
+		,file_lock_owner_id
+		,file_lock_created`
+
+	fileLockSelectBase = `
+	SELECT` + fileLockColumns + `
+	FROM file_locks`
+)
+
+// Find finds the lock by id.
+func (s *FileLockStore) Find(ctx context.Context, id int64) (*types.FileLock, error) {
+	const sqlQuery = fileLockSelectBase + `
+		WHERE file_lock_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &fileLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToFileLock(dst), nil
+}
+
+// Create creates a new lock, it returns store.ErrDuplicate in case the path is already locked.
+func (s *FileLockStore) Create(ctx context.Context, lock *types.FileLock) error {
+	const sqlQuery = `
+		INSERT INTO file_locks (
+			 file_lock_repo_id
+			,file_lock_path
+			,file_lock_owner_id
+			,file_lock_created
+		) values (
+			 :file_lock_repo_id
+			,:file_lock_path
+			,:file_lock_owner_id
+			,:file_lock_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalFileLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind file lock object")
+	}
+
+	if lock.ID, err = database.InsertReturningID(ctx, db, query, "file_lock_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the lock with the given id.
+func (s *FileLockStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM file_locks
+		WHERE file_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns the locks of a repository matching the filter.
+func (s *FileLockStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.FileLockFilter,
+) ([]*types.FileLock, error) {
+	stmt := database.Builder.
+		Select(fileLockColumns).
+		From("file_locks").
+		Where("file_lock_repo_id = ?", repoID)
+
+	stmt = applyFileLockFilter(stmt, filter)
+
+	stmt = stmt.
+		OrderBy("file_lock_path ASC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*fileLock{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToFileLocks(dst), nil
+}
+
+// Count returns the number of locks of a repository matching the filter.
+func (s *FileLockStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.FileLockFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("file_locks").
+		Where("file_lock_repo_id = ?", repoID)
+
+	stmt = applyFileLockFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// ListByPaths returns the locks of a repository held on any of the provided paths.
+func (s *FileLockStore) ListByPaths(
+	ctx context.Context,
+	repoID int64,
+	paths []string,
+) ([]*types.FileLock, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	res := []*types.FileLock{}
+	for len(paths) > 0 {
+		batch := paths
+		if len(batch) > fileLockPathBatchSize {
+			batch = batch[:fileLockPathBatchSize]
+		}
+		paths = paths[len(batch):]
+
+		stmt := database.Builder.
+			Select(fileLockColumns).
+			From("file_locks").
+			Where("file_lock_repo_id = ?", repoID).
+			Where(squirrel.Eq{"file_lock_path": batch}).
+			OrderBy("file_lock_path ASC")
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+		}
+
+		dst := []*fileLock{}
+		if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Select query failed")
+		}
+
+		res = append(res, mapToFileLocks(dst)...)
+	}
+
+	return res, nil
+}
+
+func applyFileLockFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.FileLockFilter,
+) squirrel.SelectBuilder {
+	if filter.Path != "" {
+		stmt = stmt.Where("file_lock_path = ?", filter.Path)
+	}
+
+	if filter.ID > 0 {
+		stmt = stmt.Where("file_lock_id = ?", filter.ID)
+	}
+
+	if filter.OwnerID > 0 {
+		stmt = stmt.Where("file_lock_owner_id = ?", filter.OwnerID)
+	}
+
+	return stmt
+}
+
+func mapToFileLock(in *fileLock) *types.FileLock {
+	return &types.FileLock{
+		ID:      in.ID,
+		RepoID:  in.RepoID,
+		Path:    in.Path,
+		OwnerID: in.OwnerID,
+		Created: in.Created,
+	}
+}
+
+func mapToFileLocks(in []*fileLock) []*types.FileLock {
+	res := make([]*types.FileLock, len(in))
+	for i := range in {
+		res[i] = mapToFileLock(in[i])
+	}
+
+	return res
+}
+
+func mapToInternalFileLock(in *types.FileLock) *fileLock {
+	return &fileLock{
+		ID:      in.ID,
+		RepoID:  in.RepoID,
+		Path:    in.Path,
+		OwnerID: in.OwnerID,
+		Created: in.Created,
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.InsightsStore = (*InsightsStore)(nil)
+
+// NewInsightsStore returns a new InsightsStore.
+func NewInsightsStore(db *sqlx.DB) *InsightsStore {
+	return &InsightsStore{
+		db: db,
+	}
+}
+
+// InsightsStore implements store.InsightsStore backed by a relational database.
+type InsightsStore struct {
+	db *sqlx.DB
+}
+
+// insightsDay is an internal representation used to store aggregated daily statistics in the database.
+type insightsDay struct {
+	RepoID           int64 `db:"insights_day_repo_id"`
+	Day              int64 `db:"insights_day_day"`
+	Commits          int64 `db:"insights_day_commits"`
+	PullReqsOpened   int64 `db:"insights_day_pullreqs_opened"`
+	PullReqsMerged   int64 `db:"insights_day_pullreqs_merged"`
+	MergeTimeTotal   int64 `db:"insights_day_merge_time_total"`
+	PullReqsReviewed int64 `db:"insights_day_pullreqs_reviewed"`
+	ReviewTimeTotal  int64 `db:"insights_day_review_time_total"`
+}
+
+// insightsContributor is an internal representation used to store contributor statistics in the database.
+type insightsContributor struct {
+	RepoID  int64  `db:"insights_contributor_repo_id"`
+	Day     int64  `db:"insights_contributor_day"`
+	Email   string `db:"insights_contributor_email"`
+	Name    string `db:"insights_contributor_name"`
+	Commits int64  `db:"insights_contributor_commits"`
+}
+
+// insightsPullReqDay is used to read the result of the pull request aggregation queries.
+type insightsPullReqDay struct {
+	Day   int64 `db:"pullreq_day"`
+	Count int64 `db:"pullreq_count"`
+	Total int64 `db:"pullreq_total"`
+}
+
+const (
+	// insightsMillisPerDay is used to bucket timestamps (in unix milliseconds) into UTC days.
+	insightsMillisPerDay = "86400000"
+
+	// insightsSpaceReposCTE selects into the space_descendants table the space (the single placeholder)
+	// and all its subspaces.
+	insightsSpaceReposCTE = `
+	WITH RECURSIVE space_descendants(space_descendant_id) AS (
+		SELECT space_id
+		FROM spaces
+		WHERE space_id = ?
+	UNION
+		SELECT space_id
+		FROM spaces
+		JOIN space_descendants ON space_parent_id = space_descendant_id
+		WHERE space_deleted IS NULL
+	)`
+
+	insightsSpaceReposSubQuery = `
+		SELECT repo_id
+		FROM repositories
+		JOIN space_descendants ON repo_parent_id = space_descendant_id
+		WHERE repo_deleted IS NULL`
+)
+
+// FindLatestDay returns the most recent day aggregated for the repo, or 0 if there is none.
+func (s *InsightsStore) FindLatestDay(ctx context.Context, repoID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT COALESCE(MAX(insights_day_day), 0)
+	FROM insights_days
+	WHERE insights_day_repo_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var day int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID).Scan(&day); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to find latest insights day")
+	}
+
+	return day, nil
+}
+
+// AggregatePullReqs computes the daily pull request statistics of the repo since the provided day.
+// Opened pull requests are counted on the day they were created, merged pull requests on the day
+// they were merged and reviewed pull requests on the day they received their first review
+// by somebody other than the author.
+func (s *InsightsStore) AggregatePullReqs(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+) ([]*types.InsightsDay, error) {
+	const sqlQueryOpened = `
+	SELECT
+		 pullreq_created / ` + insightsMillisPerDay + ` * ` + insightsMillisPerDay + ` AS pullreq_day
+		,COUNT(*) AS pullreq_count
+		,0 AS pullreq_total
+	FROM pullreqs
+	WHERE pullreq_target_repo_id = $1 AND pullreq_created >= $2
+	GROUP BY pullreq_day`
+
+	const sqlQueryMerged = `
+	SELECT
+		 pullreq_merged / ` + insightsMillisPerDay + ` * ` + insightsMillisPerDay + ` AS pullreq_day
+		,COUNT(*) AS pullreq_count
+		,SUM(pullreq_merged - pullreq_created) AS pullreq_total
+	FROM pullreqs
+	WHERE pullreq_target_repo_id = $1 AND pullreq_merged >= $2
+	GROUP BY pullreq_day`
+
+	const sqlQueryReviewed = `
+	SELECT
+		 review_first / ` + insightsMillisPerDay + ` * ` + insightsMillisPerDay + ` AS pullreq_day
+		,COUNT(*) AS pullreq_count
+		,SUM(review_first - pullreq_created) AS pullreq_total
+	FROM (
+		SELECT pullreq_created, MIN(pullreq_review_created) AS review_first
+		FROM pullreqs
+		JOIN pullreq_reviews ON pullreq_review_pullreq_id = pullreq_id
+		WHERE pullreq_target_repo_id = $1 AND pullreq_review_created_by <> pullreq_created_by
+		GROUP BY pullreq_id, pullreq_created
+	) AS first_reviews
+	WHERE review_first >= $2
+	GROUP BY pullreq_day`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	days := map[int64]*types.InsightsDay{}
+	getDay := func(day int64) *types.InsightsDay {
+		d, ok := days[day]
+		if !ok {
+			d = &types.InsightsDay{RepoID: repoID, Day: day}
+			days[day] = d
+		}
+		return d
+	}
+
+	for _, q := range []struct {
+		sql   string
+		apply func(d *types.InsightsDay, count, total int64)
+	}{
+		{sqlQueryOpened, func(d *types.InsightsDay, count, _ int64) {
+			d.PullReqsOpened = count
+		}},
+		{sqlQueryMerged, func(d *types.InsightsDay, count, total int64) {
+			d.PullReqsMerged = count
+			d.MergeTimeTotal = total
+		}},
+		{sqlQueryReviewed, func(d *types.InsightsDay, count, total int64) {
+			d.PullReqsReviewed = count
+			d.ReviewTimeTotal = total
+		}},
+	} {
+		dst := []*insightsPullReqDay{}
+		if err := db.SelectContext(ctx, &dst, q.sql, repoID, since); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Failed to aggregate pull request insights")
+		}
+
+		for _, row := range dst {
+			q.apply(getDay(row.Day), row.Count, row.Total)
+		}
+	}
+
+	res := make([]*types.InsightsDay, 0, len(days))
+	for _, d := range days {
+		res = append(res, d)
+	}
+
+	return res, nil
+}
+
+// Replace replaces all aggregated statistics of the repo since the provided day.
+// It should be called inside a transaction.
+func (s *InsightsStore) Replace(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+	days []*types.InsightsDay,
+	contributors []*types.InsightsContributorDay,
+) error {
+	const sqlQueryDeleteDays = `
+	DELETE FROM insights_days
+	WHERE insights_day_repo_id = $1 AND insights_day_day >= $2`
+
+	const sqlQueryDeleteContributors = `
+	DELETE FROM insights_contributors
+	WHERE insights_contributor_repo_id = $1 AND insights_contributor_day >= $2`
+
+	const sqlQueryInsertDay = `
+	INSERT INTO insights_days (
+		 insights_day_repo_id
+		,insights_day_day
+		,insights_day_commits
+		,insights_day_pullreqs_opened
+		,insights_day_pullreqs_merged
+		,insights_day_merge_time_total
+		,insights_day_pullreqs_reviewed
+		,insights_day_review_time_total
+	) values (
+		 :insights_day_repo_id
+		,:insights_day_day
+		,:insights_day_commits
+		,:insights_day_pullreqs_opened
+		,:insights_day_pullreqs_merged
+		,:insights_day_merge_time_total
+		,:insights_day_pullreqs_reviewed
+		,:insights_day_review_time_total
+	)`
+
+	const sqlQueryInsertContributor = `
+	INSERT INTO insights_contributors (
+		 insights_contributor_repo_id
+		,insights_contributor_day
+		,insights_contributor_email
+		,insights_contributor_name
+		,insights_contributor_commits
+	) values (
+		 :insights_contributor_repo_id
+		,:insights_contributor_day
+		,:insights_contributor_email
+		,:insights_contributor_name
+		,:insights_contributor_commits
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDeleteDays, repoID, since); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete insights days")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQueryDeleteContributors, repoID, since); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete insights contributors")
+	}
+
+	for _, d := range days {
+		query, arg, err := db.BindNamed(sqlQueryInsertDay, mapToInternalInsightsDay(repoID, d))
+		if err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to bind insights day object")
+		}
+
+		if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to insert insights day")
+		}
+	}
+
+	for _, c := range contributors {
+		query, arg, err := db.BindNamed(sqlQueryInsertContributor, &insightsContributor{
+			RepoID:  repoID,
+			Day:     c.Day,
+			Email:   c.Email,
+			Name:    c.Name,
+			Commits: c.Commits,
+		})
+		if err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to bind insights contributor object")
+		}
+
+		if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to insert insights contributor")
+		}
+	}
+
+	return nil
+}
+
+// ListDays returns the statistics of a repo or space (including all subspaces) summed up per day.
+func (s *InsightsStore) ListDays(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	filter types.InsightsFilter,
+) ([]*types.InsightsDay, error) {
+	stmt := database.Builder.
+		Select(`insights_day_day
+			,SUM(insights_day_commits) AS insights_day_commits
+			,SUM(insights_day_pullreqs_opened) AS insights_day_pullreqs_opened
+			,SUM(insights_day_pullreqs_merged) AS insights_day_pullreqs_merged
+			,SUM(insights_day_merge_time_total) AS insights_day_merge_time_total
+			,SUM(insights_day_pullreqs_reviewed) AS insights_day_pullreqs_reviewed
+			,SUM(insights_day_review_time_total) AS insights_day_review_time_total`).
+		From("insights_days").
+		Where("insights_day_day >= ? AND insights_day_day < ?", filter.Since, filter.Until).
+		GroupBy("insights_day_day").
+		OrderBy("insights_day_day ASC")
+
+	stmt = applyInsightsParent(stmt, "insights_day_repo_id", parentType, parentID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert insights days query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*insightsDay{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing insights days query")
+	}
+
+	res := make([]*types.InsightsDay, len(dst))
+	for i, d := range dst {
+		res[i] = &types.InsightsDay{
+			Day:              d.Day,
+			Commits:          d.Commits,
+			PullReqsOpened:   d.PullReqsOpened,
+			PullReqsMerged:   d.PullReqsMerged,
+			MergeTimeTotal:   d.MergeTimeTotal,
+			PullReqsReviewed: d.PullReqsReviewed,
+			ReviewTimeTotal:  d.ReviewTimeTotal,
+		}
+	}
+
+	return res, nil
+}
+
+// ListContributors returns up to limit contributors of a repo or space, ordered by number of commits.
+func (s *InsightsStore) ListContributors(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	filter types.InsightsFilter,
+	limit int,
+) ([]*types.InsightsContributor, error) {
+	stmt := database.Builder.
+		Select(`insights_contributor_email
+			,MAX(insights_contributor_name) AS insights_contributor_name
+			,SUM(insights_contributor_commits) AS insights_contributor_commits`).
+		From("insights_contributors").
+		Where("insights_contributor_day >= ? AND insights_contributor_day < ?", filter.Since, filter.Until).
+		GroupBy("insights_contributor_email").
+		OrderBy("insights_contributor_commits DESC", "insights_contributor_email ASC").
+		Limit(uint64(limit))
+
+	stmt = applyInsightsParent(stmt, "insights_contributor_repo_id", parentType, parentID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert insights contributors query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*insightsContributor{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing insights contributors query")
+	}
+
+	res := make([]*types.InsightsContributor, len(dst))
+	for i, c := range dst {
+		res[i] = &types.InsightsContributor{
+			Email:   c.Email,
+			Name:    c.Name,
+			Commits: c.Commits,
+		}
+	}
+
+	return res, nil
+}
+
+// CountContributors returns the number of distinct contributors of a repo or space.
+func (s *InsightsStore) CountContributors(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	filter types.InsightsFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(DISTINCT insights_contributor_email)").
+		From("insights_contributors").
+		Where("insights_contributor_day >= ? AND insights_contributor_day < ?", filter.Since, filter.Until)
+
+	stmt = applyInsightsParent(stmt, "insights_contributor_repo_id", parentType, parentID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert insights contributor count query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing insights contributor count query")
+	}
+
+	return count, nil
+}
+
+// applyInsightsParent restricts the statement to the repo, or to all repos of the space and its subspaces.
+func applyInsightsParent(
+	stmt squirrel.SelectBuilder,
+	repoIDColumn string,
+	parentType enum.WebhookParent,
+	parentID int64,
+) squirrel.SelectBuilder {
+	if parentType == enum.WebhookParentSpace {
+		return stmt.
+			Prefix(insightsSpaceReposCTE, parentID).
+			Where(repoIDColumn + " IN (" + insightsSpaceReposSubQuery + ")")
+	}
+
+	return stmt.Where(repoIDColumn+" = ?", parentID)
+}
+
+func mapToInternalInsightsDay(repoID int64, in *types.InsightsDay) *insightsDay {
+	return &insightsDay{
+		RepoID:           repoID,
+		Day:              in.Day,
+		Commits:          in.Commits,
+		PullReqsOpened:   in.PullReqsOpened,
+		PullReqsMerged:   in.PullReqsMerged,
+		MergeTimeTotal:   in.MergeTimeTotal,
+		PullReqsReviewed: in.PullReqsReviewed,
+		ReviewTimeTotal:  in.ReviewTimeTotal,
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.InstanceSettingStore = (*InstanceSettingStore)(nil)
+
+const (
+	instanceSettingColumns = `
+		 instance_setting_key
+		,instance_setting_value
+		,instance_setting_updated_by
+		,instance_setting_updated`
+
+	instanceSettingSelectBase = `
+	SELECT` + instanceSettingColumns + `
+	FROM instance_settings`
+
+	instanceSettingChangeColumns = `
+		 instance_setting_change_id
+		,instance_setting_change_key
+		,instance_setting_change_old_value
+		,instance_setting_change_new_value
+		,instance_setting_change_changed_by
+		,instance_setting_change_changed`
+)
+
+// NewInstanceSettingStore returns a new InstanceSettingStore.
+func NewInstanceSettingStore(db *sqlx.DB) *InstanceSettingStore {
+	return &InstanceSettingStore{
+		db: db,
+	}
+}
+
+// InstanceSettingStore implements a store.InstanceSettingStore backed by a relational database.
+type InstanceSettingStore struct {
+	db *sqlx.DB
+}
+
+type instanceSetting struct {
+	Key       enum.InstanceSettingKey `db:"instance_setting_key"`
+	Value     string                  `db:"instance_setting_value"`
+	UpdatedBy int64                   `db:"instance_setting_updated_by"`
+	Updated   int64                   `db:"instance_setting_updated"`
+}
+
+type instanceSettingChange struct {
+	ID        int64                   `db:"instance_setting_change_id"`
+	Key       enum.InstanceSettingKey `db:"instance_setting_change_key"`
+	OldValue  null.String             `db:"instance_setting_change_old_value"`
+	NewValue  null.String             `db:"instance_setting_change_new_value"`
+	ChangedBy int64                   `db:"instance_setting_change_changed_by"`
+	Changed   int64                   `db:"instance_setting_change_changed"`
+}
+
+// Find returns the instance setting with the provided key.
+func (s *InstanceSettingStore) Find(
+	ctx context.Context,
+	key enum.InstanceSettingKey,
+) (*types.InstanceSetting, error) {
+	const sqlQuery = instanceSettingSelectBase + `
+	WHERE instance_setting_key = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &instanceSetting{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find instance setting")
+	}
+
+	return mapInstanceSetting(dst), nil
+}
+
+// List returns all instance settings changed at runtime.
+func (s *InstanceSettingStore) List(ctx context.Context) ([]*types.InstanceSetting, error) {
+	const sqlQuery = instanceSettingSelectBase + `
+	ORDER BY instance_setting_key`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*instanceSetting{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list instance settings")
+	}
+
+	res := make([]*types.InstanceSetting, len(dst))
+	for i := range dst {
+		res[i] = mapInstanceSetting(dst[i])
+	}
+
+	return res, nil
+}
+
+// Upsert creates or updates an instance setting.
+func (s *InstanceSettingStore) Upsert(ctx context.Context, in *types.InstanceSetting) error {
+	const sqlQueryInsert = `
+		INSERT INTO instance_settings (
+			 instance_setting_key
+			,instance_setting_value
+			,instance_setting_updated_by
+			,instance_setting_updated
+		) VALUES (
+			 :instance_setting_key
+			,:instance_setting_value
+			,:instance_setting_updated_by
+			,:instance_setting_updated
+		)`
+
+	const sqlQueryUpdate = `
+			 instance_setting_value = :instance_setting_value
+			,instance_setting_updated_by = :instance_setting_updated_by
+			,instance_setting_updated = :instance_setting_updated`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT (instance_setting_key) DO UPDATE
+		SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, &instanceSetting{
+		Key:       in.Key,
+		Value:     string(in.Value),
+		UpdatedBy: in.UpdatedBy,
+		Updated:   in.Updated,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind instance setting object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to upsert instance setting")
+	}
+
+	return nil
+}
+
+// Delete removes the instance setting with the provided key, the server configuration applies afterwards.
+func (s *InstanceSettingStore) Delete(ctx context.Context, key enum.InstanceSettingKey) error {
+	const sqlQuery = `
+		DELETE FROM instance_settings
+		WHERE instance_setting_key = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete instance setting")
+	}
+
+	return nil
+}
+
+// CreateChange persists the audit record of a change of an instance setting.
+func (s *InstanceSettingStore) CreateChange(ctx context.Context, change *types.InstanceSettingChange) error {
+	const sqlQuery = `
+		INSERT INTO instance_setting_changes (
+			 instance_setting_change_key
+			,instance_setting_change_old_value
+			,instance_setting_change_new_value
+			,instance_setting_change_changed_by
+			,instance_setting_change_changed
+		) VALUES (
+			 :instance_setting_change_key
+			,:instance_setting_change_old_value
+			,:instance_setting_change_new_value
+			,:instance_setting_change_changed_by
+			,:instance_setting_change_changed
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, &instanceSettingChange{
+		Key:       change.Key,
+		OldValue:  nullRawMessage(change.OldValue),
+		NewValue:  nullRawMessage(change.NewValue),
+		ChangedBy: change.ChangedBy,
+		Changed:   change.Changed,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind instance setting change object")
+	}
+
+	if change.ID, err = database.InsertReturningID(ctx, db, query, "instance_setting_change_id", args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// ListChanges returns the changes of instance settings matching the filter, newest first.
+func (s *InstanceSettingStore) ListChanges(
+	ctx context.Context,
+	filter types.InstanceSettingChangeFilter,
+) ([]*types.InstanceSettingChange, error) {
+	stmt := database.Builder.
+		Select(instanceSettingChangeColumns).
+		From("instance_setting_changes")
+
+	stmt = applyInstanceSettingChangeFilter(stmt, filter)
+	stmt = stmt.OrderBy("instance_setting_change_id desc")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list instance setting changes query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*instanceSettingChange{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list instance setting changes query")
+	}
+
+	res := make([]*types.InstanceSettingChange, len(dst))
+	for i, change := range dst {
+		res[i] = &types.InstanceSettingChange{
+			ID:        change.ID,
+			Key:       change.Key,
+			OldValue:  rawMessageFromNull(change.OldValue),
+			NewValue:  rawMessageFromNull(change.NewValue),
+			ChangedBy: change.ChangedBy,
+			Changed:   change.Changed,
+		}
+	}
+
+	return res, nil
+}
+
+// CountChanges returns the number of changes of instance settings matching the filter.
+func (s *InstanceSettingStore) CountChanges(
+	ctx context.Context,
+	filter types.InstanceSettingChangeFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("instance_setting_changes")
+
+	stmt = applyInstanceSettingChangeFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count instance setting changes query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count instance setting changes query")
+	}
+
+	return count, nil
+}
+
+func applyInstanceSettingChangeFilter(
+	stmt squirrel.SelectBuilder,
+	filter types.InstanceSettingChangeFilter,
+) squirrel.SelectBuilder {
+	if filter.Key != "" {
+		stmt = stmt.Where("instance_setting_change_key = ?", filter.Key)
+	}
+
+	return stmt
+}
+
+func mapInstanceSetting(in *instanceSetting) *types.InstanceSetting {
+	return &types.InstanceSetting{
+		Key:       in.Key,
+		Value:     json.RawMessage(in.Value),
+		UpdatedBy: in.UpdatedBy,
+		Updated:   in.Updated,
+	}
+}
+
+func nullRawMessage(value json.RawMessage) null.String {
+	return null.NewString(string(value), value != nil)
+}
+
+func rawMessageFromNull(value null.String) json.RawMessage {
+	if !value.Valid {
+		return nil
+	}
+
+	return json.RawMessage(value.String)
+}
@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/store"
@@ -28,6 +29,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -65,7 +67,8 @@ const (
 		,job_recurring_cron
 		,job_consecutive_failures
 		,job_last_failure_error
-		,job_group_id`
+		,job_group_id
+		,job_trace_context`
 
 	jobSelectBase = `
 	SELECT` + jobColumns + `
@@ -113,6 +116,73 @@ func (s *JobStore) DeleteByGroupID(ctx context.Context, groupID string) (int64,
 	return n, nil
 }
 
+// List returns a list of jobs matching the provided filter, most recently created first.
+func (s *JobStore) List(ctx context.Context, filter types.JobFilter) ([]*types.Job, error) {
+	stmt := database.Builder.
+		Select(jobColumns).
+		From("jobs")
+
+	stmt = applyJobFilter(stmt, filter)
+	stmt = stmt.OrderBy("job_created desc, job_uid asc")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list jobs query to sql: %w", err)
+	}
+
+	result := make([]*types.Job, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list jobs query")
+	}
+
+	return result, nil
+}
+
+// Count returns the number of jobs matching the provided filter.
+func (s *JobStore) Count(ctx context.Context, filter types.JobFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("jobs")
+
+	stmt = applyJobFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count jobs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count jobs query")
+	}
+
+	return count, nil
+}
+
+func applyJobFilter(stmt squirrel.SelectBuilder, filter types.JobFilter) squirrel.SelectBuilder {
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"job_state": filter.States})
+	}
+
+	if filter.Type != "" {
+		stmt = stmt.Where("job_type = ?", filter.Type)
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(job_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
 // ListByGroupID fetches all jobs for a group id.
 func (s *JobStore) ListByGroupID(ctx context.Context, groupID string) ([]*types.Job, error) {
 	const sqlQuery = jobSelectBase + `
@@ -154,6 +224,7 @@ func (s *JobStore) Create(ctx context.Context, job *types.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
+			,:job_trace_context
 		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -173,7 +244,7 @@ func (s *JobStore) Create(ctx context.Context, job *types.Job) error {
 // Upsert creates or updates a job. If the job didn't exist it will insert it in the database,
 // otherwise it will update it but only if its definition has changed.
 func (s *JobStore) Upsert(ctx context.Context, job *types.Job) error {
-	const sqlQuery = `
+	const sqlQueryInsert = `
 		INSERT INTO jobs (` + jobColumns + `
 		) VALUES (
 			 :job_uid
@@ -197,9 +268,11 @@ func (s *JobStore) Upsert(ctx context.Context, job *types.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
-		)
-		ON CONFLICT (job_uid) DO
-		UPDATE SET
+			,:job_trace_context
+		)`
+
+	const sqlQueryUpdate = `
+		SET
 			 job_updated = :job_updated
 			,job_type = :job_type
 			,job_priority = :job_priority
@@ -210,8 +283,9 @@ func (s *JobStore) Upsert(ctx context.Context, job *types.Job) error {
 			,job_state = :job_state
 			,job_scheduled = :job_scheduled
 			,job_is_recurring = :job_is_recurring
-			,job_recurring_cron = :job_recurring_cron
-		WHERE
+			,job_recurring_cron = :job_recurring_cron`
+
+	const sqlQueryDefinitionChanged = `
 			jobs.job_type <> :job_type OR
 			jobs.job_priority <> :job_priority OR
 			jobs.job_data <> :job_data OR
@@ -220,6 +294,21 @@ func (s *JobStore) Upsert(ctx context.Context, job *types.Job) error {
 			jobs.job_is_recurring <> :job_is_recurring OR
 			jobs.job_recurring_cron <> :job_recurring_cron`
 
+	// mysql doesn't support conditional updates on conflict, the update is executed separately instead.
+	if database.IsMySQL(s.db) {
+		return s.upsertWithSeparateUpdate(ctx, job,
+			sqlQueryInsert+`
+		ON DUPLICATE KEY UPDATE job_uid = job_uid`,
+			`
+		UPDATE jobs`+sqlQueryUpdate+`
+		WHERE job_uid = :job_uid AND (`+sqlQueryDefinitionChanged+`)`)
+	}
+
+	const sqlQuery = sqlQueryInsert + `
+		ON CONFLICT (job_uid) DO
+		UPDATE` + sqlQueryUpdate + `
+		WHERE` + sqlQueryDefinitionChanged
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(sqlQuery, job)
@@ -234,6 +323,45 @@ func (s *JobStore) Upsert(ctx context.Context, job *types.Job) error {
 	return nil
 }
 
+func (s *JobStore) upsertWithSeparateUpdate(
+	ctx context.Context,
+	job *types.Job,
+	sqlQueryInsert string,
+	sqlQueryUpdate string,
+) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQueryInsert, job)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind job object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of inserted rows")
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	query, arg, err = db.BindNamed(sqlQueryUpdate, job)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind job object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
 // UpdateDefinition is used to update a job definition.
 func (s *JobStore) UpdateDefinition(ctx context.Context, job *types.Job) error {
 	const sqlQuery = `
@@ -374,6 +502,31 @@ func (s *JobStore) CountRunning(ctx context.Context) (int, error) {
 	return int(count), nil
 }
 
+// CountReady returns number of jobs that are ready for execution:
+// The jobs with state="scheduled" and scheduled time in the past.
+func (s *JobStore) CountReady(ctx context.Context, now time.Time) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("jobs").
+		Where("job_state = ?", enum.JobStateScheduled).
+		Where("job_scheduled <= ?", now.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count ready jobs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count ready jobs query")
+	}
+
+	return int(count), nil
+}
+
 // ListReady returns a list of jobs that are ready for execution:
 // The jobs with state="scheduled" and scheduled time in the past.
 func (s *JobStore) ListReady(ctx context.Context, now time.Time, limit int) ([]*types.Job, error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -61,6 +62,9 @@ type membership struct {
 	Updated   int64 `db:"membership_updated"`
 
 	Role enum.MembershipRole `db:"membership_role"`
+
+	Expires        null.Int `db:"membership_expires"`
+	ExpiryNotified null.Int `db:"membership_expiry_notified"`
 }
 
 type membershipPrincipal struct {
@@ -80,7 +84,9 @@ const (
 		,membership_created_by
 		,membership_created
 		,membership_updated
-		,membership_role`
+		,membership_role
+		,membership_expires
+		,membership_expiry_notified`
 
 	membershipSelectBase = `
 	SELECT` + membershipColumns + `
@@ -92,7 +98,7 @@ func (s *MembershipStore) Find(ctx context.Context, key types.MembershipKey) (*t
 	const sqlQuery = membershipSelectBase + `
 	WHERE membership_space_id = $1 AND membership_principal_id = $2`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &membership{}
 	if err := db.GetContext(ctx, dst, sqlQuery, key.SpaceID, key.PrincipalID); err != nil {
@@ -118,6 +124,52 @@ func (s *MembershipStore) FindUser(ctx context.Context, key types.MembershipKey)
 	return &result, nil
 }
 
+// FindManyForPrincipal returns the memberships of the principal in any of the provided spaces,
+// including the memberships of the user groups the principal is a (transitive) member of.
+// Expired memberships that weren't removed yet are ignored.
+func (s *MembershipStore) FindManyForPrincipal(
+	ctx context.Context,
+	principalID int64,
+	spaceIDs []int64,
+) ([]types.Membership, error) {
+	if len(spaceIDs) == 0 {
+		return []types.Membership{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(membershipColumns).
+		Prefix(userGroupAncestorsCTE, principalID).
+		From("memberships").
+		Where(squirrel.Or{
+			squirrel.Eq{"membership_principal_id": principalID},
+			squirrel.Expr("membership_principal_id IN (SELECT usergroup_id FROM usergroup_ancestors)"),
+		}).
+		Where(squirrel.Eq{"membership_space_id": spaceIDs}).
+		Where(squirrel.Or{
+			squirrel.Eq{"membership_expires": nil},
+			squirrel.Gt{"membership_expires": time.Now().UnixMilli()},
+		})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert membership query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*membership, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find memberships of principal")
+	}
+
+	result := make([]types.Membership, len(dst))
+	for i := range dst {
+		result[i] = mapToMembership(dst[i])
+	}
+
+	return result, nil
+}
+
 // Create creates a new membership.
 func (s *MembershipStore) Create(ctx context.Context, membership *types.Membership) error {
 	const sqlQuery = `
@@ -128,6 +180,8 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,membership_created
 		,membership_updated
 		,membership_role
+		,membership_expires
+		,membership_expiry_notified
 	) values (
 		 :membership_space_id
 		,:membership_principal_id
@@ -135,6 +189,8 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,:membership_created
 		,:membership_updated
 		,:membership_role
+		,:membership_expires
+		,:membership_expiry_notified
 	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -151,13 +207,15 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 	return nil
 }
 
-// Update updates the role of a member of a space.
+// Update updates the role and the expiry of a member of a space.
 func (s *MembershipStore) Update(ctx context.Context, membership *types.Membership) error {
 	const sqlQuery = `
 	UPDATE memberships
 	SET
 		 membership_updated = :membership_updated
 		,membership_role = :membership_role
+		,membership_expires = :membership_expires
+		,membership_expiry_notified = :membership_expiry_notified
 	WHERE membership_space_id = :membership_space_id AND
 	      membership_principal_id = :membership_principal_id`
 
@@ -196,6 +254,104 @@ func (s *MembershipStore) Delete(ctx context.Context, key types.MembershipKey) e
 	return nil
 }
 
+// DeleteExpired deletes the membership if it's expired at the provided time.
+// It returns false in case the membership doesn't exist or isn't expired (e.g. because the expiry was extended).
+func (s *MembershipStore) DeleteExpired(ctx context.Context, key types.MembershipKey, now int64) (bool, error) {
+	const sqlQuery = `
+	DELETE from memberships
+	WHERE membership_space_id = $1 AND
+	      membership_principal_id = $2 AND
+	      membership_expires <= $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, key.SpaceID, key.PrincipalID, now)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "delete expired membership query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// ListExpiring returns the memberships (including already expired ones) that expire before the provided time.
+func (s *MembershipStore) ListExpiring(ctx context.Context, before int64) ([]types.Membership, error) {
+	const sqlQuery = membershipSelectBase + `
+	WHERE membership_expires <= $1
+	ORDER BY membership_expires`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*membership, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list expiring memberships")
+	}
+
+	result := make([]types.Membership, len(dst))
+	for i := range dst {
+		result[i] = mapToMembership(dst[i])
+	}
+
+	return result, nil
+}
+
+// UpdateExpiryNotified sets the time the member got notified about the upcoming expiry of the membership.
+func (s *MembershipStore) UpdateExpiryNotified(ctx context.Context, key types.MembershipKey, notified int64) error {
+	const sqlQuery = `
+	UPDATE memberships
+	SET membership_expiry_notified = $1
+	WHERE membership_space_id = $2 AND
+	      membership_principal_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, notified, key.SpaceID, key.PrincipalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update membership expiry notification time")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDsByRole returns the principals with an unexpired membership
+// of the provided role in any of the provided spaces.
+func (s *MembershipStore) ListPrincipalIDsByRole(
+	ctx context.Context,
+	spaceIDs []int64,
+	role enum.MembershipRole,
+) ([]int64, error) {
+	if len(spaceIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("DISTINCT membership_principal_id").
+		From("memberships").
+		Where(squirrel.Eq{"membership_space_id": spaceIDs}).
+		Where("membership_role = ?", role).
+		Where(squirrel.Or{
+			squirrel.Eq{"membership_expires": nil},
+			squirrel.Gt{"membership_expires": time.Now().UnixMilli()},
+		})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert membership principal query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list membership principals")
+	}
+
+	return dst, nil
+}
+
 // CountUsers returns a number of users memberships that matches the provided filter.
 func (s *MembershipStore) CountUsers(ctx context.Context,
 	spaceID int64,
@@ -214,7 +370,7 @@ func (s *MembershipStore) CountUsers(ctx context.Context,
 		return 0, fmt.Errorf("failed to convert membership users count query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -260,7 +416,7 @@ func (s *MembershipStore) ListUsers(ctx context.Context,
 
 	dst := make([]*membershipPrincipal, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing membership users list query")
@@ -294,7 +450,8 @@ func (s *MembershipStore) CountSpaces(ctx context.Context,
 		Select("count(*)").
 		From("memberships").
 		InnerJoin("spaces ON spaces.space_id = membership_space_id").
-		Where("membership_principal_id = ?", userID)
+		Where("membership_principal_id = ?", userID).
+		Where("space_deleted IS NULL")
 
 	stmt = applyMembershipSpaceFilter(stmt, filter)
 
@@ -303,7 +460,7 @@ func (s *MembershipStore) CountSpaces(ctx context.Context,
 		return 0, fmt.Errorf("failed to convert membership spaces count query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -324,7 +481,8 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 		Select(columns).
 		From("memberships").
 		InnerJoin("spaces ON spaces.space_id = membership_space_id").
-		Where("membership_principal_id = ?", userID)
+		Where("membership_principal_id = ?", userID).
+		Where("space_deleted IS NULL")
 
 	stmt = applyMembershipSpaceFilter(stmt, filter)
 	stmt = stmt.Limit(database.Limit(filter.Size))
@@ -347,7 +505,7 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 		return nil, fmt.Errorf("failed to convert membership spaces list query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := make([]*membershipSpace, 0)
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -384,6 +542,9 @@ func mapToMembership(m *membership) types.Membership {
 		Created:   m.Created,
 		Updated:   m.Updated,
 		Role:      m.Role,
+
+		Expires:        m.Expires.Ptr(),
+		ExpiryNotified: m.ExpiryNotified.Ptr(),
 	}
 }
 
@@ -395,6 +556,9 @@ func mapToInternalMembership(m *types.Membership) membership {
 		Created:     m.Created,
 		Updated:     m.Updated,
 		Role:        m.Role,
+
+		Expires:        null.IntFromPtr(m.Expires),
+		ExpiryNotified: null.IntFromPtr(m.ExpiryNotified),
 	}
 }
 
@@ -469,7 +633,7 @@ func (s *MembershipStore) mapToMembershipSpaces(ctx context.Context,
 	res := make([]types.MembershipSpace, len(ms))
 	for i, m := range ms {
 		res[i].Membership = mapToMembership(&m.membership)
-		space, err := mapToSpace(ctx, s.spacePathStore, &m.space)
+		space, err := mapToSpace(ctx, s.db, s.spacePathStore, &m.space)
 		if err != nil {
 			return nil, fmt.Errorf("faild to map space %d: %w", m.space.ID, err)
 		}
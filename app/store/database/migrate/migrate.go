@@ -32,6 +32,9 @@ var postgres embed.FS
 //go:embed sqlite/*.sql
 var sqlite embed.FS
 
+//go:embed mysql/*.sql
+var mysql embed.FS
+
 const (
 	tableName = "migrations"
 
@@ -40,6 +43,9 @@ const (
 
 	sqliteDriverName = "sqlite3"
 	sqliteSourceDir  = "sqlite"
+
+	mysqlDriverName = "mysql"
+	mysqlSourceDir  = "mysql"
 )
 
 // Migrate performs the database migration.
@@ -62,10 +68,28 @@ func To(ctx context.Context, db *sqlx.DB, version string) error {
 
 // Current returns the current version ID (the latest migration applied) of the database.
 func Current(ctx context.Context, db *sqlx.DB) (string, error) {
-	var (
-		query               string
-		migrationTableCount int
-	)
+	exists, err := tableExists(ctx, db, tableName)
+	if err != nil {
+		return "", fmt.Errorf("failed to check migration table existence: %w", err)
+	}
+
+	if !exists {
+		return "", nil
+	}
+
+	var version string
+
+	query := "select version from " + tableName + " limit 1"
+	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to read current DB version from migration table: %w", err)
+	}
+
+	return version, nil
+}
+
+// tableExists returns whether the table exists in the database.
+func tableExists(ctx context.Context, db *sqlx.DB, table string) (bool, error) {
+	var query string
 
 	switch db.DriverName() {
 	case sqliteDriverName:
@@ -78,26 +102,21 @@ func Current(ctx context.Context, db *sqlx.DB) (string, error) {
 			SELECT count(*)
 			FROM information_schema.tables
 			WHERE table_name = ? and table_schema = 'public'`
+	case mysqlDriverName:
+		query = `
+			SELECT count(*)
+			FROM information_schema.tables
+			WHERE table_name = ? and table_schema = DATABASE()`
 	default:
-		return "", fmt.Errorf("unsupported driver '%s'", db.DriverName())
-	}
-
-	if err := db.QueryRowContext(ctx, query, tableName).Scan(&migrationTableCount); err != nil {
-		return "", fmt.Errorf("failed to check migration table existence: %w", err)
-	}
-
-	if migrationTableCount == 0 {
-		return "", nil
+		return false, fmt.Errorf("unsupported driver '%s'", db.DriverName())
 	}
 
-	var version string
-
-	query = "select version from " + tableName + " limit 1"
-	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
-		return "", fmt.Errorf("failed to read current DB version from migration table: %w", err)
+	var count int
+	if err := db.QueryRowContext(ctx, db.Rebind(query), table).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check existence of table '%s': %w", table, err)
 	}
 
-	return version, nil
+	return count > 0, nil
 }
 
 func getMigrator(db *sqlx.DB) (migrate.Options, error) {
@@ -126,6 +145,9 @@ func getMigrator(db *sqlx.DB) (migrate.Options, error) {
 	case postgresDriverName:
 		folder, _ := fs.Sub(postgres, postgresSourceDir)
 		opts.FS = folder
+	case mysqlDriverName:
+		folder, _ := fs.Sub(mysql, mysqlSourceDir)
+		opts.FS = folder
 
 	default:
 		return migrate.Options{}, fmt.Errorf("unsupported driver '%s'", db.DriverName())
@@ -0,0 +1,43 @@
+DROP TABLE branch_renames;
+DROP TABLE insights_contributors;
+DROP TABLE insights_days;
+DROP TABLE usergroup_members;
+DROP TABLE pullreq_subscribers;
+DROP TABLE chat_integrations;
+DROP TABLE notification_digest_items;
+DROP TABLE notification_settings;
+DROP TABLE user_emails;
+DROP TABLE announcement_dismissals;
+DROP TABLE announcements;
+DROP TABLE settings;
+DROP TABLE space_quotas;
+DROP TABLE repository_shards;
+DROP TABLE event_dead_letters;
+DROP TABLE event_outbox;
+DROP TABLE pullreq_file_views;
+DROP TABLE space_paths;
+DROP TABLE plugins;
+DROP TABLE triggers;
+DROP TABLE templates;
+DROP TABLE connectors;
+DROP TABLE logs;
+DROP TABLE steps;
+DROP TABLE stages;
+DROP TABLE secrets;
+DROP TABLE executions;
+DROP TABLE pipelines;
+DROP TABLE jobs;
+DROP TABLE memberships;
+DROP TABLE reqchecks;
+DROP TABLE checks;
+DROP TABLE pullreq_reviewers;
+DROP TABLE pullreq_reviews;
+DROP TABLE webhook_executions;
+DROP TABLE webhooks;
+DROP TABLE pullreq_activities;
+DROP TABLE pullreqs;
+DROP TABLE tokens;
+DROP TABLE paths;
+DROP TABLE repositories;
+DROP TABLE spaces;
+DROP TABLE principals;
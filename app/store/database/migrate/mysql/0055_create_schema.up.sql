@@ -0,0 +1,1045 @@
+-- Baseline schema for mysql and mariadb, equivalent to migration 0055 of the postgres and sqlite schemas.
+-- Tables use a case sensitive collation, columns compared case insensitively use utf8mb4_unicode_ci instead.
+-- Partial unique indices are emulated using virtual columns that are NULL for rows excluded from the index.
+
+CREATE TABLE principals (
+ principal_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,principal_uid TEXT
+,principal_uid_unique VARCHAR(255)
+,principal_email VARCHAR(255) COLLATE utf8mb4_unicode_ci
+,principal_type TEXT
+,principal_display_name TEXT
+,principal_admin BOOLEAN
+,principal_blocked BOOLEAN
+,principal_salt TEXT
+,principal_created BIGINT
+,principal_updated BIGINT
+,principal_user_password TEXT
+,principal_sa_parent_type VARCHAR(255)
+,principal_sa_parent_id BIGINT
+,principal_suspended_until BIGINT NOT NULL DEFAULT 0
+,principal_password_reset_required BOOLEAN NOT NULL DEFAULT FALSE
+,principal_usergroup_space_id BIGINT
+
+,UNIQUE KEY principals_uid_unique (principal_uid_unique)
+,UNIQUE KEY principals_lower_email (principal_email)
+,KEY principals_sa_parent_id_sa_parent_type (principal_sa_parent_id, principal_sa_parent_type)
+,KEY principals_usergroup_space_id (principal_usergroup_space_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE spaces (
+ space_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,space_version BIGINT NOT NULL DEFAULT 0
+,space_parent_id BIGINT DEFAULT NULL
+,space_uid TEXT NOT NULL
+,space_description TEXT
+,space_is_public BOOLEAN NOT NULL
+,space_created_by BIGINT NOT NULL
+,space_created BIGINT NOT NULL
+,space_updated BIGINT NOT NULL
+,space_deleted BIGINT DEFAULT NULL
+
+,KEY spaces_parent_id (space_parent_id)
+,KEY spaces_deleted (space_deleted)
+
+,CONSTRAINT fk_space_parent_id FOREIGN KEY (space_parent_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE repositories (
+ repo_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,repo_version BIGINT NOT NULL DEFAULT 0
+,repo_parent_id BIGINT NOT NULL
+,repo_uid VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL
+,repo_description TEXT
+,repo_is_public BOOLEAN NOT NULL
+,repo_created_by BIGINT NOT NULL
+,repo_created BIGINT NOT NULL
+,repo_updated BIGINT NOT NULL
+,repo_git_uid VARCHAR(255) NOT NULL
+,repo_default_branch TEXT NOT NULL
+,repo_fork_id BIGINT
+,repo_pullreq_seq BIGINT NOT NULL
+,repo_num_forks BIGINT NOT NULL
+,repo_num_pulls BIGINT NOT NULL
+,repo_num_closed_pulls BIGINT NOT NULL
+,repo_num_open_pulls BIGINT NOT NULL
+,repo_num_merged_pulls BIGINT NOT NULL
+,repo_importing BOOLEAN NOT NULL DEFAULT FALSE
+,repo_size BIGINT NOT NULL DEFAULT 0
+,repo_size_updated BIGINT NOT NULL DEFAULT 0
+,repo_deleted BIGINT DEFAULT NULL
+,repo_not_deleted BOOLEAN AS (CASE WHEN repo_deleted IS NULL THEN TRUE END) VIRTUAL
+
+,UNIQUE KEY repositories_git_uid (repo_git_uid)
+,UNIQUE KEY repositories_parent_id_uid (repo_parent_id, repo_uid, repo_not_deleted)
+,KEY repositories_deleted (repo_deleted)
+
+,CONSTRAINT fk_repo_parent_id FOREIGN KEY (repo_parent_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE paths (
+ path_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,path_version BIGINT NOT NULL DEFAULT 0
+,path_value TEXT NOT NULL
+,path_value_unique VARCHAR(255) NOT NULL
+,path_is_primary BOOLEAN DEFAULT NULL
+,path_repo_id BIGINT
+,path_space_id BIGINT
+,path_created_by BIGINT NOT NULL
+,path_created BIGINT NOT NULL
+,path_updated BIGINT NOT NULL
+
+,UNIQUE KEY paths_value_unique (path_value_unique)
+,UNIQUE KEY paths_repo_id_is_primary (path_repo_id, path_is_primary)
+,UNIQUE KEY paths_space_id_is_primary (path_space_id, path_is_primary)
+
+,CONSTRAINT fk_path_created_by FOREIGN KEY (path_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_path_space_id FOREIGN KEY (path_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_path_repo_id FOREIGN KEY (path_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE tokens (
+ token_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,token_type VARCHAR(255) COLLATE utf8mb4_unicode_ci
+,token_uid VARCHAR(255) COLLATE utf8mb4_unicode_ci
+,token_principal_id BIGINT
+,token_expires_at BIGINT
+,token_issued_at BIGINT
+,token_created_by BIGINT
+
+,UNIQUE KEY tokens_principal_id_uid (token_principal_id, token_uid)
+,KEY tokens_principal_id (token_principal_id)
+,KEY tokens_type_expires_at (token_type, token_expires_at)
+
+,CONSTRAINT fk_token_principal_id FOREIGN KEY (token_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreqs (
+ pullreq_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,pullreq_version BIGINT NOT NULL DEFAULT 0
+,pullreq_created_by BIGINT NOT NULL
+,pullreq_created BIGINT NOT NULL
+,pullreq_updated BIGINT NOT NULL
+,pullreq_edited BIGINT NOT NULL
+,pullreq_number BIGINT NOT NULL
+,pullreq_state VARCHAR(255) NOT NULL
+,pullreq_is_draft BOOLEAN NOT NULL DEFAULT FALSE
+,pullreq_comment_count BIGINT NOT NULL DEFAULT 0
+,pullreq_title TEXT NOT NULL
+,pullreq_description TEXT NOT NULL
+,pullreq_source_repo_id BIGINT NOT NULL
+,pullreq_source_branch VARCHAR(255) NOT NULL
+,pullreq_source_sha TEXT NOT NULL
+,pullreq_target_repo_id BIGINT NOT NULL
+,pullreq_target_branch VARCHAR(255) NOT NULL
+,pullreq_activity_seq BIGINT DEFAULT 0
+,pullreq_merged_by BIGINT
+,pullreq_merged BIGINT
+,pullreq_merge_method TEXT
+,pullreq_merge_check_status TEXT NOT NULL
+,pullreq_merge_target_sha TEXT
+,pullreq_merge_sha TEXT
+,pullreq_merge_conflicts TEXT
+,pullreq_merge_base_sha TEXT NOT NULL DEFAULT ('')
+,pullreq_unresolved_count BIGINT NOT NULL DEFAULT 0
+,pullreq_is_open BOOLEAN AS (CASE WHEN pullreq_state = 'open' THEN TRUE END) VIRTUAL
+
+,UNIQUE KEY pullreqs_source_repo_branch_target_repo_branch (pullreq_source_repo_id, pullreq_source_branch,
+    pullreq_target_repo_id, pullreq_target_branch, pullreq_is_open)
+,UNIQUE KEY pullreqs_target_repo_id_number (pullreq_target_repo_id, pullreq_number)
+
+,CONSTRAINT fk_pullreq_created_by FOREIGN KEY (pullreq_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_pullreq_source_repo_id FOREIGN KEY (pullreq_source_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_target_repo_id FOREIGN KEY (pullreq_target_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_merged_by FOREIGN KEY (pullreq_merged_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreq_activities (
+ pullreq_activity_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,pullreq_activity_version BIGINT NOT NULL
+,pullreq_activity_created_by BIGINT
+,pullreq_activity_created BIGINT NOT NULL
+,pullreq_activity_updated BIGINT NOT NULL
+,pullreq_activity_edited BIGINT NOT NULL
+,pullreq_activity_deleted BIGINT
+,pullreq_activity_parent_id BIGINT
+,pullreq_activity_repo_id BIGINT NOT NULL
+,pullreq_activity_pullreq_id BIGINT NOT NULL
+,pullreq_activity_order BIGINT NOT NULL
+,pullreq_activity_sub_order BIGINT NOT NULL
+,pullreq_activity_reply_seq BIGINT NOT NULL
+,pullreq_activity_type TEXT NOT NULL
+,pullreq_activity_kind TEXT NOT NULL
+,pullreq_activity_text TEXT NOT NULL
+,pullreq_activity_payload TEXT NOT NULL DEFAULT ('{}')
+,pullreq_activity_metadata TEXT NOT NULL DEFAULT ('{}')
+,pullreq_activity_resolved_by BIGINT
+,pullreq_activity_resolved BIGINT NULL
+,pullreq_activity_outdated BOOLEAN
+,pullreq_activity_code_comment_merge_base_sha TEXT
+,pullreq_activity_code_comment_source_sha TEXT
+,pullreq_activity_code_comment_path TEXT
+,pullreq_activity_code_comment_line_new BIGINT
+,pullreq_activity_code_comment_span_new BIGINT
+,pullreq_activity_code_comment_line_old BIGINT
+,pullreq_activity_code_comment_span_old BIGINT
+
+,UNIQUE KEY pullreq_activities_pullreq_id_order_sub_order (pullreq_activity_pullreq_id, pullreq_activity_order,
+    pullreq_activity_sub_order)
+
+,CONSTRAINT fk_pullreq_activities_created_by FOREIGN KEY (pullreq_activity_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_pullreq_activities_parent_id FOREIGN KEY (pullreq_activity_parent_id)
+    REFERENCES pullreq_activities (pullreq_activity_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_repo_id FOREIGN KEY (pullreq_activity_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_pullreq_id FOREIGN KEY (pullreq_activity_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_activities_resolved_by FOREIGN KEY (pullreq_activity_resolved_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE webhooks (
+ webhook_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,webhook_version BIGINT NOT NULL DEFAULT 0
+,webhook_created_by BIGINT NOT NULL
+,webhook_created BIGINT NOT NULL
+,webhook_updated BIGINT NOT NULL
+,webhook_space_id BIGINT
+,webhook_repo_id BIGINT
+,webhook_display_name TEXT NOT NULL
+,webhook_description TEXT NOT NULL
+,webhook_url TEXT NOT NULL
+,webhook_secret TEXT NOT NULL
+,webhook_enabled BOOLEAN NOT NULL
+,webhook_insecure BOOLEAN NOT NULL
+,webhook_triggers TEXT NOT NULL
+,webhook_latest_execution_result TEXT
+,webhook_internal BOOLEAN NOT NULL DEFAULT FALSE
+
+,KEY webhooks_repo_id (webhook_repo_id)
+,KEY webhooks_space_id (webhook_space_id)
+
+,CONSTRAINT fk_webhook_created_by FOREIGN KEY (webhook_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_webhook_space_id FOREIGN KEY (webhook_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_webhook_repo_id FOREIGN KEY (webhook_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE webhook_executions (
+ webhook_execution_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,webhook_execution_retrigger_of BIGINT
+,webhook_execution_retriggerable BOOLEAN NOT NULL
+,webhook_execution_webhook_id BIGINT NOT NULL
+,webhook_execution_trigger_type TEXT NOT NULL
+,webhook_execution_trigger_id TEXT NOT NULL
+,webhook_execution_result TEXT NOT NULL
+,webhook_execution_created BIGINT NOT NULL
+,webhook_execution_duration BIGINT NOT NULL
+,webhook_execution_error TEXT NOT NULL
+,webhook_execution_request_url TEXT NOT NULL
+,webhook_execution_request_headers TEXT NOT NULL
+,webhook_execution_request_body LONGTEXT NOT NULL
+,webhook_execution_response_status_code BIGINT NOT NULL
+,webhook_execution_response_status TEXT NOT NULL
+,webhook_execution_response_headers TEXT NOT NULL
+,webhook_execution_response_body LONGTEXT NOT NULL
+
+,KEY webhook_executions_webhook_id (webhook_execution_webhook_id)
+,KEY webhook_executions_created (webhook_execution_created)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreq_reviews (
+ pullreq_review_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,pullreq_review_created_by BIGINT NOT NULL
+,pullreq_review_created BIGINT NOT NULL
+,pullreq_review_updated BIGINT NOT NULL
+,pullreq_review_pullreq_id BIGINT NOT NULL
+,pullreq_review_decision TEXT NOT NULL
+,pullreq_review_sha TEXT NOT NULL
+
+,KEY index_pullreq_review_pullreq_id (pullreq_review_pullreq_id)
+
+,CONSTRAINT fk_pullreq_review_created_by FOREIGN KEY (pullreq_review_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_pullreq_review_pullreq_id FOREIGN KEY (pullreq_review_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreq_reviewers (
+ pullreq_reviewer_pullreq_id BIGINT NOT NULL
+,pullreq_reviewer_principal_id BIGINT NOT NULL
+,pullreq_reviewer_created_by BIGINT NOT NULL
+,pullreq_reviewer_created BIGINT NOT NULL
+,pullreq_reviewer_updated BIGINT NOT NULL
+,pullreq_reviewer_repo_id BIGINT NOT NULL
+,pullreq_reviewer_type TEXT NOT NULL
+,pullreq_reviewer_latest_review_id BIGINT
+,pullreq_reviewer_review_decision TEXT NOT NULL
+,pullreq_reviewer_sha TEXT NOT NULL
+
+,CONSTRAINT pk_pullreq_reviewers PRIMARY KEY (pullreq_reviewer_pullreq_id, pullreq_reviewer_principal_id)
+
+,CONSTRAINT fk_pullreq_reviewer_pullreq_id FOREIGN KEY (pullreq_reviewer_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reviewer_user_id FOREIGN KEY (pullreq_reviewer_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_pullreq_reviewer_created_by FOREIGN KEY (pullreq_reviewer_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_pullreq_reviewer_repo_id FOREIGN KEY (pullreq_reviewer_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reviewer_latest_review_id FOREIGN KEY (pullreq_reviewer_latest_review_id)
+    REFERENCES pullreq_reviews (pullreq_review_id)
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE checks (
+ check_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,check_created_by BIGINT NOT NULL
+,check_created BIGINT NOT NULL
+,check_updated BIGINT NOT NULL
+,check_repo_id BIGINT NOT NULL
+,check_commit_sha VARCHAR(255) NOT NULL
+,check_uid VARCHAR(255) NOT NULL
+,check_status TEXT NOT NULL
+,check_summary TEXT NOT NULL
+,check_link TEXT NOT NULL
+,check_payload TEXT NOT NULL
+,check_metadata TEXT NOT NULL
+,check_payload_version TEXT NOT NULL DEFAULT ('')
+,check_payload_kind TEXT NOT NULL DEFAULT ('')
+
+,UNIQUE KEY checks_repo_id_commit_sha_uid (check_repo_id, check_commit_sha, check_uid)
+,KEY checks_repo_id_created (check_repo_id, check_created)
+
+,CONSTRAINT fk_check_created_by FOREIGN KEY (check_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_check_repo_id FOREIGN KEY (check_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE reqchecks (
+ reqcheck_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,reqcheck_created_by BIGINT NOT NULL
+,reqcheck_created BIGINT NOT NULL
+,reqcheck_repo_id BIGINT NOT NULL
+,reqcheck_branch_pattern TEXT NOT NULL
+,reqcheck_check_uid TEXT NOT NULL
+
+,KEY reqchecks_repo_id (reqcheck_repo_id)
+
+,CONSTRAINT fk_reqcheck_created_by FOREIGN KEY (reqcheck_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_reqcheck_repo_id FOREIGN KEY (reqcheck_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE memberships (
+ membership_space_id BIGINT NOT NULL
+,membership_principal_id BIGINT NOT NULL
+,membership_created_by BIGINT NOT NULL
+,membership_created BIGINT NOT NULL
+,membership_updated BIGINT NOT NULL
+,membership_role TEXT NOT NULL
+
+,CONSTRAINT pk_memberships PRIMARY KEY (membership_space_id, membership_principal_id)
+
+,CONSTRAINT fk_membership_space_id FOREIGN KEY (membership_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_principal_id FOREIGN KEY (membership_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_created_by FOREIGN KEY (membership_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE jobs (
+ job_uid VARCHAR(255) NOT NULL
+,job_created BIGINT NOT NULL
+,job_updated BIGINT NOT NULL
+,job_type TEXT NOT NULL
+,job_priority BIGINT NOT NULL
+,job_data TEXT NOT NULL
+,job_result TEXT NOT NULL
+,job_max_duration_seconds BIGINT NOT NULL
+,job_max_retries BIGINT NOT NULL
+,job_state VARCHAR(255) NOT NULL
+,job_scheduled BIGINT NOT NULL
+,job_total_executions BIGINT
+,job_run_by TEXT NOT NULL
+,job_run_deadline BIGINT
+,job_run_progress BIGINT NOT NULL
+,job_last_executed BIGINT
+,job_is_recurring BOOLEAN NOT NULL
+,job_recurring_cron TEXT NOT NULL
+,job_consecutive_failures BIGINT NOT NULL
+,job_last_failure_error TEXT NOT NULL
+,job_group_id VARCHAR(255) NOT NULL DEFAULT ''
+,job_trace_context TEXT NOT NULL DEFAULT ('')
+
+,CONSTRAINT pk_jobs_uid PRIMARY KEY (job_uid)
+,KEY jobs_scheduled (job_state, job_scheduled)
+,KEY jobs_run_deadline (job_state, job_run_deadline)
+,KEY jobs_last_executed (job_last_executed)
+,KEY job_group_id (job_group_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pipelines (
+ pipeline_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,pipeline_description TEXT NOT NULL
+,pipeline_uid VARCHAR(255) NOT NULL
+,pipeline_seq BIGINT NOT NULL DEFAULT 0
+,pipeline_disabled BOOLEAN NOT NULL
+,pipeline_repo_id BIGINT NOT NULL
+,pipeline_default_branch TEXT NOT NULL
+,pipeline_created_by BIGINT NOT NULL
+,pipeline_config_path TEXT NOT NULL
+,pipeline_created BIGINT NOT NULL
+,pipeline_updated BIGINT NOT NULL
+,pipeline_version BIGINT NOT NULL
+
+,UNIQUE KEY pipelines_repo_id_uid (pipeline_repo_id, pipeline_uid)
+
+,CONSTRAINT fk_pipelines_repo_id FOREIGN KEY (pipeline_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pipelines_created_by FOREIGN KEY (pipeline_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE executions (
+ execution_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,execution_pipeline_id BIGINT NOT NULL
+,execution_repo_id BIGINT NOT NULL
+,execution_created_by BIGINT NOT NULL
+,execution_trigger TEXT NOT NULL
+,execution_number BIGINT NOT NULL
+,execution_parent BIGINT NOT NULL
+,execution_status TEXT NOT NULL
+,execution_error TEXT NOT NULL
+,execution_event TEXT NOT NULL
+,execution_action TEXT NOT NULL
+,execution_link TEXT NOT NULL
+,execution_timestamp BIGINT NOT NULL
+,execution_title TEXT NOT NULL
+,execution_message TEXT NOT NULL
+,execution_before TEXT NOT NULL
+,execution_after TEXT NOT NULL
+,execution_ref TEXT NOT NULL
+,execution_source_repo TEXT NOT NULL
+,execution_source TEXT NOT NULL
+,execution_target TEXT NOT NULL
+,execution_author TEXT NOT NULL
+,execution_author_name TEXT NOT NULL
+,execution_author_email TEXT NOT NULL
+,execution_author_avatar TEXT NOT NULL
+,execution_sender TEXT NOT NULL
+,execution_params TEXT NOT NULL
+,execution_cron TEXT NOT NULL
+,execution_deploy TEXT NOT NULL
+,execution_deploy_id BIGINT NOT NULL
+,execution_debug BOOLEAN NOT NULL DEFAULT FALSE
+,execution_started BIGINT NOT NULL
+,execution_finished BIGINT NOT NULL
+,execution_created BIGINT NOT NULL
+,execution_updated BIGINT NOT NULL
+,execution_version BIGINT NOT NULL
+
+,UNIQUE KEY executions_pipeline_id_number (execution_pipeline_id, execution_number)
+
+,CONSTRAINT fk_executions_pipeline_id FOREIGN KEY (execution_pipeline_id)
+    REFERENCES pipelines (pipeline_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_executions_repo_id FOREIGN KEY (execution_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_executions_created_by FOREIGN KEY (execution_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE secrets (
+ secret_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,secret_uid VARCHAR(255) NOT NULL
+,secret_space_id BIGINT NOT NULL
+,secret_description TEXT NOT NULL
+,secret_data LONGBLOB NOT NULL
+,secret_created BIGINT NOT NULL
+,secret_updated BIGINT NOT NULL
+,secret_version BIGINT NOT NULL
+,secret_created_by BIGINT NOT NULL
+,secret_inheritable BOOLEAN NOT NULL DEFAULT FALSE
+,secret_provider VARCHAR(255) NOT NULL DEFAULT 'gitness'
+
+,UNIQUE KEY secrets_space_id_uid (secret_space_id, secret_uid)
+
+,CONSTRAINT fk_secrets_space_id FOREIGN KEY (secret_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_secrets_created_by FOREIGN KEY (secret_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE stages (
+ stage_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,stage_execution_id BIGINT NOT NULL
+,stage_repo_id BIGINT NOT NULL
+,stage_number BIGINT NOT NULL
+,stage_kind TEXT NOT NULL
+,stage_type TEXT NOT NULL
+,stage_name TEXT NOT NULL
+,stage_status VARCHAR(255) NOT NULL
+,stage_error TEXT NOT NULL
+,stage_parent_group_id BIGINT NOT NULL
+,stage_errignore BOOLEAN NOT NULL
+,stage_exit_code BIGINT NOT NULL
+,stage_limit BIGINT NOT NULL
+,stage_os TEXT NOT NULL
+,stage_arch TEXT NOT NULL
+,stage_variant TEXT NOT NULL
+,stage_kernel TEXT NOT NULL
+,stage_machine TEXT NOT NULL
+,stage_started BIGINT NOT NULL
+,stage_stopped BIGINT NOT NULL
+,stage_created BIGINT NOT NULL
+,stage_updated BIGINT NOT NULL
+,stage_version BIGINT NOT NULL
+,stage_on_success BOOLEAN NOT NULL
+,stage_on_failure BOOLEAN NOT NULL
+,stage_depends_on TEXT NOT NULL
+,stage_labels TEXT NOT NULL
+,stage_limit_repo BIGINT NOT NULL DEFAULT 0
+
+,UNIQUE KEY stages_execution_id_number (stage_execution_id, stage_number)
+,KEY ix_stage_in_progress (stage_status)
+
+,CONSTRAINT fk_stages_execution_id FOREIGN KEY (stage_execution_id)
+    REFERENCES executions (execution_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE steps (
+ step_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,step_stage_id BIGINT NOT NULL
+,step_number BIGINT NOT NULL
+,step_name VARCHAR(100) NOT NULL
+,step_status VARCHAR(50) NOT NULL
+,step_error VARCHAR(500) NOT NULL
+,step_parent_group_id BIGINT NOT NULL
+,step_errignore BOOLEAN NOT NULL
+,step_exit_code BIGINT NOT NULL
+,step_started BIGINT NOT NULL
+,step_stopped BIGINT NOT NULL
+,step_version BIGINT NOT NULL
+,step_depends_on TEXT NOT NULL
+,step_image TEXT NOT NULL
+,step_detached BOOLEAN NOT NULL
+,step_schema TEXT NOT NULL
+,step_logs_purged BOOLEAN NOT NULL DEFAULT FALSE
+
+,UNIQUE KEY steps_stage_id_number (step_stage_id, step_number)
+
+,CONSTRAINT fk_steps_stage_id FOREIGN KEY (step_stage_id)
+    REFERENCES stages (stage_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE logs (
+ log_id BIGINT PRIMARY KEY
+,log_data LONGBLOB NOT NULL
+
+,CONSTRAINT fk_logs_id FOREIGN KEY (log_id)
+    REFERENCES steps (step_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE connectors (
+ connector_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,connector_uid VARCHAR(255) NOT NULL
+,connector_description TEXT NOT NULL
+,connector_type TEXT NOT NULL
+,connector_space_id BIGINT NOT NULL
+,connector_data TEXT NOT NULL
+,connector_created BIGINT NOT NULL
+,connector_updated BIGINT NOT NULL
+,connector_version BIGINT NOT NULL
+
+,UNIQUE KEY connectors_space_id_uid (connector_space_id, connector_uid)
+
+,CONSTRAINT fk_connectors_space_id FOREIGN KEY (connector_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE templates (
+ template_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,template_uid VARCHAR(255) NOT NULL
+,template_description TEXT NOT NULL
+,template_space_id BIGINT NOT NULL
+,template_data TEXT NOT NULL
+,template_created BIGINT NOT NULL
+,template_updated BIGINT NOT NULL
+,template_version BIGINT NOT NULL
+
+,UNIQUE KEY templates_space_id_uid (template_space_id, template_uid)
+
+,CONSTRAINT fk_templates_space_id FOREIGN KEY (template_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE triggers (
+ trigger_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,trigger_uid VARCHAR(255) NOT NULL
+,trigger_pipeline_id BIGINT NOT NULL
+,trigger_type TEXT NOT NULL
+,trigger_repo_id BIGINT NOT NULL
+,trigger_secret TEXT NOT NULL
+,trigger_description TEXT NOT NULL
+,trigger_disabled BOOLEAN NOT NULL
+,trigger_created_by BIGINT NOT NULL
+,trigger_actions TEXT NOT NULL
+,trigger_created BIGINT NOT NULL
+,trigger_updated BIGINT NOT NULL
+,trigger_version BIGINT NOT NULL
+
+,UNIQUE KEY triggers_pipeline_id_uid (trigger_pipeline_id, trigger_uid)
+
+,CONSTRAINT fk_triggers_pipeline_id FOREIGN KEY (trigger_pipeline_id)
+    REFERENCES pipelines (pipeline_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_triggers_repo_id FOREIGN KEY (trigger_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE plugins (
+ plugin_uid VARCHAR(255) NOT NULL
+,plugin_description TEXT NOT NULL
+,plugin_logo TEXT NOT NULL
+,plugin_spec LONGBLOB NOT NULL
+,plugin_type TEXT NOT NULL
+,plugin_version TEXT NOT NULL
+
+,UNIQUE KEY plugins_uid (plugin_uid)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE space_paths (
+ space_path_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,space_path_uid TEXT NOT NULL
+,space_path_uid_unique VARCHAR(255) NOT NULL
+,space_path_is_primary BOOLEAN DEFAULT NULL
+,space_path_space_id BIGINT NOT NULL
+,space_path_parent_id BIGINT
+,space_path_created_by BIGINT NOT NULL
+,space_path_created BIGINT NOT NULL
+,space_path_updated BIGINT NOT NULL
+,space_path_parent_key BIGINT AS (COALESCE(space_path_parent_id, 0)) VIRTUAL
+
+,UNIQUE KEY space_paths_space_id_is_primary (space_path_space_id, space_path_is_primary)
+,UNIQUE KEY space_paths_uid_unique (space_path_parent_key, space_path_uid_unique)
+
+,CONSTRAINT fk_space_path_created_by FOREIGN KEY (space_path_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_space_path_space_id FOREIGN KEY (space_path_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_path_parent_id FOREIGN KEY (space_path_parent_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreq_file_views (
+ pullreq_file_view_pullreq_id BIGINT NOT NULL
+,pullreq_file_view_principal_id BIGINT NOT NULL
+,pullreq_file_view_path VARCHAR(700) NOT NULL
+,pullreq_file_view_sha TEXT NOT NULL
+,pullreq_file_view_obsolete BOOLEAN NOT NULL
+,pullreq_file_view_created BIGINT NOT NULL
+,pullreq_file_view_updated BIGINT NOT NULL
+
+,CONSTRAINT pk_pullreq_file_views PRIMARY KEY (pullreq_file_view_pullreq_id, pullreq_file_view_principal_id,
+    pullreq_file_view_path)
+,KEY pullreq_file_views_pullreq_id_file_path (pullreq_file_view_pullreq_id, pullreq_file_view_path)
+
+,CONSTRAINT fk_pullreq_file_view_pullreq_id FOREIGN KEY (pullreq_file_view_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_file_view_principal_id FOREIGN KEY (pullreq_file_view_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE event_outbox (
+ event_outbox_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,event_outbox_stream_id TEXT NOT NULL
+,event_outbox_payload LONGBLOB NOT NULL
+,event_outbox_created BIGINT NOT NULL
+,event_outbox_attempts BIGINT NOT NULL DEFAULT 0
+,event_outbox_next_attempt BIGINT NOT NULL
+,event_outbox_last_error TEXT NOT NULL DEFAULT ('')
+
+,KEY event_outbox_next_attempt (event_outbox_next_attempt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE event_dead_letters (
+ event_dead_letter_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,event_dead_letter_stream_id VARCHAR(255) NOT NULL
+,event_dead_letter_group_name VARCHAR(255) NOT NULL
+,event_dead_letter_message_id TEXT NOT NULL
+,event_dead_letter_payload LONGBLOB NOT NULL
+,event_dead_letter_retries BIGINT NOT NULL DEFAULT 0
+,event_dead_letter_last_error TEXT NOT NULL DEFAULT ('')
+,event_dead_letter_created BIGINT NOT NULL
+
+,KEY event_dead_letters_stream_id_group_name (event_dead_letter_stream_id, event_dead_letter_group_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE repository_shards (
+ repository_shard_git_uid VARCHAR(255) PRIMARY KEY
+,repository_shard_name VARCHAR(255) NOT NULL
+,repository_shard_created BIGINT NOT NULL
+
+,KEY repository_shards_name (repository_shard_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE space_quotas (
+ space_quota_space_id BIGINT PRIMARY KEY
+,space_quota_max_repos BIGINT
+,space_quota_max_size BIGINT
+,space_quota_created_by BIGINT NOT NULL
+,space_quota_created BIGINT NOT NULL
+,space_quota_updated BIGINT NOT NULL
+
+,CONSTRAINT fk_space_quota_space_id FOREIGN KEY (space_quota_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE settings (
+ setting_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,setting_space_id BIGINT
+,setting_repo_id BIGINT
+,setting_key VARCHAR(255) NOT NULL
+,setting_value TEXT NOT NULL
+,setting_locked BOOLEAN NOT NULL DEFAULT FALSE
+,setting_created_by BIGINT NOT NULL
+,setting_created BIGINT NOT NULL
+,setting_updated BIGINT NOT NULL
+
+,UNIQUE KEY settings_space_id_key (setting_space_id, setting_key)
+,UNIQUE KEY settings_repo_id_key (setting_repo_id, setting_key)
+
+,CONSTRAINT fk_setting_space_id FOREIGN KEY (setting_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_setting_repo_id FOREIGN KEY (setting_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE announcements (
+ announcement_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,announcement_message TEXT NOT NULL
+,announcement_severity TEXT NOT NULL
+,announcement_starts BIGINT NOT NULL DEFAULT 0
+,announcement_ends BIGINT NOT NULL DEFAULT 0
+,announcement_dismissible BOOLEAN NOT NULL DEFAULT TRUE
+,announcement_created_by BIGINT NOT NULL
+,announcement_created BIGINT NOT NULL
+,announcement_updated BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE announcement_dismissals (
+ announcement_dismissal_announcement_id BIGINT NOT NULL
+,announcement_dismissal_principal_id BIGINT NOT NULL
+,announcement_dismissal_created BIGINT NOT NULL
+
+,CONSTRAINT pk_announcement_dismissals PRIMARY KEY (announcement_dismissal_announcement_id,
+    announcement_dismissal_principal_id)
+
+,CONSTRAINT fk_announcement_dismissal_announcement_id FOREIGN KEY (announcement_dismissal_announcement_id)
+    REFERENCES announcements (announcement_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_announcement_dismissal_principal_id FOREIGN KEY (announcement_dismissal_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE user_emails (
+ user_email_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,user_email_principal_id BIGINT NOT NULL
+,user_email_address VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL
+,user_email_verified BIGINT
+,user_email_verification_token TEXT NOT NULL DEFAULT ('')
+,user_email_verification_expires BIGINT NOT NULL DEFAULT 0
+,user_email_created BIGINT NOT NULL
+,user_email_updated BIGINT NOT NULL
+,user_email_verified_address VARCHAR(255) COLLATE utf8mb4_unicode_ci
+    AS (CASE WHEN user_email_verified IS NOT NULL THEN user_email_address END) VIRTUAL
+
+,UNIQUE KEY user_emails_principal_id_lower_address (user_email_principal_id, user_email_address)
+,UNIQUE KEY user_emails_lower_address_verified (user_email_verified_address)
+
+,CONSTRAINT fk_user_email_principal_id FOREIGN KEY (user_email_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE notification_settings (
+ notification_settings_principal_id BIGINT PRIMARY KEY
+,notification_settings_delivery TEXT NOT NULL
+,notification_settings_review_requested BOOLEAN NOT NULL
+,notification_settings_comments BOOLEAN NOT NULL
+,notification_settings_merged BOOLEAN NOT NULL
+,notification_settings_pipeline_failed BOOLEAN NOT NULL
+,notification_settings_updated BIGINT NOT NULL
+
+,CONSTRAINT fk_notification_settings_principal_id FOREIGN KEY (notification_settings_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE notification_digest_items (
+ notification_digest_item_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,notification_digest_item_principal_id BIGINT NOT NULL
+,notification_digest_item_subject TEXT NOT NULL
+,notification_digest_item_body TEXT NOT NULL
+,notification_digest_item_created BIGINT NOT NULL
+
+,KEY notification_digest_items_principal_id (notification_digest_item_principal_id)
+
+,CONSTRAINT fk_notification_digest_item_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE chat_integrations (
+ chat_integration_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,chat_integration_version BIGINT NOT NULL DEFAULT 0
+,chat_integration_space_id BIGINT
+,chat_integration_repo_id BIGINT
+,chat_integration_created_by BIGINT NOT NULL
+,chat_integration_created BIGINT NOT NULL
+,chat_integration_updated BIGINT NOT NULL
+,chat_integration_display_name TEXT NOT NULL
+,chat_integration_provider TEXT NOT NULL
+,chat_integration_url TEXT NOT NULL
+,chat_integration_enabled BOOLEAN NOT NULL
+,chat_integration_triggers TEXT NOT NULL
+
+,KEY chat_integrations_space_id (chat_integration_space_id)
+,KEY chat_integrations_repo_id (chat_integration_repo_id)
+
+,CONSTRAINT fk_chat_integration_created_by FOREIGN KEY (chat_integration_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_chat_integration_space_id FOREIGN KEY (chat_integration_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_chat_integration_repo_id FOREIGN KEY (chat_integration_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE pullreq_subscribers (
+ pullreq_subscriber_pullreq_id BIGINT NOT NULL
+,pullreq_subscriber_principal_id BIGINT NOT NULL
+,pullreq_subscriber_subscribed BOOLEAN NOT NULL
+,pullreq_subscriber_created BIGINT NOT NULL
+,pullreq_subscriber_updated BIGINT NOT NULL
+
+,CONSTRAINT pk_pullreq_subscribers PRIMARY KEY (pullreq_subscriber_pullreq_id, pullreq_subscriber_principal_id)
+
+,CONSTRAINT fk_pullreq_subscriber_pullreq_id FOREIGN KEY (pullreq_subscriber_pullreq_id)
+    REFERENCES pullreqs (pullreq_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_subscriber_principal_id FOREIGN KEY (pullreq_subscriber_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE usergroup_members (
+ usergroup_member_usergroup_id BIGINT NOT NULL
+,usergroup_member_principal_id BIGINT NOT NULL
+,usergroup_member_created_by BIGINT NOT NULL
+,usergroup_member_created BIGINT NOT NULL
+
+,CONSTRAINT pk_usergroup_members PRIMARY KEY (usergroup_member_usergroup_id, usergroup_member_principal_id)
+,KEY usergroup_members_principal_id (usergroup_member_principal_id)
+
+,CONSTRAINT fk_usergroup_member_usergroup_id FOREIGN KEY (usergroup_member_usergroup_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_member_principal_id FOREIGN KEY (usergroup_member_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_usergroup_member_created_by FOREIGN KEY (usergroup_member_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE insights_days (
+ insights_day_repo_id BIGINT NOT NULL
+,insights_day_day BIGINT NOT NULL
+,insights_day_commits BIGINT NOT NULL
+,insights_day_pullreqs_opened BIGINT NOT NULL
+,insights_day_pullreqs_merged BIGINT NOT NULL
+,insights_day_merge_time_total BIGINT NOT NULL
+,insights_day_pullreqs_reviewed BIGINT NOT NULL
+,insights_day_review_time_total BIGINT NOT NULL
+
+,CONSTRAINT pk_insights_days PRIMARY KEY (insights_day_repo_id, insights_day_day)
+
+,CONSTRAINT fk_insights_day_repo_id FOREIGN KEY (insights_day_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE insights_contributors (
+ insights_contributor_repo_id BIGINT NOT NULL
+,insights_contributor_day BIGINT NOT NULL
+,insights_contributor_email VARCHAR(255) NOT NULL
+,insights_contributor_name TEXT NOT NULL
+,insights_contributor_commits BIGINT NOT NULL
+
+,CONSTRAINT pk_insights_contributors
+    PRIMARY KEY (insights_contributor_repo_id, insights_contributor_day, insights_contributor_email)
+
+,CONSTRAINT fk_insights_contributor_repo_id FOREIGN KEY (insights_contributor_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE branch_renames (
+ branch_rename_repo_id BIGINT NOT NULL
+,branch_rename_old_name VARCHAR(255) NOT NULL
+,branch_rename_new_name TEXT NOT NULL
+,branch_rename_created_by BIGINT NOT NULL
+,branch_rename_created BIGINT NOT NULL
+
+,CONSTRAINT pk_branch_renames PRIMARY KEY (branch_rename_repo_id, branch_rename_old_name)
+
+,CONSTRAINT fk_branch_rename_repo_id FOREIGN KEY (branch_rename_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_branch_rename_created_by FOREIGN KEY (branch_rename_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,45 @@
+CREATE TABLE scan_findings (
+ scan_finding_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,scan_finding_repo_id BIGINT NOT NULL
+,scan_finding_commit_sha VARCHAR(255) NOT NULL
+,scan_finding_scanner VARCHAR(255) NOT NULL
+,scan_finding_kind TEXT NOT NULL
+,scan_finding_vulnerability_id VARCHAR(255) NOT NULL
+,scan_finding_package VARCHAR(255) NOT NULL
+,scan_finding_version TEXT NOT NULL
+,scan_finding_fixed_version TEXT NOT NULL
+,scan_finding_severity VARCHAR(255) NOT NULL
+,scan_finding_title TEXT NOT NULL
+,scan_finding_path TEXT NOT NULL
+,scan_finding_url TEXT NOT NULL
+,scan_finding_created BIGINT NOT NULL
+
+,KEY scan_findings_repo_id_commit_sha_scanner (scan_finding_repo_id, scan_finding_commit_sha, scan_finding_scanner)
+
+,CONSTRAINT fk_scan_finding_repo_id FOREIGN KEY (scan_finding_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE scan_suppressions (
+ scan_suppression_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,scan_suppression_repo_id BIGINT NOT NULL
+,scan_suppression_vulnerability_id VARCHAR(255) NOT NULL
+,scan_suppression_package VARCHAR(255) NOT NULL
+,scan_suppression_reason TEXT NOT NULL
+,scan_suppression_created_by BIGINT NOT NULL
+,scan_suppression_created BIGINT NOT NULL
+,scan_suppression_expires BIGINT NOT NULL
+
+,KEY scan_suppressions_repo_id (scan_suppression_repo_id)
+
+,CONSTRAINT fk_scan_suppression_repo_id FOREIGN KEY (scan_suppression_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_scan_suppression_created_by FOREIGN KEY (scan_suppression_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
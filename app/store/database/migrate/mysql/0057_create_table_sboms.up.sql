@@ -0,0 +1,16 @@
+CREATE TABLE sboms (
+ sbom_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,sbom_repo_id BIGINT NOT NULL
+,sbom_tag VARCHAR(255) NOT NULL
+,sbom_commit_sha VARCHAR(255) NOT NULL
+,sbom_format VARCHAR(255) NOT NULL
+,sbom_size BIGINT NOT NULL
+,sbom_created BIGINT NOT NULL
+
+,UNIQUE KEY sboms_repo_id_tag_format (sbom_repo_id, sbom_tag, sbom_format)
+
+,CONSTRAINT fk_sbom_repo_id FOREIGN KEY (sbom_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
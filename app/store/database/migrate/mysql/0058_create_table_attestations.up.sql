@@ -0,0 +1,30 @@
+CREATE TABLE attestations (
+ attestation_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,attestation_repo_id BIGINT NOT NULL
+,attestation_commit_sha VARCHAR(255) NOT NULL
+,attestation_execution_id BIGINT
+,attestation_predicate_type TEXT NOT NULL
+,attestation_envelope LONGTEXT NOT NULL
+,attestation_status TEXT NOT NULL
+,attestation_key_name TEXT NOT NULL
+,attestation_status_message TEXT NOT NULL
+,attestation_created_by BIGINT NOT NULL
+,attestation_created BIGINT NOT NULL
+,attestation_verified BIGINT NOT NULL
+
+,KEY attestations_repo_id_commit_sha (attestation_repo_id, attestation_commit_sha)
+,KEY attestations_execution_id (attestation_execution_id)
+
+,CONSTRAINT fk_attestation_repo_id FOREIGN KEY (attestation_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_attestation_execution_id FOREIGN KEY (attestation_execution_id)
+    REFERENCES executions (execution_id)
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+,CONSTRAINT fk_attestation_created_by FOREIGN KEY (attestation_created_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,18 @@
+CREATE TABLE file_locks (
+ file_lock_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,file_lock_repo_id BIGINT NOT NULL
+,file_lock_path VARCHAR(700) NOT NULL
+,file_lock_owner_id BIGINT NOT NULL
+,file_lock_created BIGINT NOT NULL
+
+,UNIQUE KEY file_locks_repo_id_path (file_lock_repo_id, file_lock_path)
+
+,CONSTRAINT fk_file_lock_repo_id FOREIGN KEY (file_lock_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_file_lock_owner_id FOREIGN KEY (file_lock_owner_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
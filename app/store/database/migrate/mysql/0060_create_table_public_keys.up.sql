@@ -0,0 +1,39 @@
+CREATE TABLE public_keys (
+ public_key_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,public_key_principal_id BIGINT NOT NULL
+,public_key_identifier VARCHAR(255) NOT NULL
+,public_key_scheme VARCHAR(255) NOT NULL
+,public_key_fingerprint VARCHAR(255) NOT NULL
+,public_key_content TEXT NOT NULL
+,public_key_created BIGINT NOT NULL
+
+,UNIQUE KEY public_keys_fingerprint (public_key_fingerprint)
+,KEY public_keys_principal_id (public_key_principal_id)
+
+,CONSTRAINT fk_public_key_principal_id FOREIGN KEY (public_key_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE commit_verifications (
+ commit_verification_repo_id BIGINT NOT NULL
+,commit_verification_sha VARCHAR(255) NOT NULL
+,commit_verification_status VARCHAR(255) NOT NULL
+,commit_verification_scheme VARCHAR(255) NOT NULL
+,commit_verification_key_id VARCHAR(255) NOT NULL
+,commit_verification_signer_id BIGINT
+,commit_verification_created BIGINT NOT NULL
+
+,PRIMARY KEY (commit_verification_repo_id, commit_verification_sha)
+,KEY commit_verifications_key_id (commit_verification_key_id)
+
+,CONSTRAINT fk_commit_verification_repo_id FOREIGN KEY (commit_verification_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_commit_verification_signer_id FOREIGN KEY (commit_verification_signer_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
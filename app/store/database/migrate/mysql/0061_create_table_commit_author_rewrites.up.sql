@@ -0,0 +1,19 @@
+CREATE TABLE commit_author_rewrites (
+ commit_author_rewrite_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,commit_author_rewrite_repo_id BIGINT NOT NULL
+,commit_author_rewrite_sha VARCHAR(255) NOT NULL
+,commit_author_rewrite_operation VARCHAR(255) NOT NULL
+,commit_author_rewrite_principal_id BIGINT NOT NULL
+,commit_author_rewrite_requested_name TEXT NOT NULL
+,commit_author_rewrite_requested_email TEXT NOT NULL
+,commit_author_rewrite_author_name TEXT NOT NULL
+,commit_author_rewrite_author_email TEXT NOT NULL
+,commit_author_rewrite_created BIGINT NOT NULL
+
+,KEY commit_author_rewrites_repo_id_sha (commit_author_rewrite_repo_id, commit_author_rewrite_sha)
+
+,CONSTRAINT fk_commit_author_rewrite_repo_id FOREIGN KEY (commit_author_rewrite_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,13 @@
+CREATE TABLE repo_topics (
+ repo_topic_repo_id BIGINT NOT NULL
+,repo_topic_topic VARCHAR(255) NOT NULL
+,repo_topic_created BIGINT NOT NULL
+
+,PRIMARY KEY (repo_topic_repo_id, repo_topic_topic)
+,KEY repo_topics_topic (repo_topic_topic)
+
+,CONSTRAINT fk_repo_topic_repo_id FOREIGN KEY (repo_topic_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
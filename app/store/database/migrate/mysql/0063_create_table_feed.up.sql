@@ -0,0 +1,37 @@
+CREATE TABLE repo_stars (
+ repo_star_repo_id BIGINT NOT NULL
+,repo_star_principal_id BIGINT NOT NULL
+,repo_star_created BIGINT NOT NULL
+
+,PRIMARY KEY (repo_star_principal_id, repo_star_repo_id)
+,KEY repo_stars_repo_id (repo_star_repo_id)
+
+,CONSTRAINT fk_repo_star_repo_id FOREIGN KEY (repo_star_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_star_principal_id FOREIGN KEY (repo_star_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE feed_events (
+ feed_event_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,feed_event_repo_id BIGINT NOT NULL
+,feed_event_principal_id BIGINT NOT NULL
+,feed_event_type VARCHAR(255) NOT NULL
+,feed_event_ref TEXT NOT NULL
+,feed_event_sha VARCHAR(255) NOT NULL
+,feed_event_pullreq_number BIGINT NOT NULL
+,feed_event_created BIGINT NOT NULL
+
+,KEY feed_events_repo_id_id (feed_event_repo_id, feed_event_id)
+,KEY feed_events_principal_id (feed_event_principal_id)
+,KEY feed_events_created (feed_event_created)
+
+,CONSTRAINT fk_feed_event_repo_id FOREIGN KEY (feed_event_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
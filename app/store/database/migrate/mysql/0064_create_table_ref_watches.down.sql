@@ -0,0 +1 @@
+DROP TABLE ref_watches;
@@ -0,0 +1,20 @@
+CREATE TABLE ref_watches (
+ ref_watch_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,ref_watch_repo_id BIGINT NOT NULL
+,ref_watch_principal_id BIGINT NOT NULL
+,ref_watch_type VARCHAR(255) NOT NULL
+,ref_watch_pattern VARCHAR(255) NOT NULL
+,ref_watch_created BIGINT NOT NULL
+
+,UNIQUE KEY ref_watches_repo_id_principal_id_type_pattern
+    (ref_watch_repo_id, ref_watch_principal_id, ref_watch_type, ref_watch_pattern)
+
+,CONSTRAINT fk_ref_watch_repo_id FOREIGN KEY (ref_watch_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_ref_watch_principal_id FOREIGN KEY (ref_watch_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
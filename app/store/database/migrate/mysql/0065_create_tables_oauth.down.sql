@@ -0,0 +1,3 @@
+DROP TABLE oauth_codes;
+DROP TABLE oauth_authorizations;
+DROP TABLE oauth_apps;
@@ -0,0 +1,3 @@
+ALTER TABLE oauth_apps ADD COLUMN oauth_app_scopes VARCHAR(255) NOT NULL DEFAULT '';
+
+UPDATE oauth_apps SET oauth_app_scopes = 'openid profile email user:read repo:read repo:write';
@@ -0,0 +1,18 @@
+CREATE TABLE upload_quarantines (
+ upload_quarantine_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,upload_quarantine_sha256 VARCHAR(64) NOT NULL
+,upload_quarantine_source VARCHAR(255) NOT NULL
+,upload_quarantine_repo_id BIGINT NOT NULL DEFAULT 0
+,upload_quarantine_name TEXT NOT NULL
+,upload_quarantine_size BIGINT NOT NULL
+,upload_quarantine_scanner VARCHAR(255) NOT NULL
+,upload_quarantine_signature TEXT NOT NULL
+,upload_quarantine_status VARCHAR(255) NOT NULL
+,upload_quarantine_uploaded_by BIGINT NOT NULL
+,upload_quarantine_released_by BIGINT
+,upload_quarantine_created BIGINT NOT NULL
+,upload_quarantine_updated BIGINT NOT NULL
+
+,UNIQUE KEY upload_quarantines_sha256 (upload_quarantine_sha256)
+,KEY upload_quarantines_status (upload_quarantine_status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,16 @@
+CREATE TABLE attachments (
+ attachment_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,attachment_repo_id BIGINT NOT NULL
+,attachment_sha256 VARCHAR(64) NOT NULL
+,attachment_content_type VARCHAR(255) NOT NULL
+,attachment_size BIGINT NOT NULL
+,attachment_created_by BIGINT NOT NULL
+,attachment_created BIGINT NOT NULL
+
+,UNIQUE KEY attachments_repo_id_sha256 (attachment_repo_id, attachment_sha256)
+
+,CONSTRAINT fk_attachment_repo_id FOREIGN KEY (attachment_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,16 @@
+-- backups are kept after the repository is deleted, so there's no foreign key to repositories.
+CREATE TABLE backups (
+ backup_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,backup_repo_id BIGINT NOT NULL
+,backup_repo_path TEXT NOT NULL
+,backup_state VARCHAR(255) NOT NULL
+,backup_trigger VARCHAR(255) NOT NULL
+,backup_bundle_size BIGINT NOT NULL DEFAULT 0
+,backup_metadata_size BIGINT NOT NULL DEFAULT 0
+,backup_error TEXT NOT NULL
+,backup_created_by BIGINT NOT NULL
+,backup_created BIGINT NOT NULL
+,backup_updated BIGINT NOT NULL
+
+,KEY backups_repo_id (backup_repo_id, backup_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,2 @@
+DROP TABLE instance_setting_changes;
+DROP TABLE instance_settings;
@@ -0,0 +1,17 @@
+CREATE TABLE instance_settings (
+ instance_setting_key VARCHAR(255) PRIMARY KEY
+,instance_setting_value TEXT NOT NULL
+,instance_setting_updated_by BIGINT NOT NULL
+,instance_setting_updated BIGINT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+CREATE TABLE instance_setting_changes (
+ instance_setting_change_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,instance_setting_change_key VARCHAR(255) NOT NULL
+,instance_setting_change_old_value TEXT
+,instance_setting_change_new_value TEXT
+,instance_setting_change_changed_by BIGINT NOT NULL
+,instance_setting_change_changed BIGINT NOT NULL
+
+,KEY instance_setting_changes_changed (instance_setting_change_changed)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
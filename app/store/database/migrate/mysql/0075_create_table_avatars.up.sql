@@ -0,0 +1,20 @@
+CREATE TABLE avatars (
+ avatar_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,avatar_principal_id BIGINT
+,avatar_space_id BIGINT
+,avatar_sha256 VARCHAR(64) NOT NULL
+,avatar_created_by BIGINT NOT NULL
+,avatar_updated BIGINT NOT NULL
+
+,UNIQUE KEY avatars_principal_id (avatar_principal_id)
+,UNIQUE KEY avatars_space_id (avatar_space_id)
+
+,CONSTRAINT fk_avatar_principal_id FOREIGN KEY (avatar_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_avatar_space_id FOREIGN KEY (avatar_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,15 @@
+CREATE TABLE saved_replies (
+ saved_reply_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,saved_reply_principal_id BIGINT NOT NULL
+,saved_reply_identifier VARCHAR(255) COLLATE utf8mb4_unicode_ci NOT NULL
+,saved_reply_content TEXT NOT NULL
+,saved_reply_created BIGINT NOT NULL
+,saved_reply_updated BIGINT NOT NULL
+
+,UNIQUE KEY saved_replies_principal_id_identifier (saved_reply_principal_id, saved_reply_identifier)
+
+,CONSTRAINT fk_saved_reply_principal_id FOREIGN KEY (saved_reply_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
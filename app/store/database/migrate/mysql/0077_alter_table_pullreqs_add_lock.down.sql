@@ -0,0 +1,3 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_lock_reason;
+ALTER TABLE pullreqs DROP COLUMN pullreq_locked;
+ALTER TABLE pullreqs DROP COLUMN pullreq_locked_by;
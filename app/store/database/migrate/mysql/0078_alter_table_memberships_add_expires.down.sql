@@ -0,0 +1,4 @@
+DROP INDEX memberships_expires ON memberships;
+
+ALTER TABLE memberships DROP COLUMN membership_expiry_notified;
+ALTER TABLE memberships DROP COLUMN membership_expires;
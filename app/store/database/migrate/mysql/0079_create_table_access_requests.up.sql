@@ -0,0 +1,33 @@
+CREATE TABLE access_requests (
+ access_request_id BIGINT PRIMARY KEY AUTO_INCREMENT
+,access_request_space_id BIGINT NOT NULL
+,access_request_repo_id BIGINT
+,access_request_principal_id BIGINT NOT NULL
+,access_request_role VARCHAR(255) NOT NULL
+,access_request_message TEXT NOT NULL
+,access_request_state VARCHAR(255) NOT NULL
+,access_request_created BIGINT NOT NULL
+,access_request_updated BIGINT NOT NULL
+,access_request_decided_by BIGINT
+,access_request_decided BIGINT
+,access_request_decision_reason TEXT NOT NULL
+
+,KEY access_requests_space_id_state (access_request_space_id, access_request_state)
+
+,CONSTRAINT fk_access_request_space_id FOREIGN KEY (access_request_space_id)
+    REFERENCES spaces (space_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_access_request_repo_id FOREIGN KEY (access_request_repo_id)
+    REFERENCES repositories (repo_id)
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+,CONSTRAINT fk_access_request_principal_id FOREIGN KEY (access_request_principal_id)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_access_request_decided_by FOREIGN KEY (access_request_decided_by)
+    REFERENCES principals (principal_id)
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
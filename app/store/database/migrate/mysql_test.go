@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	appdatabase "github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TestMySQL migrates an empty mysql database and verifies the mysql specific upsert of the job store.
+// The test is skipped unless GITNESS_TEST_MYSQL_DATASOURCE points to an empty database.
+func TestMySQL(t *testing.T) {
+	datasource := os.Getenv("GITNESS_TEST_MYSQL_DATASOURCE")
+	if datasource == "" {
+		t.Skip("GITNESS_TEST_MYSQL_DATASOURCE not set")
+	}
+
+	ctx := context.Background()
+
+	db, err := database.ConnectAndMigrate(ctx, database.MySQLDriverName, datasource, migrate.Migrate)
+	if err != nil {
+		t.Fatalf("failed to connect and migrate: %s", err)
+	}
+	defer db.Close()
+
+	version, err := migrate.Current(ctx, db)
+	if err != nil {
+		t.Fatalf("failed to get current version: %s", err)
+	}
+	if version == "" {
+		t.Fatalf("expected database to be migrated")
+	}
+
+	jobStore := appdatabase.NewJobStore(db)
+
+	job := &types.Job{
+		UID:           "mysql-test",
+		Type:          "test",
+		Priority:      enum.JobPriorityNormal,
+		State:         enum.JobStateScheduled,
+		IsRecurring:   true,
+		RecurringCron: "* * * * *",
+		GroupID:       "mysql-test",
+	}
+
+	if err = jobStore.Upsert(ctx, job); err != nil {
+		t.Fatalf("failed to insert job: %s", err)
+	}
+
+	job.RecurringCron = "0 * * * *"
+	if err = jobStore.Upsert(ctx, job); err != nil {
+		t.Fatalf("failed to update job: %s", err)
+	}
+
+	found, err := jobStore.Find(ctx, job.UID)
+	if err != nil {
+		t.Fatalf("failed to find job: %s", err)
+	}
+	if found.RecurringCron != job.RecurringCron {
+		t.Errorf("want recurring cron %q, got %q", job.RecurringCron, found.RecurringCron)
+	}
+
+	if _, err = jobStore.DeleteByGroupID(ctx, job.GroupID); err != nil {
+		t.Fatalf("failed to delete job: %s", err)
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE secrets ADD COLUMN secret_inheritable BOOLEAN NOT NULL DEFAULT false;
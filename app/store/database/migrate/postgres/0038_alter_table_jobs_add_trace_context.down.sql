@@ -0,0 +1 @@
+ALTER TABLE jobs DROP COLUMN job_trace_context;
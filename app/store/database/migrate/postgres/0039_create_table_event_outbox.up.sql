@@ -0,0 +1,13 @@
+CREATE TABLE event_outbox (
+ event_outbox_id SERIAL PRIMARY KEY
+,event_outbox_stream_id TEXT NOT NULL
+,event_outbox_payload BYTEA NOT NULL
+,event_outbox_created BIGINT NOT NULL
+,event_outbox_attempts INTEGER NOT NULL DEFAULT 0
+,event_outbox_next_attempt BIGINT NOT NULL
+,event_outbox_last_error TEXT NOT NULL DEFAULT ''
+);
+
+-- this index is used by the dispatcher to find the messages that are ready for delivery
+CREATE INDEX event_outbox_next_attempt
+    ON event_outbox(event_outbox_next_attempt);
@@ -0,0 +1 @@
+DROP TABLE event_dead_letters;
@@ -0,0 +1,14 @@
+CREATE TABLE event_dead_letters (
+ event_dead_letter_id SERIAL PRIMARY KEY
+,event_dead_letter_stream_id TEXT NOT NULL
+,event_dead_letter_group_name TEXT NOT NULL
+,event_dead_letter_message_id TEXT NOT NULL
+,event_dead_letter_payload BYTEA NOT NULL
+,event_dead_letter_retries INTEGER NOT NULL DEFAULT 0
+,event_dead_letter_last_error TEXT NOT NULL DEFAULT ''
+,event_dead_letter_created BIGINT NOT NULL
+);
+
+-- this index is used to list the dead letters of a stream and consumer group
+CREATE INDEX event_dead_letters_stream_id_group_name
+    ON event_dead_letters(event_dead_letter_stream_id, event_dead_letter_group_name);
@@ -0,0 +1,10 @@
+DROP INDEX spaces_deleted;
+DELETE FROM spaces WHERE space_deleted IS NOT NULL;
+ALTER TABLE spaces DROP COLUMN space_deleted;
+
+DROP INDEX repositories_deleted;
+DROP INDEX repositories_parent_id_uid;
+DELETE FROM repositories WHERE repo_deleted IS NOT NULL;
+ALTER TABLE repositories DROP COLUMN repo_deleted;
+
+CREATE UNIQUE INDEX repositories_parent_id_uid ON repositories(repo_parent_id, LOWER(repo_uid));
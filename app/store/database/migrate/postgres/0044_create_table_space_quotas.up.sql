@@ -0,0 +1,12 @@
+CREATE TABLE space_quotas (
+ space_quota_space_id INTEGER PRIMARY KEY
+,space_quota_max_repos BIGINT
+,space_quota_max_size BIGINT
+,space_quota_created_by INTEGER NOT NULL
+,space_quota_created BIGINT NOT NULL
+,space_quota_updated BIGINT NOT NULL
+,CONSTRAINT fk_space_quota_space_id FOREIGN KEY (space_quota_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
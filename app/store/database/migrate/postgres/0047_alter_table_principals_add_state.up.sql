@@ -0,0 +1,2 @@
+ALTER TABLE principals ADD COLUMN principal_suspended_until BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE principals ADD COLUMN principal_password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
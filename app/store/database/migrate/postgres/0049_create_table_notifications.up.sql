@@ -0,0 +1,28 @@
+CREATE TABLE notification_settings (
+ notification_settings_principal_id INTEGER PRIMARY KEY
+,notification_settings_delivery TEXT NOT NULL
+,notification_settings_review_requested BOOLEAN NOT NULL
+,notification_settings_comments BOOLEAN NOT NULL
+,notification_settings_merged BOOLEAN NOT NULL
+,notification_settings_pipeline_failed BOOLEAN NOT NULL
+,notification_settings_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_settings_principal_id FOREIGN KEY (notification_settings_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE notification_digest_items (
+ notification_digest_item_id SERIAL PRIMARY KEY
+,notification_digest_item_principal_id INTEGER NOT NULL
+,notification_digest_item_subject TEXT NOT NULL
+,notification_digest_item_body TEXT NOT NULL
+,notification_digest_item_created BIGINT NOT NULL
+,CONSTRAINT fk_notification_digest_item_principal_id FOREIGN KEY (notification_digest_item_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_principal_id
+ON notification_digest_items(notification_digest_item_principal_id);
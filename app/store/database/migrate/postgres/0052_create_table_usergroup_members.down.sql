@@ -0,0 +1,3 @@
+DROP TABLE usergroup_members;
+DROP INDEX principals_usergroup_space_id;
+ALTER TABLE principals DROP COLUMN principal_usergroup_space_id;
@@ -0,0 +1,2 @@
+DROP TABLE insights_contributors;
+DROP TABLE insights_days;
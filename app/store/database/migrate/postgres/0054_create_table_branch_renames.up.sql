@@ -0,0 +1,18 @@
+CREATE TABLE branch_renames (
+ branch_rename_repo_id INTEGER NOT NULL
+,branch_rename_old_name TEXT NOT NULL
+,branch_rename_new_name TEXT NOT NULL
+,branch_rename_created_by INTEGER NOT NULL
+,branch_rename_created BIGINT NOT NULL
+
+,CONSTRAINT pk_branch_renames PRIMARY KEY (branch_rename_repo_id, branch_rename_old_name)
+
+,CONSTRAINT fk_branch_rename_repo_id FOREIGN KEY (branch_rename_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_branch_rename_created_by FOREIGN KEY (branch_rename_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
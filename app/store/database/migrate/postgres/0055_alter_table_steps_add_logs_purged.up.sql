@@ -0,0 +1 @@
+ALTER TABLE steps ADD COLUMN step_logs_purged BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+DROP INDEX pullreq_activities_search;
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_search;
+
+DROP INDEX pullreqs_search;
+ALTER TABLE pullreqs DROP COLUMN pullreq_search;
@@ -0,0 +1,13 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_search TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', pullreq_title || ' ' || pullreq_description)) STORED;
+
+CREATE INDEX pullreqs_search
+    ON pullreqs USING GIN (pullreq_search);
+
+ALTER TABLE pullreq_activities
+    ADD COLUMN pullreq_activity_search TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', pullreq_activity_text)) STORED;
+
+CREATE INDEX pullreq_activities_search
+    ON pullreq_activities USING GIN (pullreq_activity_search);
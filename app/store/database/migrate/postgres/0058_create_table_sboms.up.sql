@@ -0,0 +1,16 @@
+CREATE TABLE sboms (
+sbom_id SERIAL PRIMARY KEY
+,sbom_repo_id INTEGER NOT NULL
+,sbom_tag TEXT NOT NULL
+,sbom_commit_sha TEXT NOT NULL
+,sbom_format TEXT NOT NULL
+,sbom_size BIGINT NOT NULL
+,sbom_created BIGINT NOT NULL
+,CONSTRAINT fk_sbom_repo_id FOREIGN KEY (sbom_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX sboms_repo_id_tag_format
+    ON sboms(sbom_repo_id, sbom_tag, sbom_format);
@@ -0,0 +1,32 @@
+CREATE TABLE attestations (
+attestation_id SERIAL PRIMARY KEY
+,attestation_repo_id INTEGER NOT NULL
+,attestation_commit_sha TEXT NOT NULL
+,attestation_execution_id INTEGER
+,attestation_predicate_type TEXT NOT NULL
+,attestation_envelope TEXT NOT NULL
+,attestation_status TEXT NOT NULL
+,attestation_key_name TEXT NOT NULL
+,attestation_status_message TEXT NOT NULL
+,attestation_created_by INTEGER NOT NULL
+,attestation_created BIGINT NOT NULL
+,attestation_verified BIGINT NOT NULL
+,CONSTRAINT fk_attestation_repo_id FOREIGN KEY (attestation_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_attestation_execution_id FOREIGN KEY (attestation_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+,CONSTRAINT fk_attestation_created_by FOREIGN KEY (attestation_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX attestations_repo_id_commit_sha
+    ON attestations(attestation_repo_id, attestation_commit_sha);
+
+CREATE INDEX attestations_execution_id
+    ON attestations(attestation_execution_id);
@@ -0,0 +1,18 @@
+CREATE TABLE file_locks (
+file_lock_id SERIAL PRIMARY KEY
+,file_lock_repo_id INTEGER NOT NULL
+,file_lock_path TEXT NOT NULL
+,file_lock_owner_id INTEGER NOT NULL
+,file_lock_created BIGINT NOT NULL
+,CONSTRAINT fk_file_lock_repo_id FOREIGN KEY (file_lock_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_file_lock_owner_id FOREIGN KEY (file_lock_owner_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX file_locks_repo_id_path
+    ON file_locks(file_lock_repo_id, file_lock_path);
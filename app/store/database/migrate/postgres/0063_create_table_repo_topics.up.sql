@@ -0,0 +1,13 @@
+CREATE TABLE repo_topics (
+repo_topic_repo_id INTEGER NOT NULL
+,repo_topic_topic TEXT NOT NULL
+,repo_topic_created BIGINT NOT NULL
+,CONSTRAINT pk_repo_topics PRIMARY KEY (repo_topic_repo_id, repo_topic_topic)
+,CONSTRAINT fk_repo_topic_repo_id FOREIGN KEY (repo_topic_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_topics_topic
+    ON repo_topics(repo_topic_topic);
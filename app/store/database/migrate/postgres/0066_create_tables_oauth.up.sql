@@ -0,0 +1,55 @@
+CREATE TABLE oauth_apps (
+ oauth_app_id SERIAL PRIMARY KEY
+,oauth_app_client_id TEXT NOT NULL
+,oauth_app_name TEXT NOT NULL
+,oauth_app_description TEXT NOT NULL
+,oauth_app_homepage_url TEXT NOT NULL
+,oauth_app_client_secret TEXT NOT NULL
+,oauth_app_redirect_uris TEXT NOT NULL
+,oauth_app_created_by INTEGER NOT NULL
+,oauth_app_created BIGINT NOT NULL
+,oauth_app_updated BIGINT NOT NULL
+,CONSTRAINT fk_oauth_app_created_by FOREIGN KEY (oauth_app_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oauth_apps_client_id
+    ON oauth_apps(oauth_app_client_id);
+
+CREATE TABLE oauth_authorizations (
+ oauth_authorization_id SERIAL PRIMARY KEY
+,oauth_authorization_app_id INTEGER NOT NULL
+,oauth_authorization_principal_id INTEGER NOT NULL
+,oauth_authorization_scopes TEXT NOT NULL
+,oauth_authorization_created BIGINT NOT NULL
+,oauth_authorization_updated BIGINT NOT NULL
+,CONSTRAINT fk_oauth_authorization_app_id FOREIGN KEY (oauth_authorization_app_id)
+    REFERENCES oauth_apps (oauth_app_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_oauth_authorization_principal_id FOREIGN KEY (oauth_authorization_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oauth_authorizations_app_id_principal_id
+    ON oauth_authorizations(oauth_authorization_app_id, oauth_authorization_principal_id);
+
+CREATE TABLE oauth_codes (
+ oauth_code_hash TEXT PRIMARY KEY
+,oauth_code_authorization_id INTEGER NOT NULL
+,oauth_code_redirect_uri TEXT NOT NULL
+,oauth_code_scopes TEXT NOT NULL
+,oauth_code_challenge TEXT NOT NULL
+,oauth_code_challenge_method TEXT NOT NULL
+,oauth_code_nonce TEXT NOT NULL
+,oauth_code_expires BIGINT NOT NULL
+,oauth_code_created BIGINT NOT NULL
+,CONSTRAINT fk_oauth_code_authorization_id FOREIGN KEY (oauth_code_authorization_id)
+    REFERENCES oauth_authorizations (oauth_authorization_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
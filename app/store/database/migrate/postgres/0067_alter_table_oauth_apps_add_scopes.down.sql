@@ -0,0 +1 @@
+ALTER TABLE oauth_apps DROP COLUMN oauth_app_scopes;
@@ -0,0 +1,22 @@
+CREATE TABLE upload_quarantines (
+ upload_quarantine_id SERIAL PRIMARY KEY
+,upload_quarantine_sha256 TEXT NOT NULL
+,upload_quarantine_source TEXT NOT NULL
+,upload_quarantine_repo_id INTEGER NOT NULL DEFAULT 0
+,upload_quarantine_name TEXT NOT NULL
+,upload_quarantine_size BIGINT NOT NULL
+,upload_quarantine_scanner TEXT NOT NULL
+,upload_quarantine_signature TEXT NOT NULL
+,upload_quarantine_status TEXT NOT NULL
+,upload_quarantine_uploaded_by INTEGER NOT NULL
+,upload_quarantine_released_by INTEGER
+,upload_quarantine_created BIGINT NOT NULL
+,upload_quarantine_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX upload_quarantines_sha256
+    ON upload_quarantines(upload_quarantine_sha256);
+
+-- this index is used to list the quarantined uploads by status
+CREATE INDEX upload_quarantines_status
+    ON upload_quarantines(upload_quarantine_status);
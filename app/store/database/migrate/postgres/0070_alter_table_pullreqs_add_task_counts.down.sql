@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_task_completed_count;
+ALTER TABLE pullreqs DROP COLUMN pullreq_task_count;
@@ -0,0 +1,33 @@
+CREATE TABLE pullreq_pushes (
+ pullreq_push_pullreq_id INTEGER NOT NULL
+,pullreq_push_number INTEGER NOT NULL
+,pullreq_push_source_sha TEXT NOT NULL
+,pullreq_push_merge_base_sha TEXT NOT NULL
+,pullreq_push_forced BOOLEAN NOT NULL
+,pullreq_push_created_by INTEGER NOT NULL
+,pullreq_push_created BIGINT NOT NULL
+,CONSTRAINT pk_pullreq_pushes PRIMARY KEY (pullreq_push_pullreq_id, pullreq_push_number)
+,CONSTRAINT fk_pullreq_push_pullreq_id FOREIGN KEY (pullreq_push_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+INSERT INTO pullreq_pushes (
+ pullreq_push_pullreq_id
+,pullreq_push_number
+,pullreq_push_source_sha
+,pullreq_push_merge_base_sha
+,pullreq_push_forced
+,pullreq_push_created_by
+,pullreq_push_created
+)
+SELECT
+ pullreq_id
+,1
+,pullreq_source_sha
+,pullreq_merge_base_sha
+,FALSE
+,pullreq_created_by
+,pullreq_created
+FROM pullreqs;
@@ -0,0 +1 @@
+ALTER TABLE pullreq_reviewers DROP COLUMN pullreq_reviewer_reminded;
@@ -0,0 +1,17 @@
+CREATE TABLE backups (
+ backup_id SERIAL PRIMARY KEY
+,backup_repo_id INTEGER NOT NULL
+,backup_repo_path TEXT NOT NULL
+,backup_state TEXT NOT NULL
+,backup_trigger TEXT NOT NULL
+,backup_bundle_size BIGINT NOT NULL DEFAULT 0
+,backup_metadata_size BIGINT NOT NULL DEFAULT 0
+,backup_error TEXT NOT NULL DEFAULT ''
+,backup_created_by INTEGER NOT NULL
+,backup_created BIGINT NOT NULL
+,backup_updated BIGINT NOT NULL
+);
+
+-- backups are kept after the repository is deleted, so there's no foreign key to repositories.
+CREATE INDEX backups_repo_id
+    ON backups(backup_repo_id, backup_id);
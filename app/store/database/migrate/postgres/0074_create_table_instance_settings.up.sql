@@ -0,0 +1,18 @@
+CREATE TABLE instance_settings (
+ instance_setting_key TEXT PRIMARY KEY
+,instance_setting_value TEXT NOT NULL
+,instance_setting_updated_by INTEGER NOT NULL
+,instance_setting_updated BIGINT NOT NULL
+);
+
+CREATE TABLE instance_setting_changes (
+ instance_setting_change_id SERIAL PRIMARY KEY
+,instance_setting_change_key TEXT NOT NULL
+,instance_setting_change_old_value TEXT
+,instance_setting_change_new_value TEXT
+,instance_setting_change_changed_by INTEGER NOT NULL
+,instance_setting_change_changed BIGINT NOT NULL
+);
+
+CREATE INDEX instance_setting_changes_changed
+    ON instance_setting_changes(instance_setting_change_changed);
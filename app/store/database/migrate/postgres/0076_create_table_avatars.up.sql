@@ -0,0 +1,22 @@
+CREATE TABLE avatars (
+ avatar_id SERIAL PRIMARY KEY
+,avatar_principal_id INTEGER
+,avatar_space_id INTEGER
+,avatar_sha256 TEXT NOT NULL
+,avatar_created_by INTEGER NOT NULL
+,avatar_updated BIGINT NOT NULL
+,CONSTRAINT fk_avatar_principal_id FOREIGN KEY (avatar_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_avatar_space_id FOREIGN KEY (avatar_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX avatars_principal_id
+    ON avatars(avatar_principal_id);
+
+CREATE UNIQUE INDEX avatars_space_id
+    ON avatars(avatar_space_id);
@@ -0,0 +1,3 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_locked_by INTEGER;
+ALTER TABLE pullreqs ADD COLUMN pullreq_locked BIGINT;
+ALTER TABLE pullreqs ADD COLUMN pullreq_lock_reason TEXT;
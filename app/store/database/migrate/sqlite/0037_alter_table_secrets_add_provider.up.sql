@@ -0,0 +1 @@
+ALTER TABLE secrets ADD COLUMN secret_provider TEXT NOT NULL DEFAULT 'gitness';
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN job_trace_context TEXT NOT NULL DEFAULT '';
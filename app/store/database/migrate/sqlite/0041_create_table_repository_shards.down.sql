@@ -0,0 +1 @@
+DROP TABLE repository_shards;
@@ -0,0 +1,9 @@
+CREATE TABLE repository_shards (
+ repository_shard_git_uid TEXT PRIMARY KEY
+,repository_shard_name TEXT NOT NULL
+,repository_shard_created BIGINT NOT NULL
+);
+
+-- this index is used to count the repositories stored on each shard
+CREATE INDEX repository_shards_name
+    ON repository_shards(repository_shard_name);
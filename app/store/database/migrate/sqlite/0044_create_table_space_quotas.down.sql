@@ -0,0 +1 @@
+DROP TABLE space_quotas;
@@ -0,0 +1,27 @@
+CREATE TABLE announcements (
+ announcement_id INTEGER PRIMARY KEY AUTOINCREMENT
+,announcement_message TEXT NOT NULL
+,announcement_severity TEXT NOT NULL
+,announcement_starts BIGINT NOT NULL DEFAULT 0
+,announcement_ends BIGINT NOT NULL DEFAULT 0
+,announcement_dismissible BOOLEAN NOT NULL DEFAULT TRUE
+,announcement_created_by INTEGER NOT NULL
+,announcement_created BIGINT NOT NULL
+,announcement_updated BIGINT NOT NULL
+);
+
+CREATE TABLE announcement_dismissals (
+ announcement_dismissal_announcement_id INTEGER NOT NULL
+,announcement_dismissal_principal_id INTEGER NOT NULL
+,announcement_dismissal_created BIGINT NOT NULL
+,CONSTRAINT pk_announcement_dismissals PRIMARY KEY (announcement_dismissal_announcement_id,
+    announcement_dismissal_principal_id)
+,CONSTRAINT fk_announcement_dismissal_announcement_id FOREIGN KEY (announcement_dismissal_announcement_id)
+    REFERENCES announcements (announcement_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_announcement_dismissal_principal_id FOREIGN KEY (announcement_dismissal_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE principals DROP COLUMN principal_password_reset_required;
+ALTER TABLE principals DROP COLUMN principal_suspended_until;
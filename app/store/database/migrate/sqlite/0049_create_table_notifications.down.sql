@@ -0,0 +1,2 @@
+DROP TABLE notification_digest_items;
+DROP TABLE notification_settings;
@@ -0,0 +1,32 @@
+CREATE TABLE chat_integrations (
+chat_integration_id INTEGER PRIMARY KEY AUTOINCREMENT
+,chat_integration_version INTEGER NOT NULL DEFAULT 0
+,chat_integration_space_id INTEGER
+,chat_integration_repo_id INTEGER
+,chat_integration_created_by INTEGER NOT NULL
+,chat_integration_created BIGINT NOT NULL
+,chat_integration_updated BIGINT NOT NULL
+,chat_integration_display_name TEXT NOT NULL
+,chat_integration_provider TEXT NOT NULL
+,chat_integration_url TEXT NOT NULL
+,chat_integration_enabled BOOLEAN NOT NULL
+,chat_integration_triggers TEXT NOT NULL
+,CONSTRAINT fk_chat_integration_created_by FOREIGN KEY (chat_integration_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_chat_integration_space_id FOREIGN KEY (chat_integration_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_chat_integration_repo_id FOREIGN KEY (chat_integration_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX chat_integrations_space_id
+    ON chat_integrations(chat_integration_space_id);
+
+CREATE INDEX chat_integrations_repo_id
+    ON chat_integrations(chat_integration_repo_id);
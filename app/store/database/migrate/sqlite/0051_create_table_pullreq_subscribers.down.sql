@@ -0,0 +1 @@
+DROP TABLE pullreq_subscribers;
@@ -0,0 +1,19 @@
+CREATE TABLE pullreq_subscribers (
+ pullreq_subscriber_pullreq_id INTEGER NOT NULL
+,pullreq_subscriber_principal_id INTEGER NOT NULL
+,pullreq_subscriber_subscribed BOOLEAN NOT NULL
+,pullreq_subscriber_created BIGINT NOT NULL
+,pullreq_subscriber_updated BIGINT NOT NULL
+
+-- explicit unsubscriptions are kept to prevent mentions from subscribing the user again
+,CONSTRAINT pk_pullreq_subscribers PRIMARY KEY (pullreq_subscriber_pullreq_id, pullreq_subscriber_principal_id)
+
+,CONSTRAINT fk_pullreq_subscriber_pullreq_id FOREIGN KEY (pullreq_subscriber_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_subscriber_principal_id FOREIGN KEY (pullreq_subscriber_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
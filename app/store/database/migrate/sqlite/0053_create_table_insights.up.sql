@@ -0,0 +1,33 @@
+CREATE TABLE insights_days (
+ insights_day_repo_id INTEGER NOT NULL
+,insights_day_day BIGINT NOT NULL
+,insights_day_commits INTEGER NOT NULL
+,insights_day_pullreqs_opened INTEGER NOT NULL
+,insights_day_pullreqs_merged INTEGER NOT NULL
+,insights_day_merge_time_total BIGINT NOT NULL
+,insights_day_pullreqs_reviewed INTEGER NOT NULL
+,insights_day_review_time_total BIGINT NOT NULL
+
+,CONSTRAINT pk_insights_days PRIMARY KEY (insights_day_repo_id, insights_day_day)
+
+,CONSTRAINT fk_insights_day_repo_id FOREIGN KEY (insights_day_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE insights_contributors (
+ insights_contributor_repo_id INTEGER NOT NULL
+,insights_contributor_day BIGINT NOT NULL
+,insights_contributor_email TEXT NOT NULL
+,insights_contributor_name TEXT NOT NULL
+,insights_contributor_commits INTEGER NOT NULL
+
+,CONSTRAINT pk_insights_contributors
+    PRIMARY KEY (insights_contributor_repo_id, insights_contributor_day, insights_contributor_email)
+
+,CONSTRAINT fk_insights_contributor_repo_id FOREIGN KEY (insights_contributor_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE branch_renames;
@@ -0,0 +1 @@
+ALTER TABLE steps DROP COLUMN step_logs_purged;
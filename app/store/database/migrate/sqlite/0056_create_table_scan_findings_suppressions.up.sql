@@ -0,0 +1,45 @@
+CREATE TABLE scan_findings (
+scan_finding_id INTEGER PRIMARY KEY AUTOINCREMENT
+,scan_finding_repo_id INTEGER NOT NULL
+,scan_finding_commit_sha TEXT NOT NULL
+,scan_finding_scanner TEXT NOT NULL
+,scan_finding_kind TEXT NOT NULL
+,scan_finding_vulnerability_id TEXT NOT NULL
+,scan_finding_package TEXT NOT NULL
+,scan_finding_version TEXT NOT NULL
+,scan_finding_fixed_version TEXT NOT NULL
+,scan_finding_severity TEXT NOT NULL
+,scan_finding_title TEXT NOT NULL
+,scan_finding_path TEXT NOT NULL
+,scan_finding_url TEXT NOT NULL
+,scan_finding_created BIGINT NOT NULL
+,CONSTRAINT fk_scan_finding_repo_id FOREIGN KEY (scan_finding_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX scan_findings_repo_id_commit_sha_scanner
+    ON scan_findings(scan_finding_repo_id, scan_finding_commit_sha, scan_finding_scanner);
+
+CREATE TABLE scan_suppressions (
+scan_suppression_id INTEGER PRIMARY KEY AUTOINCREMENT
+,scan_suppression_repo_id INTEGER NOT NULL
+,scan_suppression_vulnerability_id TEXT NOT NULL
+,scan_suppression_package TEXT NOT NULL
+,scan_suppression_reason TEXT NOT NULL
+,scan_suppression_created_by INTEGER NOT NULL
+,scan_suppression_created BIGINT NOT NULL
+,scan_suppression_expires BIGINT NOT NULL
+,CONSTRAINT fk_scan_suppression_repo_id FOREIGN KEY (scan_suppression_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_scan_suppression_created_by FOREIGN KEY (scan_suppression_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX scan_suppressions_repo_id
+    ON scan_suppressions(scan_suppression_repo_id);
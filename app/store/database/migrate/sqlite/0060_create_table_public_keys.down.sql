@@ -0,0 +1,2 @@
+DROP TABLE commit_verifications;
+DROP TABLE public_keys;
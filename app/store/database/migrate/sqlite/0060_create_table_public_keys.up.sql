@@ -0,0 +1,41 @@
+CREATE TABLE public_keys (
+public_key_id INTEGER PRIMARY KEY AUTOINCREMENT
+,public_key_principal_id INTEGER NOT NULL
+,public_key_identifier TEXT NOT NULL
+,public_key_scheme TEXT NOT NULL
+,public_key_fingerprint TEXT NOT NULL
+,public_key_content TEXT NOT NULL
+,public_key_created BIGINT NOT NULL
+,CONSTRAINT fk_public_key_principal_id FOREIGN KEY (public_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX public_keys_fingerprint
+    ON public_keys(public_key_fingerprint);
+
+CREATE INDEX public_keys_principal_id
+    ON public_keys(public_key_principal_id);
+
+CREATE TABLE commit_verifications (
+commit_verification_repo_id INTEGER NOT NULL
+,commit_verification_sha TEXT NOT NULL
+,commit_verification_status TEXT NOT NULL
+,commit_verification_scheme TEXT NOT NULL
+,commit_verification_key_id TEXT NOT NULL
+,commit_verification_signer_id INTEGER
+,commit_verification_created BIGINT NOT NULL
+,CONSTRAINT pk_commit_verifications PRIMARY KEY (commit_verification_repo_id, commit_verification_sha)
+,CONSTRAINT fk_commit_verification_repo_id FOREIGN KEY (commit_verification_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_commit_verification_signer_id FOREIGN KEY (commit_verification_signer_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX commit_verifications_key_id
+    ON commit_verifications(commit_verification_key_id);
@@ -0,0 +1 @@
+DROP TABLE commit_author_rewrites;
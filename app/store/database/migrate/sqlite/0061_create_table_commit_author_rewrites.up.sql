@@ -0,0 +1,19 @@
+CREATE TABLE commit_author_rewrites (
+commit_author_rewrite_id INTEGER PRIMARY KEY AUTOINCREMENT
+,commit_author_rewrite_repo_id INTEGER NOT NULL
+,commit_author_rewrite_sha TEXT NOT NULL
+,commit_author_rewrite_operation TEXT NOT NULL
+,commit_author_rewrite_principal_id INTEGER NOT NULL
+,commit_author_rewrite_requested_name TEXT NOT NULL
+,commit_author_rewrite_requested_email TEXT NOT NULL
+,commit_author_rewrite_author_name TEXT NOT NULL
+,commit_author_rewrite_author_email TEXT NOT NULL
+,commit_author_rewrite_created BIGINT NOT NULL
+,CONSTRAINT fk_commit_author_rewrite_repo_id FOREIGN KEY (commit_author_rewrite_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX commit_author_rewrites_repo_id_sha
+    ON commit_author_rewrites(commit_author_rewrite_repo_id, commit_author_rewrite_sha);
@@ -0,0 +1,41 @@
+CREATE TABLE repo_stars (
+repo_star_repo_id INTEGER NOT NULL
+,repo_star_principal_id INTEGER NOT NULL
+,repo_star_created BIGINT NOT NULL
+,CONSTRAINT pk_repo_stars PRIMARY KEY (repo_star_principal_id, repo_star_repo_id)
+,CONSTRAINT fk_repo_star_repo_id FOREIGN KEY (repo_star_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_star_principal_id FOREIGN KEY (repo_star_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_stars_repo_id
+    ON repo_stars(repo_star_repo_id);
+
+CREATE TABLE feed_events (
+feed_event_id INTEGER PRIMARY KEY AUTOINCREMENT
+,feed_event_repo_id INTEGER NOT NULL
+,feed_event_principal_id INTEGER NOT NULL
+,feed_event_type TEXT NOT NULL
+,feed_event_ref TEXT NOT NULL
+,feed_event_sha TEXT NOT NULL
+,feed_event_pullreq_number INTEGER NOT NULL
+,feed_event_created BIGINT NOT NULL
+,CONSTRAINT fk_feed_event_repo_id FOREIGN KEY (feed_event_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX feed_events_repo_id_id
+    ON feed_events(feed_event_repo_id, feed_event_id);
+
+CREATE INDEX feed_events_principal_id
+    ON feed_events(feed_event_principal_id);
+
+CREATE INDEX feed_events_created
+    ON feed_events(feed_event_created);
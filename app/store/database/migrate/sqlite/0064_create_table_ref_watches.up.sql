@@ -0,0 +1,19 @@
+CREATE TABLE ref_watches (
+ref_watch_id INTEGER PRIMARY KEY AUTOINCREMENT
+,ref_watch_repo_id INTEGER NOT NULL
+,ref_watch_principal_id INTEGER NOT NULL
+,ref_watch_type TEXT NOT NULL
+,ref_watch_pattern TEXT NOT NULL
+,ref_watch_created BIGINT NOT NULL
+,CONSTRAINT fk_ref_watch_repo_id FOREIGN KEY (ref_watch_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_ref_watch_principal_id FOREIGN KEY (ref_watch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ref_watches_repo_id_principal_id_type_pattern
+    ON ref_watches(ref_watch_repo_id, ref_watch_principal_id, ref_watch_type, ref_watch_pattern);
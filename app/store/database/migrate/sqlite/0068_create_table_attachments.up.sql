@@ -0,0 +1,16 @@
+CREATE TABLE attachments (
+ attachment_id INTEGER PRIMARY KEY AUTOINCREMENT
+,attachment_repo_id INTEGER NOT NULL
+,attachment_sha256 TEXT NOT NULL
+,attachment_content_type TEXT NOT NULL
+,attachment_size BIGINT NOT NULL
+,attachment_created_by INTEGER NOT NULL
+,attachment_created BIGINT NOT NULL
+,CONSTRAINT fk_attachment_repo_id FOREIGN KEY (attachment_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX attachments_repo_id_sha256
+    ON attachments(attachment_repo_id, attachment_sha256);
@@ -0,0 +1,16 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_task_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pullreqs ADD COLUMN pullreq_task_completed_count INTEGER NOT NULL DEFAULT 0;
+
+-- approximates the tasks of existing pull requests, the counts are exact once the description gets updated.
+UPDATE pullreqs
+SET
+     pullreq_task_completed_count = (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '- [x]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '- [X]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '* [x]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '* [X]', ''))) / 5
+    ,pullreq_task_count = (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '- [x]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '- [X]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '* [x]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '* [X]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '- [ ]', ''))) / 5
+        + (LENGTH(pullreq_description) - LENGTH(REPLACE(pullreq_description, '* [ ]', ''))) / 5;
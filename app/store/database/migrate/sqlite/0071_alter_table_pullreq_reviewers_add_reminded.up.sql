@@ -0,0 +1 @@
+ALTER TABLE pullreq_reviewers ADD COLUMN pullreq_reviewer_reminded BIGINT NOT NULL DEFAULT 0;
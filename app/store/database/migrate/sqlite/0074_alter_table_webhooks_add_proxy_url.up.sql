@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_proxy_url TEXT NOT NULL DEFAULT '';
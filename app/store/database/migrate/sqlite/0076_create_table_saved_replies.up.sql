@@ -0,0 +1,15 @@
+CREATE TABLE saved_replies (
+saved_reply_id INTEGER PRIMARY KEY AUTOINCREMENT
+,saved_reply_principal_id INTEGER NOT NULL
+,saved_reply_identifier TEXT NOT NULL
+,saved_reply_content TEXT NOT NULL
+,saved_reply_created BIGINT NOT NULL
+,saved_reply_updated BIGINT NOT NULL
+,CONSTRAINT fk_saved_reply_principal_id FOREIGN KEY (saved_reply_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX saved_replies_principal_id_identifier
+    ON saved_replies(saved_reply_principal_id, LOWER(saved_reply_identifier));
@@ -0,0 +1,6 @@
+ALTER TABLE memberships ADD COLUMN membership_expires BIGINT;
+ALTER TABLE memberships ADD COLUMN membership_expiry_notified BIGINT;
+
+CREATE INDEX memberships_expires
+    ON memberships(membership_expires)
+    WHERE membership_expires IS NOT NULL;
@@ -0,0 +1,37 @@
+CREATE TABLE access_requests (
+access_request_id INTEGER PRIMARY KEY AUTOINCREMENT
+,access_request_space_id INTEGER NOT NULL
+,access_request_repo_id INTEGER
+,access_request_principal_id INTEGER NOT NULL
+,access_request_role TEXT NOT NULL
+,access_request_message TEXT NOT NULL
+,access_request_state TEXT NOT NULL
+,access_request_created BIGINT NOT NULL
+,access_request_updated BIGINT NOT NULL
+,access_request_decided_by INTEGER
+,access_request_decided BIGINT
+,access_request_decision_reason TEXT NOT NULL
+,CONSTRAINT fk_access_request_space_id FOREIGN KEY (access_request_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_access_request_repo_id FOREIGN KEY (access_request_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+,CONSTRAINT fk_access_request_principal_id FOREIGN KEY (access_request_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_access_request_decided_by FOREIGN KEY (access_request_decided_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE INDEX access_requests_space_id_state
+    ON access_requests(access_request_space_id, access_request_state);
+
+CREATE UNIQUE INDEX access_requests_space_id_principal_id_pending
+    ON access_requests(access_request_space_id, access_request_principal_id)
+    WHERE access_request_state = 'pending';
@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/maragudk/migrate"
+)
+
+const (
+	// contractMarker marks contract migrations, e.g. "0060_contract_drop_column_x.up.sql".
+	// Contract migrations remove schema elements still used by older versions and must only be applied
+	// after all replicas are running the new version. All other migrations are considered expand migrations.
+	contractMarker = "_contract_"
+
+	// estimatedDurationBase is the estimated duration of a migration not touching any existing rows.
+	estimatedDurationBase = 50 * time.Millisecond
+	// estimatedDurationPerRow is the estimated duration per row of an affected table.
+	estimatedDurationPerRow = 20 * time.Microsecond
+)
+
+var (
+	upFileMatcher = regexp.MustCompile(`^([\w-]+)\.up\.sql$`)
+	// tableMatcher matches the tables that are rewritten or scanned by a migration statement.
+	tableMatcher = regexp.MustCompile(
+		`(?i)(?:ALTER\s+TABLE|UPDATE|DELETE\s+FROM|INSERT\s+INTO|CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?\w+\s+ON)\s+(\w+)`)
+)
+
+// Expand performs all migrations up to the first contract migration.
+// It allows to upgrade the database while replicas of the previous version are still running.
+func Expand(ctx context.Context, db *sqlx.DB) error {
+	opts, err := getMigrator(db)
+	if err != nil {
+		return fmt.Errorf("failed to get migrator: %w", err)
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	versions, err := listVersions(opts.FS)
+	if err != nil {
+		return err
+	}
+
+	target := current
+	for _, version := range versions {
+		if version <= current {
+			continue
+		}
+		if phaseOf(version) == types.MigrationPhaseContract {
+			break
+		}
+		target = version
+	}
+
+	if target == current {
+		return nil
+	}
+
+	return migrate.New(opts).MigrateTo(ctx, target)
+}
+
+// Status returns the pending migrations of the database with their estimated duration.
+func Status(ctx context.Context, db *sqlx.DB) (*types.MigrationStatus, error) {
+	opts, err := getMigrator(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrator: %w", err)
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := listVersions(opts.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.MigrationStatus{
+		Current: current,
+		Pending: []types.PendingMigration{},
+	}
+	if len(versions) > 0 {
+		status.Latest = versions[len(versions)-1]
+	}
+
+	rowCounts := map[string]int64{}
+	for _, version := range versions {
+		if version <= current {
+			continue
+		}
+
+		script, err := fs.ReadFile(opts.FS, version+".up.sql")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration '%s': %w", version, err)
+		}
+
+		pending := types.PendingMigration{
+			Version: version,
+			Phase:   phaseOf(version),
+			Tables:  []string{},
+		}
+
+		estimate := estimatedDurationBase
+		for _, table := range affectedTables(string(script)) {
+			count, ok := rowCounts[table]
+			if !ok {
+				count, err = countRows(ctx, db, table)
+				if err != nil {
+					return nil, err
+				}
+				rowCounts[table] = count
+			}
+
+			// tables created by earlier pending migrations don't exist yet
+			if count < 0 {
+				continue
+			}
+
+			pending.Tables = append(pending.Tables, table)
+			estimate += time.Duration(count) * estimatedDurationPerRow
+		}
+
+		pending.EstimatedDuration = estimate.Milliseconds()
+		status.EstimatedDuration += pending.EstimatedDuration
+		status.ContractPending = status.ContractPending || pending.Phase == types.MigrationPhaseContract
+		status.Pending = append(status.Pending, pending)
+	}
+
+	return status, nil
+}
+
+func phaseOf(version string) types.MigrationPhase {
+	if strings.Contains(version, contractMarker) {
+		return types.MigrationPhaseContract
+	}
+
+	return types.MigrationPhaseExpand
+}
+
+// listVersions returns the versions of all up migrations in the order they are applied.
+func listVersions(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		matches := upFileMatcher.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		versions = append(versions, matches[1])
+	}
+
+	sort.Strings(versions)
+
+	return versions, nil
+}
+
+// affectedTables returns the distinct tables that are modified by the migration script.
+func affectedTables(script string) []string {
+	var tables []string
+	seen := map[string]bool{}
+	for _, matches := range tableMatcher.FindAllStringSubmatch(script, -1) {
+		table := strings.ToLower(matches[1])
+		if seen[table] {
+			continue
+		}
+		seen[table] = true
+		tables = append(tables, table)
+	}
+
+	return tables
+}
+
+// countRows returns the number of rows of the table, or -1 if the table doesn't exist.
+func countRows(ctx context.Context, db *sqlx.DB, table string) (int64, error) {
+	exists, err := tableExists(ctx, db, table)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return -1, nil
+	}
+
+	var count int64
+	// table names are matched by tableMatcher and only contain word characters
+	if err = db.QueryRowContext(ctx, "SELECT count(*) FROM "+table).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rows of table '%s': %w", table, err)
+	}
+
+	return count, nil
+}
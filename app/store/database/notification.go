@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+const (
+	notificationSettingsSelectBase = `
+	SELECT
+		 notification_settings_principal_id
+		,notification_settings_delivery
+		,notification_settings_review_requested
+		,notification_settings_comments
+		,notification_settings_merged
+		,notification_settings_pipeline_failed
+		,notification_settings_updated
+	FROM notification_settings`
+
+	notificationDigestItemSelectBase = `
+	SELECT
+		 notification_digest_item_id
+		,notification_digest_item_principal_id
+		,notification_digest_item_subject
+		,notification_digest_item_body
+		,notification_digest_item_created
+	FROM notification_digest_items`
+)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(db *sqlx.DB) *NotificationStore {
+	return &NotificationStore{
+		db: db,
+	}
+}
+
+// NotificationStore implements a store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db *sqlx.DB
+}
+
+// FindSettings returns the notification settings of the principal.
+func (s *NotificationStore) FindSettings(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationSettings, error) {
+	const sqlQuery = notificationSettingsSelectBase + `
+		WHERE notification_settings_principal_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.NotificationSettings{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find notification settings")
+	}
+
+	return dst, nil
+}
+
+// UpsertSettings creates or updates the notification settings of a principal.
+func (s *NotificationStore) UpsertSettings(ctx context.Context, settings *types.NotificationSettings) error {
+	const sqlQueryInsert = `
+		INSERT INTO notification_settings (
+			 notification_settings_principal_id
+			,notification_settings_delivery
+			,notification_settings_review_requested
+			,notification_settings_comments
+			,notification_settings_merged
+			,notification_settings_pipeline_failed
+			,notification_settings_updated
+		) VALUES (
+			 :notification_settings_principal_id
+			,:notification_settings_delivery
+			,:notification_settings_review_requested
+			,:notification_settings_comments
+			,:notification_settings_merged
+			,:notification_settings_pipeline_failed
+			,:notification_settings_updated
+		)`
+
+	const sqlQueryUpdate = `
+			 notification_settings_delivery         = :notification_settings_delivery
+			,notification_settings_review_requested = :notification_settings_review_requested
+			,notification_settings_comments         = :notification_settings_comments
+			,notification_settings_merged           = :notification_settings_merged
+			,notification_settings_pipeline_failed  = :notification_settings_pipeline_failed
+			,notification_settings_updated          = :notification_settings_updated`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT (notification_settings_principal_id) DO UPDATE
+		SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, settings)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind notification settings object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// CreateDigestItem stores a notification for the next digest of the principal.
+func (s *NotificationStore) CreateDigestItem(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+		INSERT INTO notification_digest_items (
+			 notification_digest_item_principal_id
+			,notification_digest_item_subject
+			,notification_digest_item_body
+			,notification_digest_item_created
+		) VALUES (
+			 :notification_digest_item_principal_id
+			,:notification_digest_item_subject
+			,:notification_digest_item_body
+			,:notification_digest_item_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, item)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind notification digest item object")
+	}
+
+	if item.ID, err = database.InsertReturningID(ctx, db, query, "notification_digest_item_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// ListDigestItems returns pending digest notifications ordered by principal.
+func (s *NotificationStore) ListDigestItems(
+	ctx context.Context,
+	limit int,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = notificationDigestItemSelectBase + `
+		ORDER BY notification_digest_item_principal_id, notification_digest_item_id
+		LIMIT $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.NotificationDigestItem, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list notification digest items")
+	}
+
+	return dst, nil
+}
+
+// DeleteDigestItems removes the digest notifications with the provided ids.
+func (s *NotificationStore) DeleteDigestItems(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Delete("notification_digest_items").
+		Where(squirrel.Eq{"notification_digest_item_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete notification digest items query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete notification digest items")
+	}
+
+	return nil
+}
@@ -0,0 +1,734 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OAuthAppStore = (*OAuthAppStore)(nil)
+var _ store.OAuthAuthorizationStore = (*OAuthAuthorizationStore)(nil)
+var _ store.OAuthCodeStore = (*OAuthCodeStore)(nil)
+
+// redirectURIsSeparator defines the character that's used to join redirect uris for storing them in the DB.
+// ASSUMPTION: redirect uris are validated to not contain any whitespace.
+const redirectURIsSeparator = "\n"
+
+type oauthApp struct {
+	ID           int64  `db:"oauth_app_id"`
+	ClientID     string `db:"oauth_app_client_id"`
+	Name         string `db:"oauth_app_name"`
+	Description  string `db:"oauth_app_description"`
+	HomepageURL  string `db:"oauth_app_homepage_url"`
+	ClientSecret string `db:"oauth_app_client_secret"`
+	RedirectURIs string `db:"oauth_app_redirect_uris"`
+	Scopes       string `db:"oauth_app_scopes"`
+	CreatedBy    int64  `db:"oauth_app_created_by"`
+	Created      int64  `db:"oauth_app_created"`
+	Updated      int64  `db:"oauth_app_updated"`
+}
+
+const oauthAppColumns = `
+	 oauth_app_id
+	,oauth_app_client_id
+	,oauth_app_name
+	,oauth_app_description
+	,oauth_app_homepage_url
+	,oauth_app_client_secret
+	,oauth_app_redirect_uris
+	,oauth_app_scopes
+	,oauth_app_created_by
+	,oauth_app_created
+	,oauth_app_updated`
+
+// NewOAuthAppStore returns a new OAuthAppStore.
+func NewOAuthAppStore(db *sqlx.DB) *OAuthAppStore {
+	return &OAuthAppStore{
+		db: db,
+	}
+}
+
+// OAuthAppStore implements store.OAuthAppStore backed by a relational database.
+type OAuthAppStore struct {
+	db *sqlx.DB
+}
+
+// Find finds the OAuth app by id.
+func (s *OAuthAppStore) Find(ctx context.Context, id int64) (*types.OAuthApp, error) {
+	const sqlQuery = `
+		SELECT` + oauthAppColumns + `
+		FROM oauth_apps
+		WHERE oauth_app_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &oauthApp{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oauth app")
+	}
+
+	return mapToOAuthApp(dst), nil
+}
+
+// FindByClientID finds the OAuth app by its client id.
+func (s *OAuthAppStore) FindByClientID(ctx context.Context, clientID string) (*types.OAuthApp, error) {
+	const sqlQuery = `
+		SELECT` + oauthAppColumns + `
+		FROM oauth_apps
+		WHERE oauth_app_client_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &oauthApp{}
+	if err := db.GetContext(ctx, dst, sqlQuery, clientID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oauth app by client id")
+	}
+
+	return mapToOAuthApp(dst), nil
+}
+
+// Create creates a new OAuth app.
+func (s *OAuthAppStore) Create(ctx context.Context, app *types.OAuthApp) error {
+	const sqlQuery = `
+		INSERT INTO oauth_apps (
+			 oauth_app_client_id
+			,oauth_app_name
+			,oauth_app_description
+			,oauth_app_homepage_url
+			,oauth_app_client_secret
+			,oauth_app_redirect_uris
+			,oauth_app_scopes
+			,oauth_app_created_by
+			,oauth_app_created
+			,oauth_app_updated
+		) values (
+			 :oauth_app_client_id
+			,:oauth_app_name
+			,:oauth_app_description
+			,:oauth_app_homepage_url
+			,:oauth_app_client_secret
+			,:oauth_app_redirect_uris
+			,:oauth_app_scopes
+			,:oauth_app_created_by
+			,:oauth_app_created
+			,:oauth_app_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOAuthApp(app))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oauth app object")
+	}
+
+	if app.ID, err = database.InsertReturningID(ctx, db, query, "oauth_app_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing OAuth app.
+func (s *OAuthAppStore) Update(ctx context.Context, app *types.OAuthApp) error {
+	const sqlQuery = `
+		UPDATE oauth_apps
+		SET
+			 oauth_app_name = :oauth_app_name
+			,oauth_app_description = :oauth_app_description
+			,oauth_app_homepage_url = :oauth_app_homepage_url
+			,oauth_app_client_secret = :oauth_app_client_secret
+			,oauth_app_redirect_uris = :oauth_app_redirect_uris
+			,oauth_app_scopes = :oauth_app_scopes
+			,oauth_app_updated = :oauth_app_updated
+		WHERE oauth_app_id = :oauth_app_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOAuthApp(app))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oauth app object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update oauth app")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the OAuth app with the provided id.
+func (s *OAuthAppStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM oauth_apps
+		WHERE oauth_app_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete oauth app")
+	}
+
+	return nil
+}
+
+// List returns the OAuth apps created by the principal.
+func (s *OAuthAppStore) List(ctx context.Context, createdBy int64) ([]*types.OAuthApp, error) {
+	const sqlQuery = `
+		SELECT` + oauthAppColumns + `
+		FROM oauth_apps
+		WHERE oauth_app_created_by = $1
+		ORDER BY oauth_app_name`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*oauthApp, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, createdBy); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list oauth apps")
+	}
+
+	res := make([]*types.OAuthApp, len(dst))
+	for i := range dst {
+		res[i] = mapToOAuthApp(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToOAuthApp(app *oauthApp) *types.OAuthApp {
+	redirectURIs := []string{}
+	if app.RedirectURIs != "" {
+		redirectURIs = strings.Split(app.RedirectURIs, redirectURIsSeparator)
+	}
+
+	scopes, _ := enum.ParseOAuthScopes(app.Scopes)
+
+	return &types.OAuthApp{
+		ID:           app.ID,
+		ClientID:     app.ClientID,
+		Name:         app.Name,
+		Description:  app.Description,
+		HomepageURL:  app.HomepageURL,
+		ClientSecret: app.ClientSecret,
+		RedirectURIs: redirectURIs,
+		Scopes:       scopes,
+		CreatedBy:    app.CreatedBy,
+		Created:      app.Created,
+		Updated:      app.Updated,
+	}
+}
+
+func mapToInternalOAuthApp(app *types.OAuthApp) *oauthApp {
+	return &oauthApp{
+		ID:           app.ID,
+		ClientID:     app.ClientID,
+		Name:         app.Name,
+		Description:  app.Description,
+		HomepageURL:  app.HomepageURL,
+		ClientSecret: app.ClientSecret,
+		RedirectURIs: strings.Join(app.RedirectURIs, redirectURIsSeparator),
+		Scopes:       enum.FormatOAuthScopes(app.Scopes),
+		CreatedBy:    app.CreatedBy,
+		Created:      app.Created,
+		Updated:      app.Updated,
+	}
+}
+
+type oauthAuthorization struct {
+	ID          int64  `db:"oauth_authorization_id"`
+	AppID       int64  `db:"oauth_authorization_app_id"`
+	PrincipalID int64  `db:"oauth_authorization_principal_id"`
+	Scopes      string `db:"oauth_authorization_scopes"`
+	Created     int64  `db:"oauth_authorization_created"`
+	Updated     int64  `db:"oauth_authorization_updated"`
+}
+
+const oauthAuthorizationColumns = `
+	 oauth_authorization_id
+	,oauth_authorization_app_id
+	,oauth_authorization_principal_id
+	,oauth_authorization_scopes
+	,oauth_authorization_created
+	,oauth_authorization_updated`
+
+// NewOAuthAuthorizationStore returns a new OAuthAuthorizationStore.
+func NewOAuthAuthorizationStore(db *sqlx.DB) *OAuthAuthorizationStore {
+	return &OAuthAuthorizationStore{
+		db: db,
+	}
+}
+
+// OAuthAuthorizationStore implements store.OAuthAuthorizationStore backed by a relational database.
+type OAuthAuthorizationStore struct {
+	db *sqlx.DB
+}
+
+// Find finds the authorization by id.
+func (s *OAuthAuthorizationStore) Find(ctx context.Context, id int64) (*types.OAuthAuthorization, error) {
+	const sqlQuery = `
+		SELECT` + oauthAuthorizationColumns + `
+		FROM oauth_authorizations
+		WHERE oauth_authorization_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &oauthAuthorization{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oauth authorization")
+	}
+
+	return mapToOAuthAuthorization(dst), nil
+}
+
+// FindByAppAndPrincipal finds the authorization the principal gave to the app.
+func (s *OAuthAuthorizationStore) FindByAppAndPrincipal(
+	ctx context.Context,
+	appID int64,
+	principalID int64,
+) (*types.OAuthAuthorization, error) {
+	const sqlQuery = `
+		SELECT` + oauthAuthorizationColumns + `
+		FROM oauth_authorizations
+		WHERE oauth_authorization_app_id = $1 AND oauth_authorization_principal_id = $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &oauthAuthorization{}
+	if err := db.GetContext(ctx, dst, sqlQuery, appID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oauth authorization by app and principal")
+	}
+
+	return mapToOAuthAuthorization(dst), nil
+}
+
+// Create creates a new authorization.
+func (s *OAuthAuthorizationStore) Create(ctx context.Context, authorization *types.OAuthAuthorization) error {
+	const sqlQuery = `
+		INSERT INTO oauth_authorizations (
+			 oauth_authorization_app_id
+			,oauth_authorization_principal_id
+			,oauth_authorization_scopes
+			,oauth_authorization_created
+			,oauth_authorization_updated
+		) values (
+			 :oauth_authorization_app_id
+			,:oauth_authorization_principal_id
+			,:oauth_authorization_scopes
+			,:oauth_authorization_created
+			,:oauth_authorization_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOAuthAuthorization(authorization))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oauth authorization object")
+	}
+
+	if authorization.ID, err = database.InsertReturningID(ctx, db, query, "oauth_authorization_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateScopes updates the scopes of an existing authorization.
+func (s *OAuthAuthorizationStore) UpdateScopes(ctx context.Context, authorization *types.OAuthAuthorization) error {
+	const sqlQuery = `
+		UPDATE oauth_authorizations
+		SET
+			 oauth_authorization_scopes = :oauth_authorization_scopes
+			,oauth_authorization_updated = :oauth_authorization_updated
+		WHERE oauth_authorization_id = :oauth_authorization_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOAuthAuthorization(authorization))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oauth authorization object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update oauth authorization")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the authorization with the provided id.
+func (s *OAuthAuthorizationStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM oauth_authorizations
+		WHERE oauth_authorization_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete oauth authorization")
+	}
+
+	return nil
+}
+
+// List returns all authorizations of the principal.
+func (s *OAuthAuthorizationStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.OAuthAuthorization, error) {
+	const sqlQuery = `
+		SELECT` + oauthAuthorizationColumns + `
+			,oauth_app_client_id
+			,oauth_app_name
+			,oauth_app_description
+			,oauth_app_homepage_url
+		FROM oauth_authorizations
+		INNER JOIN oauth_apps ON oauth_app_id = oauth_authorization_app_id
+		WHERE oauth_authorization_principal_id = $1
+		ORDER BY oauth_app_name`
+
+	type oauthAuthorizationWithApp struct {
+		oauthAuthorization
+		AppClientID    string `db:"oauth_app_client_id"`
+		AppName        string `db:"oauth_app_name"`
+		AppDescription string `db:"oauth_app_description"`
+		AppHomepageURL string `db:"oauth_app_homepage_url"`
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*oauthAuthorizationWithApp, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list oauth authorizations")
+	}
+
+	res := make([]*types.OAuthAuthorization, len(dst))
+	for i := range dst {
+		res[i] = mapToOAuthAuthorization(&dst[i].oauthAuthorization)
+		res[i].App = &types.OAuthAppInfo{
+			ClientID:    dst[i].AppClientID,
+			Name:        dst[i].AppName,
+			Description: dst[i].AppDescription,
+			HomepageURL: dst[i].AppHomepageURL,
+		}
+	}
+
+	return res, nil
+}
+
+// ListByApp returns all authorizations users gave to the app.
+func (s *OAuthAuthorizationStore) ListByApp(ctx context.Context, appID int64) ([]*types.OAuthAuthorization, error) {
+	const sqlQuery = `
+		SELECT` + oauthAuthorizationColumns + `
+		FROM oauth_authorizations
+		WHERE oauth_authorization_app_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*oauthAuthorization, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, appID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list oauth authorizations by app")
+	}
+
+	res := make([]*types.OAuthAuthorization, len(dst))
+	for i := range dst {
+		res[i] = mapToOAuthAuthorization(dst[i])
+	}
+
+	return res, nil
+}
+
+// ListBySpaces returns all authorizations given by principals that are members of any of the provided spaces.
+// The returned authorizations contain the info of the app and the principal.
+func (s *OAuthAuthorizationStore) ListBySpaces(
+	ctx context.Context,
+	spaceIDs []int64,
+) ([]*types.OAuthAuthorization, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.OAuthAuthorization{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(oauthAuthorizationColumns+`
+			,oauth_app_client_id
+			,oauth_app_name
+			,oauth_app_description
+			,oauth_app_homepage_url
+			,principal_uid
+			,principal_type
+			,principal_display_name
+			,principal_email`).
+		From("oauth_authorizations").
+		InnerJoin("oauth_apps ON oauth_app_id = oauth_authorization_app_id").
+		InnerJoin("principals ON principal_id = oauth_authorization_principal_id").
+		// the sub query has to use the default placeholder format, the outer builder replaces them.
+		Where(squirrel.Expr("oauth_authorization_principal_id IN (?)",
+			squirrel.
+				Select("membership_principal_id").
+				From("memberships").
+				Where(squirrel.Eq{"membership_space_id": spaceIDs}))).
+		OrderBy("oauth_app_name", "oauth_app_id", "principal_uid")
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	type oauthAuthorizationWithAppAndPrincipal struct {
+		oauthAuthorization
+		AppClientID          string             `db:"oauth_app_client_id"`
+		AppName              string             `db:"oauth_app_name"`
+		AppDescription       string             `db:"oauth_app_description"`
+		AppHomepageURL       string             `db:"oauth_app_homepage_url"`
+		PrincipalUID         string             `db:"principal_uid"`
+		PrincipalType        enum.PrincipalType `db:"principal_type"`
+		PrincipalDisplayName string             `db:"principal_display_name"`
+		PrincipalEmail       string             `db:"principal_email"`
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*oauthAuthorizationWithAppAndPrincipal, 0)
+	if err = db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list oauth authorizations by spaces")
+	}
+
+	res := make([]*types.OAuthAuthorization, len(dst))
+	for i := range dst {
+		res[i] = mapToOAuthAuthorization(&dst[i].oauthAuthorization)
+		res[i].App = &types.OAuthAppInfo{
+			ClientID:    dst[i].AppClientID,
+			Name:        dst[i].AppName,
+			Description: dst[i].AppDescription,
+			HomepageURL: dst[i].AppHomepageURL,
+		}
+		res[i].Principal = &types.PrincipalInfo{
+			ID:          dst[i].PrincipalID,
+			UID:         dst[i].PrincipalUID,
+			DisplayName: dst[i].PrincipalDisplayName,
+			Email:       dst[i].PrincipalEmail,
+			Type:        dst[i].PrincipalType,
+		}
+	}
+
+	return res, nil
+}
+
+func mapToOAuthAuthorization(authorization *oauthAuthorization) *types.OAuthAuthorization {
+	scopes, _ := enum.ParseOAuthScopes(authorization.Scopes)
+
+	return &types.OAuthAuthorization{
+		ID:          authorization.ID,
+		AppID:       authorization.AppID,
+		PrincipalID: authorization.PrincipalID,
+		Scopes:      scopes,
+		Created:     authorization.Created,
+		Updated:     authorization.Updated,
+	}
+}
+
+func mapToInternalOAuthAuthorization(authorization *types.OAuthAuthorization) *oauthAuthorization {
+	return &oauthAuthorization{
+		ID:          authorization.ID,
+		AppID:       authorization.AppID,
+		PrincipalID: authorization.PrincipalID,
+		Scopes:      enum.FormatOAuthScopes(authorization.Scopes),
+		Created:     authorization.Created,
+		Updated:     authorization.Updated,
+	}
+}
+
+type oauthCode struct {
+	CodeHash            string `db:"oauth_code_hash"`
+	AuthorizationID     int64  `db:"oauth_code_authorization_id"`
+	RedirectURI         string `db:"oauth_code_redirect_uri"`
+	Scopes              string `db:"oauth_code_scopes"`
+	CodeChallenge       string `db:"oauth_code_challenge"`
+	CodeChallengeMethod string `db:"oauth_code_challenge_method"`
+	Nonce               string `db:"oauth_code_nonce"`
+	Expires             int64  `db:"oauth_code_expires"`
+	Created             int64  `db:"oauth_code_created"`
+}
+
+const oauthCodeColumns = `
+	 oauth_code_hash
+	,oauth_code_authorization_id
+	,oauth_code_redirect_uri
+	,oauth_code_scopes
+	,oauth_code_challenge
+	,oauth_code_challenge_method
+	,oauth_code_nonce
+	,oauth_code_expires
+	,oauth_code_created`
+
+// NewOAuthCodeStore returns a new OAuthCodeStore.
+func NewOAuthCodeStore(db *sqlx.DB) *OAuthCodeStore {
+	return &OAuthCodeStore{
+		db: db,
+	}
+}
+
+// OAuthCodeStore implements store.OAuthCodeStore backed by a relational database.
+type OAuthCodeStore struct {
+	db *sqlx.DB
+}
+
+// Create stores a new authorization code.
+func (s *OAuthCodeStore) Create(ctx context.Context, code *types.OAuthAuthorizationCode) error {
+	const sqlQuery = `
+		INSERT INTO oauth_codes (` + oauthCodeColumns + `
+		) values (
+			 :oauth_code_hash
+			,:oauth_code_authorization_id
+			,:oauth_code_redirect_uri
+			,:oauth_code_scopes
+			,:oauth_code_challenge
+			,:oauth_code_challenge_method
+			,:oauth_code_nonce
+			,:oauth_code_expires
+			,:oauth_code_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOAuthCode(code))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oauth code object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Consume finds the authorization code by its hash and deletes it, so it can't be used again.
+func (s *OAuthCodeStore) Consume(ctx context.Context, codeHash string) (*types.OAuthAuthorizationCode, error) {
+	const sqlQuerySelect = `
+		SELECT` + oauthCodeColumns + `
+		FROM oauth_codes
+		WHERE oauth_code_hash = $1`
+
+	const sqlQueryDelete = `
+		DELETE FROM oauth_codes
+		WHERE oauth_code_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &oauthCode{}
+	if err := db.GetContext(ctx, dst, sqlQuerySelect, codeHash); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oauth code")
+	}
+
+	result, err := db.ExecContext(ctx, sqlQueryDelete, codeHash)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to delete oauth code")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	// the code was consumed concurrently
+	if count == 0 {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return mapToOAuthCode(dst), nil
+}
+
+// DeleteExpiredBefore deletes all authorization codes that expired before the provided time.
+func (s *OAuthCodeStore) DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM oauth_codes
+		WHERE oauth_code_expires < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to delete expired oauth codes")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of deleted oauth codes: %w", err)
+	}
+
+	return n, nil
+}
+
+func mapToOAuthCode(code *oauthCode) *types.OAuthAuthorizationCode {
+	scopes, _ := enum.ParseOAuthScopes(code.Scopes)
+
+	return &types.OAuthAuthorizationCode{
+		CodeHash:            code.CodeHash,
+		AuthorizationID:     code.AuthorizationID,
+		RedirectURI:         code.RedirectURI,
+		Scopes:              scopes,
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		Nonce:               code.Nonce,
+		Expires:             code.Expires,
+		Created:             code.Created,
+	}
+}
+
+func mapToInternalOAuthCode(code *types.OAuthAuthorizationCode) *oauthCode {
+	return &oauthCode{
+		CodeHash:            code.CodeHash,
+		AuthorizationID:     code.AuthorizationID,
+		RedirectURI:         code.RedirectURI,
+		Scopes:              enum.FormatOAuthScopes(code.Scopes),
+		CodeChallenge:       code.CodeChallenge,
+		CodeChallengeMethod: code.CodeChallengeMethod,
+		Nonce:               code.Nonce,
+		Expires:             code.Expires,
+		Created:             code.Created,
+	}
+}
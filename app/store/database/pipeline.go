@@ -69,7 +69,7 @@ type pipelineStore struct {
 func (s *pipelineStore) Find(ctx context.Context, id int64) (*types.Pipeline, error) {
 	const findQueryStmt = pipelineQueryBase + `
 		WHERE pipeline_id = $1`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Pipeline)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
@@ -82,7 +82,7 @@ func (s *pipelineStore) Find(ctx context.Context, id int64) (*types.Pipeline, er
 func (s *pipelineStore) FindByUID(ctx context.Context, repoID int64, uid string) (*types.Pipeline, error) {
 	const findQueryStmt = pipelineQueryBase + `
 		WHERE pipeline_repo_id = $1 AND pipeline_uid = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Pipeline)
 	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, uid); err != nil {
@@ -118,7 +118,7 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_created,
 		:pipeline_updated,
 		:pipeline_version
-	) RETURNING pipeline_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(pipelineInsertStmt, pipeline)
@@ -126,7 +126,7 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		return database.ProcessSQLErrorf(err, "Failed to bind pipeline object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pipeline.ID); err != nil {
+	if pipeline.ID, err = database.InsertReturningID(ctx, db, query, "pipeline_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Pipeline query failed")
 	}
 
@@ -202,7 +202,7 @@ func (s *pipelineStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Pipeline{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -276,7 +276,7 @@ func (s *pipelineStore) ListLatest(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*pipelineExecutionJoin{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -332,7 +332,7 @@ func (s *pipelineStore) Count(ctx context.Context, repoID int64, filter types.Li
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -69,7 +69,7 @@ func (s *pluginStore) Create(ctx context.Context, plugin *types.Plugin) error {
 		,:plugin_version
 		,:plugin_logo
 		,:plugin_spec
-	) RETURNING plugin_uid`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -78,7 +78,7 @@ func (s *pluginStore) Create(ctx context.Context, plugin *types.Plugin) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind plugin object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&plugin.UID); err != nil {
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "plugin query failed")
 	}
 
@@ -92,7 +92,7 @@ func (s *pluginStore) Find(ctx context.Context, name, version string) (*types.Pl
 		`FROM plugins
 	WHERE plugin_uid = $1 AND plugin_version = $2
 	`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Plugin)
 	if err := db.GetContext(ctx, dst, pluginFindStmt, name, version); err != nil {
@@ -123,7 +123,7 @@ func (s *pluginStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Plugin{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -146,7 +146,7 @@ func (s *pluginStore) ListAll(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Plugin{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -171,7 +171,7 @@ func (s *pluginStore) Count(ctx context.Context, filter types.ListQueryFilter) (
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -34,10 +34,15 @@ import (
 var _ store.PrincipalStore = (*PrincipalStore)(nil)
 
 // NewPrincipalStore returns a new PrincipalStore.
-func NewPrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransformation) *PrincipalStore {
+func NewPrincipalStore(
+	db *sqlx.DB,
+	uidTransformation store.PrincipalUIDTransformation,
+	evictor store.PrincipalEvictor,
+) *PrincipalStore {
 	return &PrincipalStore{
 		db:                db,
 		uidTransformation: uidTransformation,
+		evictor:           evictor,
 	}
 }
 
@@ -45,6 +50,7 @@ func NewPrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransfor
 type PrincipalStore struct {
 	db                *sqlx.DB
 	uidTransformation store.PrincipalUIDTransformation
+	evictor           store.PrincipalEvictor
 }
 
 // principal is a DB representation of a principal.
@@ -70,7 +76,9 @@ const principalCommonColumns = `
 // principalColumns defines the column that are used only in a principal itself
 // (for explicit principals the type is implicit, only the generic principal struct stores it explicitly).
 const principalColumns = principalCommonColumns + `
-	,principal_type`
+	,principal_type
+	,principal_suspended_until
+	,principal_password_reset_required`
 
 const principalSelectBase = `
 	SELECT` + principalColumns + `
@@ -81,7 +89,7 @@ func (s *PrincipalStore) Find(ctx context.Context, id int64) (*types.Principal,
 	const sqlQuery = principalSelectBase + `
 		WHERE principal_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(principal)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -104,7 +112,7 @@ func (s *PrincipalStore) FindByUID(ctx context.Context, uid string) (*types.Prin
 		return nil, gitness_store.ErrResourceNotFound
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(principal)
 	if err = db.GetContext(ctx, dst, sqlQuery, uidUnique); err != nil {
@@ -132,7 +140,7 @@ func (s *PrincipalStore) FindManyByUID(ctx context.Context, uids []string) ([]*t
 		Select(principalColumns).
 		From("principals").
 		Where(squirrel.Eq{"principal_uid_unique": uids})
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	sqlQuery, params, err := stmt.ToSql()
 	if err != nil {
@@ -148,11 +156,16 @@ func (s *PrincipalStore) FindManyByUID(ctx context.Context, uids []string) ([]*t
 }
 
 // FindByEmail finds the principal by email.
+// Besides the primary email, verified additional emails of users are matched as well.
 func (s *PrincipalStore) FindByEmail(ctx context.Context, email string) (*types.Principal, error) {
 	const sqlQuery = principalSelectBase + `
-		WHERE LOWER(principal_email) = $1`
+		WHERE LOWER(principal_email) = $1 OR principal_id IN (
+			SELECT user_email_principal_id
+			FROM user_emails
+			WHERE LOWER(user_email_address) = $1 AND user_email_verified IS NOT NULL
+		)`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(principal)
 	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(email)); err != nil {
@@ -195,7 +208,7 @@ func (s *PrincipalStore) List(ctx context.Context,
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*principal{}
 	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -69,7 +69,7 @@ func (s *PrincipalInfoView) Find(ctx context.Context, id int64) (*types.Principa
 		FROM principals
 		WHERE principal_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	v := db.QueryRowContext(ctx, sqlQuery, id)
 	if err := v.Err(); err != nil {
@@ -88,7 +88,7 @@ func (s *PrincipalInfoView) Find(ctx context.Context, id int64) (*types.Principa
 
 // FindMany returns a several principal info objects by id from the `principals` database table.
 func (s *PrincipalInfoView) FindMany(ctx context.Context, ids []int64) ([]*types.PrincipalInfo, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	stmt := database.Builder.
 		Select(principalInfoCommonColumns).
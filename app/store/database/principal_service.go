@@ -45,7 +45,7 @@ func (s *PrincipalStore) FindService(ctx context.Context, id int64) (*types.Serv
 	const sqlQuery = serviceSelectBase + `
 		WHERE principal_type = 'service' AND principal_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(service)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -68,7 +68,7 @@ func (s *PrincipalStore) FindServiceByUID(ctx context.Context, uid string) (*typ
 		return nil, gitness_store.ErrResourceNotFound
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(service)
 	if err = db.GetContext(ctx, dst, sqlQuery, uidUnique); err != nil {
@@ -103,7 +103,7 @@ func (s *PrincipalStore) CreateService(ctx context.Context, svc *types.Service)
 			,:principal_salt
 			,:principal_created
 			,:principal_updated
-		) RETURNING principal_id`
+		)`
 
 	dbSVC, err := s.mapToDBservice(svc)
 	if err != nil {
@@ -117,7 +117,7 @@ func (s *PrincipalStore) CreateService(ctx context.Context, svc *types.Service)
 		return database.ProcessSQLErrorf(err, "Failed to bind service object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&svc.ID); err != nil {
+	if svc.ID, err = database.InsertReturningID(ctx, db, query, "principal_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -152,7 +152,9 @@ func (s *PrincipalStore) UpdateService(ctx context.Context, svc *types.Service)
 		return database.ProcessSQLErrorf(err, "Update query failed")
 	}
 
-	return err
+	s.evictor.Evict(ctx, svc.ID)
+
+	return nil
 }
 
 // DeleteService deletes the service.
@@ -168,6 +170,8 @@ func (s *PrincipalStore) DeleteService(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(err, "The delete query failed")
 	}
 
+	s.evictor.Evict(ctx, id)
+
 	return nil
 }
 
@@ -177,7 +181,7 @@ func (s *PrincipalStore) ListServices(ctx context.Context) ([]*types.Service, er
 		WHERE principal_type = 'service'
 		ORDER BY principal_uid ASC`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*service{}
 
@@ -196,7 +200,7 @@ func (s *PrincipalStore) CountServices(ctx context.Context) (int64, error) {
 		FROM principals
 		WHERE principal_type = 'service'`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err := db.QueryRowContext(ctx, sqlQuery).Scan(&count)
@@ -47,7 +47,7 @@ func (s *PrincipalStore) FindServiceAccount(ctx context.Context, id int64) (*typ
 	const sqlQuery = serviceAccountSelectBase + `
 		WHERE principal_type = 'serviceaccount' AND principal_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(serviceAccount)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -69,7 +69,7 @@ func (s *PrincipalStore) FindServiceAccountByUID(ctx context.Context, uid string
 		return nil, gitness_store.ErrResourceNotFound
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(serviceAccount)
 	if err = db.GetContext(ctx, dst, sqlQuery, uidUnique); err != nil {
@@ -108,7 +108,7 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 			,:principal_updated
 			,:principal_sa_parent_type
 			,:principal_sa_parent_id
-		) RETURNING principal_id`
+		)`
 
 	dbSA, err := s.mapToDBserviceAccount(sa)
 	if err != nil {
@@ -122,7 +122,7 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 		return database.ProcessSQLErrorf(err, "Failed to bind service account object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&sa.ID); err != nil {
+	if sa.ID, err = database.InsertReturningID(ctx, db, query, "principal_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -157,7 +157,9 @@ func (s *PrincipalStore) UpdateServiceAccount(ctx context.Context, sa *types.Ser
 		return database.ProcessSQLErrorf(err, "Update query failed")
 	}
 
-	return err
+	s.evictor.Evict(ctx, sa.ID)
+
+	return nil
 }
 
 // DeleteServiceAccount deletes the service account.
@@ -172,6 +174,8 @@ func (s *PrincipalStore) DeleteServiceAccount(ctx context.Context, id int64) err
 		return database.ProcessSQLErrorf(err, "The delete query failed")
 	}
 
+	s.evictor.Evict(ctx, id)
+
 	return nil
 }
 
@@ -182,7 +186,7 @@ func (s *PrincipalStore) ListServiceAccounts(ctx context.Context, parentType enu
 		WHERE principal_type = 'serviceaccount' AND principal_sa_parent_type = $1 AND principal_sa_parent_id = $2
 		ORDER BY principal_uid ASC`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*serviceAccount{}
 	err := db.SelectContext(ctx, &dst, sqlQuery, parentType, parentID)
@@ -201,7 +205,7 @@ func (s *PrincipalStore) CountServiceAccounts(ctx context.Context,
 		FROM principals
 		WHERE principal_type = 'serviceaccount' and principal_sa_parentType = $1 and principal_sa_parentId = $2`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err := db.QueryRowContext(ctx, sqlQuery, parentType, parentID).Scan(&count)
@@ -37,6 +37,8 @@ type user struct {
 }
 
 const userColumns = principalCommonColumns + `
+	,principal_suspended_until
+	,principal_password_reset_required
 	,principal_user_password`
 
 const userSelectBase = `
@@ -48,7 +50,7 @@ func (s *PrincipalStore) FindUser(ctx context.Context, id int64) (*types.User, e
 	const sqlQuery = userSelectBase + `
 		WHERE principal_type = 'user' AND principal_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(user)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -71,7 +73,7 @@ func (s *PrincipalStore) FindUserByUID(ctx context.Context, uid string) (*types.
 		return nil, gitness_store.ErrResourceNotFound
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(user)
 	if err = db.GetContext(ctx, dst, sqlQuery, uidUnique); err != nil {
@@ -86,7 +88,7 @@ func (s *PrincipalStore) FindUserByEmail(ctx context.Context, email string) (*ty
 	const sqlQuery = userSelectBase + `
 		WHERE principal_type = 'user' AND LOWER(principal_email) = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(user)
 	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(email)); err != nil {
@@ -123,7 +125,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,:principal_created
 			,:principal_updated
 			,:principal_user_password
-		) RETURNING principal_id`
+		)`
 
 	dbUser, err := s.mapToDBUser(user)
 	if err != nil {
@@ -137,7 +139,7 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 		return database.ProcessSQLErrorf(err, "Failed to bind user object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&user.ID); err != nil {
+	if user.ID, err = database.InsertReturningID(ctx, db, query, "principal_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -149,13 +151,15 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 	const sqlQuery = `
 		UPDATE principals
 		SET
-			principal_email     	  = :principal_email
-			,principal_display_name   = :principal_display_name
-			,principal_admin          = :principal_admin
-			,principal_blocked        = :principal_blocked
-			,principal_salt           = :principal_salt
-			,principal_updated        = :principal_updated
-			,principal_user_password  = :principal_user_password
+			 principal_email                   = :principal_email
+			,principal_display_name            = :principal_display_name
+			,principal_admin                   = :principal_admin
+			,principal_blocked                 = :principal_blocked
+			,principal_salt                    = :principal_salt
+			,principal_updated                 = :principal_updated
+			,principal_suspended_until         = :principal_suspended_until
+			,principal_password_reset_required = :principal_password_reset_required
+			,principal_user_password           = :principal_user_password
 		WHERE principal_type = 'user' AND principal_id = :principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -174,7 +178,9 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 		return database.ProcessSQLErrorf(err, "Update query failed")
 	}
 
-	return err
+	s.evictor.Evict(ctx, user.ID)
+
+	return nil
 }
 
 // DeleteUser deletes the user.
@@ -189,12 +195,14 @@ func (s *PrincipalStore) DeleteUser(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(err, "The delete query failed")
 	}
 
+	s.evictor.Evict(ctx, id)
+
 	return nil
 }
 
 // ListUsers returns a list of users.
 func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter) ([]*types.User, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 	dst := []*user{}
 
 	stmt := database.Builder.
@@ -255,7 +263,7 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
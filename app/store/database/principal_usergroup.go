@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// userGroup is a DB representation of a user group principal.
+// It is required to allow storing transformed UIDs used for uniquness constraints and searching.
+type userGroup struct {
+	types.UserGroup
+	UIDUnique string `db:"principal_uid_unique"`
+}
+
+const userGroupColumns = principalCommonColumns + `
+	,principal_usergroup_space_id`
+
+const userGroupSelectBase = `
+	SELECT` + userGroupColumns + `
+	FROM principals`
+
+// FindUserGroup finds the user group by id.
+func (s *PrincipalStore) FindUserGroup(ctx context.Context, id int64) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+		WHERE principal_type = 'usergroup' AND principal_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := new(userGroup)
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select by id query failed")
+	}
+	return s.mapDBUserGroup(dst), nil
+}
+
+// FindUserGroupByUID finds the user group by uid.
+func (s *PrincipalStore) FindUserGroupByUID(ctx context.Context, uid string) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+		WHERE principal_type = 'usergroup' AND principal_uid_unique = $1`
+
+	// map the UID to unique UID before searching!
+	uidUnique, err := s.uidTransformation(uid)
+	if err != nil {
+		// in case we fail to transform, return a not found (as it can't exist in the first place)
+		log.Ctx(ctx).Debug().Msgf("failed to transform uid '%s': %s", uid, err.Error())
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := new(userGroup)
+	if err = db.GetContext(ctx, dst, sqlQuery, uidUnique); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select by uid query failed")
+	}
+
+	return s.mapDBUserGroup(dst), nil
+}
+
+// CreateUserGroup saves the user group.
+func (s *PrincipalStore) CreateUserGroup(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+		INSERT INTO principals (
+			principal_type
+			,principal_uid
+			,principal_uid_unique
+			,principal_email
+			,principal_display_name
+			,principal_admin
+			,principal_blocked
+			,principal_salt
+			,principal_created
+			,principal_updated
+			,principal_usergroup_space_id
+		) values (
+			'usergroup'
+			,:principal_uid
+			,:principal_uid_unique
+			,:principal_email
+			,:principal_display_name
+			,false
+			,false
+			,:principal_salt
+			,:principal_created
+			,:principal_updated
+			,:principal_usergroup_space_id
+		)`
+
+	dbGroup, err := s.mapToDBUserGroup(group)
+	if err != nil {
+		return fmt.Errorf("failed to map db user group: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbGroup)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group object")
+	}
+
+	if group.ID, err = database.InsertReturningID(ctx, db, query, "principal_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateUserGroup updates the user group details.
+func (s *PrincipalStore) UpdateUserGroup(ctx context.Context, group *types.UserGroup) error {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_email           = :principal_email
+			,principal_display_name   = :principal_display_name
+			,principal_updated        = :principal_updated
+		WHERE principal_type = 'usergroup' AND principal_id = :principal_id`
+
+	dbGroup, err := s.mapToDBUserGroup(group)
+	if err != nil {
+		return fmt.Errorf("failed to map db user group: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbGroup)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	s.evictor.Evict(ctx, group.ID)
+
+	return nil
+}
+
+// DeleteUserGroup deletes the user group.
+// Pending reviewer requests of the group are deleted as well,
+// as reviewer entries don't get removed together with their principal.
+func (s *PrincipalStore) DeleteUserGroup(ctx context.Context, id int64) error {
+	const sqlQueryReviewers = `
+		DELETE FROM pullreq_reviewers
+		WHERE pullreq_reviewer_principal_id = $1`
+
+	const sqlQuery = `
+		DELETE FROM principals
+		WHERE principal_type = 'usergroup' AND principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryReviewers, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete reviewer entries of the user group")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete query failed")
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// ListUserGroups returns a list of user groups defined in a space.
+func (s *PrincipalStore) ListUserGroups(ctx context.Context, spaceID int64) ([]*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+		WHERE principal_type = 'usergroup' AND principal_usergroup_space_id = $1
+		ORDER BY principal_uid ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*userGroup{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user group list query")
+	}
+
+	return s.mapDBUserGroups(dst), nil
+}
+
+func (s *PrincipalStore) mapDBUserGroup(dbGroup *userGroup) *types.UserGroup {
+	return &dbGroup.UserGroup
+}
+
+func (s *PrincipalStore) mapDBUserGroups(dbGroups []*userGroup) []*types.UserGroup {
+	res := make([]*types.UserGroup, len(dbGroups))
+	for i := range dbGroups {
+		res[i] = s.mapDBUserGroup(dbGroups[i])
+	}
+	return res
+}
+
+func (s *PrincipalStore) mapToDBUserGroup(group *types.UserGroup) (*userGroup, error) {
+	// user group comes from outside.
+	if group == nil {
+		return nil, fmt.Errorf("user group is nil")
+	}
+
+	uidUnique, err := s.uidTransformation(group.UID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform user group UID: %w", err)
+	}
+	dbGroup := &userGroup{
+		UserGroup: *group,
+		UIDUnique: uidUnique,
+	}
+
+	return dbGroup, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+const (
+	publicKeyColumns = `
+		 public_key_id
+		,public_key_principal_id
+		,public_key_identifier
+		,public_key_scheme
+		,public_key_fingerprint
+		,public_key_content
+		,public_key_created`
+
+	publicKeySelectBase = `
+	SELECT` + publicKeyColumns + `
+	FROM public_keys`
+)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{
+		db: db,
+	}
+}
+
+// PublicKeyStore implements a store.PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+// Find finds the public key by id.
+func (s *PublicKeyStore) Find(ctx context.Context, id int64) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.PublicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key")
+	}
+
+	return dst, nil
+}
+
+// FindByFingerprint finds the public key by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_fingerprint = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.PublicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, fingerprint); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key by fingerprint")
+	}
+
+	return dst, nil
+}
+
+// Create creates a new public key.
+func (s *PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error {
+	const sqlQuery = `
+		INSERT INTO public_keys (
+			 public_key_principal_id
+			,public_key_identifier
+			,public_key_scheme
+			,public_key_fingerprint
+			,public_key_content
+			,public_key_created
+		) VALUES (
+			 :public_key_principal_id
+			,:public_key_identifier
+			,:public_key_scheme
+			,:public_key_fingerprint
+			,:public_key_content
+			,:public_key_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, key)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind public key object")
+	}
+
+	if key.ID, err = database.InsertReturningID(ctx, db, query, "public_key_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the public key with the given id.
+func (s *PublicKeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM public_keys
+		WHERE public_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete public key")
+	}
+
+	return nil
+}
+
+// List returns all public keys of the principal.
+func (s *PublicKeyStore) List(ctx context.Context, principalID int64) ([]*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_principal_id = $1
+		ORDER BY public_key_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.PublicKey, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list public keys")
+	}
+
+	return dst, nil
+}
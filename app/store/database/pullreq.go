@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/harness/gitness/app/store"
 	gitness_store "github.com/harness/gitness/store"
@@ -69,6 +70,9 @@ type pullReq struct {
 	CommentCount    int `db:"pullreq_comment_count"`
 	UnresolvedCount int `db:"pullreq_unresolved_count"`
 
+	TaskCount          int `db:"pullreq_task_count"`
+	TaskCompletedCount int `db:"pullreq_task_completed_count"`
+
 	Title       string `db:"pullreq_title"`
 	Description string `db:"pullreq_description"`
 
@@ -89,6 +93,10 @@ type pullReq struct {
 	MergeBaseSHA     string                `db:"pullreq_merge_base_sha"`
 	MergeSHA         null.String           `db:"pullreq_merge_sha"`
 	MergeConflicts   null.String           `db:"pullreq_merge_conflicts"`
+
+	LockedBy   null.Int    `db:"pullreq_locked_by"`
+	Locked     null.Int    `db:"pullreq_locked"`
+	LockReason null.String `db:"pullreq_lock_reason"`
 }
 
 const (
@@ -104,6 +112,8 @@ const (
 		,pullreq_is_draft
 		,pullreq_comment_count
 		,pullreq_unresolved_count
+		,pullreq_task_count
+		,pullreq_task_completed_count
 		,pullreq_title
 		,pullreq_description
 		,pullreq_source_repo_id
@@ -119,7 +129,10 @@ const (
 		,pullreq_merge_target_sha
 		,pullreq_merge_base_sha
 		,pullreq_merge_sha
-		,pullreq_merge_conflicts`
+		,pullreq_merge_conflicts
+		,pullreq_locked_by
+		,pullreq_locked
+		,pullreq_lock_reason`
 
 	pullReqSelectBase = `
 	SELECT` + pullReqColumns + `
@@ -131,7 +144,7 @@ func (s *PullReqStore) Find(ctx context.Context, id int64) (*types.PullReq, erro
 	const sqlQuery = pullReqSelectBase + `
 	WHERE pullreq_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &pullReq{}
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -193,6 +206,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_is_draft
 		,pullreq_comment_count
 		,pullreq_unresolved_count
+		,pullreq_task_count
+		,pullreq_task_completed_count
 		,pullreq_title
 		,pullreq_description
 		,pullreq_source_repo_id
@@ -220,6 +235,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_is_draft
 		,:pullreq_comment_count
 		,:pullreq_unresolved_count
+		,:pullreq_task_count
+		,:pullreq_task_completed_count
 		,:pullreq_title
 		,:pullreq_description
 		,:pullreq_source_repo_id
@@ -236,7 +253,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merge_base_sha
 		,:pullreq_merge_sha
 		,:pullreq_merge_conflicts
-	) RETURNING pullreq_id`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -245,7 +262,7 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind pullReq object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pr.ID); err != nil {
+	if pr.ID, err = database.InsertReturningID(ctx, db, query, "pullreq_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -264,6 +281,8 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_is_draft = :pullreq_is_draft
 		,pullreq_comment_count = :pullreq_comment_count
 		,pullreq_unresolved_count = :pullreq_unresolved_count
+		,pullreq_task_count = :pullreq_task_count
+		,pullreq_task_completed_count = :pullreq_task_completed_count
 		,pullreq_title = :pullreq_title
 		,pullreq_description = :pullreq_description
 		,pullreq_activity_seq = :pullreq_activity_seq
@@ -276,6 +295,9 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_base_sha = :pullreq_merge_base_sha
 		,pullreq_merge_sha = :pullreq_merge_sha
 		,pullreq_merge_conflicts = :pullreq_merge_conflicts
+		,pullreq_locked_by = :pullreq_locked_by
+		,pullreq_locked = :pullreq_locked
+		,pullreq_lock_reason = :pullreq_lock_reason
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -409,28 +431,42 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where("pullreq_source_branch = ?", opts.SourceBranch)
 	}
 
+	if len(opts.SourceBranches) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"pullreq_source_branch": opts.SourceBranches})
+	}
+
 	if opts.TargetRepoID != 0 {
 		stmt = stmt.Where("pullreq_target_repo_id = ?", opts.TargetRepoID)
 	}
 
+	if len(opts.TargetRepoIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"pullreq_target_repo_id": opts.TargetRepoIDs})
+	}
+
 	if opts.TargetBranch != "" {
 		stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
 	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+		stmt = s.applyQueryFilter(stmt, opts.Query, opts.SearchComments)
 	}
 
 	if opts.CreatedBy != 0 {
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	if opts.ReviewedBy != 0 {
+		stmt = stmt.Where(`EXISTS (
+			SELECT 1 FROM pullreq_reviews
+			WHERE pullreq_review_pullreq_id = pullreq_id AND pullreq_review_created_by = ?)`, opts.ReviewedBy)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -441,6 +477,55 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 	return count, nil
 }
 
+// applyQueryFilter restricts the pull requests to the ones matching the text query.
+// With postgres the query is matched against the full-text search vectors of the title and description,
+// otherwise the title is matched against the query as substring.
+func (s *PullReqStore) applyQueryFilter(
+	stmt squirrel.SelectBuilder,
+	query string,
+	searchComments bool,
+) squirrel.SelectBuilder {
+	if tsQuery := textSearchQuery(query); s.db.DriverName() == database.PostgresDriverName && tsQuery != "" {
+		if !searchComments {
+			return stmt.Where("pullreq_search @@ to_tsquery('simple', ?)", tsQuery)
+		}
+
+		return stmt.Where(`(pullreq_search @@ to_tsquery('simple', ?) OR EXISTS (
+			SELECT 1 FROM pullreq_activities
+			WHERE pullreq_activity_pullreq_id = pullreq_id AND
+				pullreq_activity_kind IN (?, ?) AND
+				pullreq_activity_deleted IS NULL AND
+				pullreq_activity_search @@ to_tsquery('simple', ?)))`,
+			tsQuery, enum.PullReqActivityKindComment, enum.PullReqActivityKindChangeComment, tsQuery)
+	}
+
+	pattern := fmt.Sprintf("%%%s%%", strings.ToLower(query))
+	if !searchComments {
+		return stmt.Where("LOWER(pullreq_title) LIKE ?", pattern)
+	}
+
+	return stmt.Where(`(LOWER(pullreq_title) LIKE ? OR EXISTS (
+		SELECT 1 FROM pullreq_activities
+		WHERE pullreq_activity_pullreq_id = pullreq_id AND
+			pullreq_activity_kind IN (?, ?) AND
+			pullreq_activity_deleted IS NULL AND
+			LOWER(pullreq_activity_text) LIKE ?))`,
+		pattern, enum.PullReqActivityKindComment, enum.PullReqActivityKindChangeComment, pattern)
+}
+
+// textSearchQuery converts the text query to a postgres tsquery matching all words of the query as prefixes.
+func textSearchQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i := range words {
+		words[i] += ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
 // List returns a list of pull requests for a repo.
 func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
 	stmt := database.Builder.
@@ -461,22 +546,36 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where("pullreq_source_branch = ?", opts.SourceBranch)
 	}
 
+	if len(opts.SourceBranches) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"pullreq_source_branch": opts.SourceBranches})
+	}
+
 	if opts.TargetRepoID != 0 {
 		stmt = stmt.Where("pullreq_target_repo_id = ?", opts.TargetRepoID)
 	}
 
+	if len(opts.TargetRepoIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"pullreq_target_repo_id": opts.TargetRepoIDs})
+	}
+
 	if opts.TargetBranch != "" {
 		stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
 	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+		stmt = s.applyQueryFilter(stmt, opts.Query, opts.SearchComments)
 	}
 
 	if opts.CreatedBy != 0 {
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	if opts.ReviewedBy != 0 {
+		stmt = stmt.Where(`EXISTS (
+			SELECT 1 FROM pullreq_reviews
+			WHERE pullreq_review_pullreq_id = pullreq_id AND pullreq_review_created_by = ?)`, opts.ReviewedBy)
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -493,7 +592,7 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 
 	dst := make([]*pullReq, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing custom list query")
@@ -507,40 +606,127 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 	return result, nil
 }
 
+// ListAging returns up to limit open pull requests targeting repositories of the space (including all subspaces),
+// that were either created before createdBefore or have no activity since idleBefore, ordered by creation time.
+// A time of 0 disables the respective condition.
+func (s *PullReqStore) ListAging(
+	ctx context.Context,
+	spaceID int64,
+	createdBefore int64,
+	idleBefore int64,
+	limit int,
+) ([]*types.PullReqAging, error) {
+	// The last activity is taken from the activity timeline, because pullreq_updated
+	// is also bumped by background processing like the merge check.
+	const lastActivity = `COALESCE((
+		SELECT MAX(pullreq_activity_created)
+		FROM pullreq_activities
+		WHERE pullreq_activity_pullreq_id = pullreq_id), pullreq_created)`
+
+	conditions := squirrel.Or{}
+	if createdBefore > 0 {
+		conditions = append(conditions, squirrel.Lt{"pullreq_created": createdBefore})
+	}
+	if idleBefore > 0 {
+		conditions = append(conditions, squirrel.Expr(lastActivity+" < ?", idleBefore))
+	}
+	if len(conditions) == 0 {
+		return []*types.PullReqAging{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(pullReqColumns+", "+lastActivity+" AS pullreq_last_activity").
+		From("pullreqs").
+		Prefix(insightsSpaceReposCTE, spaceID).
+		Where("pullreq_target_repo_id IN ("+insightsSpaceReposSubQuery+")").
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		Where(conditions).
+		OrderBy("pullreq_created ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert aging pull requests query to sql")
+	}
+
+	dst := make([]*struct {
+		pullReq
+		LastActivity int64 `db:"pullreq_last_activity"`
+	}, 0)
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing aging pull requests query")
+	}
+
+	prs := make([]*pullReq, len(dst))
+	for i := range dst {
+		prs[i] = &dst[i].pullReq
+	}
+
+	prList, err := s.mapSlicePullReq(ctx, prs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.PullReqAging, len(prList))
+	for i, pr := range prList {
+		result[i] = &types.PullReqAging{
+			RepoID:       pr.TargetRepoID,
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			IsDraft:      pr.IsDraft,
+			Created:      pr.Created,
+			LastActivity: dst[i].LastActivity,
+		}
+	}
+
+	return result, nil
+}
+
 func mapPullReq(pr *pullReq) *types.PullReq {
 	return &types.PullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         pr.MergedBy.Ptr(),
-		Merged:           pr.Merged.Ptr(),
-		MergeMethod:      (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   pr.MergeTargetSHA.Ptr(),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         pr.MergeSHA.Ptr(),
-		MergeConflicts:   pr.MergeConflicts.Ptr(),
-		Author:           types.PrincipalInfo{},
-		Merger:           nil,
+		ID:                 pr.ID,
+		Version:            pr.Version,
+		Number:             pr.Number,
+		CreatedBy:          pr.CreatedBy,
+		Created:            pr.Created,
+		Updated:            pr.Updated,
+		Edited:             pr.Edited,
+		State:              pr.State,
+		IsDraft:            pr.IsDraft,
+		CommentCount:       pr.CommentCount,
+		UnresolvedCount:    pr.UnresolvedCount,
+		TaskCount:          pr.TaskCount,
+		TaskCompletedCount: pr.TaskCompletedCount,
+		Title:              pr.Title,
+		Description:        pr.Description,
+		SourceRepoID:       pr.SourceRepoID,
+		SourceBranch:       pr.SourceBranch,
+		SourceSHA:          pr.SourceSHA,
+		TargetRepoID:       pr.TargetRepoID,
+		TargetBranch:       pr.TargetBranch,
+		ActivitySeq:        pr.ActivitySeq,
+		MergedBy:           pr.MergedBy.Ptr(),
+		Merged:             pr.Merged.Ptr(),
+		MergeMethod:        (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
+		MergeCheckStatus:   pr.MergeCheckStatus,
+		MergeTargetSHA:     pr.MergeTargetSHA.Ptr(),
+		MergeBaseSHA:       pr.MergeBaseSHA,
+		MergeSHA:           pr.MergeSHA.Ptr(),
+		MergeConflicts:     pr.MergeConflicts.Ptr(),
+		LockedBy:           pr.LockedBy.Ptr(),
+		Locked:             pr.Locked.Ptr(),
+		LockReason:         (*enum.PullReqLockReason)(pr.LockReason.Ptr()),
+		Author:             types.PrincipalInfo{},
+		Merger:             nil,
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
+			Tasks:           pr.TaskCount,
+			TasksCompleted:  pr.TaskCompletedCount,
 			DiffStats: types.DiffStats{
 				Commits:      0,
 				FilesChanged: 0,
@@ -551,33 +737,38 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 
 func mapInternalPullReq(pr *types.PullReq) *pullReq {
 	m := &pullReq{
-		ID:               pr.ID,
-		Version:          pr.Version,
-		Number:           pr.Number,
-		CreatedBy:        pr.CreatedBy,
-		Created:          pr.Created,
-		Updated:          pr.Updated,
-		Edited:           pr.Edited,
-		State:            pr.State,
-		IsDraft:          pr.IsDraft,
-		CommentCount:     pr.CommentCount,
-		UnresolvedCount:  pr.UnresolvedCount,
-		Title:            pr.Title,
-		Description:      pr.Description,
-		SourceRepoID:     pr.SourceRepoID,
-		SourceBranch:     pr.SourceBranch,
-		SourceSHA:        pr.SourceSHA,
-		TargetRepoID:     pr.TargetRepoID,
-		TargetBranch:     pr.TargetBranch,
-		ActivitySeq:      pr.ActivitySeq,
-		MergedBy:         null.IntFromPtr(pr.MergedBy),
-		Merged:           null.IntFromPtr(pr.Merged),
-		MergeMethod:      null.StringFromPtr((*string)(pr.MergeMethod)),
-		MergeCheckStatus: pr.MergeCheckStatus,
-		MergeTargetSHA:   null.StringFromPtr(pr.MergeTargetSHA),
-		MergeBaseSHA:     pr.MergeBaseSHA,
-		MergeSHA:         null.StringFromPtr(pr.MergeSHA),
-		MergeConflicts:   null.StringFromPtr(pr.MergeConflicts),
+		ID:                 pr.ID,
+		Version:            pr.Version,
+		Number:             pr.Number,
+		CreatedBy:          pr.CreatedBy,
+		Created:            pr.Created,
+		Updated:            pr.Updated,
+		Edited:             pr.Edited,
+		State:              pr.State,
+		IsDraft:            pr.IsDraft,
+		CommentCount:       pr.CommentCount,
+		UnresolvedCount:    pr.UnresolvedCount,
+		TaskCount:          pr.TaskCount,
+		TaskCompletedCount: pr.TaskCompletedCount,
+		Title:              pr.Title,
+		Description:        pr.Description,
+		SourceRepoID:       pr.SourceRepoID,
+		SourceBranch:       pr.SourceBranch,
+		SourceSHA:          pr.SourceSHA,
+		TargetRepoID:       pr.TargetRepoID,
+		TargetBranch:       pr.TargetBranch,
+		ActivitySeq:        pr.ActivitySeq,
+		MergedBy:           null.IntFromPtr(pr.MergedBy),
+		Merged:             null.IntFromPtr(pr.Merged),
+		MergeMethod:        null.StringFromPtr((*string)(pr.MergeMethod)),
+		MergeCheckStatus:   pr.MergeCheckStatus,
+		MergeTargetSHA:     null.StringFromPtr(pr.MergeTargetSHA),
+		MergeBaseSHA:       pr.MergeBaseSHA,
+		MergeSHA:           null.StringFromPtr(pr.MergeSHA),
+		MergeConflicts:     null.StringFromPtr(pr.MergeConflicts),
+		LockedBy:           null.IntFromPtr(pr.LockedBy),
+		Locked:             null.IntFromPtr(pr.Locked),
+		LockReason:         null.StringFromPtr((*string)(pr.LockReason)),
 	}
 
 	return m
@@ -586,7 +777,7 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 func (s *PullReqStore) mapPullReq(ctx context.Context, pr *pullReq) *types.PullReq {
 	m := mapPullReq(pr)
 
-	var author, merger *types.PrincipalInfo
+	var author, merger, locker *types.PrincipalInfo
 	var err error
 
 	author, err = s.pCache.Get(ctx, pr.CreatedBy)
@@ -605,6 +796,14 @@ func (s *PullReqStore) mapPullReq(ctx context.Context, pr *pullReq) *types.PullR
 		m.Merger = merger
 	}
 
+	if pr.LockedBy.Valid {
+		locker, err = s.pCache.Get(ctx, pr.LockedBy.Int64)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to load PR locker")
+		}
+		m.Locker = locker
+	}
+
 	return m
 }
 
@@ -616,6 +815,9 @@ func (s *PullReqStore) mapSlicePullReq(ctx context.Context, prs []*pullReq) ([]*
 		if pr.MergedBy.Valid {
 			ids = append(ids, pr.MergedBy.Int64)
 		}
+		if pr.LockedBy.Valid {
+			ids = append(ids, pr.LockedBy.Int64)
+		}
 	}
 
 	// pull principal infos from cache
@@ -636,6 +838,11 @@ func (s *PullReqStore) mapSlicePullReq(ctx context.Context, prs []*pullReq) ([]*
 				m[i].Merger = merger
 			}
 		}
+		if pr.LockedBy.Valid {
+			if locker, ok := infoMap[pr.LockedBy.Int64]; ok {
+				m[i].Locker = locker
+			}
+		}
 	}
 
 	return m, nil
@@ -134,7 +134,7 @@ func (s *PullReqActivityStore) Find(ctx context.Context, id int64) (*types.PullR
 	const sqlQuery = pullreqActivitySelectBase + `
 	WHERE pullreq_activity_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &pullReqActivity{}
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -203,7 +203,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_code_comment_span_new
 		,:pullreq_activity_code_comment_line_old
 		,:pullreq_activity_code_comment_span_old
-	) RETURNING pullreq_activity_id`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -212,7 +212,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		return database.ProcessSQLErrorf(err, "Failed to bind pull request activity object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&act.ID); err != nil {
+	if act.ID, err = database.InsertReturningID(ctx, db, query, "pullreq_activity_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Failed to insert pull request activity")
 	}
 
@@ -369,7 +369,7 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -423,7 +423,7 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 
 	dst := make([]*pullReqActivity, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing pull request activity list query")
@@ -452,7 +452,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
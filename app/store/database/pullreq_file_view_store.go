@@ -69,7 +69,7 @@ const (
 
 // Upsert inserts or updates the latest viewed sha for a file in a PR.
 func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFileView) error {
-	const sqlQuery = `
+	const sqlQueryInsert = `
 	INSERT INTO pullreq_file_views (
 		 pullreq_file_view_pullreq_id
 		,pullreq_file_view_principal_id
@@ -86,12 +86,22 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 		,:pullreq_file_view_obsolete
 		,:pullreq_file_view_created
 		,:pullreq_file_view_updated
-	)
-	ON CONFLICT (pullreq_file_view_pullreq_id, pullreq_file_view_principal_id, pullreq_file_view_path) DO
-	UPDATE SET
+	)`
+
+	const sqlQueryUpdate = `
 		 pullreq_file_view_updated = :pullreq_file_view_updated
 		,pullreq_file_view_sha = :pullreq_file_view_sha
-		,pullreq_file_view_obsolete = :pullreq_file_view_obsolete
+		,pullreq_file_view_obsolete = :pullreq_file_view_obsolete`
+
+	// mysql doesn't support RETURNING, the creation time is selected after the upsert instead.
+	if database.IsMySQL(s.db) {
+		return s.upsertWithSeparateSelect(ctx, view, sqlQueryInsert+`
+	ON DUPLICATE KEY UPDATE`+sqlQueryUpdate)
+	}
+
+	const sqlQuery = sqlQueryInsert + `
+	ON CONFLICT (pullreq_file_view_pullreq_id, pullreq_file_view_principal_id, pullreq_file_view_path) DO
+	UPDATE SET` + sqlQueryUpdate + `
 	RETURNING pullreq_file_view_created`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -108,6 +118,38 @@ func (s *PullReqFileViewStore) Upsert(ctx context.Context, view *types.PullReqFi
 	return nil
 }
 
+func (s *PullReqFileViewStore) upsertWithSeparateSelect(
+	ctx context.Context,
+	view *types.PullReqFileView,
+	sqlQueryUpsert string,
+) error {
+	const sqlQuerySelect = `
+	SELECT pullreq_file_view_created
+	FROM pullreq_file_views
+	WHERE pullreq_file_view_pullreq_id = $1
+		AND pullreq_file_view_principal_id = $2
+		AND pullreq_file_view_path = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQueryUpsert, mapToInternalPullreqFileView(view))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pullreq file view object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	err = db.QueryRowContext(ctx, sqlQuerySelect, view.PullReqID, view.PrincipalID, view.Path).
+		Scan(&view.Created)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to find upserted pullreq file view")
+	}
+
+	return nil
+}
+
 // DeleteByFileForPrincipal deletes the entry for the specified PR, principal, and file.
 func (s *PullReqFileViewStore) DeleteByFileForPrincipal(
 	ctx context.Context,
@@ -171,7 +213,7 @@ func (s *PullReqFileViewStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var dst []*pullReqFileView
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
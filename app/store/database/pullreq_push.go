@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqPushStore = (*PullReqPushStore)(nil)
+
+func NewPullReqPushStore(db *sqlx.DB) *PullReqPushStore {
+	return &PullReqPushStore{
+		db: db,
+	}
+}
+
+// PullReqPushStore implements store.PullReqPushStore backed by a relational database.
+type PullReqPushStore struct {
+	db *sqlx.DB
+}
+
+const (
+	pullReqPushColumns = `
+		 pullreq_push_pullreq_id
+		,pullreq_push_number
+		,pullreq_push_source_sha
+		,pullreq_push_merge_base_sha
+		,pullreq_push_forced
+		,pullreq_push_created_by
+		,pullreq_push_created`
+
+	pullReqPushSelectBase = `
+	SELECT` + pullReqPushColumns + `
+	FROM pullreq_pushes`
+)
+
+// Find returns the push of the pull request with the provided number.
+func (s *PullReqPushStore) Find(ctx context.Context, prID, number int64) (*types.PullReqPush, error) {
+	const sqlQuery = pullReqPushSelectBase + `
+	WHERE pullreq_push_pullreq_id = $1 AND pullreq_push_number = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.PullReqPush{}
+	if err := db.GetContext(ctx, result, sqlQuery, prID, number); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request push")
+	}
+
+	return result, nil
+}
+
+// FindBySourceSHA returns the latest push of the pull request with the provided source sha.
+func (s *PullReqPushStore) FindBySourceSHA(ctx context.Context, prID int64, sha string) (*types.PullReqPush, error) {
+	const sqlQuery = pullReqPushSelectBase + `
+	WHERE pullreq_push_pullreq_id = $1 AND pullreq_push_source_sha = $2
+	ORDER BY pullreq_push_number DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.PullReqPush{}
+	if err := db.GetContext(ctx, result, sqlQuery, prID, sha); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request push by source sha")
+	}
+
+	return result, nil
+}
+
+// FindLatest returns the latest push of the pull request.
+func (s *PullReqPushStore) FindLatest(ctx context.Context, prID int64) (*types.PullReqPush, error) {
+	const sqlQuery = pullReqPushSelectBase + `
+	WHERE pullreq_push_pullreq_id = $1
+	ORDER BY pullreq_push_number DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.PullReqPush{}
+	if err := db.GetContext(ctx, result, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find latest pull request push")
+	}
+
+	return result, nil
+}
+
+// Create records a new push, the number of the push is assigned by the store.
+func (s *PullReqPushStore) Create(ctx context.Context, push *types.PullReqPush) error {
+	// mysql doesn't support RETURNING, the number is selected before the insert instead.
+	if database.IsMySQL(s.db) {
+		return s.createWithSeparateSelect(ctx, push)
+	}
+
+	const sqlQuery = `
+		INSERT INTO pullreq_pushes (` + pullReqPushColumns + `
+		)
+		SELECT
+			 :pullreq_push_pullreq_id
+			,COALESCE(MAX(pullreq_push_number), 0) + 1
+			,:pullreq_push_source_sha
+			,:pullreq_push_merge_base_sha
+			,:pullreq_push_forced
+			,:pullreq_push_created_by
+			,:pullreq_push_created
+		FROM pullreq_pushes
+		WHERE pullreq_push_pullreq_id = :pullreq_push_pullreq_id
+		RETURNING pullreq_push_number`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, push)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request push object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&push.Number); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+func (s *PullReqPushStore) createWithSeparateSelect(ctx context.Context, push *types.PullReqPush) error {
+	const sqlQuerySelect = `
+		SELECT COALESCE(MAX(pullreq_push_number), 0) + 1
+		FROM pullreq_pushes
+		WHERE pullreq_push_pullreq_id = $1`
+
+	const sqlQueryInsert = `
+		INSERT INTO pullreq_pushes (` + pullReqPushColumns + `
+		) VALUES (
+			 :pullreq_push_pullreq_id
+			,:pullreq_push_number
+			,:pullreq_push_source_sha
+			,:pullreq_push_merge_base_sha
+			,:pullreq_push_forced
+			,:pullreq_push_created_by
+			,:pullreq_push_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err := db.QueryRowContext(ctx, sqlQuerySelect, push.PullReqID).Scan(&push.Number); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to find next pull request push number")
+	}
+
+	query, arg, err := db.BindNamed(sqlQueryInsert, push)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request push object")
+	}
+
+	// a concurrent push with the same number fails on the primary key and is reported as a duplicate.
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns all pushes of the pull request, ordered by number.
+func (s *PullReqPushStore) List(ctx context.Context, prID int64) ([]*types.PullReqPush, error) {
+	const sqlQuery = pullReqPushSelectBase + `
+	WHERE pullreq_push_pullreq_id = $1
+	ORDER BY pullreq_push_number ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := []*types.PullReqPush{}
+	if err := db.SelectContext(ctx, &result, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list pull request pushes")
+	}
+
+	return result, nil
+}
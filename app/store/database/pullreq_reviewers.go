@@ -64,6 +64,7 @@ type pullReqReviewer struct {
 
 	ReviewDecision enum.PullReqReviewDecision `db:"pullreq_reviewer_review_decision"`
 	SHA            string                     `db:"pullreq_reviewer_sha"`
+	Reminded       int64                      `db:"pullreq_reviewer_reminded"`
 }
 
 const (
@@ -77,7 +78,8 @@ const (
 		,pullreq_reviewer_type
 		,pullreq_reviewer_latest_review_id
 		,pullreq_reviewer_review_decision
-		,pullreq_reviewer_sha`
+		,pullreq_reviewer_sha
+		,pullreq_reviewer_reminded`
 
 	pullreqReviewerSelectBase = `
 	SELECT` + pullreqReviewerColumns + `
@@ -89,7 +91,7 @@ func (s *PullReqReviewerStore) Find(ctx context.Context, prID, principalID int64
 	const sqlQuery = pullreqReviewerSelectBase + `
 	WHERE pullreq_reviewer_pullreq_id = $1 AND pullreq_reviewer_principal_id = $2`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &pullReqReviewer{}
 	if err := db.GetContext(ctx, dst, sqlQuery, prID, principalID); err != nil {
@@ -174,6 +176,23 @@ func (s *PullReqReviewerStore) Update(ctx context.Context, v *types.PullReqRevie
 	return nil
 }
 
+// UpdateReminded sets the time the reviewer was last reminded about the pending review.
+func (s *PullReqReviewerStore) UpdateReminded(ctx context.Context, prID, principalID, reminded int64) error {
+	const sqlQuery = `
+	UPDATE pullreq_reviewers
+	SET pullreq_reviewer_reminded = $1
+	WHERE pullreq_reviewer_pullreq_id = $2 AND
+	      pullreq_reviewer_principal_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, reminded, prID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update pull request reviewer reminder time")
+	}
+
+	return nil
+}
+
 // Delete deletes the pull request reviewer.
 func (s *PullReqReviewerStore) Delete(ctx context.Context, prID, reviewerID int64) error {
 	const sqlQuery = `
@@ -205,7 +224,7 @@ func (s *PullReqReviewerStore) List(ctx context.Context, prID int64) ([]*types.P
 
 	dst := make([]*pullReqReviewer, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing pull request reviewer list query")
@@ -219,6 +238,45 @@ func (s *PullReqReviewerStore) List(ctx context.Context, prID int64) ([]*types.P
 	return result, nil
 }
 
+// ListPendingReminders returns the requested reviewers of open pull requests that haven't reviewed yet,
+// and were neither requested nor reminded after the provided time.
+func (s *PullReqReviewerStore) ListPendingReminders(
+	ctx context.Context,
+	before int64,
+) ([]*types.PullReqReviewer, error) {
+	stmt := database.Builder.
+		Select(pullreqReviewerColumns).
+		From("pullreq_reviewers").
+		Join("pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id").
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		Where("pullreq_is_draft = ?", false).
+		Where("pullreq_reviewer_review_decision = ?", enum.PullReqReviewDecisionPending).
+		Where("pullreq_reviewer_type <> ?", enum.PullReqReviewerTypeSelfAssigned).
+		Where("pullreq_reviewer_created < ?", before).
+		Where("pullreq_reviewer_reminded < ?", before).
+		OrderBy("pullreq_reviewer_repo_id", "pullreq_reviewer_pullreq_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pending reviewer reminders query to sql")
+	}
+
+	dst := make([]*pullReqReviewer, 0)
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing pending reviewer reminders query")
+	}
+
+	result := make([]*types.PullReqReviewer, len(dst))
+	for i, v := range dst {
+		result[i] = mapPullReqReviewer(v)
+	}
+
+	return result, nil
+}
+
 func mapPullReqReviewer(v *pullReqReviewer) *types.PullReqReviewer {
 	m := &types.PullReqReviewer{
 		PullReqID:      v.PullReqID,
@@ -231,6 +289,7 @@ func mapPullReqReviewer(v *pullReqReviewer) *types.PullReqReviewer {
 		LatestReviewID: v.LatestReviewID.Ptr(),
 		ReviewDecision: v.ReviewDecision,
 		SHA:            v.SHA,
+		Reminded:       v.Reminded,
 	}
 	return m
 }
@@ -247,6 +306,7 @@ func mapInternalPullReqReviewer(v *types.PullReqReviewer) *pullReqReviewer {
 		LatestReviewID: null.IntFromPtr(v.LatestReviewID),
 		ReviewDecision: v.ReviewDecision,
 		SHA:            v.SHA,
+		Reminded:       v.Reminded,
 	}
 	return m
 }
@@ -263,6 +323,7 @@ func (s *PullReqReviewerStore) mapPullReqReviewer(ctx context.Context, v *pullRe
 		LatestReviewID: v.LatestReviewID.Ptr(),
 		ReviewDecision: v.ReviewDecision,
 		SHA:            v.SHA,
+		Reminded:       v.Reminded,
 	}
 
 	addedBy, err := s.pCache.Get(ctx, v.CreatedBy)
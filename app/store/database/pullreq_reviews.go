@@ -74,7 +74,7 @@ func (s *PullReqReviewStore) Find(ctx context.Context, id int64) (*types.PullReq
 	const sqlQuery = pullreqReviewSelectBase + `
 	WHERE pullreq_review_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &pullReqReview{}
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -101,7 +101,7 @@ func (s *PullReqReviewStore) Create(ctx context.Context, v *types.PullReqReview)
 		,:pullreq_review_pullreq_id
 		,:pullreq_review_decision
 		,:pullreq_review_sha
-	) RETURNING pullreq_review_id`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -110,7 +110,7 @@ func (s *PullReqReviewStore) Create(ctx context.Context, v *types.PullReqReview)
 		return database.ProcessSQLErrorf(err, "Failed to bind pull request review object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&v.ID); err != nil {
+	if v.ID, err = database.InsertReturningID(ctx, db, query, "pullreq_review_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Failed to insert pull request review")
 	}
 
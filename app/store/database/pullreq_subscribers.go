@@ -0,0 +1,258 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.PullReqSubscriberStore = (*PullReqSubscriberStore)(nil)
+
+// NewPullReqSubscriberStore returns a new PullReqSubscriberStore.
+func NewPullReqSubscriberStore(db *sqlx.DB,
+	pCache store.PrincipalInfoCache) *PullReqSubscriberStore {
+	return &PullReqSubscriberStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// PullReqSubscriberStore implements store.PullReqSubscriberStore backed by a relational database.
+type PullReqSubscriberStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// pullReqSubscriber is used to fetch pull request subscriber data from the database.
+type pullReqSubscriber struct {
+	PullReqID   int64 `db:"pullreq_subscriber_pullreq_id"`
+	PrincipalID int64 `db:"pullreq_subscriber_principal_id"`
+	Subscribed  bool  `db:"pullreq_subscriber_subscribed"`
+	Created     int64 `db:"pullreq_subscriber_created"`
+	Updated     int64 `db:"pullreq_subscriber_updated"`
+}
+
+const (
+	pullreqSubscriberColumns = `
+		 pullreq_subscriber_pullreq_id
+		,pullreq_subscriber_principal_id
+		,pullreq_subscriber_subscribed
+		,pullreq_subscriber_created
+		,pullreq_subscriber_updated`
+
+	pullreqSubscriberInsert = `
+	INSERT INTO pullreq_subscribers (
+		 pullreq_subscriber_pullreq_id
+		,pullreq_subscriber_principal_id
+		,pullreq_subscriber_subscribed
+		,pullreq_subscriber_created
+		,pullreq_subscriber_updated
+	) VALUES (
+		 :pullreq_subscriber_pullreq_id
+		,:pullreq_subscriber_principal_id
+		,:pullreq_subscriber_subscribed
+		,:pullreq_subscriber_created
+		,:pullreq_subscriber_updated
+	)`
+
+	pullreqSubscriberOnConflict = `
+	ON CONFLICT (pullreq_subscriber_pullreq_id, pullreq_subscriber_principal_id) DO`
+)
+
+// Find returns the subscription of the principal to the pull request.
+func (s *PullReqSubscriberStore) Find(ctx context.Context,
+	prID, principalID int64) (*types.PullReqSubscriber, error) {
+	const sqlQuery = `
+	SELECT` + pullreqSubscriberColumns + `
+	FROM pullreq_subscribers
+	WHERE pullreq_subscriber_pullreq_id = $1 AND pullreq_subscriber_principal_id = $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &pullReqSubscriber{}
+	if err := db.GetContext(ctx, dst, sqlQuery, prID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request subscriber")
+	}
+
+	return s.mapPullReqSubscriber(ctx, dst), nil
+}
+
+// Upsert creates or overwrites the subscription of the principal to the pull request.
+func (s *PullReqSubscriberStore) Upsert(ctx context.Context, subscriber *types.PullReqSubscriber) error {
+	const sqlQueryUpdate = `
+		 pullreq_subscriber_subscribed = :pullreq_subscriber_subscribed
+		,pullreq_subscriber_updated = :pullreq_subscriber_updated`
+
+	// mysql doesn't support RETURNING, the creation time is selected after the upsert instead.
+	if database.IsMySQL(s.db) {
+		return s.upsertWithSeparateSelect(ctx, subscriber, pullreqSubscriberInsert+`
+	ON DUPLICATE KEY UPDATE`+sqlQueryUpdate)
+	}
+
+	const sqlQuery = pullreqSubscriberInsert + pullreqSubscriberOnConflict + `
+	UPDATE SET` + sqlQueryUpdate + `
+	RETURNING pullreq_subscriber_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPullReqSubscriber(subscriber))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request subscriber object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&subscriber.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func (s *PullReqSubscriberStore) upsertWithSeparateSelect(
+	ctx context.Context,
+	subscriber *types.PullReqSubscriber,
+	sqlQueryUpsert string,
+) error {
+	const sqlQuerySelect = `
+	SELECT pullreq_subscriber_created
+	FROM pullreq_subscribers
+	WHERE pullreq_subscriber_pullreq_id = $1 AND pullreq_subscriber_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQueryUpsert, mapInternalPullReqSubscriber(subscriber))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request subscriber object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	err = db.QueryRowContext(ctx, sqlQuerySelect, subscriber.PullReqID, subscriber.PrincipalID).
+		Scan(&subscriber.Created)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to find upserted pull request subscriber")
+	}
+
+	return nil
+}
+
+// CreateIfNotExists creates the subscription unless the principal already has one
+// (including an explicit unsubscription).
+func (s *PullReqSubscriberStore) CreateIfNotExists(ctx context.Context, subscriber *types.PullReqSubscriber) error {
+	sqlQuery := pullreqSubscriberInsert + pullreqSubscriberOnConflict + ` NOTHING`
+	if database.IsMySQL(s.db) {
+		sqlQuery = pullreqSubscriberInsert + `
+	ON DUPLICATE KEY UPDATE pullreq_subscriber_created = pullreq_subscriber_created`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPullReqSubscriber(subscriber))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request subscriber object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns all subscription entries of the pull request, including unsubscribed ones.
+func (s *PullReqSubscriberStore) List(ctx context.Context, prID int64) ([]*types.PullReqSubscriber, error) {
+	const sqlQuery = `
+	SELECT` + pullreqSubscriberColumns + `
+	FROM pullreq_subscribers
+	WHERE pullreq_subscriber_pullreq_id = $1
+	ORDER BY pullreq_subscriber_created ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*pullReqSubscriber, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing pull request subscriber list query")
+	}
+
+	return s.mapSlicePullReqSubscriber(ctx, dst)
+}
+
+func mapPullReqSubscriber(v *pullReqSubscriber) *types.PullReqSubscriber {
+	return &types.PullReqSubscriber{
+		PullReqID:   v.PullReqID,
+		PrincipalID: v.PrincipalID,
+		Subscribed:  v.Subscribed,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}
+}
+
+func mapInternalPullReqSubscriber(v *types.PullReqSubscriber) *pullReqSubscriber {
+	return &pullReqSubscriber{
+		PullReqID:   v.PullReqID,
+		PrincipalID: v.PrincipalID,
+		Subscribed:  v.Subscribed,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}
+}
+
+func (s *PullReqSubscriberStore) mapPullReqSubscriber(ctx context.Context,
+	v *pullReqSubscriber) *types.PullReqSubscriber {
+	m := mapPullReqSubscriber(v)
+
+	principal, err := s.pCache.Get(ctx, v.PrincipalID)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load PR subscriber principal")
+	}
+	if principal != nil {
+		m.Principal = *principal
+	}
+
+	return m
+}
+
+func (s *PullReqSubscriberStore) mapSlicePullReqSubscriber(ctx context.Context,
+	subscribers []*pullReqSubscriber) ([]*types.PullReqSubscriber, error) {
+	ids := make([]int64, len(subscribers))
+	for i, v := range subscribers {
+		ids[i] = v.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PR principal infos: %w", err)
+	}
+
+	m := make([]*types.PullReqSubscriber, len(subscribers))
+	for i, v := range subscribers {
+		m[i] = mapPullReqSubscriber(v)
+		if principal, ok := infoMap[v.PrincipalID]; ok {
+			m[i].Principal = *principal
+		}
+	}
+
+	return m, nil
+}
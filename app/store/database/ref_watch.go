@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RefWatchStore = (*RefWatchStore)(nil)
+
+const refWatchColumns = `
+	 ref_watch_id
+	,ref_watch_repo_id
+	,ref_watch_principal_id
+	,ref_watch_type
+	,ref_watch_pattern
+	,ref_watch_created`
+
+// NewRefWatchStore returns a new RefWatchStore.
+func NewRefWatchStore(db *sqlx.DB) *RefWatchStore {
+	return &RefWatchStore{
+		db: db,
+	}
+}
+
+// RefWatchStore implements store.RefWatchStore backed by a relational database.
+type RefWatchStore struct {
+	db *sqlx.DB
+}
+
+// Find finds the ref watch by id.
+func (s *RefWatchStore) Find(ctx context.Context, id int64) (*types.RefWatch, error) {
+	const sqlQuery = `
+		SELECT` + refWatchColumns + `
+		FROM ref_watches
+		WHERE ref_watch_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.RefWatch{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find ref watch")
+	}
+
+	return dst, nil
+}
+
+// Create creates a new ref watch.
+func (s *RefWatchStore) Create(ctx context.Context, watch *types.RefWatch) error {
+	const sqlQuery = `
+		INSERT INTO ref_watches (
+			 ref_watch_repo_id
+			,ref_watch_principal_id
+			,ref_watch_type
+			,ref_watch_pattern
+			,ref_watch_created
+		) values (
+			 :ref_watch_repo_id
+			,:ref_watch_principal_id
+			,:ref_watch_type
+			,:ref_watch_pattern
+			,:ref_watch_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, watch)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind ref watch object")
+	}
+
+	if watch.ID, err = database.InsertReturningID(ctx, db, query, "ref_watch_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the ref watch with the provided id.
+func (s *RefWatchStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM ref_watches
+		WHERE ref_watch_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete ref watch")
+	}
+
+	return nil
+}
+
+// List returns the ref watches of the principal in the repository.
+func (s *RefWatchStore) List(ctx context.Context, repoID, principalID int64) ([]*types.RefWatch, error) {
+	const sqlQuery = `
+		SELECT` + refWatchColumns + `
+		FROM ref_watches
+		WHERE ref_watch_repo_id = $1 AND ref_watch_principal_id = $2
+		ORDER BY ref_watch_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.RefWatch, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list ref watches")
+	}
+
+	return dst, nil
+}
+
+// ListByType returns all ref watches of the repository for the provided type of refs.
+func (s *RefWatchStore) ListByType(
+	ctx context.Context,
+	repoID int64,
+	refWatchType enum.RefWatchType,
+) ([]*types.RefWatch, error) {
+	const sqlQuery = `
+		SELECT` + refWatchColumns + `
+		FROM ref_watches
+		WHERE ref_watch_repo_id = $1 AND ref_watch_type = $2
+		ORDER BY ref_watch_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.RefWatch, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, refWatchType); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list ref watches by type")
+	}
+
+	return dst, nil
+}
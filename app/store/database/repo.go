@@ -17,6 +17,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,22 +30,29 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 )
 
 var _ store.RepoStore = (*RepoStore)(nil)
 
+// repoIDBatchSize is the max number of repo ids queried at once to stay below the parameter limits of the DBs.
+const repoIDBatchSize = 500
+
 // NewRepoStore returns a new RepoStore.
 func NewRepoStore(
 	db *sqlx.DB,
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
+	evictor store.RepoEvictor,
 ) *RepoStore {
 	return &RepoStore{
 		db:             db,
 		spacePathCache: spacePathCache,
 		spacePathStore: spacePathStore,
+		evictor:        evictor,
 	}
 }
 
@@ -53,6 +61,7 @@ type RepoStore struct {
 	db             *sqlx.DB
 	spacePathCache store.SpacePathCache
 	spacePathStore store.SpacePathStore
+	evictor        store.RepoEvictor
 }
 
 type repository struct {
@@ -79,6 +88,11 @@ type repository struct {
 	NumMergedPulls int `db:"repo_num_merged_pulls"`
 
 	Importing bool `db:"repo_importing"`
+
+	Size        int64 `db:"repo_size"`
+	SizeUpdated int64 `db:"repo_size_updated"`
+
+	Deleted null.Int `db:"repo_deleted"`
 }
 
 const (
@@ -101,7 +115,10 @@ const (
 		,repo_num_closed_pulls
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
-		,repo_importing`
+		,repo_importing
+		,repo_size
+		,repo_size_updated
+		,repo_deleted`
 
 	repoSelectBase = `
 		SELECT` + repoColumnsForJoin + `
@@ -111,9 +128,9 @@ const (
 // Find finds the repo by id.
 func (s *RepoStore) Find(ctx context.Context, id int64) (*types.Repository, error) {
 	const sqlQuery = repoSelectBase + `
-		WHERE repo_id = $1`
+		WHERE repo_id = $1 AND repo_deleted IS NULL`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(repository)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -126,9 +143,9 @@ func (s *RepoStore) Find(ctx context.Context, id int64) (*types.Repository, erro
 // Find finds the repo with the given UID in the given space ID.
 func (s *RepoStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Repository, error) {
 	const sqlQuery = repoSelectBase + `
-		WHERE repo_parent_id = $1 AND LOWER(repo_uid) = $2`
+		WHERE repo_parent_id = $1 AND LOWER(repo_uid) = $2 AND repo_deleted IS NULL`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(repository)
 	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(uid)); err != nil {
@@ -158,6 +175,40 @@ func (s *RepoStore) FindByRef(ctx context.Context, repoRef string) (*types.Repos
 	return s.Find(ctx, id)
 }
 
+// FindByRefAndDeleted finds the repo deleted at the provided time
+// using the repoRef as either the id or the repo path.
+func (s *RepoStore) FindByRefAndDeleted(ctx context.Context, repoRef string, deleted int64) (*types.Repository, error) {
+	const sqlQueryID = repoSelectBase + `
+		WHERE repo_id = $1 AND repo_deleted = $2`
+	const sqlQueryUID = repoSelectBase + `
+		WHERE repo_parent_id = $1 AND LOWER(repo_uid) = $2 AND repo_deleted = $3`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+	dst := new(repository)
+
+	// ASSUMPTION: digits only is not a valid repo path
+	id, err := strconv.ParseInt(repoRef, 10, 64)
+	if err != nil {
+		spacePath, repoUID, err := paths.DisectLeaf(repoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to disect leaf for path '%s': %w", repoRef, err)
+		}
+		pathObject, err := s.spacePathCache.Get(ctx, spacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space path: %w", err)
+		}
+
+		err = db.GetContext(ctx, dst, sqlQueryUID, pathObject.SpaceID, strings.ToLower(repoUID), deleted)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Failed to find deleted repo")
+		}
+	} else if err = db.GetContext(ctx, dst, sqlQueryID, id, deleted); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find deleted repo")
+	}
+
+	return s.mapToRepo(ctx, dst)
+}
+
 // Create creates a new repository.
 func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 	const sqlQuery = `
@@ -199,7 +250,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_num_open_pulls
 			,:repo_num_merged_pulls
 			,:repo_importing
-		) RETURNING repo_id`
+		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -209,7 +260,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind repo object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&repo.ID); err != nil {
+	if repo.ID, err = database.InsertReturningID(ctx, db, query, "repo_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -270,6 +321,8 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 		return gitness_store.ErrVersionConflict
 	}
 
+	s.evictor.Evict(ctx, repo.ID)
+
 	repo.Version = dbRepo.Version
 	repo.Updated = dbRepo.Updated
 
@@ -310,6 +363,114 @@ func (s *RepoStore) UpdateOptLock(ctx context.Context,
 	}
 }
 
+// UpdateSize updates the storage size of the repository.
+// It doesn't change the version of the repository as it's not a user facing change.
+func (s *RepoStore) UpdateSize(ctx context.Context, id int64, size int64) error {
+	const sqlQuery = `
+		UPDATE repositories
+		SET
+			 repo_size = $1
+			,repo_size_updated = $2
+		WHERE repo_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, size, time.Now().UnixMilli(), id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update repository size")
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// SoftDelete moves the repository to the trash.
+func (s *RepoStore) SoftDelete(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		UPDATE repositories
+		SET
+			 repo_version = repo_version + 1
+			,repo_deleted = $1
+		WHERE repo_id = $2 AND repo_deleted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, deletedAt, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to soft delete repository")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// Restore restores the repository deleted at the provided time using the provided uid.
+func (s *RepoStore) Restore(ctx context.Context, id int64, deletedAt int64, uid string) error {
+	const sqlQuery = `
+		UPDATE repositories
+		SET
+			 repo_version = repo_version + 1
+			,repo_updated = $1
+			,repo_uid = $2
+			,repo_deleted = NULL
+		WHERE repo_id = $3 AND repo_deleted = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), uid, id, deletedAt)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to restore repository")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// Purge deletes the repository deleted at the provided time permanently.
+func (s *RepoStore) Purge(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		DELETE FROM repositories
+		WHERE repo_id = $1 AND repo_deleted = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id, deletedAt)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "the purge query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
 // Delete the repository.
 func (s *RepoStore) Delete(ctx context.Context, id int64) error {
 	const repoDelete = `
@@ -322,6 +483,8 @@ func (s *RepoStore) Delete(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(err, "the delete query failed")
 	}
 
+	s.evictor.Evict(ctx, id)
+
 	return nil
 }
 
@@ -329,7 +492,8 @@ func (s *RepoStore) Delete(ctx context.Context, id int64) error {
 func (s *RepoStore) Count(ctx context.Context, parentID int64, opts *types.RepoFilter) (int64, error) {
 	stmt := database.Builder.
 		Select("count(*)").
-		From("repositories")
+		From("repositories").
+		Where("repo_deleted IS NULL")
 
 	if parentID > 0 {
 		stmt = stmt.Where("repo_parent_id = ?", parentID)
@@ -344,7 +508,7 @@ func (s *RepoStore) Count(ctx context.Context, parentID int64, opts *types.RepoF
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -359,7 +523,8 @@ func (s *RepoStore) List(ctx context.Context, parentID int64, opts *types.RepoFi
 	stmt := database.Builder.
 		Select(repoColumnsForJoin).
 		From("repositories").
-		Where("repo_parent_id = ?", fmt.Sprint(parentID))
+		Where("repo_parent_id = ?", fmt.Sprint(parentID)).
+		Where("repo_deleted IS NULL")
 
 	if opts.Query != "" {
 		stmt = stmt.Where("LOWER(repo_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
@@ -385,7 +550,7 @@ func (s *RepoStore) List(ctx context.Context, parentID int64, opts *types.RepoFi
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*repository{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -395,6 +560,159 @@ func (s *RepoStore) List(ctx context.Context, parentID int64, opts *types.RepoFi
 	return s.mapToRepos(ctx, dst)
 }
 
+// ListByParentIDs returns all repositories of the provided spaces.
+func (s *RepoStore) ListByParentIDs(ctx context.Context, parentIDs []int64) ([]*types.Repository, error) {
+	if len(parentIDs) == 0 {
+		return []*types.Repository{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where(squirrel.Eq{"repo_parent_id": parentIDs}).
+		Where("repo_deleted IS NULL").
+		OrderBy("repo_parent_id, repo_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list by parent ids query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListByIDs returns all (not deleted) repositories with the provided ids ordered by path.
+func (s *RepoStore) ListByIDs(ctx context.Context, ids []int64) ([]*types.Repository, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	res := []*types.Repository{}
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > repoIDBatchSize {
+			batch = batch[:repoIDBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		stmt := database.Builder.
+			Select(repoColumnsForJoin).
+			From("repositories").
+			Where(squirrel.Eq{"repo_id": batch}).
+			Where("repo_deleted IS NULL")
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert query to sql")
+		}
+
+		dst := []*repository{}
+		if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Failed executing list by ids query")
+		}
+
+		repos, err := s.mapToRepos(ctx, dst)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, repos...)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+
+	return res, nil
+}
+
+// ListDeleted returns the deleted repositories of a space, most recently deleted first.
+func (s *RepoStore) ListDeleted(ctx context.Context, parentID int64) ([]*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		WHERE repo_parent_id = $1 AND repo_deleted IS NOT NULL
+		ORDER BY repo_deleted DESC, repo_uid ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, parentID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list deleted query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListDeletedByParentIDs returns all repositories of the provided spaces that were deleted at the provided time.
+func (s *RepoStore) ListDeletedByParentIDs(
+	ctx context.Context,
+	parentIDs []int64,
+	deletedAt int64,
+) ([]*types.Repository, error) {
+	if len(parentIDs) == 0 {
+		return []*types.Repository{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where(squirrel.Eq{"repo_parent_id": parentIDs}).
+		Where("repo_deleted = ?", deletedAt).
+		OrderBy("repo_parent_id, repo_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list deleted by parent ids query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListDeletedBefore returns up to limit repositories of all spaces that were deleted before the provided time.
+func (s *RepoStore) ListDeletedBefore(ctx context.Context, deletedBefore int64, limit int) ([]*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		WHERE repo_deleted < $1
+		ORDER BY repo_deleted ASC, repo_id ASC
+		LIMIT $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, deletedBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list deleted before query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListAll returns up to limit non-deleted repositories of all spaces with an id greater than afterID.
+func (s *RepoStore) ListAll(ctx context.Context, afterID int64, limit int) ([]*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		WHERE repo_deleted IS NULL AND repo_id > $1
+		ORDER BY repo_id ASC
+		LIMIT $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, afterID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list all query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 func (s *RepoStore) mapToRepo(
 	ctx context.Context,
 	in *repository,
@@ -420,10 +738,18 @@ func (s *RepoStore) mapToRepo(
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
 		Importing:      in.Importing,
+		Size:           in.Size,
+		SizeUpdated:    in.SizeUpdated,
+		Deleted:        in.Deleted.Ptr(),
 		// Path: is set below
 	}
 
-	res.Path, err = s.getRepoPath(ctx, in.ParentID, in.UID)
+	// the parent of a deleted repository might be deleted as well and has no primary path anymore.
+	if in.Deleted.Valid {
+		res.Path, err = getDeletedRepoPath(ctx, s.db, in.ParentID, in.UID)
+	} else {
+		res.Path, err = s.getRepoPath(ctx, in.ParentID, in.UID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -431,6 +757,16 @@ func (s *RepoStore) mapToRepo(
 	return res, nil
 }
 
+// getDeletedRepoPath returns the path of a deleted repository using the spaces table,
+// as its parent might be deleted as well and have no primary path anymore.
+func getDeletedRepoPath(ctx context.Context, db *sqlx.DB, parentID int64, repoUID string) (string, error) {
+	spacePath, err := getDeletedSpacePath(ctx, db, parentID)
+	if err != nil {
+		return "", err
+	}
+	return paths.Concatinate(spacePath, repoUID), nil
+}
+
 func (s *RepoStore) getRepoPath(ctx context.Context, parentID int64, repoUID string) (string, error) {
 	spacePath, err := s.spacePathStore.FindPrimaryBySpaceID(ctx, parentID)
 	if err != nil {
@@ -475,5 +811,8 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
 		Importing:      in.Importing,
+		Size:           in.Size,
+		SizeUpdated:    in.SizeUpdated,
+		Deleted:        null.IntFromPtr(in.Deleted),
 	}
 }
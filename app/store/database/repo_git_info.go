@@ -45,7 +45,7 @@ func (s *RepoGitInfoView) Find(ctx context.Context, id int64) (*types.Repository
 		FROM repositories
 		WHERE repo_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	v := db.QueryRowContext(ctx, sqlQuery, id)
 	if err := v.Err(); err != nil {
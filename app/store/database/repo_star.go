@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoStarStore = (*RepoStarStore)(nil)
+
+// NewRepoStarStore returns a new RepoStarStore.
+func NewRepoStarStore(db *sqlx.DB) *RepoStarStore {
+	return &RepoStarStore{
+		db: db,
+	}
+}
+
+// RepoStarStore implements store.RepoStarStore backed by a relational database.
+type RepoStarStore struct {
+	db *sqlx.DB
+}
+
+// Star marks the repository as starred by the principal, starring a repository twice is a no-op.
+func (s *RepoStarStore) Star(ctx context.Context, repoID, principalID int64) error {
+	const sqlQueryInsert = `
+		INSERT INTO repo_stars (
+			 repo_star_repo_id
+			,repo_star_principal_id
+			,repo_star_created
+		) VALUES (
+			 :repo_star_repo_id
+			,:repo_star_principal_id
+			,:repo_star_created
+		)`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT DO NOTHING`
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE repo_star_created = repo_star_created`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &types.RepoStar{
+		RepoID:      repoID,
+		PrincipalID: principalID,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repo star object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Unstar removes the star of the principal from the repository.
+func (s *RepoStarStore) Unstar(ctx context.Context, repoID, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM repo_stars
+		WHERE repo_star_repo_id = $1 AND repo_star_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repo star")
+	}
+
+	return nil
+}
+
+// IsStarred returns whether the repository is starred by the principal.
+func (s *RepoStarStore) IsStarred(ctx context.Context, repoID, principalID int64) (bool, error) {
+	const sqlQuery = `
+		SELECT count(*)
+		FROM repo_stars
+		WHERE repo_star_repo_id = $1 AND repo_star_principal_id = $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID, principalID).Scan(&count); err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count > 0, nil
+}
+
+// ListRepoIDs returns the ids of all repositories starred by the principal.
+func (s *RepoStarStore) ListRepoIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	const sqlQuery = `
+		SELECT repo_star_repo_id
+		FROM repo_stars
+		WHERE repo_star_principal_id = $1
+		ORDER BY repo_star_repo_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list starred repo ids")
+	}
+
+	return dst, nil
+}
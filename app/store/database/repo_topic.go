@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoTopicStore = (*RepoTopicStore)(nil)
+
+// NewRepoTopicStore returns a new RepoTopicStore.
+func NewRepoTopicStore(db *sqlx.DB) *RepoTopicStore {
+	return &RepoTopicStore{
+		db: db,
+	}
+}
+
+// RepoTopicStore implements store.RepoTopicStore backed by a relational database.
+type RepoTopicStore struct {
+	db *sqlx.DB
+}
+
+// List returns the topics of the repository.
+func (s *RepoTopicStore) List(ctx context.Context, repoID int64) ([]string, error) {
+	const sqlQuery = `
+		SELECT repo_topic_topic
+		FROM repo_topics
+		WHERE repo_topic_repo_id = $1
+		ORDER BY repo_topic_topic`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]string, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list repo topics")
+	}
+
+	return dst, nil
+}
+
+// Set replaces the topics of the repository.
+func (s *RepoTopicStore) Set(ctx context.Context, repoID int64, topics []string) error {
+	const sqlQueryDelete = `
+		DELETE FROM repo_topics
+		WHERE repo_topic_repo_id = $1`
+
+	const sqlQueryInsert = `
+		INSERT INTO repo_topics (
+			 repo_topic_repo_id
+			,repo_topic_topic
+			,repo_topic_created
+		) VALUES (
+			 :repo_topic_repo_id
+			,:repo_topic_topic
+			,:repo_topic_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repo topics")
+	}
+
+	now := time.Now().UnixMilli()
+	for _, topic := range topics {
+		query, arg, err := db.BindNamed(sqlQueryInsert, &types.RepoTopic{
+			RepoID:  repoID,
+			Topic:   topic,
+			Created: now,
+		})
+		if err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to bind repo topic object")
+		}
+
+		if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+			return database.ProcessSQLErrorf(err, "Insert query failed")
+		}
+	}
+
+	return nil
+}
+
+// ListAll returns the topics of all repositories that contain the query (if provided).
+func (s *RepoTopicStore) ListAll(ctx context.Context, query string) ([]*types.RepoTopic, error) {
+	stmt := database.Builder.
+		Select("repo_topic_repo_id, repo_topic_topic, repo_topic_created").
+		From("repo_topics").
+		InnerJoin("repositories ON repo_id = repo_topic_repo_id").
+		Where("repo_deleted IS NULL").
+		OrderBy("repo_topic_topic, repo_topic_repo_id")
+
+	if query != "" {
+		stmt = stmt.Where("repo_topic_topic LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.RepoTopic{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list all repo topics")
+	}
+
+	return dst, nil
+}
+
+// ListRepoIDs returns the ids of all repositories with the topic.
+func (s *RepoTopicStore) ListRepoIDs(ctx context.Context, topic string) ([]int64, error) {
+	const sqlQuery = `
+		SELECT repo_topic_repo_id
+		FROM repo_topics
+		WHERE repo_topic_topic = $1
+		ORDER BY repo_topic_repo_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, topic); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list repo ids by topic")
+	}
+
+	return dst, nil
+}
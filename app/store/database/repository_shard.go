@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepositoryShardStore = (*RepositoryShardStore)(nil)
+
+func NewRepositoryShardStore(db *sqlx.DB) *RepositoryShardStore {
+	return &RepositoryShardStore{
+		db: db,
+	}
+}
+
+// RepositoryShardStore stores the routing table of the repositories to the shards storing them.
+// It always uses the primary database, as a newly assigned shard has to be visible right away.
+type RepositoryShardStore struct {
+	db *sqlx.DB
+}
+
+// Find returns the shard of the repository with the provided git uid.
+func (s *RepositoryShardStore) Find(ctx context.Context, gitUID string) (*types.RepositoryShard, error) {
+	const sqlQuery = `
+	SELECT
+		 repository_shard_git_uid
+		,repository_shard_name
+		,repository_shard_created
+	FROM repository_shards
+	WHERE repository_shard_git_uid = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.RepositoryShard{}
+	if err := db.GetContext(ctx, result, sqlQuery, gitUID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository shard")
+	}
+
+	return result, nil
+}
+
+// Create persists the shard of a repository, unless the repository is already assigned to a shard.
+func (s *RepositoryShardStore) Create(ctx context.Context, repoShard *types.RepositoryShard) error {
+	const sqlQueryInsert = `
+		INSERT INTO repository_shards (
+			 repository_shard_git_uid
+			,repository_shard_name
+			,repository_shard_created
+		) VALUES (
+			 :repository_shard_git_uid
+			,:repository_shard_name
+			,:repository_shard_created
+		)`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT (repository_shard_git_uid) DO NOTHING`
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE repository_shard_git_uid = repository_shard_git_uid`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, repoShard)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository shard object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert repository shard")
+	}
+
+	return nil
+}
+
+// CountByShard returns the number of repositories stored on each shard.
+func (s *RepositoryShardStore) CountByShard(ctx context.Context) (map[string]int64, error) {
+	const sqlQuery = `
+	SELECT repository_shard_name, count(*)
+	FROM repository_shards
+	GROUP BY repository_shard_name`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sqlQuery)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to count repositories by shard")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]int64)
+	for rows.Next() {
+		var (
+			shard string
+			count int64
+		)
+		if err = rows.Scan(&shard, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Failed to scan repository shard count")
+		}
+		result[shard] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to count repositories by shard")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.SavedReplyStore = (*SavedReplyStore)(nil)
+
+const (
+	savedReplyColumns = `
+		 saved_reply_id
+		,saved_reply_principal_id
+		,saved_reply_identifier
+		,saved_reply_content
+		,saved_reply_created
+		,saved_reply_updated`
+
+	savedReplySelectBase = `
+	SELECT` + savedReplyColumns + `
+	FROM saved_replies`
+)
+
+// NewSavedReplyStore returns a new SavedReplyStore.
+func NewSavedReplyStore(db *sqlx.DB) *SavedReplyStore {
+	return &SavedReplyStore{
+		db: db,
+	}
+}
+
+// SavedReplyStore implements a store.SavedReplyStore backed by a relational database.
+type SavedReplyStore struct {
+	db *sqlx.DB
+}
+
+// Find finds the saved reply by id.
+func (s *SavedReplyStore) Find(ctx context.Context, id int64) (*types.SavedReply, error) {
+	const sqlQuery = savedReplySelectBase + `
+		WHERE saved_reply_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.SavedReply{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find saved reply")
+	}
+
+	return dst, nil
+}
+
+// Create creates a new saved reply.
+func (s *SavedReplyStore) Create(ctx context.Context, reply *types.SavedReply) error {
+	const sqlQuery = `
+		INSERT INTO saved_replies (
+			 saved_reply_principal_id
+			,saved_reply_identifier
+			,saved_reply_content
+			,saved_reply_created
+			,saved_reply_updated
+		) VALUES (
+			 :saved_reply_principal_id
+			,:saved_reply_identifier
+			,:saved_reply_content
+			,:saved_reply_created
+			,:saved_reply_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, reply)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind saved reply object")
+	}
+
+	if reply.ID, err = database.InsertReturningID(ctx, db, query, "saved_reply_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the identifier and the content of the saved reply.
+func (s *SavedReplyStore) Update(ctx context.Context, reply *types.SavedReply) error {
+	const sqlQuery = `
+		UPDATE saved_replies
+		SET
+			 saved_reply_identifier = :saved_reply_identifier
+			,saved_reply_content = :saved_reply_content
+			,saved_reply_updated = :saved_reply_updated
+		WHERE saved_reply_id = :saved_reply_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, reply)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind saved reply object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update saved reply")
+	}
+
+	return nil
+}
+
+// Delete deletes the saved reply with the given id.
+func (s *SavedReplyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM saved_replies
+		WHERE saved_reply_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete saved reply")
+	}
+
+	return nil
+}
+
+// List returns the saved replies of the principal, ordered by identifier.
+func (s *SavedReplyStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter types.ListQueryFilter,
+) ([]*types.SavedReply, error) {
+	stmt := database.Builder.
+		Select(savedReplyColumns).
+		From("saved_replies").
+		Where("saved_reply_principal_id = ?", principalID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	stmt = stmt.OrderBy("LOWER(saved_reply_identifier)")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.SavedReply, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list saved replies")
+	}
+
+	return dst, nil
+}
+
+// Count returns the number of saved replies of the principal.
+func (s *SavedReplyStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("saved_replies").
+		Where("saved_reply_principal_id = ?", principalID)
+
+	stmt = s.applyQuery(stmt, filter.Query)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to count saved replies")
+	}
+
+	return count, nil
+}
+
+func (s *SavedReplyStore) applyQuery(stmt squirrel.SelectBuilder, query string) squirrel.SelectBuilder {
+	if query == "" {
+		return stmt
+	}
+
+	return stmt.Where("LOWER(saved_reply_identifier) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SBOMStore = (*SBOMStore)(nil)
+
+// NewSBOMStore returns a new SBOMStore.
+func NewSBOMStore(db *sqlx.DB) *SBOMStore {
+	return &SBOMStore{
+		db: db,
+	}
+}
+
+// SBOMStore implements store.SBOMStore backed by a relational database.
+type SBOMStore struct {
+	db *sqlx.DB
+}
+
+// sbom is an internal representation used to store SBOM data in the database.
+type sbom struct {
+	ID        int64           `db:"sbom_id"`
+	RepoID    int64           `db:"sbom_repo_id"`
+	Tag       string          `db:"sbom_tag"`
+	CommitSHA string          `db:"sbom_commit_sha"`
+	Format    enum.SBOMFormat `db:"sbom_format"`
+	Size      int64           `db:"sbom_size"`
+	Created   int64           `db:"sbom_created"`
+}
+
+const (
+	sbomColumns = `
+		 sbom_id
+		,sbom_repo_id
+		,sbom_tag
+		,sbom_commit_sha
+		,sbom_format
+		,sbom_size
+		,sbom_created`
+
+	sbomSelectBase = `
+	SELECT` + sbomColumns + `
+	FROM sboms`
+)
+
+// Find finds the SBOM by id.
+func (s *SBOMStore) Find(ctx context.Context, id int64) (*types.SBOM, error) {
+	const sqlQuery = sbomSelectBase + `
+		WHERE sbom_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &sbom{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToSBOM(dst), nil
+}
+
+// Upsert creates the SBOM or replaces the existing SBOM of the same tag and format.
+func (s *SBOMStore) Upsert(ctx context.Context, sbom *types.SBOM) error {
+	const sqlQueryInsert = `
+	INSERT INTO sboms (
+		 sbom_repo_id
+		,sbom_tag
+		,sbom_commit_sha
+		,sbom_format
+		,sbom_size
+		,sbom_created
+	) VALUES (
+		 :sbom_repo_id
+		,:sbom_tag
+		,:sbom_commit_sha
+		,:sbom_format
+		,:sbom_size
+		,:sbom_created
+	)`
+
+	const sqlQueryUpdate = `
+		 sbom_commit_sha = :sbom_commit_sha
+		,sbom_size = :sbom_size
+		,sbom_created = :sbom_created`
+
+	sqlQuery := sqlQueryInsert + `
+	ON CONFLICT (sbom_repo_id, sbom_tag, sbom_format) DO
+	UPDATE SET` + sqlQueryUpdate
+
+	// LAST_INSERT_ID(expr) makes the id of an updated row available as the id generated by the insert.
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+	ON DUPLICATE KEY UPDATE
+		sbom_id = LAST_INSERT_ID(sbom_id),` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSBOM(sbom))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind sbom object")
+	}
+
+	if sbom.ID, err = database.InsertReturningID(ctx, db, query, "sbom_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the SBOM with the given id.
+func (s *SBOMStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM sboms
+		WHERE sbom_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns the SBOMs of a repository, optionally limited to a single tag.
+func (s *SBOMStore) List(ctx context.Context, repoID int64, tag string) ([]*types.SBOM, error) {
+	stmt := database.Builder.
+		Select(sbomColumns).
+		From("sboms").
+		Where("sbom_repo_id = ?", repoID)
+
+	if tag != "" {
+		stmt = stmt.Where("sbom_tag = ?", tag)
+	}
+
+	stmt = stmt.OrderBy("sbom_created DESC", "sbom_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*sbom{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res := make([]*types.SBOM, len(dst))
+	for i := range dst {
+		res[i] = mapToSBOM(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToSBOM(in *sbom) *types.SBOM {
+	return &types.SBOM{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Tag:       in.Tag,
+		CommitSHA: in.CommitSHA,
+		Format:    in.Format,
+		Size:      in.Size,
+		Created:   in.Created,
+	}
+}
+
+func mapToInternalSBOM(in *types.SBOM) *sbom {
+	return &sbom{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Tag:       in.Tag,
+		CommitSHA: in.CommitSHA,
+		Format:    in.Format,
+		Size:      in.Size,
+		Created:   in.Created,
+	}
+}
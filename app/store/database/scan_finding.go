@@ -0,0 +1,299 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ScanFindingStore = (*ScanFindingStore)(nil)
+
+// NewScanFindingStore returns a new ScanFindingStore.
+func NewScanFindingStore(db *sqlx.DB) *ScanFindingStore {
+	return &ScanFindingStore{
+		db: db,
+	}
+}
+
+// ScanFindingStore implements store.ScanFindingStore backed by a relational database.
+type ScanFindingStore struct {
+	db *sqlx.DB
+}
+
+// scanFinding is an internal representation used to store scan finding data in the database.
+type scanFinding struct {
+	ID              int64             `db:"scan_finding_id"`
+	RepoID          int64             `db:"scan_finding_repo_id"`
+	CommitSHA       string            `db:"scan_finding_commit_sha"`
+	Scanner         string            `db:"scan_finding_scanner"`
+	Kind            enum.ScanKind     `db:"scan_finding_kind"`
+	VulnerabilityID string            `db:"scan_finding_vulnerability_id"`
+	Package         string            `db:"scan_finding_package"`
+	Version         string            `db:"scan_finding_version"`
+	FixedVersion    string            `db:"scan_finding_fixed_version"`
+	Severity        enum.ScanSeverity `db:"scan_finding_severity"`
+	Title           string            `db:"scan_finding_title"`
+	Path            string            `db:"scan_finding_path"`
+	URL             string            `db:"scan_finding_url"`
+	Created         int64             `db:"scan_finding_created"`
+	Suppressed      bool              `db:"scan_finding_suppressed"`
+}
+
+const (
+	scanFindingColumns = `
+		 scan_finding_id
+		,scan_finding_repo_id
+		,scan_finding_commit_sha
+		,scan_finding_scanner
+		,scan_finding_kind
+		,scan_finding_vulnerability_id
+		,scan_finding_package
+		,scan_finding_version
+		,scan_finding_fixed_version
+		,scan_finding_severity
+		,scan_finding_title
+		,scan_finding_path
+		,scan_finding_url
+		,scan_finding_created`
+
+	// scanFindingSuppressed is true if an active suppression of the repository matches the finding.
+	scanFindingSuppressed = `EXISTS (
+		SELECT 1
+		FROM scan_suppressions
+		WHERE scan_suppression_repo_id = scan_finding_repo_id
+			AND scan_suppression_vulnerability_id = scan_finding_vulnerability_id
+			AND (scan_suppression_package = '' OR scan_suppression_package = scan_finding_package)
+			AND (scan_suppression_expires = 0 OR scan_suppression_expires > ?))`
+
+	// scanFindingSeverityRank orders findings from the most to the least severe.
+	scanFindingSeverityRank = `CASE scan_finding_severity
+		WHEN 'critical' THEN 4
+		WHEN 'high' THEN 3
+		WHEN 'medium' THEN 2
+		WHEN 'low' THEN 1
+		ELSE 0 END`
+)
+
+// Replace replaces the findings of a scanner for a commit of a repository.
+func (s *ScanFindingStore) Replace(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	scanner string,
+	findings []*types.ScanFinding,
+) error {
+	const sqlQueryDelete = `
+		DELETE FROM scan_findings
+		WHERE scan_finding_repo_id = $1
+			AND scan_finding_commit_sha = $2
+			AND scan_finding_scanner = $3`
+
+	const sqlQueryInsert = `
+		INSERT INTO scan_findings (
+			 scan_finding_repo_id
+			,scan_finding_commit_sha
+			,scan_finding_scanner
+			,scan_finding_kind
+			,scan_finding_vulnerability_id
+			,scan_finding_package
+			,scan_finding_version
+			,scan_finding_fixed_version
+			,scan_finding_severity
+			,scan_finding_title
+			,scan_finding_path
+			,scan_finding_url
+			,scan_finding_created
+		) values (
+			 :scan_finding_repo_id
+			,:scan_finding_commit_sha
+			,:scan_finding_scanner
+			,:scan_finding_kind
+			,:scan_finding_vulnerability_id
+			,:scan_finding_package
+			,:scan_finding_version
+			,:scan_finding_fixed_version
+			,:scan_finding_severity
+			,:scan_finding_title
+			,:scan_finding_path
+			,:scan_finding_url
+			,:scan_finding_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, repoID, commitSHA, scanner); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete previous scan findings")
+	}
+
+	for _, finding := range findings {
+		finding.RepoID = repoID
+		finding.CommitSHA = commitSHA
+		finding.Scanner = scanner
+
+		query, arg, err := db.BindNamed(sqlQueryInsert, mapToInternalScanFinding(finding))
+		if err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to bind scan finding object")
+		}
+
+		if finding.ID, err = database.InsertReturningID(ctx, db, query, "scan_finding_id", arg...); err != nil {
+			return database.ProcessSQLErrorf(err, "Insert query failed")
+		}
+	}
+
+	return nil
+}
+
+// List returns the findings of a repository matching the filter, most severe first.
+func (s *ScanFindingStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.ScanFindingFilter,
+) ([]*types.ScanFinding, error) {
+	now := time.Now().UnixMilli()
+
+	stmt := database.Builder.
+		Select(scanFindingColumns).
+		Column(squirrel.Expr(scanFindingSuppressed+" AS scan_finding_suppressed", now)).
+		From("scan_findings").
+		Where("scan_finding_repo_id = ?", repoID)
+
+	stmt = applyScanFindingFilter(stmt, filter, now)
+
+	stmt = stmt.
+		OrderBy(scanFindingSeverityRank+" DESC", "scan_finding_vulnerability_id ASC", "scan_finding_id ASC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*scanFinding{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res := make([]*types.ScanFinding, len(dst))
+	for i := range dst {
+		res[i] = mapToScanFinding(dst[i])
+	}
+
+	return res, nil
+}
+
+// Count returns the number of findings of a repository matching the filter.
+func (s *ScanFindingStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.ScanFindingFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("scan_findings").
+		Where("scan_finding_repo_id = ?", repoID)
+
+	stmt = applyScanFindingFilter(stmt, filter, time.Now().UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+func applyScanFindingFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ScanFindingFilter,
+	now int64,
+) squirrel.SelectBuilder {
+	if filter.CommitSHA != "" {
+		stmt = stmt.Where("scan_finding_commit_sha = ?", filter.CommitSHA)
+	}
+
+	if filter.Scanner != "" {
+		stmt = stmt.Where("scan_finding_scanner = ?", filter.Scanner)
+	}
+
+	if filter.MinSeverity != "" && filter.MinSeverity != enum.ScanSeverityUnknown {
+		stmt = stmt.Where(squirrel.Eq{"scan_finding_severity": filter.MinSeverity.AtLeast()})
+	}
+
+	if !filter.IncludeSuppressed {
+		stmt = stmt.Where("NOT "+scanFindingSuppressed, now)
+	}
+
+	return stmt
+}
+
+func mapToScanFinding(in *scanFinding) *types.ScanFinding {
+	return &types.ScanFinding{
+		ID:              in.ID,
+		RepoID:          in.RepoID,
+		CommitSHA:       in.CommitSHA,
+		Scanner:         in.Scanner,
+		Kind:            in.Kind,
+		VulnerabilityID: in.VulnerabilityID,
+		Package:         in.Package,
+		Version:         in.Version,
+		FixedVersion:    in.FixedVersion,
+		Severity:        in.Severity,
+		Title:           in.Title,
+		Path:            in.Path,
+		URL:             in.URL,
+		Created:         in.Created,
+		Suppressed:      in.Suppressed,
+	}
+}
+
+func mapToInternalScanFinding(in *types.ScanFinding) *scanFinding {
+	return &scanFinding{
+		ID:              in.ID,
+		RepoID:          in.RepoID,
+		CommitSHA:       in.CommitSHA,
+		Scanner:         in.Scanner,
+		Kind:            in.Kind,
+		VulnerabilityID: in.VulnerabilityID,
+		Package:         in.Package,
+		Version:         in.Version,
+		FixedVersion:    in.FixedVersion,
+		Severity:        in.Severity,
+		Title:           in.Title,
+		Path:            in.Path,
+		URL:             in.URL,
+		Created:         in.Created,
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ScanSuppressionStore = (*ScanSuppressionStore)(nil)
+
+// NewScanSuppressionStore returns a new ScanSuppressionStore.
+func NewScanSuppressionStore(db *sqlx.DB) *ScanSuppressionStore {
+	return &ScanSuppressionStore{
+		db: db,
+	}
+}
+
+// ScanSuppressionStore implements store.ScanSuppressionStore backed by a relational database.
+type ScanSuppressionStore struct {
+	db *sqlx.DB
+}
+
+// scanSuppression is an internal representation used to store scan suppression data in the database.
+type scanSuppression struct {
+	ID              int64  `db:"scan_suppression_id"`
+	RepoID          int64  `db:"scan_suppression_repo_id"`
+	VulnerabilityID string `db:"scan_suppression_vulnerability_id"`
+	Package         string `db:"scan_suppression_package"`
+	Reason          string `db:"scan_suppression_reason"`
+	CreatedBy       int64  `db:"scan_suppression_created_by"`
+	Created         int64  `db:"scan_suppression_created"`
+	Expires         int64  `db:"scan_suppression_expires"`
+}
+
+const (
+	scanSuppressionColumns = `
+		 scan_suppression_id
+		,scan_suppression_repo_id
+		,scan_suppression_vulnerability_id
+		,scan_suppression_package
+		,scan_suppression_reason
+		,scan_suppression_created_by
+		,scan_suppression_created
+		,scan_suppression_expires`
+
+	scanSuppressionSelectBase = `
+	SELECT` + scanSuppressionColumns + `
+	FROM scan_suppressions`
+)
+
+// Find finds the suppression by id.
+func (s *ScanSuppressionStore) Find(ctx context.Context, id int64) (*types.ScanSuppression, error) {
+	const sqlQuery = scanSuppressionSelectBase + `
+		WHERE scan_suppression_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &scanSuppression{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToScanSuppression(dst), nil
+}
+
+// Create creates a new suppression.
+func (s *ScanSuppressionStore) Create(ctx context.Context, suppression *types.ScanSuppression) error {
+	const sqlQuery = `
+		INSERT INTO scan_suppressions (
+			 scan_suppression_repo_id
+			,scan_suppression_vulnerability_id
+			,scan_suppression_package
+			,scan_suppression_reason
+			,scan_suppression_created_by
+			,scan_suppression_created
+			,scan_suppression_expires
+		) values (
+			 :scan_suppression_repo_id
+			,:scan_suppression_vulnerability_id
+			,:scan_suppression_package
+			,:scan_suppression_reason
+			,:scan_suppression_created_by
+			,:scan_suppression_created
+			,:scan_suppression_expires
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalScanSuppression(suppression))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind scan suppression object")
+	}
+
+	if suppression.ID, err = database.InsertReturningID(ctx, db, query, "scan_suppression_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the suppression with the given id.
+func (s *ScanSuppressionStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM scan_suppressions
+		WHERE scan_suppression_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns all suppressions of a repository.
+func (s *ScanSuppressionStore) List(ctx context.Context, repoID int64) ([]*types.ScanSuppression, error) {
+	const sqlQuery = scanSuppressionSelectBase + `
+		WHERE scan_suppression_repo_id = $1
+		ORDER BY scan_suppression_id ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*scanSuppression{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res := make([]*types.ScanSuppression, len(dst))
+	for i := range dst {
+		res[i] = mapToScanSuppression(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToScanSuppression(in *scanSuppression) *types.ScanSuppression {
+	return &types.ScanSuppression{
+		ID:              in.ID,
+		RepoID:          in.RepoID,
+		VulnerabilityID: in.VulnerabilityID,
+		Package:         in.Package,
+		Reason:          in.Reason,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Expires:         in.Expires,
+	}
+}
+
+func mapToInternalScanSuppression(in *types.ScanSuppression) *scanSuppression {
+	return &scanSuppression{
+		ID:              in.ID,
+		RepoID:          in.RepoID,
+		VulnerabilityID: in.VulnerabilityID,
+		Package:         in.Package,
+		Reason:          in.Reason,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Expires:         in.Expires,
+	}
+}
@@ -45,6 +45,8 @@ const (
 	secret_created_by,
 	secret_uid,
 	secret_data,
+	secret_inheritable,
+	secret_provider,
 	secret_created,
 	secret_updated,
 	secret_version
@@ -66,7 +68,7 @@ type secretStore struct {
 func (s *secretStore) Find(ctx context.Context, id int64) (*types.Secret, error) {
 	const findQueryStmt = secretQueryBase + `
 		WHERE secret_id = $1`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Secret)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
@@ -79,7 +81,7 @@ func (s *secretStore) Find(ctx context.Context, id int64) (*types.Secret, error)
 func (s *secretStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Secret, error) {
 	const findQueryStmt = secretQueryBase + `
 		WHERE secret_space_id = $1 AND secret_uid = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Secret)
 	if err := db.GetContext(ctx, dst, findQueryStmt, spaceID, uid); err != nil {
@@ -98,6 +100,8 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		secret_created_by,
 		secret_uid,
 		secret_data,
+		secret_inheritable,
+		secret_provider,
 		secret_created,
 		secret_updated,
 		secret_version
@@ -107,10 +111,12 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		:secret_created_by,
 		:secret_uid,
 		:secret_data,
+		:secret_inheritable,
+		:secret_provider,
 		:secret_created,
 		:secret_updated,
 		:secret_version
-	) RETURNING secret_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(secretInsertStmt, secret)
@@ -118,7 +124,7 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind secret object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&secret.ID); err != nil {
+	if secret.ID, err = database.InsertReturningID(ctx, db, query, "secret_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "secret query failed")
 	}
 
@@ -132,6 +138,8 @@ func (s *secretStore) Update(ctx context.Context, p *types.Secret) error {
 		secret_description = :secret_description,
 		secret_uid = :secret_uid,
 		secret_data = :secret_data,
+		secret_inheritable = :secret_inheritable,
+		secret_provider = :secret_provider,
 		secret_updated = :secret_updated,
 		secret_version = :secret_version
 	WHERE secret_id = :secret_id AND secret_version = :secret_version - 1`
@@ -214,7 +222,7 @@ func (s *secretStore) List(ctx context.Context, parentID int64, filter types.Lis
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Secret{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -236,7 +244,7 @@ func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Sec
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Secret{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -246,6 +254,72 @@ func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Sec
 	return dst, nil
 }
 
+// ListAllInherited lists all the secrets available in a space.
+// It includes the secrets of the space itself and the inheritable secrets of all its ancestor spaces.
+// If multiple spaces define a secret with the same UID, the secret of the closest space takes precedence.
+func (s *secretStore) ListAllInherited(ctx context.Context, spaceID int64) ([]*types.Secret, error) {
+	//nolint:gosec // wrong flagging
+	const secretListInheritedStmt = `
+	WITH RECURSIVE space_ancestors(space_ancestor_id, space_ancestor_parent_id, space_ancestor_depth) AS (
+		SELECT space_id, space_parent_id, 0
+		FROM spaces
+		WHERE space_id = $1
+	UNION
+		SELECT space_id, space_parent_id, space_ancestor_depth + 1
+		FROM spaces
+		JOIN space_ancestors ON space_id = space_ancestor_parent_id
+	)
+	SELECT` + secretColumns + `
+	FROM secrets
+	JOIN space_ancestors ON secret_space_id = space_ancestor_id
+	WHERE space_ancestor_depth = 0 OR secret_inheritable
+	ORDER BY space_ancestor_depth ASC, secret_uid ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	all := []*types.Secret{}
+	if err := db.SelectContext(ctx, &all, secretListInheritedStmt, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing inherited list query")
+	}
+
+	// secrets are ordered from the closest to the most distant space, skip the overridden ones.
+	seen := make(map[string]struct{}, len(all))
+	dst := make([]*types.Secret, 0, len(all))
+	for _, secret := range all {
+		if _, ok := seen[secret.UID]; ok {
+			continue
+		}
+		seen[secret.UID] = struct{}{}
+		dst = append(dst, secret)
+	}
+
+	return dst, nil
+}
+
+// ListAfter lists up to limit secrets of all spaces with an ID greater than afterID, ordered by ID.
+func (s *secretStore) ListAfter(ctx context.Context, afterID int64, limit int) ([]*types.Secret, error) {
+	stmt := database.Builder.
+		Select(secretColumns).
+		From("secrets").
+		Where("secret_id > ?", afterID).
+		OrderBy("secret_id ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.Secret{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list after query")
+	}
+
+	return dst, nil
+}
+
 // Delete deletes a secret given a secret ID.
 func (s *secretStore) Delete(ctx context.Context, id int64) error {
 	//nolint:gosec // wrong flagging
@@ -294,7 +368,7 @@ func (s *secretStore) Count(ctx context.Context, parentID int64, filter types.Li
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SettingStore = (*SettingStore)(nil)
+
+const (
+	settingColumns = `
+		 setting_id
+		,setting_space_id
+		,setting_repo_id
+		,setting_key
+		,setting_value
+		,setting_locked
+		,setting_created_by
+		,setting_created
+		,setting_updated`
+
+	settingSelectBase = `
+	SELECT` + settingColumns + `
+	FROM settings`
+)
+
+// NewSettingStore returns a new SettingStore.
+func NewSettingStore(db *sqlx.DB) *SettingStore {
+	return &SettingStore{
+		db: db,
+	}
+}
+
+// SettingStore implements a store.SettingStore backed by a relational database.
+type SettingStore struct {
+	db *sqlx.DB
+}
+
+type setting struct {
+	ID        int64           `db:"setting_id"`
+	SpaceID   null.Int        `db:"setting_space_id"`
+	RepoID    null.Int        `db:"setting_repo_id"`
+	Key       enum.SettingKey `db:"setting_key"`
+	Value     string          `db:"setting_value"`
+	Locked    bool            `db:"setting_locked"`
+	CreatedBy int64           `db:"setting_created_by"`
+	Created   int64           `db:"setting_created"`
+	Updated   int64           `db:"setting_updated"`
+}
+
+// ListSpace returns all settings defined on the space.
+func (s *SettingStore) ListSpace(ctx context.Context, spaceID int64) ([]*types.Setting, error) {
+	const sqlQuery = settingSelectBase + `
+	WHERE setting_space_id = $1
+	ORDER BY setting_key`
+
+	return s.list(ctx, sqlQuery, spaceID)
+}
+
+// ListRepo returns all settings defined on the repository.
+func (s *SettingStore) ListRepo(ctx context.Context, repoID int64) ([]*types.Setting, error) {
+	const sqlQuery = settingSelectBase + `
+	WHERE setting_repo_id = $1
+	ORDER BY setting_key`
+
+	return s.list(ctx, sqlQuery, repoID)
+}
+
+// ListInherited returns all settings defined on the space and its ancestors,
+// ordered from the most distant to the closest space.
+func (s *SettingStore) ListInherited(ctx context.Context, spaceID int64) ([]*types.Setting, error) {
+	const sqlQuery = `
+	WITH RECURSIVE space_ancestors(space_ancestor_id, space_ancestor_parent_id, space_ancestor_depth) AS (
+		SELECT space_id, space_parent_id, 0
+		FROM spaces
+		WHERE space_id = $1
+	UNION
+		SELECT space_id, space_parent_id, space_ancestor_depth + 1
+		FROM spaces
+		JOIN space_ancestors ON space_id = space_ancestor_parent_id
+	)
+	SELECT` + settingColumns + `
+	FROM settings
+	JOIN space_ancestors ON setting_space_id = space_ancestor_id
+	ORDER BY space_ancestor_depth DESC, setting_key`
+
+	return s.list(ctx, sqlQuery, spaceID)
+}
+
+// Upsert creates or updates the setting of a space or a repository.
+func (s *SettingStore) Upsert(ctx context.Context, in *types.Setting) error {
+	const sqlQueryInsert = `
+		INSERT INTO settings (
+			 setting_space_id
+			,setting_repo_id
+			,setting_key
+			,setting_value
+			,setting_locked
+			,setting_created_by
+			,setting_created
+			,setting_updated
+		) VALUES (
+			 :setting_space_id
+			,:setting_repo_id
+			,:setting_key
+			,:setting_value
+			,:setting_locked
+			,:setting_created_by
+			,:setting_created
+			,:setting_updated
+		)`
+
+	const sqlQueryUpdate = `
+			 setting_value = :setting_value
+			,setting_locked = :setting_locked
+			,setting_updated = :setting_updated`
+
+	// the conflict target has to match one of the partial unique indices.
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT (setting_space_id, setting_key) WHERE setting_space_id IS NOT NULL
+		DO UPDATE SET` + sqlQueryUpdate
+	if in.RepoID != nil {
+		sqlQuery = sqlQueryInsert + `
+		ON CONFLICT (setting_repo_id, setting_key) WHERE setting_repo_id IS NOT NULL
+		DO UPDATE SET` + sqlQueryUpdate
+	}
+
+	// mysql doesn't support partial indices, the unique keys ignore rows with a NULL owner instead.
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalSetting(in))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind setting object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to upsert setting")
+	}
+
+	return nil
+}
+
+// DeleteSpace deletes the setting with the provided key of the space.
+func (s *SettingStore) DeleteSpace(ctx context.Context, spaceID int64, key enum.SettingKey) error {
+	const sqlQuery = `
+		DELETE FROM settings
+		WHERE setting_space_id = $1 AND setting_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID, key); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete space setting")
+	}
+
+	return nil
+}
+
+// DeleteRepo deletes the setting with the provided key of the repository.
+func (s *SettingStore) DeleteRepo(ctx context.Context, repoID int64, key enum.SettingKey) error {
+	const sqlQuery = `
+		DELETE FROM settings
+		WHERE setting_repo_id = $1 AND setting_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, key); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repository setting")
+	}
+
+	return nil
+}
+
+// DeleteDescendants deletes the setting with the provided key of all descendant spaces of the space
+// and all repositories within the space or any of its descendants.
+func (s *SettingStore) DeleteDescendants(ctx context.Context, spaceID int64, key enum.SettingKey) error {
+	const sqlQuery = `
+	WITH RECURSIVE space_descendants(space_descendant_id) AS (
+		SELECT space_id
+		FROM spaces
+		WHERE space_id = $1
+	UNION
+		SELECT space_id
+		FROM spaces
+		JOIN space_descendants ON space_parent_id = space_descendant_id
+	)
+	DELETE FROM settings
+	WHERE setting_key = $2 AND (
+		(setting_space_id <> $1 AND setting_space_id IN (SELECT space_descendant_id FROM space_descendants))
+		OR setting_repo_id IN (
+			SELECT repo_id
+			FROM repositories
+			WHERE repo_parent_id IN (SELECT space_descendant_id FROM space_descendants)
+		)
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID, key); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete settings of descendants")
+	}
+
+	return nil
+}
+
+func (s *SettingStore) list(ctx context.Context, sqlQuery string, args ...any) ([]*types.Setting, error) {
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*setting{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list settings")
+	}
+
+	res := make([]*types.Setting, len(dst))
+	for i := range dst {
+		var err error
+		res[i], err = mapToSetting(dst[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func mapToSetting(in *setting) (*types.Setting, error) {
+	if !json.Valid([]byte(in.Value)) {
+		return nil, fmt.Errorf("setting %d contains an invalid json value", in.ID)
+	}
+
+	return &types.Setting{
+		ID:        in.ID,
+		SpaceID:   in.SpaceID.Ptr(),
+		RepoID:    in.RepoID.Ptr(),
+		Key:       in.Key,
+		Value:     json.RawMessage(in.Value),
+		Locked:    in.Locked,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}, nil
+}
+
+func mapToInternalSetting(in *types.Setting) *setting {
+	return &setting{
+		ID:        in.ID,
+		SpaceID:   null.IntFromPtr(in.SpaceID),
+		RepoID:    null.IntFromPtr(in.RepoID),
+		Key:       in.Key,
+		Value:     string(in.Value),
+		Locked:    in.Locked,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+}
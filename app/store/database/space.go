@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
@@ -40,11 +41,13 @@ func NewSpaceStore(
 	db *sqlx.DB,
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
+	evictor store.SpaceEvictor,
 ) *SpaceStore {
 	return &SpaceStore{
 		db:             db,
 		spacePathCache: spacePathCache,
 		spacePathStore: spacePathStore,
+		evictor:        evictor,
 	}
 }
 
@@ -53,6 +56,7 @@ type SpaceStore struct {
 	db             *sqlx.DB
 	spacePathCache store.SpacePathCache
 	spacePathStore store.SpacePathStore
+	evictor        store.SpaceEvictor
 }
 
 // space is an internal representation used to store space data in DB.
@@ -67,6 +71,7 @@ type space struct {
 	CreatedBy   int64    `db:"space_created_by"`
 	Created     int64    `db:"space_created"`
 	Updated     int64    `db:"space_updated"`
+	Deleted     null.Int `db:"space_deleted"`
 }
 
 const (
@@ -79,7 +84,8 @@ const (
 		,space_is_public
 		,space_created_by
 		,space_created
-		,space_updated`
+		,space_updated
+		,space_deleted`
 
 	spaceSelectBase = `
 	SELECT` + spaceColumns + `
@@ -89,16 +95,16 @@ const (
 // Find the space by id.
 func (s *SpaceStore) Find(ctx context.Context, id int64) (*types.Space, error) {
 	const sqlQuery = spaceSelectBase + `
-		WHERE space_id = $1`
+		WHERE space_id = $1 AND space_deleted IS NULL`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(space)
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to find space")
 	}
 
-	return mapToSpace(ctx, s.spacePathStore, dst)
+	return mapToSpace(ctx, s.db, s.spacePathStore, dst)
 }
 
 // FindByRef finds the space using the spaceRef as either the id or the space path.
@@ -118,6 +124,54 @@ func (s *SpaceStore) FindByRef(ctx context.Context, spaceRef string) (*types.Spa
 	return s.Find(ctx, id)
 }
 
+// FindByRefAndDeleted finds the space deleted at the provided time
+// using the spaceRef as either the id or the space path.
+func (s *SpaceStore) FindByRefAndDeleted(ctx context.Context, spaceRef string, deleted int64) (*types.Space, error) {
+	const sqlQueryID = spaceSelectBase + `
+		WHERE space_id = $1 AND space_deleted = $2`
+	const sqlQueryNoParent = spaceSelectBase + `
+		WHERE space_parent_id IS NULL AND LOWER(space_uid) = $1 AND space_deleted = $2`
+	const sqlQueryParent = spaceSelectBase + `
+		WHERE space_parent_id = $1 AND LOWER(space_uid) = $2 AND space_deleted = $3`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+	dst := new(space)
+
+	// ASSUMPTION: digits only is not a valid space path
+	id, err := strconv.ParseInt(spaceRef, 10, 64)
+	if err == nil {
+		err = db.GetContext(ctx, dst, sqlQueryID, id, deleted)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(err, "Failed to find deleted space")
+		}
+
+		return mapToSpace(ctx, s.db, s.spacePathStore, dst)
+	}
+
+	// the deleted space has no path anymore, find it via its parent instead.
+	parentPath, uid, err := paths.DisectLeaf(spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disect leaf for path '%s': %w", spaceRef, err)
+	}
+
+	if parentPath == "" {
+		err = db.GetContext(ctx, dst, sqlQueryNoParent, strings.ToLower(uid), deleted)
+	} else {
+		var parent *types.SpacePath
+		parent, err = s.spacePathCache.Get(ctx, parentPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent path: %w", err)
+		}
+
+		err = db.GetContext(ctx, dst, sqlQueryParent, parent.SpaceID, strings.ToLower(uid), deleted)
+	}
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find deleted space")
+	}
+
+	return mapToSpace(ctx, s.db, s.spacePathStore, dst)
+}
+
 // Create a new space.
 func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 	if space == nil {
@@ -143,7 +197,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			,:space_created_by
 			,:space_created
 			,:space_updated
-		) RETURNING space_id`
+		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -152,7 +206,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind space object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, args...).Scan(&space.ID); err != nil {
+	if space.ID, err = database.InsertReturningID(ctx, db, query, "space_id", args...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -203,6 +257,8 @@ func (s *SpaceStore) Update(ctx context.Context, space *types.Space) error {
 		return gitness_store.ErrVersionConflict
 	}
 
+	s.evictor.Evict(ctx, space.ID)
+
 	space.Version = dbSpace.Version
 	space.Updated = dbSpace.Updated
 
@@ -243,6 +299,107 @@ func (s *SpaceStore) UpdateOptLock(ctx context.Context,
 	}
 }
 
+// SoftDelete moves the space to the trash.
+func (s *SpaceStore) SoftDelete(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		UPDATE spaces
+		SET
+			 space_version = space_version + 1
+			,space_deleted = $1
+		WHERE space_id = $2 AND space_deleted IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, deletedAt, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to soft delete space")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// Restore restores the space deleted at the provided time.
+func (s *SpaceStore) Restore(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		UPDATE spaces
+		SET
+			 space_version = space_version + 1
+			,space_updated = $1
+			,space_deleted = NULL
+		WHERE space_id = $2 AND space_deleted = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, time.Now().UnixMilli(), id, deletedAt)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to restore space")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	s.evictor.Evict(ctx, id)
+
+	return nil
+}
+
+// Purge deletes the space deleted at the provided time permanently, including all its descendants.
+// It fails with ErrResourceNotFound in case the space or any of its descendants still contain repositories,
+// as their git repositories have to be purged first.
+func (s *SpaceStore) Purge(ctx context.Context, id int64, deletedAt int64) error {
+	const sqlQuery = `
+		WITH RECURSIVE space_tree(space_tree_id) AS (
+			SELECT space_id
+			FROM spaces
+			WHERE space_id = $1
+		UNION ALL
+			SELECT space_id
+			FROM spaces
+			JOIN space_tree ON space_parent_id = space_tree_id
+		)
+		DELETE FROM spaces
+		WHERE space_id = $1 AND space_deleted = $2 AND NOT EXISTS (
+			SELECT 1
+			FROM repositories
+			WHERE repo_parent_id IN (SELECT space_tree_id FROM space_tree)
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id, deletedAt)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "the purge query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
 // Delete deletes a space.
 func (s *SpaceStore) Delete(ctx context.Context, id int64) error {
 	const sqlQuery = `
@@ -255,6 +412,8 @@ func (s *SpaceStore) Delete(ctx context.Context, id int64) error {
 		return database.ProcessSQLErrorf(err, "The delete query failed")
 	}
 
+	s.evictor.Evict(ctx, id)
+
 	return nil
 }
 
@@ -263,7 +422,8 @@ func (s *SpaceStore) Count(ctx context.Context, id int64, opts *types.SpaceFilte
 	stmt := database.Builder.
 		Select("count(*)").
 		From("spaces").
-		Where("space_parent_id = ?", id)
+		Where("space_parent_id = ?", id).
+		Where("space_deleted IS NULL")
 
 	if opts.Query != "" {
 		stmt = stmt.Where("LOWER(space_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
@@ -274,7 +434,7 @@ func (s *SpaceStore) Count(ctx context.Context, id int64, opts *types.SpaceFilte
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -290,7 +450,8 @@ func (s *SpaceStore) List(ctx context.Context, id int64, opts *types.SpaceFilter
 	stmt := database.Builder.
 		Select(spaceColumns).
 		From("spaces").
-		Where("space_parent_id = ?", fmt.Sprint(id))
+		Where("space_parent_id = ?", fmt.Sprint(id)).
+		Where("space_deleted IS NULL")
 
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
@@ -318,7 +479,7 @@ func (s *SpaceStore) List(ctx context.Context, id int64, opts *types.SpaceFilter
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var dst []*space
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -328,8 +489,133 @@ func (s *SpaceStore) List(ctx context.Context, id int64, opts *types.SpaceFilter
 	return s.mapToSpaces(ctx, dst)
 }
 
+// spaceTreeNode is an internal representation of a node of the space tree including its statistics.
+type spaceTreeNode struct {
+	ID          int64    `db:"space_id"`
+	ParentID    null.Int `db:"space_parent_id"`
+	UID         string   `db:"space_uid"`
+	Description string   `db:"space_description"`
+	IsPublic    bool     `db:"space_is_public"`
+	NumRepos    int64    `db:"space_tree_num_repos"`
+	NumMembers  int64    `db:"space_tree_num_members"`
+	RepoSize    int64    `db:"space_tree_repo_size"`
+}
+
+// ListTree returns the space and all its descendants with the statistics of each space, ordered by depth.
+func (s *SpaceStore) ListTree(ctx context.Context, id int64) ([]*types.SpaceTreeNode, error) {
+	const sqlQuery = `
+		WITH RECURSIVE space_tree(space_tree_id, space_tree_depth) AS (
+			SELECT space_id, 0
+			FROM spaces
+			WHERE space_id = $1 AND space_deleted IS NULL
+		UNION ALL
+			SELECT space_id, space_tree_depth + 1
+			FROM spaces
+			JOIN space_tree ON space_parent_id = space_tree_id
+			WHERE space_deleted IS NULL
+		)
+		SELECT
+			 space_id
+			,space_parent_id
+			,space_uid
+			,space_description
+			,space_is_public
+			,(SELECT COUNT(*) FROM repositories
+				WHERE repo_parent_id = space_id AND repo_deleted IS NULL) AS space_tree_num_repos
+			,(SELECT COALESCE(SUM(repo_size), 0) FROM repositories
+				WHERE repo_parent_id = space_id AND repo_deleted IS NULL) AS space_tree_repo_size
+			,(SELECT COUNT(*) FROM memberships
+				WHERE membership_space_id = space_id) AS space_tree_num_members
+		FROM space_tree
+		JOIN spaces ON space_id = space_tree_id
+		ORDER BY space_tree_depth ASC, space_uid ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*spaceTreeNode{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing space tree query")
+	}
+
+	res := make([]*types.SpaceTreeNode, len(dst))
+	for i, node := range dst {
+		res[i] = &types.SpaceTreeNode{
+			ID:          node.ID,
+			ParentID:    node.ParentID.Int64,
+			UID:         node.UID,
+			Description: node.Description,
+			IsPublic:    node.IsPublic,
+			NumRepos:    node.NumRepos,
+			NumMembers:  node.NumMembers,
+			RepoSize:    node.RepoSize,
+		}
+	}
+
+	return res, nil
+}
+
+// ListDeletedTreeIDs returns the IDs of the space and all its descendants that were deleted at the provided time.
+func (s *SpaceStore) ListDeletedTreeIDs(ctx context.Context, id int64, deletedAt int64) ([]int64, error) {
+	const sqlQuery = `
+		WITH RECURSIVE space_tree(space_tree_id) AS (
+			SELECT space_id
+			FROM spaces
+			WHERE space_id = $1 AND space_deleted = $2
+		UNION ALL
+			SELECT space_id
+			FROM spaces
+			JOIN space_tree ON space_parent_id = space_tree_id
+			WHERE space_deleted = $2
+		)
+		SELECT space_tree_id
+		FROM space_tree`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []int64{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, id, deletedAt); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing deleted space tree query")
+	}
+
+	return dst, nil
+}
+
+// ListDeleted returns the deleted child spaces of a space, most recently deleted first.
+func (s *SpaceStore) ListDeleted(ctx context.Context, id int64) ([]*types.Space, error) {
+	const sqlQuery = spaceSelectBase + `
+		WHERE space_parent_id = $1 AND space_deleted IS NOT NULL
+		ORDER BY space_deleted DESC, space_uid ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*space{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list deleted query")
+	}
+
+	return s.mapToSpaces(ctx, dst)
+}
+
+// ListDeletedBefore returns up to limit spaces that were deleted before the provided time.
+func (s *SpaceStore) ListDeletedBefore(ctx context.Context, deletedBefore int64, limit int) ([]*types.Space, error) {
+	const sqlQuery = spaceSelectBase + `
+		WHERE space_deleted < $1
+		ORDER BY space_deleted ASC, space_id ASC
+		LIMIT $2`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*space{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, deletedBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list deleted before query")
+	}
+
+	return s.mapToSpaces(ctx, dst)
+}
+
 func mapToSpace(
 	ctx context.Context,
+	db *sqlx.DB,
 	spacePathStore store.SpacePathStore,
 	in *space,
 ) (*types.Space, error) {
@@ -343,6 +629,7 @@ func mapToSpace(
 		Created:     in.Created,
 		CreatedBy:   in.CreatedBy,
 		Updated:     in.Updated,
+		Deleted:     in.Deleted.Ptr(),
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -350,8 +637,12 @@ func mapToSpace(
 		res.ParentID = in.ParentID.Int64
 	}
 
-	// backfill path
-	res.Path, err = getSpacePath(ctx, spacePathStore, in.ID)
+	// backfill path (deleted spaces don't have a primary path anymore)
+	if in.Deleted.Valid {
+		res.Path, err = getDeletedSpacePath(ctx, db, in.ID)
+	} else {
+		res.Path, err = getSpacePath(ctx, spacePathStore, in.ID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get primary path for space %d: %w", in.ID, err)
 	}
@@ -372,6 +663,41 @@ func getSpacePath(
 	return spacePath.Value, nil
 }
 
+// getDeletedSpacePath returns the path of a space using the spaces table
+// as deleted spaces and their descendants don't have a primary path anymore.
+func getDeletedSpacePath(ctx context.Context, db *sqlx.DB, spaceID int64) (string, error) {
+	const sqlQuery = `
+		WITH RECURSIVE space_ancestors(space_ancestor_id, space_ancestor_uid,
+			space_ancestor_parent_id, space_ancestor_depth) AS (
+			SELECT space_id, space_uid, space_parent_id, 0
+			FROM spaces
+			WHERE space_id = $1
+		UNION
+			SELECT space_id, space_uid, space_parent_id, space_ancestor_depth + 1
+			FROM spaces
+			JOIN space_ancestors ON space_id = space_ancestor_parent_id
+		)
+		SELECT space_ancestor_uid
+		FROM space_ancestors
+		ORDER BY space_ancestor_depth DESC`
+
+	dst := []string{}
+	if err := dbtx.GetReadAccessor(ctx, db).SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return "", database.ProcessSQLErrorf(err, "Failed executing space ancestors query")
+	}
+
+	if len(dst) == 0 {
+		return "", fmt.Errorf("space %d not found: %w", spaceID, gitness_store.ErrResourceNotFound)
+	}
+
+	path := ""
+	for _, uid := range dst {
+		path = paths.Concatinate(path, uid)
+	}
+
+	return path, nil
+}
+
 func (s *SpaceStore) mapToSpaces(
 	ctx context.Context,
 	spaces []*space,
@@ -379,7 +705,7 @@ func (s *SpaceStore) mapToSpaces(
 	var err error
 	res := make([]*types.Space, len(spaces))
 	for i := range spaces {
-		res[i], err = mapToSpace(ctx, s.spacePathStore, spaces[i])
+		res[i], err = mapToSpace(ctx, s.db, s.spacePathStore, spaces[i])
 		if err != nil {
 			return nil, err
 		}
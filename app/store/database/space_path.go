@@ -120,7 +120,7 @@ func (s *SpacePathStore) FindPrimaryBySpaceID(ctx context.Context, spaceID int64
 	sqlQuery := spacePathSelectBase + `
 		where space_path_space_id = $1 AND space_path_is_primary = TRUE`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 	dst := new(spacePathSegment)
 
 	path := ""
@@ -146,7 +146,7 @@ func (s *SpacePathStore) FindByPath(ctx context.Context, path string) (*types.Sp
 	const sqlQueryNoParent = spacePathSelectBase + ` WHERE space_path_uid_unique = $1 AND space_path_parent_id IS NULL`
 	const sqlQueryParent = spacePathSelectBase + ` WHERE space_path_uid_unique = $1 AND space_path_parent_id = $2`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 	segment := new(spacePathSegment)
 
 	segmentUIDs := paths.Segments(path)
@@ -197,6 +197,62 @@ func (s *SpacePathStore) DeletePrimarySegment(ctx context.Context, spaceID int64
 	return nil
 }
 
+// AliasPrimarySegment turns the primary segment of the space into an alias.
+func (s *SpacePathStore) AliasPrimarySegment(ctx context.Context, spaceID int64) error {
+	const sqlQuery = `
+		UPDATE space_paths
+		SET space_path_is_primary = NULL
+		WHERE space_path_space_id = $1 AND space_path_is_primary = TRUE`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID); err != nil {
+		return database.ProcessSQLErrorf(err, "the update query failed")
+	}
+
+	return nil
+}
+
+// DeleteAliasSegment deletes the alias segment with the provided uid of any space within a parent.
+func (s *SpacePathStore) DeleteAliasSegment(ctx context.Context, parentID int64, uid string) error {
+	const sqlQueryNoParent = `
+		DELETE FROM space_paths
+		WHERE space_path_uid_unique = $1 AND space_path_parent_id IS NULL AND space_path_is_primary IS NULL`
+	const sqlQueryParent = `
+		DELETE FROM space_paths
+		WHERE space_path_uid_unique = $1 AND space_path_parent_id = $2 AND space_path_is_primary IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var err error
+	uniqueUID := s.spacePathTransformation(uid, parentID == 0)
+	if parentID == 0 {
+		_, err = db.ExecContext(ctx, sqlQueryNoParent, uniqueUID)
+	} else {
+		_, err = db.ExecContext(ctx, sqlQueryParent, uniqueUID, parentID)
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "the delete query failed")
+	}
+
+	return nil
+}
+
+// DeleteSegments deletes all segments (primary and aliases) of the space.
+func (s *SpacePathStore) DeleteSegments(ctx context.Context, spaceID int64) error {
+	const sqlQuery = `
+		DELETE FROM space_paths
+		WHERE space_path_space_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID); err != nil {
+		return database.ProcessSQLErrorf(err, "the delete query failed")
+	}
+
+	return nil
+}
+
 func (s *SpacePathStore) mapToInternalSpacePathSegment(p *types.SpacePathSegment) *spacePathSegment {
 	res := &spacePathSegment{
 		ID:        p.ID,
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SpaceQuotaStore = (*SpaceQuotaStore)(nil)
+
+const (
+	spaceQuotaColumns = `
+		 space_quota_space_id
+		,space_quota_max_repos
+		,space_quota_max_size
+		,space_quota_created_by
+		,space_quota_created
+		,space_quota_updated`
+)
+
+// NewSpaceQuotaStore returns a new SpaceQuotaStore.
+func NewSpaceQuotaStore(db *sqlx.DB) *SpaceQuotaStore {
+	return &SpaceQuotaStore{
+		db: db,
+	}
+}
+
+// SpaceQuotaStore implements a store.SpaceQuotaStore backed by a relational database.
+type SpaceQuotaStore struct {
+	db *sqlx.DB
+}
+
+// Find returns the quota of the space.
+func (s *SpaceQuotaStore) Find(ctx context.Context, spaceID int64) (*types.SpaceQuota, error) {
+	const sqlQuery = `
+	SELECT` + spaceQuotaColumns + `
+	FROM space_quotas
+	WHERE space_quota_space_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.SpaceQuota{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find space quota")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates or updates the quota of a space.
+func (s *SpaceQuotaStore) Upsert(ctx context.Context, quota *types.SpaceQuota) error {
+	const sqlQueryInsert = `
+		INSERT INTO space_quotas (
+			 space_quota_space_id
+			,space_quota_max_repos
+			,space_quota_max_size
+			,space_quota_created_by
+			,space_quota_created
+			,space_quota_updated
+		) VALUES (
+			 :space_quota_space_id
+			,:space_quota_max_repos
+			,:space_quota_max_size
+			,:space_quota_created_by
+			,:space_quota_created
+			,:space_quota_updated
+		)`
+
+	const sqlQueryUpdate = `
+			 space_quota_max_repos = :space_quota_max_repos
+			,space_quota_max_size = :space_quota_max_size
+			,space_quota_updated = :space_quota_updated`
+
+	sqlQuery := sqlQueryInsert + `
+		ON CONFLICT (space_quota_space_id) DO UPDATE
+		SET` + sqlQueryUpdate
+	if database.IsMySQL(s.db) {
+		sqlQuery = sqlQueryInsert + `
+		ON DUPLICATE KEY UPDATE` + sqlQueryUpdate
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, quota)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind space quota object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to upsert space quota")
+	}
+
+	return nil
+}
+
+// Delete deletes the quota of the space.
+func (s *SpaceQuotaStore) Delete(ctx context.Context, spaceID int64) error {
+	const sqlQuery = `
+		DELETE FROM space_quotas
+		WHERE space_quota_space_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete space quota")
+	}
+
+	return nil
+}
+
+// ListInherited returns the quotas of the space and all its ancestors, ordered from the closest to the most
+// distant space.
+func (s *SpaceQuotaStore) ListInherited(ctx context.Context, spaceID int64) ([]*types.SpaceQuota, error) {
+	const sqlQuery = `
+	WITH RECURSIVE space_ancestors(space_ancestor_id, space_ancestor_parent_id, space_ancestor_depth) AS (
+		SELECT space_id, space_parent_id, 0
+		FROM spaces
+		WHERE space_id = $1
+	UNION
+		SELECT space_id, space_parent_id, space_ancestor_depth + 1
+		FROM spaces
+		JOIN space_ancestors ON space_id = space_ancestor_parent_id
+	)
+	SELECT` + spaceQuotaColumns + `
+	FROM space_quotas
+	JOIN space_ancestors ON space_quota_space_id = space_ancestor_id
+	ORDER BY space_ancestor_depth ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*types.SpaceQuota{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list inherited space quotas")
+	}
+
+	return dst, nil
+}
+
+// Usage returns the resources used by the space and all its descendants.
+// Deleted spaces and repositories are excluded.
+func (s *SpaceQuotaStore) Usage(ctx context.Context, spaceID int64) (*types.SpaceUsage, error) {
+	const sqlQuery = `
+	WITH RECURSIVE space_descendants(space_descendant_id) AS (
+		SELECT space_id
+		FROM spaces
+		WHERE space_id = $1
+	UNION
+		SELECT space_id
+		FROM spaces
+		JOIN space_descendants ON space_parent_id = space_descendant_id
+		WHERE space_deleted IS NULL
+	)
+	SELECT COUNT(repo_id), COALESCE(SUM(repo_size), 0)
+	FROM repositories
+	JOIN space_descendants ON repo_parent_id = space_descendant_id
+	WHERE repo_deleted IS NULL`
+
+	// quotas are enforced based on the usage, hence the primary database is used.
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	usage := &types.SpaceUsage{}
+	if err := db.QueryRowContext(ctx, sqlQuery, spaceID).Scan(&usage.NumRepos, &usage.Size); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to compute space usage")
+	}
+
+	return usage, nil
+}
@@ -112,7 +112,7 @@ func (s *stageStore) FindByNumber(ctx context.Context, executionID int64, stageN
 		SELECT` + stageColumns + `
 		FROM stages
 		WHERE stage_execution_id = $1 AND stage_number = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(stage)
 	if err := db.GetContext(ctx, dst, findQueryStmt, executionID, stageNum); err != nil {
@@ -181,7 +181,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_depends_on
 			,:stage_labels
 
-		) RETURNING stage_id`
+		)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	stage := mapStageToInternal(st)
@@ -189,7 +189,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err != nil {
 		return database.ProcessSQLErrorf(err, "Failed to bind stage object")
 	}
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
+	if stage.ID, err = database.InsertReturningID(ctx, db, query, "stage_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Stage query failed")
 	}
 	return nil
@@ -207,7 +207,7 @@ func (s *stageStore) ListWithSteps(ctx context.Context, executionID int64) ([]*t
 	stage_id ASC
 	,step_id ASC
 	`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	rows, err := db.QueryContext(ctx, queryNumberWithSteps, executionID)
 	if err != nil {
@@ -223,7 +223,7 @@ func (s *stageStore) Find(ctx context.Context, stageID int64) (*types.Stage, err
 	FROM stages
 	WHERE stage_id = $1
 	`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(stage)
 	if err := db.GetContext(ctx, dst, queryFind, stageID); err != nil {
@@ -240,7 +240,7 @@ func (s *stageStore) ListIncomplete(ctx context.Context) ([]*types.Stage, error)
 	WHERE stage_status IN ('pending','running')
 	ORDER BY stage_id ASC
 	`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*stage{}
 	if err := db.SelectContext(ctx, &dst, queryListIncomplete); err != nil {
@@ -258,7 +258,7 @@ func (s *stageStore) List(ctx context.Context, executionID int64) ([]*types.Stag
 	WHERE stage_execution_id = $1
 	ORDER BY stage_number ASC
 	`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*stage{}
 	if err := db.SelectContext(ctx, &dst, queryList, executionID); err != nil {
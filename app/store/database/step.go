@@ -17,6 +17,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/store"
 	gitness_store "github.com/harness/gitness/store"
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
@@ -87,7 +89,7 @@ func (s *stepStore) FindByNumber(ctx context.Context, stageID int64, stepNum int
 		SELECT` + stepColumns + `
 		FROM steps
 		WHERE step_stage_id = $1 AND step_number = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(step)
 	if err := db.GetContext(ctx, dst, findQueryStmt, stageID, stepNum); err != nil {
@@ -131,7 +133,7 @@ func (s *stepStore) Create(ctx context.Context, step *types.Step) error {
 		,:step_image
 		,:step_detached
 		,:step_schema
-	) RETURNING step_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(stepInsertStmt, mapStepToInternal(step))
@@ -139,7 +141,7 @@ func (s *stepStore) Create(ctx context.Context, step *types.Step) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind step object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&step.ID); err != nil {
+	if step.ID, err = database.InsertReturningID(ctx, db, query, "step_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Step query failed")
 	}
 
@@ -198,3 +200,54 @@ func (s *stepStore) Update(ctx context.Context, e *types.Step) error {
 	e.Version = step.Version
 	return nil
 }
+
+// ListIDsWithExpiredLogs returns up to limit IDs of steps of the repository that stopped before
+// the provided time and whose logs haven't been purged yet.
+func (s *stepStore) ListIDsWithExpiredLogs(
+	ctx context.Context,
+	repoID int64,
+	stoppedBefore time.Time,
+	limit int,
+) ([]int64, error) {
+	const sqlQuery = `
+		SELECT step_id
+		FROM steps
+		INNER JOIN stages ON stage_id = step_stage_id
+		WHERE stage_repo_id = $1 AND step_stopped > 0 AND step_stopped < $2 AND NOT step_logs_purged
+		ORDER BY step_id
+		LIMIT $3`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery, repoID, stoppedBefore.UnixMilli(), limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list steps with expired logs")
+	}
+
+	return ids, nil
+}
+
+// MarkLogsPurged marks the logs of the steps as purged.
+func (s *stepStore) MarkLogsPurged(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Update("steps").
+		Set("step_logs_purged", true).
+		Where(squirrel.Eq{"step_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert mark logs purged query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to mark logs of steps as purged")
+	}
+
+	return nil
+}
@@ -0,0 +1,700 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nosqlite
+// +build !nosqlite
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	appdatabase "github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// setupDB returns a migrated database for the store tests. The stores are tested against
+// the mysql database referenced by GITNESS_TEST_MYSQL_DATASOURCE if it's set, against
+// a new sqlite database otherwise.
+func setupDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	driver, datasource := "sqlite3", filepath.Join(t.TempDir(), "gitness.db")
+	if mysqlDatasource := os.Getenv("GITNESS_TEST_MYSQL_DATASOURCE"); mysqlDatasource != "" {
+		driver, datasource = database.MySQLDriverName, mysqlDatasource
+	}
+
+	db, err := database.ConnectAndMigrate(context.Background(), driver, datasource, migrate.Migrate)
+	if err != nil {
+		t.Fatalf("failed to connect and migrate: %s", err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// uniqueName returns a name that isn't used by previous runs against a persistent database.
+func uniqueName(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+}
+
+type testFixture struct {
+	user  *types.User
+	space *types.Space
+	repo  *types.Repository
+}
+
+func setupFixture(ctx context.Context, t *testing.T, db *sqlx.DB) testFixture {
+	t.Helper()
+
+	now := time.Now().UnixMilli()
+
+	user := &types.User{
+		UID:         uniqueName("user"),
+		Email:       uniqueName("user") + "@example.com",
+		DisplayName: "Test User",
+		Salt:        "salt",
+		Created:     now,
+		Updated:     now,
+	}
+	principalStore := appdatabase.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation, nil)
+	if err := principalStore.CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %s", err)
+	}
+
+	space := &types.Space{
+		UID:       uniqueName("space"),
+		CreatedBy: user.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := appdatabase.NewSpaceStore(db, nil, nil, nil).Create(ctx, space); err != nil {
+		t.Fatalf("failed to create space: %s", err)
+	}
+
+	spacePathStore := appdatabase.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	segment := &types.SpacePathSegment{
+		UID:       space.UID,
+		IsPrimary: true,
+		SpaceID:   space.ID,
+		CreatedBy: user.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := spacePathStore.InsertSegment(ctx, segment); err != nil {
+		t.Fatalf("failed to create space path: %s", err)
+	}
+
+	repo := &types.Repository{
+		ParentID:      space.ID,
+		UID:           uniqueName("repo"),
+		GitUID:        uniqueName("git"),
+		DefaultBranch: "main",
+		CreatedBy:     user.ID,
+		Created:       now,
+		Updated:       now,
+	}
+	if err := appdatabase.NewRepoStore(db, nil, spacePathStore, nil).Create(ctx, repo); err != nil {
+		t.Fatalf("failed to create repo: %s", err)
+	}
+
+	return testFixture{user: user, space: space, repo: repo}
+}
+
+func TestCreateReturnsID(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	f1 := setupFixture(ctx, t, db)
+	f2 := setupFixture(ctx, t, db)
+
+	if f1.user.ID == 0 || f1.space.ID == 0 || f1.repo.ID == 0 {
+		t.Fatalf("expected ids to be assigned, got user %d, space %d, repo %d",
+			f1.user.ID, f1.space.ID, f1.repo.ID)
+	}
+	if f1.user.ID == f2.user.ID || f1.space.ID == f2.space.ID || f1.repo.ID == f2.repo.ID {
+		t.Errorf("expected distinct ids for distinct rows")
+	}
+
+	spacePathStore := appdatabase.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	found, err := appdatabase.NewRepoStore(db, nil, spacePathStore, nil).Find(ctx, f2.repo.ID)
+	if err != nil {
+		t.Fatalf("failed to find repo: %s", err)
+	}
+	if found.UID != f2.repo.UID {
+		t.Errorf("want repo %q for id %d, got %q", f2.repo.UID, f2.repo.ID, found.UID)
+	}
+}
+
+func TestUpsertUpdatesExistingRow(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+
+	quotaStore := appdatabase.NewSpaceQuotaStore(db)
+	for _, maxRepos := range []int64{5, 10} {
+		maxRepos := maxRepos
+		quota := &types.SpaceQuota{SpaceID: f.space.ID, MaxRepos: &maxRepos, CreatedBy: f.user.ID}
+		if err := quotaStore.Upsert(ctx, quota); err != nil {
+			t.Fatalf("failed to upsert space quota: %s", err)
+		}
+	}
+
+	quota, err := quotaStore.Find(ctx, f.space.ID)
+	if err != nil {
+		t.Fatalf("failed to find space quota: %s", err)
+	}
+	if quota.MaxRepos == nil || *quota.MaxRepos != 10 {
+		t.Errorf("want max repos 10, got %v", quota.MaxRepos)
+	}
+
+	settingStore := appdatabase.NewSettingStore(db)
+	for _, setting := range []*types.Setting{
+		{SpaceID: &f.space.ID, Key: enum.SettingKeyDefaultBranch, Value: json.RawMessage(`"main"`)},
+		{SpaceID: &f.space.ID, Key: enum.SettingKeyDefaultBranch, Value: json.RawMessage(`"develop"`)},
+		{RepoID: &f.repo.ID, Key: enum.SettingKeyDefaultBranch, Value: json.RawMessage(`"trunk"`)},
+	} {
+		setting.CreatedBy = f.user.ID
+		if err = settingStore.Upsert(ctx, setting); err != nil {
+			t.Fatalf("failed to upsert setting: %s", err)
+		}
+	}
+
+	spaceSettings, err := settingStore.ListSpace(ctx, f.space.ID)
+	if err != nil {
+		t.Fatalf("failed to list space settings: %s", err)
+	}
+	if len(spaceSettings) != 1 || string(spaceSettings[0].Value) != `"develop"` {
+		t.Errorf("want the updated space setting only, got %d settings", len(spaceSettings))
+	}
+
+	repoSettings, err := settingStore.ListRepo(ctx, f.repo.ID)
+	if err != nil {
+		t.Fatalf("failed to list repo settings: %s", err)
+	}
+	if len(repoSettings) != 1 || string(repoSettings[0].Value) != `"trunk"` {
+		t.Errorf("want the repo setting only, got %d settings", len(repoSettings))
+	}
+}
+
+func TestUpsertReturnsExistingRow(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+
+	sbomStore := appdatabase.NewSBOMStore(db)
+
+	first := &types.SBOM{RepoID: f.repo.ID, Tag: "v1", CommitSHA: "a", Format: enum.SBOMFormatSPDX, Size: 1}
+	if err := sbomStore.Upsert(ctx, first); err != nil {
+		t.Fatalf("failed to insert sbom: %s", err)
+	}
+
+	other := &types.SBOM{RepoID: f.repo.ID, Tag: "v1", CommitSHA: "a", Format: enum.SBOMFormatCycloneDX, Size: 1}
+	if err := sbomStore.Upsert(ctx, other); err != nil {
+		t.Fatalf("failed to insert sbom: %s", err)
+	}
+
+	second := &types.SBOM{RepoID: f.repo.ID, Tag: "v1", CommitSHA: "b", Format: enum.SBOMFormatSPDX, Size: 2}
+	if err := sbomStore.Upsert(ctx, second); err != nil {
+		t.Fatalf("failed to update sbom: %s", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("want id %d of the updated sbom, got %d", first.ID, second.ID)
+	}
+
+	found, err := sbomStore.Find(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("failed to find sbom: %s", err)
+	}
+	if found.CommitSHA != "b" || found.Size != 2 {
+		t.Errorf("want updated sbom, got commit %q and size %d", found.CommitSHA, found.Size)
+	}
+
+	checkStore := appdatabase.NewCheckStore(db, nil)
+
+	check := &types.Check{
+		CreatedBy: f.user.ID,
+		Created:   100,
+		Updated:   100,
+		RepoID:    f.repo.ID,
+		CommitSHA: "a",
+		UID:       "build",
+		Status:    enum.CheckStatusPending,
+		Metadata:  json.RawMessage(`{}`),
+		Payload:   types.CheckPayload{Kind: enum.CheckPayloadKindEmpty, Data: json.RawMessage(`{}`)},
+	}
+	if err = checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to insert check: %s", err)
+	}
+
+	update := *check
+	update.ID = 0
+	update.Created = 200
+	update.Updated = 200
+	update.Status = enum.CheckStatusSuccess
+	if err = checkStore.Upsert(ctx, &update); err != nil {
+		t.Fatalf("failed to update check: %s", err)
+	}
+	if update.ID != check.ID || update.Created != check.Created {
+		t.Errorf("want id %d and created %d of the existing check, got %d and %d",
+			check.ID, check.Created, update.ID, update.Created)
+	}
+}
+
+func TestInsertIgnoresDuplicates(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+
+	starStore := appdatabase.NewRepoStarStore(db)
+	for i := 0; i < 2; i++ {
+		if err := starStore.Star(ctx, f.repo.ID, f.user.ID); err != nil {
+			t.Fatalf("failed to star repo: %s", err)
+		}
+	}
+
+	repoIDs, err := starStore.ListRepoIDs(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("failed to list starred repos: %s", err)
+	}
+	if len(repoIDs) != 1 || repoIDs[0] != f.repo.ID {
+		t.Errorf("want starred repo %d once, got %v", f.repo.ID, repoIDs)
+	}
+
+	announcementStore := appdatabase.NewAnnouncementStore(db)
+
+	announcement := &types.Announcement{
+		Message:     uniqueName("announcement"),
+		Severity:    enum.AnnouncementSeverityInfo,
+		Dismissible: true,
+		CreatedBy:   f.user.ID,
+	}
+	if err = announcementStore.Create(ctx, announcement); err != nil {
+		t.Fatalf("failed to create announcement: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = announcementStore.Dismiss(ctx, announcement.ID, f.user.ID, time.Now().UnixMilli()); err != nil {
+			t.Fatalf("failed to dismiss announcement: %s", err)
+		}
+	}
+
+	active, err := announcementStore.ListActive(ctx, f.user.ID, time.Now().UnixMilli())
+	if err != nil {
+		t.Fatalf("failed to list active announcements: %s", err)
+	}
+	for _, a := range active {
+		if a.ID == announcement.ID {
+			t.Errorf("expected dismissed announcement not to be active")
+		}
+	}
+}
+
+func TestMembershipExpiry(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+	other := setupFixture(ctx, t, db)
+
+	spacePathStore := appdatabase.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	membershipStore := appdatabase.NewMembershipStore(db, nil, spacePathStore)
+
+	now := time.Now().UnixMilli()
+	expired := now - time.Hour.Milliseconds()
+	expiring := now + time.Hour.Milliseconds()
+
+	expiredKey := types.MembershipKey{SpaceID: f.space.ID, PrincipalID: f.user.ID}
+	expiringKey := types.MembershipKey{SpaceID: f.space.ID, PrincipalID: other.user.ID}
+	permanentKey := types.MembershipKey{SpaceID: other.space.ID, PrincipalID: f.user.ID}
+
+	for _, membership := range []*types.Membership{
+		{MembershipKey: expiredKey, Role: enum.MembershipRoleSpaceOwner, Expires: &expired},
+		{MembershipKey: expiringKey, Role: enum.MembershipRoleReader, Expires: &expiring},
+		{MembershipKey: permanentKey, Role: enum.MembershipRoleReader},
+	} {
+		membership.CreatedBy = f.user.ID
+		membership.Created = now
+		membership.Updated = now
+		if err := membershipStore.Create(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %s", err)
+		}
+	}
+
+	memberships, err := membershipStore.FindManyForPrincipal(ctx, f.user.ID,
+		[]int64{f.space.ID, other.space.ID})
+	if err != nil {
+		t.Fatalf("failed to find memberships: %s", err)
+	}
+	if len(memberships) != 1 || memberships[0].MembershipKey != permanentKey {
+		t.Errorf("want the unexpired membership only, got %v", memberships)
+	}
+
+	listExpiring := func() map[types.MembershipKey]types.Membership {
+		list, err := membershipStore.ListExpiring(ctx, now+2*time.Hour.Milliseconds())
+		if err != nil {
+			t.Fatalf("failed to list expiring memberships: %s", err)
+		}
+		result := make(map[types.MembershipKey]types.Membership)
+		for _, m := range list {
+			if m.SpaceID == f.space.ID || m.SpaceID == other.space.ID {
+				result[m.MembershipKey] = m
+			}
+		}
+		return result
+	}
+
+	listed := listExpiring()
+	if _, ok := listed[expiredKey]; !ok || len(listed) != 2 {
+		t.Fatalf("want the expired and the expiring membership, got %v", listed)
+	}
+	if m, ok := listed[expiringKey]; !ok || m.ExpiryNotified != nil {
+		t.Fatalf("want the expiring membership without notification time, got %v", listed)
+	}
+
+	if err = membershipStore.UpdateExpiryNotified(ctx, expiringKey, now); err != nil {
+		t.Fatalf("failed to update expiry notification time: %s", err)
+	}
+	if m := listExpiring()[expiringKey]; m.ExpiryNotified == nil || *m.ExpiryNotified != now {
+		t.Errorf("want expiry notification time %d, got %v", now, m.ExpiryNotified)
+	}
+
+	for _, test := range []struct {
+		key  types.MembershipKey
+		want bool
+	}{
+		{key: expiringKey, want: false},
+		{key: permanentKey, want: false},
+		{key: expiredKey, want: true},
+		{key: expiredKey, want: false},
+	} {
+		deleted, err := membershipStore.DeleteExpired(ctx, test.key, now)
+		if err != nil {
+			t.Fatalf("failed to delete expired membership: %s", err)
+		}
+		if deleted != test.want {
+			t.Errorf("want deleted %t for membership %v, got %t", test.want, test.key, deleted)
+		}
+	}
+
+	listed = listExpiring()
+	if _, ok := listed[expiringKey]; !ok || len(listed) != 1 {
+		t.Errorf("want the expiring membership only, got %v", listed)
+	}
+	if _, err = membershipStore.Find(ctx, permanentKey); err != nil {
+		t.Errorf("failed to find the permanent membership: %s", err)
+	}
+}
+
+type noopRepoEvictor struct{}
+
+func (noopRepoEvictor) Evict(context.Context, int64) {}
+
+func TestSpaceQuotaInheritanceAndUsage(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+
+	now := time.Now().UnixMilli()
+	child := &types.Space{
+		ParentID:  f.space.ID,
+		UID:       uniqueName("child"),
+		CreatedBy: f.user.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := appdatabase.NewSpaceStore(db, nil, nil, nil).Create(ctx, child); err != nil {
+		t.Fatalf("failed to create child space: %s", err)
+	}
+
+	spacePathStore := appdatabase.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	segment := &types.SpacePathSegment{
+		UID:       child.UID,
+		IsPrimary: true,
+		SpaceID:   child.ID,
+		ParentID:  f.space.ID,
+		CreatedBy: f.user.ID,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := spacePathStore.InsertSegment(ctx, segment); err != nil {
+		t.Fatalf("failed to create space path: %s", err)
+	}
+
+	repoStore := appdatabase.NewRepoStore(db, nil, spacePathStore, noopRepoEvictor{})
+	childRepo := &types.Repository{
+		ParentID:      child.ID,
+		UID:           uniqueName("repo"),
+		GitUID:        uniqueName("git"),
+		DefaultBranch: "main",
+		CreatedBy:     f.user.ID,
+		Created:       now,
+		Updated:       now,
+	}
+	if err := repoStore.Create(ctx, childRepo); err != nil {
+		t.Fatalf("failed to create repo: %s", err)
+	}
+	if err := repoStore.UpdateSize(ctx, f.repo.ID, 10); err != nil {
+		t.Fatalf("failed to update repo size: %s", err)
+	}
+	if err := repoStore.UpdateSize(ctx, childRepo.ID, 32); err != nil {
+		t.Fatalf("failed to update repo size: %s", err)
+	}
+
+	quotaStore := appdatabase.NewSpaceQuotaStore(db)
+	maxRepos, maxSize := int64(5), int64(100)
+	for _, quota := range []*types.SpaceQuota{
+		{SpaceID: f.space.ID, MaxRepos: &maxRepos},
+		{SpaceID: child.ID, MaxSize: &maxSize},
+	} {
+		quota.CreatedBy = f.user.ID
+		if err := quotaStore.Upsert(ctx, quota); err != nil {
+			t.Fatalf("failed to upsert space quota: %s", err)
+		}
+	}
+
+	quotas, err := quotaStore.ListInherited(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("failed to list inherited quotas: %s", err)
+	}
+	if len(quotas) != 2 || quotas[0].SpaceID != child.ID || quotas[1].SpaceID != f.space.ID {
+		t.Errorf("want the quotas of the child space followed by its parent, got %d quotas", len(quotas))
+	}
+
+	usage, err := quotaStore.Usage(ctx, f.space.ID)
+	if err != nil {
+		t.Fatalf("failed to get usage: %s", err)
+	}
+	if usage.NumRepos != 2 || usage.Size != 42 {
+		t.Errorf("want usage of 2 repos with 42 KiB, got %d repos with %d KiB", usage.NumRepos, usage.Size)
+	}
+
+	usage, err = quotaStore.Usage(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("failed to get usage: %s", err)
+	}
+	if usage.NumRepos != 1 || usage.Size != 32 {
+		t.Errorf("want usage of 1 repo with 32 KiB, got %d repos with %d KiB", usage.NumRepos, usage.Size)
+	}
+}
+
+func TestFeedEvents(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+	other := setupFixture(ctx, t, db)
+
+	feedEventStore := appdatabase.NewFeedEventStore(db)
+
+	now := time.Now()
+	for i, event := range []*types.FeedEvent{
+		{RepoID: f.repo.ID, PrincipalID: f.user.ID, Type: enum.FeedEventTypeBranchCreated, Ref: "refs/heads/old",
+			Created: now.Add(-48 * time.Hour).UnixMilli()},
+		{RepoID: f.repo.ID, PrincipalID: other.user.ID, Type: enum.FeedEventTypeBranchUpdated, Ref: "refs/heads/main",
+			Created: now.Add(-time.Hour).UnixMilli()},
+		{RepoID: other.repo.ID, PrincipalID: other.user.ID, Type: enum.FeedEventTypePullReqCreated, PullReqNumber: 1,
+			Created: now.UnixMilli()},
+	} {
+		if err := feedEventStore.Create(ctx, event); err != nil {
+			t.Fatalf("failed to create feed event %d: %s", i, err)
+		}
+	}
+
+	events, err := feedEventStore.List(ctx, []int64{f.repo.ID, other.repo.ID}, types.Pagination{Page: 1, Size: 2})
+	if err != nil {
+		t.Fatalf("failed to list feed events: %s", err)
+	}
+	if len(events) != 2 || events[0].RepoID != other.repo.ID || events[1].Ref != "refs/heads/main" {
+		t.Errorf("want the 2 newest events, got %d events", len(events))
+	}
+
+	count, err := feedEventStore.Count(ctx, []int64{f.repo.ID})
+	if err != nil {
+		t.Fatalf("failed to count feed events: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("want 2 events of the repo, got %d", count)
+	}
+
+	repoIDs, err := feedEventStore.ListContributedRepoIDs(ctx, other.user.ID)
+	if err != nil {
+		t.Fatalf("failed to list contributed repos: %s", err)
+	}
+	if len(repoIDs) != 2 || repoIDs[0] != f.repo.ID || repoIDs[1] != other.repo.ID {
+		t.Errorf("want contributed repos %d and %d, got %v", f.repo.ID, other.repo.ID, repoIDs)
+	}
+
+	if _, err = feedEventStore.DeleteOld(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("failed to delete old feed events: %s", err)
+	}
+
+	repoIDs, err = feedEventStore.ListContributedRepoIDs(ctx, f.user.ID)
+	if err != nil {
+		t.Fatalf("failed to list contributed repos: %s", err)
+	}
+	if len(repoIDs) != 0 {
+		t.Errorf("expected the only event of the user to be deleted, got repos %v", repoIDs)
+	}
+}
+
+func TestBackups(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+	other := setupFixture(ctx, t, db)
+
+	backupStore := appdatabase.NewBackupStore(db)
+
+	now := time.Now().UnixMilli()
+	backups := []*types.Backup{
+		{RepoID: f.repo.ID, RepoPath: f.repo.Path, State: enum.BackupStateSucceeded},
+		{RepoID: other.repo.ID, RepoPath: other.repo.Path, State: enum.BackupStateSucceeded},
+		{RepoID: f.repo.ID, RepoPath: f.repo.Path, State: enum.BackupStatePending},
+	}
+	for i, backup := range backups {
+		backup.Trigger = enum.BackupTriggerScheduled
+		backup.CreatedBy = f.user.ID
+		backup.Created = now
+		backup.Updated = now
+		if err := backupStore.Create(ctx, backup); err != nil {
+			t.Fatalf("failed to create backup %d: %s", i, err)
+		}
+	}
+
+	pending := backups[2]
+	pending.State = enum.BackupStateFailed
+	pending.Error = "git failure"
+	pending.BundleSize = 42
+	if err := backupStore.Update(ctx, pending); err != nil {
+		t.Fatalf("failed to update backup: %s", err)
+	}
+
+	found, err := backupStore.Find(ctx, pending.ID)
+	if err != nil {
+		t.Fatalf("failed to find backup: %s", err)
+	}
+	if found.State != enum.BackupStateFailed || found.Error != "git failure" || found.BundleSize != 42 {
+		t.Errorf("want updated backup, got state %s, error %q and size %d", found.State, found.Error, found.BundleSize)
+	}
+
+	list, err := backupStore.ListByRepo(ctx, f.repo.ID)
+	if err != nil {
+		t.Fatalf("failed to list backups of repo: %s", err)
+	}
+	if len(list) != 2 || list[0].ID != pending.ID || list[1].ID != backups[0].ID {
+		t.Errorf("want the 2 backups of the repo newest first, got %d backups", len(list))
+	}
+
+	count, err := backupStore.Count(ctx, types.BackupFilter{State: enum.BackupStateSucceeded})
+	if err != nil {
+		t.Fatalf("failed to count backups: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("want 2 succeeded backups, got %d", count)
+	}
+
+	list, err = backupStore.List(ctx, types.BackupFilter{Page: 1, Size: 10, RepoID: other.repo.ID})
+	if err != nil {
+		t.Fatalf("failed to list backups: %s", err)
+	}
+	if len(list) != 1 || list[0].ID != backups[1].ID {
+		t.Errorf("want the only backup of the other repo, got %d backups", len(list))
+	}
+
+	if err = backupStore.Delete(ctx, pending.ID); err != nil {
+		t.Fatalf("failed to delete backup: %s", err)
+	}
+	if _, err = backupStore.Find(ctx, pending.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("want deleted backup to be not found, got %v", err)
+	}
+}
+
+func TestInstanceSettings(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	f := setupFixture(ctx, t, db)
+
+	instanceSettingStore := appdatabase.NewInstanceSettingStore(db)
+
+	key := enum.InstanceSettingKeyDiffMaxFiles
+	filter := types.InstanceSettingChangeFilter{Page: 1, Size: 10, Key: key}
+
+	countBefore, err := instanceSettingStore.CountChanges(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to count changes: %s", err)
+	}
+
+	for i, value := range []string{"50", "20"} {
+		now := time.Now().UnixMilli()
+		setting := &types.InstanceSetting{Key: key, Value: json.RawMessage(value), UpdatedBy: f.user.ID, Updated: now}
+		if err = instanceSettingStore.Upsert(ctx, setting); err != nil {
+			t.Fatalf("failed to upsert instance setting %d: %s", i, err)
+		}
+
+		change := &types.InstanceSettingChange{Key: key, NewValue: json.RawMessage(value), ChangedBy: f.user.ID,
+			Changed: now}
+		if i > 0 {
+			change.OldValue = json.RawMessage("50")
+		}
+		if err = instanceSettingStore.CreateChange(ctx, change); err != nil {
+			t.Fatalf("failed to create change %d: %s", i, err)
+		}
+	}
+
+	setting, err := instanceSettingStore.Find(ctx, key)
+	if err != nil {
+		t.Fatalf("failed to find instance setting: %s", err)
+	}
+	if string(setting.Value) != "20" {
+		t.Errorf("want the upserted value 20, got %s", setting.Value)
+	}
+
+	changes, err := instanceSettingStore.ListChanges(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to list changes: %s", err)
+	}
+	if len(changes) < 2 || string(changes[0].OldValue) != "50" || string(changes[0].NewValue) != "20" ||
+		changes[1].OldValue != nil {
+		t.Errorf("want the 2 changes newest first, got %d changes", len(changes))
+	}
+
+	count, err := instanceSettingStore.CountChanges(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to count changes: %s", err)
+	}
+	if count != countBefore+2 {
+		t.Errorf("want %d changes, got %d", countBefore+2, count)
+	}
+
+	if err = instanceSettingStore.Delete(ctx, key); err != nil {
+		t.Fatalf("failed to delete instance setting: %s", err)
+	}
+	if _, err = instanceSettingStore.Find(ctx, key); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("want deleted instance setting to be not found, got %v", err)
+	}
+}
@@ -64,7 +64,7 @@ type templateStore struct {
 func (s *templateStore) Find(ctx context.Context, id int64) (*types.Template, error) {
 	const findQueryStmt = templateQueryBase + `
 		WHERE template_id = $1`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Template)
 	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
@@ -77,7 +77,7 @@ func (s *templateStore) Find(ctx context.Context, id int64) (*types.Template, er
 func (s *templateStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Template, error) {
 	const findQueryStmt = templateQueryBase + `
 		WHERE template_space_id = $1 AND template_uid = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Template)
 	if err := db.GetContext(ctx, dst, findQueryStmt, spaceID, uid); err != nil {
@@ -105,7 +105,7 @@ func (s *templateStore) Create(ctx context.Context, template *types.Template) er
 		:template_created,
 		:template_updated,
 		:template_version
-	) RETURNING template_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	query, arg, err := db.BindNamed(templateInsertStmt, template)
@@ -113,7 +113,7 @@ func (s *templateStore) Create(ctx context.Context, template *types.Template) er
 		return database.ProcessSQLErrorf(err, "Failed to bind template object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&template.ID); err != nil {
+	if template.ID, err = database.InsertReturningID(ctx, db, query, "template_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "template query failed")
 	}
 
@@ -213,7 +213,7 @@ func (s *templateStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Template{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -269,7 +269,7 @@ func (s *templateStore) Count(ctx context.Context, parentID int64, filter types.
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -43,7 +43,7 @@ type TokenStore struct {
 
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
@@ -55,7 +55,7 @@ func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 
 // FindByUID finds the token by principalId and tokenUID.
 func (s *TokenStore) FindByUID(ctx context.Context, principalID int64, tokenUID string) (*types.Token, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(types.Token)
 	if err := db.GetContext(ctx, dst, TokenSelectByPrincipalIDAndUID, principalID, tokenUID); err != nil {
@@ -74,7 +74,7 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind token object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&token.ID); err != nil {
+	if token.ID, err = database.InsertReturningID(ctx, db, query, "token_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -130,7 +130,7 @@ func (s *TokenStore) DeleteExpiredBefore(
 // Count returns a count of tokens of a specifc type for a specific principal.
 func (s *TokenStore) Count(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) (int64, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err := db.QueryRowContext(ctx, tokenCountForPrincipalIDOfType, principalID, tokenType).Scan(&count)
@@ -144,7 +144,7 @@ func (s *TokenStore) Count(ctx context.Context,
 // List returns a list of tokens of a specific type for a specific principal.
 func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*types.Token{}
 
@@ -208,5 +208,5 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
-) RETURNING token_id
+)
 `
@@ -136,7 +136,7 @@ func (s *triggerStore) FindByUID(ctx context.Context, pipelineID int64, uid stri
 	SELECT` + triggerColumns + `
 	FROM triggers
 	WHERE trigger_pipeline_id = $1 AND trigger_uid = $2`
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := new(trigger)
 	if err := db.GetContext(ctx, dst, findQueryStmt, pipelineID, uid); err != nil {
@@ -174,7 +174,7 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
-	) RETURNING trigger_id`
+	)`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	trigger := mapTriggerToInternal(t)
@@ -183,7 +183,7 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind trigger object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&trigger.ID); err != nil {
+	if trigger.ID, err = database.InsertReturningID(ctx, db, query, "trigger_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Trigger query failed")
 	}
 
@@ -284,7 +284,7 @@ func (s *triggerStore) List(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*trigger{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -309,7 +309,7 @@ func (s *triggerStore) ListAllEnabled(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*trigger{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -335,7 +335,7 @@ func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UploadQuarantineStore = (*UploadQuarantineStore)(nil)
+
+func NewUploadQuarantineStore(db *sqlx.DB) *UploadQuarantineStore {
+	return &UploadQuarantineStore{
+		db: db,
+	}
+}
+
+type UploadQuarantineStore struct {
+	db *sqlx.DB
+}
+
+const (
+	uploadQuarantineColumns = `
+		 upload_quarantine_id
+		,upload_quarantine_sha256
+		,upload_quarantine_source
+		,upload_quarantine_repo_id
+		,upload_quarantine_name
+		,upload_quarantine_size
+		,upload_quarantine_scanner
+		,upload_quarantine_signature
+		,upload_quarantine_status
+		,upload_quarantine_uploaded_by
+		,upload_quarantine_released_by
+		,upload_quarantine_created
+		,upload_quarantine_updated`
+
+	uploadQuarantineSelectBase = `
+	SELECT` + uploadQuarantineColumns + `
+	FROM upload_quarantines`
+)
+
+// Find returns the quarantined upload with the provided id.
+func (s *UploadQuarantineStore) Find(ctx context.Context, id int64) (*types.UploadQuarantine, error) {
+	const sqlQuery = uploadQuarantineSelectBase + `
+	WHERE upload_quarantine_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.UploadQuarantine{}
+	if err := db.GetContext(ctx, result, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find upload quarantine")
+	}
+
+	return result, nil
+}
+
+// FindBySHA256 returns the quarantined upload with the provided content checksum.
+func (s *UploadQuarantineStore) FindBySHA256(ctx context.Context, sha256 string) (*types.UploadQuarantine, error) {
+	const sqlQuery = uploadQuarantineSelectBase + `
+	WHERE upload_quarantine_sha256 = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &types.UploadQuarantine{}
+	if err := db.GetContext(ctx, result, sqlQuery, sha256); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find upload quarantine by sha256")
+	}
+
+	return result, nil
+}
+
+// Create persists a new quarantined upload.
+func (s *UploadQuarantineStore) Create(ctx context.Context, quarantine *types.UploadQuarantine) error {
+	const sqlQuery = `
+		INSERT INTO upload_quarantines (
+			 upload_quarantine_sha256
+			,upload_quarantine_source
+			,upload_quarantine_repo_id
+			,upload_quarantine_name
+			,upload_quarantine_size
+			,upload_quarantine_scanner
+			,upload_quarantine_signature
+			,upload_quarantine_status
+			,upload_quarantine_uploaded_by
+			,upload_quarantine_released_by
+			,upload_quarantine_created
+			,upload_quarantine_updated
+		) VALUES (
+			 :upload_quarantine_sha256
+			,:upload_quarantine_source
+			,:upload_quarantine_repo_id
+			,:upload_quarantine_name
+			,:upload_quarantine_size
+			,:upload_quarantine_scanner
+			,:upload_quarantine_signature
+			,:upload_quarantine_status
+			,:upload_quarantine_uploaded_by
+			,:upload_quarantine_released_by
+			,:upload_quarantine_created
+			,:upload_quarantine_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, quarantine)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind upload quarantine object")
+	}
+
+	if quarantine.ID, err = database.InsertReturningID(ctx, db, query, "upload_quarantine_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status of a quarantined upload.
+func (s *UploadQuarantineStore) UpdateStatus(ctx context.Context, quarantine *types.UploadQuarantine) error {
+	const sqlQuery = `
+		UPDATE upload_quarantines
+		SET
+			 upload_quarantine_status = :upload_quarantine_status
+			,upload_quarantine_released_by = :upload_quarantine_released_by
+			,upload_quarantine_updated = :upload_quarantine_updated
+		WHERE upload_quarantine_id = :upload_quarantine_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, quarantine)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind upload quarantine object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update upload quarantine status")
+	}
+
+	return nil
+}
+
+// List returns the quarantined uploads matching the filter, newest first.
+func (s *UploadQuarantineStore) List(
+	ctx context.Context,
+	filter types.UploadQuarantineFilter,
+) ([]*types.UploadQuarantine, error) {
+	stmt := database.Builder.
+		Select(uploadQuarantineColumns).
+		From("upload_quarantines")
+
+	stmt = applyUploadQuarantineFilter(stmt, filter)
+	stmt = stmt.OrderBy("upload_quarantine_id desc")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list upload quarantines query to sql: %w", err)
+	}
+
+	result := make([]*types.UploadQuarantine, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to execute list upload quarantines query")
+	}
+
+	return result, nil
+}
+
+// Count returns the number of quarantined uploads matching the filter.
+func (s *UploadQuarantineStore) Count(ctx context.Context, filter types.UploadQuarantineFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("upload_quarantines")
+
+	stmt = applyUploadQuarantineFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count upload quarantines query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed executing count upload quarantines query")
+	}
+
+	return count, nil
+}
+
+// Delete removes a quarantined upload.
+func (s *UploadQuarantineStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM upload_quarantines
+		WHERE upload_quarantine_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete upload quarantine")
+	}
+
+	return nil
+}
+
+func applyUploadQuarantineFilter(
+	stmt squirrel.SelectBuilder,
+	filter types.UploadQuarantineFilter,
+) squirrel.SelectBuilder {
+	if filter.Status != "" {
+		stmt = stmt.Where("upload_quarantine_status = ?", filter.Status)
+	}
+
+	return stmt
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserEmailStore = (*UserEmailStore)(nil)
+
+const (
+	userEmailColumns = `
+		 user_email_id
+		,user_email_principal_id
+		,user_email_address
+		,user_email_verified
+		,user_email_verification_token
+		,user_email_verification_expires
+		,user_email_created
+		,user_email_updated`
+
+	userEmailSelectBase = `
+	SELECT` + userEmailColumns + `
+	FROM user_emails`
+)
+
+// NewUserEmailStore returns a new UserEmailStore.
+func NewUserEmailStore(db *sqlx.DB) *UserEmailStore {
+	return &UserEmailStore{
+		db: db,
+	}
+}
+
+// UserEmailStore implements a store.UserEmailStore backed by a relational database.
+type UserEmailStore struct {
+	db *sqlx.DB
+}
+
+// Find returns the user email with the provided id.
+func (s *UserEmailStore) Find(ctx context.Context, id int64) (*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE user_email_id = $1`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.UserEmail{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user email")
+	}
+
+	return dst, nil
+}
+
+// FindVerified returns the verified user email matching the provided address (case insensitive).
+func (s *UserEmailStore) FindVerified(ctx context.Context, email string) (*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE LOWER(user_email_address) = $1 AND user_email_verified IS NOT NULL`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := &types.UserEmail{}
+	if err := db.GetContext(ctx, dst, sqlQuery, strings.ToLower(email)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find verified user email")
+	}
+
+	return dst, nil
+}
+
+// Create persists a new user email.
+func (s *UserEmailStore) Create(ctx context.Context, email *types.UserEmail) error {
+	const sqlQuery = `
+		INSERT INTO user_emails (
+			 user_email_principal_id
+			,user_email_address
+			,user_email_verified
+			,user_email_verification_token
+			,user_email_verification_expires
+			,user_email_created
+			,user_email_updated
+		) VALUES (
+			 :user_email_principal_id
+			,:user_email_address
+			,:user_email_verified
+			,:user_email_verification_token
+			,:user_email_verification_expires
+			,:user_email_created
+			,:user_email_updated
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, email)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user email object")
+	}
+
+	if email.ID, err = database.InsertReturningID(ctx, db, query, "user_email_id", arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing user email.
+func (s *UserEmailStore) Update(ctx context.Context, email *types.UserEmail) error {
+	const sqlQuery = `
+		UPDATE user_emails
+		SET
+			 user_email_address              = :user_email_address
+			,user_email_verified             = :user_email_verified
+			,user_email_verification_token   = :user_email_verification_token
+			,user_email_verification_expires = :user_email_verification_expires
+			,user_email_updated              = :user_email_updated
+		WHERE user_email_id = :user_email_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, email)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user email object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+// List returns all emails of the principal.
+func (s *UserEmailStore) List(ctx context.Context, principalID int64) ([]*types.UserEmail, error) {
+	const sqlQuery = userEmailSelectBase + `
+		WHERE user_email_principal_id = $1
+		ORDER BY user_email_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*types.UserEmail, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list user emails")
+	}
+
+	return dst, nil
+}
+
+// Delete removes a user email.
+func (s *UserEmailStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM user_emails
+		WHERE user_email_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user email")
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMemberStore implements store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	CreatedBy   int64 `db:"usergroup_member_created_by"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+const (
+	userGroupMemberColumns = `
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created`
+
+	// userGroupAncestorsCTE selects into the usergroup_ancestors table the IDs of all user groups
+	// the principal (the single placeholder) is a member of, directly or through nested groups.
+	// UNION (instead of UNION ALL) guarantees termination even if the groups form a cycle.
+	userGroupAncestorsCTE = `
+	WITH RECURSIVE usergroup_ancestors(usergroup_id) AS (
+		SELECT usergroup_member_usergroup_id
+		FROM usergroup_members
+		WHERE usergroup_member_principal_id = ?
+		UNION
+		SELECT usergroup_member_usergroup_id
+		FROM usergroup_members
+		INNER JOIN usergroup_ancestors ON usergroup_member_principal_id = usergroup_ancestors.usergroup_id
+	)`
+)
+
+// Create adds the principal to the user group.
+func (s *UserGroupMemberStore) Create(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_members (
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created
+	) VALUES (
+		 :usergroup_member_usergroup_id
+		,:usergroup_member_principal_id
+		,:usergroup_member_created_by
+		,:usergroup_member_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroupMember(member))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert user group member")
+	}
+
+	return nil
+}
+
+// Delete removes the principal from the user group.
+func (s *UserGroupMemberStore) Delete(ctx context.Context, groupID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, groupID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete user group member")
+	}
+
+	return nil
+}
+
+// List returns the direct members of the user group.
+func (s *UserGroupMemberStore) List(ctx context.Context, groupID int64) ([]*types.UserGroupMember, error) {
+	const sqlQuery = `
+	SELECT` + userGroupMemberColumns + `
+	FROM usergroup_members
+	WHERE usergroup_member_usergroup_id = $1
+	ORDER BY usergroup_member_created ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := make([]*userGroupMember, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, groupID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user group member list query")
+	}
+
+	return s.mapSliceUserGroupMember(ctx, dst)
+}
+
+// ListUserIDs returns the IDs of all users of the user group, including users of nested groups.
+func (s *UserGroupMemberStore) ListUserIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	const sqlQuery = `
+	WITH RECURSIVE usergroup_descendants(principal_id) AS (
+		SELECT usergroup_member_principal_id
+		FROM usergroup_members
+		WHERE usergroup_member_usergroup_id = $1
+		UNION
+		SELECT usergroup_member_principal_id
+		FROM usergroup_members
+		INNER JOIN usergroup_descendants ON usergroup_member_usergroup_id = usergroup_descendants.principal_id
+	)
+	SELECT principals.principal_id
+	FROM usergroup_descendants
+	INNER JOIN principals ON principals.principal_id = usergroup_descendants.principal_id
+	WHERE principal_type = 'user'
+	ORDER BY principals.principal_id ASC`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err := db.SelectContext(ctx, &ids, sqlQuery, groupID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user group users query")
+	}
+
+	return ids, nil
+}
+
+// ListGroupIDs returns the IDs of all user groups the principal is a member of,
+// either directly or through nested groups.
+func (s *UserGroupMemberStore) ListGroupIDs(ctx context.Context, principalID int64) ([]int64, error) {
+	stmt := database.Builder.
+		Select("usergroup_id").
+		Prefix(userGroupAncestorsCTE, principalID).
+		From("usergroup_ancestors").
+		OrderBy("usergroup_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user groups of principal query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err = db.SelectContext(ctx, &ids, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user groups of principal query")
+	}
+
+	return ids, nil
+}
+
+func mapToInternalUserGroupMember(m *types.UserGroupMember) *userGroupMember {
+	return &userGroupMember{
+		UserGroupID: m.UserGroupID,
+		PrincipalID: m.PrincipalID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+	}
+}
+
+func mapToUserGroupMember(m *userGroupMember) *types.UserGroupMember {
+	return &types.UserGroupMember{
+		UserGroupID: m.UserGroupID,
+		PrincipalID: m.PrincipalID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+	}
+}
+
+func (s *UserGroupMemberStore) mapSliceUserGroupMember(ctx context.Context,
+	members []*userGroupMember) ([]*types.UserGroupMember, error) {
+	ids := make([]int64, 0, 2*len(members))
+	for _, m := range members {
+		ids = append(ids, m.PrincipalID, m.CreatedBy)
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group member principal infos: %w", err)
+	}
+
+	result := make([]*types.UserGroupMember, len(members))
+	for i, m := range members {
+		result[i] = mapToUserGroupMember(m)
+		if principal, ok := infoMap[m.PrincipalID]; ok {
+			result[i].Principal = *principal
+		}
+		if addedBy, ok := infoMap[m.CreatedBy]; ok {
+			result[i].AddedBy = *addedBy
+		}
+	}
+
+	return result, nil
+}
@@ -63,6 +63,7 @@ type webhook struct {
 	Secret                string      `db:"webhook_secret"`
 	Enabled               bool        `db:"webhook_enabled"`
 	Insecure              bool        `db:"webhook_insecure"`
+	ProxyURL              string      `db:"webhook_proxy_url"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
 }
@@ -82,6 +83,7 @@ const (
 		,webhook_secret
 		,webhook_enabled
 		,webhook_insecure
+		,webhook_proxy_url
 		,webhook_triggers
 		,webhook_latest_execution_result
 		,webhook_internal`
@@ -96,7 +98,7 @@ func (s *WebhookStore) Find(ctx context.Context, id int64) (*types.Webhook, erro
 	const sqlQuery = webhookSelectBase + `
 		WHERE webhook_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &webhook{}
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -126,6 +128,7 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_secret
 			,webhook_enabled
 			,webhook_insecure
+			,webhook_proxy_url
 			,webhook_triggers
 			,webhook_latest_execution_result
 			,webhook_internal
@@ -141,10 +144,11 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_secret
 			,:webhook_enabled
 			,:webhook_insecure
+			,:webhook_proxy_url
 			,:webhook_triggers
 			,:webhook_latest_execution_result
 			,:webhook_internal
-		) RETURNING webhook_id`
+		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -158,7 +162,7 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 		return database.ProcessSQLErrorf(err, "Failed to bind webhook object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&hook.ID); err != nil {
+	if hook.ID, err = database.InsertReturningID(ctx, db, query, "webhook_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
@@ -178,6 +182,7 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_secret = :webhook_secret
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
+			,webhook_proxy_url = :webhook_proxy_url
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
@@ -283,7 +288,7 @@ func (s *WebhookStore) Count(ctx context.Context, parentType enum.WebhookParent,
 		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -337,7 +342,36 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*webhook{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Select query failed")
+	}
+
+	res, err := mapToWebhooks(dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map webhooks to external type: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListAfter lists up to limit webhooks of all parents with an ID greater than afterID, ordered by ID.
+func (s *WebhookStore) ListAfter(ctx context.Context, afterID int64, limit int) ([]*types.Webhook, error) {
+	stmt := database.Builder.
+		Select(webhookColumns).
+		From("webhooks").
+		Where("webhook_id > ?", afterID).
+		OrderBy("webhook_id ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*webhook{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -365,6 +399,7 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Secret:                hook.Secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
+		ProxyURL:              hook.ProxyURL,
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
@@ -399,6 +434,7 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Secret:                hook.Secret,
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
+		ProxyURL:              hook.ProxyURL,
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
@@ -95,7 +95,7 @@ func (s *WebhookExecutionStore) Find(ctx context.Context, id int64) (*types.Webh
 	const sqlQuery = webhookExecutionSelectBase + `
 	WHERE webhook_execution_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := &webhookExecution{}
 	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
@@ -142,7 +142,7 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		,:webhook_execution_response_status
 		,:webhook_execution_response_headers
 		,:webhook_execution_response_body
-	) RETURNING webhook_execution_id`
+	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -151,17 +151,18 @@ func (s *WebhookExecutionStore) Create(ctx context.Context, execution *types.Web
 		return database.ProcessSQLErrorf(err, "Failed to bind webhook execution object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&execution.ID); err != nil {
+	if execution.ID, err = database.InsertReturningID(ctx, db, query, "webhook_execution_id", arg...); err != nil {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
 	return nil
 }
 
-// DeleteOld removes all executions that are older than the provided time.
-func (s *WebhookExecutionStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+// DeleteOld removes all executions of the webhook that are older than the provided time.
+func (s *WebhookExecutionStore) DeleteOld(ctx context.Context, webhookID int64, olderThan time.Time) (int64, error) {
 	stmt := database.Builder.
 		Delete("webhook_executions").
+		Where("webhook_execution_webhook_id = ?", webhookID).
 		Where("webhook_execution_created < ?", olderThan.UnixMilli())
 
 	sql, args, err := stmt.ToSql()
@@ -184,6 +185,36 @@ func (s *WebhookExecutionStore) DeleteOld(ctx context.Context, olderThan time.Ti
 	return n, nil
 }
 
+// DeleteExcess removes all executions of the webhook except the latest keep executions.
+func (s *WebhookExecutionStore) DeleteExcess(ctx context.Context, webhookID int64, keep int) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM webhook_executions
+		WHERE webhook_execution_webhook_id = $1 AND webhook_execution_id <= (
+			-- the derived table is required by mysql, which can't select from the table deleted from.
+			SELECT cutoff_id FROM (
+				SELECT webhook_execution_id AS cutoff_id
+				FROM webhook_executions
+				WHERE webhook_execution_webhook_id = $1
+				ORDER BY webhook_execution_id DESC
+				LIMIT 1 OFFSET $2
+			) AS cutoff
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, webhookID, keep)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to execute delete excess executions query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to get number of deleted executions")
+	}
+
+	return n, nil
+}
+
 // ListForWebhook lists the webhook executions for a given webhook id.
 func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID int64,
 	opts *types.WebhookExecutionFilter) ([]*types.WebhookExecution, error) {
@@ -203,7 +234,7 @@ func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID in
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*webhookExecution{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -219,7 +250,7 @@ func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	const sqlQuery = webhookExecutionSelectBase + `
 	WHERE webhook_execution_trigger_id = $1`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*webhookExecution{}
 	if err := db.SelectContext(ctx, &dst, sqlQuery, triggerID); err != nil {
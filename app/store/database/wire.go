@@ -16,10 +16,12 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
@@ -34,6 +36,40 @@ var WireSet = wire.NewSet(
 	ProvideSpaceStore,
 	ProvideRepoStore,
 	ProvideJobStore,
+	ProvideEventOutboxStore,
+	ProvideEventDeadLetterStore,
+	ProvideRepositoryShardStore,
+	ProvideSpaceQuotaStore,
+	ProvideSettingStore,
+	ProvideInstanceSettingStore,
+	ProvideAnnouncementStore,
+	ProvideUserEmailStore,
+	ProvideNotificationStore,
+	ProvideChatIntegrationStore,
+	ProvideScanFindingStore,
+	ProvideScanSuppressionStore,
+	ProvideSBOMStore,
+	ProvideAttestationStore,
+	ProvideFileLockStore,
+	ProvidePublicKeyStore,
+	ProvideSavedReplyStore,
+	ProvideAccessRequestStore,
+	ProvideCommitVerificationStore,
+	ProvideCommitAuthorRewriteStore,
+	ProvideRepoTopicStore,
+	ProvideRepoStarStore,
+	ProvideFeedEventStore,
+	ProvideContributionStore,
+	ProvideRefWatchStore,
+	ProvideOAuthAppStore,
+	ProvideOAuthAuthorizationStore,
+	ProvideOAuthCodeStore,
+	ProvideUploadQuarantineStore,
+	ProvideBackupStore,
+	ProvideAttachmentStore,
+	ProvideAvatarStore,
+	ProvideInsightsStore,
+	ProvideBranchRenameStore,
 	ProvideExecutionStore,
 	ProvidePipelineStore,
 	ProvideStageStore,
@@ -41,12 +77,15 @@ var WireSet = wire.NewSet(
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
+	ProvideUserGroupMemberStore,
 	ProvideTokenStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
 	ProvideCodeCommentView,
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
+	ProvidePullReqPushStore,
+	ProvidePullReqSubscriberStore,
 	ProvidePullReqFileViewStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
@@ -64,19 +103,57 @@ func migrator(ctx context.Context, db *sqlx.DB) error {
 	return migrate.Migrate(ctx, db)
 }
 
+// expandMigrator is helper function to set up the database by only performing
+// the expand migration steps, keeping the database compatible with the previous version.
+func expandMigrator(ctx context.Context, db *sqlx.DB) error {
+	return migrate.Expand(ctx, db)
+}
+
 // ProvideDatabase provides a database connection.
+// If read replicas are configured, they are registered for the read-only queries of the database.
 func ProvideDatabase(ctx context.Context, config database.Config) (*sqlx.DB, error) {
-	return database.ConnectAndMigrate(
+	migrateFn := migrator
+	if config.SkipContractMigrations {
+		migrateFn = expandMigrator
+	}
+
+	db, err := database.ConnectAndMigrate(
 		ctx,
 		config.Driver,
 		config.Datasource,
-		migrator,
+		migrateFn,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.ReplicaDatasources) == 0 {
+		return db, nil
+	}
+
+	replicas := make([]*sqlx.DB, len(config.ReplicaDatasources))
+	for i, datasource := range config.ReplicaDatasources {
+		replicas[i], err = database.Connect(ctx, config.Driver, datasource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to read replica %d: %w", i, err)
+		}
+	}
+
+	replicaSet := dbtx.NewReplicaSet(config.ReplicaMaxLag, replicas...)
+	dbtx.RegisterReplicas(db, replicaSet)
+
+	go replicaSet.Monitor(ctx, config.ReplicaCheckInterval)
+
+	return db, nil
 }
 
 // ProvidePrincipalStore provides a principal store.
-func ProvidePrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransformation) store.PrincipalStore {
-	return NewPrincipalStore(db, uidTransformation)
+func ProvidePrincipalStore(
+	db *sqlx.DB,
+	uidTransformation store.PrincipalUIDTransformation,
+	evictor store.PrincipalEvictor,
+) store.PrincipalStore {
+	return NewPrincipalStore(db, uidTransformation, evictor)
 }
 
 // ProvidePrincipalInfoView provides a principal info store.
@@ -97,8 +174,9 @@ func ProvideSpaceStore(
 	db *sqlx.DB,
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
+	evictor store.SpaceEvictor,
 ) store.SpaceStore {
-	return NewSpaceStore(db, spacePathCache, spacePathStore)
+	return NewSpaceStore(db, spacePathCache, spacePathStore, evictor)
 }
 
 // ProvideRepoStore provides a repo store.
@@ -106,8 +184,179 @@ func ProvideRepoStore(
 	db *sqlx.DB,
 	spacePathCache store.SpacePathCache,
 	spacePathStore store.SpacePathStore,
+	evictor store.RepoEvictor,
 ) store.RepoStore {
-	return NewRepoStore(db, spacePathCache, spacePathStore)
+	return NewRepoStore(db, spacePathCache, spacePathStore, evictor)
+}
+
+// ProvideEventOutboxStore provides an event outbox store.
+func ProvideEventOutboxStore(db *sqlx.DB) store.EventOutboxStore {
+	return NewEventOutboxStore(db)
+}
+
+// ProvideEventDeadLetterStore provides an event dead letter store.
+func ProvideEventDeadLetterStore(db *sqlx.DB) store.EventDeadLetterStore {
+	return NewEventDeadLetterStore(db)
+}
+
+// ProvideSpaceQuotaStore provides a space quota store.
+func ProvideSpaceQuotaStore(db *sqlx.DB) store.SpaceQuotaStore {
+	return NewSpaceQuotaStore(db)
+}
+
+// ProvideSettingStore provides a setting store.
+func ProvideSettingStore(db *sqlx.DB) store.SettingStore {
+	return NewSettingStore(db)
+}
+
+// ProvideInstanceSettingStore provides an instance setting store.
+func ProvideInstanceSettingStore(db *sqlx.DB) store.InstanceSettingStore {
+	return NewInstanceSettingStore(db)
+}
+
+// ProvideAnnouncementStore provides an announcement store.
+func ProvideAnnouncementStore(db *sqlx.DB) store.AnnouncementStore {
+	return NewAnnouncementStore(db)
+}
+
+// ProvideUserEmailStore provides a user email store.
+func ProvideUserEmailStore(db *sqlx.DB) store.UserEmailStore {
+	return NewUserEmailStore(db)
+}
+
+// ProvideChatIntegrationStore provides a chat integration store.
+func ProvideChatIntegrationStore(db *sqlx.DB) store.ChatIntegrationStore {
+	return NewChatIntegrationStore(db)
+}
+
+// ProvideInsightsStore provides an insights store.
+func ProvideInsightsStore(db *sqlx.DB) store.InsightsStore {
+	return NewInsightsStore(db)
+}
+
+// ProvideBranchRenameStore provides a branch rename store.
+func ProvideBranchRenameStore(db *sqlx.DB) store.BranchRenameStore {
+	return NewBranchRenameStore(db)
+}
+
+// ProvideScanFindingStore provides a scan finding store.
+func ProvideScanFindingStore(db *sqlx.DB) store.ScanFindingStore {
+	return NewScanFindingStore(db)
+}
+
+// ProvideScanSuppressionStore provides a scan suppression store.
+func ProvideScanSuppressionStore(db *sqlx.DB) store.ScanSuppressionStore {
+	return NewScanSuppressionStore(db)
+}
+
+// ProvideSBOMStore provides an sbom store.
+func ProvideSBOMStore(db *sqlx.DB) store.SBOMStore {
+	return NewSBOMStore(db)
+}
+
+// ProvideAttestationStore provides an attestation store.
+func ProvideAttestationStore(db *sqlx.DB) store.AttestationStore {
+	return NewAttestationStore(db)
+}
+
+// ProvideFileLockStore provides a file lock store.
+func ProvideFileLockStore(db *sqlx.DB) store.FileLockStore {
+	return NewFileLockStore(db)
+}
+
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideSavedReplyStore provides a saved reply store.
+func ProvideSavedReplyStore(db *sqlx.DB) store.SavedReplyStore {
+	return NewSavedReplyStore(db)
+}
+
+// ProvideAccessRequestStore provides an access request store.
+func ProvideAccessRequestStore(db *sqlx.DB) store.AccessRequestStore {
+	return NewAccessRequestStore(db)
+}
+
+// ProvideCommitVerificationStore provides a commit verification store.
+func ProvideCommitVerificationStore(db *sqlx.DB) store.CommitVerificationStore {
+	return NewCommitVerificationStore(db)
+}
+
+// ProvideCommitAuthorRewriteStore provides a commit author rewrite store.
+func ProvideCommitAuthorRewriteStore(db *sqlx.DB) store.CommitAuthorRewriteStore {
+	return NewCommitAuthorRewriteStore(db)
+}
+
+// ProvideRepoTopicStore provides a repo topic store.
+func ProvideRepoTopicStore(db *sqlx.DB) store.RepoTopicStore {
+	return NewRepoTopicStore(db)
+}
+
+// ProvideRepoStarStore provides a repo star store.
+func ProvideRepoStarStore(db *sqlx.DB) store.RepoStarStore {
+	return NewRepoStarStore(db)
+}
+
+// ProvideFeedEventStore provides a feed event store.
+func ProvideFeedEventStore(db *sqlx.DB) store.FeedEventStore {
+	return NewFeedEventStore(db)
+}
+
+// ProvideContributionStore provides a contribution store.
+func ProvideContributionStore(db *sqlx.DB) store.ContributionStore {
+	return NewContributionStore(db)
+}
+
+// ProvideRefWatchStore provides a ref watch store.
+func ProvideRefWatchStore(db *sqlx.DB) store.RefWatchStore {
+	return NewRefWatchStore(db)
+}
+
+// ProvideOAuthAppStore provides an OAuth app store.
+func ProvideOAuthAppStore(db *sqlx.DB) store.OAuthAppStore {
+	return NewOAuthAppStore(db)
+}
+
+// ProvideOAuthAuthorizationStore provides an OAuth authorization store.
+func ProvideOAuthAuthorizationStore(db *sqlx.DB) store.OAuthAuthorizationStore {
+	return NewOAuthAuthorizationStore(db)
+}
+
+// ProvideOAuthCodeStore provides an OAuth authorization code store.
+func ProvideOAuthCodeStore(db *sqlx.DB) store.OAuthCodeStore {
+	return NewOAuthCodeStore(db)
+}
+
+// ProvideUploadQuarantineStore provides an upload quarantine store.
+func ProvideUploadQuarantineStore(db *sqlx.DB) store.UploadQuarantineStore {
+	return NewUploadQuarantineStore(db)
+}
+
+// ProvideBackupStore provides a repository backup store.
+func ProvideBackupStore(db *sqlx.DB) store.BackupStore {
+	return NewBackupStore(db)
+}
+
+// ProvideAttachmentStore provides an attachment store.
+func ProvideAttachmentStore(db *sqlx.DB) store.AttachmentStore {
+	return NewAttachmentStore(db)
+}
+
+// ProvideAvatarStore provides an avatar store.
+func ProvideAvatarStore(db *sqlx.DB) store.AvatarStore {
+	return NewAvatarStore(db)
+}
+
+// ProvideNotificationStore provides a notification store.
+func ProvideNotificationStore(db *sqlx.DB) store.NotificationStore {
+	return NewNotificationStore(db)
+}
+
+// ProvideRepositoryShardStore provides a repository shard store.
+func ProvideRepositoryShardStore(db *sqlx.DB) store.RepositoryShardStore {
+	return NewRepositoryShardStore(db)
 }
 
 // ProvideJobStore provides a job store.
@@ -173,6 +422,14 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore)
 }
 
+// ProvideUserGroupMemberStore provides a user group member store.
+func ProvideUserGroupMemberStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db, principalInfoCache)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
@@ -202,6 +459,13 @@ func ProvidePullReqReviewStore(db *sqlx.DB) store.PullReqReviewStore {
 	return NewPullReqReviewStore(db)
 }
 
+// ProvidePullReqSubscriberStore provides a pull request subscriber store.
+func ProvidePullReqSubscriberStore(db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.PullReqSubscriberStore {
+	return NewPullReqSubscriberStore(db, principalInfoCache)
+}
+
 // ProvidePullReqReviewerStore provides a pull request reviewer store.
 func ProvidePullReqReviewerStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -209,6 +473,11 @@ func ProvidePullReqReviewerStore(db *sqlx.DB,
 	return NewPullReqReviewerStore(db, principalInfoCache)
 }
 
+// ProvidePullReqPushStore provides a pull request push store.
+func ProvidePullReqPushStore(db *sqlx.DB) store.PullReqPushStore {
+	return NewPullReqPushStore(db)
+}
+
 // ProvidePullReqFileViewStore provides a pull request file view store.
 func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
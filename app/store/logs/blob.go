@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+)
+
+// logsBlobDir is the directory of the blob store used for logs.
+const logsBlobDir = "logs"
+
+// NewBlobLogStore returns a new log store that stores logs in the provided blob store.
+func NewBlobLogStore(blobStore blob.Store) store.LogStore {
+	return &blobLogStore{
+		store: blobStore,
+	}
+}
+
+type blobLogStore struct {
+	store blob.Store
+}
+
+func (s *blobLogStore) Find(ctx context.Context, step int64) (io.ReadCloser, error) {
+	return s.store.Download(ctx, s.path(step))
+}
+
+func (s *blobLogStore) Create(ctx context.Context, step int64, r io.Reader) error {
+	return s.store.Upload(ctx, r, s.path(step))
+}
+
+func (s *blobLogStore) Update(ctx context.Context, step int64, r io.Reader) error {
+	return s.Create(ctx, step, r)
+}
+
+func (s *blobLogStore) Delete(ctx context.Context, step int64) error {
+	return s.store.Delete(ctx, s.path(step))
+}
+
+func (s *blobLogStore) path(step int64) string {
+	return fmt.Sprintf("%s/%d", logsBlobDir, step)
+}
@@ -16,6 +16,7 @@ package logs
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -27,7 +28,7 @@ var WireSet = wire.NewSet(
 	ProvideLogStore,
 )
 
-func ProvideLogStore(db *sqlx.DB, config *types.Config) store.LogStore {
+func ProvideLogStore(db *sqlx.DB, config *types.Config, blobStore blob.Store) store.LogStore {
 	s := NewDatabaseLogStore(db)
 	if config.Logs.S3.Bucket != "" {
 		p := NewS3LogStore(
@@ -38,5 +39,8 @@ func ProvideLogStore(db *sqlx.DB, config *types.Config) store.LogStore {
 		)
 		return NewCombined(p, s)
 	}
+	if config.Logs.BlobStore {
+		return NewCombined(NewBlobLogStore(blobStore), s)
+	}
 	return s
 }
@@ -39,6 +39,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GetInternalAPIURL() string
 
+	// GetAPIURL returns the publicly reachable base url of the api.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetAPIURL() string
+
 	// GenerateContainerGITCloneURL generates a URL that can be used by CI container builds to
 	// interact with gitness and clone a repo.
 	GenerateContainerGITCloneURL(repoPath string) string
@@ -56,6 +60,12 @@ type Provider interface {
 	// GenerateUICompareURL returns the url for the UI screen comparing two references.
 	GenerateUICompareURL(repoPath string, ref1 string, ref2 string) string
 
+	// GenerateUISignInURL returns the url for the UI sign in screen that returns to the provided url afterwards.
+	GenerateUISignInURL(returnURL string) string
+
+	// GenerateUIOAuthConsentURL returns the url for the UI screen where users consent to an OAuth app request.
+	GenerateUIOAuthConsentURL(query url.Values) string
+
 	// GetAPIHostname returns the host for the api endpoint.
 	GetAPIHostname() string
 
@@ -136,6 +146,10 @@ func (p *provider) GetInternalAPIURL() string {
 	return p.internalURL.JoinPath(APIMount).String()
 }
 
+func (p *provider) GetAPIURL() string {
+	return p.apiURL.String()
+}
+
 func (p *provider) GenerateContainerGITCloneURL(repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
@@ -166,6 +180,18 @@ func (p *provider) GenerateUICompareURL(repoPath string, ref1 string, ref2 strin
 	return p.uiURL.JoinPath(repoPath, "pulls/compare", ref1+"..."+ref2).String()
 }
 
+func (p *provider) GenerateUISignInURL(returnURL string) string {
+	u := p.uiURL.JoinPath("signin")
+	u.RawQuery = url.Values{"returnUrl": {returnURL}}.Encode()
+	return u.String()
+}
+
+func (p *provider) GenerateUIOAuthConsentURL(query url.Values) string {
+	u := p.uiURL.JoinPath("oauth/authorize")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
 func (p *provider) GetAPIHostname() string {
 	return p.apiURL.Hostname()
 }
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	azureblob "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+)
+
+var _ Store = (*AzureStore)(nil)
+
+// AzureStore is a blob store backed by an azure storage container.
+type AzureStore struct {
+	client          *azblob.Client
+	container       string
+	prefix          string
+	signedURLExpiry time.Duration
+	cpkScopeInfo    *azureblob.CPKScopeInfo
+}
+
+func NewAzureStore(cfg Config) (*AzureStore, error) {
+	cred, err := azblob.NewSharedKeyCredential(cfg.Azure.AccountName, cfg.Azure.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure credentials: %w", err)
+	}
+
+	serviceURL := cfg.Azure.ServiceURL
+	if serviceURL == "" {
+		serviceURL = fmt.Sprintf("https://%s.blob.core.windows.net/", cfg.Azure.AccountName)
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure client: %w", err)
+	}
+
+	var cpkScopeInfo *azureblob.CPKScopeInfo
+	if cfg.Azure.EncryptionScope != "" {
+		encryptionScope := cfg.Azure.EncryptionScope
+		cpkScopeInfo = &azureblob.CPKScopeInfo{EncryptionScope: &encryptionScope}
+	}
+
+	return &AzureStore{
+		client:          client,
+		container:       cfg.Bucket,
+		prefix:          cfg.Prefix,
+		signedURLExpiry: cfg.SignedURLExpiry,
+		cpkScopeInfo:    cpkScopeInfo,
+	}, nil
+}
+
+func (s *AzureStore) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := s.client.UploadStream(ctx, s.container, s.key(filePath), file, &azblob.UploadStreamOptions{
+		CPKScopeInfo: s.cpkScopeInfo,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AzureStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	resp, err := s.client.DownloadStream(ctx, s.container, s.key(filePath), nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *AzureStore) Delete(ctx context.Context, filePath string) error {
+	_, err := s.client.DeleteBlob(ctx, s.container, s.key(filePath), nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AzureStore) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	signedURL, err := s.client.ServiceClient().
+		NewContainerClient(s.container).
+		NewBlobClient(s.key(filePath)).
+		GetSASURL(sas.BlobPermissions{Read: true}, time.Now().Add(s.signedURLExpiry), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob sas url: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+func (s *AzureStore) key(filePath string) string {
+	return path.Join(cleanPath(s.prefix), cleanPath(filePath))
+}
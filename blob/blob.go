@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrNotFound is returned if the requested file doesn't exist in the blob store.
+	ErrNotFound = errors.New("resource not found")
+
+	// ErrNotSupported is returned if the operation isn't supported by the blob store provider.
+	ErrNotSupported = errors.New("not supported")
+)
+
+// Store is an abstraction of a storage for arbitrary, non-git data (e.g. LFS objects, artifacts, avatars, exports).
+type Store interface {
+	// Upload uploads the content of the reader to the provided path, overwriting any existing file.
+	Upload(ctx context.Context, file io.Reader, filePath string) error
+
+	// Download returns a reader for the file with the provided path.
+	// It returns ErrNotFound if the file doesn't exist.
+	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete deletes the file with the provided path. Deleting a non-existing file is not an error.
+	Delete(ctx context.Context, filePath string) error
+
+	// GetSignedURL returns a time-limited URL that allows to download the file without further authentication.
+	// It returns ErrNotSupported if the provider doesn't support signed URLs.
+	GetSignedURL(ctx context.Context, filePath string) (string, error)
+}
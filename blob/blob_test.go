@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSystemStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	store, err := NewFileSystemStore(Config{
+		Prefix:     "prefix",
+		FileSystem: FileSystemConfig{Root: root},
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	if err = store.Upload(ctx, strings.NewReader("hello"), "a/b.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+	if err = store.Upload(ctx, strings.NewReader("world"), "a/b.txt"); err != nil {
+		t.Fatalf("failed to overwrite: %v", err)
+	}
+
+	rc, err := store.Download(ctx, "a/b.txt")
+	if err != nil {
+		t.Fatalf("failed to download: %v", err)
+	}
+	data, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	if string(data) != "world" {
+		t.Errorf("expected content %q, got %q", "world", data)
+	}
+
+	if _, err = store.GetSignedURL(ctx, "a/b.txt"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+
+	if err = store.Delete(ctx, "a/b.txt"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if err = store.Delete(ctx, "a/b.txt"); err != nil {
+		t.Errorf("deleting a missing file should succeed, got %v", err)
+	}
+	if _, err = store.Download(ctx, "a/b.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFileSystemStore_PathTraversal(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	store, err := NewFileSystemStore(Config{
+		FileSystem: FileSystemConfig{Root: filepath.Join(root, "store")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	if err = store.Upload(ctx, strings.NewReader("x"), "../../escaped.txt"); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(root, "escaped.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file must not be written outside of the store root")
+	}
+	if _, err = os.Stat(filepath.Join(root, "store", "escaped.txt")); err != nil {
+		t.Errorf("expected file to be written inside the store root: %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "filesystem",
+			config: Config{Provider: ProviderFileSystem, FileSystem: FileSystemConfig{Root: "/tmp"}},
+		},
+		{
+			name:    "filesystem without root",
+			config:  Config{Provider: ProviderFileSystem},
+			wantErr: true,
+		},
+		{
+			name:    "s3 without bucket",
+			config:  Config{Provider: ProviderS3},
+			wantErr: true,
+		},
+		{
+			name: "s3 with kms",
+			config: Config{Provider: ProviderS3, Bucket: "b",
+				S3: S3Config{ServerSideEncryption: S3ServerSideEncryptionKMS, KMSKeyID: "key"}},
+		},
+		{
+			name:    "s3 with kms key but without kms encryption",
+			config:  Config{Provider: ProviderS3, Bucket: "b", S3: S3Config{KMSKeyID: "key"}},
+			wantErr: true,
+		},
+		{
+			name:    "s3 with unknown encryption",
+			config:  Config{Provider: ProviderS3, Bucket: "b", S3: S3Config{ServerSideEncryption: "rot13"}},
+			wantErr: true,
+		},
+		{
+			name:   "gcs",
+			config: Config{Provider: ProviderGCS, Bucket: "b"},
+		},
+		{
+			name:    "azure without account",
+			config:  Config{Provider: ProviderAzure, Bucket: "b"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider",
+			config:  Config{Provider: "ftp", Bucket: "b"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Provider string
+
+const (
+	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
+	ProviderGCS        Provider = "gcs"
+	ProviderAzure      Provider = "azure"
+)
+
+// S3ServerSideEncryption defines the server-side encryption applied to objects stored in S3.
+type S3ServerSideEncryption string
+
+const (
+	S3ServerSideEncryptionNone   S3ServerSideEncryption = ""
+	S3ServerSideEncryptionAES256 S3ServerSideEncryption = "AES256"
+	S3ServerSideEncryptionKMS    S3ServerSideEncryption = "aws:kms"
+)
+
+type Config struct {
+	Provider Provider
+
+	// Bucket is the name of the bucket (or the container in case of azure) used by cloud providers.
+	Bucket string
+	// Prefix is prepended to the path of all files.
+	Prefix string
+	// SignedURLExpiry is the duration for which signed URLs are valid.
+	SignedURLExpiry time.Duration
+
+	FileSystem FileSystemConfig
+	S3         S3Config
+	GCS        GCSConfig
+	Azure      AzureConfig
+}
+
+type FileSystemConfig struct {
+	// Root is the directory under which all files are stored.
+	Root string
+}
+
+type S3Config struct {
+	Region    string
+	Endpoint  string
+	PathStyle bool
+
+	ServerSideEncryption S3ServerSideEncryption
+	// KMSKeyID is the id of the KMS key used with S3ServerSideEncryptionKMS (uses the aws managed key if empty).
+	KMSKeyID string
+}
+
+type GCSConfig struct {
+	// KeyPath is the path to the service account key file (uses the default credentials if empty).
+	KeyPath string
+	// KMSKeyName is the name of the customer-managed key used to encrypt objects (uses the bucket default if empty).
+	KMSKeyName string
+}
+
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string
+	// ServiceURL is the url of the blob service (defaults to https://<account>.blob.core.windows.net/).
+	ServiceURL string
+	// EncryptionScope is the name of the encryption scope used to encrypt blobs (uses the account default if empty).
+	EncryptionScope string
+}
+
+func (c *Config) Validate() error {
+	switch c.Provider {
+	case ProviderFileSystem:
+		if c.FileSystem.Root == "" {
+			return errors.New("config.FileSystem.Root is required for the filesystem provider")
+		}
+		return nil
+	case ProviderS3:
+		if c.S3.ServerSideEncryption != S3ServerSideEncryptionNone &&
+			c.S3.ServerSideEncryption != S3ServerSideEncryptionAES256 &&
+			c.S3.ServerSideEncryption != S3ServerSideEncryptionKMS {
+			return fmt.Errorf("config.S3.ServerSideEncryption '%s' is not supported", c.S3.ServerSideEncryption)
+		}
+		if c.S3.KMSKeyID != "" && c.S3.ServerSideEncryption != S3ServerSideEncryptionKMS {
+			return errors.New("config.S3.KMSKeyID requires config.S3.ServerSideEncryption to be 'aws:kms'")
+		}
+	case ProviderGCS:
+	case ProviderAzure:
+		if c.Azure.AccountName == "" || c.Azure.AccountKey == "" {
+			return errors.New("config.Azure.AccountName and config.Azure.AccountKey are required for the azure provider")
+		}
+	default:
+		return fmt.Errorf("config.Provider '%s' is not supported", c.Provider)
+	}
+
+	if c.Bucket == "" {
+		return fmt.Errorf("config.Bucket is required for the %s provider", c.Provider)
+	}
+
+	return nil
+}
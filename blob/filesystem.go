@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const (
+	fileSystemDirPerm  = 0o755
+	fileSystemFilePerm = 0o644
+)
+
+var _ Store = (*FileSystemStore)(nil)
+
+// FileSystemStore is a blob store that stores all files in a directory of the local filesystem.
+type FileSystemStore struct {
+	basePath string
+}
+
+func NewFileSystemStore(cfg Config) (*FileSystemStore, error) {
+	basePath := filepath.Join(cfg.FileSystem.Root, filepath.FromSlash(cleanPath(cfg.Prefix)))
+	if err := os.MkdirAll(basePath, fileSystemDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+	}
+
+	return &FileSystemStore{
+		basePath: basePath,
+	}, nil
+}
+
+func (s *FileSystemStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	fullPath := s.fullPath(filePath)
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), fileSystemDirPerm); err != nil {
+		return fmt.Errorf("failed to create directory for file: %w", err)
+	}
+
+	// write to a temporary file first to ensure readers never observe a partially written file.
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = io.Copy(tmp, file); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err = tmp.Chmod(fileSystemFilePerm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), fullPath); err != nil {
+		return fmt.Errorf("failed to move file into place: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	file, err := os.Open(s.fullPath(filePath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	err := os.Remove(s.fullPath(filePath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileSystemStore) GetSignedURL(context.Context, string) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (s *FileSystemStore) fullPath(filePath string) string {
+	return filepath.Join(s.basePath, filepath.FromSlash(cleanPath(filePath)))
+}
+
+// cleanPath returns the cleaned, relative version of the provided path.
+// It ensures that the path can't escape the root of the store (e.g. via "../").
+func cleanPath(filePath string) string {
+	return path.Join("/", filePath)[1:]
+}
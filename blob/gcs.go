@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+var _ Store = (*GCSStore)(nil)
+
+// GCSStore is a blob store backed by a google cloud storage bucket.
+type GCSStore struct {
+	bucket          *storage.BucketHandle
+	prefix          string
+	signedURLExpiry time.Duration
+	kmsKeyName      string
+}
+
+func NewGCSStore(ctx context.Context, cfg Config) (*GCSStore, error) {
+	var opts []option.ClientOption
+	if cfg.GCS.KeyPath != "" {
+		opts = append(opts, option.WithCredentialsFile(cfg.GCS.KeyPath))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcs client: %w", err)
+	}
+
+	return &GCSStore{
+		bucket:          client.Bucket(cfg.Bucket),
+		prefix:          cfg.Prefix,
+		signedURLExpiry: cfg.SignedURLExpiry,
+		kmsKeyName:      cfg.GCS.KMSKeyName,
+	}, nil
+}
+
+func (s *GCSStore) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	w := s.bucket.Object(s.key(filePath)).NewWriter(ctx)
+	w.KMSKeyName = s.kmsKeyName
+
+	if _, err := io.Copy(w, file); err != nil {
+		// closing the writer without writing the full content would otherwise create the object.
+		_ = w.CloseWithError(err) //nolint:staticcheck // still the only way to abort an upload
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GCSStore) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	r, err := s.bucket.Object(s.key(filePath)).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return r, nil
+}
+
+func (s *GCSStore) Delete(ctx context.Context, filePath string) error {
+	err := s.bucket.Object(s.key(filePath)).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GCSStore) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	signedURL, err := s.bucket.SignedURL(s.key(filePath), &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(s.signedURLExpiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign object url: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+func (s *GCSStore) key(filePath string) string {
+	return path.Join(cleanPath(s.prefix), cleanPath(filePath))
+}
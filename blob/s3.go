@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+var _ Store = (*S3Store)(nil)
+
+// S3Store is a blob store backed by an AWS S3 (or S3 compatible) bucket.
+type S3Store struct {
+	bucket          string
+	prefix          string
+	signedURLExpiry time.Duration
+	sse             S3ServerSideEncryption
+	kmsKeyID        string
+
+	svc      *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (*S3Store, error) {
+	awsConfig := &aws.Config{
+		S3ForcePathStyle: aws.Bool(cfg.S3.PathStyle),
+	}
+	if cfg.S3.Region != "" {
+		awsConfig.Region = aws.String(cfg.S3.Region)
+	}
+	if cfg.S3.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.S3.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.S3.Endpoint, "https://"))
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	return &S3Store{
+		bucket:          cfg.Bucket,
+		prefix:          cfg.Prefix,
+		signedURLExpiry: cfg.SignedURLExpiry,
+		sse:             cfg.S3.ServerSideEncryption,
+		kmsKeyID:        cfg.S3.KMSKeyID,
+		svc:             s3.New(sess),
+		uploader:        s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (s *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	input := &s3manager.UploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(filePath)),
+		Body:   file,
+	}
+	if s.sse != S3ServerSideEncryptionNone {
+		input.ServerSideEncryption = aws.String(string(s.sse))
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
+	}
+
+	if _, err := s.uploader.UploadWithContext(ctx, input); err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := s.svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(filePath)),
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, filePath string) error {
+	_, err := s.svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(filePath)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+func (s *S3Store) GetSignedURL(ctx context.Context, filePath string) (string, error) {
+	req, _ := s.svc.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(filePath)),
+	})
+	req.SetContext(ctx)
+
+	signedURL, err := req.Presign(s.signedURLExpiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object url: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+func (s *S3Store) key(filePath string) string {
+	return path.Join(cleanPath(s.prefix), cleanPath(filePath))
+}
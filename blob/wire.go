@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideStore,
+)
+
+func ProvideStore(ctx context.Context, config Config) (Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("provided blob store config is invalid: %w", err)
+	}
+
+	switch config.Provider {
+	case ProviderS3:
+		return NewS3Store(config)
+	case ProviderGCS:
+		return NewGCSStore(ctx, config)
+	case ProviderAzure:
+		return NewAzureStore(config)
+	default:
+		return NewFileSystemStore(config)
+	}
+}
@@ -22,6 +22,7 @@ import (
 type Cache[K any, V any] interface {
 	Stats() (int64, int64)
 	Get(ctx context.Context, key K) (V, error)
+	Evict(ctx context.Context, key K)
 }
 
 // ExtendedCache is an extension of the simple cache abstraction that adds mapping functionality.
@@ -30,6 +31,11 @@ type ExtendedCache[K comparable, V Identifiable[K]] interface {
 	Map(ctx context.Context, keys []K) (map[K]V, error)
 }
 
+// Evictor is used to evict keys from a cache after the underlying entity changed.
+type Evictor[K any] interface {
+	Evict(ctx context.Context, key K)
+}
+
 type Identifiable[K comparable] interface {
 	Identifier() K
 }
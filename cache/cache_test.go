@@ -15,8 +15,10 @@
 package cache
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDeduplicate(t *testing.T) {
@@ -67,3 +69,62 @@ func TestDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+type countingGetter struct {
+	calls int
+}
+
+func (g *countingGetter) Find(_ context.Context, key int) (int, error) {
+	g.calls++
+	return key * 10, nil
+}
+
+func TestTTLCacheEvict(t *testing.T) {
+	ctx := context.Background()
+	getter := &countingGetter{}
+	c := New[int, int](getter, time.Minute)
+	defer c.Stop()
+
+	for _, step := range []struct {
+		evict     bool
+		wantCalls int
+	}{
+		{evict: false, wantCalls: 1},
+		{evict: false, wantCalls: 1},
+		{evict: true, wantCalls: 2},
+		{evict: false, wantCalls: 2},
+	} {
+		if step.evict {
+			c.Evict(ctx, 4)
+		}
+
+		v, err := c.Get(ctx, 4)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v != 40 {
+			t.Errorf("failed - want=40, got=%d", v)
+		}
+		if want, got := step.wantCalls, getter.calls; want != got {
+			t.Errorf("failed - want calls=%d, got=%d", want, got)
+		}
+	}
+}
+
+func TestGobCodec(t *testing.T) {
+	type item struct {
+		Name   string
+		Secret string `json:"-"`
+	}
+
+	codec := GobCodec[*item]{}
+	in := &item{Name: "name", Secret: "secret"}
+
+	out, err := codec.Decode(codec.Encode(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("failed - want=%v, got=%v", in, out)
+	}
+}
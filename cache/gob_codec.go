@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+// GobCodec encodes cached values using gob.
+// Unlike JSON it keeps the fields that are hidden from API responses (like the principal salt).
+type GobCodec[V any] struct{}
+
+func (GobCodec[V]) Encode(value V) string {
+	buf := &bytes.Buffer{}
+	// encoding of plain structs can't fail, an empty value would be treated as a cache miss anyway.
+	_ = gob.NewEncoder(buf).Encode(value)
+	return buf.String()
+}
+
+func (GobCodec[V]) Decode(encoded string) (V, error) {
+	var value V
+	if err := gob.NewDecoder(bytes.NewBufferString(encoded)).Decode(&value); err != nil {
+		return value, fmt.Errorf("failed to decode cached value: %w", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/pubsub"
+
+	"github.com/rs/zerolog/log"
+)
+
+const evictorNamespace = "cache"
+
+// PubSubEvictor evicts keys from caches of all running instances by broadcasting the evictions through pubsub.
+type PubSubEvictor[K any] struct {
+	topic string
+	bus   pubsub.PubSub
+}
+
+// NewPubSubEvictor returns a new PubSubEvictor that publishes evictions to the provided topic.
+func NewPubSubEvictor[K any](topic string, bus pubsub.PubSub) *PubSubEvictor[K] {
+	return &PubSubEvictor[K]{
+		topic: topic,
+		bus:   bus,
+	}
+}
+
+// Subscribe registers the caches that get the keys evicted whenever an eviction is published.
+// The subscription is active until the context is canceled.
+func (e *PubSubEvictor[K]) Subscribe(ctx context.Context, caches ...Evictor[K]) {
+	_ = e.bus.Subscribe(ctx, e.topic, func(payload []byte) error {
+		var key K
+		if err := json.Unmarshal(payload, &key); err != nil {
+			return fmt.Errorf("failed to unmarshal cache key: %w", err)
+		}
+
+		for _, c := range caches {
+			c.Evict(ctx, key)
+		}
+
+		return nil
+	}, pubsub.WithChannelNamespace(evictorNamespace))
+}
+
+// Evict publishes the eviction of the key.
+// Failures are only logged, the stale objects expire once the cache duration elapses.
+func (e *PubSubEvictor[K]) Evict(ctx context.Context, key K) {
+	payload, err := json.Marshal(key)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to marshal key for cache eviction on topic %s", e.topic)
+		return
+	}
+
+	err = e.bus.Publish(ctx, e.topic, payload, pubsub.WithPublishNamespace(evictorNamespace))
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish cache eviction on topic %s", e.topic)
+	}
+}
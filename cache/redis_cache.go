@@ -77,10 +77,13 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	raw, err := c.client.Get(ctx, strKey).Result()
 	if err == nil {
-		c.countHit++
-		return c.codec.Decode(raw)
-	}
-	if !errors.Is(err, redis.Nil) {
+		item, errDecode := c.codec.Decode(raw)
+		if errDecode == nil {
+			c.countHit++
+			return item, nil
+		}
+		// values that can't be decoded (e.g. written by an older version) are treated as a miss and replaced.
+	} else if !errors.Is(err, redis.Nil) {
 		return nothing, err
 	}
 
@@ -98,3 +101,9 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	return item, nil
 }
+
+// Evict removes the object with the provided key from the cache.
+// Failures are ignored, the stale object expires once the cache duration elapses.
+func (c *Redis[K, V]) Evict(ctx context.Context, key K) {
+	_ = c.client.Del(ctx, c.keyEncoder(key)).Err()
+}
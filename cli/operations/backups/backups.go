@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backups
+
+import (
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const backupTmpl = `
+id:      {{ .ID }}
+repo:    {{ .RepoPath }}
+state:   {{ .State }}
+trigger: {{ .Trigger }}
+size:    {{ .BundleSize }}
+`
+
+const repoTmpl = `
+id:        {{ .ID }}
+path:      {{ .Path }}
+importing: {{ .Importing }}
+`
+
+const impactTmpl = `
+path:              {{ .Path }}
+replaced repo:     {{ if .Repo }}{{ .Repo.Path }} ({{ .Repo.ID }}){{ else }}-{{ end }}
+lost pullreqs:     {{ .LostPullReqs }}
+reverted pullreqs: {{ .RevertedPullReqs }}
+restored pullreqs: {{ .RestoredPullReqs }}
+`
+
+// Register the command.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("backups", "manage repository backups (requires admin)")
+	registerList(cmd)
+	registerCreate(cmd)
+	registerRestore(cmd)
+}
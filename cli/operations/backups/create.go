@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backups
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	repoRef string
+	tmpl    string
+	json    bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().BackupCreate(ctx, &backup.CreateInput{
+		RepoRef: c.repoRef,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the backup create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "start a backup of a repository").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(backupTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
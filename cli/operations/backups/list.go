@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backups
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type listCommand struct {
+	repoID int64
+	state  string
+	page   int
+	size   int
+	tmpl   string
+	json   bool
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	list, err := provide.Client().BackupList(ctx, types.BackupFilter{
+		Page:   c.page,
+		Size:   c.size,
+		RepoID: c.repoID,
+		State:  enum.BackupState(c.state),
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.OutputList(list, c.tmpl, c.json)
+}
+
+// helper function registers the backup list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of repository backups").
+		Action(c.run)
+
+	cmd.Flag("repo-id", "only list the backups of the repository with the id").
+		Int64Var(&c.repoID)
+
+	cmd.Flag("state", "only list the backups in the state").
+		StringVar(&c.state)
+
+	cmd.Flag("page", "page number").
+		IntVar(&c.page)
+
+	cmd.Flag("per-page", "page size").
+		IntVar(&c.size)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(backupTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
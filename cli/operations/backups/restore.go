@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backups
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type restoreCommand struct {
+	id        int64
+	parentRef string
+	uid       string
+	overwrite bool
+	dryRun    bool
+	tmpl      string
+	json      bool
+}
+
+func (c *restoreCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	in := &backup.RestoreInput{
+		ParentRef: c.parentRef,
+		UID:       c.uid,
+		Overwrite: c.overwrite,
+	}
+
+	if c.dryRun {
+		impact, err := provide.Client().BackupRestoreDryRun(ctx, c.id, in)
+		if err != nil {
+			return err
+		}
+
+		return textui.Output(impact, impactTmpl, c.json)
+	}
+
+	out, err := provide.Client().BackupRestore(ctx, c.id, in)
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the backup restore command.
+func registerRestore(app *kingpin.CmdClause) {
+	c := &restoreCommand{}
+
+	cmd := app.Command("restore", "restore a backup to the original path of the repository or a new path, "+
+		"the repository is importing until the restore completes").
+		Action(c.run)
+
+	cmd.Arg("id", "backup id").
+		Required().
+		Int64Var(&c.id)
+
+	cmd.Arg("space", "path or id of the space the repository is restored into").
+		StringVar(&c.parentRef)
+
+	cmd.Arg("uid", "identifier of the restored repository").
+		StringVar(&c.uid)
+
+	cmd.Flag("overwrite", "replace the repository at the path, it's moved to the trash").
+		BoolVar(&c.overwrite)
+
+	cmd.Flag("dry-run", "show what would be overwritten without restoring the backup").
+		BoolVar(&c.dryRun)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -47,4 +47,6 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("migrate", "database migration tool")
 	registerCurrent(cmd)
 	registerTo(cmd)
+	registerStatus(cmd)
+	registerPhases(cmd)
 }
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandPhase struct {
+	envfile string
+	timeout time.Duration
+	migrate func(ctx context.Context, db *sqlx.DB) error
+}
+
+func (c *commandPhase) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	db, err := getDB(ctx, c.envfile)
+	if err != nil {
+		return err
+	}
+
+	return c.migrate(ctx, db)
+}
+
+func registerPhases(app *kingpin.CmdClause) {
+	registerPhase(app, "expand",
+		"applies all migrations up to the first contract migration (safe while old replicas are running)",
+		migrate.Expand)
+	registerPhase(app, "contract",
+		"applies all pending migrations (only once all replicas are running the new version)",
+		migrate.Migrate)
+}
+
+func registerPhase(app *kingpin.CmdClause, name string, help string,
+	migrateFn func(ctx context.Context, db *sqlx.DB) error) {
+	c := &commandPhase{
+		migrate: migrateFn,
+	}
+
+	cmd := app.Command(name, help).
+		Action(c.run)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+
+	cmd.Flag("timeout", "maximum duration of the migration").
+		Default("1h").
+		DurationVar(&c.timeout)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqs
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	repoRef       string
+	sourceRepoRef string
+	sourceBranch  string
+	targetBranch  string
+	title         string
+	description   string
+	draft         bool
+	tmpl          string
+	json          bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().PullReqCreate(ctx, c.repoRef, &pullreq.CreateInput{
+		IsDraft:       c.draft,
+		Title:         c.title,
+		Description:   c.description,
+		SourceRepoRef: c.sourceRepoRef,
+		SourceBranch:  c.sourceBranch,
+		TargetBranch:  c.targetBranch,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the pull request create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "target repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("source", "source branch").
+		Required().
+		StringVar(&c.sourceBranch)
+
+	cmd.Arg("target", "target branch").
+		Required().
+		StringVar(&c.targetBranch)
+
+	cmd.Flag("title", "pull request title").
+		Required().
+		StringVar(&c.title)
+
+	cmd.Flag("description", "pull request description").
+		StringVar(&c.description)
+
+	cmd.Flag("source-repo", "source repository path or id (for pull requests from forks)").
+		StringVar(&c.sourceRepoRef)
+
+	cmd.Flag("draft", "create the pull request as draft").
+		BoolVar(&c.draft)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
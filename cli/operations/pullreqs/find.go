@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqs
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type findCommand struct {
+	repoRef string
+	number  int64
+	tmpl    string
+	json    bool
+}
+
+func (c *findCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pr, err := provide.Client().PullReq(ctx, c.repoRef, c.number)
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(pr, c.tmpl, c.json)
+}
+
+// helper function registers the pull request find command.
+func registerFind(app *kingpin.CmdClause) {
+	c := &findCommand{}
+
+	cmd := app.Command("find", "display pull request details").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("number", "pull request number").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqs
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type listCommand struct {
+	repoRef string
+	query   string
+	states  []string
+	page    int
+	size    int
+	tmpl    string
+	json    bool
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	states := make([]enum.PullReqState, len(c.states))
+	for i, state := range c.states {
+		states[i] = enum.PullReqState(state)
+	}
+
+	list, err := provide.Client().PullReqList(ctx, c.repoRef, types.PullReqFilter{
+		Page:   c.page,
+		Size:   c.size,
+		Query:  c.query,
+		States: states,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.OutputList(list, c.tmpl, c.json)
+}
+
+// helper function registers the pull request list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of pull requests of a repository").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Flag("query", "filter pull requests by title").
+		StringVar(&c.query)
+
+	cmd.Flag("state", "filter pull requests by state (repeatable)").
+		EnumsVar(&c.states,
+			string(enum.PullReqStateOpen), string(enum.PullReqStateMerged), string(enum.PullReqStateClosed))
+
+	cmd.Flag("page", "page number").
+		IntVar(&c.page)
+
+	cmd.Flag("per-page", "page size").
+		IntVar(&c.size)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
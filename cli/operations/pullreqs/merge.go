@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqs
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	gitrpcenum "github.com/harness/gitness/gitrpc/enum"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type mergeCommand struct {
+	repoRef            string
+	number             int64
+	method             string
+	sourceSHA          string
+	deleteSourceBranch *bool
+	tmpl               string
+	json               bool
+}
+
+func (c *mergeCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sourceSHA := c.sourceSHA
+	if sourceSHA == "" {
+		pr, err := provide.Client().PullReq(ctx, c.repoRef, c.number)
+		if err != nil {
+			return err
+		}
+		sourceSHA = pr.SourceSHA
+	}
+
+	out, err := provide.Client().PullReqMerge(ctx, c.repoRef, c.number, &pullreq.MergeInput{
+		Method:             enum.MergeMethod(c.method),
+		SourceSHA:          sourceSHA,
+		DeleteSourceBranch: c.deleteSourceBranch,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the pull request merge command.
+func registerMerge(app *kingpin.CmdClause) {
+	c := &mergeCommand{}
+	deleteSourceBranch := false
+
+	cmd := app.Command("merge", "merge a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("number", "pull request number").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Flag("method", "merge method").
+		Default(string(gitrpcenum.MergeMethodMerge)).
+		EnumVar(&c.method, string(gitrpcenum.MergeMethodMerge), string(gitrpcenum.MergeMethodSquash),
+			string(gitrpcenum.MergeMethodRebase))
+
+	cmd.Flag("sha", "expected head commit of the source branch (defaults to the current head)").
+		StringVar(&c.sourceSHA)
+
+	// the repository setting is only overridden if the flag is provided explicitly.
+	cmd.Flag("delete-source-branch", "delete the source branch after the merge (defaults to the repository setting)").
+		Action(func(*kingpin.ParseContext) error {
+			c.deleteSourceBranch = &deleteSourceBranch
+			return nil
+		}).
+		BoolVar(&deleteSourceBranch)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(mergeTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
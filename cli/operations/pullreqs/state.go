@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqs
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type stateCommand struct {
+	repoRef string
+	number  int64
+	state   string
+	draft   bool
+	message string
+	tmpl    string
+	json    bool
+}
+
+func (c *stateCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().PullReqState(ctx, c.repoRef, c.number, &pullreq.StateInput{
+		State:   enum.PullReqState(c.state),
+		IsDraft: c.draft,
+		Message: c.message,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the pull request state command.
+func registerState(app *kingpin.CmdClause) {
+	c := &stateCommand{}
+
+	cmd := app.Command("state", "close, reopen or change the draft status of a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("number", "pull request number").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Arg("state", "new state of the pull request").
+		Required().
+		EnumVar(&c.state, string(enum.PullReqStateOpen), string(enum.PullReqStateClosed))
+
+	cmd.Flag("draft", "mark the pull request as draft").
+		BoolVar(&c.draft)
+
+	cmd.Flag("message", "message explaining the state change").
+		StringVar(&c.message)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
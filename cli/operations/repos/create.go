@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	spaceRef      string
+	uid           string
+	description   string
+	defaultBranch string
+	public        *bool
+	readme        bool
+	tmpl          string
+	json          bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().RepoCreate(ctx, &repo.CreateInput{
+		ParentRef:     c.spaceRef,
+		UID:           c.uid,
+		Description:   c.description,
+		DefaultBranch: c.defaultBranch,
+		IsPublic:      c.public,
+		Readme:        c.readme,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the repository create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+	public := false
+
+	cmd := app.Command("create", "create a repository").
+		Action(c.run)
+
+	cmd.Arg("space", "space path or id").
+		Required().
+		StringVar(&c.spaceRef)
+
+	cmd.Arg("uid", "repository uid").
+		Required().
+		StringVar(&c.uid)
+
+	cmd.Flag("description", "repository description").
+		StringVar(&c.description)
+
+	cmd.Flag("default-branch", "default branch of the repository").
+		StringVar(&c.defaultBranch)
+
+	// the instance default visibility is only overridden if the flag is provided explicitly.
+	cmd.Flag("public", "repository is public (defaults to the instance default visibility)").
+		Action(func(*kingpin.ParseContext) error {
+			c.public = &public
+			return nil
+		}).
+		BoolVar(&public)
+
+	cmd.Flag("readme", "initialize the repository with a readme").
+		BoolVar(&c.readme)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
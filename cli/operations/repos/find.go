@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type findCommand struct {
+	ref  string
+	tmpl string
+	json bool
+}
+
+func (c *findCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	repo, err := provide.Client().Repo(ctx, c.ref)
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(repo, c.tmpl, c.json)
+}
+
+// helper function registers the repository find command.
+func registerFind(app *kingpin.CmdClause) {
+	c := &findCommand{}
+
+	cmd := app.Command("find", "display repository details").
+		Action(c.run)
+
+	cmd.Arg("path or id", "repository path or id").
+		Required().
+		StringVar(&c.ref)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
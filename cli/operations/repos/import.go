@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type importCommand struct {
+	spaceRef     string
+	uid          string
+	description  string
+	provider     string
+	host         string
+	username     string
+	password     string
+	proxy        string
+	providerRepo string
+	pipelines    string
+	tmpl         string
+	json         bool
+}
+
+func (c *importCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().RepoImport(ctx, &repo.ImportInput{
+		ParentRef:   c.spaceRef,
+		UID:         c.uid,
+		Description: c.description,
+		Provider: importer.Provider{
+			Type:     importer.ProviderType(c.provider),
+			Host:     c.host,
+			Username: c.username,
+			Password: c.password,
+			ProxyURL: c.proxy,
+		},
+		ProviderRepo: c.providerRepo,
+		Pipelines:    importer.PipelineOption(c.pipelines),
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the repository import command.
+func registerImport(app *kingpin.CmdClause) {
+	c := &importCommand{}
+
+	cmd := app.Command("import", "import a repository from an external provider").
+		Action(c.run)
+
+	cmd.Arg("space", "space path or id").
+		Required().
+		StringVar(&c.spaceRef)
+
+	cmd.Arg("provider repo", "the repository to import from the provider (e.g. org/repo)").
+		Required().
+		StringVar(&c.providerRepo)
+
+	cmd.Flag("uid", "repository uid (defaults to the name of the provider repository)").
+		StringVar(&c.uid)
+
+	cmd.Flag("description", "repository description").
+		StringVar(&c.description)
+
+	cmd.Flag("provider", "provider type").
+		Default(string(importer.ProviderTypeGitHub)).
+		EnumVar(&c.provider, string(importer.ProviderTypeGitHub), string(importer.ProviderTypeGitLab))
+
+	cmd.Flag("host", "provider host (for self-hosted providers)").
+		StringVar(&c.host)
+
+	cmd.Flag("username", "provider username").
+		StringVar(&c.username)
+
+	cmd.Flag("password", "provider password or access token").
+		Envar("GITNESS_IMPORT_PASSWORD").
+		StringVar(&c.password)
+
+	cmd.Flag("proxy", "url of the proxy used to connect to the provider (configured proxy if empty)").
+		StringVar(&c.proxy)
+
+	cmd.Flag("pipelines", "how to import pipelines").
+		Default(string(importer.PipelineOptionIgnore)).
+		EnumVar(&c.pipelines, string(importer.PipelineOptionConvert), string(importer.PipelineOptionIgnore))
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
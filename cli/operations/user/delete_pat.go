@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type deletePATCommand struct {
+	uid string
+}
+
+func (c *deletePATCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return provide.Client().UserDeletePAT(ctx, c.uid)
+}
+
+// Register the command.
+func registerDeletePAT(app *kingpin.CmdClause) {
+	c := &deletePATCommand{}
+
+	cmd := app.Command("delete-pat", "delete personal access token").
+		Action(c.run)
+
+	cmd.Arg("uid", "the uid of the token").
+		Required().StringVar(&c.uid)
+}
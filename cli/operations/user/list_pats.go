@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const patTmpl = `
+uid:       {{ .UID }}
+issuedAt:  {{ .IssuedAt }}
+expiresAt: {{ .ExpiresAt }}
+`
+
+type listPATsCommand struct {
+	json bool
+	tmpl string
+}
+
+func (c *listPATsCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	list, err := provide.Client().UserListPATs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return textui.OutputList(list, c.tmpl, c.json)
+}
+
+// Register the command.
+func registerListPATs(app *kingpin.CmdClause) {
+	c := &listPATsCommand{}
+
+	cmd := app.Command("pats", "display a list of personal access tokens").
+		Action(c.run)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(patTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
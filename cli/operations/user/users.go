@@ -23,4 +23,6 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("user", "manage currently logged-in user")
 	registerSelf(cmd)
 	registerCreatePAT(cmd)
+	registerListPATs(cmd)
+	registerDeletePAT(cmd)
 }
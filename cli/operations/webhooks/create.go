@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	repoRef     string
+	displayName string
+	description string
+	url         string
+	secret      string
+	insecure    bool
+	proxyURL    string
+	disabled    bool
+	triggers    []string
+	tmpl        string
+	json        bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	triggers := make([]enum.WebhookTrigger, len(c.triggers))
+	for i, trigger := range c.triggers {
+		triggers[i] = enum.WebhookTrigger(trigger)
+	}
+
+	out, err := provide.Client().WebhookCreate(ctx, c.repoRef, &webhook.CreateInput{
+		DisplayName: c.displayName,
+		Description: c.description,
+		URL:         c.url,
+		Secret:      c.secret,
+		Enabled:     !c.disabled,
+		Insecure:    c.insecure,
+		ProxyURL:    c.proxyURL,
+		Triggers:    triggers,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Output(out, c.tmpl, c.json)
+}
+
+// helper function registers the webhook create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a webhook for a repository").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository path or id").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("name", "webhook display name").
+		Required().
+		StringVar(&c.displayName)
+
+	cmd.Arg("url", "webhook url").
+		Required().
+		StringVar(&c.url)
+
+	cmd.Flag("description", "webhook description").
+		StringVar(&c.description)
+
+	cmd.Flag("secret", "secret used to sign the webhook payload").
+		Envar("GITNESS_WEBHOOK_SECRET").
+		StringVar(&c.secret)
+
+	cmd.Flag("insecure", "skip verification of the server certificate").
+		BoolVar(&c.insecure)
+
+	cmd.Flag("proxy", "url of the proxy used to send the webhook (configured proxy if empty)").
+		StringVar(&c.proxyURL)
+
+	cmd.Flag("disabled", "create the webhook disabled").
+		BoolVar(&c.disabled)
+
+	cmd.Flag("trigger", "trigger of the webhook (repeatable, all triggers if empty)").
+		StringsVar(&c.triggers)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(webhookTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
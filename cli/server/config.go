@@ -22,9 +22,19 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/services/backup"
+	"github.com/harness/gitness/app/services/chatintegration"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/feed"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/refwatch"
+	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/sbom"
+	"github.com/harness/gitness/app/services/scanning"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/gitrpc"
 	"github.com/harness/gitness/gitrpc/server"
@@ -32,6 +42,7 @@ import (
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/kelseyhightower/envconfig"
 	"golang.org/x/text/runes"
@@ -202,8 +213,12 @@ func getSanitizedMachineName() (string, error) {
 // ProvideDatabaseConfig loads the database config from the main config.
 func ProvideDatabaseConfig(config *types.Config) database.Config {
 	return database.Config{
-		Driver:     config.Database.Driver,
-		Datasource: config.Database.Datasource,
+		Driver:                 config.Database.Driver,
+		Datasource:             config.Database.Datasource,
+		SkipContractMigrations: config.Database.SkipContractMigrations,
+		ReplicaDatasources:     config.Database.ReplicaDatasources,
+		ReplicaMaxLag:          config.Database.ReplicaMaxLag,
+		ReplicaCheckInterval:   config.Database.ReplicaCheckInterval,
 	}
 }
 
@@ -255,19 +270,18 @@ func ProvideEventsConfig(config *types.Config) events.Config {
 		Namespace:             config.Events.Namespace,
 		MaxStreamLength:       config.Events.MaxStreamLength,
 		ApproxMaxStreamLength: config.Events.ApproxMaxStreamLength,
+		NatsURL:               config.Events.NatsURL,
 	}
 }
 
 // ProvideWebhookConfig loads the webhook service config from the main config.
 func ProvideWebhookConfig(config *types.Config) webhook.Config {
 	return webhook.Config{
-		UserAgentIdentity:   config.Webhook.UserAgentIdentity,
-		HeaderIdentity:      config.Webhook.HeaderIdentity,
-		EventReaderName:     config.InstanceID,
-		Concurrency:         config.Webhook.Concurrency,
-		MaxRetries:          config.Webhook.MaxRetries,
-		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
-		AllowLoopback:       config.Webhook.AllowLoopback,
+		UserAgentIdentity: config.Webhook.UserAgentIdentity,
+		HeaderIdentity:    config.Webhook.HeaderIdentity,
+		EventReaderName:   config.InstanceID,
+		Concurrency:       config.Webhook.Concurrency,
+		MaxRetries:        config.Webhook.MaxRetries,
 	}
 }
 
@@ -280,6 +294,15 @@ func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	}
 }
 
+// ProvideRepoSizeConfig loads the repo size service config from the main config.
+func ProvideRepoSizeConfig(config *types.Config) reposize.Config {
+	return reposize.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Webhook.Concurrency,
+		MaxRetries:      config.Webhook.MaxRetries,
+	}
+}
+
 // ProvideLockConfig generates the `lock` package config from the gitness config.
 func ProvideLockConfig(config *types.Config) lock.Config {
 	return lock.Config{
@@ -294,6 +317,46 @@ func ProvideLockConfig(config *types.Config) lock.Config {
 	}
 }
 
+// ProvideBlobStoreConfig loads the blob store config from the main config.
+func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
+	root := config.BlobStore.Root
+	if root == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return blob.Config{}, err
+		}
+
+		root = filepath.Join(homedir, ".gitness", "blobs")
+	}
+
+	return blob.Config{
+		Provider:        config.BlobStore.Provider,
+		Bucket:          config.BlobStore.Bucket,
+		Prefix:          config.BlobStore.Prefix,
+		SignedURLExpiry: config.BlobStore.SignedURLExpiry,
+		FileSystem: blob.FileSystemConfig{
+			Root: root,
+		},
+		S3: blob.S3Config{
+			Region:               config.BlobStore.S3.Region,
+			Endpoint:             config.BlobStore.S3.Endpoint,
+			PathStyle:            config.BlobStore.S3.PathStyle,
+			ServerSideEncryption: config.BlobStore.S3.ServerSideEncryption,
+			KMSKeyID:             config.BlobStore.S3.KMSKeyID,
+		},
+		GCS: blob.GCSConfig{
+			KeyPath:    config.BlobStore.GCS.KeyPath,
+			KMSKeyName: config.BlobStore.GCS.KMSKeyName,
+		},
+		Azure: blob.AzureConfig{
+			AccountName:     config.BlobStore.Azure.AccountName,
+			AccountKey:      config.BlobStore.Azure.AccountKey,
+			ServiceURL:      config.BlobStore.Azure.ServiceURL,
+			EncryptionScope: config.BlobStore.Azure.EncryptionScope,
+		},
+	}, nil
+}
+
 // ProvidePubsubConfig loads the pubsub config from the main config.
 func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	return pubsub.Config{
@@ -310,5 +373,122 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime: config.Webhook.RetentionTime,
+		WebhookExecutionsMax:           config.Webhook.RetentionMaxExecutions,
+		PipelineLogsRetentionTime:      config.Logs.RetentionTime,
+		DeadLettersRetentionTime:       config.Events.DeadLetters.RetentionTime,
+		DeletedRetentionTime:           config.Trash.RetentionTime,
+		FeedEventsRetentionTime:        config.Feed.RetentionTime,
+	}
+}
+
+// ProvideNotificationConfig loads the notification service config from the main config.
+func ProvideNotificationConfig(config *types.Config) notification.Config {
+	return notification.Config{
+		Enabled:         config.Notification.Enabled,
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Notification.Concurrency,
+		MaxRetries:      config.Notification.MaxRetries,
+		SMTP: notification.SMTPConfig{
+			Host:     config.SMTP.Host,
+			Port:     config.SMTP.Port,
+			Username: config.SMTP.Username,
+			Password: config.SMTP.Password,
+			FromMail: config.SMTP.FromMail,
+			Insecure: config.SMTP.Insecure,
+		},
+	}
+}
+
+// ProvideChatIntegrationConfig loads the chat integration service config from the main config.
+func ProvideChatIntegrationConfig(config *types.Config) chatintegration.Config {
+	return chatintegration.Config{
+		EventReaderName:   config.InstanceID,
+		UserAgentIdentity: config.Webhook.UserAgentIdentity,
+		Concurrency:       config.ChatIntegration.Concurrency,
+		MaxRetries:        config.ChatIntegration.MaxRetries,
+		RateLimit:         config.ChatIntegration.RateLimit,
+	}
+}
+
+// ProvideFeedConfig loads the feed service config from the main config.
+func ProvideFeedConfig(config *types.Config) feed.Config {
+	return feed.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Feed.Concurrency,
+		MaxRetries:      config.Feed.MaxRetries,
+	}
+}
+
+// ProvideRefWatchConfig loads the ref watch service config from the main config.
+func ProvideRefWatchConfig(config *types.Config) refwatch.Config {
+	return refwatch.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.RefWatch.Concurrency,
+		MaxRetries:      config.RefWatch.MaxRetries,
+	}
+}
+
+// ProvideScanningConfig loads the scanning service config from the main config.
+func ProvideScanningConfig(config *types.Config) (scanning.Config, error) {
+	var scanners []scanning.Definition
+	if config.Scanning.ConfigPath != "" {
+		var err error
+		scanners, err = scanning.LoadDefinitions(config.Scanning.ConfigPath)
+		if err != nil {
+			return scanning.Config{}, err
+		}
+	}
+
+	return scanning.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Scanning.Concurrency,
+		MaxRetries:      config.Scanning.MaxRetries,
+		DefaultTimeout:  config.Scanning.DefaultTimeout,
+		FailSeverity:    enum.ScanSeverity(config.Scanning.FailSeverity),
+		MaxFileSize:     config.Scanning.MaxFileSize,
+		Scanners:        scanners,
+	}, nil
+}
+
+// ProvideUploadScanConfig loads the upload scan service config from the main config.
+func ProvideUploadScanConfig(config *types.Config) uploadscan.Config {
+	return uploadscan.Config{
+		Backend:  strings.ToLower(config.UploadScan.Backend),
+		Address:  config.UploadScan.Address,
+		Timeout:  config.UploadScan.Timeout,
+		MaxSize:  config.UploadScan.MaxSize,
+		FailOpen: config.UploadScan.FailOpen,
+	}
+}
+
+// ProvideSBOMConfig loads the sbom service config from the main config.
+func ProvideSBOMConfig(config *types.Config) sbom.Config {
+	formats := make([]enum.SBOMFormat, len(config.SBOM.Formats))
+	for i, f := range config.SBOM.Formats {
+		formats[i] = enum.SBOMFormat(strings.TrimSpace(f))
+	}
+
+	return sbom.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.SBOM.Concurrency,
+		MaxRetries:      config.SBOM.MaxRetries,
+		Command:         config.SBOM.GeneratorCommand,
+		Formats:         formats,
+		Paths:           config.SBOM.Paths,
+		Timeout:         config.SBOM.Timeout,
+		MaxFileSize:     config.SBOM.MaxFileSize,
+		MaxSize:         config.SBOM.MaxSize,
+	}
+}
+
+// ProvideBackupConfig loads the backup service config from the main config.
+func ProvideBackupConfig(config *types.Config) backup.Config {
+	return backup.Config{
+		Enabled:        config.Backup.Enabled,
+		Cron:           config.Backup.Cron,
+		RetentionCount: config.Backup.RetentionCount,
+		Timeout:        config.Backup.Timeout,
+		MaxRetries:     config.Backup.MaxRetries,
+		DefaultBranch:  config.Git.DefaultBranch,
 	}
 }
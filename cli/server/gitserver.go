@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	gitrpcserver "github.com/harness/gitness/gitrpc/server"
+	gitrpccron "github.com/harness/gitness/gitrpc/server/cron"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/version"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// GitServer stores the sub-routines of a standalone gitrpc server.
+type GitServer struct {
+	gitRPCServer   *gitrpcserver.GRPCServer
+	gitRPCCronMngr *gitrpccron.Manager
+}
+
+// NewGitServer returns a new git server structure.
+func NewGitServer(gitRPCServer *gitrpcserver.GRPCServer, gitRPCCronMngr *gitrpccron.Manager) *GitServer {
+	return &GitServer{
+		gitRPCServer:   gitRPCServer,
+		gitRPCCronMngr: gitRPCCronMngr,
+	}
+}
+
+type gitServerCommand struct {
+	envfile     string
+	initializer func(context.Context, *types.Config) (*GitServer, error)
+}
+
+func (c *gitServerCommand) run(*kingpin.ParseContext) error {
+	// Create context that listens for the interrupt signal from the OS.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// load environment variables from file.
+	// no error handling needed when file is not present
+	_ = godotenv.Load(c.envfile)
+
+	config, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("encountered an error while loading configuration: %w", err)
+	}
+
+	SetupLogger(config)
+	SetupProfiler(config)
+
+	shutdownTracing, err := SetupTracing(ctx, config)
+	if err != nil {
+		return fmt.Errorf("encountered an error while setting up tracing: %w", err)
+	}
+
+	// add logger to context
+	log := log.Logger.With().Logger()
+	ctx = log.WithContext(ctx)
+
+	gitServer, err := c.initializer(ctx, config)
+	if err != nil {
+		return fmt.Errorf("encountered an error while wiring the git server: %w", err)
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(gitServer.gitRPCServer.Start)
+	g.Go(func() error {
+		return gitServer.gitRPCCronMngr.Run(gCtx)
+	})
+
+	log.Info().
+		Str("revision", version.GitCommit).
+		Str("repository", version.GitRepository).
+		Stringer("version", version.Version).
+		Msg("git server started")
+
+	// wait until the error group context is done
+	<-gCtx.Done()
+
+	// restore default behavior on the interrupt signal and notify user of shutdown.
+	stop()
+	log.Info().Msg("shutting down gracefully (press Ctrl+C again to force)")
+
+	if rpcErr := gitServer.gitRPCServer.Stop(); rpcErr != nil {
+		log.Err(rpcErr).Msg("failed to shutdown grpc server gracefully")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GracefulShutdownTime)
+	defer cancel()
+
+	if tErr := shutdownTracing(shutdownCtx); tErr != nil {
+		log.Err(tErr).Msg("failed to flush pending spans")
+	}
+
+	log.Info().Msg("wait for subroutines to complete")
+
+	return g.Wait()
+}
+
+// RegisterGitServer registers the command starting a standalone gitrpc server.
+// It allows to run the git storage separately from the API (e.g. as one of multiple shards),
+// with the server pointed to it via GITRPC_CLIENT_ADDR or GITRPC_CLIENT_SHARDS and --enable-gitrpc=false.
+func RegisterGitServer(app *kingpin.Application, initializer func(context.Context, *types.Config) (*GitServer, error)) {
+	c := new(gitServerCommand)
+	c.initializer = initializer
+
+	cmd := app.Command("gitserver", "starts a standalone gitrpc server").
+		Action(c.run)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
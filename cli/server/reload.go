@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/harness/gitness/app/server"
+	"github.com/harness/gitness/loglevel"
+
+	"github.com/joho/godotenv"
+	"github.com/rs/zerolog/log"
+)
+
+// configReloader applies the config values that can be changed at runtime without restarting the server:
+// the default log level and the tls certificate of the http server.
+type configReloader struct {
+	envfile    string
+	processEnv map[string]struct{}
+	server     *server.Server
+
+	envfileModTime time.Time
+	envfileKeys    map[string]struct{}
+}
+
+func newConfigReloader(envfile string, processEnv map[string]struct{}, server *server.Server) *configReloader {
+	r := &configReloader{
+		envfile:    envfile,
+		processEnv: processEnv,
+		server:     server,
+	}
+
+	// the env file got loaded on startup already.
+	r.envfileModTime, _ = r.envfileChanged()
+	if values, err := godotenv.Read(envfile); err == nil {
+		r.envfileKeys = r.fileKeys(values)
+	}
+
+	return r
+}
+
+// Run reloads the config on SIGHUP and whenever the env file changed (checked on every interval).
+// It blocks until the context is canceled.
+func (r *configReloader) Run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Ctx(ctx).Info().Msg("received SIGHUP, reloading config")
+		case <-tick:
+			modTime, changed := r.envfileChanged()
+			if !changed {
+				continue
+			}
+			r.envfileModTime = modTime
+			log.Ctx(ctx).Info().Str("envfile", r.envfile).Msg("env file changed, reloading config")
+		}
+
+		if err := r.reload(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload config")
+		}
+	}
+}
+
+func (r *configReloader) reload() error {
+	if err := r.reloadEnvfile(); err != nil {
+		return err
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	loglevel.SetDefault(logLevel(config))
+
+	if err = r.server.ReloadCertificate(); err != nil {
+		return fmt.Errorf("failed to reload tls certificate: %w", err)
+	}
+
+	return nil
+}
+
+// reloadEnvfile updates the environment with the current values of the env file.
+// Variables removed from the file are unset, variables set by the process environment are left untouched.
+func (r *configReloader) reloadEnvfile() error {
+	values, err := godotenv.Read(r.envfile)
+	if errors.Is(err, fs.ErrNotExist) {
+		values = map[string]string{}
+	} else if err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	keys := r.fileKeys(values)
+	for key := range r.envfileKeys {
+		if _, ok := keys[key]; !ok {
+			_ = os.Unsetenv(key)
+		}
+	}
+	for key := range keys {
+		if err = os.Setenv(key, values[key]); err != nil {
+			return fmt.Errorf("failed to set env variable %q: %w", key, err)
+		}
+	}
+
+	r.envfileKeys = keys
+
+	return nil
+}
+
+// fileKeys returns the keys of the env file values that aren't overridden by the process environment.
+func (r *configReloader) fileKeys(values map[string]string) map[string]struct{} {
+	keys := make(map[string]struct{}, len(values))
+	for key := range values {
+		if _, ok := r.processEnv[key]; !ok {
+			keys[key] = struct{}{}
+		}
+	}
+	return keys
+}
+
+func (r *configReloader) envfileChanged() (time.Time, bool) {
+	info, err := os.Stat(r.envfile)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return info.ModTime(), !info.ModTime().Equal(r.envfileModTime)
+}
+
+// environKeys returns the keys of all variables of the process environment.
+func environKeys() map[string]struct{} {
+	environ := os.Environ()
+	keys := make(map[string]struct{}, len(environ))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		keys[key] = struct{}{}
+	}
+	return keys
+}
@@ -22,7 +22,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/harness/gitness/loglevel"
 	"github.com/harness/gitness/profiler"
+	"github.com/harness/gitness/tracing"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
 
@@ -45,9 +47,16 @@ type command struct {
 
 func (c *command) run(*kingpin.ParseContext) error {
 	// Create context that listens for the interrupt signal from the OS.
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// the system context is only canceled once the in-flight work got drained after the interrupt signal.
+	ctx, cancelSystem := context.WithCancel(context.Background())
+	defer cancelSystem()
+
+	// remember the variables set by the process environment, they take precedence over the env file on reload.
+	processEnv := environKeys()
+
 	// load environment variables from file.
 	// no error handling needed when file is not present
 	_ = godotenv.Load(c.envfile)
@@ -65,6 +74,12 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// configure profiler
 	SetupProfiler(config)
 
+	// configure tracing
+	shutdownTracing, err := SetupTracing(ctx, config)
+	if err != nil {
+		return fmt.Errorf("encountered an error while setting up tracing: %w", err)
+	}
+
 	// add logger to context
 	log := log.Logger.With().Logger()
 	ctx = log.WithContext(ctx)
@@ -100,12 +115,55 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if system.services.Notification != nil {
+			if err := system.services.Notification.Register(gCtx); err != nil {
+				log.Error().Err(err).Msg("failed to register notification service")
+				return err
+			}
+		}
+
+		if err := system.services.Insights.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register insights service")
+			return err
+		}
+
+		if err := system.services.ReviewReminder.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register review reminder service")
+			return err
+		}
+
+		if err := system.services.MembershipExpiry.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register membership expiry service")
+			return err
+		}
+
+		if err := system.services.Backup.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register backup service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
+	// campaign for the leadership among all instances (for work that has to run on a single instance)
+	g.Go(func() error {
+		return system.services.LeaderElector.Run(gCtx)
+	})
+
+	// deliver the events persisted in the outbox
+	g.Go(func() error {
+		return system.services.EventOutbox.Run(gCtx)
+	})
+
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
+
+	// apply changes of the reloadable config values on SIGHUP and when the env file changes.
+	g.Go(func() error {
+		newConfigReloader(c.envfile, processEnv, system.server).Run(gCtx, config.ConfigReloadInterval)
+		return nil
+	})
 	if c.enableCI {
 		// start populating plugins
 		g.Go(func() error {
@@ -133,6 +191,14 @@ func (c *command) run(*kingpin.ParseContext) error {
 		Stringer("version", version.Version).
 		Msg("server started")
 
+	if config.Server.GRPC.Enabled {
+		// start the grpc api server
+		g.Go(system.grpcServer.Start)
+		log.Info().
+			Int("port", config.Server.GRPC.Port).
+			Msg("grpc api server started")
+	}
+
 	if c.enableGitRPC {
 		// start grpc server
 		g.Go(system.gitRPCServer.Start)
@@ -145,13 +211,20 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Info().Msg("gitrpc cron manager subroutine started")
 	}
 
-	// wait until the error group context is done
-	<-gCtx.Done()
+	// wait until the interrupt signal is received or the error group context is done
+	select {
+	case <-signalCtx.Done():
+	case <-gCtx.Done():
+	}
 
 	// restore default behavior on the interrupt signal and notify user of shutdown.
 	stop()
 	log.Info().Msg("shutting down gracefully (press Ctrl+C again to force)")
 
+	// stop accepting new work and wait for the in-flight git operations, hooks and event handlers.
+	system.services.Drain.Drain(ctx)
+	cancelSystem()
+
 	// shutdown servers gracefully
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GracefulShutdownTime)
 	defer cancel()
@@ -160,6 +233,12 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
+	if config.Server.GRPC.Enabled {
+		if grpcErr := system.grpcServer.Stop(); grpcErr != nil {
+			log.Err(grpcErr).Msg("failed to shutdown grpc api server gracefully")
+		}
+	}
+
 	if c.enableGitRPC {
 		if rpcErr := system.gitRPCServer.Stop(); rpcErr != nil {
 			log.Err(rpcErr).Msg("failed to shutdown grpc server gracefully")
@@ -168,23 +247,37 @@ func (c *command) run(*kingpin.ParseContext) error {
 
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
+	if tErr := shutdownTracing(shutdownCtx); tErr != nil {
+		log.Err(tErr).Msg("failed to flush pending spans")
+	}
+
 	log.Info().Msg("wait for subroutines to complete")
 	err = g.Wait()
 
 	return err
 }
 
-// SetupLogger configures the global logger from the loaded configuration.
-func SetupLogger(config *types.Config) {
-	// configure the log level
+// logLevel returns the default log level configured in the config.
+func logLevel(config *types.Config) zerolog.Level {
 	switch {
 	case config.Trace:
-		zerolog.SetGlobalLevel(zerolog.TraceLevel)
+		return zerolog.TraceLevel
 	case config.Debug:
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+		return zerolog.DebugLevel
 	default:
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+		return zerolog.InfoLevel
 	}
+}
+
+// SetupLogger configures the global logger from the loaded configuration.
+func SetupLogger(config *types.Config) {
+	// configure the log level
+	level := logLevel(config)
+
+	// the global level doesn't filter anything, the effective level is set on the loggers instead.
+	// That allows to adjust the level of single subsystems (and spaces) at runtime.
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	loglevel.SetDefault(level)
 
 	// configure time format (ignored if running in terminal)
 	zerolog.TimeFieldFormat = time.RFC3339Nano
@@ -200,6 +293,8 @@ func SetupLogger(config *types.Config) {
 			},
 		)
 	}
+
+	log.Logger = log.Logger.Level(level)
 }
 
 func SetupProfiler(config *types.Config) {
@@ -235,3 +330,19 @@ func Register(app *kingpin.Application, initializer func(context.Context, *types
 		Envar("ENABLE_CI").
 		BoolVar(&c.enableCI)
 }
+
+// SetupTracing configures the OpenTelemetry tracing from the loaded configuration.
+func SetupTracing(ctx context.Context, config *types.Config) (tracing.ShutdownFunc, error) {
+	if !config.Tracing.Enabled {
+		log.Info().Msg("Tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	return tracing.Setup(ctx, tracing.Config{
+		ServiceName:    config.Tracing.ServiceName,
+		ServiceVersion: version.Version.String(),
+		Endpoint:       config.Tracing.Endpoint,
+		Insecure:       config.Tracing.Insecure,
+		SampleRatio:    config.Tracing.SampleRatio,
+	})
+}
@@ -15,6 +15,7 @@
 package server
 
 import (
+	apigrpc "github.com/harness/gitness/app/api/grpc"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/server"
@@ -29,6 +30,7 @@ import (
 type System struct {
 	bootstrap      bootstrap.Bootstrap
 	server         *server.Server
+	grpcServer     *apigrpc.Server
 	gitRPCServer   *gitrpcserver.GRPCServer
 	pluginManager  *plugin.Manager
 	poller         *poller.Poller
@@ -37,12 +39,13 @@ type System struct {
 }
 
 // NewSystem returns a new system structure.
-func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, poller *poller.Poller,
+func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, grpcServer *apigrpc.Server, poller *poller.Poller,
 	gitRPCServer *gitrpcserver.GRPCServer, pluginManager *plugin.Manager,
 	gitrpccron *gitrpccron.Manager, services services.Services) *System {
 	return &System{
 		bootstrap:      bootstrap,
 		server:         server,
+		grpcServer:     grpcServer,
 		poller:         poller,
 		gitRPCServer:   gitRPCServer,
 		pluginManager:  pluginManager,
@@ -23,8 +23,14 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
 
@@ -143,10 +149,262 @@ func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
 	return err
 }
 
+// UserListPATs returns the PATs of the user.
+func (c *HTTPClient) UserListPATs(ctx context.Context) ([]types.Token, error) {
+	out := []types.Token{}
+	uri := fmt.Sprintf("%s/api/v1/user/tokens", c.base)
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// UserDeletePAT deletes a PAT of the user.
+func (c *HTTPClient) UserDeletePAT(ctx context.Context, tokenUID string) error {
+	uri := fmt.Sprintf("%s/api/v1/user/tokens/%s", c.base, url.PathEscape(tokenUID))
+	return c.delete(ctx, uri)
+}
+
+//
+// Space Endpoints
+//
+
+// Space returns a space by path or ID.
+func (c *HTTPClient) Space(ctx context.Context, spaceRef string) (*types.Space, error) {
+	out := new(types.Space)
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s", c.base, url.PathEscape(spaceRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// SpaceList returns the child spaces of a space.
+func (c *HTTPClient) SpaceList(ctx context.Context, spaceRef string, params types.SpaceFilter) ([]types.Space, error) {
+	out := []types.Space{}
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s/spaces?%s", c.base, url.PathEscape(spaceRef),
+		listQuery(params.Page, params.Size, params.Query).Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// SpaceCreate creates a new space.
+func (c *HTTPClient) SpaceCreate(ctx context.Context, in *space.CreateInput) (*types.Space, error) {
+	out := new(types.Space)
+	uri := fmt.Sprintf("%s/api/v1/spaces", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// SpaceImport imports a space (and its repositories) from an external provider.
+func (c *HTTPClient) SpaceImport(ctx context.Context, in *space.ImportInput) (*types.Space, error) {
+	out := new(types.Space)
+	uri := fmt.Sprintf("%s/api/v1/spaces/import", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// SpaceDelete deletes a space by path or ID.
+func (c *HTTPClient) SpaceDelete(ctx context.Context, spaceRef string) error {
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s", c.base, url.PathEscape(spaceRef))
+	return c.delete(ctx, uri)
+}
+
+//
+// Repository Endpoints
+//
+
+// Repo returns a repository by path or ID.
+func (c *HTTPClient) Repo(ctx context.Context, repoRef string) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s", c.base, url.PathEscape(repoRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// RepoList returns the repositories of a space.
+func (c *HTTPClient) RepoList(ctx context.Context, spaceRef string,
+	params types.RepoFilter) ([]types.Repository, error) {
+	out := []types.Repository{}
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s/repos?%s", c.base, url.PathEscape(spaceRef),
+		listQuery(params.Page, params.Size, params.Query).Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// RepoCreate creates a new repository.
+func (c *HTTPClient) RepoCreate(ctx context.Context, in *repo.CreateInput) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/repos", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// RepoImport imports a repository from an external provider.
+func (c *HTTPClient) RepoImport(ctx context.Context, in *repo.ImportInput) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/repos/import", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// RepoImportProgress returns the progress of a repository import.
+func (c *HTTPClient) RepoImportProgress(ctx context.Context, repoRef string) (*types.JobProgress, error) {
+	out := new(types.JobProgress)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/import-progress", c.base, url.PathEscape(repoRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// RepoDelete deletes a repository by path or ID.
+func (c *HTTPClient) RepoDelete(ctx context.Context, repoRef string) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s", c.base, url.PathEscape(repoRef))
+	return c.delete(ctx, uri)
+}
+
+//
+// Webhook Endpoints
+//
+
+// WebhookList returns the webhooks of a repository.
+func (c *HTTPClient) WebhookList(ctx context.Context, repoRef string,
+	params types.WebhookFilter) ([]types.Webhook, error) {
+	out := []types.Webhook{}
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/webhooks?%s", c.base, url.PathEscape(repoRef),
+		listQuery(params.Page, params.Size, params.Query).Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// WebhookCreate creates a new webhook for a repository.
+func (c *HTTPClient) WebhookCreate(ctx context.Context, repoRef string,
+	in *webhook.CreateInput) (*types.Webhook, error) {
+	out := new(types.Webhook)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/webhooks", c.base, url.PathEscape(repoRef))
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// WebhookDelete deletes a webhook of a repository.
+func (c *HTTPClient) WebhookDelete(ctx context.Context, repoRef string, webhookID int64) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/webhooks/%d", c.base, url.PathEscape(repoRef), webhookID)
+	return c.delete(ctx, uri)
+}
+
+//
+// Pull Request Endpoints
+//
+
+// PullReq returns a pull request by number.
+func (c *HTTPClient) PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq/%d", c.base, url.PathEscape(repoRef), number)
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// PullReqList returns the pull requests of a repository.
+func (c *HTTPClient) PullReqList(ctx context.Context, repoRef string,
+	params types.PullReqFilter) ([]types.PullReq, error) {
+	query := listQuery(params.Page, params.Size, params.Query)
+	for _, state := range params.States {
+		query.Add("state", string(state))
+	}
+
+	out := []types.PullReq{}
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq?%s", c.base, url.PathEscape(repoRef), query.Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// PullReqCreate creates a new pull request.
+func (c *HTTPClient) PullReqCreate(ctx context.Context, repoRef string,
+	in *pullreq.CreateInput) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq", c.base, url.PathEscape(repoRef))
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// PullReqState updates the state of a pull request.
+func (c *HTTPClient) PullReqState(ctx context.Context, repoRef string, number int64,
+	in *pullreq.StateInput) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq/%d/state", c.base, url.PathEscape(repoRef), number)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// PullReqMerge merges a pull request.
+func (c *HTTPClient) PullReqMerge(ctx context.Context, repoRef string, number int64,
+	in *pullreq.MergeInput) (*types.MergeResponse, error) {
+	out := new(types.MergeResponse)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq/%d/merge", c.base, url.PathEscape(repoRef), number)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+//
+// Backup Endpoints
+//
+
+// BackupList returns the repository backups.
+func (c *HTTPClient) BackupList(ctx context.Context, params types.BackupFilter) ([]types.Backup, error) {
+	query := listQuery(params.Page, params.Size, "")
+	if params.RepoID != 0 {
+		query.Set("repo_id", strconv.FormatInt(params.RepoID, 10))
+	}
+	if params.State != "" {
+		query.Set("state", string(params.State))
+	}
+
+	out := []types.Backup{}
+	uri := fmt.Sprintf("%s/api/v1/admin/backups?%s", c.base, query.Encode())
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// BackupCreate starts an on-demand backup of a repository.
+func (c *HTTPClient) BackupCreate(ctx context.Context, in *backup.CreateInput) (*types.Backup, error) {
+	out := new(types.Backup)
+	uri := fmt.Sprintf("%s/api/v1/admin/backups", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// BackupRestore restores a repository backup to its original path or a new path.
+func (c *HTTPClient) BackupRestore(ctx context.Context, backupID int64,
+	in *backup.RestoreInput) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/admin/backups/%d/restore", c.base, backupID)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// BackupRestoreDryRun returns what would be overwritten by restoring a repository backup.
+func (c *HTTPClient) BackupRestoreDryRun(ctx context.Context, backupID int64,
+	in *backup.RestoreInput) (*types.BackupRestoreImpact, error) {
+	out := new(types.BackupRestoreImpact)
+	uri := fmt.Sprintf("%s/api/v1/admin/backups/%d/restore?dry_run=true", c.base, backupID)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
 //
 // http request helper functions
 //
 
+// helper function to build the query of list requests.
+func listQuery(page, size int, query string) url.Values {
+	values := url.Values{}
+	if page > 0 {
+		values.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		values.Set("limit", strconv.Itoa(size))
+	}
+	if query != "" {
+		values.Set("query", query)
+	}
+	return values
+}
+
 // helper function for making an http GET request.
 func (c *HTTPClient) get(ctx context.Context, rawurl string, out interface{}) error {
 	return c.do(ctx, rawurl, "GET", false, nil, out)
@@ -17,7 +17,12 @@ package client
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/types"
 )
 
@@ -49,6 +54,82 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// UserListPATs returns the PATs of the user.
+	UserListPATs(ctx context.Context) ([]types.Token, error)
+
+	// UserDeletePAT deletes a PAT of the user.
+	UserDeletePAT(ctx context.Context, tokenUID string) error
+
+	// Space returns a space by path or ID.
+	Space(ctx context.Context, spaceRef string) (*types.Space, error)
+
+	// SpaceList returns the child spaces of a space.
+	SpaceList(ctx context.Context, spaceRef string, params types.SpaceFilter) ([]types.Space, error)
+
+	// SpaceCreate creates a new space.
+	SpaceCreate(ctx context.Context, in *space.CreateInput) (*types.Space, error)
+
+	// SpaceImport imports a space (and its repositories) from an external provider.
+	SpaceImport(ctx context.Context, in *space.ImportInput) (*types.Space, error)
+
+	// SpaceDelete deletes a space by path or ID.
+	SpaceDelete(ctx context.Context, spaceRef string) error
+
+	// Repo returns a repository by path or ID.
+	Repo(ctx context.Context, repoRef string) (*types.Repository, error)
+
+	// RepoList returns the repositories of a space.
+	RepoList(ctx context.Context, spaceRef string, params types.RepoFilter) ([]types.Repository, error)
+
+	// RepoCreate creates a new repository.
+	RepoCreate(ctx context.Context, in *repo.CreateInput) (*types.Repository, error)
+
+	// RepoImport imports a repository from an external provider.
+	RepoImport(ctx context.Context, in *repo.ImportInput) (*types.Repository, error)
+
+	// RepoImportProgress returns the progress of a repository import.
+	RepoImportProgress(ctx context.Context, repoRef string) (*types.JobProgress, error)
+
+	// RepoDelete deletes a repository by path or ID.
+	RepoDelete(ctx context.Context, repoRef string) error
+
+	// WebhookList returns the webhooks of a repository.
+	WebhookList(ctx context.Context, repoRef string, params types.WebhookFilter) ([]types.Webhook, error)
+
+	// WebhookCreate creates a new webhook for a repository.
+	WebhookCreate(ctx context.Context, repoRef string, in *webhook.CreateInput) (*types.Webhook, error)
+
+	// WebhookDelete deletes a webhook of a repository.
+	WebhookDelete(ctx context.Context, repoRef string, webhookID int64) error
+
+	// PullReq returns a pull request by number.
+	PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error)
+
+	// PullReqList returns the pull requests of a repository.
+	PullReqList(ctx context.Context, repoRef string, params types.PullReqFilter) ([]types.PullReq, error)
+
+	// PullReqCreate creates a new pull request.
+	PullReqCreate(ctx context.Context, repoRef string, in *pullreq.CreateInput) (*types.PullReq, error)
+
+	// PullReqState updates the state of a pull request.
+	PullReqState(ctx context.Context, repoRef string, number int64, in *pullreq.StateInput) (*types.PullReq, error)
+
+	// PullReqMerge merges a pull request.
+	PullReqMerge(ctx context.Context, repoRef string, number int64, in *pullreq.MergeInput) (*types.MergeResponse, error)
+
+	// BackupList returns the repository backups.
+	BackupList(ctx context.Context, params types.BackupFilter) ([]types.Backup, error)
+
+	// BackupCreate starts an on-demand backup of a repository.
+	BackupCreate(ctx context.Context, in *backup.CreateInput) (*types.Backup, error)
+
+	// BackupRestore restores a repository backup to its original path or a new path.
+	BackupRestore(ctx context.Context, backupID int64, in *backup.RestoreInput) (*types.Repository, error)
+
+	// BackupRestoreDryRun returns what would be overwritten by restoring a repository backup.
+	BackupRestoreDryRun(ctx context.Context, backupID int64,
+		in *backup.RestoreInput) (*types.BackupRestoreImpact, error)
 }
 
 // remoteError store the error payload returned
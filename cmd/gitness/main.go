@@ -17,10 +17,15 @@ package main
 import (
 	"github.com/harness/gitness/cli"
 	"github.com/harness/gitness/cli/operations/account"
+	"github.com/harness/gitness/cli/operations/backups"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/pullreqs"
+	"github.com/harness/gitness/cli/operations/repos"
+	"github.com/harness/gitness/cli/operations/spaces"
 	"github.com/harness/gitness/cli/operations/user"
 	"github.com/harness/gitness/cli/operations/users"
+	"github.com/harness/gitness/cli/operations/webhooks"
 	"github.com/harness/gitness/cli/server"
 	"github.com/harness/gitness/version"
 
@@ -39,10 +44,17 @@ func main() {
 
 	migrate.Register(app)
 	server.Register(app, initSystem)
+	server.RegisterGitServer(app, initGitServer)
 
 	user.Register(app)
 	users.Register(app)
 
+	spaces.Register(app)
+	repos.Register(app)
+	webhooks.Register(app)
+	pullreqs.Register(app)
+	backups.Register(app)
+
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
 	account.RegisterLogout(app)
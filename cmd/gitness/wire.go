@@ -10,16 +10,28 @@ package main
 import (
 	"context"
 
+	controlleraccessrequest "github.com/harness/gitness/app/api/controller/accessrequest"
+	"github.com/harness/gitness/app/api/controller/attachment"
+	controllerattestation "github.com/harness/gitness/app/api/controller/attestation"
+	controlleravatar "github.com/harness/gitness/app/api/controller/avatar"
+	controllerbackup "github.com/harness/gitness/app/api/controller/backup"
+	controllerchatintegration "github.com/harness/gitness/app/api/controller/chatintegration"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	controllerfilelock "github.com/harness/gitness/app/api/controller/filelock"
 	"github.com/harness/gitness/app/api/controller/githook"
+	controllerinsights "github.com/harness/gitness/app/api/controller/insights"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controllermarkdown "github.com/harness/gitness/app/api/controller/markdown"
+	controlleroauth "github.com/harness/gitness/app/api/controller/oauth"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	controllersbom "github.com/harness/gitness/app/api/controller/sbom"
+	controllerscan "github.com/harness/gitness/app/api/controller/scan"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -28,12 +40,18 @@ import (
 	"github.com/harness/gitness/app/api/controller/template"
 	controllertrigger "github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	controllerwebhook "github.com/harness/gitness/app/api/controller/webhook"
+	apigrpc "github.com/harness/gitness/app/api/grpc"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	spaceevents "github.com/harness/gitness/app/events/space"
+	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/health"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -41,25 +59,59 @@ import (
 	pluginmanager "github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	pipelinesecret "github.com/harness/gitness/app/pipeline/secret"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/attestation"
+	"github.com/harness/gitness/app/services/authorship"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/backup"
+	"github.com/harness/gitness/app/services/chatintegration"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/deadletter"
+	"github.com/harness/gitness/app/services/drain"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/feed"
+	"github.com/harness/gitness/app/services/filerender"
+	"github.com/harness/gitness/app/services/githookplugin"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/gitshard"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keyrotation"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/membershipexpiry"
+	"github.com/harness/gitness/app/services/mergecheck"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/outbox"
+	"github.com/harness/gitness/app/services/pathprotection"
+	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/refwatch"
+	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/sbom"
+	"github.com/harness/gitness/app/services/scanning"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/server"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
@@ -89,12 +141,14 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		pullreqservice.WireSet,
 		services.WireSet,
 		server.WireSet,
+		apigrpc.WireSet,
 		url.WireSet,
 		space.WireSet,
 		repo.WireSet,
 		pullreq.WireSet,
 		controllerwebhook.WireSet,
 		serviceaccount.WireSet,
+		usergroup.WireSet,
 		user.WireSet,
 		service.WireSet,
 		principal.WireSet,
@@ -102,11 +156,15 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		authn.WireSet,
 		authz.WireSet,
 		gitevents.WireSet,
+		pipelineevents.WireSet,
 		pullreqevents.WireSet,
+		spaceevents.WireSet,
+		userevents.WireSet,
 		cliserver.ProvideGitRPCServerConfig,
 		gitrpcserver.WireSet,
 		cliserver.ProvideGitRPCClientConfig,
 		gitrpc.WireSet,
+		gitshard.WireSet,
 		store.WireSet,
 		check.WireSet,
 		encrypt.WireSet,
@@ -116,19 +174,39 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		webhook.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
+		cliserver.ProvideRepoSizeConfig,
+		reposize.WireSet,
+		quota.WireSet,
+		pathprotection.WireSet,
+		publickey.WireSet,
+		authorship.WireSet,
+		settings.WireSet,
+		instancesettings.WireSet,
 		githook.WireSet,
 		cliserver.ProvideLockConfig,
 		lock.WireSet,
 		cliserver.ProvidePubsubConfig,
 		pubsub.WireSet,
 		cliserver.ProvideCleanupConfig,
+		cliserver.ProvideNotificationConfig,
+		cliserver.ProvideChatIntegrationConfig,
+		cliserver.ProvideFeedConfig,
+		cliserver.ProvideRefWatchConfig,
 		cleanup.WireSet,
 		codecomments.WireSet,
 		job.WireSet,
+		keyrotation.WireSet,
+		outbox.WireSet,
+		deadletter.WireSet,
+		drain.WireSet,
+		health.WireSet,
 		gitrpccron.WireSet,
+		wire.Bind(new(gitrpccron.Leader), new(*lock.Elector)),
 		checkcontroller.WireSet,
 		execution.WireSet,
 		pipeline.WireSet,
+		cliserver.ProvideBlobStoreConfig,
+		blob.WireSet,
 		logs.WireSet,
 		livelog.WireSet,
 		controllerlogs.WireSet,
@@ -141,6 +219,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		runner.WireSet,
 		sse.WireSet,
 		scheduler.WireSet,
+		pipelinesecret.WireSet,
 		commit.WireSet,
 		controllertrigger.WireSet,
 		plugin.WireSet,
@@ -148,7 +227,54 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		importer.WireSet,
 		canceler.WireSet,
 		exporter.WireSet,
+		gitmetrics.WireSet,
+		githookplugin.WireSet,
+		mergecheck.WireSet,
+		codeowners.WireSet,
 		metric.WireSet,
+		notification.WireSet,
+		controllerchatintegration.WireSet,
+		controlleraccessrequest.WireSet,
+		chatintegration.WireSet,
+		feed.WireSet,
+		refwatch.WireSet,
+		reviewreminder.WireSet,
+		membershipexpiry.WireSet,
+		avatar.WireSet,
+		backup.WireSet,
+		cliserver.ProvideBackupConfig,
+		controllerinsights.WireSet,
+		insights.WireSet,
+		controllerscan.WireSet,
+		cliserver.ProvideScanningConfig,
+		scanning.WireSet,
+		controllersbom.WireSet,
+		cliserver.ProvideSBOMConfig,
+		cliserver.ProvideUploadScanConfig,
+		uploadscan.WireSet,
+		attachment.WireSet,
+		sbom.WireSet,
+		controllerattestation.WireSet,
+		controlleravatar.WireSet,
+		controllerbackup.WireSet,
+		controllerfilelock.WireSet,
+		controlleroauth.WireSet,
+		markdown.WireSet,
+		filerender.WireSet,
+		worddiff.WireSet,
+		controllermarkdown.WireSet,
+		attestation.WireSet,
 	)
 	return &cliserver.System{}, nil
 }
+
+func initGitServer(ctx context.Context, config *types.Config) (*cliserver.GitServer, error) {
+	wire.Build(
+		cliserver.NewGitServer,
+		cliserver.ProvideRedis,
+		cliserver.ProvideGitRPCServerConfig,
+		gitrpcserver.WireSet,
+		gitrpccron.StandaloneWireSet,
+	)
+	return &cliserver.GitServer{}, nil
+}
@@ -8,16 +8,28 @@ package main
 
 import (
 	"context"
+	"github.com/harness/gitness/app/api/controller/accessrequest"
+	"github.com/harness/gitness/app/api/controller/attachment"
+	attestation2 "github.com/harness/gitness/app/api/controller/attestation"
+	avatar2 "github.com/harness/gitness/app/api/controller/avatar"
+	backup2 "github.com/harness/gitness/app/api/controller/backup"
+	"github.com/harness/gitness/app/api/controller/chatintegration"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/filelock"
 	"github.com/harness/gitness/app/api/controller/githook"
+	insights2 "github.com/harness/gitness/app/api/controller/insights"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	markdown2 "github.com/harness/gitness/app/api/controller/markdown"
+	"github.com/harness/gitness/app/api/controller/oauth"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	sbom2 "github.com/harness/gitness/app/api/controller/sbom"
+	"github.com/harness/gitness/app/api/controller/scan"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -26,12 +38,18 @@ import (
 	"github.com/harness/gitness/app/api/controller/template"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/grpc"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
-	events3 "github.com/harness/gitness/app/events/git"
-	events2 "github.com/harness/gitness/app/events/pullreq"
+	events2 "github.com/harness/gitness/app/events/git"
+	events6 "github.com/harness/gitness/app/events/pipeline"
+	events3 "github.com/harness/gitness/app/events/pullreq"
+	events4 "github.com/harness/gitness/app/events/space"
+	events5 "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/health"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -39,25 +57,59 @@ import (
 	plugin2 "github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	secret2 "github.com/harness/gitness/app/pipeline/secret"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/attestation"
+	"github.com/harness/gitness/app/services/authorship"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/backup"
+	chatintegration2 "github.com/harness/gitness/app/services/chatintegration"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/deadletter"
+	"github.com/harness/gitness/app/services/drain"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/feed"
+	"github.com/harness/gitness/app/services/filerender"
+	"github.com/harness/gitness/app/services/githookplugin"
+	"github.com/harness/gitness/app/services/gitmetrics"
+	"github.com/harness/gitness/app/services/gitshard"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/insights"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keyrotation"
+	"github.com/harness/gitness/app/services/markdown"
+	"github.com/harness/gitness/app/services/membershipexpiry"
+	"github.com/harness/gitness/app/services/mergecheck"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/outbox"
+	"github.com/harness/gitness/app/services/pathprotection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/quota"
+	"github.com/harness/gitness/app/services/refwatch"
+	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/reviewreminder"
+	"github.com/harness/gitness/app/services/sbom"
+	"github.com/harness/gitness/app/services/scanning"
+	"github.com/harness/gitness/app/services/settings"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/uploadscan"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/services/worddiff"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/cli/server"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
@@ -86,31 +138,73 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	spacePathTransformation := store.ProvidePathTransformation()
 	spacePathStore := database.ProvideSpacePathStore(db, spacePathTransformation)
 	spacePathCache := cache.ProvidePathCache(spacePathStore, spacePathTransformation)
-	spaceStore := database.ProvideSpaceStore(db, spacePathCache, spacePathStore)
+	pubsubConfig := server.ProvidePubsubConfig(config)
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
+	spaceEvictor := cache.ProvideSpaceEvictor(pubSub)
+	spaceStore := database.ProvideSpaceStore(db, spacePathCache, spacePathStore, spaceEvictor)
+	spaceCache := cache.ProvideSpaceCache(ctx, config, spaceStore, pubSub, universalClient)
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, spaceCache, membershipStore)
+	repoEvictor := cache.ProvideRepoEvictor(pubSub)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, repoEvictor)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceCache, spacePathCache, membershipStore, repoStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
-	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
+	principalEvictor := cache.ProvidePrincipalEvictor(pubSub)
+	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation, principalEvictor)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	announcementStore := database.ProvideAnnouncementStore(db)
+	eventsConfig := server.ProvideEventsConfig(config)
+	eventOutboxStore := database.ProvideEventOutboxStore(db)
+	outboxOutbox := outbox.ProvideOutbox(eventOutboxStore)
+	eventsOutbox := outbox.ProvideEventsOutbox(config, outboxOutbox)
+	eventDeadLetterStore := database.ProvideEventDeadLetterStore(db)
+	queue := deadletter.ProvideQueue(eventDeadLetterStore)
+	deadLetterQueue := deadletter.ProvideEventsDeadLetterQueue(config, queue)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient, eventsOutbox, deadLetterQueue)
+	if err != nil {
+		return nil, err
+	}
+	drainService := drain.ProvideService(config, eventsSystem)
+	reporter3, err := events5.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	userEmailStore := database.ProvideUserEmailStore(db)
+	notificationStore := database.ProvideNotificationStore(db)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	commitVerificationStore := database.ProvideCommitVerificationStore(db)
+	publickeyService := publickey.ProvideService(publicKeyStore, commitVerificationStore, principalStore, principalInfoCache)
+	repoStarStore := database.ProvideRepoStarStore(db)
+	feedEventStore := database.ProvideFeedEventStore(db)
+	refWatchStore := database.ProvideRefWatchStore(db)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	contributionStore := database.ProvideContributionStore(db)
+	savedReplyStore := database.ProvideSavedReplyStore(db)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, announcementStore, reporter3, userEmailStore, notificationStore, publicKeyStore, publickeyService, repoStore, repoStarStore, feedEventStore, principalInfoCache, pullReqStore, contributionStore, savedReplyStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
+	principalCache := cache.ProvidePrincipalCache(ctx, config, principalStore, principalInfoCache, pubSub, universalClient)
+	oAuthAuthorizationStore := database.ProvideOAuthAuthorizationStore(db)
+	authenticator := authn.ProvideAuthenticator(config, principalCache, tokenStore, oAuthAuthorizationStore)
 	provider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
 	pathUID := check.ProvidePathUIDCheck()
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	gitrpcConfig, err := server.ProvideGitRPCClientConfig()
 	if err != nil {
 		return nil, err
 	}
-	gitrpcInterface, err := gitrpc.ProvideClient(gitrpcConfig)
+	repositoryShardStore := database.ProvideRepositoryShardStore(db)
+	shardRouter := gitshard.ProvideRouter(repositoryShardStore, gitrpcConfig)
+	gitrpcInterface, err := gitrpc.ProvideClient(gitrpcConfig, shardRouter)
 	if err != nil {
 		return nil, err
 	}
@@ -120,25 +214,74 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	jobStore := database.ProvideJobStore(db)
-	pubsubConfig := server.ProvidePubsubConfig(config)
-	universalClient, err := server.ProvideRedis(config)
-	if err != nil {
-		return nil, err
-	}
-	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
 	executor := job.ProvideExecutor(jobStore, pubSub)
 	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient, db)
 	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, config)
 	if err != nil {
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitrpcInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer)
+	instanceSettingStore := database.ProvideInstanceSettingStore(db)
+	instanceSettingEvictor := cache.ProvideInstanceSettingEvictor(pubSub)
+	instanceSettingCache := cache.ProvideInstanceSettingCache(ctx, instanceSettingStore, pubSub)
+	instancesettingsService, err := instancesettings.ProvideService(config, transactor, instanceSettingStore, instanceSettingCache, instanceSettingEvictor)
+	if err != nil {
+		return nil, err
+	}
+	clientFactory, err := instancesettings.ProvideOutboundClientFactory(config, instancesettingsService)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := importer.ProvideRepoImporter(config, provider, gitrpcInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, instancesettingsService, clientFactory)
+	if err != nil {
+		return nil, err
+	}
+	spaceQuotaStore := database.ProvideSpaceQuotaStore(db)
+	enforcer := quota.ProvideEnforcer(spaceQuotaStore, spaceStore)
+	settingStore := database.ProvideSettingStore(db)
+	webhookConfig := server.ProvideWebhookConfig(config)
+	webhookStore := database.ProvideWebhookStore(db)
+	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
+	readerFactory, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitrpcInterface, encrypter, clientFactory)
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, gitrpcInterface, repository)
+	webhookController := webhook2.ProvideController(authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter, instancesettingsService)
+	settingsService := settings.ProvideService(config, transactor, settingStore, spaceStore, webhookController)
+	commitAuthorRewriteStore := database.ProvideCommitAuthorRewriteStore(db)
+	repoTopicStore := database.ProvideRepoTopicStore(db)
+	authorshipService := authorship.ProvideService(settingsService, userEmailStore, commitAuthorRewriteStore)
+	branchRenameStore := database.ProvideBranchRenameStore(db)
+	fileLockStore := database.ProvideFileLockStore(db)
+	pushedBranchCache := cache.ProvidePushedBranchCache(config, universalClient)
+	uploadscanConfig := server.ProvideUploadScanConfig(config)
+	uploadQuarantineStore := database.ProvideUploadQuarantineStore(db)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	uploadscanService, err := uploadscan.ProvideService(uploadscanConfig, uploadQuarantineStore, blobStore)
+	if err != nil {
+		return nil, err
+	}
+	renderer := markdown.ProvideRenderer()
+	filerenderService := filerender.ProvideService(config, gitrpcInterface, renderer)
+	worddiffService := worddiff.ProvideService(gitrpcInterface)
+	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, pullReqStore, branchRenameStore, gitrpcInterface, repository, enforcer, settingsService, publickeyService, authorshipService, repoTopicStore, repoStarStore, pushedBranchCache, refWatchStore, uploadscanService, filerenderService, worddiffService, instancesettingsService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
@@ -152,7 +295,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	fileService := file.ProvideService(gitrpcInterface)
 	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, schedulerScheduler, repoStore)
 	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
-	logStore := logs.ProvideLogStore(db, config)
+	logStore := logs.ProvideLogStore(db, config, blobStore)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	secretStore := database.ProvideSecretStore(db)
@@ -162,7 +305,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository)
+	spaceTreeCache := cache.ProvideSpaceTreeCache(spaceStore)
+	reporter, err := events4.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, spaceTreeCache, spacePathCache, spaceEvictor, repoEvictor, reporter, spaceQuotaStore, enforcer, settingsService, instancesettingsService)
 	pipelineController := pipeline.ProvideController(pathUID, repoStore, triggerStore, authorizer, pipelineStore)
 	secretController := secret.ProvideController(pathUID, encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pathUID, pipelineStore, repoStore)
@@ -170,60 +318,108 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	templateController := template.ProvideController(pathUID, templateStore, authorizer, spaceStore)
 	pluginStore := database.ProvidePluginStore(db)
 	pluginController := plugin.ProvideController(pluginStore)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
-	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
+	pullReqSubscriberStore := database.ProvidePullReqSubscriberStore(db, principalInfoCache)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db, principalInfoCache)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	pullReqPushStore := database.ProvidePullReqPushStore(db)
+	reqCheckStore := database.ProvideReqCheckStore(db, principalInfoCache)
+	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	reporter, err := events2.ProvideReporter(eventsSystem)
+	migrator := codecomments.ProvideMigrator(gitrpcInterface)
+	repoGitInfoView := database.ProvideRepoGitInfoView(db)
+	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitrpcInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pullReqPushStore, pubSub, provider, streamer, settingsService)
 	if err != nil {
 		return nil, err
 	}
-	migrator := codecomments.ProvideMigrator(gitrpcInterface)
-	readerFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	mergecheckService := mergecheck.ProvideService(config, settingsService, clientFactory)
+	codeownersService := codeowners.ProvideService(gitrpcInterface, principalStore, userGroupMemberStore)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, pullReqSubscriberStore, userGroupMemberStore, repoStore, principalStore, pullReqFileViewStore, pullReqPushStore, checkStore, reqCheckStore, gitrpcInterface, eventsReporter, mutexManager, migrator, pullreqService, streamer, settingsService, mergecheckService, codeownersService, authorshipService, worddiffService, instancesettingsService)
+	repoCache := cache.ProvideRepoCache(ctx, config, repoStore, pubSub, universalClient)
+	reporter2, err := events2.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events2.ProvideReaderFactory(eventsSystem)
+	githookpluginManager, err := githookplugin.ProvideManager(config, settingsService, clientFactory)
 	if err != nil {
 		return nil, err
 	}
-	repoGitInfoView := database.ProvideRepoGitInfoView(db)
-	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter, gitrpcInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pathprotectionEnforcer := pathprotection.ProvideEnforcer(settingsService, userGroupMemberStore)
+	githookController := githook.ProvideController(authorizer, principalStore, repoCache, reporter2, pullReqStore, branchRenameStore, fileLockStore, provider, enforcer, pathprotectionEnforcer, githookpluginManager, pushedBranchCache, streamer, instancesettingsService)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	principalController := principal.ProvideController(principalStore)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, reqCheckStore, pullReqStore, gitrpcInterface)
+	keyrotationService, err := keyrotation.ProvideService(encrypter, jobScheduler, executor, secretStore, webhookStore)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, gitrpcInterface, reporter, mutexManager, migrator, pullreqService, streamer)
-	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
-	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, provider, principalStore, gitrpcInterface, encrypter)
+	redeliverer := deadletter.ProvideRedeliverer(eventDeadLetterStore, eventsSystem)
+	tracker := gitmetrics.ProvideTracker(config)
+	systemController := system.NewController(principalStore, config, keyrotationService, jobScheduler, jobStore, eventDeadLetterStore, redeliverer, transactor, announcementStore, tracker, db, uploadQuarantineStore, uploadscanService, instancesettingsService, drainService)
+	chatIntegrationStore := database.ProvideChatIntegrationStore(db)
+	chatintegrationController := chatintegration.ProvideController(authorizer, chatIntegrationStore, repoStore, spaceStore, encrypter)
+	accessRequestStore := database.ProvideAccessRequestStore(db)
+	accessrequestController := accessrequest.ProvideController(transactor, authorizer, accessRequestStore, membershipStore, repoStore, spaceStore, principalInfoCache, reporter)
+	insightsStore := database.ProvideInsightsStore(db)
+	insightsController := insights2.ProvideController(authorizer, insightsStore, repoStore, spaceStore, pullReqStore, userGroupMemberStore, principalInfoCache, settingsService, gitrpcInterface)
+	scanFindingStore := database.ProvideScanFindingStore(db)
+	scanSuppressionStore := database.ProvideScanSuppressionStore(db)
+	scanController := scan.ProvideController(authorizer, repoStore, scanFindingStore, scanSuppressionStore, gitrpcInterface)
+	sbomStore := database.ProvideSBOMStore(db)
+	sbomController := sbom2.ProvideController(authorizer, repoStore, sbomStore, blobStore)
+	attachmentStore := database.ProvideAttachmentStore(db)
+	attachmentController := attachment.ProvideController(authorizer, provider, repoStore, attachmentStore, blobStore, settingsService, uploadscanService)
+	avatarStore := database.ProvideAvatarStore(db)
+	avatarService, err := avatar.ProvideService(config, avatarStore, blobStore)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	avatarController := avatar2.ProvideController(authorizer, principalStore, spaceStore, avatarService)
+	attestationStore := database.ProvideAttestationStore(db)
+	attestationService := attestation.ProvideService(settingsService, attestationStore, checkStore)
+	attestationController := attestation2.ProvideController(transactor, authorizer, repoStore, pipelineStore, executionStore, attestationStore, attestationService, gitrpcInterface)
+	usergroupController := usergroup.ProvideController(transactor, principalUID, authorizer, principalStore, spaceStore, userGroupMemberStore)
+	filelockController := filelock.ProvideController(authorizer, repoStore, fileLockStore, principalInfoCache)
+	oAuthAppStore := database.ProvideOAuthAppStore(db)
+	oAuthCodeStore := database.ProvideOAuthCodeStore(db)
+	oauthController := oauth.ProvideController(config, transactor, encrypter, provider, authorizer, principalStore, spaceStore, oAuthAppStore, oAuthAuthorizationStore, oAuthCodeStore)
+	markdownController := markdown2.ProvideController(authorizer, provider, repoStore, renderer)
+	backupConfig := server.ProvideBackupConfig(config)
+	backupStore := database.ProvideBackupStore(db)
+	backupService, err := backup.ProvideService(backupConfig, transactor, repoStore, pullReqStore, backupStore, blobStore, gitrpcInterface, provider, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, eventsReporter, pullReqStore, provider)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore)
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitrpcInterface)
-	systemController := system.NewController(principalStore, config)
-	apiHandler := router.ProvideAPIHandler(config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController)
-	gitHandler := router.ProvideGitHandler(config, provider, repoStore, authenticator, authorizer, gitrpcInterface, repoController)
+	backupController := backup2.ProvideController(backupStore, backupService, repoStore, spaceStore, pathUID)
+	apiHandler := router.ProvideAPIHandler(config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, chatintegrationController, accessrequestController, insightsController, scanController, sbomController, attachmentController, avatarController, attestationController, filelockController, githookController, serviceaccountController, usergroupController, controller, principalController, checkController, systemController, backupController, oauthController, markdownController, instancesettingsService, drainService)
+	gitHandler := router.ProvideGitHandler(config, provider, repoStore, authenticator, authorizer, gitrpcInterface, repoController, filelockController, tracker, instancesettingsService, drainService)
 	webHandler := router.ProvideWebHandler(config)
-	routerRouter := router.ProvideRouter(config, apiHandler, gitHandler, webHandler, provider)
+	metricsHandler := router.ProvideMetricsHandler(config, authenticator)
+	serverConfig, err := server.ProvideGitRPCServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	healthService, err := health.ProvideService(config, db, universalClient, serverConfig)
+	if err != nil {
+		return nil, err
+	}
+	healthHandler := router.ProvideHealthHandler(healthService)
+	routerRouter := router.ProvideRouter(config, apiHandler, gitHandler, webHandler, metricsHandler, healthHandler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
+	resolver, err := secret2.ProvideResolver(config, encrypter, clientFactory)
+	if err != nil {
+		return nil, err
+	}
+	reporter4, err := events6.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, resolver, stageStore, stepStore, principalStore, reporter4, drainService)
 	client := manager.ProvideExecutionClient(executionManager, config)
 	pluginManager := plugin2.ProvidePluginManager(config, pluginStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, pluginManager, executionManager)
@@ -231,10 +427,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	poller := runner.ProvideExecutionPoller(runtimeRunner, config, client)
-	serverConfig, err := server.ProvideGitRPCServerConfig()
-	if err != nil {
-		return nil, err
-	}
 	goGitRepoProvider := server3.ProvideGoGitRepoProvider()
 	cacheCache := server3.ProvideLastCommitCache(serverConfig, universalClient, goGitRepoProvider)
 	gitAdapter, err := server3.ProvideGITAdapter(goGitRepoProvider, cacheCache)
@@ -245,7 +437,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	cronManager := cron.ProvideManager(serverConfig)
+	elector := lock.ProvideElector(lockConfig, mutexManager)
+	cronManager := cron.ProvideManager(serverConfig, elector, gitAdapter)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
 	if err != nil {
@@ -256,11 +449,86 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookStore, webhookExecutionStore, tokenStore, repoController, repoStore, spaceStore, stepStore, logStore, eventDeadLetterStore, feedEventStore, settingsService)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher := outbox.ProvideDispatcher(config, outboxOutbox, eventOutboxStore, eventsSystem, mutexManager)
+	reposizeConfig := server.ProvideRepoSizeConfig(config)
+	reposizeService, err := reposize.ProvideService(ctx, reposizeConfig, repoStore, gitrpcInterface, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	notificationConfig := server.ProvideNotificationConfig(config)
+	readerFactory2, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory3, err := events4.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	notificationService, err := notification.ProvideService(ctx, notificationConfig, notificationStore, principalStore, repoStore, pullReqStore, pullReqActivityStore, pullReqReviewerStore, pullReqSubscriberStore, userGroupMemberStore, pipelineStore, spaceStore, membershipStore, accessRequestStore, provider, jobScheduler, executor, eventsReaderFactory, readerFactory2, readerFactory3)
+	if err != nil {
+		return nil, err
+	}
+	chatintegrationConfig := server.ProvideChatIntegrationConfig(config)
+	chatintegrationService, err := chatintegration2.ProvideService(ctx, chatintegrationConfig, chatIntegrationStore, repoStore, pullReqStore, principalStore, pipelineStore, provider, encrypter, readerFactory, eventsReaderFactory, readerFactory2, clientFactory)
+	if err != nil {
+		return nil, err
+	}
+	insightsService := insights.ProvideService(transactor, repoStore, insightsStore, gitrpcInterface, jobScheduler, executor)
+	scanningConfig, err := server.ProvideScanningConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	scanningService, err := scanning.ProvideService(ctx, scanningConfig, transactor, repoStore, checkStore, scanFindingStore, gitrpcInterface, readerFactory, eventsReaderFactory)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, cleanupService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, grpcServer, pluginManager, cronManager, servicesServices)
+	sbomConfig := server.ProvideSBOMConfig(config)
+	sbomService, err := sbom.ProvideService(ctx, sbomConfig, repoStore, sbomStore, blobStore, gitrpcInterface, jobScheduler, executor, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	feedConfig := server.ProvideFeedConfig(config)
+	feedService, err := feed.ProvideService(ctx, feedConfig, feedEventStore, readerFactory, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	refwatchConfig := server.ProvideRefWatchConfig(config)
+	refwatchService, err := refwatch.ProvideService(ctx, refwatchConfig, refWatchStore, repoStore, streamer, notificationService, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	reviewreminderService := reviewreminder.ProvideService(pullReqReviewerStore, pullReqStore, repoStore, settingsService, eventsReporter, jobScheduler, executor)
+	membershipexpiryService := membershipexpiry.ProvideService(config, membershipStore, spaceStore, notificationService, jobScheduler, executor)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, cleanupService, dispatcher, elector, reposizeService, notificationService, chatintegrationService, insightsService, scanningService, sbomService, feedService, refwatchService, reviewreminderService, membershipexpiryService, backupService, drainService)
+	grpcServer2 := grpc.ProvideServer(config, authenticator, repoController, pullreqController, checkController)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, grpcServer2, poller, grpcServer, pluginManager, cronManager, servicesServices)
 	return serverSystem, nil
 }
+
+func initGitServer(ctx context.Context, config *types.Config) (*server.GitServer, error) {
+	serverConfig, err := server.ProvideGitRPCServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	goGitRepoProvider := server3.ProvideGoGitRepoProvider()
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	cacheCache := server3.ProvideLastCommitCache(serverConfig, universalClient, goGitRepoProvider)
+	gitAdapter, err := server3.ProvideGITAdapter(goGitRepoProvider, cacheCache)
+	if err != nil {
+		return nil, err
+	}
+	grpcServer, err := server3.ProvideServer(serverConfig, gitAdapter)
+	if err != nil {
+		return nil, err
+	}
+	cronManager := cron.ProvideStandaloneManager(serverConfig, gitAdapter)
+	gitServer := server.NewGitServer(grpcServer, cronManager)
+	return gitServer, nil
+}
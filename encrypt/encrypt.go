@@ -28,3 +28,9 @@ type Encrypter interface {
 	Encrypt(plaintext string) ([]byte, error)
 	Decrypt(ciphertext []byte) (string, error)
 }
+
+// KeyRotator is implemented by encrypters that support rotation of the master key.
+type KeyRotator interface {
+	// NeedsRotation returns true if the ciphertext isn't encrypted with the current master key.
+	NeedsRotation(ciphertext []byte) bool
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// envelopePrefix marks values encrypted using envelope encryption.
+// The format of an envelope is: $gitness$<key version>$<wrapped data key>$<ciphertext>.
+const (
+	envelopePrefix    = "$gitness$"
+	envelopeSeparator = "$"
+	dataKeySize       = 32
+)
+
+var errMalformedEnvelope = errors.New("malformed envelope")
+
+// Envelope provides an encrypter that uses envelope encryption.
+// Every value is encrypted with a random data key, which is then encrypted (wrapped)
+// with a versioned master key. The version of the master key is stored with the value,
+// which allows to rotate the master key without having to decrypt all values at once.
+type Envelope struct {
+	version string
+	keys    map[string]cipher.AEAD
+
+	// legacy is used to decrypt values that were stored before envelope encryption was enabled.
+	legacy Encrypter
+}
+
+// NewEnvelope provides a new envelope encrypter. keys contains all known master keys
+// indexed by version, and version defines the master key used to encrypt new values.
+func NewEnvelope(version string, keys map[string]string, legacy Encrypter) (*Envelope, error) {
+	if _, ok := keys[version]; !ok {
+		return nil, fmt.Errorf("master key version %q not found", version)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for v, key := range keys {
+		if v == "" || strings.Contains(v, envelopeSeparator) {
+			return nil, fmt.Errorf("invalid master key version %q", v)
+		}
+
+		aead, err := newAEAD([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid master key %q: %w", v, err)
+		}
+
+		aeads[v] = aead
+	}
+
+	if legacy == nil {
+		legacy = &none{}
+	}
+
+	return &Envelope{
+		version: version,
+		keys:    aeads,
+		legacy:  legacy,
+	}, nil
+}
+
+// Encrypt encrypts the plaintext with a new data key wrapped with the current master key.
+func (e *Envelope) Encrypt(plaintext string) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := seal(e.keys[e.version], dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := seal(aead, []byte(plaintext))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(envelopePrefix)
+	buf.WriteString(e.version)
+	buf.WriteString(envelopeSeparator)
+	buf.WriteString(base64.RawStdEncoding.EncodeToString(wrappedKey))
+	buf.WriteString(envelopeSeparator)
+	buf.WriteString(base64.RawStdEncoding.EncodeToString(ciphertext))
+
+	return buf.Bytes(), nil
+}
+
+// Decrypt decrypts the ciphertext. Values that aren't encrypted using envelope encryption
+// are decrypted using the legacy encrypter.
+func (e *Envelope) Decrypt(ciphertext []byte) (string, error) {
+	version, wrappedKey, data, ok, err := parseEnvelope(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return e.legacy.Decrypt(ciphertext)
+	}
+
+	masterKey, ok := e.keys[version]
+	if !ok {
+		return "", fmt.Errorf("master key version %q not found", version)
+	}
+
+	dataKey, err := open(masterKey, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(aead, data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// NeedsRotation returns true if the ciphertext isn't encrypted with the current master key.
+func (e *Envelope) NeedsRotation(ciphertext []byte) bool {
+	version, _, _, ok, err := parseEnvelope(ciphertext)
+	return err != nil || !ok || version != e.version
+}
+
+// parseEnvelope splits the envelope into its parts.
+// It returns false if the ciphertext isn't an envelope.
+func parseEnvelope(ciphertext []byte) (string, []byte, []byte, bool, error) {
+	if !bytes.HasPrefix(ciphertext, []byte(envelopePrefix)) {
+		return "", nil, nil, false, nil
+	}
+
+	parts := strings.Split(string(ciphertext[len(envelopePrefix):]), envelopeSeparator)
+	if len(parts) != 3 {
+		return "", nil, nil, false, errMalformedEnvelope
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, false, errMalformedEnvelope
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, false, errMalformedEnvelope
+	}
+
+	return parts[0], wrappedKey, data, true, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, errKeySize
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"testing"
+)
+
+const (
+	testKeyV1 = "00000000000000000000000000000001"
+	testKeyV2 = "00000000000000000000000000000002"
+)
+
+func TestEnvelopeRotation(t *testing.T) {
+	legacy, err := New(testKeyV1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v1, err := NewEnvelope("v1", map[string]string{"v1": testKeyV1}, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v2, err := NewEnvelope("v2", map[string]string{"v1": testKeyV1, "v2": testKeyV2}, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyCiphertext, err := legacy.Encrypt("legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v1Ciphertext, err := v1.Encrypt("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v2Ciphertext, err := v2.Encrypt("second")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		ciphertext    []byte
+		want          string
+		needsRotation bool
+	}{
+		{name: "legacy", ciphertext: legacyCiphertext, want: "legacy", needsRotation: true},
+		{name: "previous key", ciphertext: v1Ciphertext, want: "first", needsRotation: true},
+		{name: "current key", ciphertext: v2Ciphertext, want: "second", needsRotation: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := v2.Decrypt(test.ciphertext)
+			if err != nil {
+				t.Fatalf("failed to decrypt: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("want %q, got %q", test.want, got)
+			}
+			if v2.NeedsRotation(test.ciphertext) != test.needsRotation {
+				t.Errorf("want needs rotation %t", test.needsRotation)
+			}
+		})
+	}
+
+	if _, err = v1.Decrypt(v2Ciphertext); err == nil {
+		t.Error("expected error decrypting value with unknown master key version")
+	}
+}
@@ -26,8 +26,19 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideEncrypter(config *types.Config) (Encrypter, error) {
-	if config.Encrypter.Secret == "" {
-		return &none{}, nil
+	var legacy Encrypter = &none{}
+	if config.Encrypter.Secret != "" {
+		var err error
+		legacy, err = New(config.Encrypter.Secret, config.Encrypter.MixedContent)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return New(config.Encrypter.Secret, config.Encrypter.MixedContent)
+
+	if len(config.Encrypter.Keys) == 0 {
+		return legacy, nil
+	}
+
+	// values encrypted before envelope encryption was enabled are still decrypted with the legacy encrypter.
+	return NewEnvelope(config.Encrypter.KeyVersion, config.Encrypter.Keys, legacy)
 }
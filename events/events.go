@@ -18,19 +18,46 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"go.opentelemetry.io/otel"
 )
 
 const (
 	// streamPayloadKey is the key used for storing the event in a stream message.
 	streamPayloadKey = "event"
+
+	// streamTraceKey is the key used for storing the trace context in a stream message.
+	streamTraceKey = "trace"
+
+	// streamTargetGroupKey is the key used for restricting a stream message to a single consumer group.
+	streamTargetGroupKey = "target_group"
 )
 
+// tracer is used to trace the sending and the processing of events.
+var tracer = otel.Tracer("github.com/harness/gitness/events")
+
 type Event[T interface{}] struct {
-	ID        string    `json:"id"`
+	ID string `json:"id"`
+	// Key is the idempotency key of the event.
+	// It's generated when the event is reported and, unlike the ID, it stays the same if the message gets
+	// redelivered (e.g. by the outbox or from the dead letter queue), which allows consumers to deduplicate events.
+	Key       string    `json:"key"`
 	Timestamp time.Time `json:"timestamp"`
 	Payload   T         `json:"payload"`
 }
 
+// WithTargetGroup returns a copy of the stream payload that is only processed by readers of the provided group.
+// It is used to redeliver a message to the group that failed to process it, without affecting other groups.
+func WithTargetGroup(streamPayload map[string]interface{}, groupName string) map[string]interface{} {
+	res := make(map[string]interface{}, len(streamPayload)+1)
+	for k, v := range streamPayload {
+		res[k] = v
+	}
+	res[streamTargetGroupKey] = groupName
+
+	return res
+}
+
 // EventType describes the type of event.
 type EventType string
 
@@ -45,6 +72,7 @@ type Mode string
 const (
 	ModeRedis    Mode = "redis"
 	ModeInMemory Mode = "inmemory"
+	ModeNats     Mode = "nats"
 )
 
 // Config defines the config of the events system.
@@ -53,15 +81,20 @@ type Config struct {
 	Namespace             string
 	MaxStreamLength       int64
 	ApproxMaxStreamLength bool
+	// NatsURL is the url of the NATS server(s) used in nats mode (requires JetStream).
+	NatsURL string
 }
 
 func (c *Config) Validate() error {
 	if c == nil {
 		return errors.New("config is required")
 	}
-	if c.Mode != ModeRedis && c.Mode != ModeInMemory {
+	if c.Mode != ModeRedis && c.Mode != ModeInMemory && c.Mode != ModeNats {
 		return fmt.Errorf("config.Mode '%s' is not supported", c.Mode)
 	}
+	if c.Mode == ModeNats && c.NatsURL == "" {
+		return errors.New("config.NatsURL is required in nats mode")
+	}
 	if c.MaxStreamLength < 1 {
 		return errors.New("config.MaxStreamLength has to be a positive number")
 	}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/inflight"
+	"github.com/harness/gitness/stream"
+)
+
+const testEventType EventType = "test-event"
+
+type testPayload struct {
+	Value string
+}
+
+type fakeStreamProducer struct {
+	payloads []map[string]interface{}
+}
+
+func (p *fakeStreamProducer) Send(_ context.Context, _ string, payload map[string]interface{}) (string, error) {
+	p.payloads = append(p.payloads, payload)
+	return "1-0", nil
+}
+
+type fakeStreamConsumer struct {
+	StreamConsumer
+	handlers map[string]stream.HandlerFunc
+}
+
+func (c *fakeStreamConsumer) Register(streamID string, handler stream.HandlerFunc, _ ...stream.HandlerOption) error {
+	c.handlers[streamID] = handler
+	return nil
+}
+
+// setupReaderAndReporter returns a reporter and the handler registered by a reader of the same category,
+// the handler records the events it receives.
+func setupReaderAndReporter(t *testing.T) (*GenericReporter, *fakeStreamProducer, stream.HandlerFunc,
+	*[]*Event[*testPayload]) {
+	t.Helper()
+
+	producer := &fakeStreamProducer{}
+	reporter := &GenericReporter{producer: producer, category: "test"}
+
+	consumer := &fakeStreamConsumer{handlers: map[string]stream.HandlerFunc{}}
+	reader := &GenericReader{
+		streamConsumer: consumer,
+		category:       "test",
+		groupName:      "group",
+		handlers:       inflight.NewTracker(),
+	}
+
+	var received []*Event[*testPayload]
+	err := ReaderRegisterEvent(reader, testEventType, func(_ context.Context, event *Event[*testPayload]) error {
+		received = append(received, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to register handler: %v", err)
+	}
+
+	handler, ok := consumer.handlers[getStreamID("test", testEventType)]
+	if !ok {
+		t.Fatalf("expected handler to be registered for the event stream")
+	}
+
+	return reporter, producer, handler, &received
+}
+
+func TestEventKeyIsKeptOnRedelivery(t *testing.T) {
+	ctx := context.Background()
+	reporter, producer, handler, received := setupReaderAndReporter(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := ReporterSendEvent(reporter, ctx, testEventType, &testPayload{Value: "value"}); err != nil {
+			t.Fatalf("failed to send event: %v", err)
+		}
+	}
+
+	// the first message is delivered twice, and redelivered from the dead letter queue to the group.
+	deliveries := []struct {
+		messageID string
+		payload   map[string]interface{}
+	}{
+		{messageID: "1-0", payload: producer.payloads[0]},
+		{messageID: "1-0", payload: producer.payloads[0]},
+		{messageID: "2-0", payload: WithTargetGroup(producer.payloads[0], "group")},
+		{messageID: "3-0", payload: producer.payloads[1]},
+	}
+	for _, delivery := range deliveries {
+		if err := handler(ctx, delivery.messageID, delivery.payload); err != nil {
+			t.Fatalf("failed to handle message %s: %v", delivery.messageID, err)
+		}
+	}
+
+	events := *received
+	if len(events) != len(deliveries) {
+		t.Fatalf("expected %d events, got %d", len(deliveries), len(events))
+	}
+
+	key := events[0].Key
+	if key == "" || key == events[0].ID {
+		t.Fatalf("expected the key generated by the reporter, got %q", key)
+	}
+	for i, event := range events[:3] {
+		if event.Key != key {
+			t.Errorf("event %d: expected key %q, got %q", i, key, event.Key)
+		}
+		if event.Payload.Value != "value" {
+			t.Errorf("event %d: expected payload to be decoded, got %q", i, event.Payload.Value)
+		}
+	}
+	if events[2].ID != "2-0" {
+		t.Errorf("expected ID of the redelivered message, got %q", events[2].ID)
+	}
+	if events[3].Key == key {
+		t.Errorf("expected a different key for a separately reported event")
+	}
+}
+
+func TestEventKeyFallsBackToMessageID(t *testing.T) {
+	_, _, handler, received := setupReaderAndReporter(t)
+
+	// messages reported before the introduction of idempotency keys don't contain a key.
+	buff := &bytes.Buffer{}
+	err := gob.NewEncoder(buff).Encode(&Event[*testPayload]{
+		Timestamp: time.Now(),
+		Payload:   &testPayload{Value: "value"},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+
+	err = handler(context.Background(), "5-0", map[string]interface{}{streamPayloadKey: buff.Bytes()})
+	if err != nil {
+		t.Fatalf("failed to handle message: %v", err)
+	}
+
+	if len(*received) != 1 {
+		t.Fatalf("expected one event, got %d", len(*received))
+	}
+	if event := (*received)[0]; event.Key != "5-0" || event.ID != "5-0" {
+		t.Errorf("expected key and ID to be the message ID, got key %q and ID %q", event.Key, event.ID)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// queueLag tracks the time between the creation of an event and the start of its processing.
+var queueLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "gitness",
+	Subsystem: "events",
+	Name:      "queue_lag_seconds",
+	Help:      "Time between the creation of an event and the start of its processing.",
+	Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+}, []string{"category", "type"})
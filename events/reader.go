@@ -18,10 +18,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/harness/gitness/inflight"
+	"github.com/harness/gitness/loglevel"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/tracing"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ReaderFactoryFunc is an abstraction of a factory method that creates customized Reader implementations (type [R]).
@@ -34,6 +44,8 @@ type ReaderFactoryFunc[R Reader] func(reader *GenericReader) (R, error)
 type ReaderFactory[R Reader] struct {
 	category                string
 	streamConsumerFactoryFn StreamConsumerFactoryFunc
+	deadLetters             DeadLetterQueue
+	handlers                *inflight.Tracker
 	readerFactoryFn         ReaderFactoryFunc[R]
 }
 
@@ -64,10 +76,17 @@ func (f *ReaderFactory[R]) Launch(ctx context.Context,
 		return nil, fmt.Errorf("failed to create new stream consumer: %w", err)
 	}
 
+	// messages exceeding the max retries are added to the dead letter queue (if any) instead of being discarded.
+	if f.deadLetters != nil {
+		streamConsumer.Configure(stream.WithDeadLetterHandler(f.deadLetters.Add))
+	}
+
 	// create generic reader object
 	innerReader := &GenericReader{
 		streamConsumer: streamConsumer,
 		category:       f.category,
+		groupName:      groupName,
+		handlers:       f.handlers,
 	}
 
 	// create new reader (could return the innerReader itself, but also allows to launch customized readers)
@@ -152,6 +171,8 @@ type HandlerFunc[T interface{}] func(context.Context, *Event[T]) error
 type GenericReader struct {
 	streamConsumer StreamConsumer
 	category       string
+	groupName      string
+	handlers       *inflight.Tracker
 }
 
 // ReaderRegisterEvent registers a type safe handler function on the reader for a specific event.
@@ -168,6 +189,19 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 				return fmt.Errorf("stream payload is nil for message '%s'", messageID)
 			}
 
+			// while draining, new messages are left unacknowledged to be redelivered (e.g. after the restart).
+			handlerDone, err := reader.handlers.Start()
+			if errors.Is(err, inflight.ErrDraining) {
+				<-ctx.Done()
+				return fmt.Errorf("message '%s' not processed: %w", messageID, err)
+			}
+			defer handlerDone()
+
+			// skip messages that are redelivered to a different consumer group.
+			if targetGroup, ok := streamPayload[streamTargetGroupKey].(string); ok && targetGroup != reader.groupName {
+				return nil
+			}
+
 			// retrieve event from stream payload
 			eventRaw, ok := streamPayload[streamPayloadKey]
 			if !ok {
@@ -190,7 +224,7 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 			// decode event to correct type
 			var event Event[T]
 			decoder := gob.NewDecoder(bytes.NewReader(eventBytes))
-			err := decoder.Decode(&event)
+			err = decoder.Decode(&event)
 			if err != nil {
 				//nolint:gocritic // only way to achieve this AFAIK - lint proposal is not building
 				return fmt.Errorf("stream payload can't be decoded into type %T (message '%s')", *new(T), messageID)
@@ -199,11 +233,31 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 			// populate event ID using the message ID (has to be populated here, producer doesn't know the message ID yet)
 			event.ID = messageID
 
-			// update ctx with event type for proper logging
-			log := log.Ctx(ctx).With().
+			// events reported before the introduction of idempotency keys fall back to the message ID.
+			if event.Key == "" {
+				event.Key = messageID
+			}
+
+			queueLag.WithLabelValues(reader.category, string(eventType)).Observe(time.Since(event.Timestamp).Seconds())
+
+			// continue the trace of the event producer (if any).
+			ctx = extractTraceContext(ctx, streamPayload)
+			ctx, span := tracer.Start(ctx, "events.process "+streamID,
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("events.category", reader.category),
+					attribute.String("events.type", string(eventType)),
+					attribute.String("events.id", event.ID),
+					attribute.String("events.key", event.Key),
+				))
+			defer span.End()
+
+			// update ctx with event type for proper logging (using the level of the events subsystem)
+			log := loglevel.Logger(log.Ctx(ctx).With().
 				Str("events.type", string(eventType)).
 				Str("events.id", event.ID).
-				Logger()
+				Str("events.key", event.Key).
+				Logger(), loglevel.SubsystemEvents, "")
 			ctx = log.WithContext(ctx)
 
 			// call provided handler with correctly typed payload
@@ -215,11 +269,36 @@ func ReaderRegisterEvent[T interface{}](reader *GenericReader,
 				return nil
 			}
 
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+
 			// any other error we return as is
 			return err
 		}, toStreamHandlerOptions(opts)...)
 }
 
+// extractTraceContext returns a copy of ctx with the trace context stored in the stream payload (if any).
+func extractTraceContext(ctx context.Context, streamPayload map[string]interface{}) context.Context {
+	var raw []byte
+	switch v := streamPayload[streamTraceKey].(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return ctx
+	}
+
+	carrier := map[string]string{}
+	if err := json.Unmarshal(raw, &carrier); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to decode trace context of event")
+		return ctx
+	}
+
+	return tracing.Extract(ctx, carrier)
+}
+
 func (r *GenericReader) Configure(opts ...ReaderOption) {
 	r.streamConsumer.Configure(toStreamConsumerOptions(opts)...)
 }
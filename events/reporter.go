@@ -18,8 +18,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/harness/gitness/tracing"
+
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GenericReporter represents an event reporter that supports sending typesafe messages
@@ -38,8 +46,18 @@ type GenericReporter struct {
 func ReporterSendEvent[T interface{}](reporter *GenericReporter, ctx context.Context,
 	eventType EventType, payload T) (string, error) {
 	streamID := getStreamID(reporter.category, eventType)
+
+	ctx, span := tracer.Start(ctx, "events.send "+streamID,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("events.category", reporter.category),
+			attribute.String("events.type", string(eventType)),
+		))
+	defer span.End()
+
 	event := Event[T]{
 		ID:        "", // will be set by GenericReader
+		Key:       xid.New().String(),
 		Timestamp: time.Now(),
 		Payload:   payload,
 	}
@@ -51,10 +69,23 @@ func ReporterSendEvent[T interface{}](reporter *GenericReporter, ctx context.Con
 		return "", fmt.Errorf("failed to encode payload: %w", err)
 	}
 
+	// propagate the trace context to the event consumers.
+	traceContext, err := json.Marshal(tracing.Inject(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode trace context: %w", err)
+	}
+
 	streamPayload := map[string]interface{}{
 		streamPayloadKey: buff.Bytes(),
+		streamTraceKey:   string(traceContext),
 	}
 
 	// We are using the message ID as event ID.
-	return reporter.producer.Send(ctx, streamID, streamPayload)
+	id, err := reporter.producer.Send(ctx, streamID, streamPayload)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	return id, nil
 }
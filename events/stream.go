@@ -25,6 +25,19 @@ type StreamProducer interface {
 	Send(ctx context.Context, streamID string, payload map[string]interface{}) (string, error)
 }
 
+// Outbox is an abstraction of a transactional outbox used by reporters instead of sending messages directly.
+// The outbox persists the messages (e.g. together with the database change that triggered them)
+// and takes care of delivering them to the streams using the producer of the System (see System.StreamProducer).
+type Outbox interface {
+	Enqueue(ctx context.Context, streamID string, payload map[string]interface{}) (string, error)
+}
+
+// DeadLetterQueue is an abstraction of a queue storing the messages that readers failed to process
+// within the max retries of the event handler, allowing them to be inspected and redelivered later on.
+type DeadLetterQueue interface {
+	Add(ctx context.Context, deadLetter stream.DeadLetter) error
+}
+
 // StreamConsumer is an abstraction of a consumer from the streams package.
 type StreamConsumer interface {
 	Register(streamID string, handler stream.HandlerFunc, opts ...stream.HandlerOption) error
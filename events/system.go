@@ -14,13 +14,21 @@
 
 package events
 
-import "errors"
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/inflight"
+)
 
 // System represents a single contained event system that is used
 // to setup event Reporters and ReaderFactories.
 type System struct {
 	streamConsumerFactoryFn StreamConsumerFactoryFunc
 	streamProducer          StreamProducer
+	outbox                  Outbox
+	deadLetters             DeadLetterQueue
+	handlers                *inflight.Tracker
 }
 
 func NewSystem(streamConsumerFactoryFunc StreamConsumerFactoryFunc, streamProducer StreamProducer) (*System, error) {
@@ -34,9 +42,21 @@ func NewSystem(streamConsumerFactoryFunc StreamConsumerFactoryFunc, streamProduc
 	return &System{
 		streamConsumerFactoryFn: streamConsumerFactoryFunc,
 		streamProducer:          streamProducer,
+		handlers:                inflight.NewTracker(),
 	}, nil
 }
 
+// Handlers returns the tracker of the event handlers in flight (of all readers of the system).
+// Draining it makes the readers leave new messages for redelivery.
+func (s *System) Handlers() *inflight.Tracker {
+	return s.handlers
+}
+
+// StreamProducer returns the producer that sends messages directly to the streams.
+func (s *System) StreamProducer() StreamProducer {
+	return s.streamProducer
+}
+
 func NewReaderFactory[R Reader](system *System, category string, fn ReaderFactoryFunc[R]) (*ReaderFactory[R], error) {
 	if system == nil {
 		return nil, errors.New("system can't be empty")
@@ -51,6 +71,8 @@ func NewReaderFactory[R Reader](system *System, category string, fn ReaderFactor
 	return &ReaderFactory[R]{
 		// values coming from system
 		streamConsumerFactoryFn: system.streamConsumerFactoryFn,
+		deadLetters:             system.deadLetters,
+		handlers:                system.handlers,
 
 		// values coming from input parameters
 		category:        category,
@@ -66,11 +88,25 @@ func NewReporter(system *System, category string) (*GenericReporter, error) {
 		return nil, errors.New("category can't be empty")
 	}
 
+	var producer StreamProducer = system.streamProducer
+	if system.outbox != nil {
+		producer = outboxProducer{outbox: system.outbox}
+	}
+
 	return &GenericReporter{
 		// values coming from system
-		producer: system.streamProducer,
+		producer: producer,
 
 		// values coming from input parameters
 		category: category,
 	}, nil
 }
+
+// outboxProducer is a StreamProducer that enqueues all messages in an outbox.
+type outboxProducer struct {
+	outbox Outbox
+}
+
+func (p outboxProducer) Send(ctx context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	return p.outbox.Enqueue(ctx, streamID, payload)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/nats-io/nats.go"
 )
 
 // WireSet provides a wire set for this package.
@@ -29,7 +30,15 @@ var WireSet = wire.NewSet(
 	ProvideSystem,
 )
 
-func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, error) {
+// ProvideSystem provides the events system for the configured mode.
+// If an outbox is provided, all events are reported via the outbox.
+// If a dead letter queue is provided, events that readers failed to process are added to it.
+func ProvideSystem(
+	config Config,
+	redisClient redis.UniversalClient,
+	outbox Outbox,
+	deadLetters DeadLetterQueue,
+) (*System, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("provided config is invalid: %w", err)
 	}
@@ -41,6 +50,8 @@ func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, e
 		system, err = provideSystemInMemory(config)
 	case ModeRedis:
 		system, err = provideSystemRedis(config, redisClient)
+	case ModeNats:
+		system, err = provideSystemNats(config)
 	default:
 		return nil, fmt.Errorf("events system mode '%s' is not supported", config.Mode)
 	}
@@ -49,6 +60,9 @@ func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, e
 		return nil, fmt.Errorf("failed to setup event system for mode '%s': %w", config.Mode, err)
 	}
 
+	system.outbox = outbox
+	system.deadLetters = deadLetters
+
 	return system, nil
 }
 
@@ -76,6 +90,24 @@ func provideSystemRedis(config Config, redisClient redis.UniversalClient) (*Syst
 	)
 }
 
+func provideSystemNats(config Config) (*System, error) {
+	conn, err := nats.Connect(config.NatsURL, nats.Name("gitness"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create nats jetstream context: %w", err)
+	}
+
+	return NewSystem(
+		newNatsStreamConsumerFactoryMethod(js, config.Namespace, config.MaxStreamLength),
+		stream.NewNatsProducer(js, config.Namespace, config.MaxStreamLength),
+	)
+}
+
 func newMemoryStreamConsumerFactoryMethod(broker *stream.MemoryBroker, namespace string) StreamConsumerFactoryFunc {
 	return func(groupName string, consumerName string) (StreamConsumer, error) {
 		return stream.NewMemoryConsumer(broker, namespace, groupName)
@@ -99,3 +131,13 @@ func newRedisStreamProducer(redisClient redis.UniversalClient, namespace string,
 	maxStreamLength int64, approxMaxStreamLength bool) StreamProducer {
 	return stream.NewRedisProducer(redisClient, namespace, maxStreamLength, approxMaxStreamLength)
 }
+
+func newNatsStreamConsumerFactoryMethod(
+	js nats.JetStreamContext,
+	namespace string,
+	maxStreamLength int64,
+) StreamConsumerFactoryFunc {
+	return func(groupName string, consumerName string) (StreamConsumer, error) {
+		return stream.NewNatsConsumer(js, namespace, groupName, consumerName, maxStreamLength)
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -47,8 +50,20 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 		return fmt.Errorf("failed to read updated references from std in: %w", err)
 	}
 
+	incomingObjectsSize, err := getIncomingObjectsSize()
+	if err != nil {
+		return fmt.Errorf("failed to get size of incoming objects: %w", err)
+	}
+
+	changedPaths, err := getChangedPaths(ctx, refUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to get changed paths: %w", err)
+	}
+
 	in := &PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:          refUpdates,
+		IncomingObjectsSize: incomingObjectsSize,
+		ChangedPaths:        changedPaths,
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -114,6 +129,86 @@ func handleServerHookOutput(out *Output, err error) error {
 	return nil
 }
 
+// getIncomingObjectsSize returns the size (in KiB) of the objects received by git.
+// Git keeps the received objects in a quarantine directory until the pre-receive hook succeeded.
+// For more details see https://git-scm.com/docs/git-receive-pack#_quarantine_environment
+func getIncomingObjectsSize() (int64, error) {
+	quarantinePath, ok := os.LookupEnv(envNameQuarantinePath)
+	if !ok || quarantinePath == "" {
+		return 0, nil
+	}
+
+	var size int64
+	err := filepath.WalkDir(quarantinePath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	const kib = 1024
+	return (size + kib - 1) / kib, nil
+}
+
+// getChangedPaths returns the paths of all files changed by the commits received per updated branch.
+// The hook runs with the quarantine environment of git, hence the received objects are accessible.
+func getChangedPaths(ctx context.Context, refUpdates []ReferenceUpdate) (map[string][]string, error) {
+	changedPaths := map[string][]string{}
+	for _, refUpdate := range refUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, "refs/heads/") || isNilSHA(refUpdate.New) {
+			continue
+		}
+
+		// diff against the old commit for updates, and against everything that's known already for new branches.
+		args := []string{"diff", "--name-only", "--no-renames", "-z", refUpdate.Old, refUpdate.New}
+		if isNilSHA(refUpdate.Old) {
+			args = []string{"log", "--format=", "--name-only", "--no-renames", "-z", refUpdate.New, "--not", "--all"}
+		}
+
+		out, err := exec.CommandContext(ctx, "git", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list changed paths of '%s': %w", refUpdate.Ref, err)
+		}
+
+		seen := map[string]struct{}{}
+		paths := []string{}
+		for _, path := range strings.Split(string(out), "\x00") {
+			path = strings.Trim(path, "\n")
+			if path == "" {
+				continue
+			}
+			if _, ok := seen[path]; ok {
+				continue
+			}
+
+			seen[path] = struct{}{}
+			paths = append(paths, path)
+		}
+
+		changedPaths[refUpdate.Ref] = paths
+	}
+
+	return changedPaths, nil
+}
+
+func isNilSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
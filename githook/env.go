@@ -26,6 +26,10 @@ import (
 const (
 	// envNamePayload defines the environment variable name used to send the payload to githook binary.
 	envNamePayload = "GIT_HOOK_PAYLOAD"
+
+	// envNameQuarantinePath defines the environment variable name git uses to provide the quarantine directory
+	// of the received objects to the pre-receive hook.
+	envNameQuarantinePath = "GIT_QUARANTINE_PATH"
 )
 
 var (
@@ -43,6 +43,11 @@ type PostReceiveInput struct {
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+	// IncomingObjectsSize is the size (in KiB) of the objects received as part of the git operation.
+	IncomingObjectsSize int64 `json:"incoming_objects_size"`
+	// ChangedPaths contains the paths of all files changed by the commits received as part of the git operation,
+	// keyed by the full name of the updated branch.
+	ChangedPaths map[string][]string `json:"changed_paths"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/gitrpc/internal/streamio"
+	"github.com/harness/gitness/gitrpc/rpc"
+)
+
+type CreateBundleParams struct {
+	ReadParams
+}
+
+type RestoreBundleParams struct {
+	WriteParams
+	// Bundle is the git bundle created by CreateBundle.
+	Bundle io.Reader
+}
+
+// CreateBundle writes a git bundle containing all references of the repository to w.
+// Nothing is written if the repository doesn't contain any references.
+func (c *Client) CreateBundle(ctx context.Context, params *CreateBundleParams, w io.Writer) error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+
+	stream, err := c.repoService.CreateBundle(ctx, &rpc.CreateBundleRequest{
+		Base: mapToRPCReadRequest(params.ReadParams),
+	})
+	if err != nil {
+		return processRPCErrorf(err, "failed to start bundle stream")
+	}
+
+	reader := streamio.NewReader(func() ([]byte, error) {
+		resp, rErr := stream.Recv()
+		return resp.GetData(), rErr
+	})
+
+	if _, err = io.Copy(w, reader); err != nil {
+		return processRPCErrorf(err, "failed to read bundle from stream")
+	}
+
+	return nil
+}
+
+// RestoreBundle fetches all references of the bundle into the existing repository.
+// References of the repository that aren't part of the bundle are removed.
+func (c *Client) RestoreBundle(ctx context.Context, params *RestoreBundleParams) error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+	if params.Bundle == nil {
+		return errors.New("bundle cannot be nil")
+	}
+
+	stream, err := c.repoService.RestoreBundle(ctx)
+	if err != nil {
+		return processRPCErrorf(err, "failed to start restore bundle stream")
+	}
+
+	if err = stream.Send(&rpc.RestoreBundleRequest{
+		Base: mapToRPCWriteRequest(params.WriteParams),
+	}); err != nil {
+		return processRPCErrorf(err, "failed to send restore bundle request")
+	}
+
+	sw := streamio.NewWriter(func(p []byte) error {
+		return stream.Send(&rpc.RestoreBundleRequest{Data: p})
+	})
+
+	if _, err = io.Copy(sw, params.Bundle); err != nil {
+		return fmt.Errorf("failed to send bundle: %w", err)
+	}
+
+	if _, err = stream.CloseAndRecv(); err != nil {
+		return processRPCErrorf(err, "failed to restore bundle on server")
+	}
+
+	return nil
+}
@@ -20,13 +20,14 @@ import (
 
 	"github.com/harness/gitness/gitrpc/rpc"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Client struct {
-	conn               *grpc.ClientConn
+	conn               grpc.ClientConnInterface
 	repoService        rpc.RepositoryServiceClient
 	refService         rpc.ReferenceServiceClient
 	httpService        rpc.SmartHTTPServiceClient
@@ -42,6 +43,35 @@ func New(config Config) (*Client, error) {
 		return nil, fmt.Errorf("provided config is invalid: %w", err)
 	}
 
+	conn, err := dial(config, config.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithConn(conn), nil
+}
+
+// NewSharded returns a client that sends the requests of a repository to the shard (storage node) storing it.
+// The shard of a repository is retrieved from the provided router.
+func NewSharded(config Config, router ShardRouter) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("provided config is invalid: %w", err)
+	}
+
+	conns := make(map[string]*grpc.ClientConn, len(config.Shards))
+	for shard, addr := range config.Shards {
+		conn, err := dial(config, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial shard '%s': %w", shard, err)
+		}
+
+		conns[shard] = conn
+	}
+
+	return NewWithConn(newShardedConn(router, conns)), nil
+}
+
+func dial(config Config, addr string) (*grpc.ClientConn, error) {
 	// create interceptors
 	logIntc := NewClientLogInterceptor()
 
@@ -50,9 +80,11 @@ func New(config Config) (*Client, error) {
 		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, config.LoadBalancingPolicy)),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithChainUnaryInterceptor(
+			otelgrpc.UnaryClientInterceptor(),
 			logIntc.UnaryClientInterceptor(),
 		),
 		grpc.WithChainStreamInterceptor(
+			otelgrpc.StreamClientInterceptor(),
 			logIntc.StreamClientInterceptor(),
 		),
 		grpc.WithConnectParams(
@@ -69,15 +101,10 @@ func New(config Config) (*Client, error) {
 		),
 	}
 
-	conn, err := grpc.Dial(config.Addr, grpcOpts...)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewWithConn(conn), nil
+	return grpc.Dial(addr, grpcOpts...)
 }
 
-func NewWithConn(conn *grpc.ClientConn) *Client {
+func NewWithConn(conn grpc.ClientConnInterface) *Client {
 	return &Client{
 		conn:               conn,
 		repoService:        rpc.NewRepositoryServiceClient(conn),
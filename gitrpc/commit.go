@@ -43,6 +43,14 @@ type Commit struct {
 	Message   string    `json:"message,omitempty"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+	// Signature is the cryptographic signature of the commit (nil if the commit isn't signed).
+	Signature *CommitSignature `json:"-"`
+}
+
+// CommitSignature contains the raw signature of a commit and the payload that was signed.
+type CommitSignature struct {
+	Signature string
+	Payload   string
 }
 
 type Signature struct {
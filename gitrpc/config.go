@@ -16,21 +16,36 @@ package gitrpc
 
 import (
 	"errors"
+	"fmt"
 )
 
 // Config represents the config for the gitrpc client.
 type Config struct {
 	Addr                string `envconfig:"GITRPC_CLIENT_ADDR" default:"127.0.0.1:3001"`
 	LoadBalancingPolicy string `envconfig:"GITRPC_CLIENT_LOAD_BALANCING_POLICY" default:"pick_first"`
+
+	// Shards maps the names of the gitrpc servers storing the repositories to their address (name:addr,name:addr).
+	// If set, the requests of a repository are sent to the server storing it, otherwise all requests are sent to Addr.
+	Shards map[string]string `envconfig:"GITRPC_CLIENT_SHARDS"`
+	// DefaultShard is the shard storing the repositories created before sharding was enabled.
+	DefaultShard string `envconfig:"GITRPC_CLIENT_DEFAULT_SHARD"`
 }
 
 func (c *Config) Validate() error {
 	if c == nil {
 		return errors.New("config is required")
 	}
-	if c.Addr == "" {
+	if c.Addr == "" && len(c.Shards) == 0 {
 		return errors.New("config.Addr is required")
 	}
+	for shard, addr := range c.Shards {
+		if addr == "" {
+			return fmt.Errorf("config.Shards is missing the address of shard '%s'", shard)
+		}
+	}
+	if _, ok := c.Shards[c.DefaultShard]; len(c.Shards) > 0 && !ok {
+		return fmt.Errorf("config.DefaultShard '%s' has to be one of the shards", c.DefaultShard)
+	}
 
 	return nil
 }
@@ -32,6 +32,52 @@ type DiffParams struct {
 	HeadRef      string
 	MergeBase    bool
 	IncludePatch bool
+	// Paths restricts the diff to the provided file paths (optional).
+	Paths []string
+	// Limits are the thresholds above which the patch of a file is omitted (optional).
+	// NOTE: Limits.MaxFiles is ignored if Paths are provided.
+	Limits DiffLimits
+	// Options control how git compares the files (optional).
+	Options DiffOptions
+}
+
+// DiffAlgorithm is the algorithm used by git to compute the diff.
+type DiffAlgorithm string
+
+const (
+	DiffAlgorithmDefault   DiffAlgorithm = ""
+	DiffAlgorithmMyers     DiffAlgorithm = "myers"
+	DiffAlgorithmMinimal   DiffAlgorithm = "minimal"
+	DiffAlgorithmPatience  DiffAlgorithm = "patience"
+	DiffAlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+// DiffOptions control how git compares files.
+type DiffOptions struct {
+	// Algorithm is the diff algorithm, the default of git is used if empty.
+	Algorithm DiffAlgorithm
+	// IgnoreWhitespace ignores whitespace when comparing lines.
+	IgnoreWhitespace bool
+	// IgnoreBlankLines ignores changes whose lines are all blank.
+	IgnoreBlankLines bool
+}
+
+func (o DiffOptions) Validate() error {
+	switch o.Algorithm {
+	case DiffAlgorithmDefault, DiffAlgorithmMyers, DiffAlgorithmMinimal,
+		DiffAlgorithmPatience, DiffAlgorithmHistogram:
+		return nil
+	default:
+		return ErrInvalidArgumentf("unknown diff algorithm '%s'", o.Algorithm)
+	}
+}
+
+// DiffLimits defines the thresholds above which the patch of a file is omitted and the file is marked as collapsed.
+type DiffLimits struct {
+	// MaxFiles is the max number of files whose patch is included (0 = unlimited).
+	MaxFiles int
+	// MaxFileLines is the max number of patch lines of a single file (0 = unlimited).
+	MaxFileLines int
 }
 
 func (p DiffParams) Validate() error {
@@ -42,7 +88,26 @@ func (p DiffParams) Validate() error {
 	if p.HeadRef == "" {
 		return ErrInvalidArgumentf("head ref cannot be empty")
 	}
-	return nil
+	if p.Limits.MaxFiles < 0 || p.Limits.MaxFileLines < 0 {
+		return ErrInvalidArgumentf("diff limits cannot be negative")
+	}
+	return p.Options.Validate()
+}
+
+type CommitDiffParams struct {
+	ReadParams
+	// SHA is the git commit sha
+	SHA string
+	// Options control how git compares the files (optional).
+	Options DiffOptions
+}
+
+func (p CommitDiffParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	return p.Options.Validate()
 }
 
 func (c *Client) RawDiff(ctx context.Context, params *DiffParams, out io.Writer) error {
@@ -54,6 +119,8 @@ func (c *Client) RawDiff(ctx context.Context, params *DiffParams, out io.Writer)
 		BaseRef:   params.BaseRef,
 		HeadRef:   params.HeadRef,
 		MergeBase: params.MergeBase,
+		Paths:     params.Paths,
+		Options:   mapToRPCDiffOptions(params.Options),
 	})
 	if err != nil {
 		return processRPCErrorf(err, "failed to fetch diff between '%s' and '%s' with err: %v",
@@ -74,13 +141,14 @@ func (c *Client) RawDiff(ctx context.Context, params *DiffParams, out io.Writer)
 	return nil
 }
 
-func (c *Client) CommitDiff(ctx context.Context, params *GetCommitParams, out io.Writer) error {
+func (c *Client) CommitDiff(ctx context.Context, params *CommitDiffParams, out io.Writer) error {
 	if err := params.Validate(); err != nil {
 		return err
 	}
 	diff, err := c.diffService.CommitDiff(ctx, &rpc.CommitDiffRequest{
-		Base: mapToRPCReadRequest(params.ReadParams),
-		Sha:  params.SHA,
+		Base:    mapToRPCReadRequest(params.ReadParams),
+		Sha:     params.SHA,
+		Options: mapToRPCDiffOptions(params.Options),
 	})
 	if err != nil {
 		return processRPCErrorf(err, "failed to fetch diff for commit '%s': %v", params.SHA, err)
@@ -326,6 +394,31 @@ type FileDiff struct {
 	Patch       []byte         `json:"patch,omitempty"`
 	IsBinary    bool           `json:"is_binary"`
 	IsSubmodule bool           `json:"is_submodule"`
+	IsCollapsed bool           `json:"is_collapsed"`
+	// Binary describes the change of binary files, instead of an opaque "binary file changed".
+	Binary *BinaryFileDiff `json:"binary,omitempty"`
+	// WordDiff marks the changed words of modified lines, it's only set if requested.
+	WordDiff *WordDiff `json:"word_diff,omitempty"`
+}
+
+// WordDiff contains the intra-line changes of a file, computed on language-aware tokens.
+type WordDiff struct {
+	Language string         `json:"language"`
+	Lines    []WordDiffLine `json:"lines"`
+}
+
+// WordDiffLine marks the changed words of a modified line, identified by its line numbers in the old and new file.
+type WordDiffLine struct {
+	OldLine int             `json:"old_line"`
+	NewLine int             `json:"new_line"`
+	Old     []WordDiffRange `json:"old"`
+	New     []WordDiffRange `json:"new"`
+}
+
+// WordDiffRange is a changed range of a line, in characters (runes) with an exclusive end.
+type WordDiffRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
 }
 
 type FileDiffStatus string
@@ -361,8 +454,13 @@ func (c *Client) Diff(ctx context.Context, params *DiffParams) (<-chan *FileDiff
 			HeadRef:      params.HeadRef,
 			MergeBase:    params.MergeBase,
 			IncludePatch: params.IncludePatch,
+			Paths:        params.Paths,
+			MaxFiles:     int32(params.Limits.MaxFiles),
+			MaxFileLines: int32(params.Limits.MaxFileLines),
+			Options:      mapToRPCDiffOptions(params.Options),
 		})
 		if err != nil {
+			cherr <- processRPCErrorf(err, "failed to get git diff stream")
 			return
 		}
 
@@ -376,7 +474,7 @@ func (c *Client) Diff(ctx context.Context, params *DiffParams) (<-chan *FileDiff
 				return
 			}
 
-			ch <- &FileDiff{
+			file := &FileDiff{
 				SHA:         resp.Sha,
 				OldSHA:      resp.OldSha,
 				Path:        resp.Path,
@@ -388,7 +486,18 @@ func (c *Client) Diff(ctx context.Context, params *DiffParams) (<-chan *FileDiff
 				Patch:       resp.Patch,
 				IsBinary:    resp.IsBinary,
 				IsSubmodule: resp.IsSubmodule,
+				IsCollapsed: resp.IsCollapsed,
 			}
+
+			if file.IsBinary {
+				file.Binary, err = c.getBinaryFileDiff(ctx, params.ReadParams, file)
+				if err != nil {
+					cherr <- err
+					return
+				}
+			}
+
+			ch <- file
 		}
 	}()
 
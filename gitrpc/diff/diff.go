@@ -70,6 +70,7 @@ type Section struct {
 
 	numAdditions int
 	numDeletions int
+	numLines     int // includes lines that weren't kept due to the parser limits
 }
 
 // NumLines returns the number of lines in the section.
@@ -148,6 +149,8 @@ type File struct {
 
 	IsBinary    bool
 	IsSubmodule bool
+	// IsCollapsed is true in case the sections were dropped as the file exceeded the parser limits.
+	IsCollapsed bool
 }
 
 func (f *File) Status() string {
@@ -202,6 +205,13 @@ func (f *File) IsEmpty() bool {
 type Parser struct {
 	*bufio.Reader
 
+	// MaxFiles is the max number of files whose sections are kept (0 = unlimited).
+	// Any further file is parsed without keeping its sections and is marked as collapsed.
+	MaxFiles int
+	// MaxFileLines is the max number of section lines kept for a single file (0 = unlimited).
+	// The sections of a file exceeding the limit are dropped and the file is marked as collapsed.
+	MaxFileLines int
+
 	// The next line that hasn't been processed. It is used to determine what kind
 	// of process should go in.
 	buffer []byte
@@ -340,20 +350,26 @@ checkType:
 	return file, nil
 }
 
+// parseSection parses the next section, keeping at most maxLines lines of it (negative = unlimited).
+//
 //nolint:gocognit // refactor if needed
-func (p *Parser) parseSection() (*Section, error) {
+func (p *Parser) parseSection(maxLines int) (*Section, error) {
 	line := string(p.buffer)
 	p.buffer = nil
 
-	section := &Section{
-		Lines: []*Line{
-			{
-				Type:    DiffLineSection,
-				Content: line,
-			},
-		},
+	section := &Section{}
+	addLine := func(l *Line) {
+		section.numLines++
+		if maxLines < 0 || len(section.Lines) < maxLines {
+			section.Lines = append(section.Lines, l)
+		}
 	}
 
+	addLine(&Line{
+		Type:    DiffLineSection,
+		Content: line,
+	})
+
 	// Parse line number, e.g. @@ -0,0 +1,3 @@
 	var leftLine, rightLine int
 	ss := strings.Split(line, "@@")
@@ -394,7 +410,7 @@ func (p *Parser) parseSection() (*Section, error) {
 
 		switch subLine[0] {
 		case ' ':
-			section.Lines = append(section.Lines, &Line{
+			addLine(&Line{
 				Type:      DiffLinePlain,
 				Content:   subLine,
 				LeftLine:  leftLine,
@@ -403,7 +419,7 @@ func (p *Parser) parseSection() (*Section, error) {
 			leftLine++
 			rightLine++
 		case '+':
-			section.Lines = append(section.Lines, &Line{
+			addLine(&Line{
 				Type:      DiffLineAdd,
 				Content:   subLine,
 				RightLine: rightLine,
@@ -411,7 +427,7 @@ func (p *Parser) parseSection() (*Section, error) {
 			section.numAdditions++
 			rightLine++
 		case '-':
-			section.Lines = append(section.Lines, &Line{
+			addLine(&Line{
 				Type:     DiffLineDelete,
 				Content:  subLine,
 				LeftLine: leftLine,
@@ -430,6 +446,7 @@ func (p *Parser) parseSection() (*Section, error) {
 func (p *Parser) Parse(send func(f *File) error) error {
 	file := new(File)
 	currentFileLines := 0
+	numFiles := 0
 	additions := 0
 	deletions := 0
 
@@ -462,6 +479,11 @@ func (p *Parser) Parse(send func(f *File) error) error {
 				return err
 			}
 
+			numFiles++
+			if p.MaxFiles > 0 && numFiles > p.MaxFiles {
+				file.IsCollapsed = true
+			}
+
 			currentFileLines = 0
 			continue
 		}
@@ -483,16 +505,32 @@ func (p *Parser) Parse(send func(f *File) error) error {
 			continue
 		}
 
-		section, err := p.parseSection()
+		maxLines := -1
+		switch {
+		case file.IsCollapsed:
+			maxLines = 0
+		case p.MaxFileLines > 0:
+			maxLines = p.MaxFileLines - currentFileLines
+		}
+
+		section, err := p.parseSection(maxLines)
 		if err != nil {
 			return err
 		}
-		file.Sections = append(file.Sections, section)
 		file.numAdditions += section.numAdditions
 		file.numDeletions += section.numDeletions
 		additions += section.numAdditions
 		deletions += section.numDeletions
-		currentFileLines += section.NumLines()
+		currentFileLines += section.numLines
+
+		if p.MaxFileLines > 0 && currentFileLines > p.MaxFileLines {
+			file.IsCollapsed = true
+		}
+		if file.IsCollapsed {
+			file.Sections = nil
+			continue
+		}
+		file.Sections = append(file.Sections, section)
 	}
 
 	// stream last file
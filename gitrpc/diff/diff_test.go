@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+const testDiff = `diff --git a/a.txt b/a.txt
+new file mode 100644
+index 0000000..fb0c863
+--- /dev/null
++++ b/a.txt
+@@ -0,0 +1,3 @@
++line 1
++line 2
++line 3
+diff --git a/b.txt b/b.txt
+index f043b93..e9449b5 100644
+--- a/b.txt
++++ b/b.txt
+@@ -7,3 +7,4 @@
+ unchanged 
+-removed
++added 1
++added 2
+ unchanged 
+@@ -27,2 +28,3 @@
+ unchanged 
++added
+ unchanged 
+diff --git a/c.txt b/c.txt
+deleted file mode 100644
+index f043b93..0000000
+--- a/c.txt
++++ /dev/null
+@@ -1,1 +0,0 @@
+-line 1
+`
+
+func TestParserLimits(t *testing.T) {
+	type result struct {
+		collapsed bool
+		lines     int
+		additions int
+	}
+
+	tests := []struct {
+		name         string
+		maxFiles     int
+		maxFileLines int
+		want         []result
+	}{
+		{
+			name: "unlimited",
+			want: []result{{false, 4, 3}, {false, 10, 3}, {false, 2, 0}},
+		},
+		{
+			name:     "max files",
+			maxFiles: 1,
+			want:     []result{{false, 4, 3}, {true, 0, 3}, {true, 0, 0}},
+		},
+		{
+			name:         "max file lines",
+			maxFileLines: 4,
+			want:         []result{{false, 4, 3}, {true, 0, 3}, {false, 2, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := Parser{
+				Reader:       bufio.NewReader(strings.NewReader(testDiff)),
+				MaxFiles:     test.maxFiles,
+				MaxFileLines: test.maxFileLines,
+			}
+
+			var got []result
+			err := parser.Parse(func(f *File) error {
+				lines := 0
+				for _, section := range f.Sections {
+					lines += section.NumLines()
+				}
+				got = append(got, result{f.IsCollapsed, lines, f.NumAdditions()})
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to parse diff: %v", err)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("want %d files, got %d", len(test.want), len(got))
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("file %d: want %+v, got %+v", i, test.want[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"strings"
+
+	// register the decoders used to read the dimensions of changed images.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// binaryPeekSize is the number of bytes read from binary blobs to detect their type and image dimensions.
+const binaryPeekSize = 64 * 1024
+
+// BinaryFileDiff describes the change of a binary file, with the blobs before and after the change.
+// Old is nil for added files and New is nil for deleted files.
+type BinaryFileDiff struct {
+	IsImage   bool        `json:"is_image"`
+	Old       *BinaryBlob `json:"old,omitempty"`
+	New       *BinaryBlob `json:"new,omitempty"`
+	SizeDelta int64       `json:"size_delta"`
+}
+
+// BinaryBlob references one side of a binary file change.
+// Width and Height are only set for images in a format the server can decode (PNG, JPEG and GIF).
+type BinaryBlob struct {
+	SHA      string `json:"sha"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+func (c *Client) getBinaryFileDiff(
+	ctx context.Context,
+	readParams ReadParams,
+	file *FileDiff,
+) (*BinaryFileDiff, error) {
+	binary := &BinaryFileDiff{}
+
+	var err error
+
+	if file.Status != FileDiffStatusAdded && !isZeroSHA(file.OldSHA) {
+		binary.Old, err = c.getBinaryBlob(ctx, readParams, file.OldSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get old blob of '%s': %w", file.Path, err)
+		}
+	}
+
+	if file.Status != FileDiffStatusDeleted && !isZeroSHA(file.SHA) {
+		binary.New, err = c.getBinaryBlob(ctx, readParams, file.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get new blob of '%s': %w", file.Path, err)
+		}
+	}
+
+	if binary.Old != nil {
+		binary.SizeDelta -= binary.Old.Size
+		binary.IsImage = strings.HasPrefix(binary.Old.MimeType, "image/")
+	}
+
+	if binary.New != nil {
+		binary.SizeDelta += binary.New.Size
+		binary.IsImage = binary.IsImage || strings.HasPrefix(binary.New.MimeType, "image/")
+	}
+
+	return binary, nil
+}
+
+func (c *Client) getBinaryBlob(ctx context.Context, readParams ReadParams, sha string) (*BinaryBlob, error) {
+	blob, err := c.GetBlob(ctx, &GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  binaryPeekSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return newBinaryBlob(sha, blob.Size, content), nil
+}
+
+// newBinaryBlob detects the mime type of the blob from the start of its content and,
+// for images, reads the dimensions from the image header.
+func newBinaryBlob(sha string, size int64, content []byte) *BinaryBlob {
+	b := &BinaryBlob{
+		SHA:      sha,
+		Size:     size,
+		MimeType: http.DetectContentType(content),
+	}
+
+	if !strings.HasPrefix(b.MimeType, "image/") {
+		return b
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err == nil {
+		b.Width = cfg.Width
+		b.Height = cfg.Height
+	}
+
+	return b
+}
+
+func isZeroSHA(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "github.com/harness/gitness/gitrpc/rpc"
+
+// ObjectFormat represents the hash algorithm used for the object ids of a repository.
+type ObjectFormat string
+
+const (
+	// ObjectFormatSHA1 uses 40 character long SHA-1 object ids (default).
+	ObjectFormatSHA1 ObjectFormat = "sha1"
+	// ObjectFormatSHA256 uses 64 character long SHA-256 object ids.
+	ObjectFormatSHA256 ObjectFormat = "sha256"
+)
+
+func ObjectFormatFromRPC(f rpc.CreateRepositoryRequestHeader_ObjectFormat) ObjectFormat {
+	switch f {
+	case rpc.CreateRepositoryRequestHeader_sha1:
+		return ObjectFormatSHA1
+	case rpc.CreateRepositoryRequestHeader_sha256:
+		return ObjectFormatSHA256
+	default:
+		return ObjectFormatSHA1
+	}
+}
+
+func (f ObjectFormat) ToRPC() rpc.CreateRepositoryRequestHeader_ObjectFormat {
+	switch f {
+	case ObjectFormatSHA1:
+		return rpc.CreateRepositoryRequestHeader_sha1
+	case ObjectFormatSHA256:
+		return rpc.CreateRepositoryRequestHeader_sha256
+	default:
+		return rpc.CreateRepositoryRequestHeader_sha1
+	}
+}
@@ -41,6 +41,11 @@ type Interface interface {
 	UpdateRef(ctx context.Context, params UpdateRefParams) error
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
+	GetRepositorySize(ctx context.Context, params *GetRepositorySizeParams) (*GetRepositorySizeOutput, error)
+	UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error
+	FetchCommit(ctx context.Context, params *FetchCommitParams) (FetchCommitOutput, error)
+	CreateBundle(ctx context.Context, params *CreateBundleParams, w io.Writer) error
+	RestoreBundle(ctx context.Context, params *RestoreBundleParams) error
 
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
@@ -65,7 +70,7 @@ type Interface interface {
 	 */
 	RawDiff(ctx context.Context, in *DiffParams, w io.Writer) error
 	Diff(ctx context.Context, in *DiffParams) (<-chan *FileDiff, <-chan error)
-	CommitDiff(ctx context.Context, params *GetCommitParams, w io.Writer) error
+	CommitDiff(ctx context.Context, params *CommitDiffParams, w io.Writer) error
 	DiffShortStat(ctx context.Context, params *DiffParams) (DiffShortStatOutput, error)
 	DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error)
 
@@ -34,14 +34,21 @@ func (g Adapter) RawDiff(
 	baseRef string,
 	headRef string,
 	mergeBase bool,
+	opts types.DiffOptions,
 	w io.Writer,
+	paths ...string,
 ) error {
-	args := make([]string, 0, 8)
+	args := make([]string, 0, 11+len(paths))
 	args = append(args, "diff", "-M", "--full-index")
+	args = append(args, diffOptionsArgs(opts)...)
 	if mergeBase {
 		args = append(args, "--merge-base")
 	}
 	args = append(args, baseRef, headRef)
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
 
 	cmd := git.NewCommand(ctx, args...)
 	cmd.SetDescription(fmt.Sprintf("GetDiffRange [repo_path: %s]", repoPath))
@@ -59,10 +66,27 @@ func (g Adapter) RawDiff(
 	return nil
 }
 
+// diffOptionsArgs returns the git diff arguments of the provided options.
+func diffOptionsArgs(opts types.DiffOptions) []string {
+	args := make([]string, 0, 3)
+	if opts.Algorithm != "" {
+		args = append(args, "--diff-algorithm="+opts.Algorithm)
+	}
+	if opts.IgnoreWhitespace {
+		args = append(args, "--ignore-all-space")
+	}
+	if opts.IgnoreBlankLines {
+		args = append(args, "--ignore-blank-lines")
+	}
+	return args
+}
+
 // CommitDiff will stream diff for provided ref.
-func (g Adapter) CommitDiff(ctx context.Context, repoPath, sha string, w io.Writer) error {
-	args := make([]string, 0, 8)
-	args = append(args, "show", "--full-index", "--pretty=format:%b", sha)
+func (g Adapter) CommitDiff(ctx context.Context, repoPath, sha string, opts types.DiffOptions, w io.Writer) error {
+	args := make([]string, 0, 11)
+	args = append(args, "show", "--full-index", "--pretty=format:%b")
+	args = append(args, diffOptionsArgs(opts)...)
+	args = append(args, sha)
 
 	stderr := new(bytes.Buffer)
 	cmd := git.NewCommand(ctx, args...)
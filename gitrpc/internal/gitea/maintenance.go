@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/modules/git"
+)
+
+// maintenanceConfig is the local git configuration required for git to maintain and use
+// commit-graph files and reachability bitmaps of a repository.
+var maintenanceConfig = [][2]string{
+	{"core.commitGraph", "true"},
+	{"fetch.writeCommitGraph", "true"},
+	{"repack.writeBitmaps", "true"},
+	{"pack.useBitmaps", "true"},
+	{"pack.writeBitmapHashCache", "true"},
+}
+
+// ConfigureMaintenance sets the local git configuration of the repository
+// that enables the usage of commit-graph files and reachability bitmaps.
+func (g Adapter) ConfigureMaintenance(ctx context.Context, repoPath string) error {
+	for _, kv := range maintenanceConfig {
+		if err := g.Config(ctx, repoPath, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCommitGraph writes the commit-graph file of the repository containing all reachable commits.
+// It speeds up commit walks like merge-base, ancestry checks and the negotiation during fetches.
+// NOTE: The graph isn't split on purpose, as go-git only reads the single file variant.
+func (g Adapter) WriteCommitGraph(ctx context.Context, repoPath string) error {
+	cmd := git.NewCommand(ctx, "commit-graph", "write", "--reachable")
+	// bloom filters speed up path-limited history queries (e.g. commits changing a file).
+	if git.CheckGitVersionAtLeast("2.27") == nil {
+		cmd.AddArguments("--changed-paths")
+	}
+
+	if _, _, err := cmd.RunStdString(&git.RunOpts{Dir: repoPath}); err != nil {
+		return fmt.Errorf("failed to write commit-graph: %w", err)
+	}
+	return nil
+}
+
+// RepackWithBitmaps repacks all objects of the repository into a single pack with a reachability bitmap.
+// Unreachable objects are kept, as they could belong to a push that's still in progress.
+func (g Adapter) RepackWithBitmaps(ctx context.Context, repoPath string) error {
+	if _, _, err := git.NewCommand(ctx, "repack", "-a", "-d", "--keep-unreachable", "--write-bitmap-index").
+		RunStdString(&git.RunOpts{Dir: repoPath}); err != nil {
+		return fmt.Errorf("failed to repack repository: %w", err)
+	}
+	return nil
+}
@@ -142,6 +142,15 @@ func mapGiteaCommit(giteaCommit *gitea.Commit) (*types.Commit, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to map gitea commiter: %w", err)
 	}
+
+	var signature *types.CommitSignature
+	if giteaCommit.Signature != nil {
+		signature = &types.CommitSignature{
+			Signature: giteaCommit.Signature.Signature,
+			Payload:   giteaCommit.Signature.Payload,
+		}
+	}
+
 	return &types.Commit{
 		SHA:   giteaCommit.ID.String(),
 		Title: giteaCommit.Summary(),
@@ -149,6 +158,7 @@ func mapGiteaCommit(giteaCommit *gitea.Commit) (*types.Commit, error) {
 		Message:   strings.TrimRight(giteaCommit.Message(), "\n"),
 		Author:    author,
 		Committer: committer,
+		Signature: signature,
 	}, nil
 }
 
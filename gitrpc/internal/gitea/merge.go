@@ -69,7 +69,14 @@ func (g Adapter) CreateTemporaryRepoForPR(
 		return types.TempRepository{}, err
 	}
 
-	if err = g.InitRepository(ctx, tmpBasePath, false); err != nil {
+	// the temporary repository has to use the same object format as the base repository.
+	objectFormat, err := g.GetObjectFormat(ctx, baseRepoPath)
+	if err != nil {
+		_ = tempdir.RemoveTemporaryPath(tmpBasePath)
+		return types.TempRepository{}, err
+	}
+
+	if err = g.InitRepository(ctx, tmpBasePath, false, objectFormat); err != nil {
 		_ = tempdir.RemoveTemporaryPath(tmpBasePath)
 		return types.TempRepository{}, err
 	}
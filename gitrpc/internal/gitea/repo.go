@@ -15,12 +15,16 @@
 package gitea
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/harness/gitness/gitrpc/enum"
 	"github.com/harness/gitness/gitrpc/internal/types"
 
 	gitea "code.gitea.io/gitea/modules/git"
@@ -29,9 +33,43 @@ import (
 
 var lsRemoteHeadRegexp = regexp.MustCompile(`ref: refs/heads/([^\s]+)\s+HEAD`)
 
-// InitRepository initializes a new Git repository.
-func (g Adapter) InitRepository(ctx context.Context, repoPath string, bare bool) error {
-	return gitea.InitRepository(ctx, repoPath, bare)
+// InitRepository initializes a new Git repository using the provided object format.
+func (g Adapter) InitRepository(ctx context.Context, repoPath string, bare bool,
+	objectFormat enum.ObjectFormat) error {
+	if objectFormat == "" || objectFormat == enum.ObjectFormatSHA1 {
+		return gitea.InitRepository(ctx, repoPath, bare)
+	}
+
+	// gitea doesn't support other object formats, call git init directly.
+	if err := os.MkdirAll(repoPath, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", repoPath, err)
+	}
+
+	cmd := gitea.NewCommand(ctx, "init", "--object-format="+string(objectFormat))
+	if bare {
+		cmd.AddArguments("--bare")
+	}
+	if _, _, err := cmd.RunStdString(&gitea.RunOpts{Dir: repoPath}); err != nil {
+		return processGiteaErrorf(err, "failed to init repository")
+	}
+
+	return nil
+}
+
+// GetObjectFormat returns the object format used by the repository.
+func (g Adapter) GetObjectFormat(ctx context.Context, repoPath string) (enum.ObjectFormat, error) {
+	stdout, _, err := gitea.NewCommand(ctx, "rev-parse", "--show-object-format").
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		return "", processGiteaErrorf(err, "failed to get object format")
+	}
+
+	switch format := enum.ObjectFormat(strings.TrimSpace(stdout)); format {
+	case enum.ObjectFormatSHA1, enum.ObjectFormatSHA256:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown object format '%s'", format)
+	}
 }
 
 // SetDefaultBranch sets the default branch of a repo.
@@ -77,15 +115,17 @@ func (g Adapter) GetDefaultBranch(ctx context.Context, repoPath string) (string,
 
 // GetRemoteDefaultBranch retrieves the default branch of a remote repository.
 // If the repo doesn't have a default branch, types.ErrNoDefaultBranch is returned.
-func (g Adapter) GetRemoteDefaultBranch(ctx context.Context, remoteURL string) (string, error) {
-	args := []string{
+// If proxyURL is empty, the proxy configuration of git is used.
+func (g Adapter) GetRemoteDefaultBranch(ctx context.Context, remoteURL string, proxyURL string) (string, error) {
+	args := proxyArgs(proxyURL)
+	args = append(args,
 		"-c", "credential.helper=",
 		"ls-remote",
 		"--symref",
 		"-q",
 		remoteURL,
 		"HEAD",
-	}
+	)
 
 	cmd := gitea.NewCommand(ctx, args...)
 	stdOut, _, err := cmd.RunStdString(nil)
@@ -125,9 +165,11 @@ func (g Adapter) Clone(ctx context.Context, from, to string, opts types.CloneRep
 }
 
 // Sync synchronizes the repository to match the provided source.
+// If proxyURL is empty, the proxy configuration of git is used.
 // NOTE: This is a read operation and doesn't trigger any server side hooks.
-func (g Adapter) Sync(ctx context.Context, repoPath string, remoteURL string) error {
-	args := []string{
+func (g Adapter) Sync(ctx context.Context, repoPath string, remoteURL string, proxyURL string) error {
+	args := proxyArgs(proxyURL)
+	args = append(args,
 		"-c", "advice.fetchShowForcedUpdates=false",
 		"-c", "credential.helper=",
 		"fetch",
@@ -139,7 +181,7 @@ func (g Adapter) Sync(ctx context.Context, repoPath string, remoteURL string) er
 		"--no-show-forced-updates",
 		remoteURL,
 		"+refs/*:refs/*",
-	}
+	)
 
 	cmd := gitea.NewCommand(ctx, args...)
 	_, _, err := cmd.RunStdString(&gitea.RunOpts{
@@ -153,6 +195,69 @@ func (g Adapter) Sync(ctx context.Context, repoPath string, remoteURL string) er
 	return nil
 }
 
+// proxyArgs returns the git config arguments that make git connect to remotes via the provided proxy.
+func proxyArgs(proxyURL string) []string {
+	if proxyURL == "" {
+		return nil
+	}
+
+	return []string{"-c", "http.proxy=" + proxyURL}
+}
+
+// CreateBundle writes a git bundle containing all references of the repository (including HEAD) to w.
+// Nothing is written if the repository doesn't contain any references, as git can't create empty bundles.
+func (g Adapter) CreateBundle(ctx context.Context, repoPath string, w io.Writer) error {
+	stdout, _, runErr := gitea.NewCommand(ctx, "for-each-ref", "--count=1", "--format=%(refname)").
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return processGiteaErrorf(runErr, "failed to check references of the repository")
+	}
+	if strings.TrimSpace(stdout) == "" {
+		return nil
+	}
+
+	cmd := gitea.NewCommand(ctx, "bundle", "create", "--quiet", "-", "--all")
+	cmd.SetDescription(fmt.Sprintf("CreateBundle [repo_path: %s]", repoPath))
+	errbuf := bytes.Buffer{}
+	if err := cmd.Run(&gitea.RunOpts{
+		Dir:    repoPath,
+		Stderr: &errbuf,
+		Stdout: w,
+	}); err != nil {
+		if errbuf.Len() > 0 {
+			err = &runStdError{err: err, stderr: errbuf.String()}
+		}
+		return processGiteaErrorf(err, "failed to create bundle")
+	}
+
+	return nil
+}
+
+// FetchObjects fetches the provided objects (and everything reachable from them) from the source repository.
+// No references are created, the fetched objects stay unreferenced until gc removes them.
+func (g Adapter) FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs ...string) error {
+	args := []string{
+		"-c", "credential.helper=",
+		"fetch",
+		"--quiet",
+		"--no-tags",
+		"--no-write-fetch-head",
+		source,
+	}
+	args = append(args, objectSHAs...)
+
+	cmd := gitea.NewCommand(ctx, args...)
+	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+		Dir:               repoPath,
+		UseContextTimeout: true,
+	})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
 func (g Adapter) AddFiles(repoPath string, all bool, files ...string) error {
 	err := gitea.AddChanges(repoPath, all, files...)
 	if err != nil {
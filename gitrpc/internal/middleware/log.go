@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/gitrpc/rpc"
+	"github.com/harness/gitness/loglevel"
 
 	"github.com/rs/xid"
 	"github.com/rs/zerolog/log"
@@ -121,8 +122,8 @@ func injectLogging(ctx context.Context, fullMethod string) context.Context {
 		logCtx = logCtx.Str("grpc.peer", p.Addr.String())
 	}
 
-	// inject logger in context
-	logger := logCtx.Logger()
+	// inject logger (using the level of the gitrpc subsystem) in context
+	logger := loglevel.Logger(logCtx.Logger(), loglevel.SubsystemGitRPC, "")
 	return logger.WithContext(ctx)
 }
 
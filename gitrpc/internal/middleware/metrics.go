@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "gitrpc",
+		Name:      "requests_total",
+		Help:      "Total number of git operations handled by the gitrpc server.",
+	}, []string{"service", "method", "code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gitness",
+		Subsystem: "gitrpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the git operations handled by the gitrpc server.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{"service", "method"})
+)
+
+// MetricsInterceptor records the number and the duration of the git operations.
+type MetricsInterceptor struct {
+}
+
+func NewMetricsInterceptor() MetricsInterceptor {
+	return MetricsInterceptor{}
+}
+
+func (i MetricsInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		value, err := handler(ctx, req)
+
+		observe(info.FullMethod, start, err)
+
+		return value, err
+	}
+}
+
+func (i MetricsInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observe(fullMethod string, start time.Time, err error) {
+	// split fullMethod into service and method (expected format: "/package.service/method")
+	service, method := "", fullMethod
+	if s, m, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/"); ok {
+		service, method = s, m
+	}
+
+	requestDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	requestsTotal.WithLabelValues(service, method, status.Code(err).String()).Inc()
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/harness/gitness/gitrpc/internal/streamio"
+	"github.com/harness/gitness/gitrpc/internal/types"
+	"github.com/harness/gitness/gitrpc/rpc"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CreateBundle streams a git bundle containing all references of the repository.
+// The stream is empty if the repository doesn't contain any references.
+func (s RepositoryService) CreateBundle(
+	request *rpc.CreateBundleRequest,
+	stream rpc.RepositoryService_CreateBundleServer,
+) error {
+	base := request.GetBase()
+	if base == nil {
+		return types.ErrBaseCannotBeEmpty
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, base.GetRepoUid())
+	if _, err := os.Stat(repoPath); errors.Is(err, os.ErrNotExist) {
+		return ErrNotFoundf("repository %s not found", base.GetRepoUid())
+	}
+
+	sw := streamio.NewWriter(func(p []byte) error {
+		return stream.Send(&rpc.CreateBundleResponse{Data: p})
+	})
+
+	if err := s.adapter.CreateBundle(stream.Context(), repoPath, sw); err != nil {
+		return processGitErrorf(err, "failed to create bundle")
+	}
+
+	return nil
+}
+
+// RestoreBundle fetches all references of the streamed git bundle into the (existing) repository.
+// References of the repository that aren't part of the bundle are removed.
+func (s RepositoryService) RestoreBundle(stream rpc.RepositoryService_RestoreBundleServer) error {
+	ctx := stream.Context()
+
+	request, err := stream.Recv()
+	if err != nil {
+		return ErrInternalf("cannot receive restore bundle request: %v", err)
+	}
+
+	base := request.GetBase()
+	if base == nil {
+		return types.ErrBaseCannotBeEmpty
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, base.GetRepoUid())
+	if _, err = os.Stat(repoPath); errors.Is(err, os.ErrNotExist) {
+		return ErrNotFoundf("repository %s not found", base.GetRepoUid())
+	}
+
+	bundle, err := os.CreateTemp(s.tmpDir, "*-"+base.GetRepoUid()+".bundle")
+	if err != nil {
+		return ErrInternalf("failed to create bundle file: %v", err)
+	}
+	defer func() {
+		_ = bundle.Close()
+		if errRm := os.Remove(bundle.Name()); errRm != nil {
+			log.Ctx(ctx).Warn().Err(errRm).Msgf("failed to remove bundle file '%s'", bundle.Name())
+		}
+	}()
+
+	data := request.GetData()
+	reader := streamio.NewReader(func() ([]byte, error) {
+		if data != nil {
+			p := data
+			data = nil
+			return p, nil
+		}
+		req, errRecv := stream.Recv()
+		return req.GetData(), errRecv
+	})
+
+	size, err := io.Copy(bundle, reader)
+	if err != nil {
+		return ErrInternalf("failed to receive bundle: %v", err)
+	}
+	if err = bundle.Close(); err != nil {
+		return ErrInternalf("failed to write bundle file: %v", err)
+	}
+
+	// an empty bundle is the backup of a repository without any references.
+	if size > 0 {
+		if err = s.adapter.Sync(ctx, repoPath, bundle.Name(), ""); err != nil {
+			return processGitErrorf(err, "failed to fetch references from bundle")
+		}
+	}
+
+	log.Ctx(ctx).Info().Msgf("restored %d bytes bundle into repository %s", size, base.GetRepoUid())
+
+	if err = stream.SendAndClose(&rpc.RestoreBundleResponse{}); err != nil {
+		return ErrInternalf("cannot send completion response: %v", err)
+	}
+
+	return nil
+}
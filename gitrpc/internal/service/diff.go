@@ -62,7 +62,8 @@ func (s DiffService) rawDiff(ctx context.Context, request *rpc.DiffRequest, w io
 	base := request.GetBase()
 	repoPath := getFullPathForRepo(s.reposRoot, base.GetRepoUid())
 
-	err = s.adapter.RawDiff(ctx, repoPath, request.GetBaseRef(), request.GetHeadRef(), request.MergeBase, w)
+	err = s.adapter.RawDiff(ctx, repoPath, request.GetBaseRef(), request.GetHeadRef(), request.MergeBase,
+		mapDiffOptions(request.GetOptions()), w, request.GetPaths()...)
 	if err != nil {
 		return processGitErrorf(err, "failed to fetch diff "+
 			"between %s and %s", request.GetBaseRef(), request.GetHeadRef())
@@ -83,7 +84,7 @@ func (s DiffService) CommitDiff(request *rpc.CommitDiffRequest, stream rpc.DiffS
 		return stream.Send(&rpc.CommitDiffResponse{Data: p})
 	})
 
-	return s.adapter.CommitDiff(stream.Context(), repoPath, request.Sha, sw)
+	return s.adapter.CommitDiff(stream.Context(), repoPath, request.Sha, mapDiffOptions(request.GetOptions()), sw)
 }
 
 func validateDiffRequest(in *rpc.DiffRequest) error {
@@ -203,15 +204,17 @@ func (s DiffService) Diff(request *rpc.DiffRequest, stream rpc.DiffService_DiffS
 	defer pr.Close()
 
 	parser := diff.Parser{
-		Reader: bufio.NewReader(pr),
+		Reader:       bufio.NewReader(pr),
+		MaxFileLines: int(request.GetMaxFileLines()),
+	}
+	// explicitly requested files (e.g. lazy loading of collapsed files) aren't limited by count.
+	if len(request.GetPaths()) == 0 {
+		parser.MaxFiles = int(request.GetMaxFiles())
 	}
 
 	go func() {
-		defer pw.Close()
-		err := s.rawDiff(stream.Context(), request, pw)
-		if err != nil {
-			return
-		}
+		// forward any error to the parser, otherwise a failed diff would look like an empty one.
+		_ = pw.CloseWithError(s.rawDiff(stream.Context(), request, pw))
 	}()
 
 	return parser.Parse(func(f *diff.File) error {
@@ -246,15 +249,16 @@ func streamDiffFile(f *diff.File, includePatch bool, stream rpc.DiffService_Diff
 	}
 
 	err := stream.Send(&rpc.DiffResponse{
-		Path:      f.Path,
-		OldPath:   f.OldPath,
-		Sha:       f.SHA,
-		OldSha:    f.OldSHA,
-		Status:    status,
-		Additions: int32(f.NumAdditions()),
-		Deletions: int32(f.NumDeletions()),
-		Changes:   int32(f.NumChanges()),
-		Patch:     patch.Bytes(),
+		Path:        f.Path,
+		OldPath:     f.OldPath,
+		Sha:         f.SHA,
+		OldSha:      f.OldSHA,
+		Status:      status,
+		Additions:   int32(f.NumAdditions()),
+		Deletions:   int32(f.NumDeletions()),
+		Changes:     int32(f.NumChanges()),
+		Patch:       patch.Bytes(),
+		IsCollapsed: includePatch && f.IsCollapsed,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to send diff response on stream: %w", err)
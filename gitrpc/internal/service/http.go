@@ -39,13 +39,21 @@ type SmartHTTPService struct {
 	rpc.UnimplementedSmartHTTPServiceServer
 	adapter   GitAdapter
 	reposRoot string
+
+	// commitGraphWriter updates the commit-graph after pushes (nil if disabled).
+	commitGraphWriter *commitGraphWriter
 }
 
-func NewHTTPService(adapter GitAdapter, reposRoot string) (*SmartHTTPService, error) {
-	return &SmartHTTPService{
+func NewHTTPService(adapter GitAdapter, reposRoot string, commitGraphAfterPush bool) (*SmartHTTPService, error) {
+	s := &SmartHTTPService{
 		adapter:   adapter,
 		reposRoot: reposRoot,
-	}, nil
+	}
+	if commitGraphAfterPush {
+		s.commitGraphWriter = newCommitGraphWriter(adapter)
+	}
+
+	return s, nil
 }
 
 func (s *SmartHTTPService) InfoRefs(
@@ -133,7 +141,17 @@ func (s *SmartHTTPService) ServicePack(stream rpc.SmartHTTPService_ServicePackSe
 		return stream.Send(&rpc.ServicePackResponse{Data: p})
 	})
 
-	return serviceRPC(ctx, stdin, stdout, request, repoPath)
+	err = serviceRPC(ctx, stdin, stdout, request, repoPath)
+	if err != nil {
+		return err
+	}
+
+	// keep the commit-graph up to date with the pushed commits.
+	if request.GetService() == rpc.ServiceReceivePack && s.commitGraphWriter != nil {
+		s.commitGraphWriter.Update(ctx, repoPath)
+	}
+
+	return nil
 }
 
 func serviceRPC(ctx context.Context, stdin io.Reader, stdout io.Writer,
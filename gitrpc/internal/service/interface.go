@@ -24,15 +24,20 @@ import (
 
 // GitAdapter for accessing git commands from gitea.
 type GitAdapter interface {
-	InitRepository(ctx context.Context, path string, bare bool) error
+	InitRepository(ctx context.Context, path string, bare bool, objectFormat enum.ObjectFormat) error
+	GetObjectFormat(ctx context.Context, repoPath string) (enum.ObjectFormat, error)
 	Config(ctx context.Context, repoPath, key, value string) error
+	ConfigureMaintenance(ctx context.Context, repoPath string) error
+	WriteCommitGraph(ctx context.Context, repoPath string) error
+	RepackWithBitmaps(ctx context.Context, repoPath string) error
 	SetDefaultBranch(ctx context.Context, repoPath string, defaultBranch string, allowEmpty bool) error
 	GetDefaultBranch(ctx context.Context, repoPath string) (string, error)
-	GetRemoteDefaultBranch(ctx context.Context, remoteURL string) (string, error)
+	GetRemoteDefaultBranch(ctx context.Context, remoteURL string, proxyURL string) (string, error)
 	Clone(ctx context.Context, from, to string, opts types.CloneRepoOptions) error
 	AddFiles(repoPath string, all bool, files ...string) error
 	Commit(ctx context.Context, repoPath string, opts types.CommitChangesOptions) error
 	Push(ctx context.Context, repoPath string, opts types.PushOptions) error
+	FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs ...string) error
 	ReadTree(ctx context.Context, repoPath, ref string, w io.Writer, args ...string) error
 	GetTreeNode(ctx context.Context, repoPath string, ref string, treePath string) (*types.TreeNode, error)
 	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string) ([]types.TreeNode, error)
@@ -63,7 +68,8 @@ type GitAdapter interface {
 		tmpBasePath string, mergeMsg string, env []string, identity *types.Identity) error
 	GetMergeBase(ctx context.Context, repoPath, remote, base, head string) (string, string, error)
 	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
-	Sync(ctx context.Context, repoPath string, source string) error
+	Sync(ctx context.Context, repoPath string, source string, proxyURL string) error
+	CreateBundle(ctx context.Context, repoPath string, w io.Writer) error
 
 	//
 	// Diff operations
@@ -79,11 +85,14 @@ type GitAdapter interface {
 		base,
 		head string,
 		mergeBase bool,
-		w io.Writer) error
+		opts types.DiffOptions,
+		w io.Writer,
+		paths ...string) error
 
 	CommitDiff(ctx context.Context,
 		repoPath,
 		sha string,
+		opts types.DiffOptions,
 		w io.Writer) error
 
 	DiffShortStat(ctx context.Context,
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const commitGraphWriteTimeout = 10 * time.Minute
+
+// commitGraphWriter updates the commit-graph of repositories in the background.
+// At most one write is running per repository - updates requested during a write
+// are merged into a single follow-up write.
+type commitGraphWriter struct {
+	adapter GitAdapter
+
+	mx      sync.Mutex
+	pending map[string]bool // repoPath -> another write was requested while running
+}
+
+func newCommitGraphWriter(adapter GitAdapter) *commitGraphWriter {
+	return &commitGraphWriter{
+		adapter: adapter,
+		pending: make(map[string]bool),
+	}
+}
+
+// Update triggers an asynchronous update of the commit-graph of the repository.
+func (w *commitGraphWriter) Update(ctx context.Context, repoPath string) {
+	w.mx.Lock()
+	if _, running := w.pending[repoPath]; running {
+		w.pending[repoPath] = true
+		w.mx.Unlock()
+		return
+	}
+	w.pending[repoPath] = false
+	w.mx.Unlock()
+
+	// the write has to outlive the request it was triggered by.
+	logger := log.Ctx(ctx).With().Str("repo_path", repoPath).Logger()
+	ctx = logger.WithContext(context.Background())
+
+	go func() {
+		for {
+			w.write(ctx, repoPath)
+
+			w.mx.Lock()
+			if !w.pending[repoPath] {
+				delete(w.pending, repoPath)
+				w.mx.Unlock()
+				return
+			}
+			w.pending[repoPath] = false
+			w.mx.Unlock()
+		}
+	}()
+}
+
+func (w *commitGraphWriter) write(ctx context.Context, repoPath string) {
+	ctx, cancel := context.WithTimeout(ctx, commitGraphWriteTimeout)
+	defer cancel()
+
+	if err := w.adapter.WriteCommitGraph(ctx, repoPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update commit-graph after push")
+	}
+}
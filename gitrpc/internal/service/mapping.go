@@ -39,6 +39,27 @@ func mapSortOrder(s rpc.SortOrder) types.SortOrder {
 	}
 }
 
+func mapDiffOptions(o *rpc.DiffOptions) types.DiffOptions {
+	opts := types.DiffOptions{
+		IgnoreWhitespace: o.GetIgnoreWhitespace(),
+		IgnoreBlankLines: o.GetIgnoreBlankLines(),
+	}
+
+	switch o.GetAlgorithm() {
+	case rpc.DiffAlgorithm_DiffAlgorithmMyers:
+		opts.Algorithm = "myers"
+	case rpc.DiffAlgorithm_DiffAlgorithmMinimal:
+		opts.Algorithm = "minimal"
+	case rpc.DiffAlgorithm_DiffAlgorithmPatience:
+		opts.Algorithm = "patience"
+	case rpc.DiffAlgorithm_DiffAlgorithmHistogram:
+		opts.Algorithm = "histogram"
+	case rpc.DiffAlgorithm_DiffAlgorithmDefault:
+	}
+
+	return opts
+}
+
 func mapListCommitTagsSortOption(s rpc.ListCommitTagsRequest_SortOption) types.GitReferenceField {
 	switch s {
 	case rpc.ListCommitTagsRequest_Date:
@@ -103,12 +124,21 @@ func mapGitCommit(gitCommit *types.Commit) (*rpc.Commit, error) {
 		return nil, status.Errorf(codes.Internal, "git commit is nil")
 	}
 
+	var signature *rpc.CommitSignature
+	if gitCommit.Signature != nil {
+		signature = &rpc.CommitSignature{
+			Signature: gitCommit.Signature.Signature,
+			Payload:   gitCommit.Signature.Payload,
+		}
+	}
+
 	return &rpc.Commit{
 		Sha:       gitCommit.SHA,
 		Title:     gitCommit.Title,
 		Message:   gitCommit.Message,
 		Author:    mapGitSignature(gitCommit.Author),
 		Committer: mapGitSignature(gitCommit.Committer),
+		Signature: signature,
 	}, nil
 }
 
@@ -70,6 +70,9 @@ func (s MergeService) Merge(
 		BaseBranch:   request.BaseBranch,
 		HeadBranch:   request.HeadBranch,
 	}
+	if request.HeadRepoUid != "" {
+		pr.HeadRepoPath = getFullPathForRepo(s.reposRoot, request.HeadRepoUid)
+	}
 
 	// Clone base repo.
 	tmpRepo, err := s.adapter.CreateTemporaryRepoForPR(ctx, s.reposTempDir, pr, baseBranch, trackingBranch)
@@ -213,7 +213,12 @@ func (s *CommitFilesService) prepareTree(ctx context.Context, shared *SharedRepo
 func (s *CommitFilesService) prepareTreeEmptyRepo(ctx context.Context, shared *SharedRepo,
 	actions []fileAction) error {
 	// init a new repo (full clone would cause risk that by time of push someone wrote to the remote repo!)
-	err := shared.Init(ctx)
+	objectFormat, err := s.adapter.GetObjectFormat(ctx, shared.remoteRepo.Path)
+	if err != nil {
+		return processGitErrorf(err, "failed to get object format of repository")
+	}
+
+	err = shared.Init(ctx, objectFormat)
 	if err != nil {
 		return processGitErrorf(err, "failed to init shared tmp repository")
 	}
@@ -19,12 +19,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/gitrpc/enum"
 	"github.com/harness/gitness/gitrpc/hash"
 	"github.com/harness/gitness/gitrpc/internal/types"
 	"github.com/harness/gitness/gitrpc/rpc"
@@ -137,6 +140,7 @@ func (s RepositoryService) CreateRepository(stream rpc.RepositoryService_CreateR
 		stream.Context(),
 		base,
 		header.GetDefaultBranch(),
+		enum.ObjectFormatFromRPC(header.GetObjectFormat()),
 		nextFSElement,
 		committer,
 		committerDate,
@@ -161,6 +165,7 @@ func (s RepositoryService) createRepositoryInternal(
 	ctx context.Context,
 	base *rpc.WriteRequest,
 	defaultBranch string,
+	objectFormat enum.ObjectFormat,
 	nextFSElement func() (*rpc.FileUpload, error),
 	committer *rpc.Identity,
 	committerDate time.Time,
@@ -176,7 +181,7 @@ func (s RepositoryService) createRepositoryInternal(
 	// create repository in repos folder
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	err := s.adapter.InitRepository(ctx, repoPath, true)
+	err := s.adapter.InitRepository(ctx, repoPath, true, objectFormat)
 	// delete repo dir on error
 	defer func() {
 		if err != nil {
@@ -197,6 +202,12 @@ func (s RepositoryService) createRepositoryInternal(
 		return processGitErrorf(err, "error updating default branch for repo '%s'", base.GetRepoUid())
 	}
 
+	// enable commit-graph and bitmap usage (the files themselves are written by the repository maintenance)
+	err = s.adapter.ConfigureMaintenance(ctx, repoPath)
+	if err != nil {
+		return processGitErrorf(err, "error configuring maintenance for repo '%s'", base.GetRepoUid())
+	}
+
 	// only execute file creation logic if files are provided
 	//nolint: nestif
 	if nextFSElement != nil {
@@ -213,9 +224,9 @@ func (s RepositoryService) createRepositoryInternal(
 			}
 		}(tempDir)
 
-		// Clone repository to temp dir
-		if err = s.adapter.Clone(ctx, repoPath, tempDir, types.CloneRepoOptions{}); err != nil {
-			return processGitErrorf(err, "failed to clone repo")
+		// init temp repo with the same object format (cloning an empty repo always falls back to sha1)
+		if err = s.adapter.InitRepository(ctx, tempDir, false, objectFormat); err != nil {
+			return processGitErrorf(err, "failed to init temp repo")
 		}
 
 		// logic for receiving files
@@ -241,10 +252,9 @@ func (s RepositoryService) createRepositoryInternal(
 			if author == nil {
 				author = committer
 			}
-			// NOTE: This creates the branch in origin repo (as it doesn't exist as of now)
-			// TODO: this should at least be a constant and not hardcoded?
+			// NOTE: This creates the branch in the new repo (as it doesn't exist as of now)
 			if err = s.addFilesAndPush(ctx, tempDir, filePaths, "HEAD:"+defaultBranch, nil, author, authorDate,
-				committer, committerDate, "origin", "initial commit"); err != nil {
+				committer, committerDate, repoPath, "initial commit"); err != nil {
 				return err
 			}
 		}
@@ -337,6 +347,7 @@ func (s RepositoryService) SyncRepository(
 			ctx,
 			base,
 			syncDefaultBranch,
+			enum.ObjectFormatSHA1,
 			nil,
 			nil,
 			time.Time{},
@@ -348,13 +359,13 @@ func (s RepositoryService) SyncRepository(
 	}
 
 	// sync repo content
-	err = s.adapter.Sync(ctx, repoPath, request.GetSource())
+	err = s.adapter.Sync(ctx, repoPath, request.GetSource(), request.GetProxyUrl())
 	if err != nil {
 		return nil, processGitErrorf(err, "failed to sync git repo")
 	}
 
 	// get remote default branch
-	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, request.GetSource())
+	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, request.GetSource(), request.GetProxyUrl())
 	if errors.Is(err, types.ErrNoDefaultBranch) {
 		return &rpc.SyncRepositoryResponse{
 			DefaultBranch: "",
@@ -465,3 +476,113 @@ func (s RepositoryService) HashRepository(
 		Hash: res,
 	}, nil
 }
+
+// GetRepositorySize returns the disk space used by the repository in KiB.
+func (s RepositoryService) GetRepositorySize(
+	ctx context.Context,
+	request *rpc.GetRepositorySizeRequest,
+) (*rpc.GetRepositorySizeResponse, error) {
+	base := request.GetBase()
+	if base == nil {
+		return nil, types.ErrBaseCannotBeEmpty
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, base.RepoUid)
+
+	var size int64
+	err := filepath.WalkDir(repoPath, func(filePath string, d fs.DirEntry, err error) error {
+		// files and folders can get removed while walking the repo (e.g. by a concurrent gc)
+		if errors.Is(err, fs.ErrNotExist) && filePath != repoPath {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFoundf("repository %s not found", base.RepoUid)
+	}
+	if err != nil {
+		return nil, ErrInternalf("failed to compute repository size: %v", err)
+	}
+
+	const kib = 1024
+	return &rpc.GetRepositorySizeResponse{
+		Size: (size + kib - 1) / kib,
+	}, nil
+}
+
+// UpdateDefaultBranch points HEAD of the repository to the provided branch, which has to exist.
+func (s RepositoryService) UpdateDefaultBranch(
+	ctx context.Context,
+	request *rpc.UpdateDefaultBranchRequest,
+) (*rpc.UpdateDefaultBranchResponse, error) {
+	base := request.GetBase()
+	if base == nil {
+		return nil, types.ErrBaseCannotBeEmpty
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, base.GetRepoUid())
+
+	err := s.adapter.SetDefaultBranch(ctx, repoPath, request.GetDefaultBranch(), false)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to update default branch of repo")
+	}
+
+	return &rpc.UpdateDefaultBranchResponse{}, nil
+}
+
+// FetchCommit makes the commit the provided ref of the source repository points to (including its history)
+// available in the repository without creating any references for it.
+func (s RepositoryService) FetchCommit(
+	ctx context.Context,
+	request *rpc.FetchCommitRequest,
+) (*rpc.FetchCommitResponse, error) {
+	base := request.GetBase()
+	if base == nil {
+		return nil, types.ErrBaseCannotBeEmpty
+	}
+	if request.GetSourceRepoUid() == "" {
+		return nil, ErrInvalidArgumentf("source repository has to be provided")
+	}
+	if request.GetRef() == "" {
+		return nil, ErrInvalidArgumentf("ref has to be provided")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, base.GetRepoUid())
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, request.GetSourceRepoUid())
+
+	commit, err := s.adapter.GetCommit(ctx, sourceRepoPath, request.GetRef())
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to get commit from source repo")
+	}
+
+	if request.GetSourceRepoUid() != base.GetRepoUid() {
+		err = s.adapter.FetchObjects(ctx, repoPath, sourceRepoPath, commit.SHA)
+		if err != nil {
+			return nil, processGitErrorf(err, "failed to fetch commit from source repo")
+		}
+	}
+
+	return &rpc.FetchCommitResponse{
+		Sha: commit.SHA,
+	}, nil
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harness/gitness/gitrpc/enum"
 	"github.com/harness/gitness/gitrpc/internal/gitea"
 	"github.com/harness/gitness/gitrpc/internal/middleware"
 	"github.com/harness/gitness/gitrpc/internal/tempdir"
@@ -93,10 +94,14 @@ func (r *SharedRepo) Clone(ctx context.Context, branchName string) error {
 	return nil
 }
 
-// Init the repository.
-func (r *SharedRepo) Init(ctx context.Context) error {
-	if err := git.InitRepository(ctx, r.tmpPath, false); err != nil {
-		return err
+// Init the repository using the provided object format.
+func (r *SharedRepo) Init(ctx context.Context, objectFormat enum.ObjectFormat) error {
+	if objectFormat == "" {
+		objectFormat = enum.ObjectFormatSHA1
+	}
+	if _, _, err := git.NewCommand(ctx, "init", "--object-format="+string(objectFormat)).
+		RunStdString(&git.RunOpts{Dir: r.tmpPath}); err != nil {
+		return fmt.Errorf("Init: %w", err)
 	}
 	gitRepo, err := git.OpenRepository(ctx, r.tmpPath)
 	if err != nil {
@@ -150,9 +155,15 @@ func (r *SharedRepo) RemoveFilesFromIndex(ctx context.Context, filenames ...stri
 	stdOut := new(bytes.Buffer)
 	stdErr := new(bytes.Buffer)
 	stdIn := new(bytes.Buffer)
+
+	nilSHA, err := r.nilSHA(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, file := range filenames {
 		if file != "" {
-			stdIn.WriteString("0 0000000000000000000000000000000000000000\t")
+			stdIn.WriteString("0 " + nilSHA + "\t")
 			stdIn.WriteString(file)
 			stdIn.WriteByte('\000')
 		}
@@ -171,6 +182,20 @@ func (r *SharedRepo) RemoveFilesFromIndex(ctx context.Context, filenames ...stri
 	return nil
 }
 
+// nilSHA returns the zero object id matching the object format of the shared repository.
+func (r *SharedRepo) nilSHA(ctx context.Context) (string, error) {
+	stdout, _, err := git.NewCommand(ctx, "rev-parse", "--show-object-format").
+		RunStdString(&git.RunOpts{Dir: r.tmpPath})
+	if err != nil {
+		return "", fmt.Errorf("failed to get object format: %w", err)
+	}
+
+	if enum.ObjectFormat(strings.TrimSpace(stdout)) == enum.ObjectFormatSHA256 {
+		return strings.Repeat("0", 64), nil
+	}
+	return strings.Repeat("0", 40), nil
+}
+
 // WriteGitObject writes the provided content to the object db and returns its hash.
 func (r *SharedRepo) WriteGitObject(ctx context.Context, content io.Reader) (string, error) {
 	stdOut := new(bytes.Buffer)
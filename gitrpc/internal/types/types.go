@@ -142,6 +142,13 @@ type Commit struct {
 	Message   string
 	Author    Signature
 	Committer Signature
+	Signature *CommitSignature
+}
+
+// CommitSignature is the cryptographic signature of a commit (gpgsig header) and the payload it signs.
+type CommitSignature struct {
+	Signature string
+	Payload   string
 }
 
 type Branch struct {
@@ -275,6 +282,14 @@ type PullRequest struct {
 	HeadBranch string
 }
 
+// DiffOptions control how git compares files.
+type DiffOptions struct {
+	// Algorithm is the value of the --diff-algorithm flag, the default of git is used if empty.
+	Algorithm        string
+	IgnoreWhitespace bool
+	IgnoreBlankLines bool
+}
+
 type DiffShortStat struct {
 	Files     int
 	Additions int
@@ -36,6 +36,31 @@ func mapToRPCSortOrder(o SortOrder) rpc.SortOrder {
 	}
 }
 
+func mapToRPCDiffOptions(o DiffOptions) *rpc.DiffOptions {
+	opts := &rpc.DiffOptions{
+		IgnoreWhitespace: o.IgnoreWhitespace,
+		IgnoreBlankLines: o.IgnoreBlankLines,
+	}
+
+	switch o.Algorithm {
+	case DiffAlgorithmMyers:
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmMyers
+	case DiffAlgorithmMinimal:
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmMinimal
+	case DiffAlgorithmPatience:
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmPatience
+	case DiffAlgorithmHistogram:
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmHistogram
+	case DiffAlgorithmDefault:
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmDefault
+	default:
+		// no need to error out - just use the default algorithm
+		opts.Algorithm = rpc.DiffAlgorithm_DiffAlgorithmDefault
+	}
+
+	return opts
+}
+
 func mapToRPCListBranchesSortOption(o BranchSortOption) rpc.ListBranchesRequest_SortOption {
 	switch o {
 	case BranchSortOptionName:
@@ -147,12 +172,21 @@ func mapRPCCommit(c *rpc.Commit) (*Commit, error) {
 		return nil, fmt.Errorf("failed to map rpc committer: %w", err)
 	}
 
+	var signature *CommitSignature
+	if c.GetSignature() != nil {
+		signature = &CommitSignature{
+			Signature: c.GetSignature().GetSignature(),
+			Payload:   c.GetSignature().GetPayload(),
+		}
+	}
+
 	return &Commit{
 		SHA:       c.GetSha(),
 		Title:     c.GetTitle(),
 		Message:   c.GetMessage(),
 		Author:    *author,
 		Committer: *comitter,
+		Signature: signature,
 	}, nil
 }
 
@@ -27,7 +27,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// The head repo has to be stored on the same gitrpc server as the base repo.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -106,6 +106,7 @@ func (c *Client) Merge(ctx context.Context, params *MergeParams) (MergeOutput, e
 		Force:            params.Force,
 		DeleteHeadBranch: params.DeleteHeadBranch,
 		Method:           params.Method.ToRPC(),
+		HeadRepoUid:      params.HeadRepoUID,
 	})
 	if err != nil {
 		return MergeOutput{}, processRPCErrorf(err, "merging failed")
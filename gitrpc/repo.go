@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/gitrpc/enum"
 	"github.com/harness/gitness/gitrpc/hash"
 	"github.com/harness/gitness/gitrpc/rpc"
 
@@ -44,6 +45,10 @@ type CreateRepositoryParams struct {
 	DefaultBranch string
 	Files         []File
 
+	// ObjectFormat is the hash algorithm used for the object ids of the repository
+	// (optional, default: sha1)
+	ObjectFormat enum.ObjectFormat
+
 	// Committer overwrites the git committer used for committing the files
 	// (optional, default: actor)
 	Committer *Identity
@@ -70,6 +75,8 @@ type SyncRepositoryParams struct {
 	WriteParams
 	Source            string
 	CreateIfNotExists bool
+	// ProxyURL is the url of the proxy used to fetch from the source (optional).
+	ProxyURL string
 }
 
 type SyncRepositoryOutput struct {
@@ -86,6 +93,34 @@ type HashRepositoryOutput struct {
 	Hash []byte
 }
 
+type GetRepositorySizeParams struct {
+	ReadParams
+}
+
+type GetRepositorySizeOutput struct {
+	// Size is the disk space used by the repository in KiB.
+	Size int64
+}
+
+type UpdateDefaultBranchParams struct {
+	WriteParams
+	// DefaultBranch is the name of the (existing) branch HEAD is pointed to.
+	DefaultBranch string
+}
+
+type FetchCommitParams struct {
+	ReadParams
+	// SourceRepoUID is the UID of the repository the commit is fetched from.
+	// It has to be stored on the same gitrpc server as the target repository.
+	SourceRepoUID string
+	// Ref is the reference (or commit sha) in the source repository that's resolved to the commit.
+	Ref string
+}
+
+type FetchCommitOutput struct {
+	SHA string
+}
+
 func (c *Client) CreateRepository(ctx context.Context,
 	params *CreateRepositoryParams) (*CreateRepositoryOutput, error) {
 	if params == nil {
@@ -125,6 +160,7 @@ func (c *Client) CreateRepository(ctx context.Context,
 				AuthorDate:    mapToRPCTimeOptional(params.AuthorDate),
 				Committer:     mapToRPCIdentityOptional(params.Committer),
 				CommitterDate: mapToRPCTimeOptional(params.CommitterDate),
+				ObjectFormat:  params.ObjectFormat.ToRPC(),
 			},
 		},
 	}
@@ -179,6 +215,7 @@ func (c *Client) SyncRepository(ctx context.Context, params *SyncRepositoryParam
 		Base:              mapToRPCWriteRequest(params.WriteParams),
 		Source:            params.Source,
 		CreateIfNotExists: params.CreateIfNotExists,
+		ProxyUrl:          params.ProxyURL,
 	})
 	if err != nil {
 		return nil, processRPCErrorf(err, "failed to sync repository on server to match provided source")
@@ -212,3 +249,60 @@ func (c *Client) HashRepository(ctx context.Context, params *HashRepositoryParam
 		Hash: resp.GetHash(),
 	}, nil
 }
+
+func (c *Client) GetRepositorySize(
+	ctx context.Context,
+	params *GetRepositorySizeParams,
+) (*GetRepositorySizeOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	resp, err := c.repoService.GetRepositorySize(ctx, &rpc.GetRepositorySizeRequest{
+		Base: mapToRPCReadRequest(params.ReadParams),
+	})
+	if err != nil {
+		return nil, processRPCErrorf(err, "failed to get repository size on server")
+	}
+
+	return &GetRepositorySizeOutput{
+		Size: resp.GetSize(),
+	}, nil
+}
+
+func (c *Client) UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+
+	_, err := c.repoService.UpdateDefaultBranch(ctx, &rpc.UpdateDefaultBranchRequest{
+		Base:          mapToRPCWriteRequest(params.WriteParams),
+		DefaultBranch: params.DefaultBranch,
+	})
+	if err != nil {
+		return processRPCErrorf(err, "failed to update default branch on server")
+	}
+
+	return nil
+}
+
+// FetchCommit makes the commit a ref of the source repository points to available in the target repository.
+// No references are created in the target repository.
+func (c *Client) FetchCommit(ctx context.Context, params *FetchCommitParams) (FetchCommitOutput, error) {
+	if params == nil {
+		return FetchCommitOutput{}, ErrNoParamsProvided
+	}
+
+	resp, err := c.repoService.FetchCommit(ctx, &rpc.FetchCommitRequest{
+		Base:          mapToRPCReadRequest(params.ReadParams),
+		SourceRepoUid: params.SourceRepoUID,
+		Ref:           params.Ref,
+	})
+	if err != nil {
+		return FetchCommitOutput{}, processRPCErrorf(err, "failed to fetch commit on server")
+	}
+
+	return FetchCommitOutput{
+		SHA: resp.GetSha(),
+	}, nil
+}
@@ -34,9 +34,14 @@ type RepositoryServiceClient interface {
 	DeleteRepository(ctx context.Context, in *DeleteRepositoryRequest, opts ...grpc.CallOption) (*DeleteRepositoryResponse, error)
 	SyncRepository(ctx context.Context, in *SyncRepositoryRequest, opts ...grpc.CallOption) (*SyncRepositoryResponse, error)
 	HashRepository(ctx context.Context, in *HashRepositoryRequest, opts ...grpc.CallOption) (*HashRepositoryResponse, error)
+	GetRepositorySize(ctx context.Context, in *GetRepositorySizeRequest, opts ...grpc.CallOption) (*GetRepositorySizeResponse, error)
+	UpdateDefaultBranch(ctx context.Context, in *UpdateDefaultBranchRequest, opts ...grpc.CallOption) (*UpdateDefaultBranchResponse, error)
 	MergeBase(ctx context.Context, in *MergeBaseRequest, opts ...grpc.CallOption) (*MergeBaseResponse, error)
 	MatchFiles(ctx context.Context, in *MatchFilesRequest, opts ...grpc.CallOption) (*MatchFilesResponse, error)
 	GeneratePipeline(ctx context.Context, in *GeneratePipelineRequest, opts ...grpc.CallOption) (*GeneratePipelineResponse, error)
+	FetchCommit(ctx context.Context, in *FetchCommitRequest, opts ...grpc.CallOption) (*FetchCommitResponse, error)
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (RepositoryService_CreateBundleClient, error)
+	RestoreBundle(ctx context.Context, opts ...grpc.CallOption) (RepositoryService_RestoreBundleClient, error)
 }
 
 type repositoryServiceClient struct {
@@ -249,6 +254,24 @@ func (c *repositoryServiceClient) HashRepository(ctx context.Context, in *HashRe
 	return out, nil
 }
 
+func (c *repositoryServiceClient) GetRepositorySize(ctx context.Context, in *GetRepositorySizeRequest, opts ...grpc.CallOption) (*GetRepositorySizeResponse, error) {
+	out := new(GetRepositorySizeResponse)
+	err := c.cc.Invoke(ctx, "/rpc.RepositoryService/GetRepositorySize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoryServiceClient) UpdateDefaultBranch(ctx context.Context, in *UpdateDefaultBranchRequest, opts ...grpc.CallOption) (*UpdateDefaultBranchResponse, error) {
+	out := new(UpdateDefaultBranchResponse)
+	err := c.cc.Invoke(ctx, "/rpc.RepositoryService/UpdateDefaultBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *repositoryServiceClient) MergeBase(ctx context.Context, in *MergeBaseRequest, opts ...grpc.CallOption) (*MergeBaseResponse, error) {
 	out := new(MergeBaseResponse)
 	err := c.cc.Invoke(ctx, "/rpc.RepositoryService/MergeBase", in, out, opts...)
@@ -276,6 +299,81 @@ func (c *repositoryServiceClient) GeneratePipeline(ctx context.Context, in *Gene
 	return out, nil
 }
 
+func (c *repositoryServiceClient) FetchCommit(ctx context.Context, in *FetchCommitRequest, opts ...grpc.CallOption) (*FetchCommitResponse, error) {
+	out := new(FetchCommitResponse)
+	err := c.cc.Invoke(ctx, "/rpc.RepositoryService/FetchCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoryServiceClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (RepositoryService_CreateBundleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RepositoryService_ServiceDesc.Streams[4], "/rpc.RepositoryService/CreateBundle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &repositoryServiceCreateBundleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RepositoryService_CreateBundleClient interface {
+	Recv() (*CreateBundleResponse, error)
+	grpc.ClientStream
+}
+
+type repositoryServiceCreateBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *repositoryServiceCreateBundleClient) Recv() (*CreateBundleResponse, error) {
+	m := new(CreateBundleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *repositoryServiceClient) RestoreBundle(ctx context.Context, opts ...grpc.CallOption) (RepositoryService_RestoreBundleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RepositoryService_ServiceDesc.Streams[5], "/rpc.RepositoryService/RestoreBundle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &repositoryServiceRestoreBundleClient{stream}
+	return x, nil
+}
+
+type RepositoryService_RestoreBundleClient interface {
+	Send(*RestoreBundleRequest) error
+	CloseAndRecv() (*RestoreBundleResponse, error)
+	grpc.ClientStream
+}
+
+type repositoryServiceRestoreBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *repositoryServiceRestoreBundleClient) Send(m *RestoreBundleRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *repositoryServiceRestoreBundleClient) CloseAndRecv() (*RestoreBundleResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreBundleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RepositoryServiceServer is the server API for RepositoryService service.
 // All implementations must embed UnimplementedRepositoryServiceServer
 // for forward compatibility
@@ -292,9 +390,14 @@ type RepositoryServiceServer interface {
 	DeleteRepository(context.Context, *DeleteRepositoryRequest) (*DeleteRepositoryResponse, error)
 	SyncRepository(context.Context, *SyncRepositoryRequest) (*SyncRepositoryResponse, error)
 	HashRepository(context.Context, *HashRepositoryRequest) (*HashRepositoryResponse, error)
+	GetRepositorySize(context.Context, *GetRepositorySizeRequest) (*GetRepositorySizeResponse, error)
+	UpdateDefaultBranch(context.Context, *UpdateDefaultBranchRequest) (*UpdateDefaultBranchResponse, error)
 	MergeBase(context.Context, *MergeBaseRequest) (*MergeBaseResponse, error)
 	MatchFiles(context.Context, *MatchFilesRequest) (*MatchFilesResponse, error)
 	GeneratePipeline(context.Context, *GeneratePipelineRequest) (*GeneratePipelineResponse, error)
+	FetchCommit(context.Context, *FetchCommitRequest) (*FetchCommitResponse, error)
+	CreateBundle(*CreateBundleRequest, RepositoryService_CreateBundleServer) error
+	RestoreBundle(RepositoryService_RestoreBundleServer) error
 	mustEmbedUnimplementedRepositoryServiceServer()
 }
 
@@ -338,6 +441,12 @@ func (UnimplementedRepositoryServiceServer) SyncRepository(context.Context, *Syn
 func (UnimplementedRepositoryServiceServer) HashRepository(context.Context, *HashRepositoryRequest) (*HashRepositoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HashRepository not implemented")
 }
+func (UnimplementedRepositoryServiceServer) GetRepositorySize(context.Context, *GetRepositorySizeRequest) (*GetRepositorySizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRepositorySize not implemented")
+}
+func (UnimplementedRepositoryServiceServer) UpdateDefaultBranch(context.Context, *UpdateDefaultBranchRequest) (*UpdateDefaultBranchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDefaultBranch not implemented")
+}
 func (UnimplementedRepositoryServiceServer) MergeBase(context.Context, *MergeBaseRequest) (*MergeBaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MergeBase not implemented")
 }
@@ -347,6 +456,16 @@ func (UnimplementedRepositoryServiceServer) MatchFiles(context.Context, *MatchFi
 func (UnimplementedRepositoryServiceServer) GeneratePipeline(context.Context, *GeneratePipelineRequest) (*GeneratePipelineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GeneratePipeline not implemented")
 }
+
+func (UnimplementedRepositoryServiceServer) FetchCommit(context.Context, *FetchCommitRequest) (*FetchCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchCommit not implemented")
+}
+func (UnimplementedRepositoryServiceServer) CreateBundle(*CreateBundleRequest, RepositoryService_CreateBundleServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateBundle not implemented")
+}
+func (UnimplementedRepositoryServiceServer) RestoreBundle(RepositoryService_RestoreBundleServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreBundle not implemented")
+}
 func (UnimplementedRepositoryServiceServer) mustEmbedUnimplementedRepositoryServiceServer() {}
 
 // UnsafeRepositoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -593,6 +712,42 @@ func _RepositoryService_HashRepository_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoryService_GetRepositorySize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRepositorySizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).GetRepositorySize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.RepositoryService/GetRepositorySize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).GetRepositorySize(ctx, req.(*GetRepositorySizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoryService_UpdateDefaultBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDefaultBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).UpdateDefaultBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.RepositoryService/UpdateDefaultBranch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).UpdateDefaultBranch(ctx, req.(*UpdateDefaultBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RepositoryService_MergeBase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MergeBaseRequest)
 	if err := dec(in); err != nil {
@@ -647,6 +802,71 @@ func _RepositoryService_GeneratePipeline_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoryService_FetchCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoryServiceServer).FetchCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.RepositoryService/FetchCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoryServiceServer).FetchCommit(ctx, req.(*FetchCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoryService_CreateBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RepositoryServiceServer).CreateBundle(m, &repositoryServiceCreateBundleServer{stream})
+}
+
+type RepositoryService_CreateBundleServer interface {
+	Send(*CreateBundleResponse) error
+	grpc.ServerStream
+}
+
+type repositoryServiceCreateBundleServer struct {
+	grpc.ServerStream
+}
+
+func (x *repositoryServiceCreateBundleServer) Send(m *CreateBundleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RepositoryService_RestoreBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RepositoryServiceServer).RestoreBundle(&repositoryServiceRestoreBundleServer{stream})
+}
+
+type RepositoryService_RestoreBundleServer interface {
+	SendAndClose(*RestoreBundleResponse) error
+	Recv() (*RestoreBundleRequest, error)
+	grpc.ServerStream
+}
+
+type repositoryServiceRestoreBundleServer struct {
+	grpc.ServerStream
+}
+
+func (x *repositoryServiceRestoreBundleServer) SendAndClose(m *RestoreBundleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *repositoryServiceRestoreBundleServer) Recv() (*RestoreBundleRequest, error) {
+	m := new(RestoreBundleRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RepositoryService_ServiceDesc is the grpc.ServiceDesc for RepositoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -686,6 +906,14 @@ var RepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HashRepository",
 			Handler:    _RepositoryService_HashRepository_Handler,
 		},
+		{
+			MethodName: "GetRepositorySize",
+			Handler:    _RepositoryService_GetRepositorySize_Handler,
+		},
+		{
+			MethodName: "UpdateDefaultBranch",
+			Handler:    _RepositoryService_UpdateDefaultBranch_Handler,
+		},
 		{
 			MethodName: "MergeBase",
 			Handler:    _RepositoryService_MergeBase_Handler,
@@ -698,6 +926,10 @@ var RepositoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GeneratePipeline",
 			Handler:    _RepositoryService_GeneratePipeline_Handler,
 		},
+		{
+			MethodName: "FetchCommit",
+			Handler:    _RepositoryService_FetchCommit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -720,6 +952,16 @@ var RepositoryService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RepositoryService_ListCommits_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CreateBundle",
+			Handler:       _RepositoryService_CreateBundle_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreBundle",
+			Handler:       _RepositoryService_RestoreBundle_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "repo.proto",
 }
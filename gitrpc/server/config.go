@@ -16,9 +16,16 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/robfig/cron/v3"
 )
 
+// cronParser parses cron specs the same way the gitrpc cron manager does (with seconds).
+var cronParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 const (
 	ModeInMemory = "inmemory"
 	ModeRedis    = "redis"
@@ -51,6 +58,16 @@ type Config struct {
 		DurationSeconds int `envconfig:"GITRPC_LAST_COMMIT_CACHE_SECONDS" default:"43200"`
 	}
 
+	// Maintenance holds configuration options for the maintenance of commit-graph files and reachability bitmaps.
+	Maintenance struct {
+		// CommitGraphAfterPush determines whether the commit-graph of a repo is updated after every push.
+		CommitGraphAfterPush bool `envconfig:"GITRPC_SERVER_MAINTENANCE_COMMIT_GRAPH_AFTER_PUSH" default:"true"`
+
+		// Schedule is the cron spec (with seconds) of the full maintenance of all repositories
+		// (repack with bitmaps and commit-graph). An empty value disables the scheduled maintenance.
+		Schedule string `envconfig:"GITRPC_SERVER_MAINTENANCE_SCHEDULE" default:"0 0 2 * * *"`
+	}
+
 	Redis struct {
 		Endpoint           string `envconfig:"GITRPC_REDIS_ENDPOINT"             default:"localhost:6379"`
 		MaxRetries         int    `envconfig:"GITRPC_REDIS_MAX_RETRIES"          default:"3"`
@@ -85,6 +102,12 @@ func (c *Config) Validate() error {
 	if m := c.LastCommitCache.Mode; m != "" && m != ModeInMemory && m != ModeRedis && m != ModeNone {
 		return errors.New("LastCommitCache.Mode has unsupported value")
 	}
+	if c.Maintenance.Schedule != "" {
+		if _, err := cronParser.Parse(c.Maintenance.Schedule); err != nil {
+			//nolint: stylecheck // that's the name of the field
+			return fmt.Errorf("Maintenance.Schedule is invalid: %w", err)
+		}
+	}
 
 	return nil
 }
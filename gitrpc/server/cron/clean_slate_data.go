@@ -20,6 +20,7 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/harness/gitness/gitrpc/internal/service"
 	"github.com/harness/gitness/gitrpc/server"
 
 	"github.com/rs/zerolog/log"
@@ -47,12 +48,24 @@ func cleanupRepoGraveyard(ctx context.Context, graveyardpath string) error {
 	return nil
 }
 
-func AddAllGitRPCCronJobs(cm *Manager, gitrpcconfig server.Config) error {
+func AddAllGitRPCCronJobs(cm *Manager, gitrpcconfig server.Config, adapter service.GitAdapter) error {
 	// periodic repository graveyard cleanup
 	graveyardpath := filepath.Join(gitrpcconfig.GitRoot, server.ReposGraveyardSubdirName)
 	err := cm.NewCronTask(Nightly, func(ctx context.Context) error { return cleanupRepoGraveyard(ctx, graveyardpath) })
 	if err != nil {
 		return err
 	}
+
+	// periodic repository maintenance (commit-graph and reachability bitmaps)
+	if gitrpcconfig.Maintenance.Schedule != "" {
+		reposRoot := filepath.Join(gitrpcconfig.GitRoot, server.ReposSubdirName)
+		err = cm.NewCronTask(gitrpcconfig.Maintenance.Schedule, func(ctx context.Context) error {
+			return maintainRepositories(ctx, adapter, reposRoot)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
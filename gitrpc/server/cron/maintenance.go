@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/gitrpc/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maintainRepositories repacks all repositories stored in reposRoot with reachability bitmaps
+// and rewrites their commit-graph.
+// NOTE: errors of a single repository are logged and don't stop the maintenance of the others.
+func maintainRepositories(ctx context.Context, adapter service.GitAdapter, reposRoot string) error {
+	logger := log.Ctx(ctx)
+
+	var maintained, failed int
+	err := filepath.WalkDir(reposRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// exit early if context is cancelled
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !d.IsDir() || !strings.HasSuffix(d.Name(), ".git") {
+			return nil
+		}
+
+		if err = maintainRepository(ctx, adapter, path); err != nil {
+			logger.Warn().Err(err).Msgf("failed maintenance of repository %s", path)
+			failed++
+		} else {
+			maintained++
+		}
+
+		// don't walk the content of the repository
+		return filepath.SkipDir
+	})
+
+	logger.Info().Msgf("repository maintenance finished: %d repositories maintained, %d failed", maintained, failed)
+
+	return err
+}
+
+func maintainRepository(ctx context.Context, adapter service.GitAdapter, repoPath string) error {
+	// ensure repositories created before the maintenance was introduced use the files, too.
+	if err := adapter.ConfigureMaintenance(ctx, repoPath); err != nil {
+		return err
+	}
+	if err := adapter.RepackWithBitmaps(ctx, repoPath); err != nil {
+		return err
+	}
+	return adapter.WriteCommitGraph(ctx, repoPath)
+}
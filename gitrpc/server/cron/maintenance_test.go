@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/gitrpc/internal/gitea"
+)
+
+func TestMaintainRepositories(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	ctx := context.Background()
+	reposRoot := t.TempDir()
+	repoPath := filepath.Join(reposRoot, "ab", "cd", "efgh.git")
+	workPath := t.TempDir()
+
+	run := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@b.c",
+			"GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@b.c")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run(reposRoot, "init", "--bare", repoPath)
+	run(workPath, "init")
+	run(workPath, "commit", "--allow-empty", "-m", "initial")
+	run(workPath, "push", repoPath, "HEAD:refs/heads/main")
+
+	adapter, err := gitea.New(gitea.NewGoGitRepoProvider(1<<20, time.Minute), nil)
+	if err != nil {
+		t.Fatalf("failed to create adapter: %v", err)
+	}
+
+	if err = maintainRepositories(ctx, adapter, reposRoot); err != nil {
+		t.Fatalf("maintainRepositories failed: %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(repoPath, "objects", "info", "commit-graph")); err != nil {
+		t.Errorf("commit-graph wasn't written: %v", err)
+	}
+	bitmaps, _ := filepath.Glob(filepath.Join(repoPath, "objects", "pack", "*.bitmap"))
+	if len(bitmaps) != 1 {
+		t.Errorf("expected a single bitmap, got %d", len(bitmaps))
+	}
+}
@@ -34,11 +34,19 @@ const (
 
 var ErrFatal = errors.New("fatal error occurred")
 
+// Leader reports whether the instance is the leader among all instances.
+type Leader interface {
+	IsLeader() bool
+}
+
 type Manager struct {
 	c      *cron.Cron
 	ctx    context.Context
 	cancel context.CancelFunc
 	fatal  chan error
+
+	// leader is used to run the cron jobs only on a single instance (all instances run them if nil).
+	leader Leader
 }
 
 // NewManager creates a cron manager.
@@ -52,6 +60,11 @@ func NewManager() *Manager {
 // NewCronTask adds a new func to cron job.
 func (c *Manager) NewCronTask(sepc string, job func(ctx context.Context) error) error {
 	_, err := c.c.AddFunc(sepc, func() {
+		if c.leader != nil && !c.leader.IsLeader() {
+			log.Ctx(c.ctx).Debug().Msg("gitrpc cron job skipped, instance isn't the leader")
+			return
+		}
+
 		jerr := job(c.ctx)
 		if jerr != nil { // check different severity of errors
 			log.Ctx(c.ctx).Error().Err(jerr).Msg("gitrpc cron job failed")
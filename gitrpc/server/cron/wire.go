@@ -15,6 +15,7 @@
 package cron
 
 import (
+	"github.com/harness/gitness/gitrpc/internal/service"
 	"github.com/harness/gitness/gitrpc/server"
 
 	"github.com/google/wire"
@@ -23,8 +24,19 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(ProvideManager)
 
-func ProvideManager(gitrpcconfig server.Config) *Manager {
+// ProvideManager provides the cron manager, running the cron jobs only when the instance is the leader.
+func ProvideManager(gitrpcconfig server.Config, leader Leader, adapter service.GitAdapter) *Manager {
 	cmngr := NewManager()
-	_ = AddAllGitRPCCronJobs(cmngr, gitrpcconfig)
+	cmngr.leader = leader
+	_ = AddAllGitRPCCronJobs(cmngr, gitrpcconfig, adapter)
 	return cmngr
 }
+
+// StandaloneWireSet provides a wire set for gitrpc servers running on their own storage (e.g. a shard),
+// where each server has to run the cron jobs for the repositories it stores.
+var StandaloneWireSet = wire.NewSet(ProvideStandaloneManager)
+
+// ProvideStandaloneManager provides the cron manager, always running the cron jobs.
+func ProvideStandaloneManager(gitrpcconfig server.Config, adapter service.GitAdapter) *Manager {
+	return ProvideManager(gitrpcconfig, nil, adapter)
+}
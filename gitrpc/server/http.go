@@ -52,7 +52,7 @@ func NewHTTPServer(config Config) (*HTTPServer, error) {
 		return nil, fmt.Errorf("configuration is invalid: %w", err)
 	}
 
-	reposRoot := filepath.Join(config.GitRoot, repoSubdirName)
+	reposRoot := filepath.Join(config.GitRoot, ReposSubdirName)
 
 	return &HTTPServer{
 		gitnesshttp.NewServer(
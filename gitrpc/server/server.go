@@ -28,12 +28,13 @@ import (
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
 
 const (
-	repoSubdirName           = "repos"
+	ReposSubdirName          = "repos"
 	ReposGraveyardSubdirName = "cleanup"
 )
 
@@ -47,7 +48,7 @@ func NewServer(config Config, adapter service.GitAdapter) (*GRPCServer, error) {
 		return nil, fmt.Errorf("configuration is invalid: %w", err)
 	}
 	// Create repos folder
-	reposRoot := filepath.Join(config.GitRoot, repoSubdirName)
+	reposRoot := filepath.Join(config.GitRoot, ReposSubdirName)
 	if _, err := os.Stat(reposRoot); errors.Is(err, os.ErrNotExist) {
 		if err = os.MkdirAll(reposRoot, 0o700); err != nil {
 			return nil, err
@@ -57,16 +58,21 @@ func NewServer(config Config, adapter service.GitAdapter) (*GRPCServer, error) {
 	// interceptors
 	errIntc := middleware.NewErrInterceptor()
 	logIntc := middleware.NewLogInterceptor()
+	metricsIntc := middleware.NewMetricsInterceptor()
 
 	s := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_recovery.UnaryServerInterceptor(),
+			otelgrpc.UnaryServerInterceptor(),
 			logIntc.UnaryInterceptor(),
+			metricsIntc.UnaryInterceptor(),
 			errIntc.UnaryInterceptor(),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			grpc_recovery.StreamServerInterceptor(),
+			otelgrpc.StreamServerInterceptor(),
 			logIntc.StreamInterceptor(),
+			metricsIntc.StreamInterceptor(),
 			errIntc.StreamInterceptor(),
 		)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -93,7 +99,7 @@ func NewServer(config Config, adapter service.GitAdapter) (*GRPCServer, error) {
 	if err != nil {
 		return nil, err
 	}
-	httpService, err := service.NewHTTPService(adapter, reposRoot)
+	httpService, err := service.NewHTTPService(adapter, reposRoot, config.Maintenance.CommitGraphAfterPush)
 	if err != nil {
 		return nil, err
 	}
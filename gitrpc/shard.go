@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/gitrpc/rpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// createRepositoryMethod is the grpc method creating a new repository, which requires a shard to be assigned.
+const createRepositoryMethod = "/rpc.RepositoryService/CreateRepository"
+
+// ShardRouter returns the shard (the name of the gitrpc server) storing a repository.
+type ShardRouter interface {
+	// Route returns the shard storing the existing repository with the provided uid.
+	Route(ctx context.Context, repoUID string) (string, error)
+
+	// Assign returns the shard a new repository with the provided uid is created on.
+	Assign(ctx context.Context, repoUID string) (string, error)
+}
+
+var _ grpc.ClientConnInterface = (*shardedConn)(nil)
+
+// shardedConn is a grpc connection that sends each request to the shard storing the repository of the request.
+type shardedConn struct {
+	router ShardRouter
+	conns  map[string]*grpc.ClientConn
+}
+
+func newShardedConn(router ShardRouter, conns map[string]*grpc.ClientConn) *shardedConn {
+	return &shardedConn{
+		router: router,
+		conns:  conns,
+	}
+}
+
+func (c *shardedConn) Invoke(ctx context.Context, method string, args, reply interface{},
+	opts ...grpc.CallOption) error {
+	conn, err := c.connFor(ctx, method, args)
+	if err != nil {
+		return err
+	}
+
+	return conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream returns a stream that is opened with the shard of the first message sent,
+// as the repository of the request isn't known before.
+func (c *shardedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string,
+	opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return &shardedStream{
+		ctx:    ctx,
+		conn:   c,
+		desc:   desc,
+		method: method,
+		opts:   opts,
+	}, nil
+}
+
+func (c *shardedConn) connFor(ctx context.Context, method string, msg interface{}) (*grpc.ClientConn, error) {
+	repoUID := repoUIDFromRequest(msg)
+	if repoUID == "" {
+		return nil, status.Error(codes.InvalidArgument, "request has no repository to route to a shard")
+	}
+
+	var (
+		shard string
+		err   error
+	)
+	if method == createRepositoryMethod {
+		shard, err = c.router.Assign(ctx, repoUID)
+	} else {
+		shard, err = c.router.Route(ctx, repoUID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to route repository to a shard: %s", err)
+	}
+
+	conn, ok := c.conns[shard]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "repository is stored on unknown shard '%s'", shard)
+	}
+
+	return conn, nil
+}
+
+// repoUIDFromRequest returns the uid of the repository of a request message.
+func repoUIDFromRequest(msg interface{}) string {
+	switch r := msg.(type) {
+	case interface{ GetBase() *rpc.ReadRequest }:
+		return r.GetBase().GetRepoUid()
+	case interface{ GetBase() *rpc.WriteRequest }:
+		return r.GetBase().GetRepoUid()
+	case *rpc.ServicePackRequest:
+		if base := r.GetReadBase(); base != nil {
+			return base.GetRepoUid()
+		}
+		return r.GetWriteBase().GetRepoUid()
+	case *rpc.CommitFilesRequest:
+		return r.GetHeader().GetBase().GetRepoUid()
+	case *rpc.CreateRepositoryRequest:
+		return r.GetHeader().GetBase().GetRepoUid()
+	default:
+		return ""
+	}
+}
+
+var errStreamNotStarted = errors.New("stream isn't started yet, no message was sent")
+
+// shardedStream is a client stream that is opened lazily with the first message sent.
+type shardedStream struct {
+	ctx    context.Context
+	conn   *shardedConn
+	desc   *grpc.StreamDesc
+	method string
+	opts   []grpc.CallOption
+
+	stream grpc.ClientStream
+}
+
+func (s *shardedStream) SendMsg(m interface{}) error {
+	if s.stream == nil {
+		conn, err := s.conn.connFor(s.ctx, s.method, m)
+		if err != nil {
+			return err
+		}
+
+		s.stream, err = conn.NewStream(s.ctx, s.desc, s.method, s.opts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.stream.SendMsg(m)
+}
+
+func (s *shardedStream) RecvMsg(m interface{}) error {
+	if s.stream == nil {
+		return errStreamNotStarted
+	}
+
+	return s.stream.RecvMsg(m)
+}
+
+func (s *shardedStream) Header() (metadata.MD, error) {
+	if s.stream == nil {
+		return nil, errStreamNotStarted
+	}
+
+	return s.stream.Header()
+}
+
+func (s *shardedStream) Trailer() metadata.MD {
+	if s.stream == nil {
+		return nil
+	}
+
+	return s.stream.Trailer()
+}
+
+func (s *shardedStream) CloseSend() error {
+	if s.stream == nil {
+		return errStreamNotStarted
+	}
+
+	return s.stream.CloseSend()
+}
+
+func (s *shardedStream) Context() context.Context {
+	if s.stream == nil {
+		return s.ctx
+	}
+
+	return s.stream.Context()
+}
@@ -21,6 +21,12 @@ var WireSet = wire.NewSet(
 	ProvideClient,
 )
 
-func ProvideClient(config Config) (Interface, error) {
+// ProvideClient provides the gitrpc client.
+// The requests are routed to the shards using the provided router if any shards are configured.
+func ProvideClient(config Config, router ShardRouter) (Interface, error) {
+	if len(config.Shards) > 0 {
+		return NewSharded(config, router)
+	}
+
 	return New(config)
 }
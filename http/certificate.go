@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CertificateReloader serves the certificate loaded from a pair of files and reloads it whenever the files change,
+// allowing short-lived certificates (e.g. issued by cert-manager) to be rotated without restarting the server.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mx      sync.RWMutex
+	cert    *tls.Certificate
+	version fileVersion
+}
+
+// fileVersion identifies the content of the certificate files on disk.
+type fileVersion struct {
+	certModTime time.Time
+	certSize    int64
+	keyModTime  time.Time
+	keySize     int64
+}
+
+// NewCertificateReloader returns a new CertificateReloader and loads the certificate from the provided files.
+func NewCertificateReloader(certFile, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns the currently loaded certificate, it's meant to be used as tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	return r.cert, nil
+}
+
+// Reload loads the certificate from the files. In case of an error the previously loaded certificate is kept.
+func (r *CertificateReloader) Reload() error {
+	version, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate key pair: %w", err)
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.cert = &cert
+	r.version = version
+
+	return nil
+}
+
+// Watch checks the certificate files for changes on every interval and reloads the certificate if they changed.
+// It blocks until the context is canceled.
+func (r *CertificateReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		version, err := r.stat()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to check the tls certificate files")
+			continue
+		}
+
+		r.mx.RLock()
+		changed := version != r.version
+		r.mx.RUnlock()
+
+		if !changed {
+			continue
+		}
+
+		if err = r.Reload(); err != nil {
+			// files might be in the middle of being replaced, the reload is retried on the next interval.
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload the tls certificate")
+			continue
+		}
+
+		log.Ctx(ctx).Info().
+			Str("cert_file", r.certFile).
+			Msg("reloaded the tls certificate")
+	}
+}
+
+func (r *CertificateReloader) stat() (fileVersion, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fileVersion{}, fmt.Errorf("failed to stat certificate file: %w", err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fileVersion{}, fmt.Errorf("failed to stat key file: %w", err)
+	}
+
+	return fileVersion{
+		certModTime: certInfo.ModTime(),
+		certSize:    certInfo.Size(),
+		keyModTime:  keyInfo.ModTime(),
+		keySize:     keyInfo.Size(),
+	}, nil
+}
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/sync/errgroup"
 )
@@ -40,6 +41,10 @@ type Config struct {
 	Key               string
 	AcmeHost          string
 	ReadHeaderTimeout time.Duration
+
+	// CertReloadInterval is the interval in which the certificate files are checked for changes.
+	// The certificate is only reloaded on demand (see ReloadCertificate) if it's zero.
+	CertReloadInterval time.Duration
 }
 
 // Server is a wrapper around http.Server that exposes different async ListenAndServe methods
@@ -47,6 +52,9 @@ type Config struct {
 type Server struct {
 	config  Config
 	handler http.Handler
+
+	// certs is set once the server is serving TLS using the configured certificate files.
+	certs *CertificateReloader
 }
 
 // ShutdownFunction defines a function that is called to shutdown the server.
@@ -87,8 +95,31 @@ func (s *Server) listenAndServe() (*errgroup.Group, ShutdownFunction) {
 	return &g, s1.Shutdown
 }
 
+// ReloadCertificate reloads the certificate from the configured files.
+// It's a no-op if the server isn't serving TLS using certificate files.
+func (s *Server) ReloadCertificate() error {
+	if s.certs == nil {
+		return nil
+	}
+
+	return s.certs.Reload()
+}
+
 func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 	var g errgroup.Group
+
+	certs, err := NewCertificateReloader(s.config.Cert, s.config.Key)
+	if err != nil {
+		g.Go(func() error {
+			return err
+		})
+		return &g, func(context.Context) error { return nil }
+	}
+	s.certs = certs
+
+	// the context is used to stop watching the certificate files on shutdown.
+	ctx, cancel := context.WithCancel(log.Logger.WithContext(context.Background()))
+
 	s1 := &http.Server{
 		Addr:              ":http",
 		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
@@ -98,18 +129,28 @@ func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 		Addr:              ":https",
 		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
 		Handler:           s.handler,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+			NextProtos:     []string{"h2", "http/1.1"},
+		},
 	}
 	g.Go(func() error {
 		return s1.ListenAndServe()
 	})
 	g.Go(func() error {
-		return s2.ListenAndServeTLS(
-			s.config.Cert,
-			s.config.Key,
-		)
+		return s2.ListenAndServeTLS("", "")
 	})
+	if s.config.CertReloadInterval > 0 {
+		g.Go(func() error {
+			certs.Watch(ctx, s.config.CertReloadInterval)
+			return nil
+		})
+	}
 
 	return &g, func(ctx context.Context) error {
+		cancel()
+
 		var sg errgroup.Group
 		sg.Go(func() error {
 			return s1.Shutdown(ctx)
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrDraining is returned if an operation is started while the tracker is draining.
+var ErrDraining = errors.New("the server is draining and doesn't accept new operations")
+
+// Tracker keeps track of the operations in flight and allows to drain them,
+// meaning no new operations are accepted and the operations in flight are waited for.
+type Tracker struct {
+	mx       sync.Mutex
+	draining bool
+	count    int
+	// idle is closed (and reset) whenever the count drops to zero while someone is waiting.
+	idle chan struct{}
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Start registers a new operation, the returned function has to be called once the operation completed.
+// ErrDraining is returned if the tracker is draining.
+func (t *Tracker) Start() (func(), error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.draining {
+		return nil, ErrDraining
+	}
+
+	t.count++
+
+	return t.doneFunc(), nil
+}
+
+// Join registers a new operation even if the tracker is draining.
+// It is meant for operations that are part of operations in flight (e.g. the git hooks of a push).
+// The returned function has to be called once the operation completed.
+func (t *Tracker) Join() func() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.count++
+
+	return t.doneFunc()
+}
+
+func (t *Tracker) doneFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(t.done)
+	}
+}
+
+func (t *Tracker) done() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.count--
+	if t.count == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// Drain stops the tracker from accepting new operations.
+func (t *Tracker) Drain() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.draining = true
+}
+
+// Wait blocks until there are no operations in flight or the context is done.
+func (t *Tracker) Wait(ctx context.Context) error {
+	for {
+		t.mx.Lock()
+		if t.count == 0 {
+			t.mx.Unlock()
+			return nil
+		}
+		if t.idle == nil {
+			t.idle = make(chan struct{})
+		}
+		idle := t.idle
+		t.mx.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-idle:
+		}
+	}
+}
+
+// Draining returns true if the tracker doesn't accept new operations.
+func (t *Tracker) Draining() bool {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.draining
+}
+
+// InFlight returns the number of operations in flight.
+func (t *Tracker) InFlight() int {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.count
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_Drain(t *testing.T) {
+	tracker := NewTracker()
+
+	done1, err := tracker.Start()
+	require.NoError(t, err)
+	done2, err := tracker.Start()
+	require.NoError(t, err)
+	require.Equal(t, 2, tracker.InFlight())
+
+	tracker.Drain()
+	require.True(t, tracker.Draining())
+
+	_, err = tracker.Start()
+	require.True(t, errors.Is(err, ErrDraining))
+
+	// operations that are part of operations in flight are still accepted
+	done3 := tracker.Join()
+	require.Equal(t, 3, tracker.InFlight())
+
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- tracker.Wait(context.Background())
+	}()
+
+	done1()
+	done1() // calling done multiple times has no effect
+	done3()
+	require.Equal(t, 1, tracker.InFlight())
+
+	select {
+	case <-waitErr:
+		t.Fatal("wait returned with an operation in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	done2()
+
+	select {
+	case err = <-waitErr:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("wait didn't return after all operations completed")
+	}
+}
+
+func TestTracker_WaitTimeout(t *testing.T) {
+	tracker := NewTracker()
+
+	_, err := tracker.Start()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = tracker.Wait(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
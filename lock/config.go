@@ -19,8 +19,9 @@ import "time"
 type Provider string
 
 const (
-	MemoryProvider Provider = "inmemory"
-	RedisProvider  Provider = "redis"
+	MemoryProvider   Provider = "inmemory"
+	RedisProvider    Provider = "redis"
+	PostgresProvider Provider = "postgres"
 )
 
 // A DelayFunc is used to decide the amount of time to wait between retries.
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Elector elects a single leader among all instances competing for the same key.
+// Leadership is represented by holding a distributed lock, which the leader keeps extending
+// while it's alive. If the leader stops extending the lock (e.g. crash), another instance takes over.
+type Elector struct {
+	manager MutexManager
+	key     string
+	ttl     time.Duration
+
+	isLeader atomic.Bool
+}
+
+// NewElector creates a new Elector for the provided key.
+// The ttl defines how long the leadership is kept without being renewed.
+func NewElector(manager MutexManager, key string, ttl time.Duration) *Elector {
+	return &Elector{
+		manager: manager,
+		key:     key,
+		ttl:     ttl,
+	}
+}
+
+// IsLeader returns true if the instance currently holds the leadership.
+func (e *Elector) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+// Run campaigns for the leadership until the context is done.
+// The leadership is released once the context is done.
+func (e *Elector) Run(ctx context.Context) error {
+	// retry and renew often enough to not lose the leadership because of a single failed attempt.
+	interval := e.ttl / 3
+
+	var mx Mutex
+	defer func() {
+		if mx == nil {
+			return
+		}
+
+		// use a fresh context as the original one is done already.
+		unlockCtx, cancelFn := context.WithTimeout(context.Background(), interval)
+		defer cancelFn()
+
+		e.resign(unlockCtx, mx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		mx, err = e.campaign(ctx, mx)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign tries to acquire the leadership, or to extend it if it's already held.
+// It returns the mutex representing the leadership (nil if the instance isn't the leader).
+func (e *Elector) campaign(ctx context.Context, mx Mutex) (Mutex, error) {
+	if mx != nil {
+		err := mx.Extend(ctx)
+		if err == nil {
+			return mx, nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msgf("leader election: lost leadership of '%s'", e.key)
+		e.resign(ctx, mx)
+	}
+
+	// a new mutex is used for every attempt, as a lost lock can't be acquired again by the same mutex.
+	mx, err := e.manager.NewMutex(e.key, WithExpiry(e.ttl), WithTries(1))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mx.Lock(ctx); err != nil {
+		// another instance is the leader.
+		return nil, nil //nolint:nilnil // not being the leader isn't an error
+	}
+
+	e.isLeader.Store(true)
+	log.Ctx(ctx).Info().Msgf("leader election: acquired leadership of '%s'", e.key)
+
+	return mx, nil
+}
+
+// resign gives up the leadership represented by the provided mutex.
+func (e *Elector) resign(ctx context.Context, mx Mutex) {
+	e.isLeader.Store(false)
+
+	// best effort, the lock might not be held anymore.
+	if err := mx.Unlock(ctx); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msgf("leader election: failed to release leadership of '%s'", e.key)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestElector_SingleLeader(t *testing.T) {
+	manager := NewInMemory(Config{
+		App:        "gitness",
+		Namespace:  "default",
+		Expiry:     300 * time.Millisecond,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+
+	electorA := NewElector(manager, "leader", 300*time.Millisecond)
+	electorB := NewElector(manager, "leader", 300*time.Millisecond)
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		_ = electorA.Run(ctxA)
+	}()
+
+	waitFor(t, electorA.IsLeader)
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	go func() {
+		_ = electorB.Run(ctxB)
+	}()
+
+	// the leadership is kept while the leader is extending it (longer than the ttl).
+	time.Sleep(time.Second)
+	if !electorA.IsLeader() || electorB.IsLeader() {
+		t.Fatalf("expected only the first elector to be the leader, got a=%t b=%t",
+			electorA.IsLeader(), electorB.IsLeader())
+	}
+
+	// once the leader stops, the leadership is taken over.
+	cancelA()
+	<-doneA
+
+	if electorA.IsLeader() {
+		t.Fatal("expected the stopped elector to give up the leadership")
+	}
+	waitFor(t, electorB.IsLeader)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// Postgres is a MutexManager using postgres session level advisory locks.
+// Every held lock keeps a dedicated database connection, the lock is released by postgres
+// as soon as the connection is closed (e.g. if the instance holding the lock crashes).
+type Postgres struct {
+	config Config // force value copy
+	db     *sql.DB
+}
+
+// NewPostgres creates a new Postgres instance using the provided database.
+func NewPostgres(config Config, db *sql.DB) *Postgres {
+	return &Postgres{
+		config: config,
+		db:     db,
+	}
+}
+
+// NewMutex creates a mutex for the given key. The returned mutex is not held
+// and must be acquired with a call to .Lock.
+func (p *Postgres) NewMutex(key string, options ...Option) (Mutex, error) {
+	// copy default values
+	config := p.config
+
+	// set default delayFunc
+	if config.DelayFunc == nil {
+		config.DelayFunc = func(i int) time.Duration {
+			return config.RetryDelay
+		}
+	}
+
+	// override config with custom options
+	for _, opt := range options {
+		opt.Apply(&config)
+	}
+
+	// format key
+	key = formatKey(config.App, config.Namespace, key)
+
+	// advisory locks are identified by a 64 bit integer
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+
+	waitTime := config.Expiry
+	if config.TimeoutFactor > 0 {
+		waitTime = time.Duration(int64(float64(config.Expiry) * config.TimeoutFactor))
+	}
+
+	return &postgresMutex{
+		db:        p.db,
+		key:       key,
+		lockID:    int64(hash.Sum64()),
+		waitTime:  waitTime,
+		tries:     config.Tries,
+		delayFunc: config.DelayFunc,
+	}, nil
+}
+
+type postgresMutex struct {
+	mutex sync.Mutex // Used while manipulating the internal state of the lock itself
+
+	db     *sql.DB
+	key    string
+	lockID int64
+
+	waitTime  time.Duration
+	tries     int
+	delayFunc DelayFunc
+
+	// conn is the connection holding the advisory lock (nil if the lock isn't held).
+	conn *sql.Conn
+}
+
+// Key returns the key to be locked.
+func (m *postgresMutex) Key() string {
+	return m.key
+}
+
+// Lock acquires the lock. It fails with error if the lock is already held.
+func (m *postgresMutex) Lock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn != nil {
+		return NewError(LockHeld, m.key, nil)
+	}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return NewError(ProviderError, m.key, err)
+	}
+
+	timeout := time.NewTimer(m.waitTime)
+	defer timeout.Stop()
+
+	for attempt := 1; ; attempt++ {
+		var acquired bool
+		err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", m.lockID).Scan(&acquired)
+		if err != nil {
+			_ = conn.Close()
+			return NewError(ProviderError, m.key, err)
+		}
+
+		if acquired {
+			m.conn = conn
+			return nil
+		}
+
+		if attempt >= m.tries {
+			_ = conn.Close()
+			return NewError(MaxRetriesExceeded, m.key, nil)
+		}
+
+		delay := time.NewTimer(m.delayFunc(attempt))
+
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			_ = conn.Close()
+			return NewError(Context, m.key, ctx.Err())
+		case <-timeout.C:
+			delay.Stop()
+			_ = conn.Close()
+			return NewError(CannotLock, m.key, nil)
+		case <-delay.C: // just wait
+		}
+	}
+}
+
+// Unlock releases the lock. It fails with error if the lock is not currently held.
+func (m *postgresMutex) Unlock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn == nil {
+		return NewError(LockNotHeld, m.key, nil)
+	}
+
+	// closing the connection releases the lock in any case
+	defer func() {
+		_ = m.conn.Close()
+		m.conn = nil
+	}()
+
+	var released bool
+	err := m.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", m.lockID).Scan(&released)
+	if err != nil {
+		return NewError(ProviderError, m.key, err)
+	}
+	if !released {
+		return NewError(LockNotHeld, m.key, nil)
+	}
+
+	return nil
+}
+
+// Extend verifies that the lock is still held - advisory locks don't expire
+// as long as the connection holding them is alive.
+func (m *postgresMutex) Extend(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.conn == nil {
+		return NewError(LockNotHeld, m.key, nil)
+	}
+
+	if err := m.conn.PingContext(ctx); err != nil {
+		return NewError(LockNotHeld, m.key, err)
+	}
+
+	return nil
+}
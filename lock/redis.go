@@ -101,6 +101,18 @@ func (l *RedisMutex) Unlock(ctx context.Context) error {
 	return nil
 }
 
+// Extend resets the expiry of the lock. It fails with error if the lock is not currently held.
+func (l *RedisMutex) Extend(ctx context.Context) error {
+	ok, err := l.mutex.ExtendContext(ctx)
+	if err != nil {
+		return translateRedisErr(err, l.Key())
+	}
+	if !ok {
+		return NewError(LockNotHeld, l.Key(), nil)
+	}
+	return nil
+}
+
 func translateRedisErr(err error, key string) error {
 	var kind KindError
 	switch {
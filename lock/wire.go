@@ -17,18 +17,31 @@ package lock
 import (
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
 )
 
+// leaderKey is the key of the lock representing the leadership among all instances.
+const leaderKey = "leader"
+
 var WireSet = wire.NewSet(
 	ProvideMutexManager,
+	ProvideElector,
 )
 
-func ProvideMutexManager(config Config, client redis.UniversalClient) MutexManager {
+func ProvideMutexManager(config Config, client redis.UniversalClient, db *sqlx.DB) MutexManager {
 	switch config.Provider {
 	case MemoryProvider:
 		return NewInMemory(config)
 	case RedisProvider:
 		return NewRedis(config, client)
+	case PostgresProvider:
+		return NewPostgres(config, db.DB)
 	}
 	return nil
 }
+
+// ProvideElector provides the elector of the leader among all instances.
+// The leadership expires with the same expiry as any other lock.
+func ProvideElector(config Config, manager MutexManager) *Elector {
+	return NewElector(manager, leaderKey, config.Expiry)
+}
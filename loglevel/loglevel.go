@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loglevel
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// std is the registry used by the whole application.
+var std = New(zerolog.InfoLevel)
+
+// SetDefault sets the level used if no other override applies.
+func SetDefault(level zerolog.Level) {
+	std.SetDefault(level)
+}
+
+// For returns the log level to use for the provided subsystem and space path (can be empty).
+func For(subsystem Subsystem, spacePath string) zerolog.Level {
+	return std.For(subsystem, spacePath)
+}
+
+// Get returns the currently configured log levels.
+func Get() Settings {
+	return std.Get()
+}
+
+// Update applies the provided settings on top of the existing ones.
+func Update(in Settings) error {
+	return std.Update(in)
+}
+
+// Logger returns a copy of the logger using the log level of the provided subsystem and space path (can be empty).
+func Logger(logger zerolog.Logger, subsystem Subsystem, spacePath string) zerolog.Logger {
+	return logger.Level(For(subsystem, spacePath))
+}
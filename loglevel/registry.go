@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loglevel allows to adjust the log levels of the different subsystems at runtime.
+package loglevel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Subsystem identifies a part of gitness that can be configured with its own log level.
+type Subsystem string
+
+const (
+	SubsystemAPI    Subsystem = "api"
+	SubsystemGit    Subsystem = "git"
+	SubsystemGitRPC Subsystem = "gitrpc"
+	SubsystemEvents Subsystem = "events"
+	SubsystemJobs   Subsystem = "jobs"
+)
+
+// Subsystems returns all subsystems whose log level can be configured.
+func Subsystems() []Subsystem {
+	return []Subsystem{
+		SubsystemAPI,
+		SubsystemGit,
+		SubsystemGitRPC,
+		SubsystemEvents,
+		SubsystemJobs,
+	}
+}
+
+func (s Subsystem) valid() bool {
+	for _, subsystem := range Subsystems() {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings describes the configured log levels.
+// Levels are the zerolog level names (e.g. "debug", "info", "warn").
+type Settings struct {
+	// Default is the level used if no other override applies.
+	Default string `json:"default"`
+
+	// Subsystems contains the level overrides per subsystem.
+	Subsystems map[Subsystem]string `json:"subsystems"`
+
+	// Spaces contains the level overrides per space path.
+	// An override applies to the space and all its descendants, and takes precedence over subsystem overrides.
+	Spaces map[string]string `json:"spaces"`
+}
+
+// Registry stores the log levels of all subsystems and spaces.
+type Registry struct {
+	mx         sync.RWMutex
+	def        zerolog.Level
+	subsystems map[Subsystem]zerolog.Level
+	spaces     map[string]zerolog.Level
+}
+
+// New returns a new registry using the provided level as default.
+func New(def zerolog.Level) *Registry {
+	return &Registry{
+		def:        def,
+		subsystems: map[Subsystem]zerolog.Level{},
+		spaces:     map[string]zerolog.Level{},
+	}
+}
+
+// SetDefault sets the level used if no other override applies.
+func (r *Registry) SetDefault(level zerolog.Level) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.def = level
+}
+
+// For returns the log level to use for the provided subsystem and space path (can be empty).
+func (r *Registry) For(subsystem Subsystem, spacePath string) zerolog.Level {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	// the closest space with an override wins.
+	for path := normalizePath(spacePath); path != ""; {
+		if level, ok := r.spaces[path]; ok {
+			return level
+		}
+
+		idx := strings.LastIndex(path, "/")
+		if idx < 0 {
+			break
+		}
+		path = path[:idx]
+	}
+
+	if level, ok := r.subsystems[subsystem]; ok {
+		return level
+	}
+
+	return r.def
+}
+
+// Get returns the currently configured log levels.
+func (r *Registry) Get() Settings {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	settings := Settings{
+		Default:    r.def.String(),
+		Subsystems: make(map[Subsystem]string, len(r.subsystems)),
+		Spaces:     make(map[string]string, len(r.spaces)),
+	}
+	for subsystem, level := range r.subsystems {
+		settings.Subsystems[subsystem] = level.String()
+	}
+	for path, level := range r.spaces {
+		settings.Spaces[path] = level.String()
+	}
+
+	return settings
+}
+
+// Update applies the provided settings on top of the existing ones.
+// An empty default keeps the current default, an empty override level removes the override.
+// The settings are validated upfront - in case of an error no change is applied.
+func (r *Registry) Update(in Settings) error {
+	def, err := parseLevel(in.Default)
+	if err != nil {
+		return fmt.Errorf("invalid default level: %w", err)
+	}
+
+	subsystems := make(map[Subsystem]*zerolog.Level, len(in.Subsystems))
+	for subsystem, raw := range in.Subsystems {
+		if !subsystem.valid() {
+			return fmt.Errorf("unknown subsystem '%s'", subsystem)
+		}
+		if subsystems[subsystem], err = parseLevel(raw); err != nil {
+			return fmt.Errorf("invalid level for subsystem '%s': %w", subsystem, err)
+		}
+	}
+
+	spaces := make(map[string]*zerolog.Level, len(in.Spaces))
+	for raw, rawLevel := range in.Spaces {
+		path := normalizePath(raw)
+		if path == "" {
+			return fmt.Errorf("space path can't be empty")
+		}
+		if spaces[path], err = parseLevel(rawLevel); err != nil {
+			return fmt.Errorf("invalid level for space '%s': %w", raw, err)
+		}
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if def != nil {
+		r.def = *def
+	}
+	for subsystem, level := range subsystems {
+		if level == nil {
+			delete(r.subsystems, subsystem)
+			continue
+		}
+		r.subsystems[subsystem] = *level
+	}
+	for path, level := range spaces {
+		if level == nil {
+			delete(r.spaces, path)
+			continue
+		}
+		r.spaces[path] = *level
+	}
+
+	return nil
+}
+
+// parseLevel parses the provided level name - an empty name results in a nil level.
+func parseLevel(raw string) (*zerolog.Level, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	level, err := zerolog.ParseLevel(strings.ToLower(raw))
+	if err != nil || level == zerolog.NoLevel {
+		return nil, fmt.Errorf("unknown level '%s', supported levels are: %s", raw, strings.Join(levelNames(), ", "))
+	}
+
+	return &level, nil
+}
+
+func levelNames() []string {
+	names := []string{}
+	for level := zerolog.TraceLevel; level <= zerolog.PanicLevel; level++ {
+		names = append(names, level.String())
+	}
+	names = append(names, zerolog.Disabled.String())
+	sort.Strings(names)
+	return names
+}
+
+// normalizePath converts the space path into the format used as key of the space overrides.
+// Space paths are case insensitive.
+func normalizePath(path string) string {
+	return strings.ToLower(strings.Trim(path, "/"))
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loglevel
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestRegistryFor(t *testing.T) {
+	r := New(zerolog.InfoLevel)
+	err := r.Update(Settings{
+		Subsystems: map[Subsystem]string{
+			SubsystemAPI: "debug",
+		},
+		Spaces: map[string]string{
+			"/Space1/":     "trace",
+			"space1/noisy": "error",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		subsystem Subsystem
+		space     string
+		want      zerolog.Level
+	}{
+		{name: "default", subsystem: SubsystemJobs, want: zerolog.InfoLevel},
+		{name: "subsystem", subsystem: SubsystemAPI, want: zerolog.DebugLevel},
+		{name: "other space", subsystem: SubsystemAPI, space: "space2", want: zerolog.DebugLevel},
+		{name: "space", subsystem: SubsystemJobs, space: "space1", want: zerolog.TraceLevel},
+		{name: "descendant space", subsystem: SubsystemAPI, space: "SPACE1/repo", want: zerolog.TraceLevel},
+		{name: "closest space", subsystem: SubsystemAPI, space: "space1/noisy/repo", want: zerolog.ErrorLevel},
+		{name: "prefix only", subsystem: SubsystemGit, space: "space10", want: zerolog.InfoLevel},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := r.For(test.subsystem, test.space); got != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRegistryUpdate(t *testing.T) {
+	r := New(zerolog.InfoLevel)
+	if err := r.Update(Settings{Subsystems: map[Subsystem]string{SubsystemGit: "debug"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid settings are rejected without applying any change.
+	err := r.Update(Settings{Default: "warn", Subsystems: map[Subsystem]string{SubsystemGit: "verbose"}})
+	if err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+	if err = r.Update(Settings{Subsystems: map[Subsystem]string{"unknown": "debug"}}); err == nil {
+		t.Fatal("expected error for unknown subsystem")
+	}
+	if got := r.For(SubsystemGit, ""); got != zerolog.DebugLevel {
+		t.Fatalf("want=%s, got=%s", zerolog.DebugLevel, got)
+	}
+
+	// an empty level removes the override.
+	if err = r.Update(Settings{Subsystems: map[Subsystem]string{SubsystemGit: ""}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.For(SubsystemGit, ""); got != zerolog.InfoLevel {
+		t.Fatalf("want=%s, got=%s", zerolog.InfoLevel, got)
+	}
+}
@@ -14,8 +14,18 @@
 
 package database
 
+import "time"
+
 // Config specifies the config for the database package.
 type Config struct {
 	Driver     string
 	Datasource string
+
+	// SkipContractMigrations only applies expand migrations on startup (for zero-downtime upgrades).
+	SkipContractMigrations bool
+
+	// ReplicaDatasources are the datasources of the read replicas (optional).
+	ReplicaDatasources   []string
+	ReplicaMaxLag        time.Duration
+	ReplicaCheckInterval time.Duration
 }
@@ -22,6 +22,7 @@ import (
 
 const (
 	postgres = "postgres"
+	mysql    = "mysql"
 )
 
 type locker interface {
@@ -34,7 +35,7 @@ type locker interface {
 var globalMx sync.RWMutex
 
 func needsLocking(driver string) bool {
-	return driver != postgres
+	return driver != postgres && driver != mysql
 }
 
 func getLocker(db *sqlx.DB) locker {
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// replicaLagQuery returns the replication lag of a postgres replica in seconds.
+// A replica that replayed everything it received isn't lagging, even if the primary is idle.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// replicaSets contains the registered read replicas of the primary databases.
+var replicaSets sync.Map // *sqlx.DB -> *ReplicaSet
+
+// ctxKeyReplicaReads is context key for the replica read state of a context.
+type ctxKeyReplicaReads struct{}
+
+// replicaReads tracks whether a context has written to the primary database.
+type replicaReads struct {
+	written atomic.Bool
+}
+
+// WithReplicaReads returns a copy of the context that allows read queries to be served by read replicas.
+// As soon as the context is used to write to the primary database, all further reads are served by the primary,
+// to guarantee that the context reads its own writes.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyReplicaReads{}, &replicaReads{})
+}
+
+// markWrite records that the context has been used to write to (or potentially write to) the primary database.
+func markWrite(ctx context.Context) {
+	if s, ok := ctx.Value(ctxKeyReplicaReads{}).(*replicaReads); ok {
+		s.written.Store(true)
+	}
+}
+
+// markWrite records that the runner has been used to (potentially) write to the primary database.
+func (r runnerDB) markWrite(ctx context.Context) {
+	if !r.replica {
+		markWrite(ctx)
+	}
+}
+
+// replicaReadsAllowed returns true if the context allows reads to be served by read replicas.
+func replicaReadsAllowed(ctx context.Context) bool {
+	s, ok := ctx.Value(ctxKeyReplicaReads{}).(*replicaReads)
+	return ok && !s.written.Load()
+}
+
+// GetReadAccessor returns the Accessor for read-only queries.
+// It returns the transaction from the context if it exists, otherwise a read replica of the provided db
+// if the context allows replica reads (see WithReplicaReads) and a replica without excessive lag is available.
+// In any other case the accessor of the provided db is returned.
+func GetReadAccessor(ctx context.Context, db *sqlx.DB) Accessor {
+	if a, ok := ctx.Value(ctxKeyTx{}).(Accessor); ok {
+		return a
+	}
+
+	if !replicaReadsAllowed(ctx) {
+		return New(db)
+	}
+
+	set, ok := replicaSets.Load(db)
+	if !ok {
+		return New(db)
+	}
+
+	replica := set.(*ReplicaSet).pick()
+	if replica == nil {
+		return New(db)
+	}
+
+	return runnerDB{
+		db:      sqlDB{replica},
+		mx:      getLocker(replica),
+		replica: true,
+	}
+}
+
+// RegisterReplicas registers the read replicas of the provided primary database.
+func RegisterReplicas(primary *sqlx.DB, set *ReplicaSet) {
+	replicaSets.Store(primary, set)
+}
+
+// ReplicaSet is a set of read replicas which are used for reads as long as their replication lag is acceptable.
+type ReplicaSet struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+type replica struct {
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// NewReplicaSet creates a new set of read replicas with the provided max accepted replication lag.
+// Replicas aren't used before their replication lag is verified (see ReplicaSet.Monitor).
+func NewReplicaSet(maxLag time.Duration, dbs ...*sqlx.DB) *ReplicaSet {
+	replicas := make([]*replica, len(dbs))
+	for i, db := range dbs {
+		replicas[i] = &replica{db: db}
+	}
+
+	return &ReplicaSet{
+		replicas: replicas,
+		maxLag:   maxLag,
+	}
+}
+
+// pick returns the next healthy replica (round robin) or nil if none of the replicas is healthy.
+func (s *ReplicaSet) pick() *sqlx.DB {
+	n := uint64(len(s.replicas))
+	for i := uint64(0); i < n; i++ {
+		r := s.replicas[(s.next.Add(1)+i)%n]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+
+	return nil
+}
+
+// Monitor periodically verifies the replication lag of all replicas until the context is done.
+// Replicas that are unreachable or lagging behind more than the max lag aren't used for reads.
+func (s *ReplicaSet) Monitor(ctx context.Context, interval time.Duration) {
+	s.check(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+func (s *ReplicaSet) check(ctx context.Context) {
+	for i, r := range s.replicas {
+		var lagSeconds float64
+		err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&lagSeconds)
+		lag := time.Duration(lagSeconds * float64(time.Second))
+
+		healthy := err == nil && lag <= s.maxLag
+		if healthy != r.healthy.Swap(healthy) {
+			log.Ctx(ctx).Warn().Err(err).
+				Int("replica", i).
+				Dur("lag", lag).
+				Bool("healthy", healthy).
+				Msg("database read replica changed health")
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestGetReadAccessor(t *testing.T) {
+	primary := sqlx.NewDb(&sql.DB{}, postgres)
+	replicaDB := sqlx.NewDb(&sql.DB{}, postgres)
+
+	set := NewReplicaSet(time.Second, replicaDB)
+	RegisterReplicas(primary, set)
+	defer replicaSets.Delete(primary)
+
+	tests := []struct {
+		name          string
+		ctx           func() context.Context
+		healthy       bool
+		expectReplica bool
+	}{
+		{
+			name:          "replica-reads-not-allowed",
+			ctx:           context.Background,
+			healthy:       true,
+			expectReplica: false,
+		},
+		{
+			name:          "replica-reads-allowed",
+			ctx:           func() context.Context { return WithReplicaReads(context.Background()) },
+			healthy:       true,
+			expectReplica: true,
+		},
+		{
+			name:          "replica-unhealthy",
+			ctx:           func() context.Context { return WithReplicaReads(context.Background()) },
+			healthy:       false,
+			expectReplica: false,
+		},
+		{
+			name: "read-after-write",
+			ctx: func() context.Context {
+				ctx := WithReplicaReads(context.Background())
+				markWrite(ctx)
+				return ctx
+			},
+			healthy:       true,
+			expectReplica: false,
+		},
+		{
+			name: "read-after-replica-read",
+			ctx: func() context.Context {
+				ctx := WithReplicaReads(context.Background())
+				GetReadAccessor(ctx, primary).(runnerDB).markWrite(ctx)
+				return ctx
+			},
+			healthy:       true,
+			expectReplica: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set.replicas[0].healthy.Store(test.healthy)
+
+			r, ok := GetReadAccessor(test.ctx(), primary).(runnerDB)
+			isReplica := ok && r.replica
+
+			if isReplica != test.expectReplica {
+				t.Errorf("expected replica=%t, got replica=%t", test.expectReplica, isReplica)
+			}
+		})
+	}
+}
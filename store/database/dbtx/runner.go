@@ -27,6 +27,9 @@ import (
 type runnerDB struct {
 	db transactor
 	mx locker
+
+	// replica is true if the runner is used for a read replica (which can't be written to).
+	replica bool
 }
 
 var _ AccessorTx = runnerDB{}
@@ -47,6 +50,7 @@ func (r runnerDB) WithTx(ctx context.Context, txFn func(context.Context) error,
 		r.mx.RLock()
 		defer r.mx.RUnlock()
 	} else {
+		r.markWrite(ctx)
 		r.mx.Lock()
 		defer r.mx.Unlock()
 	}
@@ -100,48 +104,56 @@ func (r runnerDB) BindNamed(query string, arg interface{}) (string, []interface{
 }
 
 func (r runnerDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.QueryContext(ctx, query, args...)
 }
 
 func (r runnerDB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.QueryxContext(ctx, query, args...)
 }
 
 func (r runnerDB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.QueryRowxContext(ctx, query, args...)
 }
 
 func (r runnerDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.ExecContext(ctx, query, args...)
 }
 
 func (r runnerDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.QueryRowContext(ctx, query, args...)
 }
 
 func (r runnerDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.PrepareContext(ctx, query)
 }
 
 func (r runnerDB) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.PreparexContext(ctx, query)
 }
 
 func (r runnerDB) PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error) {
+	r.markWrite(ctx)
 	r.mx.Lock()
 	defer r.mx.Unlock()
 	return r.db.PrepareNamedContext(ctx, query)
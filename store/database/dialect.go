@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// IsMySQL returns true if the database is accessed using the mysql driver.
+func IsMySQL(db interface{ DriverName() string }) bool {
+	return db.DriverName() == MySQLDriverName
+}
+
+// InsertReturningID executes the insert query and returns the id generated for the new row.
+// The query must not contain a RETURNING clause, for postgres and sqlite the clause
+// returning the id column is appended to the query. Mysql doesn't support RETURNING,
+// the id is retrieved from the result of the query instead.
+func InsertReturningID(
+	ctx context.Context,
+	db sqlx.ExtContext,
+	query string,
+	idColumn string,
+	args ...interface{},
+) (int64, error) {
+	if IsMySQL(db) {
+		result, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return result.LastInsertId()
+	}
+
+	var id int64
+	if err := db.QueryRowxContext(ctx, query+"\n\tRETURNING "+idColumn, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
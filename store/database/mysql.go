@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// MySQLDriverName is the name of the driver used for MySQL and MariaDB databases.
+	MySQLDriverName = "mysql"
+
+	// mysqlDialectDriverName is the name under which the placeholder translating mysql driver is registered.
+	mysqlDialectDriverName = "gitness-mysql"
+)
+
+func init() {
+	sql.Register(mysqlDialectDriverName, &mysqlDialectDriver{parent: &mysql.MySQLDriver{}})
+}
+
+// rewritePlaceholdersForMySQL replaces all numbered placeholders ($1, $2, ...) outside of literals
+// with positional placeholders (?). It returns the translated query and, in case the query uses
+// numbered placeholders, the index of the original argument for each of the positional placeholders.
+//
+// Other differences between the dialects aren't translated, the stores use dedicated mysql queries instead.
+func rewritePlaceholdersForMySQL(query string) (string, []int) {
+	var (
+		sb         strings.Builder
+		argIndexes []int
+		quote      byte
+	)
+
+	sb.Grow(len(query))
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			n := 0
+			for i+1 < len(query) && isDigit(query[i+1]) {
+				i++
+				n = n*10 + int(query[i]-'0')
+			}
+
+			sb.WriteByte('?')
+			argIndexes = append(argIndexes, n-1)
+
+			continue
+		}
+
+		sb.WriteByte(c)
+	}
+
+	if argIndexes == nil {
+		return query, nil
+	}
+
+	return sb.String(), argIndexes
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// remapArgsForMySQL orders the arguments based on the argument indexes returned by rewritePlaceholdersForMySQL.
+func remapArgsForMySQL(args []driver.NamedValue, argIndexes []int) ([]driver.NamedValue, error) {
+	if argIndexes == nil {
+		return args, nil
+	}
+
+	mapped := make([]driver.NamedValue, len(argIndexes))
+	for i, idx := range argIndexes {
+		if idx < 0 || idx >= len(args) {
+			return nil, fmt.Errorf("missing argument for placeholder $%d", idx+1)
+		}
+
+		mapped[i] = driver.NamedValue{Ordinal: i + 1, Value: args[idx].Value}
+	}
+
+	return mapped, nil
+}
+
+// mysqlDialectDriver wraps the mysql driver and translates the numbered placeholders used by the stores.
+type mysqlDialectDriver struct {
+	parent driver.Driver
+}
+
+func (d *mysqlDialectDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.parent.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mysqlDialectConn{Conn: conn}, nil
+}
+
+type mysqlDialectConn struct {
+	driver.Conn
+}
+
+func (c *mysqlDialectConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query, argIndexes := rewritePlaceholdersForMySQL(query)
+
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &mysqlDialectStmt{Stmt: stmt, argIndexes: argIndexes}, nil
+}
+
+func (c *mysqlDialectConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *mysqlDialectConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	query, argIndexes := rewritePlaceholdersForMySQL(query)
+
+	args, err := remapArgsForMySQL(args, argIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *mysqlDialectConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	query, argIndexes := rewritePlaceholdersForMySQL(query)
+
+	args, err := remapArgsForMySQL(args, argIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *mysqlDialectConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	//nolint:staticcheck // fallback for drivers not supporting contexts.
+	return c.Conn.Begin()
+}
+
+func (c *mysqlDialectConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *mysqlDialectConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *mysqlDialectConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c *mysqlDialectConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+type mysqlDialectStmt struct {
+	driver.Stmt
+	argIndexes []int
+}
+
+func (s *mysqlDialectStmt) NumInput() int {
+	if s.argIndexes != nil {
+		// numbered placeholders can be used multiple times, skip the sanity check of database/sql.
+		return -1
+	}
+
+	return s.Stmt.NumInput()
+}
+
+func (s *mysqlDialectStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	args, err := remapArgsForMySQL(args, s.argIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+
+	//nolint:staticcheck // fallback for drivers not supporting contexts.
+	return s.Stmt.Exec(namedValuesToValues(args))
+}
+
+func (s *mysqlDialectStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	args, err := remapArgsForMySQL(args, s.argIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	//nolint:staticcheck // fallback for drivers not supporting contexts.
+	return s.Stmt.Query(namedValuesToValues(args))
+}
+
+func namedValuesToValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	return values
+}
+
+// prepareMySQLDatasource enables the features required by the migrations on the mysql datasource.
+func prepareMySQLDatasource(datasource string) (string, error) {
+	cfg, err := mysql.ParseDSN(datasource)
+	if err != nil {
+		return "", fmt.Errorf("datasource is of invalid format for driver mysql: %w", err)
+	}
+
+	// migration files contain multiple statements.
+	cfg.MultiStatements = true
+
+	return cfg.FormatDSN(), nil
+}
+
+// isMySQLUniqueConstraintError returns true if the error is a mysql duplicate entry error.
+func isMySQLUniqueConstraintError(original error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(original, &mysqlErr) {
+		return mysqlErr.Number == 1062 // ER_DUP_ENTRY
+	}
+
+	return false
+}
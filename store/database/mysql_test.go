@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestRewritePlaceholdersForMySQL(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		want       string
+		argIndexes []int
+	}{
+		{
+			name:  "positional placeholders are kept",
+			query: "SELECT a FROM t WHERE a = ? AND b = ?",
+			want:  "SELECT a FROM t WHERE a = ? AND b = ?",
+		},
+		{
+			name:       "numbered placeholders",
+			query:      "SELECT a FROM t WHERE a = $2 AND b = $1 AND c = $2 LIMIT $10",
+			want:       "SELECT a FROM t WHERE a = ? AND b = ? AND c = ? LIMIT ?",
+			argIndexes: []int{1, 0, 1, 9},
+		},
+		{
+			name:       "placeholders in literals are ignored",
+			query:      "SELECT '$1', \"$2\" FROM t WHERE a = $1",
+			want:       "SELECT '$1', \"$2\" FROM t WHERE a = ?",
+			argIndexes: []int{0},
+		},
+		{
+			name:  "no placeholders",
+			query: "SELECT a FROM t",
+			want:  "SELECT a FROM t",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, argIndexes := rewritePlaceholdersForMySQL(test.query)
+
+			if got != test.want {
+				t.Errorf("want query %q, got %q", test.want, got)
+			}
+			if !reflect.DeepEqual(argIndexes, test.argIndexes) {
+				t.Errorf("want arg indexes %v, got %v", test.argIndexes, argIndexes)
+			}
+		})
+	}
+}
+
+func TestRemapArgsForMySQL(t *testing.T) {
+	args := []driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: int64(2)}}
+
+	got, err := remapArgsForMySQL(args, []int{1, 0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []driver.NamedValue{
+		{Ordinal: 1, Value: int64(2)},
+		{Ordinal: 2, Value: "a"},
+		{Ordinal: 3, Value: int64(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if _, err = remapArgsForMySQL(args, []int{2}); err == nil {
+		t.Errorf("expected error for missing argument")
+	}
+}
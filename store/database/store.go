@@ -13,31 +13,36 @@
 // limitations under the License.
 
 // Package database provides persistent data storage using
-// a postgres or sqlite3 database.
+// a postgres, mysql or sqlite3 database.
 package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"net/url"
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/XSAM/otelsql"
 	"github.com/jmoiron/sqlx"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
 	// sqlForUpdate is the sql statement used for locking rows returned by select queries.
 	SQLForUpdate = "FOR UPDATE"
+
+	// PostgresDriverName is the name of the driver used for postgres databases.
+	PostgresDriverName = "postgres"
 )
 
 type Migrator func(ctx context.Context, dbx *sqlx.DB) error
 
 // Builder is a global instance of the sql builder. we are able to
-// hardcode to postgres since sqlite3 is compatible with postgres.
+// hardcode to postgres since sqlite3 is compatible with postgres
+// and mysql queries are translated by the mysql driver.
 var Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 // Connect to a database and verify with a ping.
@@ -47,7 +52,17 @@ func Connect(ctx context.Context, driver string, datasource string) (*sqlx.DB, e
 		return nil, fmt.Errorf("failed to prepare datasource: %w", err)
 	}
 
-	db, err := sql.Open(driver, datasource)
+	// mysql queries are translated from the postgres dialect by a wrapping driver.
+	openDriver := driver
+	if driver == MySQLDriverName {
+		openDriver = mysqlDialectDriverName
+	}
+
+	// the db connection is instrumented for tracing (noop unless a tracer provider is configured).
+	db, err := otelsql.Open(openDriver, datasource,
+		otelsql.WithAttributes(attribute.String("db.system", driver)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open the db: %w", err)
 	}
@@ -104,6 +119,8 @@ func prepareDatasourceForDriver(driver string, datasource string) (string, error
 		url.RawQuery = query.Encode()
 
 		return url.String(), nil
+	case MySQLDriverName:
+		return prepareMySQLDatasource(datasource)
 	default:
 		return datasource, nil
 	}
@@ -35,5 +35,5 @@ func isSQLUniqueConstraintError(original error) bool {
 		return pqErr.Code == "23505" // unique_violation
 	}
 
-	return false
+	return isMySQLUniqueConstraintError(original)
 }
@@ -27,6 +27,10 @@ import (
 type memoryMessage struct {
 	message
 	retries int64
+	// lastError is the error of the last failed processing attempt.
+	lastError error
+	// deadLettered indicates that the message failed all retries and only has to be dead-lettered.
+	deadLettered bool
 }
 
 // MemoryConsumer consumes streams from a MemoryBroker.
@@ -187,6 +191,13 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 				continue
 			}
 
+			if m.deadLettered {
+				if !c.deadLetter(ctx, m, m.lastError) {
+					c.requeue(m, handler.config.idleTimeout)
+				}
+				continue
+			}
+
 			err := func() (err error) {
 				// Ensure that handlers don't cause panic.
 				defer func() {
@@ -204,6 +215,14 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 					m.id, m.streamID, m.retries, err))
 
 				if m.retries >= int64(handler.config.maxRetries) {
+					if !c.deadLetter(ctx, m, err) {
+						// dead-lettering failed, keep the message and try again later (without processing it again)
+						m.deadLettered = true
+						m.lastError = err
+						c.requeue(m, handler.config.idleTimeout)
+						continue
+					}
+
 					c.pushError(fmt.Errorf(
 						"discard message with id '%s' from stream '%s' - failed %d retries",
 						m.id, m.streamID, m.retries))
@@ -213,18 +232,53 @@ func (c *MemoryConsumer) consume(ctx context.Context) {
 				// increase retry count
 				m.retries++
 
-				// requeue message for a retry (needs to be in a separate go func to avoid deadlock)
+				// requeue message for a retry
 				// IMPORTANT: this won't requeue to broker, only in this consumer's queue!
-				go func() {
-					// TODO: linear/exponential backoff relative to retry count might be good
-					time.Sleep(handler.config.idleTimeout)
-					c.messageQueue <- m
-				}()
+				// TODO: linear/exponential backoff relative to retry count might be good
+				c.requeue(m, handler.config.idleTimeout)
 			}
 		}
 	}
 }
 
+// requeue puts the message back into the queue of the consumer after the provided delay.
+// NOTE: needs to be in a separate go func to avoid deadlock.
+func (c *MemoryConsumer) requeue(m memoryMessage, delay time.Duration) {
+	go func() {
+		time.Sleep(delay)
+		c.messageQueue <- m
+	}()
+}
+
+// deadLetter passes the message to the dead letter handler (if any) and returns true if the message can be discarded.
+func (c *MemoryConsumer) deadLetter(ctx context.Context, m memoryMessage, lastErr error) bool {
+	if c.Config.DeadLetterHandler == nil {
+		return true
+	}
+
+	deadLetter := DeadLetter{
+		StreamID:  untransposeStreamID(c.namespace, m.streamID),
+		GroupName: c.groupName,
+		MessageID: m.id,
+		Values:    m.values,
+		Retries:   m.retries,
+	}
+	if lastErr != nil {
+		deadLetter.LastError = lastErr.Error()
+	}
+
+	if err := c.Config.DeadLetterHandler(ctx, deadLetter); err != nil {
+		c.pushError(fmt.Errorf("failed to dead-letter message with id '%s' from stream '%s': %w",
+			m.id, m.streamID, err))
+		return false
+	}
+
+	c.pushInfo(fmt.Sprintf("dead-lettered message with id '%s' from stream '%s' after %d retries",
+		m.id, m.streamID, m.retries))
+
+	return true
+}
+
 func (c *MemoryConsumer) Errors() <-chan error { return c.errorCh }
 func (c *MemoryConsumer) Infos() <-chan string { return c.infoCh }
 
@@ -234,3 +288,10 @@ func (c *MemoryConsumer) pushError(err error) {
 	default:
 	}
 }
+
+func (c *MemoryConsumer) pushInfo(s string) {
+	select {
+	case c.infoCh <- s:
+	default:
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsMessage is used internally for passing NATS messages via channels.
+type natsMessage struct {
+	streamID string
+	msg      *nats.Msg
+}
+
+// NatsConsumer provides functionality to process NATS JetStream streams as part of a consumer group.
+// Every consumer group is mapped to a durable pull consumer, which distributes the messages across
+// all consumers of the group (e.g. gitness instances) and takes care of the redelivery of failed messages.
+type NatsConsumer struct {
+	js nats.JetStreamContext
+	// namespace specifies the namespace of the keys - any stream key will be prefixed with it
+	namespace string
+	// groupName specifies the name of the consumer group.
+	groupName string
+	// consumerName specifies the name of the consumer.
+	consumerName string
+	// maxStreamLength defines the maximum number of entries in each stream (used if the stream doesn't exist yet).
+	maxStreamLength int64
+
+	// Config is the generic consumer configuration.
+	Config ConsumerConfig
+
+	// streams is a map of all registered streams and their handlers.
+	streams map[string]handler
+
+	isStarted    bool
+	messageQueue chan natsMessage
+	errorCh      chan error
+	infoCh       chan string
+}
+
+// NewNatsConsumer creates new NATS JetStream consumer.
+// It returns channels of info messages and errors. The caller should not block on these channels for too long.
+// These channels are provided mainly for logging.
+func NewNatsConsumer(js nats.JetStreamContext, namespace string,
+	groupName string, consumerName string, maxStreamLength int64) (*NatsConsumer, error) {
+	if groupName == "" {
+		return nil, errors.New("groupName can't be empty")
+	}
+	if consumerName == "" {
+		return nil, errors.New("consumerName can't be empty")
+	}
+
+	const queueCapacity = 500
+	const errorChCapacity = 64
+	const infoChCapacity = 64
+
+	return &NatsConsumer{
+		js:              js,
+		namespace:       namespace,
+		groupName:       groupName,
+		consumerName:    consumerName,
+		maxStreamLength: maxStreamLength,
+		streams:         map[string]handler{},
+		Config:          defaultConfig,
+		isStarted:       false,
+		messageQueue:    make(chan natsMessage, queueCapacity),
+		errorCh:         make(chan error, errorChCapacity),
+		infoCh:          make(chan string, infoChCapacity),
+	}, nil
+}
+
+func (c *NatsConsumer) Configure(opts ...ConsumerOption) {
+	if c.isStarted {
+		return
+	}
+
+	for _, opt := range opts {
+		opt.apply(&c.Config)
+	}
+}
+
+func (c *NatsConsumer) Register(streamID string, fn HandlerFunc, opts ...HandlerOption) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+	if streamID == "" {
+		return errors.New("streamID can't be empty")
+	}
+	if fn == nil {
+		return errors.New("fn can't be empty")
+	}
+
+	// transpose streamID to key namespace - no need to keep inner streamID
+	transposedStreamID := transposeStreamID(c.namespace, streamID)
+	if _, ok := c.streams[transposedStreamID]; ok {
+		return fmt.Errorf("consumer is already registered for '%s' (nats stream '%s')", streamID, transposedStreamID)
+	}
+
+	// create final config for handler
+	config := c.Config.DefaultHandlerConfig
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	c.streams[transposedStreamID] = handler{
+		handle: fn,
+		config: config,
+	}
+
+	return nil
+}
+
+func (c *NatsConsumer) Start(ctx context.Context) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+
+	if len(c.streams) == 0 {
+		return errors.New("no streams registered")
+	}
+
+	// Create the durable consumer of the group for all streams, creates streams if they don't exist.
+	subscriptions := make(map[string]*nats.Subscription, len(c.streams))
+	for streamID, handler := range c.streams {
+		sub, err := c.subscribe(streamID, handler.config)
+		if err != nil {
+			return err
+		}
+		subscriptions[streamID] = sub
+	}
+
+	// mark as started before starting go routines (can't error out from here)
+	c.isStarted = true
+
+	wg := &sync.WaitGroup{}
+
+	for streamID, sub := range subscriptions {
+		wg.Add(1)
+		go func(streamID string, sub *nats.Subscription) {
+			defer wg.Done()
+			// launch nats reader, it will finish when the ctx is done
+			c.reader(ctx, streamID, sub)
+		}(streamID, sub)
+	}
+
+	for i := 0; i < c.Config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// launch nats message consumer, it will finish when the ctx is done
+			c.consumer(ctx)
+		}()
+	}
+
+	go func() {
+		// wait for all go routines to complete
+		wg.Wait()
+
+		// close all channels
+		close(c.messageQueue)
+		close(c.errorCh)
+		close(c.infoCh)
+	}()
+
+	return nil
+}
+
+// subscribe creates (or binds to) the durable pull consumer of the group for the provided stream.
+func (c *NatsConsumer) subscribe(streamID string, config HandlerConfig) (*nats.Subscription, error) {
+	err := ensureNatsStream(c.js, streamID, c.maxStreamLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// Similar to redis consumer groups, a new group only receives messages from now on.
+	// Retries are handled by the consumer itself (see consumer), hence no max deliver is configured.
+	sub, err := c.js.PullSubscribe(streamID, natsName(c.groupName),
+		nats.BindStream(natsName(streamID)),
+		nats.DeliverNew(),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.AckWait(config.idleTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer group '%s' for stream '%s': %w", c.groupName, streamID, err)
+	}
+
+	return sub, nil
+}
+
+// reader method fetches the messages of a stream from the durable consumer of the group.
+// The messages are then sent to a go channel for processing.
+// The method terminates when the provided context finishes.
+func (c *NatsConsumer) reader(ctx context.Context, streamID string, sub *nats.Subscription) {
+	delays := []time.Duration{1 * time.Millisecond, 5 * time.Second, 15 * time.Second, 30 * time.Second, time.Minute}
+	consecutiveFailures := 0
+
+	for {
+		var delay time.Duration
+		if consecutiveFailures < len(delays) {
+			delay = delays[consecutiveFailures]
+		} else {
+			delay = delays[len(delays)-1]
+		}
+		readTimer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			readTimer.Stop()
+			return
+
+		case <-readTimer.C:
+			const count = 100
+
+			fetchCtx, cancelFn := context.WithTimeout(ctx, 5*time.Minute)
+			msgs, err := sub.Fetch(count, nats.Context(fetchCtx))
+			cancelFn()
+
+			// if context is canceled, continue and next iteration will exit cleanly
+			if errors.Is(err, context.Canceled) {
+				continue
+			}
+
+			// nothing to read within the timeout - read again
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+				consecutiveFailures = 0
+				continue
+			}
+
+			if err != nil {
+				consecutiveFailures++
+				c.pushError(fmt.Errorf("failed to read nats stream '%s' (consecutive fails: %d): %w",
+					streamID, consecutiveFailures, err))
+				continue
+			}
+
+			// reset fail count
+			consecutiveFailures = 0
+
+			for _, msg := range msgs {
+				c.messageQueue <- natsMessage{
+					streamID: streamID,
+					msg:      msg,
+				}
+			}
+		}
+	}
+}
+
+// consumer method consumes messages coming from NATS. The method terminates when the ctx is done.
+func (c *NatsConsumer) consumer(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-c.messageQueue:
+			if m.msg == nil {
+				// msg should never be empty, if it is then the channel is closed
+				return
+			}
+
+			c.process(ctx, m)
+		}
+	}
+}
+
+func (c *NatsConsumer) process(ctx context.Context, m natsMessage) {
+	handler, ok := c.streams[m.streamID]
+	if !ok {
+		// we don't want to ack the message, it will be redelivered (worst case it expires)
+		c.pushError(fmt.Errorf("received message in stream '%s' that doesn't belong to us, skip", m.streamID))
+		return
+	}
+
+	meta, err := m.msg.Metadata()
+	if err != nil {
+		c.pushError(fmt.Errorf("failed to read metadata of message in stream '%s': %w", m.streamID, err))
+		return
+	}
+
+	id := strconv.FormatUint(meta.Sequence.Stream, 10)
+	retries := int64(meta.NumDelivered) - 1
+
+	values, err := decodeNatsPayload(m.msg.Data)
+	if err != nil {
+		// the message can never be processed, discard it.
+		c.pushError(fmt.Errorf("discard message '%s' in stream '%s' - failed to decode payload: %w",
+			id, m.streamID, err))
+		c.term(m, id)
+		return
+	}
+
+	err = func() (err error) {
+		// Ensure that handlers don't cause panic.
+		defer func() {
+			if r := recover(); r != nil {
+				c.pushError(fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
+					id, m.streamID, debug.Stack()))
+				err = fmt.Errorf("panic when processing message: %v", r)
+			}
+		}()
+
+		return handler.handle(ctx, id, values)
+	}()
+	if err == nil {
+		if errAck := m.msg.Ack(); errAck != nil {
+			c.pushError(fmt.Errorf("failed to acknowledge message '%s' in stream '%s': %w", id, m.streamID, errAck))
+		}
+		return
+	}
+
+	c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s' (retries: %d): %w",
+		id, m.streamID, retries, err))
+
+	if retries < int64(handler.config.maxRetries) {
+		// request redelivery of the message after the idle timeout.
+		if errNak := m.msg.NakWithDelay(handler.config.idleTimeout); errNak != nil {
+			c.pushError(fmt.Errorf("failed to request redelivery of message '%s' in stream '%s': %w",
+				id, m.streamID, errNak))
+		}
+		return
+	}
+
+	if c.Config.DeadLetterHandler != nil {
+		errDeadLetter := c.Config.DeadLetterHandler(ctx, DeadLetter{
+			StreamID:  untransposeStreamID(c.namespace, m.streamID),
+			GroupName: c.groupName,
+			MessageID: id,
+			Values:    values,
+			Retries:   retries,
+			LastError: err.Error(),
+		})
+		if errDeadLetter != nil {
+			// keep the message, it's redelivered and dead-lettered again if it keeps failing.
+			c.pushError(fmt.Errorf("failed to dead-letter message '%s' in stream '%s': %w",
+				id, m.streamID, errDeadLetter))
+			_ = m.msg.NakWithDelay(handler.config.idleTimeout)
+			return
+		}
+
+		c.pushInfo(fmt.Sprintf("dead-lettered message '%s' in stream '%s' after %d retries", id, m.streamID, retries))
+	} else {
+		c.pushError(fmt.Errorf("discard message '%s' in stream '%s' - failed %d retries", id, m.streamID, retries))
+	}
+
+	c.term(m, id)
+}
+
+// term stops the redelivery of the message (WARNING this will discard the message!).
+func (c *NatsConsumer) term(m natsMessage, id string) {
+	if err := m.msg.Term(); err != nil {
+		c.pushError(fmt.Errorf("failed to discard message '%s' in stream '%s': %w", id, m.streamID, err))
+	}
+}
+
+func (c *NatsConsumer) Errors() <-chan error { return c.errorCh }
+func (c *NatsConsumer) Infos() <-chan string { return c.infoCh }
+
+func (c *NatsConsumer) pushError(err error) {
+	select {
+	case c.errorCh <- err:
+	default:
+	}
+}
+
+func (c *NatsConsumer) pushInfo(s string) {
+	select {
+	case c.infoCh <- s:
+	default:
+	}
+}
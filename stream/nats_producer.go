@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NatsProducer sends messages to NATS JetStream streams.
+// Every stream is stored in its own JetStream stream (created on first use) with a subject of the same name.
+type NatsProducer struct {
+	js nats.JetStreamContext
+	// namespace defines the namespace of the stream keys - any stream key will be prefixed with it.
+	namespace string
+	// maxStreamLength defines the maximum number of entries in each stream (oldest entries are discarded).
+	maxStreamLength int64
+
+	// streams keeps track of the JetStream streams that are known to exist.
+	streams sync.Map
+}
+
+func NewNatsProducer(js nats.JetStreamContext, namespace string, maxStreamLength int64) *NatsProducer {
+	return &NatsProducer{
+		js:              js,
+		namespace:       namespace,
+		maxStreamLength: maxStreamLength,
+	}
+}
+
+// Send sends information to the JetStream stream.
+// Returns the message ID (the sequence number of the message in the stream) in case of success.
+func (p *NatsProducer) Send(ctx context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	// ensure we transpose streamID using the key namespace
+	transposedStreamID := transposeStreamID(p.namespace, streamID)
+
+	if _, ok := p.streams.Load(transposedStreamID); !ok {
+		if err := ensureNatsStream(p.js, transposedStreamID, p.maxStreamLength); err != nil {
+			return "", err
+		}
+		p.streams.Store(transposedStreamID, struct{}{})
+	}
+
+	data, err := encodeNatsPayload(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode message for stream '%s': %w", streamID, err)
+	}
+
+	ack, err := p.js.Publish(transposedStreamID, data, nats.Context(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to write to stream '%s' (nats stream '%s'). Error: %w",
+			streamID, transposedStreamID, err)
+	}
+
+	return strconv.FormatUint(ack.Sequence, 10), nil
+}
+
+// ensureNatsStream creates the JetStream stream for the provided (transposed) streamID if it doesn't exist yet.
+func ensureNatsStream(js nats.JetStreamContext, streamID string, maxStreamLength int64) error {
+	name := natsName(streamID)
+
+	_, err := js.StreamInfo(name)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		return fmt.Errorf("failed to get info of nats stream '%s': %w", name, err)
+	}
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     name,
+		Subjects: []string{streamID},
+		MaxMsgs:  maxStreamLength,
+		Discard:  nats.DiscardOld,
+		Storage:  nats.FileStorage,
+	})
+	// the stream might have been created concurrently by another instance.
+	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("failed to create nats stream '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// natsName converts the provided value into a valid NATS stream or consumer name.
+func natsName(s string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "/", "_", "\\", "_").Replace(s)
+}
+
+func encodeNatsPayload(payload map[string]interface{}) ([]byte, error) {
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(payload); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+func decodeNatsPayload(data []byte) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
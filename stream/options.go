@@ -64,6 +64,13 @@ func WithHandlerOptions(opts ...HandlerOption) ConsumerOption {
 	})
 }
 
+// WithDeadLetterHandler sets up the function called with messages that exceeded their max retries.
+func WithDeadLetterHandler(fn DeadLetterFunc) ConsumerOption {
+	return consumerOptionFunc(func(c *ConsumerConfig) {
+		c.DeadLetterHandler = fn
+	})
+}
+
 // HandlerOption is used to configure the handler consuming a single stream.
 type HandlerOption interface {
 	apply(*HandlerConfig)
@@ -43,6 +43,9 @@ type RedisConsumer struct {
 	// streams is a map of all registered streams and their handlers.
 	streams map[string]handler
 
+	// lastErrors keeps the last processing error of failed messages (for dead-lettering).
+	lastErrors sync.Map
+
 	isStarted    bool
 	messageQueue chan message
 	errorCh      chan error
@@ -378,7 +381,12 @@ func (c *RedisConsumer) reclaimer(ctx context.Context, reclaimInterval time.Dura
 					if resMessage.RetryCount > int64(handler.config.maxRetries) {
 						// Retry count gets increased after every XCLAIM.
 						// Large retry count might mean there is something wrong with the message, so we'll XACK it.
-						// WARNING this will discard the message!
+						// WARNING this will discard the message (unless it can be dead-lettered)!
+						if !c.deadLetter(ctx, streamID, resMessage.ID, resMessage.RetryCount-1) {
+							// keep the message pending, dead-lettering is attempted again in the next iteration.
+							continue
+						}
+
 						errAck := c.rdb.XAck(ctx, streamID, c.groupName, resMessage.ID).Err()
 						if errAck != nil {
 							c.pushError(fmt.Errorf(
@@ -496,9 +504,12 @@ func (c *RedisConsumer) consumer(ctx context.Context) {
 			}()
 			if err != nil {
 				c.pushError(fmt.Errorf("failed to process message '%s' in stream '%s': %w", m.id, m.streamID, err))
+				c.lastErrors.Store(m.streamID+"/"+m.id, err.Error())
 				continue
 			}
 
+			c.lastErrors.Delete(m.streamID + "/" + m.id)
+
 			err = c.rdb.XAck(ctx, m.streamID, c.groupName, m.id).Err()
 			if err != nil {
 				c.pushError(fmt.Errorf("failed to acknowledge message '%s' in stream '%s': %w", m.id, m.streamID, err))
@@ -508,6 +519,49 @@ func (c *RedisConsumer) consumer(ctx context.Context) {
 	}
 }
 
+// deadLetter passes the pending message to the dead letter handler (if any)
+// and returns true if the message can be discarded.
+func (c *RedisConsumer) deadLetter(ctx context.Context, streamID string, messageID string, retries int64) bool {
+	if c.Config.DeadLetterHandler == nil {
+		return true
+	}
+
+	key := streamID + "/" + messageID
+
+	resRange, err := c.rdb.XRangeN(ctx, streamID, messageID, messageID, 1).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.pushError(fmt.Errorf("failed to read message '%s' in stream '%s' for dead-lettering: %w",
+			messageID, streamID, err))
+		return false
+	}
+
+	// the message is removed from the stream (because of MAXLEN) - nothing left to dead-letter.
+	if len(resRange) == 0 {
+		c.lastErrors.Delete(key)
+		return true
+	}
+
+	deadLetter := DeadLetter{
+		StreamID:  untransposeStreamID(c.namespace, streamID),
+		GroupName: c.groupName,
+		MessageID: messageID,
+		Values:    resRange[0].Values,
+		Retries:   retries,
+	}
+	if lastErr, ok := c.lastErrors.Load(key); ok {
+		deadLetter.LastError, _ = lastErr.(string)
+	}
+
+	if err = c.Config.DeadLetterHandler(ctx, deadLetter); err != nil {
+		c.pushError(fmt.Errorf("failed to dead-letter message '%s' in stream '%s': %w", messageID, streamID, err))
+		return false
+	}
+
+	c.lastErrors.Delete(key)
+
+	return true
+}
+
 func (c *RedisConsumer) removeStaleConsumers(ctx context.Context, maxAge time.Duration) {
 	for streamID := range c.streams {
 		// Fetch all consumers for this stream and group.
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -41,6 +42,10 @@ type ConsumerConfig struct {
 
 	// DefaultHandlerConfig is the default config used for stream handlers.
 	DefaultHandlerConfig HandlerConfig
+
+	// DeadLetterHandler is called with messages that exceeded the max retries of their handler.
+	// If the handler fails, the message is kept and dead-lettered again on a later attempt (if possible).
+	DeadLetterHandler DeadLetterFunc
 }
 
 // HandlerConfig defines the configuration for a single stream handler containing externally exposed values
@@ -57,6 +62,25 @@ type HandlerConfig struct {
 // HandlerFunc defines the signature of a function handling stream messages.
 type HandlerFunc func(ctx context.Context, messageID string, payload map[string]interface{}) error
 
+// DeadLetter contains a message that couldn't be processed successfully within the max retries of its handler.
+type DeadLetter struct {
+	// StreamID is the ID of the stream (without the namespace) the message belongs to.
+	StreamID string
+	// GroupName is the name of the consumer group that failed to process the message.
+	GroupName string
+	// MessageID is the ID of the message in the stream.
+	MessageID string
+	// Values contains the payload of the message.
+	Values map[string]interface{}
+	// Retries is the number of retries of the message.
+	Retries int64
+	// LastError is the last error returned by the handler (if known).
+	LastError string
+}
+
+// DeadLetterFunc defines the signature of a function handling dead-lettered stream messages.
+type DeadLetterFunc func(ctx context.Context, deadLetter DeadLetter) error
+
 // handler defines a handler of a single stream.
 type handler struct {
 	handle HandlerFunc
@@ -74,3 +98,8 @@ type message struct {
 func transposeStreamID(namespace string, streamID string) string {
 	return fmt.Sprintf("%s:%s", namespace, streamID)
 }
+
+// untransposeStreamID removes the namespace from the provided (transposed) streamID.
+func untransposeStreamID(namespace string, streamID string) string {
+	return strings.TrimPrefix(streamID, namespace+":")
+}
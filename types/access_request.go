@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// AccessRequest is the request of a user to become a member of a space, either directly
+// or through one of its repositories. Decided requests are kept as the audit trail of the granted access.
+type AccessRequest struct {
+	ID int64 `json:"id"`
+	// SpaceID is the space the membership is requested for, for repositories it's the parent space.
+	SpaceID int64 `json:"space_id"`
+	// RepoID is the repository the access was requested through, if any.
+	RepoID      *int64                  `json:"repo_id,omitempty"`
+	PrincipalID int64                   `json:"-"`
+	Role        enum.MembershipRole     `json:"role"`
+	Message     string                  `json:"message"`
+	State       enum.AccessRequestState `json:"state"`
+	Created     int64                   `json:"created"`
+	Updated     int64                   `json:"updated"`
+
+	DecidedBy      *int64 `json:"-"`
+	Decided        *int64 `json:"decided,omitempty"`
+	DecisionReason string `json:"decision_reason,omitempty"`
+
+	Requester *PrincipalInfo `json:"requester,omitempty"`
+	Decider   *PrincipalInfo `json:"decider,omitempty"`
+}
+
+// AccessRequestFilter stores access request query parameters.
+type AccessRequestFilter struct {
+	Page   int                       `json:"page"`
+	Size   int                       `json:"size"`
+	States []enum.AccessRequestState `json:"state"`
+	// RepoID restricts the result to the requests made through a repository, 0 returns all requests of the space.
+	RepoID int64 `json:"repo_id"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Announcement is a message shown to all users of the instance, e.g. about a maintenance window.
+type Announcement struct {
+	ID       int64                     `db:"announcement_id"       json:"id"`
+	Message  string                    `db:"announcement_message"  json:"message"`
+	Severity enum.AnnouncementSeverity `db:"announcement_severity" json:"severity"`
+	// Starts is the time the announcement is shown from, 0 means immediately.
+	Starts int64 `db:"announcement_starts" json:"starts"`
+	// Ends is the time the announcement is shown until, 0 means until it's deleted.
+	Ends        int64 `db:"announcement_ends"        json:"ends"`
+	Dismissible bool  `db:"announcement_dismissible" json:"dismissible"`
+	CreatedBy   int64 `db:"announcement_created_by"  json:"created_by"`
+	Created     int64 `db:"announcement_created"     json:"created"`
+	Updated     int64 `db:"announcement_updated"     json:"updated"`
+}
+
+// AnnouncementFilter stores announcement query parameters.
+type AnnouncementFilter struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Attachment is a file uploaded to a repository to be referenced from the markdown of pull requests and comments.
+// Files are deduplicated per repository by the checksum of their content.
+type Attachment struct {
+	ID          int64  `json:"-" db:"attachment_id"`
+	RepoID      int64  `json:"repo_id" db:"attachment_repo_id"`
+	SHA256      string `json:"sha256" db:"attachment_sha256"`
+	ContentType string `json:"content_type" db:"attachment_content_type"`
+	Size        int64  `json:"size" db:"attachment_size"`
+	CreatedBy   int64  `json:"created_by" db:"attachment_created_by"`
+	Created     int64  `json:"created" db:"attachment_created"`
+}
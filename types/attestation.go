@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Attestation is a signed provenance statement (e.g. SLSA provenance) about a commit,
+// optionally produced by a pipeline execution.
+type Attestation struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"repo_id"`
+	CommitSHA   string `json:"commit_sha"`
+	ExecutionID *int64 `json:"execution_id,omitempty"`
+	// PredicateType is the type of the predicate of the in-toto statement, e.g. "https://slsa.dev/provenance/v1".
+	PredicateType string `json:"predicate_type"`
+	// Envelope is the DSSE envelope containing the signed in-toto statement.
+	Envelope json.RawMessage `json:"envelope"`
+
+	Status enum.AttestationStatus `json:"status"`
+	// KeyName is the name of the trusted key that verified the signature.
+	KeyName       string `json:"key_name,omitempty"`
+	StatusMessage string `json:"status_message,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	// Verified is the time of the last verification of the attestation.
+	Verified int64 `json:"verified"`
+}
+
+// AttestationKey is a public key trusted to sign attestations.
+type AttestationKey struct {
+	Name string `json:"name"`
+	// PublicKey is the PEM encoded public key (ed25519, ECDSA or RSA).
+	PublicKey string `json:"public_key"`
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Avatar is the image uploaded as avatar of a principal or a space.
+// The image is stored in the blob store in all size variants, addressed by the checksum of the uploaded content.
+type Avatar struct {
+	OwnerType enum.AvatarOwnerType `json:"owner_type"`
+	OwnerID   int64                `json:"owner_id"`
+	SHA256    string               `json:"sha256"`
+	CreatedBy int64                `json:"created_by"`
+	Updated   int64                `json:"updated"`
+}
+
+// AvatarImage is an avatar image of a specific size, either uploaded or generated.
+type AvatarImage struct {
+	// ETag identifies the content of the image, it changes whenever the avatar changes.
+	ETag        string
+	ContentType string
+	Content     []byte
+}
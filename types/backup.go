@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Backup represents a snapshot of a repository stored in the blob store.
+// A snapshot consists of a git bundle with all references and a JSON export of the repository metadata.
+// Backups outlive the repository, so the repository path is recorded at the time of the backup.
+type Backup struct {
+	ID           int64              `db:"backup_id"            json:"id"`
+	RepoID       int64              `db:"backup_repo_id"       json:"repo_id"`
+	RepoPath     string             `db:"backup_repo_path"     json:"repo_path"`
+	State        enum.BackupState   `db:"backup_state"         json:"state"`
+	Trigger      enum.BackupTrigger `db:"backup_trigger"       json:"trigger"`
+	BundleSize   int64              `db:"backup_bundle_size"   json:"bundle_size"`
+	MetadataSize int64              `db:"backup_metadata_size" json:"metadata_size"`
+	Error        string             `db:"backup_error"         json:"error,omitempty"`
+	CreatedBy    int64              `db:"backup_created_by"    json:"created_by"`
+	Created      int64              `db:"backup_created"       json:"created"`
+	Updated      int64              `db:"backup_updated"       json:"updated"`
+}
+
+// BackupFilter stores backup query parameters.
+type BackupFilter struct {
+	Page   int              `json:"page"`
+	Size   int              `json:"size"`
+	RepoID int64            `json:"repo_id"`
+	State  enum.BackupState `json:"state"`
+}
+
+// BackupMetadata is the metadata of a repository exported alongside the git bundle of a backup.
+type BackupMetadata struct {
+	Version    int              `json:"version"`
+	Created    int64            `json:"created"`
+	Repository BackupRepository `json:"repository"`
+	PullReqs   []BackupPullReq  `json:"pull_requests"`
+}
+
+// BackupRepository is the exported metadata of a repository.
+type BackupRepository struct {
+	ID            int64  `json:"id"`
+	Path          string `json:"path"`
+	Description   string `json:"description"`
+	IsPublic      bool   `json:"is_public"`
+	DefaultBranch string `json:"default_branch"`
+	PullReqSeq    int64  `json:"pullreq_seq"`
+	CreatedBy     int64  `json:"created_by"`
+	Created       int64  `json:"created"`
+}
+
+// BackupPullReq is the exported metadata of a pull request.
+// Only pull requests within the repository are exported, pull requests from forks aren't.
+type BackupPullReq struct {
+	Number         int64             `json:"number"`
+	CreatedBy      int64             `json:"created_by"`
+	Created        int64             `json:"created"`
+	Edited         int64             `json:"edited"`
+	State          enum.PullReqState `json:"state"`
+	IsDraft        bool              `json:"is_draft"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	SourceBranch   string            `json:"source_branch"`
+	SourceSHA      string            `json:"source_sha"`
+	TargetBranch   string            `json:"target_branch"`
+	MergedBy       *int64            `json:"merged_by,omitempty"`
+	Merged         *int64            `json:"merged,omitempty"`
+	MergeMethod    *enum.MergeMethod `json:"merge_method,omitempty"`
+	MergeTargetSHA *string           `json:"merge_target_sha,omitempty"`
+	MergeBaseSHA   string            `json:"merge_base_sha"`
+	MergeSHA       *string           `json:"merge_sha,omitempty"`
+}
+
+// BackupRestoreImpact describes what is overwritten by restoring a backup.
+type BackupRestoreImpact struct {
+	// Path is the path the repository is restored to.
+	Path string `json:"path"`
+	// Repo is the repository at the path that's moved to the trash by the restore, if any.
+	Repo *ImpactedResource `json:"repo"`
+	// LostPullReqs are the numbers of the pull requests that don't exist in the backup.
+	LostPullReqs []int64 `json:"lost_pullreqs"`
+	// RevertedPullReqs are the numbers of the pull requests that changed after the backup was taken.
+	RevertedPullReqs []int64 `json:"reverted_pullreqs"`
+	// RestoredPullReqs is the number of pull requests in the backup.
+	RestoredPullReqs int `json:"restored_pullreqs"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// ChatIntegration posts messages about repository activity to a chat provider.
+// Unlike webhooks, the payload is formatted specifically for the configured provider.
+type ChatIntegration struct {
+	ID         int64              `json:"id"`
+	Version    int64              `json:"version"`
+	ParentID   int64              `json:"parent_id"`
+	ParentType enum.WebhookParent `json:"parent_type"`
+	CreatedBy  int64              `json:"created_by"`
+	Created    int64              `json:"created"`
+	Updated    int64              `json:"updated"`
+
+	DisplayName string                        `json:"display_name"`
+	Provider    enum.ChatProvider             `json:"provider"`
+	URL         string                        `json:"-"` // encrypted, the incoming webhook url acts as a credential.
+	Enabled     bool                          `json:"enabled"`
+	Triggers    []enum.ChatIntegrationTrigger `json:"triggers"`
+}
+
+// IsTriggeredBy returns true in case the integration is enabled and registered for the trigger.
+// An empty list of triggers means the integration is registered for all triggers.
+func (i *ChatIntegration) IsTriggeredBy(trigger enum.ChatIntegrationTrigger) bool {
+	if !i.Enabled {
+		return false
+	}
+
+	if len(i.Triggers) == 0 {
+		return true
+	}
+
+	for _, t := range i.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+
+	return false
+}
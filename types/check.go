@@ -16,6 +16,7 @@ package types
 
 import (
 	"encoding/json"
+	"path"
 
 	"github.com/harness/gitness/types/enum"
 )
@@ -60,6 +61,24 @@ type ReqCheck struct {
 	AddedBy PrincipalInfo `json:"added_by"`
 }
 
+// Matches returns true if the required status check applies to the provided branch.
+// The branch pattern is either the exact branch name or a glob pattern, e.g. "release/*".
+func (r *ReqCheck) Matches(branch string) bool {
+	if r.BranchPattern == branch {
+		return true
+	}
+
+	ok, _ := path.Match(r.BranchPattern, branch)
+	return ok
+}
+
+// ReqCheckViolation describes a required status check that is not satisfied for a commit.
+type ReqCheckViolation struct {
+	CheckUID      string           `json:"check_uid"`
+	BranchPattern string           `json:"branch_pattern"`
+	Status        enum.CheckStatus `json:"status,omitempty"` // empty if the check wasn't reported at all
+}
+
 type CheckPayloadText struct {
 	Details string `json:"details"`
 }
@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "testing"
+
+func TestReqCheckMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		branch  string
+		want    bool
+	}{
+		{"main", "main", true},
+		{"main", "master", false},
+		{"release/*", "release/1.0", true},
+		{"release/*", "release/1.0/hotfix", false},
+		{"*", "feature", true},
+		{"*", "feature/x", false},
+		{"[", "[", true},
+		{"[", "main", false},
+	}
+
+	for _, test := range tests {
+		reqCheck := &ReqCheck{BranchPattern: test.pattern}
+		if got := reqCheck.Matches(test.branch); got != test.want {
+			t.Errorf("Want pattern %q matching branch %q to be %t, got %t", test.pattern, test.branch, test.want, got)
+		}
+	}
+}
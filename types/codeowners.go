@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// CodeOwnersSummary maps the changed files of a pull request to their owners as defined by the CODEOWNERS file
+// of the target branch, together with the approvals of the owners.
+type CodeOwnersSummary struct {
+	// File is the path of the CODEOWNERS file, empty if the target branch has none.
+	File    string            `json:"file"`
+	Entries []CodeOwnersEntry `json:"entries"`
+	// UnownedPaths are the changed files that don't match any rule of the CODEOWNERS file.
+	UnownedPaths []string `json:"unowned_paths"`
+}
+
+// CodeOwnersEntry groups the changed files owned by the same rule of the CODEOWNERS file.
+type CodeOwnersEntry struct {
+	Pattern    string      `json:"pattern"`
+	LineNumber int         `json:"line_number"`
+	Paths      []string    `json:"paths"`
+	Owners     []CodeOwner `json:"owners"`
+	// Approved indicates that at least one of the owners approved the pull request.
+	Approved bool `json:"approved"`
+}
+
+// CodeOwner is an owner of a CODEOWNERS rule.
+type CodeOwner struct {
+	// Identifier is the owner as written in the CODEOWNERS file, e.g. "@uid" or an email address.
+	Identifier string `json:"identifier"`
+	// Principal is the user or user group of the owner, nil if it couldn't be resolved.
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+	// ApprovedBy are the reviewers that approved on behalf of the owner.
+	// For user groups these are the members that approved.
+	ApprovedBy []PrincipalInfo `json:"approved_by"`
+}
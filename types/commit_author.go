@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// CommitAuthorRewrite is the audit record of a commit created via the API whose requested author
+// got replaced by the identity of the acting principal.
+type CommitAuthorRewrite struct {
+	ID             int64                      `db:"commit_author_rewrite_id"              json:"id"`
+	RepoID         int64                      `db:"commit_author_rewrite_repo_id"         json:"-"`
+	SHA            string                     `db:"commit_author_rewrite_sha"             json:"sha"`
+	Operation      enum.CommitAuthorOperation `db:"commit_author_rewrite_operation"       json:"operation"`
+	PrincipalID    int64                      `db:"commit_author_rewrite_principal_id"    json:"principal_id"`
+	RequestedName  string                     `db:"commit_author_rewrite_requested_name"  json:"requested_name"`
+	RequestedEmail string                     `db:"commit_author_rewrite_requested_email" json:"requested_email"`
+	AuthorName     string                     `db:"commit_author_rewrite_author_name"     json:"author_name"`
+	AuthorEmail    string                     `db:"commit_author_rewrite_author_email"    json:"author_email"`
+	Created        int64                      `db:"commit_author_rewrite_created"         json:"created"`
+}
+
+// CommitAuthorRewriteFilter stores commit author rewrite query parameters.
+type CommitAuthorRewriteFilter struct {
+	Page int    `json:"page"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+}
@@ -17,6 +17,8 @@ package types
 import (
 	"time"
 
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
@@ -31,10 +33,24 @@ type Config struct {
 	Debug bool `envconfig:"GITNESS_DEBUG"`
 	Trace bool `envconfig:"GITNESS_TRACE"`
 
+	// ConfigReloadInterval is the interval in which the env file is checked for changes to apply the config values
+	// that can be changed at runtime (the log level and the tls certificate). Set to zero to only reload on SIGHUP.
+	ConfigReloadInterval time.Duration `envconfig:"GITNESS_CONFIG_RELOAD_INTERVAL" default:"1m"`
+
 	// GracefulShutdownTime defines the max time we wait when shutting down a server.
 	// 5min should be enough for most git clones to complete.
 	GracefulShutdownTime time.Duration `envconfig:"GITNESS_GRACEFUL_SHUTDOWN_TIME" default:"300s"`
 
+	// Drain defines how the work in flight is drained before the server shuts down
+	// (new git operations and pipeline dispatches are rejected while draining).
+	Drain struct {
+		// GitTimeout is the max time we wait for git operations in flight (including their hooks).
+		GitTimeout time.Duration `envconfig:"GITNESS_DRAIN_GIT_TIMEOUT" default:"120s"`
+		// EventsTimeout is the max time we wait for event handlers in flight.
+		EventsTimeout time.Duration `envconfig:"GITNESS_DRAIN_EVENTS_TIMEOUT" default:"30s"`
+	}
+
+	// UserSignupEnabled is the default of the user_signup_enabled instance setting.
 	UserSignupEnabled   bool `envconfig:"GITNESS_USER_SIGNUP_ENABLED" default:"true"`
 	NestedSpacesEnabled bool `envconfig:"GITNESS_NESTED_SPACES_ENABLED" default:"false"`
 
@@ -43,6 +59,16 @@ type Config struct {
 		ServiceName string `envconfig:"GITNESS_PROFILER_SERVICE_NAME" default:"gitness"`
 	}
 
+	// Tracing defines the configuration of the OpenTelemetry tracing.
+	Tracing struct {
+		Enabled     bool   `envconfig:"GITNESS_TRACING_ENABLED"`
+		ServiceName string `envconfig:"GITNESS_TRACING_SERVICE_NAME" default:"gitness"`
+		// Endpoint is the address of the OTLP (gRPC) collector the spans are exported to.
+		Endpoint    string  `envconfig:"GITNESS_TRACING_ENDPOINT" default:"localhost:4317"`
+		Insecure    bool    `envconfig:"GITNESS_TRACING_INSECURE"`
+		SampleRatio float64 `envconfig:"GITNESS_TRACING_SAMPLE_RATIO" default:"1"`
+	}
+
 	// URL defines the URLs via which the different parts of the service are reachable by.
 	URL struct {
 		// Base is used to generate external facing URLs in case they aren't provided explicitly.
@@ -83,12 +109,90 @@ type Config struct {
 	// Git defines the git configuration parameters
 	Git struct {
 		DefaultBranch string `envconfig:"GITNESS_GIT_DEFAULTBRANCH" default:"main"`
+		// SHA256Enabled allows creating repositories using the sha256 object format.
+		// NOTE: experimental - not all read operations support sha256 object ids yet.
+		SHA256Enabled bool `envconfig:"GITNESS_GIT_SHA256_ENABLED" default:"false"`
+
+		// DiffMaxFiles is the max number of files of a diff whose patch is returned,
+		// any further file is returned collapsed (without patch). 0 disables the limit.
+		// NOTE: The diff limits are defaults that can be changed at runtime via the instance settings.
+		DiffMaxFiles int `envconfig:"GITNESS_GIT_DIFF_MAX_FILES" default:"300"`
+		// DiffMaxFileLines is the max number of patch lines of a single file,
+		// bigger files are returned collapsed (without patch). 0 disables the limit.
+		DiffMaxFileLines int `envconfig:"GITNESS_GIT_DIFF_MAX_FILE_LINES" default:"10000"`
+
+		// SlowOperationThreshold is the duration after which a git http operation (clone, fetch, push)
+		// is logged and kept as slow operation.
+		SlowOperationThreshold time.Duration `envconfig:"GITNESS_GIT_SLOW_OPERATION_THRESHOLD" default:"30s"`
+		// SlowOperationsMax is the max number of slow operations kept in memory by each instance.
+		SlowOperationsMax int `envconfig:"GITNESS_GIT_SLOW_OPERATIONS_MAX" default:"100"`
+	}
+
+	// MergeChecks defines the configuration of the external merge checks of pull requests.
+	MergeChecks struct {
+		// DefaultTimeout is the timeout of merge check providers that don't define their own.
+		DefaultTimeout time.Duration `envconfig:"GITNESS_MERGE_CHECKS_DEFAULT_TIMEOUT" default:"10s"`
+	}
+
+	// GithookPlugins defines the server side git hook plugins that can be enabled per repository.
+	GithookPlugins struct {
+		// ConfigPath is the path of a JSON file containing the definitions of the plugins.
+		ConfigPath string `envconfig:"GITNESS_GITHOOK_PLUGINS_CONFIG_PATH"`
+		// DefaultTimeout is the timeout of plugins that don't define their own.
+		DefaultTimeout time.Duration `envconfig:"GITNESS_GITHOOK_PLUGINS_DEFAULT_TIMEOUT" default:"10s"`
+	}
+
+	// Trash defines the configuration of deleted spaces and repositories.
+	Trash struct {
+		// RetentionTime is the duration after which deleted spaces and repositories are purged permanently.
+		RetentionTime time.Duration `envconfig:"GITNESS_TRASH_RETENTION_TIME" default:"168h"` // 7 days
 	}
 
 	// Encrypter defines the parameters for the encrypter
 	Encrypter struct {
 		Secret       string `envconfig:"GITNESS_ENCRYPTER_SECRET"` // key used for encryption
 		MixedContent bool   `envconfig:"GITNESS_ENCRYPTER_MIXED_CONTENT"`
+
+		// Keys contains the versioned master keys used for envelope encryption (version:key,version:key).
+		// Keys of previous versions have to be kept until all values are re-encrypted with the current key.
+		Keys map[string]string `envconfig:"GITNESS_ENCRYPTER_KEYS"`
+		// KeyVersion is the version of the master key used to encrypt new values.
+		KeyVersion string `envconfig:"GITNESS_ENCRYPTER_KEY_VERSION"`
+	}
+
+	// Secrets defines the external secret managers that can be used to store pipeline secrets.
+	Secrets struct {
+		// LeaseTTL is the max duration a value fetched from an external secret manager is reused for.
+		LeaseTTL time.Duration `envconfig:"GITNESS_SECRETS_LEASE_TTL" default:"60s"`
+
+		Vault struct {
+			Address   string `envconfig:"GITNESS_SECRETS_VAULT_ADDRESS"`
+			Token     string `envconfig:"GITNESS_SECRETS_VAULT_TOKEN"`
+			Namespace string `envconfig:"GITNESS_SECRETS_VAULT_NAMESPACE"`
+		}
+
+		AWS struct {
+			Enabled  bool   `envconfig:"GITNESS_SECRETS_AWS_ENABLED"`
+			Region   string `envconfig:"GITNESS_SECRETS_AWS_REGION"`
+			Endpoint string `envconfig:"GITNESS_SECRETS_AWS_ENDPOINT"`
+		}
+
+		GCP struct {
+			Enabled bool `envconfig:"GITNESS_SECRETS_GCP_ENABLED"`
+		}
+	}
+
+	// Metrics defines the configuration of the prometheus metrics endpoint.
+	Metrics struct {
+		Enabled bool `envconfig:"GITNESS_METRICS_ENABLED" default:"true"`
+		// RequireAdmin restricts access to the metrics endpoint to admin users.
+		// If disabled, the metrics endpoint is publicly accessible.
+		RequireAdmin bool `envconfig:"GITNESS_METRICS_REQUIRE_ADMIN" default:"true"`
+	}
+
+	Health struct {
+		// Timeout is the maximum duration of the check of a single dependency.
+		Timeout time.Duration `envconfig:"GITNESS_HEALTH_TIMEOUT" default:"5s"`
 	}
 
 	// Server defines the server configuration parameters.
@@ -97,6 +201,15 @@ type Config struct {
 		HTTP struct {
 			Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 			Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
+
+			// TLS defines the certificate served by the http server (ignored if Acme is enabled).
+			TLS struct {
+				CertFile string `envconfig:"GITNESS_HTTP_TLS_CERT_FILE"`
+				KeyFile  string `envconfig:"GITNESS_HTTP_TLS_KEY_FILE"`
+				// ReloadInterval is the interval in which the certificate files are checked for changes.
+				// Set to zero to only reload the certificate on SIGHUP.
+				ReloadInterval time.Duration `envconfig:"GITNESS_HTTP_TLS_RELOAD_INTERVAL" default:"1m"`
+			}
 		}
 
 		// Acme defines Acme configuration parameters.
@@ -106,6 +219,12 @@ type Config struct {
 			Email   bool   `envconfig:"GITNESS_ACME_EMAIL"`
 			Host    string `envconfig:"GITNESS_ACME_HOST"`
 		}
+
+		// GRPC defines the configuration of the gRPC API used for internal service-to-service integrations.
+		GRPC struct {
+			Enabled bool `envconfig:"GITNESS_GRPC_ENABLED" default:"false"`
+			Port    int  `envconfig:"GITNESS_GRPC_PORT" default:"3002"`
+		}
 	}
 
 	// CI defines configuration related to build executions.
@@ -119,8 +238,23 @@ type Config struct {
 
 	// Database defines the database configuration parameters.
 	Database struct {
+		// Driver is the database driver, one of sqlite3, postgres or mysql (MySQL 8.0 or MariaDB 10.5 or newer).
 		Driver     string `envconfig:"GITNESS_DATABASE_DRIVER" default:"sqlite3"`
 		Datasource string `envconfig:"GITNESS_DATABASE_DATASOURCE" default:"database.sqlite3"`
+
+		// SkipContractMigrations only applies expand migrations on startup, allowing replicas of the previous
+		// version to keep running during an upgrade. Contract migrations are applied with "migrate contract"
+		// once all replicas are upgraded.
+		SkipContractMigrations bool `envconfig:"GITNESS_DATABASE_SKIP_CONTRACT_MIGRATIONS"`
+
+		// ReplicaDatasources are the datasources of read replicas used for read-only API requests (postgres only).
+		ReplicaDatasources []string `envconfig:"GITNESS_DATABASE_REPLICA_DATASOURCES"`
+		// ReplicaMaxLag is the max replication lag of a replica to be used for reads.
+		ReplicaMaxLag time.Duration `envconfig:"GITNESS_DATABASE_REPLICA_MAX_LAG" default:"2s"`
+		// ReplicaCheckInterval is the interval in which the replication lag of the replicas is verified.
+		ReplicaCheckInterval time.Duration `envconfig:"GITNESS_DATABASE_REPLICA_CHECK_INTERVAL" default:"5s"`
+		// ReplicaStickiness is the duration a client reads from the primary after a write (to read its own writes).
+		ReplicaStickiness time.Duration `envconfig:"GITNESS_DATABASE_REPLICA_STICKINESS" default:"5s"`
 	}
 
 	// Token defines token configuration parameters.
@@ -129,6 +263,14 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OAuth configures gitness acting as OAuth2 / OpenID Connect provider for third party apps.
+	OAuth struct {
+		// AccessTokenLifetime is the lifetime of access tokens issued to OAuth apps.
+		AccessTokenLifetime time.Duration `envconfig:"GITNESS_OAUTH_ACCESS_TOKEN_LIFETIME" default:"8h"`
+		// CodeLifetime is the time an app has to exchange an authorization code for an access token.
+		CodeLifetime time.Duration `envconfig:"GITNESS_OAUTH_CODE_LIFETIME" default:"10m"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -137,6 +279,46 @@ type Config struct {
 			Endpoint  string `envconfig:"GITNESS_LOGS_S3_ENDPOINT"`
 			PathStyle bool   `envconfig:"GITNESS_LOGS_S3_PATH_STYLE"`
 		}
+
+		// BlobStore stores logs in the configured blob store instead of the database (ignored if S3 is configured).
+		BlobStore bool `envconfig:"GITNESS_LOGS_BLOB_STORE"`
+
+		// RetentionTime is the duration after which the logs of pipeline steps are purged (0 keeps logs forever).
+		// It can be overridden per space using the retention setting.
+		RetentionTime time.Duration `envconfig:"GITNESS_LOGS_RETENTION_TIME" default:"0"`
+	}
+
+	// BlobStore defines the storage used for non-git data.
+	BlobStore struct {
+		// Provider is the name of the blob store provider (filesystem, s3, gcs or azure).
+		Provider        blob.Provider `envconfig:"GITNESS_BLOB_STORE_PROVIDER"          default:"filesystem"`
+		Bucket          string        `envconfig:"GITNESS_BLOB_STORE_BUCKET"`
+		Prefix          string        `envconfig:"GITNESS_BLOB_STORE_PREFIX"`
+		SignedURLExpiry time.Duration `envconfig:"GITNESS_BLOB_STORE_SIGNED_URL_EXPIRY" default:"1h"`
+
+		// Root is the directory used by the filesystem provider (defaults to ~/.gitness/blobs).
+		Root string `envconfig:"GITNESS_BLOB_STORE_ROOT"`
+
+		S3 struct {
+			Region    string `envconfig:"GITNESS_BLOB_STORE_S3_REGION"`
+			Endpoint  string `envconfig:"GITNESS_BLOB_STORE_S3_ENDPOINT"`
+			PathStyle bool   `envconfig:"GITNESS_BLOB_STORE_S3_PATH_STYLE"`
+			// ServerSideEncryption is the server-side encryption of new objects (AES256 or aws:kms).
+			ServerSideEncryption blob.S3ServerSideEncryption `envconfig:"GITNESS_BLOB_STORE_S3_SSE"`
+			KMSKeyID             string                      `envconfig:"GITNESS_BLOB_STORE_S3_SSE_KMS_KEY_ID"`
+		}
+
+		GCS struct {
+			KeyPath    string `envconfig:"GITNESS_BLOB_STORE_GCS_KEY_PATH"`
+			KMSKeyName string `envconfig:"GITNESS_BLOB_STORE_GCS_KMS_KEY_NAME"`
+		}
+
+		Azure struct {
+			AccountName     string `envconfig:"GITNESS_BLOB_STORE_AZURE_ACCOUNT_NAME"`
+			AccountKey      string `envconfig:"GITNESS_BLOB_STORE_AZURE_ACCOUNT_KEY"`
+			ServiceURL      string `envconfig:"GITNESS_BLOB_STORE_AZURE_SERVICE_URL"`
+			EncryptionScope string `envconfig:"GITNESS_BLOB_STORE_AZURE_ENCRYPTION_SCOPE"`
+		}
 	}
 
 	// Cors defines http cors parameters
@@ -206,10 +388,27 @@ type Config struct {
 		Namespace             string      `envconfig:"GITNESS_EVENTS_NAMESPACE"                default:"gitness"`
 		MaxStreamLength       int64       `envconfig:"GITNESS_EVENTS_MAX_STREAM_LENGTH"        default:"10000"`
 		ApproxMaxStreamLength bool        `envconfig:"GITNESS_EVENTS_APPROX_MAX_STREAM_LENGTH" default:"true"`
+		NatsURL               string      `envconfig:"GITNESS_EVENTS_NATS_URL"                 default:"nats://localhost:4222"`
+
+		// Outbox configures the transactional outbox used to reliably report events.
+		// Reported events are persisted in the database and delivered to the streams by a dispatcher.
+		Outbox struct {
+			Enabled      bool          `envconfig:"GITNESS_EVENTS_OUTBOX_ENABLED"       default:"true"`
+			PollInterval time.Duration `envconfig:"GITNESS_EVENTS_OUTBOX_POLL_INTERVAL" default:"5s"`
+			BatchSize    int           `envconfig:"GITNESS_EVENTS_OUTBOX_BATCH_SIZE"    default:"100"`
+		}
+
+		// DeadLetters configures the handling of events that readers failed to process within the max retries.
+		// Dead-lettered events are persisted in the database and can be redelivered via the admin API.
+		DeadLetters struct {
+			Enabled bool `envconfig:"GITNESS_EVENTS_DEAD_LETTERS_ENABLED" default:"true"`
+			// RetentionTime is the duration after which dead letters are purged from the DB.
+			RetentionTime time.Duration `envconfig:"GITNESS_EVENTS_DEAD_LETTERS_RETENTION_TIME" default:"720h"` // 30 days
+		}
 	}
 
 	Lock struct {
-		// Provider is a name of distributed lock service like redis, memory, file etc...
+		// Provider is a name of distributed lock service like redis, postgres, memory, file etc...
 		Provider      lock.Provider `envconfig:"GITNESS_LOCK_PROVIDER"          default:"inmemory"`
 		Expiry        time.Duration `envconfig:"GITNESS_LOCK_EXPIRE"            default:"8s"`
 		Tries         int           `envconfig:"GITNESS_LOCK_TRIES"             default:"32"`
@@ -223,7 +422,7 @@ type Config struct {
 	}
 
 	PubSub struct {
-		// Provider is a name of distributed lock service like redis, memory, file etc...
+		// Provider is a name of distributed lock service like redis, postgres, memory, file etc...
 		Provider pubsub.Provider `envconfig:"GITNESS_PUBSUB_PROVIDER"                default:"inmemory"`
 		// AppNamespace is just service app prefix to avoid conflicts on channel definition
 		AppNamespace string `envconfig:"GITNESS_PUBSUB_APP_NAMESPACE"                default:"gitness"`
@@ -234,6 +433,15 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	// Cache defines the read-through cache of frequently read entities (repositories, spaces and principals).
+	// Changes are evicted from the caches of all instances using pubsub, the TTL bounds the staleness otherwise
+	// (e.g. the paths of repositories and spaces after one of their ancestor spaces got moved).
+	Cache struct {
+		// Provider is the name of the cache backend (inmemory or redis).
+		Provider cache.Provider `envconfig:"GITNESS_CACHE_PROVIDER" default:"inmemory"`
+		TTL      time.Duration  `envconfig:"GITNESS_CACHE_TTL"      default:"1m"`
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -249,13 +457,31 @@ type Config struct {
 		UserAgentIdentity string `envconfig:"GITNESS_WEBHOOK_USER_AGENT_IDENTITY" default:"Gitness"`
 		// HeaderIdentity specifies the identity used for headers in webhook calls (e.g. X-Gitness-Trigger, ...).
 		// NOTE: If no value is provided, the UserAgentIdentity will be used.
-		HeaderIdentity      string `envconfig:"GITNESS_WEBHOOK_HEADER_IDENTITY"`
-		Concurrency         int    `envconfig:"GITNESS_WEBHOOK_CONCURRENCY" default:"4"`
-		MaxRetries          int    `envconfig:"GITNESS_WEBHOOK_MAX_RETRIES" default:"3"`
-		AllowPrivateNetwork bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_PRIVATE_NETWORK" default:"false"`
-		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
+		HeaderIdentity string `envconfig:"GITNESS_WEBHOOK_HEADER_IDENTITY"`
+		Concurrency    int    `envconfig:"GITNESS_WEBHOOK_CONCURRENCY" default:"4"`
+		MaxRetries     int    `envconfig:"GITNESS_WEBHOOK_MAX_RETRIES" default:"3"`
+		// AllowPrivateNetwork and AllowLoopback are the defaults of the outbound policy of the instance settings.
+		AllowPrivateNetwork bool `envconfig:"GITNESS_WEBHOOK_ALLOW_PRIVATE_NETWORK" default:"false"`
+		AllowLoopback       bool `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
+		// AllowRules and DenyRules are the defaults of the outbound allow and deny rules
+		// (IP addresses, CIDR blocks, host names or wildcard host names like "*.example.com").
+		// They apply to webhooks, merge checks, chat integrations and repository imports.
+		AllowRules []string `envconfig:"GITNESS_WEBHOOK_ALLOW_RULES"`
+		DenyRules  []string `envconfig:"GITNESS_WEBHOOK_DENY_RULES"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
+		// RetentionMaxExecutions is the maximum number of executions kept per webhook (0 means no limit).
+		// Both retention values can be overridden per space using the retention setting.
+		RetentionMaxExecutions int `envconfig:"GITNESS_WEBHOOK_RETENTION_MAX_EXECUTIONS" default:"0"`
+	}
+
+	// Proxy defines the proxy of outbound requests (webhooks, merge checks, chat integrations and imports).
+	// If none of the values are set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.
+	Proxy struct {
+		HTTP  string `envconfig:"GITNESS_PROXY_HTTP"`
+		HTTPS string `envconfig:"GITNESS_PROXY_HTTPS"`
+		// NoProxy is a comma-separated list of hosts, domains and CIDR blocks that are connected to directly.
+		NoProxy string `envconfig:"GITNESS_PROXY_NO_PROXY"`
 	}
 
 	Trigger struct {
@@ -268,4 +494,127 @@ type Config struct {
 		Endpoint string `envconfig:"GITNESS_METRIC_ENDPOINT" default:"https://stats.drone.ci/api/v1/gitness"`
 		Token    string `envconfig:"GITNESS_METRIC_TOKEN"`
 	}
+
+	// SMTP defines the mail server used for sending emails.
+	SMTP struct {
+		Host     string `envconfig:"GITNESS_SMTP_HOST"`
+		Port     int    `envconfig:"GITNESS_SMTP_PORT" default:"587"`
+		Username string `envconfig:"GITNESS_SMTP_USERNAME"`
+		Password string `envconfig:"GITNESS_SMTP_PASSWORD"`
+		FromMail string `envconfig:"GITNESS_SMTP_FROM_MAIL"`
+		// Insecure skips the verification of the TLS certificate of the mail server.
+		Insecure bool `envconfig:"GITNESS_SMTP_INSECURE" default:"false"`
+	}
+
+	// Scanning defines the dependency and container scans of repositories.
+	Scanning struct {
+		// ConfigPath is the path of a JSON file containing the definitions of the scanners.
+		// Scans are disabled if no scanners are configured.
+		ConfigPath string `envconfig:"GITNESS_SCANNING_CONFIG_PATH"`
+		// DefaultTimeout is the timeout of scanners that don't define their own.
+		DefaultTimeout time.Duration `envconfig:"GITNESS_SCANNING_DEFAULT_TIMEOUT" default:"5m"`
+		// FailSeverity is the min severity of unsuppressed findings that fail the status check of a scan.
+		FailSeverity string `envconfig:"GITNESS_SCANNING_FAIL_SEVERITY" default:"high"`
+		// MaxFileSize is the max size of a single file provided to the scanners.
+		MaxFileSize int `envconfig:"GITNESS_SCANNING_MAX_FILE_SIZE" default:"5242880"`
+		Concurrency int `envconfig:"GITNESS_SCANNING_CONCURRENCY" default:"2"`
+		MaxRetries  int `envconfig:"GITNESS_SCANNING_MAX_RETRIES" default:"1"`
+	}
+
+	SBOM struct {
+		// GeneratorCommand is the executable (and its arguments) generating the SBOMs of tags.
+		// SBOM generation is disabled if no generator is configured.
+		GeneratorCommand []string `envconfig:"GITNESS_SBOM_GENERATOR_COMMAND"`
+		Formats          []string `envconfig:"GITNESS_SBOM_FORMATS" default:"spdx,cyclonedx"`
+		// Paths are the files of the repository provided to the generator, the last element can be a glob pattern.
+		Paths []string `envconfig:"GITNESS_SBOM_PATHS" default:"go.mod,go.sum,package.json,package-lock.json,yarn.lock,requirements*.txt,Pipfile.lock,poetry.lock,pom.xml,build.gradle,Cargo.lock,Gemfile.lock,composer.lock,Dockerfile"` //nolint:lll // struct tags can't be multiline
+		// Timeout is the max duration of generating a single SBOM.
+		Timeout time.Duration `envconfig:"GITNESS_SBOM_TIMEOUT" default:"10m"`
+		// MaxFileSize is the max size of a single file provided to the generator.
+		MaxFileSize int `envconfig:"GITNESS_SBOM_MAX_FILE_SIZE" default:"5242880"`
+		// MaxSize is the max size of a generated SBOM document.
+		MaxSize     int `envconfig:"GITNESS_SBOM_MAX_SIZE" default:"52428800"`
+		Concurrency int `envconfig:"GITNESS_SBOM_CONCURRENCY" default:"2"`
+		MaxRetries  int `envconfig:"GITNESS_SBOM_MAX_RETRIES" default:"1"`
+	}
+
+	Backup struct {
+		// Enabled turns on the scheduled backups of all repositories.
+		Enabled bool   `envconfig:"GITNESS_BACKUP_ENABLED" default:"false"`
+		Cron    string `envconfig:"GITNESS_BACKUP_CRON" default:"0 2 * * *"` // At 02:00 every day.
+		// RetentionCount is the number of successful backups kept per repository.
+		RetentionCount int `envconfig:"GITNESS_BACKUP_RETENTION_COUNT" default:"7"`
+		// Timeout is the max duration of backing up or restoring a single repository.
+		Timeout    time.Duration `envconfig:"GITNESS_BACKUP_TIMEOUT" default:"1h"`
+		MaxRetries int           `envconfig:"GITNESS_BACKUP_MAX_RETRIES" default:"1"`
+	}
+
+	UploadScan struct {
+		// Backend is the virus scanner of uploaded content, one of clamav or icap.
+		// Uploads aren't scanned if no backend is configured.
+		Backend string `envconfig:"GITNESS_UPLOAD_SCAN_BACKEND"`
+		// Address is the address of clamd (host:port) or the url of the icap service (icap://host[:port]/service).
+		Address string        `envconfig:"GITNESS_UPLOAD_SCAN_ADDRESS"`
+		Timeout time.Duration `envconfig:"GITNESS_UPLOAD_SCAN_TIMEOUT" default:"30s"`
+		// MaxSize is the max size of an upload that can be scanned, larger uploads are rejected.
+		MaxSize int64 `envconfig:"GITNESS_UPLOAD_SCAN_MAX_SIZE" default:"26214400"`
+		// FailOpen accepts uploads that can't be scanned instead of rejecting them.
+		FailOpen bool `envconfig:"GITNESS_UPLOAD_SCAN_FAIL_OPEN"`
+	}
+
+	Attachments struct {
+		// MaxSize is the default max size of files attached to pull request descriptions and comments.
+		// It can be overridden per space or repository with the attachment_max_size setting.
+		MaxSize int64 `envconfig:"GITNESS_ATTACHMENTS_MAX_SIZE" default:"10485760"`
+	}
+
+	Avatar struct {
+		// MaxSize is the max size of uploaded avatar images.
+		MaxSize int64 `envconfig:"GITNESS_AVATAR_MAX_SIZE" default:"1048576"`
+		// Default is the generator of the avatars of principals and spaces without uploaded avatar
+		// (identicon or none).
+		Default string `envconfig:"GITNESS_AVATAR_DEFAULT" default:"identicon"`
+		// CacheDuration is how long avatar images are kept in memory.
+		CacheDuration time.Duration `envconfig:"GITNESS_AVATAR_CACHE_DURATION" default:"10m"`
+	}
+
+	Membership struct {
+		// ExpiryNotice is how long before the expiry of a membership the member is notified about it.
+		ExpiryNotice time.Duration `envconfig:"GITNESS_MEMBERSHIP_EXPIRY_NOTICE" default:"72h"`
+	}
+
+	FileRender struct {
+		// MaxSize is the max size of files that are rendered as rich previews (notebooks, CSV and GeoJSON).
+		MaxSize int64 `envconfig:"GITNESS_FILE_RENDER_MAX_SIZE" default:"5242880"`
+		// MaxRows is the max number of rows returned for CSV and TSV files.
+		MaxRows int `envconfig:"GITNESS_FILE_RENDER_MAX_ROWS" default:"1000"`
+		// CacheDuration is how long rendered files are kept in memory.
+		CacheDuration time.Duration `envconfig:"GITNESS_FILE_RENDER_CACHE_DURATION" default:"10m"`
+	}
+
+	ChatIntegration struct {
+		Concurrency int `envconfig:"GITNESS_CHAT_INTEGRATION_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_CHAT_INTEGRATION_MAX_RETRIES" default:"3"`
+		// RateLimit is the maximum number of messages per minute sent to a single chat integration.
+		RateLimit int `envconfig:"GITNESS_CHAT_INTEGRATION_RATE_LIMIT" default:"20"`
+	}
+
+	Feed struct {
+		Concurrency int `envconfig:"GITNESS_FEED_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_FEED_MAX_RETRIES" default:"3"`
+		// RetentionTime is the duration after which feed events are purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_FEED_RETENTION_TIME" default:"2160h"` // 90 days
+	}
+
+	RefWatch struct {
+		Concurrency int `envconfig:"GITNESS_REF_WATCH_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_REF_WATCH_MAX_RETRIES" default:"3"`
+	}
+
+	Notification struct {
+		// Enabled turns on email notifications, it requires the SMTP host to be configured.
+		Enabled     bool `envconfig:"GITNESS_NOTIFICATION_ENABLED" default:"false"`
+		Concurrency int  `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+		MaxRetries  int  `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
+	}
 }
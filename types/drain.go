@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// DrainStatus is the status of draining an instance before it shuts down.
+type DrainStatus struct {
+	// Instance is the id of the instance the status belongs to.
+	Instance string `json:"instance"`
+	Draining bool   `json:"draining"`
+	Started  int64  `json:"started,omitempty"`
+	// Completed is set once the work in flight completed or the drain timeouts expired.
+	Completed int64 `json:"completed,omitempty"`
+
+	// the number of operations in flight.
+	GitOperations int `json:"git_operations"`
+	GitHooks      int `json:"git_hooks"`
+	EventHandlers int `json:"event_handlers"`
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AccessRequestState defines the state of an access request.
+type AccessRequestState string
+
+func (AccessRequestState) Enum() []interface{} { return toInterfaceSlice(accessRequestStates) }
+func (s AccessRequestState) Sanitize() (AccessRequestState, bool) {
+	return Sanitize(s, GetAllAccessRequestStates)
+}
+func GetAllAccessRequestStates() ([]AccessRequestState, AccessRequestState) {
+	return accessRequestStates, ""
+}
+
+// AccessRequestState enumeration.
+const (
+	AccessRequestStatePending  AccessRequestState = "pending"
+	AccessRequestStateApproved AccessRequestState = "approved"
+	AccessRequestStateDenied   AccessRequestState = "denied"
+)
+
+var accessRequestStates = sortEnum([]AccessRequestState{
+	AccessRequestStatePending,
+	AccessRequestStateApproved,
+	AccessRequestStateDenied,
+})
+
+// AccessRequestParent defines the types of resources access can be requested through.
+type AccessRequestParent string
+
+func (AccessRequestParent) Enum() []interface{} { return toInterfaceSlice(accessRequestParents) }
+
+const (
+	// AccessRequestParentSpace describes access requested for a space.
+	AccessRequestParentSpace AccessRequestParent = "space"
+
+	// AccessRequestParentRepo describes access requested through a repo, for the parent space of the repo.
+	AccessRequestParentRepo AccessRequestParent = "repo"
+)
+
+var accessRequestParents = sortEnum([]AccessRequestParent{
+	AccessRequestParentSpace,
+	AccessRequestParentRepo,
+})
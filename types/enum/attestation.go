@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AttestationStatus defines the verification status of an attestation.
+type AttestationStatus string
+
+func (AttestationStatus) Enum() []interface{} { return toInterfaceSlice(attestationStatuses) }
+func (s AttestationStatus) Sanitize() (AttestationStatus, bool) {
+	return Sanitize(s, GetAllAttestationStatuses)
+}
+func GetAllAttestationStatuses() ([]AttestationStatus, AttestationStatus) {
+	return attestationStatuses, ""
+}
+
+// AttestationStatus enumeration.
+const (
+	// AttestationStatusVerified is an attestation with a valid signature of a trusted key.
+	AttestationStatusVerified AttestationStatus = "verified"
+	// AttestationStatusUnverified is an attestation without a signature of any trusted key.
+	AttestationStatusUnverified AttestationStatus = "unverified"
+	// AttestationStatusInvalid is an attestation with a signature of a trusted key that doesn't match the payload.
+	AttestationStatusInvalid AttestationStatus = "invalid"
+)
+
+var attestationStatuses = sortEnum([]AttestationStatus{
+	AttestationStatusVerified,
+	AttestationStatusUnverified,
+	AttestationStatusInvalid,
+})
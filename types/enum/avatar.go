@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AvatarOwnerType defines the kind of entity an avatar belongs to.
+type AvatarOwnerType string
+
+func (AvatarOwnerType) Enum() []interface{} { return toInterfaceSlice(avatarOwnerTypes) }
+func (s AvatarOwnerType) Sanitize() (AvatarOwnerType, bool) {
+	return Sanitize(s, GetAllAvatarOwnerTypes)
+}
+func GetAllAvatarOwnerTypes() ([]AvatarOwnerType, AvatarOwnerType) {
+	return avatarOwnerTypes, ""
+}
+
+// AvatarOwnerType enumeration.
+const (
+	AvatarOwnerTypePrincipal AvatarOwnerType = "principal"
+	AvatarOwnerTypeSpace     AvatarOwnerType = "space"
+)
+
+var avatarOwnerTypes = sortEnum([]AvatarOwnerType{
+	AvatarOwnerTypePrincipal,
+	AvatarOwnerTypeSpace,
+})
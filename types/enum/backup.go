@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// BackupState represents the state of a repository backup.
+type BackupState string
+
+// BackupState enumeration.
+const (
+	BackupStatePending   BackupState = "pending"
+	BackupStateRunning   BackupState = "running"
+	BackupStateSucceeded BackupState = "succeeded"
+	BackupStateFailed    BackupState = "failed"
+)
+
+var backupStates = sortEnum([]BackupState{
+	BackupStatePending,
+	BackupStateRunning,
+	BackupStateSucceeded,
+	BackupStateFailed,
+})
+
+func (BackupState) Enum() []interface{} { return toInterfaceSlice(backupStates) }
+func (s BackupState) Sanitize() (BackupState, bool) {
+	return Sanitize(s, GetAllBackupStates)
+}
+func GetAllBackupStates() ([]BackupState, BackupState) {
+	return backupStates, ""
+}
+
+// BackupTrigger represents what started a repository backup.
+type BackupTrigger string
+
+// BackupTrigger enumeration.
+const (
+	// BackupTriggerScheduled is a backup taken by the periodic backup job.
+	BackupTriggerScheduled BackupTrigger = "scheduled"
+	// BackupTriggerManual is a backup requested by an admin.
+	BackupTriggerManual BackupTrigger = "manual"
+)
+
+var backupTriggers = sortEnum([]BackupTrigger{
+	BackupTriggerScheduled,
+	BackupTriggerManual,
+})
+
+func (BackupTrigger) Enum() []interface{} { return toInterfaceSlice(backupTriggers) }
+func (t BackupTrigger) Sanitize() (BackupTrigger, bool) {
+	return Sanitize(t, GetAllBackupTriggers)
+}
+func GetAllBackupTriggers() ([]BackupTrigger, BackupTrigger) {
+	return backupTriggers, ""
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ChatProvider defines the chat providers supported by chat integrations.
+type ChatProvider string
+
+func (ChatProvider) Enum() []interface{}              { return toInterfaceSlice(chatProviders) }
+func (s ChatProvider) Sanitize() (ChatProvider, bool) { return Sanitize(s, GetAllChatProviders) }
+func GetAllChatProviders() ([]ChatProvider, ChatProvider) {
+	return chatProviders, "" // No default value
+}
+
+// ChatProvider enumeration.
+const (
+	ChatProviderSlack   ChatProvider = "slack"
+	ChatProviderTeams   ChatProvider = "teams"
+	ChatProviderDiscord ChatProvider = "discord"
+)
+
+var chatProviders = sortEnum([]ChatProvider{
+	ChatProviderSlack,
+	ChatProviderTeams,
+	ChatProviderDiscord,
+})
+
+// ChatIntegrationTrigger defines the events for which chat integrations post messages.
+type ChatIntegrationTrigger string
+
+func (ChatIntegrationTrigger) Enum() []interface{} { return toInterfaceSlice(chatIntegrationTriggers) }
+func (s ChatIntegrationTrigger) Sanitize() (ChatIntegrationTrigger, bool) {
+	return Sanitize(s, GetAllChatIntegrationTriggers)
+}
+func GetAllChatIntegrationTriggers() ([]ChatIntegrationTrigger, ChatIntegrationTrigger) {
+	return chatIntegrationTriggers, "" // No default value
+}
+
+const (
+	// ChatIntegrationTriggerPush gets triggered when a branch gets created or updated.
+	ChatIntegrationTriggerPush ChatIntegrationTrigger = "push"
+	// ChatIntegrationTriggerPullReqCreated gets triggered when a pull request gets created.
+	ChatIntegrationTriggerPullReqCreated ChatIntegrationTrigger = "pullreq_created"
+	// ChatIntegrationTriggerPullReqMerged gets triggered when a pull request gets merged.
+	ChatIntegrationTriggerPullReqMerged ChatIntegrationTrigger = "pullreq_merged"
+	// ChatIntegrationTriggerPullReqClosed gets triggered when a pull request gets closed without merging.
+	ChatIntegrationTriggerPullReqClosed ChatIntegrationTrigger = "pullreq_closed"
+	// ChatIntegrationTriggerPullReqReviewReminder gets triggered when a requested review is overdue.
+	ChatIntegrationTriggerPullReqReviewReminder ChatIntegrationTrigger = "pullreq_review_reminder"
+	// ChatIntegrationTriggerPipelineSucceeded gets triggered when a pipeline execution succeeds.
+	ChatIntegrationTriggerPipelineSucceeded ChatIntegrationTrigger = "pipeline_succeeded"
+	// ChatIntegrationTriggerPipelineFailed gets triggered when a pipeline execution fails.
+	ChatIntegrationTriggerPipelineFailed ChatIntegrationTrigger = "pipeline_failed"
+)
+
+var chatIntegrationTriggers = sortEnum([]ChatIntegrationTrigger{
+	ChatIntegrationTriggerPush,
+	ChatIntegrationTriggerPullReqCreated,
+	ChatIntegrationTriggerPullReqMerged,
+	ChatIntegrationTriggerPullReqClosed,
+	ChatIntegrationTriggerPullReqReviewReminder,
+	ChatIntegrationTriggerPipelineSucceeded,
+	ChatIntegrationTriggerPipelineFailed,
+})
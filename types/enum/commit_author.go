@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CommitAuthorOperation defines the API operation that created a commit.
+type CommitAuthorOperation string
+
+func (CommitAuthorOperation) Enum() []interface{} { return toInterfaceSlice(commitAuthorOperations) }
+
+// CommitAuthorOperation enumeration.
+const (
+	CommitAuthorOperationCommitFiles CommitAuthorOperation = "commit_files"
+	CommitAuthorOperationMerge       CommitAuthorOperation = "merge"
+)
+
+var commitAuthorOperations = sortEnum([]CommitAuthorOperation{
+	CommitAuthorOperationCommitFiles,
+	CommitAuthorOperationMerge,
+})
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ContributionType defines the kind of activity that counts as contribution of a user.
+type ContributionType string
+
+func (ContributionType) Enum() []interface{} { return toInterfaceSlice(contributionTypes) }
+
+// ContributionType enumeration.
+const (
+	// ContributionTypePullReq is a pull request opened by the user.
+	ContributionTypePullReq ContributionType = "pullreq"
+	// ContributionTypeReview is a review submitted by the user.
+	ContributionTypeReview ContributionType = "review"
+	// ContributionTypeComment is a pull request comment of the user.
+	ContributionTypeComment ContributionType = "comment"
+	// ContributionTypePush is a push of a branch or tag by the user.
+	ContributionTypePush ContributionType = "push"
+)
+
+var contributionTypes = sortEnum([]ContributionType{
+	ContributionTypePullReq,
+	ContributionTypeReview,
+	ContributionTypeComment,
+	ContributionTypePush,
+})
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// FeedEventType defines the type of repository activity shown in the personal feed of users.
+type FeedEventType string
+
+func (FeedEventType) Enum() []interface{} { return toInterfaceSlice(feedEventTypes) }
+
+// FeedEventType enumeration.
+const (
+	FeedEventTypeBranchCreated   FeedEventType = "branch_created"
+	FeedEventTypeBranchUpdated   FeedEventType = "branch_updated"
+	FeedEventTypeBranchDeleted   FeedEventType = "branch_deleted"
+	FeedEventTypeTagCreated      FeedEventType = "tag_created"
+	FeedEventTypePullReqCreated  FeedEventType = "pullreq_created"
+	FeedEventTypePullReqMerged   FeedEventType = "pullreq_merged"
+	FeedEventTypePullReqClosed   FeedEventType = "pullreq_closed"
+	FeedEventTypePullReqReopened FeedEventType = "pullreq_reopened"
+	FeedEventTypePullReqComment  FeedEventType = "pullreq_comment"
+)
+
+var feedEventTypes = sortEnum([]FeedEventType{
+	FeedEventTypeBranchCreated,
+	FeedEventTypeBranchUpdated,
+	FeedEventTypeBranchDeleted,
+	FeedEventTypeTagCreated,
+	FeedEventTypePullReqCreated,
+	FeedEventTypePullReqMerged,
+	FeedEventTypePullReqClosed,
+	FeedEventTypePullReqReopened,
+	FeedEventTypePullReqComment,
+})
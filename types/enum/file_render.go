@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// FileRenderType defines the kind of rich preview rendered for a file.
+type FileRenderType string
+
+func (FileRenderType) Enum() []interface{}                      { return toInterfaceSlice(fileRenderTypes) }
+func (t FileRenderType) Sanitize() (FileRenderType, bool)       { return Sanitize(t, GetAllFileRenderTypes) }
+func GetAllFileRenderTypes() ([]FileRenderType, FileRenderType) { return fileRenderTypes, "" }
+
+// FileRenderType enumeration.
+const (
+	FileRenderTypeNotebook FileRenderType = "notebook"
+	FileRenderTypeCSV      FileRenderType = "csv"
+	FileRenderTypeGeoJSON  FileRenderType = "geojson"
+)
+
+var fileRenderTypes = sortEnum([]FileRenderType{
+	FileRenderTypeNotebook,
+	FileRenderTypeCSV,
+	FileRenderTypeGeoJSON,
+})
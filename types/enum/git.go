@@ -87,3 +87,51 @@ func (o TagSortOption) String() string {
 		return undefined
 	}
 }
+
+// GitObjectFormat defines the hash algorithm used for the object ids of a repository.
+type GitObjectFormat string
+
+func (GitObjectFormat) Enum() []interface{} { return toInterfaceSlice(gitObjectFormats) }
+func (f GitObjectFormat) Sanitize() (GitObjectFormat, bool) {
+	return Sanitize(f, GetAllGitObjectFormats)
+}
+func GetAllGitObjectFormats() ([]GitObjectFormat, GitObjectFormat) {
+	return gitObjectFormats, GitObjectFormatSHA1
+}
+
+// GitObjectFormat enumeration.
+const (
+	GitObjectFormatSHA1   GitObjectFormat = "sha1"
+	GitObjectFormatSHA256 GitObjectFormat = "sha256"
+)
+
+var gitObjectFormats = sortEnum([]GitObjectFormat{
+	GitObjectFormatSHA1,
+	GitObjectFormatSHA256,
+})
+
+// DiffAlgorithm defines the algorithm used by git to compute diffs.
+type DiffAlgorithm string
+
+func (DiffAlgorithm) Enum() []interface{} { return toInterfaceSlice(diffAlgorithms) }
+func (a DiffAlgorithm) Sanitize() (DiffAlgorithm, bool) {
+	return Sanitize(a, GetAllDiffAlgorithms)
+}
+func GetAllDiffAlgorithms() ([]DiffAlgorithm, DiffAlgorithm) {
+	return diffAlgorithms, ""
+}
+
+// DiffAlgorithm enumeration.
+const (
+	DiffAlgorithmMyers     DiffAlgorithm = "myers"
+	DiffAlgorithmMinimal   DiffAlgorithm = "minimal"
+	DiffAlgorithmPatience  DiffAlgorithm = "patience"
+	DiffAlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+var diffAlgorithms = sortEnum([]DiffAlgorithm{
+	DiffAlgorithmMyers,
+	DiffAlgorithmMinimal,
+	DiffAlgorithmPatience,
+	DiffAlgorithmHistogram,
+})
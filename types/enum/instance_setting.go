@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// InstanceSettingKey defines the key of an instance setting.
+type InstanceSettingKey string
+
+func (InstanceSettingKey) Enum() []interface{} { return toInterfaceSlice(instanceSettingKeys) }
+func (k InstanceSettingKey) Sanitize() (InstanceSettingKey, bool) {
+	return Sanitize(k, GetAllInstanceSettingKeys)
+}
+func GetAllInstanceSettingKeys() ([]InstanceSettingKey, InstanceSettingKey) {
+	return instanceSettingKeys, ""
+}
+
+// InstanceSettingKey enumeration.
+const (
+	// InstanceSettingKeyDefaultVisibility is the visibility of new spaces and repositories created without one.
+	InstanceSettingKeyDefaultVisibility InstanceSettingKey = "default_visibility"
+	// InstanceSettingKeyDiffMaxFileLines is the max number of patch lines of a single file of a diff.
+	InstanceSettingKeyDiffMaxFileLines InstanceSettingKey = "diff_max_file_lines"
+	// InstanceSettingKeyDiffMaxFiles is the max number of files of a diff whose patch is returned.
+	InstanceSettingKeyDiffMaxFiles InstanceSettingKey = "diff_max_files"
+	// InstanceSettingKeyMaintenanceMode rejects all changes (API mutations and git pushes) while enabled.
+	InstanceSettingKeyMaintenanceMode InstanceSettingKey = "maintenance_mode"
+	// InstanceSettingKeyMaintenanceMessage is the message shown to users while the maintenance mode is enabled.
+	InstanceSettingKeyMaintenanceMessage InstanceSettingKey = "maintenance_message"
+	// InstanceSettingKeyOutboundAllowRules exempts matching targets of outbound requests
+	// from the loopback and private network restrictions.
+	InstanceSettingKeyOutboundAllowRules InstanceSettingKey = "outbound_allow_rules"
+	// InstanceSettingKeyOutboundDenyRules blocks outbound requests to matching targets.
+	InstanceSettingKeyOutboundDenyRules InstanceSettingKey = "outbound_deny_rules"
+	// InstanceSettingKeyUserSignupEnabled allows users to sign up on their own.
+	InstanceSettingKeyUserSignupEnabled InstanceSettingKey = "user_signup_enabled"
+	// InstanceSettingKeyWebhookAllowLoopback allows webhooks to target loopback addresses.
+	InstanceSettingKeyWebhookAllowLoopback InstanceSettingKey = "webhook_allow_loopback"
+	// InstanceSettingKeyWebhookAllowPrivateNetwork allows webhooks to target private network addresses.
+	InstanceSettingKeyWebhookAllowPrivateNetwork InstanceSettingKey = "webhook_allow_private_network"
+)
+
+var instanceSettingKeys = sortEnum([]InstanceSettingKey{
+	InstanceSettingKeyDefaultVisibility,
+	InstanceSettingKeyDiffMaxFileLines,
+	InstanceSettingKeyDiffMaxFiles,
+	InstanceSettingKeyMaintenanceMessage,
+	InstanceSettingKeyMaintenanceMode,
+	InstanceSettingKeyOutboundAllowRules,
+	InstanceSettingKeyOutboundDenyRules,
+	InstanceSettingKeyUserSignupEnabled,
+	InstanceSettingKeyWebhookAllowLoopback,
+	InstanceSettingKeyWebhookAllowPrivateNetwork,
+})
+
+// Visibility defines the visibility of spaces and repositories.
+type Visibility string
+
+func (Visibility) Enum() []interface{}               { return toInterfaceSlice(visibilities) }
+func (v Visibility) Sanitize() (Visibility, bool)    { return Sanitize(v, GetAllVisibilities) }
+func GetAllVisibilities() ([]Visibility, Visibility) { return visibilities, "" }
+
+// Visibility enumeration.
+const (
+	VisibilityPrivate Visibility = "private"
+	VisibilityPublic  Visibility = "public"
+)
+
+var visibilities = sortEnum([]Visibility{
+	VisibilityPrivate,
+	VisibilityPublic,
+})
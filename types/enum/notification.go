@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationDelivery defines how notifications are delivered to a user.
+type NotificationDelivery string
+
+func (NotificationDelivery) Enum() []interface{} { return toInterfaceSlice(notificationDeliveries) }
+func (s NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(s, GetAllNotificationDeliveries)
+}
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryImmediate
+}
+
+// NotificationDelivery enumeration.
+const (
+	// NotificationDeliveryImmediate sends a mail for every notification.
+	NotificationDeliveryImmediate NotificationDelivery = "immediate"
+	// NotificationDeliveryDigest collects notifications and sends them periodically in a single mail.
+	NotificationDeliveryDigest NotificationDelivery = "digest"
+	// NotificationDeliveryDisabled doesn't send any notifications.
+	NotificationDeliveryDisabled NotificationDelivery = "disabled"
+)
+
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryDigest,
+	NotificationDeliveryDisabled,
+})
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "strings"
+
+// OAuthScope defines the access an OAuth app can request on behalf of a user.
+type OAuthScope string
+
+func (OAuthScope) Enum() []interface{} { return toInterfaceSlice(oauthScopes) }
+func (s OAuthScope) Sanitize() (OAuthScope, bool) {
+	return Sanitize(s, GetAllOAuthScopes)
+}
+func GetAllOAuthScopes() ([]OAuthScope, OAuthScope) {
+	return oauthScopes, ""
+}
+
+// OAuthScope enumeration.
+const (
+	// OAuthScopeOpenID requests an OpenID Connect ID token.
+	OAuthScopeOpenID OAuthScope = "openid"
+	// OAuthScopeProfile grants access to the uid and display name of the user.
+	OAuthScopeProfile OAuthScope = "profile"
+	// OAuthScopeEmail grants access to the email of the user.
+	OAuthScopeEmail OAuthScope = "email"
+	// OAuthScopeUserRead grants read access to the user via the API.
+	OAuthScopeUserRead OAuthScope = "user:read"
+	// OAuthScopeRepoRead grants read access to the spaces and repositories the user has access to.
+	OAuthScopeRepoRead OAuthScope = "repo:read"
+	// OAuthScopeRepoWrite grants write access to the spaces and repositories the user has access to.
+	OAuthScopeRepoWrite OAuthScope = "repo:write"
+)
+
+var oauthScopes = sortEnum([]OAuthScope{
+	OAuthScopeOpenID,
+	OAuthScopeProfile,
+	OAuthScopeEmail,
+	OAuthScopeUserRead,
+	OAuthScopeRepoRead,
+	OAuthScopeRepoWrite,
+})
+
+// ParseOAuthScopes parses a space separated list of scopes as used by OAuth2.
+// It returns false in case any of the scopes is unknown.
+func ParseOAuthScopes(s string) ([]OAuthScope, bool) {
+	fields := strings.Fields(s)
+	scopes := make([]OAuthScope, 0, len(fields))
+	for _, field := range fields {
+		scope, ok := OAuthScope(field).Sanitize()
+		if !ok {
+			return nil, false
+		}
+
+		if !OAuthScopesContain(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes, true
+}
+
+// FormatOAuthScopes returns the scopes as space separated list as used by OAuth2.
+func FormatOAuthScopes(scopes []OAuthScope) string {
+	s := make([]string, len(scopes))
+	for i, scope := range scopes {
+		s[i] = string(scope)
+	}
+
+	return strings.Join(s, " ")
+}
+
+// OAuthScopesContain returns true if the scope is part of the provided scopes.
+func OAuthScopesContain(scopes []OAuthScope, scope OAuthScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -28,10 +28,13 @@ const (
 	PrincipalTypeServiceAccount PrincipalType = "serviceaccount"
 	// PrincipalTypeService represents a service.
 	PrincipalTypeService PrincipalType = "service"
+	// PrincipalTypeUserGroup represents a group of users (team).
+	PrincipalTypeUserGroup PrincipalType = "usergroup"
 )
 
 var principalTypes = sortEnum([]PrincipalType{
 	PrincipalTypeUser,
 	PrincipalTypeServiceAccount,
 	PrincipalTypeService,
+	PrincipalTypeUserGroup,
 })
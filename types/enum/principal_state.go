@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PrincipalState defines the state of a principal's account.
+type PrincipalState string
+
+func (PrincipalState) Enum() []interface{}                      { return toInterfaceSlice(principalStates) }
+func (s PrincipalState) Sanitize() (PrincipalState, bool)       { return Sanitize(s, GetAllPrincipalStates) }
+func GetAllPrincipalStates() ([]PrincipalState, PrincipalState) { return principalStates, "" }
+
+const (
+	// PrincipalStateActive represents a principal that can use the system.
+	PrincipalStateActive PrincipalState = "active"
+	// PrincipalStateBlocked represents a principal that is blocked until it gets unblocked by an admin.
+	PrincipalStateBlocked PrincipalState = "blocked"
+	// PrincipalStateSuspended represents a principal that is blocked until the suspension expires.
+	PrincipalStateSuspended PrincipalState = "suspended"
+)
+
+var principalStates = sortEnum([]PrincipalState{
+	PrincipalStateActive,
+	PrincipalStateBlocked,
+	PrincipalStateSuspended,
+})
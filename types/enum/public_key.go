@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PublicKeyScheme defines the scheme of a public key.
+type PublicKeyScheme string
+
+func (PublicKeyScheme) Enum() []interface{} { return toInterfaceSlice(publicKeySchemes) }
+func (s PublicKeyScheme) Sanitize() (PublicKeyScheme, bool) {
+	return Sanitize(s, GetAllPublicKeySchemes)
+}
+func GetAllPublicKeySchemes() ([]PublicKeyScheme, PublicKeyScheme) { return publicKeySchemes, "" }
+
+// PublicKeyScheme enumeration.
+const (
+	PublicKeySchemePGP PublicKeyScheme = "pgp"
+	PublicKeySchemeSSH PublicKeyScheme = "ssh"
+)
+
+var publicKeySchemes = sortEnum([]PublicKeyScheme{
+	PublicKeySchemePGP,
+	PublicKeySchemeSSH,
+})
+
+// CommitVerificationStatus defines the result of the signature verification of a commit.
+type CommitVerificationStatus string
+
+func (CommitVerificationStatus) Enum() []interface{} {
+	return toInterfaceSlice(commitVerificationStatuses)
+}
+
+// CommitVerificationStatus enumeration.
+const (
+	// CommitVerificationStatusGood means the signature was made by a known key and is valid.
+	CommitVerificationStatusGood CommitVerificationStatus = "good"
+	// CommitVerificationStatusBad means the signature doesn't match the commit or the key.
+	CommitVerificationStatusBad CommitVerificationStatus = "bad"
+	// CommitVerificationStatusUnknownKey means the key used for the signature isn't known.
+	CommitVerificationStatusUnknownKey CommitVerificationStatus = "unknown_key"
+)
+
+var commitVerificationStatuses = sortEnum([]CommitVerificationStatus{
+	CommitVerificationStatusGood,
+	CommitVerificationStatusBad,
+	CommitVerificationStatusUnknownKey,
+})
@@ -60,6 +60,31 @@ var pullReqSorts = sortEnum([]PullReqSort{
 	PullReqSortMerged,
 })
 
+// PullReqAgingGroupBy defines how the pull requests of an aging report are grouped.
+type PullReqAgingGroupBy string
+
+func (PullReqAgingGroupBy) Enum() []interface{} { return toInterfaceSlice(pullReqAgingGroupBys) }
+func (g PullReqAgingGroupBy) Sanitize() (PullReqAgingGroupBy, bool) {
+	return Sanitize(g, GetAllPullReqAgingGroupBys)
+}
+func GetAllPullReqAgingGroupBys() ([]PullReqAgingGroupBy, PullReqAgingGroupBy) {
+	return pullReqAgingGroupBys, PullReqAgingGroupByRepo
+}
+
+// PullReqAgingGroupBy enumeration.
+const (
+	PullReqAgingGroupByRepo   PullReqAgingGroupBy = "repo"
+	PullReqAgingGroupByAuthor PullReqAgingGroupBy = "author"
+	// PullReqAgingGroupByTeam groups pull requests by the user groups of their author.
+	PullReqAgingGroupByTeam PullReqAgingGroupBy = "team"
+)
+
+var pullReqAgingGroupBys = sortEnum([]PullReqAgingGroupBy{
+	PullReqAgingGroupByRepo,
+	PullReqAgingGroupByAuthor,
+	PullReqAgingGroupByTeam,
+})
+
 // PullReqActivityType defines pull request activity message type.
 // Essentially, the Type determines the structure of the pull request activity's Payload structure.
 type PullReqActivityType string
@@ -76,14 +101,19 @@ func GetAllPullReqActivityTypes() ([]PullReqActivityType, PullReqActivityType) {
 
 // PullReqActivityType enumeration.
 const (
-	PullReqActivityTypeComment      PullReqActivityType = "comment"
-	PullReqActivityTypeCodeComment  PullReqActivityType = "code-comment"
-	PullReqActivityTypeTitleChange  PullReqActivityType = "title-change"
-	PullReqActivityTypeStateChange  PullReqActivityType = "state-change"
-	PullReqActivityTypeReviewSubmit PullReqActivityType = "review-submit"
-	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
-	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
-	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeComment       PullReqActivityType = "comment"
+	PullReqActivityTypeCodeComment   PullReqActivityType = "code-comment"
+	PullReqActivityTypeTitleChange   PullReqActivityType = "title-change"
+	PullReqActivityTypeStateChange   PullReqActivityType = "state-change"
+	PullReqActivityTypeReviewSubmit  PullReqActivityType = "review-submit"
+	PullReqActivityTypeBranchUpdate  PullReqActivityType = "branch-update"
+	PullReqActivityTypeBranchDelete  PullReqActivityType = "branch-delete"
+	PullReqActivityTypeBranchRestore PullReqActivityType = "branch-restore"
+	PullReqActivityTypeTargetChange  PullReqActivityType = "target-branch-change"
+	PullReqActivityTypeMerge         PullReqActivityType = "merge"
+	PullReqActivityTypeTaskToggle    PullReqActivityType = "task-toggle"
+	PullReqActivityTypeLock          PullReqActivityType = "lock"
+	PullReqActivityTypeUnlock        PullReqActivityType = "unlock"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -94,7 +124,12 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeReviewSubmit,
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
+	PullReqActivityTypeBranchRestore,
+	PullReqActivityTypeTargetChange,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeTaskToggle,
+	PullReqActivityTypeLock,
+	PullReqActivityTypeUnlock,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -125,6 +160,62 @@ var pullReqActivityKinds = sortEnum([]PullReqActivityKind{
 	PullReqActivityKindChangeComment,
 })
 
+// PullReqTargetDeleteAction defines what happens to open pull requests when their target branch gets deleted.
+type PullReqTargetDeleteAction string
+
+func (PullReqTargetDeleteAction) Enum() []interface{} {
+	return toInterfaceSlice(pullReqTargetDeleteActions)
+}
+
+func (a PullReqTargetDeleteAction) Sanitize() (PullReqTargetDeleteAction, bool) {
+	return Sanitize(a, GetAllPullReqTargetDeleteActions)
+}
+
+func GetAllPullReqTargetDeleteActions() ([]PullReqTargetDeleteAction, PullReqTargetDeleteAction) {
+	return pullReqTargetDeleteActions, PullReqTargetDeleteActionRetarget
+}
+
+// PullReqTargetDeleteAction enumeration.
+const (
+	// PullReqTargetDeleteActionRetarget retargets the pull requests to the default branch of the repository.
+	PullReqTargetDeleteActionRetarget PullReqTargetDeleteAction = "retarget"
+	// PullReqTargetDeleteActionClose closes the pull requests.
+	PullReqTargetDeleteActionClose PullReqTargetDeleteAction = "close"
+)
+
+var pullReqTargetDeleteActions = sortEnum([]PullReqTargetDeleteAction{
+	PullReqTargetDeleteActionRetarget,
+	PullReqTargetDeleteActionClose,
+})
+
+// PullReqLockReason defines the reason why the conversation of a pull request got locked.
+type PullReqLockReason string
+
+func (PullReqLockReason) Enum() []interface{} { return toInterfaceSlice(pullReqLockReasons) }
+
+func (r PullReqLockReason) Sanitize() (PullReqLockReason, bool) {
+	return Sanitize(r, GetAllPullReqLockReasons)
+}
+
+func GetAllPullReqLockReasons() ([]PullReqLockReason, PullReqLockReason) {
+	return pullReqLockReasons, "" // No default value
+}
+
+// PullReqLockReason enumeration.
+const (
+	PullReqLockReasonOffTopic  PullReqLockReason = "off-topic"
+	PullReqLockReasonTooHeated PullReqLockReason = "too-heated"
+	PullReqLockReasonResolved  PullReqLockReason = "resolved"
+	PullReqLockReasonSpam      PullReqLockReason = "spam"
+)
+
+var pullReqLockReasons = sortEnum([]PullReqLockReason{
+	PullReqLockReasonOffTopic,
+	PullReqLockReasonTooHeated,
+	PullReqLockReasonResolved,
+	PullReqLockReasonSpam,
+})
+
 // PullReqCommentStatus defines status of a pull request comment.
 type PullReqCommentStatus string
 
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RefWatchType defines the kind of refs a ref watch applies to.
+type RefWatchType string
+
+func (RefWatchType) Enum() []interface{} { return toInterfaceSlice(refWatchTypes) }
+func (s RefWatchType) Sanitize() (RefWatchType, bool) {
+	return Sanitize(s, GetAllRefWatchTypes)
+}
+func GetAllRefWatchTypes() ([]RefWatchType, RefWatchType) {
+	return refWatchTypes, RefWatchTypeBranch
+}
+
+// RefWatchType enumeration.
+const (
+	RefWatchTypeBranch RefWatchType = "branch"
+	RefWatchTypeTag    RefWatchType = "tag"
+)
+
+var refWatchTypes = sortEnum([]RefWatchType{
+	RefWatchTypeBranch,
+	RefWatchTypeTag,
+})
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ScanKind defines the kind of a security scan.
+type ScanKind string
+
+func (ScanKind) Enum() []interface{}          { return toInterfaceSlice(scanKinds) }
+func (s ScanKind) Sanitize() (ScanKind, bool) { return Sanitize(s, GetAllScanKinds) }
+func GetAllScanKinds() ([]ScanKind, ScanKind) { return scanKinds, "" }
+
+// ScanKind enumeration.
+const (
+	// ScanKindDependency scans the dependency manifests of a repository for known vulnerabilities.
+	ScanKindDependency ScanKind = "dependency"
+	// ScanKindContainer scans the container images referenced by a repository for known vulnerabilities.
+	ScanKindContainer ScanKind = "container"
+)
+
+var scanKinds = sortEnum([]ScanKind{
+	ScanKindDependency,
+	ScanKindContainer,
+})
+
+// ScanSeverity defines the severity of a scan finding.
+type ScanSeverity string
+
+func (ScanSeverity) Enum() []interface{}              { return toInterfaceSlice(scanSeverities) }
+func (s ScanSeverity) Sanitize() (ScanSeverity, bool) { return Sanitize(s, GetAllScanSeverities) }
+func GetAllScanSeverities() ([]ScanSeverity, ScanSeverity) {
+	return scanSeverities, ScanSeverityUnknown
+}
+
+// ScanSeverity enumeration.
+const (
+	ScanSeverityUnknown  ScanSeverity = "unknown"
+	ScanSeverityLow      ScanSeverity = "low"
+	ScanSeverityMedium   ScanSeverity = "medium"
+	ScanSeverityHigh     ScanSeverity = "high"
+	ScanSeverityCritical ScanSeverity = "critical"
+)
+
+var scanSeverities = sortEnum([]ScanSeverity{
+	ScanSeverityUnknown,
+	ScanSeverityLow,
+	ScanSeverityMedium,
+	ScanSeverityHigh,
+	ScanSeverityCritical,
+})
+
+// Rank returns the order of the severity, higher values are more severe.
+func (s ScanSeverity) Rank() int {
+	switch s {
+	case ScanSeverityLow:
+		return 1
+	case ScanSeverityMedium:
+		return 2
+	case ScanSeverityHigh:
+		return 3
+	case ScanSeverityCritical:
+		return 4
+	case ScanSeverityUnknown:
+		return 0
+	}
+
+	return 0
+}
+
+// AtLeast returns the severities that are at least as severe as the provided one.
+func (s ScanSeverity) AtLeast() []ScanSeverity {
+	var res []ScanSeverity
+	for _, severity := range scanSeverities {
+		if severity.Rank() >= s.Rank() {
+			res = append(res, severity)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SecretProvider defines where the value of a secret is stored.
+type SecretProvider string
+
+func (SecretProvider) Enum() []interface{}                { return toInterfaceSlice(secretProviders) }
+func (s SecretProvider) Sanitize() (SecretProvider, bool) { return Sanitize(s, GetAllSecretProviders) }
+func GetAllSecretProviders() ([]SecretProvider, SecretProvider) {
+	return secretProviders, SecretProviderGitness
+}
+
+// SecretProvider enumeration.
+const (
+	// SecretProviderGitness is used for secrets with the (encrypted) value stored by gitness.
+	SecretProviderGitness SecretProvider = "gitness"
+	// SecretProviderVault is used for secrets stored in HashiCorp Vault.
+	SecretProviderVault SecretProvider = "vault"
+	// SecretProviderAWS is used for secrets stored in AWS Secrets Manager.
+	SecretProviderAWS SecretProvider = "aws"
+	// SecretProviderGCP is used for secrets stored in GCP Secret Manager.
+	SecretProviderGCP SecretProvider = "gcp"
+)
+
+var secretProviders = sortEnum([]SecretProvider{
+	SecretProviderGitness,
+	SecretProviderVault,
+	SecretProviderAWS,
+	SecretProviderGCP,
+})
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SettingKey defines the key of a setting.
+type SettingKey string
+
+func (SettingKey) Enum() []interface{}              { return toInterfaceSlice(settingKeys) }
+func (k SettingKey) Sanitize() (SettingKey, bool)   { return Sanitize(k, GetAllSettingKeys) }
+func GetAllSettingKeys() ([]SettingKey, SettingKey) { return settingKeys, "" }
+
+// SettingKey enumeration.
+const (
+	// SettingKeyAttachmentMaxSize is the max size in bytes of files attached to pull requests.
+	SettingKeyAttachmentMaxSize SettingKey = "attachment_max_size"
+	// SettingKeyAttestationKeys are the public keys trusted to sign attestations of commits and pipeline executions.
+	SettingKeyAttestationKeys SettingKey = "attestation_keys"
+	// SettingKeyDefaultBranch is the default branch name of newly created repositories.
+	SettingKeyDefaultBranch SettingKey = "default_branch"
+	// SettingKeyDeleteSourceBranch enables the deletion of the source branch after a pull request got merged.
+	SettingKeyDeleteSourceBranch SettingKey = "delete_source_branch"
+	// SettingKeyEnforceCommitAuthor enforces the acting principal as author of commits created via the API.
+	SettingKeyEnforceCommitAuthor SettingKey = "enforce_commit_author"
+	// SettingKeyGithookPlugins are the names of the server side git hook plugins enabled for repositories.
+	SettingKeyGithookPlugins SettingKey = "githook_plugins"
+	// SettingKeyMergeChecks are the external merge check providers consulted before pull requests are merged.
+	SettingKeyMergeChecks SettingKey = "merge_checks"
+	// SettingKeyMergeMethods are the merge methods allowed for pull requests.
+	SettingKeyMergeMethods SettingKey = "merge_methods"
+	// SettingKeyPathProtections restrict who is allowed to change files matching path patterns per branch pattern.
+	SettingKeyPathProtections SettingKey = "path_protections"
+	// SettingKeyPullReqSLA are the age and idle thresholds above which open pull requests are reported as aging.
+	SettingKeyPullReqSLA SettingKey = "pullreq_sla"
+	// SettingKeyPullReqRules are the rules a pull request has to satisfy before it can be merged.
+	SettingKeyPullReqRules SettingKey = "pullreq_rules"
+	// SettingKeyPushToCreate enables the creation of repositories by pushing to a non-existent repository path.
+	SettingKeyPushToCreate SettingKey = "push_to_create"
+	// SettingKeyRetention overrides the system wide retention of webhook executions and pipeline logs.
+	SettingKeyRetention SettingKey = "retention"
+	// SettingKeyReviewReminders is the policy for reminding requested reviewers about pending reviews.
+	SettingKeyReviewReminders SettingKey = "review_reminders"
+	// SettingKeyStaleBranches is the policy for detecting and cleaning up stale branches.
+	SettingKeyStaleBranches SettingKey = "stale_branches"
+	// SettingKeyTargetBranchDelete is the action applied to open pull requests when their target branch is deleted.
+	SettingKeyTargetBranchDelete SettingKey = "target_branch_delete"
+	// SettingKeyWebhookTemplates are the webhooks created for newly created repositories.
+	SettingKeyWebhookTemplates SettingKey = "webhook_templates"
+)
+
+var settingKeys = sortEnum([]SettingKey{
+	SettingKeyAttachmentMaxSize,
+	SettingKeyAttestationKeys,
+	SettingKeyDefaultBranch,
+	SettingKeyDeleteSourceBranch,
+	SettingKeyEnforceCommitAuthor,
+	SettingKeyGithookPlugins,
+	SettingKeyMergeChecks,
+	SettingKeyMergeMethods,
+	SettingKeyPathProtections,
+	SettingKeyPullReqRules,
+	SettingKeyPullReqSLA,
+	SettingKeyPushToCreate,
+	SettingKeyRetention,
+	SettingKeyReviewReminders,
+	SettingKeyStaleBranches,
+	SettingKeyTargetBranchDelete,
+	SettingKeyWebhookTemplates,
+})
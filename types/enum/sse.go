@@ -28,4 +28,8 @@ const (
 	SSETypeRepositoryExportCompleted = "repository_export_completed"
 
 	SSETypePullrequesUpdated = "pullreq_updated"
+
+	SSETypeBranchPushed = "branch_pushed"
+
+	SSETypeRefWatchTriggered = "ref_watch_triggered"
 )
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// UploadSource represents the feature through which content was uploaded.
+type UploadSource string
+
+// UploadSource enumeration.
+const (
+	// UploadSourceAttachment is a file attached to a pull request description or comment.
+	UploadSourceAttachment UploadSource = "attachment"
+	// UploadSourceWeb is content uploaded through the web editor (committed files).
+	UploadSourceWeb UploadSource = "web"
+)
+
+var uploadSources = sortEnum([]UploadSource{
+	UploadSourceAttachment,
+	UploadSourceWeb,
+})
+
+func (UploadSource) Enum() []interface{} { return toInterfaceSlice(uploadSources) }
+func (s UploadSource) Sanitize() (UploadSource, bool) {
+	return Sanitize(s, GetAllUploadSources)
+}
+func GetAllUploadSources() ([]UploadSource, UploadSource) {
+	return uploadSources, ""
+}
+
+// UploadQuarantineStatus represents the status of uploaded content flagged by the virus scanner.
+type UploadQuarantineStatus string
+
+// UploadQuarantineStatus enumeration.
+const (
+	// UploadQuarantineStatusQuarantined is content that is held back and rejected when uploaded.
+	UploadQuarantineStatusQuarantined UploadQuarantineStatus = "quarantined"
+	// UploadQuarantineStatusReleased is content an admin marked as safe, it's accepted without scanning.
+	UploadQuarantineStatusReleased UploadQuarantineStatus = "released"
+)
+
+var uploadQuarantineStatuses = sortEnum([]UploadQuarantineStatus{
+	UploadQuarantineStatusQuarantined,
+	UploadQuarantineStatusReleased,
+})
+
+func (UploadQuarantineStatus) Enum() []interface{} { return toInterfaceSlice(uploadQuarantineStatuses) }
+func (s UploadQuarantineStatus) Sanitize() (UploadQuarantineStatus, bool) {
+	return Sanitize(s, GetAllUploadQuarantineStatuses)
+}
+func GetAllUploadQuarantineStatuses() ([]UploadQuarantineStatus, UploadQuarantineStatus) {
+	return uploadQuarantineStatuses, ""
+}
@@ -135,6 +135,14 @@ const (
 	WebhookTriggerPullReqBranchUpdated WebhookTrigger = "pullreq_branch_updated"
 	// WebhookTriggerPullReqClosed gets triggered when a pull request is closed.
 	WebhookTriggerPullReqClosed WebhookTrigger = "pullreq_closed"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a review is submitted for a pull request.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+	// WebhookTriggerPullReqCommentCreated gets triggered when a comment is created on a pull request.
+	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
+	// WebhookTriggerPullReqCommentUpdated gets triggered when a comment of a pull request is edited.
+	WebhookTriggerPullReqCommentUpdated WebhookTrigger = "pullreq_comment_updated"
+	// WebhookTriggerPullReqCommentResolved gets triggered when a comment of a pull request is resolved.
+	WebhookTriggerPullReqCommentResolved WebhookTrigger = "pullreq_comment_resolved"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -148,4 +156,8 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqReopened,
 	WebhookTriggerPullReqBranchUpdated,
 	WebhookTriggerPullReqClosed,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqCommentCreated,
+	WebhookTriggerPullReqCommentUpdated,
+	WebhookTriggerPullReqCommentResolved,
 })
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// EventDeadLetter represents a stream message that a consumer group failed to process within the max retries.
+type EventDeadLetter struct {
+	ID        int64  `db:"event_dead_letter_id"         json:"id"`
+	StreamID  string `db:"event_dead_letter_stream_id"  json:"stream_id"`
+	GroupName string `db:"event_dead_letter_group_name" json:"group_name"`
+	MessageID string `db:"event_dead_letter_message_id" json:"message_id"`
+	Payload   []byte `db:"event_dead_letter_payload"    json:"-"`
+	Retries   int64  `db:"event_dead_letter_retries"    json:"retries"`
+	LastError string `db:"event_dead_letter_last_error" json:"last_error"`
+	Created   int64  `db:"event_dead_letter_created"    json:"created"`
+}
+
+// EventDeadLetterFilter stores event dead letter query parameters.
+type EventDeadLetterFilter struct {
+	Page      int    `json:"page"`
+	Size      int    `json:"size"`
+	StreamID  string `json:"stream_id"`
+	GroupName string `json:"group_name"`
+}
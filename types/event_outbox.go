@@ -0,0 +1,26 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// EventOutboxMessage represents a stream message that is persisted in the event outbox until it's delivered.
+type EventOutboxMessage struct {
+	ID          int64  `db:"event_outbox_id"`
+	StreamID    string `db:"event_outbox_stream_id"`
+	Payload     []byte `db:"event_outbox_payload"`
+	Created     int64  `db:"event_outbox_created"`
+	Attempts    int    `db:"event_outbox_attempts"`
+	NextAttempt int64  `db:"event_outbox_next_attempt"`
+	LastError   string `db:"event_outbox_last_error"`
+}
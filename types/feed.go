@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoStar marks a repository as favorite of a principal.
+type RepoStar struct {
+	RepoID      int64 `db:"repo_star_repo_id"`
+	PrincipalID int64 `db:"repo_star_principal_id"`
+	Created     int64 `db:"repo_star_created"`
+}
+
+// FeedEvent is an activity in a repository shown in the personal feed of users.
+type FeedEvent struct {
+	ID            int64              `db:"feed_event_id"             json:"id"`
+	RepoID        int64              `db:"feed_event_repo_id"        json:"repo_id"`
+	PrincipalID   int64              `db:"feed_event_principal_id"   json:"-"`
+	Type          enum.FeedEventType `db:"feed_event_type"           json:"type"`
+	Ref           string             `db:"feed_event_ref"            json:"ref,omitempty"`
+	SHA           string             `db:"feed_event_sha"            json:"sha,omitempty"`
+	PullReqNumber int64              `db:"feed_event_pullreq_number" json:"pullreq_number,omitempty"`
+	Created       int64              `db:"feed_event_created"        json:"created"`
+
+	RepoPath string         `db:"-" json:"repo_path"`
+	Actor    *PrincipalInfo `db:"-" json:"actor"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// FileLock is an advisory lock of a path in a repository. Pushes changing a locked path
+// are rejected unless they are made by the owner of the lock.
+type FileLock struct {
+	ID      int64  `json:"id"`
+	RepoID  int64  `json:"-"`
+	Path    string `json:"path"`
+	OwnerID int64  `json:"owner_id"`
+	Created int64  `json:"created"`
+
+	Owner *PrincipalInfo `json:"owner,omitempty"`
+}
+
+// FileLockFilter stores file lock query parameters.
+type FileLockFilter struct {
+	Page int    `json:"page"`
+	Size int    `json:"size"`
+	Path string `json:"path"`
+	// ID restricts the result to a single lock, 0 returns all locks.
+	ID int64 `json:"id"`
+	// OwnerID restricts the result to the locks of a single principal, 0 returns the locks of all principals.
+	OwnerID int64 `json:"owner_id"`
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// RenderedFile is the rich preview of a file of a repository.
+// Only the field matching the type of the rendered file is set.
+type RenderedFile struct {
+	Type     enum.FileRenderType `json:"type"`
+	Path     string              `json:"path"`
+	SHA      string              `json:"sha"`
+	Size     int64               `json:"size"`
+	Notebook *RenderedNotebook   `json:"notebook,omitempty"`
+	Table    *RenderedTable      `json:"table,omitempty"`
+	GeoJSON  *RenderedGeoJSON    `json:"geojson,omitempty"`
+}
+
+// RenderedNotebook is a Jupyter notebook with its markdown cells rendered to safe HTML.
+type RenderedNotebook struct {
+	Language string                 `json:"language"`
+	Cells    []RenderedNotebookCell `json:"cells"`
+}
+
+// RenderedNotebookCell is a single cell of a rendered Jupyter notebook.
+// Markdown cells only have HTML set, code and raw cells have the source and, for code cells, the outputs.
+type RenderedNotebookCell struct {
+	Type           string                   `json:"type"`
+	Source         string                   `json:"source,omitempty"`
+	HTML           string                   `json:"html,omitempty"`
+	ExecutionCount *int                     `json:"execution_count,omitempty"`
+	Outputs        []RenderedNotebookOutput `json:"outputs,omitempty"`
+}
+
+// RenderedNotebookOutput is a single output of a code cell.
+// Text is set for plain text outputs, errors and HTML outputs (which are never rendered),
+// HTML for markdown outputs and Data for base64 encoded images.
+type RenderedNotebookOutput struct {
+	Type     string `json:"type"`
+	MimeType string `json:"mime_type,omitempty"`
+	Text     string `json:"text,omitempty"`
+	HTML     string `json:"html,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
+// RenderedTable is a CSV or TSV file split into its header and rows.
+type RenderedTable struct {
+	Headers   []string   `json:"headers"`
+	Rows      [][]string `json:"rows"`
+	Truncated bool       `json:"truncated"`
+}
+
+// RenderedGeoJSON is a validated GeoJSON document together with its bounding box.
+type RenderedGeoJSON struct {
+	Type         string          `json:"type"`
+	FeatureCount int             `json:"feature_count"`
+	BBox         []float64       `json:"bbox,omitempty"`
+	Data         json.RawMessage `json:"data"`
+}
@@ -15,12 +15,31 @@
 package types
 
 import (
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/types/enum"
 )
 
-const NilSHA = "0000000000000000000000000000000000000000"
+const (
+	// NilSHA is the zero object id of repositories using the sha1 object format.
+	NilSHA = "0000000000000000000000000000000000000000"
+	// NilSHA256 is the zero object id of repositories using the sha256 object format.
+	NilSHA256 = "0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// IsNilSHA returns true iff the provided sha is the zero object id of any supported object format.
+func IsNilSHA(sha string) bool {
+	return (len(sha) == len(NilSHA) || len(sha) == len(NilSHA256)) && strings.Trim(sha, "0") == ""
+}
+
+// NilSHAFor returns the zero object id of the object format the provided sha belongs to.
+func NilSHAFor(sha string) string {
+	if len(sha) == len(NilSHA256) {
+		return NilSHA256
+	}
+	return NilSHA
+}
 
 // PaginationFilter stores pagination query parameters.
 type PaginationFilter struct {
@@ -38,6 +57,14 @@ type CommitFilter struct {
 	Committer string `json:"committer"`
 }
 
+// DiffOptions stores the diff query parameters that control how git compares files.
+type DiffOptions struct {
+	// Algorithm is the diff algorithm, the default of git is used if empty.
+	Algorithm        enum.DiffAlgorithm `json:"algorithm"`
+	IgnoreWhitespace bool               `json:"ignore_whitespace"`
+	IgnoreBlankLines bool               `json:"ignore_blank_lines"`
+}
+
 // BranchFilter stores branch query parameters.
 type BranchFilter struct {
 	Query string                `json:"query"`
@@ -62,6 +89,8 @@ type Commit struct {
 	Message   string    `json:"message"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+	// Verification is the signature verification result of the commit (nil if the commit isn't signed).
+	Verification *CommitVerification `json:"verification,omitempty"`
 }
 
 type Signature struct {
@@ -86,3 +115,21 @@ type ListCommitResponse struct {
 	RenameDetails []RenameDetails `json:"rename_details"`
 	TotalCommits  int             `json:"total_commits,omitempty"`
 }
+
+// GitOperation describes a git operation (upload-pack or receive-pack) served via the smart http protocol.
+type GitOperation struct {
+	Service     string `json:"service"`
+	RepoID      int64  `json:"repo_id"`
+	RepoPath    string `json:"repo_path"`
+	PrincipalID int64  `json:"principal_id"`
+	Started     int64  `json:"started"`
+	// Duration of the operation in milliseconds.
+	Duration int64 `json:"duration"`
+	// RequestSize is the number of bytes received from the client (includes the pack for pushes).
+	RequestSize int64 `json:"request_size"`
+	// ResponseSize is the number of bytes sent to the client (includes the pack for fetches).
+	ResponseSize int64 `json:"response_size"`
+	// RefCount is the number of refs updated by a push or the number of objects wanted by a fetch.
+	RefCount int    `json:"ref_count"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "testing"
+
+func TestIsNilSHA(t *testing.T) {
+	tests := []struct {
+		sha  string
+		want bool
+	}{
+		{NilSHA, true},
+		{NilSHA256, true},
+		{"", false},
+		{"0000000", false},
+		{"1000000000000000000000000000000000000000", false},
+		{"6f1e1c7d5ed8a3a2e7b4c0b7cbd0e3f0d9e5b6a7", false},
+		{NilSHA + "00", false},
+	}
+
+	for _, test := range tests {
+		if got := IsNilSHA(test.sha); got != test.want {
+			t.Errorf("Want IsNilSHA(%q) to be %t, got %t", test.sha, test.want, got)
+		}
+	}
+}
+
+func TestNilSHAFor(t *testing.T) {
+	tests := []struct {
+		sha  string
+		want string
+	}{
+		{"6f1e1c7d5ed8a3a2e7b4c0b7cbd0e3f0d9e5b6a7", NilSHA},
+		{"6f1e1c7d5ed8a3a2e7b4c0b7cbd0e3f0d9e5b6a76f1e1c7d5ed8a3a2e7b4c0b7", NilSHA256},
+		{"", NilSHA},
+	}
+
+	for _, test := range tests {
+		if got := NilSHAFor(test.sha); got != test.want {
+			t.Errorf("Want NilSHAFor(%q) to be %q, got %q", test.sha, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ImpactedResource identifies a space or repository that is affected by an operation.
+type ImpactedResource struct {
+	ID   int64  `json:"id"`
+	Path string `json:"path"`
+}
+
+// DeleteImpact describes the resources that are affected by deleting a space or repository.
+type DeleteImpact struct {
+	Spaces       []ImpactedResource `json:"spaces"`
+	Repos        []ImpactedResource `json:"repos"`
+	OpenPullReqs int64              `json:"open_pullreqs"`
+	Memberships  int64              `json:"memberships"`
+}
+
+// ReqCheckImpact describes the open pull requests that are affected
+// by adding or removing a required status check.
+type ReqCheckImpact struct {
+	PullReqNumbers []int64 `json:"pullreq_numbers"`
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// InsightsDay holds the activity of a repository aggregated for a single (UTC) day.
+type InsightsDay struct {
+	RepoID int64 `json:"-"`
+	// Day is the start of the day in unix milliseconds.
+	Day int64 `json:"day"`
+
+	Commits        int64 `json:"commits"`
+	PullReqsOpened int64 `json:"pullreqs_opened"`
+	PullReqsMerged int64 `json:"pullreqs_merged"`
+
+	// MergeTimeTotal is the sum of the time (in ms) the pull requests merged on this day were open.
+	MergeTimeTotal int64 `json:"-"`
+	// PullReqsReviewed is the number of pull requests that received their first review on this day.
+	PullReqsReviewed int64 `json:"pullreqs_reviewed"`
+	// ReviewTimeTotal is the sum of the time (in ms) the pull requests reviewed on this day waited for a review.
+	ReviewTimeTotal int64 `json:"-"`
+}
+
+// InsightsContributor holds the commits of a single contributor.
+type InsightsContributor struct {
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Commits int64  `json:"commits"`
+}
+
+// InsightsContributorDay holds the commits of a single contributor to a repository on a single (UTC) day.
+type InsightsContributorDay struct {
+	RepoID int64
+	Day    int64
+	InsightsContributor
+}
+
+// InsightsFilter stores the time range for which insights are requested, both in unix milliseconds.
+type InsightsFilter struct {
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+}
+
+// Insights contains the contribution statistics of a repository or space for a time range.
+type Insights struct {
+	InsightsFilter
+
+	Commits          int64 `json:"commits"`
+	Contributors     int64 `json:"contributors"`
+	PullReqsOpened   int64 `json:"pullreqs_opened"`
+	PullReqsMerged   int64 `json:"pullreqs_merged"`
+	PullReqsReviewed int64 `json:"pullreqs_reviewed"`
+
+	// AvgCycleTime is the average time (in ms) between opening and merging a pull request.
+	AvgCycleTime int64 `json:"avg_cycle_time"`
+	// AvgReviewTurnaround is the average time (in ms) a pull request waited for its first review.
+	AvgReviewTurnaround int64 `json:"avg_review_turnaround"`
+	// MergesPerWeek is the average number of pull requests merged per week.
+	MergesPerWeek float64 `json:"merges_per_week"`
+
+	Activity        []*InsightsDay         `json:"activity"`
+	TopContributors []*InsightsContributor `json:"top_contributors"`
+}
+
+// PullReqAgingFilter stores the thresholds and the grouping of a pull request aging report.
+// Thresholds of 0 fall back to the pull request SLA of the space.
+type PullReqAgingFilter struct {
+	PullReqSLA
+	GroupBy enum.PullReqAgingGroupBy `json:"group_by"`
+}
+
+// PullReqAgingReport lists the open pull requests of a space (including all subspaces)
+// that exceed the age or idle threshold.
+type PullReqAgingReport struct {
+	PullReqAgingFilter
+
+	// Total is the number of aging pull requests. When grouped by team,
+	// a pull request is part of the groups of all teams of its author.
+	Total  int                  `json:"total"`
+	Groups []*PullReqAgingGroup `json:"groups"`
+}
+
+// PullReqAgingGroup holds the aging pull requests of a single repository, author or team.
+type PullReqAgingGroup struct {
+	// Key is the path of the repository, or the uid of the author or user group.
+	// It's empty for the group of pull requests whose author isn't member of any team.
+	Key string `json:"key"`
+
+	Count         int `json:"count"`
+	ExceedingAge  int `json:"exceeding_age"`
+	ExceedingIdle int `json:"exceeding_idle"`
+
+	PullReqs []*PullReqAging `json:"pullreqs"`
+}
+
+// PullReqAging describes an open pull request exceeding the age or idle threshold.
+type PullReqAging struct {
+	RepoID   int64         `json:"-"`
+	RepoPath string        `json:"repo_path"`
+	Number   int64         `json:"number"`
+	Title    string        `json:"title"`
+	Author   PrincipalInfo `json:"author"`
+	IsDraft  bool          `json:"is_draft"`
+
+	Created      int64 `json:"created"`
+	LastActivity int64 `json:"last_activity"`
+	AgeDays      int   `json:"age_days"`
+	IdleDays     int   `json:"idle_days"`
+	ExceedsAge   bool  `json:"exceeds_age"`
+	ExceedsIdle  bool  `json:"exceeds_idle"`
+}
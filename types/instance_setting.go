@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// InstanceSetting is the value of an instance setting changed at runtime.
+type InstanceSetting struct {
+	Key       enum.InstanceSettingKey `json:"key"`
+	Value     json.RawMessage         `json:"value"`
+	UpdatedBy int64                   `json:"updated_by"`
+	Updated   int64                   `json:"updated"`
+}
+
+// EffectiveInstanceSetting is the value of an instance setting that applies to the instance.
+type EffectiveInstanceSetting struct {
+	Key   enum.InstanceSettingKey `json:"key"`
+	Value json.RawMessage         `json:"value"`
+	// Default indicates that the value wasn't changed at runtime and the server configuration applies.
+	Default   bool  `json:"default"`
+	UpdatedBy int64 `json:"updated_by,omitempty"`
+	Updated   int64 `json:"updated,omitempty"`
+}
+
+// InstanceSettingChange is the audit record of a change of an instance setting.
+type InstanceSettingChange struct {
+	ID  int64                   `json:"id"`
+	Key enum.InstanceSettingKey `json:"key"`
+	// OldValue is null if the server configuration applied before the change.
+	OldValue json.RawMessage `json:"old_value"`
+	// NewValue is null if the setting got reset to the server configuration.
+	NewValue  json.RawMessage `json:"new_value"`
+	ChangedBy int64           `json:"changed_by"`
+	Changed   int64           `json:"changed"`
+}
+
+// InstanceSettingChangeFilter stores instance setting change query parameters.
+type InstanceSettingChangeFilter struct {
+	Page int                     `json:"page"`
+	Size int                     `json:"size"`
+	Key  enum.InstanceSettingKey `json:"key"`
+}
+
+// MaintenanceMode describes the maintenance mode of the instance.
+// While enabled, all changes (API mutations and git pushes) are rejected.
+type MaintenanceMode struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
@@ -17,27 +17,37 @@ package types
 import "github.com/harness/gitness/types/enum"
 
 type Job struct {
-	UID                 string           `db:"job_uid"`
-	Created             int64            `db:"job_created"`
-	Updated             int64            `db:"job_updated"`
-	Type                string           `db:"job_type"`
-	Priority            enum.JobPriority `db:"job_priority"`
-	Data                string           `db:"job_data"`
-	Result              string           `db:"job_result"`
-	MaxDurationSeconds  int              `db:"job_max_duration_seconds"`
-	MaxRetries          int              `db:"job_max_retries"`
-	State               enum.JobState    `db:"job_state"`
-	Scheduled           int64            `db:"job_scheduled"`
-	TotalExecutions     int              `db:"job_total_executions"`
-	RunBy               string           `db:"job_run_by"`
-	RunDeadline         int64            `db:"job_run_deadline"`
-	RunProgress         int              `db:"job_run_progress"`
-	LastExecuted        int64            `db:"job_last_executed"`
-	IsRecurring         bool             `db:"job_is_recurring"`
-	RecurringCron       string           `db:"job_recurring_cron"`
-	ConsecutiveFailures int              `db:"job_consecutive_failures"`
-	LastFailureError    string           `db:"job_last_failure_error"`
-	GroupID             string           `db:"job_group_id"`
+	UID                 string           `db:"job_uid"                  json:"uid"`
+	Created             int64            `db:"job_created"              json:"created"`
+	Updated             int64            `db:"job_updated"              json:"updated"`
+	Type                string           `db:"job_type"                 json:"type"`
+	Priority            enum.JobPriority `db:"job_priority"             json:"priority"`
+	Data                string           `db:"job_data"                 json:"-"`
+	Result              string           `db:"job_result"               json:"result"`
+	MaxDurationSeconds  int              `db:"job_max_duration_seconds" json:"max_duration_seconds"`
+	MaxRetries          int              `db:"job_max_retries"          json:"max_retries"`
+	State               enum.JobState    `db:"job_state"                json:"state"`
+	Scheduled           int64            `db:"job_scheduled"            json:"scheduled"`
+	TotalExecutions     int              `db:"job_total_executions"     json:"total_executions"`
+	RunBy               string           `db:"job_run_by"               json:"run_by"`
+	RunDeadline         int64            `db:"job_run_deadline"         json:"run_deadline"`
+	RunProgress         int              `db:"job_run_progress"         json:"run_progress"`
+	LastExecuted        int64            `db:"job_last_executed"        json:"last_executed"`
+	IsRecurring         bool             `db:"job_is_recurring"         json:"is_recurring"`
+	RecurringCron       string           `db:"job_recurring_cron"       json:"recurring_cron"`
+	ConsecutiveFailures int              `db:"job_consecutive_failures" json:"consecutive_failures"`
+	LastFailureError    string           `db:"job_last_failure_error"   json:"last_failure_error"`
+	GroupID             string           `db:"job_group_id"             json:"group_id"`
+	TraceContext        string           `db:"job_trace_context"        json:"-"`
+}
+
+// JobFilter stores job query parameters.
+type JobFilter struct {
+	Page   int             `json:"page"`
+	Size   int             `json:"size"`
+	Query  string          `json:"query"`
+	Type   string          `json:"type"`
+	States []enum.JobState `json:"states"`
 }
 
 type JobStateChange struct {
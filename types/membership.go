@@ -33,6 +33,10 @@ type Membership struct {
 	Updated   int64 `json:"updated"`
 
 	Role enum.MembershipRole `json:"role"`
+
+	// Expires is the time when the membership gets revoked, memberships without it never expire.
+	Expires        *int64 `json:"expires,omitempty"`
+	ExpiryNotified *int64 `json:"-"`
 }
 
 // MembershipUser adds user info to the Membership data.
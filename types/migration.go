@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MigrationPhase defines the phase of a database migration in expand/contract style upgrades.
+type MigrationPhase string
+
+const (
+	// MigrationPhaseExpand migrations are backwards compatible and can be applied while old replicas are running.
+	MigrationPhaseExpand MigrationPhase = "expand"
+	// MigrationPhaseContract migrations break old replicas and can only be applied once all replicas are upgraded.
+	MigrationPhaseContract MigrationPhase = "contract"
+)
+
+// PendingMigration describes a database migration that hasn't been applied yet.
+type PendingMigration struct {
+	Version string         `json:"version"`
+	Phase   MigrationPhase `json:"phase"`
+	// Tables are the existing tables affected by the migration.
+	Tables []string `json:"tables"`
+	// EstimatedDuration is the estimated duration of the migration in milliseconds.
+	EstimatedDuration int64 `json:"estimated_duration"`
+}
+
+// MigrationStatus describes the state of the database schema compared to the migrations of this instance.
+type MigrationStatus struct {
+	Current string             `json:"current"`
+	Latest  string             `json:"latest"`
+	Pending []PendingMigration `json:"pending"`
+	// EstimatedDuration is the estimated duration of all pending migrations in milliseconds.
+	EstimatedDuration int64 `json:"estimated_duration"`
+	// ContractPending is true if any of the pending migrations requires all replicas to be upgraded first.
+	ContractPending bool `json:"contract_pending"`
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// NotificationSettings stores the email notification preferences of a user.
+type NotificationSettings struct {
+	PrincipalID     int64                     `db:"notification_settings_principal_id"     json:"-"`
+	Delivery        enum.NotificationDelivery `db:"notification_settings_delivery"         json:"delivery"`
+	ReviewRequested bool                      `db:"notification_settings_review_requested" json:"review_requested"`
+	Comments        bool                      `db:"notification_settings_comments"         json:"comments"`
+	Merged          bool                      `db:"notification_settings_merged"           json:"merged"`
+	PipelineFailed  bool                      `db:"notification_settings_pipeline_failed"  json:"pipeline_failed"`
+	Updated         int64                     `db:"notification_settings_updated"          json:"updated"`
+}
+
+// DefaultNotificationSettings returns the notification settings of users that didn't configure them.
+func DefaultNotificationSettings(principalID int64) *NotificationSettings {
+	return &NotificationSettings{
+		PrincipalID:     principalID,
+		Delivery:        enum.NotificationDeliveryImmediate,
+		ReviewRequested: true,
+		Comments:        true,
+		Merged:          true,
+		PipelineFailed:  true,
+	}
+}
+
+// NotificationDigestItem is a notification waiting to be sent to a user as part of a digest.
+type NotificationDigestItem struct {
+	ID          int64  `db:"notification_digest_item_id"`
+	PrincipalID int64  `db:"notification_digest_item_principal_id"`
+	Subject     string `db:"notification_digest_item_subject"`
+	Body        string `db:"notification_digest_item_body"`
+	Created     int64  `db:"notification_digest_item_created"`
+}
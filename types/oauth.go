@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// OAuthApp is a third party application that can request access to gitness on behalf of a user.
+type OAuthApp struct {
+	ID           int64    `json:"id"`
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	HomepageURL  string   `json:"homepage_url"`
+	RedirectURIs []string `json:"redirect_uris"`
+	CreatedBy    int64    `json:"created_by"`
+	Created      int64    `json:"created"`
+	Updated      int64    `json:"updated"`
+
+	// Scopes contains the scopes the app is allowed to request from users.
+	Scopes []enum.OAuthScope `json:"scopes"`
+
+	// ClientSecret contains the encrypted client secret of the app.
+	ClientSecret string `json:"-"`
+}
+
+// OAuthAppInfo contains the publicly visible information of an OAuth app.
+type OAuthAppInfo struct {
+	ClientID    string `json:"client_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	HomepageURL string `json:"homepage_url"`
+}
+
+// OAuthAuthorization is the consent of a user for an OAuth app to access gitness on their behalf.
+// Revoking the authorization invalidates all access tokens the app was issued for the user.
+type OAuthAuthorization struct {
+	ID          int64             `json:"id"`
+	AppID       int64             `json:"app_id"`
+	PrincipalID int64             `json:"-"`
+	Scopes      []enum.OAuthScope `json:"scopes"`
+	Created     int64             `json:"created"`
+	Updated     int64             `json:"updated"`
+
+	// App is only populated when listing the authorizations of a user or a space.
+	App *OAuthAppInfo `json:"app,omitempty"`
+
+	// Principal is only populated when listing the authorizations of a space.
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+}
+
+// OAuthIntegration is an OAuth app that was authorized by members of a space.
+type OAuthIntegration struct {
+	App OAuthAppInfo `json:"app"`
+
+	// Scopes contains all scopes the app was granted by any of the members.
+	Scopes []enum.OAuthScope `json:"scopes"`
+
+	// Authorizations contains the authorizations of the individual members.
+	Authorizations []OAuthIntegrationAccess `json:"authorizations"`
+}
+
+// OAuthIntegrationAccess is the access a single member of a space granted to an OAuth app.
+type OAuthIntegrationAccess struct {
+	Principal PrincipalInfo     `json:"principal"`
+	Scopes    []enum.OAuthScope `json:"scopes"`
+	Created   int64             `json:"created"`
+	Updated   int64             `json:"updated"`
+}
+
+// OAuthAuthorizationCode is a short-lived, single use code that can be exchanged for an access token.
+type OAuthAuthorizationCode struct {
+	// CodeHash is the hex encoded sha256 hash of the code handed out to the app.
+	CodeHash            string
+	AuthorizationID     int64
+	RedirectURI         string
+	Scopes              []enum.OAuthScope
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	Expires             int64
+	Created             int64
+}
@@ -33,11 +33,31 @@ type Principal struct {
 	Blocked bool   `db:"principal_blocked"            json:"blocked"`
 	Salt    string `db:"principal_salt"               json:"-"`
 
+	// SuspendedUntil is the time until which the principal is suspended, 0 if it isn't suspended.
+	SuspendedUntil        int64 `db:"principal_suspended_until"         json:"suspended_until"`
+	PasswordResetRequired bool  `db:"principal_password_reset_required" json:"password_reset_required"`
+
 	// Other info
 	Created int64 `db:"principal_created"                json:"created"`
 	Updated int64 `db:"principal_updated"                json:"updated"`
 }
 
+// State returns the state of the principal's account at the provided time.
+func (p *Principal) State(now int64) enum.PrincipalState {
+	return principalState(p.Blocked, p.SuspendedUntil, now)
+}
+
+func principalState(blocked bool, suspendedUntil int64, now int64) enum.PrincipalState {
+	switch {
+	case blocked:
+		return enum.PrincipalStateBlocked
+	case suspendedUntil > now:
+		return enum.PrincipalStateSuspended
+	default:
+		return enum.PrincipalStateActive
+	}
+}
+
 func (p *Principal) ToPrincipalInfo() *PrincipalInfo {
 	return &PrincipalInfo{
 		ID:          p.ID,
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPrincipalState(t *testing.T) {
+	const now = 1000
+
+	tests := []struct {
+		blocked        bool
+		suspendedUntil int64
+		want           enum.PrincipalState
+	}{
+		{false, 0, enum.PrincipalStateActive},
+		{false, now, enum.PrincipalStateActive},
+		{false, now + 1, enum.PrincipalStateSuspended},
+		{true, 0, enum.PrincipalStateBlocked},
+		{true, now + 1, enum.PrincipalStateBlocked},
+	}
+
+	for _, test := range tests {
+		p := &Principal{Blocked: test.blocked, SuspendedUntil: test.suspendedUntil}
+		if got := p.State(now); got != test.want {
+			t.Errorf("Want state of principal (blocked=%t, suspended_until=%d) to be %q, got %q",
+				test.blocked, test.suspendedUntil, test.want, got)
+		}
+	}
+}
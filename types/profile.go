@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Contribution is a single activity of a principal in a repository.
+type Contribution struct {
+	RepoID  int64                 `db:"contribution_repo_id"`
+	Type    enum.ContributionType `db:"contribution_type"`
+	Created int64                 `db:"contribution_created"`
+}
+
+// ContributionDay holds the number of contributions of a user on a day (in UTC).
+type ContributionDay struct {
+	// Date is the day in the format YYYY-MM-DD.
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// Profile holds the public activity of a user, restricted to the repositories the viewer has access to.
+type Profile struct {
+	User *PrincipalInfo `json:"user"`
+
+	// Contributions are the days with contributions of the user in the past year, oldest first.
+	Contributions []ContributionDay `json:"contributions"`
+	// Totals is the number of contributions in the past year per contribution type.
+	Totals map[enum.ContributionType]int `json:"totals"`
+}
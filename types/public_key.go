@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PublicKey is a public key of a user used to verify commit signatures.
+type PublicKey struct {
+	ID          int64                `db:"public_key_id"           json:"id"`
+	PrincipalID int64                `db:"public_key_principal_id" json:"-"`
+	Identifier  string               `db:"public_key_identifier"   json:"identifier"`
+	Scheme      enum.PublicKeyScheme `db:"public_key_scheme"       json:"scheme"`
+	// Fingerprint is the fingerprint of the (primary) key, it's unique across all users.
+	Fingerprint string `db:"public_key_fingerprint" json:"fingerprint"`
+	Content     string `db:"public_key_content"     json:"content"`
+	Created     int64  `db:"public_key_created"     json:"created"`
+}
+
+// CommitVerification is the result of the signature verification of a commit.
+type CommitVerification struct {
+	RepoID int64                         `db:"commit_verification_repo_id" json:"-"`
+	SHA    string                        `db:"commit_verification_sha"     json:"-"`
+	Status enum.CommitVerificationStatus `db:"commit_verification_status"  json:"status"`
+	Scheme enum.PublicKeyScheme          `db:"commit_verification_scheme"  json:"scheme"`
+	// KeyID is the id of the key that created the signature (long key id for PGP, SHA256 fingerprint for SSH).
+	KeyID    string         `db:"commit_verification_key_id"    json:"key_id"`
+	SignerID *int64         `db:"commit_verification_signer_id" json:"-"`
+	Signer   *PrincipalInfo `db:"-"                             json:"signer,omitempty"`
+	Created  int64          `db:"commit_verification_created"   json:"-"`
+}
@@ -35,6 +35,9 @@ type PullReq struct {
 	CommentCount    int `json:"-"` // returned as "conversations" in the Stats
 	UnresolvedCount int `json:"-"` // returned as "unresolved_count" in the Stats
 
+	TaskCount          int `json:"-"` // returned as "tasks" in the Stats
+	TaskCompletedCount int `json:"-"` // returned as "tasks_completed" in the Stats
+
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
@@ -56,8 +59,14 @@ type PullReq struct {
 	MergeSHA         *string               `json:"merge_sha"`
 	MergeConflicts   *string               `json:"merge_conflicts,omitempty"`
 
+	// Locked is set while the conversation is locked, only users with push access can comment then.
+	LockedBy   *int64                  `json:"-"` // not returned, because the locker info is in the Locker field
+	Locked     *int64                  `json:"locked,omitempty"`
+	LockReason *enum.PullReqLockReason `json:"lock_reason,omitempty"`
+
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
+	Locker *PrincipalInfo `json:"locker,omitempty"`
 	Stats  PullReqStats   `json:"stats"`
 }
 
@@ -72,22 +81,28 @@ type PullReqStats struct {
 	DiffStats
 	Conversations   int `json:"conversations,omitempty"`
 	UnresolvedCount int `json:"unresolved_count,omitempty"`
+	Tasks           int `json:"tasks,omitempty"`
+	TasksCompleted  int `json:"tasks_completed,omitempty"`
 }
 
 // PullReqFilter stores pull request query parameters.
 type PullReqFilter struct {
-	Page          int                 `json:"page"`
-	Size          int                 `json:"size"`
-	Query         string              `json:"query"`
-	CreatedBy     int64               `json:"created_by"`
-	SourceRepoID  int64               `json:"-"` // caller should use source_repo_ref
-	SourceRepoRef string              `json:"source_repo_ref"`
-	SourceBranch  string              `json:"source_branch"`
-	TargetRepoID  int64               `json:"-"`
-	TargetBranch  string              `json:"target_branch"`
-	States        []enum.PullReqState `json:"state"`
-	Sort          enum.PullReqSort    `json:"sort"`
-	Order         enum.Order          `json:"order"`
+	Page           int                 `json:"page"`
+	Size           int                 `json:"size"`
+	Query          string              `json:"query"`
+	SearchComments bool                `json:"search_comments"` // query also matches comments of pull requests
+	CreatedBy      int64               `json:"created_by"`
+	SourceRepoID   int64               `json:"-"` // caller should use source_repo_ref
+	SourceRepoRef  string              `json:"source_repo_ref"`
+	SourceBranch   string              `json:"source_branch"`
+	SourceBranches []string            `json:"-"`
+	TargetRepoID   int64               `json:"-"`
+	TargetRepoIDs  []int64             `json:"-"` // restricts the pull requests to the provided target repos
+	TargetBranch   string              `json:"target_branch"`
+	ReviewedBy     int64               `json:"-"` // pull requests with a review submitted by the principal
+	States         []enum.PullReqState `json:"state"`
+	Sort           enum.PullReqSort    `json:"sort"`
+	Order          enum.Order          `json:"order"`
 }
 
 // PullReqReview holds pull request review.
@@ -120,10 +135,40 @@ type PullReqReviewer struct {
 	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
 	SHA            string                     `json:"sha"`
 
+	// Reminded is the time the reviewer was last reminded about the pending review, 0 if never.
+	Reminded int64 `json:"reminded"`
+
 	Reviewer PrincipalInfo `json:"reviewer"`
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// PullReqPush holds the state of the source branch of a pull request after a push,
+// the pushes of a pull request are numbered sequentially starting with 1 at creation.
+type PullReqPush struct {
+	PullReqID int64 `json:"-" db:"pullreq_push_pullreq_id"`
+	Number    int64 `json:"number" db:"pullreq_push_number"`
+
+	SourceSHA    string `json:"source_sha" db:"pullreq_push_source_sha"`
+	MergeBaseSHA string `json:"merge_base_sha" db:"pullreq_push_merge_base_sha"`
+	Forced       bool   `json:"forced" db:"pullreq_push_forced"`
+
+	CreatedBy int64 `json:"created_by" db:"pullreq_push_created_by"`
+	Created   int64 `json:"created" db:"pullreq_push_created"`
+}
+
+// PullReqSubscriber holds the subscription state of a principal for a pull request.
+// Unsubscribed entries are kept to record that the principal opted out of notifications.
+type PullReqSubscriber struct {
+	PullReqID   int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+
+	Subscribed bool  `json:"subscribed"`
+	Created    int64 `json:"created"`
+	Updated    int64 `json:"updated"`
+
+	Principal PrincipalInfo `json:"principal"`
+}
+
 // PullReqFileView represents a file reviewed entry for a given pr and principal.
 // NOTE: keep api lightweight and don't return unnecessary extra data.
 type PullReqFileView struct {
@@ -138,7 +183,19 @@ type PullReqFileView struct {
 	Updated int64 `json:"-"`
 }
 
+// MergeCheckViolation is an external merge check that didn't pass.
+type MergeCheckViolation struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Message  string `json:"message"`
+}
+
 type MergeResponse struct {
 	SHA           string   `json:"sha,omitempty"`
 	ConflictFiles []string `json:"conflict_files,omitempty"`
+	// MergeCheckViolations are the external merge checks that didn't pass.
+	// The merge is only blocked if at least one of them is required.
+	MergeCheckViolations []MergeCheckViolation `json:"merge_check_violations,omitempty"`
+	// BranchDeleted indicates that the source branch got deleted after the merge.
+	BranchDeleted bool `json:"branch_deleted,omitempty"`
 }
@@ -190,6 +190,11 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetChange{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTaskToggle{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadLock{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadUnlock{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -251,6 +256,30 @@ func (a *PullRequestActivityPayloadTitleChange) ActivityType() enum.PullReqActiv
 	return enum.PullReqActivityTypeTitleChange
 }
 
+type PullRequestActivityPayloadTaskToggle struct {
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+func (a *PullRequestActivityPayloadTaskToggle) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTaskToggle
+}
+
+type PullRequestActivityPayloadLock struct {
+	Reason *enum.PullReqLockReason `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadLock) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeLock
+}
+
+type PullRequestActivityPayloadUnlock struct{}
+
+func (a *PullRequestActivityPayloadUnlock) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeUnlock
+}
+
 type PullRequestActivityPayloadReviewSubmit struct {
 	CommitSHA string                     `json:"commit_sha"`
 	Message   string                     `json:"message,omitempty"`
@@ -277,3 +306,20 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+type PullRequestActivityPayloadBranchRestore struct {
+	SHA string `json:"sha"`
+}
+
+func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeBranchRestore
+}
+
+type PullRequestActivityPayloadTargetChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (a *PullRequestActivityPayloadTargetChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetChange
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RefWatch subscribes a principal to pushes to the branches or tags of a repository matching the pattern.
+type RefWatch struct {
+	ID          int64             `db:"ref_watch_id"           json:"id"`
+	RepoID      int64             `db:"ref_watch_repo_id"      json:"repo_id"`
+	PrincipalID int64             `db:"ref_watch_principal_id" json:"-"`
+	Type        enum.RefWatchType `db:"ref_watch_type"         json:"type"`
+	// Pattern is matched against the branch or tag name, e.g. "release/*".
+	Pattern string `db:"ref_watch_pattern" json:"pattern"`
+	Created int64  `db:"ref_watch_created" json:"created"`
+}
+
+// RefWatchTriggered is sent to the watchers of a ref in case it got pushed to.
+type RefWatchTriggered struct {
+	RepoID       int64             `json:"repo_id"`
+	RepoPath     string            `json:"repo_path"`
+	Type         enum.RefWatchType `json:"type"`
+	Name         string            `json:"name"`
+	SHA          string            `json:"sha"`
+	ActorID      int64             `json:"actor_id"`
+	PrincipalIDs []int64           `json:"principal_ids"`
+}
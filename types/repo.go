@@ -45,6 +45,13 @@ type Repository struct {
 
 	Importing bool `json:"importing"`
 
+	// Size is the storage used by the repository in KiB, as of SizeUpdated.
+	Size        int64 `json:"size"`
+	SizeUpdated int64 `json:"size_updated"`
+
+	// Deleted is the time the repository was deleted at, if it's in the trash.
+	Deleted *int64 `json:"deleted,omitempty"`
+
 	// git urls
 	GitURL string `json:"git_url"`
 }
@@ -68,3 +75,13 @@ type RepositoryGitInfo struct {
 	ParentID int64
 	GitUID   string
 }
+
+// BranchRename records that a branch of a repository got renamed.
+// It's used to guide users that still push to the old branch.
+type BranchRename struct {
+	RepoID    int64  `json:"-"`
+	OldName   string `json:"old_name"`
+	NewName   string `json:"new_name"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+}
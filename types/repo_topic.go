@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoTopic is a free-form topic of a repository.
+type RepoTopic struct {
+	RepoID  int64  `db:"repo_topic_repo_id" json:"-"`
+	Topic   string `db:"repo_topic_topic"   json:"topic"`
+	Created int64  `db:"repo_topic_created" json:"-"`
+}
+
+// TopicSummary is a topic together with the number of repositories using it.
+type TopicSummary struct {
+	Topic     string `json:"topic"`
+	RepoCount int    `json:"repo_count"`
+}
+
+// TopicFilter stores topic query parameters.
+type TopicFilter struct {
+	Page  int    `json:"page"`
+	Size  int    `json:"size"`
+	Query string `json:"query"`
+}
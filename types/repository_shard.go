@@ -0,0 +1,22 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepositoryShard is an entry of the routing table storing on which shard (gitrpc server) a repository is stored.
+type RepositoryShard struct {
+	GitUID  string `db:"repository_shard_git_uid" json:"git_uid"`
+	Shard   string `db:"repository_shard_name"    json:"shard"`
+	Created int64  `db:"repository_shard_created" json:"created"`
+}
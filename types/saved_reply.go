@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SavedReply is a reusable comment snippet of a user, e.g. for common review comments.
+type SavedReply struct {
+	ID          int64  `db:"saved_reply_id"           json:"id"`
+	PrincipalID int64  `db:"saved_reply_principal_id" json:"-"`
+	Identifier  string `db:"saved_reply_identifier"   json:"identifier"`
+	Content     string `db:"saved_reply_content"      json:"content"`
+	Created     int64  `db:"saved_reply_created"      json:"created"`
+	Updated     int64  `db:"saved_reply_updated"      json:"updated"`
+}
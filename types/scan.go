@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// ScanFinding is a vulnerability reported by a security scan for a commit of a repository.
+type ScanFinding struct {
+	ID              int64             `json:"id"`
+	RepoID          int64             `json:"-"`
+	CommitSHA       string            `json:"commit_sha"`
+	Scanner         string            `json:"scanner"`
+	Kind            enum.ScanKind     `json:"kind"`
+	VulnerabilityID string            `json:"vulnerability_id"`
+	Package         string            `json:"package"`
+	Version         string            `json:"version"`
+	FixedVersion    string            `json:"fixed_version"`
+	Severity        enum.ScanSeverity `json:"severity"`
+	Title           string            `json:"title"`
+	Path            string            `json:"path"`
+	URL             string            `json:"url"`
+	Created         int64             `json:"created"`
+
+	// Suppressed indicates that the finding matches an active suppression of the repository.
+	Suppressed bool `json:"suppressed"`
+}
+
+// ScanFindingFilter stores scan finding query parameters.
+type ScanFindingFilter struct {
+	Page              int               `json:"page"`
+	Size              int               `json:"size"`
+	CommitSHA         string            `json:"commit_sha"`
+	Scanner           string            `json:"scanner"`
+	MinSeverity       enum.ScanSeverity `json:"min_severity"`
+	IncludeSuppressed bool              `json:"include_suppressed"`
+}
+
+// ScanSuppression suppresses findings of a vulnerability in a repository,
+// e.g. because the vulnerable code isn't reachable or the risk got accepted.
+type ScanSuppression struct {
+	ID              int64  `json:"id"`
+	RepoID          int64  `json:"-"`
+	VulnerabilityID string `json:"vulnerability_id"`
+	// Package restricts the suppression to a single package, empty suppresses the vulnerability in all packages.
+	Package   string `json:"package"`
+	Reason    string `json:"reason"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	// Expires is the time after which the suppression no longer applies, 0 if it never expires.
+	Expires int64 `json:"expires"`
+}
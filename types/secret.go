@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type Secret struct {
 	ID          int64  `db:"secret_id"              json:"id"`
 	Description string `db:"secret_description"     json:"description"`
@@ -21,9 +23,14 @@ type Secret struct {
 	CreatedBy   int64  `db:"secret_created_by"      json:"created_by"`
 	UID         string `db:"secret_uid"             json:"uid"`
 	Data        string `db:"secret_data"            json:"-"`
+	Inheritable bool   `db:"secret_inheritable"     json:"inheritable"`
 	Created     int64  `db:"secret_created"         json:"created"`
 	Updated     int64  `db:"secret_updated"         json:"updated"`
 	Version     int64  `db:"secret_version"         json:"-"`
+
+	// Provider defines where the value is stored. For external providers
+	// the data contains the reference of the secret in the external secret manager.
+	Provider enum.SecretProvider `db:"secret_provider" json:"provider"`
 }
 
 // Copy makes a copy of the secret without the value.
@@ -33,6 +40,8 @@ func (s *Secret) CopyWithoutData() *Secret {
 		Description: s.Description,
 		UID:         s.UID,
 		SpaceID:     s.SpaceID,
+		Inheritable: s.Inheritable,
+		Provider:    s.Provider,
 		Created:     s.Created,
 		Updated:     s.Updated,
 		Version:     s.Version,
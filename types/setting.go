@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Setting represents the value of a setting defined on a space or a repository.
+type Setting struct {
+	ID        int64           `json:"-"`
+	SpaceID   *int64          `json:"space_id,omitempty"`
+	RepoID    *int64          `json:"repo_id,omitempty"`
+	Key       enum.SettingKey `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	Locked    bool            `json:"locked"`
+	CreatedBy int64           `json:"created_by"`
+	Created   int64           `json:"created"`
+	Updated   int64           `json:"updated"`
+}
+
+// EffectiveSetting is the value of a setting that applies to a space or a repository.
+type EffectiveSetting struct {
+	Key   enum.SettingKey `json:"key"`
+	Value json.RawMessage `json:"value"`
+	// Locked indicates that the value is locked by a space and can't be overridden.
+	Locked bool `json:"locked"`
+	// Inherited indicates that the value is defined by an ancestor space.
+	Inherited bool `json:"inherited"`
+	// SpaceID is the space defining the value, it's 0 for values defined by the repository or the system.
+	SpaceID int64 `json:"space_id,omitempty"`
+	// ETag identifies the effective value, it's used with If-Match to prevent lost updates.
+	ETag string `json:"etag"`
+}
+
+// MergeCheckProvider is an external service that is consulted via http callback before a pull request is merged.
+type MergeCheckProvider struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// TimeoutSeconds is the max duration of the callback, 0 uses the system default.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// Required providers block the merge if they fail or time out, failures of optional providers are only reported.
+	Required bool `json:"required"`
+}
+
+// PullReqRules are the rules a pull request has to satisfy before it can be merged.
+type PullReqRules struct {
+	MinApprovals            int  `json:"min_approvals"`
+	RequireResolvedComments bool `json:"require_resolved_comments"`
+	// RequireCodeOwners requires the approval of a code owner for all changed files owned by the CODEOWNERS file.
+	RequireCodeOwners bool `json:"require_code_owners"`
+}
+
+// PathProtection restricts who is allowed to change files matching any of the path patterns on matching branches.
+type PathProtection struct {
+	// Branches are patterns of the branches the protection applies to, e.g. "release/*".
+	// The protection applies to all branches if empty.
+	Branches []string `json:"branches"`
+	// Paths are patterns of the protected files, where "**" matches any number of directories, e.g. "infra/**".
+	Paths []string `json:"paths"`
+	// AllowedPrincipalIDs are the principals allowed to change the protected files.
+	AllowedPrincipalIDs []int64 `json:"allowed_principal_ids"`
+	// AllowedUserGroupIDs are the user groups whose members are allowed to change the protected files.
+	AllowedUserGroupIDs []int64 `json:"allowed_user_group_ids"`
+}
+
+// StaleBranchPolicy defines when branches of a repository are considered stale and whether they get cleaned up.
+type StaleBranchPolicy struct {
+	// StaleAfterDays is the number of days without commits after which a branch is considered stale.
+	StaleAfterDays int `json:"stale_after_days"`
+	// ExcludedBranches are patterns of branches that are never flagged, e.g. "release/*".
+	// The default branch is always excluded.
+	ExcludedBranches []string `json:"excluded_branches"`
+	// Cleanup enables the automatic deletion of stale branches.
+	Cleanup bool `json:"cleanup"`
+	// CleanupMerged enables the automatic deletion of branches that are merged into the default branch.
+	CleanupMerged bool `json:"cleanup_merged"`
+}
+
+// RetentionPolicy overrides the system wide retention of data for the repositories of a space.
+// A value of 0 keeps the system default.
+type RetentionPolicy struct {
+	// WebhookExecutionsDays is the number of days after which webhook executions are purged.
+	WebhookExecutionsDays int `json:"webhook_executions_days"`
+	// WebhookExecutionsMax is the maximum number of executions kept per webhook.
+	WebhookExecutionsMax int `json:"webhook_executions_max"`
+	// PipelineLogsDays is the number of days after which the logs of pipeline steps are purged.
+	PipelineLogsDays int `json:"pipeline_logs_days"`
+}
+
+// PullReqSLA defines when open pull requests are considered aging, a value of 0 disables the threshold.
+type PullReqSLA struct {
+	// MaxAgeDays is the number of days after creation after which a pull request exceeds the SLA.
+	MaxAgeDays int `json:"max_age_days"`
+	// MaxIdleDays is the number of days without any activity after which a pull request exceeds the SLA.
+	MaxIdleDays int `json:"max_idle_days"`
+}
+
+// ReviewReminderPolicy defines when requested reviewers get reminded about their pending reviews.
+type ReviewReminderPolicy struct {
+	// SLAHours is the number of hours after which a pending review is overdue, 0 disables reminders.
+	// Reviewers are reminded again every SLAHours until they submit a review.
+	SLAHours int `json:"sla_hours"`
+}
+
+// WebhookTemplate is used to create a webhook for newly created repositories.
+type WebhookTemplate struct {
+	DisplayName string                `json:"display_name"`
+	Description string                `json:"description"`
+	URL         string                `json:"url"`
+	Enabled     bool                  `json:"enabled"`
+	Insecure    bool                  `json:"insecure"`
+	ProxyURL    string                `json:"proxy_url,omitempty"`
+	Triggers    []enum.WebhookTrigger `json:"triggers"`
+}
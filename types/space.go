@@ -41,6 +41,30 @@ type Space struct {
 	CreatedBy   int64  `json:"created_by"`
 	Created     int64  `json:"created"`
 	Updated     int64  `json:"updated"`
+
+	// Deleted is the time the space was deleted at, if it's in the trash.
+	Deleted *int64 `json:"deleted,omitempty"`
+}
+
+// SpaceTreeNode represents a space within the tree of spaces beneath a space, including usage statistics.
+type SpaceTreeNode struct {
+	ID          int64  `json:"id"`
+	ParentID    int64  `json:"parent_id"`
+	Path        string `json:"path"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// statistics of the space itself.
+	NumRepos   int64 `json:"num_repos"`
+	NumMembers int64 `json:"num_members"`
+	RepoSize   int64 `json:"repo_size"`
+
+	// statistics of the space and all its descendants.
+	TotalNumRepos int64 `json:"total_num_repos"`
+	TotalRepoSize int64 `json:"total_repo_size"`
+
+	Children []*SpaceTreeNode `json:"children"`
 }
 
 // Stores spaces query parameters.
@@ -51,3 +75,22 @@ type SpaceFilter struct {
 	Sort  enum.SpaceAttr `json:"sort"`
 	Order enum.Order     `json:"order"`
 }
+
+// SpaceMoveImpact describes the spaces and repositories whose paths change when moving a space.
+type SpaceMoveImpact struct {
+	Spaces []PathChange `json:"spaces"`
+	Repos  []PathChange `json:"repos"`
+}
+
+// PathChange describes the change of the path of a resource.
+type PathChange struct {
+	ID      int64  `json:"id"`
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+}
+
+// Trash contains the deleted spaces and repositories of a space that can be restored.
+type Trash struct {
+	Spaces []*Space      `json:"spaces"`
+	Repos  []*Repository `json:"repos"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SpaceQuota defines the limits of a space, which apply to the space and all its descendants.
+// A nil limit means the space isn't limited in that regard.
+type SpaceQuota struct {
+	SpaceID int64 `db:"space_quota_space_id" json:"space_id"`
+	// MaxRepos is the maximum number of repositories.
+	MaxRepos *int64 `db:"space_quota_max_repos" json:"max_repos"`
+	// MaxSize is the maximum git storage size of all repositories in KiB.
+	MaxSize *int64 `db:"space_quota_max_size" json:"max_size"`
+
+	CreatedBy int64 `db:"space_quota_created_by" json:"created_by"`
+	Created   int64 `db:"space_quota_created"    json:"created"`
+	Updated   int64 `db:"space_quota_updated"    json:"updated"`
+}
+
+// SpaceUsage contains the resources used by a space and all its descendants.
+type SpaceUsage struct {
+	NumRepos int64 `json:"num_repos"`
+	// Size is the git storage size of all repositories in KiB.
+	Size int64 `json:"size"`
+}
+
+// SpaceQuotaUsage is a quota together with the current usage of the space it is defined on.
+type SpaceQuotaUsage struct {
+	SpaceQuota
+	Path  string     `json:"path"`
+	Usage SpaceUsage `json:"usage"`
+}
+
+// SpaceQuotaReport contains the usage of a space and all quotas that apply to it,
+// meaning the quota of the space itself and the quotas of its ancestors.
+type SpaceQuotaReport struct {
+	Usage  SpaceUsage         `json:"usage"`
+	Quotas []*SpaceQuotaUsage `json:"quotas"`
+}
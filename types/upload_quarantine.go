@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// UploadQuarantine represents uploaded content that was flagged by the virus scanner.
+// The content is identified by its SHA-256 checksum, so releasing it allows the same content to be uploaded again.
+type UploadQuarantine struct {
+	ID         int64                       `db:"upload_quarantine_id"          json:"id"`
+	SHA256     string                      `db:"upload_quarantine_sha256"      json:"sha256"`
+	Source     enum.UploadSource           `db:"upload_quarantine_source"      json:"source"`
+	RepoID     int64                       `db:"upload_quarantine_repo_id"     json:"repo_id"`
+	Name       string                      `db:"upload_quarantine_name"        json:"name"`
+	Size       int64                       `db:"upload_quarantine_size"        json:"size"`
+	Scanner    string                      `db:"upload_quarantine_scanner"     json:"scanner"`
+	Signature  string                      `db:"upload_quarantine_signature"   json:"signature"`
+	Status     enum.UploadQuarantineStatus `db:"upload_quarantine_status"      json:"status"`
+	UploadedBy int64                       `db:"upload_quarantine_uploaded_by" json:"uploaded_by"`
+	ReleasedBy *int64                      `db:"upload_quarantine_released_by" json:"released_by,omitempty"`
+	Created    int64                       `db:"upload_quarantine_created"     json:"created"`
+	Updated    int64                       `db:"upload_quarantine_updated"     json:"updated"`
+}
+
+// UploadQuarantineFilter stores upload quarantine query parameters.
+type UploadQuarantineFilter struct {
+	Page   int                         `json:"page"`
+	Size   int                         `json:"size"`
+	Status enum.UploadQuarantineStatus `json:"status"`
+}
@@ -33,6 +33,9 @@ type (
 		Created     int64  `db:"principal_created"        json:"created"`
 		Updated     int64  `db:"principal_updated"        json:"updated"`
 
+		SuspendedUntil        int64 `db:"principal_suspended_until"         json:"suspended_until"`
+		PasswordResetRequired bool  `db:"principal_password_reset_required" json:"password_reset_required"`
+
 		// User specific fields
 		Password string `db:"principal_user_password"    json:"-"`
 	}
@@ -68,9 +71,17 @@ func (u *User) ToPrincipal() *Principal {
 		Salt:        u.Salt,
 		Created:     u.Created,
 		Updated:     u.Updated,
+
+		SuspendedUntil:        u.SuspendedUntil,
+		PasswordResetRequired: u.PasswordResetRequired,
 	}
 }
 
+// State returns the state of the user's account at the provided time.
+func (u *User) State(now int64) enum.PrincipalState {
+	return principalState(u.Blocked, u.SuspendedUntil, now)
+}
+
 func (u *User) ToPrincipalInfo() *PrincipalInfo {
 	return u.ToPrincipal().ToPrincipalInfo()
 }
@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// UserEmail is an additional email address of a user.
+// The primary email address of a user is stored with the user itself.
+type UserEmail struct {
+	ID          int64  `db:"user_email_id"           json:"id"`
+	PrincipalID int64  `db:"user_email_principal_id" json:"-"`
+	Email       string `db:"user_email_address"      json:"email"`
+	// Verified is the time the email address got verified, nil if it isn't verified yet.
+	Verified *int64 `db:"user_email_verified" json:"verified"`
+	// VerificationToken is the hash of the token required to verify the email address.
+	VerificationToken   string `db:"user_email_verification_token"   json:"-"`
+	VerificationExpires int64  `db:"user_email_verification_expires" json:"-"`
+	Created             int64  `db:"user_email_created"              json:"created"`
+	Updated             int64  `db:"user_email_updated"              json:"updated"`
+}
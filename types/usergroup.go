@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+type (
+	// UserGroup is a principal representing a group of users (team) defined within a space.
+	// User groups can contain users as well as other user groups.
+	UserGroup struct {
+		// Fields from Principal (without admin, as it's never an admin)
+		ID          int64  `db:"principal_id"           json:"id"`
+		UID         string `db:"principal_uid"          json:"uid"`
+		Email       string `db:"principal_email"        json:"email"`
+		DisplayName string `db:"principal_display_name" json:"display_name"`
+		Admin       bool   `db:"principal_admin"        json:"-"`
+		Blocked     bool   `db:"principal_blocked"      json:"-"`
+		Salt        string `db:"principal_salt"         json:"-"`
+		Created     int64  `db:"principal_created"      json:"created"`
+		Updated     int64  `db:"principal_updated"      json:"updated"`
+
+		// UserGroup specific fields
+		SpaceID int64 `db:"principal_usergroup_space_id" json:"space_id"`
+	}
+
+	// UserGroupMember represents a membership of a principal (user or user group) in a user group.
+	UserGroupMember struct {
+		UserGroupID int64 `json:"-"`
+		PrincipalID int64 `json:"-"`
+		CreatedBy   int64 `json:"-"`
+		Created     int64 `json:"created"`
+
+		Principal PrincipalInfo `json:"principal"`
+		AddedBy   PrincipalInfo `json:"added_by"`
+	}
+)
+
+func (g *UserGroup) ToPrincipal() *Principal {
+	return &Principal{
+		ID:          g.ID,
+		UID:         g.UID,
+		Email:       g.Email,
+		Type:        enum.PrincipalTypeUserGroup,
+		DisplayName: g.DisplayName,
+		Admin:       g.Admin,
+		Blocked:     g.Blocked,
+		Salt:        g.Salt,
+		Created:     g.Created,
+		Updated:     g.Updated,
+	}
+}
+
+func (g *UserGroup) ToPrincipalInfo() *PrincipalInfo {
+	return g.ToPrincipal().ToPrincipalInfo()
+}
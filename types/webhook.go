@@ -37,6 +37,7 @@ type Webhook struct {
 	Secret                string                       `json:"-"`
 	Enabled               bool                         `json:"enabled"`
 	Insecure              bool                         `json:"insecure"`
+	ProxyURL              string                       `json:"proxy_url,omitempty"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
 }